@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// junitSuite is a JUnit XML test suite as read by CI systems
+type junitSuite struct {
+	XMLName  xml.Name    `xml:"testsuite"`
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Time     float64     `xml:"time,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+// junitCase is a single test case in a JUnit suite
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      float64       `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+// junitFailure describes why a test case failed
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// addCase adds a test case to the suite, failed when failures is not empty
+func (s *junitSuite) addCase(name string, elapsed time.Duration, failures []string, output string) {
+	tc := junitCase{
+		Name:      name,
+		ClassName: s.Name,
+		Time:      elapsed.Seconds(),
+		SystemOut: output,
+	}
+	if len(failures) > 0 {
+		tc.Failure = &junitFailure{Message: failures[0], Text: strings.Join(failures, "\n")}
+		s.Failures++
+	}
+	s.Cases = append(s.Cases, tc)
+	s.Tests++
+	s.Time += elapsed.Seconds()
+}
+
+// write saves the suite as JUnit XML to path
+func (s *junitSuite) write(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create results directory: %w", err)
+	}
+
+	out, err := xml.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode JUnit results: %w", err)
+	}
+	if err := os.WriteFile(path, append([]byte(xml.Header), append(out, '\n')...), 0644); err != nil {
+		return fmt.Errorf("failed to write JUnit results: %w", err)
+	}
+	return nil
+}
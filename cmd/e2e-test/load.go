@@ -0,0 +1,52 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/hustler/trading-bot/pkg/loadtest"
+)
+
+// loadReaders is the number of concurrent readers polling state during a load run,
+// standing in for API and dashboard clients
+const loadReaders = 4
+
+// runLoad runs the load test, prints a summary and writes the results as JSON.
+// It reports whether the run stayed within the default budget.
+func runLoad(symbols, cycles int, path string) bool {
+	result, err := loadtest.Run(loadtest.Config{Symbols: symbols, Cycles: cycles, Readers: loadReaders})
+	if err != nil {
+		log.Printf("Error running load test: %v", err)
+		return false
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		log.Printf("Error creating results directory: %v", err)
+		return false
+	}
+	encoded, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		log.Printf("Error encoding load results: %v", err)
+		return false
+	}
+	if err := os.WriteFile(path, encoded, 0644); err != nil {
+		log.Printf("Error writing load results: %v", err)
+		return false
+	}
+
+	fmt.Printf("Load test: %d symbols, %d cycles\n", result.Symbols, result.Cycles)
+	fmt.Printf("  watcher cycle  p50 %s  p95 %s  max %s\n", result.WatcherCycle.P50, result.WatcherCycle.P95, result.WatcherCycle.Max)
+	fmt.Printf("  market check   p50 %s  p95 %s  max %s\n", result.MarketCheck.P50, result.MarketCheck.P95, result.MarketCheck.Max)
+	fmt.Printf("  reader wait    p50 %s  p95 %s  (%d calls)\n", result.ReaderWait.P50, result.ReaderWait.P95, result.ReaderCalls)
+	fmt.Printf("  heap %d KB, %d KB allocated per cycle\n", result.HeapBytes>>10, result.AllocPerCycle>>10)
+
+	violations := result.Check(loadtest.DefaultBudget())
+	for _, violation := range violations {
+		fmt.Printf("    %s\n", violation)
+	}
+	fmt.Printf("Results written to %s\n", path)
+	return len(violations) == 0
+}
@@ -2,9 +2,11 @@ package main
 
 import (
 	"database/sql"
+	"flag"
 	"fmt"
 	"log"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/hustler/trading-bot/pkg/api"
@@ -19,6 +21,27 @@ import (
 )
 
 func main() {
+	scenarioNames := flag.String("scenarios", "", "Run scripted scenarios offline instead of the live test: comma-separated names or \"all\"")
+	junitPath := flag.String("junit", "./test_results/junit.xml", "Where scenario results are written as JUnit XML")
+	loadSymbols := flag.Int("load", 0, "Run an offline load test over this many synthetic symbols instead of the live test")
+	loadCycles := flag.Int("load-cycles", 20, "Update cycles measured by the load test")
+	loadPath := flag.String("load-out", "./test_results/load.json", "Where load test results are written as JSON")
+	flag.Parse()
+
+	if *loadSymbols > 0 {
+		if !runLoad(*loadSymbols, *loadCycles, *loadPath) {
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *scenarioNames != "" {
+		if !runScenarios(*scenarioNames, *junitPath) {
+			os.Exit(1)
+		}
+		return
+	}
+
 	log.Println("Starting Hustler Trading Bot...")
 
 	// Connect to database
@@ -149,3 +172,46 @@ func writeResultsToFile(signals []*signal.Signal, messages []string) {
 
 	log.Println("Test results written to ./test_results/")
 }
+
+// runScenarios runs the named scenarios, prints a summary and writes JUnit
+// results. It reports whether every scenario passed.
+func runScenarios(names, junitPath string) bool {
+	selected, err := selectScenarios(names)
+	if err != nil {
+		log.Printf("Error selecting scenarios: %v", err)
+		return false
+	}
+
+	suite := &junitSuite{Name: "e2e-scenarios"}
+	for _, sc := range selected {
+		started := time.Now()
+		result, err := runScenario(sc)
+
+		var failures []string
+		var output string
+		if err != nil {
+			failures = []string{err.Error()}
+		} else {
+			failures = sc.check(result)
+			output = fmt.Sprintf("signals: %d, outcomes: %d, messages: %d, risk actions: %s",
+				len(result.signals), len(result.outcomes), len(result.messages), strings.Join(result.riskActions, "; "))
+		}
+		suite.addCase(sc.name, time.Since(started), failures, output)
+
+		status := "PASS"
+		if len(failures) > 0 {
+			status = "FAIL"
+		}
+		fmt.Printf("%s %s\n", status, sc.name)
+		for _, failure := range failures {
+			fmt.Printf("    %s\n", failure)
+		}
+	}
+
+	if err := suite.write(junitPath); err != nil {
+		log.Printf("Error writing scenario results: %v", err)
+		return false
+	}
+	fmt.Printf("\n%d/%d scenarios passed, results written to %s\n", suite.Tests-suite.Failures, suite.Tests, junitPath)
+	return suite.Failures == 0
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/backtest"
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/execution"
+	"github.com/hustler/trading-bot/pkg/mock"
+	"github.com/hustler/trading-bot/pkg/monitor"
+	"github.com/hustler/trading-bot/pkg/signal"
+	"github.com/hustler/trading-bot/pkg/strategy"
+)
+
+// scenarioStart is the simulated session open all scenarios replay from
+var scenarioStart = time.Date(2025, 4, 21, 13, 30, 0, 0, time.UTC)
+
+// scenario is a scripted market fed through the full pipeline
+type scenario struct {
+	name     string
+	fixtures map[string]string // Symbol to synthetic data fixture
+	holding  string            // Symbol held long from the open, to exercise risk actions
+	check    func(r *scenarioResult) []string
+}
+
+// scenarioResult is what the pipeline produced for a scenario
+type scenarioResult struct {
+	signals     []*signal.Signal
+	outcomes    []backtest.Outcome
+	messages    []string // As formatted by the mock Telegram bot
+	riskActions []string
+}
+
+// scenarios are the scripted scenarios, in run order
+var scenarios = []scenario{
+	{
+		name:     "breakout",
+		fixtures: map[string]string{"BRKO": "breakout"},
+		check: func(r *scenarioResult) []string {
+			var failures []string
+			failures = append(failures, expectSignal(r, "BRKO", signal.BUY)...)
+			failures = append(failures, expectOutcome(r, "BRKO", signal.StatusSuccess)...)
+			failures = append(failures, expectMessage(r, "BRKO")...)
+			if len(r.riskActions) > 0 {
+				failures = append(failures, fmt.Sprintf("expected no risk actions, got %v", r.riskActions))
+			}
+			return failures
+		},
+	},
+	{
+		name:     "crash",
+		fixtures: map[string]string{"CRSH": "crash"},
+		holding:  "CRSH",
+		check: func(r *scenarioResult) []string {
+			var failures []string
+			failures = append(failures, expectSignal(r, "CRSH", signal.SELL)...)
+			failures = append(failures, expectMessage(r, "CRSH")...)
+			if !containsPrefix(r.riskActions, "stop loss CRSH") {
+				failures = append(failures, fmt.Sprintf("expected a stop loss on CRSH, got %v", r.riskActions))
+			}
+			return failures
+		},
+	},
+	{
+		name:     "choppy",
+		fixtures: map[string]string{"CHOP": "choppy"},
+		check: func(r *scenarioResult) []string {
+			var failures []string
+			if len(r.signals) > 0 {
+				failures = append(failures, fmt.Sprintf("expected no signals in a choppy market, got %d", len(r.signals)))
+			}
+			if len(r.messages) > 0 {
+				failures = append(failures, fmt.Sprintf("expected no Telegram messages, got %d", len(r.messages)))
+			}
+			return failures
+		},
+	},
+}
+
+// selectScenarios returns the scenarios named in a comma-separated list, or all of them
+func selectScenarios(names string) ([]scenario, error) {
+	if names == "all" {
+		return scenarios, nil
+	}
+
+	var selected []scenario
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		found := false
+		for _, s := range scenarios {
+			if s.name == name {
+				selected = append(selected, s)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown scenario: %s", name)
+		}
+	}
+	return selected, nil
+}
+
+// runScenario replays a scenario's session bar by bar through the signal
+// pipeline, the paper trade manager and the risk manager
+func runScenario(sc scenario) (*scenarioResult, error) {
+	history := make(map[string]*data.MarketData, len(sc.fixtures))
+	symbols := make([]string, 0, len(sc.fixtures))
+	for symbol, fixture := range sc.fixtures {
+		md, err := data.Fixture(fixture, symbol, scenarioStart)
+		if err != nil {
+			return nil, err
+		}
+		history[symbol] = md
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+
+	cfg := config.CreateDefaultConfig()
+	cfg.StockSymbols = symbols
+	// Fixtures have no daily history to measure liquidity from
+	cfg.Liquidity = config.LiquidityConfig{}
+
+	runner := backtest.NewRunner(cfg, history)
+	bot := mock.NewMockTelegramBot()
+	runner.Notifier.Forward(bot)
+
+	trades := execution.NewTradeManager(10000, 100)
+	trades.SetClock(runner.Clock)
+	risk := monitor.NewRiskManager(500, 100, trades)
+	risk.SetClock(runner.Clock)
+	risk.SetAccount(cfg.Compliance)
+	trades.AddPreTradeCheck(risk.BuyingPowerCheck())
+
+	result := &scenarioResult{}
+	seen := 0
+	timestamps := history[symbols[0]].Timestamps
+	// Start once there are enough bars for the indicators
+	for _, now := range timestamps[30:] {
+		if err := runner.Step(now); err != nil {
+			return nil, err
+		}
+		stocks := latestQuotes(runner, symbols, now)
+
+		if sc.holding != "" && len(trades.GetAllTrades()) == 0 {
+			decision := &strategy.TradeDecision{Symbol: sc.holding, Signal: strategy.Buy, Rationale: "scenario position"}
+			if _, err := trades.ExecuteTrade(decision, stocks[sc.holding]); err != nil {
+				return nil, fmt.Errorf("failed to open scenario position: %w", err)
+			}
+		}
+
+		// Follow new BUY signals with paper trades at their levels
+		signals := runner.Notifier.Signals()
+		for _, s := range signals[seen:] {
+			if s.Type != signal.BUY {
+				continue
+			}
+			decision := &strategy.TradeDecision{Symbol: s.Symbol, Signal: strategy.Buy, TargetPrice: s.TargetPrice, StopPrice: s.StopLoss, Rationale: s.ID, SignalID: s.ID}
+			if _, err := trades.ExecuteTrade(decision, stocks[s.Symbol]); err != nil {
+				log.Printf("Scenario %s: not following %s: %v", sc.name, s.ID, err)
+			}
+		}
+		seen = len(signals)
+
+		for _, closed := range trades.CheckStopLoss(stocks) {
+			if position, ok := trades.GetTrade(closed.PositionID); ok {
+				risk.UpdateDailyPnL(position, closed)
+			}
+			result.riskActions = append(result.riskActions, fmt.Sprintf("stop loss %s at $%.2f", closed.Symbol, closed.Price))
+		}
+		if hit, pnl := risk.CheckDailyLoss(stocks); hit {
+			trades.CloseAllPositions(stocks)
+			result.riskActions = append(result.riskActions, fmt.Sprintf("daily loss limit at $%.2f", pnl))
+		}
+	}
+
+	result.signals = runner.Notifier.Signals()
+	result.outcomes = runner.Notifier.Outcomes()
+	result.messages = bot.GetMockMessages()
+	return result, nil
+}
+
+// latestQuotes returns the last replayed price of each symbol as a quote
+func latestQuotes(runner *backtest.Runner, symbols []string, now time.Time) map[string]*data.Stock {
+	results, _ := runner.Source.GetMarketDataBatch(symbols)
+	stocks := make(map[string]*data.Stock, len(results))
+	for symbol, md := range results {
+		price := md.Prices[len(md.Prices)-1]
+		stocks[symbol] = &data.Stock{Symbol: symbol, CurrentPrice: price, Bid: price, Ask: price, LastUpdated: now}
+	}
+	return stocks
+}
+
+// expectSignal checks that a signal of the given type was sent for symbol
+func expectSignal(r *scenarioResult, symbol string, signalType signal.SignalType) []string {
+	for _, s := range r.signals {
+		if s.Symbol == symbol && s.Type == signalType {
+			return nil
+		}
+	}
+	return []string{fmt.Sprintf("expected a %s signal for %s, got %d signals", signalType, symbol, len(r.signals))}
+}
+
+// expectOutcome checks that a signal for symbol closed with the given status
+func expectOutcome(r *scenarioResult, symbol string, status signal.Status) []string {
+	for _, o := range r.outcomes {
+		if o.Signal.Symbol == symbol && o.Signal.Status == status {
+			return nil
+		}
+	}
+	return []string{fmt.Sprintf("expected a %s outcome for %s, got %d outcomes", status, symbol, len(r.outcomes))}
+}
+
+// expectMessage checks that a Telegram message mentioning text was sent
+func expectMessage(r *scenarioResult, text string) []string {
+	for _, m := range r.messages {
+		if strings.Contains(m, text) {
+			return nil
+		}
+	}
+	return []string{fmt.Sprintf("expected a Telegram message mentioning %s", text)}
+}
+
+// containsPrefix reports whether any value starts with prefix
+func containsPrefix(values []string, prefix string) bool {
+	for _, v := range values {
+		if strings.HasPrefix(v, prefix) {
+			return true
+		}
+	}
+	return false
+}
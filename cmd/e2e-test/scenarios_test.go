@@ -0,0 +1,24 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScenariosPass(t *testing.T) {
+	for _, sc := range scenarios {
+		result, err := runScenario(sc)
+		assert.NoError(t, err, sc.name)
+		assert.Empty(t, sc.check(result), sc.name)
+	}
+}
+
+func TestRunScenariosWritesJUnit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "junit.xml")
+	assert.True(t, runScenarios("breakout,choppy", path))
+	assert.FileExists(t, path)
+
+	assert.False(t, runScenarios("sideways", path))
+}
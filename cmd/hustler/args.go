@@ -0,0 +1,22 @@
+package main
+
+import "strings"
+
+// profileFlag removes --profile <name> or --profile=<name> from args and
+// returns the selected config profile, empty if none, with the other args
+func profileFlag(args []string) (string, []string) {
+	var profile string
+	rest := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--profile" && i+1 < len(args):
+			profile = args[i+1]
+			i++
+		case strings.HasPrefix(args[i], "--profile="):
+			profile = strings.TrimPrefix(args[i], "--profile=")
+		default:
+			rest = append(rest, args[i])
+		}
+	}
+	return profile, rest
+}
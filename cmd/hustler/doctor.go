@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/doctor"
+)
+
+// doctorCheckTimeout bounds each self-test
+const doctorCheckTimeout = 30 * time.Second
+
+// runDoctor tests the configuration and every service it points at, prints a
+// pass/fail report and returns the process exit code
+func runDoctor(args []string, profile string) int {
+	cfg := config.CreateDefaultConfig()
+	if len(args) > 0 {
+		loadedCfg, err := config.LoadConfigProfile(args[0], profile)
+		if err != nil {
+			fmt.Printf("[FAIL] config  %v\n", err)
+			return 1
+		}
+		cfg = loadedCfg
+		fmt.Printf("Checking %s", args[0])
+		if cfg.Profile != "" {
+			fmt.Printf(" (profile %s)", cfg.Profile)
+		}
+		fmt.Print("\n\n")
+	} else {
+		fmt.Print("No config file specified, checking the default configuration\n\n")
+	}
+
+	report := doctor.Run(context.Background(), doctor.Checks(cfg), doctorCheckTimeout)
+	fmt.Print(report.String())
+	if !report.OK() {
+		return 1
+	}
+	return 0
+}
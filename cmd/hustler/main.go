@@ -1,35 +1,119 @@
 package main
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"log"
 	"os"
 	ossignal "os/signal"
 	"syscall"
 	"time"
 
+	"github.com/hustler/trading-bot/pkg/alerts"
+	"github.com/hustler/trading-bot/pkg/anomaly"
 	"github.com/hustler/trading-bot/pkg/api"
+	"github.com/hustler/trading-bot/pkg/artifacts"
+	"github.com/hustler/trading-bot/pkg/attribution"
+	"github.com/hustler/trading-bot/pkg/auth"
+	"github.com/hustler/trading-bot/pkg/backfill"
+	"github.com/hustler/trading-bot/pkg/backup"
+	"github.com/hustler/trading-bot/pkg/briefing"
+	"github.com/hustler/trading-bot/pkg/cache"
+	"github.com/hustler/trading-bot/pkg/calendar"
 	"github.com/hustler/trading-bot/pkg/config"
 	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/desktop"
+	"github.com/hustler/trading-bot/pkg/execution"
+	"github.com/hustler/trading-bot/pkg/features"
+	"github.com/hustler/trading-bot/pkg/filings"
+	"github.com/hustler/trading-bot/pkg/focus"
+	"github.com/hustler/trading-bot/pkg/follower"
+	"github.com/hustler/trading-bot/pkg/grafana"
+	"github.com/hustler/trading-bot/pkg/heartbeat"
+	"github.com/hustler/trading-bot/pkg/indicators"
+	"github.com/hustler/trading-bot/pkg/journal"
 	"github.com/hustler/trading-bot/pkg/llm"
+	"github.com/hustler/trading-bot/pkg/logtail"
 	"github.com/hustler/trading-bot/pkg/monitor"
+	"github.com/hustler/trading-bot/pkg/news"
+	"github.com/hustler/trading-bot/pkg/objectstore"
+	"github.com/hustler/trading-bot/pkg/performance"
+	"github.com/hustler/trading-bot/pkg/privacy"
+	"github.com/hustler/trading-bot/pkg/publicpage"
+	"github.com/hustler/trading-bot/pkg/quota"
+	"github.com/hustler/trading-bot/pkg/regime"
+	"github.com/hustler/trading-bot/pkg/seasonality"
 	"github.com/hustler/trading-bot/pkg/signal"
+	"github.com/hustler/trading-bot/pkg/similarity"
+	"github.com/hustler/trading-bot/pkg/social"
+	"github.com/hustler/trading-bot/pkg/store"
+	"github.com/hustler/trading-bot/pkg/stream"
+	"github.com/hustler/trading-bot/pkg/symbols"
 	"github.com/hustler/trading-bot/pkg/telegram"
+	"github.com/hustler/trading-bot/pkg/timeseries"
+	"github.com/hustler/trading-bot/pkg/tracing"
+	"github.com/hustler/trading-bot/pkg/tuning"
+	"github.com/hustler/trading-bot/pkg/webpush"
 )
 
+// questradeTokenKey is the app state key the Questrade tokens are saved under
+const questradeTokenKey = "questrade_token"
+
 func main() {
+	// --profile <name> selects a profile of the config file, overriding $HUSTLER_PROFILE
+	profile, args := profileFlag(os.Args[1:])
+
+	// hustler doctor [config file] tests the setup and exits
+	if len(args) > 0 && args[0] == "doctor" {
+		os.Exit(runDoctor(args[1:], profile))
+	}
+	// hustler testsignal <symbol> [config file] sends a TEST signal and exits
+	if len(args) > 0 && args[0] == "testsignal" {
+		os.Exit(runTestSignal(args[1:], profile))
+	}
+	// hustler replay (--out file | --channel id) [config file] rebuilds the channel history and exits
+	if len(args) > 0 && args[0] == "replay" {
+		os.Exit(runReplay(args[1:], profile))
+	}
+	// hustler backup [--out file] [config file] snapshots the store and exits
+	if len(args) > 0 && args[0] == "backup" {
+		os.Exit(runBackup(args[1:], profile))
+	}
+	// hustler restore [--verify] archive verifies an archive, restores the store from it and exits
+	if len(args) > 0 && args[0] == "restore" {
+		os.Exit(runRestore(args[1:], profile))
+	}
+	// hustler vapidkeys prints a key pair for web_push and exits
+	if len(args) > 0 && args[0] == "vapidkeys" {
+		publicKey, privateKey, err := webpush.GenerateVAPIDKeys()
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Public key:  %s\nPrivate key: %s\n", publicKey, privateKey)
+		return
+	}
+
+	// Keep recent log lines for the API, e.g. for the terminal dashboard
+	logs := logtail.NewTail(1000)
+	log.SetOutput(io.MultiWriter(os.Stderr, logs))
+
 	log.Println("Starting Hustler Trading Bot...")
 
 	// Load configuration
 	cfg := config.CreateDefaultConfig()
-	if len(os.Args) > 1 {
-		configFile := os.Args[1]
-		loadedCfg, err := config.LoadConfigFromFile(configFile)
+	if len(args) > 0 {
+		configFile := args[0]
+		loadedCfg, err := config.LoadConfigProfile(configFile, profile)
 		if err != nil {
 			log.Printf("Warning: Failed to load config from %s: %v", configFile, err)
 			log.Println("Using default configuration")
 		} else {
 			cfg = loadedCfg
 			log.Printf("Loaded configuration from %s", configFile)
+			if cfg.Profile != "" {
+				log.Printf("Using config profile %s", cfg.Profile)
+			}
 		}
 	} else {
 		log.Println("No config file specified, using default configuration")
@@ -38,31 +122,678 @@ func main() {
 	// Initialize components
 	dataProvider := data.NewProvider(cfg)
 	signalGen := signal.NewGenerator(cfg)
+
+	// Share one Questrade client between the provider and the quote watchers:
+	// every refresh rotates the token, so a second session would revoke the first
+	questrade := data.QuestradeClientFromConfig(cfg.DataSource)
+	if questrade != nil {
+		dataProvider.SetQuestradeClient(questrade)
+	}
+
+	// Open the store shared by the components that persist state
+	var db *store.Logger
+	if cfg.Store.DatabaseURL != "" {
+		var err error
+		if db, err = openStore(cfg.Store.DatabaseURL, cfg.Store); err != nil {
+			log.Fatalf("Failed to open the store: %v", err)
+		}
+		defer db.Close()
+		if err := db.InitDB(); err != nil {
+			log.Fatalf("Failed to initialize the store: %v", err)
+		}
+	}
+
+	// Save rotated Questrade tokens, so a restart resumes the session instead
+	// of refreshing with the token from the config, which is no longer valid
+	if questrade != nil && db != nil && cfg.Store.TokenSecret != "" {
+		tokens, err := auth.NewEncryptedTokenStore(db, questradeTokenKey, []byte(cfg.Store.TokenSecret))
+		if err != nil {
+			log.Fatalf("Failed to set up the token store: %v", err)
+		}
+		if err := questrade.SetTokenStore(tokens); err != nil {
+			log.Printf("Error loading the saved Questrade token: %v", err)
+		}
+	}
+
+	// Gate risky new behavior so it can be rolled out gradually and toggled at runtime
+	featureFlags := features.NewFlags(cfg.Features)
+	signalGen.SetFeatureGate(featureFlags)
 	telegramBot := telegram.NewBot(cfg.Telegram)
 
+	// Initialize user alert rules, delivered via Telegram
+	alertEngine := alerts.NewEngine()
+	alertEngine.AddNotifier(telegramBot)
+	telegramBot.SetAlertEngine(alertEngine)
+
+	// Symbol blacklist/allowlist, managed from Telegram and the API
+	symbolLists := symbols.NewLists()
+	signalGen.SetSymbolFilter(symbolLists)
+	telegramBot.SetSymbolLists(symbolLists)
+
+	// Expand watchlist entries like QQQ:components to the ETF's holdings
+	expander := symbols.NewExpander(cfg.Constituents)
+
+	// Signals from each watchlist go to the channels bound to it
+	telegramBot.SetWatchlists(cfg.GetWatchlists())
+
+	// Bot state that must survive restarts, e.g. Telegram messages still queued
+	var botState telegram.StateStore
+	if cfg.Backfill.StateFile != "" {
+		botState = backfill.NewFileState(cfg.Backfill.StateFile)
+	}
+
+	// Send Telegram messages through a rate-limited queue that retries failures
+	// and restores the messages still pending from the last run
+	if cfg.Telegram.BotToken != "" {
+		if err := telegramBot.StartQueue(botState); err != nil {
+			log.Fatalf("Failed to start Telegram queue: %v", err)
+		}
+		defer telegramBot.StopQueue()
+	}
+
+	// Restore the preferences subscribers chose when onboarding, so direct
+	// messages keep following them after a restart
+	if botState != nil {
+		if err := telegramBot.SetPreferenceStore(botState); err != nil {
+			log.Printf("Error restoring subscriber preferences: %v", err)
+		}
+	}
+
+	// Send direct signals only to subscribers with paid premium access, kept in the store
+	if cfg.Subscription.Enabled {
+		telegramBot.SetSubscription(cfg.Subscription, db)
+	}
+
 	// Initialize LLM manager
 	llmManager, err := llm.NewManager(&cfg.LLM)
 	if err != nil {
 		log.Fatalf("Failed to initialize LLM manager: %v", err)
 	}
 
+	// Push signals to a desktop notification service, alongside Telegram or
+	// instead of it when no bot token is set
+	var notifier monitor.Notifier = telegramBot
+	if cfg.Desktop.Enabled {
+		service, err := desktop.NewService(cfg.Desktop)
+		if err != nil {
+			log.Fatalf("Failed to initialize desktop notifications: %v", err)
+		}
+		var desktopNotifier *desktop.Notifier
+		if cfg.Telegram.BotToken == "" {
+			desktopNotifier = desktop.NewNotifier(nil, service)
+		} else {
+			desktopNotifier = desktop.NewNotifier(telegramBot, service)
+		}
+		desktopNotifier.SetFilter(cfg.Desktop.Filter)
+		notifier = desktopNotifier
+		log.Printf("Pushing signals to %s", cfg.Desktop.Service)
+	}
+
+	// Push signals and risk alerts to browsers that installed the dashboard
+	var pushSender *webpush.Sender
+	var pushSubs *webpush.Subscriptions
+	if cfg.WebPush.Enabled {
+		pushSubs, err = webpush.NewSubscriptions(cfg.WebPush.SubscriptionsFile)
+		if err != nil {
+			log.Fatalf("Failed to load push subscriptions: %v", err)
+		}
+		pushSender, err = webpush.NewSender(cfg.WebPush, pushSubs)
+		if err != nil {
+			log.Fatalf("Failed to initialize web push: %v", err)
+		}
+		pushNotifier := webpush.NewNotifier(notifier, pushSender)
+		pushNotifier.SetFilter(cfg.WebPush.Filter)
+		notifier = pushNotifier
+		alertEngine.AddNotifier(pushNotifier)
+		log.Printf("Pushing signals to %d subscribed browsers", len(pushSubs.List()))
+	}
+
+	// Share quotes, notification dedup and signal events with other instances
+	var sharedCache *cache.Redis
+	if cfg.Redis.Enabled {
+		sharedCache = cache.NewRedis(cfg.Redis)
+		dataProvider.SetQuoteCache(sharedCache, time.Duration(cfg.Redis.QuoteTTLSeconds)*time.Second)
+		telegramBot.SetDedupLedger(sharedCache)
+		notifier = cache.NewEventNotifier(notifier, sharedCache)
+		log.Printf("Sharing state through Redis at %s", cfg.Redis.Addr)
+	} else if cfg.Telegram.Dedup.DatabaseURL != "" {
+		// Without Redis, keep the ledger of sent notifications in the store and
+		// prune what a restart no longer needs
+		ledger, err := openStore(cfg.Telegram.Dedup.DatabaseURL, cfg.Store)
+		if err != nil {
+			log.Fatalf("Failed to open notification ledger: %v", err)
+		}
+		defer ledger.Close()
+		telegramBot.SetDedupLedger(ledger)
+
+		retention := time.Duration(cfg.Telegram.Dedup.RetentionHours) * time.Hour
+		stopPrune := make(chan struct{})
+		defer close(stopPrune)
+		go func() {
+			for {
+				if pruned, err := ledger.PruneNotifications(time.Now().Add(-retention)); err != nil {
+					log.Printf("Error pruning notification ledger: %v", err)
+				} else if pruned > 0 {
+					log.Printf("Pruned %d notifications from the ledger", pruned)
+				}
+
+				select {
+				case <-stopPrune:
+					return
+				case <-time.After(time.Hour):
+				}
+			}
+		}()
+	}
+
+	// Mark signals and their outcomes on Grafana dashboards
+	if cfg.Grafana.Enabled {
+		notifier = grafana.NewNotifier(notifier, grafana.NewClient(cfg.Grafana))
+		log.Printf("Publishing annotations to Grafana at %s", cfg.Grafana.URL)
+	}
+
 	// Initialize market monitor
 	marketMonitor := monitor.NewMarketMonitor(
 		cfg,
 		dataProvider,
 		signalGen,
 		llmManager,
-		telegramBot,
+		notifier,
 	)
+	marketMonitor.SetSymbolExpander(expander)
+
+	// Keep active signal levels consistent across splits and dividends
+	if cfg.DataSource.AdjustCorporateActions {
+		marketMonitor.SetCorporateActionSource(dataProvider)
+	}
+
+	// Enrich signals with company names, sectors and board lots
+	metadata := symbols.NewMetadataService(cfg.Metadata, symbols.NewFinnhubMetadata(cfg.DataSource.APIKeys["finnhub"]))
+	marketMonitor.SetMetadata(metadata)
+
+	// Classify the daily market regime to switch signal parameters
+	if cfg.Regime.Enabled {
+		marketMonitor.SetRegimeClassifier(regime.NewClassifier(dataProvider, cfg.Regime))
+	}
+
+	// Decay per-symbol tuned parameters toward the defaults unless a recent
+	// backtest re-validates them, and only let optimizer proposals replace
+	// them after beating them out of sample, auditing each decision in the store
+	var tunedParams *tuning.Registry
+	var paramValidator *tuning.Validator
+	var optimizerGuard *tuning.Guard
+	if len(cfg.SymbolParams) > 0 || cfg.OptimizerGuard.HoldoutDays > 0 {
+		tunedParams = tuning.NewRegistry(cfg.ParamDecay, cfg.SymbolParams)
+		signalGen.SetSymbolParams(tunedParams)
+		if cfg.ParamDecay.ValidateHours > 0 {
+			paramValidator = tuning.NewValidator(cfg, dataProvider, tunedParams)
+			stopValidation := make(chan struct{})
+			defer close(stopValidation)
+			go paramValidator.Run(stopValidation)
+		}
+		if cfg.OptimizerGuard.HoldoutDays > 0 {
+			optimizerGuard = tuning.NewGuard(cfg, dataProvider, tunedParams)
+			if db != nil {
+				optimizerGuard.SetAuditLog(db)
+			}
+		}
+	}
+
+	// Scale the global thresholds to each symbol's realized volatility, so
+	// volatile symbols don't over-trigger and quiet ones still trigger
+	var volatilitySurface *tuning.VolatilitySurface
+	if cfg.VolatilityScaling.Enabled {
+		volatilitySurface = tuning.NewVolatilitySurface(dataProvider, tunedParams, cfg.VolatilityScaling)
+		signalGen.SetSymbolParams(volatilitySurface)
+		stopSurface := make(chan struct{})
+		defer close(stopSurface)
+		go volatilitySurface.Run(cfg.WatchedSymbols(), stopSurface)
+	}
+
+	// Ground signal time frames in each symbol's typical intraday behavior
+	if cfg.Seasonality.Enabled {
+		tracker := seasonality.NewTracker(dataProvider, cfg.Seasonality)
+		marketMonitor.SetSeasonalityTracker(tracker)
+		signalGen.AddFactorSource(tracker)
+	}
+
+	// Let several strategies vote on each symbol, weighted by their recent outcomes
+	if cfg.Ensemble.Enabled {
+		ensemble, err := signal.NewEnsemble(cfg.Ensemble)
+		if err != nil {
+			log.Fatalf("Failed to initialize strategy ensemble: %v", err)
+		}
+		signalGen.SetEnsemble(ensemble)
+		marketMonitor.OnSignalClosed(ensemble.Resolve)
+	}
+
+	// Trade the spreads of configured pairs, long one leg and short the other
+	if cfg.Pairs.Enabled {
+		pairStrategy, err := signal.NewPairStrategy(cfg.Pairs.Strategy)
+		if err != nil {
+			log.Fatalf("Failed to initialize pair strategy: %v", err)
+		}
+		signalGen.SetPairStrategy(pairStrategy)
+	}
+
+	// Track what a subscriber acting on each signal after a delay would make
+	var followerSim *follower.Simulator
+	if cfg.Follower.Enabled {
+		followerSim = follower.NewSimulator(cfg.Follower)
+		marketMonitor.SetFollowerSimulator(followerSim)
+		marketMonitor.OnSignalClosed(followerSim.Close)
+	}
+
+	// Cap signals and LLM tokens per watchlist and API calls per user
+	var quotas *quota.Manager
+	if cfg.Quotas.Enabled {
+		quotas = quota.NewManager(cfg.Quotas)
+		marketMonitor.SetQuotaEnforcer(quotas)
+	}
+
+	// Track the outcome of delivered signals
+	perf := performance.NewMonitor()
+	if session, err := cfg.Session.Clock(); err == nil {
+		perf.SetSession(session)
+	}
+	marketMonitor.OnSignalPublished(perf.AddSignal)
+	marketMonitor.OnMarketData(perf.Observe)
+	marketMonitor.OnSignalClosed(func(s *signal.Signal, exitPrice float64) {
+		if err := perf.UpdateSignalStatus(s.ID, s.Status, exitPrice); err != nil {
+			log.Printf("Error tracking signal outcome: %v", err)
+		}
+	})
+
+	// Bound the signals, results and articles kept in memory, storing those
+	// dropped by the retention policies when there is a store
+	var archiver monitor.Archiver
+	if db != nil {
+		archiver = db
+		marketMonitor.SetArchiver(archiver)
+	}
+	perf.SetRetention(cfg.Retention.Results, archiver)
+
+	// Save every signal and its adjustments and outcome to the store, to be
+	// searched from the API
+	if db != nil {
+		marketMonitor.SetSignalStore(db)
+	}
+
+	// Record subscribers' reactions to signal messages with the outcomes
+	telegramBot.AddSentimentRecorder(perf)
+	if db != nil {
+		telegramBot.AddSentimentRecorder(db)
+	}
+
+	// Stream signal changes to API clients
+	signalHub := stream.NewHub()
+	signalHub.StreamSignals(marketMonitor)
+
+	// Store generated reports and exports in object storage, deleting them
+	// past their retention
+	var artifactUploader *artifacts.Uploader
+	if cfg.Artifacts.Enabled {
+		artifactStorage, err := objectstore.NewS3(cfg.Artifacts.Storage)
+		if err != nil {
+			log.Fatalf("Failed to set up artifact storage: %v", err)
+		}
+		artifactUploader = artifacts.NewUploader(cfg.Artifacts, artifactStorage)
+		stopArtifacts := make(chan struct{})
+		defer close(stopArtifacts)
+		go artifactUploader.Run(stopArtifacts)
+	}
+
+	// Publish a shareable track record of delivered signals, refreshed daily
+	if cfg.PublicPage.Enabled {
+		var followerSource publicpage.FollowerSource
+		if followerSim != nil {
+			followerSource = followerSim
+		}
+		page := publicpage.NewGenerator(cfg.PublicPage, perf, followerSource)
+		if artifactUploader != nil {
+			page.SetUploader(artifactUploader)
+		}
+		stopPage := make(chan struct{})
+		defer close(stopPage)
+		go page.Run(stopPage)
+	}
+
+	// Compare new signals with similar past setups and learn their outcomes
+	if cfg.Similarity.Enabled {
+		setups := similarity.NewIndex(similarity.NewHashEmbedder(), cfg.Similarity)
+		marketMonitor.SetSetupIndex(setups)
+		marketMonitor.OnSignalClosed(setups.Resolve)
+	}
+	if sharedCache != nil {
+		mirrorCtx, stopMirror := context.WithCancel(context.Background())
+		defer stopMirror()
+		go sharedCache.MirrorMonitorState(mirrorCtx, marketMonitor, time.Duration(cfg.CheckInterval)*time.Second)
+	}
+
+	// Trace each market check from data fetch to persistence via OTLP
+	if cfg.Tracing.Enabled {
+		exporter := tracing.NewOTLPExporter(cfg.Tracing)
+		exporter.Start()
+		defer exporter.Stop()
+		marketMonitor.SetTracer(tracing.NewTracer(exporter))
+	}
+
+	// Poll quotes of the watched symbols for the components that follow every
+	// tick, sharing one poller between them
+	quotes := data.NewMarketWatcher(auth.NewAuthManager(), cfg.DataSource.Primary, cfg.CheckInterval)
+	if questrade != nil {
+		quotes.SetQuestradeClient(questrade)
+	}
+	for _, symbol := range expander.Expand(cfg.WatchedSymbols()) {
+		quotes.AddStock(symbol)
+	}
+	// Follow symbols added by the screener import or a config update without a restart
+	marketMonitor.OnWatchlistChange(func(symbols []string) {
+		added, removed := quotes.SyncWatchlist(symbols)
+		if len(added) > 0 || len(removed) > 0 {
+			log.Printf("Quote watchlist synced: %d added, %d removed", len(added), len(removed))
+		}
+	})
+
+	// Evaluate user alert rules on every tick of the watched symbols
+	alertEngine.Attach(quotes)
+
+	// Flag unusual price moves and volume spikes on the ticks, whatever the strategies say
+	anomalies := anomaly.NewDetector(cfg.Anomaly)
+	anomalies.Attach(quotes, telegramBot)
+
+	// Stream raw quotes and indicator values to the time-series database
+	if cfg.Timeseries.Enabled {
+		backend, err := timeseries.NewBackend(cfg.Timeseries)
+		if err != nil {
+			log.Fatalf("Failed to initialize time-series backend: %v", err)
+		}
+		sink := timeseries.NewSink(backend, cfg.Timeseries)
+		sink.Start()
+		defer sink.Stop()
+
+		quotes.OnUpdate(sink.RecordQuote)
+		pipeline := indicators.NewDefaultPipeline(indicators.NewIndicatorProcessor())
+		pipeline.SetLogger(sink, time.Minute)
+		pipeline.Attach(quotes)
+	}
+
+	// Re-validate signals against the latest tick before they are published
+	if cfg.SignalRevision.MaxDriftPercent > 0 {
+		marketMonitor.SetPriceSource(quotes)
+	}
+
+	// Alert on insider and institutional filings and feed insider activity to strategies
+	if cfg.Filings.Enabled {
+		filingsMonitor := filings.NewMonitor(cfg.Filings, filings.NewEDGAR(cfg.Filings.UserAgent), telegramBot, marketMonitor)
+		signalGen.AddFactorSource(filingsMonitor)
+		filingsCtx, stopFilings := context.WithCancel(context.Background())
+		defer stopFilings()
+		go filingsMonitor.Run(filingsCtx)
+	}
+
+	// Track social chatter, feeding mention volume and sentiment to strategies
+	if cfg.Social.Enabled {
+		socialMonitor := social.NewMonitor(cfg.Social, social.NewSources(cfg.Social), telegramBot, marketMonitor)
+		signalGen.AddFactorSource(socialMonitor)
+		socialCtx, stopSocial := context.WithCancel(context.Background())
+		defer stopSocial()
+		go socialMonitor.Run(socialCtx)
+	}
+
+	// Watch the news, checking symbols immediately on breaking headlines
+	var newsMonitor *news.Monitor
+	if len(cfg.News.Sources) > 0 {
+		newsMonitor = news.NewMonitor(cfg.News, auth.NewAuthManager())
+		newsMonitor.SetRetention(cfg.Retention.Articles, archiver)
+		if cfg.News.Breaking.Enabled {
+			breaking, err := news.NewBreaking(cfg.News.Breaking, marketMonitor, marketMonitor, telegramBot)
+			if err != nil {
+				log.Fatalf("Failed to initialize breaking news: %v", err)
+			}
+			breaking.Attach(newsMonitor)
+		}
+		newsMonitor.Start()
+		defer newsMonitor.Stop()
+	}
+
+	// Allow admins to adjust active signals from Telegram
+	telegramBot.SetSignalAdjuster(marketMonitor)
+	telegramBot.SetTestSignalSender(marketMonitor)
+	telegramBot.SetIdeaDesk(marketMonitor)
+
+	// Let admins add symbols to the watchlist in bulk from CSV files, after
+	// checking the data provider has quotes for them
+	importer := symbols.NewImporter(cfg.WatchlistImport, dataProvider, marketMonitor)
+	telegramBot.SetWatchlistImporter(importer)
+
+	// Poll one symbol every few seconds while a live trade is managed
+	focusWatcher := focus.NewWatcher(cfg.Focus, dataProvider, telegramBot)
+	telegramBot.SetFocusWatcher(focusWatcher)
+
+	// Let subscribers export or delete the data stored about them
+	subscriberData := privacy.NewService()
+	subscriberData.AddSource("telegram", telegramBot)
+	subscriberData.AddSource("alerts", alertEngine)
+	telegramBot.SetDataRequests(subscriberData)
+
+	// Check paper orders against the compliance rules and the account's buying power
+	trades, risk := newPaperTrades(cfg)
+	// Include market breadth in the risk reports
+	risk.SetBreadthSource(marketMonitor)
+	if cfg.Compliance.Enabled {
+		if balance, ok := risk.AccountBalance(nil); ok {
+			log.Printf("Paper trading with $%.2f buying power", balance.BuyingPower)
+		}
+	}
+
+	// Follow BUY signals with paper trades, exited at their stops and targets
+	// as quotes come in
+	marketMonitor.OnSignalPublished(func(s *signal.Signal) {
+		followSignal(trades, quotes, s)
+	})
+	managePaperTrades(trades, risk, quotes)
+
+	// Let admins change and close paper trades from Telegram and the API at
+	// the latest quotes, auditing every change in the store
+	if db != nil {
+		trades.SetAuditLog(db)
+	}
+	telegramBot.SetTradeDesk(trades, quotes)
+
+	// Move the tracked levels and the signal's open paper trade with the
+	// target and stop adjusted from Telegram or the API
+	marketMonitor.OnSignalAdjusted(func(s *signal.Signal) {
+		if err := perf.AdjustSignal(s.ID, s.TargetPrice, s.StopLoss); err != nil {
+			log.Printf("Error tracking signal adjustment: %v", err)
+		}
+		if trade, ok := trades.TradeForSignal(s.ID); ok && trade.Status == execution.Executed {
+			if _, err := trades.AdjustLevels(s.Symbol, s.TargetPrice, s.StopLoss); err != nil {
+				log.Printf("Error adjusting the paper trade for %s: %v", s.ID, err)
+			}
+		}
+	})
+
+	// Journal every closed paper trade with an LLM post-mortem, kept in the store
+	tradeJournal := journal.NewJournal(llmManager)
+	if db != nil {
+		if err := tradeJournal.SetStore(db); err != nil {
+			log.Printf("Error restoring the trade journal: %v", err)
+		}
+	}
+	tradeJournal.Attach(trades)
+
+	// Flatten the paper positions before the close, post the closing summary
+	// and record the outcomes of the signals they followed
+	eodJob := monitor.NewEODJob(risk, trades, quotes)
+	eodJob.SetSender(telegramBot)
+	eodJob.SetOutcomeRecorder(perf)
+	stopEOD := make(chan struct{})
+	defer close(stopEOD)
+	go eodJob.Run(stopEOD)
+
+	// Attribute each signal's outcome to the paper trade that followed it, and
+	// post the week's attribution to the channel when enabled
+	attributionReporter := attribution.NewReporter(perf, trades)
+	if cfg.Attribution.WeeklyReport {
+		weeklyAttribution := attribution.NewWeeklyJob(attributionReporter, telegramBot, cfg.Attribution)
+		stopAttribution := make(chan struct{})
+		defer close(stopAttribution)
+		go weeklyAttribution.Run(stopAttribution)
+	}
+
+	// Refresh each symbol's beta and historical volatility once a day, to
+	// size and stop positions by them
+	var indicatorLog indicators.IndicatorLogger
+	if db != nil {
+		indicatorLog = db
+	}
+	stopProfiles := make(chan struct{})
+	defer close(stopProfiles)
+	go func() {
+		for {
+			if err := risk.RefreshVolatilityProfiles(dataProvider, expander.Expand(marketMonitor.WatchedSymbols()), indicatorLog); err != nil {
+				log.Printf("Error refreshing volatility profiles: %v", err)
+			}
+
+			select {
+			case <-stopProfiles:
+				return
+			case <-time.After(time.Hour):
+			}
+		}
+	}()
 
 	// Initialize API server
 	server := api.NewServer()
+	server.SetAlertEngine(alertEngine)
+	server.SetSignalAdjuster(marketMonitor)
+	server.SetTestSignalSender(marketMonitor)
+	server.SetIdeaDesk(marketMonitor)
+	server.SetFocusWatcher(focusWatcher)
+	server.SetFeatureFlags(featureFlags)
+	server.SetPerformanceSource(perf)
+	server.SetSignalStream(signalHub)
+	server.SetLogSource(logs)
+	server.SetHeatmapSource(marketMonitor)
+	server.SetBreadthSource(marketMonitor)
+	server.SetCheckHealthSource(marketMonitor)
+	server.SetShadowSource(marketMonitor)
+	server.SetSessionSource(marketMonitor)
+	server.SetPublicAPI(cfg.PublicAPI, marketMonitor)
+	if tunedParams != nil {
+		var validator api.ParamValidator
+		if paramValidator != nil {
+			validator = paramValidator
+		}
+		server.SetTunedParams(tunedParams, validator)
+	}
+	if volatilitySurface != nil {
+		server.SetVolatilitySurface(volatilitySurface)
+	}
+	if optimizerGuard != nil {
+		server.SetOptimizerGuard(optimizerGuard)
+	}
+	server.SetJournal(tradeJournal)
+	symbolSources := api.SymbolSources{
+		Market:      quotes,
+		Indicators:  signalGen,
+		Signals:     marketMonitor,
+		Trades:      trades,
+		Performance: perf,
+	}
+	if newsMonitor != nil {
+		symbolSources.News = newsSource{monitor: newsMonitor}
+	}
+	server.SetSymbolSources(symbolSources)
+	if db != nil {
+		server.SetSignalSearcher(db)
+	}
+	server.SetWatchlistImporter(importer)
+	server.SetAttributionReporter(attributionReporter)
+	server.SetTradeDesk(trades, quotes)
+	server.SetSignalTrades(trades)
+	server.SetSymbolLists(symbolLists)
+	server.SetSubscriberData(subscriberData)
+	if cfg.Subscription.Enabled {
+		server.SetStripeWebhook(cfg.Subscription.StripeWebhookSecret, db)
+	}
+	if pushSender != nil {
+		server.SetWebPush(pushSender.PublicKey(), pushSubs)
+	}
+	if cfg.CalendarFeed.Enabled {
+		server.SetCalendarFeed(calendar.NewFeed(cfg), cfg.CalendarFeed.Token)
+	}
+	if quotas != nil {
+		server.SetQuotaManager(quotas)
+	}
+	if followerSim != nil {
+		server.SetFollowerSource(followerSim)
+	}
+	if artifactUploader != nil {
+		server.SetArtifactStore(artifactUploader)
+	}
 	go func() {
 		if err := server.Start(":8080"); err != nil {
 			log.Fatalf("Failed to start API server: %v", err)
 		}
 	}()
 
+	// Report what was missed while the bot was down, then keep a heartbeat
+	if cfg.Backfill.Enabled {
+		backfillJob := backfill.NewJob(cfg, dataProvider, botState, telegramBot)
+		if _, err := backfillJob.Run(); err != nil {
+			log.Printf("Error backfilling missed market checks: %v", err)
+		}
+		stopHeartbeat := make(chan struct{})
+		defer close(stopHeartbeat)
+		go backfillJob.RunHeartbeat(stopHeartbeat)
+	}
+
+	// Tell the admins daily that the bot is alive, with uptime and usage stats
+	if cfg.Heartbeat.Enabled {
+		aliveJob := heartbeat.NewJob(marketMonitor, llmManager, telegramBot, cfg.Telegram.AdminUserIDs, cfg.Heartbeat)
+		stopAlive := make(chan struct{})
+		defer close(stopAlive)
+		go aliveJob.Run(stopAlive)
+	}
+
+	// Brief the channel on futures, movers, events and yesterday's signals before the open
+	if cfg.Briefing.Enabled {
+		briefingJob := briefing.NewJob(dataProvider, calendar.NewFeed(cfg), perf, telegramBot, cfg.WatchedSymbols(), cfg.Briefing)
+		briefingJob.SetSummarizer(llmManager)
+		stopBriefing := make(chan struct{})
+		defer close(stopBriefing)
+		go briefingJob.Run(stopBriefing)
+	}
+
+	// Back up the store and the config file on a schedule
+	if cfg.Backup.Enabled {
+		if backupStore, err := openStore(cfg.Backup.DatabaseURL, cfg.Store); err != nil {
+			log.Printf("Error opening the store for backups: %v", err)
+		} else {
+			var configFile string
+			if len(args) > 0 {
+				configFile = args[0]
+			}
+			backupJob := backup.NewJob(cfg.Backup, backupStore, configFile)
+			if cfg.Backup.S3.Bucket != "" {
+				remote, err := objectstore.NewS3(cfg.Backup.S3)
+				if err != nil {
+					log.Fatalf("Failed to set up backup uploads: %v", err)
+				}
+				backupJob.SetRemote(remote)
+			}
+			stopBackup := make(chan struct{})
+			defer close(stopBackup)
+			go backupJob.Run(stopBackup)
+		}
+	}
+
+	// Start polling quotes once every component follows them
+	quotes.StartWatching()
+	defer quotes.StopWatching()
+
 	// Start market monitor
 	err = marketMonitor.Start()
 	if err != nil {
@@ -77,6 +808,8 @@ func main() {
 			if err != nil {
 				log.Printf("Error processing Telegram updates: %v", err)
 			}
+			telegramBot.FlushDigests(time.Now())
+			telegramBot.ReleaseHeldMessages(time.Now())
 			time.Sleep(5 * time.Second)
 		}
 	}()
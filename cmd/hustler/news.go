@@ -0,0 +1,28 @@
+package main
+
+import (
+	"github.com/hustler/trading-bot/pkg/api"
+	"github.com/hustler/trading-bot/pkg/news"
+)
+
+// newsSource serves a news monitor's articles to the symbol detail endpoint
+type newsSource struct {
+	monitor *news.Monitor
+}
+
+// RecentNews returns the latest articles about symbol, newest first
+func (n newsSource) RecentNews(symbol string, limit int) []api.NewsItem {
+	articles := n.monitor.GetArticlesForSymbol(symbol, limit)
+	items := make([]api.NewsItem, 0, len(articles))
+	for _, article := range articles {
+		items = append(items, api.NewsItem{
+			Title:       article.Title,
+			Description: article.Description,
+			URL:         article.URL,
+			Source:      article.Source,
+			PublishedAt: article.PublishedAt,
+			Sentiment:   article.Sentiment,
+		})
+	}
+	return items
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/store"
+)
+
+// openStore opens the store at dsn, writing new payloads with the codec and
+// compression threshold of cfg
+func openStore(dsn string, cfg config.StoreConfig) (*store.Logger, error) {
+	name := cfg.Codec
+	if name == "" {
+		name = store.CodecJSON
+	}
+	codec, err := store.CodecByName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	logger, err := store.OpenLogger(dsn)
+	if err != nil {
+		return nil, err
+	}
+	logger.SetPayloadCodec(codec, cfg.CompressAboveBytes)
+	return logger, nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/llm"
+	"github.com/hustler/trading-bot/pkg/monitor"
+	"github.com/hustler/trading-bot/pkg/signal"
+	"github.com/hustler/trading-bot/pkg/symbols"
+	"github.com/hustler/trading-bot/pkg/telegram"
+)
+
+// runTestSignal sends a labeled TEST signal for a symbol through the real data
+// source, LLM and Telegram channels and returns the process exit code
+func runTestSignal(args []string, profile string) int {
+	if len(args) == 0 {
+		fmt.Println("Usage: hustler testsignal <symbol> [config file]")
+		return 2
+	}
+
+	cfg := config.CreateDefaultConfig()
+	if len(args) > 1 {
+		loadedCfg, err := config.LoadConfigProfile(args[1], profile)
+		if err != nil {
+			fmt.Printf("Failed to load config: %v\n", err)
+			return 1
+		}
+		cfg = loadedCfg
+	}
+
+	llmManager, err := llm.NewManager(&cfg.LLM)
+	if err != nil {
+		fmt.Printf("Failed to initialize LLM manager: %v\n", err)
+		return 1
+	}
+	telegramBot := telegram.NewBot(cfg.Telegram)
+	telegramBot.SetWatchlists(cfg.GetWatchlists())
+
+	dataProvider := data.NewProvider(cfg)
+	if questrade := data.QuestradeClientFromConfig(cfg.DataSource); questrade != nil {
+		dataProvider.SetQuestradeClient(questrade)
+	}
+	marketMonitor := monitor.NewMarketMonitor(cfg, dataProvider, signal.NewGenerator(cfg), llmManager, telegramBot)
+	marketMonitor.SetMetadata(symbols.NewMetadataService(cfg.Metadata, symbols.NewFinnhubMetadata(cfg.DataSource.APIKeys["finnhub"])))
+
+	s, err := marketMonitor.SendTestSignal(strings.ToUpper(args[0]))
+	if err != nil {
+		fmt.Printf("Test signal failed: %v\n", err)
+		return 1
+	}
+	fmt.Printf("Test signal %s sent for %s at $%.2f\n", s.ID, s.Symbol, s.Price)
+	return 0
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"log"
+
+	"github.com/hustler/trading-bot/pkg/compliance"
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/execution"
+	"github.com/hustler/trading-bot/pkg/monitor"
+	"github.com/hustler/trading-bot/pkg/signal"
+	"github.com/hustler/trading-bot/pkg/strategy"
+)
+
+// Limits of the paper trades, as in the end-to-end scenarios
+const (
+	paperCapitalPerStock = 10000.0
+	paperMaxLossPerTrade = 100.0
+	paperMaxDailyLoss    = 500.0
+)
+
+// newPaperTrades returns a paper trade manager and its risk manager. Orders
+// must pass the compliance checks and the buying power check, and positions
+// are sized and stopped by each symbol's volatility and beta and held
+// overnight as the holding policy allows.
+func newPaperTrades(cfg *config.Config) (*execution.TradeManager, *monitor.RiskManager) {
+	trades := execution.NewTradeManager(paperCapitalPerStock, paperMaxLossPerTrade)
+	for _, check := range compliance.NewChecks(cfg.Compliance) {
+		trades.AddPreTradeCheck(check)
+	}
+
+	risk := monitor.NewRiskManager(paperMaxDailyLoss, paperMaxLossPerTrade, trades)
+	risk.SetAccount(cfg.Compliance)
+	risk.SetTargetVolatility(cfg.Sizing.TargetVolatility)
+	risk.SetHoldingPolicy(cfg.Holding)
+	trades.AddPreTradeCheck(risk.BuyingPowerCheck())
+	trades.SetRiskSizer(risk)
+	return trades, risk
+}
+
+// followSignal opens a paper position at the target and stop of a published
+// BUY signal, priced at the latest quote of its symbol
+func followSignal(trades *execution.TradeManager, quotes *data.MarketWatcher, s *signal.Signal) {
+	if s.Type != signal.BUY || s.Test {
+		return
+	}
+	stock, ok := quotes.GetStock(s.Symbol)
+	if !ok {
+		log.Printf("Not following %s: no quote for %s", s.ID, s.Symbol)
+		return
+	}
+
+	decision := &strategy.TradeDecision{
+		Symbol:      s.Symbol,
+		Signal:      strategy.Buy,
+		TargetPrice: s.TargetPrice,
+		StopPrice:   s.StopLoss,
+		Rationale:   s.ID,
+		SignalID:    s.ID,
+	}
+	if _, err := trades.ExecuteTrade(decision, stock); err != nil {
+		log.Printf("Not following %s: %v", s.ID, err)
+	}
+}
+
+// managePaperTrades exits paper positions at their stops and targets as
+// quotes come in, and closes them all once the daily loss limit is reached
+func managePaperTrades(trades *execution.TradeManager, risk *monitor.RiskManager, quotes *data.MarketWatcher) {
+	quotes.OnUpdate(func(stock *data.Stock) {
+		stocks := map[string]*data.Stock{stock.Symbol: stock}
+		exits := append(trades.CheckStopLoss(stocks), trades.CheckTargets(stocks)...)
+
+		all := make(map[string]*data.Stock)
+		for _, quote := range quotes.GetAllStocks() {
+			all[quote.Symbol] = quote
+		}
+		if hit, pnl := risk.CheckDailyLoss(all); hit && len(trades.GetActiveTrades()) > 0 {
+			log.Printf("Daily loss limit reached at $%.2f, closing all paper positions", pnl)
+			exits = append(exits, trades.CloseAllPositions(all)...)
+		}
+
+		for _, exit := range exits {
+			if position, ok := trades.GetTrade(exit.PositionID); ok {
+				risk.UpdateDailyPnL(position, exit)
+			}
+		}
+	})
+}
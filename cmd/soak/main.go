@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/soak"
+)
+
+func main() {
+	duration := flag.Duration("duration", 2*time.Hour, "How long the soak test runs")
+	symbols := flag.Int("symbols", 50, "Synthetic symbols on the watchlist")
+	failureRate := flag.Float64("failure-rate", 0.05, "Probability (0-1) of an injected failure per data fetch, LLM call and market check")
+	seed := flag.Int64("seed", 1, "Random seed for the synthetic data and the chaos")
+	out := flag.String("out", "./test_results/soak.json", "Where the soak results are written as JSON")
+	flag.Parse()
+
+	log.Printf("Soaking %d symbols for %s with a %.0f%% failure rate", *symbols, *duration, *failureRate*100)
+	result, err := soak.Run(soak.Config{Duration: *duration, Symbols: *symbols, FailureRate: *failureRate, Seed: *seed})
+	if err != nil {
+		log.Fatalf("Error running soak test: %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(*out), 0755); err != nil {
+		log.Fatalf("Error creating results directory: %v", err)
+	}
+	encoded, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		log.Fatalf("Error encoding soak results: %v", err)
+	}
+	if err := os.WriteFile(*out, encoded, 0644); err != nil {
+		log.Fatalf("Error writing soak results: %v", err)
+	}
+
+	fmt.Printf("Soak test: %d checks over %d sessions in %s\n", result.Checks, result.Sessions, result.Elapsed.Round(time.Second))
+	fmt.Printf("  injected %d provider failures, %d database outages, %d LLM timeouts\n",
+		result.Injected.ProviderFailures, result.Injected.DatabaseOutages, result.Injected.LLMTimeouts)
+	fmt.Printf("  %d failed checks, %d signals, %d outcomes\n", result.FailedChecks, result.Signals, result.Outcomes)
+	fmt.Printf("  goroutines %d at start, %d at most, %d at end\n", result.GoroutinesStart, result.GoroutinesMax, result.GoroutinesEnd)
+
+	violations := result.Check()
+	for _, violation := range violations {
+		fmt.Printf("    %s\n", violation)
+	}
+	fmt.Printf("Results written to %s\n", *out)
+	if len(violations) > 0 {
+		os.Exit(1)
+	}
+}
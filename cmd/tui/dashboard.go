@@ -0,0 +1,248 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/client"
+	"github.com/hustler/trading-bot/pkg/follower"
+	"github.com/hustler/trading-bot/pkg/monitor"
+	"github.com/hustler/trading-bot/pkg/performance"
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// Colors
+const (
+	bold  = "\033[1m"
+	dim   = "\033[2m"
+	red   = "\033[31m"
+	green = "\033[32m"
+	reset = "\033[0m"
+)
+
+// maxActiveSignals is the number of active signals fetched for display
+const maxActiveSignals = 20
+
+// snapshot is everything shown on one screen. A section that failed to load
+// keeps its error so the rest of the dashboard still renders.
+type snapshot struct {
+	watchlist    []monitor.HeatmapCell
+	watchlistErr error
+	signals      []*signal.Signal
+	signalsErr   error
+	follower     *client.FollowerReport
+	followerErr  error
+	metrics      *performance.Metrics
+	metricsErr   error
+	logs         []string
+	logsErr      error
+}
+
+// dashboard fetches and draws the terminal dashboard
+type dashboard struct {
+	client   *client.Client
+	logLines int
+	width    int
+}
+
+// newDashboard creates a dashboard reading from c
+func newDashboard(c *client.Client, logLines, width int) *dashboard {
+	return &dashboard{client: c, logLines: logLines, width: width}
+}
+
+// fetch loads every section from the API
+func (d *dashboard) fetch(ctx context.Context) snapshot {
+	var s snapshot
+	s.watchlist, s.watchlistErr = d.client.Heatmap(ctx)
+	page, err := d.client.Signals(ctx, signal.Query{Status: signal.StatusActive, Limit: maxActiveSignals})
+	if err == nil {
+		s.signals = page.Signals
+	}
+	s.signalsErr = err
+	s.follower, s.followerErr = d.client.Follower(ctx)
+	s.metrics, s.metricsErr = d.client.Performance(ctx)
+	if d.logLines > 0 {
+		s.logs, s.logsErr = d.client.Logs(ctx, d.logLines)
+	}
+	return s
+}
+
+// render draws a snapshot taken at now
+func (d *dashboard) render(s snapshot, now time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%sHUSTLER%s  %s%s  Ctrl-C to quit%s\n", bold, reset, dim, now.Format("2006-01-02 15:04:05"), reset)
+
+	d.section(&b, "P&L")
+	d.line(&b, pnlLine(s.metrics, s.metricsErr))
+	if s.follower != nil {
+		sum := s.follower.Summary
+		d.line(&b, fmt.Sprintf("Follower  total ROI %s  win rate %.0f%%  open %d  closed %d",
+			colorPercent(sum.TotalROI), sum.WinRate, sum.Open, sum.Closed))
+	}
+
+	d.section(&b, "WATCHLIST")
+	if s.watchlistErr != nil {
+		d.line(&b, unavailable(s.watchlistErr))
+	} else {
+		watchlist := append([]monitor.HeatmapCell(nil), s.watchlist...)
+		sort.Slice(watchlist, func(i, j int) bool { return watchlist[i].Symbol < watchlist[j].Symbol })
+		d.line(&b, dim+fmt.Sprintf("%-8s %10s %8s %8s  %s", "SYMBOL", "PRICE", "CHANGE", "REL VOL", "SIGNAL")+reset)
+		for _, cell := range watchlist {
+			d.line(&b, fmt.Sprintf("%-8s %10.2f %s %7.0f%%  %s", cell.Symbol, cell.Price,
+				padColor(colorPercent(cell.ChangePercent), 8), cell.RelativeVolume, cell.SignalType))
+		}
+	}
+
+	d.section(&b, "ACTIVE SIGNALS")
+	switch {
+	case s.signalsErr != nil:
+		d.line(&b, unavailable(s.signalsErr))
+	case len(s.signals) == 0:
+		d.line(&b, dim+"none"+reset)
+	default:
+		d.line(&b, dim+fmt.Sprintf("%-8s %-4s %10s %10s %10s %5s %8s", "SYMBOL", "TYPE", "ENTRY", "TARGET", "STOP", "CONF", "AGE")+reset)
+		for _, sig := range s.signals {
+			d.line(&b, fmt.Sprintf("%-8s %-4s %10.2f %10.2f %10.2f %4.0f%% %8s", sig.Symbol, sig.Type,
+				sig.Price, sig.TargetPrice, sig.StopLoss, sig.Confidence*100, age(now, sig.GeneratedAt)))
+		}
+	}
+
+	d.section(&b, "OPEN POSITIONS")
+	switch {
+	case s.followerErr != nil:
+		d.line(&b, unavailable(s.followerErr))
+	default:
+		open := openPositions(s.follower.Positions)
+		if len(open) == 0 {
+			d.line(&b, dim+"none"+reset)
+		}
+		for _, p := range open {
+			d.line(&b, fmt.Sprintf("%-8s %-4s %-8s entry %10.2f  %s", p.Symbol, p.Type, p.State, p.EntryPrice, age(now, p.EnteredAt)))
+		}
+	}
+
+	if d.logLines > 0 {
+		d.section(&b, "LOGS")
+		if s.logsErr != nil {
+			d.line(&b, unavailable(s.logsErr))
+		}
+		for _, line := range s.logs {
+			d.line(&b, dim+line+reset)
+		}
+	}
+	return b.String()
+}
+
+// section starts a section
+func (d *dashboard) section(b *strings.Builder, title string) {
+	fmt.Fprintf(b, "\n%s%s%s\n", bold, title, reset)
+}
+
+// line writes a line cut to the terminal width
+func (d *dashboard) line(b *strings.Builder, text string) {
+	b.WriteString(truncate(text, d.width))
+	b.WriteString(reset + "\n")
+}
+
+// pnlLine summarizes signal performance
+func pnlLine(m *performance.Metrics, err error) string {
+	if err != nil {
+		return unavailable(err)
+	}
+	return fmt.Sprintf("Signals   %d  success rate %.0f%%  avg ROI %s  total profit %s",
+		m.SignalsCount, m.SuccessRate, colorPercent(m.AverageROI), colorMoney(m.TotalProfit))
+}
+
+// openPositions returns the positions the follower holds
+func openPositions(positions []follower.Position) []follower.Position {
+	var open []follower.Position
+	for _, p := range positions {
+		if !p.EnteredAt.IsZero() && p.ExitedAt.IsZero() {
+			open = append(open, p)
+		}
+	}
+	return open
+}
+
+// unavailable describes a section that failed to load
+func unavailable(err error) string {
+	return red + "unavailable: " + err.Error() + reset
+}
+
+// age formats how long ago t was
+func age(now, t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return now.Sub(t).Round(time.Minute).String()
+}
+
+// colorPercent formats a percentage, green when positive and red when negative
+func colorPercent(v float64) string {
+	return colorSign(v, fmt.Sprintf("%+.2f%%", v))
+}
+
+// colorMoney formats an amount, green when positive and red when negative
+func colorMoney(v float64) string {
+	return colorSign(v, fmt.Sprintf("$%.2f", v))
+}
+
+func colorSign(v float64, text string) string {
+	switch {
+	case v > 0:
+		return green + text + reset
+	case v < 0:
+		return red + text + reset
+	}
+	return text
+}
+
+// padColor right-aligns colored text to width visible characters
+func padColor(text string, width int) string {
+	if n := visibleLen(text); n < width {
+		return strings.Repeat(" ", width-n) + text
+	}
+	return text
+}
+
+// truncate cuts text to width visible characters, keeping color codes intact
+func truncate(text string, width int) string {
+	var b strings.Builder
+	visible := 0
+	inEscape := false
+	for _, r := range text {
+		if r == '\033' {
+			inEscape = true
+		}
+		if inEscape {
+			b.WriteRune(r)
+			inEscape = r != 'm'
+			continue
+		}
+		if visible < width {
+			b.WriteRune(r)
+			visible++
+		}
+	}
+	return b.String()
+}
+
+// visibleLen counts the characters of text that are not color codes
+func visibleLen(text string) int {
+	n := 0
+	inEscape := false
+	for _, r := range text {
+		if r == '\033' {
+			inEscape = true
+		}
+		if inEscape {
+			inEscape = r != 'm'
+			continue
+		}
+		n++
+	}
+	return n
+}
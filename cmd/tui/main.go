@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	ossignal "os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/client"
+)
+
+// Terminal control sequences
+const (
+	hideCursor  = "\033[?25l"
+	showCursor  = "\033[?25h"
+	clearScreen = "\033[H\033[2J"
+)
+
+func main() {
+	apiURL := flag.String("url", envOr("HUSTLER_API_URL", "http://localhost:8080"), "Base URL of the Hustler API")
+	username := flag.String("user", os.Getenv("HUSTLER_API_USER"), "API username")
+	password := flag.String("password", os.Getenv("HUSTLER_API_PASSWORD"), "API password")
+	token := flag.String("token", os.Getenv("HUSTLER_API_TOKEN"), "API token, instead of a username and password")
+	refresh := flag.Duration("refresh", 5*time.Second, "How often the dashboard is refreshed")
+	logLines := flag.Int("logs", 10, "Recent log lines shown")
+	flag.Parse()
+
+	ctx, stop := ossignal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	c := client.NewClient(*apiURL)
+	c.SetRetry(1, 0) // The next refresh is the retry
+	if *token != "" {
+		c.SetToken(*token)
+	} else if *username != "" {
+		if err := c.Login(ctx, *username, *password); err != nil {
+			log.Fatalf("Failed to log in to %s: %v", *apiURL, err)
+		}
+	} else {
+		log.Fatal("Set -token or -user and -password (or HUSTLER_API_TOKEN, HUSTLER_API_USER and HUSTLER_API_PASSWORD)")
+	}
+
+	// Redraw as soon as a signal changes rather than at the next refresh
+	changed := make(chan struct{}, 1)
+	go func() {
+		err := c.SubscribeSignals(ctx, func(client.SignalEvent) {
+			select {
+			case changed <- struct{}{}:
+			default:
+			}
+		})
+		if err != nil && ctx.Err() == nil {
+			log.Printf("Signal stream stopped: %v", err)
+		}
+	}()
+
+	dashboard := newDashboard(c, *logLines, terminalWidth())
+	fmt.Print(hideCursor)
+	defer fmt.Print(showCursor)
+
+	ticker := time.NewTicker(*refresh)
+	defer ticker.Stop()
+	for {
+		snapshot := dashboard.fetch(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		fmt.Print(clearScreen + dashboard.render(snapshot, time.Now()))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-changed:
+		}
+	}
+}
+
+// terminalWidth returns the width lines are cut to, from $COLUMNS
+func terminalWidth() int {
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	return 120
+}
+
+// envOr returns an environment variable, or fallback if it is unset
+func envOr(name, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
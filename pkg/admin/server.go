@@ -9,17 +9,15 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
-	"sync"
 
 	"github.com/hustler/trading-bot/pkg/config"
 )
 
 // Server represents the admin web interface server
 type Server struct {
-	config     *config.Config
+	config     *config.Shared
 	configPath string
 	templates  *template.Template
-	mu         sync.RWMutex
 }
 
 // NewServer creates a new admin server
@@ -31,10 +29,9 @@ func NewServer(cfg *config.Config, configPath string, templatesDir string) (*Ser
 	}
 
 	return &Server{
-		config:     cfg,
+		config:     config.NewShared(cfg),
 		configPath: configPath,
 		templates:  templates,
-		mu:         sync.RWMutex{},
 	}, nil
 }
 
@@ -56,7 +53,7 @@ func (s *Server) Start() error {
 	http.Handle("/static/", http.StripPrefix("/static/", fs))
 
 	// Start server
-	addr := fmt.Sprintf(":%d", s.config.Admin.Port)
+	addr := fmt.Sprintf(":%d", s.config.Get().Admin.Port)
 	log.Printf("Starting admin server on %s", addr)
 	return http.ListenAndServe(addr, nil)
 }
@@ -91,10 +88,9 @@ func (s *Server) handleLogin(w http.ResponseWriter, r *http.Request) {
 		username := r.FormValue("username")
 		password := r.FormValue("password")
 
-		s.mu.RLock()
-		validUsername := s.config.Admin.Username
-		validPassword := s.config.Admin.Password
-		s.mu.RUnlock()
+		admin := s.config.Get().Admin
+		validUsername := admin.Username
+		validPassword := admin.Password
 
 		if username == validUsername && password == validPassword {
 			// Set authentication cookie
@@ -138,9 +134,7 @@ func (s *Server) handleLogout(w http.ResponseWriter, r *http.Request) {
 
 // handleDashboard handles the dashboard page
 func (s *Server) handleDashboard(w http.ResponseWriter, r *http.Request) {
-	s.mu.RLock()
-	cfg := s.config
-	s.mu.RUnlock()
+	cfg := s.config.Get()
 
 	// Render dashboard template
 	s.templates.ExecuteTemplate(w, "dashboard.html", map[string]interface{}{
@@ -151,9 +145,7 @@ func (s *Server) handleDashboard(w http.ResponseWriter, r *http.Request) {
 
 // handleStocks handles the stocks management page
 func (s *Server) handleStocks(w http.ResponseWriter, r *http.Request) {
-	s.mu.RLock()
-	cfg := s.config
-	s.mu.RUnlock()
+	cfg := s.config.Get()
 
 	// Render stocks template
 	s.templates.ExecuteTemplate(w, "stocks.html", map[string]interface{}{
@@ -164,9 +156,7 @@ func (s *Server) handleStocks(w http.ResponseWriter, r *http.Request) {
 
 // handleSettings handles the settings page
 func (s *Server) handleSettings(w http.ResponseWriter, r *http.Request) {
-	s.mu.RLock()
-	cfg := s.config
-	s.mu.RUnlock()
+	cfg := s.config.Get()
 
 	// Render settings template
 	s.templates.ExecuteTemplate(w, "settings.html", map[string]interface{}{
@@ -181,9 +171,7 @@ func (s *Server) handleAPIConfig(w http.ResponseWriter, r *http.Request) {
 
 	if r.Method == http.MethodGet {
 		// Return current configuration
-		s.mu.RLock()
-		cfg := s.config
-		s.mu.RUnlock()
+		cfg := s.config.Get()
 
 		json.NewEncoder(w).Encode(cfg)
 		return
@@ -198,18 +186,13 @@ func (s *Server) handleAPIConfig(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		// Validate configuration
-		err = config.ValidateConfig(&newConfig)
+		// Validate and update configuration
+		err = s.config.Set(&newConfig)
 		if err != nil {
 			http.Error(w, fmt.Sprintf("Invalid configuration: %v", err), http.StatusBadRequest)
 			return
 		}
 
-		// Update configuration
-		s.mu.Lock()
-		s.config = &newConfig
-		s.mu.Unlock()
-
 		// Save configuration to file
 		err = config.SaveConfig(&newConfig, s.configPath)
 		if err != nil {
@@ -233,9 +216,7 @@ func (s *Server) handleAPIStocks(w http.ResponseWriter, r *http.Request) {
 
 	if r.Method == http.MethodGet {
 		// Return current stocks
-		s.mu.RLock()
-		stocks := s.config.StockSymbols
-		s.mu.RUnlock()
+		stocks := s.config.Get().StockSymbols
 
 		json.NewEncoder(w).Encode(stocks)
 		return
@@ -260,12 +241,16 @@ func (s *Server) handleAPIStocks(w http.ResponseWriter, r *http.Request) {
 		}
 
 		// Update configuration
-		s.mu.Lock()
-		s.config.StockSymbols = stocks
-		s.mu.Unlock()
+		updated, err := s.config.Update(func(cfg *config.Config) {
+			cfg.StockSymbols = stocks
+		})
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Invalid configuration: %v", err), http.StatusBadRequest)
+			return
+		}
 
 		// Save configuration to file
-		err = config.SaveConfig(s.config, s.configPath)
+		err = config.SaveConfig(updated, s.configPath)
 		if err != nil {
 			http.Error(w, fmt.Sprintf("Failed to save configuration: %v", err), http.StatusInternalServerError)
 			return
@@ -371,16 +356,18 @@ func (s *Server) handleAPIPerformance(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(performance)
 }
 
-// UpdateConfig updates the server configuration
-func (s *Server) UpdateConfig(cfg *config.Config) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	s.config = cfg
+// UpdateConfig updates the server configuration to a copy of cfg if it is valid
+func (s *Server) UpdateConfig(cfg *config.Config) error {
+	return s.config.Set(cfg)
 }
 
-// GetConfig returns the current server configuration
+// GetConfig returns a copy of the current server configuration
 func (s *Server) GetConfig() *config.Config {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-	return s.config
+	return s.config.Get()
+}
+
+// OnConfigChange registers a callback invoked with a copy of the configuration
+// after it is changed through the admin interface, e.g. to update the monitor
+func (s *Server) OnConfigChange(fn func(cfg *config.Config)) {
+	s.config.OnChange(fn)
 }
@@ -0,0 +1,241 @@
+package alerts
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/indicators"
+)
+
+// maxHistory is the number of prices kept per symbol for indicator rules
+const maxHistory = 500
+
+// Trigger represents a rule that fired
+type Trigger struct {
+	Rule        Rule      `json:"rule"`
+	Observed    float64   `json:"observed"`
+	Price       float64   `json:"price"`
+	TriggeredAt time.Time `json:"triggered_at"`
+}
+
+// Notifier delivers triggered alerts through a channel (e.g. telegram.Bot)
+type Notifier interface {
+	SendAlert(t Trigger) error
+}
+
+// ruleState tracks a rule's previous evaluation so alerts fire on transitions only
+type ruleState struct {
+	rule    *Rule
+	lastMet bool
+	seen    bool
+}
+
+// Engine evaluates user-defined alert rules on every data update
+type Engine struct {
+	rules     map[string]*ruleState
+	history   map[string][]float64
+	notifiers []Notifier
+	nextID    int
+	now       func() time.Time
+	mu        sync.Mutex
+}
+
+// NewEngine creates a new alert rules engine
+func NewEngine() *Engine {
+	return &Engine{
+		rules:   make(map[string]*ruleState),
+		history: make(map[string][]float64),
+		now:     time.Now,
+	}
+}
+
+// AddNotifier adds a channel that triggered alerts are sent through
+func (e *Engine) AddNotifier(notifier Notifier) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.notifiers = append(e.notifiers, notifier)
+}
+
+// Attach evaluates rules on every MarketWatcher update
+func (e *Engine) Attach(watcher *data.MarketWatcher) {
+	watcher.OnUpdate(func(stock *data.Stock) {
+		e.Evaluate(stock)
+	})
+}
+
+// AddRule parses an expression and adds it as a rule owned by userID
+func (e *Engine) AddRule(userID int64, expression string) (*Rule, error) {
+	rule, err := ParseRule(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.nextID++
+	rule.ID = fmt.Sprintf("ALR-%d", e.nextID)
+	rule.UserID = userID
+	rule.CreatedAt = e.now()
+	e.rules[rule.ID] = &ruleState{rule: rule}
+
+	result := *rule
+	return &result, nil
+}
+
+// RemoveRule removes a rule. A userID of 0 may remove any rule.
+func (e *Engine) RemoveRule(id string, userID int64) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	state, ok := e.rules[id]
+	if !ok || (userID != 0 && state.rule.UserID != userID) {
+		return fmt.Errorf("alert not found: %s", id)
+	}
+
+	delete(e.rules, id)
+	return nil
+}
+
+// ListRules returns the rules owned by userID, or all rules if userID is 0
+func (e *Engine) ListRules(userID int64) []Rule {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	rules := make([]Rule, 0, len(e.rules))
+	for _, state := range e.rules {
+		if userID == 0 || state.rule.UserID == userID {
+			rules = append(rules, *state.rule)
+		}
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].CreatedAt.Before(rules[j].CreatedAt) || (rules[i].CreatedAt.Equal(rules[j].CreatedAt) && rules[i].ID < rules[j].ID)
+	})
+
+	return rules
+}
+
+// ExportSubscriber returns the rules owned by userID
+func (e *Engine) ExportSubscriber(userID int64) (interface{}, error) {
+	if userID == 0 {
+		return nil, fmt.Errorf("user ID is required")
+	}
+	return e.ListRules(userID), nil
+}
+
+// DeleteSubscriber removes every rule owned by userID
+func (e *Engine) DeleteSubscriber(userID int64) error {
+	if userID == 0 {
+		return fmt.Errorf("user ID is required")
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for id, state := range e.rules {
+		if state.rule.UserID == userID {
+			delete(e.rules, id)
+		}
+	}
+	return nil
+}
+
+// Evaluate checks every rule for the stock's symbol, notifies for rules that fired and returns them
+func (e *Engine) Evaluate(stock *data.Stock) []Trigger {
+	e.mu.Lock()
+	history := append(e.history[stock.Symbol], stock.CurrentPrice)
+	if len(history) > maxHistory {
+		history = history[len(history)-maxHistory:]
+	}
+	e.history[stock.Symbol] = history
+
+	now := e.now()
+	var triggers []Trigger
+	for _, state := range e.rules {
+		rule := state.rule
+		if rule.Symbol != stock.Symbol {
+			continue
+		}
+
+		value, ok := fieldValue(rule, stock, history)
+		if !ok {
+			continue
+		}
+
+		met := rule.conditionMet(value)
+		// Fire on the transition into the condition; crosses also need a prior observation
+		fire := met && !state.lastMet && (state.seen || !rule.isCross())
+		state.lastMet = met
+		state.seen = true
+
+		if fire {
+			rule.LastTriggered = now
+			triggers = append(triggers, Trigger{
+				Rule:        *rule,
+				Observed:    value,
+				Price:       stock.CurrentPrice,
+				TriggeredAt: now,
+			})
+		}
+	}
+	notifiers := make([]Notifier, len(e.notifiers))
+	copy(notifiers, e.notifiers)
+	e.mu.Unlock()
+
+	for _, trigger := range triggers {
+		for _, notifier := range notifiers {
+			if err := notifier.SendAlert(trigger); err != nil {
+				log.Printf("Error sending alert %s: %v", trigger.Rule.ID, err)
+			}
+		}
+	}
+
+	return triggers
+}
+
+// fieldValue returns the value of the rule's field, or false if it is not available yet
+func fieldValue(rule *Rule, stock *data.Stock, history []float64) (float64, bool) {
+	switch rule.Field {
+	case "price":
+		return stock.CurrentPrice, true
+	case "change_percent":
+		return stock.ChangePercent, true
+	case "volume":
+		return float64(stock.Volume), true
+	case "rsi":
+		if len(history) < rule.Period+1 {
+			return 0, false
+		}
+		return indicators.RelativeStrength(history, rule.Period), true
+	case "sma":
+		if len(history) < rule.Period {
+			return 0, false
+		}
+		return indicators.SimpleAverage(history, rule.Period), true
+	case "ema":
+		if len(history) < rule.Period {
+			return 0, false
+		}
+		return indicators.ExponentialAverage(history, rule.Period), true
+	default:
+		return 0, false
+	}
+}
+
+// FormatAlertMessage formats a triggered alert for delivery
+func FormatAlertMessage(t Trigger) string {
+	message := fmt.Sprintf("🔔 <b>ALERT: %s</b>\n\n", t.Rule.Symbol)
+	message += fmt.Sprintf("Rule: %s\n", t.Rule.Describe())
+	if t.Rule.Field != "price" {
+		message += fmt.Sprintf("Observed: %.2f\n", t.Observed)
+	}
+	message += fmt.Sprintf("Price: $%.2f\n", t.Price)
+	message += fmt.Sprintf("Alert ID: %s\n\n", t.Rule.ID)
+	message += fmt.Sprintf("⏰ Triggered at: %s", t.TriggeredAt.Format("2006-01-02 15:04:05"))
+	return message
+}
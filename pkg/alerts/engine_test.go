@@ -0,0 +1,106 @@
+package alerts
+
+import (
+	"testing"
+
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingNotifier records triggered alerts for tests
+type recordingNotifier struct {
+	triggers []Trigger
+}
+
+func (n *recordingNotifier) SendAlert(t Trigger) error {
+	n.triggers = append(n.triggers, t)
+	return nil
+}
+
+func TestParseRule(t *testing.T) {
+	rule, err := ParseRule("aapl crosses above 200")
+	assert.NoError(t, err)
+	assert.Equal(t, "AAPL", rule.Symbol)
+	assert.Equal(t, "price", rule.Field)
+	assert.Equal(t, CrossesAbove, rule.Operator)
+	assert.Equal(t, 200.0, rule.Value)
+
+	rule, err = ParseRule("RSI(TSLA) < 20")
+	assert.NoError(t, err)
+	assert.Equal(t, "TSLA", rule.Symbol)
+	assert.Equal(t, "rsi", rule.Field)
+	assert.Equal(t, 14, rule.Period)
+	assert.Equal(t, Below, rule.Operator)
+	assert.Equal(t, "RSI(TSLA, 14) < 20", rule.Describe())
+
+	rule, err = ParseRule("SMA(TD.TO, 50) >= 80.5")
+	assert.NoError(t, err)
+	assert.Equal(t, "TD.TO", rule.Symbol)
+	assert.Equal(t, 50, rule.Period)
+	assert.Equal(t, AtOrAbove, rule.Operator)
+	assert.Equal(t, 80.5, rule.Value)
+
+	_, err = ParseRule("MACD(AAPL) > 1")
+	assert.Error(t, err)
+
+	_, err = ParseRule("buy AAPL now")
+	assert.Error(t, err)
+}
+
+func TestEngineCrossesFiresOnTransition(t *testing.T) {
+	engine := NewEngine()
+	notifier := &recordingNotifier{}
+	engine.AddNotifier(notifier)
+
+	rule, err := engine.AddRule(42, "AAPL crosses above 200")
+	assert.NoError(t, err)
+
+	// Already above on the first observation: a cross needs a prior value below
+	assert.Empty(t, engine.Evaluate(&data.Stock{Symbol: "AAPL", CurrentPrice: 201}))
+	assert.Empty(t, engine.Evaluate(&data.Stock{Symbol: "AAPL", CurrentPrice: 199}))
+
+	triggers := engine.Evaluate(&data.Stock{Symbol: "AAPL", CurrentPrice: 202})
+	assert.Len(t, triggers, 1)
+	assert.Equal(t, rule.ID, triggers[0].Rule.ID)
+	assert.Equal(t, int64(42), triggers[0].Rule.UserID)
+
+	// Staying above does not fire again
+	assert.Empty(t, engine.Evaluate(&data.Stock{Symbol: "AAPL", CurrentPrice: 203}))
+	// Other symbols are ignored
+	assert.Empty(t, engine.Evaluate(&data.Stock{Symbol: "MSFT", CurrentPrice: 150}))
+
+	assert.Len(t, notifier.triggers, 1)
+	assert.Contains(t, FormatAlertMessage(notifier.triggers[0]), "AAPL crosses above 200")
+}
+
+func TestEngineIndicatorRule(t *testing.T) {
+	engine := NewEngine()
+	_, err := engine.AddRule(7, "RSI(TSLA, 3) < 20")
+	assert.NoError(t, err)
+
+	// Not enough history yet
+	assert.Empty(t, engine.Evaluate(&data.Stock{Symbol: "TSLA", CurrentPrice: 100}))
+	assert.Empty(t, engine.Evaluate(&data.Stock{Symbol: "TSLA", CurrentPrice: 99}))
+	assert.Empty(t, engine.Evaluate(&data.Stock{Symbol: "TSLA", CurrentPrice: 98}))
+
+	// Three straight losses give an RSI of 0, below the threshold on first evaluation
+	triggers := engine.Evaluate(&data.Stock{Symbol: "TSLA", CurrentPrice: 97})
+	assert.Len(t, triggers, 1)
+	assert.Equal(t, 0.0, triggers[0].Observed)
+}
+
+func TestEngineRulesAreScopedToUser(t *testing.T) {
+	engine := NewEngine()
+	first, err := engine.AddRule(1, "AAPL > 200")
+	assert.NoError(t, err)
+	_, err = engine.AddRule(2, "MSFT < 300")
+	assert.NoError(t, err)
+
+	assert.Len(t, engine.ListRules(1), 1)
+	assert.Len(t, engine.ListRules(0), 2)
+
+	// Users cannot remove each other's rules
+	assert.Error(t, engine.RemoveRule(first.ID, 2))
+	assert.NoError(t, engine.RemoveRule(first.ID, 1))
+	assert.Empty(t, engine.ListRules(1))
+}
@@ -0,0 +1,136 @@
+package alerts
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Operator represents how a rule compares a value against its threshold
+type Operator string
+
+const (
+	// Above fires when the value rises above the threshold
+	Above Operator = ">"
+	// Below fires when the value falls below the threshold
+	Below Operator = "<"
+	// AtOrAbove fires when the value reaches or rises above the threshold
+	AtOrAbove Operator = ">="
+	// AtOrBelow fires when the value reaches or falls below the threshold
+	AtOrBelow Operator = "<="
+	// CrossesAbove fires when the value moves from below to above the threshold
+	CrossesAbove Operator = "crosses above"
+	// CrossesBelow fires when the value moves from above to below the threshold
+	CrossesBelow Operator = "crosses below"
+)
+
+// Rule represents a user-defined alert condition
+type Rule struct {
+	ID            string    `json:"id"`
+	UserID        int64     `json:"user_id"`
+	Symbol        string    `json:"symbol"`
+	Field         string    `json:"field"`  // price, change_percent, volume, rsi, sma, ema
+	Period        int       `json:"period"` // Indicator period in updates (rsi, sma, ema)
+	Operator      Operator  `json:"operator"`
+	Value         float64   `json:"value"`
+	Expression    string    `json:"expression"`
+	CreatedAt     time.Time `json:"created_at"`
+	LastTriggered time.Time `json:"last_triggered,omitempty"`
+}
+
+// defaultPeriods are used when an indicator rule does not specify a period
+var defaultPeriods = map[string]int{
+	"rsi": 14,
+	"sma": 20,
+	"ema": 20,
+}
+
+// rulePattern matches "AAPL crosses above 200", "RSI(TSLA) < 20" and "SMA(AAPL, 50) > 180"
+var rulePattern = regexp.MustCompile(`(?i)^\s*(?:([a-z_]+)\(\s*([a-z0-9.\-]+)\s*(?:,\s*(\d+)\s*)?\)|([a-z0-9.\-]+))\s+(crosses\s+above|crosses\s+below|>=|<=|>|<|above|below)\s+(-?\d+(?:\.\d+)?)\s*$`)
+
+// ParseRule parses an alert expression into a rule
+func ParseRule(expression string) (*Rule, error) {
+	match := rulePattern.FindStringSubmatch(expression)
+	if match == nil {
+		return nil, fmt.Errorf("invalid alert expression %q, expected e.g. \"AAPL crosses above 200\" or \"RSI(TSLA) < 20\"", expression)
+	}
+
+	rule := &Rule{
+		Field:      "price",
+		Expression: strings.TrimSpace(expression),
+	}
+
+	if match[1] != "" {
+		rule.Field = strings.ToLower(match[1])
+		rule.Symbol = strings.ToUpper(match[2])
+		if match[3] != "" {
+			rule.Period, _ = strconv.Atoi(match[3])
+		}
+	} else {
+		rule.Symbol = strings.ToUpper(match[4])
+	}
+
+	switch rule.Field {
+	case "price", "change_percent", "volume":
+		rule.Period = 0
+	case "rsi", "sma", "ema":
+		if rule.Period == 0 {
+			rule.Period = defaultPeriods[rule.Field]
+		}
+	default:
+		return nil, fmt.Errorf("unsupported alert field: %s", rule.Field)
+	}
+
+	switch op := strings.Join(strings.Fields(strings.ToLower(match[5])), " "); op {
+	case "above":
+		rule.Operator = Above
+	case "below":
+		rule.Operator = Below
+	default:
+		rule.Operator = Operator(op)
+	}
+
+	value, err := strconv.ParseFloat(match[6], 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid alert threshold: %w", err)
+	}
+	rule.Value = value
+
+	return rule, nil
+}
+
+// conditionMet reports whether value satisfies the rule's level condition
+func (r *Rule) conditionMet(value float64) bool {
+	switch r.Operator {
+	case Above, CrossesAbove:
+		return value > r.Value
+	case Below, CrossesBelow:
+		return value < r.Value
+	case AtOrAbove:
+		return value >= r.Value
+	case AtOrBelow:
+		return value <= r.Value
+	default:
+		return false
+	}
+}
+
+// isCross reports whether the rule requires a prior observation on the other side of the threshold
+func (r *Rule) isCross() bool {
+	return r.Operator == CrossesAbove || r.Operator == CrossesBelow
+}
+
+// Describe returns a human-readable description of the rule
+func (r *Rule) Describe() string {
+	subject := r.Symbol
+	switch r.Field {
+	case "price":
+	case "rsi", "sma", "ema":
+		subject = fmt.Sprintf("%s(%s, %d)", strings.ToUpper(r.Field), r.Symbol, r.Period)
+	default:
+		subject = fmt.Sprintf("%s(%s)", r.Field, r.Symbol)
+	}
+	return fmt.Sprintf("%s %s %g", subject, r.Operator, r.Value)
+}
@@ -0,0 +1,196 @@
+package anomaly
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+)
+
+// Kind represents the series an anomaly was found in
+type Kind string
+
+const (
+	// PriceMove is an unusually large tick-to-tick price change
+	PriceMove Kind = "PRICE"
+	// VolumeSpike is an unusually large volume increase between ticks
+	VolumeSpike Kind = "VOLUME"
+)
+
+// Anomaly represents an unusual print or volume spike
+type Anomaly struct {
+	Symbol     string    `json:"symbol"`
+	Kind       Kind      `json:"kind"`
+	Value      float64   `json:"value"`    // Observed price change (%) or volume increase
+	Expected   float64   `json:"expected"` // EWMA of the series before this observation
+	ZScore     float64   `json:"z_score"`
+	Price      float64   `json:"price"`
+	DetectedAt time.Time `json:"detected_at"`
+}
+
+// Notifier delivers anomaly alerts (implemented by telegram.Bot)
+type Notifier interface {
+	SendAnomaly(a Anomaly) error
+}
+
+// ewma tracks an exponentially weighted mean and variance
+type ewma struct {
+	mean     float64
+	variance float64
+	samples  int
+}
+
+// symbolState holds the per-symbol series statistics
+type symbolState struct {
+	lastPrice  float64
+	lastVolume int64
+	returns    ewma
+	volume     ewma
+}
+
+// Detector flags statistical outliers in price and volume, independent of any strategy
+type Detector struct {
+	config    config.AnomalyConfig
+	symbols   map[string]*symbolState
+	lastAlert map[string]time.Time
+	now       func() time.Time
+	mu        sync.Mutex
+}
+
+// NewDetector creates a new Detector
+func NewDetector(cfg config.AnomalyConfig) *Detector {
+	return &Detector{
+		config:    cfg,
+		symbols:   make(map[string]*symbolState),
+		lastAlert: make(map[string]time.Time),
+		now:       time.Now,
+	}
+}
+
+// Attach runs the detector on every MarketWatcher update and sends anomalies to notifier.
+// It does nothing when anomaly detection is disabled.
+func (d *Detector) Attach(watcher *data.MarketWatcher, notifier Notifier) {
+	if !d.config.Enabled {
+		return
+	}
+
+	watcher.OnUpdate(func(stock *data.Stock) {
+		for _, a := range d.Observe(stock) {
+			if err := notifier.SendAnomaly(a); err != nil {
+				log.Printf("Error sending anomaly alert for %s: %v", a.Symbol, err)
+			}
+		}
+	})
+}
+
+// Observe scores a stock update against its history and returns any anomalies
+func (d *Detector) Observe(stock *data.Stock) []Anomaly {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	state, ok := d.symbols[stock.Symbol]
+	if !ok {
+		d.symbols[stock.Symbol] = &symbolState{lastPrice: stock.CurrentPrice, lastVolume: stock.Volume}
+		return nil
+	}
+
+	var anomalies []Anomaly
+	now := d.now()
+
+	if state.lastPrice > 0 && stock.CurrentPrice > 0 {
+		change := (stock.CurrentPrice - state.lastPrice) / state.lastPrice * 100
+		if a, ok := d.score(&state.returns, stock, PriceMove, change, now); ok {
+			anomalies = append(anomalies, a)
+		}
+	}
+
+	// Volume is cumulative for the day; a drop means a new session started
+	if stock.Volume >= state.lastVolume {
+		increase := float64(stock.Volume - state.lastVolume)
+		if a, ok := d.score(&state.volume, stock, VolumeSpike, increase, now); ok {
+			anomalies = append(anomalies, a)
+		}
+	}
+
+	state.lastPrice = stock.CurrentPrice
+	state.lastVolume = stock.Volume
+
+	return anomalies
+}
+
+// score updates the series with value and reports an anomaly if it is an outlier. Caller must hold the lock.
+func (d *Detector) score(series *ewma, stock *data.Stock, kind Kind, value float64, now time.Time) (Anomaly, bool) {
+	expected := series.mean
+	stdDev := math.Sqrt(series.variance)
+	warm := series.samples >= d.config.WarmupSamples
+
+	d.update(series, value)
+
+	if !warm || stdDev == 0 {
+		return Anomaly{}, false
+	}
+
+	z := (value - expected) / stdDev
+	// Only unusually large volume is interesting; prices can break either way
+	if kind == VolumeSpike && z < 0 {
+		return Anomaly{}, false
+	}
+	if math.Abs(z) < d.config.ZScoreThreshold {
+		return Anomaly{}, false
+	}
+
+	key := stock.Symbol + "/" + string(kind)
+	cooldown := time.Duration(d.config.CooldownMinutes) * time.Minute
+	if last, ok := d.lastAlert[key]; ok && now.Sub(last) < cooldown {
+		return Anomaly{}, false
+	}
+	d.lastAlert[key] = now
+
+	return Anomaly{
+		Symbol:     stock.Symbol,
+		Kind:       kind,
+		Value:      value,
+		Expected:   expected,
+		ZScore:     z,
+		Price:      stock.CurrentPrice,
+		DetectedAt: now,
+	}, true
+}
+
+// update folds value into the EWMA mean and variance
+func (d *Detector) update(series *ewma, value float64) {
+	alpha := d.config.Smoothing
+	if alpha <= 0 || alpha > 1 {
+		alpha = 0.1
+	}
+
+	if series.samples == 0 {
+		series.mean = value
+	} else {
+		diff := value - series.mean
+		series.mean += alpha * diff
+		series.variance = (1 - alpha) * (series.variance + alpha*diff*diff)
+	}
+	series.samples++
+}
+
+// FormatAnomalyMessage formats an anomaly as a heads-up Telegram message
+func FormatAnomalyMessage(a Anomaly) string {
+	message := fmt.Sprintf("👀 <b>HEADS-UP: %s</b>\n\n", a.Symbol)
+
+	switch a.Kind {
+	case PriceMove:
+		message += fmt.Sprintf("Unusual price move of %+.2f%% to $%.2f (%.1fσ)\n", a.Value, a.Price, a.ZScore)
+	case VolumeSpike:
+		message += fmt.Sprintf("Volume spike of %.0f shares vs %.0f typical (%.1fσ) at $%.2f\n", a.Value, a.Expected, a.ZScore, a.Price)
+	}
+
+	message += "\n<i>This is an informational alert, not a trade signal.</i>\n"
+	message += fmt.Sprintf("⏰ Detected at: %s", a.DetectedAt.Format("2006-01-02 15:04:05"))
+
+	return message
+}
@@ -0,0 +1,55 @@
+package anomaly
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectorFlagsOutliers(t *testing.T) {
+	detector := NewDetector(config.AnomalyConfig{
+		Enabled:         true,
+		ZScoreThreshold: 4,
+		Smoothing:       0.1,
+		WarmupSamples:   20,
+		CooldownMinutes: 30,
+	})
+	now := time.Date(2025, 4, 21, 14, 0, 0, 0, time.UTC)
+	detector.now = func() time.Time { return now }
+
+	price, volume := 100.0, int64(0)
+	tick := func(priceMove float64, volumeAdded int64) []Anomaly {
+		price *= 1 + priceMove/100
+		volume += volumeAdded
+		now = now.Add(time.Minute)
+		return detector.Observe(&data.Stock{Symbol: "AAPL", CurrentPrice: price, Volume: volume})
+	}
+
+	// Normal trading builds the baseline without alerts
+	for i := 0; i < 40; i++ {
+		move := 0.05
+		if i%2 == 0 {
+			move = -0.05
+		}
+		assert.Empty(t, tick(move, 10000+int64(i%3)*1000))
+	}
+
+	// A volume spike is flagged on its own
+	anomalies := tick(0.05, 200000)
+	assert.Len(t, anomalies, 1)
+	assert.Equal(t, VolumeSpike, anomalies[0].Kind)
+	assert.Greater(t, anomalies[0].ZScore, 4.0)
+
+	// A sharp drop is flagged as a price move
+	anomalies = tick(-3, 11000)
+	assert.Len(t, anomalies, 1)
+	assert.Equal(t, PriceMove, anomalies[0].Kind)
+	assert.Less(t, anomalies[0].ZScore, -4.0)
+	assert.Contains(t, FormatAnomalyMessage(anomalies[0]), "not a trade signal")
+
+	// A repeat within the cooldown is suppressed
+	assert.Empty(t, tick(0.05, 400000))
+}
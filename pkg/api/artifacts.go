@@ -0,0 +1,49 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hustler/trading-bot/pkg/artifacts"
+)
+
+// ArtifactStore lists stored reports and exports (implemented by artifacts.Uploader)
+type ArtifactStore interface {
+	List(kind string) ([]artifacts.Artifact, error)
+}
+
+// SetArtifactStore sets the store behind the artifacts endpoint
+func (s *Server) SetArtifactStore(store ArtifactStore) {
+	s.artifacts = store
+}
+
+// handleArtifacts lists the stored artifacts of the kind query param,
+// reports by default, newest first with fresh signed URLs to share them
+func (s *Server) handleArtifacts(w http.ResponseWriter, r *http.Request) {
+	if s.artifacts == nil {
+		http.Error(w, "Artifact storage not available", http.StatusServiceUnavailable)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	kind := r.URL.Query().Get("kind")
+	switch kind {
+	case "":
+		kind = artifacts.KindReport
+	case artifacts.KindReport, artifacts.KindExport, artifacts.KindChart:
+	default:
+		http.Error(w, "Unknown artifact kind", http.StatusBadRequest)
+		return
+	}
+
+	list, err := s.artifacts.List(kind)
+	if err != nil {
+		http.Error(w, "Failed to list artifacts", http.StatusBadGateway)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(list)
+}
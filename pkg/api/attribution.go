@@ -0,0 +1,61 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/attribution"
+)
+
+// defaultAttributionDays is the period reported when the query gives no from
+const defaultAttributionDays = 7
+
+// AttributionReporter joins signals with trades and outcomes (implemented by attribution.Reporter)
+type AttributionReporter interface {
+	Report(from, to time.Time) attribution.Report
+}
+
+// SetAttributionReporter sets the reporter served by the attribution endpoint
+func (s *Server) SetAttributionReporter(reporter AttributionReporter) {
+	s.attribution = reporter
+}
+
+// handleAttribution reports whether each signal generated between the query
+// params from and to was executed, its slippage and its actual vs expected ROI.
+// The period defaults to the last seven days.
+func (s *Server) handleAttribution(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.attribution == nil {
+		http.Error(w, "Attribution not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	from, err := parseQueryTime(r.URL.Query().Get("from"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid from: %v", err), http.StatusBadRequest)
+		return
+	}
+	to, err := parseQueryTime(r.URL.Query().Get("to"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid to: %v", err), http.StatusBadRequest)
+		return
+	}
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -defaultAttributionDays)
+	}
+	if !from.Before(to) {
+		http.Error(w, "from must be before to", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.attribution.Report(from, to))
+}
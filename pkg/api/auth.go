@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"net/http"
@@ -31,6 +32,15 @@ type LoginResponse struct {
 	Token string `json:"token"`
 }
 
+// usernameKey is the request context key of the authenticated username
+type usernameKey struct{}
+
+// Username returns the authenticated username of a request, or "" if unknown
+func Username(r *http.Request) string {
+	username, _ := r.Context().Value(usernameKey{}).(string)
+	return username
+}
+
 // JWT secret key
 var jwtSecret = []byte("your-secret-key") // In production, this should be in environment variables
 
@@ -119,6 +129,13 @@ func (s *AuthService) AuthMiddleware(next http.HandlerFunc) http.HandlerFunc {
 			return
 		}
 
+		// Handlers can tell which user made the request
+		if claims, ok := token.Claims.(jwt.MapClaims); ok {
+			if username, ok := claims["username"].(string); ok {
+				r = r.WithContext(context.WithValue(r.Context(), usernameKey{}, username))
+			}
+		}
+
 		next.ServeHTTP(w, r)
 	}
 }
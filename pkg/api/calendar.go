@@ -0,0 +1,46 @@
+package api
+
+import (
+	"crypto/subtle"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/calendar"
+)
+
+// CalendarFeed lists upcoming events (implemented by calendar.Feed)
+type CalendarFeed interface {
+	Events(now time.Time) []calendar.Event
+}
+
+// SetCalendarFeed enables the ICS calendar feed. Calendar apps cannot log in,
+// so the feed URL carries token instead.
+func (s *Server) SetCalendarFeed(feed CalendarFeed, token string) {
+	s.calendar = feed
+	s.calendarToken = token
+}
+
+// handleCalendarFeed returns upcoming events as an ICS document for calendar
+// apps to subscribe to, e.g. /api/v1/calendar.ics?token=...
+func (s *Server) handleCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.calendar == nil || s.calendarToken == "" {
+		http.Error(w, "Calendar feed not available", http.StatusServiceUnavailable)
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(r.URL.Query().Get("token")), []byte(s.calendarToken)) != 1 {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	now := time.Now()
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", "inline; filename=\"hustler.ics\"")
+	if err := calendar.WriteICS(w, "Hustler", s.calendar.Events(now), now); err != nil {
+		log.Printf("Error writing calendar feed: %v", err)
+	}
+}
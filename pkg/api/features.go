@@ -0,0 +1,61 @@
+package api
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/features"
+)
+
+// FeatureFlags lists and toggles feature flags (implemented by features.Flags)
+type FeatureFlags interface {
+	List() []features.Flag
+	Set(name string, flag config.FeatureFlag) error
+	Enabled(name string) bool
+}
+
+// setFeatureRequest represents a runtime change to a feature flag
+type setFeatureRequest struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	Rollout int    `json:"rollout"`
+}
+
+// SetFeatureFlags sets the flags behind the feature flag endpoint
+func (s *Server) SetFeatureFlags(flags FeatureFlags) {
+	s.features = flags
+}
+
+// handleFeatures lists the feature flags (GET) or toggles one until restart (PUT)
+func (s *Server) handleFeatures(w http.ResponseWriter, r *http.Request) {
+	if s.features == nil {
+		http.Error(w, "Feature flags not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(s.features.List())
+
+	case http.MethodPut:
+		var req setFeatureRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		if err := s.features.Set(req.Name, config.FeatureFlag{Enabled: req.Enabled, Rollout: req.Rollout}); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.Printf("Feature %s set to enabled=%t rollout=%d by %s", req.Name, req.Enabled, req.Rollout, Username(r))
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(s.features.List())
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
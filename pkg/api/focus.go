@@ -0,0 +1,71 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/focus"
+)
+
+// FocusWatcher polls single symbols at high frequency (implemented by focus.Watcher)
+type FocusWatcher interface {
+	Start(symbol string, duration time.Duration, chatID int64) (focus.Session, error)
+	Stop(symbol string) bool
+	Sessions() []focus.Session
+}
+
+// SetFocusWatcher sets the watcher behind the focus endpoint
+func (s *Server) SetFocusWatcher(watcher FocusWatcher) {
+	s.focus = watcher
+}
+
+// focusRequest represents a request to focus on a symbol
+type focusRequest struct {
+	Symbol  string `json:"symbol"`
+	Minutes int    `json:"minutes"` // 0 uses the configured default
+}
+
+// handleFocus lists the symbols in focus (GET), focuses on a symbol (POST)
+// and ends a focus given by the symbol query param (DELETE). Alerts of focus
+// started through the API go to the channel.
+func (s *Server) handleFocus(w http.ResponseWriter, r *http.Request) {
+	if s.focus == nil {
+		http.Error(w, "Focus mode not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(s.focus.Sessions())
+
+	case http.MethodPost:
+		var req focusRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Symbol == "" || req.Minutes < 0 {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		session, err := s.focus.Start(strings.ToUpper(req.Symbol), time.Duration(req.Minutes)*time.Minute, 0)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(session)
+
+	case http.MethodDelete:
+		if !s.focus.Stop(strings.ToUpper(r.URL.Query().Get("symbol"))) {
+			http.Error(w, "Symbol not in focus", http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
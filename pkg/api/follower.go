@@ -0,0 +1,44 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hustler/trading-bot/pkg/follower"
+)
+
+// FollowerSource reports the simulated subscriber's results (implemented by follower.Simulator)
+type FollowerSource interface {
+	Summary() follower.Summary
+	Positions() []follower.Position
+}
+
+// followerResponse is the follower simulation summary with its positions
+type followerResponse struct {
+	Summary   follower.Summary    `json:"summary"`
+	Positions []follower.Position `json:"positions"`
+}
+
+// SetFollowerSource sets the simulation served by the follower endpoint
+func (s *Server) SetFollowerSource(source FollowerSource) {
+	s.follower = source
+}
+
+// handleFollower returns the ROI a subscriber following every signal after
+// the configured delay would have achieved
+func (s *Server) handleFollower(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.follower == nil {
+		http.Error(w, "Follower simulation not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(followerResponse{
+		Summary:   s.follower.Summary(),
+		Positions: s.follower.Positions(),
+	})
+}
@@ -0,0 +1,97 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// IdeaDesk vets trade ideas and tracks them as signals (implemented by monitor.MarketMonitor)
+type IdeaDesk interface {
+	AssessIdea(ctx context.Context, symbol string, side signal.SignalType, thesis, submittedBy string) (*signal.Idea, error)
+	TrackIdea(id string) (*signal.Signal, error)
+	Ideas() []signal.Idea
+}
+
+// SetIdeaDesk sets the desk behind the trade idea endpoints
+func (s *Server) SetIdeaDesk(desk IdeaDesk) {
+	s.ideas = desk
+}
+
+// ideaRequest is a trade idea submitted to POST /api/v1/ideas
+type ideaRequest struct {
+	Symbol string            `json:"symbol"`
+	Side   signal.SignalType `json:"side,omitempty"` // BUY or SELL; empty follows the strategies
+	Thesis string            `json:"thesis,omitempty"`
+}
+
+// handleIdeas lists the assessed trade ideas (GET) or vets a new one (POST)
+func (s *Server) handleIdeas(w http.ResponseWriter, r *http.Request) {
+	if s.ideas == nil {
+		http.Error(w, "Trade ideas not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(s.ideas.Ideas())
+
+	case http.MethodPost:
+		var req ideaRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || strings.TrimSpace(req.Symbol) == "" {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		side := signal.SignalType(strings.ToUpper(string(req.Side)))
+		if side != "" && side != signal.BUY && side != signal.SELL {
+			http.Error(w, "Side must be BUY or SELL", http.StatusBadRequest)
+			return
+		}
+
+		symbol := strings.ToUpper(strings.TrimPrefix(strings.TrimSpace(req.Symbol), "$"))
+		idea, err := s.ideas.AssessIdea(r.Context(), symbol, side, strings.TrimSpace(req.Thesis), Username(r))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(idea)
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleTrackIdea turns the assessed idea in the request body into a tracked signal
+func (s *Server) handleTrackIdea(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.ideas == nil {
+		http.Error(w, "Trade ideas not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	var req struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.ID == "" {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	tracked, err := s.ideas.TrackIdea(req.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tracked)
+}
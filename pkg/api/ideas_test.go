@@ -0,0 +1,76 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hustler/trading-bot/pkg/signal"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeIdeaDesk assesses every idea as a BUY at $100
+type fakeIdeaDesk struct {
+	ideas []signal.Idea
+}
+
+func (f *fakeIdeaDesk) AssessIdea(ctx context.Context, symbol string, side signal.SignalType, thesis, submittedBy string) (*signal.Idea, error) {
+	idea := signal.Idea{ID: fmt.Sprintf("IDEA-%d", len(f.ideas)+1), Symbol: symbol, Thesis: thesis, SubmittedBy: submittedBy,
+		Evaluation: signal.Evaluation{Symbol: symbol, Price: 100, Decision: signal.BUY, Side: side}}
+	f.ideas = append(f.ideas, idea)
+	return &idea, nil
+}
+
+func (f *fakeIdeaDesk) TrackIdea(id string) (*signal.Signal, error) {
+	for _, idea := range f.ideas {
+		if idea.ID == id {
+			return &signal.Signal{ID: "IDEA-" + idea.Symbol, Symbol: idea.Symbol, Type: signal.BUY}, nil
+		}
+	}
+	return nil, fmt.Errorf("no trade idea %s", id)
+}
+
+func (f *fakeIdeaDesk) Ideas() []signal.Idea { return f.ideas }
+
+func TestIdeaEndpoints(t *testing.T) {
+	server := NewServer("0", nil)
+	recorder := httptest.NewRecorder()
+	server.handleIdeas(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/ideas", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+
+	desk := &fakeIdeaDesk{}
+	server.SetIdeaDesk(desk)
+
+	recorder = httptest.NewRecorder()
+	server.handleIdeas(recorder, httptest.NewRequest(http.MethodPost, "/api/v1/ideas",
+		strings.NewReader(`{"symbol": "$nvda", "side": "buy", "thesis": " Base breakout "}`)))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var idea signal.Idea
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &idea))
+	assert.Equal(t, "NVDA", idea.Symbol)
+	assert.Equal(t, signal.BUY, idea.Evaluation.Side)
+	assert.Equal(t, "Base breakout", idea.Thesis)
+
+	recorder = httptest.NewRecorder()
+	server.handleIdeas(recorder, httptest.NewRequest(http.MethodPost, "/api/v1/ideas", strings.NewReader(`{"symbol": "NVDA", "side": "hold"}`)))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	server.handleIdeas(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/ideas", nil))
+	var ideas []signal.Idea
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &ideas))
+	assert.Len(t, ideas, 1)
+
+	recorder = httptest.NewRecorder()
+	server.handleTrackIdea(recorder, httptest.NewRequest(http.MethodPost, "/api/v1/ideas/track", strings.NewReader(`{"id": "IDEA-1"}`)))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"symbol":"NVDA"`)
+
+	recorder = httptest.NewRecorder()
+	server.handleTrackIdea(recorder, httptest.NewRequest(http.MethodPost, "/api/v1/ideas/track", strings.NewReader(`{"id": "IDEA-9"}`)))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
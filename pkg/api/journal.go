@@ -0,0 +1,89 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/hustler/trading-bot/pkg/journal"
+)
+
+// TradeJournal holds the narrative entries for closed trades (implemented by journal.Journal)
+type TradeJournal interface {
+	Entries(symbol string) []journal.Entry
+	Get(tradeID string) (*journal.Entry, bool)
+	SetNotes(tradeID, notes string) (*journal.Entry, error)
+	Excursions(symbol string) journal.ExcursionReport
+}
+
+// SetJournal sets the journal served by the journal endpoint
+func (s *Server) SetJournal(j TradeJournal) {
+	s.journal = j
+}
+
+// journalNotesRequest represents a request to edit the notes on a journal entry
+type journalNotesRequest struct {
+	TradeID string `json:"trade_id"`
+	Notes   string `json:"notes"`
+}
+
+// handleJournal lists entries or returns one by trade_id (GET) and edits notes (PUT)
+func (s *Server) handleJournal(w http.ResponseWriter, r *http.Request) {
+	if s.journal == nil {
+		http.Error(w, "Journal not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		query := r.URL.Query()
+		if tradeID := query.Get("trade_id"); tradeID != "" {
+			entry, exists := s.journal.Get(tradeID)
+			if !exists {
+				http.Error(w, "Journal entry not found", http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(entry)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(s.journal.Entries(query.Get("symbol")))
+
+	case http.MethodPut:
+		var req journalNotesRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		entry, err := s.journal.SetNotes(req.TradeID, req.Notes)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(entry)
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleJournalExcursions returns the MAE and MFE distributions of closed
+// trades, for one symbol or all of them
+func (s *Server) handleJournalExcursions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.journal == nil {
+		http.Error(w, "Journal not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.journal.Excursions(strings.ToUpper(r.URL.Query().Get("symbol"))))
+}
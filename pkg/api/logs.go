@@ -0,0 +1,46 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// defaultLogLines is the number of log lines returned when the query gives none
+const defaultLogLines = 100
+
+// LogSource provides recent log lines (implemented by logtail.Tail)
+type LogSource interface {
+	Lines(n int) []string
+}
+
+// SetLogSource sets the source for the logs endpoint
+func (s *Server) SetLogSource(source LogSource) {
+	s.logs = source
+}
+
+// handleLogs returns the most recent log lines, oldest first. The lines query
+// param sets how many; it defaults to 100.
+func (s *Server) handleLogs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.logs == nil {
+		http.Error(w, "Logs not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	lines := defaultLogLines
+	if v := r.URL.Query().Get("lines"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			http.Error(w, "Invalid lines", http.StatusBadRequest)
+			return
+		}
+		lines = n
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.logs.Lines(lines))
+}
@@ -0,0 +1,177 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/hustler/trading-bot/pkg/tuning"
+)
+
+// TunedParams reports the decay of per-symbol parameters (implemented by tuning.Registry)
+type TunedParams interface {
+	Status() []tuning.SymbolStatus
+}
+
+// ParamValidator backtests a symbol's tuned parameters (implemented by tuning.Validator)
+type ParamValidator interface {
+	Validate(symbol string) (*tuning.Validation, error)
+}
+
+// SetTunedParams sets the per-symbol parameters behind the params endpoints.
+// validator may be nil when re-validation is disabled.
+func (s *Server) SetTunedParams(params TunedParams, validator ParamValidator) {
+	s.tunedParams = params
+	s.paramValidator = validator
+}
+
+// handleTunedParams returns how far each symbol's tuned parameters have
+// decayed toward the global parameters
+func (s *Server) handleTunedParams(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.tunedParams == nil {
+		http.Error(w, "Tuned parameters not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.tunedParams.Status())
+}
+
+// handleValidateParams backtests the tuned parameters of the symbol in the
+// request body, restoring their full weight if they still hold up
+func (s *Server) handleValidateParams(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.paramValidator == nil {
+		http.Error(w, "Parameter validation not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	var req struct {
+		Symbol string `json:"symbol"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Symbol == "" {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	validation, err := s.paramValidator.Validate(strings.ToUpper(req.Symbol))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(validation)
+}
+
+// VolatilitySurface reports the realized volatility the thresholds of each
+// symbol are scaled to (implemented by tuning.VolatilitySurface)
+type VolatilitySurface interface {
+	Surface() []tuning.SymbolVolatility
+}
+
+// SetVolatilitySurface sets the surface behind the volatility endpoint
+func (s *Server) SetVolatilitySurface(surface VolatilitySurface) {
+	s.volSurface = surface
+}
+
+// handleVolatilitySurface returns each symbol's realized volatility and the
+// multiplier of its thresholds
+func (s *Server) handleVolatilitySurface(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.volSurface == nil {
+		http.Error(w, "Volatility scaling not enabled", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.volSurface.Surface())
+}
+
+// OptimizerGuard checks optimizer proposals out of sample before they are
+// applied (implemented by tuning.Guard)
+type OptimizerGuard interface {
+	Evaluate(p tuning.Proposal) (*tuning.Decision, error)
+	Apply(symbol, actor string) (*tuning.Decision, error)
+	Pending() []tuning.Decision
+}
+
+// SetOptimizerGuard sets the guard behind the parameter proposal endpoints
+func (s *Server) SetOptimizerGuard(guard OptimizerGuard) {
+	s.optimizerGuard = guard
+}
+
+// handleParamProposals lists the accepted proposals awaiting approval (GET)
+// or checks a new proposal from the optimizer out of sample (POST)
+func (s *Server) handleParamProposals(w http.ResponseWriter, r *http.Request) {
+	if s.optimizerGuard == nil {
+		http.Error(w, "Optimizer guard not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(s.optimizerGuard.Pending())
+
+	case http.MethodPost:
+		var proposal tuning.Proposal
+		if err := json.NewDecoder(r.Body).Decode(&proposal); err != nil || proposal.Symbol == "" {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		proposal.Symbol = strings.ToUpper(proposal.Symbol)
+
+		decision, err := s.optimizerGuard.Evaluate(proposal)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(decision)
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleApplyProposal applies the accepted proposal for the symbol in the
+// request body, recording the requesting user in the audit log
+func (s *Server) handleApplyProposal(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.optimizerGuard == nil {
+		http.Error(w, "Optimizer guard not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	var req struct {
+		Symbol string `json:"symbol"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Symbol == "" {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	decision, err := s.optimizerGuard.Apply(strings.ToUpper(req.Symbol), Username(r))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(decision)
+}
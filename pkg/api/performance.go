@@ -0,0 +1,59 @@
+package api
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/hustler/trading-bot/pkg/pb"
+	"github.com/hustler/trading-bot/pkg/performance"
+)
+
+// protobufContentType is the media type of responses encoded as the messages
+// of proto/hustler/v1/domain.proto
+const protobufContentType = "application/x-protobuf"
+
+// PerformanceSource provides signal performance metrics (implemented by performance.Monitor)
+type PerformanceSource interface {
+	GetMetrics() *performance.Metrics
+}
+
+// SetPerformanceSource sets the source for the performance endpoint
+func (s *Server) SetPerformanceSource(source PerformanceSource) {
+	s.performance = source
+}
+
+// handlePerformance returns overall, per-symbol and per-day signal performance,
+// as a hustler.v1.Metrics message when the request accepts protobuf
+func (s *Server) handlePerformance(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.performance == nil {
+		http.Error(w, "Performance not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	metrics := s.performance.GetMetrics()
+	if acceptsProtobuf(r) {
+		w.Header().Set("Content-Type", protobufContentType)
+		w.Write(pb.MarshalMetrics(metrics))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(metrics)
+}
+
+// acceptsProtobuf reports whether the Accept header asks for protobuf
+func acceptsProtobuf(r *http.Request) bool {
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accept))
+		if err == nil && (mediaType == protobufContentType || mediaType == "application/protobuf") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hustler/trading-bot/pkg/pb"
+	"github.com/hustler/trading-bot/pkg/performance"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePerformance struct {
+	metrics *performance.Metrics
+}
+
+func (f fakePerformance) GetMetrics() *performance.Metrics {
+	return f.metrics
+}
+
+func TestHandlePerformanceNegotiatesProtobuf(t *testing.T) {
+	server := NewServer("0", nil)
+	metrics := &performance.Metrics{
+		SignalsCount:      3,
+		SuccessCount:      2,
+		SuccessRate:       66.7,
+		SymbolPerformance: map[string]performance.SymbolMetrics{"AAPL": {Symbol: "AAPL", SignalsCount: 3}},
+		DailyPerformance:  map[string]performance.DailyMetrics{"2024-03-01": {Date: "2024-03-01", SignalsCount: 3}},
+	}
+	server.SetPerformanceSource(fakePerformance{metrics: metrics})
+
+	recorder := httptest.NewRecorder()
+	server.handlePerformance(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/performance", nil))
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	var decoded performance.Metrics
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &decoded))
+	assert.Equal(t, 3, decoded.SignalsCount)
+
+	request := httptest.NewRequest(http.MethodGet, "/api/v1/performance", nil)
+	request.Header.Set("Accept", "application/json;q=0.5, application/x-protobuf")
+	recorder = httptest.NewRecorder()
+	server.handlePerformance(recorder, request)
+	assert.Equal(t, protobufContentType, recorder.Header().Get("Content-Type"))
+	message, err := pb.UnmarshalMetrics(recorder.Body.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, metrics, message)
+}
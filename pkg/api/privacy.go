@@ -0,0 +1,60 @@
+package api
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/hustler/trading-bot/pkg/privacy"
+)
+
+// SubscriberData exports and deletes everything stored about a subscriber
+// (implemented by privacy.Service)
+type SubscriberData interface {
+	Export(userID int64) (*privacy.Export, error)
+	Delete(userID int64) error
+}
+
+// SetSubscriberData sets the service behind the subscriber data endpoint
+func (s *Server) SetSubscriberData(data SubscriberData) {
+	s.subscriberData = data
+}
+
+// handleSubscriberData exports (GET) or deletes (DELETE) everything stored
+// about the Telegram user given by the user_id query param
+func (s *Server) handleSubscriberData(w http.ResponseWriter, r *http.Request) {
+	if s.subscriberData == nil {
+		http.Error(w, "Subscriber data requests not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	userID, err := parseUserID(r.URL.Query().Get("user_id"))
+	if err != nil || userID == 0 {
+		http.Error(w, "Invalid user_id", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		export, err := s.subscriberData.Export(userID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Disposition", "attachment; filename=\"subscriber-data.json\"")
+		json.NewEncoder(w).Encode(export)
+
+	case http.MethodDelete:
+		if err := s.subscriberData.Delete(userID); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		log.Printf("Deleted stored data for Telegram user %d via API", userID)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
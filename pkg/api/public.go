@@ -0,0 +1,235 @@
+package api
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/features"
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// publicWindow is the window public API requests are counted over per client
+const publicWindow = time.Minute
+
+// Public API settings used when the config leaves them at zero
+const (
+	defaultPublicDelay             = 60 * time.Minute
+	defaultPublicRequestsPerMinute = 10
+)
+
+// publicAPI is the unauthenticated, read-only subset of the API
+type publicAPI struct {
+	config  config.PublicAPIConfig
+	delay   time.Duration
+	signals SignalHistory
+	limiter *publicLimiter
+	now     func() time.Time
+}
+
+// SetPublicAPI sets the settings and signal history of the public API. Its
+// endpoints answer 404 unless the public_api feature flag is on.
+func (s *Server) SetPublicAPI(cfg config.PublicAPIConfig, signals SignalHistory) {
+	delay := time.Duration(cfg.DelayMinutes) * time.Minute
+	if delay == 0 {
+		delay = defaultPublicDelay
+	}
+	limit := cfg.RequestsPerMinute
+	if limit == 0 {
+		limit = defaultPublicRequestsPerMinute
+	}
+	s.public = &publicAPI{
+		config:  cfg,
+		delay:   delay,
+		signals: signals,
+		limiter: newPublicLimiter(limit, publicWindow),
+		now:     time.Now,
+	}
+}
+
+// PublicSignal is a delayed signal as the public API shows it, without its
+// rationale, indicators or sizing
+type PublicSignal struct {
+	ID          string            `json:"id"`
+	Symbol      string            `json:"symbol"`
+	Type        signal.SignalType `json:"type"`
+	Price       float64           `json:"price"`
+	TargetPrice float64           `json:"target_price"`
+	StopLoss    float64           `json:"stop_loss"`
+	ExpectedROI float64           `json:"expected_roi"`
+	Confidence  float64           `json:"confidence"`
+	Status      signal.Status     `json:"status"`
+	GeneratedAt time.Time         `json:"generated_at"`
+}
+
+// PublicPerformance is the aggregate track record the public API shows
+type PublicPerformance struct {
+	Signals     int       `json:"signals"`
+	Wins        int       `json:"wins"`
+	Losses      int       `json:"losses"`
+	Pending     int       `json:"pending"`
+	WinRate     float64   `json:"win_rate"`
+	AverageROI  float64   `json:"average_roi"`
+	LastUpdated time.Time `json:"last_updated"`
+}
+
+// PublicHealth is whether the bot is running, as the public API shows it
+type PublicHealth struct {
+	Status    string    `json:"status"` // ok, degraded, or starting before the first market check
+	LastCheck time.Time `json:"last_check,omitempty"`
+}
+
+// unauthenticated wraps a public API handler: it hides the endpoint unless
+// the public_api flag is on, allows only GET from any origin and rate limits
+// each client IP
+func (s *Server) unauthenticated(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.public == nil || s.features == nil || !s.features.Enabled(features.PublicAPI) {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if retryAfter, ok := s.public.limiter.allow(s.public.clientIP(r), s.public.now()); !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())+1))
+			http.Error(w, "Rate limit exceeded", http.StatusTooManyRequests)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// clientIP returns the IP address a request came from
+func (p *publicAPI) clientIP(r *http.Request) string {
+	if p.config.TrustForwardedFor {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			return strings.TrimSpace(strings.Split(forwarded, ",")[0])
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// handlePublicSignals returns the most recent signals older than the
+// configured delay, newest first. Test signals are left out.
+func (s *Server) handlePublicSignals(w http.ResponseWriter, r *http.Request) {
+	if s.public.signals == nil {
+		http.Error(w, "Signals not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.public.delayedSignals())
+}
+
+// delayedSignals returns the signals the public API may show
+func (p *publicAPI) delayedSignals() []PublicSignal {
+	cutoff := p.now().Add(-p.delay)
+	signals := []PublicSignal{}
+	for _, sig := range p.signals.GetSignalHistory() {
+		if sig.Test || sig.GeneratedAt.After(cutoff) {
+			continue
+		}
+		signals = append(signals, PublicSignal{
+			ID:          sig.ID,
+			Symbol:      sig.Symbol,
+			Type:        sig.Type,
+			Price:       sig.Price,
+			TargetPrice: sig.TargetPrice,
+			StopLoss:    sig.StopLoss,
+			ExpectedROI: sig.ExpectedROI,
+			Confidence:  sig.Confidence,
+			Status:      sig.Status,
+			GeneratedAt: sig.GeneratedAt,
+		})
+	}
+	sort.Slice(signals, func(i, j int) bool { return signals[i].GeneratedAt.After(signals[j].GeneratedAt) })
+	if p.config.RecentSignals > 0 && len(signals) > p.config.RecentSignals {
+		signals = signals[:p.config.RecentSignals]
+	}
+	return signals
+}
+
+// handlePublicPerformance returns the overall track record, without the
+// per-symbol, per-day and slippage breakdowns
+func (s *Server) handlePublicPerformance(w http.ResponseWriter, r *http.Request) {
+	if s.performance == nil {
+		http.Error(w, "Performance not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	metrics := s.performance.GetMetrics()
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(PublicPerformance{
+		Signals:     metrics.SignalsCount,
+		Wins:        metrics.SuccessCount,
+		Losses:      metrics.FailureCount,
+		Pending:     metrics.PendingCount,
+		WinRate:     metrics.SuccessRate,
+		AverageROI:  metrics.AverageROI,
+		LastUpdated: metrics.LastUpdated,
+	})
+}
+
+// handlePublicHealth returns whether the bot is running, without the symbols
+// or errors of its checks
+func (s *Server) handlePublicHealth(w http.ResponseWriter, r *http.Request) {
+	health := PublicHealth{Status: "starting"}
+	if s.checkHealth != nil {
+		checks := s.checkHealth.GetCheckHealth()
+		if checks.LastCheck != nil {
+			health.Status = "ok"
+			health.LastCheck = checks.LastCheck.At
+			if checks.LastCheck.Degraded || len(checks.Failing) > 0 {
+				health.Status = "degraded"
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(health)
+}
+
+// publicLimiter counts requests per client over fixed windows
+type publicLimiter struct {
+	limit       int // Requests per client per window
+	window      time.Duration
+	windowStart time.Time
+	counts      map[string]int
+	mu          sync.Mutex
+}
+
+// newPublicLimiter creates a limiter allowing limit requests per client per window
+func newPublicLimiter(limit int, window time.Duration) *publicLimiter {
+	return &publicLimiter{limit: limit, window: window, counts: make(map[string]int)}
+}
+
+// allow counts a request from client and reports whether it is within the
+// limit, or how long until the next window if not
+func (l *publicLimiter) allow(client string, now time.Time) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.windowStart) >= l.window {
+		l.windowStart = now.Truncate(l.window)
+		l.counts = make(map[string]int)
+	}
+	if l.counts[client] >= l.limit {
+		return l.windowStart.Add(l.window).Sub(now), false
+	}
+	l.counts[client]++
+	return 0, true
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/features"
+	"github.com/hustler/trading-bot/pkg/performance"
+	"github.com/hustler/trading-bot/pkg/signal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublicAPI(t *testing.T) {
+	now := time.Date(2026, 10, 16, 15, 0, 0, 0, time.UTC)
+	flags := features.NewFlags(nil)
+	server := NewServer("0", nil)
+	server.SetFeatureFlags(flags)
+	server.SetPerformanceSource(performance.NewMonitor())
+	server.SetPublicAPI(config.PublicAPIConfig{DelayMinutes: 30, RequestsPerMinute: 3}, fakeSignals{
+		{ID: "SIG-1", Symbol: "AAPL", Type: signal.BUY, GeneratedAt: now.Add(-2 * time.Hour), Rationale: "Breakout"},
+		{ID: "SIG-2", Symbol: "MSFT", Type: signal.SELL, GeneratedAt: now.Add(-time.Hour)},
+		{ID: "SIG-3", Symbol: "NVDA", Type: signal.BUY, GeneratedAt: now.Add(-10 * time.Minute)},
+		{ID: "SIG-4", Symbol: "TSLA", Type: signal.BUY, GeneratedAt: now.Add(-3 * time.Hour), Test: true},
+	})
+	server.public.now = func() time.Time { return now }
+	handler := server.unauthenticated(server.handlePublicSignals)
+
+	get := func(remoteAddr string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodGet, "/api/public/v1/signals", nil)
+		request.RemoteAddr = remoteAddr
+		recorder := httptest.NewRecorder()
+		handler(recorder, request)
+		return recorder
+	}
+
+	// Hidden until the flag is on
+	assert.Equal(t, http.StatusNotFound, get("192.0.2.1:1000").Code)
+	assert.NoError(t, flags.Set(features.PublicAPI, config.FeatureFlag{Enabled: true}))
+
+	recorder := get("192.0.2.1:1000")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "*", recorder.Header().Get("Access-Control-Allow-Origin"))
+	assert.NotContains(t, recorder.Body.String(), "Breakout")
+	var signals []PublicSignal
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &signals))
+	if assert.Len(t, signals, 2) {
+		assert.Equal(t, "SIG-2", signals[0].ID)
+		assert.Equal(t, "SIG-1", signals[1].ID)
+	}
+
+	// Each client IP gets its own budget per minute
+	assert.Equal(t, http.StatusOK, get("192.0.2.1:1001").Code)
+	assert.Equal(t, http.StatusOK, get("192.0.2.1:1002").Code)
+	recorder = get("192.0.2.1:1003")
+	assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	assert.Equal(t, "61", recorder.Header().Get("Retry-After"))
+	assert.Equal(t, http.StatusOK, get("192.0.2.2:1000").Code)
+	server.public.now = func() time.Time { return now.Add(time.Minute) }
+	assert.Equal(t, http.StatusOK, get("192.0.2.1:1004").Code)
+
+	// Read-only
+	recorder = httptest.NewRecorder()
+	server.unauthenticated(server.handlePublicHealth)(recorder, httptest.NewRequest(http.MethodPost, "/api/public/v1/health", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	server.unauthenticated(server.handlePublicPerformance)(recorder, httptest.NewRequest(http.MethodGet, "/api/public/v1/performance", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.NotContains(t, recorder.Body.String(), "symbol_performance")
+}
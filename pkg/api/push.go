@@ -0,0 +1,71 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hustler/trading-bot/pkg/webpush"
+)
+
+// PushSubscriptions stores browser push subscriptions (implemented by webpush.Subscriptions)
+type PushSubscriptions interface {
+	Add(sub webpush.Subscription) error
+	Remove(endpoint string) error
+}
+
+// SetWebPush enables the web push endpoints. publicKey is the VAPID key
+// browsers subscribe with.
+func (s *Server) SetWebPush(publicKey string, subs PushSubscriptions) {
+	s.pushKey = publicKey
+	s.pushSubs = subs
+}
+
+// handlePushKey returns the VAPID public key for PushManager.subscribe
+func (s *Server) handlePushKey(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.pushSubs == nil {
+		http.Error(w, "Web push not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"public_key": s.pushKey})
+}
+
+// handlePushSubscriptions subscribes (POST) or unsubscribes (DELETE) a browser.
+// The body is the browser's PushSubscription as JSON; DELETE needs only its endpoint.
+func (s *Server) handlePushSubscriptions(w http.ResponseWriter, r *http.Request) {
+	if s.pushSubs == nil {
+		http.Error(w, "Web push not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	var sub webpush.Subscription
+	if err := json.NewDecoder(r.Body).Decode(&sub); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+		sub.Username = Username(r)
+		if err := s.pushSubs.Add(sub); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+
+	case http.MethodDelete:
+		if err := s.pushSubs.Remove(sub.Endpoint); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
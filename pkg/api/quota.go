@@ -0,0 +1,62 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/quota"
+)
+
+// QuotaManager meters API calls per user and reports quota usage (implemented by quota.Manager)
+type QuotaManager interface {
+	Allow(tenant, kind string, amount int) bool
+	Status() []quota.Usage
+}
+
+// SetQuotaManager meters API calls per user against the quota manager
+func (s *Server) SetQuotaManager(manager QuotaManager) {
+	s.quota = manager
+}
+
+// protected requires a valid token and meters the call against the user's API quota
+func (s *Server) protected(next http.HandlerFunc) http.HandlerFunc {
+	return s.auth.AuthMiddleware(s.metered(next))
+}
+
+// metered rejects calls over the user's hourly API quota with 429 Too Many Requests
+func (s *Server) metered(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.quota != nil {
+			tenant := Username(r)
+			if tenant == "" {
+				tenant = quota.DefaultTenant
+			}
+			if !s.quota.Allow(tenant, quota.APICalls, 1) {
+				now := time.Now()
+				retryAfter := now.Truncate(time.Hour).Add(time.Hour).Sub(now)
+				w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())+1))
+				http.Error(w, "API call quota exceeded", http.StatusTooManyRequests)
+				return
+			}
+		}
+		next(w, r)
+	}
+}
+
+// handleQuotas returns the current usage of every quota. It is not metered,
+// so usage can be checked once a quota is exhausted.
+func (s *Server) handleQuotas(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.quota == nil {
+		http.Error(w, "Quotas not enabled", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.quota.Status())
+}
@@ -2,15 +2,88 @@ package api
 
 import (
 	"database/sql"
+	"encoding/json"
 	"log"
 	"net/http"
+	"strconv"
+
+	"github.com/hustler/trading-bot/pkg/alerts"
+	"github.com/hustler/trading-bot/pkg/indicators"
+	"github.com/hustler/trading-bot/pkg/monitor"
+	"github.com/hustler/trading-bot/pkg/signal"
 )
 
+// BreadthSource provides the latest market breadth (implemented by monitor.MarketMonitor)
+type BreadthSource interface {
+	GetBreadth() (indicators.Breadth, bool)
+}
+
+// HeatmapSource provides the watchlist heatmap (implemented by monitor.MarketMonitor)
+type HeatmapSource interface {
+	GetHeatmap() []monitor.HeatmapCell
+}
+
+// CheckHealthSource reports market data failures of recent checks (implemented by monitor.MarketMonitor)
+type CheckHealthSource interface {
+	GetCheckHealth() monitor.CheckHealth
+}
+
+// AlertEngine manages user-defined alert rules (implemented by alerts.Engine)
+type AlertEngine interface {
+	AddRule(userID int64, expression string) (*alerts.Rule, error)
+	RemoveRule(id string, userID int64) error
+	ListRules(userID int64) []alerts.Rule
+}
+
+// SignalAdjuster changes an active signal's target or stop (implemented by monitor.MarketMonitor)
+type SignalAdjuster interface {
+	AdjustSignal(signalID, level string, value float64) (*signal.Signal, error)
+}
+
 // Server represents the API server
 type Server struct {
-	port string
-	db   *sql.DB
-	auth *AuthService
+	port           string
+	db             *sql.DB
+	auth           *AuthService
+	breadth        BreadthSource
+	heatmap        HeatmapSource
+	checkHealth    CheckHealthSource
+	alerts         AlertEngine
+	adjuster       SignalAdjuster
+	symbolLists    SymbolLists
+	journal        TradeJournal
+	symbolSources  SymbolSources
+	signalSearch   SignalSearcher
+	importer       WatchlistImporter
+	attribution    AttributionReporter
+	follower       FollowerSource
+	subscriberData SubscriberData
+	quota          QuotaManager
+	testSignals    TestSignalSender
+	ideas          IdeaDesk
+	features       FeatureFlags
+	performance    PerformanceSource
+	signalStream   http.Handler
+	logs           LogSource
+	pushKey        string
+	pushSubs       PushSubscriptions
+	calendar       CalendarFeed
+	calendarToken  string
+	focus          FocusWatcher
+	shadow         ShadowSource
+	session        SessionSource
+	tunedParams    TunedParams
+	paramValidator ParamValidator
+	volSurface     VolatilitySurface
+	optimizerGuard OptimizerGuard
+	tradeDesk      TradeDesk
+	quotes         QuoteLookup
+	signalTrades   SignalTrades
+	public         *publicAPI
+	artifacts      ArtifactStore
+
+	stripeSecret string
+	entitlements EntitlementStore
 }
 
 // NewServer creates a new API server
@@ -22,16 +95,249 @@ func NewServer(port string, db *sql.DB) *Server {
 	}
 }
 
+// SetBreadthSource sets the source for the market breadth endpoint
+func (s *Server) SetBreadthSource(source BreadthSource) {
+	s.breadth = source
+}
+
+// SetHeatmapSource sets the source for the heatmap endpoint
+func (s *Server) SetHeatmapSource(source HeatmapSource) {
+	s.heatmap = source
+}
+
+// SetCheckHealthSource sets the source for the market check health endpoint
+func (s *Server) SetCheckHealthSource(source CheckHealthSource) {
+	s.checkHealth = source
+}
+
+// SetAlertEngine sets the engine for the alerts endpoint
+func (s *Server) SetAlertEngine(engine AlertEngine) {
+	s.alerts = engine
+}
+
+// SetSignalAdjuster sets the handler for signal target/stop adjustments
+func (s *Server) SetSignalAdjuster(adjuster SignalAdjuster) {
+	s.adjuster = adjuster
+}
+
 // Start starts the API server
 func (s *Server) Start() error {
 	// Set up routes
 	http.HandleFunc("/api/login", s.auth.LoginHandler)
 
-	// Protected routes
+	// Protected routes, metered against each user's API quota
 	http.HandleFunc("/api/protected", s.auth.AuthMiddleware(func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte("Protected endpoint"))
 	}))
+	http.HandleFunc("/api/v1/breadth", s.protected(s.handleBreadth))
+	http.HandleFunc("/api/v1/heatmap", s.protected(s.handleHeatmap))
+	http.HandleFunc("/api/v1/checks/health", s.protected(s.handleCheckHealth))
+	http.HandleFunc("/api/v1/alerts", s.protected(s.handleAlerts))
+	http.HandleFunc("/api/v1/signals", s.protected(s.handleSignals))
+	http.HandleFunc("/api/v1/signals/adjust", s.protected(s.handleAdjustSignal))
+	http.HandleFunc("/api/v1/signals/test", s.protected(s.handleTestSignal))
+	http.HandleFunc("/api/v1/ideas", s.protected(s.handleIdeas))
+	http.HandleFunc("/api/v1/ideas/track", s.protected(s.handleTrackIdea))
+	http.HandleFunc("/api/v1/signals/stream", s.protected(s.handleSignalStream))
+	http.HandleFunc("/api/v1/signals/throttled", s.protected(s.handleThrottled))
+	http.HandleFunc("/api/v1/signals/", s.protected(s.handleSignalDetail))
+	http.HandleFunc("/api/v1/session", s.protected(s.handleSession))
+	http.HandleFunc("/api/v1/params/symbols", s.protected(s.handleTunedParams))
+	http.HandleFunc("/api/v1/params/validate", s.protected(s.handleValidateParams))
+	http.HandleFunc("/api/v1/params/volatility", s.protected(s.handleVolatilitySurface))
+	http.HandleFunc("/api/v1/params/proposals", s.protected(s.handleParamProposals))
+	http.HandleFunc("/api/v1/params/proposals/apply", s.protected(s.handleApplyProposal))
+	http.HandleFunc("/api/v1/performance", s.protected(s.handlePerformance))
+	http.HandleFunc("/api/v1/trades", s.protected(s.handleTrades))
+	http.HandleFunc("/api/v1/trades/modify", s.protected(s.handleModifyTrade))
+	http.HandleFunc("/api/v1/logs", s.protected(s.handleLogs))
+	http.HandleFunc("/api/v1/symbols/lists", s.protected(s.handleSymbolLists))
+	http.HandleFunc("/api/v1/symbols/", s.protected(s.handleSymbolDetail))
+	http.HandleFunc("/api/v1/watchlist/import", s.protected(s.handleImportWatchlist))
+	http.HandleFunc("/api/v1/journal", s.protected(s.handleJournal))
+	http.HandleFunc("/api/v1/journal/excursions", s.protected(s.handleJournalExcursions))
+	http.HandleFunc("/api/v1/attribution", s.protected(s.handleAttribution))
+	http.HandleFunc("/api/v1/follower", s.protected(s.handleFollower))
+	http.HandleFunc("/api/v1/subscribers/data", s.protected(s.handleSubscriberData))
+	http.HandleFunc("/api/v1/quotas", s.auth.AuthMiddleware(s.handleQuotas))
+	http.HandleFunc("/api/v1/features", s.protected(s.handleFeatures))
+	http.HandleFunc("/api/v1/push/key", s.protected(s.handlePushKey))
+	http.HandleFunc("/api/v1/push/subscriptions", s.protected(s.handlePushSubscriptions))
+	http.HandleFunc("/api/v1/focus", s.protected(s.handleFocus))
+	http.HandleFunc("/api/v1/artifacts", s.protected(s.handleArtifacts))
+
+	// Webhooks authenticate with their own signatures
+	http.HandleFunc("/api/v1/webhooks/stripe", s.handleStripeWebhook)
+
+	// Calendar apps authenticate with the feed token in the URL
+	http.HandleFunc("/api/v1/calendar.ics", s.handleCalendarFeed)
+
+	// The public website reads a rate limited subset without authenticating
+	http.HandleFunc("/api/public/v1/signals", s.unauthenticated(s.handlePublicSignals))
+	http.HandleFunc("/api/public/v1/performance", s.unauthenticated(s.handlePublicPerformance))
+	http.HandleFunc("/api/public/v1/health", s.unauthenticated(s.handlePublicHealth))
 
 	log.Printf("Starting API server on port %s", s.port)
 	return http.ListenAndServe(":"+s.port, nil)
 }
+
+// handleBreadth returns the latest watchlist breadth statistics
+func (s *Server) handleBreadth(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if s.breadth == nil {
+		http.Error(w, "Breadth not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	breadth, ok := s.breadth.GetBreadth()
+	if !ok {
+		http.Error(w, "Breadth not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(breadth)
+}
+
+// handleHeatmap returns per-symbol change, relative volume and signal state for the watchlist
+func (s *Server) handleHeatmap(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if s.heatmap == nil {
+		http.Error(w, "Heatmap not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.heatmap.GetHeatmap())
+}
+
+// handleCheckHealth returns the latest market check report and the symbols failing to fetch
+func (s *Server) handleCheckHealth(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if s.checkHealth == nil {
+		http.Error(w, "Check health not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.checkHealth.GetCheckHealth())
+}
+
+// createAlertRequest represents a request to create an alert rule
+type createAlertRequest struct {
+	UserID int64  `json:"user_id"`
+	Rule   string `json:"rule"`
+}
+
+// handleAlerts lists (GET), creates (POST) and removes (DELETE) alert rules.
+// user_id is the Telegram chat the alert is delivered to.
+func (s *Server) handleAlerts(w http.ResponseWriter, r *http.Request) {
+	if s.alerts == nil {
+		http.Error(w, "Alerts not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		userID, err := parseUserID(r.URL.Query().Get("user_id"))
+		if err != nil {
+			http.Error(w, "Invalid user_id", http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(s.alerts.ListRules(userID))
+
+	case http.MethodPost:
+		var req createAlertRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		if req.UserID == 0 {
+			http.Error(w, "user_id is required", http.StatusBadRequest)
+			return
+		}
+
+		rule, err := s.alerts.AddRule(req.UserID, req.Rule)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(rule)
+
+	case http.MethodDelete:
+		userID, err := parseUserID(r.URL.Query().Get("user_id"))
+		if err != nil {
+			http.Error(w, "Invalid user_id", http.StatusBadRequest)
+			return
+		}
+
+		if err := s.alerts.RemoveRule(r.URL.Query().Get("id"), userID); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// parseUserID parses an optional user_id parameter; empty means all users
+func parseUserID(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(value, 10, 64)
+}
+
+// adjustSignalRequest represents a request to change a signal's target or stop
+type adjustSignalRequest struct {
+	SignalID string  `json:"signal_id"`
+	Level    string  `json:"level"` // target or stop
+	Price    float64 `json:"price"`
+}
+
+// handleAdjustSignal changes an active signal's target or stop
+func (s *Server) handleAdjustSignal(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if s.adjuster == nil {
+		http.Error(w, "Signal adjustment not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	var req adjustSignalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	adjusted, err := s.adjuster.AdjustSignal(req.SignalID, req.Level, req.Price)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(adjusted)
+}
@@ -0,0 +1,33 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hustler/trading-bot/pkg/market"
+)
+
+// SessionSource reports the trading day's session state (implemented by monitor.MarketMonitor)
+type SessionSource interface {
+	GetSessionState() market.SessionState
+}
+
+// SetSessionSource sets the source for the session state endpoint
+func (s *Server) SetSessionSource(source SessionSource) {
+	s.session = source
+}
+
+// handleSession returns the current session state, e.g. power_hour
+func (s *Server) handleSession(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.session == nil {
+		http.Error(w, "Session state not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]market.SessionState{"state": s.session.GetSessionState()})
+}
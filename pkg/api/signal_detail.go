@@ -0,0 +1,137 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/execution"
+	"github.com/hustler/trading-bot/pkg/journal"
+	"github.com/hustler/trading-bot/pkg/performance"
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// signalDetailLead is how much price history before a signal, and after it
+// closed, its chart shows
+const signalDetailLead = 2 * time.Hour
+
+// SignalTrades provides the trades opened for signals (implemented by execution.TradeManager)
+type SignalTrades interface {
+	TradeForSignal(signalID string) (*execution.Trade, bool)
+	GetAllTrades() []*execution.Trade
+}
+
+// SetSignalTrades sets where the signal detail endpoint finds the trades of a signal
+func (s *Server) SetSignalTrades(trades SignalTrades) {
+	s.signalTrades = trades
+}
+
+// SignalDetail is everything known about one signal. The indicator snapshot
+// and LLM rationale are part of the signal.
+type SignalDetail struct {
+	Signal  *signal.Signal            `json:"signal"`
+	Candles []data.Candle             `json:"candles"` // From before the signal until after it closed
+	Trades  []*execution.Trade        `json:"trades"`  // The entry trade first, then its scale-ins and exits
+	Journal []journal.Entry           `json:"journal"`
+	Outcome *performance.SignalResult `json:"outcome,omitempty"`
+}
+
+// handleSignalDetail returns the detail of the signal in the path /api/v1/signals/{id}
+func (s *Server) handleSignalDetail(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/v1/signals/"), "/")
+	if id == "" || strings.Contains(id, "/") {
+		http.Error(w, "Signal ID is required", http.StatusBadRequest)
+		return
+	}
+
+	detail, exists := s.SignalDetail(id)
+	if !exists {
+		http.Error(w, "Signal not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(detail)
+}
+
+// SignalDetail aggregates the signal history, market data, trades, journal
+// and performance sources for a signal. Only signals still in the history
+// are found.
+func (s *Server) SignalDetail(id string) (SignalDetail, bool) {
+	sources := s.symbolSources
+	if sources.Signals == nil {
+		return SignalDetail{}, false
+	}
+
+	var sig *signal.Signal
+	for _, candidate := range sources.Signals.GetSignalHistory() {
+		if candidate.ID == id {
+			sig = candidate
+			break
+		}
+	}
+	if sig == nil {
+		return SignalDetail{}, false
+	}
+
+	detail := SignalDetail{
+		Signal:  sig,
+		Candles: []data.Candle{},
+		Trades:  []*execution.Trade{},
+		Journal: []journal.Entry{},
+	}
+
+	if sources.Market != nil {
+		from := sig.GeneratedAt.Add(-signalDetailLead)
+		var to time.Time
+		if sig.Status.Closed() {
+			to = sig.StatusChangedAt().Add(signalDetailLead)
+		}
+		for _, candle := range sources.Market.GetCandles(sig.Symbol) {
+			if candle.Start.Before(from) || (!to.IsZero() && candle.Start.After(to)) {
+				continue
+			}
+			detail.Candles = append(detail.Candles, candle)
+		}
+	}
+
+	if s.signalTrades != nil {
+		if position, exists := s.signalTrades.TradeForSignal(id); exists {
+			var followUps []*execution.Trade
+			for _, trade := range s.signalTrades.GetAllTrades() {
+				if trade.PositionID == position.ID {
+					followUps = append(followUps, trade)
+				}
+			}
+			sort.Slice(followUps, func(i, j int) bool {
+				return followUps[i].CreatedAt.Before(followUps[j].CreatedAt)
+			})
+			detail.Trades = append(append(detail.Trades, position), followUps...)
+
+			if s.journal != nil {
+				if entry, exists := s.journal.Get(position.ID); exists {
+					detail.Journal = append(detail.Journal, *entry)
+				}
+			}
+		}
+	}
+
+	if sources.Performance != nil {
+		for _, result := range sources.Performance.GetResultsBySymbol(sig.Symbol) {
+			if result.SignalID == id {
+				detail.Outcome = result
+				break
+			}
+		}
+	}
+
+	return detail, true
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/execution"
+	"github.com/hustler/trading-bot/pkg/performance"
+	"github.com/hustler/trading-bot/pkg/signal"
+	"github.com/stretchr/testify/assert"
+)
+
+// hourlyMarket serves hourly candles over the day
+type hourlyMarket struct {
+	start time.Time
+}
+
+func (m hourlyMarket) GetStock(symbol string) (*data.Stock, bool) { return nil, false }
+
+func (m hourlyMarket) GetCandles(symbol string) []data.Candle {
+	candles := make([]data.Candle, 24)
+	for i := range candles {
+		candles[i] = data.Candle{Start: m.start.Add(time.Duration(i) * time.Hour), Close: float64(100 + i)}
+	}
+	return candles
+}
+
+type fakeSignalTrades []*execution.Trade
+
+func (f fakeSignalTrades) TradeForSignal(signalID string) (*execution.Trade, bool) {
+	for _, trade := range f {
+		if trade.SignalID == signalID {
+			return trade, true
+		}
+	}
+	return nil, false
+}
+
+func (f fakeSignalTrades) GetAllTrades() []*execution.Trade { return f }
+
+func TestSignalDetail(t *testing.T) {
+	start := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	sig := &signal.Signal{ID: "SIG-1", Symbol: "AAPL", Type: signal.BUY, Price: 110, TargetPrice: 115, StopLoss: 108,
+		Status: signal.StatusActive, GeneratedAt: start.Add(10 * time.Hour), Rationale: "Breakout",
+		TechnicalData: map[string]float64{"rsi": 62}}
+	assert.NoError(t, sig.SetStatus(signal.StatusSuccess, start.Add(14*time.Hour)))
+
+	perf := performance.NewMonitor()
+	perf.AddSignal(sig)
+	perf.UpdateSignalStatus(sig.ID, performance.StatusSuccess, 115)
+
+	server := NewServer("0", nil)
+	server.SetSymbolSources(SymbolSources{
+		Market:      hourlyMarket{start: start},
+		Signals:     fakeSignals{{ID: "SIG-0", Symbol: "AAPL"}, sig},
+		Performance: perf,
+	})
+	server.SetSignalTrades(fakeSignalTrades{
+		{ID: "T-3", Symbol: "AAPL", PositionID: "T-1", CreatedAt: start.Add(14 * time.Hour)},
+		{ID: "T-1", Symbol: "AAPL", SignalID: "SIG-1", CreatedAt: start.Add(10 * time.Hour)},
+		{ID: "T-2", Symbol: "AAPL", PositionID: "T-1", CreatedAt: start.Add(12 * time.Hour)},
+		{ID: "T-4", Symbol: "MSFT", CreatedAt: start.Add(12 * time.Hour)},
+	})
+
+	recorder := httptest.NewRecorder()
+	server.handleSignalDetail(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/signals/SIG-1", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var detail SignalDetail
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &detail))
+	assert.Equal(t, "Breakout", detail.Signal.Rationale)
+	assert.Equal(t, 62.0, detail.Signal.TechnicalData["rsi"])
+	// Two hours either side of the signal's life
+	assert.Len(t, detail.Candles, 9)
+	assert.Equal(t, 108.0, detail.Candles[0].Close)
+	assert.Equal(t, []string{"T-1", "T-2", "T-3"}, []string{detail.Trades[0].ID, detail.Trades[1].ID, detail.Trades[2].ID})
+	assert.Empty(t, detail.Journal)
+	assert.Equal(t, performance.StatusSuccess, detail.Outcome.Status)
+	assert.Equal(t, 115.0, detail.Outcome.ExitPrice)
+
+	recorder = httptest.NewRecorder()
+	server.handleSignalDetail(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/signals/SIG-9", nil))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// SignalSearcher searches stored signals (implemented by store.Logger)
+type SignalSearcher interface {
+	SearchSignals(q signal.Query) (signal.Page, error)
+}
+
+// SetSignalSearcher sets the store searched by the signals endpoint
+func (s *Server) SetSignalSearcher(searcher SignalSearcher) {
+	s.signalSearch = searcher
+}
+
+// handleSignals returns a page of stored signals filtered by the query params
+// symbol, type, status, min_confidence, from, to and strategy, ordered by
+// sort and order and paged by limit and offset
+func (s *Server) handleSignals(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.signalSearch == nil {
+		http.Error(w, "Signal search not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	query, err := parseSignalQuery(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	page, err := s.signalSearch.SearchSignals(query)
+	if err != nil {
+		http.Error(w, "Failed to search signals", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(page)
+}
+
+// parseSignalQuery builds a validated signal query from URL query params
+func parseSignalQuery(values url.Values) (signal.Query, error) {
+	q := signal.Query{
+		Symbol:   strings.ToUpper(values.Get("symbol")),
+		Type:     signal.SignalType(strings.ToUpper(values.Get("type"))),
+		Status:   signal.Status(strings.ToUpper(values.Get("status"))),
+		Strategy: values.Get("strategy"),
+		SortBy:   values.Get("sort"),
+	}
+
+	var err error
+	if v := values.Get("min_confidence"); v != "" {
+		if q.MinConfidence, err = strconv.ParseFloat(v, 64); err != nil {
+			return q, fmt.Errorf("invalid min_confidence: %s", v)
+		}
+	}
+	if q.From, err = parseQueryTime(values.Get("from")); err != nil {
+		return q, fmt.Errorf("invalid from: %w", err)
+	}
+	if q.To, err = parseQueryTime(values.Get("to")); err != nil {
+		return q, fmt.Errorf("invalid to: %w", err)
+	}
+
+	switch values.Get("order") {
+	case "", "desc":
+	case "asc":
+		q.Ascending = true
+	default:
+		return q, fmt.Errorf("invalid order: %s", values.Get("order"))
+	}
+
+	if v := values.Get("limit"); v != "" {
+		if q.Limit, err = strconv.Atoi(v); err != nil {
+			return q, fmt.Errorf("invalid limit: %s", v)
+		}
+	}
+	if v := values.Get("offset"); v != "" {
+		if q.Offset, err = strconv.Atoi(v); err != nil {
+			return q, fmt.Errorf("invalid offset: %s", v)
+		}
+	}
+
+	if err := q.Normalize(); err != nil {
+		return q, err
+	}
+	return q, nil
+}
+
+// parseQueryTime parses an RFC 3339 timestamp or a date. Empty values are the zero time.
+func parseQueryTime(v string) (time.Time, error) {
+	if v == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected RFC 3339 time or YYYY-MM-DD date: %s", v)
+	}
+	return t, nil
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/signal"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSearcher struct {
+	query signal.Query
+}
+
+func (f *fakeSearcher) SearchSignals(q signal.Query) (signal.Page, error) {
+	f.query = q
+	return signal.Page{Signals: []*signal.Signal{{ID: "SIG-1"}}, Total: 7, Limit: q.Limit, Offset: q.Offset}, nil
+}
+
+func TestParseSignalQuery(t *testing.T) {
+	q, err := parseSignalQuery(url.Values{
+		"symbol":         {"aapl"},
+		"type":           {"buy"},
+		"min_confidence": {"0.8"},
+		"from":           {"2024-01-01"},
+		"to":             {"2024-02-01T00:00:00Z"},
+		"strategy":       {"momentum"},
+		"sort":           {"confidence"},
+		"order":          {"asc"},
+		"limit":          {"1000"},
+		"offset":         {"20"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "AAPL", q.Symbol)
+	assert.Equal(t, signal.BUY, q.Type)
+	assert.Equal(t, 0.8, q.MinConfidence)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), q.From)
+	assert.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), q.To)
+	assert.Equal(t, "momentum", q.Strategy)
+	assert.Equal(t, signal.SortConfidence, q.SortBy)
+	assert.True(t, q.Ascending)
+	assert.Equal(t, signal.MaxQueryLimit, q.Limit)
+	assert.Equal(t, 20, q.Offset)
+
+	q, err = parseSignalQuery(url.Values{})
+	assert.NoError(t, err)
+	assert.Equal(t, signal.SortGeneratedAt, q.SortBy)
+	assert.Equal(t, signal.DefaultQueryLimit, q.Limit)
+
+	for _, values := range []url.Values{
+		{"sort": {"rationale"}},
+		{"type": {"short"}},
+		{"min_confidence": {"1.5"}},
+		{"from": {"yesterday"}},
+		{"from": {"2024-02-01"}, "to": {"2024-01-01"}},
+		{"order": {"sideways"}},
+		{"limit": {"-1"}},
+	} {
+		_, err := parseSignalQuery(values)
+		assert.Error(t, err, values.Encode())
+	}
+}
+
+func TestHandleSignals(t *testing.T) {
+	server := NewServer("0", nil)
+
+	recorder := httptest.NewRecorder()
+	server.handleSignals(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/signals", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+
+	searcher := &fakeSearcher{}
+	server.SetSignalSearcher(searcher)
+
+	recorder = httptest.NewRecorder()
+	server.handleSignals(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/signals?status=active&limit=10", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, signal.StatusActive, searcher.query.Status)
+
+	var page signal.Page
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &page))
+	assert.Equal(t, 7, page.Total)
+	assert.Equal(t, 10, page.Limit)
+	assert.Len(t, page.Signals, 1)
+
+	recorder = httptest.NewRecorder()
+	server.handleSignals(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/signals?sort=bogus", nil))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
@@ -0,0 +1,18 @@
+package api
+
+import "net/http"
+
+// SetSignalStream sets the server-sent event stream of signal events
+// (implemented by stream.Hub)
+func (s *Server) SetSignalStream(stream http.Handler) {
+	s.signalStream = stream
+}
+
+// handleSignalStream streams new, adjusted and closed signals as server-sent events
+func (s *Server) handleSignalStream(w http.ResponseWriter, r *http.Request) {
+	if s.signalStream == nil {
+		http.Error(w, "Signal stream not available", http.StatusServiceUnavailable)
+		return
+	}
+	s.signalStream.ServeHTTP(w, r)
+}
@@ -0,0 +1,158 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// stripeSignatureTolerance is the maximum age of a signed Stripe webhook
+const stripeSignatureTolerance = 5 * time.Minute
+
+// EntitlementStore records which users have paid premium access (implemented by store.Logger)
+type EntitlementStore interface {
+	GrantEntitlement(userID int64, source string, expiresAt time.Time) error
+	RevokeEntitlement(userID int64) error
+}
+
+// stripeEvent is the subset of a Stripe webhook event used for entitlements
+type stripeEvent struct {
+	Type string `json:"type"`
+	Data struct {
+		Object struct {
+			ClientReferenceID string            `json:"client_reference_id"`
+			Metadata          map[string]string `json:"metadata"`
+		} `json:"object"`
+	} `json:"data"`
+}
+
+// SetStripeWebhook enables the Stripe webhook that grants and revokes premium access
+func (s *Server) SetStripeWebhook(secret string, entitlements EntitlementStore) {
+	s.stripeSecret = secret
+	s.entitlements = entitlements
+}
+
+// handleStripeWebhook grants premium access when a checkout completes and revokes it
+// when the subscription ends. The Telegram user ID comes from the checkout's
+// client_reference_id or the subscription's telegram_user_id metadata.
+func (s *Server) handleStripeWebhook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if s.entitlements == nil || s.stripeSecret == "" {
+		http.Error(w, "Subscriptions not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	payload, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+	if err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := verifyStripeSignature(payload, r.Header.Get("Stripe-Signature"), s.stripeSecret, time.Now()); err != nil {
+		log.Printf("Rejected Stripe webhook: %v", err)
+		http.Error(w, "Invalid signature", http.StatusBadRequest)
+		return
+	}
+
+	var event stripeEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		http.Error(w, "Invalid event", http.StatusBadRequest)
+		return
+	}
+
+	var grant bool
+	switch event.Type {
+	case "checkout.session.completed":
+		grant = true
+	case "customer.subscription.deleted":
+		grant = false
+	default:
+		// Acknowledge events we don't act on so Stripe doesn't retry them
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	object := event.Data.Object
+	reference := object.ClientReferenceID
+	if reference == "" {
+		reference = object.Metadata["telegram_user_id"]
+	}
+
+	userID, err := strconv.ParseInt(reference, 10, 64)
+	if err != nil {
+		// Retrying cannot fix a missing user ID, so acknowledge and log it
+		log.Printf("Ignoring Stripe %s event without a valid telegram user id %q", event.Type, reference)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if grant {
+		err = s.entitlements.GrantEntitlement(userID, "stripe", time.Time{})
+	} else {
+		err = s.entitlements.RevokeEntitlement(userID)
+	}
+	if err != nil {
+		log.Printf("Error handling Stripe %s event: %v", event.Type, err)
+		http.Error(w, "Failed to process event", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// verifyStripeSignature checks a Stripe-Signature header ("t=<unix>,v1=<hex hmac>")
+// against the payload and rejects signatures older than the tolerance
+func verifyStripeSignature(payload []byte, header, secret string, now time.Time) error {
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	if timestamp == "" || len(signatures) == 0 {
+		return fmt.Errorf("missing timestamp or signature")
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp: %w", err)
+	}
+	if age := now.Sub(time.Unix(unix, 0)); age > stripeSignatureTolerance || age < -stripeSignatureTolerance {
+		return fmt.Errorf("timestamp outside tolerance")
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+	expected := mac.Sum(nil)
+
+	for _, signature := range signatures {
+		decoded, err := hex.DecodeString(signature)
+		if err == nil && hmac.Equal(decoded, expected) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("no matching signature")
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryEntitlements is an in-memory EntitlementStore for tests
+type memoryEntitlements struct {
+	granted map[int64]string
+}
+
+func (m *memoryEntitlements) GrantEntitlement(userID int64, source string, expiresAt time.Time) error {
+	m.granted[userID] = source
+	return nil
+}
+
+func (m *memoryEntitlements) RevokeEntitlement(userID int64) error {
+	delete(m.granted, userID)
+	return nil
+}
+
+func signStripePayload(payload, secret string, at time.Time) string {
+	timestamp := fmt.Sprintf("%d", at.Unix())
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + payload))
+	return fmt.Sprintf("t=%s,v1=%s", timestamp, hex.EncodeToString(mac.Sum(nil)))
+}
+
+func TestVerifyStripeSignature(t *testing.T) {
+	now := time.Date(2025, 4, 21, 14, 0, 0, 0, time.UTC)
+	payload := `{"type":"checkout.session.completed"}`
+	header := signStripePayload(payload, "whsec_test", now)
+
+	assert.NoError(t, verifyStripeSignature([]byte(payload), header, "whsec_test", now))
+	assert.Error(t, verifyStripeSignature([]byte(payload), header, "whsec_other", now))
+	assert.Error(t, verifyStripeSignature([]byte(payload+" "), header, "whsec_test", now))
+	assert.Error(t, verifyStripeSignature([]byte(payload), header, "whsec_test", now.Add(10*time.Minute)))
+	assert.Error(t, verifyStripeSignature([]byte(payload), "", "whsec_test", now))
+}
+
+func TestStripeWebhookGrantsAndRevokes(t *testing.T) {
+	entitlements := &memoryEntitlements{granted: make(map[int64]string)}
+	server := NewServer("0", nil)
+	server.SetStripeWebhook("whsec_test", entitlements)
+
+	send := func(payload string) int {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks/stripe", strings.NewReader(payload))
+		req.Header.Set("Stripe-Signature", signStripePayload(payload, "whsec_test", time.Now()))
+		rec := httptest.NewRecorder()
+		server.handleStripeWebhook(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, send(`{"type":"checkout.session.completed","data":{"object":{"client_reference_id":"42"}}}`))
+	assert.Equal(t, "stripe", entitlements.granted[42])
+
+	// Unhandled events are acknowledged
+	assert.Equal(t, http.StatusOK, send(`{"type":"invoice.paid","data":{"object":{}}}`))
+
+	assert.Equal(t, http.StatusOK, send(`{"type":"customer.subscription.deleted","data":{"object":{"metadata":{"telegram_user_id":"42"}}}}`))
+	assert.Empty(t, entitlements.granted)
+
+	// Events without a user ID can never succeed, so they are acknowledged without a grant
+	assert.Equal(t, http.StatusOK, send(`{"type":"checkout.session.completed","data":{"object":{}}}`))
+	assert.Empty(t, entitlements.granted)
+}
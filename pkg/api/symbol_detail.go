@@ -0,0 +1,185 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/execution"
+	"github.com/hustler/trading-bot/pkg/performance"
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+const (
+	// detailCandles is the number of recent candles in a symbol detail
+	detailCandles = 120
+	// detailNews is the number of recent articles in a symbol detail
+	detailNews = 10
+	// detailResults is the number of recent signal results in a symbol detail
+	detailResults = 20
+)
+
+// MarketSnapshot provides live quotes and candles (implemented by data.MarketWatcher)
+type MarketSnapshot interface {
+	GetStock(symbol string) (*data.Stock, bool)
+	GetCandles(symbol string) []data.Candle
+}
+
+// IndicatorSource provides the latest indicator values of a symbol (implemented by signal.Generator)
+type IndicatorSource interface {
+	LatestIndicators(symbol string) (map[string]float64, bool)
+}
+
+// SignalHistory provides recent signals (implemented by monitor.MarketMonitor)
+type SignalHistory interface {
+	GetSignalHistory() []*signal.Signal
+}
+
+// OpenTrades provides open positions (implemented by execution.TradeManager)
+type OpenTrades interface {
+	GetActiveTrades() []*execution.Trade
+}
+
+// NewsItem is a news article about a symbol
+type NewsItem struct {
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	URL         string    `json:"url"`
+	Source      string    `json:"source"`
+	PublishedAt time.Time `json:"published_at"`
+	Sentiment   float64   `json:"sentiment"`
+}
+
+// NewsSource provides recent news about a symbol, newest first
+type NewsSource interface {
+	RecentNews(symbol string, limit int) []NewsItem
+}
+
+// PerformanceHistory provides signal outcomes (implemented by performance.Monitor)
+type PerformanceHistory interface {
+	GetMetrics() *performance.Metrics
+	GetResultsBySymbol(symbol string) []*performance.SignalResult
+	TrackRecord(symbol string, limit int) []performance.CallRecord
+}
+
+// SymbolSources are the sources aggregated by the symbol detail endpoint.
+// Sources left nil are omitted from the payload.
+type SymbolSources struct {
+	Market      MarketSnapshot
+	Indicators  IndicatorSource
+	Signals     SignalHistory
+	Trades      OpenTrades
+	News        NewsSource
+	Performance PerformanceHistory
+}
+
+// SetSymbolSources sets the sources for the symbol detail endpoint
+func (s *Server) SetSymbolSources(sources SymbolSources) {
+	s.symbolSources = sources
+}
+
+// SymbolDetail is everything known about a symbol
+type SymbolDetail struct {
+	Symbol        string                      `json:"symbol"`
+	Quote         *data.Stock                 `json:"quote,omitempty"`
+	Indicators    map[string]float64          `json:"indicators,omitempty"`
+	Candles       []data.Candle               `json:"candles"`
+	ActiveSignals []*signal.Signal            `json:"active_signals"`
+	OpenTrades    []*execution.Trade          `json:"open_trades"`
+	News          []NewsItem                  `json:"news"`
+	Performance   *performance.SymbolMetrics  `json:"performance,omitempty"`
+	TrackRecord   []performance.CallRecord    `json:"track_record"`
+	Results       []*performance.SignalResult `json:"results"` // Most recent first
+}
+
+// handleSymbolDetail returns the detail of the symbol in the path /api/v1/symbols/{symbol}
+func (s *Server) handleSymbolDetail(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	symbol := strings.ToUpper(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/v1/symbols/"), "/"))
+	if symbol == "" || strings.Contains(symbol, "/") {
+		http.Error(w, "Symbol is required", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.symbolDetail(symbol))
+}
+
+// symbolDetail aggregates the configured sources for symbol
+func (s *Server) symbolDetail(symbol string) SymbolDetail {
+	sources := s.symbolSources
+	detail := SymbolDetail{
+		Symbol:        symbol,
+		Candles:       []data.Candle{},
+		ActiveSignals: []*signal.Signal{},
+		OpenTrades:    []*execution.Trade{},
+		News:          []NewsItem{},
+		TrackRecord:   []performance.CallRecord{},
+		Results:       []*performance.SignalResult{},
+	}
+
+	if sources.Market != nil {
+		if stock, ok := sources.Market.GetStock(symbol); ok {
+			quote := *stock
+			detail.Quote = &quote
+		}
+		candles := sources.Market.GetCandles(symbol)
+		if len(candles) > detailCandles {
+			candles = candles[len(candles)-detailCandles:]
+		}
+		detail.Candles = append(detail.Candles, candles...)
+	}
+
+	if sources.Indicators != nil {
+		if values, ok := sources.Indicators.LatestIndicators(symbol); ok {
+			detail.Indicators = values
+		}
+	}
+
+	if sources.Signals != nil {
+		for _, sig := range sources.Signals.GetSignalHistory() {
+			if sig.Symbol == symbol && sig.Status == signal.StatusActive {
+				detail.ActiveSignals = append(detail.ActiveSignals, sig)
+			}
+		}
+	}
+
+	if sources.Trades != nil {
+		for _, trade := range sources.Trades.GetActiveTrades() {
+			if trade.Symbol == symbol {
+				detail.OpenTrades = append(detail.OpenTrades, trade)
+			}
+		}
+	}
+
+	if sources.News != nil {
+		detail.News = append(detail.News, sources.News.RecentNews(symbol, detailNews)...)
+	}
+
+	if sources.Performance != nil {
+		if metrics := sources.Performance.GetMetrics(); metrics != nil {
+			if symbolMetrics, ok := metrics.SymbolPerformance[symbol]; ok {
+				detail.Performance = &symbolMetrics
+			}
+		}
+		detail.TrackRecord = append(detail.TrackRecord, sources.Performance.TrackRecord(symbol, 0)...)
+
+		results := sources.Performance.GetResultsBySymbol(symbol)
+		sort.SliceStable(results, func(i, j int) bool {
+			return results[i].GeneratedAt.After(results[j].GeneratedAt)
+		})
+		if len(results) > detailResults {
+			results = results[:detailResults]
+		}
+		detail.Results = append(detail.Results, results...)
+	}
+
+	return detail
+}
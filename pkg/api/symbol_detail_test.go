@@ -0,0 +1,106 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/execution"
+	"github.com/hustler/trading-bot/pkg/performance"
+	"github.com/hustler/trading-bot/pkg/signal"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeMarket struct{}
+
+func (fakeMarket) GetStock(symbol string) (*data.Stock, bool) {
+	if symbol != "AAPL" {
+		return nil, false
+	}
+	return &data.Stock{Symbol: "AAPL", CurrentPrice: 101}, true
+}
+
+func (fakeMarket) GetCandles(symbol string) []data.Candle {
+	candles := make([]data.Candle, detailCandles+10)
+	for i := range candles {
+		candles[i] = data.Candle{Close: float64(i)}
+	}
+	return candles
+}
+
+type fakeIndicators map[string]map[string]float64
+
+func (f fakeIndicators) LatestIndicators(symbol string) (map[string]float64, bool) {
+	values, ok := f[symbol]
+	return values, ok
+}
+
+type fakeSignals []*signal.Signal
+
+func (f fakeSignals) GetSignalHistory() []*signal.Signal { return f }
+
+type fakeTrades []*execution.Trade
+
+func (f fakeTrades) GetActiveTrades() []*execution.Trade { return f }
+
+type fakeNews struct{}
+
+func (fakeNews) RecentNews(symbol string, limit int) []NewsItem {
+	return []NewsItem{{Title: symbol + " beats estimates"}}
+}
+
+func TestSymbolDetail(t *testing.T) {
+	perf := performance.NewMonitor()
+	old := &signal.Signal{ID: "SIG-1", Symbol: "AAPL", Type: signal.BUY, Price: 100, GeneratedAt: time.Now().Add(-time.Hour)}
+	perf.AddSignal(old)
+	perf.UpdateSignalStatus(old.ID, performance.StatusSuccess, 102)
+
+	server := NewServer("0", nil)
+	server.SetSymbolSources(SymbolSources{
+		Market:     fakeMarket{},
+		Indicators: fakeIndicators{"AAPL": {"rsi": 55}},
+		Signals: fakeSignals{
+			{ID: "SIG-2", Symbol: "AAPL", Status: "ACTIVE"},
+			{ID: "SIG-3", Symbol: "AAPL", Status: "SUCCESS"},
+			{ID: "SIG-4", Symbol: "MSFT", Status: "ACTIVE"},
+		},
+		Trades:      fakeTrades{{ID: "T-1", Symbol: "AAPL"}, {ID: "T-2", Symbol: "MSFT"}},
+		News:        fakeNews{},
+		Performance: perf,
+	})
+
+	recorder := httptest.NewRecorder()
+	server.handleSymbolDetail(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/symbols/aapl", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var detail SymbolDetail
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &detail))
+	assert.Equal(t, "AAPL", detail.Symbol)
+	assert.Equal(t, 101.0, detail.Quote.CurrentPrice)
+	assert.Equal(t, 55.0, detail.Indicators["rsi"])
+	assert.Len(t, detail.Candles, detailCandles)
+	assert.Equal(t, 10.0, detail.Candles[0].Close)
+	assert.Len(t, detail.ActiveSignals, 1)
+	assert.Equal(t, "SIG-2", detail.ActiveSignals[0].ID)
+	assert.Len(t, detail.OpenTrades, 1)
+	assert.Equal(t, "AAPL beats estimates", detail.News[0].Title)
+	assert.Equal(t, 1, detail.Performance.SuccessCount)
+	assert.Len(t, detail.TrackRecord, 1)
+	assert.Len(t, detail.Results, 1)
+}
+
+func TestSymbolDetailWithoutSources(t *testing.T) {
+	server := NewServer("0", nil)
+
+	recorder := httptest.NewRecorder()
+	server.handleSymbolDetail(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/symbols/TSLA", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, `{"symbol":"TSLA","candles":[],"active_signals":[],"open_trades":[],"news":[],"track_record":[],"results":[]}`, recorder.Body.String())
+
+	recorder = httptest.NewRecorder()
+	server.handleSymbolDetail(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/symbols/", nil))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/symbols"
+)
+
+// SymbolLists manages the symbol blacklist and allowlist (implemented by symbols.Lists)
+type SymbolLists interface {
+	Add(list, symbol, reason, addedBy string, expiresAt time.Time) (symbols.Entry, error)
+	Remove(list, symbol string) error
+	Entries(list string) []symbols.Entry
+}
+
+// SetSymbolLists sets the lists managed by the symbol lists endpoint
+func (s *Server) SetSymbolLists(lists SymbolLists) {
+	s.symbolLists = lists
+}
+
+// addSymbolRequest represents a request to put a symbol on a list
+type addSymbolRequest struct {
+	List      string    `json:"list"` // blacklist or allowlist
+	Symbol    string    `json:"symbol"`
+	Reason    string    `json:"reason"`
+	ExpiresAt time.Time `json:"expires_at"` // Optional; omitted means the entry never expires
+}
+
+// handleSymbolLists lists (GET), adds (POST) and removes (DELETE) blacklist and allowlist entries
+func (s *Server) handleSymbolLists(w http.ResponseWriter, r *http.Request) {
+	if s.symbolLists == nil {
+		http.Error(w, "Symbol lists not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(s.symbolLists.Entries(r.URL.Query().Get("list")))
+
+	case http.MethodPost:
+		var req addSymbolRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		entry, err := s.symbolLists.Add(req.List, req.Symbol, req.Reason, "api", req.ExpiresAt)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(entry)
+
+	case http.MethodDelete:
+		query := r.URL.Query()
+		if err := s.symbolLists.Remove(query.Get("list"), query.Get("symbol")); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// TestSignalSender pushes a labeled test signal through the real signal
+// pipeline (implemented by monitor.MarketMonitor)
+type TestSignalSender interface {
+	SendTestSignal(symbol string) (*signal.Signal, error)
+}
+
+// testSignalRequest represents a request to send a test signal
+type testSignalRequest struct {
+	Symbol string `json:"symbol"`
+}
+
+// SetTestSignalSender sets the handler for the test signal endpoint
+func (s *Server) SetTestSignalSender(sender TestSignalSender) {
+	s.testSignals = sender
+}
+
+// handleTestSignal sends a TEST signal for the requested symbol and returns it
+func (s *Server) handleTestSignal(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if s.testSignals == nil {
+		http.Error(w, "Test signals not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	var req testSignalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || strings.TrimSpace(req.Symbol) == "" {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	sent, err := s.testSignals.SendTestSignal(strings.ToUpper(strings.TrimSpace(req.Symbol)))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(sent)
+}
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hustler/trading-bot/pkg/monitor"
+)
+
+// ShadowSource reports the signals held back by the daily caps (implemented by monitor.MarketMonitor)
+type ShadowSource interface {
+	GetShadowReport() monitor.ShadowReport
+}
+
+// SetShadowSource sets the source for the throttled signals endpoint
+func (s *Server) SetShadowSource(source ShadowSource) {
+	s.shadow = source
+}
+
+// handleThrottled returns today's published count and the signals held back
+// by the daily caps, with how they would have done
+func (s *Server) handleThrottled(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.shadow == nil {
+		http.Error(w, "Throttled signals not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.shadow.GetShadowReport())
+}
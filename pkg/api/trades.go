@@ -0,0 +1,125 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/execution"
+)
+
+// TradeDesk modifies and closes open trades on behalf of a user, recording
+// every change in the audit log (implemented by execution.TradeManager)
+type TradeDesk interface {
+	GetActiveTrades() []*execution.Trade
+	GetTrade(tradeID string) (*execution.Trade, bool)
+	ChangeStop(tradeID string, stopPrice float64, actor string) (*execution.Trade, error)
+	ReduceTrade(tradeID string, quantity int, stock *data.Stock, actor string) (*execution.Trade, error)
+	CloseTrade(tradeID string, stock *data.Stock, actor string) (*execution.Trade, error)
+}
+
+// QuoteLookup provides the latest quote of a symbol (implemented by data.MarketWatcher)
+type QuoteLookup interface {
+	GetStock(symbol string) (*data.Stock, bool)
+}
+
+// SetTradeDesk sets the trades behind the trade endpoints and the quotes
+// reductions and closes are priced at
+func (s *Server) SetTradeDesk(desk TradeDesk, quotes QuoteLookup) {
+	s.tradeDesk = desk
+	s.quotes = quotes
+}
+
+// Trade modification actions
+const (
+	tradeActionStop   = "stop"
+	tradeActionReduce = "reduce"
+	tradeActionClose  = "close"
+)
+
+// modifyTradeRequest represents a change to an open trade
+type modifyTradeRequest struct {
+	TradeID   string  `json:"trade_id"`
+	Action    string  `json:"action"`               // stop, reduce or close
+	StopPrice float64 `json:"stop_price,omitempty"` // New stop for stop
+	Quantity  int     `json:"quantity,omitempty"`   // Shares to exit for reduce
+}
+
+// handleTrades returns the open positions
+func (s *Server) handleTrades(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.tradeDesk == nil {
+		http.Error(w, "Trading not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.tradeDesk.GetActiveTrades())
+}
+
+// handleModifyTrade changes the stop of, reduces or closes an open trade. It
+// returns the position for a stop change and the exit trade otherwise.
+func (s *Server) handleModifyTrade(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.tradeDesk == nil {
+		http.Error(w, "Trading not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	var req modifyTradeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.TradeID == "" {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	position, ok := s.tradeDesk.GetTrade(req.TradeID)
+	if !ok || position.Status != execution.Executed || position.PositionID != "" {
+		http.Error(w, "Open trade not found", http.StatusNotFound)
+		return
+	}
+
+	var trade *execution.Trade
+	var err error
+	switch req.Action {
+	case tradeActionStop:
+		trade, err = s.tradeDesk.ChangeStop(req.TradeID, req.StopPrice, Username(r))
+	case tradeActionReduce, tradeActionClose:
+		stock, quoted := s.quote(position.Symbol)
+		if !quoted {
+			http.Error(w, "No quote for "+position.Symbol, http.StatusServiceUnavailable)
+			return
+		}
+		if req.Action == tradeActionReduce {
+			trade, err = s.tradeDesk.ReduceTrade(req.TradeID, req.Quantity, stock, Username(r))
+		} else {
+			trade, err = s.tradeDesk.CloseTrade(req.TradeID, stock, Username(r))
+		}
+	default:
+		http.Error(w, "Action must be stop, reduce or close", http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(trade)
+}
+
+// quote returns the latest quote of a symbol
+func (s *Server) quote(symbol string) (*data.Stock, bool) {
+	if s.quotes == nil {
+		return nil, false
+	}
+	stock, ok := s.quotes.GetStock(symbol)
+	if !ok || stock.CurrentPrice <= 0 {
+		return nil, false
+	}
+	return stock, true
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/hustler/trading-bot/pkg/symbols"
+)
+
+// WatchlistImporter adds symbols from CSV files to the watchlist (implemented by symbols.Importer)
+type WatchlistImporter interface {
+	ImportCSV(r io.Reader) (symbols.ImportResult, error)
+	ImportURL(ctx context.Context, url string) (symbols.ImportResult, error)
+}
+
+// SetWatchlistImporter sets the importer for the watchlist import endpoint
+func (s *Server) SetWatchlistImporter(importer WatchlistImporter) {
+	s.importer = importer
+}
+
+// importWatchlistRequest represents a request to import a watchlist from a URL
+type importWatchlistRequest struct {
+	URL string `json:"url"`
+}
+
+// handleImportWatchlist imports a watchlist from a JSON body with a CSV url, a
+// multipart upload in the file field, or a raw CSV body
+func (s *Server) handleImportWatchlist(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.importer == nil {
+		http.Error(w, "Watchlist import not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	var result symbols.ImportResult
+	var err error
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/json":
+		var req importWatchlistRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.URL == "" {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		result, err = s.importer.ImportURL(r.Context(), req.URL)
+
+	case "multipart/form-data":
+		file, _, formErr := r.FormFile("file")
+		if formErr != nil {
+			http.Error(w, "Missing file upload", http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		result, err = s.importer.ImportCSV(file)
+
+	default:
+		result, err = s.importer.ImportCSV(r.Body)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
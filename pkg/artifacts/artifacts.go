@@ -0,0 +1,168 @@
+package artifacts
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/objectstore"
+)
+
+// Kinds of artifacts, each stored under its own prefix with its own retention
+const (
+	KindReport = "reports" // Generated pages and reports
+	KindExport = "exports" // Data exports, e.g. CSV or JSON lines
+	KindChart  = "charts"  // Chart images
+)
+
+// defaultURLExpiry is how long signed URLs stay valid when the config leaves it at zero
+const defaultURLExpiry = 24 * time.Hour
+
+// pruneInterval is how often expired artifacts are deleted
+const pruneInterval = 6 * time.Hour
+
+// Storage keeps artifacts (implemented by objectstore.S3)
+type Storage interface {
+	Put(key string, data []byte, contentType string) error
+	List(prefix string) ([]objectstore.Object, error)
+	Delete(key string) error
+	PresignGet(key string, expires time.Duration) (string, error)
+}
+
+// Artifact is a stored artifact and a signed URL to share it with
+type Artifact struct {
+	Kind      string    `json:"kind"`
+	Key       string    `json:"key"`
+	Size      int64     `json:"size"`
+	CreatedAt time.Time `json:"created_at"`
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"url_expires_at"`
+}
+
+// Uploader stores artifacts by kind and deletes them past their retention
+type Uploader struct {
+	config  config.ArtifactsConfig
+	storage Storage
+	expiry  time.Duration
+	now     func() time.Time
+}
+
+// NewUploader creates an uploader storing artifacts in storage
+func NewUploader(cfg config.ArtifactsConfig, storage Storage) *Uploader {
+	expiry := time.Duration(cfg.URLExpiryHours) * time.Hour
+	if expiry == 0 {
+		expiry = defaultURLExpiry
+	}
+	return &Uploader{config: cfg, storage: storage, expiry: expiry, now: time.Now}
+}
+
+// Upload stores an artifact of a kind and returns it with a signed URL. Keys
+// are the kind, the upload time and the name, so that uploads under the same
+// name are kept apart.
+func (u *Uploader) Upload(kind, name string, data []byte, contentType string) (Artifact, error) {
+	now := u.now().UTC()
+	key := u.prefix(kind) + now.Format("2006/01/02/150405-") + path.Base(name)
+	if err := u.storage.Put(key, data, contentType); err != nil {
+		return Artifact{}, fmt.Errorf("failed to upload %s: %w", name, err)
+	}
+	return u.artifact(kind, objectstore.Object{Key: key, Size: int64(len(data)), LastModified: now})
+}
+
+// UploadFile stores a local file as an artifact of a kind
+func (u *Uploader) UploadFile(kind, file, contentType string) (Artifact, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return Artifact{}, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	return u.Upload(kind, filepath.Base(file), data, contentType)
+}
+
+// List returns the stored artifacts of a kind with fresh signed URLs, newest first
+func (u *Uploader) List(kind string) ([]Artifact, error) {
+	objects, err := u.storage.List(u.prefix(kind))
+	if err != nil {
+		return nil, err
+	}
+
+	artifacts := make([]Artifact, 0, len(objects))
+	for i := len(objects) - 1; i >= 0; i-- {
+		artifact, err := u.artifact(kind, objects[i])
+		if err != nil {
+			return nil, err
+		}
+		artifacts = append(artifacts, artifact)
+	}
+	return artifacts, nil
+}
+
+// Prune deletes the artifacts older than their kind's retention and returns
+// how many it deleted
+func (u *Uploader) Prune() (int, error) {
+	now := u.now()
+	deleted := 0
+	for kind, days := range u.config.RetentionDays {
+		if days <= 0 {
+			continue
+		}
+		objects, err := u.storage.List(u.prefix(kind))
+		if err != nil {
+			return deleted, err
+		}
+		cutoff := now.AddDate(0, 0, -days)
+		for _, object := range objects {
+			if !object.LastModified.Before(cutoff) {
+				continue
+			}
+			if err := u.storage.Delete(object.Key); err != nil {
+				return deleted, err
+			}
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// Run deletes expired artifacts now and then periodically until stop is closed
+func (u *Uploader) Run(stop <-chan struct{}) {
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+
+	for {
+		if deleted, err := u.Prune(); err != nil {
+			log.Printf("Error deleting expired artifacts: %v", err)
+		} else if deleted > 0 {
+			log.Printf("Deleted %d expired artifacts", deleted)
+		}
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// prefix returns the key prefix of a kind's artifacts
+func (u *Uploader) prefix(kind string) string {
+	return u.config.Storage.Prefix + strings.Trim(kind, "/") + "/"
+}
+
+// artifact describes a stored object with a signed URL
+func (u *Uploader) artifact(kind string, object objectstore.Object) (Artifact, error) {
+	url, err := u.storage.PresignGet(object.Key, u.expiry)
+	if err != nil {
+		return Artifact{}, fmt.Errorf("failed to sign URL of %s: %w", object.Key, err)
+	}
+	return Artifact{
+		Kind:      kind,
+		Key:       object.Key,
+		Size:      object.Size,
+		CreatedAt: object.LastModified,
+		URL:       url,
+		ExpiresAt: u.now().Add(u.expiry),
+	}, nil
+}
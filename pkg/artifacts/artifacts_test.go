@@ -0,0 +1,81 @@
+package artifacts
+
+import (
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/objectstore"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryStorage keeps objects in memory
+type memoryStorage struct {
+	objects map[string]objectstore.Object
+	now     time.Time
+}
+
+func (m *memoryStorage) Put(key string, data []byte, contentType string) error {
+	m.objects[key] = objectstore.Object{Key: key, Size: int64(len(data)), LastModified: m.now}
+	return nil
+}
+
+func (m *memoryStorage) List(prefix string) ([]objectstore.Object, error) {
+	var objects []objectstore.Object
+	for key, object := range m.objects {
+		if strings.HasPrefix(key, prefix) {
+			objects = append(objects, object)
+		}
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return objects, nil
+}
+
+func (m *memoryStorage) Delete(key string) error {
+	delete(m.objects, key)
+	return nil
+}
+
+func (m *memoryStorage) PresignGet(key string, expires time.Duration) (string, error) {
+	return "https://bucket.example/" + key + "?expires=" + expires.String(), nil
+}
+
+func TestUploadListAndPrune(t *testing.T) {
+	now := time.Date(2026, 10, 16, 14, 30, 0, 0, time.UTC)
+	storage := &memoryStorage{objects: make(map[string]objectstore.Object), now: now.AddDate(0, 0, -40)}
+	cfg := config.ArtifactsConfig{
+		Storage:       config.S3Config{Prefix: "artifacts/"},
+		RetentionDays: map[string]int{KindExport: 30, KindReport: 0},
+	}
+	uploader := NewUploader(cfg, storage)
+	uploader.now = func() time.Time { return storage.now }
+
+	_, err := uploader.Upload(KindExport, "old.jsonl", []byte("{}\n"), "application/x-ndjson")
+	assert.NoError(t, err)
+	_, err = uploader.Upload(KindReport, "old.html", []byte("<html>"), "text/html")
+	assert.NoError(t, err)
+
+	storage.now = now
+	artifact, err := uploader.Upload(KindExport, "exports/history.jsonl", []byte("{}\n{}\n"), "application/x-ndjson")
+	assert.NoError(t, err)
+	assert.Equal(t, "artifacts/exports/2026/10/16/143000-history.jsonl", artifact.Key)
+	assert.Equal(t, "https://bucket.example/artifacts/exports/2026/10/16/143000-history.jsonl?expires=24h0m0s", artifact.URL)
+	assert.Equal(t, now.Add(24*time.Hour), artifact.ExpiresAt)
+
+	exports, err := uploader.List(KindExport)
+	assert.NoError(t, err)
+	if assert.Len(t, exports, 2) {
+		assert.Equal(t, artifact.Key, exports[0].Key, "newest first")
+	}
+
+	// Exports past 30 days are deleted, reports are kept
+	deleted, err := uploader.Prune()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	exports, _ = uploader.List(KindExport)
+	assert.Len(t, exports, 1)
+	reports, _ := uploader.List(KindReport)
+	assert.Len(t, reports, 1)
+}
@@ -0,0 +1,252 @@
+package attribution
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/execution"
+	"github.com/hustler/trading-bot/pkg/performance"
+	"github.com/hustler/trading-bot/pkg/strategy"
+)
+
+// maxListedSignals caps the signals listed in the weekly report
+const maxListedSignals = 20
+
+// ResultSource provides signal outcomes (implemented by performance.Monitor)
+type ResultSource interface {
+	GetResults() []*performance.SignalResult
+}
+
+// TradeSource provides the trades entered for signals (implemented by execution.TradeManager)
+type TradeSource interface {
+	SignalTrades() []execution.Trade
+}
+
+// Entry follows one published signal through to its trade
+type Entry struct {
+	SignalID    string                   `json:"signal_id"`
+	Symbol      string                   `json:"symbol"`
+	Type        string                   `json:"type"`
+	GeneratedAt time.Time                `json:"generated_at"`
+	Status      performance.SignalStatus `json:"status"`
+	StatedEntry float64                  `json:"stated_entry"`
+	ExpectedROI float64                  `json:"expected_roi"`
+	ActualROI   float64                  `json:"actual_roi"` // ROI of the signal at its close, from the stated entry
+	Strategies  []string                 `json:"strategies,omitempty"`
+	Regime      string                   `json:"regime,omitempty"`
+	ParamSet    string                   `json:"param_set,omitempty"`
+
+	Executed    bool    `json:"executed"`
+	TradeID     string  `json:"trade_id,omitempty"`
+	FillPrice   float64 `json:"fill_price,omitempty"`
+	SlippagePct float64 `json:"slippage_pct"` // Percent the fill was worse (positive) or better than the stated entry
+	TradeROI    float64 `json:"trade_roi"`    // ROI realized by the position's exits so far, from its entry fills
+	TradeClosed bool    `json:"trade_closed"`
+}
+
+// Report joins the signals generated in a period with the trades taken on
+// them and their outcomes
+type Report struct {
+	From           time.Time `json:"from"`
+	To             time.Time `json:"to"`
+	Signals        []Entry   `json:"signals"`
+	Executed       int       `json:"executed"`
+	ExecutionRate  float64   `json:"execution_rate"` // Percent of signals traded
+	AvgSlippagePct float64   `json:"avg_slippage_pct"`
+	Closed         int       `json:"closed"` // Signals that hit target or stop, or expired
+	AvgExpectedROI float64   `json:"avg_expected_roi"`
+	AvgActualROI   float64   `json:"avg_actual_roi"`
+	ClosedTrades   int       `json:"closed_trades"`
+	AvgTradeROI    float64   `json:"avg_trade_roi"`
+	ByStrategy     []Group   `json:"by_strategy"`
+	ByParamSet     []Group   `json:"by_param_set"`
+	ByRegime       []Group   `json:"by_regime"`
+}
+
+// Reporter builds attribution reports from signal outcomes and trades
+type Reporter struct {
+	results ResultSource
+	trades  TradeSource
+}
+
+// NewReporter creates a reporter. trades may be nil when no trades are taken,
+// in which case every signal is reported as not executed.
+func NewReporter(results ResultSource, trades TradeSource) *Reporter {
+	return &Reporter{results: results, trades: trades}
+}
+
+// Report reports on the signals generated from from until to, oldest first
+func (r *Reporter) Report(from, to time.Time) Report {
+	var results []performance.SignalResult
+	for _, result := range r.results.GetResults() {
+		if !result.GeneratedAt.Before(from) && result.GeneratedAt.Before(to) {
+			results = append(results, *result)
+		}
+	}
+
+	var trades []execution.Trade
+	if r.trades != nil {
+		trades = r.trades.SignalTrades()
+	}
+	report := Attribute(results, trades)
+	report.From, report.To = from, to
+	return report
+}
+
+// Attribute joins signal results with the trades entered for them
+func Attribute(results []performance.SignalResult, trades []execution.Trade) Report {
+	bySignal := make(map[string]execution.Trade, len(trades))
+	for _, trade := range trades {
+		if trade.SignalID != "" {
+			bySignal[trade.SignalID] = trade
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].GeneratedAt.Before(results[j].GeneratedAt)
+	})
+
+	report := Report{Signals: make([]Entry, 0, len(results))}
+	var slippage, expected, actual, tradeROI float64
+	for _, result := range results {
+		entry := Entry{
+			SignalID:    result.SignalID,
+			Symbol:      result.Symbol,
+			Type:        result.Type,
+			GeneratedAt: result.GeneratedAt,
+			Status:      result.Status,
+			StatedEntry: result.EntryPrice,
+			ExpectedROI: result.ExpectedROI,
+			ActualROI:   result.ActualROI,
+			Strategies:  result.Strategies,
+			Regime:      result.Regime,
+			ParamSet:    result.ParamSet,
+		}
+		if result.Status != performance.StatusActive {
+			report.Closed++
+			expected += result.ExpectedROI
+			actual += result.ActualROI
+		}
+
+		if trade, ok := bySignal[result.SignalID]; ok {
+			attributeTrade(&entry, trade)
+			report.Executed++
+			slippage += entry.SlippagePct
+			if entry.TradeClosed {
+				report.ClosedTrades++
+				tradeROI += entry.TradeROI
+			}
+		}
+		report.Signals = append(report.Signals, entry)
+	}
+
+	if len(results) > 0 {
+		report.ExecutionRate = float64(report.Executed) / float64(len(results)) * 100
+	}
+	if report.Executed > 0 {
+		report.AvgSlippagePct = slippage / float64(report.Executed)
+	}
+	if report.Closed > 0 {
+		report.AvgExpectedROI = expected / float64(report.Closed)
+		report.AvgActualROI = actual / float64(report.Closed)
+	}
+	if report.ClosedTrades > 0 {
+		report.AvgTradeROI = tradeROI / float64(report.ClosedTrades)
+	}
+
+	report.ByStrategy = breakdown(report.Signals, strategiesOf)
+	report.ByParamSet = breakdown(report.Signals, paramSetOf)
+	report.ByRegime = breakdown(report.Signals, regimeOf)
+	return report
+}
+
+// attributeTrade adds the fill, slippage and realized ROI of the trade
+// entered for a signal. A scale-in trade has no fills of its own, so its price
+// is the fill and no ROI is attributed to it.
+func attributeTrade(entry *Entry, trade execution.Trade) {
+	entry.Executed = true
+	entry.TradeID = trade.ID
+	entry.FillPrice = trade.Price
+
+	// Positions record every fill; the first on the position's side (a buy for
+	// a long, a sell for a short) is the entry for the signal
+	var entered, enteredCost, exited, exitedValue float64
+	for _, fill := range trade.Fills {
+		quantity := float64(fill.Quantity)
+		if fill.Side == trade.Type {
+			if entered == 0 {
+				entry.FillPrice = fill.Price
+			}
+			entered += quantity
+			enteredCost += quantity * fill.Price
+		} else {
+			exited += quantity
+			exitedValue += quantity * fill.Price
+		}
+	}
+
+	if entry.StatedEntry > 0 {
+		entry.SlippagePct = (entry.FillPrice - entry.StatedEntry) / entry.StatedEntry * 100
+		if entry.Type == "SELL" {
+			entry.SlippagePct = -entry.SlippagePct
+		}
+	}
+	if entered > 0 && exited > 0 {
+		average := enteredCost / entered
+		entry.TradeROI = (exitedValue/exited - average) / average * 100
+		if trade.Type == strategy.Sell {
+			entry.TradeROI = -entry.TradeROI
+		}
+	}
+	entry.TradeClosed = trade.Status == execution.Completed
+}
+
+// FormatReport formats an attribution report for the weekly Telegram summary
+func FormatReport(report Report) string {
+	message := "📒 <b>WEEKLY SIGNAL ATTRIBUTION</b>\n"
+	message += fmt.Sprintf("%s – %s\n\n", report.From.Format("Jan 2"), report.To.Format("Jan 2, 2006"))
+
+	if len(report.Signals) == 0 {
+		return message + "No signals were published this week."
+	}
+
+	message += fmt.Sprintf("📡 Signals: %d, executed: %d (%.0f%%)\n", len(report.Signals), report.Executed, report.ExecutionRate)
+	if report.Executed > 0 {
+		message += fmt.Sprintf("🎯 Avg slippage vs stated entry: %+.2f%%\n", report.AvgSlippagePct)
+	}
+	if report.Closed > 0 {
+		message += fmt.Sprintf("📈 Closed signals: %d, ROI %+.2f%% actual vs %+.2f%% expected\n",
+			report.Closed, report.AvgActualROI, report.AvgExpectedROI)
+	}
+	if report.ClosedTrades > 0 {
+		message += fmt.Sprintf("💼 Closed trades: %d, avg ROI %+.2f%%\n", report.ClosedTrades, report.AvgTradeROI)
+	}
+
+	// Parameter sets and regimes are only broken down when there is more than one
+	message += "\n" + formatGroups("By strategy", report.ByStrategy)
+	if len(report.ByParamSet) > 1 {
+		message += formatGroups("By parameter set", report.ByParamSet)
+	}
+	if len(report.ByRegime) > 1 {
+		message += formatGroups("By regime", report.ByRegime)
+	}
+
+	message += "\n"
+	for i, entry := range report.Signals {
+		if i == maxListedSignals {
+			message += fmt.Sprintf("…and %d more\n", len(report.Signals)-maxListedSignals)
+			break
+		}
+		line := fmt.Sprintf("• %s %s @ $%.2f: ", entry.Type, entry.Symbol, entry.StatedEntry)
+		if entry.Executed {
+			line += fmt.Sprintf("filled $%.2f (%+.2f%%)", entry.FillPrice, entry.SlippagePct)
+		} else {
+			line += "not traded"
+		}
+		if entry.Status != performance.StatusActive {
+			line += fmt.Sprintf(", %s %+.2f%% vs %+.2f%% expected", entry.Status, entry.ActualROI, entry.ExpectedROI)
+		}
+		message += line + "\n"
+	}
+	return message
+}
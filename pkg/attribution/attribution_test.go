@@ -0,0 +1,81 @@
+package attribution
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/execution"
+	"github.com/hustler/trading-bot/pkg/market"
+	"github.com/hustler/trading-bot/pkg/performance"
+	"github.com/hustler/trading-bot/pkg/signal"
+	"github.com/hustler/trading-bot/pkg/strategy"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingSender struct {
+	messages []string
+}
+
+func (r *recordingSender) SendMessage(message string) error {
+	r.messages = append(r.messages, message)
+	return nil
+}
+
+func TestReportJoinsSignalsTradesAndOutcomes(t *testing.T) {
+	generated := time.Date(2025, 4, 14, 14, 0, 0, 0, time.UTC)
+	perf := performance.NewMonitor()
+	perf.AddSignal(&signal.Signal{ID: "SIG-AAPL", Symbol: "AAPL", Type: signal.BUY, Price: 100, TargetPrice: 104, ExpectedROI: 4, GeneratedAt: generated})
+	perf.AddSignal(&signal.Signal{ID: "SIG-MSFT", Symbol: "MSFT", Type: signal.BUY, Price: 200, TargetPrice: 210, ExpectedROI: 5, GeneratedAt: generated.Add(time.Hour)})
+	perf.AddSignal(&signal.Signal{ID: "SIG-OLD", Symbol: "TSLA", Type: signal.BUY, Price: 50, GeneratedAt: generated.AddDate(0, 0, -10)})
+	perf.UpdateSignalStatus("SIG-AAPL", performance.StatusSuccess, 104)
+
+	trades := execution.NewTradeManager(1000, 100)
+	_, err := trades.ExecuteTrade(&strategy.TradeDecision{Symbol: "AAPL", Signal: strategy.Buy, SignalID: "SIG-AAPL"}, &data.Stock{Symbol: "AAPL", CurrentPrice: 101})
+	assert.NoError(t, err)
+	trades.CloseAllPositions(map[string]*data.Stock{"AAPL": {Symbol: "AAPL", CurrentPrice: 103.02}})
+
+	report := NewReporter(perf, trades).Report(generated.AddDate(0, 0, -7), generated.AddDate(0, 0, 1))
+
+	assert.Len(t, report.Signals, 2)
+	aapl, msft := report.Signals[0], report.Signals[1]
+	assert.True(t, aapl.Executed)
+	assert.Equal(t, 101.0, aapl.FillPrice)
+	assert.InDelta(t, 1.0, aapl.SlippagePct, 1e-9)
+	assert.True(t, aapl.TradeClosed)
+	assert.InDelta(t, 2.0, aapl.TradeROI, 1e-9)
+	assert.False(t, msft.Executed)
+
+	assert.Equal(t, 1, report.Executed)
+	assert.Equal(t, 50.0, report.ExecutionRate)
+	assert.Equal(t, 1, report.Closed)
+	assert.Equal(t, 4.0, report.AvgExpectedROI)
+	assert.InDelta(t, 2.0, report.AvgTradeROI, 1e-9)
+
+	message := FormatReport(report)
+	assert.Contains(t, message, "executed: 1 (50%)")
+	assert.Contains(t, message, "BUY MSFT @ $200.00: not traded")
+}
+
+func TestWeeklyJobPostsOncePerWeek(t *testing.T) {
+	session := market.DefaultClock().Location()
+	friday := time.Date(2025, 4, 18, 16, 0, 0, 0, session)
+	fake := clock.NewFake(friday)
+	sender := &recordingSender{}
+
+	job := NewWeeklyJob(NewReporter(performance.NewMonitor(), nil), sender, config.AttributionConfig{WeeklyReport: true, ReportWeekday: "friday", ReportHour: 17})
+	job.SetClock(fake)
+
+	assert.False(t, job.RunIfDue())
+	fake.Set(friday.Add(time.Hour))
+	assert.True(t, job.RunIfDue())
+	fake.Set(friday.Add(2 * time.Hour))
+	assert.False(t, job.RunIfDue())
+	fake.Set(friday.AddDate(0, 0, 7).Add(time.Hour))
+	assert.True(t, job.RunIfDue())
+
+	assert.Len(t, sender.messages, 2)
+	assert.Contains(t, sender.messages[0], "No signals were published this week.")
+}
@@ -0,0 +1,121 @@
+package attribution
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hustler/trading-bot/pkg/performance"
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// unlabeled groups signals generated without a regime classifier
+const unlabeled = "none"
+
+// Group is the performance of the signals sharing a strategy, parameter set
+// or market regime
+type Group struct {
+	Key           string  `json:"key"`
+	Signals       int     `json:"signals"`
+	Closed        int     `json:"closed"`
+	Successes     int     `json:"successes"`
+	SuccessRate   float64 `json:"success_rate"`   // Percent of closed signals that hit their target
+	AvgActualROI  float64 `json:"avg_actual_roi"` // Per closed signal
+	TotalROI      float64 `json:"total_roi"`      // Sum over closed signals: the group's share of the returns
+	Executed      int     `json:"executed"`
+	ClosedTrades  int     `json:"closed_trades"`
+	AvgTradeROI   float64 `json:"avg_trade_roi"`
+	totalTradeROI float64
+}
+
+// breakdown groups entries by the keys each belongs to, best total ROI first.
+// An entry with several keys, such as a signal several strategies voted for,
+// counts in full toward each.
+func breakdown(entries []Entry, keys func(Entry) []string) []Group {
+	groups := make(map[string]*Group)
+	for _, entry := range entries {
+		for _, key := range keys(entry) {
+			group, ok := groups[key]
+			if !ok {
+				group = &Group{Key: key}
+				groups[key] = group
+			}
+			group.add(entry)
+		}
+	}
+
+	result := make([]Group, 0, len(groups))
+	for _, group := range groups {
+		if group.Closed > 0 {
+			group.SuccessRate = float64(group.Successes) / float64(group.Closed) * 100
+			group.AvgActualROI = group.TotalROI / float64(group.Closed)
+		}
+		if group.ClosedTrades > 0 {
+			group.AvgTradeROI = group.totalTradeROI / float64(group.ClosedTrades)
+		}
+		result = append(result, *group)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].TotalROI != result[j].TotalROI {
+			return result[i].TotalROI > result[j].TotalROI
+		}
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
+
+// add counts an entry toward the group
+func (g *Group) add(entry Entry) {
+	g.Signals++
+	if entry.Status != performance.StatusActive {
+		g.Closed++
+		g.TotalROI += entry.ActualROI
+		if entry.Status == performance.StatusSuccess {
+			g.Successes++
+		}
+	}
+	if entry.Executed {
+		g.Executed++
+		if entry.TradeClosed {
+			g.ClosedTrades++
+			g.totalTradeROI += entry.TradeROI
+		}
+	}
+}
+
+// strategiesOf returns the strategies that voted for a signal. Signals
+// without ensemble votes come from the volatility strategy.
+func strategiesOf(entry Entry) []string {
+	if len(entry.Strategies) == 0 {
+		return []string{"volatility"}
+	}
+	return entry.Strategies
+}
+
+// paramSetOf returns the parameter set a signal was generated with
+func paramSetOf(entry Entry) []string {
+	if entry.ParamSet == "" {
+		return []string{signal.ParamSetDefault}
+	}
+	return []string{entry.ParamSet}
+}
+
+// regimeOf returns the market regime a signal was generated in
+func regimeOf(entry Entry) []string {
+	if entry.Regime == "" {
+		return []string{unlabeled}
+	}
+	return []string{entry.Regime}
+}
+
+// formatGroups formats a breakdown for the weekly summary
+func formatGroups(title string, groups []Group) string {
+	message := fmt.Sprintf("<b>%s</b>\n", title)
+	for _, group := range groups {
+		message += fmt.Sprintf("• %s: %d signals", group.Key, group.Signals)
+		if group.Closed > 0 {
+			message += fmt.Sprintf(", %.0f%% of %d closed hit target, total ROI %+.2f%%", group.SuccessRate, group.Closed, group.TotalROI)
+		}
+		message += "\n"
+	}
+	return message
+}
@@ -0,0 +1,44 @@
+package attribution
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/performance"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttributeBreaksDownByStrategyParamSetAndRegime(t *testing.T) {
+	generated := time.Date(2025, 4, 14, 14, 0, 0, 0, time.UTC)
+	results := []performance.SignalResult{
+		{SignalID: "1", Symbol: "AAPL", Status: performance.StatusSuccess, ActualROI: 3, GeneratedAt: generated,
+			Strategies: []string{"momentum", "volatility"}, Regime: "TRENDING", ParamSet: "TRENDING"},
+		{SignalID: "2", Symbol: "MSFT", Status: performance.StatusFailure, ActualROI: -1, GeneratedAt: generated,
+			Strategies: []string{"mean_reversion"}, Regime: "RANGING", ParamSet: "tuned"},
+		{SignalID: "3", Symbol: "NVDA", Status: performance.StatusActive, GeneratedAt: generated},
+	}
+
+	report := Attribute(results, nil)
+
+	assert.Len(t, report.ByStrategy, 3)
+	assert.Equal(t, Group{Key: "momentum", Signals: 1, Closed: 1, Successes: 1, SuccessRate: 100, AvgActualROI: 3, TotalROI: 3}, report.ByStrategy[0])
+	assert.Equal(t, "volatility", report.ByStrategy[1].Key)
+	assert.Equal(t, 2, report.ByStrategy[1].Signals)
+	assert.Equal(t, "mean_reversion", report.ByStrategy[2].Key)
+	assert.Equal(t, 0.0, report.ByStrategy[2].SuccessRate)
+
+	assert.Equal(t, []string{"TRENDING", "default", "tuned"}, groupKeys(report.ByParamSet))
+	assert.Equal(t, []string{"TRENDING", "none", "RANGING"}, groupKeys(report.ByRegime))
+
+	message := FormatReport(report)
+	assert.Contains(t, message, "• momentum: 1 signals, 100% of 1 closed hit target, total ROI +3.00%")
+	assert.Contains(t, message, "By regime")
+}
+
+func groupKeys(groups []Group) []string {
+	keys := make([]string, len(groups))
+	for i, group := range groups {
+		keys[i] = group.Key
+	}
+	return keys
+}
@@ -0,0 +1,90 @@
+package attribution
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/market"
+)
+
+// weeklyCheckInterval is how often the weekly job checks whether the report is due
+const weeklyCheckInterval = 5 * time.Minute
+
+// MessageSender posts messages to the signal channel (implemented by telegram.Bot)
+type MessageSender interface {
+	SendMessage(message string) error
+}
+
+// WeeklyJob posts the attribution report of the past seven days once a week
+type WeeklyJob struct {
+	reporter *Reporter
+	sender   MessageSender
+	config   config.AttributionConfig
+	session  *market.Clock
+	clock    clock.Clock
+	lastWeek string
+	mu       sync.Mutex
+}
+
+// NewWeeklyJob creates a job posting the report on the configured weekday and
+// hour in the market time zone
+func NewWeeklyJob(reporter *Reporter, sender MessageSender, cfg config.AttributionConfig) *WeeklyJob {
+	return &WeeklyJob{
+		reporter: reporter,
+		sender:   sender,
+		config:   cfg,
+		session:  market.DefaultClock(),
+		clock:    clock.Real{},
+	}
+}
+
+// SetClock sets the clock used to decide when the report is due
+func (j *WeeklyJob) SetClock(c clock.Clock) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.clock = c
+}
+
+// Run checks for the report time until stop is closed
+func (j *WeeklyJob) Run(stop <-chan struct{}) {
+	ticker := time.NewTicker(weeklyCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			j.RunIfDue()
+		}
+	}
+}
+
+// RunIfDue posts the report once per week, from the configured hour of the report day
+func (j *WeeklyJob) RunIfDue() bool {
+	weekday, ok := config.ParseWeekday(j.config.ReportWeekday)
+	if !ok {
+		return false
+	}
+
+	j.mu.Lock()
+	now := j.clock.Now().In(j.session.Location())
+	year, week := now.ISOWeek()
+	key := fmt.Sprintf("%d-W%02d", year, week)
+	if now.Weekday() != weekday || now.Hour() < j.config.ReportHour || j.lastWeek == key {
+		j.mu.Unlock()
+		return false
+	}
+	j.lastWeek = key
+	j.mu.Unlock()
+
+	report := j.reporter.Report(now.AddDate(0, 0, -7), now)
+	if err := j.sender.SendMessage(FormatReport(report)); err != nil {
+		log.Printf("Error sending weekly attribution report: %v", err)
+	}
+	return true
+}
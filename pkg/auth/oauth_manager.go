@@ -5,12 +5,20 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 )
 
+// questradeTokenURL is the Questrade OAuth token endpoint (overridable in tests)
+var questradeTokenURL = "https://login.questrade.com/oauth2/token"
+
+// tokenExpirySkew refreshes tokens slightly before they expire
+const tokenExpirySkew = 30 * time.Second
+
 // OAuthManager handles authentication with Questrade API
 type OAuthManager struct {
 	ClientID     string
@@ -18,6 +26,8 @@ type OAuthManager struct {
 	AccessToken  string
 	ApiServer    string
 	ExpiresAt    time.Time
+	store        TokenStore
+	mu           sync.Mutex
 }
 
 // TokenResponse represents the response from Questrade token endpoint
@@ -37,8 +47,41 @@ func NewOAuthManager(clientID, refreshToken string) *OAuthManager {
 	}
 }
 
+// SetTokenStore sets the store used to persist rotated tokens and reloads any
+// previously saved token, which takes precedence over the configured refresh token.
+func (o *OAuthManager) SetTokenStore(store TokenStore) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.store = store
+
+	token, err := store.LoadToken()
+	if err != nil {
+		return fmt.Errorf("failed to load persisted token: %w", err)
+	}
+	if token == nil || token.RefreshToken == "" {
+		return nil
+	}
+
+	o.AccessToken = token.AccessToken
+	o.RefreshToken = token.RefreshToken
+	o.ApiServer = token.ApiServer
+	o.ExpiresAt = token.ExpiresAt
+
+	return nil
+}
+
 // RefreshAccessToken refreshes the access token using the refresh token
 func (o *OAuthManager) RefreshAccessToken() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return o.refreshLocked()
+}
+
+// refreshLocked refreshes the access token; the caller must hold o.mu.
+// Questrade refresh tokens are single-use, so refreshes must never run concurrently.
+func (o *OAuthManager) refreshLocked() error {
 	if o.RefreshToken == "" {
 		return errors.New("refresh token is required")
 	}
@@ -47,7 +90,7 @@ func (o *OAuthManager) RefreshAccessToken() error {
 	data.Set("grant_type", "refresh_token")
 	data.Set("refresh_token", o.RefreshToken)
 
-	req, err := http.NewRequest("POST", "https://login.questrade.com/oauth2/token", strings.NewReader(data.Encode()))
+	req, err := http.NewRequest("POST", questradeTokenURL, strings.NewReader(data.Encode()))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
@@ -81,24 +124,42 @@ func (o *OAuthManager) RefreshAccessToken() error {
 	o.ApiServer = tokenResp.ApiServer
 	o.ExpiresAt = time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
 
+	// Persist immediately: the previous refresh token is no longer valid
+	if o.store != nil {
+		err := o.store.SaveToken(&StoredToken{
+			AccessToken:  o.AccessToken,
+			RefreshToken: o.RefreshToken,
+			ApiServer:    o.ApiServer,
+			ExpiresAt:    o.ExpiresAt,
+		})
+		if err != nil {
+			log.Printf("Warning: failed to persist refreshed Questrade token: %v", err)
+		}
+	}
+
 	return nil
 }
 
 // GetAuthenticatedRequest creates an authenticated HTTP request
 func (o *OAuthManager) GetAuthenticatedRequest(method, endpoint string, body io.Reader) (*http.Request, error) {
-	if time.Now().After(o.ExpiresAt) {
-		if err := o.RefreshAccessToken(); err != nil {
+	o.mu.Lock()
+	if time.Now().Add(tokenExpirySkew).After(o.ExpiresAt) {
+		if err := o.refreshLocked(); err != nil {
+			o.mu.Unlock()
 			return nil, fmt.Errorf("failed to refresh token: %w", err)
 		}
 	}
+	apiServer := o.ApiServer
+	accessToken := o.AccessToken
+	o.mu.Unlock()
 
-	apiURL := fmt.Sprintf("%s%s", o.ApiServer, endpoint)
+	apiURL := fmt.Sprintf("%s%s", apiServer, endpoint)
 	req, err := http.NewRequest(method, apiURL, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", o.AccessToken))
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", accessToken))
 	req.Header.Add("Content-Type", "application/json")
 
 	return req, nil
@@ -0,0 +1,126 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// StoredToken represents the OAuth token state persisted between restarts
+type StoredToken struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	ApiServer    string    `json:"api_server"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// TokenStore persists OAuth tokens
+type TokenStore interface {
+	SaveToken(token *StoredToken) error
+	LoadToken() (*StoredToken, error)
+}
+
+// StateStore is a key/value store for application state (implemented by store.Logger)
+type StateStore interface {
+	SaveAppState(key string, value []byte) error
+	LoadAppState(key string) ([]byte, error)
+}
+
+// EncryptedTokenStore encrypts tokens with AES-GCM before saving them to a StateStore
+type EncryptedTokenStore struct {
+	state StateStore
+	key   string
+	aead  cipher.AEAD
+}
+
+// encryptedPayload is the JSON envelope written to the state store
+type encryptedPayload struct {
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// NewEncryptedTokenStore creates a token store that saves under key, encrypted with secret
+func NewEncryptedTokenStore(state StateStore, key string, secret []byte) (*EncryptedTokenStore, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("encryption secret is required")
+	}
+
+	// Derive a 256-bit key so any secret length can be used
+	derived := sha256.Sum256(secret)
+	block, err := aes.NewCipher(derived[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+
+	return &EncryptedTokenStore{
+		state: state,
+		key:   key,
+		aead:  aead,
+	}, nil
+}
+
+// SaveToken encrypts and saves a token
+func (s *EncryptedTokenStore) SaveToken(token *StoredToken) error {
+	plaintext, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("failed to marshal token: %w", err)
+	}
+
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	payload, err := json.Marshal(encryptedPayload{
+		Nonce:      nonce,
+		Ciphertext: s.aead.Seal(nil, nonce, plaintext, []byte(s.key)),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal encrypted token: %w", err)
+	}
+
+	if err := s.state.SaveAppState(s.key, payload); err != nil {
+		return fmt.Errorf("failed to save token: %w", err)
+	}
+
+	return nil
+}
+
+// LoadToken loads and decrypts a token. It returns nil if no token has been saved.
+func (s *EncryptedTokenStore) LoadToken() (*StoredToken, error) {
+	raw, err := s.state.LoadAppState(s.key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load token: %w", err)
+	}
+	if raw == nil {
+		return nil, nil
+	}
+
+	var payload encryptedPayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return nil, fmt.Errorf("failed to parse encrypted token: %w", err)
+	}
+
+	plaintext, err := s.aead.Open(nil, payload.Nonce, payload.Ciphertext, []byte(s.key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt token: %w", err)
+	}
+
+	var token StoredToken
+	if err := json.Unmarshal(plaintext, &token); err != nil {
+		return nil, fmt.Errorf("failed to parse token: %w", err)
+	}
+
+	return &token, nil
+}
@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryStateStore is an in-memory StateStore for tests
+type memoryStateStore struct {
+	values map[string][]byte
+	mu     sync.Mutex
+}
+
+func newMemoryStateStore() *memoryStateStore {
+	return &memoryStateStore{values: make(map[string][]byte)}
+}
+
+func (m *memoryStateStore) SaveAppState(key string, value []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.values[key] = value
+	return nil
+}
+
+func (m *memoryStateStore) LoadAppState(key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.values[key], nil
+}
+
+func TestEncryptedTokenStoreRoundTrip(t *testing.T) {
+	state := newMemoryStateStore()
+	store, err := NewEncryptedTokenStore(state, "questrade_token", []byte("secret"))
+	assert.NoError(t, err)
+
+	// Nothing saved yet
+	token, err := store.LoadToken()
+	assert.NoError(t, err)
+	assert.Nil(t, token)
+
+	expiresAt := time.Date(2025, 4, 21, 10, 0, 0, 0, time.UTC)
+	err = store.SaveToken(&StoredToken{
+		AccessToken:  "access",
+		RefreshToken: "refresh",
+		ApiServer:    "https://api01.iq.questrade.com/",
+		ExpiresAt:    expiresAt,
+	})
+	assert.NoError(t, err)
+
+	// The raw value must not contain the plaintext refresh token
+	assert.NotContains(t, string(state.values["questrade_token"]), "refresh")
+
+	token, err = store.LoadToken()
+	assert.NoError(t, err)
+	assert.Equal(t, "refresh", token.RefreshToken)
+	assert.True(t, expiresAt.Equal(token.ExpiresAt))
+
+	// A different secret cannot decrypt the token
+	other, err := NewEncryptedTokenStore(state, "questrade_token", []byte("wrong"))
+	assert.NoError(t, err)
+	_, err = other.LoadToken()
+	assert.Error(t, err)
+}
+
+func TestOAuthManagerPersistsRotatedTokens(t *testing.T) {
+	var refreshes int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&refreshes, 1)
+		time.Sleep(10 * time.Millisecond)
+		fmt.Fprintf(w, `{"access_token":"access-%d","expires_in":1800,"refresh_token":"refresh-%d","api_server":"https://api01.iq.questrade.com/"}`, n, n)
+	}))
+	defer server.Close()
+
+	originalURL := questradeTokenURL
+	questradeTokenURL = server.URL
+	defer func() { questradeTokenURL = originalURL }()
+
+	store, err := NewEncryptedTokenStore(newMemoryStateStore(), "questrade_token", []byte("secret"))
+	assert.NoError(t, err)
+
+	manager := NewOAuthManager("client", "initial-refresh")
+	assert.NoError(t, manager.SetTokenStore(store))
+
+	// Concurrent requests must trigger exactly one refresh
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, err := manager.GetAuthenticatedRequest("GET", "v1/accounts", nil)
+			assert.NoError(t, err)
+			assert.Equal(t, "Bearer access-1", req.Header.Get("Authorization"))
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&refreshes))
+
+	// A new manager (simulating a restart) reloads the rotated token
+	restarted := NewOAuthManager("client", "initial-refresh")
+	assert.NoError(t, restarted.SetTokenStore(store))
+	assert.Equal(t, "refresh-1", restarted.RefreshToken)
+	assert.Equal(t, "access-1", restarted.AccessToken)
+}
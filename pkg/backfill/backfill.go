@@ -0,0 +1,278 @@
+package backfill
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/backtest"
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/market"
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// lastSeenKey is the app state key the last running time is stored under
+const lastSeenKey = "backfill.last_seen"
+
+// warmupDays of history before the gap are replayed for indicators to settle
+const warmupDays = 5
+
+// dailyHistoryDays of daily bars are served to liquidity and volatility checks
+const dailyHistoryDays = 365
+
+// maxListedSignals caps the missed signals listed in the report message
+const maxListedSignals = 10
+
+// HistorySource provides candles for the missed window (implemented by data.Provider)
+type HistorySource interface {
+	GetIntradayHistory(symbol string, days int) (*data.MarketData, error)
+	GetDailyHistory(symbol string, days int) (*data.MarketData, error)
+}
+
+// StateStore persists when the bot was last running (implemented by store.Logger and FileState)
+type StateStore interface {
+	SaveAppState(key string, value []byte) error
+	LoadAppState(key string) ([]byte, error)
+}
+
+// MessageSender posts the backfill report (implemented by telegram.Bot)
+type MessageSender interface {
+	SendMessage(message string) error
+}
+
+// Session is a stretch of market hours the bot was down for
+type Session struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Report describes what the bot missed while it was down
+type Report struct {
+	DownSince time.Time
+	Restarted time.Time
+	Sessions  []Session
+	Missed    time.Duration // Market time the bot was down for
+	Checks    int
+	Symbols   int
+	Signals   []*signal.Signal
+	Outcomes  []backtest.Outcome
+}
+
+// Job records a heartbeat while the bot runs and, on startup, replays the
+// market hours since the last heartbeat through the signal pipeline. Missed
+// signals are reported but never published: their entries are stale.
+type Job struct {
+	config  *config.Config
+	history HistorySource
+	state   StateStore
+	sender  MessageSender
+	session *market.Clock
+	clock   clock.Clock
+	mu      sync.Mutex
+}
+
+// NewJob creates a backfill job. sender may be nil to only log the report.
+func NewJob(cfg *config.Config, history HistorySource, state StateStore, sender MessageSender) *Job {
+	return &Job{
+		config:  cfg,
+		history: history,
+		state:   state,
+		sender:  sender,
+		session: market.DefaultClock(),
+		clock:   clock.Real{},
+	}
+}
+
+// SetClock sets the clock heartbeats and the gap are measured with
+func (j *Job) SetClock(c clock.Clock) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.clock = c
+}
+
+// now returns the current time of the job's clock
+func (j *Job) now() time.Time {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.clock.Now()
+}
+
+// Heartbeat records that the bot is running now
+func (j *Job) Heartbeat() error {
+	value := []byte(j.now().UTC().Format(time.RFC3339))
+	if err := j.state.SaveAppState(lastSeenKey, value); err != nil {
+		return fmt.Errorf("failed to save backfill heartbeat: %w", err)
+	}
+	return nil
+}
+
+// RunHeartbeat records a heartbeat every configured interval until stop is closed
+func (j *Job) RunHeartbeat(stop <-chan struct{}) {
+	ticker := time.NewTicker(time.Duration(j.config.Backfill.HeartbeatSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if err := j.Heartbeat(); err != nil {
+				log.Printf("Error recording backfill heartbeat: %v", err)
+			}
+		}
+	}
+}
+
+// lastSeen returns the time of the last heartbeat, or false before the first
+func (j *Job) lastSeen() (time.Time, bool, error) {
+	value, err := j.state.LoadAppState(lastSeenKey)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("failed to load backfill heartbeat: %w", err)
+	}
+	if len(value) == 0 {
+		return time.Time{}, false, nil
+	}
+	seen, err := time.Parse(time.RFC3339, string(value))
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("failed to parse backfill heartbeat: %w", err)
+	}
+	return seen, true, nil
+}
+
+// Sessions returns the market hours between from and to, oldest first
+func (j *Job) Sessions(from, to time.Time) []Session {
+	var sessions []Session
+	for day := j.session.TradingDay(from); day.Before(to); day = day.AddDate(0, 0, 1) {
+		if !j.session.IsTradingDay(day) {
+			continue
+		}
+		start := j.session.SessionOpen(day)
+		if start.Before(from) {
+			start = from
+		}
+		end := j.session.SessionClose(day)
+		if end.After(to) {
+			end = to
+		}
+		if start.Before(end) {
+			sessions = append(sessions, Session{Start: start, End: end})
+		}
+	}
+	return sessions
+}
+
+// Run backfills the market hours missed since the last heartbeat and reports
+// them. It returns nil when nothing worth backfilling was missed. A heartbeat
+// is recorded either way so a failed backfill is not retried on every start.
+func (j *Job) Run() (*Report, error) {
+	now := j.now()
+	since, ok, err := j.lastSeen()
+	if err != nil {
+		return nil, err
+	}
+	if err := j.Heartbeat(); err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	from := since
+	if oldest := now.AddDate(0, 0, -j.config.Backfill.MaxDays); from.Before(oldest) {
+		from = oldest
+	}
+	report := &Report{DownSince: since, Restarted: now, Sessions: j.Sessions(from, now)}
+	for _, s := range report.Sessions {
+		report.Missed += s.End.Sub(s.Start)
+	}
+	if len(report.Sessions) == 0 || report.Missed < time.Duration(j.config.Backfill.MinGapMinutes)*time.Minute {
+		return nil, nil
+	}
+
+	history := j.fetch(now.Sub(report.Sessions[0].Start))
+	if len(history) == 0 {
+		return nil, fmt.Errorf("failed to backfill: no history for any watched symbol")
+	}
+	report.Symbols = len(history)
+
+	runner := backtest.NewRunner(j.config, history)
+	for symbol := range history {
+		if daily, err := j.history.GetDailyHistory(symbol, dailyHistoryDays); err == nil {
+			runner.Source.SetDailyHistory(symbol, daily)
+		}
+	}
+
+	step := time.Duration(j.config.CheckInterval) * time.Second
+	for _, s := range report.Sessions {
+		for at := s.Start; at.Before(s.End); at = at.Add(step) {
+			if err := runner.Step(at); err != nil {
+				return nil, fmt.Errorf("failed to backfill: %w", err)
+			}
+			report.Checks++
+		}
+	}
+	report.Signals = runner.Notifier.Signals()
+	report.Outcomes = runner.Notifier.Outcomes()
+
+	message := FormatReport(report)
+	if j.sender == nil {
+		log.Print(message)
+	} else if err := j.sender.SendMessage(message); err != nil {
+		return report, fmt.Errorf("failed to send backfill report: %w", err)
+	}
+	return report, nil
+}
+
+// fetch returns the intraday history of every watched symbol covering span plus
+// the warm-up, skipping symbols whose history is unavailable
+func (j *Job) fetch(span time.Duration) map[string]*data.MarketData {
+	days := int(math.Ceil(span.Hours()/24)) + warmupDays
+	history := make(map[string]*data.MarketData)
+	for _, symbol := range j.config.WatchedSymbols() {
+		md, err := j.history.GetIntradayHistory(symbol, days)
+		if err != nil {
+			log.Printf("Skipping %s in backfill: %v", symbol, err)
+			continue
+		}
+		history[symbol] = md
+	}
+	return history
+}
+
+// FormatReport formats a backfill report for the signal channel
+func FormatReport(report *Report) string {
+	loc := market.DefaultClock().Location()
+	message := "⏪ <b>MISSED MARKET CHECKS</b>\n"
+	message += fmt.Sprintf("The bot was down from %s to %s, missing %s of market hours.\n",
+		report.DownSince.In(loc).Format("Jan 2 15:04"), report.Restarted.In(loc).Format("Jan 2 15:04 MST"),
+		report.Missed.Round(time.Minute))
+	message += fmt.Sprintf("Replayed %d checks over %d symbols.\n\n", report.Checks, report.Symbols)
+
+	if len(report.Signals) == 0 {
+		return message + "No signals would have been published."
+	}
+
+	message += fmt.Sprintf("📡 %d signals would have been published. They are not being sent now as their entries are stale:\n", len(report.Signals))
+	for i, s := range report.Signals {
+		if i == maxListedSignals {
+			message += fmt.Sprintf("…and %d more\n", len(report.Signals)-maxListedSignals)
+			break
+		}
+		message += fmt.Sprintf("• %s %s @ $%.2f at %s\n", s.Type, s.Symbol, s.Price, s.GeneratedAt.In(loc).Format("Jan 2 15:04"))
+	}
+	if len(report.Outcomes) > 0 {
+		message += fmt.Sprintf("\n📈 %d of them would have closed since:\n", len(report.Outcomes))
+		for i, o := range report.Outcomes {
+			if i == maxListedSignals {
+				message += fmt.Sprintf("…and %d more\n", len(report.Outcomes)-maxListedSignals)
+				break
+			}
+			message += fmt.Sprintf("• %s %s %s at $%.2f\n", o.Signal.Type, o.Signal.Symbol, o.Signal.Status, o.ExitPrice)
+		}
+	}
+	return message
+}
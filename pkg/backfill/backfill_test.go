@@ -0,0 +1,107 @@
+package backfill
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/stretchr/testify/assert"
+)
+
+// flatHistory serves constant 15-minute bars from start through end
+type flatHistory struct {
+	start, end time.Time
+}
+
+func (h flatHistory) GetIntradayHistory(symbol string, days int) (*data.MarketData, error) {
+	md := &data.MarketData{Symbol: symbol}
+	for t := h.start; !t.After(h.end); t = t.Add(15 * time.Minute) {
+		md.Prices = append(md.Prices, 100)
+		md.Volumes = append(md.Volumes, 100000)
+		md.Timestamps = append(md.Timestamps, t)
+	}
+	return md, nil
+}
+
+func (h flatHistory) GetDailyHistory(symbol string, days int) (*data.MarketData, error) {
+	return nil, fmt.Errorf("no daily history")
+}
+
+type recordingSender struct {
+	messages []string
+}
+
+func (s *recordingSender) SendMessage(message string) error {
+	s.messages = append(s.messages, message)
+	return nil
+}
+
+func TestBackfillReplaysMissedMarketHours(t *testing.T) {
+	cfg := config.CreateDefaultConfig()
+	cfg.StockSymbols = []string{"AAPL"}
+	cfg.Liquidity = config.LiquidityConfig{}
+	cfg.Backfill.Enabled = true
+
+	// Down from Monday 11:00 to Tuesday 10:00 New York time
+	down := time.Date(2025, 4, 21, 15, 0, 0, 0, time.UTC)
+	restart := time.Date(2025, 4, 22, 14, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(down)
+	state := NewFileState(filepath.Join(t.TempDir(), "state", "backfill.json"))
+	sender := &recordingSender{}
+	job := NewJob(cfg, flatHistory{start: down.AddDate(0, 0, -7), end: restart}, state, sender)
+	job.SetClock(fake)
+
+	// The first start only records the heartbeat
+	report, err := job.Run()
+	assert.NoError(t, err)
+	assert.Nil(t, report)
+
+	// A gap shorter than the minimum is not backfilled
+	fake.Advance(10 * time.Minute)
+	report, err = job.Run()
+	assert.NoError(t, err)
+	assert.Nil(t, report)
+
+	fake.Set(down)
+	assert.NoError(t, job.Heartbeat())
+	fake.Set(restart)
+	report, err = job.Run()
+	assert.NoError(t, err)
+	if assert.NotNil(t, report) {
+		assert.Len(t, report.Sessions, 2)
+		assert.Equal(t, 5*time.Hour+30*time.Minute, report.Missed)
+		assert.Equal(t, time.Date(2025, 4, 22, 13, 30, 0, 0, time.UTC), report.Sessions[1].Start.UTC())
+		assert.Equal(t, 66, report.Checks)
+		assert.Equal(t, 1, report.Symbols)
+		// A flat market would have produced no signals
+		assert.Empty(t, report.Signals)
+	}
+	if assert.Len(t, sender.messages, 1) {
+		assert.Contains(t, sender.messages[0], "5h30m0s of market hours")
+	}
+
+	// The restart was recorded as the new heartbeat
+	seen, ok, err := job.lastSeen()
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.True(t, seen.Equal(restart))
+}
+
+func TestSessionsSkipWeekendsAndClosedHours(t *testing.T) {
+	job := NewJob(config.CreateDefaultConfig(), nil, nil, nil)
+
+	// Friday 20:00 to Monday 08:00 New York time spans no market hours
+	sessions := job.Sessions(time.Date(2025, 4, 19, 0, 0, 0, 0, time.UTC), time.Date(2025, 4, 21, 12, 0, 0, 0, time.UTC))
+	assert.Empty(t, sessions)
+
+	// Friday noon to Monday noon covers the Friday afternoon and Monday morning
+	sessions = job.Sessions(time.Date(2025, 4, 18, 16, 0, 0, 0, time.UTC), time.Date(2025, 4, 21, 16, 0, 0, 0, time.UTC))
+	if assert.Len(t, sessions, 2) {
+		assert.Equal(t, 4*time.Hour, sessions[0].End.Sub(sessions[0].Start))
+		assert.Equal(t, 2*time.Hour+30*time.Minute, sessions[1].End.Sub(sessions[1].Start))
+	}
+}
@@ -0,0 +1,80 @@
+package backfill
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileState is a StateStore kept in a JSON file, for deployments without a database
+type FileState struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileState creates a state store backed by the file at path
+func NewFileState(path string) *FileState {
+	return &FileState{path: path}
+}
+
+// SaveAppState saves the value under key, replacing the file atomically
+func (f *FileState) SaveAppState(key string, value []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	state, err := f.read()
+	if err != nil {
+		return err
+	}
+	state[key] = string(value)
+
+	body, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(f.path), 0755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	tmp := f.path + ".tmp"
+	if err := os.WriteFile(tmp, body, 0644); err != nil {
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+	if err := os.Rename(tmp, f.path); err != nil {
+		return fmt.Errorf("failed to replace state: %w", err)
+	}
+	return nil
+}
+
+// LoadAppState loads the value saved under key, or nil if there is none
+func (f *FileState) LoadAppState(key string) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	state, err := f.read()
+	if err != nil {
+		return nil, err
+	}
+	value, ok := state[key]
+	if !ok {
+		return nil, nil
+	}
+	return []byte(value), nil
+}
+
+// read returns the saved state, empty if the file does not exist yet
+func (f *FileState) read() (map[string]string, error) {
+	state := make(map[string]string)
+	body, err := os.ReadFile(f.path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state: %w", err)
+	}
+	if err := json.Unmarshal(body, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse state: %w", err)
+	}
+	return state, nil
+}
@@ -0,0 +1,86 @@
+package backtest
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/data"
+)
+
+// Replay serves recorded market data as if it were live: each request only sees
+// the bars up to the clock's current time
+type Replay struct {
+	clock    clock.Clock
+	intraday map[string]*data.MarketData
+	daily    map[string]*data.MarketData
+}
+
+// NewReplay creates a Replay of intraday history keyed by symbol
+func NewReplay(c clock.Clock, intraday map[string]*data.MarketData) *Replay {
+	return &Replay{
+		clock:    c,
+		intraday: intraday,
+		daily:    make(map[string]*data.MarketData),
+	}
+}
+
+// SetDailyHistory sets the daily bars served for a symbol
+func (r *Replay) SetDailyHistory(symbol string, history *data.MarketData) {
+	r.daily[symbol] = history
+}
+
+// GetMarketDataBatch returns the intraday bars seen so far for each symbol
+func (r *Replay) GetMarketDataBatch(symbols []string) (map[string]*data.MarketData, map[string]error) {
+	results := make(map[string]*data.MarketData, len(symbols))
+	errs := make(map[string]error)
+	for _, symbol := range symbols {
+		history, ok := r.intraday[symbol]
+		if !ok {
+			errs[symbol] = fmt.Errorf("no history for %s", symbol)
+			continue
+		}
+		seen := upTo(history, r.clock.Now(), true)
+		if len(seen.Prices) == 0 {
+			errs[symbol] = fmt.Errorf("no data for %s before %s", symbol, r.clock.Now().Format("2006-01-02 15:04"))
+			continue
+		}
+		results[symbol] = seen
+	}
+	return results, errs
+}
+
+// GetDailyHistory returns up to days completed daily bars before the current day
+func (r *Replay) GetDailyHistory(symbol string, days int) (*data.MarketData, error) {
+	history, ok := r.daily[symbol]
+	if !ok {
+		return nil, fmt.Errorf("no daily history for %s", symbol)
+	}
+
+	seen := upTo(history, r.clock.Now().Truncate(24*time.Hour), false)
+	if start := len(seen.Prices) - days; days > 0 && start > 0 {
+		seen.Prices = seen.Prices[start:]
+		seen.Volumes = seen.Volumes[start:]
+		seen.Timestamps = seen.Timestamps[start:]
+	}
+	return seen, nil
+}
+
+// upTo returns a copy of the bars at or before now (before now when inclusive is false)
+func upTo(history *data.MarketData, now time.Time, inclusive bool) *data.MarketData {
+	n := sort.Search(len(history.Timestamps), func(i int) bool {
+		if inclusive {
+			return history.Timestamps[i].After(now)
+		}
+		return !history.Timestamps[i].Before(now)
+	})
+
+	return &data.MarketData{
+		Symbol:     history.Symbol,
+		Prices:     append([]float64(nil), history.Prices[:n]...),
+		Volumes:    append([]float64(nil), history.Volumes[:n]...),
+		Timestamps: append([]time.Time(nil), history.Timestamps[:n]...),
+		Exchange:   history.Exchange,
+	}
+}
@@ -0,0 +1,138 @@
+package backtest
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/follower"
+	"github.com/hustler/trading-bot/pkg/monitor"
+	"github.com/hustler/trading-bot/pkg/performance"
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// Result summarizes a backtest run
+type Result struct {
+	Checks    int
+	Signals   []*signal.Signal
+	Outcomes  []Outcome
+	Messages  []string
+	Metrics   *performance.Metrics
+	Followers *follower.Summary // What subscribers could have made; nil unless follower is enabled
+}
+
+// Runner runs the live signal pipeline over recorded data. The market monitor,
+// signal generator and performance monitor are the production components; only
+// the clock, data source, LLM and notifier are replaced.
+type Runner struct {
+	Clock       *clock.Fake
+	Source      *Replay
+	Notifier    *RecordingNotifier
+	Generator   *signal.Generator
+	Monitor     *monitor.MarketMonitor
+	Performance *performance.Monitor
+	Followers   *follower.Simulator // Nil unless follower is enabled
+	recorded    int                 // Signals already added to Performance
+}
+
+// NewRunner creates a Runner over intraday history keyed by symbol. The economic
+// calendar feed is not fetched; only events configured in cfg apply.
+func NewRunner(cfg *config.Config, history map[string]*data.MarketData) *Runner {
+	backtestCfg := *cfg
+	backtestCfg.EconomicCalendar.URL = ""
+
+	fake := clock.NewFake(time.Time{})
+	source := NewReplay(fake, history)
+	notifier := NewRecordingNotifier()
+
+	generator := signal.NewGenerator(&backtestCfg)
+	generator.SetClock(fake)
+
+	perf := performance.NewMonitor()
+	perf.SetClock(fake)
+
+	marketMonitor := monitor.NewMarketMonitor(&backtestCfg, source, generator, StaticExplainer{}, notifier)
+	marketMonitor.SetClock(fake)
+	marketMonitor.OnMarketData(perf.Observe)
+	marketMonitor.OnSignalClosed(func(s *signal.Signal, exitPrice float64) {
+		if err := perf.UpdateSignalStatus(s.ID, s.Status, exitPrice); err != nil {
+			log.Printf("Error tracking signal outcome: %v", err)
+		}
+	})
+
+	// Replay the strategy ensemble as configured, learning weights as signals close
+	if backtestCfg.Ensemble.Enabled {
+		if ensemble, err := signal.NewEnsemble(backtestCfg.Ensemble); err == nil {
+			generator.SetEnsemble(ensemble)
+			marketMonitor.OnSignalClosed(ensemble.Resolve)
+		} else {
+			log.Printf("Backtesting without the strategy ensemble: %v", err)
+		}
+	}
+
+	runner := &Runner{
+		Clock:       fake,
+		Source:      source,
+		Notifier:    notifier,
+		Generator:   generator,
+		Monitor:     marketMonitor,
+		Performance: perf,
+	}
+
+	// Follow each signal as a subscriber would, entering after the delay to
+	// read and act on it and paying slippage, so results show the fills the
+	// audience could achieve and not just the published prices
+	if backtestCfg.Follower.Enabled {
+		runner.Followers = follower.NewSimulator(backtestCfg.Follower)
+		runner.Followers.SetClock(fake)
+		marketMonitor.SetFollowerSimulator(runner.Followers)
+		marketMonitor.OnSignalClosed(runner.Followers.Close)
+	}
+	return runner
+}
+
+// Step checks the market at now and records any new signals with the
+// performance monitor
+func (r *Runner) Step(now time.Time) error {
+	r.Clock.Set(now)
+	if err := r.Monitor.CheckMarket(); err != nil {
+		return fmt.Errorf("failed to check market at %s: %w", now.Format(time.RFC3339), err)
+	}
+
+	// Record new signals before the next check can close them
+	signals := r.Notifier.Signals()
+	for _, s := range signals[r.recorded:] {
+		r.Performance.AddSignal(s)
+	}
+	r.recorded = len(signals)
+	return nil
+}
+
+// Run checks the market every step from start through end and returns what the
+// pipeline produced
+func (r *Runner) Run(start, end time.Time, step time.Duration) (*Result, error) {
+	if step <= 0 {
+		return nil, fmt.Errorf("step must be positive")
+	}
+
+	result := &Result{}
+	for now := start; !now.After(end); now = now.Add(step) {
+		if err := r.Step(now); err != nil {
+			return nil, err
+		}
+		result.Checks++
+	}
+
+	result.Signals = r.Notifier.Signals()
+	result.Outcomes = r.Notifier.Outcomes()
+	result.Messages = r.Notifier.Messages()
+	result.Metrics = r.Performance.GetMetrics()
+	if r.Followers != nil {
+		summary := r.Followers.Summary()
+		result.Followers = &summary
+	}
+	return result, nil
+}
@@ -0,0 +1,94 @@
+package backtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/stretchr/testify/assert"
+)
+
+// minuteBars returns n one-minute bars starting at start with a constant price
+func minuteBars(symbol string, start time.Time, n int, price float64) *data.MarketData {
+	md := &data.MarketData{Symbol: symbol}
+	for i := 0; i < n; i++ {
+		md.Prices = append(md.Prices, price)
+		md.Volumes = append(md.Volumes, 100000)
+		md.Timestamps = append(md.Timestamps, start.Add(time.Duration(i)*time.Minute))
+	}
+	return md
+}
+
+func TestReplayOnlyServesPastBars(t *testing.T) {
+	start := time.Date(2025, 4, 21, 13, 30, 0, 0, time.UTC)
+	fake := clock.NewFake(start.Add(-time.Minute))
+	replay := NewReplay(fake, map[string]*data.MarketData{"AAPL": minuteBars("AAPL", start, 60, 100)})
+
+	_, errs := replay.GetMarketDataBatch([]string{"AAPL", "MSFT"})
+	assert.Len(t, errs, 2)
+
+	fake.Set(start.Add(9 * time.Minute))
+	results, errs := replay.GetMarketDataBatch([]string{"AAPL"})
+	assert.Empty(t, errs)
+	assert.Len(t, results["AAPL"].Prices, 10)
+	assert.Equal(t, start.Add(9*time.Minute), results["AAPL"].Timestamps[9])
+
+	daily := &data.MarketData{Symbol: "AAPL"}
+	for i := 0; i < 30; i++ {
+		daily.Prices = append(daily.Prices, 100)
+		daily.Volumes = append(daily.Volumes, 1e6)
+		daily.Timestamps = append(daily.Timestamps, start.Truncate(24*time.Hour).AddDate(0, 0, i-29))
+	}
+	replay.SetDailyHistory("AAPL", daily)
+
+	// Today's bar is excluded
+	history, err := replay.GetDailyHistory("AAPL", 20)
+	assert.NoError(t, err)
+	assert.Len(t, history.Prices, 20)
+	assert.True(t, history.Timestamps[19].Before(start.Truncate(24*time.Hour)))
+}
+
+func TestRunnerUsesSimulatedTime(t *testing.T) {
+	cfg := config.CreateDefaultConfig()
+	cfg.StockSymbols = []string{"AAPL"}
+	cfg.Liquidity.MinAvgDollarVolume = 0
+	cfg.Liquidity.MaxVolumePercent = 0
+
+	start := time.Date(2025, 4, 21, 13, 30, 0, 0, time.UTC)
+	runner := NewRunner(cfg, map[string]*data.MarketData{"AAPL": minuteBars("AAPL", start, 120, 100)})
+
+	result, err := runner.Run(start.Add(30*time.Minute), start.Add(119*time.Minute), 5*time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, 18, result.Checks)
+	assert.Equal(t, start.Add(115*time.Minute), runner.Clock.Now())
+
+	// A flat market produces no signals and no messages
+	assert.Empty(t, result.Signals)
+	assert.Empty(t, result.Messages)
+	assert.Equal(t, 0, result.Metrics.SignalsCount)
+
+	_, err = runner.Run(start, start, 0)
+	assert.Error(t, err)
+}
+
+func TestRunnerReportsFollowersWhenEnabled(t *testing.T) {
+	cfg := config.CreateDefaultConfig()
+	cfg.StockSymbols = []string{"AAPL"}
+	cfg.Follower = config.FollowerConfig{Enabled: true, EntryDelaySeconds: 90, SlippagePct: 0.1}
+
+	start := time.Date(2025, 4, 21, 13, 30, 0, 0, time.UTC)
+	runner := NewRunner(cfg, map[string]*data.MarketData{"AAPL": minuteBars("AAPL", start, 60, 100)})
+
+	result, err := runner.Run(start.Add(30*time.Minute), start.Add(59*time.Minute), 5*time.Minute)
+	assert.NoError(t, err)
+	assert.NotNil(t, result.Followers)
+	assert.Equal(t, 90, result.Followers.EntryDelay)
+	assert.Equal(t, 0, result.Followers.Followed)
+
+	cfg.Follower.Enabled = false
+	result, err = NewRunner(cfg, nil).Run(start, start, time.Minute)
+	assert.NoError(t, err)
+	assert.Nil(t, result.Followers)
+}
@@ -0,0 +1,130 @@
+package backtest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hustler/trading-bot/pkg/monitor"
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// Outcome is a signal close reported to the notifier
+type Outcome struct {
+	Signal    signal.Signal
+	ExitPrice float64
+}
+
+// RecordingNotifier records everything the monitor would send to subscribers
+// instead of sending it
+type RecordingNotifier struct {
+	forward  monitor.Notifier
+	signals  []*signal.Signal
+	updates  []signal.Signal
+	outcomes []Outcome
+	messages []string
+	mu       sync.Mutex
+}
+
+// NewRecordingNotifier creates a new RecordingNotifier
+func NewRecordingNotifier() *RecordingNotifier {
+	return &RecordingNotifier{}
+}
+
+// Forward also passes everything recorded on to another notifier, such as a
+// mock-mode telegram.Bot, so message formatting is exercised too
+func (n *RecordingNotifier) Forward(to monitor.Notifier) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.forward = to
+}
+
+// SendSignal records a new signal
+func (n *RecordingNotifier) SendSignal(s *signal.Signal) error {
+	n.mu.Lock()
+	n.signals = append(n.signals, s)
+	forward := n.forward
+	n.mu.Unlock()
+
+	if forward != nil {
+		return forward.SendSignal(s)
+	}
+	return nil
+}
+
+// UpdateSignal records a copy of an adjusted signal
+func (n *RecordingNotifier) UpdateSignal(s *signal.Signal) error {
+	n.mu.Lock()
+	n.updates = append(n.updates, *s)
+	forward := n.forward
+	n.mu.Unlock()
+
+	if forward != nil {
+		return forward.UpdateSignal(s)
+	}
+	return nil
+}
+
+// SendSignalOutcome records a closed signal
+func (n *RecordingNotifier) SendSignalOutcome(s *signal.Signal, exitPrice float64) error {
+	n.mu.Lock()
+	n.outcomes = append(n.outcomes, Outcome{Signal: *s, ExitPrice: exitPrice})
+	forward := n.forward
+	n.mu.Unlock()
+
+	if forward != nil {
+		return forward.SendSignalOutcome(s, exitPrice)
+	}
+	return nil
+}
+
+// SendMessage records a channel message
+func (n *RecordingNotifier) SendMessage(message string) error {
+	n.mu.Lock()
+	n.messages = append(n.messages, message)
+	forward := n.forward
+	n.mu.Unlock()
+
+	if forward != nil {
+		return forward.SendMessage(message)
+	}
+	return nil
+}
+
+// Signals returns the signals sent so far
+func (n *RecordingNotifier) Signals() []*signal.Signal {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]*signal.Signal(nil), n.signals...)
+}
+
+// Updates returns the signal adjustments sent so far
+func (n *RecordingNotifier) Updates() []signal.Signal {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]signal.Signal(nil), n.updates...)
+}
+
+// Outcomes returns the signal outcomes sent so far
+func (n *RecordingNotifier) Outcomes() []Outcome {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]Outcome(nil), n.outcomes...)
+}
+
+// Messages returns the channel messages sent so far
+func (n *RecordingNotifier) Messages() []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]string(nil), n.messages...)
+}
+
+// StaticExplainer returns the same rationale for every signal so backtests
+// make no LLM calls
+type StaticExplainer struct {
+	Text string
+}
+
+// GenerateSignalExplanation returns the static rationale
+func (e StaticExplainer) GenerateSignalExplanation(ctx context.Context, s *signal.Signal) (string, error) {
+	return e.Text, nil
+}
@@ -0,0 +1,235 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/store"
+)
+
+// FormatVersion is the version of the archive layout written by Write
+const FormatVersion = 1
+
+// Names of the files in an archive. Each table is a JSON lines file under tables/.
+const (
+	manifestName = "manifest.json"
+	configName   = "config.json"
+	tablesDir    = "tables/"
+)
+
+// ErrCorrupt is returned when an archive's contents don't match its manifest
+var ErrCorrupt = errors.New("backup archive is corrupt")
+
+// Database is the store an archive is taken from or restored to (implemented
+// by store.Logger)
+type Database interface {
+	SchemaVersion() (int, error)
+	MigrateTo(version int) error
+	Migrate() error
+	DumpTable(table string, w io.Writer) (int, error)
+	LoadTables(dumps map[string][]byte) error
+}
+
+// Manifest describes an archive, so that a restore can verify it
+type Manifest struct {
+	Format        int       `json:"format"`
+	CreatedAt     time.Time `json:"created_at"`
+	SchemaVersion int       `json:"schema_version"` // Migration the store was at
+	Files         []File    `json:"files"`
+}
+
+// File is an archived file with its checksum
+type File struct {
+	Name   string `json:"name"`
+	Rows   int    `json:"rows,omitempty"` // Rows of a table
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Archive is a read and verified backup
+type Archive struct {
+	Manifest Manifest
+	Tables   map[string][]byte // JSON lines dumps, by table
+	Config   []byte            // The config file, if one was backed up
+}
+
+// Write takes a snapshot of every backup table and the config file, if not
+// nil, and writes it to w as a gzipped tar archive with a manifest
+func Write(w io.Writer, db Database, configFile []byte, now time.Time) (Manifest, error) {
+	version, err := db.SchemaVersion()
+	if err != nil {
+		return Manifest{}, err
+	}
+	manifest := Manifest{Format: FormatVersion, CreatedAt: now.UTC(), SchemaVersion: version}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	add := func(name string, data []byte, rows int) error {
+		sum := sha256.Sum256(data)
+		manifest.Files = append(manifest.Files, File{Name: name, Rows: rows, Size: int64(len(data)), SHA256: hex.EncodeToString(sum[:])})
+		return writeFile(tw, name, data, now)
+	}
+
+	for _, table := range store.BackupTables {
+		var dump bytes.Buffer
+		rows, err := db.DumpTable(table, &dump)
+		if err != nil {
+			return Manifest{}, err
+		}
+		if err := add(tablesDir+table+".jsonl", dump.Bytes(), rows); err != nil {
+			return Manifest{}, err
+		}
+	}
+	if configFile != nil {
+		if err := add(configName, configFile, 0); err != nil {
+			return Manifest{}, err
+		}
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return Manifest{}, fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	if err := writeFile(tw, manifestName, data, now); err != nil {
+		return Manifest{}, err
+	}
+	if err := tw.Close(); err != nil {
+		return Manifest{}, fmt.Errorf("failed to finish archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return Manifest{}, fmt.Errorf("failed to compress archive: %w", err)
+	}
+	return manifest, nil
+}
+
+// writeFile adds a file to a tar archive
+func writeFile(tw *tar.Writer, name string, data []byte, modTime time.Time) error {
+	header := &tar.Header{Name: name, Mode: 0600, Size: int64(len(data)), ModTime: modTime}
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write %s header: %w", name, err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// Read reads an archive and verifies every file against the manifest: its
+// size, checksum and, for tables, row count. Files missing from the archive,
+// or not in the manifest, fail the verification too.
+func Read(r io.Reader) (*Archive, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer gz.Close()
+
+	files := make(map[string][]byte)
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", header.Name, err)
+		}
+		files[header.Name] = data
+	}
+
+	data, ok := files[manifestName]
+	if !ok {
+		return nil, fmt.Errorf("%w: no manifest", ErrCorrupt)
+	}
+	archive := &Archive{Tables: make(map[string][]byte)}
+	if err := json.Unmarshal(data, &archive.Manifest); err != nil {
+		return nil, fmt.Errorf("%w: invalid manifest: %v", ErrCorrupt, err)
+	}
+	if archive.Manifest.Format != FormatVersion {
+		return nil, fmt.Errorf("unsupported backup format %d", archive.Manifest.Format)
+	}
+	delete(files, manifestName)
+
+	for _, file := range archive.Manifest.Files {
+		data, ok := files[file.Name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s is missing", ErrCorrupt, file.Name)
+		}
+		delete(files, file.Name)
+		sum := sha256.Sum256(data)
+		if int64(len(data)) != file.Size || hex.EncodeToString(sum[:]) != file.SHA256 {
+			return nil, fmt.Errorf("%w: checksum of %s does not match", ErrCorrupt, file.Name)
+		}
+
+		switch {
+		case file.Name == configName:
+			archive.Config = data
+		case strings.HasPrefix(file.Name, tablesDir):
+			if rows := bytes.Count(data, []byte("\n")); rows != file.Rows {
+				return nil, fmt.Errorf("%w: %s has %d rows, expected %d", ErrCorrupt, file.Name, rows, file.Rows)
+			}
+			table := strings.TrimSuffix(strings.TrimPrefix(file.Name, tablesDir), ".jsonl")
+			archive.Tables[table] = data
+		}
+	}
+	for name := range files {
+		return nil, fmt.Errorf("%w: %s is not in the manifest", ErrCorrupt, name)
+	}
+	return archive, nil
+}
+
+// Restore replaces the contents of the store's tables with the archived ones.
+// The rows are loaded at the archive's schema version and then migrated up
+// with the store. A store already past that version is never migrated down,
+// which would drop its newer tables and columns; restore into an empty
+// database instead.
+func Restore(db Database, archive *Archive) error {
+	version := archive.Manifest.SchemaVersion
+	current, err := db.SchemaVersion()
+	if err != nil {
+		return err
+	}
+	if current > version {
+		return fmt.Errorf("the store is at schema version %d, past the archive's version %d; restore into an empty database", current, version)
+	}
+
+	if err := db.MigrateTo(version); err != nil {
+		return fmt.Errorf("failed to migrate to schema version %d: %w", version, err)
+	}
+	if current, err = db.SchemaVersion(); err != nil {
+		return err
+	}
+	if current != version {
+		return fmt.Errorf("the archive is at schema version %d, newer than this release's %d", version, current)
+	}
+
+	if err := db.LoadTables(archive.Tables); err != nil {
+		return err
+	}
+	if err := db.Migrate(); err != nil {
+		return fmt.Errorf("failed to migrate the restored store: %w", err)
+	}
+	return nil
+}
+
+// Rows returns the number of archived rows
+func (m Manifest) Rows() int {
+	rows := 0
+	for _, file := range m.Files {
+		rows += file.Rows
+	}
+	return rows
+}
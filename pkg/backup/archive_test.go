@@ -0,0 +1,177 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeDatabase holds table dumps in memory, with migrations up to latest
+type fakeDatabase struct {
+	version  int
+	latest   int
+	tables   map[string][]byte
+	migrated []int // Versions migrated to, in order
+}
+
+func (d *fakeDatabase) SchemaVersion() (int, error) { return d.version, nil }
+
+func (d *fakeDatabase) MigrateTo(version int) error {
+	if version > d.latest {
+		version = d.latest
+	}
+	d.version = version
+	d.migrated = append(d.migrated, version)
+	return nil
+}
+
+func (d *fakeDatabase) Migrate() error {
+	return d.MigrateTo(d.latest)
+}
+
+func (d *fakeDatabase) DumpTable(table string, w io.Writer) (int, error) {
+	data := d.tables[table]
+	w.Write(data)
+	return bytes.Count(data, []byte("\n")), nil
+}
+
+func (d *fakeDatabase) LoadTables(dumps map[string][]byte) error {
+	d.tables = dumps
+	return nil
+}
+
+func TestWriteAndRead(t *testing.T) {
+	db := &fakeDatabase{version: 11, tables: map[string][]byte{
+		"trades":      []byte(`{"id":"t1","symbol":"AAPL"}` + "\n"),
+		"subscribers": []byte(`{"user_id":1}` + "\n" + `{"user_id":2}` + "\n"),
+	}}
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	var buf bytes.Buffer
+	manifest, err := Write(&buf, db, []byte(`{"check_interval":60}`), now)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, 11, manifest.SchemaVersion)
+	assert.Equal(t, 3, manifest.Rows())
+
+	archive, err := Read(bytes.NewReader(buf.Bytes()))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, `{"check_interval":60}`, string(archive.Config))
+	assert.Equal(t, db.tables["subscribers"], archive.Tables["subscribers"])
+	assert.Empty(t, archive.Tables["signals"])
+
+	restored := &fakeDatabase{latest: 11}
+	assert.NoError(t, Restore(restored, archive))
+	assert.Equal(t, []int{11, 11}, restored.migrated)
+	assert.Equal(t, db.tables["trades"], restored.tables["trades"])
+}
+
+func TestRestoreMigratesUpFromTheArchiveVersion(t *testing.T) {
+	archive := &Archive{
+		Manifest: Manifest{SchemaVersion: 5},
+		Tables:   map[string][]byte{"trades": []byte(`{"id":"t1"}` + "\n")},
+	}
+
+	// The rows are loaded at the archive's version, then migrated up
+	older := &fakeDatabase{version: 3, latest: 11}
+	assert.NoError(t, Restore(older, archive))
+	assert.Equal(t, []int{5, 11}, older.migrated)
+	assert.Equal(t, archive.Tables, older.tables)
+
+	// A store past the archive's version is not migrated down
+	newer := &fakeDatabase{version: 11, latest: 11, tables: map[string][]byte{"trades": nil}}
+	assert.Error(t, Restore(newer, archive))
+	assert.Empty(t, newer.migrated)
+	assert.Equal(t, map[string][]byte{"trades": nil}, newer.tables)
+
+	// An archive from a newer release is refused
+	release := &fakeDatabase{latest: 4}
+	assert.Error(t, Restore(release, archive))
+	assert.Nil(t, release.tables)
+}
+
+func TestReadRejectsTamperedArchives(t *testing.T) {
+	db := &fakeDatabase{tables: map[string][]byte{"trades": []byte(`{"id":"t1"}` + "\n")}}
+	var buf bytes.Buffer
+	_, err := Write(&buf, db, nil, time.Now())
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// Rewrite the archive with one table's contents changed
+	rewrite := func(change func(name string, data []byte) []byte) []byte {
+		gz, _ := gzip.NewReader(bytes.NewReader(buf.Bytes()))
+		tr := tar.NewReader(gz)
+		var out bytes.Buffer
+		gw := gzip.NewWriter(&out)
+		tw := tar.NewWriter(gw)
+		for {
+			header, err := tr.Next()
+			if err != nil {
+				break
+			}
+			data, _ := io.ReadAll(tr)
+			if data = change(header.Name, data); data != nil {
+				writeFile(tw, header.Name, data, time.Now())
+			}
+		}
+		tw.Close()
+		gw.Close()
+		return out.Bytes()
+	}
+
+	tampered := rewrite(func(name string, data []byte) []byte {
+		if name == "tables/trades.jsonl" {
+			return []byte(`{"id":"t2"}` + "\n")
+		}
+		return data
+	})
+	_, err = Read(bytes.NewReader(tampered))
+	assert.ErrorIs(t, err, ErrCorrupt)
+
+	missing := rewrite(func(name string, data []byte) []byte {
+		if name == "tables/trades.jsonl" {
+			return nil
+		}
+		return data
+	})
+	_, err = Read(bytes.NewReader(missing))
+	assert.ErrorIs(t, err, ErrCorrupt)
+
+	_, err = Read(bytes.NewReader(buf.Bytes()[:buf.Len()/2]))
+	assert.Error(t, err)
+}
+
+func TestJobKeepsNewestArchives(t *testing.T) {
+	dir := t.TempDir()
+	cfg := config.BackupConfig{Enabled: true, Dir: dir, IntervalHours: 24, Keep: 2}
+	start := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	for day := 0; day < 3; day++ {
+		os.WriteFile(filepath.Join(dir, ArchiveName(start.AddDate(0, 0, day-3))), []byte("old"), 0600)
+	}
+
+	job := NewJob(cfg, &fakeDatabase{}, "")
+	job.now = func() time.Time { return start.Add(time.Hour) }
+	assert.True(t, job.RunIfDue())
+	assert.False(t, job.RunIfDue(), "not due again within the interval")
+
+	names, err := localArchives(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{ArchiveName(start.AddDate(0, 0, -1)), ArchiveName(start.Add(time.Hour))}, names)
+
+	// A restarted job counts the interval from the newest archive
+	job = NewJob(cfg, &fakeDatabase{}, "")
+	job.now = func() time.Time { return start.Add(2 * time.Hour) }
+	assert.False(t, job.RunIfDue())
+}
@@ -0,0 +1,205 @@
+package backup
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/objectstore"
+)
+
+// checkInterval is how often the job checks whether a backup is due
+const checkInterval = 10 * time.Minute
+
+// Archive file names are the prefix, the UTC time and the suffix, so that
+// they sort by age
+const (
+	namePrefix = "hustler-"
+	nameSuffix = ".tar.gz"
+	nameTime   = "20060102T150405Z"
+)
+
+// archiveType is the content type archives are uploaded with
+const archiveType = "application/gzip"
+
+// Remote stores archives off the machine (implemented by objectstore.S3)
+type Remote interface {
+	Put(key string, data []byte, contentType string) error
+	List(prefix string) ([]objectstore.Object, error)
+	Delete(key string) error
+}
+
+// Job takes scheduled backups
+type Job struct {
+	config     config.BackupConfig
+	db         Database
+	configFile string // Path of the config file backed up with the store; empty for none
+	remote     Remote
+	now        func() time.Time
+	last       time.Time
+	mu         sync.Mutex
+}
+
+// NewJob creates a backup job. The interval is counted from the newest
+// archive in the backup directory, so restarts don't take extra backups.
+func NewJob(cfg config.BackupConfig, db Database, configFile string) *Job {
+	j := &Job{config: cfg, db: db, configFile: configFile, now: time.Now}
+	if names, err := localArchives(cfg.Dir); err == nil && len(names) > 0 {
+		j.last, _ = time.Parse(nameTime, strings.TrimSuffix(strings.TrimPrefix(names[len(names)-1], namePrefix), nameSuffix))
+	}
+	return j
+}
+
+// SetRemote sets where archives are uploaded to besides the backup directory
+func (j *Job) SetRemote(remote Remote) {
+	j.remote = remote
+}
+
+// Run takes a backup whenever one is due until stop is closed
+func (j *Job) Run(stop <-chan struct{}) {
+	j.RunIfDue()
+
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			j.RunIfDue()
+		}
+	}
+}
+
+// RunIfDue takes a backup if the interval has passed since the last one
+func (j *Job) RunIfDue() bool {
+	j.mu.Lock()
+	now := j.now()
+	if now.Sub(j.last) < time.Duration(j.config.IntervalHours)*time.Hour {
+		j.mu.Unlock()
+		return false
+	}
+	j.last = now
+	j.mu.Unlock()
+
+	name, manifest, err := j.Backup(now)
+	if err != nil {
+		log.Printf("Error taking scheduled backup: %v", err)
+		return true
+	}
+	log.Printf("Backed up %d rows to %s", manifest.Rows(), name)
+	return true
+}
+
+// Backup writes an archive to the backup directory and the remote, then
+// removes the oldest archives beyond the number kept. It returns the
+// archive's file name.
+func (j *Job) Backup(now time.Time) (string, Manifest, error) {
+	var configFile []byte
+	if j.configFile != "" {
+		data, err := os.ReadFile(j.configFile)
+		if err != nil {
+			return "", Manifest{}, fmt.Errorf("failed to read config file: %w", err)
+		}
+		configFile = data
+	}
+
+	var archive bytes.Buffer
+	manifest, err := Write(&archive, j.db, configFile, now)
+	if err != nil {
+		return "", Manifest{}, err
+	}
+
+	name := ArchiveName(now)
+	if err := os.MkdirAll(j.config.Dir, 0700); err != nil {
+		return "", Manifest{}, fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(j.config.Dir, name), archive.Bytes(), 0600); err != nil {
+		return "", Manifest{}, fmt.Errorf("failed to write archive: %w", err)
+	}
+	if j.remote != nil {
+		if err := j.remote.Put(j.config.S3.Prefix+name, archive.Bytes(), archiveType); err != nil {
+			return name, manifest, fmt.Errorf("failed to upload archive: %w", err)
+		}
+	}
+
+	if err := j.prune(); err != nil {
+		log.Printf("Error removing old backups: %v", err)
+	}
+	return name, manifest, nil
+}
+
+// prune removes the oldest archives beyond the number kept
+func (j *Job) prune() error {
+	if j.config.Keep <= 0 {
+		return nil
+	}
+
+	names, err := localArchives(j.config.Dir)
+	if err != nil {
+		return err
+	}
+	for _, name := range expired(names, j.config.Keep) {
+		if err := os.Remove(filepath.Join(j.config.Dir, name)); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", name, err)
+		}
+	}
+
+	if j.remote == nil {
+		return nil
+	}
+	objects, err := j.remote.List(j.config.S3.Prefix + namePrefix)
+	if err != nil {
+		return err
+	}
+	var keys []string
+	for _, object := range objects {
+		if strings.HasSuffix(object.Key, nameSuffix) {
+			keys = append(keys, object.Key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range expired(keys, j.config.Keep) {
+		if err := j.remote.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ArchiveName returns the file name of an archive taken at t
+func ArchiveName(t time.Time) string {
+	return namePrefix + t.UTC().Format(nameTime) + nameSuffix
+}
+
+// localArchives returns the names of the archives in dir, oldest first
+func localArchives(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list backups: %w", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasPrefix(entry.Name(), namePrefix) && strings.HasSuffix(entry.Name(), nameSuffix) {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// expired returns the names, oldest first, beyond the newest keep
+func expired(names []string, keep int) []string {
+	if len(names) <= keep {
+		return nil
+	}
+	return names[:len(names)-keep]
+}
@@ -0,0 +1,304 @@
+package briefing
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/calendar"
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/llm"
+	"github.com/hustler/trading-bot/pkg/market"
+	"github.com/hustler/trading-bot/pkg/performance"
+)
+
+// checkInterval is how often the job checks whether the briefing is due
+const checkInterval = time.Minute
+
+// summaryTimeout bounds how long the LLM may take to summarize the briefing
+const summaryTimeout = 30 * time.Second
+
+// Defaults for unset briefing settings
+const (
+	defaultMinutesBeforeOpen = 30
+	defaultMaxMovers         = 5
+)
+
+// defaultFutures are the index futures briefed when none are configured
+var defaultFutures = []string{"ES=F", "NQ=F", "YM=F", "RTY=F"}
+
+// QuoteSource provides the latest prices of symbols (implemented by data.Provider)
+type QuoteSource interface {
+	GetMarketData(symbol string) (*data.MarketData, error)
+}
+
+// EventSource provides upcoming earnings and economic events (implemented by calendar.Feed)
+type EventSource interface {
+	Events(now time.Time) []calendar.Event
+}
+
+// ResultSource provides the outcomes of a day's signals (implemented by performance.Monitor)
+type ResultSource interface {
+	GetResultsByDate(date string) []*performance.SignalResult
+}
+
+// Summarizer writes the summary opening the briefing (implemented by llm.Manager)
+type Summarizer interface {
+	SummarizeBriefing(ctx context.Context, briefing llm.MarketBriefing) (string, error)
+}
+
+// MessageSender posts messages to the signal channel (implemented by telegram.Bot)
+type MessageSender interface {
+	SendMessage(message string) error
+}
+
+// Job posts the morning briefing to the channel before each session opens
+type Job struct {
+	quotes     QuoteSource
+	events     EventSource // Optional
+	results    ResultSource
+	summarizer Summarizer // Optional
+	sender     MessageSender
+	watched    []string
+	config     config.BriefingConfig
+	session    *market.Clock
+	clock      clock.Clock
+	lastDay    string
+	mu         sync.Mutex
+}
+
+// NewJob creates a job briefing the watched symbols the configured minutes
+// before the open. events may be nil.
+func NewJob(quotes QuoteSource, events EventSource, results ResultSource, sender MessageSender, watched []string, cfg config.BriefingConfig) *Job {
+	return &Job{
+		quotes:  quotes,
+		events:  events,
+		results: results,
+		sender:  sender,
+		watched: watched,
+		config:  cfg,
+		session: market.DefaultClock(),
+		clock:   clock.Real{},
+	}
+}
+
+// SetSummarizer sets the LLM opening the briefing with a summary, when enabled
+func (j *Job) SetSummarizer(summarizer Summarizer) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.summarizer = summarizer
+}
+
+// SetClock sets the clock used to decide when the briefing is due
+func (j *Job) SetClock(c clock.Clock) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.clock = c
+}
+
+// Run checks for the briefing time until stop is closed
+func (j *Job) Run(stop <-chan struct{}) {
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			j.RunIfDue()
+		}
+	}
+}
+
+// RunIfDue posts the briefing once per trading day, between the configured
+// minutes before the open and the open
+func (j *Job) RunIfDue() bool {
+	j.mu.Lock()
+	now := j.clock.Now()
+	day := j.session.DayKey(now)
+	open := j.session.SessionOpen(now)
+	lead := time.Duration(j.minutesBeforeOpen()) * time.Minute
+	if !j.session.IsTradingDay(now) || now.Before(open.Add(-lead)) || !now.Before(open) || j.lastDay == day {
+		j.mu.Unlock()
+		return false
+	}
+	j.lastDay = day
+	summarizer := j.summarizer
+	j.mu.Unlock()
+
+	briefing := j.Compile(now)
+	var summary string
+	if j.config.LLMSummary && summarizer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), summaryTimeout)
+		var err error
+		summary, err = summarizer.SummarizeBriefing(ctx, briefing)
+		cancel()
+		if err != nil {
+			log.Printf("Error summarizing morning briefing: %v", err)
+		}
+	}
+
+	if err := j.sender.SendMessage(FormatBriefing(briefing, summary)); err != nil {
+		log.Printf("Error sending morning briefing: %v", err)
+	}
+	return true
+}
+
+// Compile gathers the index futures, pre-market movers, today's events and
+// yesterday's signals at now
+func (j *Job) Compile(now time.Time) llm.MarketBriefing {
+	briefing := llm.MarketBriefing{Date: j.session.TradingDay(now)}
+
+	futures := j.config.Futures
+	if len(futures) == 0 {
+		futures = defaultFutures
+	}
+	for _, symbol := range futures {
+		if quote, ok := j.quote(symbol); ok {
+			briefing.Futures = append(briefing.Futures, quote)
+		}
+	}
+
+	for _, symbol := range j.watched {
+		if quote, ok := j.quote(symbol); ok && math.Abs(quote.ChangePct) >= j.config.MoverThresholdPct {
+			briefing.Movers = append(briefing.Movers, quote)
+		}
+	}
+	sort.SliceStable(briefing.Movers, func(a, b int) bool {
+		return math.Abs(briefing.Movers[a].ChangePct) > math.Abs(briefing.Movers[b].ChangePct)
+	})
+	if maxMovers := j.maxMovers(); len(briefing.Movers) > maxMovers {
+		briefing.Movers = briefing.Movers[:maxMovers]
+	}
+
+	if j.events != nil {
+		// From midnight, so reports due before the open are included
+		today := j.session.TradingDay(now)
+		for _, event := range j.events.Events(today) {
+			if event.Category == "report" || j.session.DayKey(event.Start) != j.session.DayKey(today) {
+				continue
+			}
+			briefing.Events = append(briefing.Events,
+				event.Start.In(j.session.Location()).Format("15:04")+" "+event.Summary)
+		}
+	}
+
+	briefing.Yesterday = j.summarizeDay(j.previousTradingDay(now))
+	return briefing
+}
+
+// quote returns the latest price of symbol and its change from the previous close
+func (j *Job) quote(symbol string) (llm.BriefingQuote, bool) {
+	md, err := j.quotes.GetMarketData(symbol)
+	if err != nil {
+		log.Printf("Error fetching %s for the morning briefing: %v", symbol, err)
+		return llm.BriefingQuote{}, false
+	}
+	if len(md.Prices) == 0 || md.PreviousClose <= 0 {
+		return llm.BriefingQuote{}, false
+	}
+	price := md.Prices[len(md.Prices)-1]
+	return llm.BriefingQuote{
+		Symbol:    symbol,
+		Price:     price,
+		ChangePct: (price - md.PreviousClose) / md.PreviousClose * 100,
+	}, true
+}
+
+// summarizeDay counts the outcomes of the signals generated on a day
+func (j *Job) summarizeDay(day time.Time) llm.DaySummary {
+	var summary llm.DaySummary
+	for _, result := range j.results.GetResultsByDate(day.Format("2006-01-02")) {
+		summary.Signals++
+		switch result.Status {
+		case performance.StatusSuccess:
+			summary.Wins++
+			summary.ReturnPct += result.ActualROI
+		case performance.StatusFailure, performance.StatusExpired:
+			summary.Losses++
+			summary.ReturnPct += result.ActualROI
+		default:
+			summary.Open++
+		}
+	}
+	return summary
+}
+
+// previousTradingDay returns local midnight of the trading day before now
+func (j *Job) previousTradingDay(now time.Time) time.Time {
+	day := j.session.TradingDay(now).AddDate(0, 0, -1)
+	for !j.session.IsTradingDay(day) {
+		day = day.AddDate(0, 0, -1)
+	}
+	return day
+}
+
+// minutesBeforeOpen returns how long before the open the briefing is posted
+func (j *Job) minutesBeforeOpen() int {
+	if j.config.MinutesBeforeOpen > 0 {
+		return j.config.MinutesBeforeOpen
+	}
+	return defaultMinutesBeforeOpen
+}
+
+// maxMovers returns how many pre-market movers the briefing lists
+func (j *Job) maxMovers() int {
+	if j.config.MaxMovers > 0 {
+		return j.config.MaxMovers
+	}
+	return defaultMaxMovers
+}
+
+// FormatBriefing formats a morning briefing for Telegram, opening with the
+// summary if there is one
+func FormatBriefing(b llm.MarketBriefing, summary string) string {
+	message := fmt.Sprintf("☀️ <b>MORNING BRIEFING</b> - %s\n\n", b.Date.Format("Mon Jan 2"))
+	if summary != "" {
+		message += html.EscapeString(summary) + "\n\n"
+	}
+
+	message += "<b>Index Futures</b>\n" + formatQuotes(b.Futures, "No futures quotes") + "\n"
+	message += "<b>Pre-Market Movers</b>\n" + formatQuotes(b.Movers, "No watched symbol is moving") + "\n"
+
+	message += "<b>Today</b>\n"
+	if len(b.Events) == 0 {
+		message += "No earnings or economic events\n"
+	}
+	for _, event := range b.Events {
+		message += "• " + html.EscapeString(event) + "\n"
+	}
+
+	y := b.Yesterday
+	message += "\n<b>Yesterday</b>\n"
+	if y.Signals == 0 {
+		message += "No signals"
+	} else {
+		message += fmt.Sprintf("%d signals: %d wins, %d losses, %d open (%+.2f%%)", y.Signals, y.Wins, y.Losses, y.Open, y.ReturnPct)
+	}
+	return message
+}
+
+// formatQuotes lists quotes one per line, or empty if there are none
+func formatQuotes(quotes []llm.BriefingQuote, empty string) string {
+	if len(quotes) == 0 {
+		return empty + "\n"
+	}
+	var lines strings.Builder
+	for _, q := range quotes {
+		arrow := "🟢"
+		if q.ChangePct < 0 {
+			arrow = "🔴"
+		}
+		fmt.Fprintf(&lines, "%s %s $%.2f (%+.2f%%)\n", arrow, q.Symbol, q.Price, q.ChangePct)
+	}
+	return lines.String()
+}
@@ -0,0 +1,105 @@
+package briefing
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/calendar"
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/llm"
+	"github.com/hustler/trading-bot/pkg/performance"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeQuotes serves a last price and previous close by symbol
+type fakeQuotes map[string][2]float64
+
+func (f fakeQuotes) GetMarketData(symbol string) (*data.MarketData, error) {
+	quote, ok := f[symbol]
+	if !ok {
+		return nil, fmt.Errorf("no data for %s", symbol)
+	}
+	return &data.MarketData{Symbol: symbol, Prices: []float64{quote[0]}, PreviousClose: quote[1]}, nil
+}
+
+type fakeEvents []calendar.Event
+
+func (f fakeEvents) Events(now time.Time) []calendar.Event { return f }
+
+type fakeResults map[string][]*performance.SignalResult
+
+func (f fakeResults) GetResultsByDate(date string) []*performance.SignalResult { return f[date] }
+
+type fakeSummarizer struct{}
+
+func (fakeSummarizer) SummarizeBriefing(ctx context.Context, b llm.MarketBriefing) (string, error) {
+	return fmt.Sprintf("%d futures & %d movers", len(b.Futures), len(b.Movers)), nil
+}
+
+type fakeSender struct {
+	messages []string
+}
+
+func (f *fakeSender) SendMessage(message string) error {
+	f.messages = append(f.messages, message)
+	return nil
+}
+
+func TestMorningBriefing(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	// Monday, before the briefing
+	fake := clock.NewFake(time.Date(2026, 10, 19, 8, 45, 0, 0, newYork))
+	quotes := fakeQuotes{
+		"ES=F": {5050, 5000},
+		"NQ=F": {17900, 18000},
+		"AAPL": {103, 100},
+		"MSFT": {100.5, 100},
+		"TSLA": {190, 200},
+	}
+	events := fakeEvents{
+		{Summary: "AAPL earnings", Category: "earnings", Start: time.Date(2026, 10, 19, 7, 0, 0, 0, newYork)},
+		{Summary: "US CPI", Category: "economic", Start: time.Date(2026, 10, 19, 8, 30, 0, 0, newYork)},
+		{Summary: "US Retail Sales", Category: "economic", Start: time.Date(2026, 10, 20, 8, 30, 0, 0, newYork)},
+		{Summary: "Weekly attribution report", Category: "report", Start: time.Date(2026, 10, 19, 17, 0, 0, 0, newYork)},
+	}
+	// Friday's signals
+	results := fakeResults{"2026-10-16": {
+		{Status: performance.StatusSuccess, ActualROI: 3},
+		{Status: performance.StatusFailure, ActualROI: -1.5},
+		{Status: performance.StatusActive},
+	}}
+	sender := &fakeSender{}
+
+	job := NewJob(quotes, events, results, sender, []string{"AAPL", "MSFT", "TSLA", "NVDA"},
+		config.BriefingConfig{Enabled: true, Futures: []string{"ES=F", "NQ=F"}, MoverThresholdPct: 2, LLMSummary: true})
+	job.SetClock(fake)
+	job.SetSummarizer(fakeSummarizer{})
+
+	assert.False(t, job.RunIfDue(), "more than 30 minutes before the open")
+
+	fake.Advance(20 * time.Minute)
+	assert.True(t, job.RunIfDue())
+	assert.False(t, job.RunIfDue(), "once a day")
+
+	briefing := job.Compile(fake.Now())
+	assert.Len(t, briefing.Futures, 2)
+	assert.InDelta(t, 1.0, briefing.Futures[0].ChangePct, 1e-9)
+	assert.Equal(t, []string{"TSLA", "AAPL"}, []string{briefing.Movers[0].Symbol, briefing.Movers[1].Symbol})
+	assert.Equal(t, []string{"07:00 AAPL earnings", "08:30 US CPI"}, briefing.Events)
+	assert.Equal(t, llm.DaySummary{Signals: 3, Wins: 1, Losses: 1, Open: 1, ReturnPct: 1.5}, briefing.Yesterday)
+
+	assert.Len(t, sender.messages, 1)
+	message := sender.messages[0]
+	assert.Contains(t, message, "2 futures &amp; 2 movers")
+	assert.Contains(t, message, "🔴 TSLA $190.00 (-5.00%)")
+	assert.Contains(t, message, "• 08:30 US CPI")
+	assert.Contains(t, message, "3 signals: 1 wins, 1 losses, 1 open (+1.50%)")
+
+	// Not on weekends
+	fake.Set(time.Date(2026, 10, 24, 9, 15, 0, 0, newYork))
+	assert.False(t, job.RunIfDue())
+}
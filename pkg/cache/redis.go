@@ -0,0 +1,320 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+)
+
+const (
+	// dialTimeout bounds connecting to Redis
+	dialTimeout = 5 * time.Second
+	// commandTimeout bounds a single command round trip
+	commandTimeout = 3 * time.Second
+	// resubscribeDelay is the pause before a dropped subscription reconnects
+	resubscribeDelay = 2 * time.Second
+)
+
+// errNil is returned by a command whose reply is the RESP nil bulk string
+var errNil = errors.New("redis: nil")
+
+// Redis is a minimal Redis client covering the commands the shared cache uses.
+// A single connection is shared by commands and redialed after an error.
+type Redis struct {
+	cfg    config.RedisConfig
+	conn   net.Conn
+	reader *bufio.Reader
+	mu     sync.Mutex
+}
+
+// NewRedis creates a Redis client. The connection is made on first use.
+func NewRedis(cfg config.RedisConfig) *Redis {
+	return &Redis{cfg: cfg}
+}
+
+// Key returns key with the configured prefix
+func (r *Redis) Key(key string) string {
+	return r.cfg.KeyPrefix + key
+}
+
+// Get returns the value stored at key, reporting false if it does not exist
+func (r *Redis) Get(key string) ([]byte, bool, error) {
+	reply, err := r.do("GET", r.Key(key))
+	if err == errNil {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	value, ok := reply.([]byte)
+	if !ok {
+		return nil, false, fmt.Errorf("unexpected GET reply: %v", reply)
+	}
+	return value, true, nil
+}
+
+// Set stores value at key, expiring after ttl (0 never expires)
+func (r *Redis) Set(key string, value []byte, ttl time.Duration) error {
+	args := []interface{}{"SET", r.Key(key), value}
+	if ttl > 0 {
+		args = append(args, "PX", ttl.Milliseconds())
+	}
+	_, err := r.do(args...)
+	return err
+}
+
+// SetNX stores value at key only if it does not exist, reporting whether it was stored
+func (r *Redis) SetNX(key string, value []byte, ttl time.Duration) (bool, error) {
+	args := []interface{}{"SET", r.Key(key), value, "NX"}
+	if ttl > 0 {
+		args = append(args, "PX", ttl.Milliseconds())
+	}
+	_, err := r.do(args...)
+	if err == errNil {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Delete removes key, doing nothing if it does not exist
+func (r *Redis) Delete(key string) error {
+	_, err := r.do("DEL", r.Key(key))
+	return err
+}
+
+// Publish sends payload to every subscriber of channel
+func (r *Redis) Publish(channel string, payload []byte) error {
+	_, err := r.do("PUBLISH", r.Key(channel), payload)
+	return err
+}
+
+// Subscribe calls handler with each message published to channel until ctx is
+// done. It reconnects after connection errors, so messages published while
+// disconnected are missed.
+func (r *Redis) Subscribe(ctx context.Context, channel string, handler func([]byte)) {
+	for ctx.Err() == nil {
+		if err := r.subscribeOnce(ctx, r.Key(channel), handler); err != nil && ctx.Err() == nil {
+			log.Printf("Redis subscription to %s dropped, reconnecting: %v", channel, err)
+			select {
+			case <-ctx.Done():
+			case <-time.After(resubscribeDelay):
+			}
+		}
+	}
+}
+
+// Ping checks that Redis is reachable and accepts the configured credentials
+func (r *Redis) Ping() error {
+	_, err := r.do("PING")
+	return err
+}
+
+// Close closes the command connection
+func (r *Redis) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.conn == nil {
+		return nil
+	}
+	err := r.conn.Close()
+	r.conn = nil
+	return err
+}
+
+// subscribeOnce runs one subscription on a dedicated connection
+func (r *Redis) subscribeOnce(ctx context.Context, channel string, handler func([]byte)) error {
+	conn, reader, err := r.dial()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// Unblock the read when the context ends
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	if err := writeCommand(conn, "SUBSCRIBE", channel); err != nil {
+		return err
+	}
+	for {
+		reply, err := readReply(reader)
+		if err != nil {
+			return err
+		}
+		// Messages arrive as ["message", channel, payload]
+		parts, ok := reply.([]interface{})
+		if !ok || len(parts) != 3 {
+			continue
+		}
+		if kind, _ := parts[0].([]byte); string(kind) != "message" {
+			continue
+		}
+		if payload, ok := parts[2].([]byte); ok {
+			handler(payload)
+		}
+	}
+}
+
+// do sends a command and reads its reply, redialing once if the connection is broken
+func (r *Redis) do(args ...interface{}) (interface{}, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for attempt := 0; ; attempt++ {
+		if r.conn == nil {
+			conn, reader, err := r.dial()
+			if err != nil {
+				return nil, err
+			}
+			r.conn, r.reader = conn, reader
+		}
+
+		r.conn.SetDeadline(time.Now().Add(commandTimeout))
+		err := writeCommand(r.conn, args...)
+		var reply interface{}
+		if err == nil {
+			reply, err = readReply(r.reader)
+		}
+
+		var redisErr redisError
+		if err == nil || err == errNil || errors.As(err, &redisErr) {
+			return reply, err
+		}
+
+		// Connection errors leave the stream in an unknown state
+		r.conn.Close()
+		r.conn = nil
+		if attempt > 0 {
+			return nil, fmt.Errorf("redis command failed: %w", err)
+		}
+	}
+}
+
+// dial connects and authenticates a new connection
+func (r *Redis) dial() (net.Conn, *bufio.Reader, error) {
+	conn, err := net.DialTimeout("tcp", r.cfg.Addr, dialTimeout)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to redis: %w", err)
+	}
+	reader := bufio.NewReader(conn)
+
+	setup := [][]interface{}{}
+	if r.cfg.Password != "" {
+		setup = append(setup, []interface{}{"AUTH", r.cfg.Password})
+	}
+	if r.cfg.DB != 0 {
+		setup = append(setup, []interface{}{"SELECT", r.cfg.DB})
+	}
+	conn.SetDeadline(time.Now().Add(commandTimeout))
+	for _, command := range setup {
+		if err := writeCommand(conn, command...); err != nil {
+			conn.Close()
+			return nil, nil, err
+		}
+		if _, err := readReply(reader); err != nil {
+			conn.Close()
+			return nil, nil, fmt.Errorf("failed to set up redis connection: %w", err)
+		}
+	}
+	conn.SetDeadline(time.Time{})
+
+	return conn, reader, nil
+}
+
+// redisError is an error reply from the server
+type redisError string
+
+func (e redisError) Error() string {
+	return "redis: " + string(e)
+}
+
+// writeCommand writes a command as a RESP array of bulk strings
+func writeCommand(w io.Writer, args ...interface{}) error {
+	buf := []byte("*" + strconv.Itoa(len(args)) + "\r\n")
+	for _, arg := range args {
+		var b []byte
+		switch v := arg.(type) {
+		case string:
+			b = []byte(v)
+		case []byte:
+			b = v
+		case int:
+			b = []byte(strconv.Itoa(v))
+		case int64:
+			b = []byte(strconv.FormatInt(v, 10))
+		default:
+			return fmt.Errorf("unsupported redis argument type %T", arg)
+		}
+		buf = append(buf, '$')
+		buf = strconv.AppendInt(buf, int64(len(b)), 10)
+		buf = append(buf, '\r', '\n')
+		buf = append(buf, b...)
+		buf = append(buf, '\r', '\n')
+	}
+	_, err := w.Write(buf)
+	return err
+}
+
+// readReply reads one RESP reply. Bulk strings are returned as []byte, integers
+// as int64 and arrays as []interface{}.
+func readReply(r *bufio.Reader) (interface{}, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("malformed redis reply: %q", line)
+	}
+	kind, body := line[0], line[1:len(line)-2]
+
+	switch kind {
+	case '+':
+		return body, nil
+	case '-':
+		return nil, redisError(body)
+	case ':':
+		return strconv.ParseInt(body, 10, 64)
+	case '$':
+		n, err := strconv.Atoi(body)
+		if err != nil {
+			return nil, fmt.Errorf("malformed bulk length: %q", body)
+		}
+		if n < 0 {
+			return nil, errNil
+		}
+		data := make([]byte, n+2)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, err
+		}
+		return data[:n], nil
+	case '*':
+		n, err := strconv.Atoi(body)
+		if err != nil {
+			return nil, fmt.Errorf("malformed array length: %q", body)
+		}
+		if n < 0 {
+			return nil, errNil
+		}
+		items := make([]interface{}, n)
+		for i := range items {
+			item, err := readReply(r)
+			if err != nil && err != errNil {
+				return nil, err
+			}
+			items[i] = item
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("unknown redis reply type %q", kind)
+	}
+}
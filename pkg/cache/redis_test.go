@@ -0,0 +1,176 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/signal"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeRedis is an in-memory server for the commands the client uses
+type fakeRedis struct {
+	listener    net.Listener
+	values      map[string][]byte
+	subscribers map[string][]net.Conn
+	mu          sync.Mutex
+}
+
+func startFakeRedis(t *testing.T) *fakeRedis {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &fakeRedis{listener: listener, values: make(map[string][]byte), subscribers: make(map[string][]net.Conn)}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+	return f
+}
+
+func (f *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for {
+		reply, err := readReply(reader)
+		if err != nil {
+			return
+		}
+		parts := reply.([]interface{})
+		args := make([]string, len(parts))
+		for i, part := range parts {
+			args[i] = string(part.([]byte))
+		}
+
+		f.mu.Lock()
+		switch args[0] {
+		case "GET":
+			if value, ok := f.values[args[1]]; ok {
+				conn.Write([]byte("$" + strconv.Itoa(len(value)) + "\r\n" + string(value) + "\r\n"))
+			} else {
+				conn.Write([]byte("$-1\r\n"))
+			}
+		case "SET":
+			_, exists := f.values[args[1]]
+			if len(args) > 3 && args[3] == "NX" && exists {
+				conn.Write([]byte("$-1\r\n"))
+			} else {
+				f.values[args[1]] = []byte(args[2])
+				conn.Write([]byte("+OK\r\n"))
+			}
+		case "DEL":
+			_, exists := f.values[args[1]]
+			delete(f.values, args[1])
+			if exists {
+				conn.Write([]byte(":1\r\n"))
+			} else {
+				conn.Write([]byte(":0\r\n"))
+			}
+		case "PUBLISH":
+			for _, sub := range f.subscribers[args[1]] {
+				writeCommand(sub, "message", args[1], args[2])
+			}
+			conn.Write([]byte(":1\r\n"))
+		case "SUBSCRIBE":
+			f.subscribers[args[1]] = append(f.subscribers[args[1]], conn)
+			writeCommand(conn, "subscribe", args[1], "1")
+		default:
+			conn.Write([]byte("-ERR unknown command\r\n"))
+		}
+		f.mu.Unlock()
+	}
+}
+
+func (f *fakeRedis) subscriberCount(channel string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.subscribers[channel])
+}
+
+func TestRedisGetSetAndClaim(t *testing.T) {
+	server := startFakeRedis(t)
+	client := NewRedis(config.RedisConfig{Addr: server.listener.Addr().String(), KeyPrefix: "test:", DedupTTLHours: 1})
+	defer client.Close()
+
+	_, ok, err := client.Get("missing")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.NoError(t, client.Set("quote:AAPL", []byte("data"), time.Minute))
+	assert.Contains(t, server.values, "test:quote:AAPL")
+	value, ok, err := client.Get("quote:AAPL")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("data"), value)
+
+	claimed, err := client.ClaimNotification("abc", "@channel")
+	assert.NoError(t, err)
+	assert.True(t, claimed)
+	claimed, err = client.ClaimNotification("abc", "@channel")
+	assert.NoError(t, err)
+	assert.False(t, claimed)
+
+	// A released claim can be taken again
+	assert.NoError(t, client.ReleaseNotification("abc", "@channel"))
+	assert.NotContains(t, server.values, "test:dedup:@channel:abc")
+	claimed, err = client.ClaimNotification("abc", "@channel")
+	assert.NoError(t, err)
+	assert.True(t, claimed)
+}
+
+func TestReadReplyParsesTypes(t *testing.T) {
+	reader := bufio.NewReader(strings.NewReader("$4\r\ndata\r\n:42\r\n-ERR bad\r\n*2\r\n+OK\r\n$-1\r\n"))
+
+	reply, err := readReply(reader)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("data"), reply)
+
+	reply, err = readReply(reader)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), reply)
+
+	_, err = readReply(reader)
+	assert.EqualError(t, err, "redis: ERR bad")
+
+	reply, err = readReply(reader)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"OK", nil}, reply)
+}
+
+func TestEventsReachSubscribers(t *testing.T) {
+	server := startFakeRedis(t)
+	cfg := config.RedisConfig{Addr: server.listener.Addr().String(), KeyPrefix: "test:"}
+	publisher := NewRedis(cfg)
+	defer publisher.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	received := make(chan Event, 1)
+	go NewRedis(cfg).SubscribeEvents(ctx, func(event Event) { received <- event })
+
+	assert.Eventually(t, func() bool { return server.subscriberCount("test:events") == 1 }, time.Second, 10*time.Millisecond)
+
+	assert.NoError(t, publisher.PublishEvent(Event{Type: EventSignal, Signal: &signal.Signal{ID: "SIG-1", Symbol: "AAPL"}}))
+	select {
+	case event := <-received:
+		assert.Equal(t, EventSignal, event.Type)
+		assert.Equal(t, "SIG-1", event.Signal.ID)
+	case <-time.After(time.Second):
+		t.Fatal("event not received")
+	}
+}
@@ -0,0 +1,213 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/indicators"
+	"github.com/hustler/trading-bot/pkg/monitor"
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+const (
+	// eventsChannel is the pub/sub channel signal events are published on
+	eventsChannel = "events"
+	// breadthKey and heatmapKey hold the monitor state mirrored for API instances
+	breadthKey = "monitor:breadth"
+	heatmapKey = "monitor:heatmap"
+)
+
+// Event types published by EventNotifier
+const (
+	EventSignal        = "signal"
+	EventSignalUpdate  = "signal_update"
+	EventSignalOutcome = "signal_outcome"
+	EventMessage       = "message"
+)
+
+// Event is a signal event shared between instances
+type Event struct {
+	Type      string         `json:"type"`
+	Time      time.Time      `json:"time"`
+	Signal    *signal.Signal `json:"signal,omitempty"`
+	ExitPrice float64        `json:"exit_price,omitempty"`
+	Message   string         `json:"message,omitempty"`
+}
+
+// ClaimNotification records a notification as sent to a chat, reporting false if
+// any instance already sent it. Keys expire after the configured dedup TTL.
+func (r *Redis) ClaimNotification(hash, chatID string) (bool, error) {
+	ttl := time.Duration(r.cfg.DedupTTLHours) * time.Hour
+	claimed, err := r.SetNX("dedup:"+chatID+":"+hash, []byte("1"), ttl)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim notification: %w", err)
+	}
+	return claimed, nil
+}
+
+// ReleaseNotification forgets a claimed notification that could not be sent,
+// so it is sent again by the next delivery
+func (r *Redis) ReleaseNotification(hash, chatID string) error {
+	if err := r.Delete("dedup:" + chatID + ":" + hash); err != nil {
+		return fmt.Errorf("failed to release notification: %w", err)
+	}
+	return nil
+}
+
+// PublishEvent publishes an event to every subscribed instance
+func (r *Redis) PublishEvent(event Event) error {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	if err := r.Publish(eventsChannel, payload); err != nil {
+		return fmt.Errorf("failed to publish event: %w", err)
+	}
+	return nil
+}
+
+// SubscribeEvents calls handler with each published event until ctx is done
+func (r *Redis) SubscribeEvents(ctx context.Context, handler func(Event)) {
+	r.Subscribe(ctx, eventsChannel, func(payload []byte) {
+		var event Event
+		if err := json.Unmarshal(payload, &event); err != nil {
+			log.Printf("Error decoding shared event: %v", err)
+			return
+		}
+		handler(event)
+	})
+}
+
+// EventNotifier forwards notifications to another notifier and publishes them as
+// events, so API instances see signals produced by the monitor instance
+type EventNotifier struct {
+	next  monitor.Notifier
+	redis *Redis
+}
+
+// NewEventNotifier creates an EventNotifier in front of next
+func NewEventNotifier(next monitor.Notifier, redis *Redis) *EventNotifier {
+	return &EventNotifier{next: next, redis: redis}
+}
+
+// SendSignal sends a new signal and publishes it
+func (n *EventNotifier) SendSignal(s *signal.Signal) error {
+	n.publish(Event{Type: EventSignal, Signal: s})
+	return n.next.SendSignal(s)
+}
+
+// UpdateSignal sends a signal change and publishes it
+func (n *EventNotifier) UpdateSignal(s *signal.Signal) error {
+	n.publish(Event{Type: EventSignalUpdate, Signal: s})
+	return n.next.UpdateSignal(s)
+}
+
+// SendSignalOutcome sends a signal's outcome and publishes it
+func (n *EventNotifier) SendSignalOutcome(s *signal.Signal, exitPrice float64) error {
+	n.publish(Event{Type: EventSignalOutcome, Signal: s, ExitPrice: exitPrice})
+	return n.next.SendSignalOutcome(s, exitPrice)
+}
+
+// SendMessage sends a message and publishes it
+func (n *EventNotifier) SendMessage(message string) error {
+	n.publish(Event{Type: EventMessage, Message: message})
+	return n.next.SendMessage(message)
+}
+
+// publish publishes an event, logging failures so notifications are never blocked by Redis
+func (n *EventNotifier) publish(event Event) {
+	if err := n.redis.PublishEvent(event); err != nil {
+		log.Printf("Error publishing %s event: %v", event.Type, err)
+	}
+}
+
+// MonitorState is the monitor state mirrored to API instances (implemented by monitor.MarketMonitor)
+type MonitorState interface {
+	GetBreadth() (indicators.Breadth, bool)
+	GetHeatmap() []monitor.HeatmapCell
+}
+
+// MirrorMonitorState copies the monitor's breadth and heatmap to Redis every
+// interval until ctx is done. Mirrored state expires after three intervals, so
+// API instances stop serving it if the monitor instance goes away.
+func (r *Redis) MirrorMonitorState(ctx context.Context, source MonitorState, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.publishMonitorState(source, 3*interval); err != nil {
+			log.Printf("Error mirroring monitor state: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// publishMonitorState writes the current monitor state
+func (r *Redis) publishMonitorState(source MonitorState, ttl time.Duration) error {
+	if breadth, ok := source.GetBreadth(); ok {
+		encoded, err := json.Marshal(breadth)
+		if err != nil {
+			return fmt.Errorf("failed to encode breadth: %w", err)
+		}
+		if err := r.Set(breadthKey, encoded, ttl); err != nil {
+			return err
+		}
+	}
+
+	encoded, err := json.Marshal(source.GetHeatmap())
+	if err != nil {
+		return fmt.Errorf("failed to encode heatmap: %w", err)
+	}
+	return r.Set(heatmapKey, encoded, ttl)
+}
+
+// RemoteMonitor serves monitor state mirrored to Redis, for API instances that
+// do not run a monitor themselves
+type RemoteMonitor struct {
+	redis *Redis
+}
+
+// NewRemoteMonitor creates a RemoteMonitor
+func NewRemoteMonitor(redis *Redis) *RemoteMonitor {
+	return &RemoteMonitor{redis: redis}
+}
+
+// GetBreadth returns the mirrored market breadth
+func (m *RemoteMonitor) GetBreadth() (indicators.Breadth, bool) {
+	var breadth indicators.Breadth
+	return breadth, m.load(breadthKey, &breadth)
+}
+
+// GetHeatmap returns the mirrored heatmap
+func (m *RemoteMonitor) GetHeatmap() []monitor.HeatmapCell {
+	var cells []monitor.HeatmapCell
+	m.load(heatmapKey, &cells)
+	return cells
+}
+
+// load decodes the value at key into v, reporting whether it was present
+func (m *RemoteMonitor) load(key string, v interface{}) bool {
+	data, ok, err := m.redis.Get(key)
+	if err != nil {
+		log.Printf("Error reading %s from redis: %v", key, err)
+		return false
+	}
+	if !ok {
+		return false
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		log.Printf("Error decoding %s from redis: %v", key, err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,200 @@
+package calendar
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/market"
+)
+
+// economicRefreshInterval is how often the economic calendar feed is re-fetched
+const economicRefreshInterval = 6 * time.Hour
+
+// Event durations, as calendar apps need an end
+const (
+	earningsDuration = time.Hour
+	economicDuration = 30 * time.Minute
+	reportDuration   = 15 * time.Minute
+)
+
+// EarningsCalendar provides upcoming earnings report times (implemented by Earnings)
+type EarningsCalendar interface {
+	NextEarnings(symbol string) (time.Time, bool)
+}
+
+// Earnings is a fixed set of earnings report times by symbol
+type Earnings map[string]time.Time
+
+// NewEarnings parses RFC 3339 report times by symbol, skipping invalid times
+func NewEarnings(times map[string]string) Earnings {
+	earnings := make(Earnings, len(times))
+	for symbol, value := range times {
+		at, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			log.Printf("Skipping invalid earnings time for %s: %v", symbol, err)
+			continue
+		}
+		earnings[symbol] = at
+	}
+	return earnings
+}
+
+// NextEarnings returns the earnings report time of symbol, if known
+func (e Earnings) NextEarnings(symbol string) (time.Time, bool) {
+	at, ok := e[symbol]
+	return at, ok
+}
+
+// Feed lists the upcoming events that affect the bot: earnings of watched
+// symbols, economic events and the scheduled reports
+type Feed struct {
+	config    *config.Config
+	session   *market.Clock
+	earnings  EarningsCalendar
+	economic  []data.EconomicEvent
+	fetchedAt time.Time
+	mu        sync.Mutex
+}
+
+// NewFeed creates a feed of the configured earnings times, economic calendar
+// and reports
+func NewFeed(cfg *config.Config) *Feed {
+	return &Feed{
+		config:   cfg,
+		session:  market.DefaultClock(),
+		earnings: NewEarnings(cfg.CalendarFeed.Earnings),
+	}
+}
+
+// SetEarningsCalendar sets where earnings report times come from
+func (f *Feed) SetEarningsCalendar(earnings EarningsCalendar) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.earnings = earnings
+}
+
+// Events returns the events from now until the configured horizon, by start time
+func (f *Feed) Events(now time.Time) []Event {
+	until := now.AddDate(0, 0, f.config.CalendarFeed.HorizonDays)
+	inRange := func(t time.Time) bool { return !t.Before(now) && t.Before(until) }
+
+	var events []Event
+	for _, e := range f.earningsEvents() {
+		if inRange(e.Start) {
+			events = append(events, e)
+		}
+	}
+	for _, e := range f.economicEvents(now) {
+		if inRange(e.Start) {
+			events = append(events, e)
+		}
+	}
+	events = append(events, f.reportEvents(now, until)...)
+
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Start.Before(events[j].Start) })
+	return events
+}
+
+// earningsEvents returns the earnings report of each watched symbol
+func (f *Feed) earningsEvents() []Event {
+	f.mu.Lock()
+	earnings := f.earnings
+	f.mu.Unlock()
+
+	var events []Event
+	for _, symbol := range f.config.WatchedSymbols() {
+		at, ok := earnings.NextEarnings(symbol)
+		if !ok {
+			continue
+		}
+		events = append(events, Event{
+			UID:         uid("earnings", symbol+"|"+at.UTC().Format(time.RFC3339)),
+			Summary:     symbol + " earnings",
+			Description: fmt.Sprintf("%s reports earnings. Expect gaps and wide spreads around the report.", symbol),
+			Category:    "earnings",
+			Start:       at,
+			End:         at.Add(earningsDuration),
+		})
+	}
+	return events
+}
+
+// economicEvents returns the economic calendar, refreshing it when due
+func (f *Feed) economicEvents(now time.Time) []Event {
+	cfg := f.config.EconomicCalendar
+	if !cfg.Enabled {
+		return nil
+	}
+
+	f.mu.Lock()
+	due := now.Sub(f.fetchedAt) >= economicRefreshInterval
+	f.mu.Unlock()
+
+	if due {
+		loaded, err := data.LoadEconomicCalendar(cfg)
+		f.mu.Lock()
+		// Keep the previous events if the feed failed
+		if err != nil {
+			log.Printf("Error loading economic calendar: %v", err)
+		} else {
+			f.economic = loaded
+		}
+		f.fetchedAt = now
+		f.mu.Unlock()
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	events := make([]Event, 0, len(f.economic))
+	for _, e := range f.economic {
+		events = append(events, Event{
+			UID:         uid("economic", e.Key()),
+			Summary:     fmt.Sprintf("%s %s", e.Country, e.Title),
+			Description: fmt.Sprintf("%s impact economic event. Signals may be paused or filtered around it.", e.Impact),
+			Category:    "economic",
+			Start:       e.Time,
+			End:         e.Time.Add(economicDuration),
+		})
+	}
+	return events
+}
+
+// reportEvents returns the weekly attribution reports between now and until
+func (f *Feed) reportEvents(now, until time.Time) []Event {
+	attribution := f.config.Attribution
+	weekday, ok := config.ParseWeekday(attribution.ReportWeekday)
+	if !attribution.WeeklyReport || !ok {
+		return nil
+	}
+
+	var events []Event
+	local := now.In(f.session.Location())
+	day := time.Date(local.Year(), local.Month(), local.Day(), attribution.ReportHour, 0, 0, 0, local.Location())
+	for ; day.Before(until); day = day.AddDate(0, 0, 1) {
+		if day.Weekday() != weekday || day.Before(now) {
+			continue
+		}
+		events = append(events, Event{
+			UID:         uid("report", "attribution|"+day.Format("2006-01-02")),
+			Summary:     "Weekly attribution report",
+			Description: "The bot posts the past week's signals, trades taken on them and outcomes.",
+			Category:    "report",
+			Start:       day,
+			End:         day.Add(reportDuration),
+		})
+	}
+	return events
+}
+
+// uid derives a stable event UID from its kind and identity
+func uid(kind, key string) string {
+	sum := sha1.Sum([]byte(key))
+	return kind + "-" + hex.EncodeToString(sum[:8]) + "@hustler"
+}
@@ -0,0 +1,78 @@
+package calendar
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hustler/trading-bot/pkg/config"
+)
+
+func TestFeedEvents(t *testing.T) {
+	cfg := config.CreateDefaultConfig()
+	cfg.StockSymbols = []string{"NVDA", "SHOP"}
+	cfg.CalendarFeed.HorizonDays = 14
+	cfg.CalendarFeed.Earnings = map[string]string{
+		"NVDA": "2026-10-21T20:20:00Z",
+		"SHOP": "2026-12-01T12:00:00Z", // Beyond the horizon
+		"AAPL": "2026-10-20T20:30:00Z", // Not watched
+	}
+	cfg.EconomicCalendar.Enabled = true
+	cfg.EconomicCalendar.URL = ""
+	cfg.EconomicCalendar.Events = []config.EconomicEventConfig{
+		{Title: "CPI m/m", Country: "USD", Time: "2026-10-19T12:30:00Z"},
+	}
+	cfg.Attribution.WeeklyReport = true
+	cfg.Attribution.ReportWeekday = "Friday"
+	cfg.Attribution.ReportHour = 17
+
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC) // Friday 08:00 in New York
+	events := NewFeed(cfg).Events(now)
+
+	var summaries []string
+	for _, e := range events {
+		summaries = append(summaries, e.Summary)
+	}
+	assert.Equal(t, []string{
+		"Weekly attribution report",
+		"USD CPI m/m",
+		"NVDA earnings",
+		"Weekly attribution report",
+	}, summaries)
+
+	assert.Equal(t, time.Date(2026, 10, 16, 21, 0, 0, 0, time.UTC), events[0].Start.UTC())
+	assert.NotEqual(t, events[0].UID, events[3].UID)
+
+	// UIDs are stable so calendar apps update events rather than duplicate them
+	assert.Equal(t, events[2].UID, NewFeed(cfg).Events(now)[2].UID)
+}
+
+func TestWriteICS(t *testing.T) {
+	start := time.Date(2026, 10, 21, 20, 20, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	err := WriteICS(&buf, "Hustler", []Event{{
+		UID:         "earnings-1@hustler",
+		Summary:     "NVDA earnings; Q3, after close",
+		Description: strings.Repeat("Expect gaps é ", 10),
+		Category:    "earnings",
+		Start:       start,
+		End:         start.Add(time.Hour),
+	}}, start)
+	assert.NoError(t, err)
+
+	ics := buf.String()
+	assert.True(t, strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\n"))
+	assert.True(t, strings.HasSuffix(ics, "END:VCALENDAR\r\n"))
+	assert.Contains(t, ics, "DTSTART:20261021T202000Z\r\n")
+	assert.Contains(t, ics, `SUMMARY:NVDA earnings\; Q3\, after close`+"\r\n")
+	assert.Contains(t, ics, "CATEGORIES:EARNINGS\r\n")
+
+	for _, line := range strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), maxLineOctets)
+	}
+	unfolded := strings.ReplaceAll(ics, "\r\n ", "")
+	assert.Contains(t, unfolded, "DESCRIPTION:"+strings.Repeat("Expect gaps é ", 10)+"\r\n")
+}
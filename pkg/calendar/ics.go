@@ -0,0 +1,82 @@
+package calendar
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// icsTime is the UTC date-time format of iCalendar
+const icsTime = "20060102T150405Z"
+
+// maxLineOctets is the longest content line before it is folded (RFC 5545 3.1)
+const maxLineOctets = 75
+
+// Event is an entry of the calendar feed
+type Event struct {
+	UID         string // Stable across refreshes so calendar apps update rather than duplicate
+	Summary     string
+	Description string
+	Category    string // earnings, economic or report
+	Start       time.Time
+	End         time.Time
+}
+
+// WriteICS writes events as an iCalendar (RFC 5545) document. now is the
+// time stamp of every event.
+func WriteICS(w io.Writer, name string, events []Event, now time.Time) error {
+	out := bufio.NewWriter(w)
+	line := func(name, value string) {
+		writeFolded(out, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//Hustler//Trading Bot//EN")
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	line("X-WR-CALNAME", escape(name))
+	line("REFRESH-INTERVAL;VALUE=DURATION", "PT6H")
+	line("X-PUBLISHED-TTL", "PT6H")
+	for _, e := range events {
+		line("BEGIN", "VEVENT")
+		line("UID", e.UID)
+		line("DTSTAMP", now.UTC().Format(icsTime))
+		line("DTSTART", e.Start.UTC().Format(icsTime))
+		line("DTEND", e.End.UTC().Format(icsTime))
+		line("SUMMARY", escape(e.Summary))
+		if e.Description != "" {
+			line("DESCRIPTION", escape(e.Description))
+		}
+		if e.Category != "" {
+			line("CATEGORIES", escape(strings.ToUpper(e.Category)))
+		}
+		line("TRANSP", "TRANSPARENT")
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+	return out.Flush()
+}
+
+// escape escapes a text value
+func escape(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(text)
+}
+
+// writeFolded writes a content line, folding it into lines of at most 75
+// octets without splitting a UTF-8 character
+func writeFolded(w *bufio.Writer, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		w.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		limit = maxLineOctets - 1 // The leading space of a continuation line counts
+	}
+	w.WriteString(line + "\r\n")
+}
@@ -0,0 +1,335 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/attribution"
+	"github.com/hustler/trading-bot/pkg/errkind"
+	"github.com/hustler/trading-bot/pkg/follower"
+	"github.com/hustler/trading-bot/pkg/monitor"
+	"github.com/hustler/trading-bot/pkg/performance"
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+const (
+	// defaultAttempts is how many times a read is sent before giving up
+	defaultAttempts = 3
+	// defaultBackoff is the wait before the first retry; it doubles per retry
+	defaultBackoff = 500 * time.Millisecond
+)
+
+// Client calls the Hustler REST API. Reads that fail because the server is
+// down or throttling are retried with backoff, and an expired token is
+// renewed when the client logged in with a password.
+type Client struct {
+	baseURL  string
+	http     *http.Client
+	attempts int
+	backoff  time.Duration
+
+	token    string
+	username string
+	password string
+	mu       sync.RWMutex
+}
+
+// NewClient creates a client for the API at baseURL, e.g. http://localhost:8080
+func NewClient(baseURL string) *Client {
+	return &Client{
+		baseURL:  strings.TrimRight(baseURL, "/"),
+		http:     &http.Client{Timeout: 30 * time.Second},
+		attempts: defaultAttempts,
+		backoff:  defaultBackoff,
+	}
+}
+
+// SetHTTPClient sets the HTTP client requests are sent with. Its timeout does
+// not apply to signal subscriptions.
+func (c *Client) SetHTTPClient(httpClient *http.Client) {
+	c.http = httpClient
+}
+
+// SetRetry sets how many times reads are attempted and the backoff before the
+// first retry
+func (c *Client) SetRetry(attempts int, backoff time.Duration) {
+	c.attempts = max(attempts, 1)
+	c.backoff = backoff
+}
+
+// SetToken authenticates requests with an existing JWT
+func (c *Client) SetToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = token
+}
+
+// Login exchanges a username and password for a token. The credentials are
+// kept so the token can be renewed when it expires.
+func (c *Client) Login(ctx context.Context, username, password string) error {
+	body, err := json.Marshal(map[string]string{"username": username, "password": password})
+	if err != nil {
+		return fmt.Errorf("failed to encode login: %w", err)
+	}
+	resp, err := c.send(ctx, c.http, http.MethodPost, "/api/login", body, "")
+	if err != nil {
+		return fmt.Errorf("failed to log in: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var login struct {
+		Token string `json:"token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&login); err != nil {
+		return fmt.Errorf("failed to decode login response: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token, c.username, c.password = login.Token, username, password
+	return nil
+}
+
+// Signals returns a page of stored signals matching q
+func (c *Client) Signals(ctx context.Context, q signal.Query) (*signal.Page, error) {
+	var page signal.Page
+	if err := c.get(ctx, "/api/v1/signals", signalParams(q), &page); err != nil {
+		return nil, fmt.Errorf("failed to get signals: %w", err)
+	}
+	return &page, nil
+}
+
+// Performance returns overall, per-symbol and per-day signal performance
+func (c *Client) Performance(ctx context.Context) (*performance.Metrics, error) {
+	var metrics performance.Metrics
+	if err := c.get(ctx, "/api/v1/performance", nil, &metrics); err != nil {
+		return nil, fmt.Errorf("failed to get performance: %w", err)
+	}
+	return &metrics, nil
+}
+
+// Attribution reports on the signals generated between from and to. Zero
+// times use the server's default of the last seven days.
+func (c *Client) Attribution(ctx context.Context, from, to time.Time) (*attribution.Report, error) {
+	params := url.Values{}
+	if !from.IsZero() {
+		params.Set("from", from.Format(time.RFC3339))
+	}
+	if !to.IsZero() {
+		params.Set("to", to.Format(time.RFC3339))
+	}
+
+	var report attribution.Report
+	if err := c.get(ctx, "/api/v1/attribution", params, &report); err != nil {
+		return nil, fmt.Errorf("failed to get attribution: %w", err)
+	}
+	return &report, nil
+}
+
+// Heatmap returns the price change, relative volume and signal state of
+// every watchlist symbol
+func (c *Client) Heatmap(ctx context.Context) ([]monitor.HeatmapCell, error) {
+	var cells []monitor.HeatmapCell
+	if err := c.get(ctx, "/api/v1/heatmap", nil, &cells); err != nil {
+		return nil, fmt.Errorf("failed to get heatmap: %w", err)
+	}
+	return cells, nil
+}
+
+// FollowerReport is what a subscriber following every signal would have made
+type FollowerReport struct {
+	Summary   follower.Summary    `json:"summary"`
+	Positions []follower.Position `json:"positions"`
+}
+
+// Follower returns the follower simulation's summary and positions
+func (c *Client) Follower(ctx context.Context) (*FollowerReport, error) {
+	var report FollowerReport
+	if err := c.get(ctx, "/api/v1/follower", nil, &report); err != nil {
+		return nil, fmt.Errorf("failed to get follower simulation: %w", err)
+	}
+	return &report, nil
+}
+
+// Logs returns up to the last n log lines, oldest first
+func (c *Client) Logs(ctx context.Context, n int) ([]string, error) {
+	params := url.Values{"lines": {strconv.Itoa(max(n, 1))}}
+	var lines []string
+	if err := c.get(ctx, "/api/v1/logs", params, &lines); err != nil {
+		return nil, fmt.Errorf("failed to get logs: %w", err)
+	}
+	return lines, nil
+}
+
+// AdjustSignal moves an active signal's "target" or "stop" level to price
+func (c *Client) AdjustSignal(ctx context.Context, signalID, level string, price float64) (*signal.Signal, error) {
+	var adjusted signal.Signal
+	request := map[string]interface{}{"signal_id": signalID, "level": level, "price": price}
+	if err := c.post(ctx, "/api/v1/signals/adjust", request, &adjusted); err != nil {
+		return nil, fmt.Errorf("failed to adjust signal: %w", err)
+	}
+	return &adjusted, nil
+}
+
+// SendTestSignal sends a labeled test signal for symbol through the real
+// signal pipeline
+func (c *Client) SendTestSignal(ctx context.Context, symbol string) (*signal.Signal, error) {
+	var sent signal.Signal
+	if err := c.post(ctx, "/api/v1/signals/test", map[string]string{"symbol": symbol}, &sent); err != nil {
+		return nil, fmt.Errorf("failed to send test signal: %w", err)
+	}
+	return &sent, nil
+}
+
+// signalParams encodes a signal query as the signals endpoint's query params
+func signalParams(q signal.Query) url.Values {
+	params := url.Values{}
+	set := func(key, value string) {
+		if value != "" {
+			params.Set(key, value)
+		}
+	}
+	set("symbol", q.Symbol)
+	set("type", string(q.Type))
+	set("status", string(q.Status))
+	set("strategy", q.Strategy)
+	set("sort", q.SortBy)
+	if q.MinConfidence > 0 {
+		params.Set("min_confidence", strconv.FormatFloat(q.MinConfidence, 'f', -1, 64))
+	}
+	if !q.From.IsZero() {
+		params.Set("from", q.From.Format(time.RFC3339))
+	}
+	if !q.To.IsZero() {
+		params.Set("to", q.To.Format(time.RFC3339))
+	}
+	if q.Ascending {
+		params.Set("order", "asc")
+	}
+	if q.Limit > 0 {
+		params.Set("limit", strconv.Itoa(q.Limit))
+	}
+	if q.Offset > 0 {
+		params.Set("offset", strconv.Itoa(q.Offset))
+	}
+	return params
+}
+
+// get decodes the JSON response of a GET request into v, retrying with
+// backoff while the server is down or throttling
+func (c *Client) get(ctx context.Context, path string, params url.Values, v interface{}) error {
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	backoff := c.backoff
+	var err error
+	for attempt := 1; attempt <= c.attempts; attempt++ {
+		err = c.do(ctx, http.MethodGet, path, nil, v)
+		if err == nil || !errkind.Retryable(err) || attempt == c.attempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+	return err
+}
+
+// post sends request as JSON and decodes the JSON response into v. Writes
+// are not retried, as the server may have acted on a failed attempt.
+func (c *Client) post(ctx context.Context, path string, request, v interface{}) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+	return c.do(ctx, http.MethodPost, path, body, v)
+}
+
+// do sends an authenticated request and decodes its JSON response into v
+func (c *Client) do(ctx context.Context, method, path string, body []byte, v interface{}) error {
+	resp, err := c.open(ctx, c.http, method, path, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// open sends an authenticated request. A rejected token is renewed once if
+// the client has credentials.
+func (c *Client) open(ctx context.Context, httpClient *http.Client, method, path string, body []byte) (*http.Response, error) {
+	resp, err := c.send(ctx, httpClient, method, path, body, c.currentToken())
+	if errors.Is(err, errkind.ErrAuth) && c.renewToken(ctx) {
+		resp, err = c.send(ctx, httpClient, method, path, body, c.currentToken())
+	}
+	return resp, err
+}
+
+// send sends a request, returning an errkind.StatusError for non-2xx
+// responses. The caller closes the body of successful responses.
+func (c *Client) send(ctx context.Context, httpClient *http.Client, method, path string, body []byte, token string) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, errkind.Unreachable(err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, errkind.Status(resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	return resp, nil
+}
+
+// currentToken returns the token requests are authenticated with
+func (c *Client) currentToken() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.token
+}
+
+// renewToken logs in again with the stored credentials, reporting whether a
+// new token was issued
+func (c *Client) renewToken(ctx context.Context) bool {
+	c.mu.RLock()
+	username, password := c.username, c.password
+	c.mu.RUnlock()
+
+	if username == "" {
+		return false
+	}
+	return c.Login(ctx, username, password) == nil
+}
@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/performance"
+	"github.com/hustler/trading-bot/pkg/signal"
+	"github.com/hustler/trading-bot/pkg/stream"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientRetriesAndRenewsToken(t *testing.T) {
+	logins, performanceCalls := 0, 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/login", func(w http.ResponseWriter, r *http.Request) {
+		logins++
+		json.NewEncoder(w).Encode(map[string]string{"token": fmt.Sprintf("token-%d", logins)})
+	})
+	mux.HandleFunc("/api/v1/signals", func(w http.ResponseWriter, r *http.Request) {
+		// The first token has expired
+		if r.Header.Get("Authorization") != "Bearer token-2" {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		assert.Equal(t, "AAPL", r.URL.Query().Get("symbol"))
+		assert.Equal(t, "0.7", r.URL.Query().Get("min_confidence"))
+		assert.Equal(t, "asc", r.URL.Query().Get("order"))
+		json.NewEncoder(w).Encode(signal.Page{Signals: []*signal.Signal{{ID: "SIG-1", Symbol: "AAPL"}}, Total: 1})
+	})
+	mux.HandleFunc("/api/v1/performance", func(w http.ResponseWriter, r *http.Request) {
+		performanceCalls++
+		if performanceCalls == 1 {
+			http.Error(w, "Performance not available", http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(performance.Metrics{SignalsCount: 4, SuccessRate: 75})
+	})
+	mux.HandleFunc("/api/v1/signals/test", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "no market data", http.StatusBadGateway)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	c := NewClient(server.URL + "/")
+	c.SetRetry(3, time.Millisecond)
+	ctx := context.Background()
+	assert.NoError(t, c.Login(ctx, "trader", "secret"))
+
+	page, err := c.Signals(ctx, signal.Query{Symbol: "AAPL", MinConfidence: 0.7, Ascending: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, page.Total)
+	assert.Equal(t, "SIG-1", page.Signals[0].ID)
+	assert.Equal(t, 2, logins)
+
+	metrics, err := c.Performance(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, metrics.SignalsCount)
+	assert.Equal(t, 2, performanceCalls)
+
+	_, err = c.SendTestSignal(ctx, "AAPL")
+	assert.ErrorContains(t, err, "no market data")
+}
+
+func TestSubscribeSignals(t *testing.T) {
+	hub := stream.NewHub()
+	mux := http.NewServeMux()
+	mux.Handle("/api/v1/signals/stream", hub)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	c := NewClient(server.URL)
+	c.SetToken("token")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := make(chan SignalEvent, 2)
+	done := make(chan error, 1)
+	go func() {
+		done <- c.SubscribeSignals(ctx, func(e SignalEvent) { events <- e })
+	}()
+
+	assert.Eventually(t, func() bool { return hub.Subscribers() == 1 }, time.Second, 5*time.Millisecond)
+	hub.Publish(stream.EventSignal, stream.SignalEvent{Signal: &signal.Signal{ID: "SIG-1", Symbol: "AAPL"}})
+	hub.Publish(stream.EventSignalClosed, stream.SignalEvent{Signal: &signal.Signal{ID: "SIG-1"}, ExitPrice: 101.5})
+
+	first := <-events
+	assert.Equal(t, stream.EventSignal, first.Type)
+	assert.Equal(t, "AAPL", first.Signal.Symbol)
+	closed := <-events
+	assert.Equal(t, stream.EventSignalClosed, closed.Type)
+	assert.Equal(t, 101.5, closed.ExitPrice)
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+}
@@ -0,0 +1,111 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/errkind"
+	"github.com/hustler/trading-bot/pkg/signal"
+	"github.com/hustler/trading-bot/pkg/stream"
+)
+
+const (
+	// maxReconnectBackoff caps the wait between attempts to reconnect a subscription
+	maxReconnectBackoff = 30 * time.Second
+	// maxEventSize is the largest server-sent event line accepted
+	maxEventSize = 1 << 20
+)
+
+// SignalEvent is a signal lifecycle change received from the signal stream
+type SignalEvent struct {
+	Type      string // stream.EventSignal, stream.EventSignalAdjusted or stream.EventSignalClosed
+	Signal    *signal.Signal
+	ExitPrice float64 // Set for closed signals
+}
+
+// SubscribeSignals calls handler with each new, adjusted and closed signal
+// until ctx is done. Dropped connections are reopened with backoff; events
+// published while disconnected are missed, so fetch Signals after a gap if
+// they matter. It returns ctx's error, or the error that made reconnecting
+// pointless, such as rejected credentials.
+func (c *Client) SubscribeSignals(ctx context.Context, handler func(SignalEvent)) error {
+	// The stream stays open indefinitely, so the request timeout cannot apply
+	streamClient := *c.http
+	streamClient.Timeout = 0
+
+	backoff := c.backoff
+	for {
+		connected, err := c.streamSignals(ctx, &streamClient, handler)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil && !errkind.Retryable(err) {
+			return fmt.Errorf("failed to subscribe to signals: %w", err)
+		}
+		if connected {
+			backoff = c.backoff
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxReconnectBackoff)
+	}
+}
+
+// streamSignals reads one connection to the signal stream until it ends,
+// reporting whether it connected
+func (c *Client) streamSignals(ctx context.Context, httpClient *http.Client, handler func(SignalEvent)) (bool, error) {
+	resp, err := c.open(ctx, httpClient, http.MethodGet, "/api/v1/signals/stream", nil)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	err = readEvents(resp.Body, func(name string, data []byte) {
+		var e stream.SignalEvent
+		if err := json.Unmarshal(data, &e); err != nil || e.Signal == nil {
+			return
+		}
+		handler(SignalEvent{Type: name, Signal: e.Signal, ExitPrice: e.ExitPrice})
+	})
+	return true, errkind.Unreachable(err)
+}
+
+// readEvents calls fn with the name and data of each server-sent event in r
+// until r ends
+func readEvents(r io.Reader, fn func(name string, data []byte)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEventSize)
+
+	var name string
+	var data []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if len(data) > 0 {
+				fn(name, []byte(strings.Join(data, "\n")))
+			}
+			name, data = "", nil
+		case strings.HasPrefix(line, ":"):
+			// Comment, e.g. a keep-alive
+		case strings.HasPrefix(line, "event:"):
+			name = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return io.ErrUnexpectedEOF
+}
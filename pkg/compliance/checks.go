@@ -0,0 +1,279 @@
+package compliance
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/execution"
+	"github.com/hustler/trading-bot/pkg/market"
+	"github.com/hustler/trading-bot/pkg/strategy"
+)
+
+// Pattern day trader rule: below the minimum equity an account may make at
+// most pdtMaxDayTrades day trades within pdtWindowDays trading days
+const (
+	pdtMinEquity    = 25000.0
+	pdtMaxDayTrades = 3
+	pdtWindowDays   = 5
+)
+
+// NewChecks returns the pre-trade checks enabled in cfg, in the order they
+// should run
+func NewChecks(cfg config.ComplianceConfig) []execution.PreTradeCheck {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	clock := market.DefaultClock()
+	var checks []execution.PreTradeCheck
+	if len(cfg.RestrictedSymbols) > 0 {
+		checks = append(checks, NewRestrictedList(cfg.RestrictedSymbols))
+	}
+	if cfg.AccountEquity > 0 {
+		checks = append(checks, NewPatternDayTrader(cfg.AccountEquity, clock))
+	}
+	// Margin accounts may buy beyond their settled cash, within the buying
+	// power RiskManager.BuyingPowerCheck enforces
+	if cfg.Cash > 0 && cfg.MarginMultiplier <= 1 {
+		checks = append(checks, NewSettledCash(cfg.Cash, cfg.SettlementDays, clock))
+	}
+	if cfg.WashSaleDays > 0 {
+		checks = append(checks, NewWashSale(cfg.WashSaleDays))
+	}
+	return checks
+}
+
+// RestrictedList blocks new positions in restricted symbols. Exits pass with
+// a warning so an existing position can always be closed.
+type RestrictedList struct {
+	symbols map[string]bool
+}
+
+// NewRestrictedList creates a check for the given symbols
+func NewRestrictedList(symbols []string) *RestrictedList {
+	restricted := make(map[string]bool, len(symbols))
+	for _, symbol := range symbols {
+		restricted[strings.ToUpper(symbol)] = true
+	}
+	return &RestrictedList{symbols: restricted}
+}
+
+// Name returns the name check results are recorded under
+func (r *RestrictedList) Name() string {
+	return "restricted_list"
+}
+
+// Check blocks an order opening or adding to a position in a restricted symbol
+func (r *RestrictedList) Check(order execution.Order, account execution.Account) execution.CheckResult {
+	if !r.symbols[strings.ToUpper(order.Symbol)] {
+		return execution.CheckResult{Passed: true}
+	}
+	if !account.Opening {
+		return execution.CheckResult{Passed: true, Warning: fmt.Sprintf("%s is restricted, exit allowed", order.Symbol)}
+	}
+	return execution.CheckResult{Reason: fmt.Sprintf("%s is on the restricted list", order.Symbol)}
+}
+
+// PatternDayTrader keeps an account below the pattern day trader equity
+// minimum from exceeding its day trade allowance. A day trade is a position
+// entered and exited on the same trading day.
+type PatternDayTrader struct {
+	equity float64
+	clock  *market.Clock
+}
+
+// NewPatternDayTrader creates a check for an account with the given equity
+func NewPatternDayTrader(equity float64, clock *market.Clock) *PatternDayTrader {
+	return &PatternDayTrader{equity: equity, clock: clock}
+}
+
+// Name returns the name check results are recorded under
+func (p *PatternDayTrader) Name() string {
+	return "pattern_day_trader"
+}
+
+// Check blocks new positions once the day trade allowance is used up and
+// warns when an exit would exceed it. Exits are never blocked.
+func (p *PatternDayTrader) Check(order execution.Order, account execution.Account) execution.CheckResult {
+	if p.equity >= pdtMinEquity {
+		return execution.CheckResult{Passed: true}
+	}
+
+	dayTrades := p.dayTrades(account.Positions, account.Now)
+	if account.Opening {
+		if dayTrades >= pdtMaxDayTrades {
+			return execution.CheckResult{Reason: fmt.Sprintf("%d day trades in the last %d trading days with equity $%.2f below $%.0f",
+				dayTrades, pdtWindowDays, p.equity, pdtMinEquity)}
+		}
+		return execution.CheckResult{Passed: true}
+	}
+
+	if account.Position != nil && p.enteredOn(*account.Position, account.Now) && dayTrades >= pdtMaxDayTrades {
+		return execution.CheckResult{Passed: true, Warning: fmt.Sprintf("exit would be day trade %d in %d trading days and flag the account as a pattern day trader",
+			dayTrades+1, pdtWindowDays)}
+	}
+	return execution.CheckResult{Passed: true}
+}
+
+// dayTrades counts the days within the window on which a position was both
+// entered and exited
+func (p *PatternDayTrader) dayTrades(positions []execution.Trade, now time.Time) int {
+	windowStart := p.clock.TradingDay(now)
+	for days := 1; days < pdtWindowDays; {
+		windowStart = windowStart.AddDate(0, 0, -1)
+		if p.clock.IsTradingDay(windowStart) {
+			days++
+		}
+	}
+
+	count := 0
+	for _, position := range positions {
+		entered := make(map[string]bool)
+		exited := make(map[string]bool)
+		for _, fill := range position.Fills {
+			if p.clock.TradingDay(fill.FilledAt).Before(windowStart) {
+				continue
+			}
+			day := p.clock.DayKey(fill.FilledAt)
+			if fill.Side == position.Type {
+				entered[day] = true
+			} else {
+				exited[day] = true
+			}
+		}
+		for day := range exited {
+			if entered[day] {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// enteredOn reports whether position has an entry fill on now's trading day
+func (p *PatternDayTrader) enteredOn(position execution.Trade, now time.Time) bool {
+	today := p.clock.DayKey(now)
+	for _, fill := range position.Fills {
+		if fill.Side == position.Type && p.clock.DayKey(fill.FilledAt) == today {
+			return true
+		}
+	}
+	return false
+}
+
+// SettledCash blocks long entries the account's settled cash cannot pay for.
+// Sale proceeds become available settlementDays trading days after the sale.
+type SettledCash struct {
+	cash           float64
+	settlementDays int
+	clock          *market.Clock
+}
+
+// NewSettledCash creates a check for an account that started with cash
+func NewSettledCash(cash float64, settlementDays int, clock *market.Clock) *SettledCash {
+	return &SettledCash{cash: cash, settlementDays: settlementDays, clock: clock}
+}
+
+// Name returns the name check results are recorded under
+func (s *SettledCash) Name() string {
+	return "settled_cash"
+}
+
+// Check blocks a buy costing more than the settled cash available
+func (s *SettledCash) Check(order execution.Order, account execution.Account) execution.CheckResult {
+	if !account.Opening || order.Side != strategy.Buy {
+		return execution.CheckResult{Passed: true}
+	}
+
+	settled, unsettled := s.available(account.Positions, account.Now)
+	cost := order.Price * float64(order.Quantity)
+	if cost <= settled {
+		return execution.CheckResult{Passed: true}
+	}
+	if cost <= settled+unsettled {
+		return execution.CheckResult{Reason: fmt.Sprintf("order costs $%.2f, only $%.2f settled with $%.2f still settling", cost, settled, unsettled)}
+	}
+	return execution.CheckResult{Reason: fmt.Sprintf("order costs $%.2f, only $%.2f cash available", cost, settled)}
+}
+
+// available returns the settled cash left after every long fill and the sale
+// proceeds that have not settled yet
+func (s *SettledCash) available(positions []execution.Trade, now time.Time) (settled, unsettled float64) {
+	settled = s.cash
+	for _, position := range positions {
+		if position.Type != strategy.Buy {
+			continue
+		}
+		for _, fill := range position.Fills {
+			amount := fill.Price * float64(fill.Quantity)
+			switch {
+			case fill.Side == strategy.Buy:
+				settled -= amount
+			case s.settled(fill.FilledAt, now):
+				settled += amount
+			default:
+				unsettled += amount
+			}
+		}
+	}
+	return settled, unsettled
+}
+
+// settled reports whether a sale at soldAt has settled by now
+func (s *SettledCash) settled(soldAt, now time.Time) bool {
+	day := s.clock.TradingDay(soldAt)
+	for days := 0; days < s.settlementDays; {
+		day = day.AddDate(0, 0, 1)
+		if s.clock.IsTradingDay(day) {
+			days++
+		}
+	}
+	return !s.clock.TradingDay(now).Before(day)
+}
+
+// WashSale warns when a buy repurchases a symbol sold at a loss within the
+// wash sale window, which would disallow the loss for tax purposes
+type WashSale struct {
+	days int
+}
+
+// NewWashSale creates a check with a window of the given number of days
+func NewWashSale(days int) *WashSale {
+	return &WashSale{days: days}
+}
+
+// Name returns the name check results are recorded under
+func (w *WashSale) Name() string {
+	return "wash_sale"
+}
+
+// Check warns about a buy of a symbol closed at a loss within the window. It
+// never blocks the order.
+func (w *WashSale) Check(order execution.Order, account execution.Account) execution.CheckResult {
+	if !account.Opening || order.Side != strategy.Buy {
+		return execution.CheckResult{Passed: true}
+	}
+
+	windowStart := account.Now.AddDate(0, 0, -w.days)
+	for _, position := range account.Positions {
+		if position.Symbol != order.Symbol || position.Type != strategy.Buy ||
+			position.Status != execution.Completed || position.RealizedPnL >= 0 {
+			continue
+		}
+		if closedAt(position).After(windowStart) {
+			return execution.CheckResult{Passed: true, Warning: fmt.Sprintf("%s was sold at a $%.2f loss within %d days, buying it back may be a wash sale",
+				order.Symbol, -position.RealizedPnL, w.days)}
+		}
+	}
+	return execution.CheckResult{Passed: true}
+}
+
+// closedAt returns the time of the last fill of a position
+func closedAt(position execution.Trade) time.Time {
+	if len(position.Fills) == 0 {
+		return position.UpdatedAt
+	}
+	return position.Fills[len(position.Fills)-1].FilledAt
+}
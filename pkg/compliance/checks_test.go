@@ -0,0 +1,84 @@
+package compliance
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/execution"
+	"github.com/hustler/trading-bot/pkg/market"
+	"github.com/hustler/trading-bot/pkg/strategy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecksBlockRestrictedSymbolsAndPatternDayTrading(t *testing.T) {
+	manager := execution.NewTradeManager(1000, 100)
+	// Tuesday 10:00 New York time
+	manager.SetClock(clock.NewFake(time.Date(2026, 10, 13, 14, 0, 0, 0, time.UTC)))
+	for _, check := range NewChecks(config.ComplianceConfig{
+		Enabled:           true,
+		AccountEquity:     10000,
+		RestrictedSymbols: []string{"tsla"},
+		WashSaleDays:      30,
+	}) {
+		manager.AddPreTradeCheck(check)
+	}
+
+	_, err := manager.ExecuteTrade(&strategy.TradeDecision{Symbol: "TSLA", Signal: strategy.Buy}, &data.Stock{Symbol: "TSLA", CurrentPrice: 200})
+	assert.True(t, errors.Is(err, execution.ErrComplianceBlocked))
+	assert.Empty(t, manager.GetActiveTrades())
+
+	for i := 0; i < 3; i++ {
+		position, err := manager.ExecuteTrade(&strategy.TradeDecision{Symbol: "AAPL", Signal: strategy.Buy}, &data.Stock{Symbol: "AAPL", CurrentPrice: 100})
+		assert.NoError(t, err)
+		assert.Len(t, position.Checks, 3)
+		assert.True(t, position.Checks[0].Passed)
+		_, err = manager.CloseTrade(position.ID, &data.Stock{Symbol: "AAPL", CurrentPrice: 99}, "test")
+		assert.NoError(t, err)
+	}
+
+	// The losing round trips make a fourth entry a wash sale, but the day trade limit blocks it first
+	_, err = manager.ExecuteTrade(&strategy.TradeDecision{Symbol: "AAPL", Signal: strategy.Buy}, &data.Stock{Symbol: "AAPL", CurrentPrice: 100})
+	assert.True(t, errors.Is(err, execution.ErrComplianceBlocked))
+	assert.Contains(t, err.Error(), "pattern_day_trader")
+	assert.Empty(t, manager.GetActiveTrades())
+}
+
+func TestSettledCashWaitsForSaleProceeds(t *testing.T) {
+	check := NewSettledCash(1000, 1, market.DefaultClock())
+	thursday := time.Date(2026, 10, 15, 15, 0, 0, 0, time.UTC)
+	friday := thursday.AddDate(0, 0, 1)
+	monday := thursday.AddDate(0, 0, 4)
+	sold := execution.Trade{
+		Symbol:      "AAPL",
+		Type:        strategy.Buy,
+		Status:      execution.Completed,
+		RealizedPnL: -50,
+		Fills: []execution.Fill{
+			{Symbol: "AAPL", Side: strategy.Buy, Quantity: 10, Price: 100, FilledAt: thursday},
+			{Symbol: "AAPL", Side: strategy.Sell, Quantity: 10, Price: 95, FilledAt: friday},
+		},
+	}
+	order := execution.Order{Symbol: "MSFT", Side: strategy.Buy, Quantity: 5, Price: 100}
+
+	result := check.Check(order, execution.Account{Now: friday, Opening: true, Positions: []execution.Trade{sold}})
+	assert.False(t, result.Passed)
+	assert.Contains(t, result.Reason, "still settling")
+
+	result = check.Check(order, execution.Account{Now: monday, Opening: true, Positions: []execution.Trade{sold}})
+	assert.True(t, result.Passed)
+
+	// Exits never need cash
+	result = check.Check(execution.Order{Symbol: "AAPL", Side: strategy.Sell, Quantity: 50, Price: 100},
+		execution.Account{Now: friday, Positions: []execution.Trade{sold}})
+	assert.True(t, result.Passed)
+
+	// Buying back the loser within the window is flagged but allowed
+	wash := NewWashSale(30).Check(execution.Order{Symbol: "AAPL", Side: strategy.Buy, Quantity: 1, Price: 95},
+		execution.Account{Now: monday, Opening: true, Positions: []execution.Trade{sold}})
+	assert.True(t, wash.Passed)
+	assert.Contains(t, wash.Warning, "wash sale")
+}
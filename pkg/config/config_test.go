@@ -1,9 +1,11 @@
 package config
 
 import (
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/hustler/trading-bot/pkg/market"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -134,8 +136,206 @@ func TestSaveConfigToFile(t *testing.T) {
 	
 	cfg := CreateDefaultConfig()
 	
-	// Verify function exists
+	// Write to a temporary file, leaving the test.json fixture alone
 	assert.NotPanics(t, func() {
-		SaveConfigToFile(cfg, "test.json")
+		SaveConfigToFile(cfg, filepath.Join(t.TempDir(), "test.json"))
 	})
 }
+
+func TestValidateTelegramChannels(t *testing.T) {
+	cfg := CreateDefaultConfig()
+	cfg.Telegram.Channels = []TelegramChannelConfig{
+		{Name: "premium", ChannelID: "@hustler_premium", Tier: "premium"},
+		{Name: "free", ChannelID: "@hustler_free", Tier: "free", MinConfidence: 0.8, DelaySeconds: 900},
+	}
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.Telegram.Channels[1].MinConfidence = 80
+	assert.Error(t, ValidateConfig(cfg))
+
+	cfg.Telegram.Channels[1].MinConfidence = 0.8
+	cfg.Telegram.Channels[1].ChannelID = ""
+	assert.Error(t, ValidateConfig(cfg))
+}
+
+func TestSignalFilter(t *testing.T) {
+	assert.True(t, SignalFilter{}.Allows(0.1, 0, "SELL"))
+
+	public := SignalFilter{MinConfidence: 0.8, MinROI: 1.5, SignalTypes: []string{"buy"}}
+	assert.True(t, public.Allows(0.85, 2, "BUY"))
+	assert.False(t, public.Allows(0.75, 2, "BUY"))
+	assert.False(t, public.Allows(0.85, 1, "BUY"))
+	assert.False(t, public.Allows(0.85, 2, "SELL"))
+
+	cfg := CreateDefaultConfig()
+	cfg.Telegram.Channels = []TelegramChannelConfig{
+		{Name: "public", ChannelID: "@hustler_public", MinConfidence: 0.8, SignalTypes: []string{"BUY"}},
+	}
+	assert.NoError(t, ValidateConfig(cfg))
+	assert.Equal(t, SignalFilter{MinConfidence: 0.8, SignalTypes: []string{"BUY"}}, cfg.Telegram.Channels[0].Filter())
+
+	cfg.Telegram.Channels[0].SignalTypes = []string{"LONG"}
+	assert.Error(t, ValidateConfig(cfg))
+
+	cfg.Telegram.Channels[0].SignalTypes = nil
+	cfg.Telegram.Channels[0].MinROI = -1
+	assert.Error(t, ValidateConfig(cfg))
+}
+
+func TestSessionConfig(t *testing.T) {
+	cfg := CreateDefaultConfig()
+	cfg.Session.States = map[string]SessionStateConfig{
+		"opening_window": {ConfidenceBoost: 0.1},
+		"post_market":    {Paused: true},
+	}
+	assert.NoError(t, ValidateConfig(cfg))
+	assert.True(t, cfg.Session.ForState(market.PostMarket).Paused)
+	assert.Equal(t, SessionStateConfig{}, cfg.Session.ForState(market.Midday))
+
+	// Zero windows fall back to the defaults
+	clock, err := SessionConfig{}.Clock()
+	assert.NoError(t, err)
+	assert.Equal(t, market.OpeningWindow, clock.State(time.Date(2025, 3, 10, 9, 45, 0, 0, clock.Location())))
+
+	cfg.Session.States["lunch"] = SessionStateConfig{Paused: true}
+	assert.Error(t, ValidateConfig(cfg))
+
+	delete(cfg.Session.States, "lunch")
+	cfg.Session.PreMarketOpen = "4am"
+	assert.Error(t, ValidateConfig(cfg))
+}
+
+func TestValidateHoldingMode(t *testing.T) {
+	cfg := CreateDefaultConfig()
+	assert.False(t, cfg.Holding.IsSwing())
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.Holding = HoldingConfig{Mode: HoldingSwing, MaxOvernightExposure: 50000, MaxOvernightPositions: 3}
+	assert.True(t, cfg.Holding.IsSwing())
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.Holding.MaxOvernightExposure = -1
+	assert.Error(t, ValidateConfig(cfg))
+
+	cfg.Holding.MaxOvernightExposure = 0
+	cfg.Holding.Mode = "weekly"
+	assert.Error(t, ValidateConfig(cfg))
+}
+
+func TestValidateStore(t *testing.T) {
+	cfg := CreateDefaultConfig()
+	cfg.Store.TokenSecret = "secret"
+	assert.Error(t, ValidateConfig(cfg), "nowhere to save the tokens")
+
+	cfg.Store.DatabaseURL = "postgres://localhost/hustler"
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.Store.Codec = "MsgPack"
+	assert.NoError(t, ValidateConfig(cfg))
+	cfg.Store.Codec = "xml"
+	assert.Error(t, ValidateConfig(cfg), "unknown codec")
+
+	cfg.Store = StoreConfig{}
+	cfg.Subscription.Enabled = true
+	assert.Error(t, ValidateConfig(cfg), "nowhere to keep the entitlements")
+}
+
+func TestRetentionPolicy(t *testing.T) {
+	now := time.Date(2025, 4, 21, 14, 0, 0, 0, time.UTC)
+	policy := RetentionPolicy{MaxCount: 2, MaxAgeHours: 24}
+	assert.True(t, policy.Keep(1, now.Add(-time.Hour), now))
+	assert.False(t, policy.Keep(2, now.Add(-time.Hour), now))
+	assert.False(t, policy.Keep(0, now.Add(-25*time.Hour), now))
+	assert.True(t, RetentionPolicy{}.Keep(1000, now.AddDate(-1, 0, 0), now))
+
+	cfg := CreateDefaultConfig()
+	cfg.Retention.Articles.MaxAgeHours = -1
+	assert.Error(t, ValidateConfig(cfg))
+}
+
+func TestValidateStrategyScaling(t *testing.T) {
+	cfg := CreateDefaultConfig()
+	cfg.Strategies = map[string]StrategyConfig{
+		"volatility": {Scaling: ScalingConfig{
+			EntryTranches: []float64{0.5, 0.5},
+			Exits:         []ScaleOutConfig{{TargetPercent: 2, Fraction: 0.5, StopToBreakeven: true}},
+		}},
+	}
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.Strategies["volatility"] = StrategyConfig{Scaling: ScalingConfig{EntryTranches: []float64{0.75, 0.5}}}
+	assert.Error(t, ValidateConfig(cfg))
+
+	cfg.Strategies["volatility"] = StrategyConfig{Scaling: ScalingConfig{Exits: []ScaleOutConfig{{TargetPercent: 0, Fraction: 0.5}}}}
+	assert.Error(t, ValidateConfig(cfg))
+}
+
+func TestValidateScoreWeights(t *testing.T) {
+	cfg := CreateDefaultConfig()
+	cfg.VolatilityParams.ScoreWeights = ScoreWeightsConfig{Bollinger: 0.4, RSI: 0.2, Volume: 0.2, PriceChange: 0.2}
+	cfg.VolatilityParams.ScoreExpression = "min(score + 0.1 * (adx > 25), 1)"
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.VolatilityParams.ScoreWeights.Bollinger = 0.5
+	assert.Error(t, ValidateConfig(cfg))
+
+	cfg.VolatilityParams.ScoreWeights = ScoreWeightsConfig{}
+	cfg.VolatilityParams.ScoreExpression = "score +"
+	assert.Error(t, ValidateConfig(cfg))
+
+	cfg.VolatilityParams.ScoreExpression = ""
+	cfg.Regime.Params = map[string]VolatilityConfig{"TRENDING": {ScoreWeights: ScoreWeightsConfig{RSI: -0.5, Volume: 1.5}}}
+	assert.Error(t, ValidateConfig(cfg))
+}
+
+func TestWatchlists(t *testing.T) {
+	cfg := CreateDefaultConfig()
+	watchlists := cfg.GetWatchlists()
+	assert.Len(t, watchlists, 1)
+	assert.Equal(t, DefaultWatchlist, watchlists[0].Name)
+	assert.Equal(t, cfg.StockSymbols, watchlists[0].Symbols)
+
+	cfg.Watchlists = []WatchlistConfig{
+		{Name: "megacaps", Symbols: []string{"AAPL", "MSFT"}, Strategies: []string{"volatility"}},
+		{Name: "gappers", Symbols: []string{"GME", "AAPL"}, CheckInterval: 60, Channels: []string{"default"}},
+	}
+	assert.Equal(t, []string{"AAPL", "MSFT", "GME"}, cfg.WatchedSymbols())
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.Watchlists[1].Strategies = []string{"momentum"}
+	assert.Error(t, ValidateConfig(cfg))
+	cfg.Ensemble.Enabled = true
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.Watchlists[1].Channels = []string{"vip"}
+	assert.Error(t, ValidateConfig(cfg))
+
+	cfg.Watchlists[1].Channels = nil
+	cfg.Watchlists[1].Name = "megacaps"
+	assert.Error(t, ValidateConfig(cfg))
+}
+
+func TestQuietHoursQuietUntil(t *testing.T) {
+	quiet := QuietHoursConfig{Start: "22:00", End: "07:00", Weekends: true, TimeZone: "America/New_York"}
+	newYork, _ := time.LoadLocation("America/New_York")
+
+	// Thursday evening in New York waits for Friday morning
+	thursday := time.Date(2026, 10, 15, 23, 30, 0, 0, newYork)
+	assert.Equal(t, time.Date(2026, 10, 16, 7, 0, 0, 0, newYork), quiet.QuietUntil(thursday, "").In(newYork))
+
+	// Friday afternoon is not quiet
+	assert.True(t, quiet.QuietUntil(time.Date(2026, 10, 16, 15, 0, 0, 0, newYork), "").IsZero())
+
+	// Friday night runs through the weekend into Monday morning
+	friday := time.Date(2026, 10, 16, 22, 15, 0, 0, newYork)
+	assert.Equal(t, time.Date(2026, 10, 19, 7, 0, 0, 0, newYork), quiet.QuietUntil(friday, "").In(newYork))
+
+	// A subscriber's own time zone overrides the window's
+	london, _ := time.LoadLocation("Europe/London")
+	assert.Equal(t, time.Date(2026, 10, 16, 7, 0, 0, 0, london),
+		quiet.QuietUntil(time.Date(2026, 10, 15, 20, 0, 0, 0, newYork), "Europe/London").In(london))
+
+	assert.True(t, QuietHoursConfig{}.QuietUntil(thursday, "").IsZero())
+	assert.Error(t, QuietHoursConfig{Start: "22:00"}.validate("test"))
+	assert.Error(t, QuietHoursConfig{Start: "25:00", End: "07:00"}.validate("test"))
+}
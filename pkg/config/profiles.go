@@ -0,0 +1,159 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// ProfileEnv is the environment variable that selects a config profile when
+// none is given explicitly
+const ProfileEnv = "HUSTLER_PROFILE"
+
+// Keys of the config file that control profiles
+const (
+	profileKey  = "profile"  // Profile used when none is selected
+	profilesKey = "profiles" // Profile name -> overrides
+	extendsKey  = "extends"  // Profile the overrides apply on top of
+)
+
+// LoadConfigProfile loads configuration from a file and applies a named
+// profile. Profiles live under "profiles" in the same file; each holds
+// overrides of the base settings and may extend another profile, e.g.
+//
+//	"profiles": {
+//	  "paper": {"paper": {"enabled": true}},
+//	  "live": {"extends": "paper", "paper": {"enabled": false}}
+//	}
+//
+// Objects are merged key by key; arrays and values are replaced. An empty
+// profile falls back to $HUSTLER_PROFILE, then to the file's "profile" key,
+// then to the base settings alone.
+func LoadConfigProfile(path, profile string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	resolved, err := resolveProfile(data, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	var config Config
+	if err := json.Unmarshal(resolved, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	return &config, nil
+}
+
+// resolveProfile returns the config document with the selected profile and
+// the profiles it extends applied to the base settings
+func resolveProfile(data []byte, profile string) ([]byte, error) {
+	base, err := decodeObject(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	profiles := map[string]interface{}{}
+	if raw, ok := base[profilesKey]; ok {
+		if profiles, ok = raw.(map[string]interface{}); !ok {
+			return nil, fmt.Errorf("config profiles must be an object")
+		}
+	}
+	delete(base, profilesKey)
+
+	if profile == "" {
+		profile = os.Getenv(ProfileEnv)
+	}
+	if profile == "" {
+		profile, _ = base[profileKey].(string)
+	}
+	delete(base, profileKey)
+	if profile == "" {
+		return json.Marshal(base)
+	}
+
+	// Collect the inheritance chain, most specific first
+	var chain []map[string]interface{}
+	seen := make(map[string]bool)
+	for name := profile; name != ""; {
+		if seen[name] {
+			return nil, fmt.Errorf("config profile %q extends itself", name)
+		}
+		seen[name] = true
+
+		overrides, ok := profiles[name].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unknown config profile %q (available: %s)", name, profileNames(profiles))
+		}
+		chain = append(chain, overrides)
+
+		parent, ok := overrides[extendsKey].(string)
+		if _, present := overrides[extendsKey]; present && !ok {
+			return nil, fmt.Errorf("config profile %q: extends must be a profile name", name)
+		}
+		name = parent
+	}
+
+	for i := len(chain) - 1; i >= 0; i-- {
+		overrides := make(map[string]interface{}, len(chain[i]))
+		for key, value := range chain[i] {
+			if key != extendsKey {
+				overrides[key] = value
+			}
+		}
+		base = mergeObjects(base, overrides)
+	}
+	base[profileKey] = profile
+	return json.Marshal(base)
+}
+
+// decodeObject decodes a JSON object, keeping numbers exact
+func decodeObject(data []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var object map[string]interface{}
+	if err := decoder.Decode(&object); err != nil {
+		return nil, err
+	}
+	if object == nil {
+		object = map[string]interface{}{}
+	}
+	return object, nil
+}
+
+// mergeObjects returns base with overrides applied: nested objects are merged
+// key by key, anything else replaces the base value
+func mergeObjects(base, overrides map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(base)+len(overrides))
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range overrides {
+		baseObject, baseIsObject := merged[key].(map[string]interface{})
+		overrideObject, overrideIsObject := value.(map[string]interface{})
+		if baseIsObject && overrideIsObject {
+			merged[key] = mergeObjects(baseObject, overrideObject)
+		} else {
+			merged[key] = value
+		}
+	}
+	return merged
+}
+
+// profileNames lists the defined profiles for error messages
+func profileNames(profiles map[string]interface{}) string {
+	if len(profiles) == 0 {
+		return "none"
+	}
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const profiledConfig = `{
+	"profile": "paper",
+	"stock_symbols": ["AAPL", "MSFT"],
+	"check_interval": 300,
+	"telegram": {"bot_token": "dev-token", "channel_id": "@dev", "admin_user_ids": [1]},
+	"redis": {"enabled": false, "addr": "localhost:6379"},
+	"profiles": {
+		"paper": {"redis": {"enabled": true}},
+		"live": {
+			"extends": "paper",
+			"stock_symbols": ["SPY"],
+			"redis": {"enabled": false},
+			"telegram": {"bot_token": "live-token", "channel_id": "@signals"}
+		},
+		"loop": {"extends": "loop"}
+	}
+}`
+
+func writeProfiledConfig(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "config.json")
+	assert.NoError(t, os.WriteFile(path, []byte(profiledConfig), 0644))
+	return path
+}
+
+func TestLoadConfigProfileInheritsAndOverrides(t *testing.T) {
+	path := writeProfiledConfig(t)
+
+	live, err := LoadConfigProfile(path, "live")
+	assert.NoError(t, err)
+	assert.Equal(t, "live", live.Profile)
+	assert.Equal(t, []string{"SPY"}, live.StockSymbols)
+	assert.False(t, live.Redis.Enabled)
+	assert.Equal(t, "localhost:6379", live.Redis.Addr)
+	// Objects are merged key by key
+	assert.Equal(t, "live-token", live.Telegram.BotToken)
+	assert.Equal(t, []int64{1}, live.Telegram.AdminUserIDs)
+	assert.Equal(t, 300, live.CheckInterval)
+
+	// The file's default profile applies when none is selected
+	paper, err := LoadConfigFromFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "paper", paper.Profile)
+	assert.True(t, paper.Redis.Enabled)
+	assert.Equal(t, "dev-token", paper.Telegram.BotToken)
+
+	t.Setenv(ProfileEnv, "live")
+	fromEnv, err := LoadConfigFromFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "live", fromEnv.Profile)
+
+	_, err = LoadConfigProfile(path, "staging")
+	assert.ErrorContains(t, err, "available: live, loop, paper")
+
+	_, err = LoadConfigProfile(path, "loop")
+	assert.ErrorContains(t, err, "extends itself")
+}
@@ -0,0 +1,133 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Clone returns a deep copy of the configuration, sharing no slices or maps
+// with it, so the copy can be changed without affecting other holders
+func (c *Config) Clone() *Config {
+	if c == nil {
+		return nil
+	}
+	clone := cloneValue(reflect.ValueOf(c).Elem()).Interface().(Config)
+	return &clone
+}
+
+// cloneValue deep copies v, keeping nil slices and maps nil
+func cloneValue(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		clone := reflect.New(v.Elem().Type())
+		clone.Elem().Set(cloneValue(v.Elem()))
+		return clone
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		clone := reflect.New(v.Type()).Elem()
+		clone.Set(cloneValue(v.Elem()))
+		return clone
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		clone := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			clone.Index(i).Set(cloneValue(v.Index(i)))
+		}
+		return clone
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		clone := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			clone.SetMapIndex(iter.Key(), cloneValue(iter.Value()))
+		}
+		return clone
+	case reflect.Struct:
+		clone := reflect.New(v.Type()).Elem()
+		clone.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			// Unexported fields, e.g. time.Time's, are kept as copied by value
+			if clone.Field(i).CanSet() {
+				clone.Field(i).Set(cloneValue(v.Field(i)))
+			}
+		}
+		return clone
+	default:
+		return v
+	}
+}
+
+// Shared holds a configuration used by several components at once, e.g. the
+// admin server, the UI and the monitor. Reads return copies and updates swap
+// in a changed copy, so no holder ever sees another's partial changes.
+type Shared struct {
+	config    *Config
+	listeners []func(*Config)
+	mu        sync.RWMutex
+	notifyMu  sync.Mutex
+}
+
+// NewShared creates a shared configuration from a copy of cfg
+func NewShared(cfg *Config) *Shared {
+	return &Shared{config: cfg.Clone()}
+}
+
+// Get returns a copy of the current configuration, free to be changed
+func (s *Shared) Get() *Config {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.config.Clone()
+}
+
+// Update applies fn to a copy of the current configuration and, if the result
+// is valid, makes it current, notifies the listeners and returns a copy.
+// Concurrent updates are applied one after another, so none are lost.
+func (s *Shared) Update(fn func(cfg *Config)) (*Config, error) {
+	s.mu.Lock()
+	updated := s.config.Clone()
+	fn(updated)
+	if err := ValidateConfig(updated); err != nil {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	s.config = updated
+	listeners := make([]func(*Config), len(s.listeners))
+	copy(listeners, s.listeners)
+
+	// Notify in update order, so listeners never end on a stale configuration
+	s.notifyMu.Lock()
+	defer s.notifyMu.Unlock()
+	s.mu.Unlock()
+
+	for _, listener := range listeners {
+		listener(updated.Clone())
+	}
+	return updated.Clone(), nil
+}
+
+// Set replaces the current configuration with a copy of cfg if it is valid
+func (s *Shared) Set(cfg *Config) error {
+	_, err := s.Update(func(current *Config) {
+		*current = *cfg.Clone()
+	})
+	return err
+}
+
+// OnChange registers a callback invoked with a copy of the configuration after
+// every update. Callbacks run in update order and must not update the
+// configuration themselves.
+func (s *Shared) OnChange(fn func(cfg *Config)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners = append(s.listeners, fn)
+}
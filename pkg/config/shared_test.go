@@ -0,0 +1,95 @@
+package config
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCloneSharesNothing(t *testing.T) {
+	cfg := CreateDefaultConfig()
+	cfg.StockSymbols = []string{"AAPL"}
+	cfg.Watchlists = []WatchlistConfig{{Name: "tech", Symbols: []string{"MSFT"}}}
+	cfg.DataSource.APIKeys = map[string]string{"alphavantage": "key"}
+
+	clone := cfg.Clone()
+	assert.Equal(t, cfg, clone)
+
+	clone.StockSymbols[0] = "TSLA"
+	clone.Watchlists[0].Symbols[0] = "NVDA"
+	clone.DataSource.APIKeys["alphavantage"] = "other"
+	assert.Equal(t, "AAPL", cfg.StockSymbols[0])
+	assert.Equal(t, "MSFT", cfg.Watchlists[0].Symbols[0])
+	assert.Equal(t, "key", cfg.DataSource.APIKeys["alphavantage"])
+}
+
+func TestSharedGetReturnsCopies(t *testing.T) {
+	cfg := CreateDefaultConfig()
+	cfg.StockSymbols = []string{"AAPL"}
+	shared := NewShared(cfg)
+
+	cfg.StockSymbols[0] = "TSLA"
+	got := shared.Get()
+	assert.Equal(t, []string{"AAPL"}, got.StockSymbols)
+
+	got.StockSymbols[0] = "NVDA"
+	assert.Equal(t, []string{"AAPL"}, shared.Get().StockSymbols)
+}
+
+func TestSharedUpdateRejectsInvalid(t *testing.T) {
+	shared := NewShared(CreateDefaultConfig())
+	interval := shared.Get().CheckInterval
+
+	_, err := shared.Update(func(cfg *Config) { cfg.CheckInterval = -1 })
+	assert.Error(t, err)
+	assert.Equal(t, interval, shared.Get().CheckInterval)
+}
+
+// Run with -race: concurrent readers, updaters and listeners must not race,
+// and no update may be lost
+func TestSharedConcurrentUpdates(t *testing.T) {
+	cfg := CreateDefaultConfig()
+	cfg.StockSymbols = nil
+	shared := NewShared(cfg)
+
+	var notified []int
+	var notifiedMu sync.Mutex
+	shared.OnChange(func(cfg *Config) {
+		notifiedMu.Lock()
+		notified = append(notified, len(cfg.StockSymbols))
+		notifiedMu.Unlock()
+		cfg.StockSymbols = append(cfg.StockSymbols, "MINE")
+	})
+
+	const writers, updates = 8, 25
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(2)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < updates; i++ {
+				_, err := shared.Update(func(cfg *Config) {
+					cfg.StockSymbols = append(cfg.StockSymbols, fmt.Sprintf("S%d-%d", w, i))
+				})
+				assert.NoError(t, err)
+			}
+		}(w)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < updates; i++ {
+				got := shared.Get()
+				got.StockSymbols = append(got.StockSymbols, "LOCAL")
+				_ = got.WatchedSymbols()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, shared.Get().StockSymbols, writers*updates)
+	assert.Len(t, notified, writers*updates)
+	for i, count := range notified {
+		assert.Equal(t, i+1, count, "listeners are notified in update order")
+	}
+}
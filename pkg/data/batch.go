@@ -0,0 +1,290 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/errkind"
+)
+
+// batchQuoteSize is the maximum number of symbols requested per batch quote call
+const batchQuoteSize = 50
+
+// batchConcurrency limits parallel requests for sources without a multi-symbol endpoint
+const batchConcurrency = 8
+
+// YahooQuoteResponse represents the response from the Yahoo Finance v7 quote API
+type YahooQuoteResponse struct {
+	QuoteResponse struct {
+		Result []struct {
+			Symbol                     string  `json:"symbol"`
+			RegularMarketPrice         float64 `json:"regularMarketPrice"`
+			RegularMarketPreviousClose float64 `json:"regularMarketPreviousClose"`
+			RegularMarketVolume        int64   `json:"regularMarketVolume"`
+			RegularMarketDayHigh       float64 `json:"regularMarketDayHigh"`
+			RegularMarketDayLow        float64 `json:"regularMarketDayLow"`
+			RegularMarketChange        float64 `json:"regularMarketChange"`
+			RegularMarketChangePercent float64 `json:"regularMarketChangePercent"`
+			Bid                        float64 `json:"bid"`
+			Ask                        float64 `json:"ask"`
+		} `json:"result"`
+		Error interface{} `json:"error"`
+	} `json:"quoteResponse"`
+}
+
+// chunkSymbols splits symbols into batches of at most size
+func chunkSymbols(symbols []string, size int) [][]string {
+	chunks := make([][]string, 0, (len(symbols)+size-1)/size)
+	for start := 0; start < len(symbols); start += size {
+		end := start + size
+		if end > len(symbols) {
+			end = len(symbols)
+		}
+		chunks = append(chunks, symbols[start:end])
+	}
+	return chunks
+}
+
+// supportsBatch reports whether the data source can resolve many symbols per cycle in bulk
+func (m *MarketWatcher) supportsBatch() bool {
+	switch m.dataSource {
+	case "yahoo", "finnhub", "questrade", "synthetic":
+		return true
+	default:
+		return false
+	}
+}
+
+// updateStocksBatch fetches quotes for a batch of symbols and applies them.
+// An error means the whole batch failed.
+func (m *MarketWatcher) updateStocksBatch(symbols []string) error {
+	var quotes map[string]*Stock
+	var err error
+
+	switch m.dataSource {
+	case "yahoo":
+		quotes, err = fetchYahooQuotes(symbols)
+	case "finnhub":
+		quotes, err = m.fetchFinnhubQuotes(symbols)
+	case "questrade":
+		m.mu.RLock()
+		client := m.questrade
+		m.mu.RUnlock()
+		if client == nil {
+			return fmt.Errorf("Questrade client not configured")
+		}
+		quotes, err = client.GetQuotes(symbols)
+	case "synthetic":
+		quotes, err = m.fetchSyntheticQuotes(symbols)
+	default:
+		return fmt.Errorf("data source %s does not support batch quotes", m.dataSource)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	for symbol, quote := range quotes {
+		if stock, exists := m.stocks[symbol]; exists {
+			*stock = *quote
+		}
+	}
+	m.mu.Unlock()
+
+	// Symbols the batch did not return keep their previous data until the next cycle
+	var missing []string
+	for _, symbol := range symbols {
+		if _, ok := quotes[symbol]; !ok {
+			missing = append(missing, symbol)
+		}
+	}
+	if len(missing) > 0 {
+		log.Printf("Batch quote missing symbols: %s", strings.Join(missing, ","))
+	}
+
+	return nil
+}
+
+// fetchYahooQuotes fetches quotes for many symbols in a single Yahoo Finance request
+func fetchYahooQuotes(symbols []string) (map[string]*Stock, error) {
+	params := url.Values{}
+	params.Add("symbols", strings.Join(symbols, ","))
+
+	req, err := http.NewRequest("GET", "https://query1.finance.yahoo.com/v7/finance/quote?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Add("User-Agent", "Mozilla/5.0")
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", errkind.Unreachable(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get data: %w", errkind.Status(resp.StatusCode, string(body)))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var quoteResp YahooQuoteResponse
+	if err := json.Unmarshal(body, &quoteResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	now := time.Now()
+	quotes := make(map[string]*Stock, len(quoteResp.QuoteResponse.Result))
+	for _, r := range quoteResp.QuoteResponse.Result {
+		bid, ask := r.Bid, r.Ask
+		if bid == 0 {
+			bid = r.RegularMarketPrice
+		}
+		if ask == 0 {
+			ask = r.RegularMarketPrice
+		}
+
+		quotes[r.Symbol] = &Stock{
+			Symbol:        r.Symbol,
+			CurrentPrice:  r.RegularMarketPrice,
+			PreviousClose: r.RegularMarketPreviousClose,
+			Volume:        r.RegularMarketVolume,
+			LastUpdated:   now,
+			DailyHigh:     r.RegularMarketDayHigh,
+			DailyLow:      r.RegularMarketDayLow,
+			Bid:           bid,
+			Ask:           ask,
+			Change:        r.RegularMarketChange,
+			ChangePercent: r.RegularMarketChangePercent,
+		}
+	}
+
+	return quotes, nil
+}
+
+// fetchFinnhubQuotes fetches quotes for many symbols from Finnhub. The REST quote
+// endpoint is single-symbol, so requests fan out with bounded concurrency.
+func (m *MarketWatcher) fetchFinnhubQuotes(symbols []string) (map[string]*Stock, error) {
+	apiKey, err := m.authManager.GetAPIKey("finnhub")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Finnhub API key: %w", err)
+	}
+
+	quotes := make(map[string]*Stock, len(symbols))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, batchConcurrency)
+
+	for _, symbol := range symbols {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(symbol string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			quote, err := fetchFinnhubQuote(symbol, apiKey)
+			if err != nil {
+				log.Printf("Error fetching Finnhub quote for %s: %v", symbol, err)
+				return
+			}
+
+			mu.Lock()
+			quotes[symbol] = quote
+			mu.Unlock()
+		}(symbol)
+	}
+	wg.Wait()
+
+	return quotes, nil
+}
+
+// fetchFinnhubQuote fetches a single quote from Finnhub
+func fetchFinnhubQuote(symbol, apiKey string) (*Stock, error) {
+	params := url.Values{}
+	params.Add("symbol", symbol)
+
+	req, err := http.NewRequest("GET", "https://finnhub.io/api/v1/quote?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Add("X-Finnhub-Token", apiKey)
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", errkind.Unreachable(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get data: %w", errkind.Status(resp.StatusCode, string(body)))
+	}
+
+	var finnhubResp FinnhubResponse
+	if err := json.NewDecoder(resp.Body).Decode(&finnhubResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if finnhubResp.CurrentPrice == 0 {
+		return nil, fmt.Errorf("%w found for symbol: %s", errkind.ErrNoData, symbol)
+	}
+
+	return &Stock{
+		Symbol:        symbol,
+		CurrentPrice:  finnhubResp.CurrentPrice,
+		PreviousClose: finnhubResp.PreviousClose,
+		LastUpdated:   time.Now(),
+		DailyHigh:     finnhubResp.High,
+		DailyLow:      finnhubResp.Low,
+		Bid:           finnhubResp.CurrentPrice,
+		Ask:           finnhubResp.CurrentPrice,
+		Change:        finnhubResp.Change,
+		ChangePercent: finnhubResp.PercentChange,
+	}, nil
+}
+
+// GetMarketDataBatch fetches market data for many symbols concurrently. Symbols that
+// fail are reported in the returned error map rather than aborting the batch.
+func (p *Provider) GetMarketDataBatch(symbols []string) (map[string]*MarketData, map[string]error) {
+	results := make(map[string]*MarketData, len(symbols))
+	errs := make(map[string]error)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, batchConcurrency)
+
+	for _, symbol := range symbols {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(symbol string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			data, err := p.GetMarketData(symbol)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[symbol] = err
+				return
+			}
+			results[symbol] = data
+		}(symbol)
+	}
+	wg.Wait()
+
+	return results, errs
+}
@@ -0,0 +1,37 @@
+package data
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChunkSymbols(t *testing.T) {
+	symbols := make([]string, 120)
+	for i := range symbols {
+		symbols[i] = fmt.Sprintf("SYM%d", i)
+	}
+
+	chunks := chunkSymbols(symbols, batchQuoteSize)
+	assert.Len(t, chunks, 3)
+	assert.Len(t, chunks[0], 50)
+	assert.Len(t, chunks[2], 20)
+	assert.Equal(t, "SYM119", chunks[2][19])
+
+	assert.Empty(t, chunkSymbols(nil, batchQuoteSize))
+}
+
+func TestMarketWatcherBatchUpdate(t *testing.T) {
+	watcher := NewMarketWatcher(nil, "questrade", 60)
+	watcher.SetQuestradeClient(newTestQuestradeClient(t))
+	watcher.AddStock("RY.TO")
+	watcher.AddStock("TD.TO")
+
+	watcher.updateAllStocks()
+
+	ry, _ := watcher.GetStock("RY.TO")
+	td, _ := watcher.GetStock("TD.TO")
+	assert.Equal(t, 132.0, ry.CurrentPrice)
+	assert.Equal(t, 81.0, td.CurrentPrice)
+}
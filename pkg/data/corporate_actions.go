@@ -0,0 +1,209 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/errkind"
+)
+
+// CorporateActionType is the kind of corporate action
+type CorporateActionType string
+
+const (
+	// Split changes the number of shares, e.g. a 4-for-1 split has a ratio of 4
+	Split CorporateActionType = "SPLIT"
+	// Dividend pays cash per share, lowering the price by about the amount on the ex-date
+	Dividend CorporateActionType = "DIVIDEND"
+)
+
+// CorporateAction is a split or cash dividend taking effect on its ex-date
+type CorporateAction struct {
+	Symbol string              `json:"symbol"`
+	Type   CorporateActionType `json:"type"`
+	ExDate time.Time           `json:"ex_date"`
+	Ratio  float64             `json:"ratio,omitempty"`  // Shares after the split per share before
+	Amount float64             `json:"amount,omitempty"` // Dividend per share
+}
+
+// Key identifies the action so it is only applied once
+func (a CorporateAction) Key() string {
+	return fmt.Sprintf("%s/%s/%s", a.Symbol, a.Type, a.ExDate.Format("2006-01-02"))
+}
+
+// AdjustPrice returns a price quoted before the ex-date in terms of prices after it
+func (a CorporateAction) AdjustPrice(price float64) float64 {
+	switch a.Type {
+	case Split:
+		if a.Ratio > 0 {
+			return price / a.Ratio
+		}
+	case Dividend:
+		return math.Max(price-a.Amount, 0)
+	}
+	return price
+}
+
+// AdjustQuantity returns a share count held before the ex-date in terms of shares after it
+func (a CorporateAction) AdjustQuantity(quantity int) int {
+	if a.Type == Split && a.Ratio > 0 {
+		return int(math.Floor(float64(quantity) * a.Ratio))
+	}
+	return quantity
+}
+
+// Describe returns a short description of the action, e.g. "4-for-1 split"
+func (a CorporateAction) Describe() string {
+	if a.Type == Split {
+		if a.Ratio >= 1 {
+			return fmt.Sprintf("%s-for-1 split", strconv.FormatFloat(a.Ratio, 'f', -1, 64))
+		}
+		return fmt.Sprintf("1-for-%s reverse split", strconv.FormatFloat(1/a.Ratio, 'f', -1, 64))
+	}
+	return fmt.Sprintf("$%.2f dividend", a.Amount)
+}
+
+// AdjustHistory back-adjusts the prices and volumes recorded before each
+// action's ex-date so the series is continuous across it. Sources that already
+// adjust for splits, like Yahoo Finance, pass splits=false.
+func AdjustHistory(history *MarketData, actions []CorporateAction, splits bool) {
+	for _, action := range actions {
+		if action.Type == Split && !splits {
+			continue
+		}
+
+		// Price factor for a dividend is relative to the close before the ex-date
+		factor := 1.0
+		switch action.Type {
+		case Split:
+			if action.Ratio <= 0 {
+				continue
+			}
+			factor = 1 / action.Ratio
+		case Dividend:
+			last := -1
+			for i, ts := range history.Timestamps {
+				if ts.Before(action.ExDate) && i < len(history.Prices) {
+					last = i
+				}
+			}
+			if last < 0 || history.Prices[last] <= action.Amount {
+				continue
+			}
+			factor = 1 - action.Amount/history.Prices[last]
+		}
+
+		for i, ts := range history.Timestamps {
+			if !ts.Before(action.ExDate) {
+				continue
+			}
+			if i < len(history.Prices) {
+				history.Prices[i] *= factor
+			}
+			if action.Type == Split {
+				for _, volumes := range [][]float64{history.Volumes, history.OffExchangeVolumes, history.OddLotVolumes} {
+					if i < len(volumes) {
+						volumes[i] /= factor
+					}
+				}
+			}
+		}
+	}
+}
+
+// CorporateActions returns the splits and dividends of symbol with ex-dates
+// since since, oldest first
+func (p *Provider) CorporateActions(symbol string, since time.Time) ([]CorporateAction, error) {
+	params := url.Values{}
+	params.Add("interval", "1d")
+	params.Add("period1", strconv.FormatInt(since.Unix(), 10))
+	params.Add("period2", strconv.FormatInt(time.Now().Unix(), 10))
+	params.Add("events", "div,splits")
+
+	req, err := http.NewRequest("GET", "https://query1.finance.yahoo.com/v8/finance/chart/"+url.PathEscape(symbol)+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Add("User-Agent", "Mozilla/5.0")
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", errkind.Unreachable(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get corporate actions: %w", errkind.Status(resp.StatusCode, string(body)))
+	}
+	return parseYahooEvents(symbol, resp.Body, since)
+}
+
+// parseYahooEvents reads the dividend and split events of a Yahoo Finance chart response
+func parseYahooEvents(symbol string, r io.Reader, since time.Time) ([]CorporateAction, error) {
+	var chartResp struct {
+		Chart struct {
+			Result []struct {
+				Events struct {
+					Dividends map[string]struct {
+						Amount float64 `json:"amount"`
+						Date   int64   `json:"date"`
+					} `json:"dividends"`
+					Splits map[string]struct {
+						Date        int64   `json:"date"`
+						Numerator   float64 `json:"numerator"`
+						Denominator float64 `json:"denominator"`
+					} `json:"splits"`
+				} `json:"events"`
+			} `json:"result"`
+		} `json:"chart"`
+	}
+	if err := json.NewDecoder(r).Decode(&chartResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	var actions []CorporateAction
+	for _, result := range chartResp.Chart.Result {
+		for _, dividend := range result.Events.Dividends {
+			actions = append(actions, CorporateAction{Symbol: symbol, Type: Dividend, ExDate: time.Unix(dividend.Date, 0), Amount: dividend.Amount})
+		}
+		for _, split := range result.Events.Splits {
+			if split.Numerator <= 0 || split.Denominator <= 0 {
+				continue
+			}
+			actions = append(actions, CorporateAction{Symbol: symbol, Type: Split, ExDate: time.Unix(split.Date, 0), Ratio: split.Numerator / split.Denominator})
+		}
+	}
+
+	kept := actions[:0]
+	for _, action := range actions {
+		if !action.ExDate.Before(since) {
+			kept = append(kept, action)
+		}
+	}
+	sort.Slice(kept, func(i, j int) bool { return kept[i].ExDate.Before(kept[j].ExDate) })
+	return kept, nil
+}
+
+// adjustDailyHistory back-adjusts daily history for the corporate actions in
+// its range, leaving it unadjusted when they can't be fetched
+func (p *Provider) adjustDailyHistory(history *MarketData, splits bool) {
+	if !p.dataSource().AdjustCorporateActions || len(history.Timestamps) == 0 {
+		return
+	}
+	actions, err := p.CorporateActions(history.Symbol, history.Timestamps[0])
+	if err != nil {
+		log.Printf("Error fetching corporate actions for %s, history is unadjusted: %v", history.Symbol, err)
+		return
+	}
+	AdjustHistory(history, actions, splits)
+}
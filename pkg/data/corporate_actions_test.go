@@ -0,0 +1,56 @@
+package data
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseYahooEvents(t *testing.T) {
+	body := `{"chart":{"result":[{"events":{
+		"dividends":{"1715347800":{"amount":0.25,"date":1715347800}},
+		"splits":{"1598880600":{"date":1598880600,"numerator":4,"denominator":1,"splitRatio":"4:1"}}
+	}}]}}`
+
+	actions, err := parseYahooEvents("AAPL", strings.NewReader(body), time.Unix(0, 0))
+	assert.NoError(t, err)
+	assert.Len(t, actions, 2)
+	assert.Equal(t, Split, actions[0].Type)
+	assert.Equal(t, 4.0, actions[0].Ratio)
+	assert.Equal(t, "4-for-1 split", actions[0].Describe())
+	assert.Equal(t, Dividend, actions[1].Type)
+	assert.Equal(t, 0.25, actions[1].Amount)
+
+	// Actions before since are dropped
+	actions, err = parseYahooEvents("AAPL", strings.NewReader(body), time.Unix(1700000000, 0))
+	assert.NoError(t, err)
+	assert.Len(t, actions, 1)
+}
+
+func TestAdjustHistory(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 6, d, 20, 0, 0, 0, time.UTC) }
+	history := &MarketData{
+		Symbol:     "XYZ",
+		Prices:     []float64{400, 404, 100, 100},
+		Volumes:    []float64{1000, 1000, 4000, 4000},
+		Timestamps: []time.Time{day(2), day(3), day(4), day(5)},
+	}
+	split := CorporateAction{Symbol: "XYZ", Type: Split, ExDate: time.Date(2025, 6, 4, 0, 0, 0, 0, time.UTC), Ratio: 4}
+	dividend := CorporateAction{Symbol: "XYZ", Type: Dividend, ExDate: time.Date(2025, 6, 5, 0, 0, 0, 0, time.UTC), Amount: 1}
+
+	// A source that already adjusts splits only gets the dividend applied
+	unadjusted := *history
+	unadjusted.Prices = append([]float64(nil), history.Prices...)
+	AdjustHistory(&unadjusted, []CorporateAction{split}, false)
+	assert.Equal(t, history.Prices, unadjusted.Prices)
+
+	AdjustHistory(history, []CorporateAction{split, dividend}, true)
+	assert.InDeltaSlice(t, []float64{99, 99.99, 99, 100}, history.Prices, 1e-9)
+	assert.Equal(t, []float64{4000, 4000, 4000, 4000}, history.Volumes)
+
+	assert.Equal(t, 25.0, split.AdjustPrice(100))
+	assert.Equal(t, 400, split.AdjustQuantity(100))
+	assert.Equal(t, 99.0, dividend.AdjustPrice(100))
+}
@@ -0,0 +1,108 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/errkind"
+)
+
+// GetDailyHistory returns up to days daily closes for a symbol, oldest first,
+// adjusted for splits and dividends when configured. Questrade is used when
+// configured, otherwise Yahoo Finance.
+func (p *Provider) GetDailyHistory(symbol string, days int) (*MarketData, error) {
+	if p.questrade != nil {
+		end := time.Now()
+		// Request extra calendar days to cover weekends and holidays
+		start := end.AddDate(0, 0, -days*3/2-7)
+		data, err := p.questrade.GetCandles(symbol, "OneDay", start, end)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get daily candles: %w", err)
+		}
+		data = trimMarketData(data, days)
+		p.adjustDailyHistory(data, true)
+		return data, nil
+	}
+
+	data, err := fetchYahooChart(symbol, "1d", "6mo")
+	if err != nil {
+		return nil, err
+	}
+	// Yahoo closes are already split-adjusted
+	data = trimMarketData(data, days)
+	p.adjustDailyHistory(data, false)
+	return data, nil
+}
+
+// fetchYahooChart fetches the closes of a symbol at interval over rng, e.g.
+// "1d" over "6mo", from Yahoo Finance
+func fetchYahooChart(symbol, interval, rng string) (*MarketData, error) {
+	params := url.Values{}
+	params.Add("interval", interval)
+	params.Add("range", rng)
+
+	req, err := http.NewRequest("GET", "https://query1.finance.yahoo.com/v8/finance/chart/"+url.PathEscape(symbol)+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Add("User-Agent", "Mozilla/5.0")
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", errkind.Unreachable(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get data: %w", errkind.Status(resp.StatusCode, string(body)))
+	}
+
+	var chartResp YahooFinanceResponse
+	if err := json.NewDecoder(resp.Body).Decode(&chartResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if len(chartResp.Chart.Result) == 0 || len(chartResp.Chart.Result[0].Indicators.Quote) == 0 {
+		return nil, fmt.Errorf("%w found for symbol: %s", errkind.ErrNoData, symbol)
+	}
+
+	result := chartResp.Chart.Result[0]
+	quote := result.Indicators.Quote[0]
+	data := &MarketData{Symbol: symbol}
+	for i, ts := range result.Timestamp {
+		// Yahoo returns null (decoded as 0) for bars without a close
+		if i >= len(quote.Close) || quote.Close[i] == 0 {
+			continue
+		}
+		var volume float64
+		if i < len(quote.Volume) {
+			volume = float64(quote.Volume[i])
+		}
+		data.Prices = append(data.Prices, quote.Close[i])
+		data.Volumes = append(data.Volumes, volume)
+		data.Timestamps = append(data.Timestamps, time.Unix(ts, 0))
+	}
+
+	return data, nil
+}
+
+// trimMarketData keeps the most recent n points
+func trimMarketData(data *MarketData, n int) *MarketData {
+	if n <= 0 || len(data.Prices) <= n {
+		return data
+	}
+
+	start := len(data.Prices) - n
+	return &MarketData{
+		Symbol:     data.Symbol,
+		Prices:     data.Prices[start:],
+		Volumes:    data.Volumes[start:],
+		Timestamps: data.Timestamps[start:],
+	}
+}
@@ -0,0 +1,137 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/errkind"
+)
+
+// EconomicEvent is a scheduled macro release or policy decision
+type EconomicEvent struct {
+	Title   string    `json:"title"`
+	Country string    `json:"country"`
+	Impact  string    `json:"impact"` // Low, Medium or High
+	Time    time.Time `json:"date"`
+}
+
+// Key identifies the event for deduplication
+func (e EconomicEvent) Key() string {
+	return e.Country + "|" + e.Title + "|" + e.Time.UTC().Format(time.RFC3339)
+}
+
+// impactRank orders impact levels; unknown levels such as holidays rank lowest
+func impactRank(impact string) int {
+	switch strings.ToLower(impact) {
+	case "low":
+		return 1
+	case "medium":
+		return 2
+	case "high":
+		return 3
+	default:
+		return 0
+	}
+}
+
+// FetchEconomicCalendar fetches events from a JSON feed of objects with title,
+// country, impact and an RFC 3339 date, as published by Forex Factory mirrors
+func FetchEconomicCalendar(url string) ([]EconomicEvent, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Add("User-Agent", "Mozilla/5.0")
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", errkind.Unreachable(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get economic calendar: %w", errkind.Status(resp.StatusCode, string(body)))
+	}
+
+	var events []EconomicEvent
+	if err := json.NewDecoder(resp.Body).Decode(&events); err != nil {
+		return nil, fmt.Errorf("failed to parse economic calendar: %w", err)
+	}
+	return events, nil
+}
+
+// LoadEconomicCalendar returns the configured events plus those from the feed,
+// keeping only the configured countries and impact levels, sorted by time
+func LoadEconomicCalendar(cfg config.EconomicCalendarConfig) ([]EconomicEvent, error) {
+	events := make([]EconomicEvent, 0, len(cfg.Events))
+	for _, e := range cfg.Events {
+		at, err := time.Parse(time.RFC3339, e.Time)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time for economic event %q: %w", e.Title, err)
+		}
+		impact := e.Impact
+		if impact == "" {
+			impact = "High"
+		}
+		events = append(events, EconomicEvent{Title: e.Title, Country: e.Country, Impact: impact, Time: at})
+	}
+
+	if cfg.URL != "" {
+		fetched, err := FetchEconomicCalendar(cfg.URL)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, fetched...)
+	}
+
+	return FilterEconomicEvents(events, cfg.Countries, cfg.MinImpact), nil
+}
+
+// FilterEconomicEvents keeps events for the given countries (all when empty) at or
+// above minImpact (high when empty), removing duplicates and sorting by time
+func FilterEconomicEvents(events []EconomicEvent, countries []string, minImpact string) []EconomicEvent {
+	if minImpact == "" {
+		minImpact = "high"
+	}
+	minRank := impactRank(minImpact)
+
+	allowed := make(map[string]bool, len(countries))
+	for _, country := range countries {
+		allowed[strings.ToUpper(country)] = true
+	}
+
+	seen := make(map[string]bool)
+	filtered := make([]EconomicEvent, 0, len(events))
+	for _, event := range events {
+		if len(allowed) > 0 && event.Country != "" && !allowed[strings.ToUpper(event.Country)] {
+			continue
+		}
+		if impactRank(event.Impact) < minRank || seen[event.Key()] {
+			continue
+		}
+		seen[event.Key()] = true
+		filtered = append(filtered, event)
+	}
+
+	sort.Slice(filtered, func(i, j int) bool { return filtered[i].Time.Before(filtered[j].Time) })
+	return filtered
+}
+
+// ActiveEconomicEvent returns the first event whose window, from before ahead of
+// the event until after it, contains now
+func ActiveEconomicEvent(events []EconomicEvent, now time.Time, before, after time.Duration) (EconomicEvent, bool) {
+	for _, event := range events {
+		if !now.Before(event.Time.Add(-before)) && now.Before(event.Time.Add(after)) {
+			return event, true
+		}
+	}
+	return EconomicEvent{}, false
+}
@@ -0,0 +1,56 @@
+package data
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadEconomicCalendar(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[
+			{"title":"CPI m/m","country":"USD","date":"2025-05-13T08:30:00-04:00","impact":"High"},
+			{"title":"Retail Sales m/m","country":"USD","date":"2025-05-15T08:30:00-04:00","impact":"Medium"},
+			{"title":"CPI y/y","country":"EUR","date":"2025-05-16T05:00:00-04:00","impact":"High"}
+		]`))
+	}))
+	defer server.Close()
+
+	cfg := config.EconomicCalendarConfig{
+		URL:       server.URL,
+		Countries: []string{"USD"},
+		MinImpact: "high",
+		Events: []config.EconomicEventConfig{
+			{Title: "FOMC Statement", Country: "USD", Time: "2025-05-07T14:00:00-04:00"},
+		},
+	}
+
+	events, err := LoadEconomicCalendar(cfg)
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, "FOMC Statement", events[0].Title)
+	assert.Equal(t, "High", events[0].Impact)
+	assert.Equal(t, "CPI m/m", events[1].Title)
+}
+
+func TestActiveEconomicEvent(t *testing.T) {
+	cpi := time.Date(2025, 5, 13, 12, 30, 0, 0, time.UTC)
+	events := []EconomicEvent{{Title: "CPI m/m", Country: "USD", Impact: "High", Time: cpi}}
+
+	_, active := ActiveEconomicEvent(events, cpi.Add(-16*time.Minute), 15*time.Minute, 30*time.Minute)
+	assert.False(t, active)
+
+	event, active := ActiveEconomicEvent(events, cpi.Add(-15*time.Minute), 15*time.Minute, 30*time.Minute)
+	assert.True(t, active)
+	assert.Equal(t, "CPI m/m", event.Title)
+
+	_, active = ActiveEconomicEvent(events, cpi.Add(29*time.Minute), 15*time.Minute, 30*time.Minute)
+	assert.True(t, active)
+
+	_, active = ActiveEconomicEvent(events, cpi.Add(30*time.Minute), 15*time.Minute, 30*time.Minute)
+	assert.False(t, active)
+}
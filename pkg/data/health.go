@@ -0,0 +1,242 @@
+package data
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+)
+
+// healthSmoothing is the EWMA weight given to the newest observation
+const healthSmoothing = 0.2
+
+// ProviderHealth represents the observed health of a data provider
+type ProviderHealth struct {
+	Name         string        `json:"name"`
+	Successes    int           `json:"successes"`
+	Failures     int           `json:"failures"`
+	ErrorRate    float64       `json:"error_rate"`
+	AvgLatency   time.Duration `json:"avg_latency"`
+	LastSuccess  time.Time     `json:"last_success"`
+	LastFailure  time.Time     `json:"last_failure"`
+	LastDataTime time.Time     `json:"last_data_time"`
+	DemotedUntil time.Time     `json:"demoted_until"`
+	Score        float64       `json:"score"`
+}
+
+// Demoted reports whether the provider is currently demoted
+func (h ProviderHealth) Demoted(now time.Time) bool {
+	return now.Before(h.DemotedUntil)
+}
+
+// HealthTracker scores data providers and demotes failing ones
+type HealthTracker struct {
+	policy config.FailoverConfig
+	health map[string]*ProviderHealth
+	now    func() time.Time
+	mu     sync.RWMutex
+}
+
+// NewHealthTracker creates a new HealthTracker
+func NewHealthTracker(policy config.FailoverConfig) *HealthTracker {
+	return &HealthTracker{
+		policy: policy,
+		health: make(map[string]*ProviderHealth),
+		now:    time.Now,
+	}
+}
+
+// UpdatePolicy updates the failover policy without resetting observed health
+func (t *HealthTracker) UpdatePolicy(policy config.FailoverConfig) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.policy = policy
+}
+
+// RecordSuccess records a successful fetch. dataTime is the timestamp of the newest data point.
+func (t *HealthTracker) RecordSuccess(name string, latency time.Duration, dataTime time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	h := t.get(name)
+	now := t.now()
+	h.Successes++
+	h.ErrorRate = (1 - healthSmoothing) * h.ErrorRate
+	h.AvgLatency = smoothLatency(h.AvgLatency, latency, h.Successes+h.Failures)
+	h.LastSuccess = now
+	h.LastDataTime = dataTime
+
+	// Stale data demotes the provider just like errors do
+	if t.isStale(h, now) {
+		h.DemotedUntil = now.Add(t.probeInterval())
+	} else {
+		h.DemotedUntil = time.Time{}
+	}
+
+	h.Score = t.score(h, now)
+}
+
+// RecordFailure records a failed fetch
+func (t *HealthTracker) RecordFailure(name string, latency time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	h := t.get(name)
+	now := t.now()
+	wasProbing := !h.DemotedUntil.IsZero() && !h.Demoted(now)
+	h.Failures++
+	h.ErrorRate = (1-healthSmoothing)*h.ErrorRate + healthSmoothing
+	h.AvgLatency = smoothLatency(h.AvgLatency, latency, h.Successes+h.Failures)
+	h.LastFailure = now
+
+	// A failed recovery probe demotes again immediately; otherwise wait for enough samples
+	samples := h.Successes + h.Failures
+	if wasProbing || (samples >= t.minSamples() && h.ErrorRate > t.maxErrorRate()) {
+		h.DemotedUntil = now.Add(t.probeInterval())
+	}
+
+	h.Score = t.score(h, now)
+}
+
+// Demote records a failed fetch and demotes the provider until its next
+// probe regardless of its error rate, for failures that won't clear on retry
+func (t *HealthTracker) Demote(name string, latency time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	h := t.get(name)
+	now := t.now()
+	h.Failures++
+	h.ErrorRate = (1-healthSmoothing)*h.ErrorRate + healthSmoothing
+	h.AvgLatency = smoothLatency(h.AvgLatency, latency, h.Successes+h.Failures)
+	h.LastFailure = now
+	h.DemotedUntil = now.Add(t.probeInterval())
+	h.Score = t.score(h, now)
+}
+
+// Rank orders providers by health. Healthy providers keep their configured order
+// unless their score is worse; demoted providers move to the end of the chain.
+// Providers whose probe period has elapsed are ranked normally so they get a recovery probe.
+func (t *HealthTracker) Rank(names []string) []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	now := t.now()
+	ranked := make([]string, len(names))
+	copy(ranked, names)
+
+	position := make(map[string]int, len(names))
+	for i, name := range names {
+		position[name] = i
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		hi, hj := t.health[ranked[i]], t.health[ranked[j]]
+		demotedI := hi != nil && hi.Demoted(now)
+		demotedJ := hj != nil && hj.Demoted(now)
+		if demotedI != demotedJ {
+			return !demotedI
+		}
+		scoreI, scoreJ := t.rankScore(hi, now), t.rankScore(hj, now)
+		// Only reorder on a meaningful score gap to avoid flapping
+		if scoreI-scoreJ > 0.25 || scoreJ-scoreI > 0.25 {
+			return scoreI > scoreJ
+		}
+		return position[ranked[i]] < position[ranked[j]]
+	})
+
+	return ranked
+}
+
+// Snapshot returns a copy of the health of all tracked providers
+func (t *HealthTracker) Snapshot() []ProviderHealth {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	now := t.now()
+	result := make([]ProviderHealth, 0, len(t.health))
+	for _, h := range t.health {
+		hc := *h
+		hc.Score = t.score(h, now)
+		result = append(result, hc)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// rankScore returns the score used for ordering. Unknown providers and providers
+// due for a recovery probe rank as fully healthy so they keep their configured position.
+func (t *HealthTracker) rankScore(h *ProviderHealth, now time.Time) float64 {
+	if h == nil || (!h.DemotedUntil.IsZero() && !h.Demoted(now)) {
+		return 1
+	}
+	return t.score(h, now)
+}
+
+// get returns the health entry for a provider, creating it if needed. Caller must hold the lock.
+func (t *HealthTracker) get(name string) *ProviderHealth {
+	h, ok := t.health[name]
+	if !ok {
+		h = &ProviderHealth{Name: name}
+		t.health[name] = h
+	}
+	return h
+}
+
+// score computes a 0-1 health score from error rate, latency and staleness
+func (t *HealthTracker) score(h *ProviderHealth, now time.Time) float64 {
+	score := 1 - h.ErrorRate
+
+	if t.policy.MaxLatencyMs > 0 && h.AvgLatency > time.Duration(t.policy.MaxLatencyMs)*time.Millisecond {
+		score -= 0.25
+	}
+	if t.isStale(h, now) {
+		score -= 0.5
+	}
+
+	if score < 0 {
+		score = 0
+	}
+	return score
+}
+
+// isStale reports whether the provider's newest data point is older than the policy allows
+func (t *HealthTracker) isStale(h *ProviderHealth, now time.Time) bool {
+	if t.policy.MaxStalenessSeconds <= 0 || h.LastDataTime.IsZero() {
+		return false
+	}
+	return now.Sub(h.LastDataTime) > time.Duration(t.policy.MaxStalenessSeconds)*time.Second
+}
+
+// minSamples returns the requests needed before a provider can be demoted
+func (t *HealthTracker) minSamples() int {
+	if t.policy.MinSamples <= 0 {
+		return 3
+	}
+	return t.policy.MinSamples
+}
+
+// maxErrorRate returns the smoothed error rate that demotes a provider
+func (t *HealthTracker) maxErrorRate() float64 {
+	if t.policy.MaxErrorRate <= 0 {
+		return 0.5
+	}
+	return t.policy.MaxErrorRate
+}
+
+// probeInterval returns the demotion period
+func (t *HealthTracker) probeInterval() time.Duration {
+	if t.policy.ProbeIntervalSeconds <= 0 {
+		return 5 * time.Minute
+	}
+	return time.Duration(t.policy.ProbeIntervalSeconds) * time.Second
+}
+
+// smoothLatency applies an EWMA to latency, seeding it with the first sample
+func smoothLatency(avg, latency time.Duration, samples int) time.Duration {
+	if samples <= 1 {
+		return latency
+	}
+	return time.Duration((1-healthSmoothing)*float64(avg) + healthSmoothing*float64(latency))
+}
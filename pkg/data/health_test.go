@@ -0,0 +1,122 @@
+package data
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/errkind"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestHealthTracker(now *time.Time) *HealthTracker {
+	tracker := NewHealthTracker(config.FailoverConfig{
+		MaxErrorRate:         0.3,
+		MaxLatencyMs:         1000,
+		MaxStalenessSeconds:  600,
+		ProbeIntervalSeconds: 60,
+		MinSamples:           2,
+	})
+	tracker.now = func() time.Time { return *now }
+	return tracker
+}
+
+func TestHealthTrackerDemotesFailingProvider(t *testing.T) {
+	now := time.Date(2025, 4, 21, 14, 0, 0, 0, time.UTC)
+	tracker := newTestHealthTracker(&now)
+	chain := []string{"yahoo", "alphavantage"}
+
+	assert.Equal(t, chain, tracker.Rank(chain))
+
+	// One failure is not enough to demote
+	tracker.RecordFailure("yahoo", 100*time.Millisecond)
+	assert.Equal(t, chain, tracker.Rank(chain))
+
+	// Second failure crosses min samples and error rate
+	tracker.RecordFailure("yahoo", 100*time.Millisecond)
+	assert.Equal(t, []string{"alphavantage", "yahoo"}, tracker.Rank(chain))
+
+	// After the probe interval the provider is tried again in its configured position
+	now = now.Add(61 * time.Second)
+	assert.Equal(t, "yahoo", tracker.Rank(chain)[0])
+
+	// A failed probe demotes it again immediately
+	tracker.RecordFailure("yahoo", 100*time.Millisecond)
+	assert.Equal(t, []string{"alphavantage", "yahoo"}, tracker.Rank(chain))
+
+	// A successful probe lifts the demotion; it regains first place as its error rate decays
+	now = now.Add(61 * time.Second)
+	tracker.RecordSuccess("yahoo", 50*time.Millisecond, now)
+	assert.False(t, tracker.Snapshot()[0].Demoted(now))
+	for i := 0; i < 5; i++ {
+		tracker.RecordSuccess("yahoo", 50*time.Millisecond, now)
+	}
+	assert.Equal(t, chain, tracker.Rank(chain))
+}
+
+func TestHealthTrackerDefaultsUnsetPolicy(t *testing.T) {
+	now := time.Date(2025, 4, 21, 14, 0, 0, 0, time.UTC)
+	tracker := NewHealthTracker(config.FailoverConfig{})
+	tracker.now = func() time.Time { return now }
+	chain := []string{"yahoo", "alphavantage"}
+
+	// A single failure does not demote a provider when the policy is unset
+	tracker.RecordFailure("yahoo", 100*time.Millisecond)
+	assert.Equal(t, chain, tracker.Rank(chain))
+
+	tracker.RecordFailure("yahoo", 100*time.Millisecond)
+	tracker.RecordFailure("yahoo", 100*time.Millisecond)
+	tracker.RecordFailure("yahoo", 100*time.Millisecond)
+	assert.Equal(t, []string{"alphavantage", "yahoo"}, tracker.Rank(chain))
+}
+
+func TestHealthTrackerDemotesStaleProvider(t *testing.T) {
+	now := time.Date(2025, 4, 21, 14, 0, 0, 0, time.UTC)
+	tracker := newTestHealthTracker(&now)
+	chain := []string{"yahoo", "alphavantage"}
+
+	tracker.RecordSuccess("yahoo", 50*time.Millisecond, now.Add(-time.Hour))
+	assert.Equal(t, []string{"alphavantage", "yahoo"}, tracker.Rank(chain))
+
+	health := tracker.Snapshot()
+	assert.Len(t, health, 1)
+	assert.Equal(t, "yahoo", health[0].Name)
+	assert.True(t, health[0].Demoted(now))
+}
+
+func TestGetMarketDataFailsOver(t *testing.T) {
+	cfg := config.CreateDefaultConfig()
+	cfg.DataSource.Chain = []string{"questrade", "alphavantage"}
+	cfg.DataSource.APIKeys = map[string]string{"alphavantage": ""}
+
+	provider := NewProvider(cfg)
+
+	// Questrade is not configured and Alpha Vantage has no key, so every provider fails
+	data, err := provider.GetMarketData("AAPL")
+	assert.Nil(t, data)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "all data sources failed")
+
+	health := provider.GetProviderHealth()
+	assert.Len(t, health, 2)
+	for _, h := range health {
+		assert.Equal(t, 1, h.Failures)
+	}
+}
+
+func TestGetMarketDataDemotesUnauthorizedProviders(t *testing.T) {
+	cfg := config.CreateDefaultConfig()
+	cfg.DataSource.Chain = []string{"alphavantage"}
+	cfg.DataSource.APIKeys = map[string]string{"alphavantage": ""}
+
+	provider := NewProvider(cfg)
+
+	// A missing key won't fix itself, so the provider is demoted after one failure
+	_, err := provider.GetMarketData("AAPL")
+	assert.True(t, errors.Is(err, errkind.ErrAuth))
+
+	health := provider.GetProviderHealth()
+	assert.Len(t, health, 1)
+	assert.True(t, health[0].Demoted(time.Now()))
+}
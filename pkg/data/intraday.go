@@ -0,0 +1,34 @@
+package data
+
+import (
+	"fmt"
+	"time"
+)
+
+// maxIntradayDays is the longest history Yahoo Finance serves at 15-minute intervals
+const maxIntradayDays = 60
+
+// GetIntradayHistory returns up to days calendar days of 15-minute closes for
+// a symbol, oldest first. Questrade is used when configured, otherwise Yahoo
+// Finance, which limits the history to 60 days.
+func (p *Provider) GetIntradayHistory(symbol string, days int) (*MarketData, error) {
+	if days <= 0 || days > maxIntradayDays {
+		days = maxIntradayDays
+	}
+
+	end := time.Now()
+	start := end.AddDate(0, 0, -days)
+	if p.questrade != nil {
+		data, err := p.questrade.GetCandles(symbol, "FifteenMinutes", start, end)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get intraday candles: %w", err)
+		}
+		return data, nil
+	}
+
+	data, err := fetchYahooChart(symbol, "15m", fmt.Sprintf("%dd", days))
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
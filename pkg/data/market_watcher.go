@@ -5,13 +5,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
 	"sync"
 	"time"
 
 	"github.com/hustler/trading-bot/pkg/auth"
+	"github.com/hustler/trading-bot/pkg/errkind"
 )
 
 // Stock represents a stock with its current market data
@@ -27,17 +30,27 @@ type Stock struct {
 	Ask           float64
 	Change        float64
 	ChangePercent float64
+	Halted        bool // Trading halted, as reported by sources that support it
 }
 
 // MarketWatcher watches real-time market data for a list of stocks
 type MarketWatcher struct {
-	stocks      map[string]*Stock
-	authManager *auth.AuthManager
-	dataSource  string
-	pollInterval time.Duration
-	mu          sync.RWMutex
-	ctx         context.Context
-	cancel      context.CancelFunc
+	stocks          map[string]*Stock
+	authManager     *auth.AuthManager
+	questrade       *QuestradeClient
+	ticks           TickSource
+	dataSource      string
+	pollInterval    time.Duration
+	listeners       []func(*Stock)
+	notified        map[string]Stock
+	history         map[string]*tickRing // Recent ticks per symbol
+	historySize     int
+	candleInterval  time.Duration
+	priceThreshold  float64
+	volumeThreshold int64
+	mu              sync.RWMutex
+	ctx             context.Context
+	cancel          context.CancelFunc
 }
 
 // YahooFinanceResponse represents the response from Yahoo Finance API
@@ -100,14 +113,45 @@ func NewMarketWatcher(authManager *auth.AuthManager, dataSource string, pollInte
 	ctx, cancel := context.WithCancel(context.Background())
 	return &MarketWatcher{
 		stocks:       make(map[string]*Stock),
-		authManager:  authManager,
-		dataSource:   dataSource,
-		pollInterval: time.Duration(pollInterval) * time.Second,
-		ctx:          ctx,
-		cancel:       cancel,
+		notified:       make(map[string]Stock),
+		history:        make(map[string]*tickRing),
+		historySize:    defaultHistorySize,
+		candleInterval: defaultCandleInterval,
+		authManager:    authManager,
+		dataSource:     dataSource,
+		pollInterval:   time.Duration(pollInterval) * time.Second,
+		ctx:            ctx,
+		cancel:         cancel,
 	}
 }
 
+// SetQuestradeClient sets the Questrade client used for the "questrade" data source
+func (m *MarketWatcher) SetQuestradeClient(client *QuestradeClient) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.questrade = client
+}
+
+// OnUpdate registers a callback fired when a stock's price or volume changes beyond
+// the configured thresholds. The callback receives a copy of the stock.
+func (m *MarketWatcher) OnUpdate(callback func(*Stock)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.listeners = append(m.listeners, callback)
+}
+
+// SetChangeThreshold sets the minimum price change (in percent) and volume change
+// required before OnUpdate callbacks fire. Zero thresholds fire on any change.
+func (m *MarketWatcher) SetChangeThreshold(pricePercent float64, volume int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.priceThreshold = pricePercent
+	m.volumeThreshold = volume
+}
+
 // AddStock adds a stock to the watch list
 func (m *MarketWatcher) AddStock(symbol string) {
 	m.mu.Lock()
@@ -124,25 +168,80 @@ func (m *MarketWatcher) RemoveStock(symbol string) {
 	defer m.mu.Unlock()
 	
 	delete(m.stocks, symbol)
+	delete(m.notified, symbol)
+	delete(m.history, symbol)
 }
 
-// GetStock returns the current stock data
+// SyncWatchlist makes the watch list match symbols, adding the missing stocks
+// and removing the ones no longer listed in one step, so polling can continue
+// across watchlist changes. It returns the symbols added and removed.
+func (m *MarketWatcher) SyncWatchlist(symbols []string) (added, removed []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	wanted := make(map[string]bool, len(symbols))
+	for _, symbol := range symbols {
+		if symbol == "" || wanted[symbol] {
+			continue
+		}
+		wanted[symbol] = true
+		if _, exists := m.stocks[symbol]; !exists {
+			m.stocks[symbol] = &Stock{Symbol: symbol}
+			added = append(added, symbol)
+		}
+	}
+
+	// A poll already under way skips the removed symbols, as updates only
+	// apply to stocks still watched
+	for symbol := range m.stocks {
+		if !wanted[symbol] {
+			delete(m.stocks, symbol)
+			delete(m.notified, symbol)
+			delete(m.history, symbol)
+			removed = append(removed, symbol)
+		}
+	}
+	sort.Strings(removed)
+	return added, removed
+}
+
+// GetStock returns a copy of the current stock data, so callers can read it
+// while polling updates the watcher
 func (m *MarketWatcher) GetStock(symbol string) (*Stock, bool) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 	
 	stock, exists := m.stocks[symbol]
-	return stock, exists
+	if !exists {
+		return nil, false
+	}
+	snapshot := *stock
+	return &snapshot, true
 }
 
-// GetAllStocks returns all stocks being watched
+// GetAllStocks returns copies of all stocks being watched
 func (m *MarketWatcher) GetAllStocks() []*Stock {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 	
 	stocks := make([]*Stock, 0, len(m.stocks))
 	for _, stock := range m.stocks {
-		stocks = append(stocks, stock)
+		snapshot := *stock
+		stocks = append(stocks, &snapshot)
+	}
+	return stocks
+}
+
+// Snapshot returns copies of all stocks being watched by symbol, taken at
+// one point in time, as the risk manager and trade manager expect them
+func (m *MarketWatcher) Snapshot() map[string]*Stock {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	
+	stocks := make(map[string]*Stock, len(m.stocks))
+	for symbol, stock := range m.stocks {
+		snapshot := *stock
+		stocks[symbol] = &snapshot
 	}
 	return stocks
 }
@@ -182,13 +281,78 @@ func (m *MarketWatcher) updateAllStocks() {
 		return
 	}
 	
+	defer m.notifyChanges(symbols)
+	defer m.recordTicks(symbols)
+	
+	if !m.supportsBatch() {
+		for _, symbol := range symbols {
+			if err := m.updateStock(symbol); err != nil {
+				fmt.Printf("Error updating stock %s: %v\n", symbol, err)
+			}
+		}
+		return
+	}
+	
+	// Resolve symbols in batches, falling back to single-symbol requests for a failed batch
+	for _, batch := range chunkSymbols(symbols, batchQuoteSize) {
+		if err := m.updateStocksBatch(batch); err != nil {
+			fmt.Printf("Error updating batch of %d stocks: %v\n", len(batch), err)
+			for _, symbol := range batch {
+				if err := m.updateStock(symbol); err != nil {
+					fmt.Printf("Error updating stock %s: %v\n", symbol, err)
+				}
+			}
+		}
+	}
+}
+
+// notifyChanges fires OnUpdate callbacks for stocks that moved beyond the thresholds
+// since they were last notified. Callbacks run outside the lock.
+func (m *MarketWatcher) notifyChanges(symbols []string) {
+	m.mu.Lock()
+	if len(m.listeners) == 0 {
+		m.mu.Unlock()
+		return
+	}
+
+	var changed []Stock
 	for _, symbol := range symbols {
-		if err := m.updateStock(symbol); err != nil {
-			fmt.Printf("Error updating stock %s: %v\n", symbol, err)
+		stock, exists := m.stocks[symbol]
+		if !exists || stock.CurrentPrice == 0 {
+			continue
+		}
+		if last, ok := m.notified[symbol]; ok && !m.exceedsThreshold(last, *stock) {
+			continue
+		}
+		m.notified[symbol] = *stock
+		changed = append(changed, *stock)
+	}
+	listeners := make([]func(*Stock), len(m.listeners))
+	copy(listeners, m.listeners)
+	m.mu.Unlock()
+
+	for i := range changed {
+		for _, listener := range listeners {
+			stock := changed[i]
+			listener(&stock)
 		}
 	}
 }
 
+// exceedsThreshold reports whether current differs enough from last to notify. Caller must hold the lock.
+func (m *MarketWatcher) exceedsThreshold(last, current Stock) bool {
+	priceDelta := math.Abs(current.CurrentPrice - last.CurrentPrice)
+	if priceDelta > 0 && (last.CurrentPrice == 0 || priceDelta/last.CurrentPrice*100 >= m.priceThreshold) {
+		return true
+	}
+
+	volumeDelta := current.Volume - last.Volume
+	if volumeDelta < 0 {
+		volumeDelta = -volumeDelta
+	}
+	return volumeDelta > 0 && volumeDelta >= m.volumeThreshold
+}
+
 // updateStock updates market data for a single stock
 func (m *MarketWatcher) updateStock(symbol string) error {
 	switch m.dataSource {
@@ -198,6 +362,8 @@ func (m *MarketWatcher) updateStock(symbol string) error {
 		return m.updateStockAlphaVantage(symbol)
 	case "finnhub":
 		return m.updateStockFinnhub(symbol)
+	case "questrade":
+		return m.updateStockQuestrade(symbol)
 	default:
 		return fmt.Errorf("unsupported data source: %s", m.dataSource)
 	}
@@ -223,13 +389,13 @@ func (m *MarketWatcher) updateStockYahooFinance(symbol string) error {
 	
 	resp, err := client.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
+		return fmt.Errorf("failed to execute request: %w", errkind.Unreachable(err))
 	}
 	defer resp.Body.Close()
 	
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to get data, status: %d, body: %s", resp.StatusCode, string(body))
+		return fmt.Errorf("failed to get data: %w", errkind.Status(resp.StatusCode, string(body)))
 	}
 	
 	body, err := io.ReadAll(resp.Body)
@@ -243,7 +409,7 @@ func (m *MarketWatcher) updateStockYahooFinance(symbol string) error {
 	}
 	
 	if len(yahooResp.Chart.Result) == 0 {
-		return fmt.Errorf("no data found for symbol: %s", symbol)
+		return fmt.Errorf("%w found for symbol: %s", errkind.ErrNoData, symbol)
 	}
 	
 	result := yahooResp.Chart.Result[0]
@@ -289,13 +455,13 @@ func (m *MarketWatcher) updateStockAlphaVantage(symbol string) error {
 	
 	resp, err := http.Get(baseURL + "?" + params.Encode())
 	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
+		return fmt.Errorf("failed to execute request: %w", errkind.Unreachable(err))
 	}
 	defer resp.Body.Close()
 	
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to get data, status: %d, body: %s", resp.StatusCode, string(body))
+		return fmt.Errorf("failed to get data: %w", errkind.Status(resp.StatusCode, string(body)))
 	}
 	
 	body, err := io.ReadAll(resp.Body)
@@ -310,7 +476,7 @@ func (m *MarketWatcher) updateStockAlphaVantage(symbol string) error {
 	
 	quote := avResp.GlobalQuote
 	if quote.Symbol == "" {
-		return fmt.Errorf("no data found for symbol: %s", symbol)
+		return fmt.Errorf("%w found for symbol: %s", errkind.ErrNoData, symbol)
 	}
 	
 	// Parse string values to float64
@@ -360,64 +526,48 @@ func (m *MarketWatcher) updateStockFinnhub(symbol string) error {
 		return fmt.Errorf("failed to get Finnhub API key: %w", err)
 	}
 	
-	baseURL := "https://finnhub.io/api/v1/quote"
-	params := url.Values{}
-	params.Add("symbol", symbol)
-	
-	req, err := http.NewRequest("GET", baseURL+"?"+params.Encode(), nil)
+	quote, err := fetchFinnhubQuote(symbol, apiKey)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return err
 	}
 	
-	req.Header.Add("X-Finnhub-Token", apiKey)
-	
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
-	}
-	defer resp.Body.Close()
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to get data, status: %d, body: %s", resp.StatusCode, string(body))
+	stock, exists := m.stocks[symbol]
+	if !exists {
+		return fmt.Errorf("stock not found in watch list: %s", symbol)
 	}
 	
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
-	}
+	*stock = *quote
 	
-	var finnhubResp FinnhubResponse
-	if err := json.Unmarshal(body, &finnhubResp); err != nil {
-		return fmt.Errorf("failed to parse response: %w", err)
+	return nil
+}
+
+// updateStockQuestrade updates stock data using the Questrade L1 quotes API
+func (m *MarketWatcher) updateStockQuestrade(symbol string) error {
+	m.mu.RLock()
+	client := m.questrade
+	m.mu.RUnlock()
+
+	if client == nil {
+		return fmt.Errorf("Questrade client not configured")
 	}
-	
-	if finnhubResp.CurrentPrice == 0 {
-		return fmt.Errorf("no data found for symbol: %s", symbol)
+
+	quote, err := client.GetQuote(symbol)
+	if err != nil {
+		return fmt.Errorf("failed to get Questrade quote: %w", err)
 	}
-	
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	
+
 	stock, exists := m.stocks[symbol]
 	if !exists {
 		return fmt.Errorf("stock not found in watch list: %s", symbol)
 	}
-	
-	stock.CurrentPrice = finnhubResp.CurrentPrice
-	stock.PreviousClose = finnhubResp.PreviousClose
-	stock.Volume = 0 // Not provided in this API response
-	stock.LastUpdated = time.Now()
-	stock.DailyHigh = finnhubResp.High
-	stock.DailyLow = finnhubResp.Low
-	stock.Change = finnhubResp.Change
-	stock.ChangePercent = finnhubResp.PercentChange
-	
-	// Bid and Ask are not available in this API
-	// Using current price as an approximation
-	stock.Bid = finnhubResp.CurrentPrice
-	stock.Ask = finnhubResp.CurrentPrice
-	
+
+	*stock = *quote
+
 	return nil
 }
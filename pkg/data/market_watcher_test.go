@@ -0,0 +1,101 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarketWatcherOnUpdateThreshold(t *testing.T) {
+	watcher := NewMarketWatcher(nil, "questrade", 60)
+	watcher.AddStock("AAPL")
+	watcher.SetChangeThreshold(0.5, 1000)
+
+	var updates []Stock
+	watcher.OnUpdate(func(stock *Stock) {
+		updates = append(updates, *stock)
+		stock.CurrentPrice = -1
+	})
+
+	setQuote := func(price float64, volume int64) {
+		watcher.mu.Lock()
+		watcher.stocks["AAPL"].CurrentPrice = price
+		watcher.stocks["AAPL"].Volume = volume
+		watcher.mu.Unlock()
+		watcher.notifyChanges([]string{"AAPL"})
+	}
+
+	// First quote always notifies
+	setQuote(100, 10000)
+	assert.Len(t, updates, 1)
+
+	// Small moves below both thresholds do not notify
+	setQuote(100.2, 10500)
+	assert.Len(t, updates, 1)
+
+	// Moves accumulate against the last notified value
+	setQuote(100.6, 10500)
+	assert.Len(t, updates, 2)
+	assert.Equal(t, 100.6, updates[1].CurrentPrice)
+
+	// Volume alone can trigger a notification
+	setQuote(100.6, 12000)
+	assert.Len(t, updates, 3)
+
+	// Callbacks receive copies, so mutating them does not affect the watcher
+	stock, _ := watcher.GetStock("AAPL")
+	assert.Equal(t, 100.6, stock.CurrentPrice)
+}
+
+func TestMarketWatcherSyncWatchlist(t *testing.T) {
+	watcher := NewMarketWatcher(nil, "questrade", 60)
+	watcher.AddStock("AAPL")
+	watcher.AddStock("MSFT")
+	watcher.mu.Lock()
+	watcher.stocks["AAPL"].CurrentPrice = 190
+	watcher.notified["MSFT"] = Stock{Symbol: "MSFT"}
+	watcher.mu.Unlock()
+
+	added, removed := watcher.SyncWatchlist([]string{"AAPL", "NVDA", "NVDA", ""})
+	assert.Equal(t, []string{"NVDA"}, added)
+	assert.Equal(t, []string{"MSFT"}, removed)
+
+	// Stocks kept keep their quotes; removed ones are forgotten
+	stock, ok := watcher.GetStock("AAPL")
+	assert.True(t, ok)
+	assert.Equal(t, 190.0, stock.CurrentPrice)
+	_, ok = watcher.GetStock("MSFT")
+	assert.False(t, ok)
+	assert.NotContains(t, watcher.notified, "MSFT")
+	assert.Len(t, watcher.GetAllStocks(), 2)
+
+	// Syncing the same list again changes nothing
+	added, removed = watcher.SyncWatchlist([]string{"NVDA", "AAPL"})
+	assert.Empty(t, added)
+	assert.Empty(t, removed)
+}
+
+func TestMarketWatcherReturnsCopies(t *testing.T) {
+	watcher := NewMarketWatcher(nil, "questrade", 60)
+	watcher.AddStock("AAPL")
+	watcher.mu.Lock()
+	watcher.stocks["AAPL"].CurrentPrice = 190
+	watcher.mu.Unlock()
+
+	stock, _ := watcher.GetStock("AAPL")
+	stock.CurrentPrice = 1
+	watcher.GetAllStocks()[0].CurrentPrice = 2
+	watcher.Snapshot()["AAPL"].CurrentPrice = 3
+
+	// Updates after a read do not show through the copies taken before
+	snapshot := watcher.Snapshot()
+	watcher.mu.Lock()
+	watcher.stocks["AAPL"].CurrentPrice = 191
+	watcher.mu.Unlock()
+	assert.Equal(t, 190.0, snapshot["AAPL"].CurrentPrice)
+
+	stock, _ = watcher.GetStock("AAPL")
+	assert.Equal(t, 191.0, stock.CurrentPrice)
+	_, ok := watcher.GetStock("MSFT")
+	assert.False(t, ok)
+}
@@ -5,68 +5,175 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/errkind"
+	"github.com/hustler/trading-bot/pkg/market"
 )
 
 // Provider handles fetching market data from various sources
 type Provider struct {
-	config *config.Config
+	config     *config.Config
+	questrade  *QuestradeClient
+	health     *HealthTracker
+	cache      QuoteCache // Shared with other instances; nil fetches every time
+	cacheTTL   time.Duration
+	shortSales *ShortSaleTracker
+	mu         sync.RWMutex // Guards config, which is replaced rather than changed
 }
 
 // MarketData represents market data for a stock
 type MarketData struct {
-	Symbol     string
-	Prices     []float64
-	Volumes    []float64
-	Timestamps []time.Time
+	Symbol          string
+	Prices          []float64
+	Volumes         []float64
+	Timestamps      []time.Time
+	Exchange        string  // Listing exchange as reported by the source, if known
+	PreviousClose   float64 // Prior session close, if known
+	Halted          bool    // Trading halted, as reported by sources that support it
+	ShortRestricted bool    // Short-sale restriction (Rule 201) in effect
+
+	// Per bar breakdown of Volumes, for sources that report it; nil otherwise
+	OffExchangeVolumes []float64 // Volume printed off-exchange: dark pools and other TRF prints
+	OddLotVolumes      []float64 // Volume printed in odd lots
 }
 
-// NewProvider creates a new data provider
+// NewProvider creates a new data provider with its own copy of cfg
 func NewProvider(cfg *config.Config) *Provider {
-	return &Provider{
-		config: cfg,
+	p := &Provider{
+		config:     cfg.Clone(),
+		health:     NewHealthTracker(cfg.DataSource.Failover),
+		shortSales: NewShortSaleTracker(market.DefaultClock()),
 	}
+
+	return p
+}
+
+// SetQuestradeClient sets the Questrade client used for the "questrade" data
+// source; without one that source fails with an auth error
+func (p *Provider) SetQuestradeClient(client *QuestradeClient) {
+	p.questrade = client
 }
 
-// GetMarketData fetches market data for a symbol
+// GetMarketData fetches market data for a symbol, walking the provider chain
+// in order of health and failing over to the next provider on error. Rate
+// limited and unauthorized providers are demoted at once; providers without
+// data for the symbol are not penalized.
 func (p *Provider) GetMarketData(symbol string) (*MarketData, error) {
-	// Determine which data source to use
-	primary := p.config.DataSource.Primary
-	
-	var data *MarketData
-	var err error
-	
-	// Try primary source
-	switch primary {
-	case "yahoo":
-		data, err = p.fetchYahooFinanceData(symbol)
-	case "alphavantage":
-		data, err = p.fetchAlphaVantageData(symbol)
-	default:
-		return nil, fmt.Errorf("unsupported primary data source: %s", primary)
+	if data, ok := p.cachedMarketData(symbol); ok {
+		p.shortSales.Observe(data)
+		return data, nil
 	}
-	
-	// If primary source fails, try secondary source
-	if err != nil {
-		secondary := p.config.DataSource.Secondary
-		
-		switch secondary {
-		case "yahoo":
-			data, err = p.fetchYahooFinanceData(symbol)
-		case "alphavantage":
-			data, err = p.fetchAlphaVantageData(symbol)
-		default:
-			return nil, fmt.Errorf("primary source failed and unsupported secondary data source: %s", secondary)
+
+	chain := p.providerChain()
+	if len(chain) == 0 {
+		return nil, fmt.Errorf("no data sources configured")
+	}
+
+	if _, ok := p.fetcherFor(chain[0]); !ok {
+		return nil, fmt.Errorf("unsupported primary data source: %s", chain[0])
+	}
+
+	var lastErr error
+	for _, name := range p.health.Rank(chain) {
+		fetch, ok := p.fetcherFor(name)
+		if !ok {
+			lastErr = fmt.Errorf("unsupported data source: %s", name)
+			continue
 		}
-		
+
+		start := time.Now()
+		data, err := fetch(symbol)
 		if err != nil {
-			return nil, fmt.Errorf("both primary and secondary data sources failed: %w", err)
+			switch errkind.Of(err) {
+			case errkind.NoData:
+				// The provider is fine, it just doesn't cover the symbol
+			case errkind.RateLimited, errkind.Auth:
+				// Won't recover on the next request; skip the provider until its probe
+				p.health.Demote(name, time.Since(start))
+			default:
+				p.health.RecordFailure(name, time.Since(start))
+			}
+			lastErr = fmt.Errorf("%s: %w", name, err)
+			continue
+		}
+
+		var newest time.Time
+		if len(data.Timestamps) > 0 {
+			newest = data.Timestamps[len(data.Timestamps)-1]
 		}
+		p.health.RecordSuccess(name, time.Since(start), newest)
+		if filter := p.dataSource().VolumeFilter; filter.Enabled {
+			FilterVolumes(data, filter)
+		}
+		p.shortSales.Observe(data)
+		p.cacheMarketData(data)
+
+		return data, nil
+	}
+
+	return nil, fmt.Errorf("all data sources failed: %w", lastErr)
+}
+
+// GetProviderHealth returns the current health of each data provider
+func (p *Provider) GetProviderHealth() []ProviderHealth {
+	return p.health.Snapshot()
+}
+
+// ProbeSource fetches symbol from the named data source alone, bypassing the
+// cache and failover, to verify the source is reachable and authorized
+func (p *Provider) ProbeSource(name, symbol string) (*MarketData, error) {
+	fetch, ok := p.fetcherFor(name)
+	if !ok {
+		return nil, fmt.Errorf("unsupported data source: %s", name)
+	}
+	return fetch(symbol)
+}
+
+// ProviderChain returns the configured data sources in failover order
+func (p *Provider) ProviderChain() []string {
+	return p.providerChain()
+}
+
+// providerChain returns a copy of the configured provider chain
+func (p *Provider) providerChain() []string {
+	source := p.dataSource()
+	if len(source.Chain) > 0 {
+		return append([]string(nil), source.Chain...)
+	}
+
+	chain := make([]string, 0, 2)
+	if source.Primary != "" {
+		chain = append(chain, source.Primary)
+	}
+	if source.Secondary != "" && source.Secondary != source.Primary {
+		chain = append(chain, source.Secondary)
+	}
+	return chain
+}
+
+// dataSource returns the data source settings. The config is replaced by
+// updates, never changed in place, so its slices and maps can be read freely.
+func (p *Provider) dataSource() config.DataSourceConfig {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.config.DataSource
+}
+
+// fetcherFor returns the fetch function for a named data source
+func (p *Provider) fetcherFor(name string) (func(string) (*MarketData, error), bool) {
+	switch name {
+	case "yahoo":
+		return p.fetchYahooFinanceData, true
+	case "alphavantage":
+		return p.fetchAlphaVantageData, true
+	case "questrade":
+		return p.fetchQuestradeData, true
+	default:
+		return nil, false
 	}
-	
-	return data, nil
 }
 
 // fetchYahooFinanceData fetches data from Yahoo Finance API
@@ -95,13 +202,13 @@ func (p *Provider) fetchYahooFinanceData(symbol string) (*MarketData, error) {
 	// Send request
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		return nil, fmt.Errorf("failed to send request: %w", errkind.Unreachable(err))
 	}
 	defer resp.Body.Close()
 	
 	// Check status code
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status %d", resp.StatusCode)
+		return nil, fmt.Errorf("API request failed: %w", errkind.Status(resp.StatusCode, ""))
 	}
 	
 	// Read response body
@@ -128,7 +235,14 @@ func (p *Provider) fetchYahooFinanceData(symbol string) (*MarketData, error) {
 	}
 	
 	// For now, we'll return mock data since we can't actually call the API
-	return createMockMarketData(symbol), nil
+	data := createMockMarketData(symbol)
+	if first, ok := result[0].(map[string]interface{}); ok {
+		if meta, ok := first["meta"].(map[string]interface{}); ok {
+			data.Exchange, _ = meta["exchangeName"].(string)
+			data.PreviousClose, _ = meta["chartPreviousClose"].(float64)
+		}
+	}
+	return data, nil
 }
 
 // fetchAlphaVantageData fetches data from Alpha Vantage API
@@ -137,9 +251,9 @@ func (p *Provider) fetchAlphaVantageData(symbol string) (*MarketData, error) {
 	// For now, we'll return mock data
 	
 	// Get API key
-	apiKey, ok := p.config.DataSource.APIKeys["alphavantage"]
+	apiKey, ok := p.dataSource().APIKeys["alphavantage"]
 	if !ok || apiKey == "" {
-		return nil, fmt.Errorf("Alpha Vantage API key not found")
+		return nil, fmt.Errorf("Alpha Vantage API key not found: %w", errkind.ErrAuth)
 	}
 	
 	// Create HTTP client with timeout
@@ -165,13 +279,13 @@ func (p *Provider) fetchAlphaVantageData(symbol string) (*MarketData, error) {
 	// Send request
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		return nil, fmt.Errorf("failed to send request: %w", errkind.Unreachable(err))
 	}
 	defer resp.Body.Close()
 	
 	// Check status code
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status %d", resp.StatusCode)
+		return nil, fmt.Errorf("API request failed: %w", errkind.Status(resp.StatusCode, ""))
 	}
 	
 	// Read response body
@@ -195,6 +309,15 @@ func (p *Provider) fetchAlphaVantageData(symbol string) (*MarketData, error) {
 	return createMockMarketData(symbol), nil
 }
 
+// fetchQuestradeData fetches intraday candles from the Questrade API
+func (p *Provider) fetchQuestradeData(symbol string) (*MarketData, error) {
+	if p.questrade == nil {
+		return nil, fmt.Errorf("Questrade refresh token not found: %w", errkind.ErrAuth)
+	}
+
+	return p.questrade.GetMarketData(symbol)
+}
+
 // createMockMarketData creates mock market data for testing
 func createMockMarketData(symbol string) *MarketData {
 	// Create base price based on symbol
@@ -258,7 +381,10 @@ func createMockMarketData(symbol string) *MarketData {
 	}
 }
 
-// UpdateConfig updates the provider configuration
+// UpdateConfig updates the provider configuration to a copy of cfg
 func (p *Provider) UpdateConfig(cfg *config.Config) {
-	p.config = cfg
+	p.mu.Lock()
+	p.config = cfg.Clone()
+	p.mu.Unlock()
+	p.health.UpdatePolicy(cfg.DataSource.Failover)
 }
@@ -1,6 +1,7 @@
 package data
 
 import (
+	"sync"
 	"testing"
 
 	"github.com/hustler/trading-bot/pkg/config"
@@ -156,3 +157,37 @@ func TestFetchAlphaVantageData(t *testing.T) {
 	assert.Nil(t, data)
 	assert.Contains(t, err.Error(), "Alpha Vantage API key not found")
 }
+
+// Run with -race: the provider keeps its own copy of the config, which can
+// be updated while data is fetched
+func TestUpdateConfigConcurrentWithReads(t *testing.T) {
+	cfg := config.CreateDefaultConfig()
+	cfg.DataSource.Chain = []string{"yahoo", "alphavantage"}
+	provider := NewProvider(cfg)
+
+	// Changing the caller's config afterwards doesn't reach the provider
+	cfg.DataSource.Chain[0] = "questrade"
+	assert.Equal(t, []string{"yahoo", "alphavantage"}, provider.ProviderChain())
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			next := config.CreateDefaultConfig()
+			next.DataSource.Chain = []string{"alphavantage", "yahoo"}
+			provider.UpdateConfig(next)
+			next.DataSource.Chain[0] = "questrade"
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			chain := provider.ProviderChain()
+			chain[0] = "mine"
+		}
+	}()
+	wg.Wait()
+
+	assert.Equal(t, []string{"alphavantage", "yahoo"}, provider.ProviderChain())
+}
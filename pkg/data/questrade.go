@@ -0,0 +1,318 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/auth"
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/errkind"
+)
+
+// QuestradeClient fetches L1 quotes and candles from the Questrade market data API
+type QuestradeClient struct {
+	oauth   *auth.OAuthManager
+	client  *http.Client
+	symbols map[string]questradeSymbol
+	mu      sync.RWMutex
+}
+
+// questradeSymbol caches the symbol lookup for a ticker
+type questradeSymbol struct {
+	ID            int64
+	PrevClose     float64
+	PrevCloseAsOf string // date (YYYY-MM-DD) PrevClose was fetched on
+	Exchange      string // Listing exchange, e.g. NASDAQ or PINX
+}
+
+// QuestradeSymbolsResponse represents the response from the Questrade symbols endpoint
+type QuestradeSymbolsResponse struct {
+	Symbols []struct {
+		Symbol            string  `json:"symbol"`
+		SymbolID          int64   `json:"symbolId"`
+		PrevDayClosePrice float64 `json:"prevDayClosePrice"`
+		Currency          string  `json:"currency"`
+		ListingExchange   string  `json:"listingExchange"`
+	} `json:"symbols"`
+}
+
+// QuestradeQuotesResponse represents the response from the Questrade quotes endpoint
+type QuestradeQuotesResponse struct {
+	Quotes []questradeQuote `json:"quotes"`
+}
+
+// questradeQuote is a single L1 quote from the Questrade quotes endpoint
+type questradeQuote struct {
+	Symbol         string  `json:"symbol"`
+	SymbolID       int64   `json:"symbolId"`
+	BidPrice       float64 `json:"bidPrice"`
+	AskPrice       float64 `json:"askPrice"`
+	LastTradePrice float64 `json:"lastTradePrice"`
+	Volume         int64   `json:"volume"`
+	OpenPrice      float64 `json:"openPrice"`
+	HighPrice      float64 `json:"highPrice"`
+	LowPrice       float64 `json:"lowPrice"`
+	LastTradeTime  string  `json:"lastTradeTime"`
+	IsHalted       bool    `json:"isHalted"`
+}
+
+// QuestradeCandlesResponse represents the response from the Questrade candles endpoint
+type QuestradeCandlesResponse struct {
+	Candles []struct {
+		Start  string  `json:"start"`
+		End    string  `json:"end"`
+		Open   float64 `json:"open"`
+		High   float64 `json:"high"`
+		Low    float64 `json:"low"`
+		Close  float64 `json:"close"`
+		Volume int64   `json:"volume"`
+	} `json:"candles"`
+}
+
+// NewQuestradeClient creates a new Questrade market data client
+func NewQuestradeClient(oauth *auth.OAuthManager) *QuestradeClient {
+	return &QuestradeClient{
+		oauth:   oauth,
+		client:  &http.Client{Timeout: 10 * time.Second},
+		symbols: make(map[string]questradeSymbol),
+	}
+}
+
+// QuestradeClientFromConfig creates a Questrade client from the refresh token
+// stored under the "questrade" API key, or returns nil when none is set. Every
+// token refresh rotates the refresh token, so create one client per process
+// and share it.
+func QuestradeClientFromConfig(cfg config.DataSourceConfig) *QuestradeClient {
+	refreshToken := cfg.APIKeys["questrade"]
+	if refreshToken == "" {
+		return nil
+	}
+	return NewQuestradeClient(auth.NewOAuthManager(cfg.APIKeys["questrade_client_id"], refreshToken))
+}
+
+// SetTokenStore enables persistence of rotated OAuth tokens, reloading any saved token
+func (q *QuestradeClient) SetTokenStore(store auth.TokenStore) error {
+	return q.oauth.SetTokenStore(store)
+}
+
+// GetQuote returns the current L1 quote for a symbol
+func (q *QuestradeClient) GetQuote(symbol string) (*Stock, error) {
+	sym, err := q.lookupSymbol(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	var quotesResp QuestradeQuotesResponse
+	if err := q.get(fmt.Sprintf("v1/markets/quotes/%d", sym.ID), nil, &quotesResp); err != nil {
+		return nil, err
+	}
+
+	if len(quotesResp.Quotes) == 0 {
+		return nil, fmt.Errorf("no quote found for symbol: %s", symbol)
+	}
+
+	return questradeQuoteToStock(symbol, sym, quotesResp.Quotes[0]), nil
+}
+
+// GetQuotes returns L1 quotes for many symbols using a single quotes request.
+// Symbols that cannot be resolved are omitted from the result.
+func (q *QuestradeClient) GetQuotes(symbols []string) (map[string]*Stock, error) {
+	resolved := make(map[int64]string, len(symbols))
+	syms := make(map[string]questradeSymbol, len(symbols))
+	ids := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		sym, err := q.lookupSymbol(symbol)
+		if err != nil {
+			continue
+		}
+		resolved[sym.ID] = symbol
+		syms[symbol] = sym
+		ids = append(ids, strconv.FormatInt(sym.ID, 10))
+	}
+
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no symbols could be resolved on Questrade")
+	}
+
+	params := url.Values{}
+	params.Add("ids", strings.Join(ids, ","))
+
+	var quotesResp QuestradeQuotesResponse
+	if err := q.get("v1/markets/quotes", params, &quotesResp); err != nil {
+		return nil, err
+	}
+
+	stocks := make(map[string]*Stock, len(quotesResp.Quotes))
+	for _, quote := range quotesResp.Quotes {
+		symbol, ok := resolved[quote.SymbolID]
+		if !ok {
+			continue
+		}
+		stocks[symbol] = questradeQuoteToStock(symbol, syms[symbol], quote)
+	}
+
+	return stocks, nil
+}
+
+// questradeQuoteToStock converts a Questrade L1 quote into a Stock
+func questradeQuoteToStock(symbol string, sym questradeSymbol, quote questradeQuote) *Stock {
+	stock := &Stock{
+		Symbol:        symbol,
+		CurrentPrice:  quote.LastTradePrice,
+		PreviousClose: sym.PrevClose,
+		Volume:        quote.Volume,
+		LastUpdated:   time.Now(),
+		DailyHigh:     quote.HighPrice,
+		DailyLow:      quote.LowPrice,
+		Bid:           quote.BidPrice,
+		Ask:           quote.AskPrice,
+		Halted:        quote.IsHalted,
+	}
+
+	if sym.PrevClose > 0 {
+		stock.Change = quote.LastTradePrice - sym.PrevClose
+		stock.ChangePercent = stock.Change / sym.PrevClose * 100
+	}
+
+	return stock
+}
+
+// GetCandles returns candles for a symbol between start and end.
+// Interval uses Questrade naming, e.g. "OneMinute", "FiveMinutes", "OneDay".
+func (q *QuestradeClient) GetCandles(symbol, interval string, start, end time.Time) (*MarketData, error) {
+	sym, err := q.lookupSymbol(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Add("startTime", start.Format(time.RFC3339))
+	params.Add("endTime", end.Format(time.RFC3339))
+	params.Add("interval", interval)
+
+	var candlesResp QuestradeCandlesResponse
+	if err := q.get(fmt.Sprintf("v1/markets/candles/%d", sym.ID), params, &candlesResp); err != nil {
+		return nil, err
+	}
+
+	if len(candlesResp.Candles) == 0 {
+		return nil, fmt.Errorf("no candles found for symbol: %s", symbol)
+	}
+
+	data := &MarketData{
+		Symbol:     symbol,
+		Prices:     make([]float64, 0, len(candlesResp.Candles)),
+		Volumes:    make([]float64, 0, len(candlesResp.Candles)),
+		Timestamps: make([]time.Time, 0, len(candlesResp.Candles)),
+	}
+
+	for _, candle := range candlesResp.Candles {
+		ts, err := time.Parse(time.RFC3339, candle.End)
+		if err != nil {
+			return nil, fmt.Errorf("invalid candle timestamp %q: %w", candle.End, err)
+		}
+		data.Prices = append(data.Prices, candle.Close)
+		data.Volumes = append(data.Volumes, float64(candle.Volume))
+		data.Timestamps = append(data.Timestamps, ts)
+	}
+
+	return data, nil
+}
+
+// GetMarketData returns the last trading session of 5-minute candles for a symbol
+func (q *QuestradeClient) GetMarketData(symbol string) (*MarketData, error) {
+	end := time.Now()
+	start := end.Add(-24 * time.Hour)
+	data, err := q.GetCandles(symbol, "FiveMinutes", start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	if sym, err := q.lookupSymbol(symbol); err == nil {
+		data.Exchange = sym.Exchange
+		data.PreviousClose = sym.PrevClose
+	}
+	if quote, err := q.GetQuote(symbol); err == nil {
+		data.Halted = quote.Halted
+	}
+	return data, nil
+}
+
+// lookupSymbol resolves a ticker to its Questrade symbol ID, caching the result.
+// The previous close is refreshed once per day.
+func (q *QuestradeClient) lookupSymbol(symbol string) (questradeSymbol, error) {
+	today := time.Now().Format("2006-01-02")
+
+	q.mu.RLock()
+	cached, ok := q.symbols[symbol]
+	q.mu.RUnlock()
+	if ok && cached.PrevCloseAsOf == today {
+		return cached, nil
+	}
+
+	params := url.Values{}
+	params.Add("names", symbol)
+
+	var symbolsResp QuestradeSymbolsResponse
+	if err := q.get("v1/symbols", params, &symbolsResp); err != nil {
+		return questradeSymbol{}, err
+	}
+
+	for _, s := range symbolsResp.Symbols {
+		if strings.EqualFold(s.Symbol, symbol) {
+			sym := questradeSymbol{
+				ID:            s.SymbolID,
+				PrevClose:     s.PrevDayClosePrice,
+				PrevCloseAsOf: today,
+				Exchange:      s.ListingExchange,
+			}
+			q.mu.Lock()
+			q.symbols[symbol] = sym
+			q.mu.Unlock()
+			return sym, nil
+		}
+	}
+
+	return questradeSymbol{}, fmt.Errorf("symbol not found on Questrade: %s: %w", symbol, errkind.ErrNoData)
+}
+
+// get performs an authenticated GET request and decodes the JSON response into out
+func (q *QuestradeClient) get(endpoint string, params url.Values, out interface{}) error {
+	if len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+
+	req, err := q.oauth.GetAuthenticatedRequest("GET", endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := q.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", errkind.Unreachable(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to get data: %w", errkind.Status(resp.StatusCode, string(body)))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return nil
+}
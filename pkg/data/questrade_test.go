@@ -0,0 +1,95 @@
+package data
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestQuestradeClient(t *testing.T) *QuestradeClient {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+
+		switch r.URL.Path {
+		case "/v1/symbols":
+			switch r.URL.Query().Get("names") {
+			case "TD.TO":
+				w.Write([]byte(`{"symbols":[{"symbol":"TD.TO","symbolId":38526,"prevDayClosePrice":80.0}]}`))
+			default:
+				w.Write([]byte(`{"symbols":[{"symbol":"RY.TO","symbolId":34658,"prevDayClosePrice":130.0}]}`))
+			}
+		case "/v1/markets/quotes":
+			assert.ElementsMatch(t, []string{"34658", "38526"}, strings.Split(r.URL.Query().Get("ids"), ","))
+			w.Write([]byte(`{"quotes":[
+				{"symbol":"RY.TO","symbolId":34658,"lastTradePrice":132.0,"volume":1500000},
+				{"symbol":"TD.TO","symbolId":38526,"lastTradePrice":81.0,"volume":900000}
+			]}`))
+		case "/v1/markets/quotes/34658":
+			w.Write([]byte(`{"quotes":[{"symbol":"RY.TO","symbolId":34658,"bidPrice":131.9,"askPrice":132.1,"lastTradePrice":132.0,"volume":1500000,"highPrice":132.5,"lowPrice":129.8}]}`))
+		case "/v1/markets/candles/34658":
+			w.Write([]byte(`{"candles":[
+				{"start":"2025-04-21T09:30:00-04:00","end":"2025-04-21T09:35:00-04:00","close":130.5,"volume":1000},
+				{"start":"2025-04-21T09:35:00-04:00","end":"2025-04-21T09:40:00-04:00","close":131.0,"volume":2000}
+			]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	oauth := auth.NewOAuthManager("client", "refresh")
+	oauth.AccessToken = "test-token"
+	oauth.ApiServer = server.URL + "/"
+	oauth.ExpiresAt = time.Now().Add(time.Hour)
+
+	return NewQuestradeClient(oauth)
+}
+
+func TestQuestradeGetQuote(t *testing.T) {
+	client := newTestQuestradeClient(t)
+
+	stock, err := client.GetQuote("RY.TO")
+	assert.NoError(t, err)
+	assert.Equal(t, "RY.TO", stock.Symbol)
+	assert.Equal(t, 132.0, stock.CurrentPrice)
+	assert.Equal(t, 130.0, stock.PreviousClose)
+	assert.Equal(t, int64(1500000), stock.Volume)
+	assert.Equal(t, 131.9, stock.Bid)
+	assert.Equal(t, 132.1, stock.Ask)
+	assert.InDelta(t, 1.538, stock.ChangePercent, 0.001)
+}
+
+func TestQuestradeGetQuotes(t *testing.T) {
+	client := newTestQuestradeClient(t)
+
+	stocks, err := client.GetQuotes([]string{"RY.TO", "TD.TO", "UNKNOWN"})
+	assert.NoError(t, err)
+	assert.Len(t, stocks, 2)
+	assert.Equal(t, 132.0, stocks["RY.TO"].CurrentPrice)
+	assert.Equal(t, 81.0, stocks["TD.TO"].CurrentPrice)
+	assert.InDelta(t, 1.25, stocks["TD.TO"].ChangePercent, 0.001)
+}
+
+func TestQuestradeGetCandles(t *testing.T) {
+	client := newTestQuestradeClient(t)
+
+	data, err := client.GetCandles("RY.TO", "FiveMinutes", time.Now().Add(-time.Hour), time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{130.5, 131.0}, data.Prices)
+	assert.Equal(t, []float64{1000, 2000}, data.Volumes)
+	assert.Len(t, data.Timestamps, 2)
+	assert.True(t, data.Timestamps[1].After(data.Timestamps[0]))
+}
+
+func TestQuestradeUnknownSymbol(t *testing.T) {
+	client := newTestQuestradeClient(t)
+
+	_, err := client.GetQuote("UNKNOWN")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "symbol not found on Questrade")
+}
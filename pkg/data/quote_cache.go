@@ -0,0 +1,65 @@
+package data
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+)
+
+// QuoteCache shares fetched market data between instances (implemented by cache.Redis)
+type QuoteCache interface {
+	Get(key string) ([]byte, bool, error)
+	Set(key string, value []byte, ttl time.Duration) error
+}
+
+// SetQuoteCache makes GetMarketData serve data fetched within ttl from cache,
+// and store what it fetches there
+func (p *Provider) SetQuoteCache(cache QuoteCache, ttl time.Duration) {
+	p.cache = cache
+	p.cacheTTL = ttl
+}
+
+// quoteCacheKey returns the cache key for a symbol's market data
+func quoteCacheKey(symbol string) string {
+	return "quote:" + symbol
+}
+
+// cachedMarketData returns market data from the cache, if present. Cache errors
+// count as misses so a cache outage falls back to the providers.
+func (p *Provider) cachedMarketData(symbol string) (*MarketData, bool) {
+	if p.cache == nil {
+		return nil, false
+	}
+
+	encoded, ok, err := p.cache.Get(quoteCacheKey(symbol))
+	if err != nil {
+		log.Printf("Error reading cached market data for %s: %v", symbol, err)
+		return nil, false
+	}
+	if !ok {
+		return nil, false
+	}
+
+	var data MarketData
+	if err := json.Unmarshal(encoded, &data); err != nil {
+		log.Printf("Error decoding cached market data for %s: %v", symbol, err)
+		return nil, false
+	}
+	return &data, true
+}
+
+// cacheMarketData stores fetched market data in the cache
+func (p *Provider) cacheMarketData(data *MarketData) {
+	if p.cache == nil {
+		return
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		log.Printf("Error encoding market data for %s: %v", data.Symbol, err)
+		return
+	}
+	if err := p.cache.Set(quoteCacheKey(data.Symbol), encoded, p.cacheTTL); err != nil {
+		log.Printf("Error caching market data for %s: %v", data.Symbol, err)
+	}
+}
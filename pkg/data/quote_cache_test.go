@@ -0,0 +1,42 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryCache is a QuoteCache backed by a map
+type memoryCache map[string][]byte
+
+func (c memoryCache) Get(key string) ([]byte, bool, error) {
+	value, ok := c[key]
+	return value, ok, nil
+}
+
+func (c memoryCache) Set(key string, value []byte, ttl time.Duration) error {
+	c[key] = value
+	return nil
+}
+
+func TestGetMarketDataServesFromQuoteCache(t *testing.T) {
+	cfg := config.CreateDefaultConfig()
+	cfg.DataSource.Chain = []string{"questrade"}
+	provider := NewProvider(cfg)
+
+	cache := memoryCache{}
+	provider.SetQuoteCache(cache, time.Minute)
+
+	// Questrade is not configured, so only a cache hit can succeed
+	_, err := provider.GetMarketData("AAPL")
+	assert.Error(t, err)
+
+	provider.cacheMarketData(&MarketData{Symbol: "AAPL", Prices: []float64{101.5}})
+	data, err := provider.GetMarketData("AAPL")
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{101.5}, data.Prices)
+	// The hit did not touch the provider
+	assert.Equal(t, 1, provider.GetProviderHealth()[0].Failures)
+}
@@ -0,0 +1,224 @@
+package data
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// defaultHistorySize is the number of ticks kept per symbol
+	defaultHistorySize = 512
+	// defaultCandleInterval is the bar size candles are built with
+	defaultCandleInterval = time.Minute
+)
+
+// Tick is a single quote observed by the MarketWatcher
+type Tick struct {
+	Time   time.Time
+	Price  float64
+	Volume int64 // Cumulative session volume as reported by the source
+	Bid    float64
+	Ask    float64
+}
+
+// Candle is an OHLCV bar built from ticks
+type Candle struct {
+	Start  time.Time
+	Open   float64
+	High   float64
+	Low    float64
+	Close  float64
+	Volume float64 // Volume traded within the bar
+}
+
+// tickRing is a fixed-size ring buffer of ticks, oldest first
+type tickRing struct {
+	ticks []Tick
+	next  int
+	full  bool
+}
+
+// newTickRing creates a ring holding up to size ticks
+func newTickRing(size int) *tickRing {
+	return &tickRing{ticks: make([]Tick, size)}
+}
+
+// add appends a tick, overwriting the oldest when the ring is full
+func (r *tickRing) add(tick Tick) {
+	r.ticks[r.next] = tick
+	r.next = (r.next + 1) % len(r.ticks)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// len returns the number of ticks held
+func (r *tickRing) len() int {
+	if r.full {
+		return len(r.ticks)
+	}
+	return r.next
+}
+
+// last returns the newest tick
+func (r *tickRing) last() (Tick, bool) {
+	if r.len() == 0 {
+		return Tick{}, false
+	}
+	return r.ticks[(r.next-1+len(r.ticks))%len(r.ticks)], true
+}
+
+// snapshot returns a copy of the ticks, oldest first
+func (r *tickRing) snapshot() []Tick {
+	result := make([]Tick, 0, r.len())
+	if r.full {
+		result = append(result, r.ticks[r.next:]...)
+	}
+	return append(result, r.ticks[:r.next]...)
+}
+
+// buildCandles aggregates ticks into bars of interval. Bar volume is the change in
+// cumulative volume, so the first bar after a volume reset counts from zero.
+func buildCandles(ticks []Tick, interval time.Duration) []Candle {
+	var candles []Candle
+	var lastVolume int64
+	for i, tick := range ticks {
+		traded := tick.Volume - lastVolume
+		if i == 0 || traded < 0 {
+			traded = 0
+		}
+		lastVolume = tick.Volume
+
+		start := tick.Time.Truncate(interval)
+		if n := len(candles); n > 0 && candles[n-1].Start.Equal(start) {
+			c := &candles[n-1]
+			if tick.Price > c.High {
+				c.High = tick.Price
+			}
+			if tick.Price < c.Low {
+				c.Low = tick.Price
+			}
+			c.Close = tick.Price
+			c.Volume += float64(traded)
+			continue
+		}
+		candles = append(candles, Candle{
+			Start:  start,
+			Open:   tick.Price,
+			High:   tick.Price,
+			Low:    tick.Price,
+			Close:  tick.Price,
+			Volume: float64(traded),
+		})
+	}
+	return candles
+}
+
+// SetHistorySize sets the number of ticks kept per symbol. Existing history is discarded.
+func (m *MarketWatcher) SetHistorySize(size int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.historySize = size
+	m.history = make(map[string]*tickRing)
+}
+
+// SetCandleInterval sets the bar size used by GetCandles and GetMarketData
+func (m *MarketWatcher) SetCandleInterval(interval time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.candleInterval = interval
+}
+
+// GetTicks returns the recent ticks recorded for a symbol, oldest first
+func (m *MarketWatcher) GetTicks(symbol string) []Tick {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ring, exists := m.history[symbol]
+	if !exists {
+		return nil
+	}
+	return ring.snapshot()
+}
+
+// GetCandles returns the recent ticks for a symbol aggregated into candles
+func (m *MarketWatcher) GetCandles(symbol string) []Candle {
+	m.mu.RLock()
+	interval := m.candleInterval
+	m.mu.RUnlock()
+
+	return buildCandles(m.GetTicks(symbol), interval)
+}
+
+// GetMarketData returns a symbol's candles as market data, so indicators and
+// signal generation can run off the watcher without a Provider fetch
+func (m *MarketWatcher) GetMarketData(symbol string) (*MarketData, error) {
+	candles := m.GetCandles(symbol)
+	if len(candles) == 0 {
+		return nil, fmt.Errorf("no price history for %s", symbol)
+	}
+
+	md := &MarketData{
+		Symbol:     symbol,
+		Prices:     make([]float64, len(candles)),
+		Volumes:    make([]float64, len(candles)),
+		Timestamps: make([]time.Time, len(candles)),
+	}
+	for i, c := range candles {
+		md.Prices[i] = c.Close
+		md.Volumes[i] = c.Volume
+		md.Timestamps[i] = c.Start
+	}
+	return md, nil
+}
+
+// GetMarketDataBatch returns market data for many symbols from the watcher's
+// history. Symbols without history are reported in the error map.
+func (m *MarketWatcher) GetMarketDataBatch(symbols []string) (map[string]*MarketData, map[string]error) {
+	results := make(map[string]*MarketData, len(symbols))
+	errs := make(map[string]error)
+	for _, symbol := range symbols {
+		md, err := m.GetMarketData(symbol)
+		if err != nil {
+			errs[symbol] = err
+			continue
+		}
+		results[symbol] = md
+	}
+	return results, errs
+}
+
+// recordTicks appends the current quote of each symbol to its history. Quotes
+// that have not been updated since the last tick are skipped.
+func (m *MarketWatcher) recordTicks(symbols []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.historySize <= 0 {
+		return
+	}
+	for _, symbol := range symbols {
+		stock, exists := m.stocks[symbol]
+		if !exists || stock.CurrentPrice == 0 || stock.LastUpdated.IsZero() {
+			continue
+		}
+
+		ring, ok := m.history[symbol]
+		if !ok {
+			ring = newTickRing(m.historySize)
+			m.history[symbol] = ring
+		}
+		if last, ok := ring.last(); ok && !stock.LastUpdated.After(last.Time) {
+			continue
+		}
+		ring.add(Tick{
+			Time:   stock.LastUpdated,
+			Price:  stock.CurrentPrice,
+			Volume: stock.Volume,
+			Bid:    stock.Bid,
+			Ask:    stock.Ask,
+		})
+	}
+}
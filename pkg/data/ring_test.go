@@ -0,0 +1,68 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// scriptedTicks returns quotes from a fixed list, one per call
+type scriptedTicks struct {
+	quotes []Stock
+}
+
+func (s *scriptedTicks) Quotes(symbols []string) (map[string]*Stock, error) {
+	quote := s.quotes[0]
+	s.quotes = s.quotes[1:]
+	return map[string]*Stock{quote.Symbol: &quote}, nil
+}
+
+func TestTickRingWrapsOldestFirst(t *testing.T) {
+	ring := newTickRing(3)
+	for i := 1; i <= 5; i++ {
+		ring.add(Tick{Price: float64(i)})
+	}
+
+	ticks := ring.snapshot()
+	assert.Len(t, ticks, 3)
+	assert.Equal(t, []float64{3, 4, 5}, []float64{ticks[0].Price, ticks[1].Price, ticks[2].Price})
+	last, _ := ring.last()
+	assert.Equal(t, 5.0, last.Price)
+}
+
+func TestMarketWatcherBuildsCandlesFromTicks(t *testing.T) {
+	start := time.Date(2025, 4, 21, 13, 30, 0, 0, time.UTC)
+	quote := func(offset time.Duration, price float64, volume int64) Stock {
+		return Stock{Symbol: "AAPL", CurrentPrice: price, Volume: volume, LastUpdated: start.Add(offset)}
+	}
+	source := &scriptedTicks{quotes: []Stock{
+		quote(0, 100, 1000),
+		quote(20*time.Second, 102, 1500),
+		quote(20*time.Second, 102, 1500), // Unchanged quote is not recorded again
+		quote(40*time.Second, 99, 1800),
+		quote(70*time.Second, 101, 2500),
+	}}
+
+	watcher := NewMarketWatcher(nil, "synthetic", 60)
+	watcher.SetTickSource(source)
+	watcher.AddStock("AAPL")
+	for i := 0; i < 5; i++ {
+		watcher.UpdateNow()
+	}
+
+	assert.Len(t, watcher.GetTicks("AAPL"), 4)
+
+	candles := watcher.GetCandles("AAPL")
+	assert.Len(t, candles, 2)
+	assert.Equal(t, Candle{Start: start, Open: 100, High: 102, Low: 99, Close: 99, Volume: 800}, candles[0])
+	assert.Equal(t, Candle{Start: start.Add(time.Minute), Open: 101, High: 101, Low: 101, Close: 101, Volume: 700}, candles[1])
+
+	md, err := watcher.GetMarketData("AAPL")
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{99, 101}, md.Prices)
+
+	_, errs := watcher.GetMarketDataBatch([]string{"AAPL", "MSFT"})
+	assert.Len(t, errs, 1)
+	assert.Contains(t, errs, "MSFT")
+}
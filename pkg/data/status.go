@@ -0,0 +1,74 @@
+package data
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/market"
+)
+
+// shortSaleTriggerPercent is the drop from the previous close that triggers
+// the short-sale restriction under SEC Rule 201
+const shortSaleTriggerPercent = 10.0
+
+// ShortSaleTriggered reports whether the session's prices fell at least 10%
+// below the previous close
+func ShortSaleTriggered(previousClose float64, prices []float64) bool {
+	if previousClose <= 0 {
+		return false
+	}
+	trigger := previousClose * (1 - shortSaleTriggerPercent/100)
+	for _, price := range prices {
+		if price > 0 && price <= trigger {
+			return true
+		}
+	}
+	return false
+}
+
+// ShortSaleTracker remembers when the short-sale restriction was triggered
+// for each symbol. Once triggered it applies for the rest of that trading day
+// and all of the next.
+type ShortSaleTracker struct {
+	session   *market.Clock
+	triggered map[string]time.Time // Symbol -> trading day the restriction was triggered
+	mu        sync.Mutex
+}
+
+// NewShortSaleTracker creates a new ShortSaleTracker using the exchange session
+func NewShortSaleTracker(session *market.Clock) *ShortSaleTracker {
+	return &ShortSaleTracker{
+		session:   session,
+		triggered: make(map[string]time.Time),
+	}
+}
+
+// Observe records a trigger in data and sets data.ShortRestricted. The data's
+// newest timestamp is taken as the current time, so replayed data is judged
+// as of its own session.
+func (t *ShortSaleTracker) Observe(data *MarketData) {
+	now := time.Now()
+	if len(data.Timestamps) > 0 {
+		now = data.Timestamps[len(data.Timestamps)-1]
+	}
+	today := t.session.TradingDay(now)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if ShortSaleTriggered(data.PreviousClose, data.Prices) {
+		t.triggered[data.Symbol] = today
+	}
+
+	day, ok := t.triggered[data.Symbol]
+	data.ShortRestricted = ok && (day.Equal(today) || day.Equal(t.previousTradingDay(today)))
+}
+
+// previousTradingDay returns the trading day before day
+func (t *ShortSaleTracker) previousTradingDay(day time.Time) time.Time {
+	previous := day.AddDate(0, 0, -1)
+	for !t.session.IsTradingDay(previous) {
+		previous = previous.AddDate(0, 0, -1)
+	}
+	return previous
+}
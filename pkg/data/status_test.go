@@ -0,0 +1,35 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/market"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShortSaleTracker(t *testing.T) {
+	tracker := NewShortSaleTracker(market.DefaultClock())
+	at := func(day int) []time.Time {
+		return []time.Time{time.Date(2025, 3, day, 15, 0, 0, 0, time.UTC)}
+	}
+
+	// Thursday: a 12% drop triggers the restriction
+	thursday := &MarketData{Symbol: "XYZ", PreviousClose: 100, Prices: []float64{95, 88, 91}, Timestamps: at(6)}
+	tracker.Observe(thursday)
+	assert.True(t, thursday.ShortRestricted)
+
+	// Friday: still restricted after recovering
+	friday := &MarketData{Symbol: "XYZ", PreviousClose: 91, Prices: []float64{92}, Timestamps: at(7)}
+	tracker.Observe(friday)
+	assert.True(t, friday.ShortRestricted)
+
+	// Monday: lifted
+	monday := &MarketData{Symbol: "XYZ", PreviousClose: 92, Prices: []float64{93}, Timestamps: at(10)}
+	tracker.Observe(monday)
+	assert.False(t, monday.ShortRestricted)
+
+	// A 9% drop, or an unknown previous close, doesn't trigger it
+	assert.False(t, ShortSaleTriggered(100, []float64{91}))
+	assert.False(t, ShortSaleTriggered(0, []float64{1}))
+}
@@ -0,0 +1,155 @@
+package data
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// Synthetic market scenarios
+const (
+	ScenarioTrend         = "trend"          // Steady drift with noise
+	ScenarioMeanReversion = "mean_reversion" // Noise pulled back toward the base price
+	ScenarioGap           = "gap"            // Random walk with a gap and volume surge halfway
+	ScenarioBreakout      = "breakout"       // Range that tightens, then breaks by GapPercent and follows through
+)
+
+// SyntheticSpec describes a synthetic price series. The same spec always
+// produces the same series.
+type SyntheticSpec struct {
+	Symbol     string
+	Scenario   string
+	Start      time.Time     // Time of the first bar
+	Bars       int           // Number of bars
+	Interval   time.Duration // Time between bars
+	BasePrice  float64
+	BaseVolume float64
+	Drift      float64 // Trend: fractional move per bar, e.g. 0.001
+	Volatility float64 // Standard deviation of the per-bar noise as a fraction of price
+	Reversion  float64 // Mean reversion: fraction of the distance to the base price closed per bar
+	GapPercent float64 // Gap: size of the gap in percent, negative for a gap down
+	Seed       int64   // 0 derives the seed from the symbol and scenario
+}
+
+// withDefaults fills unset fields with values suited to intraday 5-minute bars
+func (s SyntheticSpec) withDefaults() SyntheticSpec {
+	if s.Bars <= 0 {
+		s.Bars = 78 // One regular session of 5-minute bars
+	}
+	if s.Interval <= 0 {
+		s.Interval = 5 * time.Minute
+	}
+	if s.BasePrice <= 0 {
+		s.BasePrice = 100
+	}
+	if s.BaseVolume <= 0 {
+		s.BaseVolume = 1000000
+	}
+	if s.Volatility <= 0 {
+		s.Volatility = 0.002
+	}
+	if s.Reversion <= 0 {
+		s.Reversion = 0.2
+	}
+	if s.Seed == 0 {
+		h := fnv.New64a()
+		h.Write([]byte(s.Symbol + "|" + s.Scenario))
+		s.Seed = int64(h.Sum64() >> 1)
+	}
+	return s
+}
+
+// GenerateSynthetic generates a deterministic price and volume series for a scenario
+func GenerateSynthetic(spec SyntheticSpec) (*MarketData, error) {
+	spec = spec.withDefaults()
+	rng := rand.New(rand.NewSource(spec.Seed))
+
+	md := &MarketData{
+		Symbol:     spec.Symbol,
+		Prices:     make([]float64, spec.Bars),
+		Volumes:    make([]float64, spec.Bars),
+		Timestamps: make([]time.Time, spec.Bars),
+	}
+
+	gapAt := spec.Bars / 2
+	breakAt := spec.Bars * 2 / 3
+	price := spec.BasePrice
+	for i := 0; i < spec.Bars; i++ {
+		noise := rng.NormFloat64() * spec.Volatility
+		volume := spec.BaseVolume * (0.8 + 0.4*rng.Float64())
+
+		if i > 0 {
+			switch spec.Scenario {
+			case ScenarioTrend:
+				price *= 1 + spec.Drift + noise
+			case ScenarioMeanReversion:
+				price += spec.Reversion*(spec.BasePrice-price) + price*noise
+			case ScenarioGap:
+				price *= 1 + noise
+				if i == gapAt {
+					price *= 1 + spec.GapPercent/100
+					volume *= 3
+				}
+			case ScenarioBreakout:
+				switch {
+				case i < breakAt/2:
+					price += spec.Reversion*(spec.BasePrice-price) + price*noise
+				case i < breakAt:
+					// The range contracts into a squeeze before the break
+					price += spec.Reversion*(spec.BasePrice-price) + price*noise*0.1
+				case i == breakAt:
+					price *= 1 + spec.GapPercent/100
+					volume *= 3
+				default:
+					price *= 1 + math.Copysign(spec.Volatility, spec.GapPercent) + noise*0.5
+					volume *= 1.5
+				}
+			default:
+				return nil, fmt.Errorf("unknown scenario: %s", spec.Scenario)
+			}
+		}
+
+		md.Prices[i] = math.Round(math.Max(price, 0.01)*100) / 100
+		md.Volumes[i] = math.Round(volume)
+		md.Timestamps[i] = spec.Start.Add(time.Duration(i) * spec.Interval)
+	}
+
+	return md, nil
+}
+
+// syntheticFixtures are the named scenarios shared by tests and backtests
+var syntheticFixtures = map[string]SyntheticSpec{
+	"trend_up":       {Scenario: ScenarioTrend, Drift: 0.001},
+	"trend_down":     {Scenario: ScenarioTrend, Drift: -0.001},
+	"mean_reversion": {Scenario: ScenarioMeanReversion, Volatility: 0.004},
+	"gap_up":         {Scenario: ScenarioGap, GapPercent: 3},
+	"gap_down":       {Scenario: ScenarioGap, GapPercent: -3},
+	"breakout":       {Scenario: ScenarioBreakout, GapPercent: 2, Volatility: 0.003},
+	"crash":          {Scenario: ScenarioBreakout, GapPercent: -5, Volatility: 0.003},
+	"choppy":         {Scenario: ScenarioMeanReversion, Volatility: 0.004, Reversion: 0.5},
+}
+
+// FixtureNames returns the names of the synthetic fixtures
+func FixtureNames() []string {
+	names := make([]string, 0, len(syntheticFixtures))
+	for name := range syntheticFixtures {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Fixture generates a named synthetic scenario for symbol, one session of
+// 5-minute bars starting at start
+func Fixture(name, symbol string, start time.Time) (*MarketData, error) {
+	spec, ok := syntheticFixtures[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown fixture: %s", name)
+	}
+	spec.Symbol = symbol
+	spec.Start = start
+	return GenerateSynthetic(spec)
+}
@@ -0,0 +1,52 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateSyntheticIsDeterministic(t *testing.T) {
+	start := time.Date(2025, 4, 21, 13, 30, 0, 0, time.UTC)
+	spec := SyntheticSpec{Symbol: "AAPL", Scenario: ScenarioTrend, Start: start, Drift: 0.001}
+
+	first, err := GenerateSynthetic(spec)
+	assert.NoError(t, err)
+	second, err := GenerateSynthetic(spec)
+	assert.NoError(t, err)
+	assert.Equal(t, first, second)
+
+	assert.Len(t, first.Prices, 78)
+	assert.Equal(t, start, first.Timestamps[0])
+	assert.Equal(t, start.Add(77*5*time.Minute), first.Timestamps[77])
+	assert.Greater(t, first.Prices[77], first.Prices[0])
+
+	// A different symbol gets a different seed
+	other, err := GenerateSynthetic(SyntheticSpec{Symbol: "MSFT", Scenario: ScenarioTrend, Start: start, Drift: 0.001})
+	assert.NoError(t, err)
+	assert.NotEqual(t, first.Prices, other.Prices)
+
+	_, err = GenerateSynthetic(SyntheticSpec{Symbol: "AAPL", Scenario: "sideways"})
+	assert.Error(t, err)
+}
+
+func TestFixtures(t *testing.T) {
+	start := time.Date(2025, 4, 21, 13, 30, 0, 0, time.UTC)
+	assert.Contains(t, FixtureNames(), "gap_up")
+
+	gap, err := Fixture("gap_up", "TSLA", start)
+	assert.NoError(t, err)
+	mid := len(gap.Prices) / 2
+	assert.Greater(t, gap.Prices[mid]/gap.Prices[mid-1], 1.02)
+	assert.Greater(t, gap.Volumes[mid], 2*gap.Volumes[mid-1])
+
+	reverting, err := Fixture("mean_reversion", "SPY", start)
+	assert.NoError(t, err)
+	for _, price := range reverting.Prices {
+		assert.InDelta(t, 100, price, 5)
+	}
+
+	_, err = Fixture("moonshot", "TSLA", start)
+	assert.Error(t, err)
+}
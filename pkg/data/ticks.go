@@ -0,0 +1,92 @@
+package data
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// TickSource provides quotes for the "synthetic" data source (implemented by SyntheticTicks)
+type TickSource interface {
+	Quotes(symbols []string) (map[string]*Stock, error)
+}
+
+// SyntheticTicks generates random-walk quotes for any number of symbols, for
+// load testing without a market data provider
+type SyntheticTicks struct {
+	rng    *rand.Rand
+	prices map[string]float64
+	volume map[string]int64
+	mu     sync.Mutex
+}
+
+// NewSyntheticTicks creates a SyntheticTicks source with a fixed seed
+func NewSyntheticTicks(seed int64) *SyntheticTicks {
+	return &SyntheticTicks{
+		rng:    rand.New(rand.NewSource(seed)),
+		prices: make(map[string]float64),
+		volume: make(map[string]int64),
+	}
+}
+
+// Quotes moves each symbol one tick and returns the new quotes
+func (s *SyntheticTicks) Quotes(symbols []string) (map[string]*Stock, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	quotes := make(map[string]*Stock, len(symbols))
+	for _, symbol := range symbols {
+		price, ok := s.prices[symbol]
+		if !ok {
+			price = 20 + s.rng.Float64()*180
+		}
+		previous := price
+		price = math.Max(price*(1+s.rng.NormFloat64()*0.001), 0.01)
+		s.prices[symbol] = price
+		s.volume[symbol] += 100 + s.rng.Int63n(10000)
+
+		spread := price * 0.0005
+		quotes[symbol] = &Stock{
+			Symbol:        symbol,
+			CurrentPrice:  price,
+			PreviousClose: previous,
+			Volume:        s.volume[symbol],
+			LastUpdated:   now,
+			DailyHigh:     math.Max(price, previous),
+			DailyLow:      math.Min(price, previous),
+			Bid:           price - spread,
+			Ask:           price + spread,
+			Change:        price - previous,
+			ChangePercent: (price - previous) / previous * 100,
+		}
+	}
+	return quotes, nil
+}
+
+// SetTickSource sets the quote source for the "synthetic" data source
+func (m *MarketWatcher) SetTickSource(source TickSource) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.ticks = source
+}
+
+// UpdateNow runs one update cycle immediately instead of waiting for the poll interval
+func (m *MarketWatcher) UpdateNow() {
+	m.updateAllStocks()
+}
+
+// fetchSyntheticQuotes fetches quotes from the configured tick source
+func (m *MarketWatcher) fetchSyntheticQuotes(symbols []string) (map[string]*Stock, error) {
+	m.mu.RLock()
+	source := m.ticks
+	m.mu.RUnlock()
+
+	if source == nil {
+		return nil, fmt.Errorf("synthetic tick source not configured")
+	}
+	return source.Quotes(symbols)
+}
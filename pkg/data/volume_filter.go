@@ -0,0 +1,28 @@
+package data
+
+import (
+	"math"
+
+	"github.com/hustler/trading-bot/pkg/config"
+)
+
+// FilterVolumes reweights the off-exchange and odd-lot prints in the volume of
+// each bar, so relative volume and volume surges reflect lit-market interest.
+// Bars without a breakdown are left as they are. Sources may count odd lots
+// printed off-exchange in both breakdowns, so a bar is floored at 0.
+func FilterVolumes(md *MarketData, filter config.VolumeFilterConfig) {
+	for i := range md.Volumes {
+		offExchange := breakdownAt(md.OffExchangeVolumes, i)
+		oddLot := breakdownAt(md.OddLotVolumes, i)
+		discounted := offExchange*(1-filter.OffExchangeWeight) + oddLot*(1-filter.OddLotWeight)
+		md.Volumes[i] = math.Max(md.Volumes[i]-discounted, 0)
+	}
+}
+
+// breakdownAt returns the volume of bar i in a breakdown, 0 if not reported
+func breakdownAt(volumes []float64, i int) float64 {
+	if i >= len(volumes) || volumes[i] < 0 {
+		return 0
+	}
+	return volumes[i]
+}
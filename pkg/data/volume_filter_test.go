@@ -0,0 +1,27 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterVolumes(t *testing.T) {
+	md := &MarketData{
+		Volumes:            []float64{1000, 1000, 1000, 1000},
+		OffExchangeVolumes: []float64{400, 0, 900},
+		OddLotVolumes:      []float64{100, 200, 600},
+	}
+	FilterVolumes(md, config.VolumeFilterConfig{Enabled: true, OffExchangeWeight: 0, OddLotWeight: 0.5})
+
+	// Off-exchange prints are dropped and odd lots halved; the last bar has no breakdown
+	assert.Equal(t, []float64{550, 900, 0, 1000}, md.Volumes)
+}
+
+func TestFilterVolumesWithoutBreakdown(t *testing.T) {
+	md := &MarketData{Volumes: []float64{1000, 2000}}
+	FilterVolumes(md, config.VolumeFilterConfig{Enabled: true})
+
+	assert.Equal(t, []float64{1000, 2000}, md.Volumes)
+}
@@ -0,0 +1,148 @@
+package desktop
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/monitor"
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// htmlTag matches the HTML formatting of Telegram messages
+var htmlTag = regexp.MustCompile(`</?[a-zA-Z][^>]*>`)
+
+// Notifier pushes signals, their changes and outcomes, and bot messages to a
+// desktop notification service. With a next notifier, e.g. Telegram, it
+// forwards everything and push failures are only logged; without one the
+// service is the only delivery channel and its failures are returned.
+type Notifier struct {
+	next    monitor.Notifier
+	service Service
+	filter  config.SignalFilter
+}
+
+// NewNotifier creates a Notifier pushing to service in front of next, which
+// may be nil
+func NewNotifier(next monitor.Notifier, service Service) *Notifier {
+	return &Notifier{next: next, service: service}
+}
+
+// SetFilter limits the signals pushed. Changes and outcomes of filtered
+// signals are not pushed either.
+func (n *Notifier) SetFilter(filter config.SignalFilter) {
+	n.filter = filter
+}
+
+// SendSignal pushes a new signal
+func (n *Notifier) SendSignal(s *signal.Signal) error {
+	if !n.allows(s) {
+		return n.forward(nil, func(next monitor.Notifier) error { return next.SendSignal(s) })
+	}
+	message := fmt.Sprintf("Entry $%.2f · Target $%.2f · Stop $%.2f\n%.0f%% confidence, expected ROI %.2f%%",
+		s.Price, s.TargetPrice, s.StopLoss, s.Confidence*100, s.ExpectedROI)
+	if s.Rationale != "" {
+		message += "\n" + s.Rationale
+	}
+	err := n.service.Push(Notification{
+		Title:   signalTitle(s, ""),
+		Message: message,
+		Urgent:  !s.Test,
+		Tags:    []string{signalTag(s)},
+	})
+	return n.forward(err, func(next monitor.Notifier) error { return next.SendSignal(s) })
+}
+
+// UpdateSignal pushes a change to an active signal's levels
+func (n *Notifier) UpdateSignal(s *signal.Signal) error {
+	if !n.allows(s) {
+		return n.forward(nil, func(next monitor.Notifier) error { return next.UpdateSignal(s) })
+	}
+	err := n.service.Push(Notification{
+		Title:   signalTitle(s, "updated"),
+		Message: fmt.Sprintf("Target $%.2f · Stop $%.2f", s.TargetPrice, s.StopLoss),
+		Tags:    []string{"pencil2"},
+	})
+	return n.forward(err, func(next monitor.Notifier) error { return next.UpdateSignal(s) })
+}
+
+// SendSignalOutcome pushes how a signal ended
+func (n *Notifier) SendSignalOutcome(s *signal.Signal, exitPrice float64) error {
+	if !n.allows(s) {
+		return n.forward(nil, func(next monitor.Notifier) error { return next.SendSignalOutcome(s, exitPrice) })
+	}
+	roi := 0.0
+	if s.Price > 0 {
+		roi = (exitPrice - s.Price) / s.Price * 100
+		if s.Type == signal.SELL {
+			roi = -roi
+		}
+	}
+	err := n.service.Push(Notification{
+		Title:   signalTitle(s, strings.ToLower(string(s.Status))),
+		Message: fmt.Sprintf("Exit $%.2f (entry $%.2f), %+.2f%%", exitPrice, s.Price, roi),
+		Tags:    []string{outcomeTag(roi)},
+	})
+	return n.forward(err, func(next monitor.Notifier) error { return next.SendSignalOutcome(s, exitPrice) })
+}
+
+// SendMessage pushes a bot message, such as a report or a risk alert, as plain text
+func (n *Notifier) SendMessage(message string) error {
+	err := n.service.Push(Notification{
+		Title:   "Hustler",
+		Message: html.UnescapeString(htmlTag.ReplaceAllString(message, "")),
+	})
+	return n.forward(err, func(next monitor.Notifier) error { return next.SendMessage(message) })
+}
+
+// allows reports whether the filter lets a signal through
+func (n *Notifier) allows(s *signal.Signal) bool {
+	return n.filter.Allows(s.Confidence, s.ExpectedROI, string(s.Type))
+}
+
+// forward hands the notification to the next notifier. Push failures are
+// returned only when there is no next notifier to deliver it.
+func (n *Notifier) forward(pushErr error, send func(monitor.Notifier) error) error {
+	if n.next == nil {
+		return pushErr
+	}
+	if pushErr != nil {
+		log.Printf("Error pushing desktop notification: %v", pushErr)
+	}
+	return send(n.next)
+}
+
+// signalTitle titles a notification about a signal, e.g. "BUY AAPL updated"
+func signalTitle(s *signal.Signal, event string) string {
+	title := fmt.Sprintf("%s %s", s.Type, s.Symbol)
+	if s.Test {
+		title = "TEST " + title
+	}
+	if event != "" {
+		title += " " + event
+	}
+	return title
+}
+
+// signalTag picks the ntfy emoji for a signal
+func signalTag(s *signal.Signal) string {
+	switch {
+	case s.Test:
+		return "test_tube"
+	case s.Type == signal.SELL:
+		return "chart_with_downwards_trend"
+	default:
+		return "chart_with_upwards_trend"
+	}
+}
+
+// outcomeTag picks the ntfy emoji for an outcome
+func outcomeTag(roi float64) string {
+	if roi > 0 {
+		return "white_check_mark"
+	}
+	return "x"
+}
@@ -0,0 +1,120 @@
+package desktop
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/signal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServicesPush(t *testing.T) {
+	var got *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	ntfy, err := NewService(config.DesktopConfig{Service: "ntfy", URL: server.URL, Topic: "hustler-signals", Token: "tk"})
+	assert.NoError(t, err)
+	assert.NoError(t, ntfy.Push(Notification{Title: "BUY AAPL", Message: "Entry $190.00", Urgent: true, Tags: []string{"chart"}}))
+	assert.Equal(t, "/hustler-signals", got.URL.Path)
+	assert.Equal(t, "BUY AAPL", got.Header.Get("Title"))
+	assert.Equal(t, "high", got.Header.Get("Priority"))
+	assert.Equal(t, "Bearer tk", got.Header.Get("Authorization"))
+	assert.Equal(t, "Entry $190.00", string(body))
+
+	gotify, err := NewService(config.DesktopConfig{Service: "gotify", URL: server.URL, Token: "app"})
+	assert.NoError(t, err)
+	assert.NoError(t, gotify.Push(Notification{Title: "Hustler", Message: "Daily report"}))
+	assert.Equal(t, "/message", got.URL.Path)
+	assert.Equal(t, "app", got.Header.Get("X-Gotify-Key"))
+	var message map[string]interface{}
+	assert.NoError(t, json.Unmarshal(body, &message))
+	assert.Equal(t, float64(gotifyNormal), message["priority"])
+
+	pushover, err := NewService(config.DesktopConfig{Service: "pushover", URL: server.URL, Token: "app", UserKey: "user"})
+	assert.NoError(t, err)
+	assert.NoError(t, pushover.Push(Notification{Title: "BUY AAPL", Message: "m", Urgent: true}))
+	assert.Equal(t, "/1/messages.json", got.URL.Path)
+	form, err := url.ParseQuery(string(body))
+	assert.NoError(t, err)
+	assert.Equal(t, "user", form.Get("user"))
+	assert.Equal(t, "1", form.Get("priority"))
+
+	_, err = NewService(config.DesktopConfig{Service: "pager"})
+	assert.Error(t, err)
+}
+
+// fakeService records pushed notifications
+type fakeService struct {
+	pushed []Notification
+	err    error
+}
+
+func (f *fakeService) Push(n Notification) error {
+	f.pushed = append(f.pushed, n)
+	return f.err
+}
+
+// fakeNotifier records forwarded messages
+type fakeNotifier struct {
+	messages []string
+}
+
+func (f *fakeNotifier) SendSignal(s *signal.Signal) error   { return nil }
+func (f *fakeNotifier) UpdateSignal(s *signal.Signal) error { return nil }
+func (f *fakeNotifier) SendSignalOutcome(s *signal.Signal, exitPrice float64) error {
+	return nil
+}
+func (f *fakeNotifier) SendMessage(message string) error {
+	f.messages = append(f.messages, message)
+	return nil
+}
+
+func TestNotifierWithAndWithoutTelegram(t *testing.T) {
+	service := &fakeService{err: errors.New("offline")}
+
+	// Alone, push failures are delivery failures
+	alone := NewNotifier(nil, service)
+	s := &signal.Signal{Symbol: "AAPL", Type: signal.SELL, Price: 100, TargetPrice: 95, StopLoss: 102, Confidence: 0.8, Status: "SUCCESS"}
+	assert.Error(t, alone.SendSignal(s))
+	assert.Equal(t, "SELL AAPL", service.pushed[0].Title)
+	assert.True(t, service.pushed[0].Urgent)
+
+	assert.Error(t, alone.SendSignalOutcome(s, 95))
+	assert.Equal(t, "SELL AAPL success", service.pushed[1].Title)
+	assert.Equal(t, "Exit $95.00 (entry $100.00), +5.00%", service.pushed[1].Message)
+
+	// Alongside Telegram, the message is still forwarded
+	telegram := &fakeNotifier{}
+	withTelegram := NewNotifier(telegram, service)
+	assert.NoError(t, withTelegram.SendMessage("<b>Daily report</b> &amp; summary"))
+	assert.Equal(t, "Daily report & summary", service.pushed[2].Message)
+	assert.Equal(t, []string{"<b>Daily report</b> &amp; summary"}, telegram.messages)
+}
+
+func TestNotifierFilter(t *testing.T) {
+	service := &fakeService{}
+	n := NewNotifier(&fakeNotifier{}, service)
+	n.SetFilter(config.SignalFilter{MinConfidence: 0.8, SignalTypes: []string{"BUY"}})
+
+	sell := &signal.Signal{Symbol: "AAPL", Type: signal.SELL, Confidence: 0.9, Status: "SUCCESS"}
+	assert.NoError(t, n.SendSignal(sell))
+	assert.NoError(t, n.SendSignalOutcome(sell, 95))
+	weak := &signal.Signal{Symbol: "AAPL", Type: signal.BUY, Confidence: 0.7}
+	assert.NoError(t, n.SendSignal(weak))
+	assert.Empty(t, service.pushed)
+
+	strong := &signal.Signal{Symbol: "AAPL", Type: signal.BUY, Confidence: 0.85}
+	assert.NoError(t, n.SendSignal(strong))
+	assert.Len(t, service.pushed, 1)
+}
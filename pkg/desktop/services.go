@@ -0,0 +1,157 @@
+package desktop
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/errkind"
+)
+
+// Default servers of the hosted services
+const (
+	defaultNtfyURL     = "https://ntfy.sh"
+	defaultPushoverURL = "https://api.pushover.net"
+)
+
+// Notification is a message pushed to a notification service
+type Notification struct {
+	Title   string
+	Message string
+	Urgent  bool     // Shown prominently, e.g. new signals; others arrive quietly
+	Tags    []string // Emoji shortcodes on ntfy; ignored elsewhere
+}
+
+// Service pushes notifications to a desktop notification service
+type Service interface {
+	Push(n Notification) error
+}
+
+// NewService creates the service selected by cfg
+func NewService(cfg config.DesktopConfig) (Service, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	switch cfg.Service {
+	case "ntfy":
+		return &ntfy{baseURL: baseURL(cfg.URL, defaultNtfyURL), topic: cfg.Topic, token: cfg.Token, client: client}, nil
+	case "pushover":
+		return &pushover{baseURL: baseURL(cfg.URL, defaultPushoverURL), token: cfg.Token, user: cfg.UserKey, client: client}, nil
+	case "gotify":
+		return &gotify{baseURL: baseURL(cfg.URL, ""), token: cfg.Token, client: client}, nil
+	}
+	return nil, fmt.Errorf("unknown desktop notification service: %s", cfg.Service)
+}
+
+// baseURL returns the configured server URL, or fallback if none is set
+func baseURL(configured, fallback string) string {
+	if configured == "" {
+		configured = fallback
+	}
+	return strings.TrimRight(configured, "/")
+}
+
+// ntfy publishes to a topic on an ntfy server (https://ntfy.sh)
+type ntfy struct {
+	baseURL string
+	topic   string
+	token   string
+	client  *http.Client
+}
+
+// Push implements Service
+func (s *ntfy) Push(n Notification) error {
+	req, err := http.NewRequest(http.MethodPost, s.baseURL+"/"+url.PathEscape(s.topic), strings.NewReader(n.Message))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Title", n.Title)
+	if n.Urgent {
+		req.Header.Set("Priority", "high")
+	}
+	if len(n.Tags) > 0 {
+		req.Header.Set("Tags", strings.Join(n.Tags, ","))
+	}
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+	return send(s.client, req, "ntfy")
+}
+
+// pushover sends through the Pushover message API
+type pushover struct {
+	baseURL string
+	token   string
+	user    string
+	client  *http.Client
+}
+
+// Push implements Service
+func (s *pushover) Push(n Notification) error {
+	form := url.Values{
+		"token":   {s.token},
+		"user":    {s.user},
+		"title":   {n.Title},
+		"message": {n.Message},
+	}
+	if n.Urgent {
+		form.Set("priority", "1")
+	}
+	req, err := http.NewRequest(http.MethodPost, s.baseURL+"/1/messages.json", strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return send(s.client, req, "Pushover")
+}
+
+// gotify sends to a self-hosted Gotify server
+type gotify struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+// Gotify priorities; clients alert with sound from 8 up
+const (
+	gotifyNormal = 5
+	gotifyUrgent = 8
+)
+
+// Push implements Service
+func (s *gotify) Push(n Notification) error {
+	priority := gotifyNormal
+	if n.Urgent {
+		priority = gotifyUrgent
+	}
+	body, err := json.Marshal(map[string]interface{}{"title": n.Title, "message": n.Message, "priority": priority})
+	if err != nil {
+		return fmt.Errorf("failed to encode message: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, s.baseURL+"/message", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Gotify-Key", s.token)
+	return send(s.client, req, "Gotify")
+}
+
+// send executes a request, turning non-2xx responses into errors
+func send(client *http.Client, req *http.Request, service string) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to push to %s: %w", service, errkind.Unreachable(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("failed to push to %s: %w", service, errkind.Status(resp.StatusCode, strings.TrimSpace(string(body))))
+	}
+	return nil
+}
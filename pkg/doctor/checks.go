@@ -0,0 +1,124 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/cache"
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/llm"
+	"github.com/hustler/trading-bot/pkg/signal"
+	"github.com/hustler/trading-bot/pkg/telegram"
+	"github.com/hustler/trading-bot/pkg/timeseries"
+)
+
+// probeSymbol is fetched from each data source when no symbol is watched
+const probeSymbol = "SPY"
+
+// Checks returns the standard checks for cfg: the configuration itself, each
+// data source in the failover chain, the LLM provider, the Telegram token and
+// the optional Redis and time-series connections
+func Checks(cfg *config.Config) []Check {
+	checks := []Check{{Name: "config", Run: func(ctx context.Context) (string, error) {
+		if err := config.ValidateConfig(cfg); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("valid, %d symbols watched", len(cfg.WatchedSymbols())), nil
+	}}}
+
+	provider := data.NewProvider(cfg)
+	if questrade := data.QuestradeClientFromConfig(cfg.DataSource); questrade != nil {
+		provider.SetQuestradeClient(questrade)
+	}
+	symbol := probeSymbol
+	if watched := cfg.WatchedSymbols(); len(watched) > 0 {
+		symbol = watched[0]
+	}
+	chain := provider.ProviderChain()
+	if len(chain) == 0 {
+		checks = append(checks, Check{Name: "data source", Run: func(ctx context.Context) (string, error) {
+			return "", fmt.Errorf("no data sources configured")
+		}})
+	}
+	for _, name := range chain {
+		name := name
+		checks = append(checks, Check{Name: "data source " + name, Run: func(ctx context.Context) (string, error) {
+			md, err := provider.ProbeSource(name, symbol)
+			if err != nil {
+				return "", err
+			}
+			if len(md.Prices) == 0 {
+				return "", fmt.Errorf("no data for %s", symbol)
+			}
+			return fmt.Sprintf("%s at $%.2f", symbol, md.Prices[len(md.Prices)-1]), nil
+		}})
+	}
+
+	checks = append(checks,
+		Check{Name: "llm " + cfg.LLM.Provider, Run: func(ctx context.Context) (string, error) {
+			return checkLLM(ctx, cfg, symbol)
+		}},
+		Check{Name: "telegram token", Run: func(ctx context.Context) (string, error) {
+			if cfg.Telegram.BotToken == "" {
+				return "", fmt.Errorf("bot_token is not set")
+			}
+			bot, err := telegram.NewClient(cfg.Telegram.BotToken).GetMe()
+			if err != nil {
+				return "", err
+			}
+			return "authorized as @" + bot.Username, nil
+		}},
+		Check{Name: "redis", Run: func(ctx context.Context) (string, error) {
+			if !cfg.Redis.Enabled {
+				return "", Skip("disabled")
+			}
+			client := cache.NewRedis(cfg.Redis)
+			defer client.Close()
+			if err := client.Ping(); err != nil {
+				return "", err
+			}
+			return "connected to " + cfg.Redis.Addr, nil
+		}},
+		Check{Name: "timeseries " + cfg.Timeseries.Backend, Run: func(ctx context.Context) (string, error) {
+			if !cfg.Timeseries.Enabled {
+				return "", Skip("disabled")
+			}
+			if err := timeseries.PingBackend(cfg.Timeseries); err != nil {
+				return "", err
+			}
+			return "connected", nil
+		}},
+	)
+	return checks
+}
+
+// checkLLM asks the configured provider to explain a sample signal
+func checkLLM(ctx context.Context, cfg *config.Config, symbol string) (string, error) {
+	manager, err := llm.NewManager(&cfg.LLM)
+	if err != nil {
+		return "", err
+	}
+
+	sample := &signal.Signal{
+		ID:          "DOCTOR-" + symbol,
+		Symbol:      symbol,
+		Type:        signal.BUY,
+		Price:       100,
+		TargetPrice: 105,
+		StopLoss:    97,
+		ExpectedROI: 5,
+		Confidence:  0.7,
+		GeneratedAt: time.Now(),
+		TimeFrame:   "1d",
+	}
+	explanation, err := manager.GenerateSignalExplanation(ctx, sample)
+	if err != nil {
+		return "", err
+	}
+	if explanation == "" {
+		return "", fmt.Errorf("empty explanation")
+	}
+	return fmt.Sprintf("%s explained a sample signal in %d characters", manager.GetCurrentProvider(), len(explanation)), nil
+}
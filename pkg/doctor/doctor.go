@@ -0,0 +1,134 @@
+package doctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Status is the outcome of a check
+type Status string
+
+// Check outcomes
+const (
+	StatusPass Status = "PASS"
+	StatusFail Status = "FAIL"
+	StatusSkip Status = "SKIP" // Not configured, so not tested
+)
+
+// Check is one self-test run before the bot goes live. Run returns a short
+// detail shown on success.
+type Check struct {
+	Name string
+	Run  func(ctx context.Context) (string, error)
+}
+
+// Result is the outcome of one check
+type Result struct {
+	Name     string
+	Status   Status
+	Detail   string
+	Duration time.Duration
+}
+
+// Report is the outcome of every check, in the order they ran
+type Report struct {
+	Results []Result
+}
+
+// skipError marks a check that does not apply to the configuration
+type skipError struct {
+	reason string
+}
+
+func (e *skipError) Error() string {
+	return e.reason
+}
+
+// Skip returns the error a check reports when it does not apply
+func Skip(reason string) error {
+	return &skipError{reason: reason}
+}
+
+// Run runs the checks one after another, giving each up to timeout
+func Run(ctx context.Context, checks []Check, timeout time.Duration) *Report {
+	report := &Report{}
+	for _, check := range checks {
+		report.Results = append(report.Results, runCheck(ctx, check, timeout))
+	}
+	return report
+}
+
+// runCheck runs a check, failing it if it does not return within timeout
+func runCheck(ctx context.Context, check Check, timeout time.Duration) Result {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type outcome struct {
+		detail string
+		err    error
+	}
+	done := make(chan outcome, 1)
+	start := time.Now()
+	go func() {
+		detail, err := check.Run(ctx)
+		done <- outcome{detail: detail, err: err}
+	}()
+
+	var o outcome
+	select {
+	case o = <-done:
+	case <-ctx.Done():
+		o.err = fmt.Errorf("timed out after %s", timeout)
+	}
+
+	result := Result{Name: check.Name, Status: StatusPass, Detail: o.detail, Duration: time.Since(start)}
+	var skip *skipError
+	if errors.As(o.err, &skip) {
+		result.Status = StatusSkip
+		result.Detail = skip.reason
+	} else if o.err != nil {
+		result.Status = StatusFail
+		result.Detail = o.err.Error()
+	}
+	return result
+}
+
+// OK reports whether no check failed
+func (r *Report) OK() bool {
+	for _, result := range r.Results {
+		if result.Status == StatusFail {
+			return false
+		}
+	}
+	return true
+}
+
+// String formats the report as one line per check and a summary line
+func (r *Report) String() string {
+	width := 0
+	for _, result := range r.Results {
+		width = max(width, len(result.Name))
+	}
+
+	var b strings.Builder
+	counts := make(map[Status]int)
+	for _, result := range r.Results {
+		counts[result.Status]++
+		fmt.Fprintf(&b, "[%s] %-*s  %s", result.Status, width, result.Name, result.Detail)
+		if result.Status != StatusSkip {
+			fmt.Fprintf(&b, " (%s)", result.Duration.Round(time.Millisecond))
+		}
+		b.WriteString("\n")
+	}
+
+	verdict := "ready to go live"
+	if !r.OK() {
+		verdict = "fix the failed checks before going live"
+	}
+	fmt.Fprintf(&b, "\n%d passed, %d failed, %d skipped: %s\n",
+		counts[StatusPass], counts[StatusFail], counts[StatusSkip], verdict)
+	return b.String()
+}
@@ -0,0 +1,42 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunReportsEachCheck(t *testing.T) {
+	checks := []Check{
+		{Name: "config", Run: func(ctx context.Context) (string, error) { return "valid", nil }},
+		{Name: "redis", Run: func(ctx context.Context) (string, error) { return "", Skip("disabled") }},
+		{Name: "slow", Run: func(ctx context.Context) (string, error) {
+			time.Sleep(time.Second)
+			return "done", nil
+		}},
+		{Name: "telegram token", Run: func(ctx context.Context) (string, error) {
+			return "", fmt.Errorf("bot_token is not set")
+		}},
+	}
+
+	report := Run(context.Background(), checks, 50*time.Millisecond)
+	assert.Len(t, report.Results, 4)
+	assert.Equal(t, StatusPass, report.Results[0].Status)
+	assert.Equal(t, StatusSkip, report.Results[1].Status)
+	assert.Equal(t, "disabled", report.Results[1].Detail)
+	assert.Equal(t, StatusFail, report.Results[2].Status)
+	assert.Contains(t, report.Results[2].Detail, "timed out")
+	assert.Equal(t, StatusFail, report.Results[3].Status)
+	assert.False(t, report.OK())
+
+	text := report.String()
+	assert.Contains(t, text, "[FAIL] telegram token  bot_token is not set")
+	assert.Contains(t, text, "1 passed, 2 failed, 1 skipped")
+
+	report = Run(context.Background(), checks[:2], time.Second)
+	assert.True(t, report.OK())
+	assert.Contains(t, report.String(), "ready to go live")
+}
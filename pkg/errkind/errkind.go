@@ -0,0 +1,94 @@
+package errkind
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Sentinel errors wrapped by the data, llm and telegram packages, so callers
+// can decide between retrying, failing over and alerting whichever service
+// failed. Test for them with errors.Is.
+var (
+	// ErrRateLimited means the service throttled the request; retry after a backoff
+	ErrRateLimited = errors.New("rate limited")
+	// ErrNoData means the service has nothing for the request, e.g. an unknown symbol; retrying won't help
+	ErrNoData = errors.New("no data")
+	// ErrAuth means credentials are missing, invalid or expired; an operator has to act
+	ErrAuth = errors.New("authentication failed")
+	// ErrProviderDown means the service is unreachable or failing; fail over to another provider
+	ErrProviderDown = errors.New("provider unavailable")
+)
+
+// Kind names the class of an error for logs, metrics and reports
+type Kind string
+
+const (
+	RateLimited  Kind = "rate_limited"
+	NoData       Kind = "no_data"
+	Auth         Kind = "auth"
+	ProviderDown Kind = "provider_down"
+	Other        Kind = "other"
+)
+
+// Of returns the kind of err
+func Of(err error) Kind {
+	switch {
+	case errors.Is(err, ErrRateLimited):
+		return RateLimited
+	case errors.Is(err, ErrNoData):
+		return NoData
+	case errors.Is(err, ErrAuth):
+		return Auth
+	case errors.Is(err, ErrProviderDown):
+		return ProviderDown
+	default:
+		return Other
+	}
+}
+
+// Retryable reports whether the same request may succeed if sent again later
+func Retryable(err error) bool {
+	return errors.Is(err, ErrRateLimited) || errors.Is(err, ErrProviderDown)
+}
+
+// StatusError is a failed HTTP response. It wraps the sentinel error of its status code.
+type StatusError struct {
+	Code int
+	Body string
+}
+
+// Status returns the error for a failed HTTP response
+func Status(code int, body string) error {
+	return &StatusError{Code: code, Body: body}
+}
+
+// Error implements the error interface
+func (e *StatusError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("status: %d", e.Code)
+	}
+	return fmt.Sprintf("status: %d, body: %s", e.Code, e.Body)
+}
+
+// Unwrap returns the sentinel error of the status code, or nil if it has none
+func (e *StatusError) Unwrap() error {
+	switch {
+	case e.Code == http.StatusUnauthorized || e.Code == http.StatusForbidden:
+		return ErrAuth
+	case e.Code == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case e.Code == http.StatusNotFound:
+		return ErrNoData
+	case e.Code >= 500:
+		return ErrProviderDown
+	default:
+		return nil
+	}
+}
+
+// Unreachable marks a transport failure, such as a refused connection or a
+// timeout, as the provider being down
+func Unreachable(err error) error {
+	return fmt.Errorf("%w: %w", ErrProviderDown, err)
+}
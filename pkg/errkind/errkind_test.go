@@ -0,0 +1,44 @@
+package errkind
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusErrorKinds(t *testing.T) {
+	cases := map[int]Kind{
+		http.StatusUnauthorized:    Auth,
+		http.StatusForbidden:       Auth,
+		http.StatusTooManyRequests: RateLimited,
+		http.StatusNotFound:        NoData,
+		http.StatusBadGateway:      ProviderDown,
+		http.StatusBadRequest:      Other,
+	}
+	for code, kind := range cases {
+		err := fmt.Errorf("failed to get data: %w", Status(code, "oops"))
+		assert.Equal(t, kind, Of(err), "status %d", code)
+	}
+
+	err := fmt.Errorf("failed to get data: %w", Status(http.StatusTooManyRequests, ""))
+	assert.Equal(t, "failed to get data: status: 429", err.Error())
+	assert.True(t, Retryable(err))
+
+	var statusErr *StatusError
+	assert.True(t, errors.As(err, &statusErr))
+	assert.Equal(t, http.StatusTooManyRequests, statusErr.Code)
+}
+
+func TestUnreachableKeepsCause(t *testing.T) {
+	cause := errors.New("connection refused")
+	err := fmt.Errorf("failed to execute request: %w", Unreachable(cause))
+
+	assert.True(t, errors.Is(err, ErrProviderDown))
+	assert.True(t, errors.Is(err, cause))
+	assert.True(t, Retryable(err))
+	assert.False(t, Retryable(fmt.Errorf("bad key: %w", ErrAuth)))
+	assert.Equal(t, Other, Of(errors.New("parse error")))
+}
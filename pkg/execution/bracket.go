@@ -0,0 +1,146 @@
+package execution
+
+import (
+	"fmt"
+
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/strategy"
+)
+
+// Bracket legs
+const (
+	LegTarget = "target"
+	LegStop   = "stop"
+)
+
+// BracketOrder is an entry order with attached take-profit and stop-loss orders
+type BracketOrder struct {
+	Entry       Order
+	TargetPrice float64
+	StopPrice   float64
+}
+
+// validate checks that the target is above and the stop below the entry
+func (o BracketOrder) validate() error {
+	if o.TargetPrice <= o.Entry.Price || o.StopPrice <= 0 || o.StopPrice >= o.Entry.Price {
+		return fmt.Errorf("invalid bracket for %s: target $%.2f, stop $%.2f around entry $%.2f",
+			o.Entry.Symbol, o.TargetPrice, o.StopPrice, o.Entry.Price)
+	}
+	return nil
+}
+
+// BracketExit is the fill of a bracket's target or stop leg
+type BracketExit struct {
+	BracketID string
+	Leg       string // LegTarget or LegStop
+	Fill      Fill
+}
+
+// BracketBroker is a broker that manages the exits of bracket orders itself
+// (implemented by PaperBroker)
+type BracketBroker interface {
+	Broker
+	// PlaceBracket places the entry with its target and stop, returning the entry fill and the bracket ID
+	PlaceBracket(order BracketOrder) (*Fill, string, error)
+	// ModifyBracket moves the target and stop. A zero value leaves that level unchanged.
+	ModifyBracket(bracketID string, targetPrice, stopPrice float64) error
+	// CancelBracket cancels the open target and stop
+	CancelBracket(bracketID string) error
+	// BracketExits returns the legs filled since the last call. Simulated brokers
+	// use stocks as the latest quotes; live brokers may ignore it.
+	BracketExits(stocks map[string]*data.Stock) []BracketExit
+}
+
+// bracketBroker returns the broker as a BracketBroker if it supports native
+// bracket orders. Caller must hold the lock.
+func (t *TradeManager) bracketBroker() (BracketBroker, bool) {
+	if !brokerCapabilities(t.broker).BracketOrders {
+		return nil, false
+	}
+	broker, ok := t.broker.(BracketBroker)
+	return broker, ok
+}
+
+// useBracket reports whether an entry should be submitted as a bracket: the
+// decision opens a long carrying both levels, the broker supports brackets and
+// the strategy does not scale the position. Shorts are exited client-side.
+// Caller must hold the lock.
+func (t *TradeManager) useBracket(decision *strategy.TradeDecision) (BracketBroker, bool) {
+	if decision.Signal != strategy.Buy || decision.TargetPrice <= 0 || decision.StopPrice <= 0 {
+		return nil, false
+	}
+	plan := t.scalePlans[decision.Strategy]
+	if len(plan.EntryTranches) > 0 || len(plan.Exits) > 0 {
+		return nil, false
+	}
+	return t.bracketBroker()
+}
+
+// cancelBracket cancels the broker-side exits of a position before it is closed
+// client-side. Caller must hold the lock.
+func (t *TradeManager) cancelBracket(trade *Trade) error {
+	if trade.BracketID == "" {
+		return nil
+	}
+
+	broker, ok := t.bracketBroker()
+	if !ok {
+		return fmt.Errorf("broker no longer supports bracket orders")
+	}
+	if err := broker.CancelBracket(trade.BracketID); err != nil {
+		return fmt.Errorf("failed to cancel bracket for %s: %w", trade.Symbol, err)
+	}
+	trade.BracketID = ""
+	return nil
+}
+
+// SyncBrackets closes positions whose bracket target or stop the broker has filled.
+// It returns the exit trades.
+func (t *TradeManager) SyncBrackets(stocks map[string]*data.Stock) []*Trade {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	closedTrades := make([]*Trade, 0)
+
+	broker, ok := t.bracketBroker()
+	if !ok {
+		return closedTrades
+	}
+
+	for _, exit := range broker.BracketExits(stocks) {
+		var trade *Trade
+		for _, active := range t.activeTrades {
+			if active.BracketID == exit.BracketID {
+				trade = active
+				break
+			}
+		}
+		if trade == nil {
+			continue
+		}
+
+		trade.Fills = append(trade.Fills, exit.Fill)
+		trade.RealizedPnL += trade.PnLAt(exit.Fill.Quantity, exit.Fill.Price)
+		trade.recordExcursion(exit.Fill.Price)
+
+		sellTrade := &Trade{
+			ID:         t.tradeID(trade.Symbol + "-bracket"),
+			Symbol:     trade.Symbol,
+			Quantity:   exit.Fill.Quantity,
+			Price:      exit.Fill.Price,
+			Type:       opposite(trade.Type),
+			Status:     Executed,
+			CreatedAt:  t.clock.Now(),
+			UpdatedAt:  t.clock.Now(),
+			Reason:     fmt.Sprintf("Bracket %s filled at $%.2f", exit.Leg, exit.Fill.Price),
+			PositionID: trade.ID,
+		}
+		t.recordTrade(sellTrade)
+		closedTrades = append(closedTrades, sellTrade)
+
+		trade.UpdatedAt = t.clock.Now()
+		t.completePosition(trade, sellTrade)
+	}
+
+	return closedTrades
+}
@@ -0,0 +1,71 @@
+package execution
+
+import (
+	"testing"
+
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/strategy"
+	"github.com/stretchr/testify/assert"
+)
+
+// simpleBroker only supports simple orders
+type simpleBroker struct {
+	*PaperBroker
+}
+
+func (b simpleBroker) Capabilities() Capabilities {
+	return Capabilities{}
+}
+
+func TestBracketOrderExitsAtBroker(t *testing.T) {
+	manager := NewTradeManager(1000, 100)
+	decision := &strategy.TradeDecision{Symbol: "AAPL", Signal: strategy.Buy, TargetPrice: 105, StopPrice: 98}
+
+	trade, err := manager.ExecuteTrade(decision, &data.Stock{Symbol: "AAPL", CurrentPrice: 100})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, trade.BracketID)
+
+	// The broker manages the stop, so client-side checks leave the position alone
+	assert.Empty(t, manager.CheckStopLoss(map[string]*data.Stock{"AAPL": {Symbol: "AAPL", CurrentPrice: 97}}))
+
+	// Moving the target is forwarded to the bracket
+	_, err = manager.AdjustLevels("AAPL", 103, 0)
+	assert.NoError(t, err)
+
+	assert.Empty(t, manager.SyncBrackets(map[string]*data.Stock{"AAPL": {Symbol: "AAPL", CurrentPrice: 102}}))
+	closed := manager.SyncBrackets(map[string]*data.Stock{"AAPL": {Symbol: "AAPL", CurrentPrice: 103}})
+	assert.Len(t, closed, 1)
+	assert.Equal(t, trade.ID, closed[0].PositionID)
+	assert.Contains(t, closed[0].Reason, "Bracket target")
+	assert.InDelta(t, 30.0, trade.RealizedPnL, 0.0001)
+	assert.Empty(t, manager.GetActiveTrades())
+}
+
+func TestBracketCancelledOnClientSideClose(t *testing.T) {
+	manager := NewTradeManager(1000, 100)
+	decision := &strategy.TradeDecision{Symbol: "AAPL", Signal: strategy.Buy, TargetPrice: 105, StopPrice: 98}
+
+	_, err := manager.ExecuteTrade(decision, &data.Stock{Symbol: "AAPL", CurrentPrice: 100})
+	assert.NoError(t, err)
+
+	stocks := map[string]*data.Stock{"AAPL": {Symbol: "AAPL", CurrentPrice: 101}}
+	assert.Len(t, manager.CloseAllPositions(stocks), 1)
+
+	// The cancelled bracket no longer fills
+	stocks["AAPL"].CurrentPrice = 110
+	assert.Empty(t, manager.SyncBrackets(stocks))
+}
+
+func TestBracketFallsBackToClientSideExits(t *testing.T) {
+	manager := NewTradeManager(1000, 100)
+	manager.SetBroker(simpleBroker{NewPaperBroker()})
+	decision := &strategy.TradeDecision{Symbol: "AAPL", Signal: strategy.Buy, TargetPrice: 105, StopPrice: 98}
+
+	trade, err := manager.ExecuteTrade(decision, &data.Stock{Symbol: "AAPL", CurrentPrice: 100})
+	assert.NoError(t, err)
+	assert.Empty(t, trade.BracketID)
+	assert.Equal(t, 98.0, trade.StopPrice)
+
+	closed := manager.CheckStopLoss(map[string]*data.Stock{"AAPL": {Symbol: "AAPL", CurrentPrice: 97.5}})
+	assert.Len(t, closed, 1)
+}
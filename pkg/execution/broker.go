@@ -0,0 +1,315 @@
+package execution
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/strategy"
+)
+
+// Order is an order sent to a broker
+type Order struct {
+	Symbol   string
+	Side     strategy.TradeSignal
+	Quantity int
+	Price    float64 // Reference price at the time of the order
+	Exchange string  // Listing exchange the order routes to; set by a RoutingBroker
+	Currency string  // Currency the order is priced in; set by a RoutingBroker
+}
+
+// Fill is a broker's execution of an order
+type Fill struct {
+	Symbol   string
+	Side     strategy.TradeSignal
+	Quantity int
+	Price    float64
+	FilledAt time.Time
+}
+
+// ErrOrderRejected is returned when a broker rejects an order
+var ErrOrderRejected = errors.New("order rejected")
+
+// Broker executes orders (implemented by PaperBroker)
+type Broker interface {
+	PlaceOrder(order Order) (*Fill, error)
+}
+
+// Capabilities describes the order types a broker handles natively
+type Capabilities struct {
+	BracketOrders bool // Entry with attached target and stop orders (OTO/OCO)
+	OrderUpdates  bool // Pushes order status changes (see OrderStreamer)
+}
+
+// CapabilityReporter is implemented by brokers that support more than simple orders
+type CapabilityReporter interface {
+	Capabilities() Capabilities
+}
+
+// brokerCapabilities discovers what a broker supports. Brokers that do not
+// report capabilities only support simple orders.
+func brokerCapabilities(broker Broker) Capabilities {
+	reporter, ok := broker.(CapabilityReporter)
+	if !ok {
+		return Capabilities{}
+	}
+	return reporter.Capabilities()
+}
+
+// PaperBroker simulates a broker by filling orders at their reference price.
+// By default every order fills immediately and in full; SetSimulation adds
+// latency, partial fills and rejects.
+type PaperBroker struct {
+	brackets  map[string]*paperBracket
+	nextID    int
+	sim       config.PaperConfig
+	rng       *rand.Rand
+	now       func() time.Time
+	sleep     func(time.Duration)
+	listeners []func(OrderUpdate)
+	mu        sync.Mutex
+}
+
+// paperBracket is an open bracket held by the paper broker
+type paperBracket struct {
+	order BracketOrder
+	id    string
+}
+
+// NewPaperBroker creates a new PaperBroker
+func NewPaperBroker() *PaperBroker {
+	return &PaperBroker{
+		brackets: make(map[string]*paperBracket),
+		rng:      rand.New(rand.NewSource(time.Now().UnixNano())),
+		now:      time.Now,
+		sleep:    time.Sleep,
+	}
+}
+
+// SetSimulation sets the latency, partial fill and reject behaviour of the paper broker
+func (p *PaperBroker) SetSimulation(sim config.PaperConfig) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.sim = sim
+	if sim.Seed != 0 {
+		p.rng = rand.New(rand.NewSource(sim.Seed))
+	}
+}
+
+// SetClock sets the clock fills are timestamped with
+func (p *PaperBroker) SetClock(c clock.Clock) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.now = c.Now
+}
+
+// Capabilities reports that the paper broker simulates bracket orders and
+// streams order updates
+func (p *PaperBroker) Capabilities() Capabilities {
+	return Capabilities{BracketOrders: true, OrderUpdates: true}
+}
+
+// OnOrderUpdate registers a callback fired asynchronously for every fill,
+// partial fill, reject and bracket cancel
+func (p *PaperBroker) OnOrderUpdate(fn func(OrderUpdate)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.listeners = append(p.listeners, fn)
+}
+
+// publish sends an order update to the listeners. Caller must hold the lock.
+func (p *PaperBroker) publish(update OrderUpdate) {
+	for _, fn := range p.listeners {
+		go fn(update)
+	}
+}
+
+// orderID returns the ID of a new paper order. Caller must hold the lock.
+func (p *PaperBroker) orderID() string {
+	p.nextID++
+	return fmt.Sprintf("PAPER-ORD-%d", p.nextID)
+}
+
+// PlaceOrder fills the order at its reference price after the simulated latency.
+// The order may be rejected or only partially filled.
+func (p *PaperBroker) PlaceOrder(order Order) (*Fill, error) {
+	if order.Quantity <= 0 {
+		return nil, fmt.Errorf("invalid quantity %d for %s", order.Quantity, order.Symbol)
+	}
+	if order.Price <= 0 {
+		return nil, fmt.Errorf("invalid price %.2f for %s", order.Price, order.Symbol)
+	}
+
+	p.mu.Lock()
+	sim := p.sim
+	delay := time.Duration(sim.LatencyMs) * time.Millisecond
+	if sim.LatencyJitterMs > 0 {
+		delay += time.Duration(p.rng.Intn(sim.LatencyJitterMs+1)) * time.Millisecond
+	}
+	rejected := sim.RejectRate > 0 && p.rng.Float64() < sim.RejectRate
+	quantity := order.Quantity
+	if !rejected && order.Quantity > 1 && sim.PartialFillRate > 0 && p.rng.Float64() < sim.PartialFillRate {
+		ratio := sim.MinFillRatio + p.rng.Float64()*(1-sim.MinFillRatio)
+		quantity = int(float64(order.Quantity) * ratio)
+		if quantity < 1 {
+			quantity = 1
+		}
+	}
+	update := OrderUpdate{OrderID: p.orderID(), Symbol: order.Symbol, Side: order.Side, Quantity: order.Quantity, Price: order.Price}
+	p.mu.Unlock()
+
+	if delay > 0 {
+		p.sleep(delay)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	update.At = p.now()
+	if rejected {
+		update.Status = OrderRejected
+		update.Reason = "simulated reject"
+		p.publish(update)
+		return nil, fmt.Errorf("%w: %s %d %s (simulated)", ErrOrderRejected, order.Side, order.Quantity, order.Symbol)
+	}
+
+	update.Status = OrderFilled
+	if quantity < order.Quantity {
+		update.Status = OrderPartiallyFilled
+	}
+	update.Filled = quantity
+	p.publish(update)
+
+	return &Fill{
+		Symbol:   order.Symbol,
+		Side:     order.Side,
+		Quantity: quantity,
+		Price:    order.Price,
+		FilledAt: update.At,
+	}, nil
+}
+
+// PlaceBracket fills the entry and holds the target and stop until a quote crosses one of them
+func (p *PaperBroker) PlaceBracket(order BracketOrder) (*Fill, string, error) {
+	if err := order.validate(); err != nil {
+		return nil, "", err
+	}
+
+	fill, err := p.PlaceOrder(order.Entry)
+	if err != nil {
+		return nil, "", err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.nextID++
+	id := fmt.Sprintf("PAPER-BRK-%d", p.nextID)
+	order.Entry.Quantity = fill.Quantity
+	p.brackets[id] = &paperBracket{order: order, id: id}
+
+	return fill, id, nil
+}
+
+// ModifyBracket moves the target and stop of an open bracket. A zero value leaves that level unchanged.
+func (p *PaperBroker) ModifyBracket(bracketID string, targetPrice, stopPrice float64) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	bracket, ok := p.brackets[bracketID]
+	if !ok {
+		return fmt.Errorf("bracket not found: %s", bracketID)
+	}
+	if targetPrice > 0 {
+		bracket.order.TargetPrice = targetPrice
+	}
+	if stopPrice > 0 {
+		bracket.order.StopPrice = stopPrice
+	}
+	return nil
+}
+
+// CancelBracket cancels the open target and stop of a bracket
+func (p *PaperBroker) CancelBracket(bracketID string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	bracket, ok := p.brackets[bracketID]
+	if !ok {
+		return fmt.Errorf("bracket not found: %s", bracketID)
+	}
+	delete(p.brackets, bracketID)
+
+	entry := bracket.order.Entry
+	p.publish(OrderUpdate{
+		OrderID:  bracketID,
+		Symbol:   entry.Symbol,
+		Side:     strategy.Sell,
+		Status:   OrderCancelled,
+		Quantity: entry.Quantity,
+		Price:    entry.Price,
+		Reason:   "target and stop cancelled",
+		At:       p.now(),
+	})
+	return nil
+}
+
+// BracketExits fills the target or stop of every bracket the latest quotes have crossed
+func (p *PaperBroker) BracketExits(stocks map[string]*data.Stock) []BracketExit {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	exits := make([]BracketExit, 0)
+	for id, bracket := range p.brackets {
+		stock, ok := stocks[bracket.order.Entry.Symbol]
+		if !ok {
+			continue
+		}
+
+		leg := ""
+		switch {
+		case stock.CurrentPrice >= bracket.order.TargetPrice:
+			leg = LegTarget
+		case stock.CurrentPrice <= bracket.order.StopPrice:
+			leg = LegStop
+		default:
+			continue
+		}
+
+		exit := BracketExit{
+			BracketID: id,
+			Leg:       leg,
+			Fill: Fill{
+				Symbol:   bracket.order.Entry.Symbol,
+				Side:     strategy.Sell,
+				Quantity: bracket.order.Entry.Quantity,
+				Price:    stock.CurrentPrice,
+				FilledAt: p.now(),
+			},
+		}
+		exits = append(exits, exit)
+		delete(p.brackets, id)
+
+		p.publish(OrderUpdate{
+			OrderID:  id + "-" + leg,
+			Symbol:   exit.Fill.Symbol,
+			Side:     exit.Fill.Side,
+			Status:   OrderFilled,
+			Quantity: exit.Fill.Quantity,
+			Filled:   exit.Fill.Quantity,
+			Price:    exit.Fill.Price,
+			Reason:   "bracket " + leg,
+			At:       exit.Fill.FilledAt,
+		})
+	}
+
+	return exits
+}
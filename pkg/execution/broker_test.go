@@ -0,0 +1,101 @@
+package execution
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/strategy"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSimulatedBroker(sim config.PaperConfig) (*PaperBroker, *time.Duration) {
+	broker := NewPaperBroker()
+	broker.SetSimulation(sim)
+
+	var slept time.Duration
+	broker.sleep = func(d time.Duration) { slept += d }
+	return broker, &slept
+}
+
+func TestPaperBrokerSimulatesLatencyAndRejects(t *testing.T) {
+	broker, slept := newSimulatedBroker(config.PaperConfig{LatencyMs: 50, LatencyJitterMs: 10, RejectRate: 1, Seed: 7})
+
+	_, err := broker.PlaceOrder(Order{Symbol: "AAPL", Side: strategy.Buy, Quantity: 10, Price: 100})
+	assert.True(t, errors.Is(err, ErrOrderRejected))
+	assert.GreaterOrEqual(t, *slept, 50*time.Millisecond)
+	assert.LessOrEqual(t, *slept, 60*time.Millisecond)
+}
+
+func TestPaperBrokerPartialFills(t *testing.T) {
+	broker, _ := newSimulatedBroker(config.PaperConfig{PartialFillRate: 1, MinFillRatio: 0.5, Seed: 7})
+
+	for i := 0; i < 20; i++ {
+		fill, err := broker.PlaceOrder(Order{Symbol: "AAPL", Side: strategy.Buy, Quantity: 100, Price: 100})
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, fill.Quantity, 50)
+		assert.Less(t, fill.Quantity, 100)
+	}
+}
+
+func TestPaperBrokerStreamsOrderUpdates(t *testing.T) {
+	broker, _ := newSimulatedBroker(config.PaperConfig{PartialFillRate: 1, MinFillRatio: 0.5, Seed: 7})
+	updates := make(chan OrderUpdate, 4)
+	broker.OnOrderUpdate(func(update OrderUpdate) { updates <- update })
+
+	fill, err := broker.PlaceOrder(Order{Symbol: "AAPL", Side: strategy.Buy, Quantity: 100, Price: 100})
+	assert.NoError(t, err)
+	update := <-updates
+	assert.Equal(t, OrderPartiallyFilled, update.Status)
+	assert.Equal(t, fill.Quantity, update.Filled)
+	assert.Equal(t, 100, update.Quantity)
+	assert.NotEmpty(t, update.OrderID)
+
+	broker.SetSimulation(config.PaperConfig{})
+	_, id, err := broker.PlaceBracket(BracketOrder{Entry: Order{Symbol: "MSFT", Side: strategy.Buy, Quantity: 10, Price: 100}, TargetPrice: 105, StopPrice: 98})
+	assert.NoError(t, err)
+	assert.Equal(t, OrderFilled, (<-updates).Status)
+	assert.NoError(t, broker.CancelBracket(id))
+	update = <-updates
+	assert.Equal(t, OrderCancelled, update.Status)
+	assert.Equal(t, id, update.OrderID)
+	assert.True(t, broker.Capabilities().OrderUpdates)
+}
+
+// cappedBroker fills at most max shares per order
+type cappedBroker struct {
+	*PaperBroker
+	max int
+}
+
+func (b cappedBroker) PlaceOrder(order Order) (*Fill, error) {
+	if order.Quantity > b.max {
+		order.Quantity = b.max
+	}
+	return b.PaperBroker.PlaceOrder(order)
+}
+
+func TestExitResubmitsPartialFills(t *testing.T) {
+	manager := NewTradeManager(1000, 100)
+
+	trade, err := manager.ExecuteTrade(&strategy.TradeDecision{Symbol: "AAPL", Signal: strategy.Buy}, &data.Stock{Symbol: "AAPL", CurrentPrice: 100})
+	assert.NoError(t, err)
+	assert.Equal(t, 10, trade.Quantity)
+
+	// Each exit order fills 3 shares, so three attempts leave one share open
+	manager.SetBroker(cappedBroker{PaperBroker: NewPaperBroker(), max: 3})
+	closed := manager.CloseAllPositions(map[string]*data.Stock{"AAPL": {Symbol: "AAPL", CurrentPrice: 101}})
+	assert.Len(t, closed, 1)
+	assert.Equal(t, 9, closed[0].Quantity)
+	assert.Equal(t, 1, trade.Quantity)
+	assert.Equal(t, Executed, trade.Status)
+	assert.InDelta(t, 9.0, trade.RealizedPnL, 0.0001)
+	assert.Len(t, manager.GetActiveTrades(), 1)
+
+	closed = manager.CloseAllPositions(map[string]*data.Stock{"AAPL": {Symbol: "AAPL", CurrentPrice: 101}})
+	assert.Len(t, closed, 1)
+	assert.Equal(t, Completed, trade.Status)
+	assert.Empty(t, manager.GetActiveTrades())
+}
@@ -0,0 +1,79 @@
+package execution
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrComplianceBlocked is returned when a pre-trade check blocks an order
+var ErrComplianceBlocked = errors.New("blocked by pre-trade checks")
+
+// CheckResult is the verdict of one pre-trade check on an order
+type CheckResult struct {
+	Check   string `json:"check"`
+	Passed  bool   `json:"passed"`
+	Warning string `json:"warning,omitempty"` // Set by checks that let the order through but flag it
+	Reason  string `json:"reason,omitempty"`  // Why the order was blocked
+}
+
+// Account is the state pre-trade checks judge an order against
+type Account struct {
+	Now       time.Time
+	Opening   bool    // The order opens or adds to a position rather than exiting one
+	Position  *Trade  // Copy of the position the order adds to or exits, if any
+	Positions []Trade // Copies of every position, open and completed
+}
+
+// PreTradeCheck inspects an order before it is sent to the broker (implemented
+// by the checks in package compliance)
+type PreTradeCheck interface {
+	Name() string
+	Check(order Order, account Account) CheckResult
+}
+
+// AddPreTradeCheck appends a check to the chain every order must pass. Checks
+// run in the order they were added.
+func (t *TradeManager) AddPreTradeCheck(check PreTradeCheck) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.checks = append(t.checks, check)
+}
+
+// runChecks runs the pre-trade checks on an order for position, nil for a new
+// one. It returns every result and an error if any check blocked the order.
+// Caller must hold the lock.
+func (t *TradeManager) runChecks(order Order, position *Trade, opening bool) ([]CheckResult, error) {
+	if len(t.checks) == 0 {
+		return nil, nil
+	}
+
+	account := Account{Now: t.clock.Now(), Opening: opening}
+	if position != nil {
+		positionCopy := *position
+		account.Position = &positionCopy
+	}
+	for _, trade := range t.trades {
+		if trade.PositionID == "" {
+			account.Positions = append(account.Positions, *trade)
+		}
+	}
+
+	results := make([]CheckResult, 0, len(t.checks))
+	var blocked []string
+	for _, check := range t.checks {
+		result := check.Check(order, account)
+		result.Check = check.Name()
+		results = append(results, result)
+		if !result.Passed {
+			blocked = append(blocked, fmt.Sprintf("%s: %s", result.Check, result.Reason))
+		}
+	}
+	if len(blocked) > 0 {
+		return results, fmt.Errorf("%w: %s %d %s: %s", ErrComplianceBlocked,
+			order.Side, order.Quantity, order.Symbol, strings.Join(blocked, "; "))
+	}
+	return results, nil
+}
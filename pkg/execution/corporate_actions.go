@@ -0,0 +1,58 @@
+package execution
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hustler/trading-bot/pkg/data"
+)
+
+// ApplyCorporateAction adjusts the active position in the action's symbol if
+// it was opened before the ex-date. Splits scale the quantity and divide the
+// cost basis and levels by the ratio; dividends lower the cost basis and
+// levels by the amount received, so P&L isn't distorted by the price gap.
+// It returns the adjusted trade, or nil when there was nothing to adjust.
+func (t *TradeManager) ApplyCorporateAction(action data.CorporateAction) (*Trade, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	trade, exists := t.getActiveTradeForSymbol(action.Symbol)
+	if !exists || !trade.CreatedAt.Before(action.ExDate) {
+		return nil, nil
+	}
+	key := action.Key()
+	for _, applied := range trade.CorporateActions {
+		if applied == key {
+			return nil, nil
+		}
+	}
+
+	target, stop := trade.TargetPrice, trade.StopPrice
+	if target > 0 {
+		target = action.AdjustPrice(target)
+	}
+	if stop > 0 {
+		stop = action.AdjustPrice(stop)
+	}
+
+	// Bracket exits live at the broker, so move them there first
+	if trade.BracketID != "" {
+		broker, ok := t.bracketBroker()
+		if !ok {
+			return nil, fmt.Errorf("broker no longer supports bracket orders")
+		}
+		if err := broker.ModifyBracket(trade.BracketID, target, stop); err != nil {
+			return nil, fmt.Errorf("failed to modify bracket for %s: %w", action.Symbol, err)
+		}
+	}
+
+	trade.Price = action.AdjustPrice(trade.Price)
+	trade.TargetPrice, trade.StopPrice = target, stop
+	trade.Quantity = action.AdjustQuantity(trade.Quantity)
+	trade.PlannedQuantity = action.AdjustQuantity(trade.PlannedQuantity)
+	trade.CorporateActions = append(trade.CorporateActions, key)
+	trade.UpdatedAt = t.clock.Now()
+
+	log.Printf("Adjusted position %s in %s for %s", trade.ID, action.Symbol, action.Describe())
+	return trade, nil
+}
@@ -0,0 +1,41 @@
+package execution
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/strategy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyCorporateActionAdjustsPosition(t *testing.T) {
+	fixed := clock.NewFake(time.Date(2025, 6, 3, 14, 0, 0, 0, time.UTC))
+	manager := NewTradeManager(4000, 100)
+	manager.SetClock(fixed)
+
+	trade, err := manager.ExecuteTrade(&strategy.TradeDecision{Symbol: "XYZ", Signal: strategy.Buy, TargetPrice: 440, StopPrice: 380}, &data.Stock{Symbol: "XYZ", CurrentPrice: 400})
+	assert.NoError(t, err)
+	assert.Equal(t, 10, trade.Quantity)
+
+	split := data.CorporateAction{Symbol: "XYZ", Type: data.Split, ExDate: time.Date(2025, 6, 4, 0, 0, 0, 0, time.UTC), Ratio: 4}
+	adjusted, err := manager.ApplyCorporateAction(split)
+	assert.NoError(t, err)
+	assert.Equal(t, 40, adjusted.Quantity)
+	assert.Equal(t, 100.0, adjusted.Price)
+	assert.Equal(t, 110.0, adjusted.TargetPrice)
+	assert.Equal(t, 95.0, adjusted.StopPrice)
+
+	// Applying the same action again changes nothing
+	adjusted, err = manager.ApplyCorporateAction(split)
+	assert.NoError(t, err)
+	assert.Nil(t, adjusted)
+
+	dividend := data.CorporateAction{Symbol: "XYZ", Type: data.Dividend, ExDate: time.Date(2025, 6, 5, 0, 0, 0, 0, time.UTC), Amount: 0.5}
+	adjusted, err = manager.ApplyCorporateAction(dividend)
+	assert.NoError(t, err)
+	assert.Equal(t, 99.5, adjusted.Price)
+	assert.Equal(t, 94.5, adjusted.StopPrice)
+	assert.Equal(t, []string{split.Key(), dividend.Key()}, adjusted.CorporateActions)
+}
@@ -0,0 +1,31 @@
+package execution
+
+import "github.com/hustler/trading-bot/pkg/data"
+
+// RecordQuote updates the excursions of the open positions in the stock's
+// symbol with its latest price. It matches data.MarketWatcher.OnUpdate, so
+// positions track every quote between the stop and target checks.
+func (t *TradeManager) RecordQuote(stock *data.Stock) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, trade := range t.activeTrades {
+		if trade.Symbol == stock.Symbol {
+			trade.recordExcursion(stock.CurrentPrice)
+		}
+	}
+}
+
+// recordExcursion widens the position's MAE or MFE if price moved further
+// against or in favor of it than any price seen so far
+func (t *Trade) recordExcursion(price float64) {
+	if price <= 0 || t.Price <= 0 {
+		return
+	}
+	move := (price - t.Price) / t.Price * 100
+	if t.IsShort() {
+		move = -move
+	}
+	t.MAE = max(t.MAE, -move)
+	t.MFE = max(t.MFE, move)
+}
@@ -0,0 +1,163 @@
+package execution
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hustler/trading-bot/pkg/data"
+)
+
+// Audit actions recorded for manual changes to open trades
+const (
+	ActionStopChanged = "trade.stop_changed"
+	ActionReduced     = "trade.reduced"
+	ActionClosed      = "trade.closed"
+)
+
+// AuditLog records who did what to which target (implemented by store.Logger)
+type AuditLog interface {
+	RecordAudit(actor, action, target string, details interface{}) error
+}
+
+// SetAuditLog sets the audit log manual trade changes are recorded in.
+// Without one they are only logged.
+func (t *TradeManager) SetAuditLog(audit AuditLog) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.audit = audit
+}
+
+// activeTrade returns the open position with the given ID. Caller must hold the lock.
+func (t *TradeManager) activeTrade(tradeID string) (*Trade, error) {
+	trade, active := t.activeTrades[tradeID]
+	if !active {
+		return nil, fmt.Errorf("no open position with ID %s", tradeID)
+	}
+	return trade, nil
+}
+
+// ChangeStop moves the stop of an open position on behalf of actor, at the
+// broker first if a bracket manages it
+func (t *TradeManager) ChangeStop(tradeID string, stopPrice float64, actor string) (*Trade, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if stopPrice <= 0 {
+		return nil, fmt.Errorf("invalid stop price %.2f", stopPrice)
+	}
+	trade, err := t.activeTrade(tradeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if trade.BracketID != "" {
+		broker, ok := t.bracketBroker()
+		if !ok {
+			return nil, fmt.Errorf("broker no longer supports bracket orders")
+		}
+		if err := broker.ModifyBracket(trade.BracketID, 0, stopPrice); err != nil {
+			return nil, fmt.Errorf("failed to modify bracket for %s: %w", trade.Symbol, err)
+		}
+	}
+
+	previous := trade.StopPrice
+	trade.StopPrice = stopPrice
+	trade.UpdatedAt = t.clock.Now()
+	t.recordChange(actor, ActionStopChanged, trade, map[string]float64{"from": previous, "to": stopPrice})
+
+	return trade, nil
+}
+
+// ReduceTrade exits quantity shares of an open position at the latest quote
+// on behalf of actor and returns the exit trade. A bracket managing the
+// position is cancelled first, leaving its target and stop to client-side
+// checks.
+func (t *TradeManager) ReduceTrade(tradeID string, quantity int, stock *data.Stock, actor string) (*Trade, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	trade, err := t.activeTrade(tradeID)
+	if err != nil {
+		return nil, err
+	}
+	if quantity <= 0 || quantity >= trade.Quantity {
+		return nil, fmt.Errorf("can only reduce %s by 1 to %d shares, close it instead", trade.Symbol, trade.Quantity-1)
+	}
+	if err := t.cancelBracket(trade); err != nil {
+		return nil, err
+	}
+
+	fill, err := t.exitQuantity(trade, quantity, stock.CurrentPrice)
+	if err != nil {
+		return nil, err
+	}
+
+	exitTrade := &Trade{
+		ID:         t.tradeID(trade.Symbol + "-reduce"),
+		Symbol:     trade.Symbol,
+		Quantity:   fill.Quantity,
+		Price:      fill.Price,
+		Type:       opposite(trade.Type),
+		Status:     Executed,
+		CreatedAt:  t.clock.Now(),
+		UpdatedAt:  t.clock.Now(),
+		Reason:     fmt.Sprintf("Reduced by %s", actor),
+		PositionID: trade.ID,
+		Strategy:   trade.Strategy,
+	}
+	t.recordTrade(exitTrade)
+	t.finishExit(trade, exitTrade, fill)
+	t.recordChange(actor, ActionReduced, trade, exitTrade)
+
+	return exitTrade, nil
+}
+
+// CloseTrade exits an open position at the latest quote on behalf of actor
+// and returns the exit trade
+func (t *TradeManager) CloseTrade(tradeID string, stock *data.Stock, actor string) (*Trade, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	trade, err := t.activeTrade(tradeID)
+	if err != nil {
+		return nil, err
+	}
+
+	fill, err := t.exit(trade, stock.CurrentPrice)
+	if err != nil {
+		return nil, err
+	}
+
+	exitTrade := &Trade{
+		ID:         t.tradeID(trade.Symbol + "-close"),
+		Symbol:     trade.Symbol,
+		Quantity:   fill.Quantity,
+		Price:      fill.Price,
+		Type:       opposite(trade.Type),
+		Status:     Executed,
+		CreatedAt:  t.clock.Now(),
+		UpdatedAt:  t.clock.Now(),
+		Reason:     fmt.Sprintf("Closed by %s", actor),
+		PositionID: trade.ID,
+		Strategy:   trade.Strategy,
+	}
+	t.recordTrade(exitTrade)
+	t.finishExit(trade, exitTrade, fill)
+	t.recordChange(actor, ActionClosed, trade, exitTrade)
+
+	return exitTrade, nil
+}
+
+// recordChange logs a manual change to a position and writes it to the audit
+// log. Caller must hold the lock.
+func (t *TradeManager) recordChange(actor, action string, trade *Trade, details interface{}) {
+	log.Printf("Trade %s in %s: %s by %s", trade.ID, trade.Symbol, action, actor)
+
+	if t.audit == nil {
+		return
+	}
+	if err := t.audit.RecordAudit(actor, action, trade.ID, details); err != nil {
+		log.Printf("Error recording %s for trade %s: %v", action, trade.ID, err)
+	}
+}
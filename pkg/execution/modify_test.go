@@ -0,0 +1,57 @@
+package execution
+
+import (
+	"testing"
+
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/strategy"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingAudit struct {
+	actions []string
+}
+
+func (a *recordingAudit) RecordAudit(actor, action, target string, details interface{}) error {
+	a.actions = append(a.actions, actor+" "+action+" "+target)
+	return nil
+}
+
+func TestManualTradeChangesAreAudited(t *testing.T) {
+	manager := NewTradeManager(1000, 100)
+	audit := &recordingAudit{}
+	manager.SetAuditLog(audit)
+
+	position, err := manager.ExecuteTrade(&strategy.TradeDecision{Symbol: "AAPL", Signal: strategy.Buy, TargetPrice: 110, StopPrice: 95}, &data.Stock{Symbol: "AAPL", CurrentPrice: 100})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, position.BracketID)
+
+	_, err = manager.ChangeStop(position.ID, 98, "alice")
+	assert.NoError(t, err)
+	assert.Equal(t, 98.0, position.StopPrice)
+	_, err = manager.ChangeStop("MISSING", 98, "alice")
+	assert.Error(t, err)
+
+	// Reducing by the whole position is a close
+	stock := &data.Stock{Symbol: "AAPL", CurrentPrice: 104}
+	_, err = manager.ReduceTrade(position.ID, 10, stock, "alice")
+	assert.Error(t, err)
+
+	exit, err := manager.ReduceTrade(position.ID, 4, stock, "alice")
+	assert.NoError(t, err)
+	assert.Equal(t, 4, exit.Quantity)
+	assert.Equal(t, 6, position.Quantity)
+	assert.Empty(t, position.BracketID)
+	assert.InDelta(t, 16.0, position.RealizedPnL, 0.001)
+
+	exit, err = manager.CloseTrade(position.ID, stock, "alice")
+	assert.NoError(t, err)
+	assert.Equal(t, 6, exit.Quantity)
+	assert.Equal(t, Completed, position.Status)
+
+	assert.Equal(t, []string{
+		"alice trade.stop_changed " + position.ID,
+		"alice trade.reduced " + position.ID,
+		"alice trade.closed " + position.ID,
+	}, audit.actions)
+}
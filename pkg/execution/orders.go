@@ -0,0 +1,36 @@
+package execution
+
+import (
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/strategy"
+)
+
+// Order update statuses
+const (
+	OrderFilled          = "filled"
+	OrderPartiallyFilled = "partially_filled"
+	OrderCancelled       = "cancelled"
+	OrderRejected        = "rejected"
+)
+
+// OrderUpdate is a status change of an order reported by the broker
+type OrderUpdate struct {
+	OrderID  string               `json:"order_id"`
+	Symbol   string               `json:"symbol"`
+	Side     strategy.TradeSignal `json:"side"`
+	Status   string               `json:"status"`
+	Quantity int                  `json:"quantity"` // Quantity ordered
+	Filled   int                  `json:"filled"`   // Quantity filled so far
+	Price    float64              `json:"price"`    // Fill price, or the order's reference price if nothing filled
+	Reason   string               `json:"reason,omitempty"`
+	At       time.Time            `json:"at"`
+}
+
+// OrderStreamer is a broker that pushes order status changes as they happen
+// rather than waiting to be polled (implemented by PaperBroker)
+type OrderStreamer interface {
+	// OnOrderUpdate registers a callback fired for every order update.
+	// Callbacks run asynchronously.
+	OnOrderUpdate(fn func(OrderUpdate))
+}
@@ -0,0 +1,275 @@
+package execution
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/strategy"
+)
+
+// PairLeg is one leg of a pair trade
+type PairLeg struct {
+	Symbol     string               `json:"symbol"`
+	Side       strategy.TradeSignal `json:"side"` // Entry side: Buy for the long leg, Sell for the short leg
+	Quantity   int                  `json:"quantity"`
+	Open       int                  `json:"open"` // Shares not yet exited
+	EntryPrice float64              `json:"entry_price"`
+	ExitPrice  float64              `json:"exit_price,omitempty"` // Average price of the exits so far
+}
+
+// PairTrade is a long/short position in two symbols entered and exited together
+type PairTrade struct {
+	ID          string      `json:"id"`
+	Pair        string      `json:"pair"`
+	Legs        []PairLeg   `json:"legs"`
+	Status      TradeStatus `json:"status"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+	Reason      string      `json:"reason"`
+	Strategy    string      `json:"strategy"`
+	SignalID    string      `json:"signal_id,omitempty"`
+	RealizedPnL float64     `json:"realized_pnl"` // Combined P&L of the legs' exits so far
+
+	Checks []CheckResult `json:"checks,omitempty"` // Pre-trade check results of every leg order
+}
+
+// PairPnL is the combined P&L of a pair trade at the latest prices
+type PairPnL struct {
+	PairID     string  `json:"pair_id"`
+	Pair       string  `json:"pair"`
+	Unrealized float64 `json:"unrealized"`
+	Realized   float64 `json:"realized"`
+	Total      float64 `json:"total"`
+	TotalPct   float64 `json:"total_pct"` // Total over the gross capital both legs committed
+}
+
+// legPnL returns the P&L of shares of a leg moving from entry to price
+func legPnL(leg PairLeg, shares int, price float64) float64 {
+	pnl := float64(shares) * (price - leg.EntryPrice)
+	if leg.Side == strategy.Sell {
+		pnl = -pnl
+	}
+	return pnl
+}
+
+// PnL returns the combined P&L of both legs at prices keyed by symbol. Open
+// shares without a price are valued at their entry price.
+func (p *PairTrade) PnL(prices map[string]float64) PairPnL {
+	pnl := PairPnL{PairID: p.ID, Pair: p.Pair, Realized: p.RealizedPnL}
+	var gross float64
+	for _, leg := range p.Legs {
+		gross += float64(leg.Quantity) * leg.EntryPrice
+		price, ok := prices[leg.Symbol]
+		if !ok || price <= 0 {
+			price = leg.EntryPrice
+		}
+		pnl.Unrealized += legPnL(leg, leg.Open, price)
+	}
+	pnl.Total = pnl.Realized + pnl.Unrealized
+	if gross > 0 {
+		pnl.TotalPct = pnl.Total / gross * 100
+	}
+	return pnl
+}
+
+// opposite returns the side that exits a leg entered on side
+func opposite(side strategy.TradeSignal) strategy.TradeSignal {
+	if side == strategy.Buy {
+		return strategy.Sell
+	}
+	return strategy.Buy
+}
+
+// OpenPair enters both legs of a pair trade at the latest quotes. The first
+// leg is sized from the capital per stock and the second from it by the hedge
+// ratio. If the second leg cannot be entered the first is unwound, so a pair
+// is never left half open.
+func (t *TradeManager) OpenPair(decision *strategy.PairDecision, stocks map[string]*data.Stock) (*PairTrade, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(decision.Legs) != 2 {
+		return nil, fmt.Errorf("pair %s has %d legs, want 2", decision.Pair, len(decision.Legs))
+	}
+	for _, pair := range t.pairs {
+		if decision.SignalID != "" && pair.SignalID == decision.SignalID {
+			return nil, fmt.Errorf("signal %s already opened pair trade %s: %w", decision.SignalID, pair.ID, ErrDuplicateSignal)
+		}
+		if pair.Pair == decision.Pair && pair.Status == Executed {
+			return nil, fmt.Errorf("already have an active pair trade for %s", decision.Pair)
+		}
+	}
+
+	quotes := make([]*data.Stock, len(decision.Legs))
+	for i, leg := range decision.Legs {
+		stock, ok := stocks[leg.Symbol]
+		if !ok || stock.CurrentPrice <= 0 {
+			return nil, fmt.Errorf("no quote for %s", leg.Symbol)
+		}
+		if err := t.checkSymbol(leg.Symbol, stock); err != nil {
+			return nil, err
+		}
+		quotes[i] = stock
+	}
+
+	first := int(t.capitalPerStock / quotes[0].CurrentPrice)
+	if t.sizer != nil {
+		first = t.sizer.PositionSize(quotes[0].Symbol, quotes[0].CurrentPrice, t.capitalPerStock)
+	}
+	if first <= 0 {
+		return nil, fmt.Errorf("insufficient capital to trade %s at $%.2f", quotes[0].Symbol, quotes[0].CurrentPrice)
+	}
+
+	pair := &PairTrade{
+		ID:        t.tradeID("PAIR-" + decision.Pair),
+		Pair:      decision.Pair,
+		Status:    Executed,
+		CreatedAt: t.clock.Now(),
+		UpdatedAt: t.clock.Now(),
+		Reason:    decision.Rationale,
+		Strategy:  decision.Strategy,
+		SignalID:  decision.SignalID,
+	}
+	for i, leg := range decision.Legs {
+		quantity := first
+		if i > 0 {
+			quantity = int(math.Round(float64(pair.Legs[0].Quantity) * leg.Ratio))
+		}
+		if quantity <= 0 {
+			t.unwindPair(pair, quotes)
+			return nil, fmt.Errorf("hedge ratio %.3f leaves no %s shares to trade", leg.Ratio, leg.Symbol)
+		}
+
+		order := Order{Symbol: leg.Symbol, Side: leg.Signal, Quantity: quantity, Price: quotes[i].CurrentPrice}
+		checks, err := t.runChecks(order, nil, true)
+		pair.Checks = append(pair.Checks, checks...)
+		if err != nil {
+			t.unwindPair(pair, quotes)
+			return nil, err
+		}
+		fill, err := t.broker.PlaceOrder(order)
+		if err != nil {
+			t.unwindPair(pair, quotes)
+			return nil, fmt.Errorf("failed to enter %s leg of %s: %w", leg.Symbol, decision.Pair, err)
+		}
+		pair.Legs = append(pair.Legs, PairLeg{
+			Symbol:     leg.Symbol,
+			Side:       leg.Signal,
+			Quantity:   fill.Quantity,
+			Open:       fill.Quantity,
+			EntryPrice: fill.Price,
+		})
+	}
+
+	t.pairs[pair.ID] = pair
+	return pair, nil
+}
+
+// unwindPair exits the legs of a pair that failed to open. Caller must hold the lock.
+func (t *TradeManager) unwindPair(pair *PairTrade, quotes []*data.Stock) {
+	for i := range pair.Legs {
+		if _, err := t.exitLeg(pair, &pair.Legs[i], quotes[i].CurrentPrice); err != nil {
+			log.Printf("Error unwinding %s leg of %s, %d shares left open: %v", pair.Legs[i].Symbol, pair.Pair, pair.Legs[i].Open, err)
+		}
+	}
+}
+
+// exitLeg places orders to exit the open shares of a leg of pair, resubmitting
+// the remainder of partial fills, and returns the P&L they realized. Caller must hold the lock.
+func (t *TradeManager) exitLeg(pair *PairTrade, leg *PairLeg, price float64) (float64, error) {
+	var realized float64
+	exited := leg.Quantity - leg.Open
+	for attempt := 0; attempt < maxExitAttempts && leg.Open > 0; attempt++ {
+		order := Order{Symbol: leg.Symbol, Side: opposite(leg.Side), Quantity: leg.Open, Price: price}
+		checks, err := t.runChecks(order, nil, false)
+		pair.Checks = append(pair.Checks, checks...)
+		if err != nil {
+			return realized, err
+		}
+		fill, err := t.broker.PlaceOrder(order)
+		if err != nil {
+			return realized, fmt.Errorf("failed to exit %s: %w", leg.Symbol, err)
+		}
+		leg.ExitPrice = (leg.ExitPrice*float64(exited) + fill.Price*float64(fill.Quantity)) / float64(exited+fill.Quantity)
+		exited += fill.Quantity
+		leg.Open -= fill.Quantity
+		realized += legPnL(*leg, fill.Quantity, fill.Price)
+	}
+	if leg.Open > 0 {
+		return realized, fmt.Errorf("%d %s shares still open", leg.Open, leg.Symbol)
+	}
+	return realized, nil
+}
+
+// ClosePair exits both legs of a pair trade at the latest quotes. Legs that
+// fail to exit stay open and are retried by the next call.
+func (t *TradeManager) ClosePair(pairID string, stocks map[string]*data.Stock, reason string) (*PairTrade, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	pair, exists := t.pairs[pairID]
+	if !exists {
+		return nil, fmt.Errorf("pair trade not found: %s", pairID)
+	}
+	if pair.Status != Executed {
+		return nil, fmt.Errorf("pair trade %s is %s", pairID, pair.Status)
+	}
+
+	var failed error
+	for i := range pair.Legs {
+		leg := &pair.Legs[i]
+		if leg.Open == 0 {
+			continue
+		}
+		stock, ok := stocks[leg.Symbol]
+		if !ok || stock.CurrentPrice <= 0 {
+			failed = fmt.Errorf("no quote for %s", leg.Symbol)
+			continue
+		}
+		realized, err := t.exitLeg(pair, leg, stock.CurrentPrice)
+		pair.RealizedPnL += realized
+		if err != nil {
+			failed = err
+		}
+	}
+
+	pair.UpdatedAt = t.clock.Now()
+	if failed != nil {
+		return pair, fmt.Errorf("failed to close pair trade %s: %w", pairID, failed)
+	}
+	pair.Status = Completed
+	pair.Reason = reason
+	return pair, nil
+}
+
+// GetPair gets a pair trade by ID
+func (t *TradeManager) GetPair(pairID string) (*PairTrade, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	pair, exists := t.pairs[pairID]
+	return pair, exists
+}
+
+// ActivePairs returns copies of the open pair trades, oldest first
+func (t *TradeManager) ActivePairs() []PairTrade {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	pairs := make([]PairTrade, 0, len(t.pairs))
+	for _, pair := range t.pairs {
+		if pair.Status != Executed {
+			continue
+		}
+		pairCopy := *pair
+		pairCopy.Legs = append([]PairLeg(nil), pair.Legs...)
+		pairCopy.Checks = append([]CheckResult(nil), pair.Checks...)
+		pairs = append(pairs, pairCopy)
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].CreatedAt.Before(pairs[j].CreatedAt) })
+	return pairs
+}
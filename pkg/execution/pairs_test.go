@@ -0,0 +1,70 @@
+package execution
+
+import (
+	"testing"
+
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/strategy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPairTradeCombinesBothLegs(t *testing.T) {
+	manager := NewTradeManager(1000, 100)
+	decision := &strategy.PairDecision{
+		Pair: "KO/PEP",
+		Legs: []strategy.LegDecision{
+			{Symbol: "KO", Signal: strategy.Buy, Ratio: 1},
+			{Symbol: "PEP", Signal: strategy.Sell, Ratio: 0.5},
+		},
+		SignalID: "PAIR-1",
+	}
+	stocks := map[string]*data.Stock{
+		"KO":  {Symbol: "KO", CurrentPrice: 50},
+		"PEP": {Symbol: "PEP", CurrentPrice: 100},
+	}
+
+	pair, err := manager.OpenPair(decision, stocks)
+	assert.NoError(t, err)
+	assert.Equal(t, 20, pair.Legs[0].Quantity)
+	assert.Equal(t, 10, pair.Legs[1].Quantity)
+	assert.Len(t, manager.ActivePairs(), 1)
+
+	_, err = manager.OpenPair(decision, stocks)
+	assert.ErrorIs(t, err, ErrDuplicateSignal)
+
+	// KO up $1 and PEP up $1: +$20 on the long leg, -$10 on the short leg
+	pnl := pair.PnL(map[string]float64{"KO": 51, "PEP": 101})
+	assert.InDelta(t, 10, pnl.Unrealized, 1e-9)
+	assert.InDelta(t, 0.5, pnl.TotalPct, 1e-9)
+
+	closed, err := manager.ClosePair(pair.ID, map[string]*data.Stock{
+		"KO":  {Symbol: "KO", CurrentPrice: 51},
+		"PEP": {Symbol: "PEP", CurrentPrice: 101},
+	}, "spread reverted")
+	assert.NoError(t, err)
+	assert.Equal(t, Completed, closed.Status)
+	assert.InDelta(t, 10, closed.RealizedPnL, 1e-9)
+	assert.Equal(t, 0, closed.Legs[1].Open)
+	assert.Empty(t, manager.ActivePairs())
+
+	_, err = manager.ClosePair(pair.ID, stocks, "again")
+	assert.Error(t, err)
+}
+
+func TestOpenPairRefusesHaltedLeg(t *testing.T) {
+	manager := NewTradeManager(1000, 100)
+	decision := &strategy.PairDecision{
+		Pair: "KO/PEP",
+		Legs: []strategy.LegDecision{
+			{Symbol: "KO", Signal: strategy.Sell, Ratio: 1},
+			{Symbol: "PEP", Signal: strategy.Buy, Ratio: 0.5},
+		},
+	}
+
+	_, err := manager.OpenPair(decision, map[string]*data.Stock{
+		"KO":  {Symbol: "KO", CurrentPrice: 50},
+		"PEP": {Symbol: "PEP", CurrentPrice: 100, Halted: true},
+	})
+	assert.Error(t, err)
+	assert.Empty(t, manager.ActivePairs())
+}
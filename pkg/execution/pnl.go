@@ -0,0 +1,75 @@
+package execution
+
+import (
+	"sort"
+	"time"
+)
+
+// PositionPnL is the unrealized P&L of an open position at the latest price
+type PositionPnL struct {
+	TradeID       string  `json:"trade_id"`
+	Symbol        string  `json:"symbol"`
+	Quantity      int     `json:"quantity"`
+	EntryPrice    float64 `json:"entry_price"`
+	Price         float64 `json:"price"`
+	Unrealized    float64 `json:"unrealized"`
+	UnrealizedPct float64 `json:"unrealized_pct"`
+	Realized      float64 `json:"realized"` // Taken by partial exits so far
+}
+
+// PnLSnapshot is the P&L of every open position and of the account
+type PnLSnapshot struct {
+	Positions  []PositionPnL `json:"positions"`
+	Unrealized float64       `json:"unrealized"`
+	Realized   float64       `json:"realized"` // Realized by exits since the start of the period
+	Total      float64       `json:"total"`
+	At         time.Time     `json:"at"`
+}
+
+// PnL values open positions at prices, keyed by symbol, and adds the P&L
+// realized by exits filled since since, e.g. the start of the trading day.
+// Positions without a price are valued at their entry price.
+func (t *TradeManager) PnL(prices map[string]float64, since time.Time) PnLSnapshot {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	snapshot := PnLSnapshot{Positions: []PositionPnL{}, At: t.clock.Now()}
+	for _, trade := range t.trades {
+		if trade.PositionID != "" {
+			continue
+		}
+		for _, fill := range trade.Fills {
+			if fill.Side != trade.Type && !fill.FilledAt.Before(since) {
+				snapshot.Realized += trade.PnLAt(fill.Quantity, fill.Price)
+			}
+		}
+	}
+
+	for _, trade := range t.activeTrades {
+		price, ok := prices[trade.Symbol]
+		if !ok || price <= 0 {
+			price = trade.Price
+		}
+
+		position := PositionPnL{
+			TradeID:    trade.ID,
+			Symbol:     trade.Symbol,
+			Quantity:   trade.Quantity,
+			EntryPrice: trade.Price,
+			Price:      price,
+			Unrealized: trade.PnLAt(trade.Quantity, price),
+			Realized:   trade.RealizedPnL,
+		}
+		if trade.Price > 0 && trade.Quantity > 0 {
+			position.UnrealizedPct = position.Unrealized / (float64(trade.Quantity) * trade.Price) * 100
+		}
+		snapshot.Positions = append(snapshot.Positions, position)
+		snapshot.Unrealized += position.Unrealized
+	}
+	sort.Slice(snapshot.Positions, func(i, j int) bool {
+		return snapshot.Positions[i].Symbol < snapshot.Positions[j].Symbol
+	})
+
+	snapshot.Total = snapshot.Realized + snapshot.Unrealized
+	return snapshot
+}
@@ -0,0 +1,50 @@
+package execution
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/strategy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPnLSnapshot(t *testing.T) {
+	manager := NewTradeManager(10000, 1000)
+	manager.SetScalePlan("volatility", config.ScalingConfig{
+		Exits: []config.ScaleOutConfig{{TargetPercent: 2, Fraction: 0.5}},
+	})
+	since := time.Now().Add(-time.Minute)
+
+	_, err := manager.ExecuteTrade(&strategy.TradeDecision{Symbol: "AAPL", Signal: strategy.Buy, Strategy: "volatility"}, &data.Stock{Symbol: "AAPL", CurrentPrice: 100})
+	assert.NoError(t, err)
+	_, err = manager.ExecuteTrade(&strategy.TradeDecision{Symbol: "MSFT", Signal: strategy.Buy}, &data.Stock{Symbol: "MSFT", CurrentPrice: 200})
+	assert.NoError(t, err)
+
+	// Half of AAPL is sold at the +2% target
+	assert.Len(t, manager.CheckScaleOut(map[string]*data.Stock{"AAPL": {Symbol: "AAPL", CurrentPrice: 102}}), 1)
+
+	// MSFT has no quote and is valued at entry
+	snapshot := manager.PnL(map[string]float64{"AAPL": 103}, since)
+	assert.Len(t, snapshot.Positions, 2)
+
+	aapl := snapshot.Positions[0]
+	assert.Equal(t, "AAPL", aapl.Symbol)
+	assert.Equal(t, 50, aapl.Quantity)
+	assert.InDelta(t, 150.0, aapl.Unrealized, 0.0001)
+	assert.InDelta(t, 3.0, aapl.UnrealizedPct, 0.0001)
+	assert.InDelta(t, 100.0, aapl.Realized, 0.0001)
+
+	assert.Equal(t, "MSFT", snapshot.Positions[1].Symbol)
+	assert.Equal(t, 0.0, snapshot.Positions[1].Unrealized)
+
+	assert.InDelta(t, 150.0, snapshot.Unrealized, 0.0001)
+	assert.InDelta(t, 100.0, snapshot.Realized, 0.0001)
+	assert.InDelta(t, 250.0, snapshot.Total, 0.0001)
+
+	// Exits before the start of the period are not counted
+	later := manager.PnL(map[string]float64{"AAPL": 103}, time.Now().Add(time.Hour))
+	assert.Equal(t, 0.0, later.Realized)
+	assert.InDelta(t, 150.0, later.Total, 0.0001)
+}
@@ -0,0 +1,59 @@
+package execution
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/strategy"
+)
+
+// ErrPriceDeviation is returned when a fresh quote is too far from the entry a
+// decision was analyzed at
+var ErrPriceDeviation = errors.New("quote deviates from analyzed entry")
+
+// QuoteSource fetches a fresh quote for a symbol (implemented by data.QuestradeClient)
+type QuoteSource interface {
+	GetQuote(symbol string) (*data.Stock, error)
+}
+
+// SetPriceCheck sets where a fresh quote is fetched before every entry order,
+// and how far it may be from the decision's entry price. Exits are never checked.
+func (t *TradeManager) SetPriceCheck(quotes QuoteSource, check config.PriceCheckConfig) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.quotes = quotes
+	t.priceCheck = check
+}
+
+// checkQuote fetches a fresh quote and refuses an entry whose price is outside
+// the band around the entry it was analyzed at, so fast moves are not filled
+// far from it. Buys are judged on the ask and sells on the bid, when quoted.
+// An entry is refused if no quote can be fetched. Caller must hold the lock.
+func (t *TradeManager) checkQuote(decision *strategy.TradeDecision) error {
+	if t.quotes == nil || t.priceCheck.MaxDeviationPercent <= 0 || decision.Price <= 0 {
+		return nil
+	}
+
+	quote, err := t.quotes.GetQuote(decision.Symbol)
+	if err != nil {
+		return fmt.Errorf("failed to fetch quote for %s before entry: %w", decision.Symbol, err)
+	}
+
+	price := quote.CurrentPrice
+	if decision.Signal == strategy.Buy && quote.Ask > 0 {
+		price = quote.Ask
+	} else if decision.Signal == strategy.Sell && quote.Bid > 0 {
+		price = quote.Bid
+	}
+
+	deviation := math.Abs(price-decision.Price) / decision.Price * 100
+	if price <= 0 || deviation > t.priceCheck.MaxDeviationPercent {
+		return fmt.Errorf("%w: %s at $%.2f is %.2f%% from $%.2f, over the %.2f%% band", ErrPriceDeviation,
+			decision.Symbol, price, deviation, decision.Price, t.priceCheck.MaxDeviationPercent)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package execution
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/strategy"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeQuotes map[string]*data.Stock
+
+func (f fakeQuotes) GetQuote(symbol string) (*data.Stock, error) {
+	quote, ok := f[symbol]
+	if !ok {
+		return nil, errors.New("no quote")
+	}
+	return quote, nil
+}
+
+func TestPriceCheckRefusesEntriesAwayFromAnalyzedPrice(t *testing.T) {
+	tm := NewTradeManager(10000, 100)
+	quotes := fakeQuotes{"AAPL": {Symbol: "AAPL", CurrentPrice: 100.4, Bid: 100.3, Ask: 100.5}}
+	tm.SetPriceCheck(quotes, config.PriceCheckConfig{MaxDeviationPercent: 1})
+	stock := &data.Stock{Symbol: "AAPL", CurrentPrice: 100.4}
+
+	// The ask has run more than 1% above the analyzed entry
+	_, err := tm.ExecuteTrade(&strategy.TradeDecision{Symbol: "AAPL", Signal: strategy.Buy, Price: 99}, stock)
+	assert.ErrorIs(t, err, ErrPriceDeviation)
+	assert.Empty(t, tm.GetAllTrades())
+
+	// Within the band the entry goes through
+	trade, err := tm.ExecuteTrade(&strategy.TradeDecision{Symbol: "AAPL", Signal: strategy.Buy, Price: 100}, stock)
+	assert.NoError(t, err)
+	assert.NotNil(t, trade)
+
+	// Exits are never checked
+	quotes["AAPL"] = &data.Stock{Symbol: "AAPL", CurrentPrice: 90}
+	_, err = tm.ExecuteTrade(&strategy.TradeDecision{Symbol: "AAPL", Signal: strategy.Sell, Price: 100}, stock)
+	assert.NoError(t, err)
+
+	// Entries are refused without a quote
+	_, err = tm.ExecuteTrade(&strategy.TradeDecision{Symbol: "MSFT", Signal: strategy.Buy, Price: 300}, &data.Stock{Symbol: "MSFT", CurrentPrice: 300})
+	assert.Error(t, err)
+}
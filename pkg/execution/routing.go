@@ -0,0 +1,234 @@
+package execution
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/signal"
+	"github.com/hustler/trading-bot/pkg/symbols"
+)
+
+// ErrNoRoute is returned for an order no venue broker is set for
+var ErrNoRoute = errors.New("no broker for venue")
+
+// Route is where and how an order for a symbol is executed
+type Route struct {
+	Exchange string // Listing exchange, e.g. NASDAQ or TSX; empty if unknown
+	Currency string // Currency the order is priced and settled in
+	LotSize  int    // Shares per board lot; quantities are rounded down to a multiple
+}
+
+// listingExchanges maps Canadian symbol suffixes to their exchange
+var listingExchanges = map[string]string{
+	".TO": "TSX",
+	".V":  "TSXV",
+}
+
+// ResolveRoute returns the route of a symbol. The currency and Canadian
+// exchanges follow the symbol's suffix; otherwise the exchange and board lot
+// come from the symbol's metadata, if any.
+func ResolveRoute(symbol string, metadata symbols.MetadataLookup) Route {
+	route := Route{Currency: signal.CurrencyFor(symbol), LotSize: 1}
+	if metadata != nil {
+		// Unknown symbols still carry the default board lot
+		meta, ok := metadata.Lookup(symbol)
+		if ok {
+			route.Exchange = meta.Exchange
+		}
+		if meta.LotSize > 0 {
+			route.LotSize = meta.LotSize
+		}
+	}
+	upper := strings.ToUpper(symbol)
+	for suffix, exchange := range listingExchanges {
+		if strings.HasSuffix(upper, suffix) {
+			route.Exchange = exchange
+		}
+	}
+	return route
+}
+
+// RoutingBroker sends each order to the broker of its venue, so the same
+// signal executes on US and Canadian listings through different brokers.
+// Orders are stamped with their exchange and currency and sized in whole
+// board lots. Bracket IDs are prefixed with the venue that holds them.
+type RoutingBroker struct {
+	brokers  map[string]Broker // Keyed by route currency
+	metadata symbols.MetadataLookup
+	mu       sync.RWMutex
+}
+
+// NewRoutingBroker creates a routing broker resolving exchanges and board
+// lots from metadata, which may be nil
+func NewRoutingBroker(metadata symbols.MetadataLookup) *RoutingBroker {
+	return &RoutingBroker{
+		brokers:  make(map[string]Broker),
+		metadata: metadata,
+	}
+}
+
+// SetVenueBroker sets the broker for orders routed in a currency, e.g. USD for
+// US listings and CAD for Canadian ones
+func (r *RoutingBroker) SetVenueBroker(currency string, broker Broker) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.brokers[strings.ToUpper(currency)] = broker
+}
+
+// venues returns the venue brokers by currency
+func (r *RoutingBroker) venues() map[string]Broker {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	venues := make(map[string]Broker, len(r.brokers))
+	for currency, broker := range r.brokers {
+		venues[currency] = broker
+	}
+	return venues
+}
+
+// route resolves an order's venue, returning the order stamped with its route
+// and the broker to place it with
+func (r *RoutingBroker) route(order Order) (Order, string, Broker, error) {
+	route := ResolveRoute(order.Symbol, r.metadata)
+
+	r.mu.RLock()
+	broker, ok := r.brokers[route.Currency]
+	r.mu.RUnlock()
+	if !ok {
+		return order, "", nil, fmt.Errorf("%w: %s %s", ErrNoRoute, route.Currency, order.Symbol)
+	}
+
+	lots := order.Quantity - order.Quantity%route.LotSize
+	if lots <= 0 {
+		return order, "", nil, fmt.Errorf("%w: %d %s is less than a board lot of %d",
+			ErrOrderRejected, order.Quantity, order.Symbol, route.LotSize)
+	}
+	order.Quantity = lots
+	order.Exchange = route.Exchange
+	order.Currency = route.Currency
+	return order, route.Currency, broker, nil
+}
+
+// SetClock sets the clock of every venue broker that has one
+func (r *RoutingBroker) SetClock(c clock.Clock) {
+	for _, broker := range r.venues() {
+		if broker, ok := broker.(interface{ SetClock(clock.Clock) }); ok {
+			broker.SetClock(c)
+		}
+	}
+}
+
+// Capabilities reports bracket orders if every venue broker supports them,
+// and order updates if any streams them
+func (r *RoutingBroker) Capabilities() Capabilities {
+	venues := r.venues()
+	caps := Capabilities{BracketOrders: len(venues) > 0}
+	for _, broker := range venues {
+		venueCaps := brokerCapabilities(broker)
+		caps.BracketOrders = caps.BracketOrders && venueCaps.BracketOrders
+		caps.OrderUpdates = caps.OrderUpdates || venueCaps.OrderUpdates
+	}
+	return caps
+}
+
+// OnOrderUpdate registers a callback for the order updates of every venue
+// broker that streams them
+func (r *RoutingBroker) OnOrderUpdate(fn func(OrderUpdate)) {
+	for _, broker := range r.venues() {
+		if broker, ok := broker.(OrderStreamer); ok {
+			broker.OnOrderUpdate(fn)
+		}
+	}
+}
+
+// PlaceOrder places the order with the broker of its venue
+func (r *RoutingBroker) PlaceOrder(order Order) (*Fill, error) {
+	routed, _, broker, err := r.route(order)
+	if err != nil {
+		return nil, err
+	}
+	return broker.PlaceOrder(routed)
+}
+
+// PlaceBracket places the bracket with the broker of its entry's venue
+func (r *RoutingBroker) PlaceBracket(order BracketOrder) (*Fill, string, error) {
+	routed, venue, broker, err := r.route(order.Entry)
+	if err != nil {
+		return nil, "", err
+	}
+	bracketBroker, ok := broker.(BracketBroker)
+	if !ok {
+		return nil, "", fmt.Errorf("%s broker does not support bracket orders", venue)
+	}
+
+	order.Entry = routed
+	fill, bracketID, err := bracketBroker.PlaceBracket(order)
+	if err != nil {
+		return nil, "", err
+	}
+	return fill, venue + ":" + bracketID, nil
+}
+
+// bracketVenue returns the broker holding a bracket and its ID at that broker
+func (r *RoutingBroker) bracketVenue(bracketID string) (BracketBroker, string, error) {
+	venue, id, found := strings.Cut(bracketID, ":")
+	if !found {
+		return nil, "", fmt.Errorf("bracket %s was not placed through the router", bracketID)
+	}
+
+	r.mu.RLock()
+	broker, ok := r.brokers[venue].(BracketBroker)
+	r.mu.RUnlock()
+	if !ok {
+		return nil, "", fmt.Errorf("%w: %s bracket %s", ErrNoRoute, venue, id)
+	}
+	return broker, id, nil
+}
+
+// ModifyBracket moves the target and stop of a bracket at its venue
+func (r *RoutingBroker) ModifyBracket(bracketID string, targetPrice, stopPrice float64) error {
+	broker, id, err := r.bracketVenue(bracketID)
+	if err != nil {
+		return err
+	}
+	return broker.ModifyBracket(id, targetPrice, stopPrice)
+}
+
+// CancelBracket cancels a bracket at its venue
+func (r *RoutingBroker) CancelBracket(bracketID string) error {
+	broker, id, err := r.bracketVenue(bracketID)
+	if err != nil {
+		return err
+	}
+	return broker.CancelBracket(id)
+}
+
+// BracketExits returns the bracket legs filled at every venue
+func (r *RoutingBroker) BracketExits(stocks map[string]*data.Stock) []BracketExit {
+	venues := r.venues()
+	currencies := make([]string, 0, len(venues))
+	for currency := range venues {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	var exits []BracketExit
+	for _, currency := range currencies {
+		broker, ok := venues[currency].(BracketBroker)
+		if !ok {
+			continue
+		}
+		for _, exit := range broker.BracketExits(stocks) {
+			exit.BracketID = currency + ":" + exit.BracketID
+			exits = append(exits, exit)
+		}
+	}
+	return exits
+}
@@ -0,0 +1,81 @@
+package execution
+
+import (
+	"testing"
+
+	"github.com/hustler/trading-bot/pkg/strategy"
+	"github.com/hustler/trading-bot/pkg/symbols"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeMetadata map[string]symbols.Metadata
+
+func (f fakeMetadata) Lookup(symbol string) (symbols.Metadata, bool) {
+	meta, ok := f[symbol]
+	if !ok {
+		return symbols.Metadata{Symbol: symbol, LotSize: 1}, false
+	}
+	return meta, true
+}
+
+// recordingBroker remembers the orders it fills
+type recordingBroker struct {
+	orders []Order
+}
+
+func (b *recordingBroker) PlaceOrder(order Order) (*Fill, error) {
+	b.orders = append(b.orders, order)
+	return &Fill{Symbol: order.Symbol, Side: order.Side, Quantity: order.Quantity, Price: order.Price}, nil
+}
+
+func TestResolveRoute(t *testing.T) {
+	metadata := fakeMetadata{
+		"AAPL":  {Symbol: "AAPL", Exchange: "NASDAQ", LotSize: 1},
+		"ABC.V": {Symbol: "ABC.V", Exchange: "TSX Venture", LotSize: 500},
+	}
+
+	assert.Equal(t, Route{Exchange: "NASDAQ", Currency: "USD", LotSize: 1}, ResolveRoute("AAPL", metadata))
+	assert.Equal(t, Route{Exchange: "TSX", Currency: "CAD", LotSize: 1}, ResolveRoute("shop.to", metadata))
+	assert.Equal(t, Route{Exchange: "TSXV", Currency: "CAD", LotSize: 500}, ResolveRoute("ABC.V", metadata))
+	assert.Equal(t, Route{Currency: "USD", LotSize: 1}, ResolveRoute("MSFT", nil))
+}
+
+func TestRoutingBrokerSendsOrdersToTheirVenue(t *testing.T) {
+	us, ca := &recordingBroker{}, &recordingBroker{}
+	router := NewRoutingBroker(fakeMetadata{"ABC.V": {Symbol: "ABC.V", LotSize: 100}})
+	router.SetVenueBroker("usd", us)
+	router.SetVenueBroker("CAD", ca)
+
+	_, err := router.PlaceOrder(Order{Symbol: "AAPL", Side: strategy.Buy, Quantity: 10, Price: 190})
+	assert.NoError(t, err)
+	fill, err := router.PlaceOrder(Order{Symbol: "ABC.V", Side: strategy.Buy, Quantity: 250, Price: 1.2})
+	assert.NoError(t, err)
+	assert.Equal(t, 200, fill.Quantity)
+
+	assert.Equal(t, []Order{{Symbol: "AAPL", Side: strategy.Buy, Quantity: 10, Price: 190, Currency: "USD"}}, us.orders)
+	assert.Equal(t, []Order{{Symbol: "ABC.V", Side: strategy.Buy, Quantity: 200, Price: 1.2, Exchange: "TSXV", Currency: "CAD"}}, ca.orders)
+
+	// Less than a board lot is rejected
+	_, err = router.PlaceOrder(Order{Symbol: "ABC.V", Side: strategy.Buy, Quantity: 50, Price: 1.2})
+	assert.ErrorIs(t, err, ErrOrderRejected)
+
+	// Brackets need every venue to support them
+	assert.False(t, router.Capabilities().BracketOrders)
+}
+
+func TestRoutingBrokerPrefixesBracketsWithTheirVenue(t *testing.T) {
+	router := NewRoutingBroker(nil)
+	router.SetVenueBroker("USD", NewPaperBroker())
+	router.SetVenueBroker("CAD", NewPaperBroker())
+	assert.True(t, router.Capabilities().BracketOrders)
+
+	entry := Order{Symbol: "SHOP.TO", Side: strategy.Buy, Quantity: 10, Price: 100}
+	_, bracketID, err := router.PlaceBracket(BracketOrder{Entry: entry, TargetPrice: 110, StopPrice: 95})
+	assert.NoError(t, err)
+	assert.Contains(t, bracketID, "CAD:")
+
+	assert.NoError(t, router.ModifyBracket(bracketID, 112, 0))
+	assert.NoError(t, router.CancelBracket(bracketID))
+	assert.Error(t, router.CancelBracket("USD:missing"))
+	assert.ErrorIs(t, router.CancelBracket("EUR:1"), ErrNoRoute)
+}
@@ -0,0 +1,171 @@
+package execution
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/strategy"
+)
+
+// Errors returned by a SafeBroker for orders it refuses to pass on
+var (
+	ErrOrderThrottled = errors.New("order rate limit reached")
+	ErrOrderTooLarge  = errors.New("order exceeds max notional")
+	ErrDuplicateOrder = errors.New("duplicate of an in-flight order")
+)
+
+// orderKey identifies the orders for a symbol and side
+type orderKey struct {
+	symbol string
+	side   strategy.TradeSignal
+}
+
+// SafeBroker wraps a broker with limits every order must pass, whatever the
+// logic that placed it: a maximum number of orders per minute, a maximum
+// notional per order and no second order for a symbol and side while one is
+// still in flight. Bracket orders are passed through if the wrapped broker
+// supports them, with the limits applied to the entry.
+type SafeBroker struct {
+	broker   Broker
+	limits   config.BrokerSafetyConfig
+	recent   []time.Time       // Times of the orders placed in the last minute
+	inFlight map[orderKey]bool // Orders placed but not yet answered
+	clock    clock.Clock
+	mu       sync.Mutex
+}
+
+// NewSafeBroker wraps broker with the given limits
+func NewSafeBroker(broker Broker, limits config.BrokerSafetyConfig) *SafeBroker {
+	return &SafeBroker{
+		broker:   broker,
+		limits:   limits,
+		inFlight: make(map[orderKey]bool),
+		clock:    clock.Real{},
+	}
+}
+
+// SetClock sets the clock the order rate is measured with, and that of the
+// wrapped broker if it has one
+func (s *SafeBroker) SetClock(c clock.Clock) {
+	s.mu.Lock()
+	s.clock = c
+	s.mu.Unlock()
+
+	if broker, ok := s.broker.(interface{ SetClock(clock.Clock) }); ok {
+		broker.SetClock(c)
+	}
+}
+
+// Capabilities reports the capabilities of the wrapped broker
+func (s *SafeBroker) Capabilities() Capabilities {
+	return brokerCapabilities(s.broker)
+}
+
+// OnOrderUpdate registers a callback for the order updates of the wrapped
+// broker. Without order streaming at the broker it is never called.
+func (s *SafeBroker) OnOrderUpdate(fn func(OrderUpdate)) {
+	if broker, ok := s.broker.(OrderStreamer); ok {
+		broker.OnOrderUpdate(fn)
+	}
+}
+
+// PlaceOrder places the order with the wrapped broker if it passes the limits
+func (s *SafeBroker) PlaceOrder(order Order) (*Fill, error) {
+	key, err := s.admit(order)
+	if err != nil {
+		return nil, err
+	}
+	defer s.release(key)
+
+	return s.broker.PlaceOrder(order)
+}
+
+// PlaceBracket places the bracket with the wrapped broker if its entry passes the limits
+func (s *SafeBroker) PlaceBracket(order BracketOrder) (*Fill, string, error) {
+	broker, ok := s.broker.(BracketBroker)
+	if !ok {
+		return nil, "", fmt.Errorf("broker does not support bracket orders")
+	}
+
+	key, err := s.admit(order.Entry)
+	if err != nil {
+		return nil, "", err
+	}
+	defer s.release(key)
+
+	return broker.PlaceBracket(order)
+}
+
+// ModifyBracket moves the target and stop of a bracket at the wrapped broker
+func (s *SafeBroker) ModifyBracket(bracketID string, targetPrice, stopPrice float64) error {
+	broker, ok := s.broker.(BracketBroker)
+	if !ok {
+		return fmt.Errorf("broker does not support bracket orders")
+	}
+	return broker.ModifyBracket(bracketID, targetPrice, stopPrice)
+}
+
+// CancelBracket cancels a bracket at the wrapped broker
+func (s *SafeBroker) CancelBracket(bracketID string) error {
+	broker, ok := s.broker.(BracketBroker)
+	if !ok {
+		return fmt.Errorf("broker does not support bracket orders")
+	}
+	return broker.CancelBracket(bracketID)
+}
+
+// BracketExits returns the bracket legs the wrapped broker filled
+func (s *SafeBroker) BracketExits(stocks map[string]*data.Stock) []BracketExit {
+	broker, ok := s.broker.(BracketBroker)
+	if !ok {
+		return nil
+	}
+	return broker.BracketExits(stocks)
+}
+
+// admit checks an order against the limits and, if it passes, counts it
+// toward the rate and marks it in flight
+func (s *SafeBroker) admit(order Order) (orderKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := orderKey{symbol: order.Symbol, side: order.Side}
+	if s.limits.RejectDuplicates && s.inFlight[key] {
+		return key, fmt.Errorf("%w: %s %s", ErrDuplicateOrder, order.Side, order.Symbol)
+	}
+	if notional := float64(order.Quantity) * order.Price; s.limits.MaxOrderNotional > 0 && notional > s.limits.MaxOrderNotional {
+		return key, fmt.Errorf("%w: %s %d %s worth $%.2f, limit $%.2f",
+			ErrOrderTooLarge, order.Side, order.Quantity, order.Symbol, notional, s.limits.MaxOrderNotional)
+	}
+
+	now := s.clock.Now()
+	if s.limits.MaxOrdersPerMinute > 0 {
+		cutoff := now.Add(-time.Minute)
+		kept := s.recent[:0]
+		for _, at := range s.recent {
+			if at.After(cutoff) {
+				kept = append(kept, at)
+			}
+		}
+		s.recent = kept
+		if len(s.recent) >= s.limits.MaxOrdersPerMinute {
+			return key, fmt.Errorf("%w: %d orders in the last minute", ErrOrderThrottled, len(s.recent))
+		}
+		s.recent = append(s.recent, now)
+	}
+
+	s.inFlight[key] = true
+	return key, nil
+}
+
+// release marks an order as answered by the wrapped broker
+func (s *SafeBroker) release(key orderKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.inFlight, key)
+}
@@ -0,0 +1,82 @@
+package execution
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/strategy"
+	"github.com/stretchr/testify/assert"
+)
+
+// blockingBroker holds every order until it is released
+type blockingBroker struct {
+	placed  chan Order
+	release chan struct{}
+}
+
+func (b blockingBroker) PlaceOrder(order Order) (*Fill, error) {
+	b.placed <- order
+	<-b.release
+	return &Fill{Symbol: order.Symbol, Side: order.Side, Quantity: order.Quantity, Price: order.Price}, nil
+}
+
+func TestSafeBrokerThrottlesAndCapsOrders(t *testing.T) {
+	fake := clock.NewFake(time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC))
+	broker := NewSafeBroker(NewPaperBroker(), config.BrokerSafetyConfig{MaxOrdersPerMinute: 2, MaxOrderNotional: 5000})
+	broker.SetClock(fake)
+
+	_, err := broker.PlaceOrder(Order{Symbol: "AAPL", Side: strategy.Buy, Quantity: 60, Price: 100})
+	assert.ErrorIs(t, err, ErrOrderTooLarge)
+
+	order := Order{Symbol: "AAPL", Side: strategy.Buy, Quantity: 10, Price: 100}
+	_, err = broker.PlaceOrder(order)
+	assert.NoError(t, err)
+	fake.Advance(30 * time.Second)
+	_, err = broker.PlaceOrder(order)
+	assert.NoError(t, err)
+	_, err = broker.PlaceOrder(order)
+	assert.ErrorIs(t, err, ErrOrderThrottled)
+
+	// The first order leaves the window after a minute
+	fake.Advance(31 * time.Second)
+	_, err = broker.PlaceOrder(order)
+	assert.NoError(t, err)
+
+	// Bracket support is passed through
+	assert.True(t, broker.Capabilities().BracketOrders)
+}
+
+func TestSafeBrokerRejectsDuplicateInFlightOrders(t *testing.T) {
+	inner := blockingBroker{placed: make(chan Order), release: make(chan struct{})}
+	broker := NewSafeBroker(inner, config.BrokerSafetyConfig{RejectDuplicates: true})
+	order := Order{Symbol: "AAPL", Side: strategy.Sell, Quantity: 10, Price: 100}
+
+	done := make(chan error)
+	go func() {
+		_, err := broker.PlaceOrder(order)
+		done <- err
+	}()
+	<-inner.placed
+
+	_, err := broker.PlaceOrder(order)
+	assert.ErrorIs(t, err, ErrDuplicateOrder)
+
+	// The other side of the same symbol is not a duplicate
+	go func() {
+		_, err := broker.PlaceOrder(Order{Symbol: "AAPL", Side: strategy.Buy, Quantity: 10, Price: 100})
+		done <- err
+	}()
+	<-inner.placed
+
+	close(inner.release)
+	assert.NoError(t, <-done)
+	assert.NoError(t, <-done)
+
+	// Once answered, the order may be placed again
+	go func() { <-inner.placed }()
+	_, err = broker.PlaceOrder(order)
+	assert.NoError(t, err)
+	assert.False(t, broker.Capabilities().BracketOrders)
+}
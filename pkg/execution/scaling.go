@@ -0,0 +1,175 @@
+package execution
+
+import (
+	"fmt"
+	"log"
+	"math"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/strategy"
+)
+
+// SetScalePlan sets how positions opened by a strategy are scaled into and out
+// of. The empty strategy name sets the plan for decisions without a strategy.
+func (t *TradeManager) SetScalePlan(strategyName string, plan config.ScalingConfig) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.scalePlans[strategyName] = plan
+}
+
+// trancheQuantity returns the quantity bought by the given entry tranche of a
+// planned position. Without entry tranches the first entry buys the full position.
+// Caller must hold the lock.
+func (t *TradeManager) trancheQuantity(strategyName string, planned, tranche int) int {
+	tranches := t.scalePlans[strategyName].EntryTranches
+	if len(tranches) == 0 {
+		if tranche == 0 {
+			return planned
+		}
+		return 0
+	}
+	if tranche >= len(tranches) {
+		return 0
+	}
+
+	quantity := int(math.Round(float64(planned) * tranches[tranche]))
+	if quantity == 0 && planned > 0 {
+		quantity = 1
+	}
+	return quantity
+}
+
+// canScaleIn reports whether a position has entry tranches left. Caller must hold the lock.
+func (t *TradeManager) canScaleIn(trade *Trade) bool {
+	return trade.ExitsTaken == 0 && trade.Entries < len(t.scalePlans[trade.Strategy].EntryTranches)
+}
+
+// scaleIn enters the next tranche of a position, buying into a long or selling
+// into a short, and updates its average entry price
+func (t *TradeManager) scaleIn(trade *Trade, decision *strategy.TradeDecision, stock *data.Stock) (*Trade, error) {
+	quantity := t.trancheQuantity(trade.Strategy, trade.PlannedQuantity, trade.Entries)
+	if quantity <= 0 {
+		return nil, fmt.Errorf("no entry tranches left for %s", trade.Symbol)
+	}
+
+	order := Order{Symbol: stock.Symbol, Side: trade.Type, Quantity: quantity, Price: stock.CurrentPrice}
+	checks, err := t.runChecks(order, trade, true)
+	if err != nil {
+		return nil, err
+	}
+	fill, err := t.broker.PlaceOrder(order)
+	if err != nil {
+		return nil, fmt.Errorf("failed to %s %s: %w", orderVerb(trade.Type), stock.Symbol, err)
+	}
+	trade.Checks = append(trade.Checks, checks...)
+
+	// Average the entry price over all entry fills
+	cost := float64(trade.Quantity)*trade.Price + float64(fill.Quantity)*fill.Price
+	trade.Quantity += fill.Quantity
+	trade.Price = cost / float64(trade.Quantity)
+	trade.Entries++
+	trade.Fills = append(trade.Fills, *fill)
+	trade.UpdatedAt = t.clock.Now()
+
+	// Record the tranche as its own trade linked to the position
+	addTrade := &Trade{
+		ID:         t.tradeID(stock.Symbol + "-add"),
+		Symbol:     stock.Symbol,
+		Quantity:   fill.Quantity,
+		Price:      fill.Price,
+		Type:       trade.Type,
+		Status:     Executed,
+		CreatedAt:  t.clock.Now(),
+		UpdatedAt:  t.clock.Now(),
+		Reason:     decision.Rationale,
+		PositionID: trade.ID,
+		Strategy:   trade.Strategy,
+		SignalID:   decision.SignalID,
+	}
+	t.recordTrade(addTrade)
+	t.recordSignalTrade(addTrade)
+
+	return addTrade, nil
+}
+
+// CheckScaleOut takes partial profits on active positions whose next scale-out
+// target has been reached, above the entry for longs and below it for shorts.
+// It returns the exit trades; a position is completed once its last share is exited.
+func (t *TradeManager) CheckScaleOut(stocks map[string]*data.Stock) []*Trade {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	exits := make([]*Trade, 0)
+
+	for _, trade := range t.activeTrades {
+		stock, exists := stocks[trade.Symbol]
+		if !exists {
+			continue
+		}
+
+		plan := t.scalePlans[trade.Strategy]
+		if trade.ExitsTaken >= len(plan.Exits) {
+			continue
+		}
+
+		step := plan.Exits[trade.ExitsTaken]
+		target := trade.Price * (1 + step.TargetPercent/100)
+		reached := stock.CurrentPrice >= target
+		if trade.IsShort() {
+			target = trade.Price * (1 - step.TargetPercent/100)
+			reached = stock.CurrentPrice <= target
+		}
+		if !reached {
+			continue
+		}
+
+		quantity := int(math.Round(float64(trade.PlannedQuantity) * step.Fraction))
+		if quantity <= 0 {
+			quantity = 1
+		}
+		if quantity > trade.Quantity {
+			quantity = trade.Quantity
+		}
+
+		fill, err := t.exitQuantity(trade, quantity, stock.CurrentPrice)
+		if err != nil {
+			log.Printf("Error scaling out of %s: %v", trade.Symbol, err)
+			continue
+		}
+
+		exitTrade := &Trade{
+			ID:         t.tradeID(trade.Symbol + "-scaleout"),
+			Symbol:     trade.Symbol,
+			Quantity:   fill.Quantity,
+			Price:      fill.Price,
+			Type:       opposite(trade.Type),
+			Status:     Executed,
+			CreatedAt:  t.clock.Now(),
+			UpdatedAt:  t.clock.Now(),
+			Reason:     fmt.Sprintf("Scale out: exited %d at $%.2f, target %.1f%% reached", fill.Quantity, fill.Price, step.TargetPercent),
+			PositionID: trade.ID,
+			Strategy:   trade.Strategy,
+		}
+		t.recordTrade(exitTrade)
+		exits = append(exits, exitTrade)
+
+		trade.Quantity -= fill.Quantity
+		trade.ExitsTaken++
+		trade.UpdatedAt = t.clock.Now()
+		if step.StopToBreakeven {
+			if trade.IsShort() && (trade.StopPrice == 0 || trade.StopPrice > trade.Price) {
+				trade.StopPrice = trade.Price
+			} else if !trade.IsShort() && trade.StopPrice < trade.Price {
+				trade.StopPrice = trade.Price
+			}
+		}
+
+		if trade.Quantity <= 0 {
+			t.completePosition(trade, exitTrade)
+		}
+	}
+
+	return exits
+}
@@ -0,0 +1,69 @@
+package execution
+
+import (
+	"testing"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/strategy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScaleInAndOut(t *testing.T) {
+	manager := NewTradeManager(10000, 1000)
+	manager.SetScalePlan("volatility", config.ScalingConfig{
+		EntryTranches: []float64{0.5, 0.5},
+		Exits: []config.ScaleOutConfig{
+			{TargetPercent: 2, Fraction: 0.5, StopToBreakeven: true},
+		},
+	})
+	buy := &strategy.TradeDecision{Symbol: "AAPL", Signal: strategy.Buy, Strategy: "volatility"}
+
+	// First tranche buys half of the 100 share position
+	position, err := manager.ExecuteTrade(buy, &data.Stock{Symbol: "AAPL", CurrentPrice: 100})
+	assert.NoError(t, err)
+	assert.Equal(t, 100, position.PlannedQuantity)
+	assert.Equal(t, 50, position.Quantity)
+
+	// Second tranche averages the entry price
+	add, err := manager.ExecuteTrade(buy, &data.Stock{Symbol: "AAPL", CurrentPrice: 96})
+	assert.NoError(t, err)
+	assert.Equal(t, position.ID, add.PositionID)
+	assert.Equal(t, 100, position.Quantity)
+	assert.InDelta(t, 98.0, position.Price, 0.0001)
+
+	// No tranches left
+	_, err = manager.ExecuteTrade(buy, &data.Stock{Symbol: "AAPL", CurrentPrice: 97})
+	assert.Error(t, err)
+
+	// Below the +2% target nothing is sold
+	assert.Empty(t, manager.CheckScaleOut(map[string]*data.Stock{"AAPL": {Symbol: "AAPL", CurrentPrice: 99}}))
+
+	// At the target half the position is sold and the stop moves to breakeven
+	exits := manager.CheckScaleOut(map[string]*data.Stock{"AAPL": {Symbol: "AAPL", CurrentPrice: 100}})
+	assert.Len(t, exits, 1)
+	assert.Equal(t, 50, exits[0].Quantity)
+	assert.Equal(t, 50, position.Quantity)
+	assert.InDelta(t, 98.0, position.StopPrice, 0.0001)
+	assert.InDelta(t, 100.0, position.RealizedPnL, 0.0001)
+
+	// The remainder is stopped out at breakeven
+	closed := manager.CheckStopLoss(map[string]*data.Stock{"AAPL": {Symbol: "AAPL", CurrentPrice: 98}})
+	assert.Len(t, closed, 1)
+	assert.Equal(t, 50, closed[0].Quantity)
+	assert.InDelta(t, 100.0, position.RealizedPnL, 0.0001)
+	assert.Len(t, position.Fills, 4)
+	assert.Empty(t, manager.GetActiveTrades())
+}
+
+func TestWithoutScalePlanEntersInFull(t *testing.T) {
+	manager := NewTradeManager(1000, 100)
+
+	trade, err := manager.ExecuteTrade(&strategy.TradeDecision{Symbol: "MSFT", Signal: strategy.Buy}, &data.Stock{Symbol: "MSFT", CurrentPrice: 100})
+	assert.NoError(t, err)
+	assert.Equal(t, 10, trade.Quantity)
+
+	_, err = manager.ExecuteTrade(&strategy.TradeDecision{Symbol: "MSFT", Signal: strategy.Buy}, &data.Stock{Symbol: "MSFT", CurrentPrice: 100})
+	assert.Error(t, err)
+	assert.Empty(t, manager.CheckScaleOut(map[string]*data.Stock{"MSFT": {Symbol: "MSFT", CurrentPrice: 200}}))
+}
@@ -0,0 +1,130 @@
+package execution
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/strategy"
+)
+
+// MarginChecker approves the margin a new short position ties up (implemented
+// by monitor.RiskManager)
+type MarginChecker interface {
+	// CheckMargin returns an error if a new short worth notional, on top of
+	// open shorts worth openShorts at entry, would exceed the margin available
+	CheckMargin(symbol string, notional, openShorts float64) error
+}
+
+// IsShort reports whether the trade opened a short position
+func (t *Trade) IsShort() bool {
+	return t.PositionID == "" && t.Type == strategy.Sell
+}
+
+// PnLAt returns the P&L of quantity shares of the position moving from its
+// average entry price to price: gains as the price rises for a long and as it
+// falls for a short
+func (t *Trade) PnLAt(quantity int, price float64) float64 {
+	pnl := float64(quantity) * (price - t.Price)
+	if t.IsShort() {
+		pnl = -pnl
+	}
+	return pnl
+}
+
+// orderVerb names the order side in errors and logs
+func orderVerb(side strategy.TradeSignal) string {
+	if side == strategy.Sell {
+		return "sell"
+	}
+	return "buy"
+}
+
+// SetShortSelling sets whether sell decisions for symbols without a position
+// open short positions. Off by default, when they are ignored.
+func (t *TradeManager) SetShortSelling(enabled bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.shortSelling = enabled
+}
+
+// SetMarginChecker sets the check new shorts must pass. Without one only the
+// capital per stock limits them.
+func (t *TradeManager) SetMarginChecker(margin MarginChecker) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.margin = margin
+}
+
+// checkShort refuses a short of planned shares, all tranches included, that
+// the margin check does not approve. Caller must hold the lock.
+func (t *TradeManager) checkShort(stock *data.Stock, planned int) error {
+	if t.margin == nil {
+		return nil
+	}
+
+	var openShorts float64
+	for _, trade := range t.activeTrades {
+		if trade.IsShort() {
+			openShorts += float64(trade.Quantity) * trade.Price
+		}
+	}
+	notional := float64(planned) * stock.CurrentPrice
+	if err := t.margin.CheckMargin(stock.Symbol, notional, openShorts); err != nil {
+		return fmt.Errorf("cannot short %s: %w", stock.Symbol, err)
+	}
+	return nil
+}
+
+// CheckTargets exits positions whose signal target has been reached: longs
+// once the price rises to it and shorts once it falls to it. Positions with a
+// bracket are left to the broker. It returns the exit trades.
+func (t *TradeManager) CheckTargets(stocks map[string]*data.Stock) []*Trade {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	closedTrades := make([]*Trade, 0)
+
+	for _, trade := range t.activeTrades {
+		stock, exists := stocks[trade.Symbol]
+		if !exists || trade.TargetPrice <= 0 || trade.BracketID != "" {
+			continue
+		}
+
+		reached := stock.CurrentPrice >= trade.TargetPrice
+		if trade.IsShort() {
+			reached = stock.CurrentPrice <= trade.TargetPrice
+		}
+		if !reached {
+			continue
+		}
+
+		fill, err := t.exit(trade, stock.CurrentPrice)
+		if err != nil {
+			log.Printf("Error closing %s at target: %v", trade.Symbol, err)
+			continue
+		}
+
+		exitTrade := &Trade{
+			ID:         t.tradeID(trade.Symbol + "-target"),
+			Symbol:     trade.Symbol,
+			Quantity:   fill.Quantity,
+			Price:      fill.Price,
+			Type:       opposite(trade.Type),
+			Status:     Executed,
+			CreatedAt:  t.clock.Now(),
+			UpdatedAt:  t.clock.Now(),
+			Reason:     fmt.Sprintf("Target reached: Price $%.2f hit target of $%.2f", stock.CurrentPrice, trade.TargetPrice),
+			PositionID: trade.ID,
+		}
+		t.recordTrade(exitTrade)
+		closedTrades = append(closedTrades, exitTrade)
+
+		// Complete the original trade, or keep the unfilled remainder open
+		t.finishExit(trade, exitTrade, fill)
+	}
+
+	return closedTrades
+}
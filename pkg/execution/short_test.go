@@ -0,0 +1,96 @@
+package execution
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/strategy"
+	"github.com/stretchr/testify/assert"
+)
+
+type fixedMargin struct {
+	capital float64
+}
+
+func (m fixedMargin) CheckMargin(symbol string, notional, openShorts float64) error {
+	if (notional+openShorts)/2 > m.capital {
+		return fmt.Errorf("not enough margin for %s", symbol)
+	}
+	return nil
+}
+
+func TestSellOpensShortOnlyWhenEnabled(t *testing.T) {
+	manager := NewTradeManager(1000, 100)
+	sell := &strategy.TradeDecision{Symbol: "AAPL", Signal: strategy.Sell, TargetPrice: 90, StopPrice: 105}
+	stock := &data.Stock{Symbol: "AAPL", CurrentPrice: 100}
+
+	_, err := manager.ExecuteTrade(sell, stock)
+	assert.Error(t, err)
+
+	manager.SetShortSelling(true)
+	short, err := manager.ExecuteTrade(sell, stock)
+	assert.NoError(t, err)
+	assert.True(t, short.IsShort())
+	assert.Equal(t, strategy.Sell, short.Fills[0].Side)
+
+	// A short gains as the price falls
+	pnl := manager.PnL(map[string]float64{"AAPL": 96}, time.Time{})
+	assert.InDelta(t, 40.0, pnl.Unrealized, 0.001)
+	assert.InDelta(t, 4.0, pnl.Positions[0].UnrealizedPct, 0.001)
+
+	// A buy covers it
+	cover, err := manager.ExecuteTrade(&strategy.TradeDecision{Symbol: "AAPL", Signal: strategy.Buy}, &data.Stock{Symbol: "AAPL", CurrentPrice: 95})
+	assert.NoError(t, err)
+	assert.Equal(t, strategy.Buy, cover.Type)
+	assert.Equal(t, short.ID, cover.PositionID)
+	assert.InDelta(t, 50.0, short.RealizedPnL, 0.001)
+	assert.Empty(t, manager.GetActiveTrades())
+}
+
+func TestShortStopsAboveEntryAndCoversAtTarget(t *testing.T) {
+	manager := NewTradeManager(1000, 100)
+	manager.SetShortSelling(true)
+
+	_, err := manager.ExecuteTrade(&strategy.TradeDecision{Symbol: "AAPL", Signal: strategy.Sell, TargetPrice: 90, StopPrice: 105}, &data.Stock{Symbol: "AAPL", CurrentPrice: 100})
+	assert.NoError(t, err)
+	_, err = manager.ExecuteTrade(&strategy.TradeDecision{Symbol: "MSFT", Signal: strategy.Sell, TargetPrice: 90, StopPrice: 105}, &data.Stock{Symbol: "MSFT", CurrentPrice: 100})
+	assert.NoError(t, err)
+
+	// A falling price is no stop for a short, a rising one is
+	stocks := map[string]*data.Stock{
+		"AAPL": {Symbol: "AAPL", CurrentPrice: 95},
+		"MSFT": {Symbol: "MSFT", CurrentPrice: 105},
+	}
+	closed := manager.CheckStopLoss(stocks)
+	assert.Len(t, closed, 1)
+	assert.Equal(t, "MSFT", closed[0].Symbol)
+	assert.Equal(t, strategy.Buy, closed[0].Type)
+	assert.Contains(t, closed[0].Reason, "hit stop of $105.00")
+
+	// The target is below the entry
+	assert.Empty(t, manager.CheckTargets(stocks))
+	stocks["AAPL"].CurrentPrice = 89
+	closed = manager.CheckTargets(stocks)
+	assert.Len(t, closed, 1)
+	assert.Contains(t, closed[0].Reason, "hit target of $90.00")
+	assert.Empty(t, manager.GetActiveTrades())
+}
+
+func TestShortRefusedWithoutMargin(t *testing.T) {
+	manager := NewTradeManager(1000, 100)
+	manager.SetShortSelling(true)
+	manager.SetMarginChecker(fixedMargin{capital: 800})
+
+	_, err := manager.ExecuteTrade(&strategy.TradeDecision{Symbol: "AAPL", Signal: strategy.Sell}, &data.Stock{Symbol: "AAPL", CurrentPrice: 100})
+	assert.NoError(t, err)
+
+	// The second short would take the margin to $1000
+	_, err = manager.ExecuteTrade(&strategy.TradeDecision{Symbol: "MSFT", Signal: strategy.Sell}, &data.Stock{Symbol: "MSFT", CurrentPrice: 100})
+	assert.ErrorContains(t, err, "not enough margin for MSFT")
+
+	// Longs need no margin
+	_, err = manager.ExecuteTrade(&strategy.TradeDecision{Symbol: "MSFT", Signal: strategy.Buy}, &data.Stock{Symbol: "MSFT", CurrentPrice: 100})
+	assert.NoError(t, err)
+}
@@ -1,11 +1,16 @@
 package execution
 
 import (
+	"errors"
 	"fmt"
+	"log"
 	"sync"
 	"time"
 
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/config"
 	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/ids"
 	"github.com/hustler/trading-bot/pkg/strategy"
 )
 
@@ -21,15 +26,57 @@ const (
 
 // Trade represents a trade
 type Trade struct {
-	ID        string
-	Symbol    string
-	Quantity  int
-	Price     float64
-	Type      strategy.TradeSignal
-	Status    TradeStatus
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	Reason    string
+	ID          string
+	Symbol      string
+	Quantity    int
+	Price       float64
+	Type        strategy.TradeSignal
+	Status      TradeStatus
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Reason      string
+	TargetPrice float64 // Target price from the originating signal, if any
+	StopPrice   float64 // Stop price from the originating signal, if any
+	PositionID  string  // For exits and scale-ins, the ID of the trade that opened the position
+	Strategy    string  // Strategy whose scaling plan manages the position
+	BracketID   string  // Set while the broker manages the target and stop as a bracket
+	SignalID    string  // Published signal the trade was entered for, if any
+
+	// Scale-in/scale-out state of a position. Quantity is the open quantity and
+	// Price the average entry price across all entry fills.
+	PlannedQuantity int     // Full position size the entry tranches add up to
+	Entries         int     // Number of entry tranches filled
+	ExitsTaken      int     // Number of partial exits taken
+	RealizedPnL     float64 // P&L realized by exits so far
+	Fills           []Fill  // Every entry and exit fill of the position
+
+	CorporateActions []string // Keys of the splits and dividends the position was adjusted for
+
+	Checks []CheckResult // Pre-trade check results of every order of the position
+
+	// Excursions of a position while open, in percent of the entry price
+	MAE float64 // Max adverse excursion: the furthest the price moved against the position
+	MFE float64 // Max favorable excursion: the furthest it moved in the position's favor
+}
+
+// ErrDuplicateSignal is returned when a signal that already opened or added to
+// a position is acted on again, e.g. after a retry or a replay
+var ErrDuplicateSignal = errors.New("signal already traded")
+
+// maxExitAttempts is the number of orders placed to fully exit a position that keeps filling partially
+const maxExitAttempts = 3
+
+// RiskSizer adjusts position sizes and stop limits per symbol
+type RiskSizer interface {
+	// PositionSize returns the number of shares to buy with capital at price
+	PositionSize(symbol string, price, capital float64) int
+	// StopLossLimit returns the maximum loss allowed on a position, given the base limit
+	StopLossLimit(symbol string, baseLimit float64) float64
+}
+
+// SymbolFilter decides whether a symbol may be traded (implemented by symbols.Lists)
+type SymbolFilter interface {
+	Allowed(symbol string) (bool, string)
 }
 
 // TradeManager manages trade execution
@@ -38,6 +85,21 @@ type TradeManager struct {
 	activeTrades   map[string]*Trade
 	capitalPerStock float64
 	maxLossPerTrade float64
+	sizer          RiskSizer
+	broker         Broker
+	scalePlans     map[string]config.ScalingConfig
+	symbolFilter   SymbolFilter
+	clock          clock.Clock
+	listeners      []func(Trade)
+	closeListeners []func(position, exit Trade)
+	signalTrades   map[string]string // Signal ID -> ID of the entry trade it produced
+	shortSelling   bool              // Sell decisions without a position open shorts
+	margin         MarginChecker     // Approves the margin of new shorts; nil approves all
+	audit          AuditLog          // Records manual changes to open trades
+	checks         []PreTradeCheck   // Compliance checks every order must pass
+	quotes         QuoteSource       // Fresh quotes entries are checked against; nil skips the check
+	priceCheck     config.PriceCheckConfig
+	pairs          map[string]*PairTrade // Pair trades by ID
 	mu             sync.RWMutex
 }
 
@@ -48,6 +110,92 @@ func NewTradeManager(capitalPerStock, maxLossPerTrade float64) *TradeManager {
 		activeTrades:   make(map[string]*Trade),
 		capitalPerStock: capitalPerStock,
 		maxLossPerTrade: maxLossPerTrade,
+		broker:         NewPaperBroker(),
+		scalePlans:     make(map[string]config.ScalingConfig),
+		clock:          clock.Real{},
+		signalTrades:   make(map[string]string),
+		pairs:          make(map[string]*PairTrade),
+	}
+}
+
+// SetClock sets the clock trades are timestamped with. A paper broker is set to
+// the same clock so fills line up with trades.
+func (t *TradeManager) SetClock(c clock.Clock) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.clock = c
+	if broker, ok := t.broker.(interface{ SetClock(clock.Clock) }); ok {
+		broker.SetClock(c)
+	}
+}
+
+// tradeID returns a unique trade ID with the given prefix. Caller must hold the lock.
+func (t *TradeManager) tradeID(prefix string) string {
+	return ids.New(prefix, t.clock.Now())
+}
+
+// SetBroker sets the broker orders are placed with (a PaperBroker by default)
+func (t *TradeManager) SetBroker(broker Broker) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.broker = broker
+}
+
+// SetSymbolFilter sets the blacklist/allowlist checked before positions are opened or added to
+func (t *TradeManager) SetSymbolFilter(filter SymbolFilter) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.symbolFilter = filter
+}
+
+// SetRiskSizer sets the sizer used for position sizes and stop limits
+func (t *TradeManager) SetRiskSizer(sizer RiskSizer) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.sizer = sizer
+}
+
+// OnTrade registers a callback fired for every entry, scale-in and exit trade.
+// Callbacks run asynchronously with a copy of the trade.
+func (t *TradeManager) OnTrade(fn func(Trade)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.listeners = append(t.listeners, fn)
+}
+
+// OnPositionClosed registers a callback fired when a position is fully exited,
+// with the completed position and the trade that closed it. Callbacks run
+// asynchronously with copies of both trades.
+func (t *TradeManager) OnPositionClosed(fn func(position, exit Trade)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.closeListeners = append(t.closeListeners, fn)
+}
+
+// recordTrade adds a trade to the trade log and notifies listeners. Caller must hold the lock.
+func (t *TradeManager) recordTrade(trade *Trade) {
+	t.trades[trade.ID] = trade
+	for _, fn := range t.listeners {
+		go fn(*trade)
+	}
+}
+
+// completePosition marks a fully exited position completed and notifies close
+// listeners. Caller must hold the lock.
+func (t *TradeManager) completePosition(trade, exit *Trade) {
+	delete(t.activeTrades, trade.ID)
+	trade.Status = Completed
+
+	position := *trade
+	position.Fills = append([]Fill(nil), trade.Fills...)
+	for _, fn := range t.closeListeners {
+		go fn(position, *exit)
 	}
 }
 
@@ -56,18 +204,43 @@ func (t *TradeManager) ExecuteTrade(decision *strategy.TradeDecision, stock *dat
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
+	// Enter at most once per signal, however often it is delivered. Only
+	// entries are recorded, so exits are never refused.
+	if decision.SignalID != "" {
+		if tradeID, exists := t.signalTrades[decision.SignalID]; exists {
+			return nil, fmt.Errorf("signal %s already opened trade %s: %w", decision.SignalID, tradeID, ErrDuplicateSignal)
+		}
+	}
+
 	// Check if we already have an active trade for this symbol
 	if activeTrade, exists := t.getActiveTradeForSymbol(decision.Symbol); exists {
-		// If we have an active trade and the decision is to sell, close the position
-		if decision.Signal == strategy.Sell {
+		// If the decision is against the position, close it: sell a long, cover a short
+		if decision.Signal == opposite(activeTrade.Type) {
 			return t.closePosition(activeTrade, decision, stock)
 		}
-		// If we have an active trade and the decision is not to sell, do nothing
+		// Add the next entry tranche if the position is being scaled into
+		if decision.Signal == activeTrade.Type && t.canScaleIn(activeTrade) {
+			if err := t.checkSymbol(decision.Symbol, stock); err != nil {
+				return nil, err
+			}
+			if err := t.checkQuote(decision); err != nil {
+				return nil, err
+			}
+			return t.scaleIn(activeTrade, decision, stock)
+		}
+		// If we have an active trade and the decision does not change it, do nothing
 		return nil, fmt.Errorf("already have an active trade for %s", decision.Symbol)
 	}
 
-	// If we don't have an active trade and the decision is to buy, open a position
-	if decision.Signal == strategy.Buy {
+	// If we don't have an active trade, buy to open a long position or, with
+	// short selling enabled, sell to open a short one
+	if decision.Signal == strategy.Buy || (decision.Signal == strategy.Sell && t.shortSelling) {
+		if err := t.checkSymbol(decision.Symbol, stock); err != nil {
+			return nil, err
+		}
+		if err := t.checkQuote(decision); err != nil {
+			return nil, err
+		}
 		return t.openPosition(decision, stock)
 	}
 
@@ -75,6 +248,55 @@ func (t *TradeManager) ExecuteTrade(decision *strategy.TradeDecision, stock *dat
 	return nil, fmt.Errorf("no action needed for %s", decision.Symbol)
 }
 
+// checkSymbol refuses new exposure to halted symbols and symbols the filter does
+// not allow. Exits are never blocked. Caller must hold the lock.
+func (t *TradeManager) checkSymbol(symbol string, stock *data.Stock) error {
+	if stock != nil && stock.Halted {
+		return fmt.Errorf("cannot trade %s: trading halted", symbol)
+	}
+	if t.symbolFilter == nil {
+		return nil
+	}
+	if ok, reason := t.symbolFilter.Allowed(symbol); !ok {
+		return fmt.Errorf("cannot trade %s: %s", symbol, reason)
+	}
+	return nil
+}
+
+// recordSignalTrade remembers the entry trade of a signal. Caller must hold the lock.
+func (t *TradeManager) recordSignalTrade(trade *Trade) {
+	if trade.SignalID != "" {
+		t.signalTrades[trade.SignalID] = trade.ID
+	}
+}
+
+// TradeForSignal returns the entry trade opened for a signal
+func (t *TradeManager) TradeForSignal(signalID string) (*Trade, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	trade, exists := t.trades[t.signalTrades[signalID]]
+	return trade, exists
+}
+
+// SignalTrades returns copies of the entry trades opened for signals
+func (t *TradeManager) SignalTrades() []Trade {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	trades := make([]Trade, 0, len(t.signalTrades))
+	for _, tradeID := range t.signalTrades {
+		trade, exists := t.trades[tradeID]
+		if !exists {
+			continue
+		}
+		tradeCopy := *trade
+		tradeCopy.Fills = append([]Fill(nil), trade.Fills...)
+		trades = append(trades, tradeCopy)
+	}
+	return trades
+}
+
 // getActiveTradeForSymbol gets an active trade for a symbol
 func (t *TradeManager) getActiveTradeForSymbol(symbol string) (*Trade, bool) {
 	for _, trade := range t.activeTrades {
@@ -85,62 +307,174 @@ func (t *TradeManager) getActiveTradeForSymbol(symbol string) (*Trade, bool) {
 	return nil, false
 }
 
-// openPosition opens a new position
+// openPosition opens a new position: long on a buy decision, short on a sell
 func (t *TradeManager) openPosition(decision *strategy.TradeDecision, stock *data.Stock) (*Trade, error) {
 	// Calculate quantity based on capital per stock
-	quantity := int(t.capitalPerStock / stock.CurrentPrice)
+	planned := int(t.capitalPerStock / stock.CurrentPrice)
+	if t.sizer != nil {
+		planned = t.sizer.PositionSize(stock.Symbol, stock.CurrentPrice, t.capitalPerStock)
+	}
+	if decision.MaxQuantity > 0 && planned > decision.MaxQuantity {
+		planned = decision.MaxQuantity
+	}
+	quantity := t.trancheQuantity(decision.Strategy, planned, 0)
 	if quantity <= 0 {
-		return nil, fmt.Errorf("insufficient capital to buy %s at $%.2f", stock.Symbol, stock.CurrentPrice)
+		return nil, fmt.Errorf("insufficient capital to trade %s at $%.2f", stock.Symbol, stock.CurrentPrice)
+	}
+	if decision.Signal == strategy.Sell {
+		if err := t.checkShort(stock, planned); err != nil {
+			return nil, err
+		}
+	}
+
+	// Submit entry, target and stop as one bracket when the broker supports it
+	order := Order{Symbol: stock.Symbol, Side: decision.Signal, Quantity: quantity, Price: stock.CurrentPrice}
+	checks, err := t.runChecks(order, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	var fill *Fill
+	var bracketID string
+	if broker, ok := t.useBracket(decision); ok {
+		fill, bracketID, err = broker.PlaceBracket(BracketOrder{Entry: order, TargetPrice: decision.TargetPrice, StopPrice: decision.StopPrice})
+	} else {
+		fill, err = t.broker.PlaceOrder(order)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to %s %s: %w", orderVerb(decision.Signal), stock.Symbol, err)
 	}
 
 	// Create a new trade
 	trade := &Trade{
-		ID:        fmt.Sprintf("%s-%d", stock.Symbol, time.Now().UnixNano()),
+		ID:        t.tradeID(stock.Symbol),
 		Symbol:    stock.Symbol,
-		Quantity:  quantity,
-		Price:     stock.CurrentPrice,
-		Type:      strategy.Buy,
+		Quantity:  fill.Quantity,
+		Price:     fill.Price,
+		Type:      decision.Signal,
 		Status:    Executed,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		CreatedAt: t.clock.Now(),
+		UpdatedAt: t.clock.Now(),
 		Reason:    decision.Rationale,
+		Strategy:  decision.Strategy,
+
+		TargetPrice: decision.TargetPrice,
+		StopPrice:   decision.StopPrice,
+		BracketID:   bracketID,
+		SignalID:    decision.SignalID,
+
+		PlannedQuantity: planned,
+		Entries:         1,
+		Fills:           []Fill{*fill},
+
+		Checks: checks,
 	}
 
 	// Add to trades and active trades
-	t.trades[trade.ID] = trade
+	t.recordTrade(trade)
 	t.activeTrades[trade.ID] = trade
+	t.recordSignalTrade(trade)
 
 	return trade, nil
 }
 
 // closePosition closes an existing position
 func (t *TradeManager) closePosition(trade *Trade, decision *strategy.TradeDecision, stock *data.Stock) (*Trade, error) {
+	fill, err := t.exit(trade, stock.CurrentPrice)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create a new trade for the sell
 	sellTrade := &Trade{
-		ID:        fmt.Sprintf("%s-sell-%d", stock.Symbol, time.Now().UnixNano()),
-		Symbol:    stock.Symbol,
-		Quantity:  trade.Quantity,
-		Price:     stock.CurrentPrice,
-		Type:      strategy.Sell,
-		Status:    Executed,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-		Reason:    decision.Rationale,
+		ID:         t.tradeID(stock.Symbol + "-sell"),
+		Symbol:     stock.Symbol,
+		Quantity:   fill.Quantity,
+		Price:      fill.Price,
+		Type:       opposite(trade.Type),
+		Status:     Executed,
+		CreatedAt:  t.clock.Now(),
+		UpdatedAt:  t.clock.Now(),
+		Reason:     decision.Rationale,
+		PositionID: trade.ID,
 	}
 
 	// Add to trades
-	t.trades[sellTrade.ID] = sellTrade
+	t.recordTrade(sellTrade)
 
-	// Remove from active trades
-	delete(t.activeTrades, trade.ID)
-
-	// Update original trade
-	trade.Status = Completed
-	trade.UpdatedAt = time.Now()
+	// Complete the original trade, or keep the unfilled remainder open
+	t.finishExit(trade, sellTrade, fill)
 
 	return sellTrade, nil
 }
 
+// exit places orders to exit the whole open quantity of a position with the
+// broker, selling a long or buying to cover a short, and resubmits the
+// remainder of partial fills. The returned fill aggregates all fills at their
+// average price and may be short of the open quantity if the broker kept
+// filling partially.
+func (t *TradeManager) exit(trade *Trade, price float64) (*Fill, error) {
+	if err := t.cancelBracket(trade); err != nil {
+		return nil, err
+	}
+
+	var total *Fill
+	remaining := trade.Quantity
+	for attempt := 0; attempt < maxExitAttempts && remaining > 0; attempt++ {
+		fill, err := t.exitQuantity(trade, remaining, price)
+		if err != nil {
+			if total == nil {
+				return nil, err
+			}
+			log.Printf("Error exiting remaining %d %s: %v", remaining, trade.Symbol, err)
+			break
+		}
+		remaining -= fill.Quantity
+
+		if total == nil {
+			total = fill
+			continue
+		}
+		cost := float64(total.Quantity)*total.Price + float64(fill.Quantity)*fill.Price
+		total.Quantity += fill.Quantity
+		total.Price = cost / float64(total.Quantity)
+		total.FilledAt = fill.FilledAt
+	}
+
+	return total, nil
+}
+
+// finishExit completes a position after a full exit, or reduces it to the
+// unfilled remainder after a partial one. Caller must hold the lock.
+func (t *TradeManager) finishExit(trade, exit *Trade, fill *Fill) {
+	trade.UpdatedAt = t.clock.Now()
+	if fill.Quantity < trade.Quantity {
+		trade.Quantity -= fill.Quantity
+		return
+	}
+
+	t.completePosition(trade, exit)
+}
+
+// exitQuantity places an order to exit part of a position and records the fill
+// and realized P&L on the position
+func (t *TradeManager) exitQuantity(trade *Trade, quantity int, price float64) (*Fill, error) {
+	order := Order{Symbol: trade.Symbol, Side: opposite(trade.Type), Quantity: quantity, Price: price}
+	checks, err := t.runChecks(order, trade, false)
+	if err != nil {
+		return nil, err
+	}
+	fill, err := t.broker.PlaceOrder(order)
+	if err != nil {
+		return nil, fmt.Errorf("failed to %s %s: %w", orderVerb(order.Side), trade.Symbol, err)
+	}
+
+	trade.Checks = append(trade.Checks, checks...)
+	trade.Fills = append(trade.Fills, *fill)
+	trade.RealizedPnL += trade.PnLAt(fill.Quantity, fill.Price)
+	trade.recordExcursion(fill.Price)
+	return fill, nil
+}
+
 // CancelTrade cancels a trade
 func (t *TradeManager) CancelTrade(tradeID string) error {
 	t.mu.Lock()
@@ -155,8 +489,12 @@ func (t *TradeManager) CancelTrade(tradeID string) error {
 		return fmt.Errorf("cannot cancel completed trade: %s", tradeID)
 	}
 
+	if err := t.cancelBracket(trade); err != nil {
+		return err
+	}
+
 	trade.Status = Cancelled
-	trade.UpdatedAt = time.Now()
+	trade.UpdatedAt = t.clock.Now()
 
 	// Remove from active trades if it's there
 	delete(t.activeTrades, tradeID)
@@ -197,6 +535,39 @@ func (t *TradeManager) GetActiveTrades() []*Trade {
 	return trades
 }
 
+// AdjustLevels updates the target and stop of the active trade for a symbol.
+// A zero value leaves that level unchanged.
+func (t *TradeManager) AdjustLevels(symbol string, targetPrice, stopPrice float64) (*Trade, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	trade, exists := t.getActiveTradeForSymbol(symbol)
+	if !exists {
+		return nil, fmt.Errorf("no active trade for %s", symbol)
+	}
+
+	// Bracket exits live at the broker, so move them there first
+	if trade.BracketID != "" {
+		broker, ok := t.bracketBroker()
+		if !ok {
+			return nil, fmt.Errorf("broker no longer supports bracket orders")
+		}
+		if err := broker.ModifyBracket(trade.BracketID, targetPrice, stopPrice); err != nil {
+			return nil, fmt.Errorf("failed to modify bracket for %s: %w", symbol, err)
+		}
+	}
+
+	if targetPrice > 0 {
+		trade.TargetPrice = targetPrice
+	}
+	if stopPrice > 0 {
+		trade.StopPrice = stopPrice
+	}
+	trade.UpdatedAt = t.clock.Now()
+
+	return trade, nil
+}
+
 // CheckStopLoss checks if any active trades have hit their stop loss
 func (t *TradeManager) CheckStopLoss(stocks map[string]*data.Stock) []*Trade {
 	t.mu.Lock()
@@ -204,42 +575,60 @@ func (t *TradeManager) CheckStopLoss(stocks map[string]*data.Stock) []*Trade {
 
 	closedTrades := make([]*Trade, 0)
 
-	for id, trade := range t.activeTrades {
+	for _, trade := range t.activeTrades {
 		stock, exists := stocks[trade.Symbol]
 		if !exists {
 			continue
 		}
+		trade.recordExcursion(stock.CurrentPrice)
+		if trade.BracketID != "" {
+			continue
+		}
 
-		// Calculate current value and loss
-		currentValue := float64(trade.Quantity) * stock.CurrentPrice
-		entryValue := float64(trade.Quantity) * trade.Price
-		loss := entryValue - currentValue
+		// Calculate the loss, which for a short grows as the price rises
+		loss := -trade.PnLAt(trade.Quantity, stock.CurrentPrice)
+
+		maxLoss := t.maxLossPerTrade
+		if t.sizer != nil {
+			maxLoss = t.sizer.StopLossLimit(trade.Symbol, maxLoss)
+		}
+
+		// Close the position if the loss exceeds the max loss per trade or the stop price is hit
+		reason := fmt.Sprintf("Stop loss triggered: Loss of $%.2f exceeds max loss of $%.2f", loss, maxLoss)
+		stopHit := trade.StopPrice > 0 && stock.CurrentPrice <= trade.StopPrice
+		if trade.IsShort() {
+			stopHit = trade.StopPrice > 0 && stock.CurrentPrice >= trade.StopPrice
+		}
+		if stopHit && loss <= maxLoss {
+			reason = fmt.Sprintf("Stop loss triggered: Price $%.2f hit stop of $%.2f", stock.CurrentPrice, trade.StopPrice)
+		}
+		if loss > maxLoss || stopHit {
+			fill, err := t.exit(trade, stock.CurrentPrice)
+			if err != nil {
+				log.Printf("Error closing %s at stop loss: %v", trade.Symbol, err)
+				continue
+			}
 
-		// If loss exceeds max loss per trade, close the position
-		if loss > t.maxLossPerTrade {
 			// Create a new trade for the sell
 			sellTrade := &Trade{
-				ID:        fmt.Sprintf("%s-stoploss-%d", trade.Symbol, time.Now().UnixNano()),
-				Symbol:    trade.Symbol,
-				Quantity:  trade.Quantity,
-				Price:     stock.CurrentPrice,
-				Type:      strategy.Sell,
-				Status:    Executed,
-				CreatedAt: time.Now(),
-				UpdatedAt: time.Now(),
-				Reason:    fmt.Sprintf("Stop loss triggered: Loss of $%.2f exceeds max loss of $%.2f", loss, t.maxLossPerTrade),
+				ID:         t.tradeID(trade.Symbol + "-stoploss"),
+				Symbol:     trade.Symbol,
+				Quantity:   fill.Quantity,
+				Price:      fill.Price,
+				Type:       opposite(trade.Type),
+				Status:     Executed,
+				CreatedAt:  t.clock.Now(),
+				UpdatedAt:  t.clock.Now(),
+				Reason:     reason,
+				PositionID: trade.ID,
 			}
 
 			// Add to trades
-			t.trades[sellTrade.ID] = sellTrade
+			t.recordTrade(sellTrade)
 			closedTrades = append(closedTrades, sellTrade)
 
-			// Remove from active trades
-			delete(t.activeTrades, id)
-
-			// Update original trade
-			trade.Status = Completed
-			trade.UpdatedAt = time.Now()
+			// Complete the original trade, or keep the unfilled remainder open
+			t.finishExit(trade, sellTrade, fill)
 		}
 	}
 
@@ -251,37 +640,63 @@ func (t *TradeManager) CloseAllPositions(stocks map[string]*data.Stock) []*Trade
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
+	ids := make([]string, 0, len(t.activeTrades))
+	for id := range t.activeTrades {
+		ids = append(ids, id)
+	}
+
+	return t.closeTrades(ids, stocks, "End of trading day - closing all positions")
+}
+
+// ClosePositions closes the active positions with the given trade IDs
+func (t *TradeManager) ClosePositions(tradeIDs []string, stocks map[string]*data.Stock, reason string) []*Trade {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.closeTrades(tradeIDs, stocks, reason)
+}
+
+// closeTrades sells active positions at the latest quotes. Caller must hold the lock.
+func (t *TradeManager) closeTrades(tradeIDs []string, stocks map[string]*data.Stock, reason string) []*Trade {
 	closedTrades := make([]*Trade, 0)
 
-	for id, trade := range t.activeTrades {
+	for _, id := range tradeIDs {
+		trade, active := t.activeTrades[id]
+		if !active {
+			continue
+		}
+
 		stock, exists := stocks[trade.Symbol]
 		if !exists {
 			continue
 		}
 
+		fill, err := t.exit(trade, stock.CurrentPrice)
+		if err != nil {
+			log.Printf("Error closing %s: %v", trade.Symbol, err)
+			continue
+		}
+
 		// Create a new trade for the sell
 		sellTrade := &Trade{
-			ID:        fmt.Sprintf("%s-close-%d", trade.Symbol, time.Now().UnixNano()),
-			Symbol:    trade.Symbol,
-			Quantity:  trade.Quantity,
-			Price:     stock.CurrentPrice,
-			Type:      strategy.Sell,
-			Status:    Executed,
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
-			Reason:    "End of trading day - closing all positions",
+			ID:         t.tradeID(trade.Symbol + "-close"),
+			Symbol:     trade.Symbol,
+			Quantity:   fill.Quantity,
+			Price:      fill.Price,
+			Type:       opposite(trade.Type),
+			Status:     Executed,
+			CreatedAt:  t.clock.Now(),
+			UpdatedAt:  t.clock.Now(),
+			Reason:     reason,
+			PositionID: trade.ID,
 		}
 
 		// Add to trades
-		t.trades[sellTrade.ID] = sellTrade
+		t.recordTrade(sellTrade)
 		closedTrades = append(closedTrades, sellTrade)
 
-		// Remove from active trades
-		delete(t.activeTrades, id)
-
-		// Update original trade
-		trade.Status = Completed
-		trade.UpdatedAt = time.Now()
+		// Complete the original trade, or keep the unfilled remainder open
+		t.finishExit(trade, sellTrade, fill)
 	}
 
 	return closedTrades
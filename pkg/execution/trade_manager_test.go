@@ -0,0 +1,145 @@
+package execution
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/strategy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdjustLevelsTriggersStopPrice(t *testing.T) {
+	manager := NewTradeManager(1000, 100)
+
+	_, err := manager.ExecuteTrade(&strategy.TradeDecision{Symbol: "AAPL", Signal: strategy.Buy}, &data.Stock{Symbol: "AAPL", CurrentPrice: 100})
+	assert.NoError(t, err)
+
+	_, err = manager.AdjustLevels("MSFT", 110, 98)
+	assert.Error(t, err)
+
+	trade, err := manager.AdjustLevels("AAPL", 110, 98)
+	assert.NoError(t, err)
+	assert.Equal(t, 110.0, trade.TargetPrice)
+	assert.Equal(t, 98.0, trade.StopPrice)
+
+	// A zero value leaves the level unchanged
+	trade, err = manager.AdjustLevels("AAPL", 0, 98.5)
+	assert.NoError(t, err)
+	assert.Equal(t, 110.0, trade.TargetPrice)
+	assert.Equal(t, 98.5, trade.StopPrice)
+
+	// Above the stop nothing happens
+	assert.Empty(t, manager.CheckStopLoss(map[string]*data.Stock{"AAPL": {Symbol: "AAPL", CurrentPrice: 99}}))
+
+	// The $15 loss is within the $100 limit, but the stop price closes the trade
+	closed := manager.CheckStopLoss(map[string]*data.Stock{"AAPL": {Symbol: "AAPL", CurrentPrice: 98.5}})
+	assert.Len(t, closed, 1)
+	assert.Contains(t, closed[0].Reason, "hit stop of $98.50")
+	assert.Empty(t, manager.GetActiveTrades())
+}
+
+func TestClosePositionsClosesOnlyGivenTrades(t *testing.T) {
+	manager := NewTradeManager(1000, 100)
+
+	aapl, err := manager.ExecuteTrade(&strategy.TradeDecision{Symbol: "AAPL", Signal: strategy.Buy}, &data.Stock{Symbol: "AAPL", CurrentPrice: 100})
+	assert.NoError(t, err)
+	_, err = manager.ExecuteTrade(&strategy.TradeDecision{Symbol: "MSFT", Signal: strategy.Buy}, &data.Stock{Symbol: "MSFT", CurrentPrice: 200})
+	assert.NoError(t, err)
+
+	stocks := map[string]*data.Stock{
+		"AAPL": {Symbol: "AAPL", CurrentPrice: 101},
+		"MSFT": {Symbol: "MSFT", CurrentPrice: 202},
+	}
+	closed := manager.ClosePositions([]string{aapl.ID}, stocks, "earnings")
+	assert.Len(t, closed, 1)
+	assert.Equal(t, aapl.ID, closed[0].PositionID)
+	assert.Equal(t, 101.0, closed[0].Price)
+	assert.Equal(t, "earnings", closed[0].Reason)
+
+	active := manager.GetActiveTrades()
+	assert.Len(t, active, 1)
+	assert.Equal(t, "MSFT", active[0].Symbol)
+}
+
+// blockList blocks the listed symbols
+type blockList map[string]bool
+
+func (b blockList) Allowed(symbol string) (bool, string) {
+	if b[symbol] {
+		return false, symbol + " is blacklisted"
+	}
+	return true, ""
+}
+
+func TestSymbolFilterBlocksEntriesOnly(t *testing.T) {
+	manager := NewTradeManager(1000, 100)
+
+	_, err := manager.ExecuteTrade(&strategy.TradeDecision{Symbol: "GME", Signal: strategy.Buy}, &data.Stock{Symbol: "GME", CurrentPrice: 20})
+	assert.NoError(t, err)
+
+	manager.SetSymbolFilter(blockList{"GME": true})
+	_, err = manager.ExecuteTrade(&strategy.TradeDecision{Symbol: "GME", Signal: strategy.Sell}, &data.Stock{Symbol: "GME", CurrentPrice: 21})
+	assert.NoError(t, err)
+
+	_, err = manager.ExecuteTrade(&strategy.TradeDecision{Symbol: "GME", Signal: strategy.Buy}, &data.Stock{Symbol: "GME", CurrentPrice: 21})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "blacklisted")
+}
+
+func TestHaltedSymbolsBlockEntries(t *testing.T) {
+	manager := NewTradeManager(1000, 100)
+
+	_, err := manager.ExecuteTrade(&strategy.TradeDecision{Symbol: "XYZ", Signal: strategy.Buy}, &data.Stock{Symbol: "XYZ", CurrentPrice: 20, Halted: true})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "trading halted")
+
+	_, err = manager.ExecuteTrade(&strategy.TradeDecision{Symbol: "XYZ", Signal: strategy.Buy}, &data.Stock{Symbol: "XYZ", CurrentPrice: 20})
+	assert.NoError(t, err)
+}
+
+func TestTradesUseInjectedClock(t *testing.T) {
+	now := time.Date(2025, 4, 21, 14, 0, 0, 0, time.UTC)
+	manager := NewTradeManager(1000, 100)
+	manager.SetClock(clock.NewFake(now))
+
+	buy, err := manager.ExecuteTrade(&strategy.TradeDecision{Symbol: "AAPL", Signal: strategy.Buy}, &data.Stock{Symbol: "AAPL", CurrentPrice: 100})
+	assert.NoError(t, err)
+	assert.Equal(t, now, buy.CreatedAt)
+	assert.Equal(t, now, buy.Fills[0].FilledAt)
+
+	// The clock stands still, but trade IDs stay unique
+	sell, err := manager.ExecuteTrade(&strategy.TradeDecision{Symbol: "AAPL", Signal: strategy.Sell}, &data.Stock{Symbol: "AAPL", CurrentPrice: 101})
+	assert.NoError(t, err)
+	assert.Equal(t, now, sell.CreatedAt)
+	assert.NotEqual(t, buy.ID, sell.ID)
+	assert.Len(t, manager.GetAllTrades(), 2)
+}
+
+func TestSignalIsTradedOnce(t *testing.T) {
+	manager := NewTradeManager(1000, 100)
+	decision := &strategy.TradeDecision{Symbol: "AAPL", Signal: strategy.Buy, SignalID: "SIG-AAPL-BUY-1"}
+	stock := &data.Stock{Symbol: "AAPL", CurrentPrice: 100}
+
+	trade, err := manager.ExecuteTrade(decision, stock)
+	assert.NoError(t, err)
+	assert.Equal(t, "SIG-AAPL-BUY-1", trade.SignalID)
+
+	// A retried signal is refused even after its position was closed
+	_, err = manager.ExecuteTrade(decision, stock)
+	assert.ErrorIs(t, err, ErrDuplicateSignal)
+	manager.CloseAllPositions(map[string]*data.Stock{"AAPL": stock})
+	_, err = manager.ExecuteTrade(decision, stock)
+	assert.ErrorIs(t, err, ErrDuplicateSignal)
+
+	linked, ok := manager.TradeForSignal("SIG-AAPL-BUY-1")
+	assert.True(t, ok)
+	assert.Equal(t, trade.ID, linked.ID)
+	_, ok = manager.TradeForSignal("SIG-MSFT-BUY-1")
+	assert.False(t, ok)
+
+	// A new signal for the same symbol opens a new position
+	_, err = manager.ExecuteTrade(&strategy.TradeDecision{Symbol: "AAPL", Signal: strategy.Buy, SignalID: "SIG-AAPL-BUY-2"}, stock)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,117 @@
+package features
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"sync"
+
+	"github.com/hustler/trading-bot/pkg/config"
+)
+
+// Gated behaviors
+const (
+	Ensemble  = "ensemble"   // Strategy ensemble voting, rolled out per symbol
+	PublicAPI = "public_api" // Unauthenticated read-only API for a public website; off unless configured
+)
+
+// Defaults are the flags in effect when the config does not mention them, so
+// behavior that predates its flag stays on
+var Defaults = map[string]config.FeatureFlag{
+	Ensemble: {Enabled: true},
+}
+
+// Flag is the current state of a feature flag
+type Flag struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	Rollout int    `json:"rollout"` // Percent of keys the flag is on for; 0 is everyone
+	Toggled bool   `json:"toggled"` // Changed at runtime since startup
+}
+
+// Flags holds feature flags loaded from config, which can be toggled at
+// runtime. Runtime changes last until restart.
+type Flags struct {
+	flags   map[string]config.FeatureFlag
+	toggled map[string]bool
+	mu      sync.RWMutex
+}
+
+// NewFlags creates the flags from cfg on top of Defaults
+func NewFlags(cfg map[string]config.FeatureFlag) *Flags {
+	flags := make(map[string]config.FeatureFlag, len(Defaults)+len(cfg))
+	for name, flag := range Defaults {
+		flags[name] = flag
+	}
+	for name, flag := range cfg {
+		flags[name] = flag
+	}
+	return &Flags{flags: flags, toggled: make(map[string]bool)}
+}
+
+// Enabled reports whether a flag is on for everyone. Undefined flags are off.
+func (f *Flags) Enabled(name string) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	flag, ok := f.flags[name]
+	return ok && flag.Enabled && (flag.Rollout == 0 || flag.Rollout >= 100)
+}
+
+// EnabledFor reports whether a flag is on for key, e.g. a symbol. A partial
+// rollout turns the flag on for a stable subset of keys.
+func (f *Flags) EnabledFor(name, key string) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	flag, ok := f.flags[name]
+	if !ok || !flag.Enabled {
+		return false
+	}
+	if flag.Rollout == 0 || flag.Rollout >= 100 {
+		return true
+	}
+	return bucket(name, key) < flag.Rollout
+}
+
+// Set changes a flag at runtime
+func (f *Flags) Set(name string, flag config.FeatureFlag) error {
+	if name == "" {
+		return fmt.Errorf("flag name is required")
+	}
+	if flag.Rollout < 0 || flag.Rollout > 100 {
+		return fmt.Errorf("rollout must be between 0 and 100")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.flags[name] = flag
+	f.toggled[name] = true
+	return nil
+}
+
+// List returns every flag sorted by name
+func (f *Flags) List() []Flag {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	flags := make([]Flag, 0, len(f.flags))
+	for name, flag := range f.flags {
+		flags = append(flags, Flag{
+			Name:    name,
+			Enabled: flag.Enabled,
+			Rollout: flag.Rollout,
+			Toggled: f.toggled[name],
+		})
+	}
+	sort.Slice(flags, func(i, j int) bool { return flags[i].Name < flags[j].Name })
+	return flags
+}
+
+// bucket places key in one of 100 buckets, independently per flag so the same
+// keys are not always the first to get every new behavior
+func bucket(name, key string) int {
+	h := fnv.New32a()
+	h.Write([]byte(name + ":" + key))
+	return int(h.Sum32() % 100)
+}
@@ -0,0 +1,53 @@
+package features
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlagsFromConfigAndDefaults(t *testing.T) {
+	flags := NewFlags(map[string]config.FeatureFlag{
+		"llm_veto": {Enabled: false},
+		"new_exit": {Enabled: true},
+	})
+
+	// Defaults apply to flags the config leaves out
+	assert.True(t, flags.Enabled(Ensemble))
+	assert.True(t, flags.EnabledFor(Ensemble, "AAPL"))
+	assert.False(t, flags.Enabled("llm_veto"))
+	assert.True(t, flags.Enabled("new_exit"))
+	assert.False(t, flags.Enabled("undefined"))
+
+	assert.NoError(t, flags.Set("llm_veto", config.FeatureFlag{Enabled: true}))
+	assert.True(t, flags.Enabled("llm_veto"))
+	assert.Error(t, flags.Set("llm_veto", config.FeatureFlag{Enabled: true, Rollout: 101}))
+
+	list := flags.List()
+	assert.Len(t, list, 3)
+	assert.Equal(t, Ensemble, list[0].Name)
+	assert.Equal(t, "llm_veto", list[1].Name)
+	assert.True(t, list[1].Toggled)
+	assert.False(t, list[2].Toggled)
+}
+
+func TestPartialRolloutIsStablePerKey(t *testing.T) {
+	flags := NewFlags(map[string]config.FeatureFlag{Ensemble: {Enabled: true, Rollout: 25}})
+	assert.False(t, flags.Enabled(Ensemble))
+
+	on := 0
+	for i := 0; i < 1000; i++ {
+		symbol := fmt.Sprintf("SYM%d", i)
+		enabled := flags.EnabledFor(Ensemble, symbol)
+		assert.Equal(t, enabled, flags.EnabledFor(Ensemble, symbol))
+		if enabled {
+			on++
+		}
+	}
+	assert.InDelta(t, 250, on, 60)
+
+	assert.NoError(t, flags.Set(Ensemble, config.FeatureFlag{Enabled: false, Rollout: 25}))
+	assert.False(t, flags.EnabledFor(Ensemble, "SYM1"))
+}
@@ -0,0 +1,290 @@
+package filings
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// edgarArchives hosts filing documents
+	edgarArchives = "https://www.sec.gov/Archives/edgar/data"
+	// edgarSubmissions lists a company's recent filings
+	edgarSubmissions = "https://data.sec.gov/submissions"
+	// edgarTickers maps tickers to CIKs
+	edgarTickers = "https://www.sec.gov/files/company_tickers.json"
+	// edgarRequestGap keeps requests under the SEC's limit of 10 per second
+	edgarRequestGap = 150 * time.Millisecond
+)
+
+// EDGAR fetches Form 4 and 13F-HR filings from SEC EDGAR
+type EDGAR struct {
+	userAgent string
+	client    *http.Client
+	baseURLs  edgarURLs
+	ciks      map[string]string // Ticker -> zero-padded CIK
+	fetched   map[string]bool   // Form 4 accessions already fetched
+	lastCall  time.Time
+	mu        sync.Mutex
+}
+
+// edgarURLs are the EDGAR endpoints, replaced in tests
+type edgarURLs struct {
+	archives    string
+	submissions string
+	tickers     string
+}
+
+// NewEDGAR creates a new EDGAR client. The SEC rejects requests without a
+// User-Agent naming a contact.
+func NewEDGAR(userAgent string) *EDGAR {
+	return &EDGAR{
+		userAgent: userAgent,
+		client:    &http.Client{Timeout: 30 * time.Second},
+		baseURLs:  edgarURLs{archives: edgarArchives, submissions: edgarSubmissions, tickers: edgarTickers},
+		fetched:   make(map[string]bool),
+	}
+}
+
+// recentFilings is the part of a submissions document listing recent filings
+type recentFilings struct {
+	Name    string `json:"name"`
+	Filings struct {
+		Recent struct {
+			Accession       []string `json:"accessionNumber"`
+			Form            []string `json:"form"`
+			FilingDate      []string `json:"filingDate"`
+			PrimaryDocument []string `json:"primaryDocument"`
+		} `json:"recent"`
+	} `json:"filings"`
+}
+
+// filingRef identifies one filing of a company
+type filingRef struct {
+	CIK             string
+	Accession       string
+	FiledAt         time.Time
+	PrimaryDocument string
+}
+
+// folder returns the archive folder of the filing
+func (f filingRef) folder(archives string) string {
+	cik := strings.TrimLeft(f.CIK, "0")
+	return fmt.Sprintf("%s/%s/%s", archives, cik, strings.ReplaceAll(f.Accession, "-", ""))
+}
+
+// InsiderTrades returns the open-market insider trades in Form 4 filings
+// about symbol filed since since. Each filing is only fetched and returned once.
+func (e *EDGAR) InsiderTrades(ctx context.Context, symbol string, since time.Time) ([]InsiderTrade, error) {
+	cik, err := e.lookupCIK(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+	refs, _, err := e.recent(ctx, cik, "4", since)
+	if err != nil {
+		return nil, err
+	}
+
+	var trades []InsiderTrade
+	for _, ref := range refs {
+		e.mu.Lock()
+		fetched := e.fetched[ref.Accession]
+		e.mu.Unlock()
+		if fetched {
+			continue
+		}
+		// The primary document is the XSL rendering; the raw XML shares its name
+		url := ref.folder(e.baseURLs.archives) + "/" + path.Base(ref.PrimaryDocument)
+		body, err := e.get(ctx, url)
+		if err != nil {
+			return trades, err
+		}
+		e.mu.Lock()
+		e.fetched[ref.Accession] = true
+		e.mu.Unlock()
+
+		parsed, err := ParseForm4(bytes.NewReader(body))
+		if err != nil {
+			return trades, fmt.Errorf("%s: %w", ref.Accession, err)
+		}
+		for _, trade := range parsed {
+			trade.Accession = ref.Accession
+			trade.URL = url
+			if trade.Symbol == "" {
+				trade.Symbol = symbol
+			}
+			trades = append(trades, trade)
+		}
+	}
+	return trades, nil
+}
+
+// LatestHoldings returns the most recent 13F-HR filed by the institution with the given CIK
+func (e *EDGAR) LatestHoldings(ctx context.Context, cik string) (HoldingsReport, error) {
+	cik = padCIK(cik)
+	refs, name, err := e.recent(ctx, cik, "13F-HR", time.Time{})
+	if err != nil {
+		return HoldingsReport{}, err
+	}
+	if len(refs) == 0 {
+		return HoldingsReport{}, fmt.Errorf("no 13F-HR filings for CIK %s", cik)
+	}
+	ref := refs[0]
+
+	// The information table is the XML document other than the cover page
+	body, err := e.get(ctx, ref.folder(e.baseURLs.archives)+"/index.json")
+	if err != nil {
+		return HoldingsReport{}, err
+	}
+	var index struct {
+		Directory struct {
+			Item []struct {
+				Name string `json:"name"`
+			} `json:"item"`
+		} `json:"directory"`
+	}
+	if err := json.Unmarshal(body, &index); err != nil {
+		return HoldingsReport{}, fmt.Errorf("failed to parse filing index: %w", err)
+	}
+
+	for _, item := range index.Directory.Item {
+		if !strings.HasSuffix(strings.ToLower(item.Name), ".xml") || item.Name == "primary_doc.xml" {
+			continue
+		}
+		table, err := e.get(ctx, ref.folder(e.baseURLs.archives)+"/"+item.Name)
+		if err != nil {
+			return HoldingsReport{}, err
+		}
+		holdings, err := ParseInfoTable(bytes.NewReader(table))
+		if err != nil {
+			return HoldingsReport{}, fmt.Errorf("%s: %w", ref.Accession, err)
+		}
+		return HoldingsReport{CIK: cik, Filer: name, Accession: ref.Accession, FiledAt: ref.FiledAt, Holdings: holdings}, nil
+	}
+	return HoldingsReport{}, fmt.Errorf("no information table in 13F-HR %s", ref.Accession)
+}
+
+// recent returns a company's filings of form filed since since, newest first,
+// and the company name
+func (e *EDGAR) recent(ctx context.Context, cik, form string, since time.Time) ([]filingRef, string, error) {
+	body, err := e.get(ctx, fmt.Sprintf("%s/CIK%s.json", e.baseURLs.submissions, cik))
+	if err != nil {
+		return nil, "", err
+	}
+	var doc recentFilings
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil, "", fmt.Errorf("failed to parse submissions: %w", err)
+	}
+
+	recent := doc.Filings.Recent
+	var refs []filingRef
+	for i, f := range recent.Form {
+		if f != form || i >= len(recent.Accession) || i >= len(recent.FilingDate) || i >= len(recent.PrimaryDocument) {
+			continue
+		}
+		filedAt, err := time.Parse("2006-01-02", recent.FilingDate[i])
+		if err != nil || filedAt.Before(since.Truncate(24*time.Hour)) {
+			continue
+		}
+		refs = append(refs, filingRef{CIK: cik, Accession: recent.Accession[i], FiledAt: filedAt, PrimaryDocument: recent.PrimaryDocument[i]})
+	}
+	return refs, doc.Name, nil
+}
+
+// lookupCIK returns the zero-padded CIK of a ticker, loading the ticker map once
+func (e *EDGAR) lookupCIK(ctx context.Context, symbol string) (string, error) {
+	e.mu.Lock()
+	loaded := e.ciks != nil
+	e.mu.Unlock()
+
+	if !loaded {
+		body, err := e.get(ctx, e.baseURLs.tickers)
+		if err != nil {
+			return "", err
+		}
+		var companies map[string]struct {
+			CIK    int    `json:"cik_str"`
+			Ticker string `json:"ticker"`
+		}
+		if err := json.Unmarshal(body, &companies); err != nil {
+			return "", fmt.Errorf("failed to parse ticker map: %w", err)
+		}
+
+		ciks := make(map[string]string, len(companies))
+		for _, company := range companies {
+			ciks[strings.ToUpper(company.Ticker)] = padCIK(strconv.Itoa(company.CIK))
+		}
+		e.mu.Lock()
+		e.ciks = ciks
+		e.mu.Unlock()
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	cik, ok := e.ciks[strings.ToUpper(symbol)]
+	if !ok {
+		return "", fmt.Errorf("no CIK for %s", symbol)
+	}
+	return cik, nil
+}
+
+// get fetches an EDGAR URL, spacing requests to respect the SEC rate limit
+func (e *EDGAR) get(ctx context.Context, url string) ([]byte, error) {
+	e.mu.Lock()
+	wait := edgarRequestGap - time.Since(e.lastCall)
+	e.lastCall = time.Now().Add(maxDuration(wait, 0))
+	e.mu.Unlock()
+	if wait > 0 {
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", e.userAgent)
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get %s, status: %d", url, resp.StatusCode)
+	}
+	return body, nil
+}
+
+// padCIK zero-pads a CIK to the 10 digits used by the submissions API
+func padCIK(cik string) string {
+	cik = strings.TrimLeft(strings.TrimSpace(cik), "0")
+	if len(cik) >= 10 {
+		return cik
+	}
+	return strings.Repeat("0", 10-len(cik)) + cik
+}
+
+// maxDuration returns the larger of a and b
+func maxDuration(a, b time.Duration) time.Duration {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,173 @@
+package filings
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Insider transaction directions
+const (
+	Buy  = "BUY"
+	Sell = "SELL"
+)
+
+// InsiderTrade is an open-market purchase or sale reported on a Form 4
+type InsiderTrade struct {
+	Symbol    string    `json:"symbol"`
+	Insider   string    `json:"insider"`
+	Role      string    `json:"role"` // e.g. CEO, Director, 10% Owner
+	Direction string    `json:"direction"`
+	Shares    float64   `json:"shares"`
+	Price     float64   `json:"price"`
+	Date      time.Time `json:"date"`
+	Accession string    `json:"accession"` // EDGAR accession number of the filing
+	URL       string    `json:"url"`
+}
+
+// Value returns the dollar value of the trade
+func (t InsiderTrade) Value() float64 {
+	return t.Shares * t.Price
+}
+
+// Holding is one position in a 13F information table
+type Holding struct {
+	Issuer string `json:"issuer"`
+	CUSIP  string `json:"cusip"`
+	Shares int64  `json:"shares"`
+}
+
+// HoldingsReport is an institution's 13F-HR filing
+type HoldingsReport struct {
+	CIK       string    `json:"cik"`
+	Filer     string    `json:"filer"`
+	Accession string    `json:"accession"`
+	FiledAt   time.Time `json:"filed_at"`
+	Holdings  []Holding `json:"holdings"`
+}
+
+// Shares returns the shares held per CUSIP, summing lines reported separately
+// for other managers or voting authority
+func (r HoldingsReport) Shares() map[string]int64 {
+	shares := make(map[string]int64, len(r.Holdings))
+	for _, h := range r.Holdings {
+		shares[h.CUSIP] += h.Shares
+	}
+	return shares
+}
+
+// form4 is the subset of a Form 4 ownership document the monitor uses
+type form4 struct {
+	Symbol string `xml:"issuer>issuerTradingSymbol"`
+	Owners []struct {
+		Name         string `xml:"reportingOwnerId>rptOwnerName"`
+		Relationship struct {
+			IsDirector        string `xml:"isDirector"`
+			IsOfficer         string `xml:"isOfficer"`
+			IsTenPercentOwner string `xml:"isTenPercentOwner"`
+			OfficerTitle      string `xml:"officerTitle"`
+		} `xml:"reportingOwnerRelationship"`
+	} `xml:"reportingOwner"`
+	Transactions []struct {
+		Date   string `xml:"transactionDate>value"`
+		Code   string `xml:"transactionCoding>transactionCode"`
+		Shares string `xml:"transactionAmounts>transactionShares>value"`
+		Price  string `xml:"transactionAmounts>transactionPricePerShare>value"`
+	} `xml:"nonDerivativeTable>nonDerivativeTransaction"`
+}
+
+// ParseForm4 returns the open-market purchases (code P) and sales (code S) of
+// a Form 4 ownership document. Grants, exercises and gifts are ignored.
+func ParseForm4(r io.Reader) ([]InsiderTrade, error) {
+	var doc form4
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse Form 4: %w", err)
+	}
+
+	var insider, role string
+	if len(doc.Owners) > 0 {
+		owner := doc.Owners[0]
+		insider = strings.TrimSpace(owner.Name)
+		rel := owner.Relationship
+		switch {
+		case rel.OfficerTitle != "":
+			role = strings.TrimSpace(rel.OfficerTitle)
+		case isSet(rel.IsOfficer):
+			role = "Officer"
+		case isSet(rel.IsDirector):
+			role = "Director"
+		case isSet(rel.IsTenPercentOwner):
+			role = "10% Owner"
+		}
+	}
+
+	var trades []InsiderTrade
+	for _, tx := range doc.Transactions {
+		var direction string
+		switch strings.TrimSpace(tx.Code) {
+		case "P":
+			direction = Buy
+		case "S":
+			direction = Sell
+		default:
+			continue
+		}
+
+		// Prices are omitted for some sales, e.g. under 10b5-1 plans reported in footnotes
+		shares, _ := strconv.ParseFloat(strings.TrimSpace(tx.Shares), 64)
+		price, _ := strconv.ParseFloat(strings.TrimSpace(tx.Price), 64)
+		date, _ := time.Parse("2006-01-02", strings.TrimSpace(tx.Date))
+
+		trades = append(trades, InsiderTrade{
+			Symbol:    strings.ToUpper(strings.TrimSpace(doc.Symbol)),
+			Insider:   insider,
+			Role:      role,
+			Direction: direction,
+			Shares:    shares,
+			Price:     price,
+			Date:      date,
+		})
+	}
+	return trades, nil
+}
+
+// infoTable is a 13F information table
+type infoTable struct {
+	Entries []struct {
+		Issuer string `xml:"nameOfIssuer"`
+		CUSIP  string `xml:"cusip"`
+		Shares int64  `xml:"shrsOrPrnAmt>sshPrnamt"`
+		Type   string `xml:"shrsOrPrnAmt>sshPrnamtType"`
+	} `xml:"infoTable"`
+}
+
+// ParseInfoTable returns the share positions of a 13F information table.
+// Principal amounts of debt securities are ignored.
+func ParseInfoTable(r io.Reader) ([]Holding, error) {
+	var table infoTable
+	if err := xml.NewDecoder(r).Decode(&table); err != nil {
+		return nil, fmt.Errorf("failed to parse 13F information table: %w", err)
+	}
+
+	holdings := make([]Holding, 0, len(table.Entries))
+	for _, entry := range table.Entries {
+		if strings.TrimSpace(entry.Type) != "SH" {
+			continue
+		}
+		holdings = append(holdings, Holding{
+			Issuer: strings.TrimSpace(entry.Issuer),
+			CUSIP:  strings.ToUpper(strings.TrimSpace(entry.CUSIP)),
+			Shares: entry.Shares,
+		})
+	}
+	return holdings, nil
+}
+
+// isSet reports whether a Form 4 boolean flag is set; filers use 1 or true
+func isSet(flag string) bool {
+	flag = strings.TrimSpace(flag)
+	return flag == "1" || strings.EqualFold(flag, "true")
+}
@@ -0,0 +1,63 @@
+package filings
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const form4XML = `<?xml version="1.0"?>
+<ownershipDocument>
+  <issuer><issuerCik>0000320193</issuerCik><issuerTradingSymbol>aapl</issuerTradingSymbol></issuer>
+  <reportingOwner>
+    <reportingOwnerId><rptOwnerName>Doe Jane</rptOwnerName></reportingOwnerId>
+    <reportingOwnerRelationship><isDirector>0</isDirector><isOfficer>1</isOfficer><officerTitle>CFO</officerTitle></reportingOwnerRelationship>
+  </reportingOwner>
+  <nonDerivativeTable>
+    <nonDerivativeTransaction>
+      <transactionDate><value>2025-04-15</value></transactionDate>
+      <transactionCoding><transactionCode>P</transactionCode></transactionCoding>
+      <transactionAmounts>
+        <transactionShares><value>1000</value></transactionShares>
+        <transactionPricePerShare><value>150.25</value></transactionPricePerShare>
+      </transactionAmounts>
+    </nonDerivativeTransaction>
+    <nonDerivativeTransaction>
+      <transactionDate><value>2025-04-15</value></transactionDate>
+      <transactionCoding><transactionCode>A</transactionCode></transactionCoding>
+      <transactionAmounts><transactionShares><value>5000</value></transactionShares></transactionAmounts>
+    </nonDerivativeTransaction>
+  </nonDerivativeTable>
+</ownershipDocument>`
+
+func TestParseForm4(t *testing.T) {
+	trades, err := ParseForm4(strings.NewReader(form4XML))
+	assert.NoError(t, err)
+	assert.Len(t, trades, 1)
+
+	trade := trades[0]
+	assert.Equal(t, "AAPL", trade.Symbol)
+	assert.Equal(t, "Doe Jane", trade.Insider)
+	assert.Equal(t, "CFO", trade.Role)
+	assert.Equal(t, Buy, trade.Direction)
+	assert.Equal(t, 150250.0, trade.Value())
+	assert.Equal(t, time.Date(2025, 4, 15, 0, 0, 0, 0, time.UTC), trade.Date)
+
+	_, err = ParseForm4(strings.NewReader("not xml"))
+	assert.Error(t, err)
+}
+
+func TestParseInfoTable(t *testing.T) {
+	table := `<informationTable xmlns="http://www.sec.gov/edgar/document/thirteenf/informationtable">
+  <infoTable><nameOfIssuer>APPLE INC</nameOfIssuer><cusip>037833100</cusip><shrsOrPrnAmt><sshPrnamt>1000</sshPrnamt><sshPrnamtType>SH</sshPrnamtType></shrsOrPrnAmt></infoTable>
+  <infoTable><nameOfIssuer>APPLE INC</nameOfIssuer><cusip>037833100</cusip><shrsOrPrnAmt><sshPrnamt>500</sshPrnamt><sshPrnamtType>SH</sshPrnamtType></shrsOrPrnAmt></infoTable>
+  <infoTable><nameOfIssuer>SOME CO NOTE</nameOfIssuer><cusip>123456AB1</cusip><shrsOrPrnAmt><sshPrnamt>90000</sshPrnamt><sshPrnamtType>PRN</sshPrnamtType></shrsOrPrnAmt></infoTable>
+</informationTable>`
+
+	holdings, err := ParseInfoTable(strings.NewReader(table))
+	assert.NoError(t, err)
+	assert.Len(t, holdings, 2)
+	assert.Equal(t, map[string]int64{"037833100": 1500}, HoldingsReport{Holdings: holdings}.Shares())
+}
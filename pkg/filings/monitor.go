@@ -0,0 +1,237 @@
+package filings
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+)
+
+// insiderScoreScale is the net insider dollar flow that maps to a score of
+// about ±0.76, so a few large trades dominate but never saturate the factor
+const insiderScoreScale = 1000000
+
+// Source provides insider trades and institutional holdings (implemented by EDGAR)
+type Source interface {
+	InsiderTrades(ctx context.Context, symbol string, since time.Time) ([]InsiderTrade, error)
+	LatestHoldings(ctx context.Context, cik string) (HoldingsReport, error)
+}
+
+// Notifier delivers filing alerts (implemented by telegram.Bot)
+type Notifier interface {
+	SendMessage(message string) error
+}
+
+// Watchlist provides the symbols filings are tracked for (implemented by monitor.MarketMonitor)
+type Watchlist interface {
+	WatchedSymbols() []string
+}
+
+// Monitor polls SEC filings for watched symbols, alerts on insider trades and
+// institutional position changes, and summarizes recent insider activity as
+// strategy factors
+type Monitor struct {
+	config    config.FilingsConfig
+	source    Source
+	notifier  Notifier
+	watchlist Watchlist
+	trades    map[string][]InsiderTrade // Symbol -> trades within the lookback, oldest first
+	seen      map[string]bool           // Accessions already processed
+	reports   map[string]HoldingsReport // Institution CIK -> latest 13F
+	now       func() time.Time
+	mu        sync.RWMutex
+}
+
+// NewMonitor creates a new filings monitor
+func NewMonitor(cfg config.FilingsConfig, source Source, notifier Notifier, watchlist Watchlist) *Monitor {
+	return &Monitor{
+		config:    cfg,
+		source:    source,
+		notifier:  notifier,
+		watchlist: watchlist,
+		trades:    make(map[string][]InsiderTrade),
+		seen:      make(map[string]bool),
+		reports:   make(map[string]HoldingsReport),
+		now:       time.Now,
+	}
+}
+
+// Run polls filings every poll interval until ctx is done
+func (m *Monitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(m.config.PollMinutes) * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		m.Poll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll fetches new insider trades for every watched symbol and the latest 13F
+// of every followed institution, alerting on anything new
+func (m *Monitor) Poll(ctx context.Context) {
+	now := m.now()
+	since := now.AddDate(0, 0, -m.config.LookbackDays)
+
+	for _, symbol := range m.watchlist.WatchedSymbols() {
+		trades, err := m.source.InsiderTrades(ctx, symbol, since)
+		if err != nil {
+			log.Printf("Error fetching insider trades for %s: %v", symbol, err)
+		}
+		for _, trade := range m.recordTrades(trades, since) {
+			if trade.Value() < m.config.MinAlertValue {
+				continue
+			}
+			if err := m.notifier.SendMessage(FormatInsiderTrade(trade)); err != nil {
+				log.Printf("Error sending insider trade alert: %v", err)
+			}
+		}
+	}
+
+	for _, cik := range m.config.Institutions {
+		report, err := m.source.LatestHoldings(ctx, cik)
+		if err != nil {
+			log.Printf("Error fetching 13F for CIK %s: %v", cik, err)
+			continue
+		}
+		for _, message := range m.recordHoldings(report) {
+			if err := m.notifier.SendMessage(message); err != nil {
+				log.Printf("Error sending 13F alert: %v", err)
+			}
+		}
+	}
+}
+
+// recordTrades stores trades from filings not seen before, drops trades older
+// than since, and returns the new trades
+func (m *Monitor) recordTrades(trades []InsiderTrade, since time.Time) []InsiderTrade {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var fresh []InsiderTrade
+	accessions := make(map[string]bool)
+	for _, trade := range trades {
+		if m.seen[trade.Accession] || trade.Date.Before(since) {
+			continue
+		}
+		accessions[trade.Accession] = true
+		m.trades[trade.Symbol] = append(m.trades[trade.Symbol], trade)
+		fresh = append(fresh, trade)
+	}
+	for accession := range accessions {
+		m.seen[accession] = true
+	}
+
+	for symbol, symbolTrades := range m.trades {
+		kept := symbolTrades[:0]
+		for _, trade := range symbolTrades {
+			if !trade.Date.Before(since) {
+				kept = append(kept, trade)
+			}
+		}
+		sort.SliceStable(kept, func(i, j int) bool { return kept[i].Date.Before(kept[j].Date) })
+		m.trades[symbol] = kept
+	}
+	return fresh
+}
+
+// recordHoldings stores a 13F report and returns alerts for position changes
+// in watched symbols since the institution's previous report
+func (m *Monitor) recordHoldings(report HoldingsReport) []string {
+	m.mu.Lock()
+	previous, ok := m.reports[report.CIK]
+	if ok && previous.Accession == report.Accession {
+		m.mu.Unlock()
+		return nil
+	}
+	m.reports[report.CIK] = report
+	m.mu.Unlock()
+
+	// The first report only sets the baseline
+	if !ok {
+		return nil
+	}
+
+	before, after := previous.Shares(), report.Shares()
+	var messages []string
+	for _, symbol := range m.watchlist.WatchedSymbols() {
+		cusip := strings.ToUpper(m.config.CUSIPs[symbol])
+		if cusip == "" || before[cusip] == after[cusip] {
+			continue
+		}
+		messages = append(messages, FormatPositionChange(report.Filer, symbol, before[cusip], after[cusip]))
+	}
+	return messages
+}
+
+// Trades returns the insider trades in symbol within the lookback, oldest first
+func (m *Monitor) Trades(symbol string) []InsiderTrade {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]InsiderTrade(nil), m.trades[strings.ToUpper(symbol)]...)
+}
+
+// Factors summarizes recent insider activity in symbol for strategies:
+// insider_net_value is dollars bought minus dollars sold, insider_buyers the
+// number of distinct insiders buying, and insider_score the net value squashed
+// into (-1, 1)
+func (m *Monitor) Factors(symbol string) map[string]float64 {
+	var net float64
+	buyers := make(map[string]bool)
+	for _, trade := range m.Trades(symbol) {
+		if trade.Direction == Buy {
+			net += trade.Value()
+			buyers[trade.Insider] = true
+		} else {
+			net -= trade.Value()
+		}
+	}
+	return map[string]float64{
+		"insider_net_value": net,
+		"insider_buyers":    float64(len(buyers)),
+		"insider_score":     math.Tanh(net / insiderScoreScale),
+	}
+}
+
+// FormatInsiderTrade formats an insider trade alert
+func FormatInsiderTrade(trade InsiderTrade) string {
+	emoji := "🟢"
+	verb := "bought"
+	if trade.Direction == Sell {
+		emoji = "🔴"
+		verb = "sold"
+	}
+	who := trade.Insider
+	if trade.Role != "" {
+		who += " (" + trade.Role + ")"
+	}
+	message := fmt.Sprintf("%s INSIDER %s: %s %s %.0f shares at $%.2f ($%.0f) on %s",
+		emoji, trade.Symbol, who, verb, trade.Shares, trade.Price, trade.Value(), trade.Date.Format("2006-01-02"))
+	if trade.URL != "" {
+		message += "\n" + trade.URL
+	}
+	return message
+}
+
+// FormatPositionChange formats a 13F position change alert
+func FormatPositionChange(filer, symbol string, before, after int64) string {
+	switch {
+	case before == 0:
+		return fmt.Sprintf("🏦 13F %s: %s opened a position of %d shares", symbol, filer, after)
+	case after == 0:
+		return fmt.Sprintf("🏦 13F %s: %s closed its position of %d shares", symbol, filer, before)
+	default:
+		change := float64(after-before) / float64(before) * 100
+		return fmt.Sprintf("🏦 13F %s: %s changed its position from %d to %d shares (%+.1f%%)", symbol, filer, before, after, change)
+	}
+}
@@ -0,0 +1,85 @@
+package filings
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSource serves fixed trades and the next 13F report
+type fakeSource struct {
+	trades  []InsiderTrade
+	reports []HoldingsReport
+}
+
+func (f *fakeSource) InsiderTrades(ctx context.Context, symbol string, since time.Time) ([]InsiderTrade, error) {
+	var trades []InsiderTrade
+	for _, trade := range f.trades {
+		if trade.Symbol == symbol {
+			trades = append(trades, trade)
+		}
+	}
+	return trades, nil
+}
+
+func (f *fakeSource) LatestHoldings(ctx context.Context, cik string) (HoldingsReport, error) {
+	report := f.reports[0]
+	if len(f.reports) > 1 {
+		f.reports = f.reports[1:]
+	}
+	return report, nil
+}
+
+type recordingNotifier []string
+
+func (r *recordingNotifier) SendMessage(message string) error {
+	*r = append(*r, message)
+	return nil
+}
+
+type staticWatchlist []string
+
+func (s staticWatchlist) WatchedSymbols() []string { return s }
+
+func TestMonitorAlertsAndFactors(t *testing.T) {
+	now := time.Date(2025, 4, 21, 14, 0, 0, 0, time.UTC)
+	source := &fakeSource{
+		trades: []InsiderTrade{
+			{Symbol: "AAPL", Insider: "A", Direction: Buy, Shares: 10000, Price: 150, Date: now.AddDate(0, 0, -2), Accession: "1"},
+			{Symbol: "AAPL", Insider: "B", Direction: Sell, Shares: 100, Price: 150, Date: now.AddDate(0, 0, -1), Accession: "2"},
+			{Symbol: "AAPL", Insider: "C", Direction: Buy, Shares: 10, Price: 150, Date: now.AddDate(0, -6, 0), Accession: "3"},
+		},
+		reports: []HoldingsReport{
+			{CIK: "0001067983", Filer: "BERKSHIRE HATHAWAY INC", Accession: "Q4", Holdings: []Holding{{CUSIP: "037833100", Shares: 1000}}},
+			{CIK: "0001067983", Filer: "BERKSHIRE HATHAWAY INC", Accession: "Q1", Holdings: []Holding{{CUSIP: "037833100", Shares: 500}}},
+		},
+	}
+	notifier := &recordingNotifier{}
+	cfg := config.FilingsConfig{
+		MinAlertValue: 100000,
+		LookbackDays:  90,
+		Institutions:  []string{"1067983"},
+		CUSIPs:        map[string]string{"AAPL": "037833100"},
+	}
+	m := NewMonitor(cfg, source, notifier, staticWatchlist{"AAPL"})
+	m.now = func() time.Time { return now }
+
+	// Only the large purchase is alerted; the 13F sets the baseline
+	m.Poll(context.Background())
+	assert.Len(t, *notifier, 1)
+	assert.Contains(t, (*notifier)[0], "INSIDER AAPL: A bought 10000 shares")
+	assert.Len(t, m.Trades("AAPL"), 2)
+
+	factors := m.Factors("AAPL")
+	assert.Equal(t, 1485000.0, factors["insider_net_value"])
+	assert.Equal(t, 1.0, factors["insider_buyers"])
+	assert.InDelta(t, 0.90, factors["insider_score"], 0.01)
+
+	// Trades are not alerted twice; the new 13F reports the position change
+	m.Poll(context.Background())
+	assert.Len(t, *notifier, 2)
+	assert.Contains(t, (*notifier)[1], "changed its position from 1000 to 500 shares (-50.0%)")
+}
@@ -0,0 +1,262 @@
+package focus
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+)
+
+// minWindowPoints is how many prices the breakout window needs before a
+// breakout is reported
+const minWindowPoints = 3
+
+// QuoteSource fetches market data (implemented by data.Provider)
+type QuoteSource interface {
+	GetMarketData(symbol string) (*data.MarketData, error)
+}
+
+// AlertSender delivers focus alerts to the channel or a single user (implemented by telegram.Bot)
+type AlertSender interface {
+	SendMessage(message string) error
+	SendDirectMessage(chatID int64, message string) error
+}
+
+// Session is a symbol in focus
+type Session struct {
+	Symbol    string    `json:"symbol"`
+	ChatID    int64     `json:"chat_id,omitempty"` // Telegram user alerted; 0 alerts the channel
+	StartedAt time.Time `json:"started_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+	LastPrice float64   `json:"last_price"`
+	Alerts    int       `json:"alerts"`
+}
+
+// pricePoint is a polled price
+type pricePoint struct {
+	price float64
+	at    time.Time
+}
+
+// focusState is a session and its recent prices
+type focusState struct {
+	session   Session
+	prices    []pricePoint
+	lastAlert time.Time
+	stop      chan struct{}
+}
+
+// Watcher polls symbols in focus every few seconds and alerts when the price
+// breaks out of its recent range. Focus expires on its own, so a forgotten
+// session does not keep polling.
+type Watcher struct {
+	config config.FocusConfig
+	quotes QuoteSource
+	sender AlertSender
+	clock  clock.Clock
+	states map[string]*focusState
+	mu     sync.Mutex
+}
+
+// NewWatcher creates a watcher polling quotes and alerting through sender
+func NewWatcher(cfg config.FocusConfig, quotes QuoteSource, sender AlertSender) *Watcher {
+	return &Watcher{
+		config: cfg,
+		quotes: quotes,
+		sender: sender,
+		clock:  clock.Real{},
+		states: make(map[string]*focusState),
+	}
+}
+
+// SetClock sets the clock used for expiry and the breakout window
+func (w *Watcher) SetClock(c clock.Clock) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.clock = c
+}
+
+// Start focuses on symbol for duration, or the configured default when zero.
+// Alerts go to chatID, or the channel when it is 0. Focusing on a symbol
+// already in focus restarts its session.
+func (w *Watcher) Start(symbol string, duration time.Duration, chatID int64) (Session, error) {
+	if !w.config.Enabled {
+		return Session{}, fmt.Errorf("focus mode is disabled")
+	}
+	if duration == 0 {
+		duration = time.Duration(w.config.DefaultMinutes) * time.Minute
+	}
+	if duration < 0 || duration > time.Duration(w.config.MaxMinutes)*time.Minute {
+		return Session{}, fmt.Errorf("focus must last between 1 and %d minutes", w.config.MaxMinutes)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	previous, refocus := w.states[symbol]
+	if !refocus && len(w.states) >= w.config.MaxSymbols {
+		return Session{}, fmt.Errorf("at most %d symbols can be in focus", w.config.MaxSymbols)
+	}
+	if refocus {
+		close(previous.stop)
+	}
+
+	now := w.clock.Now()
+	state := &focusState{
+		session: Session{Symbol: symbol, ChatID: chatID, StartedAt: now, ExpiresAt: now.Add(duration)},
+		stop:    make(chan struct{}),
+	}
+	w.states[symbol] = state
+	go w.run(symbol, state.stop)
+
+	log.Printf("Focusing on %s until %s", symbol, state.session.ExpiresAt.Format("15:04:05"))
+	return state.session, nil
+}
+
+// Stop ends the focus on symbol, reporting whether it was in focus
+func (w *Watcher) Stop(symbol string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	state, ok := w.states[symbol]
+	if !ok {
+		return false
+	}
+	close(state.stop)
+	delete(w.states, symbol)
+	return true
+}
+
+// Sessions returns the symbols in focus, soonest to expire first
+func (w *Watcher) Sessions() []Session {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	sessions := make([]Session, 0, len(w.states))
+	for _, state := range w.states {
+		sessions = append(sessions, state.session)
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].ExpiresAt.Before(sessions[j].ExpiresAt) })
+	return sessions
+}
+
+// run polls symbol until its focus expires or stop is closed
+func (w *Watcher) run(symbol string, stop <-chan struct{}) {
+	ticker := time.NewTicker(time.Duration(w.config.PollSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if !w.poll(symbol, stop) {
+				return
+			}
+		}
+	}
+}
+
+// poll fetches the latest price and alerts on a breakout, reporting whether
+// the focus continues
+func (w *Watcher) poll(symbol string, stop <-chan struct{}) bool {
+	if session, expired := w.expire(symbol, stop); expired {
+		w.send(session, fmt.Sprintf("🔍 Focus on <b>%s</b> ended after %d alerts.", symbol, session.Alerts))
+		return false
+	}
+
+	marketData, err := w.quotes.GetMarketData(symbol)
+	if err != nil || len(marketData.Prices) == 0 {
+		log.Printf("Error polling %s in focus: %v", symbol, err)
+		return true
+	}
+
+	if session, message, ok := w.observe(symbol, marketData.Prices[len(marketData.Prices)-1]); ok {
+		w.send(session, message)
+	}
+	return true
+}
+
+// expire removes the session of symbol once it has expired. The stop channel
+// identifies the session, so a restarted focus is not removed by the old one.
+func (w *Watcher) expire(symbol string, stop <-chan struct{}) (Session, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	state, ok := w.states[symbol]
+	if !ok || state.stop != stop || w.clock.Now().Before(state.session.ExpiresAt) {
+		return Session{}, false
+	}
+	delete(w.states, symbol)
+	return state.session, true
+}
+
+// observe records a price and returns the alert for a micro-breakout: a price
+// beyond the high or low of the breakout window by at least the minimum move
+func (w *Watcher) observe(symbol string, price float64) (Session, string, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	state, ok := w.states[symbol]
+	if !ok || price <= 0 {
+		return Session{}, "", false
+	}
+	now := w.clock.Now()
+
+	// Keep the prices within the window
+	since := now.Add(-time.Duration(w.config.BreakoutWindowSeconds) * time.Second)
+	kept := state.prices[:0]
+	for _, p := range state.prices {
+		if !p.at.Before(since) {
+			kept = append(kept, p)
+		}
+	}
+	state.prices = kept
+
+	var message string
+	cooledDown := now.Sub(state.lastAlert) >= time.Duration(w.config.AlertCooldownSeconds)*time.Second
+	if len(state.prices) >= minWindowPoints && cooledDown {
+		high, low := state.prices[0].price, state.prices[0].price
+		for _, p := range state.prices[1:] {
+			high = max(high, p.price)
+			low = min(low, p.price)
+		}
+
+		move := w.config.MinMovePercent / 100
+		switch {
+		case price > high*(1+move):
+			message = fmt.Sprintf("⚡ <b>%s</b> micro-breakout: $%.2f above the %ds high of $%.2f (%+.2f%%)",
+				symbol, price, w.config.BreakoutWindowSeconds, high, (price/high-1)*100)
+		case price < low*(1-move):
+			message = fmt.Sprintf("⚡ <b>%s</b> micro-breakdown: $%.2f below the %ds low of $%.2f (%+.2f%%)",
+				symbol, price, w.config.BreakoutWindowSeconds, low, (price/low-1)*100)
+		}
+	}
+
+	state.prices = append(state.prices, pricePoint{price: price, at: now})
+	state.session.LastPrice = price
+	if message == "" {
+		return state.session, "", false
+	}
+	state.lastAlert = now
+	state.session.Alerts++
+	return state.session, message, true
+}
+
+// send delivers an alert to the session's user, or the channel
+func (w *Watcher) send(session Session, message string) {
+	var err error
+	if session.ChatID != 0 {
+		err = w.sender.SendDirectMessage(session.ChatID, message)
+	} else {
+		err = w.sender.SendMessage(message)
+	}
+	if err != nil {
+		log.Printf("Error sending focus alert for %s: %v", session.Symbol, err)
+	}
+}
@@ -0,0 +1,92 @@
+package focus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/config"
+)
+
+func newTestWatcher() (*Watcher, *clock.Fake) {
+	cfg := config.CreateDefaultConfig().Focus
+	cfg.PollSeconds = 3600 // Tests drive observe and expire directly
+	cfg.MaxSymbols = 2
+	w := NewWatcher(cfg, nil, nil)
+	fake := clock.NewFake(time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC))
+	w.SetClock(fake)
+	return w, fake
+}
+
+func TestObserveMicroBreakout(t *testing.T) {
+	w, fake := newTestWatcher()
+	_, err := w.Start("NVDA", 0, 42)
+	assert.NoError(t, err)
+	defer w.Stop("NVDA")
+
+	for _, price := range []float64{100, 100.1, 99.9} {
+		_, _, ok := w.observe("NVDA", price)
+		assert.False(t, ok)
+		fake.Advance(2 * time.Second)
+	}
+
+	// Within the minimum move of the high
+	_, _, ok := w.observe("NVDA", 100.2)
+	assert.False(t, ok)
+	fake.Advance(2 * time.Second)
+
+	session, message, ok := w.observe("NVDA", 100.5)
+	assert.True(t, ok)
+	assert.Contains(t, message, "micro-breakout")
+	assert.Equal(t, int64(42), session.ChatID)
+	assert.Equal(t, 1, session.Alerts)
+
+	// The cooldown holds back the next alert
+	fake.Advance(2 * time.Second)
+	_, _, ok = w.observe("NVDA", 99)
+	assert.False(t, ok)
+
+	fake.Advance(30 * time.Second)
+	_, message, ok = w.observe("NVDA", 98)
+	assert.True(t, ok)
+	assert.Contains(t, message, "micro-breakdown")
+}
+
+func TestStartLimitsAndExpiry(t *testing.T) {
+	w, fake := newTestWatcher()
+
+	_, err := w.Start("NVDA", 200*time.Minute, 0)
+	assert.Error(t, err)
+
+	_, err = w.Start("NVDA", 5*time.Minute, 0)
+	assert.NoError(t, err)
+	_, err = w.Start("SHOP", 0, 0)
+	assert.NoError(t, err)
+	_, err = w.Start("TD", 0, 0)
+	assert.Error(t, err)
+
+	// Refocusing an existing symbol is allowed and restarts it
+	session, err := w.Start("NVDA", 10*time.Minute, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, fake.Now().Add(10*time.Minute), session.ExpiresAt)
+
+	sessions := w.Sessions()
+	assert.Len(t, sessions, 2)
+	assert.Equal(t, "NVDA", sessions[0].Symbol)
+
+	w.mu.Lock()
+	stop := w.states["NVDA"].stop
+	w.mu.Unlock()
+
+	_, expired := w.expire("NVDA", stop)
+	assert.False(t, expired)
+	fake.Advance(10 * time.Minute)
+	_, expired = w.expire("NVDA", stop)
+	assert.True(t, expired)
+	assert.Len(t, w.Sessions(), 1)
+
+	assert.True(t, w.Stop("SHOP"))
+	assert.False(t, w.Stop("SHOP"))
+}
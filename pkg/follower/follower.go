@@ -0,0 +1,232 @@
+package follower
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// Position states of a simulated follower
+const (
+	StatePending = "PENDING" // Waiting out the entry delay
+	StateOpen    = "OPEN"
+	StateClosed  = "CLOSED"
+	StateMissed  = "MISSED" // The signal closed before the follower could enter
+)
+
+// maxPositions caps how many simulated positions are kept; the oldest are dropped
+const maxPositions = 5000
+
+// Position is a simulated subscriber's trade on one signal
+type Position struct {
+	SignalID   string            `json:"signal_id"`
+	Symbol     string            `json:"symbol"`
+	Type       signal.SignalType `json:"type"`
+	State      string            `json:"state"`
+	Published  time.Time         `json:"published"`
+	EnterAfter time.Time         `json:"enter_after"`
+	EntryPrice float64           `json:"entry_price,omitempty"`
+	EnteredAt  time.Time         `json:"entered_at,omitempty"`
+	ExitPrice  float64           `json:"exit_price,omitempty"`
+	ExitedAt   time.Time         `json:"exited_at,omitempty"`
+	ROI        float64           `json:"roi"`        // Follower ROI after slippage, once closed
+	SignalROI  float64           `json:"signal_roi"` // ROI of the signal from its published price, once closed
+}
+
+// Summary is what a typical follower would have made
+type Summary struct {
+	Followed     int     `json:"followed"`
+	Pending      int     `json:"pending"`
+	Open         int     `json:"open"`
+	Closed       int     `json:"closed"`
+	Missed       int     `json:"missed"`
+	WinRate      float64 `json:"win_rate"`       // Percent of closed positions with a positive ROI
+	AvgROI       float64 `json:"avg_roi"`        // Average follower ROI per closed position
+	TotalROI     float64 `json:"total_roi"`      // Sum of follower ROIs, one unit per signal
+	AvgSignalROI float64 `json:"avg_signal_roi"` // Average published ROI of the same signals
+	EntryDelay   int     `json:"entry_delay_seconds"`
+	SlippagePct  float64 `json:"slippage_pct"`
+}
+
+// Simulator follows published signals as a subscriber would: entering after
+// the configured delay at the price then, less slippage, and exiting when the
+// signal hits its target or stop, or expires
+type Simulator struct {
+	config    config.FollowerConfig
+	positions map[string]*Position
+	order     []string // Signal IDs in the order they were followed
+	clock     clock.Clock
+	mu        sync.RWMutex
+}
+
+// NewSimulator creates a follower simulator
+func NewSimulator(cfg config.FollowerConfig) *Simulator {
+	return &Simulator{
+		config:    cfg,
+		positions: make(map[string]*Position),
+		clock:     clock.Real{},
+	}
+}
+
+// SetClock sets the clock follower exits are timestamped with
+func (f *Simulator) SetClock(c clock.Clock) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.clock = c
+}
+
+// Follow starts following a published signal
+func (f *Simulator) Follow(s *signal.Signal) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, exists := f.positions[s.ID]; exists {
+		return
+	}
+	f.positions[s.ID] = &Position{
+		SignalID:   s.ID,
+		Symbol:     s.Symbol,
+		Type:       s.Type,
+		State:      StatePending,
+		Published:  s.GeneratedAt,
+		EnterAfter: s.GeneratedAt.Add(time.Duration(f.config.EntryDelaySeconds) * time.Second),
+	}
+	f.order = append(f.order, s.ID)
+
+	if len(f.order) > maxPositions {
+		delete(f.positions, f.order[0])
+		f.order = f.order[1:]
+	}
+}
+
+// Observe enters pending positions whose delay has passed at now, at the
+// first bar from the end of the delay or else the latest price
+func (f *Simulator) Observe(marketData map[string]*data.MarketData, now time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, p := range f.positions {
+		if p.State != StatePending || now.Before(p.EnterAfter) {
+			continue
+		}
+		md, ok := marketData[p.Symbol]
+		if !ok || md == nil || len(md.Prices) == 0 {
+			continue
+		}
+
+		price, at := priceAt(md, p.EnterAfter, now)
+		p.EntryPrice = f.slip(price, p.Type == signal.BUY)
+		p.EnteredAt = at
+		p.State = StateOpen
+	}
+}
+
+// Close exits the follower when its signal closes at exitPrice. Signals that
+// close before the follower entered are counted as missed. It matches the
+// MarketMonitor OnSignalClosed callback.
+func (f *Simulator) Close(s *signal.Signal, exitPrice float64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	p, exists := f.positions[s.ID]
+	if !exists {
+		return
+	}
+	switch p.State {
+	case StatePending:
+		p.State = StateMissed
+	case StateOpen:
+		p.ExitPrice = f.slip(exitPrice, p.Type != signal.BUY)
+		p.ExitedAt = f.clock.Now()
+		p.ROI = roi(p.Type, p.EntryPrice, p.ExitPrice)
+		p.SignalROI = roi(p.Type, s.Price, exitPrice)
+		p.State = StateClosed
+	}
+}
+
+// Positions returns copies of the simulated positions, most recent first
+func (f *Simulator) Positions() []Position {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	positions := make([]Position, 0, len(f.order))
+	for i := len(f.order) - 1; i >= 0; i-- {
+		positions = append(positions, *f.positions[f.order[i]])
+	}
+	return positions
+}
+
+// Summary returns the follower's record across all followed signals
+func (f *Simulator) Summary() Summary {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	summary := Summary{
+		Followed:    len(f.positions),
+		EntryDelay:  f.config.EntryDelaySeconds,
+		SlippagePct: f.config.SlippagePct,
+	}
+	var wins int
+	var signalROI float64
+	for _, p := range f.positions {
+		switch p.State {
+		case StatePending:
+			summary.Pending++
+		case StateOpen:
+			summary.Open++
+		case StateMissed:
+			summary.Missed++
+		case StateClosed:
+			summary.Closed++
+			summary.TotalROI += p.ROI
+			signalROI += p.SignalROI
+			if p.ROI > 0 {
+				wins++
+			}
+		}
+	}
+	if summary.Closed > 0 {
+		summary.WinRate = float64(wins) / float64(summary.Closed) * 100
+		summary.AvgROI = summary.TotalROI / float64(summary.Closed)
+		summary.AvgSignalROI = signalROI / float64(summary.Closed)
+	}
+	return summary
+}
+
+// slip moves price against the follower: up when buying, down when selling
+func (f *Simulator) slip(price float64, buying bool) float64 {
+	if buying {
+		return price * (1 + f.config.SlippagePct/100)
+	}
+	return price * (1 - f.config.SlippagePct/100)
+}
+
+// priceAt returns the close of the first bar at or after from, or the latest
+// price at now when no bar is timestamped that late
+func priceAt(md *data.MarketData, from, now time.Time) (float64, time.Time) {
+	if len(md.Timestamps) == len(md.Prices) {
+		i := sort.Search(len(md.Timestamps), func(i int) bool {
+			return !md.Timestamps[i].Before(from)
+		})
+		if i < len(md.Timestamps) {
+			return md.Prices[i], md.Timestamps[i]
+		}
+	}
+	return md.Prices[len(md.Prices)-1], now
+}
+
+// roi returns the percent return of a BUY or SELL from entry to exit
+func roi(signalType signal.SignalType, entry, exit float64) float64 {
+	if entry == 0 {
+		return 0
+	}
+	if signalType == signal.BUY {
+		return (exit - entry) / entry * 100
+	}
+	return (entry - exit) / entry * 100
+}
@@ -0,0 +1,52 @@
+package follower
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/signal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFollowerEntersAfterDelayAndExitsWithSignal(t *testing.T) {
+	published := time.Date(2025, 4, 14, 14, 0, 0, 0, time.UTC)
+	sim := NewSimulator(config.FollowerConfig{EntryDelaySeconds: 120, SlippagePct: 0.1})
+
+	win := &signal.Signal{ID: "SIG-AAPL", Symbol: "AAPL", Type: signal.BUY, Price: 100, GeneratedAt: published}
+	missed := &signal.Signal{ID: "SIG-MSFT", Symbol: "MSFT", Type: signal.BUY, Price: 200, GeneratedAt: published}
+	sim.Follow(win)
+	sim.Follow(missed)
+	sim.Follow(win)
+
+	bars := &data.MarketData{
+		Symbol:     "AAPL",
+		Prices:     []float64{100, 100.5, 101},
+		Timestamps: []time.Time{published, published.Add(time.Minute), published.Add(2 * time.Minute)},
+	}
+	marketData := map[string]*data.MarketData{"AAPL": bars}
+
+	// Still inside the delay
+	sim.Observe(marketData, published.Add(time.Minute))
+	assert.Equal(t, 2, sim.Summary().Pending)
+
+	// Enters at the first bar after the delay, paying the slippage
+	sim.Observe(marketData, published.Add(3*time.Minute))
+	positions := sim.Positions()
+	assert.Equal(t, "SIG-MSFT", positions[0].SignalID)
+	assert.Equal(t, StateOpen, positions[1].State)
+	assert.InDelta(t, 101.101, positions[1].EntryPrice, 1e-9)
+
+	sim.Close(win, 104)
+	sim.Close(missed, 210)
+
+	summary := sim.Summary()
+	assert.Equal(t, 2, summary.Followed)
+	assert.Equal(t, 1, summary.Closed)
+	assert.Equal(t, 1, summary.Missed)
+	assert.Equal(t, 100.0, summary.WinRate)
+	assert.InDelta(t, 4.0, summary.AvgSignalROI, 1e-9)
+	// The follower entered later and pays slippage both ways
+	assert.InDelta(t, (103.896-101.101)/101.101*100, summary.AvgROI, 1e-9)
+}
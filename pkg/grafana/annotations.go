@@ -0,0 +1,137 @@
+package grafana
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/execution"
+	"github.com/hustler/trading-bot/pkg/monitor"
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// Annotation is a Grafana annotation. A non-zero TimeEnd makes it a region.
+type Annotation struct {
+	DashboardUID string   `json:"dashboardUID,omitempty"`
+	Time         int64    `json:"time"`              // Unix milliseconds
+	TimeEnd      int64    `json:"timeEnd,omitempty"` // Unix milliseconds
+	Tags         []string `json:"tags"`
+	Text         string   `json:"text"`
+}
+
+// Client creates annotations through the Grafana HTTP API
+type Client struct {
+	cfg    config.GrafanaConfig
+	client *http.Client
+}
+
+// NewClient creates a new Client
+func NewClient(cfg config.GrafanaConfig) *Client {
+	cfg.URL = strings.TrimRight(cfg.URL, "/")
+	return &Client{cfg: cfg, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Annotate creates an annotation at t, or a region from t to end when end is not zero.
+// The configured dashboard and tags are added.
+func (c *Client) Annotate(t, end time.Time, tags []string, text string) error {
+	annotation := Annotation{
+		DashboardUID: c.cfg.DashboardUID,
+		Time:         t.UnixMilli(),
+		Tags:         append(append([]string{}, c.cfg.Tags...), tags...),
+		Text:         text,
+	}
+	if !end.IsZero() {
+		annotation.TimeEnd = end.UnixMilli()
+	}
+
+	body, err := json.Marshal(annotation)
+	if err != nil {
+		return fmt.Errorf("failed to encode annotation: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", c.cfg.URL+"/api/annotations", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.cfg.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.cfg.APIKey)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to create annotation, status: %d, body: %s", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
+
+// Notifier forwards notifications to another notifier and annotates Grafana
+// with each signal and its outcome, so price charts show when and why the bot acted
+type Notifier struct {
+	next   monitor.Notifier
+	client *Client
+}
+
+// NewNotifier creates a Notifier in front of next
+func NewNotifier(next monitor.Notifier, client *Client) *Notifier {
+	return &Notifier{next: next, client: client}
+}
+
+// SendSignal sends a new signal and annotates it
+func (n *Notifier) SendSignal(s *signal.Signal) error {
+	text := fmt.Sprintf("%s %s at $%.2f, target $%.2f, stop $%.2f (%.0f%% confidence)",
+		s.Type, s.Symbol, s.Price, s.TargetPrice, s.StopLoss, s.Confidence*100)
+	if s.Rationale != "" {
+		text += "\n" + s.Rationale
+	}
+	n.annotate(s.GeneratedAt, time.Time{}, []string{"signal", s.Symbol, strings.ToLower(string(s.Type))}, text)
+	return n.next.SendSignal(s)
+}
+
+// UpdateSignal sends a signal change
+func (n *Notifier) UpdateSignal(s *signal.Signal) error {
+	return n.next.UpdateSignal(s)
+}
+
+// SendSignalOutcome sends a signal's outcome and annotates the signal's lifetime as a region
+func (n *Notifier) SendSignalOutcome(s *signal.Signal, exitPrice float64) error {
+	text := fmt.Sprintf("%s %s %s at $%.2f (entry $%.2f)", s.Type, s.Symbol, s.Status, exitPrice, s.Price)
+	n.annotate(s.GeneratedAt, time.Now(), []string{"outcome", s.Symbol, strings.ToLower(string(s.Status))}, text)
+	return n.next.SendSignalOutcome(s, exitPrice)
+}
+
+// SendMessage sends a message
+func (n *Notifier) SendMessage(message string) error {
+	return n.next.SendMessage(message)
+}
+
+// annotate creates an annotation, logging failures so notifications are never blocked by Grafana
+func (n *Notifier) annotate(t, end time.Time, tags []string, text string) {
+	if err := n.client.Annotate(t, end, tags, text); err != nil {
+		log.Printf("Error annotating Grafana: %v", err)
+	}
+}
+
+// RecordTrade annotates a trade. It matches the TradeManager OnTrade callback.
+func (c *Client) RecordTrade(trade execution.Trade) {
+	text := fmt.Sprintf("%s %d %s at $%.2f", trade.Type, trade.Quantity, trade.Symbol, trade.Price)
+	if trade.Reason != "" {
+		text += "\n" + trade.Reason
+	}
+	tags := []string{"trade", trade.Symbol, strings.ToLower(string(trade.Type))}
+	if err := c.Annotate(trade.CreatedAt, time.Time{}, tags, text); err != nil {
+		log.Printf("Error annotating trade %s: %v", trade.ID, err)
+	}
+}
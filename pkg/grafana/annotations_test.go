@@ -0,0 +1,54 @@
+package grafana
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/backtest"
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/execution"
+	"github.com/hustler/trading-bot/pkg/signal"
+	"github.com/hustler/trading-bot/pkg/strategy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotifierAnnotatesSignalsAndTrades(t *testing.T) {
+	var annotations []Annotation
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var a Annotation
+		json.NewDecoder(r.Body).Decode(&a)
+		annotations = append(annotations, a)
+		auth = r.Header.Get("Authorization")
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GrafanaConfig{URL: server.URL + "/", APIKey: "token", DashboardUID: "prices", Tags: []string{"hustler"}})
+	next := backtest.NewRecordingNotifier()
+	notifier := NewNotifier(next, client)
+
+	generated := time.Date(2025, 4, 21, 14, 0, 0, 0, time.UTC)
+	s := &signal.Signal{ID: "SIG-1", Symbol: "AAPL", Type: signal.BUY, Price: 100, TargetPrice: 105, StopLoss: 98, Confidence: 0.8, GeneratedAt: generated, Rationale: "Squeeze breakout"}
+	assert.NoError(t, notifier.SendSignal(s))
+	s.Status = "SUCCESS"
+	assert.NoError(t, notifier.SendSignalOutcome(s, 105))
+	client.RecordTrade(execution.Trade{ID: "T-1", Symbol: "AAPL", Quantity: 10, Price: 100, Type: strategy.Buy, CreatedAt: generated})
+
+	// Notifications still reach the wrapped notifier
+	assert.Len(t, next.Signals(), 1)
+	assert.Len(t, next.Outcomes(), 1)
+
+	assert.Len(t, annotations, 3)
+	assert.Equal(t, "Bearer token", auth)
+	assert.Equal(t, "prices", annotations[0].DashboardUID)
+	assert.Equal(t, generated.UnixMilli(), annotations[0].Time)
+	assert.Equal(t, []string{"hustler", "signal", "AAPL", "buy"}, annotations[0].Tags)
+	assert.Contains(t, annotations[0].Text, "Squeeze breakout")
+	assert.Equal(t, []string{"hustler", "outcome", "AAPL", "success"}, annotations[1].Tags)
+	assert.Greater(t, annotations[1].TimeEnd, annotations[1].Time)
+	assert.Contains(t, annotations[2].Tags, "trade")
+}
@@ -0,0 +1,248 @@
+package heartbeat
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/errkind"
+	"github.com/hustler/trading-bot/pkg/llm"
+	"github.com/hustler/trading-bot/pkg/market"
+	"github.com/hustler/trading-bot/pkg/monitor"
+)
+
+// checkInterval is how often the job checks whether the heartbeat is due
+const checkInterval = 5 * time.Minute
+
+// checkGrace is how long after the session opens a market check must have run
+const checkGrace = 15 * time.Minute
+
+// CheckSource reports the market checks performed (implemented by monitor.MarketMonitor)
+type CheckSource interface {
+	GetCheckHealth() monitor.CheckHealth
+}
+
+// LLMUsage reports the LLM explanations requested and their spend (implemented by llm.Manager)
+type LLMUsage interface {
+	Usage() llm.Usage
+}
+
+// AdminSender sends a message to an admin's chat (implemented by telegram.Bot)
+type AdminSender interface {
+	SendDirectMessage(chatID int64, message string) error
+}
+
+// Heartbeat is the state of the bot reported once a day. Counts are since
+// the previous heartbeat, or since startup for the first one.
+type Heartbeat struct {
+	At          time.Time
+	Uptime      time.Duration
+	Checks      int
+	TotalChecks int
+	LastCheck   time.Time // Zero before the first check
+	APIErrors   map[errkind.Kind]int
+	LLM         llm.Usage
+	Explained   monitor.ExplanationChecks // Explanations cross-checked against the indicators
+	Stalled     bool                      // No market check since the latest session opened
+	SessionOpen time.Time                 // Open of the latest session
+}
+
+// Job posts the heartbeat to the admins once a day
+type Job struct {
+	checks   CheckSource
+	llm      LLMUsage // Optional
+	sender   AdminSender
+	admins   []int64
+	config   config.HeartbeatConfig
+	session  *market.Clock
+	clock    clock.Clock
+	started  time.Time
+	lastDay  string
+	previous Heartbeat // Totals at the previous heartbeat
+	mu       sync.Mutex
+}
+
+// NewJob creates a job posting the heartbeat to the admins at the configured
+// hour in the market time zone. llm may be nil.
+func NewJob(checks CheckSource, llm LLMUsage, sender AdminSender, admins []int64, cfg config.HeartbeatConfig) *Job {
+	return &Job{
+		checks:  checks,
+		llm:     llm,
+		sender:  sender,
+		admins:  admins,
+		config:  cfg,
+		session: market.DefaultClock(),
+		clock:   clock.Real{},
+		started: time.Now(),
+	}
+}
+
+// SetClock sets the clock used to decide when the heartbeat is due. Uptime
+// is counted from when the clock is set.
+func (j *Job) SetClock(c clock.Clock) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.clock = c
+	j.started = c.Now()
+}
+
+// Run checks for the heartbeat time until stop is closed
+func (j *Job) Run(stop <-chan struct{}) {
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			j.RunIfDue()
+		}
+	}
+}
+
+// RunIfDue posts the heartbeat once per day, from the configured hour
+func (j *Job) RunIfDue() bool {
+	j.mu.Lock()
+	now := j.clock.Now().In(j.session.Location())
+	day := j.session.DayKey(now)
+	if now.Hour() < j.config.Hour || j.lastDay == day {
+		j.mu.Unlock()
+		return false
+	}
+	j.lastDay = day
+	j.mu.Unlock()
+
+	message := FormatHeartbeat(j.Beat(now))
+	for _, admin := range j.admins {
+		if err := j.sender.SendDirectMessage(admin, message); err != nil {
+			log.Printf("Error sending heartbeat to admin %d: %v", admin, err)
+		}
+	}
+	return true
+}
+
+// Beat collects the heartbeat at now and starts counting the next one
+func (j *Job) Beat(now time.Time) Heartbeat {
+	health := j.checks.GetCheckHealth()
+	var usage llm.Usage
+	if j.llm != nil {
+		usage = j.llm.Usage()
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	beat := Heartbeat{
+		At:          now,
+		Uptime:      now.Sub(j.started),
+		Checks:      health.Checks - j.previous.TotalChecks,
+		TotalChecks: health.Checks,
+		APIErrors:   make(map[errkind.Kind]int),
+		LLM: llm.Usage{
+			Requests: usage.Requests - j.previous.LLM.Requests,
+			Failures: usage.Failures - j.previous.LLM.Failures,
+			Tokens:   usage.Tokens - j.previous.LLM.Tokens,
+			Cost:     usage.Cost - j.previous.LLM.Cost,
+		},
+		Explained: monitor.ExplanationChecks{
+			Checked:     health.Explanations.Checked - j.previous.Explained.Checked,
+			Corrected:   health.Explanations.Corrected - j.previous.Explained.Corrected,
+			Corrections: health.Explanations.Corrections - j.previous.Explained.Corrections,
+		},
+		SessionOpen: j.latestSessionOpen(now),
+	}
+	if beat.Explained.Checked > 0 {
+		beat.Explained.CorrectionRate = float64(beat.Explained.Corrected) / float64(beat.Explained.Checked)
+	}
+	if health.LastCheck != nil {
+		beat.LastCheck = health.LastCheck.At
+	}
+	for kind, count := range health.FetchErrors {
+		if delta := count - j.previous.APIErrors[kind]; delta > 0 {
+			beat.APIErrors[kind] = delta
+		}
+	}
+	// The monitor may have been started after the session opened
+	beat.Stalled = !beat.SessionOpen.IsZero() && beat.SessionOpen.After(j.started) && beat.LastCheck.Before(beat.SessionOpen)
+
+	j.previous = Heartbeat{TotalChecks: health.Checks, APIErrors: health.FetchErrors, LLM: usage, Explained: health.Explanations}
+	return beat
+}
+
+// latestSessionOpen returns the open of the latest session that should have
+// seen a market check by now, zero if none in the past week
+func (j *Job) latestSessionOpen(now time.Time) time.Time {
+	for day := now; now.Sub(day) < 7*24*time.Hour; day = day.AddDate(0, 0, -1) {
+		if !j.session.IsTradingDay(day) {
+			continue
+		}
+		if open := j.session.SessionOpen(day); open.Add(checkGrace).Before(now) {
+			return open
+		}
+	}
+	return time.Time{}
+}
+
+// FormatHeartbeat formats a heartbeat for Telegram
+func FormatHeartbeat(beat Heartbeat) string {
+	message := "💓 <b>BOT IS ALIVE</b>\n\n"
+	message += fmt.Sprintf("Uptime: %s\n", formatUptime(beat.Uptime))
+	message += fmt.Sprintf("Market checks: %d since the last heartbeat, %d since startup\n", beat.Checks, beat.TotalChecks)
+	if beat.LastCheck.IsZero() {
+		message += "Last check: none yet\n"
+	} else {
+		message += fmt.Sprintf("Last check: %s (%s ago)\n", beat.LastCheck.Format("2006-01-02 15:04"),
+			formatUptime(beat.At.Sub(beat.LastCheck)))
+	}
+	message += fmt.Sprintf("API errors: %s\n", formatErrors(beat.APIErrors))
+	message += fmt.Sprintf("LLM: %d explanations, %d failed, ~%d tokens, $%.2f\n",
+		beat.LLM.Requests, beat.LLM.Failures, beat.LLM.Tokens, beat.LLM.Cost)
+	if beat.Explained.Checked > 0 {
+		message += fmt.Sprintf("Explanations corrected: %d of %d (%.0f%%)\n",
+			beat.Explained.Corrected, beat.Explained.Checked, beat.Explained.CorrectionRate*100)
+	}
+	if beat.Stalled {
+		message += fmt.Sprintf("\n⚠️ <b>No market check since the session opened at %s.</b> The monitor loop may be stuck.\n",
+			beat.SessionOpen.Format("2006-01-02 15:04"))
+	}
+	return strings.TrimSuffix(message, "\n")
+}
+
+// formatUptime formats a duration in days, hours and minutes, e.g. 2d 3h 15m
+func formatUptime(d time.Duration) string {
+	d = d.Round(time.Minute)
+	days := int(d / (24 * time.Hour))
+	hours := int(d % (24 * time.Hour) / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+	if days > 0 {
+		return fmt.Sprintf("%dd %dh %dm", days, hours, minutes)
+	}
+	if hours > 0 {
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	}
+	return fmt.Sprintf("%dm", minutes)
+}
+
+// formatErrors lists error counts by kind, e.g. "rate_limited: 3, no_data: 1"
+func formatErrors(kinds map[errkind.Kind]int) string {
+	if len(kinds) == 0 {
+		return "none"
+	}
+	names := make([]string, 0, len(kinds))
+	for kind := range kinds {
+		names = append(names, string(kind))
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("%s: %d", name, kinds[errkind.Kind(name)]))
+	}
+	return strings.Join(parts, ", ")
+}
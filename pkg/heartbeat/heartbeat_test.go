@@ -0,0 +1,97 @@
+package heartbeat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/errkind"
+	"github.com/hustler/trading-bot/pkg/llm"
+	"github.com/hustler/trading-bot/pkg/monitor"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeChecks struct {
+	health monitor.CheckHealth
+}
+
+func (f *fakeChecks) GetCheckHealth() monitor.CheckHealth { return f.health }
+
+type fakeUsage struct {
+	usage llm.Usage
+}
+
+func (f *fakeUsage) Usage() llm.Usage { return f.usage }
+
+type sentMessage struct {
+	chatID  int64
+	message string
+}
+
+type fakeSender struct {
+	sent []sentMessage
+}
+
+func (f *fakeSender) SendDirectMessage(chatID int64, message string) error {
+	f.sent = append(f.sent, sentMessage{chatID, message})
+	return nil
+}
+
+func TestHeartbeatJob(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	// Thursday 06:00 in New York
+	fake := clock.NewFake(time.Date(2026, 10, 15, 6, 0, 0, 0, newYork))
+	checks := &fakeChecks{}
+	usage := &fakeUsage{}
+	sender := &fakeSender{}
+
+	job := NewJob(checks, usage, sender, []int64{1, 2}, config.HeartbeatConfig{Enabled: true, Hour: 8})
+	job.SetClock(fake)
+
+	fake.Advance(time.Hour)
+	assert.False(t, job.RunIfDue(), "before the configured hour")
+
+	lastCheck := time.Date(2026, 10, 15, 7, 55, 0, 0, newYork)
+	checks.health = monitor.CheckHealth{
+		LastCheck:    &monitor.CheckReport{At: lastCheck},
+		Checks:       12,
+		FetchErrors:  map[errkind.Kind]int{errkind.RateLimited: 3},
+		Explanations: monitor.ExplanationChecks{Checked: 4, Corrected: 1, Corrections: 2},
+	}
+	usage.usage = llm.Usage{Requests: 4, Failures: 1, Tokens: 2000, Cost: 0.04}
+
+	fake.Advance(time.Hour + 30*time.Minute)
+	assert.True(t, job.RunIfDue())
+	assert.False(t, job.RunIfDue(), "once a day")
+	assert.Len(t, sender.sent, 2)
+	assert.Equal(t, int64(2), sender.sent[1].chatID)
+	message := sender.sent[0].message
+	assert.Contains(t, message, "Uptime: 2h 30m")
+	assert.Contains(t, message, "Market checks: 12 since the last heartbeat, 12 since startup")
+	assert.Contains(t, message, "rate_limited: 3")
+	assert.Contains(t, message, "LLM: 4 explanations, 1 failed, ~2000 tokens, $0.04")
+	assert.Contains(t, message, "Explanations corrected: 1 of 4 (25%)")
+	assert.NotContains(t, message, "No market check")
+
+	// Counts are since the previous heartbeat; the monitor has been stuck since Thursday
+	checks.health.Checks = 20
+	checks.health.FetchErrors = map[errkind.Kind]int{errkind.RateLimited: 3, errkind.NoData: 2}
+	usage.usage = llm.Usage{Requests: 6, Failures: 1, Tokens: 3000, Cost: 0.06}
+	fake.Advance(24 * time.Hour)
+
+	beat := job.Beat(fake.Now())
+	assert.Equal(t, 8, beat.Checks)
+	assert.Equal(t, map[errkind.Kind]int{errkind.NoData: 2}, beat.APIErrors)
+	assert.Equal(t, 2, beat.LLM.Requests)
+	assert.InDelta(t, 0.02, beat.LLM.Cost, 1e-9)
+	assert.True(t, beat.Stalled)
+	assert.Equal(t, time.Date(2026, 10, 15, 9, 30, 0, 0, newYork), beat.SessionOpen.In(newYork))
+	assert.Contains(t, FormatHeartbeat(beat), "No market check since the session opened at 2026-10-15 09:30")
+}
+
+func TestFormatUptime(t *testing.T) {
+	assert.Equal(t, "0m", formatUptime(20*time.Second))
+	assert.Equal(t, "3h 5m", formatUptime(3*time.Hour+5*time.Minute))
+	assert.Equal(t, "2d 0h 1m", formatUptime(48*time.Hour+time.Minute))
+}
@@ -0,0 +1,94 @@
+package ids
+
+import (
+	"crypto/rand"
+	"strings"
+	"sync"
+	"time"
+)
+
+// crockford is the Crockford base32 alphabet used by ULIDs
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// Generator creates ULIDs: a 48-bit millisecond timestamp followed by 80
+// random bits, so IDs sort by creation time. IDs created in the same
+// millisecond increment the random part, keeping them unique and ordered
+// across goroutines.
+type Generator struct {
+	lastMs   uint64
+	lastRand [10]byte
+	mu       sync.Mutex
+}
+
+// defaultGenerator is shared by New so all records draw from one sequence
+var defaultGenerator = &Generator{}
+
+// New returns a unique ID for a record created at, e.g. New("SIG-AAPL-BUY", now)
+// returns "SIG-AAPL-BUY-01HV8Y2K3M4N5P6Q7R8S9T0VWX". Safe for concurrent use.
+func New(prefix string, at time.Time) string {
+	return defaultGenerator.New(prefix, at)
+}
+
+// New returns a unique ID with the given prefix for a record created at
+func (g *Generator) New(prefix string, at time.Time) string {
+	ulid := g.ULID(at)
+	if prefix == "" {
+		return ulid
+	}
+	return prefix + "-" + ulid
+}
+
+// ULID returns a new 26-character ULID for the time at
+func (g *Generator) ULID(at time.Time) string {
+	ms := uint64(at.UnixMilli())
+
+	g.mu.Lock()
+	// Within the same millisecond the incremented random part keeps IDs
+	// ordered. Otherwise, including clocks moving backwards in backtests or the
+	// random part overflowing, fresh random bits are drawn.
+	if ms != g.lastMs || !increment(&g.lastRand) {
+		rand.Read(g.lastRand[:])
+		g.lastMs = ms
+	}
+	var id [16]byte
+	for i := 0; i < 6; i++ {
+		id[i] = byte(ms >> (40 - 8*i))
+	}
+	copy(id[6:], g.lastRand[:])
+	g.mu.Unlock()
+
+	return encode(id)
+}
+
+// increment adds one to a big-endian number, reporting false on overflow
+func increment(b *[10]byte) bool {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i]++
+		if b[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// encode writes 128 bits as 26 Crockford base32 characters, most significant first
+func encode(id [16]byte) string {
+	var sb strings.Builder
+	sb.Grow(26)
+	// 26 characters hold 130 bits; the first character carries the top 3 bits
+	for i := 25; i >= 0; i-- {
+		bit := i * 5
+		var v byte
+		for j := 0; j < 5; j++ {
+			pos := bit + j // Bit position counted from the least significant bit
+			if pos >= 128 {
+				continue
+			}
+			if id[15-pos/8]&(1<<(pos%8)) != 0 {
+				v |= 1 << j
+			}
+		}
+		sb.WriteByte(crockford[v])
+	}
+	return sb.String()
+}
@@ -0,0 +1,47 @@
+package ids
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestULIDEncodesTimestamp(t *testing.T) {
+	g := &Generator{}
+	id := g.ULID(time.UnixMilli(1469918176385))
+
+	assert.Len(t, id, 26)
+	// Timestamp part from the ULID spec example
+	assert.Equal(t, "01ARYZ6S41", id[:10])
+}
+
+func TestIDsAreUniqueAndOrderedWithinAMillisecond(t *testing.T) {
+	g := &Generator{}
+	at := time.Date(2025, 4, 17, 15, 30, 0, 0, time.UTC)
+
+	var mu sync.Mutex
+	seen := make(map[string]bool)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 500; j++ {
+				id := g.New("SIG-AAPL-BUY", at)
+				mu.Lock()
+				seen[id] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, seen, 4000)
+
+	first, second := g.New("", at), g.New("", at)
+	assert.Less(t, first, second)
+	assert.Less(t, second, g.New("", at.Add(time.Millisecond)))
+	assert.True(t, strings.HasPrefix(New("TRD", at), "TRD-"))
+}
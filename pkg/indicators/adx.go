@@ -0,0 +1,72 @@
+package indicators
+
+import "math"
+
+// ADX computes Wilder's Average Directional Index with the +DI/-DI lines.
+// Only closing prices are available to the strategy, so directional movement
+// and true range are measured close-to-close.
+//
+// Outputs adx, plus_di and minus_di (prefixed with Prefix if set). Nothing is
+// returned until 2*Period+1 prices are available.
+type ADX struct {
+	Period int
+	Prefix string
+}
+
+// Compute calculates the ADX for the latest bar
+func (a *ADX) Compute(prices, volumes []float64) map[string]float64 {
+	n := len(prices)
+	if a.Period <= 0 || n < 2*a.Period+1 {
+		return map[string]float64{}
+	}
+
+	period := float64(a.Period)
+	var trSum, plusSum, minusSum float64
+	var adx, plusDI, minusDI float64
+
+	for i := 1; i < n; i++ {
+		change := prices[i] - prices[i-1]
+		tr := math.Abs(change)
+		plusDM := math.Max(change, 0)
+		minusDM := math.Max(-change, 0)
+
+		// Seed the smoothed sums with the first period, then apply Wilder smoothing
+		if i <= a.Period {
+			trSum += tr
+			plusSum += plusDM
+			minusSum += minusDM
+			if i < a.Period {
+				continue
+			}
+		} else {
+			trSum = trSum - trSum/period + tr
+			plusSum = plusSum - plusSum/period + plusDM
+			minusSum = minusSum - minusSum/period + minusDM
+		}
+
+		plusDI, minusDI = 0, 0
+		if trSum > 0 {
+			plusDI = 100 * plusSum / trSum
+			minusDI = 100 * minusSum / trSum
+		}
+
+		dx := 0.0
+		if plusDI+minusDI > 0 {
+			dx = 100 * math.Abs(plusDI-minusDI) / (plusDI + minusDI)
+		}
+
+		// The first ADX is the mean of the first period DX values
+		step := i - a.Period + 1
+		if step <= a.Period {
+			adx += dx / period
+		} else {
+			adx = (adx*(period-1) + dx) / period
+		}
+	}
+
+	return map[string]float64{
+		a.Prefix + "adx":      adx,
+		a.Prefix + "plus_di":  plusDI,
+		a.Prefix + "minus_di": minusDI,
+	}
+}
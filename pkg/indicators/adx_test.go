@@ -0,0 +1,34 @@
+package indicators
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestADXTrendStrength(t *testing.T) {
+	adx := &ADX{Period: 14}
+
+	// A steady uptrend has a high ADX with +DI dominating
+	trending := make([]float64, 60)
+	for i := range trending {
+		trending[i] = 100 + float64(i)
+		if i%5 == 4 {
+			trending[i] -= 0.5
+		}
+	}
+	values := adx.Compute(trending, nil)
+	assert.Greater(t, values["adx"], 40.0)
+	assert.Greater(t, values["plus_di"], values["minus_di"])
+
+	// A choppy range has a low ADX
+	ranging := make([]float64, 60)
+	for i := range ranging {
+		ranging[i] = 100 + float64(i%2)
+	}
+	values = adx.Compute(ranging, nil)
+	assert.Less(t, values["adx"], 20.0)
+
+	// Not enough data returns nothing
+	assert.Empty(t, adx.Compute(trending[:20], nil))
+}
@@ -0,0 +1,93 @@
+package indicators
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/data"
+)
+
+// Breadth represents watchlist-wide market breadth statistics
+type Breadth struct {
+	Timestamp           time.Time `json:"timestamp"`
+	Symbols             int       `json:"symbols"`
+	Advancers           int       `json:"advancers"`
+	Decliners           int       `json:"decliners"`
+	Unchanged           int       `json:"unchanged"`
+	AdvanceDeclineRatio float64   `json:"advance_decline_ratio"`
+	PercentAboveVWAP    float64   `json:"percent_above_vwap"`
+	AverageRSI          float64   `json:"average_rsi"`
+}
+
+// ComputeBreadth computes breadth from each symbol's intraday session data.
+// Advancers and decliners are measured against the session's first price.
+func ComputeBreadth(marketData map[string]*data.MarketData, rsiPeriod int) Breadth {
+	breadth := Breadth{Timestamp: time.Now()}
+
+	var aboveVWAP, withVWAP int
+	var rsiSum float64
+	for _, md := range marketData {
+		if md == nil || len(md.Prices) == 0 {
+			continue
+		}
+		breadth.Symbols++
+
+		open := md.Prices[0]
+		last := md.Prices[len(md.Prices)-1]
+		switch {
+		case last > open:
+			breadth.Advancers++
+		case last < open:
+			breadth.Decliners++
+		default:
+			breadth.Unchanged++
+		}
+
+		if vwap := VWAP(md.Prices, md.Volumes); vwap > 0 {
+			withVWAP++
+			if last > vwap {
+				aboveVWAP++
+			}
+		}
+
+		rsiSum += RelativeStrength(md.Prices, rsiPeriod)
+	}
+
+	if breadth.Symbols == 0 {
+		return breadth
+	}
+
+	if breadth.Decliners > 0 {
+		breadth.AdvanceDeclineRatio = float64(breadth.Advancers) / float64(breadth.Decliners)
+	} else {
+		breadth.AdvanceDeclineRatio = float64(breadth.Advancers)
+	}
+	if withVWAP > 0 {
+		breadth.PercentAboveVWAP = float64(aboveVWAP) / float64(withVWAP) * 100
+	}
+	breadth.AverageRSI = rsiSum / float64(breadth.Symbols)
+
+	return breadth
+}
+
+// VWAP returns the volume-weighted average price, or 0 if there is no volume
+func VWAP(prices, volumes []float64) float64 {
+	var value, volume float64
+	for i := 0; i < len(prices) && i < len(volumes); i++ {
+		value += prices[i] * volumes[i]
+		volume += volumes[i]
+	}
+	if volume == 0 {
+		return 0
+	}
+	return value / volume
+}
+
+// Summary formats breadth for reports
+func (b Breadth) Summary() string {
+	summary := fmt.Sprintf("Advancers/Decliners: %d/%d (%d unchanged)\n", b.Advancers, b.Decliners, b.Unchanged)
+	summary += fmt.Sprintf("Advance/Decline Ratio: %.2f\n", b.AdvanceDeclineRatio)
+	summary += fmt.Sprintf("Above VWAP: %.1f%%\n", b.PercentAboveVWAP)
+	summary += fmt.Sprintf("Average RSI: %.1f\n", b.AverageRSI)
+	return summary
+}
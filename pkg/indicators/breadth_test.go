@@ -0,0 +1,30 @@
+package indicators
+
+import (
+	"testing"
+
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeBreadth(t *testing.T) {
+	marketData := map[string]*data.MarketData{
+		// Up on the day, above VWAP
+		"AAPL": {Prices: []float64{100, 101, 102, 103}, Volumes: []float64{10, 10, 10, 10}},
+		// Up on the day, but below VWAP after heavy volume at the high
+		"MSFT": {Prices: []float64{100, 110, 101}, Volumes: []float64{10, 100, 10}},
+		// Down on the day
+		"TSLA": {Prices: []float64{100, 98, 97}, Volumes: []float64{10, 10, 10}},
+		// No data is skipped
+		"META": {},
+	}
+
+	breadth := ComputeBreadth(marketData, 2)
+	assert.Equal(t, 3, breadth.Symbols)
+	assert.Equal(t, 2, breadth.Advancers)
+	assert.Equal(t, 1, breadth.Decliners)
+	assert.Equal(t, 2.0, breadth.AdvanceDeclineRatio)
+	assert.InDelta(t, 33.33, breadth.PercentAboveVWAP, 0.01)
+	assert.InDelta(t, (100.0+52.63+0)/3, breadth.AverageRSI, 0.01)
+	assert.Contains(t, breadth.Summary(), "Advancers/Decliners: 2/1")
+}
@@ -0,0 +1,178 @@
+package indicators
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hustler/trading-bot/pkg/config"
+)
+
+// SeriesIndicator computes indicator values from a price and volume history
+type SeriesIndicator interface {
+	Compute(prices, volumes []float64) map[string]float64
+}
+
+// SeriesFunc adapts a function to the SeriesIndicator interface
+type SeriesFunc func(prices, volumes []float64) map[string]float64
+
+// Compute calls f(prices, volumes)
+func (f SeriesFunc) Compute(prices, volumes []float64) map[string]float64 {
+	return f(prices, volumes)
+}
+
+// Build creates a series indicator from its configuration
+func Build(spec config.IndicatorConfig) (SeriesIndicator, error) {
+	period := spec.Period
+
+	switch spec.Type {
+	case "bollinger":
+		if period == 0 {
+			period = 20
+		}
+		deviation := spec.Deviation
+		if deviation == 0 {
+			deviation = 2.0
+		}
+		prefix := ""
+		if spec.Name != "" {
+			prefix = spec.Name + "_"
+		}
+		return incrementalSeries{
+			SeriesFunc: func(prices, volumes []float64) map[string]float64 {
+				sma := SimpleAverage(prices, period)
+				stdDev := StdDev(prices, period)
+				return map[string]float64{
+					prefix + "sma":        sma,
+					prefix + "upper_band": sma + deviation*stdDev,
+					prefix + "lower_band": sma - deviation*stdDev,
+				}
+			},
+			newState: func() SeriesState {
+				return &bollingerState{prices: newRollingSum(period), squares: newRollingSum(period), deviation: deviation, prefix: prefix}
+			},
+		}, nil
+	case "squeeze":
+		if period == 0 {
+			period = 20
+		}
+		squeeze := &Squeeze{
+			Period:    period,
+			Deviation: spec.Deviation,
+			Lookback:  spec.Lookback,
+			Threshold: spec.Threshold,
+		}
+		if squeeze.Deviation == 0 {
+			squeeze.Deviation = 2.0
+		}
+		if squeeze.Lookback == 0 {
+			squeeze.Lookback = 50
+		}
+		if squeeze.Threshold == 0 {
+			squeeze.Threshold = 20
+		}
+		return squeeze, nil
+	case "adx":
+		if period == 0 {
+			period = 14
+		}
+		prefix := ""
+		if spec.Name != "" {
+			prefix = spec.Name + "_"
+		}
+		return &ADX{Period: period, Prefix: prefix}, nil
+	case "rsi":
+		if period == 0 {
+			period = 14
+		}
+		key := keyOrDefault(spec.Name, "rsi")
+		return incrementalSeries{
+			SeriesFunc: func(prices, volumes []float64) map[string]float64 {
+				return map[string]float64{key: RelativeStrength(prices, period)}
+			},
+			newState: func() SeriesState { return newRSISeriesState(period, key) },
+		}, nil
+	case "sma", "ema":
+		if period == 0 {
+			return nil, fmt.Errorf("indicator %s requires a period", spec.Type)
+		}
+		key := keyOrDefault(spec.Name, spec.Type+"_"+strconv.Itoa(period))
+		if spec.Type == "ema" {
+			// The EMA is seeded afresh from the oldest price of every window, so it has no running state
+			return SeriesFunc(func(prices, volumes []float64) map[string]float64 {
+				return map[string]float64{key: ExponentialAverage(prices, period)}
+			}), nil
+		}
+		return incrementalSeries{
+			SeriesFunc: func(prices, volumes []float64) map[string]float64 {
+				return map[string]float64{key: SimpleAverage(prices, period)}
+			},
+			newState: func() SeriesState { return &averageState{prices: newRollingSum(period), key: key} },
+		}, nil
+	case "volume_ratio":
+		if period == 0 {
+			period = 10
+		}
+		key := keyOrDefault(spec.Name, "volume_ratio")
+		return incrementalSeries{
+			SeriesFunc: func(prices, volumes []float64) map[string]float64 {
+				avgVolume := SimpleAverage(volumes, period)
+				if avgVolume == 0 || len(volumes) == 0 {
+					return map[string]float64{key: 0}
+				}
+				return map[string]float64{key: volumes[len(volumes)-1] / avgVolume * 100}
+			},
+			newState: func() SeriesState { return &volumeRatioState{volumes: newRollingSum(period), key: key} },
+		}, nil
+	case "price_change":
+		key := keyOrDefault(spec.Name, "price_change")
+		return SeriesFunc(func(prices, volumes []float64) map[string]float64 {
+			return map[string]float64{key: PercentChange(prices)}
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown indicator type: %s", spec.Type)
+	}
+}
+
+// BuildAll creates series indicators for every configuration
+func BuildAll(specs []config.IndicatorConfig) ([]SeriesIndicator, error) {
+	built := make([]SeriesIndicator, 0, len(specs))
+	for _, spec := range specs {
+		indicator, err := Build(spec)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build indicator %s: %w", spec.Type, err)
+		}
+		built = append(built, indicator)
+	}
+	return built, nil
+}
+
+// ComputeAll runs every indicator and merges their outputs
+func ComputeAll(set []SeriesIndicator, prices, volumes []float64) map[string]float64 {
+	values := make(map[string]float64)
+	for _, indicator := range set {
+		for key, value := range indicator.Compute(prices, volumes) {
+			values[key] = value
+		}
+	}
+	return values
+}
+
+// DefaultVolatilityIndicators returns the indicator set used by the volatility strategy
+func DefaultVolatilityIndicators(params config.VolatilityConfig) []config.IndicatorConfig {
+	return []config.IndicatorConfig{
+		{Type: "bollinger", Period: params.BollingerPeriod, Deviation: params.BollingerDeviation},
+		{Type: "squeeze", Period: params.BollingerPeriod, Deviation: params.BollingerDeviation},
+		{Type: "rsi", Period: params.RSIPeriod},
+		{Type: "adx", Period: 14},
+		{Type: "volume_ratio", Period: 10},
+		{Type: "price_change"},
+	}
+}
+
+// keyOrDefault returns name if set, otherwise def
+func keyOrDefault(name, def string) string {
+	if name != "" {
+		return name
+	}
+	return def
+}
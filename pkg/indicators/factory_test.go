@@ -0,0 +1,38 @@
+package indicators
+
+import (
+	"testing"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildIndicators(t *testing.T) {
+	prices := []float64{10, 11, 12, 13, 14}
+	volumes := []float64{100, 100, 100, 100, 200}
+
+	set, err := BuildAll([]config.IndicatorConfig{
+		{Type: "bollinger", Period: 5, Deviation: 2},
+		{Type: "sma", Period: 3},
+		{Type: "ema", Period: 3, Name: "fast_ema"},
+		{Type: "volume_ratio", Period: 5},
+		{Type: "price_change"},
+	})
+	assert.NoError(t, err)
+
+	values := ComputeAll(set, prices, volumes)
+	assert.Equal(t, 12.0, values["sma"])
+	assert.InDelta(t, 12+2*1.4142, values["upper_band"], 0.001)
+	assert.Equal(t, 13.0, values["sma_3"])
+	assert.Equal(t, 13.25, values["fast_ema"])
+	assert.InDelta(t, 166.67, values["volume_ratio"], 0.01)
+	assert.InDelta(t, 7.69, values["price_change"], 0.01)
+}
+
+func TestBuildRejectsInvalidIndicators(t *testing.T) {
+	_, err := Build(config.IndicatorConfig{Type: "macd"})
+	assert.Error(t, err)
+
+	_, err = Build(config.IndicatorConfig{Type: "sma"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,293 @@
+package indicators
+
+import (
+	"math"
+	"time"
+)
+
+// Incremental is implemented by series indicators that can carry state from
+// one bar to the next, so a new bar costs a constant amount of work instead
+// of a pass over the history
+type Incremental interface {
+	SeriesIndicator
+	NewState() SeriesState
+}
+
+// SeriesState is the running state of an incremental indicator over one series
+type SeriesState interface {
+	// Push adds the next bar of the series
+	Push(price, volume float64)
+	// Values returns the indicator values at the latest bar, as Compute would
+	Values() map[string]float64
+}
+
+// incrementalSeries is a SeriesFunc with running state
+type incrementalSeries struct {
+	SeriesFunc
+	newState func() SeriesState
+}
+
+// NewState returns empty running state
+func (s incrementalSeries) NewState() SeriesState {
+	return s.newState()
+}
+
+// RollingSet computes an indicator set over the growing price history of many
+// symbols. Incremental indicators are only fed the bars added since the
+// previous computation of a symbol; the others are computed in full. The ADX
+// is one of them: its smoothing starts at the oldest bar, so it changes as the
+// history is trimmed.
+type RollingSet struct {
+	set    []SeriesIndicator
+	states *symbolStates[rollingState]
+}
+
+// rollingState is the running state of the indicator set for one symbol
+type rollingState struct {
+	states    []SeriesState // Per indicator in the set, nil for indicators computed in full
+	lastAt    time.Time     // Timestamp of the latest bar fed
+	lastPrice float64       // Price of the latest bar fed
+}
+
+// NewRollingSet creates a RollingSet for an indicator set
+func NewRollingSet(set []SeriesIndicator) *RollingSet {
+	return &RollingSet{set: set, states: newSymbolStates[rollingState]()}
+}
+
+// Compute returns the values of every indicator at the latest bar, like
+// ComputeAll. Bars are identified by their timestamps. A history that does
+// not continue the one seen last, e.g. after a gap, a restart or a revision
+// of the latest bar, rebuilds the symbol's state from the full history.
+func (r *RollingSet) Compute(symbol string, prices, volumes []float64, timestamps []time.Time) map[string]float64 {
+	n := len(prices)
+	if n == 0 || len(volumes) != n || len(timestamps) != n {
+		return ComputeAll(r.set, prices, volumes)
+	}
+
+	values := make(map[string]float64, 4*len(r.set))
+	r.states.update(symbol, func(state *rollingState, _ bool) {
+		start := state.resume(prices, timestamps)
+		if start < 0 {
+			state.reset(r.set)
+			start = 0
+		}
+		for i := start; i < n; i++ {
+			for _, series := range state.states {
+				if series != nil {
+					series.Push(prices[i], volumes[i])
+				}
+			}
+		}
+		state.lastAt, state.lastPrice = timestamps[n-1], prices[n-1]
+
+		for i, indicator := range r.set {
+			computed := state.states[i]
+			var output map[string]float64
+			if computed != nil {
+				output = computed.Values()
+			} else {
+				output = indicator.Compute(prices, volumes)
+			}
+			for key, value := range output {
+				values[key] = value
+			}
+		}
+	})
+	return values
+}
+
+// resume returns the index of the first bar not fed yet, or -1 if the
+// history does not continue the bars fed so far
+func (s *rollingState) resume(prices []float64, timestamps []time.Time) int {
+	if s.states == nil {
+		return -1
+	}
+	for i := len(timestamps) - 1; i >= 0; i-- {
+		if timestamps[i].Equal(s.lastAt) {
+			if prices[i] != s.lastPrice {
+				return -1
+			}
+			return i + 1
+		}
+		if timestamps[i].Before(s.lastAt) {
+			break
+		}
+	}
+	return -1
+}
+
+// reset replaces the running state with empty state for set
+func (s *rollingState) reset(set []SeriesIndicator) {
+	s.states = make([]SeriesState, len(set))
+	for i, indicator := range set {
+		if incremental, ok := indicator.(Incremental); ok {
+			s.states[i] = incremental.NewState()
+		}
+	}
+}
+
+// rollingSum is the sum of the latest values of a series. It is summed
+// afresh every time the window wraps so rounding errors cannot build up.
+type rollingSum struct {
+	window window
+	sum    float64
+}
+
+// newRollingSum creates a rollingSum over the latest period values
+func newRollingSum(period int) rollingSum {
+	return rollingSum{window: newWindow(period)}
+}
+
+// push adds a value and returns the value evicted from the window
+func (r *rollingSum) push(value float64) float64 {
+	evicted := r.window.push(value)
+	r.sum += value - evicted
+	if r.window.next == 0 && r.window.full {
+		r.sum = r.window.sum()
+	}
+	return evicted
+}
+
+// average returns the mean of the window, or 0 until it is full
+func (r *rollingSum) average() float64 {
+	if !r.window.full {
+		return 0
+	}
+	return r.sum / float64(len(r.window.values))
+}
+
+// bollingerState is the running state of Bollinger bands
+type bollingerState struct {
+	prices    rollingSum
+	squares   rollingSum
+	deviation float64
+	prefix    string
+}
+
+// Push adds the next bar
+func (s *bollingerState) Push(price, volume float64) {
+	s.prices.push(price)
+	s.squares.push(price * price)
+}
+
+// Values returns the middle, upper and lower bands
+func (s *bollingerState) Values() map[string]float64 {
+	sma := s.prices.average()
+	stdDev := math.Sqrt(math.Max(s.squares.average()-sma*sma, 0))
+	return map[string]float64{
+		s.prefix + "sma":        sma,
+		s.prefix + "upper_band": sma + s.deviation*stdDev,
+		s.prefix + "lower_band": sma - s.deviation*stdDev,
+	}
+}
+
+// averageState is the running state of a simple moving average
+type averageState struct {
+	prices rollingSum
+	key    string
+}
+
+// Push adds the next bar
+func (s *averageState) Push(price, volume float64) {
+	s.prices.push(price)
+}
+
+// Values returns the average
+func (s *averageState) Values() map[string]float64 {
+	return map[string]float64{s.key: s.prices.average()}
+}
+
+// rsiSeriesState is the running state of the RSI over a price series
+type rsiSeriesState struct {
+	changes window
+	gains   float64
+	losses  float64
+	falls   int // Negative changes in the window, so losses are exactly 0 without any
+	prev    float64
+	started bool
+	key     string
+}
+
+// newRSISeriesState creates the running state of an RSI over period changes
+func newRSISeriesState(period int, key string) *rsiSeriesState {
+	return &rsiSeriesState{changes: newWindow(period), key: key}
+}
+
+// Push adds the next bar
+func (s *rsiSeriesState) Push(price, volume float64) {
+	if !s.started {
+		s.prev, s.started = price, true
+		return
+	}
+
+	change := price - s.prev
+	s.prev = price
+	wasFull := s.changes.full
+	evicted := s.changes.push(change)
+	if wasFull {
+		s.remove(evicted)
+	}
+	s.add(change)
+
+	// Sum afresh every time the window wraps so rounding errors cannot build up
+	if s.changes.next == 0 && s.changes.full {
+		s.gains, s.losses, s.falls = 0, 0, 0
+		for _, value := range s.changes.values {
+			s.add(value)
+		}
+	}
+}
+
+// add counts a change into the gains or losses
+func (s *rsiSeriesState) add(change float64) {
+	if change >= 0 {
+		s.gains += change
+	} else {
+		s.losses -= change
+		s.falls++
+	}
+}
+
+// remove takes a change evicted from the window out of the gains or losses
+func (s *rsiSeriesState) remove(change float64) {
+	if change >= 0 {
+		s.gains -= change
+	} else {
+		s.losses += change
+		s.falls--
+	}
+}
+
+// Values returns the RSI, or 50 until period changes are available
+func (s *rsiSeriesState) Values() map[string]float64 {
+	if !s.changes.full {
+		return map[string]float64{s.key: 50}
+	}
+	if s.falls == 0 {
+		return map[string]float64{s.key: 100}
+	}
+	rs := math.Max(s.gains, 0) / s.losses
+	return map[string]float64{s.key: 100 - (100 / (1 + rs))}
+}
+
+// volumeRatioState is the running state of the latest volume relative to its average
+type volumeRatioState struct {
+	volumes rollingSum
+	latest  float64
+	key     string
+}
+
+// Push adds the next bar
+func (s *volumeRatioState) Push(price, volume float64) {
+	s.volumes.push(volume)
+	s.latest = volume
+}
+
+// Values returns the latest volume as a percentage of the average
+func (s *volumeRatioState) Values() map[string]float64 {
+	average := s.volumes.average()
+	if average == 0 {
+		return map[string]float64{s.key: 0}
+	}
+	return map[string]float64{s.key: s.latest / average * 100}
+}
@@ -0,0 +1,88 @@
+package indicators
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// randomWalk returns bars of a random walk, one per minute
+func randomWalk(bars int, seed int64) ([]float64, []float64, []time.Time) {
+	rng := rand.New(rand.NewSource(seed))
+	prices := make([]float64, bars)
+	volumes := make([]float64, bars)
+	timestamps := make([]time.Time, bars)
+	price := 100.0
+	start := time.Date(2026, 10, 16, 13, 30, 0, 0, time.UTC)
+	for i := range prices {
+		price += rng.NormFloat64() * 0.5
+		prices[i] = price
+		volumes[i] = float64(1000 + rng.Intn(5000))
+		timestamps[i] = start.Add(time.Duration(i) * time.Minute)
+	}
+	// A flat stretch squeezes the bands
+	for i := 150; i < 180; i++ {
+		prices[i] = prices[149]
+	}
+	return prices, volumes, timestamps
+}
+
+func TestRollingSetMatchesFullComputation(t *testing.T) {
+	set, err := BuildAll(append(DefaultVolatilityIndicators(config.VolatilityConfig{BollingerPeriod: 20, BollingerDeviation: 2, RSIPeriod: 14}),
+		config.IndicatorConfig{Type: "sma", Period: 5}, config.IndicatorConfig{Type: "ema", Period: 5}))
+	assert.NoError(t, err)
+	rolling := NewRollingSet(set)
+	prices, volumes, timestamps := randomWalk(300, 1)
+
+	// The history grows one bar per check and is trimmed to its last 120 bars
+	for end := 1; end <= len(prices); end++ {
+		start := max(0, end-120)
+		want := ComputeAll(set, prices[start:end], volumes[start:end])
+		got := rolling.Compute("AAPL", prices[start:end], volumes[start:end], timestamps[start:end])
+
+		assert.Len(t, got, len(want))
+		for key, value := range want {
+			assert.InDelta(t, value, got[key], 1e-6, "%s at bar %d", key, end)
+		}
+		for _, key := range []string{"band_width_percentile", "squeeze", "squeeze_breakout"} {
+			assert.Equal(t, want[key], got[key], "%s at bar %d", key, end)
+		}
+	}
+
+	// A revised latest bar rebuilds the state from the full history
+	revised := append([]float64(nil), prices[180:]...)
+	revised[len(revised)-1] += 5
+	want := ComputeAll(set, revised, volumes[180:])
+	got := rolling.Compute("AAPL", revised, volumes[180:], timestamps[180:])
+	assert.InDelta(t, want["rsi"], got["rsi"], 1e-9)
+	assert.InDelta(t, want["upper_band"], got["upper_band"], 1e-9)
+}
+
+// BenchmarkRollingSet measures a check of 1000 symbols with one new bar each
+// against recomputing the indicator set in full
+func BenchmarkRollingSet(b *testing.B) {
+	set, _ := BuildAll(DefaultVolatilityIndicators(config.VolatilityConfig{BollingerPeriod: 20, BollingerDeviation: 2, RSIPeriod: 14}))
+	prices, volumes, timestamps := randomWalk(400, 1)
+
+	b.Run("full", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			end := 200 + i%200
+			ComputeAll(set, prices[end-200:end], volumes[end-200:end])
+		}
+	})
+	b.Run("incremental", func(b *testing.B) {
+		rolling := NewRollingSet(set)
+		for _, symbol := range benchmarkSymbols {
+			rolling.Compute(symbol, prices[:200], volumes[:200], timestamps[:200])
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			// Every symbol gets the next bar once per round of the watchlist
+			end := 201 + i/len(benchmarkSymbols)%199
+			rolling.Compute(benchmarkSymbols[i%len(benchmarkSymbols)], prices[end-200:end], volumes[end-200:end], timestamps[end-200:end])
+		}
+	})
+}
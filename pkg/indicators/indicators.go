@@ -2,6 +2,7 @@ package indicators
 
 import (
 	"math"
+	"strconv"
 	"sync"
 
 	"github.com/hustler/trading-bot/pkg/data"
@@ -13,37 +14,48 @@ type Indicator interface {
 	GetName() string
 }
 
-// IndicatorProcessor processes technical indicators for stocks
+// IndicatorProcessor processes technical indicators for stocks. Symbols are
+// spread over shards with their own locks, so updates for different symbols
+// rarely contend.
 type IndicatorProcessor struct {
+	shards [shardCount]processorShard
+}
+
+// processorShard is the indicator values of the symbols hashing to one shard
+type processorShard struct {
 	indicators map[string]map[string]float64
 	mu         sync.RWMutex
 }
 
 // NewIndicatorProcessor creates a new IndicatorProcessor
 func NewIndicatorProcessor() *IndicatorProcessor {
-	return &IndicatorProcessor{
-		indicators: make(map[string]map[string]float64),
+	p := &IndicatorProcessor{}
+	for i := range p.shards {
+		p.shards[i].indicators = make(map[string]map[string]float64)
 	}
+	return p
 }
 
 // UpdateIndicator updates an indicator value for a stock
 func (p *IndicatorProcessor) UpdateIndicator(symbol, indicator string, value float64) {
-	p.mu.Lock()
-	defer p.mu.Unlock()
+	shard := &p.shards[shardOf(symbol)]
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
 
-	if _, exists := p.indicators[symbol]; !exists {
-		p.indicators[symbol] = make(map[string]float64)
+	if _, exists := shard.indicators[symbol]; !exists {
+		shard.indicators[symbol] = make(map[string]float64)
 	}
 
-	p.indicators[symbol][indicator] = value
+	shard.indicators[symbol][indicator] = value
 }
 
 // GetIndicator gets an indicator value for a stock
 func (p *IndicatorProcessor) GetIndicator(symbol, indicator string) (float64, bool) {
-	p.mu.RLock()
-	defer p.mu.RUnlock()
+	shard := &p.shards[shardOf(symbol)]
+	shard.mu.RLock()
+	defer shard.mu.RUnlock()
 
-	if indicators, exists := p.indicators[symbol]; exists {
+	if indicators, exists := shard.indicators[symbol]; exists {
 		value, exists := indicators[indicator]
 		return value, exists
 	}
@@ -53,38 +65,37 @@ func (p *IndicatorProcessor) GetIndicator(symbol, indicator string) (float64, bo
 
 // GetAllIndicators gets all indicators for a stock
 func (p *IndicatorProcessor) GetAllIndicators(symbol string) map[string]float64 {
-	p.mu.RLock()
-	defer p.mu.RUnlock()
+	shard := &p.shards[shardOf(symbol)]
+	shard.mu.RLock()
+	defer shard.mu.RUnlock()
 
-	if indicators, exists := p.indicators[symbol]; exists {
-		result := make(map[string]float64)
-		for k, v := range indicators {
-			result[k] = v
-		}
-		return result
+	result := make(map[string]float64, len(shard.indicators[symbol]))
+	for k, v := range shard.indicators[symbol] {
+		result[k] = v
 	}
-
-	return make(map[string]float64)
+	return result
 }
 
 // RSI represents the Relative Strength Index indicator
 type RSI struct {
-	period      int
-	gains       map[string][]float64
-	losses      map[string][]float64
-	prevPrices  map[string]float64
-	mu          sync.RWMutex
-	processor   *IndicatorProcessor
+	period    int
+	states    *symbolStates[rsiState]
+	processor *IndicatorProcessor
+}
+
+// rsiState is the RSI history of one symbol
+type rsiState struct {
+	prevPrice float64
+	gains     window
+	losses    window
 }
 
 // NewRSI creates a new RSI indicator
 func NewRSI(period int, processor *IndicatorProcessor) *RSI {
 	return &RSI{
-		period:     period,
-		gains:      make(map[string][]float64),
-		losses:     make(map[string][]float64),
-		prevPrices: make(map[string]float64),
-		processor:  processor,
+		period:    period,
+		states:    newSymbolStates[rsiState](),
+		processor: processor,
 	}
 }
 
@@ -95,67 +106,53 @@ func (r *RSI) GetName() string {
 
 // Calculate calculates the RSI value for a stock
 func (r *RSI) Calculate(stock *data.Stock) float64 {
-	r.mu.Lock()
-	defer r.mu.Unlock()
-
 	symbol := stock.Symbol
 	currentPrice := stock.CurrentPrice
 
-	// Initialize if this is the first calculation for this symbol
-	if _, exists := r.prevPrices[symbol]; !exists {
-		r.prevPrices[symbol] = currentPrice
-		r.gains[symbol] = make([]float64, 0, r.period)
-		r.losses[symbol] = make([]float64, 0, r.period)
-		return 50 // Default neutral value
-	}
+	rsi, ready := 50.0, false // Default neutral value
+	r.states.update(symbol, func(state *rsiState, created bool) {
+		// Initialize if this is the first calculation for this symbol
+		if created {
+			state.prevPrice = currentPrice
+			state.gains = newWindow(r.period)
+			state.losses = newWindow(r.period)
+			return
+		}
 
-	// Calculate price change
-	prevPrice := r.prevPrices[symbol]
-	change := currentPrice - prevPrice
-	r.prevPrices[symbol] = currentPrice
-
-	// Update gains and losses
-	if change > 0 {
-		r.gains[symbol] = append(r.gains[symbol], change)
-		r.losses[symbol] = append(r.losses[symbol], 0)
-	} else {
-		r.gains[symbol] = append(r.gains[symbol], 0)
-		r.losses[symbol] = append(r.losses[symbol], math.Abs(change))
-	}
+		// Calculate price change
+		change := currentPrice - state.prevPrice
+		state.prevPrice = currentPrice
+
+		// Update gains and losses
+		if change > 0 {
+			state.gains.push(change)
+			state.losses.push(0)
+		} else {
+			state.gains.push(0)
+			state.losses.push(math.Abs(change))
+		}
 
-	// Trim to period length
-	if len(r.gains[symbol]) > r.period {
-		r.gains[symbol] = r.gains[symbol][len(r.gains[symbol])-r.period:]
-		r.losses[symbol] = r.losses[symbol][len(r.losses[symbol])-r.period:]
-	}
+		// Not enough data yet
+		if !state.gains.full {
+			return
+		}
 
-	// Not enough data yet
-	if len(r.gains[symbol]) < r.period {
-		return 50 // Default neutral value
-	}
+		// Calculate average gain and loss
+		avgGain := state.gains.sum() / float64(r.period)
+		avgLoss := state.losses.sum() / float64(r.period)
 
-	// Calculate average gain and loss
-	var avgGain, avgLoss float64
-	for _, gain := range r.gains[symbol] {
-		avgGain += gain
-	}
-	for _, loss := range r.losses[symbol] {
-		avgLoss += loss
-	}
-	avgGain /= float64(r.period)
-	avgLoss /= float64(r.period)
-
-	// Calculate RSI
-	var rsi float64
-	if avgLoss == 0 {
-		rsi = 100
-	} else {
-		rs := avgGain / avgLoss
-		rsi = 100 - (100 / (1 + rs))
-	}
+		// Calculate RSI
+		if avgLoss == 0 {
+			rsi = 100
+		} else {
+			rs := avgGain / avgLoss
+			rsi = 100 - (100 / (1 + rs))
+		}
+		ready = true
+	})
 
 	// Update the indicator processor
-	if r.processor != nil {
+	if ready && r.processor != nil {
 		r.processor.UpdateIndicator(symbol, r.GetName(), rsi)
 	}
 
@@ -165,82 +162,74 @@ func (r *RSI) Calculate(stock *data.Stock) float64 {
 // MovingAverage represents a moving average indicator
 type MovingAverage struct {
 	period    int
-	prices    map[string][]float64
-	mu        sync.RWMutex
+	states    *symbolStates[window]
 	processor *IndicatorProcessor
 	maType    string // "SMA" or "EMA"
+	name      string
 }
 
 // NewSMA creates a new Simple Moving Average indicator
 func NewSMA(period int, processor *IndicatorProcessor) *MovingAverage {
-	return &MovingAverage{
-		period:    period,
-		prices:    make(map[string][]float64),
-		processor: processor,
-		maType:    "SMA",
-	}
+	return newMovingAverage("SMA", period, processor)
 }
 
 // NewEMA creates a new Exponential Moving Average indicator
 func NewEMA(period int, processor *IndicatorProcessor) *MovingAverage {
+	return newMovingAverage("EMA", period, processor)
+}
+
+// newMovingAverage creates a moving average of the given type
+func newMovingAverage(maType string, period int, processor *IndicatorProcessor) *MovingAverage {
 	return &MovingAverage{
 		period:    period,
-		prices:    make(map[string][]float64),
+		states:    newSymbolStates[window](),
 		processor: processor,
-		maType:    "EMA",
+		maType:    maType,
+		name:      maType + "-" + strconv.Itoa(period),
 	}
 }
 
 // GetName returns the name of the indicator
 func (m *MovingAverage) GetName() string {
-	return m.maType + "-" + string(rune(m.period+'0'))
+	return m.name
 }
 
 // Calculate calculates the moving average value for a stock
 func (m *MovingAverage) Calculate(stock *data.Stock) float64 {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-
 	symbol := stock.Symbol
 	currentPrice := stock.CurrentPrice
 
-	// Initialize if this is the first calculation for this symbol
-	if _, exists := m.prices[symbol]; !exists {
-		m.prices[symbol] = make([]float64, 0, m.period)
-	}
-
-	// Add current price
-	m.prices[symbol] = append(m.prices[symbol], currentPrice)
+	ma, ready := currentPrice, false // Default to current price
+	m.states.update(symbol, func(prices *window, created bool) {
+		// Initialize if this is the first calculation for this symbol
+		if created {
+			*prices = newWindow(m.period)
+		}
 
-	// Trim to period length
-	if len(m.prices[symbol]) > m.period {
-		m.prices[symbol] = m.prices[symbol][len(m.prices[symbol])-m.period:]
-	}
+		// Add current price
+		prices.push(currentPrice)
 
-	// Not enough data yet
-	if len(m.prices[symbol]) < m.period {
-		return currentPrice // Default to current price
-	}
-
-	var ma float64
-	if m.maType == "SMA" {
-		// Calculate Simple Moving Average
-		var sum float64
-		for _, price := range m.prices[symbol] {
-			sum += price
+		// Not enough data yet
+		if !prices.full {
+			return
 		}
-		ma = sum / float64(m.period)
-	} else {
-		// Calculate Exponential Moving Average
-		k := 2.0 / float64(m.period+1)
-		ma = m.prices[symbol][0]
-		for i := 1; i < len(m.prices[symbol]); i++ {
-			ma = m.prices[symbol][i]*k + ma*(1-k)
+
+		if m.maType == "SMA" {
+			// Calculate Simple Moving Average
+			ma = prices.sum() / float64(m.period)
+		} else {
+			// Calculate Exponential Moving Average
+			k := 2.0 / float64(m.period+1)
+			ma = prices.at(0)
+			for i := 1; i < m.period; i++ {
+				ma = prices.at(i)*k + ma*(1-k)
+			}
 		}
-	}
+		ready = true
+	})
 
 	// Update the indicator processor
-	if m.processor != nil {
+	if ready && m.processor != nil {
 		m.processor.UpdateIndicator(symbol, m.GetName(), ma)
 	}
 
@@ -249,15 +238,14 @@ func (m *MovingAverage) Calculate(stock *data.Stock) float64 {
 
 // VolumeAnalyzer analyzes volume changes
 type VolumeAnalyzer struct {
-	prevVolumes map[string]int64
-	mu          sync.RWMutex
+	prevVolumes *symbolStates[int64]
 	processor   *IndicatorProcessor
 }
 
 // NewVolumeAnalyzer creates a new VolumeAnalyzer
 func NewVolumeAnalyzer(processor *IndicatorProcessor) *VolumeAnalyzer {
 	return &VolumeAnalyzer{
-		prevVolumes: make(map[string]int64),
+		prevVolumes: newSymbolStates[int64](),
 		processor:   processor,
 	}
 }
@@ -269,29 +257,29 @@ func (v *VolumeAnalyzer) GetName() string {
 
 // Calculate calculates the volume surge indicator for a stock
 func (v *VolumeAnalyzer) Calculate(stock *data.Stock) float64 {
-	v.mu.Lock()
-	defer v.mu.Unlock()
-
 	symbol := stock.Symbol
 	currentVolume := stock.Volume
 
-	// Initialize if this is the first calculation for this symbol
-	if _, exists := v.prevVolumes[symbol]; !exists {
-		v.prevVolumes[symbol] = currentVolume
-		return 0 // No surge yet
-	}
-
-	prevVolume := v.prevVolumes[symbol]
-	v.prevVolumes[symbol] = currentVolume
-
-	// Calculate volume change percentage
 	var volumeChange float64
-	if prevVolume > 0 {
-		volumeChange = float64(currentVolume-prevVolume) / float64(prevVolume) * 100
-	}
+	ready := false
+	v.prevVolumes.update(symbol, func(prevVolume *int64, created bool) {
+		previous := *prevVolume
+		*prevVolume = currentVolume
+
+		// No surge on the first calculation for this symbol
+		if created {
+			return
+		}
+
+		// Calculate volume change percentage
+		if previous > 0 {
+			volumeChange = float64(currentVolume-previous) / float64(previous) * 100
+		}
+		ready = true
+	})
 
 	// Update the indicator processor
-	if v.processor != nil {
+	if ready && v.processor != nil {
 		v.processor.UpdateIndicator(symbol, v.GetName(), volumeChange)
 	}
 
@@ -0,0 +1,93 @@
+package indicators
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/data"
+)
+
+// IndicatorLogger persists indicator values (implemented by store.Logger)
+type IndicatorLogger interface {
+	LogIndicator(symbol, indicatorName string, value float64) error
+}
+
+// Pipeline feeds market updates through a set of indicators and keeps the
+// IndicatorProcessor current for every symbol
+type Pipeline struct {
+	processor      *IndicatorProcessor
+	indicators     []Indicator
+	logger         IndicatorLogger
+	sampleInterval time.Duration
+	lastLogged     *symbolStates[time.Time]
+	now            func() time.Time
+	mu             sync.RWMutex
+}
+
+// NewPipeline creates a new Pipeline writing results to processor
+func NewPipeline(processor *IndicatorProcessor, indicators ...Indicator) *Pipeline {
+	return &Pipeline{
+		processor:  processor,
+		indicators: indicators,
+		lastLogged: newSymbolStates[time.Time](),
+		now:        time.Now,
+	}
+}
+
+// NewDefaultPipeline creates a Pipeline with RSI-14, SMA-20, EMA-20 and volume surge
+func NewDefaultPipeline(processor *IndicatorProcessor) *Pipeline {
+	return NewPipeline(processor,
+		NewRSI(14, processor),
+		NewSMA(20, processor),
+		NewEMA(20, processor),
+		NewVolumeAnalyzer(processor),
+	)
+}
+
+// SetLogger enables persistence of indicator values, at most once per interval per symbol
+func (p *Pipeline) SetLogger(logger IndicatorLogger, interval time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.logger = logger
+	p.sampleInterval = interval
+}
+
+// Attach subscribes the pipeline to a MarketWatcher's updates
+func (p *Pipeline) Attach(watcher *data.MarketWatcher) {
+	watcher.OnUpdate(p.Process)
+}
+
+// Process calculates all indicators for a stock update
+func (p *Pipeline) Process(stock *data.Stock) {
+	for _, indicator := range p.indicators {
+		// Indicators only publish once warmed up; publish every value so readers never see stale data
+		p.processor.UpdateIndicator(stock.Symbol, indicator.GetName(), indicator.Calculate(stock))
+	}
+
+	p.mu.RLock()
+	logger, interval := p.logger, p.sampleInterval
+	p.mu.RUnlock()
+	if logger == nil {
+		return
+	}
+
+	now := p.now()
+	due := false
+	p.lastLogged.update(stock.Symbol, func(lastLogged *time.Time, _ bool) {
+		if now.Sub(*lastLogged) >= interval {
+			*lastLogged = now
+			due = true
+		}
+	})
+	if !due {
+		return
+	}
+
+	for name, value := range p.processor.GetAllIndicators(stock.Symbol) {
+		if err := logger.LogIndicator(stock.Symbol, name, value); err != nil {
+			log.Printf("Error logging indicator %s for %s: %v", name, stock.Symbol, err)
+		}
+	}
+}
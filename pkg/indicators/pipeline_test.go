@@ -0,0 +1,141 @@
+package indicators
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingLogger struct {
+	values map[string][]float64
+}
+
+func (l *recordingLogger) LogIndicator(symbol, indicatorName string, value float64) error {
+	l.values[symbol+"/"+indicatorName] = append(l.values[symbol+"/"+indicatorName], value)
+	return nil
+}
+
+func TestPipelineUpdatesProcessorAndSamplesLogs(t *testing.T) {
+	processor := NewIndicatorProcessor()
+	pipeline := NewPipeline(processor, NewSMA(3, processor), NewVolumeAnalyzer(processor))
+
+	now := time.Date(2025, 4, 21, 14, 0, 0, 0, time.UTC)
+	pipeline.now = func() time.Time { return now }
+	logger := &recordingLogger{values: make(map[string][]float64)}
+	pipeline.SetLogger(logger, time.Minute)
+
+	for i, price := range []float64{10, 11, 12, 13} {
+		pipeline.Process(&data.Stock{Symbol: "AAPL", CurrentPrice: price, Volume: int64(1000 * (i + 1))})
+		now = now.Add(20 * time.Second)
+	}
+
+	sma, ok := processor.GetIndicator("AAPL", "SMA-3")
+	assert.True(t, ok)
+	assert.Equal(t, 12.0, sma)
+
+	surge, ok := processor.GetIndicator("AAPL", "VolumeSurge")
+	assert.True(t, ok)
+	assert.InDelta(t, 33.33, surge, 0.01)
+
+	// Updates at 0s and 60s are logged; 20s and 40s fall inside the sampling interval
+	assert.Equal(t, []float64{10, 12}, logger.values["AAPL/SMA-3"])
+}
+
+func TestPipelineProcessesSymbolsConcurrently(t *testing.T) {
+	processor := NewIndicatorProcessor()
+	pipeline := NewDefaultPipeline(processor)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(symbol string) {
+			defer wg.Done()
+			for tick := 1; tick <= 30; tick++ {
+				pipeline.Process(&data.Stock{Symbol: symbol, CurrentPrice: float64(tick), Volume: int64(tick * 100)})
+			}
+		}(fmt.Sprintf("SYM%d", i))
+	}
+	wg.Wait()
+
+	for i := 0; i < 100; i++ {
+		symbol := fmt.Sprintf("SYM%d", i)
+		sma, _ := processor.GetIndicator(symbol, "SMA-20")
+		assert.Equal(t, 20.5, sma, symbol)
+		rsi, _ := processor.GetIndicator(symbol, "RSI")
+		assert.Equal(t, 100.0, rsi, symbol)
+	}
+}
+
+// benchmarkSymbols are the symbols of the tick benchmarks
+var benchmarkSymbols = func() []string {
+	symbols := make([]string, 1000)
+	for i := range symbols {
+		symbols[i] = fmt.Sprintf("SYM%04d", i)
+	}
+	return symbols
+}()
+
+// BenchmarkPipeline1000Symbols measures ticks processed per second across
+// 1000 symbols from concurrent feeds. Keeping up with 1000 symbols at one
+// tick per second each needs 1000 ticks/s.
+func BenchmarkPipeline1000Symbols(b *testing.B) {
+	pipeline := NewDefaultPipeline(NewIndicatorProcessor())
+	stocks := make([]data.Stock, len(benchmarkSymbols))
+	for i, symbol := range benchmarkSymbols {
+		stocks[i] = data.Stock{Symbol: symbol, CurrentPrice: 100, Volume: 1000}
+	}
+
+	var mu sync.Mutex
+	next := 0
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		// Each goroutine owns a slice of the symbols, as a feed per exchange would
+		mu.Lock()
+		offset := next * 97
+		next++
+		mu.Unlock()
+
+		for i := offset; pb.Next(); i++ {
+			stock := stocks[i%len(stocks)]
+			stock.CurrentPrice += float64(i%7) - 3
+			pipeline.Process(&stock)
+		}
+	})
+	b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "ticks/s")
+}
+
+// BenchmarkProcessorReads measures indicator reads while another goroutine
+// keeps updating, as the signal generator reads during a market check
+func BenchmarkProcessorReads(b *testing.B) {
+	processor := NewIndicatorProcessor()
+	pipeline := NewDefaultPipeline(processor)
+	for _, symbol := range benchmarkSymbols {
+		pipeline.Process(&data.Stock{Symbol: symbol, CurrentPrice: 100, Volume: 1000})
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for i := 0; ; i++ {
+			select {
+			case <-done:
+				return
+			default:
+				pipeline.Process(&data.Stock{Symbol: benchmarkSymbols[i%len(benchmarkSymbols)], CurrentPrice: 100, Volume: 1000})
+			}
+		}
+	}()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			processor.GetIndicator(benchmarkSymbols[i%len(benchmarkSymbols)], "RSI")
+		}
+	})
+}
@@ -0,0 +1,148 @@
+package indicators
+
+import "math"
+
+// SimpleAverage returns the mean of the last period values, or 0 if there are not enough values
+func SimpleAverage(values []float64, period int) float64 {
+	if period <= 0 || len(values) < period {
+		return 0
+	}
+
+	sum := 0.0
+	for i := len(values) - period; i < len(values); i++ {
+		sum += values[i]
+	}
+
+	return sum / float64(period)
+}
+
+// ExponentialAverage returns the EMA of the last period values, seeded with the oldest of them
+func ExponentialAverage(values []float64, period int) float64 {
+	if period <= 0 || len(values) < period {
+		return 0
+	}
+
+	k := 2.0 / float64(period+1)
+	window := values[len(values)-period:]
+	ema := window[0]
+	for i := 1; i < len(window); i++ {
+		ema = window[i]*k + ema*(1-k)
+	}
+
+	return ema
+}
+
+// StdDev returns the population standard deviation of the last period values
+func StdDev(values []float64, period int) float64 {
+	if period <= 0 || len(values) < period {
+		return 0
+	}
+
+	mean := SimpleAverage(values, period)
+	sumSquaredDiff := 0.0
+	for i := len(values) - period; i < len(values); i++ {
+		diff := values[i] - mean
+		sumSquaredDiff += diff * diff
+	}
+
+	return math.Sqrt(sumSquaredDiff / float64(period))
+}
+
+// RelativeStrength returns the RSI over the last period price changes, or 50 if there are not enough prices
+func RelativeStrength(prices []float64, period int) float64 {
+	if period <= 0 || len(prices) < period+1 {
+		return 50 // Default to neutral
+	}
+
+	gains := 0.0
+	losses := 0.0
+	for i := len(prices) - period; i < len(prices); i++ {
+		change := prices[i] - prices[i-1]
+		if change >= 0 {
+			gains += change
+		} else {
+			losses -= change
+		}
+	}
+
+	if losses == 0 {
+		return 100 // All gains
+	}
+
+	rs := gains / losses
+	return 100 - (100 / (1 + rs))
+}
+
+// PercentChange returns the percentage change between the last two values
+func PercentChange(values []float64) float64 {
+	if len(values) < 2 {
+		return 0
+	}
+
+	current := values[len(values)-1]
+	previous := values[len(values)-2]
+	if previous == 0 {
+		return 0
+	}
+
+	return (current - previous) / previous * 100
+}
+
+// logReturns returns the log returns of the last period+1 values
+func logReturns(values []float64, period int) []float64 {
+	if period <= 0 || len(values) < period+1 {
+		return nil
+	}
+
+	window := values[len(values)-period-1:]
+	returns := make([]float64, 0, period)
+	for i := 1; i < len(window); i++ {
+		if window[i-1] <= 0 || window[i] <= 0 {
+			return nil
+		}
+		returns = append(returns, math.Log(window[i]/window[i-1]))
+	}
+	return returns
+}
+
+// HistoricalVolatility returns the annualized volatility (in percent) of daily closes over period days
+func HistoricalVolatility(closes []float64, period int) float64 {
+	returns := logReturns(closes, period)
+	if len(returns) < 2 {
+		return 0
+	}
+
+	// Sample standard deviation of daily returns, annualized over 252 trading days
+	mean := SimpleAverage(returns, len(returns))
+	sumSquaredDiff := 0.0
+	for _, r := range returns {
+		sumSquaredDiff += (r - mean) * (r - mean)
+	}
+	daily := math.Sqrt(sumSquaredDiff / float64(len(returns)-1))
+
+	return daily * math.Sqrt(252) * 100
+}
+
+// Beta returns the beta of asset closes against benchmark closes over period days.
+// Both series must end on the same day.
+func Beta(asset, benchmark []float64, period int) float64 {
+	assetReturns := logReturns(asset, period)
+	benchReturns := logReturns(benchmark, period)
+	if len(assetReturns) < 2 || len(assetReturns) != len(benchReturns) {
+		return 0
+	}
+
+	assetMean := SimpleAverage(assetReturns, len(assetReturns))
+	benchMean := SimpleAverage(benchReturns, len(benchReturns))
+
+	var covariance, variance float64
+	for i := range assetReturns {
+		covariance += (assetReturns[i] - assetMean) * (benchReturns[i] - benchMean)
+		variance += (benchReturns[i] - benchMean) * (benchReturns[i] - benchMean)
+	}
+	if variance == 0 {
+		return 0
+	}
+
+	return covariance / variance
+}
@@ -0,0 +1,31 @@
+package indicators
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistoricalVolatilityAndBeta(t *testing.T) {
+	benchmark := make([]float64, 61)
+	asset := make([]float64, 61)
+	benchmark[0], asset[0] = 100, 50
+	for i := 1; i < len(benchmark); i++ {
+		move := 0.01
+		if i%2 == 0 {
+			move = -0.008
+		}
+		benchmark[i] = benchmark[i-1] * math.Exp(move)
+		// The asset moves twice as much as the benchmark every day
+		asset[i] = asset[i-1] * math.Exp(2*move)
+	}
+
+	assert.InDelta(t, 2.0, Beta(asset, benchmark, 60), 1e-9)
+	assert.InDelta(t, 2*HistoricalVolatility(benchmark, 20), HistoricalVolatility(asset, 20), 1e-9)
+	assert.Greater(t, HistoricalVolatility(benchmark, 20), 10.0)
+
+	// Not enough history
+	assert.Equal(t, 0.0, HistoricalVolatility(asset[:10], 20))
+	assert.Equal(t, 0.0, Beta(asset, benchmark[:30], 60))
+}
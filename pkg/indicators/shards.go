@@ -0,0 +1,104 @@
+package indicators
+
+import "sync"
+
+// shardCount is the number of locks per-symbol state is spread over. Updates
+// for symbols in different shards never wait on each other.
+const shardCount = 64
+
+// shardOf returns the shard of a symbol, hashing it with FNV-1a
+func shardOf(symbol string) int {
+	hash := uint32(2166136261)
+	for i := 0; i < len(symbol); i++ {
+		hash ^= uint32(symbol[i])
+		hash *= 16777619
+	}
+	return int(hash % shardCount)
+}
+
+// symbolStates holds the state of an indicator for every symbol, each shard
+// behind its own lock
+type symbolStates[T any] struct {
+	shards [shardCount]stateShard[T]
+}
+
+// stateShard is the states of the symbols hashing to one shard
+type stateShard[T any] struct {
+	mu     sync.Mutex
+	states map[string]*T
+}
+
+// newSymbolStates creates an empty symbolStates
+func newSymbolStates[T any]() *symbolStates[T] {
+	s := &symbolStates[T]{}
+	for i := range s.shards {
+		s.shards[i].states = make(map[string]*T)
+	}
+	return s
+}
+
+// update runs fn with the state of symbol under its shard's lock. The state
+// is created on the first update of a symbol, with created set.
+func (s *symbolStates[T]) update(symbol string, fn func(state *T, created bool)) {
+	shard := &s.shards[shardOf(symbol)]
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	state, exists := shard.states[symbol]
+	if !exists {
+		state = new(T)
+		shard.states[symbol] = state
+	}
+	fn(state, !exists)
+}
+
+// window is a fixed-size ring of the latest values of a series
+type window struct {
+	values []float64
+	next   int // Index the next value is written to
+	full   bool
+}
+
+// newWindow creates a window holding the latest size values. A window of
+// size 0 or less holds nothing and never fills.
+func newWindow(size int) window {
+	return window{values: make([]float64, max(size, 0))}
+}
+
+// push adds a value, evicting the oldest once the window is full, and
+// returns the evicted value (0 while the window fills)
+func (w *window) push(value float64) float64 {
+	if len(w.values) == 0 {
+		return value
+	}
+	evicted := w.values[w.next]
+	w.values[w.next] = value
+	w.next++
+	if w.next == len(w.values) {
+		w.next = 0
+		w.full = true
+	}
+	return evicted
+}
+
+// at returns the i-th value from the oldest. The window must be full.
+func (w *window) at(i int) float64 {
+	return w.values[(w.next+i)%len(w.values)]
+}
+
+// filled returns the values pushed so far, in no particular order
+func (w *window) filled() []float64 {
+	if w.full {
+		return w.values
+	}
+	return w.values[:w.next]
+}
+
+// sum returns the sum of the values in the window
+func (w *window) sum() float64 {
+	var total float64
+	for _, value := range w.values {
+		total += value
+	}
+	return total
+}
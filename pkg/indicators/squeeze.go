@@ -0,0 +1,184 @@
+package indicators
+
+import "math"
+
+// Squeeze measures Bollinger band width and detects squeezes, where the band width
+// is in the lowest percentiles of its recent history, and breakouts out of them.
+//
+// Outputs:
+//   - band_width: (upper - lower) / middle * 100
+//   - band_width_percentile: share of the lookback history at or below the current width
+//   - squeeze: 1 while the band width percentile is at or below Threshold
+//   - squeeze_breakout: +1/-1 when the previous bar was squeezed and price closes above/below the bands
+type Squeeze struct {
+	Period    int
+	Deviation float64
+	Lookback  int
+	Threshold float64
+}
+
+// Compute calculates band width and squeeze state for the latest bar
+func (s *Squeeze) Compute(prices, volumes []float64) map[string]float64 {
+	values := map[string]float64{
+		"band_width":            0,
+		"band_width_percentile": 100,
+		"squeeze":               0,
+		"squeeze_breakout":      0,
+	}
+
+	n := len(prices)
+	if n < s.Period {
+		return values
+	}
+
+	width := s.bandWidth(prices)
+	percentile := s.percentile(prices, width)
+	values["band_width"] = width
+	values["band_width_percentile"] = percentile
+	if percentile <= s.Threshold {
+		values["squeeze"] = 1
+	}
+
+	// A breakout needs the previous bar squeezed and the current close outside the bands
+	if n < s.Period+1 {
+		return values
+	}
+	previous := prices[:n-1]
+	if s.percentile(previous, s.bandWidth(previous)) > s.Threshold {
+		return values
+	}
+
+	sma := SimpleAverage(prices, s.Period)
+	stdDev := StdDev(prices, s.Period)
+	price := prices[n-1]
+	switch {
+	case price > sma+s.Deviation*stdDev:
+		values["squeeze_breakout"] = 1
+	case price < sma-s.Deviation*stdDev:
+		values["squeeze_breakout"] = -1
+	}
+
+	return values
+}
+
+// bandWidth returns the Bollinger band width of the last Period prices as a percentage of the middle band
+func (s *Squeeze) bandWidth(prices []float64) float64 {
+	sma := SimpleAverage(prices, s.Period)
+	if sma == 0 {
+		return 0
+	}
+	return 2 * s.Deviation * StdDev(prices, s.Period) / sma * 100
+}
+
+// percentile ranks width against the band widths of up to Lookback preceding bars
+func (s *Squeeze) percentile(prices []float64, width float64) float64 {
+	n := len(prices)
+	start := n - s.Lookback
+	if start < s.Period {
+		start = s.Period
+	}
+
+	total, atOrBelow := 0, 0
+	for end := start; end < n; end++ {
+		total++
+		if s.bandWidth(prices[:end]) <= width {
+			atOrBelow++
+		}
+	}
+
+	// Without history the width cannot be ranked, so never report a squeeze
+	if total == 0 {
+		return 100
+	}
+	return float64(atOrBelow) / float64(total) * 100
+}
+
+// NewState returns the running state of the squeeze, which keeps the band
+// widths of the lookback bars instead of recomputing them every bar
+func (s *Squeeze) NewState() SeriesState {
+	return &squeezeState{
+		squeeze:    s,
+		prices:     newWindow(s.Period),
+		widths:     newWindow(s.Lookback),
+		percentile: 100,
+	}
+}
+
+// squeezeState is the running state of a Squeeze
+type squeezeState struct {
+	squeeze *Squeeze
+	prices  window // The last Period prices
+	widths  window // Band widths of up to Lookback bars before the latest
+	hasPrev bool   // The bar before the latest had Period prices
+
+	// Outputs at the latest bar
+	width      float64
+	percentile float64
+	squeezed   bool
+	breakout   float64
+}
+
+// Push adds the next bar
+func (s *squeezeState) Push(price, volume float64) {
+	prevPercentile := s.percentile
+	s.prices.push(price)
+	s.width, s.percentile, s.squeezed, s.breakout = 0, 100, false, 0
+	if !s.prices.full {
+		return
+	}
+
+	// Sum in the same order as SimpleAverage and StdDev so the values match Compute exactly
+	period := float64(len(s.prices.values))
+	sum := 0.0
+	for i := range s.prices.values {
+		sum += s.prices.at(i)
+	}
+	sma := sum / period
+	sumSquaredDiff := 0.0
+	for i := range s.prices.values {
+		diff := s.prices.at(i) - sma
+		sumSquaredDiff += diff * diff
+	}
+	stdDev := math.Sqrt(sumSquaredDiff / period)
+	if sma != 0 {
+		s.width = 2 * s.squeeze.Deviation * stdDev / sma * 100
+	}
+
+	if history := s.widths.filled(); len(history) > 0 {
+		atOrBelow := 0
+		for _, previous := range history {
+			if previous <= s.width {
+				atOrBelow++
+			}
+		}
+		s.percentile = float64(atOrBelow) / float64(len(history)) * 100
+	}
+	s.squeezed = s.percentile <= s.squeeze.Threshold
+
+	// A breakout needs the previous bar squeezed and the current close outside the bands
+	if s.hasPrev && prevPercentile <= s.squeeze.Threshold {
+		switch {
+		case price > sma+s.squeeze.Deviation*stdDev:
+			s.breakout = 1
+		case price < sma-s.squeeze.Deviation*stdDev:
+			s.breakout = -1
+		}
+	}
+
+	s.widths.push(s.width)
+	s.hasPrev = true
+}
+
+// Values returns the band width and squeeze state of the latest bar
+func (s *squeezeState) Values() map[string]float64 {
+	squeeze := 0.0
+	if s.squeezed {
+		squeeze = 1
+	}
+	return map[string]float64{
+		"band_width":            s.width,
+		"band_width_percentile": s.percentile,
+		"squeeze":               squeeze,
+		"squeeze_breakout":      s.breakout,
+	}
+}
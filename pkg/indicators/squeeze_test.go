@@ -0,0 +1,42 @@
+package indicators
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSqueezeDetectsBreakout(t *testing.T) {
+	squeeze := &Squeeze{Period: 10, Deviation: 2, Lookback: 40, Threshold: 20}
+
+	// Wide swings followed by a tight range compress the bands
+	prices := make([]float64, 0, 61)
+	for i := 0; i < 40; i++ {
+		if i%2 == 0 {
+			prices = append(prices, 95)
+		} else {
+			prices = append(prices, 105)
+		}
+	}
+	for i := 0; i < 20; i++ {
+		prices = append(prices, 100+float64(i%2)*0.1)
+	}
+
+	values := squeeze.Compute(prices, nil)
+	assert.Equal(t, 1.0, values["squeeze"])
+	assert.Equal(t, 0.0, values["squeeze_breakout"])
+	assert.Less(t, values["band_width"], 1.0)
+
+	// A close far above the tight bands breaks out upward
+	values = squeeze.Compute(append(prices, 103), nil)
+	assert.Equal(t, 1.0, values["squeeze_breakout"])
+	assert.Equal(t, 0.0, values["squeeze"])
+
+	// And far below breaks out downward
+	values = squeeze.Compute(append(prices[:60:60], 97), nil)
+	assert.Equal(t, -1.0, values["squeeze_breakout"])
+
+	// Not enough data reports no squeeze
+	values = squeeze.Compute(prices[:5], nil)
+	assert.Equal(t, 0.0, values["squeeze"])
+}
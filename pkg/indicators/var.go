@@ -0,0 +1,51 @@
+package indicators
+
+import (
+	"math"
+	"sort"
+)
+
+// HistoricalVaR returns the one-day historical value at risk of a portfolio at
+// confidence (e.g. 0.95), as a positive dollar loss, and the number of daily
+// scenarios it was computed from. values holds the market value of each
+// position by symbol and closes the daily closes of each symbol, oldest first,
+// all ending on the same day. Every day in the shared history replays its
+// returns against today's positions; positions without closes are left out.
+func HistoricalVaR(values map[string]float64, closes map[string][]float64, confidence float64) (float64, int) {
+	// Scenarios are the days every position has history for
+	days := -1
+	for symbol := range values {
+		history, ok := closes[symbol]
+		if !ok || len(history) < 2 {
+			continue
+		}
+		if days < 0 || len(history)-1 < days {
+			days = len(history) - 1
+		}
+	}
+	if days <= 0 {
+		return 0, 0
+	}
+
+	pnl := make([]float64, days)
+	for symbol, value := range values {
+		history, ok := closes[symbol]
+		if !ok || len(history) < 2 {
+			continue
+		}
+		window := history[len(history)-days-1:]
+		for i := 1; i < len(window); i++ {
+			if window[i-1] > 0 {
+				pnl[i-1] += value * (window[i]/window[i-1] - 1)
+			}
+		}
+	}
+
+	// The loss exceeded on only (1 - confidence) of days
+	sort.Float64s(pnl)
+	index := int(math.Floor((1 - confidence) * float64(days)))
+	if index >= days {
+		index = days - 1
+	}
+	return math.Max(0, -pnl[index]), days
+}
@@ -0,0 +1,50 @@
+package indicators
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistoricalVaR(t *testing.T) {
+	// 20 days of returns: one -5% day, one -3% day and the rest +1%
+	closes := []float64{100}
+	for i := 1; i <= 20; i++ {
+		move := 0.01
+		switch i {
+		case 5:
+			move = -0.05
+		case 12:
+			move = -0.03
+		}
+		closes = append(closes, closes[i-1]*(1+move))
+	}
+
+	// At 95% the worst day is the one loss exceeded on 5% of days
+	loss, scenarios := HistoricalVaR(map[string]float64{"AAPL": 10000}, map[string][]float64{"AAPL": closes}, 0.95)
+	assert.Equal(t, 20, scenarios)
+	assert.InDelta(t, 300, loss, 1e-6)
+
+	loss, _ = HistoricalVaR(map[string]float64{"AAPL": 10000}, map[string][]float64{"AAPL": closes}, 0.99)
+	assert.InDelta(t, 500, loss, 1e-6)
+
+	// A short position hedges the same moves away
+	loss, _ = HistoricalVaR(
+		map[string]float64{"AAPL": 10000, "HEDGE": -10000},
+		map[string][]float64{"AAPL": closes, "HEDGE": closes},
+		0.95,
+	)
+	assert.InDelta(t, 0, loss, 1e-6)
+
+	// Scenarios are limited to the shortest history; symbols without one are left out
+	_, scenarios = HistoricalVaR(
+		map[string]float64{"AAPL": 10000, "MSFT": 5000, "NEW": 1000},
+		map[string][]float64{"AAPL": closes, "MSFT": closes[10:]},
+		0.95,
+	)
+	assert.Equal(t, 10, scenarios)
+
+	loss, scenarios = HistoricalVaR(map[string]float64{"AAPL": 10000}, nil, 0.95)
+	assert.Equal(t, 0.0, loss)
+	assert.Equal(t, 0, scenarios)
+}
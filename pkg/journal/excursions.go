@@ -0,0 +1,84 @@
+package journal
+
+import (
+	"math"
+	"sort"
+)
+
+// Distribution summarizes the excursions of a set of trades, in percent of
+// the entry price
+type Distribution struct {
+	Count  int     `json:"count"`
+	Mean   float64 `json:"mean"`
+	Median float64 `json:"median"`
+	P75    float64 `json:"p75"`
+	P90    float64 `json:"p90"`
+	Max    float64 `json:"max"`
+}
+
+// ExcursionReport is the distribution of the excursions of closed trades,
+// winners and losers apart. The MAE of winners shows how much room a stop
+// needs to keep the trades that work out; the MFE of losers shows how much
+// profit they gave back before the exit.
+type ExcursionReport struct {
+	Symbol    string       `json:"symbol,omitempty"`
+	WinnerMAE Distribution `json:"winner_mae"`
+	WinnerMFE Distribution `json:"winner_mfe"`
+	LoserMAE  Distribution `json:"loser_mae"`
+	LoserMFE  Distribution `json:"loser_mfe"`
+}
+
+// Excursions returns the excursion distributions of the closed trades in
+// symbol, or of all closed trades when symbol is empty. Trades closed at
+// break-even count as losers.
+func (j *Journal) Excursions(symbol string) ExcursionReport {
+	var winnerMAE, winnerMFE, loserMAE, loserMFE []float64
+	for _, entry := range j.Entries(symbol) {
+		if entry.PnL > 0 {
+			winnerMAE = append(winnerMAE, entry.MAE)
+			winnerMFE = append(winnerMFE, entry.MFE)
+		} else {
+			loserMAE = append(loserMAE, entry.MAE)
+			loserMFE = append(loserMFE, entry.MFE)
+		}
+	}
+
+	return ExcursionReport{
+		Symbol:    symbol,
+		WinnerMAE: distributionOf(winnerMAE),
+		WinnerMFE: distributionOf(winnerMFE),
+		LoserMAE:  distributionOf(loserMAE),
+		LoserMFE:  distributionOf(loserMFE),
+	}
+}
+
+// distributionOf summarizes values
+func distributionOf(values []float64) Distribution {
+	if len(values) == 0 {
+		return Distribution{}
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	var total float64
+	for _, value := range sorted {
+		total += value
+	}
+	return Distribution{
+		Count:  len(sorted),
+		Mean:   total / float64(len(sorted)),
+		Median: percentile(sorted, 50),
+		P75:    percentile(sorted, 75),
+		P90:    percentile(sorted, 90),
+		Max:    sorted[len(sorted)-1],
+	}
+}
+
+// percentile returns the p-th percentile of sorted values, interpolating
+// between the closest ranks
+func percentile(sorted []float64, p float64) float64 {
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
@@ -0,0 +1,53 @@
+package journal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/execution"
+	"github.com/hustler/trading-bot/pkg/strategy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExcursionsTrackedFromLiveQuotes(t *testing.T) {
+	manager := execution.NewTradeManager(1000, 100)
+	j := NewJournal(nil)
+	j.Attach(manager)
+
+	// A winner that dipped 2% before reaching its target, and a loser that was
+	// up 1% before it was stopped out
+	trade := func(symbol string, quotes []float64, exit float64, stop float64) {
+		_, err := manager.ExecuteTrade(&strategy.TradeDecision{Symbol: symbol, Signal: strategy.Buy, StopPrice: stop}, &data.Stock{Symbol: symbol, CurrentPrice: 100})
+		assert.NoError(t, err)
+		for _, price := range quotes {
+			manager.RecordQuote(&data.Stock{Symbol: symbol, CurrentPrice: price})
+		}
+		_, err = manager.ExecuteTrade(&strategy.TradeDecision{Symbol: symbol, Signal: strategy.Sell}, &data.Stock{Symbol: symbol, CurrentPrice: exit})
+		assert.NoError(t, err)
+	}
+	trade("AAPL", []float64{99, 98, 103}, 104, 95)
+	trade("MSFT", []float64{101, 99.5}, 97, 96)
+
+	assert.Eventually(t, func() bool { return len(j.Entries("")) == 2 }, time.Second, 10*time.Millisecond)
+	entry := j.Entries("AAPL")[0]
+	assert.InDelta(t, 2, entry.MAE, 1e-9)
+	assert.InDelta(t, 4, entry.MFE, 1e-9) // The exit fill beats the best quote
+
+	report := j.Excursions("")
+	assert.Equal(t, 1, report.WinnerMAE.Count)
+	assert.InDelta(t, 2, report.WinnerMAE.P90, 1e-9)
+	assert.InDelta(t, 3, report.LoserMAE.Max, 1e-9)
+	assert.InDelta(t, 1, report.LoserMFE.Median, 1e-9)
+	assert.Equal(t, 0, j.Excursions("NVDA").WinnerMAE.Count)
+}
+
+func TestDistributionPercentiles(t *testing.T) {
+	d := distributionOf([]float64{4, 1, 3, 2, 5})
+	assert.Equal(t, 5, d.Count)
+	assert.InDelta(t, 3, d.Mean, 1e-9)
+	assert.InDelta(t, 3, d.Median, 1e-9)
+	assert.InDelta(t, 4, d.P75, 1e-9)
+	assert.InDelta(t, 4.6, d.P90, 1e-9)
+	assert.Equal(t, 5.0, d.Max)
+}
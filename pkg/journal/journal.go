@@ -0,0 +1,293 @@
+package journal
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/execution"
+	"github.com/hustler/trading-bot/pkg/llm"
+)
+
+// postMortemTimeout bounds the LLM call for a single post-mortem
+const postMortemTimeout = 30 * time.Second
+
+// Outcome describes how a closed trade compares to its plan
+type Outcome string
+
+const (
+	OutcomeTargetHit  Outcome = "TARGET_HIT"
+	OutcomeStoppedOut Outcome = "STOPPED_OUT"
+	OutcomeClosedWin  Outcome = "CLOSED_WIN"  // Closed in profit before the target
+	OutcomeClosedLoss Outcome = "CLOSED_LOSS" // Closed at a loss before the stop
+)
+
+// Entry is the journal entry for a closed trade
+type Entry struct {
+	TradeID     string    `json:"trade_id"`
+	Symbol      string    `json:"symbol"`
+	Quantity    int       `json:"quantity"`
+	Short       bool      `json:"short,omitempty"`
+	EntryPrice  float64   `json:"entry_price"`
+	ExitPrice   float64   `json:"exit_price"`
+	TargetPrice float64   `json:"target_price"`
+	StopPrice   float64   `json:"stop_price"`
+	PnL         float64   `json:"pnl"`
+	ReturnPct   float64   `json:"return_pct"`
+	MAE         float64   `json:"mae"` // Max adverse excursion while open, percent of the entry price
+	MFE         float64   `json:"mfe"` // Max favorable excursion while open, percent of the entry price
+	Outcome     Outcome   `json:"outcome"`
+	Setup       string    `json:"setup"`
+	ExitReason  string    `json:"exit_reason"`
+	PostMortem  string    `json:"post_mortem"`
+	Notes       string    `json:"notes"`
+	OpenedAt    time.Time `json:"opened_at"`
+	ClosedAt    time.Time `json:"closed_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Writer writes post-mortems for closed trades (implemented by llm.Manager)
+type Writer interface {
+	GeneratePostMortem(ctx context.Context, review llm.TradeReview) (string, error)
+}
+
+// Store persists journal entries (implemented by store.Logger)
+type Store interface {
+	SaveJournalEntry(entry *Entry) error
+	LoadJournalEntries() ([]*Entry, error)
+}
+
+// Journal keeps a narrative entry for every closed trade
+type Journal struct {
+	writer  Writer
+	store   Store
+	entries map[string]*Entry
+	now     func() time.Time
+	mu      sync.RWMutex
+}
+
+// NewJournal creates a new Journal. writer may be nil, in which case entries
+// are recorded without a post-mortem.
+func NewJournal(writer Writer) *Journal {
+	return &Journal{
+		writer:  writer,
+		entries: make(map[string]*Entry),
+		now:     time.Now,
+	}
+}
+
+// SetStore persists entries to store and restores entries saved by a previous run
+func (j *Journal) SetStore(store Store) error {
+	entries, err := store.LoadJournalEntries()
+	if err != nil {
+		return fmt.Errorf("failed to load journal entries: %w", err)
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.store = store
+	for _, entry := range entries {
+		j.entries[entry.TradeID] = entry
+	}
+	return nil
+}
+
+// Attach records an entry for every position trades closes
+func (j *Journal) Attach(trades *execution.TradeManager) {
+	trades.OnPositionClosed(func(position, exit execution.Trade) {
+		if _, err := j.Record(position, exit); err != nil {
+			log.Printf("Error journaling trade %s: %v", position.ID, err)
+		}
+	})
+}
+
+// Record creates the entry for a closed position and writes its post-mortem.
+// Recording a position that already has an entry returns the existing entry.
+func (j *Journal) Record(position, exit execution.Trade) (*Entry, error) {
+	j.mu.RLock()
+	existing, exists := j.entries[position.ID]
+	j.mu.RUnlock()
+	if exists {
+		result := *existing
+		return &result, nil
+	}
+
+	entry := newEntry(position, exit)
+	if j.writer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), postMortemTimeout)
+		postMortem, err := j.writer.GeneratePostMortem(ctx, entry.review())
+		cancel()
+		if err != nil {
+			log.Printf("Error writing post-mortem for trade %s: %v", position.ID, err)
+		}
+		entry.PostMortem = postMortem
+	}
+	entry.UpdatedAt = j.now()
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if existing, exists := j.entries[position.ID]; exists {
+		result := *existing
+		return &result, nil
+	}
+	j.entries[position.ID] = entry
+
+	result := *entry
+	return &result, j.save(entry)
+}
+
+// SetNotes replaces the manual notes on an entry
+func (j *Journal) SetNotes(tradeID, notes string) (*Entry, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	entry, exists := j.entries[tradeID]
+	if !exists {
+		return nil, fmt.Errorf("no journal entry for trade %s", tradeID)
+	}
+
+	updated := *entry
+	updated.Notes = notes
+	updated.UpdatedAt = j.now()
+	if err := j.save(&updated); err != nil {
+		return nil, err
+	}
+	j.entries[tradeID] = &updated
+
+	result := updated
+	return &result, nil
+}
+
+// Get returns a copy of the entry for a trade
+func (j *Journal) Get(tradeID string) (*Entry, bool) {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	entry, exists := j.entries[tradeID]
+	if !exists {
+		return nil, false
+	}
+	result := *entry
+	return &result, true
+}
+
+// Entries returns copies of the entries for symbol, or all entries when symbol
+// is empty, most recently closed first
+func (j *Journal) Entries(symbol string) []Entry {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	result := make([]Entry, 0, len(j.entries))
+	for _, entry := range j.entries {
+		if symbol == "" || entry.Symbol == symbol {
+			result = append(result, *entry)
+		}
+	}
+	sort.Slice(result, func(a, b int) bool {
+		return result[a].ClosedAt.After(result[b].ClosedAt)
+	})
+	return result
+}
+
+// save persists an entry if a store is set. Caller must hold the lock.
+func (j *Journal) save(entry *Entry) error {
+	if j.store == nil {
+		return nil
+	}
+	if err := j.store.SaveJournalEntry(entry); err != nil {
+		return fmt.Errorf("failed to save journal entry: %w", err)
+	}
+	return nil
+}
+
+// newEntry builds the entry for a closed position from its fills
+func newEntry(position, exit execution.Trade) *Entry {
+	entry := &Entry{
+		TradeID:     position.ID,
+		Symbol:      position.Symbol,
+		Short:       position.IsShort(),
+		EntryPrice:  position.Price,
+		ExitPrice:   exit.Price,
+		TargetPrice: position.TargetPrice,
+		StopPrice:   position.StopPrice,
+		PnL:         position.RealizedPnL,
+		MAE:         position.MAE,
+		MFE:         position.MFE,
+		Setup:       position.Reason,
+		ExitReason:  exit.Reason,
+		OpenedAt:    position.CreatedAt,
+		ClosedAt:    exit.CreatedAt,
+	}
+
+	// The position's quantity shrinks with each exit, so size and average exit
+	// price come from the fills
+	var exitQuantity int
+	var exitValue float64
+	for _, fill := range position.Fills {
+		if fill.Side != position.Type {
+			exitQuantity += fill.Quantity
+			exitValue += float64(fill.Quantity) * fill.Price
+		} else {
+			entry.Quantity += fill.Quantity
+		}
+	}
+	if exitQuantity > 0 {
+		entry.ExitPrice = exitValue / float64(exitQuantity)
+	}
+	if entry.Quantity == 0 {
+		entry.Quantity = exitQuantity
+	}
+
+	if cost := float64(entry.Quantity) * entry.EntryPrice; cost > 0 {
+		entry.ReturnPct = entry.PnL / cost * 100
+	}
+	entry.Outcome = classify(entry)
+
+	return entry
+}
+
+// classify compares the exit with the planned target and stop, which sit
+// below and above the entry of a short
+func classify(e *Entry) Outcome {
+	targetHit := e.TargetPrice > 0 && e.ExitPrice >= e.TargetPrice
+	stoppedOut := e.StopPrice > 0 && e.ExitPrice <= e.StopPrice
+	if e.Short {
+		targetHit = e.TargetPrice > 0 && e.ExitPrice <= e.TargetPrice
+		stoppedOut = e.StopPrice > 0 && e.ExitPrice >= e.StopPrice
+	}
+
+	switch {
+	case targetHit:
+		return OutcomeTargetHit
+	case stoppedOut:
+		return OutcomeStoppedOut
+	case e.PnL > 0:
+		return OutcomeClosedWin
+	default:
+		return OutcomeClosedLoss
+	}
+}
+
+// review converts an entry to the post-mortem input
+func (e *Entry) review() llm.TradeReview {
+	return llm.TradeReview{
+		Symbol:      e.Symbol,
+		Quantity:    e.Quantity,
+		EntryPrice:  e.EntryPrice,
+		ExitPrice:   e.ExitPrice,
+		TargetPrice: e.TargetPrice,
+		StopPrice:   e.StopPrice,
+		PnL:         e.PnL,
+		ReturnPct:   e.ReturnPct,
+		OpenedAt:    e.OpenedAt,
+		ClosedAt:    e.ClosedAt,
+		Setup:       e.Setup,
+		ExitReason:  e.ExitReason,
+		Outcome:     string(e.Outcome),
+	}
+}
@@ -0,0 +1,111 @@
+package journal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/execution"
+	"github.com/hustler/trading-bot/pkg/llm"
+	"github.com/hustler/trading-bot/pkg/strategy"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubWriter records the reviews it is asked to write up
+type stubWriter struct {
+	reviews []llm.TradeReview
+}
+
+func (w *stubWriter) GeneratePostMortem(ctx context.Context, review llm.TradeReview) (string, error) {
+	w.reviews = append(w.reviews, review)
+	return "post-mortem for " + review.Symbol, nil
+}
+
+// memoryStore is an in-memory journal store
+type memoryStore struct {
+	saved map[string]Entry
+}
+
+func (s *memoryStore) SaveJournalEntry(entry *Entry) error {
+	s.saved[entry.TradeID] = *entry
+	return nil
+}
+
+func (s *memoryStore) LoadJournalEntries() ([]*Entry, error) {
+	entries := make([]*Entry, 0, len(s.saved))
+	for _, entry := range s.saved {
+		e := entry
+		entries = append(entries, &e)
+	}
+	return entries, nil
+}
+
+func TestRecordBuildsEntryAndPostMortem(t *testing.T) {
+	opened := time.Date(2025, 4, 21, 14, 0, 0, 0, time.UTC)
+	position := execution.Trade{
+		ID: "AAPL-1", Symbol: "AAPL", Quantity: 0, Price: 100, Type: strategy.Buy, Status: execution.Completed,
+		CreatedAt: opened, Reason: "Squeeze breakout", TargetPrice: 110, StopPrice: 95, RealizedPnL: 100,
+		Fills: []execution.Fill{
+			{Symbol: "AAPL", Side: strategy.Buy, Quantity: 10, Price: 100},
+			{Symbol: "AAPL", Side: strategy.Sell, Quantity: 5, Price: 108},
+			{Symbol: "AAPL", Side: strategy.Sell, Quantity: 5, Price: 112},
+		},
+	}
+	exit := execution.Trade{ID: "AAPL-2", Symbol: "AAPL", Price: 112, Type: strategy.Sell, CreatedAt: opened.Add(2 * time.Hour), Reason: "Target reached", PositionID: "AAPL-1"}
+
+	writer := &stubWriter{}
+	store := &memoryStore{saved: make(map[string]Entry)}
+	j := NewJournal(writer)
+	assert.NoError(t, j.SetStore(store))
+
+	entry, err := j.Record(position, exit)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, entry.Quantity)
+	assert.Equal(t, 110.0, entry.ExitPrice)
+	assert.InDelta(t, 10.0, entry.ReturnPct, 0.001)
+	assert.Equal(t, OutcomeTargetHit, entry.Outcome)
+	assert.Equal(t, "post-mortem for AAPL", entry.PostMortem)
+	assert.Equal(t, "Squeeze breakout", writer.reviews[0].Setup)
+	assert.Equal(t, "Target reached", writer.reviews[0].ExitReason)
+
+	// Recording the same position again keeps the first entry
+	_, err = j.Record(position, exit)
+	assert.NoError(t, err)
+	assert.Len(t, writer.reviews, 1)
+
+	updated, err := j.SetNotes("AAPL-1", "Scaled out too early")
+	assert.NoError(t, err)
+	assert.Equal(t, "Scaled out too early", updated.Notes)
+	assert.Equal(t, "Scaled out too early", store.saved["AAPL-1"].Notes)
+
+	_, err = j.SetNotes("MSFT-1", "missing")
+	assert.Error(t, err)
+
+	// A new journal restores entries from the store
+	restored := NewJournal(nil)
+	assert.NoError(t, restored.SetStore(store))
+	got, ok := restored.Get("AAPL-1")
+	assert.True(t, ok)
+	assert.Equal(t, "Scaled out too early", got.Notes)
+	assert.Len(t, restored.Entries("AAPL"), 1)
+	assert.Empty(t, restored.Entries("MSFT"))
+}
+
+func TestAttachJournalsClosedPositions(t *testing.T) {
+	manager := execution.NewTradeManager(1000, 100)
+	j := NewJournal(&stubWriter{})
+	j.Attach(manager)
+
+	_, err := manager.ExecuteTrade(&strategy.TradeDecision{Symbol: "AAPL", Signal: strategy.Buy, StopPrice: 98, Rationale: "Breakout"}, &data.Stock{Symbol: "AAPL", CurrentPrice: 100})
+	assert.NoError(t, err)
+	closed := manager.CheckStopLoss(map[string]*data.Stock{"AAPL": {Symbol: "AAPL", CurrentPrice: 97}})
+	assert.Len(t, closed, 1)
+
+	assert.Eventually(t, func() bool { return len(j.Entries("")) == 1 }, time.Second, 10*time.Millisecond)
+	entry := j.Entries("")[0]
+	assert.Equal(t, closed[0].PositionID, entry.TradeID)
+	assert.Equal(t, OutcomeStoppedOut, entry.Outcome)
+	assert.Less(t, entry.PnL, 0.0)
+	assert.Equal(t, "Breakout", entry.Setup)
+}
@@ -0,0 +1,118 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// BriefingQuote is the pre-market move of an index future or watched symbol
+type BriefingQuote struct {
+	Symbol    string
+	Price     float64
+	ChangePct float64 // From the previous close
+}
+
+// MarketBriefing is the market snapshot a morning briefing is written from
+type MarketBriefing struct {
+	Date      time.Time
+	Futures   []BriefingQuote
+	Movers    []BriefingQuote // Largest pre-market moves first
+	Events    []string        // Today's earnings and economic events, e.g. "08:30 US CPI"
+	Yesterday DaySummary
+}
+
+// DaySummary is how the bot's signals of a day fared
+type DaySummary struct {
+	Signals   int
+	Wins      int
+	Losses    int
+	Open      int
+	ReturnPct float64 // Sum of the closed signals' returns
+}
+
+// BriefingProvider is implemented by providers that can summarize morning briefings.
+// Providers without it fall back to a templated summary.
+type BriefingProvider interface {
+	SummarizeBriefing(ctx context.Context, briefing MarketBriefing) (string, error)
+}
+
+// SummarizeBriefing writes a short summary of the market going into the open
+func (m *Manager) SummarizeBriefing(ctx context.Context, briefing MarketBriefing) (string, error) {
+	if provider, ok := m.provider.(BriefingProvider); ok {
+		return provider.SummarizeBriefing(ctx, briefing)
+	}
+	return generateMockBriefingSummary(briefing), nil
+}
+
+// createBriefingPrompt creates a prompt for the LLM based on the market snapshot
+func createBriefingPrompt(b MarketBriefing) string {
+	quotes := func(quotes []BriefingQuote) string {
+		if len(quotes) == 0 {
+			return "- None\n"
+		}
+		var lines strings.Builder
+		for _, q := range quotes {
+			fmt.Fprintf(&lines, "- %s: $%.2f (%+.2f%%)\n", q.Symbol, q.Price, q.ChangePct)
+		}
+		return lines.String()
+	}
+	events := "- None\n"
+	if len(b.Events) > 0 {
+		events = "- " + strings.Join(b.Events, "\n- ") + "\n"
+	}
+
+	return fmt.Sprintf(`
+Write a two or three sentence summary of the market going into today's open (%s) for a trading signal channel.
+
+Index Futures:
+%s
+Pre-Market Movers:
+%s
+Today's Events:
+%s
+Yesterday's Signals:
+- %d signals, %d wins, %d losses, %d still open, %+.2f%% total return
+
+Mention the overall tone, the biggest movers and any event likely to move the market. Do not give advice.
+`, b.Date.Format("Monday, January 2"), quotes(b.Futures), quotes(b.Movers), events,
+		b.Yesterday.Signals, b.Yesterday.Wins, b.Yesterday.Losses, b.Yesterday.Open, b.Yesterday.ReturnPct)
+}
+
+// generateMockBriefingSummary generates a templated summary from the snapshot
+func generateMockBriefingSummary(b MarketBriefing) string {
+	var parts []string
+
+	if len(b.Futures) > 0 {
+		var total float64
+		for _, q := range b.Futures {
+			total += q.ChangePct
+		}
+		average := total / float64(len(b.Futures))
+		tone := "flat"
+		switch {
+		case average >= 0.25:
+			tone = "higher"
+		case average <= -0.25:
+			tone = "lower"
+		}
+		parts = append(parts, fmt.Sprintf("Futures point %s (%+.2f%% on average).", tone, average))
+	}
+
+	if len(b.Movers) > 0 {
+		q := b.Movers[0]
+		parts = append(parts, fmt.Sprintf("%s leads the pre-market movers at %+.2f%%.", q.Symbol, q.ChangePct))
+	}
+
+	switch len(b.Events) {
+	case 0:
+		parts = append(parts, "No scheduled events today.")
+	case 1:
+		parts = append(parts, "One scheduled event today: "+b.Events[0]+".")
+	default:
+		parts = append(parts, fmt.Sprintf("%d scheduled events today, starting with %s.", len(b.Events), b.Events[0]))
+	}
+
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,157 @@
+package llm
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hustler/trading-bot/pkg/config"
+)
+
+// rsiZoneTolerance is how far past its threshold RSI may be before calling it
+// oversold or overbought counts as a contradiction
+const rsiZoneTolerance = 5.0
+
+// Contradiction is a claim in an explanation that the signal's indicators
+// don't support, and what it was corrected to
+type Contradiction struct {
+	Claim      string  `json:"claim"`      // The claim as written
+	Indicator  string  `json:"indicator"`  // Technical data key the claim is about
+	Actual     float64 `json:"actual"`     // The indicator's actual value
+	Correction string  `json:"correction"` // What replaced the claim
+}
+
+// numericClaim matches an explanation's stated value of an indicator
+type numericClaim struct {
+	indicator string
+	pattern   *regexp.Regexp // Groups: what precedes the stated value, and the value
+	scale     float64        // Stated value times scale is in the indicator's unit
+	tolerance func(actual float64) float64
+}
+
+// numericClaims are the indicator values explanations state, e.g. "RSI of
+// 72", "ADX(14) at 31" or "2.5x average volume". A stated value within the
+// tolerance of the actual one is rounding, not a contradiction.
+var numericClaims = []numericClaim{
+	{
+		indicator: "rsi",
+		pattern:   regexp.MustCompile(`(?i)\bRSI\b(?:\s*\(\d+\))?([^\d\n.;]{0,20}?)(\d{1,3}(?:\.\d+)?)\b`),
+		scale:     1,
+		tolerance: func(float64) float64 { return 1.5 },
+	},
+	{
+		indicator: "adx",
+		pattern:   regexp.MustCompile(`(?i)\bADX\b(?:\s*\(\d+\))?([^\d\n.;]{0,20}?)(\d{1,3}(?:\.\d+)?)\b`),
+		scale:     1,
+		tolerance: func(float64) float64 { return 1.5 },
+	},
+	{
+		indicator: "volume_ratio",
+		pattern:   regexp.MustCompile(`(?i)()\b(\d+(?:\.\d+)?)\s*(?:x|times)\s+(?:the\s+)?(?:average|normal|usual)\b`),
+		scale:     100, // volume_ratio is in percent of average
+		tolerance: func(actual float64) float64 { return math.Max(actual*0.15, 10) },
+	},
+}
+
+// boundWord matches a stated value that is a bound or a past value rather
+// than the indicator's value, e.g. "RSI above 70" or "RSI rose from 45"
+var boundWord = regexp.MustCompile(`(?i)\b(above|below|over|under|near|approaching|towards?|from|crossed|past|beyond|exceed\w*)\b|[<>]`)
+
+// rsiZoneWord matches an explanation calling RSI oversold or overbought
+var rsiZoneWord = regexp.MustCompile(`(?i)\b(oversold|overbought)\b`)
+
+// negation matches a negation just before a claim, e.g. "not oversold"
+var negation = regexp.MustCompile(`(?i)\b(not|no longer|isn't|is not|neither|nor)\s+(?:yet\s+|quite\s+|deeply\s+|very\s+)?$`)
+
+// explanationEdit replaces part of an explanation
+type explanationEdit struct {
+	start, end  int
+	replacement string
+}
+
+// CrossCheck scans an explanation for claims about the signal's indicators,
+// stated values of RSI, ADX and relative volume and RSI called oversold or
+// overbought, and corrects those that contradict the technical data. It
+// returns the corrected explanation and the contradictions it corrected.
+func CrossCheck(explanation string, technicalData map[string]float64, params config.VolatilityConfig) (string, []Contradiction) {
+	var edits []explanationEdit
+	var contradictions []Contradiction
+
+	for _, claim := range numericClaims {
+		actual, ok := technicalData[claim.indicator]
+		if !ok {
+			continue
+		}
+		for _, match := range claim.pattern.FindAllStringSubmatchIndex(explanation, -1) {
+			if boundWord.MatchString(explanation[match[2]:match[3]]) {
+				continue
+			}
+			text := explanation[match[4]:match[5]]
+			stated, err := strconv.ParseFloat(text, 64)
+			if err != nil || math.Abs(stated*claim.scale-actual) <= claim.tolerance(actual) {
+				continue
+			}
+			correction := formatLike(text, actual/claim.scale)
+			edits = append(edits, explanationEdit{start: match[4], end: match[5], replacement: correction})
+			contradictions = append(contradictions, Contradiction{
+				Claim:      explanation[match[0]:match[1]],
+				Indicator:  claim.indicator,
+				Actual:     actual,
+				Correction: correction,
+			})
+		}
+	}
+
+	if rsi, ok := technicalData["rsi"]; ok {
+		zone := rsiZone(rsi, params)
+		for _, match := range rsiZoneWord.FindAllStringIndex(explanation, -1) {
+			word := strings.ToLower(explanation[match[0]:match[1]])
+			if negation.MatchString(explanation[:match[0]]) {
+				continue
+			}
+			if (word == "oversold" && rsi <= params.RSIOversold+rsiZoneTolerance) ||
+				(word == "overbought" && rsi >= params.RSIOverbought-rsiZoneTolerance) {
+				continue
+			}
+			edits = append(edits, explanationEdit{start: match[0], end: match[1], replacement: zone})
+			contradictions = append(contradictions, Contradiction{
+				Claim:      explanation[match[0]:match[1]],
+				Indicator:  "rsi",
+				Actual:     rsi,
+				Correction: zone,
+			})
+		}
+	}
+
+	// Apply the edits back to front so earlier offsets stay valid
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start > edits[j].start })
+	corrected := explanation
+	for _, edit := range edits {
+		corrected = corrected[:edit.start] + edit.replacement + corrected[edit.end:]
+	}
+	return corrected, contradictions
+}
+
+// rsiZone describes an RSI value: oversold, overbought or neutral
+func rsiZone(rsi float64, params config.VolatilityConfig) string {
+	switch {
+	case rsi >= params.RSIOverbought:
+		return "overbought"
+	case rsi <= params.RSIOversold:
+		return "oversold"
+	default:
+		return "neutral"
+	}
+}
+
+// formatLike formats a value with as many decimals as the stated value it replaces
+func formatLike(stated string, value float64) string {
+	decimals := 0
+	if dot := strings.IndexByte(stated, '.'); dot >= 0 {
+		decimals = len(stated) - dot - 1
+	}
+	return fmt.Sprintf("%.*f", decimals, value)
+}
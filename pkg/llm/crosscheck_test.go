@@ -0,0 +1,41 @@
+package llm
+
+import (
+	"testing"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCrossCheck(t *testing.T) {
+	params := config.CreateDefaultConfig().VolatilityParams
+	technicalData := map[string]float64{"rsi": 68.2, "adx": 31, "volume_ratio": 240}
+
+	explanation := "AAPL bounced off oversold RSI of 28 with volume at 2.4x average. ADX(14) at 30 confirms the trend."
+	corrected, contradictions := CrossCheck(explanation, technicalData, params)
+	assert.Equal(t, "AAPL bounced off neutral RSI of 68 with volume at 2.4x average. ADX(14) at 30 confirms the trend.", corrected)
+	if assert.Len(t, contradictions, 2) {
+		assert.Equal(t, "rsi", contradictions[0].Indicator)
+		assert.Equal(t, "RSI of 28", contradictions[0].Claim)
+		assert.Equal(t, "68", contradictions[0].Correction)
+		assert.Equal(t, "oversold", contradictions[1].Claim)
+		assert.Equal(t, "neutral", contradictions[1].Correction)
+	}
+
+	// Overstated relative volume is corrected to the actual multiple
+	corrected, contradictions = CrossCheck("Volume surged to 5.0 times the average.", technicalData, params)
+	assert.Equal(t, "Volume surged to 2.4 times the average.", corrected)
+	assert.Len(t, contradictions, 1)
+
+	// Claims the data supports, and negated ones, are left alone
+	technicalData["rsi"] = 74
+	explanation = "RSI at 73.5 is overbought after crossing above 70, though not oversold on the daily. RSI rose from 45."
+	corrected, contradictions = CrossCheck(explanation, technicalData, params)
+	assert.Equal(t, explanation, corrected)
+	assert.Empty(t, contradictions)
+
+	// Claims about indicators that weren't computed can't be checked
+	corrected, contradictions = CrossCheck("ADX of 12 shows no trend.", map[string]float64{"rsi": 50}, params)
+	assert.Equal(t, "ADX of 12 shows no trend.", corrected)
+	assert.Empty(t, contradictions)
+}
@@ -0,0 +1,104 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// IdeaReview is a trade idea submitted by an admin and how the signal
+// generator sees the symbol
+type IdeaReview struct {
+	Thesis     string // Empty when only a ticker was submitted
+	Evaluation signal.Evaluation
+}
+
+// IdeaProvider is implemented by providers that can vet trade ideas.
+// Providers without it fall back to a templated review.
+type IdeaProvider interface {
+	ReviewIdea(ctx context.Context, review IdeaReview) (string, error)
+}
+
+// ReviewIdea vets a trade idea against the indicators and strategies: whether
+// the data supports the thesis, and the risks to it
+func (m *Manager) ReviewIdea(ctx context.Context, review IdeaReview) (string, error) {
+	if provider, ok := m.provider.(IdeaProvider); ok {
+		return provider.ReviewIdea(ctx, review)
+	}
+	return generateMockIdeaReview(review), nil
+}
+
+// createIdeaPrompt creates a prompt for the LLM based on a trade idea
+func createIdeaPrompt(r IdeaReview) string {
+	e := r.Evaluation
+	thesis := r.Thesis
+	if thesis == "" {
+		thesis = "(none given, only the ticker)"
+	}
+	return fmt.Sprintf(`
+A trader suggests the following trade idea. Vet it against the technical data.
+
+Idea:
+- Symbol: %s
+- Side: %s
+- Thesis: %s
+
+Technical Data:
+- Price: $%.2f
+- RSI: %.2f
+- Volume Ratio: %.2f
+- Bollinger Bands: $%.2f - $%.2f
+- Strategies' Decision: %s (confidence %.2f)
+- Levels for the idea's side: target $%.2f, stop $%.2f, expected ROI %.2f%%
+
+Cover:
+1. Whether the technical data supports or contradicts the thesis
+2. The main risks to the idea
+3. A verdict: take it, wait for a better entry, or pass
+
+Keep it to a few short sentences.
+`, e.Symbol, e.Side, thesis, e.Price, e.Indicators["rsi"], e.Indicators["volume_ratio"],
+		e.Indicators["lower_band"], e.Indicators["upper_band"], e.Decision, e.Confidence,
+		e.TargetPrice, e.StopLoss, e.ExpectedROI)
+}
+
+// generateMockIdeaReview generates a templated review from the evaluation
+func generateMockIdeaReview(r IdeaReview) string {
+	e := r.Evaluation
+	var b strings.Builder
+
+	switch {
+	case e.Side == signal.HOLD:
+		fmt.Fprintf(&b, "The strategies see no setup in %s right now, so there is no side to take.", e.Symbol)
+	case e.Decision == e.Side:
+		fmt.Fprintf(&b, "The data supports a %s of %s: the strategies agree with %.0f%% confidence.", e.Side, e.Symbol, e.Confidence*100)
+	case e.Decision == signal.HOLD:
+		fmt.Fprintf(&b, "The strategies are neutral on %s, so a %s rests on the thesis alone.", e.Symbol, e.Side)
+	default:
+		fmt.Fprintf(&b, "The data contradicts a %s of %s: the strategies lean %s.", e.Side, e.Symbol, e.Decision)
+	}
+
+	if rsi, ok := e.Indicators["rsi"]; ok {
+		switch {
+		case rsi > 70:
+			fmt.Fprintf(&b, " RSI of %.1f is overbought.", rsi)
+		case rsi < 30:
+			fmt.Fprintf(&b, " RSI of %.1f is oversold.", rsi)
+		}
+	}
+
+	switch {
+	case e.Side == signal.HOLD:
+		b.WriteString(" Verdict: pass.")
+	case e.Qualifies:
+		fmt.Fprintf(&b, " Verdict: take it, targeting $%.2f with a stop at $%.2f.", e.TargetPrice, e.StopLoss)
+	case e.Decision == e.Side:
+		fmt.Fprintf(&b, " Verdict: wait, the expected ROI of %.2f%% is below the minimum.", e.ExpectedROI)
+	default:
+		b.WriteString(" Verdict: wait for the data to confirm.")
+	}
+
+	return b.String()
+}
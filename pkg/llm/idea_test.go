@@ -0,0 +1,31 @@
+package llm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/signal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReviewIdea(t *testing.T) {
+	manager, err := NewManager(&config.LLMConfig{Provider: "mock"})
+	assert.NoError(t, err)
+
+	evaluation := signal.Evaluation{Symbol: "NVDA", Price: 100, Decision: signal.BUY, Confidence: 0.7, Side: signal.BUY,
+		TargetPrice: 103, StopLoss: 98, ExpectedROI: 3, Qualifies: true, Indicators: map[string]float64{"rsi": 75}}
+	review, err := manager.ReviewIdea(context.Background(), IdeaReview{Thesis: "Breakout", Evaluation: evaluation})
+	assert.NoError(t, err)
+	assert.Contains(t, review, "supports a BUY of NVDA")
+	assert.Contains(t, review, "overbought")
+	assert.Contains(t, review, "Verdict: take it")
+
+	evaluation.Side, evaluation.Qualifies = signal.SELL, false
+	review, _ = manager.ReviewIdea(context.Background(), IdeaReview{Evaluation: evaluation})
+	assert.Contains(t, review, "contradicts a SELL")
+	assert.Contains(t, review, "Verdict: wait")
+
+	prompt := createIdeaPrompt(IdeaReview{Evaluation: evaluation})
+	assert.Contains(t, prompt, "only the ticker")
+}
@@ -3,12 +3,22 @@ package llm
 import (
 	"context"
 	"fmt"
+	"log"
+	"sync"
 	"time"
 
 	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/errkind"
 	"github.com/hustler/trading-bot/pkg/signal"
 )
 
+// explanationAttempts is how many times a rate limited or unavailable
+// provider is asked for an explanation
+const explanationAttempts = 3
+
+// explanationBackoff is the wait before the first retry, doubled after each
+var explanationBackoff = time.Second
+
 // Provider represents an LLM provider
 type Provider interface {
 	GenerateExplanation(ctx context.Context, s *signal.Signal) (string, error)
@@ -19,6 +29,17 @@ type Provider interface {
 type Manager struct {
 	config   *config.LLMConfig
 	provider Provider
+	usage    Usage
+	mu       sync.Mutex
+}
+
+// Usage counts the explanations requested since startup and their estimated
+// tokens and cost
+type Usage struct {
+	Requests int     `json:"requests"`
+	Failures int     `json:"failures"`
+	Tokens   int     `json:"tokens"`
+	Cost     float64 `json:"cost"` // USD, at the configured cost per 1,000 tokens
 }
 
 // NewManager creates a new LLM manager
@@ -47,9 +68,53 @@ func NewManager(cfg *config.LLMConfig) (*Manager, error) {
 	}, nil
 }
 
-// GenerateSignalExplanation generates a natural language explanation for a trading signal
+// GenerateSignalExplanation generates a natural language explanation for a
+// trading signal. Rate limited or unavailable providers are retried with
+// backoff; other errors, such as rejected credentials, are returned at once.
 func (m *Manager) GenerateSignalExplanation(ctx context.Context, s *signal.Signal) (string, error) {
-	return m.provider.GenerateExplanation(ctx, s)
+	backoff := explanationBackoff
+	var err error
+	for attempt := 1; attempt <= explanationAttempts; attempt++ {
+		var explanation string
+		explanation, err = m.provider.GenerateExplanation(ctx, s)
+		if err == nil {
+			m.recordUsage(m.EstimateTokens(s, explanation), false)
+			return explanation, nil
+		}
+		if !errkind.Retryable(err) || attempt == explanationAttempts {
+			break
+		}
+
+		log.Printf("LLM provider %s failed (%s), retrying in %s: %v", m.provider.Name(), errkind.Of(err), backoff, err)
+		select {
+		case <-ctx.Done():
+			m.recordUsage(0, true)
+			return "", fmt.Errorf("failed to generate explanation: %w", ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+	m.recordUsage(0, true)
+	return "", fmt.Errorf("failed to generate explanation with %s: %w", m.provider.Name(), err)
+}
+
+// recordUsage counts an explanation request and the tokens it used
+func (m *Manager) recordUsage(tokens int, failed bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.usage.Requests++
+	if failed {
+		m.usage.Failures++
+	}
+	m.usage.Tokens += tokens
+	m.usage.Cost += float64(tokens) / 1000 * m.config.CostPer1KTokens
+}
+
+// Usage returns the explanations requested since startup and their estimated spend
+func (m *Manager) Usage() Usage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.usage
 }
 
 // SwitchProvider switches to a different LLM provider
@@ -82,6 +147,12 @@ func (m *Manager) GetCurrentProvider() string {
 	return m.provider.Name()
 }
 
+// EstimateTokens approximates the tokens an explanation of s used, prompt and
+// completion, at four characters per token
+func (m *Manager) EstimateTokens(s *signal.Signal, explanation string) int {
+	return (len(createSignalPrompt(s)) + len(explanation) + 3) / 4
+}
+
 // OpenAIProvider implements the Provider interface for OpenAI
 type OpenAIProvider struct {
 	apiKey      string
@@ -93,7 +164,7 @@ type OpenAIProvider struct {
 // NewOpenAIProvider creates a new OpenAI provider
 func NewOpenAIProvider(apiKey, model string, maxTokens int, temperature float64) (*OpenAIProvider, error) {
 	if apiKey == "" {
-		return nil, fmt.Errorf("OpenAI API key is required")
+		return nil, fmt.Errorf("OpenAI API key is required: %w", errkind.ErrAuth)
 	}
 
 	if model == "" {
@@ -215,6 +286,12 @@ func createSignalPrompt(s *signal.Signal) string {
 		technicalData += fmt.Sprintf("- %s: %.2f\n", key, value)
 	}
 
+	// Ground the time frame in how the symbol typically trades intraday
+	seasonality := ""
+	if s.Seasonality != "" {
+		seasonality = fmt.Sprintf("\nIntraday Seasonality (typical behavior by hour for the rest of the session):\n%s\n", s.Seasonality)
+	}
+
 	// Create prompt
 	prompt := fmt.Sprintf(`
 Analyze the following trading signal and provide a clear, concise explanation for why this signal was generated and what it means for traders.
@@ -230,16 +307,15 @@ Signal Details:
 - Time Frame: %s
 
 Technical Indicators:
-%s
-
+%s%s
 Based on these details, explain:
 1. Why this %s signal was generated
 2. What technical factors support this signal
-3. What risks to be aware of
+3. What risks to be aware of, including whether the time frame fits the symbol's typical intraday behavior
 4. How traders should approach this opportunity
 
 Keep your explanation concise, informative, and suitable for both novice and experienced traders.
-`, s.Symbol, s.Type, s.Price, s.TargetPrice, s.StopLoss, s.ExpectedROI, s.Confidence*100, s.TimeFrame, technicalData, s.Type)
+`, s.Symbol, s.Type, s.Price, s.TargetPrice, s.StopLoss, s.ExpectedROI, s.Confidence*100, s.TimeFrame, technicalData, seasonality, s.Type)
 
 	return prompt
 }
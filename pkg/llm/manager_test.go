@@ -2,10 +2,12 @@ package llm
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/errkind"
 	"github.com/hustler/trading-bot/pkg/signal"
 	"github.com/stretchr/testify/assert"
 )
@@ -157,6 +159,47 @@ func TestGenerateSignalExplanation(t *testing.T) {
 	assert.Contains(t, explanation, "SELL signal for AAPL")
 }
 
+// flakyProvider fails with each of errs in turn before succeeding
+type flakyProvider struct {
+	errs  []error
+	calls int
+}
+
+func (p *flakyProvider) GenerateExplanation(ctx context.Context, s *signal.Signal) (string, error) {
+	p.calls++
+	if p.calls <= len(p.errs) {
+		return "", p.errs[p.calls-1]
+	}
+	return "explained", nil
+}
+
+func (p *flakyProvider) Name() string {
+	return "flaky"
+}
+
+func TestGenerateSignalExplanationRetries(t *testing.T) {
+	explanationBackoff = time.Millisecond
+	testSignal := &signal.Signal{Symbol: "AAPL", Type: signal.BUY}
+
+	// Rate limits and outages are retried
+	provider := &flakyProvider{errs: []error{
+		fmt.Errorf("throttled: %w", errkind.ErrRateLimited),
+		fmt.Errorf("timeout: %w", errkind.ErrProviderDown),
+	}}
+	manager := &Manager{config: &config.LLMConfig{}, provider: provider}
+	explanation, err := manager.GenerateSignalExplanation(context.Background(), testSignal)
+	assert.NoError(t, err)
+	assert.Equal(t, "explained", explanation)
+	assert.Equal(t, 3, provider.calls)
+
+	// Rejected credentials are returned at once
+	provider = &flakyProvider{errs: []error{fmt.Errorf("bad key: %w", errkind.ErrAuth)}}
+	manager.provider = provider
+	_, err = manager.GenerateSignalExplanation(context.Background(), testSignal)
+	assert.ErrorIs(t, err, errkind.ErrAuth)
+	assert.Equal(t, 1, provider.calls)
+}
+
 func TestMockProvider(t *testing.T) {
 	provider := NewMockProvider()
 	assert.NotNil(t, provider)
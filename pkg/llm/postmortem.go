@@ -0,0 +1,103 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TradeReview describes a closed trade for a post-mortem
+type TradeReview struct {
+	Symbol      string
+	Quantity    int
+	EntryPrice  float64
+	ExitPrice   float64
+	TargetPrice float64
+	StopPrice   float64
+	PnL         float64
+	ReturnPct   float64
+	OpenedAt    time.Time
+	ClosedAt    time.Time
+	Setup       string // Rationale the position was opened on
+	ExitReason  string
+	Outcome     string // How the exit compares to the plan, e.g. TARGET_HIT or STOPPED_OUT
+}
+
+// PostMortemProvider is implemented by providers that can write trade post-mortems.
+// Providers without it fall back to a templated post-mortem.
+type PostMortemProvider interface {
+	GeneratePostMortem(ctx context.Context, review TradeReview) (string, error)
+}
+
+// GeneratePostMortem writes a narrative post-mortem for a closed trade: what the
+// setup was, what happened and whether the trade hit or missed its plan
+func (m *Manager) GeneratePostMortem(ctx context.Context, review TradeReview) (string, error) {
+	if provider, ok := m.provider.(PostMortemProvider); ok {
+		return provider.GeneratePostMortem(ctx, review)
+	}
+	return generateMockPostMortem(review), nil
+}
+
+// createPostMortemPrompt creates a prompt for the LLM based on a closed trade
+func createPostMortemPrompt(r TradeReview) string {
+	return fmt.Sprintf(`
+Write a short post-mortem for the following closed trade for the trader's journal.
+
+Trade Details:
+- Symbol: %s
+- Quantity: %d
+- Entry: $%.2f on %s
+- Exit: $%.2f on %s
+- Planned Target: $%.2f
+- Planned Stop: $%.2f
+- P&L: $%.2f (%.2f%%)
+- Outcome: %s
+
+Setup:
+%s
+
+Exit Reason:
+%s
+
+Cover:
+1. What the setup was
+2. What happened while the position was open
+3. Whether the trade hit or missed its plan, and why
+4. One lesson to carry into the next trade
+
+Keep it to a few short paragraphs.
+`, r.Symbol, r.Quantity, r.EntryPrice, r.OpenedAt.Format(time.RFC3339), r.ExitPrice, r.ClosedAt.Format(time.RFC3339),
+		r.TargetPrice, r.StopPrice, r.PnL, r.ReturnPct, r.Outcome, r.Setup, r.ExitReason)
+}
+
+// generateMockPostMortem generates a templated post-mortem from the trade details
+func generateMockPostMortem(r TradeReview) string {
+	var b strings.Builder
+
+	setup := r.Setup
+	if setup == "" {
+		setup = "No setup rationale was recorded"
+	}
+	fmt.Fprintf(&b, "Setup: bought %d %s at $%.2f. %s.\n\n", r.Quantity, r.Symbol, r.EntryPrice, strings.TrimSuffix(setup, "."))
+
+	held := r.ClosedAt.Sub(r.OpenedAt).Round(time.Minute)
+	fmt.Fprintf(&b, "What happened: the position was held for %s and exited at $%.2f", held, r.ExitPrice)
+	if r.ExitReason != "" {
+		fmt.Fprintf(&b, " (%s)", r.ExitReason)
+	}
+	fmt.Fprintf(&b, " for a P&L of $%.2f (%+.2f%%).\n\n", r.PnL, r.ReturnPct)
+
+	switch r.Outcome {
+	case "TARGET_HIT":
+		fmt.Fprintf(&b, "Versus plan: hit. The target of $%.2f was reached as planned.", r.TargetPrice)
+	case "STOPPED_OUT":
+		fmt.Fprintf(&b, "Versus plan: miss. The stop at $%.2f was hit and the loss stayed within the planned risk.", r.StopPrice)
+	case "CLOSED_WIN":
+		b.WriteString("Versus plan: partial. The trade closed in profit before reaching its target.")
+	default:
+		b.WriteString("Versus plan: miss. The trade closed at a loss before reaching its stop.")
+	}
+
+	return b.String()
+}
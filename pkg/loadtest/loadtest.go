@@ -0,0 +1,226 @@
+package loadtest
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/backtest"
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+)
+
+// start is the simulated session open load runs replay from
+var start = time.Date(2025, 4, 21, 13, 30, 0, 0, time.UTC)
+
+// warmupBars is the number of bars replayed before the first measured check,
+// so the indicators have enough history
+const warmupBars = 30
+
+// Config describes a load run
+type Config struct {
+	Symbols int // Watchlist size
+	Cycles  int // Watcher update cycles and market checks measured
+	Readers int // Goroutines reading watcher and monitor state during the run
+	Seed    int64
+}
+
+// Latency summarizes a set of durations
+type Latency struct {
+	P50 time.Duration `json:"p50"`
+	P95 time.Duration `json:"p95"`
+	Max time.Duration `json:"max"`
+}
+
+// Result is what a load run measured
+type Result struct {
+	Symbols       int     `json:"symbols"`
+	Cycles        int     `json:"cycles"`
+	Readers       int     `json:"readers"`
+	WatcherCycle  Latency `json:"watcher_cycle"` // One MarketWatcher update over the whole watchlist
+	MarketCheck   Latency `json:"market_check"`  // One MarketMonitor check over the whole watchlist
+	ReaderWait    Latency `json:"reader_wait"`   // Reader calls, dominated by lock waits while cycles run
+	ReaderCalls   int     `json:"reader_calls"`
+	Signals       int     `json:"signals"`
+	HeapBytes     uint64  `json:"heap_bytes"`      // Live heap after the run
+	AllocPerCycle uint64  `json:"alloc_per_cycle"` // Bytes allocated per watcher cycle and market check
+}
+
+// Budget is the upper bound a load run must stay within. Zero fields are not checked.
+type Budget struct {
+	WatcherCycleP95 time.Duration
+	MarketCheckP95  time.Duration
+	ReaderWaitP95   time.Duration
+	HeapBytes       uint64
+}
+
+// DefaultBudget returns a budget for 1000 symbols generous enough for CI machines
+func DefaultBudget() Budget {
+	return Budget{
+		WatcherCycleP95: 250 * time.Millisecond,
+		MarketCheckP95:  5 * time.Second,
+		ReaderWaitP95:   50 * time.Millisecond,
+		HeapBytes:       512 << 20,
+	}
+}
+
+// Check returns a description of each budget the result exceeds
+func (r *Result) Check(budget Budget) []string {
+	var violations []string
+	exceeds := func(name string, got, limit time.Duration) {
+		if limit > 0 && got > limit {
+			violations = append(violations, fmt.Sprintf("%s p95 %s exceeds budget %s", name, got, limit))
+		}
+	}
+	exceeds("watcher cycle", r.WatcherCycle.P95, budget.WatcherCycleP95)
+	exceeds("market check", r.MarketCheck.P95, budget.MarketCheckP95)
+	exceeds("reader wait", r.ReaderWait.P95, budget.ReaderWaitP95)
+	if budget.HeapBytes > 0 && r.HeapBytes > budget.HeapBytes {
+		violations = append(violations, fmt.Sprintf("heap %d bytes exceeds budget %d", r.HeapBytes, budget.HeapBytes))
+	}
+	return violations
+}
+
+// Symbols returns n synthetic ticker symbols
+func Symbols(n int) []string {
+	symbols := make([]string, n)
+	for i := range symbols {
+		symbols[i] = fmt.Sprintf("LT%04d", i)
+	}
+	return symbols
+}
+
+// Run simulates a watchlist of cfg.Symbols symbols fed by synthetic ticks and
+// measures MarketWatcher cycles and MarketMonitor checks while readers poll
+// both concurrently
+func Run(cfg Config) (*Result, error) {
+	if cfg.Symbols <= 0 || cfg.Cycles <= 0 {
+		return nil, fmt.Errorf("symbols and cycles must be positive")
+	}
+
+	symbols := Symbols(cfg.Symbols)
+	history := make(map[string]*data.MarketData, len(symbols))
+	for i, symbol := range symbols {
+		md, err := data.GenerateSynthetic(data.SyntheticSpec{
+			Symbol:   symbol,
+			Scenario: syntheticScenarios[i%len(syntheticScenarios)],
+			Start:    start,
+			Interval: time.Minute,
+			Bars:     warmupBars + cfg.Cycles,
+			Seed:     cfg.Seed + int64(i) + 1,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate data for %s: %w", symbol, err)
+		}
+		history[symbol] = md
+	}
+
+	appCfg := config.CreateDefaultConfig()
+	appCfg.StockSymbols = symbols
+	// Synthetic data has no daily history to measure liquidity from
+	appCfg.Liquidity = config.LiquidityConfig{}
+	runner := backtest.NewRunner(appCfg, history)
+
+	watcher := data.NewMarketWatcher(nil, "synthetic", 1)
+	watcher.SetTickSource(data.NewSyntheticTicks(cfg.Seed))
+	for _, symbol := range symbols {
+		watcher.AddStock(symbol)
+	}
+	watcher.OnUpdate(func(*data.Stock) {})
+
+	stop := make(chan struct{})
+	var readerMu sync.Mutex
+	var readerWaits []time.Duration
+	var wg sync.WaitGroup
+	for i := 0; i < cfg.Readers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var waits []time.Duration
+			for {
+				select {
+				case <-stop:
+					readerMu.Lock()
+					readerWaits = append(readerWaits, waits...)
+					readerMu.Unlock()
+					return
+				default:
+				}
+				started := time.Now()
+				if i%2 == 0 {
+					watcher.GetAllStocks()
+				} else {
+					runner.Monitor.GetSignalHistory()
+				}
+				waits = append(waits, time.Since(started))
+				time.Sleep(time.Millisecond)
+			}
+		}(i)
+	}
+
+	runtime.GC()
+	var before runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	watcherCycles := make([]time.Duration, 0, cfg.Cycles)
+	marketChecks := make([]time.Duration, 0, cfg.Cycles)
+	var runErr error
+	for cycle := 0; cycle < cfg.Cycles; cycle++ {
+		started := time.Now()
+		watcher.UpdateNow()
+		watcherCycles = append(watcherCycles, time.Since(started))
+
+		started = time.Now()
+		if err := runner.Step(start.Add(time.Duration(warmupBars+cycle) * time.Minute)); err != nil {
+			runErr = err
+			break
+		}
+		marketChecks = append(marketChecks, time.Since(started))
+	}
+
+	var after runtime.MemStats
+	runtime.ReadMemStats(&after)
+
+	close(stop)
+	wg.Wait()
+	if runErr != nil {
+		return nil, runErr
+	}
+
+	runtime.GC()
+	var live runtime.MemStats
+	runtime.ReadMemStats(&live)
+
+	return &Result{
+		Symbols:       cfg.Symbols,
+		Cycles:        cfg.Cycles,
+		Readers:       cfg.Readers,
+		WatcherCycle:  summarize(watcherCycles),
+		MarketCheck:   summarize(marketChecks),
+		ReaderWait:    summarize(readerWaits),
+		ReaderCalls:   len(readerWaits),
+		Signals:       len(runner.Notifier.Signals()),
+		HeapBytes:     live.HeapAlloc,
+		AllocPerCycle: (after.TotalAlloc - before.TotalAlloc) / uint64(cfg.Cycles),
+	}, nil
+}
+
+// syntheticScenarios are cycled through so the watchlist mixes market conditions
+var syntheticScenarios = []string{"trend", "mean_reversion", "gap", "breakout"}
+
+// summarize returns the percentiles of durations
+func summarize(durations []time.Duration) Latency {
+	if len(durations) == 0 {
+		return Latency{}
+	}
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	percentile := func(p float64) time.Duration {
+		return sorted[int(p*float64(len(sorted)-1))]
+	}
+	return Latency{P50: percentile(0.5), P95: percentile(0.95), Max: sorted[len(sorted)-1]}
+}
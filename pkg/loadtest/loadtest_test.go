@@ -0,0 +1,43 @@
+package loadtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunMeasuresWatchlist(t *testing.T) {
+	result, err := Run(Config{Symbols: 50, Cycles: 5, Readers: 2, Seed: 1})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, 50, result.Symbols)
+	assert.Positive(t, result.WatcherCycle.P50)
+	assert.Positive(t, result.MarketCheck.P50)
+	assert.LessOrEqual(t, result.MarketCheck.P50, result.MarketCheck.Max)
+	assert.Positive(t, result.HeapBytes)
+	assert.Empty(t, result.Check(Budget{}))
+}
+
+func TestCheckReportsExceededBudget(t *testing.T) {
+	result := &Result{
+		WatcherCycle: Latency{P95: 300 * time.Millisecond},
+		MarketCheck:  Latency{P95: time.Second},
+		HeapBytes:    1 << 30,
+	}
+
+	violations := result.Check(DefaultBudget())
+	assert.Len(t, violations, 2)
+	assert.Contains(t, violations[0], "watcher cycle")
+	assert.Contains(t, violations[1], "heap")
+}
+
+func BenchmarkRun1000Symbols(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := Run(Config{Symbols: 1000, Cycles: 1}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package logtail
+
+import (
+	"bytes"
+	"sync"
+)
+
+// Tail keeps the most recent log lines in memory so they can be served to
+// operators, e.g. by the API. Install it with log.SetOutput alongside the
+// usual output.
+type Tail struct {
+	lines   []string
+	next    int    // Index the next line is written to
+	full    bool   // Lines has wrapped around
+	partial []byte // Line written without its newline yet
+	mu      sync.Mutex
+}
+
+// NewTail creates a Tail keeping the last size lines
+func NewTail(size int) *Tail {
+	return &Tail{lines: make([]string, max(size, 1))}
+}
+
+// Write implements io.Writer, splitting p into lines
+func (t *Tail) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	data := append(t.partial, p...)
+	for {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			break
+		}
+		t.add(string(data[:i]))
+		data = data[i+1:]
+	}
+	t.partial = append([]byte(nil), data...)
+	return len(p), nil
+}
+
+// add appends a line, overwriting the oldest once full
+func (t *Tail) add(line string) {
+	t.lines[t.next] = line
+	t.next = (t.next + 1) % len(t.lines)
+	if t.next == 0 {
+		t.full = true
+	}
+}
+
+// Lines returns up to the last n lines, oldest first. n <= 0 returns every
+// line kept.
+func (t *Tail) Lines(n int) []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	count := t.next
+	if t.full {
+		count = len(t.lines)
+	}
+	if n <= 0 || n > count {
+		n = count
+	}
+
+	lines := make([]string, 0, n)
+	for i := n; i > 0; i-- {
+		lines = append(lines, t.lines[(t.next-i+len(t.lines))%len(t.lines)])
+	}
+	return lines
+}
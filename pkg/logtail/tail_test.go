@@ -0,0 +1,27 @@
+package logtail
+
+import (
+	"fmt"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTailKeepsLastLines(t *testing.T) {
+	tail := NewTail(3)
+	assert.Empty(t, tail.Lines(10))
+
+	logger := log.New(tail, "", 0)
+	for i := 1; i <= 4; i++ {
+		logger.Printf("line %d", i)
+	}
+	assert.Equal(t, []string{"line 2", "line 3", "line 4"}, tail.Lines(0))
+	assert.Equal(t, []string{"line 3", "line 4"}, tail.Lines(2))
+
+	// Lines written in pieces are kept whole
+	fmt.Fprint(tail, "line ")
+	assert.Equal(t, []string{"line 4"}, tail.Lines(1))
+	fmt.Fprint(tail, "5\nline 6\n")
+	assert.Equal(t, []string{"line 4", "line 5", "line 6"}, tail.Lines(5))
+}
@@ -0,0 +1,140 @@
+package market
+
+import (
+	"fmt"
+	"time"
+
+	// Bundle the tz database so session times stay correct on hosts without zoneinfo
+	_ "time/tzdata"
+)
+
+// Regular US equity session
+const (
+	DefaultTimeZone = "America/New_York"
+	DefaultOpen     = "09:30"
+	DefaultClose    = "16:00"
+)
+
+// Clock answers market-time questions for an exchange session: whether the
+// market is open, when the session opens and closes and which trading day a
+// moment belongs to. Session times are wall-clock times in the exchange's
+// time zone, so they follow daylight saving transitions.
+type Clock struct {
+	loc   *time.Location
+	open  time.Duration // Offset of the open from local midnight
+	close time.Duration // Offset of the close from local midnight
+	// Intraday windows dividing the day into session states
+	preOpen   time.Duration // Offset of the pre-market open from local midnight
+	postClose time.Duration // Offset of the post-market close from local midnight
+	opening   time.Duration // Length of the opening window after the open
+	powerHour time.Duration // Length of the power hour before the close
+}
+
+// NewClock creates a Clock for a session from open to close ("HH:MM") in timeZone
+func NewClock(timeZone, open, close string) (*Clock, error) {
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone: %w", err)
+	}
+
+	openOffset, err := parseClockTime(open)
+	if err != nil {
+		return nil, fmt.Errorf("invalid open time: %w", err)
+	}
+	closeOffset, err := parseClockTime(close)
+	if err != nil {
+		return nil, fmt.Errorf("invalid close time: %w", err)
+	}
+	if closeOffset <= openOffset {
+		return nil, fmt.Errorf("close time %s must be after open time %s", close, open)
+	}
+
+	clock := &Clock{loc: loc, open: openOffset, close: closeOffset}
+	clock.setWindows(defaultPreOpen, defaultPostClose, DefaultOpeningWindow, DefaultPowerHour)
+	return clock, nil
+}
+
+// DefaultClock returns the Clock for the regular US equity session
+func DefaultClock() *Clock {
+	clock, err := NewClock(DefaultTimeZone, DefaultOpen, DefaultClose)
+	if err != nil {
+		// Unreachable: the tz database is embedded and the session times are constant
+		panic(err)
+	}
+	return clock
+}
+
+// Location returns the exchange time zone
+func (c *Clock) Location() *time.Location {
+	return c.loc
+}
+
+// IsTradingDay reports whether t falls on a weekday in the exchange time zone
+func (c *Clock) IsTradingDay(t time.Time) bool {
+	weekday := t.In(c.loc).Weekday()
+	return weekday != time.Saturday && weekday != time.Sunday
+}
+
+// IsOpen reports whether the session is open at t
+func (c *Clock) IsOpen(t time.Time) bool {
+	if !c.IsTradingDay(t) {
+		return false
+	}
+	return !t.Before(c.SessionOpen(t)) && t.Before(c.SessionClose(t))
+}
+
+// SessionOpen returns the open of the session on t's local date
+func (c *Clock) SessionOpen(t time.Time) time.Time {
+	return c.at(t, c.open)
+}
+
+// SessionClose returns the close of the session on t's local date
+func (c *Clock) SessionClose(t time.Time) time.Time {
+	return c.at(t, c.close)
+}
+
+// NearClose reports whether t is within window before the close of a trading day
+func (c *Clock) NearClose(t time.Time, window time.Duration) bool {
+	if !c.IsTradingDay(t) {
+		return false
+	}
+	sessionClose := c.SessionClose(t)
+	return !t.Before(sessionClose.Add(-window)) && t.Before(sessionClose)
+}
+
+// NextSessionClose returns the close of the first trading day after t's local date
+func (c *Clock) NextSessionClose(t time.Time) time.Time {
+	day := c.TradingDay(t).AddDate(0, 0, 1)
+	for !c.IsTradingDay(day) {
+		day = day.AddDate(0, 0, 1)
+	}
+	return c.SessionClose(day)
+}
+
+// TradingDay returns local midnight of the day t falls on in the exchange time zone
+func (c *Clock) TradingDay(t time.Time) time.Time {
+	local := t.In(c.loc)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, c.loc)
+}
+
+// DayKey returns t's exchange-local date as YYYY-MM-DD
+func (c *Clock) DayKey(t time.Time) string {
+	return t.In(c.loc).Format("2006-01-02")
+}
+
+// at returns the wall-clock time offset from midnight on t's local date. Building
+// it with time.Date rather than adding to midnight keeps it correct on DST days.
+func (c *Clock) at(t time.Time, offset time.Duration) time.Time {
+	local := t.In(c.loc)
+	return time.Date(local.Year(), local.Month(), local.Day(),
+		int(offset/time.Hour), int(offset%time.Hour/time.Minute), 0, 0, c.loc)
+}
+
+// parseClockTime parses "HH:MM" into an offset from midnight
+func parseClockTime(value string) (time.Duration, error) {
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(parsed.Hour())*time.Hour + time.Duration(parsed.Minute())*time.Minute, nil
+}
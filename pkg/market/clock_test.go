@@ -0,0 +1,59 @@
+package market
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClockFollowsDaylightSaving(t *testing.T) {
+	clock := DefaultClock()
+
+	// Friday before the spring-forward weekend is on EST (UTC-5)
+	friday := time.Date(2025, 3, 7, 15, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2025, 3, 7, 14, 30, 0, 0, time.UTC), clock.SessionOpen(friday).UTC())
+	assert.True(t, clock.IsOpen(friday))
+
+	// The following Monday is on EDT (UTC-4), so the same UTC time is an hour later locally
+	monday := time.Date(2025, 3, 10, 13, 45, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2025, 3, 10, 13, 30, 0, 0, time.UTC), clock.SessionOpen(monday).UTC())
+	assert.True(t, clock.IsOpen(monday))
+	assert.False(t, clock.IsOpen(time.Date(2025, 3, 7, 13, 45, 0, 0, time.UTC)))
+
+	// Closing across the weekend lands on Monday's 16:00 EDT
+	afterClose := time.Date(2025, 3, 7, 21, 30, 0, 0, time.UTC)
+	assert.False(t, clock.IsOpen(afterClose))
+	assert.Equal(t, time.Date(2025, 3, 10, 20, 0, 0, 0, time.UTC), clock.NextSessionClose(afterClose).UTC())
+
+	// Fall back: Monday after the change closes at 21:00 UTC again
+	fallMonday := time.Date(2025, 11, 3, 20, 57, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2025, 11, 3, 21, 0, 0, 0, time.UTC), clock.SessionClose(fallMonday).UTC())
+	assert.True(t, clock.NearClose(fallMonday, 5*time.Minute))
+	assert.False(t, clock.NearClose(time.Date(2025, 11, 3, 20, 50, 0, 0, time.UTC), 5*time.Minute))
+}
+
+func TestClockTradingDayUsesExchangeDate(t *testing.T) {
+	clock := DefaultClock()
+
+	// 01:30 UTC on Tuesday is still Monday evening in New York
+	late := time.Date(2025, 11, 4, 1, 30, 0, 0, time.UTC)
+	assert.Equal(t, "2025-11-03", clock.DayKey(late))
+
+	// The fall-back day is 25 hours long but is still one trading day
+	sunday := time.Date(2025, 11, 2, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, 25*time.Hour, clock.TradingDay(sunday.Add(24*time.Hour)).Sub(clock.TradingDay(sunday)))
+	assert.False(t, clock.IsTradingDay(sunday))
+}
+
+func TestNewClockRejectsInvalidSession(t *testing.T) {
+	_, err := NewClock("Mars/Olympus", "09:30", "16:00")
+	assert.Error(t, err)
+
+	_, err = NewClock(DefaultTimeZone, "16:00", "09:30")
+	assert.Error(t, err)
+
+	clock, err := NewClock("Europe/London", "08:00", "16:30")
+	assert.NoError(t, err)
+	assert.Equal(t, "Europe/London", clock.Location().String())
+}
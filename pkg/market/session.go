@@ -0,0 +1,93 @@
+package market
+
+import (
+	"fmt"
+	"time"
+)
+
+// SessionState is the part of the trading day a moment falls in
+type SessionState string
+
+// Session states in the order they occur on a trading day
+const (
+	PreMarket     SessionState = "pre_market"
+	OpeningWindow SessionState = "opening_window"
+	Midday        SessionState = "midday"
+	PowerHour     SessionState = "power_hour"
+	PostMarket    SessionState = "post_market"
+	Closed        SessionState = "closed"
+)
+
+// SessionStates lists every session state in the order they occur
+var SessionStates = []SessionState{PreMarket, OpeningWindow, Midday, PowerHour, PostMarket, Closed}
+
+// Intraday windows of the regular US equity session
+const (
+	DefaultPreMarketOpen   = "04:00"
+	DefaultPostMarketClose = "20:00"
+	DefaultOpeningWindow   = 30 * time.Minute
+	DefaultPowerHour       = time.Hour
+
+	defaultPreOpen   = 4 * time.Hour
+	defaultPostClose = 20 * time.Hour
+)
+
+// IsRegular reports whether the state is part of the regular session
+func (s SessionState) IsRegular() bool {
+	return s == OpeningWindow || s == Midday || s == PowerHour
+}
+
+// SetWindows sets the extended-hours session ("HH:MM"; empty keeps the
+// current time) and the length of the opening window and power hour
+func (c *Clock) SetWindows(preMarketOpen, postMarketClose string, opening, powerHour time.Duration) error {
+	preOpen, postClose := c.preOpen, c.postClose
+	var err error
+	if preMarketOpen != "" {
+		if preOpen, err = parseClockTime(preMarketOpen); err != nil {
+			return fmt.Errorf("invalid pre-market open time: %w", err)
+		}
+	}
+	if postMarketClose != "" {
+		if postClose, err = parseClockTime(postMarketClose); err != nil {
+			return fmt.Errorf("invalid post-market close time: %w", err)
+		}
+	}
+	if opening < 0 || powerHour < 0 {
+		return fmt.Errorf("session windows must not be negative")
+	}
+
+	c.setWindows(preOpen, postClose, opening, powerHour)
+	return nil
+}
+
+// setWindows sets the intraday windows, keeping the extended hours around the
+// regular session
+func (c *Clock) setWindows(preOpen, postClose, opening, powerHour time.Duration) {
+	c.preOpen = min(preOpen, c.open)
+	c.postClose = max(postClose, c.close)
+	c.opening = opening
+	c.powerHour = powerHour
+}
+
+// State returns the session state at t. Weekends are closed all day.
+func (c *Clock) State(t time.Time) SessionState {
+	if !c.IsTradingDay(t) {
+		return Closed
+	}
+
+	switch {
+	case t.Before(c.at(t, c.preOpen)):
+		return Closed
+	case t.Before(c.SessionOpen(t)):
+		return PreMarket
+	case t.Before(c.SessionOpen(t).Add(c.opening)):
+		return OpeningWindow
+	case t.Before(c.SessionClose(t).Add(-c.powerHour)):
+		return Midday
+	case t.Before(c.SessionClose(t)):
+		return PowerHour
+	case t.Before(c.at(t, c.postClose)):
+		return PostMarket
+	}
+	return Closed
+}
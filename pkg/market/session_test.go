@@ -0,0 +1,48 @@
+package market
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClockState(t *testing.T) {
+	clock := DefaultClock()
+	loc := clock.Location()
+	at := func(hour, minute int) time.Time {
+		return time.Date(2025, 3, 10, hour, minute, 0, 0, loc) // Monday
+	}
+
+	assert.Equal(t, Closed, clock.State(at(3, 59)))
+	assert.Equal(t, PreMarket, clock.State(at(4, 0)))
+	assert.Equal(t, OpeningWindow, clock.State(at(9, 30)))
+	assert.Equal(t, Midday, clock.State(at(10, 0)))
+	assert.Equal(t, PowerHour, clock.State(at(15, 0)))
+	assert.Equal(t, PostMarket, clock.State(at(16, 0)))
+	assert.Equal(t, Closed, clock.State(at(20, 0)))
+	assert.Equal(t, Closed, clock.State(time.Date(2025, 3, 8, 12, 0, 0, 0, loc)))
+
+	for _, state := range SessionStates {
+		assert.Equal(t, state == OpeningWindow || state == Midday || state == PowerHour, state.IsRegular())
+	}
+}
+
+func TestClockSetWindows(t *testing.T) {
+	clock := DefaultClock()
+	loc := clock.Location()
+
+	assert.NoError(t, clock.SetWindows("07:00", "", 15*time.Minute, 30*time.Minute))
+	assert.Equal(t, Closed, clock.State(time.Date(2025, 3, 10, 6, 0, 0, 0, loc)))
+	assert.Equal(t, Midday, clock.State(time.Date(2025, 3, 10, 9, 45, 0, 0, loc)))
+	assert.Equal(t, Midday, clock.State(time.Date(2025, 3, 10, 15, 15, 0, 0, loc)))
+	assert.Equal(t, PostMarket, clock.State(time.Date(2025, 3, 10, 19, 0, 0, 0, loc)))
+
+	// Extended hours never cut into the regular session
+	assert.NoError(t, clock.SetWindows("10:00", "12:00", 0, 0))
+	assert.Equal(t, Midday, clock.State(time.Date(2025, 3, 10, 9, 30, 0, 0, loc)))
+	assert.Equal(t, Closed, clock.State(time.Date(2025, 3, 10, 16, 0, 0, 0, loc)))
+
+	assert.Error(t, clock.SetWindows("4am", "", 0, 0))
+	assert.Error(t, clock.SetWindows("", "", -time.Minute, 0))
+}
@@ -0,0 +1,163 @@
+package monitor
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/execution"
+	"github.com/hustler/trading-bot/pkg/market"
+	"github.com/hustler/trading-bot/pkg/strategy"
+)
+
+// AccountBalance is the cash, margin and buying power of the account
+type AccountBalance struct {
+	Cash        float64 `json:"cash"`         // Settled and unsettled cash, negative while borrowing on margin
+	Settled     float64 `json:"settled"`      // Cash free to trade in a cash account
+	Unsettled   float64 `json:"unsettled"`    // Sale proceeds that have not settled yet
+	LongValue   float64 `json:"long_value"`   // Market value of the long positions
+	ShortValue  float64 `json:"short_value"`  // Market value of the short positions
+	Equity      float64 `json:"equity"`       // Cash plus longs minus shorts
+	MarginUsed  float64 `json:"margin_used"`  // Position value not covered by equity
+	BuyingPower float64 `json:"buying_power"` // Value of new positions the account can open
+	Margin      bool    `json:"margin"`       // Margin rather than cash account
+}
+
+// SetAccount sets the cash and margin rules orders are checked against
+func (r *RiskManager) SetAccount(account config.ComplianceConfig) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.account = account
+}
+
+// AccountBalance returns the balance of the account at the latest prices. It
+// returns false when no account cash is configured.
+func (r *RiskManager) AccountBalance(stocks map[string]*data.Stock) (AccountBalance, bool) {
+	r.mu.RLock()
+	account, session, now := r.account, r.session, r.clock.Now()
+	r.mu.RUnlock()
+
+	if account.Cash <= 0 {
+		return AccountBalance{}, false
+	}
+	return balanceOf(account, session, r.positions(), stockPrices(stocks), now), true
+}
+
+// positions returns copies of every position, open and completed
+func (r *RiskManager) positions() []execution.Trade {
+	var positions []execution.Trade
+	for _, trade := range r.tradeManager.GetAllTrades() {
+		if trade.PositionID == "" {
+			positions = append(positions, *trade)
+		}
+	}
+	return positions
+}
+
+// stockPrices returns the latest price of every quoted symbol
+func stockPrices(stocks map[string]*data.Stock) map[string]float64 {
+	latest := make(map[string]float64, len(stocks))
+	for symbol, stock := range stocks {
+		latest[symbol] = stock.CurrentPrice
+	}
+	return latest
+}
+
+// balanceOf replays the fills of positions against the account's starting
+// cash. Buys are paid at once; sale proceeds settle settlement days later.
+// Open positions without a price are valued at their entry price.
+func balanceOf(account config.ComplianceConfig, session *market.Clock, positions []execution.Trade, prices map[string]float64, now time.Time) AccountBalance {
+	balance := AccountBalance{Settled: account.Cash, Margin: account.MarginMultiplier > 1}
+	for _, position := range positions {
+		for _, fill := range position.Fills {
+			amount := fill.Price * float64(fill.Quantity)
+			switch {
+			case fill.Side == strategy.Buy:
+				balance.Settled -= amount
+			case settledBy(session, fill.FilledAt, account.SettlementDays, now):
+				balance.Settled += amount
+			default:
+				balance.Unsettled += amount
+			}
+		}
+
+		if position.Status != execution.Executed {
+			continue
+		}
+		price, ok := prices[position.Symbol]
+		if !ok || price <= 0 {
+			price = position.Price
+		}
+		value := price * float64(position.Quantity)
+		if position.IsShort() {
+			balance.ShortValue += value
+		} else {
+			balance.LongValue += value
+		}
+	}
+
+	balance.Cash = balance.Settled + balance.Unsettled
+	balance.Equity = balance.Cash + balance.LongValue - balance.ShortValue
+	gross := balance.LongValue + balance.ShortValue
+	balance.MarginUsed = max(0, gross-balance.Equity)
+	if balance.Margin {
+		balance.BuyingPower = max(0, balance.Equity*account.MarginMultiplier-gross)
+	} else {
+		balance.BuyingPower = max(0, balance.Settled)
+	}
+	return balance
+}
+
+// settledBy reports whether a sale at soldAt has settled by now
+func settledBy(session *market.Clock, soldAt time.Time, settlementDays int, now time.Time) bool {
+	day := session.TradingDay(soldAt)
+	for days := 0; days < settlementDays; {
+		day = day.AddDate(0, 0, 1)
+		if session.IsTradingDay(day) {
+			days++
+		}
+	}
+	return !session.TradingDay(now).Before(day)
+}
+
+// BuyingPowerCheck returns a pre-trade check refusing new positions beyond the
+// account's buying power, for TradeManager.AddPreTradeCheck
+func (r *RiskManager) BuyingPowerCheck() execution.PreTradeCheck {
+	return buyingPowerCheck{risk: r}
+}
+
+// buyingPowerCheck refuses orders the account cannot pay for
+type buyingPowerCheck struct {
+	risk *RiskManager
+}
+
+// Name returns the name check results are recorded under
+func (c buyingPowerCheck) Name() string {
+	return "buying_power"
+}
+
+// Check refuses an order opening or adding to a position that costs more than
+// the buying power, and any short in a cash account. Positions are valued at
+// their entry price, the order's symbol at the order price.
+func (c buyingPowerCheck) Check(order execution.Order, account execution.Account) execution.CheckResult {
+	// The trade manager's lock is held, so only the risk manager's own state is read
+	c.risk.mu.RLock()
+	settings, session := c.risk.account, c.risk.session
+	c.risk.mu.RUnlock()
+
+	if !account.Opening || settings.Cash <= 0 {
+		return execution.CheckResult{Passed: true}
+	}
+
+	balance := balanceOf(settings, session, account.Positions, map[string]float64{order.Symbol: order.Price}, account.Now)
+	if order.Side == strategy.Sell && !balance.Margin {
+		return execution.CheckResult{Reason: "short selling needs a margin account"}
+	}
+	cost := order.Price * float64(order.Quantity)
+	if cost > balance.BuyingPower {
+		return execution.CheckResult{Reason: fmt.Sprintf("order costs $%.2f, buying power is $%.2f", cost, balance.BuyingPower)}
+	}
+	return execution.CheckResult{Passed: true}
+}
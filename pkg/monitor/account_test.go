@@ -0,0 +1,116 @@
+package monitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/execution"
+	"github.com/hustler/trading-bot/pkg/market"
+	"github.com/hustler/trading-bot/pkg/strategy"
+	"github.com/stretchr/testify/assert"
+)
+
+// Trading days of the account tests, at 11:00 in New York
+var (
+	accountThursday = time.Date(2026, 10, 15, 15, 0, 0, 0, time.UTC)
+	accountFriday   = time.Date(2026, 10, 16, 15, 0, 0, 0, time.UTC)
+	accountSaturday = time.Date(2026, 10, 17, 15, 0, 0, 0, time.UTC)
+	accountMonday   = time.Date(2026, 10, 19, 15, 0, 0, 0, time.UTC)
+)
+
+// accountFill returns a fill of quantity shares of AAPL at price
+func accountFill(side strategy.TradeSignal, quantity int, price float64, at time.Time) execution.Fill {
+	return execution.Fill{Symbol: "AAPL", Side: side, Quantity: quantity, Price: price, FilledAt: at}
+}
+
+func TestBalanceOf(t *testing.T) {
+	cash := config.ComplianceConfig{Cash: 10000, SettlementDays: 1, MarginMultiplier: 1}
+	margin := config.ComplianceConfig{Cash: 10000, SettlementDays: 1, MarginMultiplier: 2}
+	roundTrip := execution.Trade{Symbol: "AAPL", Type: strategy.Buy, Status: execution.Completed, Price: 100, Fills: []execution.Fill{
+		accountFill(strategy.Buy, 10, 100, accountFriday),
+		accountFill(strategy.Sell, 10, 110, accountFriday),
+	}}
+
+	testCases := []struct {
+		name     string
+		account  config.ComplianceConfig
+		position execution.Trade
+		prices   map[string]float64
+		now      time.Time
+		want     AccountBalance
+	}{
+		{
+			name:    "cash account long at the latest price",
+			account: cash,
+			position: execution.Trade{Symbol: "AAPL", Type: strategy.Buy, Status: execution.Executed, Quantity: 10, Price: 100,
+				Fills: []execution.Fill{accountFill(strategy.Buy, 10, 100, accountFriday)}},
+			prices: map[string]float64{"AAPL": 110},
+			now:    accountFriday,
+			want:   AccountBalance{Cash: 9000, Settled: 9000, LongValue: 1100, Equity: 10100, BuyingPower: 9000},
+		},
+		{
+			name:     "sale proceeds unsettled on the day",
+			account:  cash,
+			position: roundTrip,
+			now:      accountFriday,
+			want:     AccountBalance{Cash: 10100, Settled: 9000, Unsettled: 1100, Equity: 10100, BuyingPower: 9000},
+		},
+		{
+			name:     "sale proceeds settled the next trading day",
+			account:  cash,
+			position: roundTrip,
+			now:      accountMonday,
+			want:     AccountBalance{Cash: 10100, Settled: 10100, Equity: 10100, BuyingPower: 10100},
+		},
+		{
+			name:    "margin account long without a price",
+			account: margin,
+			position: execution.Trade{Symbol: "AAPL", Type: strategy.Buy, Status: execution.Executed, Quantity: 150, Price: 100,
+				Fills: []execution.Fill{accountFill(strategy.Buy, 150, 100, accountFriday)}},
+			now: accountFriday,
+			want: AccountBalance{Cash: -5000, Settled: -5000, LongValue: 15000, Equity: 10000, MarginUsed: 5000,
+				BuyingPower: 5000, Margin: true},
+		},
+		{
+			name:    "margin account short",
+			account: margin,
+			position: execution.Trade{Symbol: "AAPL", Type: strategy.Sell, Status: execution.Executed, Quantity: 10, Price: 100,
+				Fills: []execution.Fill{accountFill(strategy.Sell, 10, 100, accountFriday)}},
+			prices: map[string]float64{"AAPL": 90},
+			now:    accountFriday,
+			want: AccountBalance{Cash: 11000, Settled: 10000, Unsettled: 1000, ShortValue: 900, Equity: 10100,
+				BuyingPower: 19300, Margin: true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			balance := balanceOf(tc.account, market.DefaultClock(), []execution.Trade{tc.position}, tc.prices, tc.now)
+			assert.Equal(t, tc.want, balance)
+		})
+	}
+}
+
+func TestSettledBy(t *testing.T) {
+	testCases := []struct {
+		name           string
+		soldAt         time.Time
+		settlementDays int
+		now            time.Time
+		settled        bool
+	}{
+		{"T+0 on the day", accountFriday, 0, accountFriday, true},
+		{"T+1 on the day", accountFriday, 1, accountFriday, false},
+		{"T+1 over the weekend", accountFriday, 1, accountSaturday, false},
+		{"T+1 on Monday", accountFriday, 1, accountMonday, true},
+		{"T+2 the next day", accountThursday, 2, accountFriday, false},
+		{"T+2 skipping the weekend", accountThursday, 2, accountMonday, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.settled, settledBy(market.DefaultClock(), tc.soldAt, tc.settlementDays, tc.now))
+		})
+	}
+}
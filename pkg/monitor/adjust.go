@@ -0,0 +1,58 @@
+package monitor
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// OnSignalAdjusted registers a callback invoked with a copy of a signal after its
+// target or stop changes, e.g. to update a TradeManager or performance tracking
+func (m *MarketMonitor) OnSignalAdjusted(fn func(*signal.Signal)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.adjustListeners = append(m.adjustListeners, fn)
+}
+
+// AdjustSignal changes an active signal's target or stop, edits its Telegram
+// message and notifies adjustment listeners
+func (m *MarketMonitor) AdjustSignal(signalID, level string, value float64) (*signal.Signal, error) {
+	m.mu.Lock()
+	var target *signal.Signal
+	for _, s := range m.signalHistory {
+		if s.ID == signalID {
+			target = s
+			break
+		}
+	}
+	if target == nil {
+		m.mu.Unlock()
+		return nil, fmt.Errorf("signal not found: %s", signalID)
+	}
+
+	if err := signal.AdjustLevel(target, level, value); err != nil {
+		m.mu.Unlock()
+		return nil, err
+	}
+
+	adjusted := target.Clone()
+	listeners := make([]func(*signal.Signal), len(m.adjustListeners))
+	copy(listeners, m.adjustListeners)
+	m.mu.Unlock()
+
+	log.Printf("Adjusted %s of signal %s to $%.2f", level, signalID, value)
+
+	if m.telegramBot != nil {
+		if err := m.telegramBot.UpdateSignal(adjusted); err != nil {
+			log.Printf("Error updating Telegram message for signal %s: %v", signalID, err)
+		}
+	}
+
+	for _, fn := range listeners {
+		fn(adjusted.Clone())
+	}
+
+	return adjusted, nil
+}
@@ -0,0 +1,189 @@
+package monitor
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/errkind"
+)
+
+// maxListedFailures caps how many failed symbols are named in logs and alerts
+const maxListedFailures = 10
+
+// CheckReport summarizes the market data fetches of one market check
+type CheckReport struct {
+	At          time.Time            `json:"at"`
+	Full        bool                 `json:"full"` // Scheduled check of due watchlists rather than a targeted one
+	Symbols     int                  `json:"symbols"`
+	Failed      int                  `json:"failed"`
+	FailureRate float64              `json:"failure_rate"` // Percent of symbols that failed
+	Errors      map[string]string    `json:"errors,omitempty"`
+	Kinds       map[errkind.Kind]int `json:"kinds,omitempty"` // Failed symbols by error kind
+	DurationMs  int64                `json:"duration_ms"`
+	Degraded    bool                 `json:"degraded"`           // Ran over its latency budget
+	Skipped     []string             `json:"skipped,omitempty"`  // Stages skipped, deferred or run in the background for the budget
+	Deferred    int                  `json:"deferred,omitempty"` // Symbols deferred to the next check
+}
+
+// SymbolFailures counts the failed fetches of one symbol across checks
+type SymbolFailures struct {
+	Symbol      string    `json:"symbol"`
+	Consecutive int       `json:"consecutive"` // Failures since the last successful fetch
+	Total       int       `json:"total"`
+	LastError   string    `json:"last_error"`
+	LastFailure time.Time `json:"last_failure"`
+}
+
+// CheckHealth is the latest check report and the symbols currently failing
+type CheckHealth struct {
+	LastCheck      *CheckReport         `json:"last_check,omitempty"`
+	Failing        []SymbolFailures     `json:"failing"`
+	DegradedChecks int                  `json:"degraded_checks"` // Checks over their latency budget since startup
+	Checks         int                  `json:"checks"`          // Checks since startup
+	FetchErrors    map[errkind.Kind]int `json:"fetch_errors"`    // Failed fetches since startup, by error kind
+	Explanations   ExplanationChecks    `json:"explanations"`    // LLM explanations corrected against the indicators since startup
+}
+
+// recordCheck tallies the fetch errors of a check, logs a partial-failure
+// summary and alerts when a scheduled check exceeds the error budget or a
+// data source rejects its credentials
+func (m *MarketMonitor) recordCheck(now time.Time, symbols []string, errs map[string]error, full bool) CheckReport {
+	report := CheckReport{At: now, Full: full, Symbols: len(symbols)}
+	if len(errs) > 0 {
+		report.Errors = make(map[string]string, len(errs))
+		report.Kinds = make(map[errkind.Kind]int)
+	}
+
+	m.mu.Lock()
+	for _, symbol := range symbols {
+		err, failed := errs[symbol]
+		failures, tracked := m.checkFailures[symbol]
+		if !failed {
+			if tracked {
+				failures.Consecutive = 0
+			}
+			continue
+		}
+		if !tracked {
+			failures = &SymbolFailures{Symbol: symbol}
+			m.checkFailures[symbol] = failures
+		}
+		failures.Consecutive++
+		failures.Total++
+		failures.LastError = err.Error()
+		failures.LastFailure = now
+		report.Errors[symbol] = err.Error()
+		report.Kinds[errkind.Of(err)]++
+		report.Failed++
+	}
+	m.checks++
+	for kind, count := range report.Kinds {
+		m.fetchErrors[kind] += count
+	}
+	if report.Symbols > 0 {
+		report.FailureRate = float64(report.Failed) / float64(report.Symbols) * 100
+	}
+	m.lastCheck = &report
+	budget := m.config.ErrorBudget
+	// Rejected credentials won't recover on their own, so they alert whatever the budget
+	overBudget := budget.MaxFailurePercent > 0 && report.Symbols >= budget.MinSymbols &&
+		report.FailureRate > budget.MaxFailurePercent
+	alert := full && (overBudget || report.Kinds[errkind.Auth] > 0) &&
+		now.Sub(m.lastBudgetAlert) >= time.Duration(budget.AlertCooldownMinutes)*time.Minute
+	if alert {
+		m.lastBudgetAlert = now
+	}
+	m.mu.Unlock()
+
+	if report.Failed > 0 {
+		log.Printf("Market check partially failed: %d/%d symbols (%.1f%%, %s): %s",
+			report.Failed, report.Symbols, report.FailureRate, formatKinds(report.Kinds), failedSymbols(report))
+	}
+	if alert {
+		if err := m.telegramBot.SendMessage(FormatErrorBudgetAlert(report, budget.MaxFailurePercent)); err != nil {
+			log.Printf("Error sending error budget alert: %v", err)
+		}
+	}
+	return report
+}
+
+// GetCheckHealth returns the latest check report and the symbols whose most
+// recent fetch failed, most consecutive failures first
+func (m *MarketMonitor) GetCheckHealth() CheckHealth {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	health := CheckHealth{Failing: []SymbolFailures{}, DegradedChecks: m.degradedChecks, Checks: m.checks,
+		FetchErrors: make(map[errkind.Kind]int, len(m.fetchErrors)), Explanations: m.explanationChecks}
+	for kind, count := range m.fetchErrors {
+		health.FetchErrors[kind] = count
+	}
+	if m.lastCheck != nil {
+		report := *m.lastCheck
+		report.Skipped = append([]string(nil), report.Skipped...)
+		health.LastCheck = &report
+	}
+	for _, failures := range m.checkFailures {
+		if failures.Consecutive > 0 {
+			health.Failing = append(health.Failing, *failures)
+		}
+	}
+	sort.Slice(health.Failing, func(i, j int) bool {
+		if health.Failing[i].Consecutive != health.Failing[j].Consecutive {
+			return health.Failing[i].Consecutive > health.Failing[j].Consecutive
+		}
+		return health.Failing[i].Symbol < health.Failing[j].Symbol
+	})
+	return health
+}
+
+// failedSymbols lists the failed symbols of a report with their errors
+func failedSymbols(report CheckReport) string {
+	symbols := make([]string, 0, len(report.Errors))
+	for symbol := range report.Errors {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+
+	listed := make([]string, 0, maxListedFailures)
+	for i, symbol := range symbols {
+		if i == maxListedFailures {
+			listed = append(listed, fmt.Sprintf("and %d more", len(symbols)-maxListedFailures))
+			break
+		}
+		listed = append(listed, fmt.Sprintf("%s (%s)", symbol, report.Errors[symbol]))
+	}
+	return strings.Join(listed, ", ")
+}
+
+// formatKinds lists failure counts by error kind, e.g. "rate_limited: 3, no_data: 1"
+func formatKinds(kinds map[errkind.Kind]int) string {
+	names := make([]string, 0, len(kinds))
+	for kind := range kinds {
+		names = append(names, string(kind))
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("%s: %d", name, kinds[errkind.Kind(name)]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// FormatErrorBudgetAlert formats an alert for a check exceeding the error budget
+func FormatErrorBudgetAlert(report CheckReport, maxPercent float64) string {
+	message := "⚠️ <b>MARKET DATA FAILURES</b>\n\n"
+	message += fmt.Sprintf("%d of %d symbols (%.1f%%) failed to fetch; the budget is %.0f%%.\n",
+		report.Failed, report.Symbols, report.FailureRate, maxPercent)
+	message += fmt.Sprintf("Causes: %s\n", formatKinds(report.Kinds))
+	if report.Kinds[errkind.Auth] > 0 {
+		message += "🔑 A data source rejected its credentials; check the API keys.\n"
+	}
+	message += fmt.Sprintf("Failed: %s\n", failedSymbols(report))
+	message += fmt.Sprintf("\n⏰ Checked at: %s", report.At.Format("2006-01-02 15:04:05"))
+	return message
+}
@@ -0,0 +1,121 @@
+package monitor
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/errkind"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newBudgetMonitor returns a monitor alerting when over 25% of at least 4 symbols fail
+func newBudgetMonitor() (*MarketMonitor, *MockTelegramBot) {
+	cfg := config.CreateDefaultConfig()
+	cfg.ErrorBudget = config.ErrorBudgetConfig{MaxFailurePercent: 25, MinSymbols: 4, AlertCooldownMinutes: 60}
+	telegramBot := &MockTelegramBot{}
+	return NewMarketMonitor(cfg, nil, nil, nil, telegramBot), telegramBot
+}
+
+func TestRecordCheckCountsFailures(t *testing.T) {
+	monitor, _ := newBudgetMonitor()
+	now := time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC)
+	symbols := []string{"AAPL", "MSFT", "NVDA", "TSLA", "AMD"}
+
+	report := monitor.recordCheck(now, symbols, map[string]error{
+		"TSLA": fmt.Errorf("quote failed: %w", errkind.ErrNoData),
+	}, false)
+	assert.Equal(t, 5, report.Symbols)
+	assert.Equal(t, 1, report.Failed)
+	assert.InDelta(t, 20.0, report.FailureRate, 0.001)
+	assert.Equal(t, map[errkind.Kind]int{errkind.NoData: 1}, report.Kinds)
+
+	// TSLA fails again, AMD starts failing
+	monitor.recordCheck(now.Add(time.Minute), symbols, map[string]error{
+		"TSLA": fmt.Errorf("quote failed: %w", errkind.ErrNoData),
+		"AMD":  fmt.Errorf("quote failed: %w", errkind.ErrRateLimited),
+	}, false)
+
+	health := monitor.GetCheckHealth()
+	assert.Equal(t, 2, health.Checks)
+	assert.Equal(t, map[errkind.Kind]int{errkind.NoData: 2, errkind.RateLimited: 1}, health.FetchErrors)
+	if assert.Len(t, health.Failing, 2) {
+		assert.Equal(t, "TSLA", health.Failing[0].Symbol)
+		assert.Equal(t, 2, health.Failing[0].Consecutive)
+		assert.Equal(t, "AMD", health.Failing[1].Symbol)
+	}
+
+	// A successful fetch resets the consecutive count but keeps the total
+	monitor.recordCheck(now.Add(2*time.Minute), symbols, nil, false)
+	health = monitor.GetCheckHealth()
+	assert.Empty(t, health.Failing)
+	assert.Equal(t, 2, monitor.checkFailures["TSLA"].Total)
+	assert.Equal(t, 0, health.LastCheck.Failed)
+}
+
+func TestRecordCheckAlertsOverBudget(t *testing.T) {
+	monitor, telegramBot := newBudgetMonitor()
+	telegramBot.On("SendMessage", mock.Anything).Return(nil)
+	now := time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC)
+	symbols := []string{"AAPL", "MSFT", "NVDA", "TSLA"}
+	errs := map[string]error{
+		"NVDA": errors.New("timeout"),
+		"TSLA": errors.New("timeout"),
+	}
+
+	// Targeted checks never alert
+	monitor.recordCheck(now, symbols, errs, false)
+	telegramBot.AssertNotCalled(t, "SendMessage", mock.Anything)
+
+	// Half the watchlist failing exceeds the budget
+	monitor.recordCheck(now, symbols, errs, true)
+	telegramBot.AssertNumberOfCalls(t, "SendMessage", 1)
+	message := telegramBot.Calls[0].Arguments.String(0)
+	assert.Contains(t, message, "2 of 4 symbols (50.0%) failed")
+	assert.Contains(t, message, "NVDA (timeout), TSLA (timeout)")
+
+	// The cooldown holds back the next alert
+	monitor.recordCheck(now.Add(30*time.Minute), symbols, errs, true)
+	telegramBot.AssertNumberOfCalls(t, "SendMessage", 1)
+	monitor.recordCheck(now.Add(61*time.Minute), symbols, errs, true)
+	telegramBot.AssertNumberOfCalls(t, "SendMessage", 2)
+}
+
+func TestRecordCheckWithinBudget(t *testing.T) {
+	monitor, telegramBot := newBudgetMonitor()
+	now := time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC)
+
+	// One of five is within the budget, and three symbols are too few to judge
+	monitor.recordCheck(now, []string{"AAPL", "MSFT", "NVDA", "TSLA", "AMD"}, map[string]error{"AMD": errors.New("timeout")}, true)
+	monitor.recordCheck(now, []string{"AAPL", "MSFT", "NVDA"}, map[string]error{"AAPL": errors.New("timeout"), "MSFT": errors.New("timeout")}, true)
+	telegramBot.AssertNotCalled(t, "SendMessage", mock.Anything)
+}
+
+func TestRecordCheckAlertsOnRejectedCredentials(t *testing.T) {
+	monitor, telegramBot := newBudgetMonitor()
+	telegramBot.On("SendMessage", mock.Anything).Return(nil)
+	now := time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC)
+
+	// A single rejected key alerts whatever the budget
+	monitor.recordCheck(now, []string{"AAPL", "MSFT", "NVDA", "TSLA", "AMD"}, map[string]error{
+		"AMD": fmt.Errorf("quote failed: %w", errkind.ErrAuth),
+	}, true)
+	telegramBot.AssertNumberOfCalls(t, "SendMessage", 1)
+	assert.Contains(t, telegramBot.Calls[0].Arguments.String(0), "rejected its credentials")
+}
+
+func TestFailedSymbolsCapsList(t *testing.T) {
+	report := CheckReport{Errors: map[string]string{}}
+	for i := 0; i < maxListedFailures+3; i++ {
+		report.Errors[fmt.Sprintf("S%02d", i)] = "timeout"
+	}
+
+	listed := failedSymbols(report)
+	assert.Contains(t, listed, "S00 (timeout), S01 (timeout)")
+	assert.NotContains(t, listed, "S10")
+	assert.Contains(t, listed, "and 3 more")
+	assert.Equal(t, "no_data: 1, rate_limited: 3", formatKinds(map[errkind.Kind]int{errkind.RateLimited: 3, errkind.NoData: 1}))
+}
@@ -0,0 +1,110 @@
+package monitor
+
+import (
+	"log"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// corporateActionLookback is how far back each daily refresh looks for ex-dates,
+// covering days the monitor was not running
+const corporateActionLookback = 7 * 24 * time.Hour
+
+// CorporateActionSource provides splits and dividends (implemented by data.Provider)
+type CorporateActionSource interface {
+	CorporateActions(symbol string, since time.Time) ([]data.CorporateAction, error)
+}
+
+// SetCorporateActionSource sets where splits and dividends are fetched from
+// once per day to adjust active signals
+func (m *MarketMonitor) SetCorporateActionSource(source CorporateActionSource) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.corporateActions = source
+}
+
+// OnCorporateAction registers a callback invoked for every split or dividend
+// applied, e.g. to adjust open positions in a TradeManager
+func (m *MarketMonitor) OnCorporateAction(fn func(data.CorporateAction)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.actionListeners = append(m.actionListeners, fn)
+}
+
+// refreshCorporateActions applies the corporate actions of every watched
+// symbol that took effect since the lookback, once per day
+func (m *MarketMonitor) refreshCorporateActions(now time.Time) {
+	day := now.Format("2006-01-02")
+	m.mu.Lock()
+	source := m.corporateActions
+	due := source != nil && m.actionsDay != day
+	if due {
+		m.actionsDay = day
+	}
+	watchlists := m.config.GetWatchlists()
+	m.mu.Unlock()
+	if !due {
+		return
+	}
+	symbols := watchlistSymbols(m.expandWatchlists(watchlists))
+
+	for _, symbol := range symbols {
+		actions, err := source.CorporateActions(symbol, now.Add(-corporateActionLookback))
+		if err != nil {
+			log.Printf("Error fetching corporate actions for %s: %v", symbol, err)
+			continue
+		}
+		for _, action := range actions {
+			if !action.ExDate.After(now) {
+				m.ApplyCorporateAction(action)
+			}
+		}
+	}
+}
+
+// ApplyCorporateAction adjusts the entry, target and stop of active signals in
+// the action's symbol generated before its ex-date, edits their Telegram
+// messages and notifies listeners. Each action is only applied once.
+func (m *MarketMonitor) ApplyCorporateAction(action data.CorporateAction) []*signal.Signal {
+	m.mu.Lock()
+	if m.appliedActions[action.Key()] {
+		m.mu.Unlock()
+		return nil
+	}
+	m.appliedActions[action.Key()] = true
+
+	var adjusted []*signal.Signal
+	for _, s := range m.signalHistory {
+		if s.Symbol != action.Symbol || s.Status != signal.StatusActive || !s.GeneratedAt.Before(action.ExDate) {
+			continue
+		}
+		s.Price = action.AdjustPrice(s.Price)
+		s.TargetPrice = action.AdjustPrice(s.TargetPrice)
+		s.StopLoss = action.AdjustPrice(s.StopLoss)
+		adjusted = append(adjusted, s.Clone())
+	}
+	adjustListeners := make([]func(*signal.Signal), len(m.adjustListeners))
+	copy(adjustListeners, m.adjustListeners)
+	actionListeners := make([]func(data.CorporateAction), len(m.actionListeners))
+	copy(actionListeners, m.actionListeners)
+	m.mu.Unlock()
+
+	log.Printf("Applying %s of %s (ex-date %s) to %d active signals", action.Describe(), action.Symbol, action.ExDate.Format("2006-01-02"), len(adjusted))
+
+	for _, s := range adjusted {
+		if m.telegramBot != nil {
+			if err := m.telegramBot.UpdateSignal(s); err != nil {
+				log.Printf("Error updating Telegram message for signal %s: %v", s.ID, err)
+			}
+		}
+		for _, fn := range adjustListeners {
+			fn(s.Clone())
+		}
+	}
+	for _, fn := range actionListeners {
+		fn(action)
+	}
+	return adjusted
+}
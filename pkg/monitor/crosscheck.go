@@ -0,0 +1,41 @@
+package monitor
+
+import (
+	"log"
+
+	"github.com/hustler/trading-bot/pkg/llm"
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// ExplanationChecks counts the LLM explanations cross-checked against their
+// signal's indicators since startup
+type ExplanationChecks struct {
+	Checked        int     `json:"checked"`
+	Corrected      int     `json:"corrected"`       // Explanations with at least one contradiction corrected
+	Corrections    int     `json:"corrections"`     // Contradictions corrected across all explanations
+	CorrectionRate float64 `json:"correction_rate"` // Corrected over checked
+}
+
+// crossCheckExplanation corrects the claims in a signal's explanation that
+// contradict its indicators before it is published, and counts them
+func (m *MarketMonitor) crossCheckExplanation(s *signal.Signal, explanation string) string {
+	m.mu.RLock()
+	params := m.config.VolatilityParams
+	m.mu.RUnlock()
+
+	corrected, contradictions := llm.CrossCheck(explanation, s.TechnicalData, params)
+	for _, c := range contradictions {
+		log.Printf("Corrected explanation of signal %s: %q contradicts %s %.2f, now %q", s.ID, c.Claim, c.Indicator, c.Actual, c.Correction)
+	}
+
+	m.mu.Lock()
+	m.explanationChecks.Checked++
+	if len(contradictions) > 0 {
+		m.explanationChecks.Corrected++
+		m.explanationChecks.Corrections += len(contradictions)
+	}
+	m.explanationChecks.CorrectionRate = float64(m.explanationChecks.Corrected) / float64(m.explanationChecks.Checked)
+	m.mu.Unlock()
+
+	return corrected
+}
@@ -0,0 +1,106 @@
+package monitor
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// economicRefreshInterval is how often the economic calendar feed is re-fetched
+const economicRefreshInterval = 6 * time.Hour
+
+// economicState caches the economic calendar and the events already announced
+type economicState struct {
+	events    []data.EconomicEvent
+	fetchedAt time.Time
+	announced map[string]bool
+}
+
+// checkEconomicCalendar returns the high-impact event whose window contains now,
+// refreshing the calendar when due and announcing each window once
+func (m *MarketMonitor) checkEconomicCalendar(now time.Time) (data.EconomicEvent, bool) {
+	m.mu.RLock()
+	cfg := m.config.EconomicCalendar
+	m.mu.RUnlock()
+
+	if !cfg.Enabled {
+		return data.EconomicEvent{}, false
+	}
+
+	m.mu.Lock()
+	if m.economic.announced == nil {
+		m.economic.announced = make(map[string]bool)
+	}
+	due := now.Sub(m.economic.fetchedAt) >= economicRefreshInterval
+	m.mu.Unlock()
+
+	if due {
+		events, err := data.LoadEconomicCalendar(cfg)
+		if err != nil {
+			log.Printf("Error loading economic calendar: %v", err)
+		}
+		m.mu.Lock()
+		// Keep the previous events if the feed failed so a known window is not lost
+		if err == nil {
+			m.economic.events = events
+		}
+		m.economic.fetchedAt = now
+		m.mu.Unlock()
+	}
+
+	m.mu.Lock()
+	event, active := data.ActiveEconomicEvent(m.economic.events, now,
+		time.Duration(cfg.WindowBeforeMinutes)*time.Minute, time.Duration(cfg.WindowAfterMinutes)*time.Minute)
+	announce := active && cfg.Announce && !m.economic.announced[event.Key()]
+	if announce {
+		m.economic.announced[event.Key()] = true
+	}
+	m.mu.Unlock()
+
+	if announce {
+		if err := m.telegramBot.SendMessage(formatEconomicNotice(event, cfg, now)); err != nil {
+			log.Printf("Error announcing economic event: %v", err)
+		}
+	}
+
+	return event, active
+}
+
+// filterForEconomicEvent applies the configured action during an event window: pause
+// drops every signal, raise drops signals below the boosted confidence threshold
+func filterForEconomicEvent(signals []*signal.Signal, cfg config.EconomicCalendarConfig, threshold float64) []*signal.Signal {
+	if cfg.Action != config.EventRaise {
+		return nil
+	}
+
+	minConfidence := threshold + cfg.ConfidenceBoost
+	kept := signals[:0]
+	for _, s := range signals {
+		if s.Confidence >= minConfidence {
+			kept = append(kept, s)
+		}
+	}
+	return kept
+}
+
+// formatEconomicNotice formats the channel announcement for an event window
+func formatEconomicNotice(event data.EconomicEvent, cfg config.EconomicCalendarConfig, now time.Time) string {
+	when := "in " + event.Time.Sub(now).Round(time.Minute).String()
+	if !now.Before(event.Time) {
+		when = "just released"
+	}
+
+	action := "Signals are paused"
+	if cfg.Action == config.EventRaise {
+		action = fmt.Sprintf("Only signals with confidence above the usual threshold +%.0f%% will be sent", cfg.ConfidenceBoost*100)
+	}
+
+	return fmt.Sprintf("📅 <b>%s %s</b> (%s impact) %s\n%s until %s.",
+		html.EscapeString(event.Country), html.EscapeString(event.Title), html.EscapeString(event.Impact), when,
+		action, event.Time.Add(time.Duration(cfg.WindowAfterMinutes)*time.Minute).Format("15:04 MST"))
+}
@@ -0,0 +1,190 @@
+package monitor
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/execution"
+)
+
+// eodCheckInterval is how often the EOD job checks whether the close is near
+const eodCheckInterval = 30 * time.Second
+
+// QuoteSource provides the latest quotes (implemented by data.MarketWatcher)
+type QuoteSource interface {
+	GetAllStocks() []*data.Stock
+}
+
+// MessageSender posts messages to the signal channel (implemented by telegram.Bot)
+type MessageSender interface {
+	SendMessage(message string) error
+}
+
+// OutcomeRecorder records signal outcomes when positions are closed (implemented by performance.Monitor)
+type OutcomeRecorder interface {
+	CloseSignalsAtMarket(symbol string, exitPrice float64) int
+}
+
+// EODJob flattens all positions shortly before the close, posts a closing summary
+// and records the outcomes
+type EODJob struct {
+	risk       *RiskManager
+	trades     *execution.TradeManager
+	quotes     QuoteSource
+	sender     MessageSender
+	outcomes   OutcomeRecorder
+	clock      clock.Clock
+	lastRunDay string
+	mu         sync.Mutex
+}
+
+// NewEODJob creates a new end-of-day flatten job
+func NewEODJob(risk *RiskManager, trades *execution.TradeManager, quotes QuoteSource) *EODJob {
+	return &EODJob{
+		risk:   risk,
+		trades: trades,
+		quotes: quotes,
+		clock:  clock.Real{},
+	}
+}
+
+// SetClock sets the clock used to decide which day has been flattened
+func (j *EODJob) SetClock(c clock.Clock) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.clock = c
+}
+
+// SetSender sets where the closing summary is posted
+func (j *EODJob) SetSender(sender MessageSender) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.sender = sender
+}
+
+// SetOutcomeRecorder sets where outcomes of flattened positions are recorded
+func (j *EODJob) SetOutcomeRecorder(recorder OutcomeRecorder) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.outcomes = recorder
+}
+
+// Run checks for the end of day until stop is closed
+func (j *EODJob) Run(stop <-chan struct{}) {
+	ticker := time.NewTicker(eodCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			j.RunIfDue()
+		}
+	}
+}
+
+// RunIfDue flattens positions once per day when the risk manager says the close is near
+func (j *EODJob) RunIfDue() bool {
+	if !j.risk.ShouldCloseAllPositions() {
+		return false
+	}
+
+	j.mu.Lock()
+	today := j.risk.MarketClock().DayKey(j.clock.Now())
+	if j.lastRunDay == today {
+		j.mu.Unlock()
+		return false
+	}
+	j.lastRunDay = today
+	j.mu.Unlock()
+
+	j.Flatten()
+	return true
+}
+
+// Flatten closes positions at the latest quotes, updates daily P&L, records
+// signal outcomes and posts a summary. In swing mode positions allowed by the
+// carry rules are held overnight instead. It returns the closing trades.
+func (j *EODJob) Flatten() []*execution.Trade {
+	stocks := make(map[string]*data.Stock)
+	for _, stock := range j.quotes.GetAllStocks() {
+		stocks[stock.Symbol] = stock
+	}
+
+	var toClose []string
+	var carried []*execution.Trade
+	reasons := make(map[string]string)
+	for _, decision := range j.risk.PlanOvernightCarry(stocks, j.now()) {
+		if decision.Carry {
+			carried = append(carried, decision.Trade)
+			continue
+		}
+		toClose = append(toClose, decision.Trade.ID)
+		reasons[decision.Trade.ID] = decision.Reason
+	}
+
+	open := len(toClose)
+	closed := j.trades.ClosePositions(toClose, stocks, "End of trading day - closing positions")
+	j.risk.MarkOvernight(carried, stocks)
+
+	j.mu.Lock()
+	sender, outcomes := j.sender, j.outcomes
+	j.mu.Unlock()
+
+	var totalPnL float64
+	summary := fmt.Sprintf("🔔 <b>End of Day</b>: closed %d of %d positions\n\n", len(closed), open)
+	for _, sell := range closed {
+		buy, ok := j.trades.GetTrade(sell.PositionID)
+		if ok {
+			j.risk.UpdateDailyPnL(buy, sell)
+			pnl := float64(sell.Quantity) * (sell.Price - buy.Price)
+			totalPnL += pnl
+			summary += fmt.Sprintf("%s: %d @ $%.2f (P&L $%.2f)", sell.Symbol, sell.Quantity, sell.Price, pnl)
+			if reason := reasons[buy.ID]; reason != "" && j.risk.IsSwingMode() {
+				summary += " - " + reason
+			}
+			summary += "\n"
+		} else {
+			summary += fmt.Sprintf("%s: %d @ $%.2f\n", sell.Symbol, sell.Quantity, sell.Price)
+		}
+
+		if outcomes != nil {
+			outcomes.CloseSignalsAtMarket(sell.Symbol, sell.Price)
+		}
+	}
+	summary += fmt.Sprintf("\nTotal P&L: $%.2f\nDaily P&L: $%.2f", totalPnL, j.risk.GetDailyPnL())
+
+	if len(carried) > 0 {
+		summary += fmt.Sprintf("\n\n🌙 Holding %d positions overnight:\n", len(carried))
+		for _, trade := range carried {
+			summary += fmt.Sprintf("%s: %d @ $%.2f\n", trade.Symbol, trade.Quantity, trade.Price)
+		}
+	}
+
+	if remaining := open - len(closed); remaining > 0 {
+		summary += fmt.Sprintf("\n⚠️ %d positions could not be closed", remaining)
+		log.Printf("EOD flatten left %d positions open", remaining)
+	}
+
+	log.Printf("EOD flatten closed %d positions, carried %d, P&L $%.2f", len(closed), len(carried), totalPnL)
+
+	if sender != nil && open+len(carried) > 0 {
+		if err := sender.SendMessage(summary); err != nil {
+			log.Printf("Error sending EOD summary: %v", err)
+		}
+	}
+
+	return closed
+}
+
+// now returns the current time from the job's clock
+func (j *EODJob) now() time.Time {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.clock.Now()
+}
@@ -0,0 +1,62 @@
+package monitor
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// asyncExplainTimeout bounds a background explanation of a signal
+const asyncExplainTimeout = 2 * time.Minute
+
+// explanationSender appends a late explanation to the messages of a sent
+// signal (implemented by telegram.Bot)
+type explanationSender interface {
+	SendSignalExplanation(s *signal.Signal) error
+}
+
+// asyncExplanation reports whether signals are published before the LLM
+// explains them
+func (m *MarketMonitor) asyncExplanation() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.config.LLM.AsyncExplanation
+}
+
+// explainLater writes the explanation of a signal already sent with the
+// generator's rationale, then updates the sent messages and the store
+func (m *MarketMonitor) explainLater(s *signal.Signal) {
+	ctx, cancel := context.WithTimeout(context.Background(), asyncExplainTimeout)
+	defer cancel()
+
+	// The signal is in the history by now and may be adjusted or resolved
+	// meanwhile, so work on copies taken under the lock
+	m.mu.RLock()
+	snapshot := s.Clone()
+	m.mu.RUnlock()
+
+	explanation, err := m.llmManager.GenerateSignalExplanation(ctx, snapshot)
+	if err != nil {
+		log.Printf("Error generating background explanation for signal %s: %v", snapshot.ID, err)
+		return
+	}
+	m.recordExplanation(snapshot, explanation)
+	explanation = m.crossCheckExplanation(snapshot, explanation)
+
+	m.mu.Lock()
+	s.Rationale = explanation
+	snapshot = s.Clone()
+	m.mu.Unlock()
+
+	if sender, ok := m.telegramBot.(explanationSender); ok {
+		err = sender.SendSignalExplanation(snapshot)
+	} else {
+		err = m.telegramBot.UpdateSignal(snapshot)
+	}
+	if err != nil {
+		log.Printf("Error updating signal %s with its explanation: %v", snapshot.ID, err)
+	}
+	m.saveSignal(snapshot)
+}
@@ -0,0 +1,66 @@
+package monitor
+
+import (
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// FollowerSimulator tracks what a subscriber acting on each signal would make
+// (implemented by follower.Simulator)
+type FollowerSimulator interface {
+	Follow(s *signal.Signal)
+	Observe(marketData map[string]*data.MarketData, now time.Time)
+}
+
+// SetFollowerSimulator sets the simulator that follows every published signal.
+// Register its Close method with OnSignalClosed so followers exit with the signal.
+func (m *MarketMonitor) SetFollowerSimulator(sim FollowerSimulator) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.follower = sim
+}
+
+// observeFollowers lets simulated followers enter at the latest prices
+func (m *MarketMonitor) observeFollowers(marketData map[string]*data.MarketData, now time.Time) {
+	m.mu.RLock()
+	sim := m.follower
+	m.mu.RUnlock()
+
+	if sim != nil {
+		sim.Observe(marketData, now)
+	}
+}
+
+// followSignal starts a simulated follower on a published signal
+func (m *MarketMonitor) followSignal(s *signal.Signal) {
+	m.mu.RLock()
+	sim := m.follower
+	m.mu.RUnlock()
+
+	if sim != nil {
+		sim.Follow(s)
+	}
+}
+
+// OnMarketData registers a callback invoked with the market data of every
+// check, after simulated followers enter, e.g. to measure slippage
+func (m *MarketMonitor) OnMarketData(fn func(marketData map[string]*data.MarketData, now time.Time)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.dataListeners = append(m.dataListeners, fn)
+}
+
+// notifyMarketData passes the market data of a check to the data listeners
+func (m *MarketMonitor) notifyMarketData(marketData map[string]*data.MarketData, now time.Time) {
+	m.mu.RLock()
+	listeners := make([]func(map[string]*data.MarketData, time.Time), len(m.dataListeners))
+	copy(listeners, m.dataListeners)
+	m.mu.RUnlock()
+
+	for _, fn := range listeners {
+		fn(marketData, now)
+	}
+}
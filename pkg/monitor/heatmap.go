@@ -0,0 +1,86 @@
+package monitor
+
+import (
+	"sort"
+
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/indicators"
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// relativeVolumePeriod is the number of bars the latest volume is compared against
+const relativeVolumePeriod = 10
+
+// HeatmapCell represents one symbol on the market heatmap
+type HeatmapCell struct {
+	Symbol         string            `json:"symbol"`
+	Price          float64           `json:"price"`
+	ChangePercent  float64           `json:"change_percent"`  // Versus the session's first price
+	RelativeVolume float64           `json:"relative_volume"` // Latest bar volume as % of the recent average
+	SignalID       string            `json:"signal_id,omitempty"`
+	SignalType     signal.SignalType `json:"signal_type,omitempty"`
+	SignalStatus   string            `json:"signal_status,omitempty"`
+}
+
+// buildHeatmap computes heatmap cells from each symbol's session data
+func buildHeatmap(marketData map[string]*data.MarketData) map[string]HeatmapCell {
+	cells := make(map[string]HeatmapCell, len(marketData))
+	for symbol, md := range marketData {
+		if md == nil || len(md.Prices) == 0 {
+			continue
+		}
+
+		open := md.Prices[0]
+		last := md.Prices[len(md.Prices)-1]
+		cell := HeatmapCell{Symbol: symbol, Price: last}
+		if open > 0 {
+			cell.ChangePercent = (last - open) / open * 100
+		}
+
+		period := relativeVolumePeriod
+		if len(md.Volumes) < period {
+			period = len(md.Volumes)
+		}
+		if avg := indicators.SimpleAverage(md.Volumes, period); avg > 0 {
+			cell.RelativeVolume = md.Volumes[len(md.Volumes)-1] / avg * 100
+		}
+
+		cells[symbol] = cell
+	}
+	return cells
+}
+
+// GetHeatmap returns the heatmap for the watchlist, sorted by symbol, with each
+// symbol's most recent active signal attached
+func (m *MarketMonitor) GetHeatmap() []HeatmapCell {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cells := make(map[string]HeatmapCell, len(m.heatmap))
+	for symbol, cell := range m.heatmap {
+		cells[symbol] = cell
+	}
+
+	// History is in generation order, so later signals overwrite earlier ones
+	for _, s := range m.signalHistory {
+		if s.Status != signal.StatusActive {
+			continue
+		}
+		cell, ok := cells[s.Symbol]
+		if !ok {
+			cell = HeatmapCell{Symbol: s.Symbol, Price: s.Price}
+		}
+		cell.SignalID = s.ID
+		cell.SignalType = s.Type
+		cell.SignalStatus = string(s.Status)
+		cells[s.Symbol] = cell
+	}
+
+	result := make([]HeatmapCell, 0, len(cells))
+	for _, cell := range cells {
+		result = append(result, cell)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Symbol < result[j].Symbol })
+
+	return result
+}
@@ -0,0 +1,65 @@
+package monitor
+
+import (
+	"testing"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/signal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildHeatmap(t *testing.T) {
+	volumes := make([]float64, 12)
+	for i := range volumes {
+		volumes[i] = 1000
+	}
+	volumes[11] = 3000 // Averaged over the last 10 bars: 1200
+
+	cells := buildHeatmap(map[string]*data.MarketData{
+		"AAPL": {Prices: []float64{100, 101, 105}, Volumes: volumes},
+		"MSFT": {Prices: []float64{200, 190}, Volumes: []float64{500, 1500}},
+		"FREE": {Prices: []float64{0, 5}},
+		"NONE": {},
+		"NIL":  nil,
+	})
+
+	assert.Len(t, cells, 3)
+	assert.InDelta(t, 5.0, cells["AAPL"].ChangePercent, 0.001)
+	assert.Equal(t, 105.0, cells["AAPL"].Price)
+	assert.InDelta(t, 250.0, cells["AAPL"].RelativeVolume, 0.001)
+	assert.InDelta(t, -5.0, cells["MSFT"].ChangePercent, 0.001)
+	assert.InDelta(t, 150.0, cells["MSFT"].RelativeVolume, 0.001)
+
+	// A zero opening price or missing volumes leave the change and volume unset
+	assert.Equal(t, HeatmapCell{Symbol: "FREE", Price: 5}, cells["FREE"])
+
+	assert.Empty(t, buildHeatmap(nil))
+	assert.Empty(t, buildHeatmap(map[string]*data.MarketData{}))
+}
+
+func TestGetHeatmapSortsAndAttachesSignals(t *testing.T) {
+	monitor := NewMarketMonitor(config.CreateDefaultConfig(), nil, nil, nil, nil)
+	assert.Empty(t, monitor.GetHeatmap())
+
+	monitor.heatmap = buildHeatmap(map[string]*data.MarketData{
+		"MSFT": {Prices: []float64{200, 210}},
+		"AAPL": {Prices: []float64{100, 99}},
+	})
+	monitor.signalHistory = []*signal.Signal{
+		{ID: "SIG-1", Symbol: "AAPL", Type: signal.BUY, Status: signal.StatusActive},
+		{ID: "SIG-2", Symbol: "AAPL", Type: signal.SELL, Status: signal.StatusActive},
+		{ID: "SIG-3", Symbol: "MSFT", Type: signal.BUY, Status: signal.StatusSuccess},
+		{ID: "SIG-4", Symbol: "TSLA", Type: signal.BUY, Status: signal.StatusActive, Price: 250},
+	}
+
+	heatmap := monitor.GetHeatmap()
+	assert.Equal(t, []string{"AAPL", "MSFT", "TSLA"}, []string{heatmap[0].Symbol, heatmap[1].Symbol, heatmap[2].Symbol})
+
+	// The latest active signal wins, resolved signals are not attached
+	assert.Equal(t, "SIG-2", heatmap[0].SignalID)
+	assert.Equal(t, signal.SELL, heatmap[0].SignalType)
+	assert.Empty(t, heatmap[1].SignalID)
+	assert.Equal(t, 250.0, heatmap[2].Price)
+	assert.Equal(t, string(signal.StatusActive), heatmap[2].SignalStatus)
+}
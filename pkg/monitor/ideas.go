@@ -0,0 +1,151 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/ids"
+	"github.com/hustler/trading-bot/pkg/llm"
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// IdeaSignalPrefix starts the ID of every signal tracked from a trade idea
+const IdeaSignalPrefix = "IDEA"
+
+// maxIdeas is how many assessed ideas are kept for tracking
+const maxIdeas = 50
+
+// ideaReviewTimeout bounds how long the LLM may take to vet an idea
+const ideaReviewTimeout = 30 * time.Second
+
+// ideaAdvisor vets trade ideas (implemented by llm.Manager)
+type ideaAdvisor interface {
+	ReviewIdea(ctx context.Context, review llm.IdeaReview) (string, error)
+}
+
+// AssessIdea fetches the symbol's market data, runs the indicators and
+// strategies on it and has the LLM advisor vet the thesis. side is BUY or
+// SELL if the idea takes one, or empty to follow the strategies. The idea is
+// kept so TrackIdea can turn it into a signal.
+func (m *MarketMonitor) AssessIdea(ctx context.Context, symbol string, side signal.SignalType, thesis, submittedBy string) (*signal.Idea, error) {
+	results, errs := m.dataProvider.GetMarketDataBatch([]string{symbol})
+	if err := errs[symbol]; err != nil {
+		return nil, fmt.Errorf("failed to get market data for %s: %w", symbol, err)
+	}
+	md := results[symbol]
+	if md == nil || len(md.Prices) == 0 {
+		return nil, fmt.Errorf("no market data for %s", symbol)
+	}
+
+	evaluation, err := m.signalGen.Evaluate(symbol, signal.MarketData{
+		Symbol:          symbol,
+		Prices:          md.Prices,
+		Volumes:         md.Volumes,
+		Timestamps:      md.Timestamps,
+		Exchange:        md.Exchange,
+		Halted:          md.Halted,
+		ShortRestricted: md.ShortRestricted,
+	}, side)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate %s: %w", symbol, err)
+	}
+
+	now := m.clock.Now()
+	idea := &signal.Idea{
+		ID:          ids.New("IDEA-"+symbol, now),
+		Symbol:      symbol,
+		Thesis:      thesis,
+		SubmittedBy: submittedBy,
+		SubmittedAt: now,
+		Evaluation:  evaluation,
+	}
+
+	review := llm.IdeaReview{Thesis: thesis, Evaluation: evaluation}
+	if advisor, ok := m.llmManager.(ideaAdvisor); ok {
+		reviewCtx, cancel := context.WithTimeout(ctx, ideaReviewTimeout)
+		idea.Review, err = advisor.ReviewIdea(reviewCtx, review)
+		cancel()
+		if err != nil {
+			log.Printf("Error reviewing trade idea for %s: %v", symbol, err)
+		}
+	}
+
+	m.mu.Lock()
+	m.ideas[idea.ID] = idea
+	m.pruneIdeas()
+	m.mu.Unlock()
+
+	copied := *idea
+	return &copied, nil
+}
+
+// TrackIdea turns an assessed idea into a signal on its side and sends it
+// through the real pipeline, so its outcome is tracked like any other signal
+func (m *MarketMonitor) TrackIdea(id string) (*signal.Signal, error) {
+	m.mu.Lock()
+	idea, ok := m.ideas[id]
+	if !ok {
+		m.mu.Unlock()
+		return nil, fmt.Errorf("no trade idea %s", id)
+	}
+	if idea.SignalID != "" {
+		m.mu.Unlock()
+		return nil, fmt.Errorf("trade idea %s is already tracked as %s", id, idea.SignalID)
+	}
+	evaluation, thesis := idea.Evaluation, idea.Thesis
+	m.mu.Unlock()
+
+	rationale := "Trade idea from an admin."
+	if thesis != "" {
+		rationale = "Trade idea from an admin: " + thesis
+	}
+	now := m.clock.Now()
+	s, err := evaluation.Signal(now, IdeaSignalPrefix, rationale)
+	if err != nil {
+		return nil, fmt.Errorf("failed to track trade idea %s: %w", id, err)
+	}
+
+	m.mu.Lock()
+	idea.SignalID = s.ID
+	m.mu.Unlock()
+
+	err = m.processSignal(context.Background(), s, now, nil)
+
+	// The tracked signal is now the monitor's to update; return a copy
+	m.mu.RLock()
+	tracked := s.Clone()
+	m.mu.RUnlock()
+	if err != nil {
+		return tracked, fmt.Errorf("failed to deliver trade idea signal: %w", err)
+	}
+	return tracked, nil
+}
+
+// Ideas returns the assessed ideas, most recent first
+func (m *MarketMonitor) Ideas() []signal.Idea {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ideas := make([]signal.Idea, 0, len(m.ideas))
+	for _, idea := range m.ideas {
+		ideas = append(ideas, *idea)
+	}
+	sort.Slice(ideas, func(i, j int) bool { return ideas[i].SubmittedAt.After(ideas[j].SubmittedAt) })
+	return ideas
+}
+
+// pruneIdeas drops the oldest ideas beyond maxIdeas; m.mu must be held
+func (m *MarketMonitor) pruneIdeas() {
+	for len(m.ideas) > maxIdeas {
+		var oldest *signal.Idea
+		for _, idea := range m.ideas {
+			if oldest == nil || idea.SubmittedAt.Before(oldest.SubmittedAt) {
+				oldest = idea
+			}
+		}
+		delete(m.ideas, oldest.ID)
+	}
+}
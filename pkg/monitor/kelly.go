@@ -0,0 +1,49 @@
+package monitor
+
+import (
+	"math"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/performance"
+)
+
+// PayoffSource provides the win rate and payoff ratio of recent closed signals
+// (implemented by performance.Monitor)
+type PayoffSource interface {
+	PayoffStats(window int) performance.PayoffStats
+}
+
+// SetSizing sets the position sizing mode. In kelly mode positions get a Kelly
+// fraction of the capital per stock, learned from history, on top of the
+// volatility scaling; until history has enough closed signals they are sized
+// as in fixed mode.
+func (r *RiskManager) SetSizing(sizing config.SizingConfig, history PayoffSource) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sizing = sizing
+	r.payoffs = history
+}
+
+// KellyFraction returns the fraction of the capital per stock positions get,
+// and false when positions are not sized by the Kelly criterion. The fraction
+// is the configured multiple of full Kelly, capped at the max fraction, and 0
+// when recent history shows no edge.
+func (r *RiskManager) KellyFraction() (float64, bool) {
+	r.mu.RLock()
+	sizing := r.sizing
+	history := r.payoffs
+	r.mu.RUnlock()
+
+	if !sizing.IsKelly() || history == nil {
+		return 0, false
+	}
+
+	stats := history.PayoffStats(sizing.KellyWindow)
+	if stats.Trades < sizing.KellyMinTrades || stats.Trades == 0 {
+		return 0, false
+	}
+
+	fraction := stats.Kelly() * sizing.KellyMultiplier
+	return math.Max(0, math.Min(fraction, sizing.MaxFraction)), true
+}
@@ -0,0 +1,94 @@
+package monitor
+
+import (
+	"log"
+	"strings"
+	"time"
+)
+
+// Check stages a check over its latency budget skips or runs in the background
+const (
+	stageLiquidity   = "liquidity"
+	stageSeasonality = "seasonality"
+	stageGenerate    = "generate"
+	stageExplain     = "llm.explain"
+	stageSimilar     = "similar_setups"
+	stagePairs       = "pairs"
+)
+
+// checkBudget tracks the wall-clock time of a market check against its
+// latency budget. A nil budget is never exceeded.
+type checkBudget struct {
+	start    time.Time
+	deadline time.Time // Zero without a budget
+	now      func() time.Time
+	skipped  []string // Stages skipped, deferred or moved to the background, in order
+	deferred int      // Symbols deferred to the next check
+}
+
+// startBudget starts tracking a check against the configured latency budget
+func (m *MarketMonitor) startBudget() *checkBudget {
+	m.mu.RLock()
+	now := m.clock.Now
+	maxSeconds := m.config.LatencyBudget.MaxCheckSeconds
+	m.mu.RUnlock()
+
+	budget := &checkBudget{start: now(), now: now}
+	if maxSeconds > 0 {
+		budget.deadline = budget.start.Add(time.Duration(maxSeconds * float64(time.Second)))
+	}
+	return budget
+}
+
+// exceeded reports whether the check has run past its budget
+func (b *checkBudget) exceeded() bool {
+	return b != nil && !b.deadline.IsZero() && b.now().After(b.deadline)
+}
+
+// skip reports whether a stage must be skipped because the check is over its
+// budget, and records it if so
+func (b *checkBudget) skip(stage string) bool {
+	if !b.exceeded() {
+		return false
+	}
+	for _, skipped := range b.skipped {
+		if skipped == stage {
+			return true
+		}
+	}
+	b.skipped = append(b.skipped, stage)
+	return true
+}
+
+// deferWatchlist marks a watchlist unchecked so it is due again on the next
+// check, and counts its symbols as deferred
+func (m *MarketMonitor) deferWatchlist(budget *checkBudget, name string, symbols int) {
+	m.mu.Lock()
+	delete(m.lastChecked, name)
+	m.mu.Unlock()
+	budget.deferred += symbols
+}
+
+// finishBudget records how long a check took and whether it was degraded in
+// its check report
+func (m *MarketMonitor) finishBudget(budget *checkBudget) {
+	elapsed := budget.now().Sub(budget.start)
+	degraded := len(budget.skipped) > 0
+
+	m.mu.Lock()
+	if m.lastCheck != nil {
+		m.lastCheck.DurationMs = elapsed.Milliseconds()
+		m.lastCheck.Degraded = degraded
+		m.lastCheck.Skipped = budget.skipped
+		m.lastCheck.Deferred = budget.deferred
+	}
+	if degraded {
+		m.degradedChecks++
+	}
+	m.mu.Unlock()
+
+	if degraded {
+		log.Printf("Market check degraded after %s over its %s latency budget: skipped %s, deferred %d symbols",
+			elapsed.Round(time.Millisecond), budget.deadline.Sub(budget.start), strings.Join(budget.skipped, ", "), budget.deferred)
+	}
+}
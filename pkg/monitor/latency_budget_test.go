@@ -0,0 +1,98 @@
+package monitor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/signal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newBudgetedMonitor returns a monitor with a 5 second check budget on a fake clock
+func newBudgetedMonitor() (*MarketMonitor, *MockLLMManager, *MockTelegramBot, *clock.Fake) {
+	cfg := config.CreateDefaultConfig()
+	cfg.LatencyBudget = config.LatencyBudgetConfig{MaxCheckSeconds: 5}
+	llmManager := &MockLLMManager{}
+	telegramBot := &MockTelegramBot{}
+	monitor := NewMarketMonitor(cfg, nil, nil, llmManager, telegramBot)
+	fake := clock.NewFake(time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC))
+	monitor.SetClock(fake)
+	return monitor, llmManager, telegramBot, fake
+}
+
+func TestCheckBudgetSkipsStagesPastDeadline(t *testing.T) {
+	monitor, _, _, fake := newBudgetedMonitor()
+	monitor.lastCheck = &CheckReport{}
+
+	budget := monitor.startBudget()
+	fake.Advance(4 * time.Second)
+	assert.False(t, budget.skip(stageSimilar))
+
+	// Past the deadline every stage is skipped, and recorded once
+	fake.Advance(2 * time.Second)
+	assert.True(t, budget.skip(stageSimilar))
+	assert.True(t, budget.skip(stageExplain))
+	assert.True(t, budget.skip(stageSimilar))
+	monitor.deferWatchlist(budget, "tech", 3)
+
+	monitor.finishBudget(budget)
+	assert.Equal(t, int64(6000), monitor.lastCheck.DurationMs)
+	assert.True(t, monitor.lastCheck.Degraded)
+	assert.Equal(t, []string{stageSimilar, stageExplain}, monitor.lastCheck.Skipped)
+	assert.Equal(t, 3, monitor.lastCheck.Deferred)
+	assert.Equal(t, 1, monitor.degradedChecks)
+
+	// Without a budget nothing is ever skipped
+	var unlimited *checkBudget
+	assert.False(t, unlimited.skip(stageExplain))
+}
+
+func TestProcessSignalExplainsLaterOverBudget(t *testing.T) {
+	monitor, llmManager, telegramBot, fake := newBudgetedMonitor()
+	llmManager.On("GenerateSignalExplanation", mock.Anything, mock.Anything).Return("LLM explanation", nil)
+	var sentRationale string
+	telegramBot.On("SendSignal", mock.Anything).Run(func(args mock.Arguments) {
+		sentRationale = args.Get(0).(*signal.Signal).Rationale
+	}).Return(nil)
+	updated := make(chan string, 1)
+	telegramBot.On("UpdateSignal", mock.Anything).Run(func(args mock.Arguments) {
+		updated <- args.Get(0).(*signal.Signal).Rationale
+	}).Return(nil)
+
+	budget := monitor.startBudget()
+	fake.Advance(10 * time.Second)
+	s := throttledSignal("1", "AAPL", fake.Now())
+	s.Rationale = "Generator rationale"
+	assert.NoError(t, monitor.processSignal(context.Background(), s, fake.Now(), budget))
+
+	// The signal went out without waiting on the LLM...
+	assert.Equal(t, "Generator rationale", sentRationale)
+	assert.Equal(t, []string{stageExplain, stageSimilar}, budget.skipped)
+
+	// ...and its message was updated once the explanation came in
+	select {
+	case rationale := <-updated:
+		assert.Equal(t, "LLM explanation", rationale)
+	case <-time.After(5 * time.Second):
+		t.Fatal("signal was not updated with its explanation")
+	}
+}
+
+func TestProcessSignalExplainsWithinBudget(t *testing.T) {
+	monitor, llmManager, telegramBot, fake := newBudgetedMonitor()
+	llmManager.On("GenerateSignalExplanation", mock.Anything, mock.Anything).Return("LLM explanation", nil)
+	telegramBot.On("SendSignal", mock.Anything).Return(nil)
+
+	budget := monitor.startBudget()
+	fake.Advance(time.Second)
+	s := throttledSignal("1", "AAPL", fake.Now())
+	assert.NoError(t, monitor.processSignal(context.Background(), s, fake.Now(), budget))
+
+	assert.Equal(t, "LLM explanation", s.Rationale)
+	assert.Empty(t, budget.skipped)
+	telegramBot.AssertNotCalled(t, "UpdateSignal", mock.Anything)
+}
@@ -0,0 +1,72 @@
+package monitor
+
+import (
+	"fmt"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+)
+
+// MarginStatus is the margin tied up by the open shorts at the latest prices
+type MarginStatus struct {
+	Capital     float64 `json:"capital"`
+	Posted      float64 `json:"posted"`      // Initial margin posted for the open shorts
+	Equity      float64 `json:"equity"`      // Posted margin plus the shorts' P&L
+	Maintenance float64 `json:"maintenance"` // Equity the shorts' current value requires
+	Call        bool    `json:"call"`        // Equity fell below the maintenance requirement
+}
+
+// SetShorting sets the margin rules new and open shorts are checked against
+func (r *RiskManager) SetShorting(shorting config.ShortingConfig) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.shorting = shorting
+}
+
+// CheckMargin refuses a new short worth notional whose initial margin, with
+// that of the open shorts, would exceed the margin capital
+func (r *RiskManager) CheckMargin(symbol string, notional, openShorts float64) error {
+	r.mu.RLock()
+	shorting := r.shorting
+	r.mu.RUnlock()
+
+	if !shorting.Enabled {
+		return fmt.Errorf("short selling is disabled")
+	}
+	required := (openShorts + notional) * shorting.InitialMarginPct / 100
+	if required > shorting.MarginCapital {
+		return fmt.Errorf("shorting $%.2f of %s needs $%.2f of margin, $%.2f available",
+			notional, symbol, required, shorting.MarginCapital)
+	}
+	return nil
+}
+
+// MarginStatus returns the margin of the open shorts at the latest prices,
+// flagging a margin call once their equity falls below maintenance
+func (r *RiskManager) MarginStatus(stocks map[string]*data.Stock) MarginStatus {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.marginStatus(stocks)
+}
+
+// marginStatus computes the margin status. Caller must hold the lock.
+func (r *RiskManager) marginStatus(stocks map[string]*data.Stock) MarginStatus {
+	status := MarginStatus{Capital: r.shorting.MarginCapital}
+	for _, trade := range r.tradeManager.GetActiveTrades() {
+		if !trade.IsShort() {
+			continue
+		}
+		price := trade.Price
+		if stock, ok := stocks[trade.Symbol]; ok && stock.CurrentPrice > 0 {
+			price = stock.CurrentPrice
+		}
+		posted := float64(trade.Quantity) * trade.Price * r.shorting.InitialMarginPct / 100
+		status.Posted += posted
+		status.Equity += posted + trade.PnLAt(trade.Quantity, price)
+		status.Maintenance += float64(trade.Quantity) * price * r.shorting.MaintenanceMarginPct / 100
+	}
+	status.Call = status.Posted > 0 && status.Equity < status.Maintenance
+	return status
+}
@@ -7,47 +7,122 @@ import (
 	"sync"
 	"time"
 
+	"github.com/hustler/trading-bot/pkg/clock"
 	"github.com/hustler/trading-bot/pkg/config"
 	"github.com/hustler/trading-bot/pkg/data"
-	"github.com/hustler/trading-bot/pkg/llm"
+	"github.com/hustler/trading-bot/pkg/errkind"
+	"github.com/hustler/trading-bot/pkg/indicators"
 	"github.com/hustler/trading-bot/pkg/signal"
-	"github.com/hustler/trading-bot/pkg/telegram"
+	"github.com/hustler/trading-bot/pkg/symbols"
+	"github.com/hustler/trading-bot/pkg/tracing"
 )
 
+// MarketDataSource provides intraday and daily market data (implemented by data.Provider)
+type MarketDataSource interface {
+	GetMarketDataBatch(symbols []string) (map[string]*data.MarketData, map[string]error)
+	GetDailyHistory(symbol string, days int) (*data.MarketData, error)
+}
+
+// Explainer writes the rationale for a signal (implemented by llm.Manager)
+type Explainer interface {
+	GenerateSignalExplanation(ctx context.Context, s *signal.Signal) (string, error)
+}
+
+// Notifier delivers signals and their updates to subscribers (implemented by telegram.Bot)
+type Notifier interface {
+	SendSignal(s *signal.Signal) error
+	UpdateSignal(s *signal.Signal) error
+	SendSignalOutcome(s *signal.Signal, exitPrice float64) error
+	SendMessage(message string) error
+}
+
 // MarketMonitor monitors the market and generates trading signals
 type MarketMonitor struct {
-	config        *config.Config
-	dataProvider  *data.Provider
-	signalGen     *signal.Generator
-	llmManager    *llm.Manager
-	telegramBot   *telegram.Bot
-	isRunning     bool
-	stopChan      chan struct{}
-	signalHistory []*signal.Signal
-	mu            sync.RWMutex
-}
-
-// NewMarketMonitor creates a new market monitor
+	config            *config.Config
+	dataProvider      MarketDataSource
+	signalGen         *signal.Generator
+	llmManager        Explainer
+	telegramBot       Notifier
+	isRunning         bool
+	stopChan          chan struct{}
+	signalHistory     []*signal.Signal
+	breadth           *indicators.Breadth
+	heatmap           map[string]HeatmapCell
+	economic          economicState
+	clock             clock.Clock
+	archiver          Archiver              // Receives signals dropped from the history
+	lastChecked       map[string]time.Time  // Watchlist name -> time of its last check
+	expander          SymbolExpander        // Optional; expands ETF:components watchlist entries
+	setups            SetupIndex            // Optional; compares new signals with similar past setups
+	regime            RegimeClassifier      // Optional; switches signal parameters by market regime
+	signalStore       SignalStore           // Optional; persists signals for search
+	corporateActions  CorporateActionSource // Optional; splits and dividends adjusting active signals
+	actionsDay        string                // Day corporate actions were last fetched
+	appliedActions    map[string]bool       // Keys of corporate actions already applied
+	actionListeners   []func(data.CorporateAction)
+	metadata          symbols.MetadataLookup     // Optional; company and sector details added to signals
+	seasonality       SeasonalityTracker         // Optional; intraday statistics attached to signals
+	checkFailures     map[string]*SymbolFailures // Fetch failures by symbol across checks
+	lastCheck         *CheckReport
+	degradedChecks    int                  // Checks over their latency budget
+	checks            int                  // Checks since startup
+	fetchErrors       map[errkind.Kind]int // Failed fetches since startup, by error kind
+	explanationChecks ExplanationChecks    // LLM explanations cross-checked against their indicators
+	lastBudgetAlert   time.Time
+	tracer            *tracing.Tracer         // Optional; traces each market check
+	follower          FollowerSimulator       // Optional; simulates a subscriber following each signal
+	quota             QuotaEnforcer           // Optional; meters signals and LLM tokens per watchlist
+	prices            PriceSource             // Optional; latest ticks signals are re-validated against before publish
+	throttle          throttleState           // Daily caps on published signals
+	session           sessionTracker          // Session state of the trading day
+	pairs             pairState               // Open pair signals
+	ideas             map[string]*signal.Idea // Assessed trade ideas by ID
+	// Listeners notified when a signal's target or stop changes, or when it closes
+	adjustListeners    []func(*signal.Signal)
+	closeListeners     []func(*signal.Signal, float64)
+	publishListeners   []func(*signal.Signal)
+	watchlistListeners []func([]string)                               // Notified with the watched symbols when they change
+	dataListeners      []func(map[string]*data.MarketData, time.Time) // Notified with the market data of every check
+	mu                 sync.RWMutex
+}
+
+// NewMarketMonitor creates a new market monitor. Live deployments pass a
+// data.Provider, llm.Manager and telegram.Bot; backtests pass replayed data and
+// sinks that record instead of sending. The monitor keeps its own copy of
+// cfg, so later changes to cfg don't reach it; use UpdateConfig.
 func NewMarketMonitor(
 	cfg *config.Config,
-	dataProvider *data.Provider,
+	dataProvider MarketDataSource,
 	signalGen *signal.Generator,
-	llmManager *llm.Manager,
-	telegramBot *telegram.Bot,
+	llmManager Explainer,
+	telegramBot Notifier,
 ) *MarketMonitor {
 	return &MarketMonitor{
-		config:        cfg,
-		dataProvider:  dataProvider,
-		signalGen:     signalGen,
-		llmManager:    llmManager,
-		telegramBot:   telegramBot,
-		isRunning:     false,
-		stopChan:      make(chan struct{}),
-		signalHistory: []*signal.Signal{},
-		mu:            sync.RWMutex{},
+		config:         cfg.Clone(),
+		dataProvider:   dataProvider,
+		signalGen:      signalGen,
+		llmManager:     llmManager,
+		telegramBot:    telegramBot,
+		isRunning:      false,
+		stopChan:       make(chan struct{}),
+		signalHistory:  []*signal.Signal{},
+		lastChecked:    make(map[string]time.Time),
+		appliedActions: make(map[string]bool),
+		checkFailures:  make(map[string]*SymbolFailures),
+		fetchErrors:    make(map[errkind.Kind]int),
+		ideas:          make(map[string]*signal.Idea),
+		clock:          clock.Real{},
+		mu:             sync.RWMutex{},
 	}
 }
 
+// SetClock sets the clock used for signal expiry and economic event windows
+func (m *MarketMonitor) SetClock(c clock.Clock) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.clock = c
+}
+
 // Start starts the market monitor
 func (m *MarketMonitor) Start() error {
 	m.mu.Lock()
@@ -90,18 +165,31 @@ func (m *MarketMonitor) IsRunning() bool {
 	return m.isRunning
 }
 
-// GetSignalHistory returns the signal history
+// GetSignalHistory returns copies of the signals in the history, which the
+// caller may keep and change while the monitor goes on updating its own
 func (m *MarketMonitor) GetSignalHistory() []*signal.Signal {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	// Return a copy to avoid race conditions
 	history := make([]*signal.Signal, len(m.signalHistory))
-	copy(history, m.signalHistory)
+	for i, s := range m.signalHistory {
+		history[i] = s.Clone()
+	}
 
 	return history
 }
 
+// GetBreadth returns the market breadth from the latest check
+func (m *MarketMonitor) GetBreadth() (indicators.Breadth, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.breadth == nil {
+		return indicators.Breadth{}, false
+	}
+	return *m.breadth, true
+}
+
 // monitorMarket monitors the market and generates signals
 func (m *MarketMonitor) monitorMarket() {
 	// Calculate initial check time
@@ -135,78 +223,277 @@ func (m *MarketMonitor) monitorMarket() {
 			// 	log.Printf("Error performing market check: %v", err)
 			// }
 
-			// Calculate next check time
-			nextCheckTime = time.Now().Add(time.Duration(m.config.CheckInterval) * time.Second)
+			// Keep the session state current so its listeners fire on time
+			m.mu.RLock()
+			now := m.clock.Now()
+			m.mu.RUnlock()
+			m.updateSession(now)
+
+			// Keep the cadence of the schedule rather than of the check ends,
+			// skipping checks that were missed
+			nextCheckTime = nextCheckTime.Add(m.checkInterval())
+			if now := time.Now(); nextCheckTime.Before(now) {
+				nextCheckTime = now
+			}
 		}
 	}
 }
 
+// CheckMarket runs a single market check immediately. Backtests call it once
+// per simulated interval instead of starting the monitor.
+func (m *MarketMonitor) CheckMarket() error {
+	return m.performMarketCheck()
+}
+
 // performMarketCheck performs a market check and generates signals
 func (m *MarketMonitor) performMarketCheck() error {
-	// Get stock symbols
+	// Get the symbols of the watchlists due for a check
 	m.mu.RLock()
-	symbols := m.config.StockSymbols
+	now := m.clock.Now()
 	m.mu.RUnlock()
+	watchlists := m.expandWatchlists(m.dueWatchlists(now))
+	return m.checkWatchlists(now, watchlists, true)
+}
+
+// checkWatchlists fetches market data for the symbols of watchlists and sends
+// the signals generated from it. Market-wide context such as breadth is only
+// updated by full checks, not targeted ones.
+func (m *MarketMonitor) checkWatchlists(now time.Time, watchlists []config.WatchlistConfig, full bool) error {
+	symbols := m.withPairSymbols(watchlistSymbols(watchlists))
+	cfg := m.currentConfig()
+	ctx, span := m.startCheckTrace(full, len(symbols))
+	defer span.End()
+	budget := m.startBudget()
+
+	// Move to the session state of the check
+	state := m.updateSession(now)
 
 	// Fetch market data for all symbols
 	marketData := make(map[string]signal.MarketData)
-	for _, symbol := range symbols {
-		data, err := m.dataProvider.GetMarketData(symbol)
-		if err != nil {
-			log.Printf("Error fetching market data for %s: %v", symbol, err)
-			continue
-		}
+	_, fetchSpan := tracing.Start(ctx, "data.fetch")
+	results, errs := m.dataProvider.GetMarketDataBatch(symbols)
+	fetchSpan.SetAttribute("failed", len(errs))
+	fetchSpan.End()
+	m.recordCheck(now, symbols, errs, full)
+	defer m.finishBudget(budget)
+	for symbol, data := range results {
 		marketData[symbol] = signal.MarketData{
-			Symbol:     symbol,
-			Prices:     data.Prices,
-			Volumes:    data.Volumes,
-			Timestamps: data.Timestamps,
+			Symbol:          symbol,
+			Prices:          data.Prices,
+			Volumes:         data.Volumes,
+			Timestamps:      data.Timestamps,
+			Exchange:        data.Exchange,
+			Halted:          data.Halted,
+			ShortRestricted: data.ShortRestricted,
 		}
 	}
 
+	// Update watchlist breadth and heatmap for context on signal quality
+	if full {
+		breadth := indicators.ComputeBreadth(results, cfg.VolatilityParams.RSIPeriod)
+		heatmap := buildHeatmap(results)
+		m.mu.Lock()
+		m.breadth = &breadth
+		m.heatmap = heatmap
+		m.mu.Unlock()
+	}
+
+	// Adjust active signals for splits and dividends before judging their levels
+	if full {
+		m.refreshCorporateActions(now)
+	}
+
+	// Enter simulated followers before their signals can close
+	m.observeFollowers(results, now)
+	m.notifyMarketData(results, now)
+
+	// Close signals that hit target or stop since the last check
+	m.resolveSignalOutcomes(results)
+	m.resolveShadowSignals(results)
+	m.resolvePairSignals(results)
+	m.applyRetention()
+
+	// Refresh average volumes for the liquidity guardrails (once per day).
+	// A check over its budget leaves it to the next one.
+	if !budget.skip(stageLiquidity) {
+		if err := m.signalGen.RefreshLiquidity(m.dataProvider, symbols); err != nil {
+			log.Printf("Error refreshing liquidity: %v", err)
+		}
+	}
+
+	// Pause or raise the bar around high-impact economic releases
+	event, inEventWindow := m.checkEconomicCalendar(now)
+	if inEventWindow && cfg.EconomicCalendar.Action != config.EventRaise {
+		log.Printf("Skipping signal generation during %s %s window", event.Country, event.Title)
+		return nil
+	}
+
+	// Apply the session state's adjustments
+	adjustments := cfg.Session.ForState(state)
+	if adjustments.Paused {
+		log.Printf("Skipping signal generation during %s", state)
+		return nil
+	}
+
+	// Switch signal parameters to the day's market regime
+	m.updateRegime(now)
+
+	// Recompute intraday seasonality (once per day)
+	if full && !budget.skip(stageSeasonality) {
+		m.updateSeasonality(now, symbols)
+	}
+
 	// Generate signals
-	signals, err := m.signalGen.GenerateSignals(marketData)
+	generateCtx, generateSpan := tracing.Start(ctx, "signals.generate")
+	signals, err := m.generateWatchlistSignals(generateCtx, watchlists, marketData, budget)
+	generateSpan.SetAttribute("signals", len(signals))
+	generateSpan.RecordError(err)
+	generateSpan.End()
 	if err != nil {
+		span.RecordError(err)
 		return fmt.Errorf("error generating signals: %w", err)
 	}
+	if inEventWindow {
+		signals = filterForEconomicEvent(signals, cfg.EconomicCalendar, cfg.VolatilityParams.ConfidenceThreshold)
+	}
+	signals = filterForSession(signals, adjustments, cfg.VolatilityParams.ConfidenceThreshold)
 
 	// Process signals
 	for _, s := range signals {
-		// Generate explanation using LLM
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		explanation, err := m.llmManager.GenerateSignalExplanation(ctx, s)
-		cancel()
-		if err != nil {
-			log.Printf("Error generating explanation for signal %s: %v", s.ID, err)
+		// Signals over a daily cap are tracked in shadow mode instead
+		if reason := m.throttleReason(s, now); reason != "" {
+			m.shadowSignal(s, reason)
+			continue
+		}
+		// Signals over their watchlist's quota are not published
+		if !m.allowSignal(s) {
+			continue
+		}
+		m.processSignal(ctx, s, now, budget)
+	}
+
+	// Trade the spreads of configured pairs
+	if !budget.skip(stagePairs) {
+		m.checkPairs(marketData)
+	}
+
+	m.applyRetention()
+
+	log.Printf("Market check completed, generated %d signals", len(signals))
+	return nil
+}
+
+// processSignal enriches and explains a new signal, sends it to subscribers
+// and records it, returning the delivery error if any. Test signals are not
+// learned from, followed or added to the history, so they never resolve. Once
+// the check is over its budget, the signal is sent with the generator's
+// rationale and explained in the background, as every signal is when
+// explanations are asynchronous. A signal the market moved away from before
+// publish is revised, or dropped without being sent.
+func (m *MarketMonitor) processSignal(ctx context.Context, s *signal.Signal, now time.Time, budget *checkBudget) error {
+	signalCtx, signalSpan := tracing.Start(ctx, "signal.process")
+	signalSpan.SetAttribute("symbol", s.Symbol)
+	signalSpan.SetAttribute("signal_id", s.ID)
+
+	// Add company and sector details and typical intraday behavior
+	m.enrichSignal(s)
+	m.addSeasonality(s, now)
+
+	// Generate explanation using LLM, keeping the generator's rationale
+	// once the watchlist's token quota is spent
+	explainLater := false
+	if m.allowExplanation(s) {
+		if m.asyncExplanation() || budget.skip(stageExplain) {
+			explainLater = true
 		} else {
-			s.Rationale = explanation
+			llmCtx, llmSpan := tracing.Start(signalCtx, "llm.explain")
+			llmCtx, cancel := context.WithTimeout(llmCtx, 30*time.Second)
+			explanation, err := m.llmManager.GenerateSignalExplanation(llmCtx, s)
+			cancel()
+			llmSpan.RecordError(err)
+			llmSpan.End()
+			if err != nil {
+				log.Printf("Error generating explanation for signal %s: %v", s.ID, err)
+			} else {
+				m.recordExplanation(s, explanation)
+				s.Rationale = m.crossCheckExplanation(s, explanation)
+			}
 		}
+	}
 
-		// Send signal to Telegram
-		err = m.telegramBot.SendSignal(s)
-		if err != nil {
-			log.Printf("Error sending signal to Telegram: %v", err)
+	// Add how similar past setups played out
+	if !s.Test && !budget.skip(stageSimilar) {
+		m.addSimilarSetups(s)
+	}
+
+	// Re-validate against the latest tick, as the market may have moved
+	// while the signal was explained
+	if !m.reviseSignal(s) {
+		signalSpan.SetAttribute("dropped", true)
+		signalSpan.End()
+		return nil
+	}
+
+	// Send signal to Telegram
+	_, notifySpan := tracing.Start(signalCtx, "notify")
+	err := m.telegramBot.SendSignal(s)
+	notifySpan.RecordError(err)
+	notifySpan.End()
+	if err != nil {
+		log.Printf("Error sending signal to Telegram: %v", err)
+	} else {
+		// Only delivered signals are followed, tracked and counted against
+		// the daily caps
+		if !s.Test {
+			m.followSignal(s)
+			m.recordPublished(s)
 		}
+		m.notifyPublished(s)
+	}
 
-		// Add signal to history
+	// Add signal to history
+	_, persistSpan := tracing.Start(signalCtx, "persist")
+	if !s.Test {
 		m.mu.Lock()
 		m.signalHistory = append(m.signalHistory, s)
-		// Limit history size to 100 signals
-		if len(m.signalHistory) > 100 {
-			m.signalHistory = m.signalHistory[len(m.signalHistory)-100:]
-		}
 		m.mu.Unlock()
+	}
+
+	m.saveSignal(s)
+	persistSpan.End()
+	signalSpan.End()
 
-		log.Printf("Generated and sent %s signal for %s", s.Type, s.Symbol)
+	// Explain the delivered signal now that the check no longer waits on it
+	if explainLater && err == nil {
+		go m.explainLater(s)
 	}
 
-	log.Printf("Market check completed, generated %d signals", len(signals))
-	return nil
+	log.Printf("Generated and sent %s signal for %s", s.Type, s.Symbol)
+	return err
 }
 
-// UpdateConfig updates the monitor configuration
+// UpdateConfig updates the monitor and signal generator configuration to a copy of cfg
 func (m *MarketMonitor) UpdateConfig(cfg *config.Config) {
 	m.mu.Lock()
-	defer m.mu.Unlock()
-	m.config = cfg
+	m.config = cfg.Clone()
+	if !m.session.fixed {
+		m.session.clock = nil // Rebuilt from the new session windows
+	}
+	signalGen := m.signalGen
+	m.mu.Unlock()
+
+	// The generator keeps its own copy, so new thresholds must reach it too
+	if signalGen != nil {
+		signalGen.UpdateConfig(cfg)
+	}
+
+	m.notifyWatchlistChange()
+}
+
+// currentConfig returns the monitor configuration. It is replaced by updates,
+// never changed in place, so it can be read without holding the lock.
+func (m *MarketMonitor) currentConfig() *config.Config {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.config
 }
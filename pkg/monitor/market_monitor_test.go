@@ -1,14 +1,14 @@
 package monitor
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/hustler/trading-bot/pkg/config"
 	"github.com/hustler/trading-bot/pkg/data"
-	"github.com/hustler/trading-bot/pkg/llm"
 	"github.com/hustler/trading-bot/pkg/signal"
-	"github.com/hustler/trading-bot/pkg/telegram"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -18,18 +18,15 @@ type MockDataProvider struct {
 	mock.Mock
 }
 
-func (m *MockDataProvider) GetMarketData(symbol string) (*data.MarketData, error) {
-	args := m.Called(symbol)
-	return args.Get(0).(*data.MarketData), args.Error(1)
+func (m *MockDataProvider) GetMarketDataBatch(symbols []string) (map[string]*data.MarketData, map[string]error) {
+	args := m.Called(symbols)
+	return args.Get(0).(map[string]*data.MarketData), args.Get(1).(map[string]error)
 }
 
-type MockSignalGenerator struct {
-	mock.Mock
-}
-
-func (m *MockSignalGenerator) GenerateSignals(marketData map[string]signal.MarketData) ([]*signal.Signal, error) {
-	args := m.Called(marketData)
-	return args.Get(0).([]*signal.Signal), args.Error(1)
+func (m *MockDataProvider) GetDailyHistory(symbol string, days int) (*data.MarketData, error) {
+	args := m.Called(symbol, days)
+	md, _ := args.Get(0).(*data.MarketData)
+	return md, args.Error(1)
 }
 
 type MockLLMManager struct {
@@ -50,11 +47,26 @@ func (m *MockTelegramBot) SendSignal(s *signal.Signal) error {
 	return args.Error(0)
 }
 
+func (m *MockTelegramBot) UpdateSignal(s *signal.Signal) error {
+	args := m.Called(s)
+	return args.Error(0)
+}
+
+func (m *MockTelegramBot) SendSignalOutcome(s *signal.Signal, exitPrice float64) error {
+	args := m.Called(s, exitPrice)
+	return args.Error(0)
+}
+
+func (m *MockTelegramBot) SendMessage(message string) error {
+	args := m.Called(message)
+	return args.Error(0)
+}
+
 func TestNewMarketMonitor(t *testing.T) {
 	// Create mocks
 	cfg := config.CreateDefaultConfig()
 	dataProvider := &MockDataProvider{}
-	signalGen := &MockSignalGenerator{}
+	signalGen := signal.NewGenerator(cfg)
 	llmManager := &MockLLMManager{}
 	telegramBot := &MockTelegramBot{}
 
@@ -77,7 +89,7 @@ func TestStartStop(t *testing.T) {
 	// Create mocks
 	cfg := config.CreateDefaultConfig()
 	dataProvider := &MockDataProvider{}
-	signalGen := &MockSignalGenerator{}
+	signalGen := signal.NewGenerator(cfg)
 	llmManager := &MockLLMManager{}
 	telegramBot := &MockTelegramBot{}
 
@@ -109,7 +121,7 @@ func TestGetSignalHistory(t *testing.T) {
 	// Create mocks
 	cfg := config.CreateDefaultConfig()
 	dataProvider := &MockDataProvider{}
-	signalGen := &MockSignalGenerator{}
+	signalGen := signal.NewGenerator(cfg)
 	llmManager := &MockLLMManager{}
 	telegramBot := &MockTelegramBot{}
 
@@ -148,7 +160,7 @@ func TestUpdateConfig(t *testing.T) {
 	// Create mocks
 	cfg := config.CreateDefaultConfig()
 	dataProvider := &MockDataProvider{}
-	signalGen := &MockSignalGenerator{}
+	signalGen := signal.NewGenerator(cfg)
 	llmManager := &MockLLMManager{}
 	telegramBot := &MockTelegramBot{}
 
@@ -175,71 +187,49 @@ func TestPerformMarketCheck(t *testing.T) {
 	cfg.StockSymbols = []string{"AAPL", "MSFT"}
 
 	dataProvider := &MockDataProvider{}
-	signalGen := &MockSignalGenerator{}
+	signalGen := signal.NewGenerator(cfg)
 	llmManager := &MockLLMManager{}
 	telegramBot := &MockTelegramBot{}
 
 	// Create monitor
 	monitor := NewMarketMonitor(cfg, dataProvider, signalGen, llmManager, telegramBot)
 
-	// Create mock data
-	appleData := &data.MarketData{
-		Symbol:     "AAPL",
-		Prices:     []float64{150.0, 151.0, 152.0},
-		Volumes:    []float64{1000000, 1100000, 1200000},
-		Timestamps: []time.Time{time.Now().Add(-2 * time.Hour), time.Now().Add(-1 * time.Hour), time.Now()},
-	}
-
-	msftData := &data.MarketData{
-		Symbol:     "MSFT",
-		Prices:     []float64{350.0, 351.0, 352.0},
-		Volumes:    []float64{2000000, 2100000, 2200000},
-		Timestamps: []time.Time{time.Now().Add(-2 * time.Hour), time.Now().Add(-1 * time.Hour), time.Now()},
-	}
-
-	// Create mock signals
-	mockSignals := []*signal.Signal{
-		{
-			ID:          "SIG-AAPL-BUY-1",
-			Symbol:      "AAPL",
-			Type:        signal.BUY,
-			Price:       152.0,
-			TargetPrice: 155.0,
-			StopLoss:    150.0,
-			ExpectedROI: 1.97,
-			Confidence:  0.85,
-			GeneratedAt: time.Now(),
+	// Create mock data, too short a session for any strategy to signal
+	now := time.Now()
+	marketData := map[string]*data.MarketData{
+		"AAPL": {
+			Symbol:     "AAPL",
+			Prices:     []float64{150.0, 151.0, 153.0},
+			Volumes:    []float64{1000000, 1100000, 1200000},
+			Timestamps: []time.Time{now.Add(-2 * time.Hour), now.Add(-1 * time.Hour), now},
+		},
+		"MSFT": {
+			Symbol:     "MSFT",
+			Prices:     []float64{350.0, 351.0, 343.0},
+			Volumes:    []float64{2000000, 2100000, 2200000},
+			Timestamps: []time.Time{now.Add(-2 * time.Hour), now.Add(-1 * time.Hour), now},
 		},
 	}
 
 	// Set up mock expectations
-	dataProvider.On("GetMarketData", "AAPL").Return(appleData, nil)
-	dataProvider.On("GetMarketData", "MSFT").Return(msftData, nil)
-
-	// The marketData parameter is complex, so we use mock.Anything
-	signalGen.On("GenerateSignals", mock.Anything).Return(mockSignals, nil)
-
-	// LLM should be called for the signal
-	llmManager.On("GenerateSignalExplanation", mock.Anything, mockSignals[0]).Return("This is a test explanation", nil)
-
-	// Telegram bot should be called to send the signal
-	telegramBot.On("SendSignal", mockSignals[0]).Return(nil)
+	dataProvider.On("GetMarketDataBatch", mock.Anything).Return(marketData, map[string]error{})
+	dataProvider.On("GetDailyHistory", mock.Anything, mock.Anything).Return(nil, errors.New("no history")).Maybe()
 
 	// Perform market check
 	err := monitor.performMarketCheck()
 
-	// Verify no error
+	// Verify no error and nothing sent
 	assert.NoError(t, err)
-
-	// Verify mocks were called
 	dataProvider.AssertExpectations(t)
-	signalGen.AssertExpectations(t)
-	llmManager.AssertExpectations(t)
-	telegramBot.AssertExpectations(t)
-
-	// Verify signal was added to history
-	history := monitor.GetSignalHistory()
-	assert.Len(t, history, 1)
-	assert.Equal(t, "SIG-AAPL-BUY-1", history[0].ID)
-	assert.Equal(t, "This is a test explanation", history[0].Rationale)
+	llmManager.AssertNotCalled(t, "GenerateSignalExplanation", mock.Anything, mock.Anything)
+	telegramBot.AssertNotCalled(t, "SendSignal", mock.Anything)
+	assert.Empty(t, monitor.GetSignalHistory())
+
+	// Verify the heatmap was built from the check
+	heatmap := monitor.GetHeatmap()
+	assert.Len(t, heatmap, 2)
+	assert.Equal(t, "AAPL", heatmap[0].Symbol)
+	assert.InDelta(t, 2.0, heatmap[0].ChangePercent, 0.001)
+	assert.Equal(t, "MSFT", heatmap[1].Symbol)
+	assert.InDelta(t, -2.0, heatmap[1].ChangePercent, 0.001)
 }
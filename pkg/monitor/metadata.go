@@ -0,0 +1,38 @@
+package monitor
+
+import (
+	"github.com/hustler/trading-bot/pkg/signal"
+	"github.com/hustler/trading-bot/pkg/symbols"
+)
+
+// SetMetadata sets the symbol reference data signals are enriched with
+func (m *MarketMonitor) SetMetadata(metadata symbols.MetadataLookup) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.metadata = metadata
+}
+
+// enrichSignal adds the company name, sector and industry of the signal's
+// symbol and rounds its size cap down to whole board lots
+func (m *MarketMonitor) enrichSignal(s *signal.Signal) {
+	m.mu.RLock()
+	lookup := m.metadata
+	m.mu.RUnlock()
+
+	if lookup == nil {
+		return
+	}
+	metadata, ok := lookup.Lookup(s.Symbol)
+	if !ok {
+		return
+	}
+
+	s.Company = metadata.Name
+	if metadata.Sector != symbols.UnknownSector {
+		s.Sector = metadata.Sector
+	}
+	s.Industry = metadata.Industry
+	if metadata.LotSize > 1 && s.MaxShares > 0 {
+		s.MaxShares -= s.MaxShares % metadata.LotSize
+	}
+}
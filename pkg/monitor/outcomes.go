@@ -0,0 +1,70 @@
+package monitor
+
+import (
+	"log"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// signalExpiry is how long a signal stays active, matching its "1-3 hours" time frame
+const signalExpiry = 3 * time.Hour
+
+// closedSignal is a signal that reached its final status during a check
+type closedSignal struct {
+	signal    *signal.Signal
+	exitPrice float64
+}
+
+// OnSignalClosed registers a callback invoked with a copy of a signal once it hits
+// target, stops out or expires, e.g. to record the outcome in performance tracking
+func (m *MarketMonitor) OnSignalClosed(fn func(s *signal.Signal, exitPrice float64)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.closeListeners = append(m.closeListeners, fn)
+}
+
+// resolveSignalOutcomes closes active signals that hit their target or stop at the
+// latest price, or expired, and updates their Telegram messages
+func (m *MarketMonitor) resolveSignalOutcomes(marketData map[string]*data.MarketData) {
+	m.mu.Lock()
+	now := m.clock.Now()
+	var closed []closedSignal
+	for _, s := range m.signalHistory {
+		md, ok := marketData[s.Symbol]
+		if !ok || md == nil || len(md.Prices) == 0 {
+			continue
+		}
+
+		price := md.Prices[len(md.Prices)-1]
+		status := signal.ResolveOutcome(s, price, now, signalExpiry)
+		if status == "" {
+			continue
+		}
+
+		if err := s.SetStatus(status, now); err != nil {
+			log.Printf("Error closing signal: %v", err)
+			continue
+		}
+		closed = append(closed, closedSignal{signal: s.Clone(), exitPrice: price})
+	}
+	listeners := make([]func(*signal.Signal, float64), len(m.closeListeners))
+	copy(listeners, m.closeListeners)
+	m.mu.Unlock()
+
+	for _, c := range closed {
+		log.Printf("Signal %s closed as %s at $%.2f", c.signal.ID, c.signal.Status, c.exitPrice)
+
+		if m.telegramBot != nil {
+			if err := m.telegramBot.SendSignalOutcome(c.signal, c.exitPrice); err != nil {
+				log.Printf("Error sending outcome for signal %s: %v", c.signal.ID, err)
+			}
+		}
+
+		for _, fn := range listeners {
+			fn(c.signal.Clone(), c.exitPrice)
+		}
+	}
+}
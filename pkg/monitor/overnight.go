@@ -0,0 +1,126 @@
+package monitor
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/execution"
+)
+
+// EarningsCalendar provides upcoming earnings report times
+type EarningsCalendar interface {
+	// NextEarnings returns the next earnings report time for symbol, if known
+	NextEarnings(symbol string) (time.Time, bool)
+}
+
+// CarryDecision describes whether a position is carried overnight
+type CarryDecision struct {
+	Trade  *execution.Trade
+	Carry  bool
+	Reason string
+}
+
+// SetHoldingPolicy sets whether positions may be held overnight and the carry rules
+func (r *RiskManager) SetHoldingPolicy(policy config.HoldingConfig) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.holding = policy
+}
+
+// SetEarningsCalendar sets the calendar used to avoid holding positions through earnings
+func (r *RiskManager) SetEarningsCalendar(calendar EarningsCalendar) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.earnings = calendar
+}
+
+// IsSwingMode reports whether positions may be carried overnight
+func (r *RiskManager) IsSwingMode() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.holding.IsSwing()
+}
+
+// PlanOvernightCarry decides which active positions are carried into the next
+// session. In intraday mode nothing is carried. In swing mode positions with
+// earnings before the next session ends are closed, then the oldest positions are
+// carried until the overnight position or exposure limit is reached.
+func (r *RiskManager) PlanOvernightCarry(stocks map[string]*data.Stock, now time.Time) []CarryDecision {
+	r.mu.RLock()
+	holding, earnings, session := r.holding, r.earnings, r.session
+	r.mu.RUnlock()
+
+	trades := r.tradeManager.GetActiveTrades()
+	sort.Slice(trades, func(i, j int) bool { return trades[i].CreatedAt.Before(trades[j].CreatedAt) })
+
+	decisions := make([]CarryDecision, 0, len(trades))
+	if !holding.IsSwing() {
+		for _, trade := range trades {
+			decisions = append(decisions, CarryDecision{Trade: trade, Reason: "intraday mode"})
+		}
+		return decisions
+	}
+
+	sessionEnd := session.NextSessionClose(now)
+	var exposure float64
+	carried := 0
+	for _, trade := range trades {
+		if holding.ExitBeforeEarnings && earnings != nil {
+			if reportAt, ok := earnings.NextEarnings(trade.Symbol); ok && !reportAt.Before(now) && reportAt.Before(sessionEnd) {
+				decisions = append(decisions, CarryDecision{
+					Trade:  trade,
+					Reason: fmt.Sprintf("earnings on %s", reportAt.In(sessionEnd.Location()).Format("Jan 2 15:04")),
+				})
+				continue
+			}
+		}
+
+		price := trade.Price
+		if stock, ok := stocks[trade.Symbol]; ok {
+			price = stock.CurrentPrice
+		}
+		value := float64(trade.Quantity) * price
+
+		if holding.MaxOvernightPositions > 0 && carried >= holding.MaxOvernightPositions {
+			decisions = append(decisions, CarryDecision{Trade: trade, Reason: "overnight position limit reached"})
+			continue
+		}
+		if holding.MaxOvernightExposure > 0 && exposure+value > holding.MaxOvernightExposure {
+			decisions = append(decisions, CarryDecision{Trade: trade, Reason: "overnight exposure limit reached"})
+			continue
+		}
+
+		exposure += value
+		carried++
+		decisions = append(decisions, CarryDecision{Trade: trade, Carry: true})
+	}
+
+	return decisions
+}
+
+// MarkOvernight records the closing prices of carried positions so the next
+// session's daily P&L is measured from the close rather than the original entry
+func (r *RiskManager) MarkOvernight(trades []*execution.Trade, stocks map[string]*data.Stock) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, trade := range trades {
+		if stock, ok := stocks[trade.Symbol]; ok {
+			r.overnightMarks[trade.ID] = stock.CurrentPrice
+		}
+	}
+}
+
+// costBasis returns the price daily P&L is measured from. Caller must hold the lock.
+func (r *RiskManager) costBasis(trade *execution.Trade) float64 {
+	if mark, ok := r.overnightMarks[trade.ID]; ok {
+		return mark
+	}
+	return trade.Price
+}
@@ -0,0 +1,169 @@
+package monitor
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// pairState tracks the open pair signals
+type pairState struct {
+	active    map[string]*signal.PairSignal // By pair
+	listeners []func(signal.PairSignal)
+}
+
+// OnPairSignal registers a callback fired with a copy of each pair signal when
+// it opens and again when it closes, e.g. to enter and exit pair trades
+func (m *MarketMonitor) OnPairSignal(fn func(signal.PairSignal)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pairs.listeners = append(m.pairs.listeners, fn)
+}
+
+// ActivePairSignals returns copies of the open pair signals, sorted by pair
+func (m *MarketMonitor) ActivePairSignals() []signal.PairSignal {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	active := make([]signal.PairSignal, 0, len(m.pairs.active))
+	for _, p := range m.pairs.active {
+		active = append(active, *p)
+	}
+	sort.Slice(active, func(i, j int) bool { return active[i].Pair < active[j].Pair })
+	return active
+}
+
+// withPairSymbols adds the legs of the configured pairs to the symbols of a check
+func (m *MarketMonitor) withPairSymbols(symbols []string) []string {
+	seen := make(map[string]bool, len(symbols))
+	for _, symbol := range symbols {
+		seen[symbol] = true
+	}
+	for _, symbol := range m.signalGen.PairSymbols() {
+		if !seen[symbol] {
+			seen[symbol] = true
+			symbols = append(symbols, symbol)
+		}
+	}
+	return symbols
+}
+
+// resolvePairSignals closes the pair signals whose spread reverted or blew out
+func (m *MarketMonitor) resolvePairSignals(marketData map[string]*data.MarketData) {
+	m.mu.Lock()
+	now := m.clock.Now()
+	var closed []signal.PairSignal
+	for pair, p := range m.pairs.active {
+		prices := latestPrices(marketData, p.Legs)
+		if prices == nil {
+			continue
+		}
+		status := p.Resolve(prices[p.Legs[0].Symbol], prices[p.Legs[1].Symbol])
+		if status == "" {
+			continue
+		}
+		if err := signal.ValidateTransition(p.Status, status); err != nil {
+			log.Printf("Error closing pair signal %s: %v", p.ID, err)
+			continue
+		}
+
+		p.Status = status
+		p.ClosedAt = now
+		p.ROI = p.ROIAt(prices)
+		closed = append(closed, *p)
+		delete(m.pairs.active, pair)
+	}
+	listeners := make([]func(signal.PairSignal), len(m.pairs.listeners))
+	copy(listeners, m.pairs.listeners)
+	m.mu.Unlock()
+
+	for _, p := range closed {
+		log.Printf("Pair signal %s closed as %s (%+.2f%%)", p.ID, p.Status, p.ROI)
+		m.sendPairMessage(formatPairOutcome(p))
+		for _, fn := range listeners {
+			fn(p)
+		}
+	}
+}
+
+// checkPairs publishes a signal for each pair without an open one that the
+// pair strategy wants to trade
+func (m *MarketMonitor) checkPairs(marketData map[string]signal.MarketData) {
+	generated := m.signalGen.GeneratePairSignals(marketData)
+	if len(generated) == 0 {
+		return
+	}
+
+	m.mu.Lock()
+	if m.pairs.active == nil {
+		m.pairs.active = make(map[string]*signal.PairSignal)
+	}
+	var opened []signal.PairSignal
+	for _, p := range generated {
+		if _, exists := m.pairs.active[p.Pair]; exists {
+			continue
+		}
+		m.pairs.active[p.Pair] = p
+		opened = append(opened, *p)
+	}
+	listeners := make([]func(signal.PairSignal), len(m.pairs.listeners))
+	copy(listeners, m.pairs.listeners)
+	m.mu.Unlock()
+
+	for _, p := range opened {
+		log.Printf("Generated pair signal %s: %s the %s spread at z-score %+.2f", p.ID, p.Type, p.Pair, p.ZScore)
+		m.sendPairMessage(formatPairSignal(p))
+		for _, fn := range listeners {
+			fn(p)
+		}
+	}
+}
+
+// sendPairMessage sends a pair signal message to subscribers
+func (m *MarketMonitor) sendPairMessage(message string) {
+	if m.telegramBot == nil {
+		return
+	}
+	if err := m.telegramBot.SendMessage(message); err != nil {
+		log.Printf("Error sending pair signal message: %v", err)
+	}
+}
+
+// latestPrices returns the latest price of each leg, or nil unless every leg has one
+func latestPrices(marketData map[string]*data.MarketData, legs []signal.Leg) map[string]float64 {
+	prices := make(map[string]float64, len(legs))
+	for _, leg := range legs {
+		md, ok := marketData[leg.Symbol]
+		if !ok || md == nil || len(md.Prices) == 0 {
+			return nil
+		}
+		prices[leg.Symbol] = md.Prices[len(md.Prices)-1]
+	}
+	return prices
+}
+
+// formatPairSignal formats a new pair signal for Telegram
+func formatPairSignal(p signal.PairSignal) string {
+	message := fmt.Sprintf("⚖️ <b>PAIR %s %s</b>\n", p.Type, p.Pair)
+	for _, leg := range p.Legs {
+		side := "Long"
+		if leg.Type == signal.SELL {
+			side = "Short"
+		}
+		message += fmt.Sprintf("• %s %.3f × %s at $%.2f\n", side, leg.Ratio, leg.Symbol, leg.Price)
+	}
+	message += fmt.Sprintf("Spread z-score %+.2f, exit inside ±%.1f, stop beyond ±%.1f", p.ZScore, p.ExitZScore, p.StopZScore)
+	return message
+}
+
+// formatPairOutcome formats the outcome of a pair signal for Telegram
+func formatPairOutcome(p signal.PairSignal) string {
+	headline := "✅ <b>SPREAD REVERTED</b>"
+	if p.Status == signal.StatusFailure {
+		headline = "❌ <b>SPREAD STOPPED OUT</b>"
+	}
+	return fmt.Sprintf("%s %s: combined %+.2f%%", headline, p.Pair, p.ROI)
+}
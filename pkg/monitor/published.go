@@ -0,0 +1,24 @@
+package monitor
+
+import "github.com/hustler/trading-bot/pkg/signal"
+
+// OnSignalPublished registers a callback invoked with a copy of each signal
+// delivered to subscribers, e.g. to start tracking its performance
+func (m *MarketMonitor) OnSignalPublished(fn func(*signal.Signal)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.publishListeners = append(m.publishListeners, fn)
+}
+
+// notifyPublished passes a delivered signal to the publish listeners
+func (m *MarketMonitor) notifyPublished(s *signal.Signal) {
+	m.mu.RLock()
+	listeners := make([]func(*signal.Signal), len(m.publishListeners))
+	copy(listeners, m.publishListeners)
+	m.mu.RUnlock()
+
+	for _, fn := range listeners {
+		fn(s.Clone())
+	}
+}
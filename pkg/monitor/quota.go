@@ -0,0 +1,79 @@
+package monitor
+
+import (
+	"log"
+
+	"github.com/hustler/trading-bot/pkg/quota"
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// QuotaEnforcer meters signals and LLM tokens per tenant (implemented by quota.Manager)
+type QuotaEnforcer interface {
+	Allow(tenant, kind string, amount int) bool
+	Record(tenant, kind string, amount int)
+	Exhausted(tenant, kind string) bool
+}
+
+// tokenEstimator approximates the tokens an explanation used (implemented by llm.Manager)
+type tokenEstimator interface {
+	EstimateTokens(s *signal.Signal, explanation string) int
+}
+
+// SetQuotaEnforcer sets the quotas signals and LLM explanations are metered
+// against, per watchlist. Signals over quota are dropped and explanations over
+// quota fall back to the generator's rationale.
+func (m *MarketMonitor) SetQuotaEnforcer(q QuotaEnforcer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.quota = q
+}
+
+// getQuota returns the quota enforcer, if any
+func (m *MarketMonitor) getQuota() QuotaEnforcer {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.quota
+}
+
+// signalTenant returns the tenant a signal is metered under: its watchlist
+func signalTenant(s *signal.Signal) string {
+	if s.Watchlist == "" {
+		return quota.DefaultTenant
+	}
+	return s.Watchlist
+}
+
+// allowSignal consumes a signal from the quota of the signal's tenant and
+// reports whether it may be published
+func (m *MarketMonitor) allowSignal(s *signal.Signal) bool {
+	q := m.getQuota()
+	if q == nil || q.Allow(signalTenant(s), quota.Signals, 1) {
+		return true
+	}
+	log.Printf("Signal quota of %s exhausted, dropping %s signal for %s", signalTenant(s), s.Type, s.Symbol)
+	return false
+}
+
+// allowExplanation reports whether the signal's tenant has LLM tokens left
+func (m *MarketMonitor) allowExplanation(s *signal.Signal) bool {
+	q := m.getQuota()
+	if q == nil || !q.Exhausted(signalTenant(s), quota.LLMTokens) {
+		return true
+	}
+	log.Printf("LLM token quota of %s exhausted, skipping explanation for signal %s", signalTenant(s), s.ID)
+	return false
+}
+
+// recordExplanation meters the tokens an explanation used
+func (m *MarketMonitor) recordExplanation(s *signal.Signal, explanation string) {
+	q := m.getQuota()
+	if q == nil {
+		return
+	}
+
+	tokens := quota.EstimateTokens(explanation)
+	if estimator, ok := m.llmManager.(tokenEstimator); ok {
+		tokens = estimator.EstimateTokens(s, explanation)
+	}
+	q.Record(signalTenant(s), quota.LLMTokens, tokens)
+}
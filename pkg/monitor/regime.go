@@ -0,0 +1,49 @@
+package monitor
+
+import (
+	"log"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/regime"
+)
+
+// RegimeClassifier labels the daily market regime (implemented by regime.Classifier)
+type RegimeClassifier interface {
+	Refresh(now time.Time) (regime.Classification, error)
+}
+
+// SetRegimeClassifier sets the classifier whose regime switches the signal
+// parameters and is attached to every signal
+func (m *MarketMonitor) SetRegimeClassifier(classifier RegimeClassifier) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.regime = classifier
+}
+
+// updateRegime refreshes the market regime and switches the generator to the
+// regime's parameter set
+func (m *MarketMonitor) updateRegime(now time.Time) {
+	m.mu.RLock()
+	classifier := m.regime
+	settings := m.config.Regime
+	m.mu.RUnlock()
+
+	if classifier == nil {
+		return
+	}
+
+	classification, err := classifier.Refresh(now)
+	if err != nil {
+		log.Printf("Error classifying market regime: %v", err)
+	}
+	if classification.Regime == "" {
+		return
+	}
+
+	label := string(classification.Regime)
+	if params, ok := settings.ParamsFor(label); ok {
+		m.signalGen.SetRegime(label, &params)
+	} else {
+		m.signalGen.SetRegime(label, nil)
+	}
+}
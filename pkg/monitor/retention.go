@@ -0,0 +1,70 @@
+package monitor
+
+import (
+	"log"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// defaultSignalHistory bounds the signal history when no count limit is configured
+const defaultSignalHistory = 100
+
+// Archiver stores records spilled from memory by a retention policy (implemented by store.Logger)
+type Archiver interface {
+	ArchiveRecord(kind, key string, createdAt time.Time, record interface{}) error
+}
+
+// SetArchiver sets where signals dropped from the history are stored. Without
+// one they are discarded.
+func (m *MarketMonitor) SetArchiver(archiver Archiver) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.archiver = archiver
+}
+
+// pruneSignalHistory removes signals outside the retention policy and returns
+// them. Active signals are only removed by the count limit, since they still
+// need their outcome resolved. Caller must hold the lock.
+func (m *MarketMonitor) pruneSignalHistory(now time.Time) []*signal.Signal {
+	policy := m.config.Retention.Signals
+	if policy.MaxCount == 0 {
+		policy.MaxCount = defaultSignalHistory
+	}
+
+	var kept, spilled []*signal.Signal
+	total := len(m.signalHistory)
+	for i, s := range m.signalHistory {
+		rank := total - 1 - i
+		keep := policy.Keep(rank, s.GeneratedAt, now)
+		if !keep && s.Status == signal.StatusActive && rank < policy.MaxCount {
+			keep = true
+		}
+		if keep {
+			kept = append(kept, s)
+		} else {
+			spilled = append(spilled, s)
+		}
+	}
+	if len(spilled) > 0 {
+		m.signalHistory = kept
+	}
+	return spilled
+}
+
+// applyRetention spills signals outside the retention policy to the archiver
+func (m *MarketMonitor) applyRetention() {
+	m.mu.Lock()
+	spilled := m.pruneSignalHistory(m.clock.Now())
+	archiver := m.archiver
+	m.mu.Unlock()
+
+	if archiver == nil {
+		return
+	}
+	for _, s := range spilled {
+		if err := archiver.ArchiveRecord("signal", s.ID, s.GeneratedAt, s); err != nil {
+			log.Printf("Error archiving signal %s: %v", s.ID, err)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package monitor
+
+import (
+	"log"
+
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// PriceSource provides the latest tick of a symbol (implemented by data.MarketWatcher)
+type PriceSource interface {
+	GetStock(symbol string) (*data.Stock, bool)
+}
+
+// SetPriceSource sets where the latest prices signals are re-validated
+// against before they are published come from
+func (m *MarketMonitor) SetPriceSource(source PriceSource) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.prices = source
+}
+
+// reviseSignal re-validates a signal against the latest tick before it is
+// published, adjusting its levels if the market moved. It returns false if
+// the signal was dropped. Ticks older than the signal are ignored.
+func (m *MarketMonitor) reviseSignal(s *signal.Signal) bool {
+	m.mu.RLock()
+	source := m.prices
+	cfg := m.config.SignalRevision
+	now := m.clock.Now()
+	m.mu.RUnlock()
+
+	if source == nil || cfg.MaxDriftPercent <= 0 {
+		return true
+	}
+	stock, ok := source.GetStock(s.Symbol)
+	if !ok || stock.LastUpdated.Before(s.GeneratedAt) {
+		return true
+	}
+
+	entry := s.Price
+	switch signal.Revise(s, stock.CurrentPrice, cfg, now) {
+	case signal.RevisionAdjusted:
+		log.Printf("Revised %s signal %s for %s: price moved from $%.2f to $%.2f before publish",
+			s.Type, s.ID, s.Symbol, entry, stock.CurrentPrice)
+	case signal.RevisionDropped:
+		log.Printf("Dropped %s signal %s for %s: price moved from $%.2f to $%.2f before publish",
+			s.Type, s.ID, s.Symbol, entry, stock.CurrentPrice)
+		return false
+	}
+	return true
+}
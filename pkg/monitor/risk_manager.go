@@ -5,28 +5,136 @@ import (
 	"sync"
 	"time"
 
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/config"
 	"github.com/hustler/trading-bot/pkg/data"
 	"github.com/hustler/trading-bot/pkg/execution"
+	"github.com/hustler/trading-bot/pkg/indicators"
+	"github.com/hustler/trading-bot/pkg/market"
+	"github.com/hustler/trading-bot/pkg/symbols"
 )
 
 // RiskManager monitors and enforces risk limits
 type RiskManager struct {
-	maxDailyLoss    float64
-	maxLossPerTrade float64
-	dailyPnL        float64
-	tradeManager    *execution.TradeManager
-	mu              sync.RWMutex
-	tradingDay      time.Time
+	maxDailyLoss     float64
+	maxLossPerTrade  float64
+	dailyPnL         float64
+	tradeManager     *execution.TradeManager
+	profiles         map[string]VolatilityProfile
+	profileDay       time.Time
+	targetVolatility float64
+	sizing           config.SizingConfig
+	payoffs          PayoffSource // History Kelly sizing learns from
+	varConfig        config.VaRConfig
+	varSender        MessageSender // Receives alerts when VaR exceeds the limit
+	latestVaR        *VaRReport
+	breadth          BreadthSource
+	metadata         symbols.MetadataLookup // Sectors for the exposure breakdown; nil omits it
+	holding          config.HoldingConfig
+	shorting         config.ShortingConfig
+	account          config.ComplianceConfig
+	earnings         EarningsCalendar
+	overnightMarks   map[string]float64 // Closing marks of positions carried overnight, by trade ID
+	session          *market.Clock
+	clock            clock.Clock
+	mu               sync.RWMutex
+	tradingDay       time.Time
+}
+
+// BreadthSource provides the latest market breadth (implemented by MarketMonitor)
+type BreadthSource interface {
+	GetBreadth() (indicators.Breadth, bool)
 }
 
 // NewRiskManager creates a new RiskManager
 func NewRiskManager(maxDailyLoss, maxLossPerTrade float64, tradeManager *execution.TradeManager) *RiskManager {
+	session := market.DefaultClock()
 	return &RiskManager{
-		maxDailyLoss:    maxDailyLoss,
-		maxLossPerTrade: maxLossPerTrade,
-		tradeManager:    tradeManager,
-		tradingDay:      time.Now().Truncate(24 * time.Hour),
+		maxDailyLoss:     maxDailyLoss,
+		maxLossPerTrade:  maxLossPerTrade,
+		tradeManager:     tradeManager,
+		profiles:         make(map[string]VolatilityProfile),
+		overnightMarks:   make(map[string]float64),
+		targetVolatility: defaultTargetVolatility,
+		session:          session,
+		clock:            clock.Real{},
+		tradingDay:       session.TradingDay(time.Now()),
+	}
+}
+
+// SetClock sets the clock the risk manager reads the time from
+func (r *RiskManager) SetClock(c clock.Clock) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.clock = c
+	r.tradingDay = r.session.TradingDay(c.Now())
+}
+
+// SetMarketClock sets the exchange session used for trading hours and trading days
+func (r *RiskManager) SetMarketClock(session *market.Clock) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.session = session
+}
+
+// MarketClock returns the exchange session clock
+func (r *RiskManager) MarketClock() *market.Clock {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.session
+}
+
+// now returns the current time from the risk manager's clock
+func (r *RiskManager) now() time.Time {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.clock.Now()
+}
+
+// SetBreadthSource sets the source of market breadth included in risk reports
+func (r *RiskManager) SetBreadthSource(source BreadthSource) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.breadth = source
+}
+
+// SetMetadata sets the symbol reference data used for sector exposure in risk reports
+func (r *RiskManager) SetMetadata(metadata symbols.MetadataLookup) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.metadata = metadata
+}
+
+// SectorExposure returns the current value of active positions grouped by sector
+func (r *RiskManager) SectorExposure(stocks map[string]*data.Stock) []symbols.SectorWeight {
+	r.mu.RLock()
+	lookup := r.metadata
+	r.mu.RUnlock()
+
+	if lookup == nil {
+		return nil
+	}
+	return r.sectorExposure(stocks, lookup)
+}
+
+// sectorExposure groups the current value of active positions by sector
+func (r *RiskManager) sectorExposure(stocks map[string]*data.Stock, lookup symbols.MetadataLookup) []symbols.SectorWeight {
+	values := make(map[string]float64)
+	for _, trade := range r.tradeManager.GetActiveTrades() {
+		price := trade.Price
+		if stock, ok := stocks[trade.Symbol]; ok && stock.CurrentPrice > 0 {
+			price = stock.CurrentPrice
+		}
+		values[trade.Symbol] += float64(trade.Quantity) * price
+	}
+	if len(values) == 0 {
+		return nil
 	}
+	return symbols.SectorExposure(values, lookup)
 }
 
 // CheckDailyLoss checks if the daily loss limit has been reached
@@ -35,7 +143,7 @@ func (r *RiskManager) CheckDailyLoss(stocks map[string]*data.Stock) (bool, float
 	defer r.mu.Unlock()
 
 	// Reset PnL if it's a new trading day
-	today := time.Now().Truncate(24 * time.Hour)
+	today := r.session.TradingDay(r.clock.Now())
 	if !today.Equal(r.tradingDay) {
 		r.dailyPnL = 0
 		r.tradingDay = today
@@ -51,10 +159,13 @@ func (r *RiskManager) CheckDailyLoss(stocks map[string]*data.Stock) (bool, float
 			continue
 		}
 
-		// Calculate trade PnL
-		entryValue := float64(trade.Quantity) * trade.Price
+		// Calculate trade PnL since the last close for positions carried overnight
+		entryValue := float64(trade.Quantity) * r.costBasis(trade)
 		currentValue := float64(trade.Quantity) * stock.CurrentPrice
 		tradePnL := currentValue - entryValue
+		if trade.IsShort() {
+			tradePnL = -tradePnL
+		}
 
 		// Add to current PnL
 		currentPnL += tradePnL
@@ -68,22 +179,29 @@ func (r *RiskManager) CheckDailyLoss(stocks map[string]*data.Stock) (bool, float
 	return false, currentPnL
 }
 
-// UpdateDailyPnL updates the daily PnL with a completed trade
+// UpdateDailyPnL updates the daily PnL with an exit from a position: a sale
+// from a long or a cover of a short
 func (r *RiskManager) UpdateDailyPnL(buyTrade, sellTrade *execution.Trade) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	// Reset PnL if it's a new trading day
-	today := time.Now().Truncate(24 * time.Hour)
+	today := r.session.TradingDay(r.clock.Now())
 	if !today.Equal(r.tradingDay) {
 		r.dailyPnL = 0
 		r.tradingDay = today
 	}
 
-	// Calculate trade PnL
-	buyValue := float64(buyTrade.Quantity) * buyTrade.Price
+	// Calculate trade PnL on the quantity exited, which may be part of the position
+	buyValue := float64(sellTrade.Quantity) * r.costBasis(buyTrade)
 	sellValue := float64(sellTrade.Quantity) * sellTrade.Price
 	tradePnL := sellValue - buyValue
+	if buyTrade.IsShort() {
+		tradePnL = -tradePnL
+	}
+	if buyTrade.Status == execution.Completed {
+		delete(r.overnightMarks, buyTrade.ID)
+	}
 
 	// Update daily PnL
 	r.dailyPnL += tradePnL
@@ -96,57 +214,19 @@ func (r *RiskManager) GetDailyPnL() float64 {
 	return r.dailyPnL
 }
 
-// IsTradingHours checks if it's currently trading hours (9:30 AM - 4:00 PM EST)
+// SessionState returns the current state of the exchange session
+func (r *RiskManager) SessionState() market.SessionState {
+	return r.MarketClock().State(r.now())
+}
+
+// IsTradingHours checks if the regular session is open (9:30 AM - 4:00 PM Eastern)
 func (r *RiskManager) IsTradingHours() bool {
-	now := time.Now()
-	
-	// Convert to EST
-	loc, err := time.LoadLocation("America/New_York")
-	if err != nil {
-		// If we can't load the location, use a simple approximation
-		// Assuming UTC-5 for EST (not accounting for daylight saving)
-		offset := -5 * 60 * 60
-		now = now.UTC().Add(time.Duration(offset) * time.Second)
-	} else {
-		now = now.In(loc)
-	}
-	
-	// Check if it's a weekday
-	if now.Weekday() == time.Saturday || now.Weekday() == time.Sunday {
-		return false
-	}
-	
-	// Check if it's between 9:30 AM and 4:00 PM
-	marketOpen := time.Date(now.Year(), now.Month(), now.Day(), 9, 30, 0, 0, now.Location())
-	marketClose := time.Date(now.Year(), now.Month(), now.Day(), 16, 0, 0, 0, now.Location())
-	
-	return now.After(marketOpen) && now.Before(marketClose)
+	return r.SessionState().IsRegular()
 }
 
-// ShouldCloseAllPositions checks if all positions should be closed (end of trading day)
+// ShouldCloseAllPositions checks if all positions should be closed (within 5 minutes of the close)
 func (r *RiskManager) ShouldCloseAllPositions() bool {
-	now := time.Now()
-	
-	// Convert to EST
-	loc, err := time.LoadLocation("America/New_York")
-	if err != nil {
-		// If we can't load the location, use a simple approximation
-		offset := -5 * 60 * 60
-		now = now.UTC().Add(time.Duration(offset) * time.Second)
-	} else {
-		now = now.In(loc)
-	}
-	
-	// Check if it's a weekday
-	if now.Weekday() == time.Saturday || now.Weekday() == time.Sunday {
-		return false
-	}
-	
-	// Check if it's close to 4:00 PM (within 5 minutes)
-	marketClose := time.Date(now.Year(), now.Month(), now.Day(), 16, 0, 0, 0, now.Location())
-	fiveMinBeforeClose := marketClose.Add(-5 * time.Minute)
-	
-	return now.After(fiveMinBeforeClose) && now.Before(marketClose)
+	return r.MarketClock().NearClose(r.now(), 5*time.Minute)
 }
 
 // GenerateRiskReport generates a risk report
@@ -178,19 +258,73 @@ func (r *RiskManager) GenerateRiskReport(stocks map[string]*data.Stock) string {
 			entryValue := float64(trade.Quantity) * trade.Price
 			currentValue := float64(trade.Quantity) * stock.CurrentPrice
 			tradePnL := currentValue - entryValue
+			side := "Long"
+			if trade.IsShort() {
+				tradePnL = -tradePnL
+				side = "Short"
+			}
 			pnlPercent := (tradePnL / entryValue) * 100
 			
-			report += fmt.Sprintf("Symbol: %s\n", trade.Symbol)
+			report += fmt.Sprintf("Symbol: %s (%s)\n", trade.Symbol, side)
 			report += fmt.Sprintf("Quantity: %d\n", trade.Quantity)
 			report += fmt.Sprintf("Entry Price: $%.2f\n", trade.Price)
 			report += fmt.Sprintf("Current Price: $%.2f\n", stock.CurrentPrice)
 			report += fmt.Sprintf("P&L: $%.2f (%.2f%%)\n", tradePnL, pnlPercent)
 			report += fmt.Sprintf("Entry Value: $%.2f\n", entryValue)
 			report += fmt.Sprintf("Current Value: $%.2f\n", currentValue)
-			report += fmt.Sprintf("Time in Trade: %s\n\n", time.Since(trade.CreatedAt).Round(time.Second))
+			report += fmt.Sprintf("Time in Trade: %s\n\n", r.clock.Now().Sub(trade.CreatedAt).Round(time.Second))
+		}
+	}
+	
+	if r.breadth != nil {
+		if breadth, ok := r.breadth.GetBreadth(); ok {
+			report += "Market Breadth:\n"
+			report += "---------------\n"
+			report += breadth.Summary() + "\n"
+		}
+	}
+	
+	if r.metadata != nil {
+		if exposure := r.sectorExposure(stocks, r.metadata); len(exposure) > 0 {
+			report += "Sector Exposure:\n"
+			report += "----------------\n"
+			for _, weight := range exposure {
+				report += fmt.Sprintf("%s: $%.2f (%.1f%%) %v\n", weight.Sector, weight.Value, weight.Percent, weight.Symbols)
+			}
+			report += "\n"
 		}
 	}
 	
+	if r.shorting.Enabled {
+		margin := r.marginStatus(stocks)
+		report += "Short Margin:\n"
+		report += "-------------\n"
+		report += fmt.Sprintf("Posted: $%.2f of $%.2f\n", margin.Posted, margin.Capital)
+		report += fmt.Sprintf("Equity: $%.2f, maintenance requirement $%.2f\n", margin.Equity, margin.Maintenance)
+		if margin.Call {
+			report += "WARNING: Short equity below maintenance margin!\n"
+		}
+		report += "\n"
+	}
+	
+	if r.account.Cash > 0 {
+		balance := balanceOf(r.account, r.session, r.positions(), stockPrices(stocks), r.clock.Now())
+		report += "Account:\n"
+		report += "--------\n"
+		report += fmt.Sprintf("Equity: $%.2f\n", balance.Equity)
+		report += fmt.Sprintf("Cash: $%.2f settled, $%.2f unsettled\n", balance.Settled, balance.Unsettled)
+		if balance.Margin {
+			report += fmt.Sprintf("Margin Used: $%.2f\n", balance.MarginUsed)
+		}
+		report += fmt.Sprintf("Buying Power: $%.2f\n\n", balance.BuyingPower)
+	}
+	
+	if r.latestVaR != nil {
+		report += "Value at Risk:\n"
+		report += "--------------\n"
+		report += r.latestVaR.Summary() + "\n"
+	}
+	
 	report += "Risk Status:\n"
 	report += "-----------\n"
 	
@@ -201,14 +335,18 @@ func (r *RiskManager) GenerateRiskReport(stocks map[string]*data.Stock) string {
 		report += "Daily loss limit not reached\n"
 	}
 	
-	if r.IsTradingHours() {
-		report += "Currently within trading hours\n"
+	if state := r.SessionState(); state.IsRegular() {
+		report += fmt.Sprintf("Currently within trading hours (%s)\n", state)
 	} else {
-		report += "Outside of trading hours\n"
+		report += fmt.Sprintf("Outside of trading hours (%s)\n", state)
 	}
 	
 	if r.ShouldCloseAllPositions() {
-		report += "WARNING: Close to market close, should close all positions\n"
+		if r.holding.IsSwing() {
+			report += "WARNING: Close to market close, positions outside the carry rules will be closed\n"
+		} else {
+			report += "WARNING: Close to market close, should close all positions\n"
+		}
 	}
 	
 	return report
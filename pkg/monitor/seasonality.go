@@ -0,0 +1,63 @@
+package monitor
+
+import (
+	"log"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/market"
+	"github.com/hustler/trading-bot/pkg/seasonality"
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// SeasonalityTracker computes intraday seasonality profiles (implemented by seasonality.Tracker)
+type SeasonalityTracker interface {
+	Refresh(now time.Time, symbols []string) error
+	Profile(symbol string) (seasonality.Profile, bool)
+	Session() *market.Clock
+}
+
+// SetSeasonalityTracker sets the tracker whose profiles are refreshed daily
+// and attached to signals
+func (m *MarketMonitor) SetSeasonalityTracker(tracker SeasonalityTracker) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.seasonality = tracker
+}
+
+// updateSeasonality refreshes the seasonality profiles of symbols
+func (m *MarketMonitor) updateSeasonality(now time.Time, symbols []string) {
+	m.mu.RLock()
+	tracker := m.seasonality
+	m.mu.RUnlock()
+
+	if tracker == nil {
+		return
+	}
+	if err := tracker.Refresh(now, symbols); err != nil {
+		log.Printf("Error refreshing intraday seasonality: %v", err)
+	}
+}
+
+// addSeasonality attaches the typical behavior of the signal's symbol for the
+// rest of the session and, when configured, sets its time frame from the
+// hours until the typical reversal
+func (m *MarketMonitor) addSeasonality(s *signal.Signal, now time.Time) {
+	m.mu.RLock()
+	tracker := m.seasonality
+	settings := m.config.Seasonality
+	m.mu.RUnlock()
+
+	if tracker == nil {
+		return
+	}
+	profile, ok := tracker.Profile(s.Symbol)
+	if !ok {
+		return
+	}
+
+	session := tracker.Session()
+	s.Seasonality = profile.Summary(now, session)
+	if settings.AdjustTimeFrame && session.IsOpen(now) {
+		s.TimeFrame = profile.TimeFrame(now, session)
+	}
+}
@@ -0,0 +1,110 @@
+package monitor
+
+import (
+	"log"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/market"
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// sessionTracker follows the trading day through its session states
+type sessionTracker struct {
+	clock     *market.Clock
+	fixed     bool // The clock was set rather than built from the config
+	current   market.SessionState
+	listeners []func(from, to market.SessionState)
+}
+
+// SetMarketClock sets the exchange session the day's states are read from. By
+// default it is the regular US session divided by the session config.
+func (m *MarketMonitor) SetMarketClock(session *market.Clock) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.session.clock = session
+	m.session.fixed = true
+}
+
+// OnSessionChange registers a callback invoked when the trading day moves to
+// a new session state, e.g. from the opening window to midday
+func (m *MarketMonitor) OnSessionChange(fn func(from, to market.SessionState)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.session.listeners = append(m.session.listeners, fn)
+}
+
+// GetSessionState returns the session state as of the last update
+func (m *MarketMonitor) GetSessionState() market.SessionState {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.session.current
+}
+
+// updateSession moves to the session state at now and returns it. Listeners
+// are notified of every change after the first state is known.
+func (m *MarketMonitor) updateSession(now time.Time) market.SessionState {
+	m.mu.Lock()
+	if m.session.clock == nil {
+		session, err := m.config.Session.Clock()
+		if err != nil {
+			log.Printf("Invalid session windows, using the defaults: %v", err)
+			session = market.DefaultClock()
+		}
+		m.session.clock = session
+	}
+	from, to := m.session.current, m.session.clock.State(now)
+	m.session.current = to
+	listeners := make([]func(from, to market.SessionState), len(m.session.listeners))
+	copy(listeners, m.session.listeners)
+	m.mu.Unlock()
+
+	if from == "" || from == to {
+		return to
+	}
+	log.Printf("Market session moved from %s to %s", from, to)
+	for _, fn := range listeners {
+		fn(from, to)
+	}
+	return to
+}
+
+// filterForSession drops signals below the session state's boosted confidence
+// threshold or from strategies the state does not allow
+func filterForSession(signals []*signal.Signal, adjustments config.SessionStateConfig, threshold float64) []*signal.Signal {
+	if adjustments.ConfidenceBoost == 0 && len(adjustments.Strategies) == 0 {
+		return signals
+	}
+
+	allowed := make(map[string]bool)
+	for _, name := range adjustments.Strategies {
+		allowed[name] = true
+	}
+
+	minConfidence := threshold + adjustments.ConfidenceBoost
+	kept := signals[:0]
+	for _, s := range signals {
+		if s.Confidence < minConfidence {
+			continue
+		}
+		if len(allowed) > 0 && !votedBy(s, allowed) {
+			continue
+		}
+		kept = append(kept, s)
+	}
+	return kept
+}
+
+// votedBy reports whether one of the strategies behind a signal is allowed.
+// Signals without ensemble votes come from the volatility strategy.
+func votedBy(s *signal.Signal, allowed map[string]bool) bool {
+	if len(s.Strategies) == 0 {
+		return allowed["volatility"]
+	}
+	for _, name := range s.Strategies {
+		if allowed[name] {
+			return true
+		}
+	}
+	return false
+}
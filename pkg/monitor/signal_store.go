@@ -0,0 +1,46 @@
+package monitor
+
+import (
+	"log"
+
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// SignalStore persists signals so they can be searched (implemented by store.Logger)
+type SignalStore interface {
+	SaveSignal(s *signal.Signal) error
+	CloseSignal(s *signal.Signal, exitPrice float64) error
+}
+
+// SetSignalStore sets where new signals are saved. Adjusted and closed
+// signals are saved again so stored levels and outcomes stay current.
+func (m *MarketMonitor) SetSignalStore(store SignalStore) {
+	m.mu.Lock()
+	m.signalStore = store
+	m.mu.Unlock()
+
+	m.OnSignalAdjusted(func(s *signal.Signal) {
+		if err := store.SaveSignal(s); err != nil {
+			log.Printf("Error saving adjusted signal %s: %v", s.ID, err)
+		}
+	})
+	m.OnSignalClosed(func(s *signal.Signal, exitPrice float64) {
+		if err := store.CloseSignal(s, exitPrice); err != nil {
+			log.Printf("Error saving closed signal %s: %v", s.ID, err)
+		}
+	})
+}
+
+// saveSignal saves a new signal to the signal store, if one is set
+func (m *MarketMonitor) saveSignal(s *signal.Signal) {
+	m.mu.RLock()
+	store := m.signalStore
+	m.mu.RUnlock()
+
+	if store == nil {
+		return
+	}
+	if err := store.SaveSignal(s); err != nil {
+		log.Printf("Error saving signal %s: %v", s.ID, err)
+	}
+}
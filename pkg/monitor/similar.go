@@ -0,0 +1,48 @@
+package monitor
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/signal"
+	"github.com/hustler/trading-bot/pkg/similarity"
+)
+
+// SetupIndex embeds signal contexts and finds similar historical setups (implemented by similarity.Index)
+type SetupIndex interface {
+	Describe(ctx context.Context, s *signal.Signal) (similarity.Summary, error)
+}
+
+// SetSetupIndex sets the index new signals are compared against. The outcomes
+// of similar past setups are added to each signal's rationale.
+func (m *MarketMonitor) SetSetupIndex(index SetupIndex) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.setups = index
+}
+
+// addSimilarSetups records a new signal in the setup index and appends the
+// outcomes of similar past setups to its rationale
+func (m *MarketMonitor) addSimilarSetups(s *signal.Signal) {
+	m.mu.RLock()
+	index := m.setups
+	m.mu.RUnlock()
+
+	if index == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	summary, err := index.Describe(ctx, s)
+	if err != nil {
+		log.Printf("Error finding similar setups for signal %s: %v", s.ID, err)
+		return
+	}
+	if text := summary.String(); text != "" {
+		s.Rationale = strings.TrimSpace(s.Rationale + "\n\n" + text + ".")
+	}
+}
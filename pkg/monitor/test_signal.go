@@ -0,0 +1,60 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hustler/trading-bot/pkg/ids"
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// TestSignalPrefix starts the ID of every fabricated test signal
+const TestSignalPrefix = "TEST"
+
+// SendTestSignal fabricates a clearly labeled TEST signal for symbol at its
+// latest price and sends it through the real pipeline: LLM explanation,
+// notifier, publish listeners and signal store. It returns the signal even if
+// delivery failed, along with the error.
+func (m *MarketMonitor) SendTestSignal(symbol string) (*signal.Signal, error) {
+	results, errs := m.dataProvider.GetMarketDataBatch([]string{symbol})
+	if err := errs[symbol]; err != nil {
+		return nil, fmt.Errorf("failed to get market data for %s: %w", symbol, err)
+	}
+	md := results[symbol]
+	if md == nil || len(md.Prices) == 0 {
+		return nil, fmt.Errorf("no market data for %s", symbol)
+	}
+
+	now := m.clock.Now()
+	price := md.Prices[len(md.Prices)-1]
+	s := &signal.Signal{
+		SchemaVersion: signal.CurrentSchemaVersion,
+		ID:            ids.New(fmt.Sprintf("%s-%s", TestSignalPrefix, symbol), now),
+		Symbol:        symbol,
+		Type:          signal.BUY,
+		Price:         price,
+		TargetPrice:   price * 1.02,
+		StopLoss:      price * 0.99,
+		ExpectedROI:   2,
+		Confidence:    0.5,
+		Rationale:     "Test signal sent by an admin to verify delivery.",
+		GeneratedAt:   now,
+		TimeFrame:     "test",
+		Status:        signal.StatusActive,
+		StatusChanges: []signal.StatusChange{{Status: signal.StatusActive, At: now}},
+		Test:          true,
+		Currency:      signal.CurrencyFor(symbol),
+		OrderType:     signal.OrderMarket,
+	}
+
+	err := m.processSignal(context.Background(), s, now, nil)
+
+	// A background explanation may still update the signal; return a copy
+	m.mu.RLock()
+	sent := s.Clone()
+	m.mu.RUnlock()
+	if err != nil {
+		return sent, fmt.Errorf("failed to deliver test signal: %w", err)
+	}
+	return sent, nil
+}
@@ -0,0 +1,136 @@
+package monitor
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// maxShadowSignals caps how many throttled signals are kept for shadow tracking
+const maxShadowSignals = 200
+
+// throttleState counts the signals published today and tracks the signals
+// held back by the daily caps
+type throttleState struct {
+	day       string
+	published int
+	bySymbol  map[string]int
+	shadow    []*signal.Signal // Most recent throttled signals, oldest first
+	report    ShadowReport
+}
+
+// ShadowReport tallies the signals held back by the daily caps and how they
+// would have done had they been published
+type ShadowReport struct {
+	Day       string           `json:"day"`
+	Published int              `json:"published"` // Signals published today
+	Throttled int              `json:"throttled"` // Signals held back since startup
+	Successes int              `json:"successes"`
+	Failures  int              `json:"failures"`
+	Expired   int              `json:"expired"`
+	Recent    []*signal.Signal `json:"recent"` // Most recent throttled signals, newest first
+}
+
+// throttleReason returns why a signal is over a daily cap, or "" if it may be
+// published. Counts reset at the start of each day.
+func (m *MarketMonitor) throttleReason(s *signal.Signal, now time.Time) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t := &m.throttle
+	if day := now.Format("2006-01-02"); t.day != day {
+		t.day = day
+		t.published = 0
+		t.bySymbol = make(map[string]int)
+	}
+
+	caps := m.config.Throttle
+	switch {
+	case caps.MaxPerDay > 0 && t.published >= caps.MaxPerDay:
+		return fmt.Sprintf("daily cap of %d signals", caps.MaxPerDay)
+	case caps.MaxPerSymbolPerDay > 0 && t.bySymbol[s.Symbol] >= caps.MaxPerSymbolPerDay:
+		return fmt.Sprintf("daily cap of %d signals for %s", caps.MaxPerSymbolPerDay, s.Symbol)
+	}
+	return ""
+}
+
+// recordPublished counts a delivered signal against today's caps. Signals
+// that failed to send or were dropped before sending do not count.
+func (m *MarketMonitor) recordPublished(s *signal.Signal) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.throttle.bySymbol == nil {
+		m.throttle.bySymbol = make(map[string]int)
+	}
+	m.throttle.published++
+	m.throttle.bySymbol[s.Symbol]++
+}
+
+// shadowSignal holds back a signal over a daily cap, tracking its outcome
+// without publishing it
+func (m *MarketMonitor) shadowSignal(s *signal.Signal, reason string) {
+	log.Printf("Throttled %s signal for %s (%s), tracking in shadow mode", s.Type, s.Symbol, reason)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.throttle.shadow = append(m.throttle.shadow, s)
+	if len(m.throttle.shadow) > maxShadowSignals {
+		m.throttle.shadow = m.throttle.shadow[len(m.throttle.shadow)-maxShadowSignals:]
+	}
+	m.throttle.report.Throttled++
+}
+
+// resolveShadowSignals closes throttled signals that hit their target or stop,
+// or expired, as if they had been published
+func (m *MarketMonitor) resolveShadowSignals(marketData map[string]*data.MarketData) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.clock.Now()
+	for _, s := range m.throttle.shadow {
+		md, ok := marketData[s.Symbol]
+		if !ok || md == nil || len(md.Prices) == 0 {
+			continue
+		}
+
+		status := signal.ResolveOutcome(s, md.Prices[len(md.Prices)-1], now, signalExpiry)
+		if status == "" {
+			continue
+		}
+		if err := s.SetStatus(status, now); err != nil {
+			log.Printf("Error closing throttled signal: %v", err)
+			continue
+		}
+		switch status {
+		case signal.StatusSuccess:
+			m.throttle.report.Successes++
+		case signal.StatusFailure:
+			m.throttle.report.Failures++
+		case signal.StatusExpired:
+			m.throttle.report.Expired++
+		}
+		log.Printf("Throttled signal %s would have closed as %s", s.ID, status)
+	}
+}
+
+// GetShadowReport returns today's published count and how the throttled
+// signals did
+func (m *MarketMonitor) GetShadowReport() ShadowReport {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	report := m.throttle.report
+	report.Day = m.throttle.day
+	report.Published = m.throttle.published
+	report.Recent = make([]*signal.Signal, 0, len(m.throttle.shadow))
+	for i := len(m.throttle.shadow) - 1; i >= 0; i-- {
+		shadow := *m.throttle.shadow[i]
+		report.Recent = append(report.Recent, &shadow)
+	}
+	return report
+}
@@ -0,0 +1,104 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/signal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newThrottledMonitor returns a monitor capping signals at 3 a day and 2 per symbol
+func newThrottledMonitor() (*MarketMonitor, *MockTelegramBot) {
+	cfg := config.CreateDefaultConfig()
+	cfg.Throttle = config.ThrottleConfig{MaxPerDay: 3, MaxPerSymbolPerDay: 2}
+	telegramBot := &MockTelegramBot{}
+	return NewMarketMonitor(cfg, nil, nil, &MockLLMManager{}, telegramBot), telegramBot
+}
+
+// throttledSignal returns an active BUY signal for symbol at $100
+func throttledSignal(id, symbol string, at time.Time) *signal.Signal {
+	return &signal.Signal{ID: id, Symbol: symbol, Type: signal.BUY, Price: 100, TargetPrice: 102, StopLoss: 99,
+		GeneratedAt: at, Status: signal.StatusActive}
+}
+
+func TestThrottleReason(t *testing.T) {
+	monitor, _ := newThrottledMonitor()
+	now := time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC)
+
+	assert.Empty(t, monitor.throttleReason(throttledSignal("1", "AAPL", now), now))
+	monitor.recordPublished(throttledSignal("1", "AAPL", now))
+	monitor.recordPublished(throttledSignal("2", "AAPL", now))
+
+	// AAPL reached its own cap, others still fit under the daily one
+	assert.Equal(t, "daily cap of 2 signals for AAPL", monitor.throttleReason(throttledSignal("3", "AAPL", now), now))
+	assert.Empty(t, monitor.throttleReason(throttledSignal("3", "MSFT", now), now))
+	monitor.recordPublished(throttledSignal("3", "MSFT", now))
+	assert.Equal(t, "daily cap of 3 signals", monitor.throttleReason(throttledSignal("4", "NVDA", now), now))
+
+	// The caps reset the next day
+	tomorrow := now.Add(24 * time.Hour)
+	assert.Empty(t, monitor.throttleReason(throttledSignal("5", "AAPL", tomorrow), tomorrow))
+	assert.Equal(t, 0, monitor.GetShadowReport().Published)
+}
+
+func TestProcessSignalCountsOnlyDelivered(t *testing.T) {
+	monitor, telegramBot := newThrottledMonitor()
+	monitor.llmManager.(*MockLLMManager).On("GenerateSignalExplanation", mock.Anything, mock.Anything).Return("", errors.New("no model"))
+	telegramBot.On("SendSignal", mock.Anything).Return(errors.New("telegram down")).Once()
+	telegramBot.On("SendSignal", mock.Anything).Return(nil)
+	now := time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC)
+	monitor.throttleReason(throttledSignal("0", "AAPL", now), now) // Start the day
+
+	// A failed delivery does not use up the cap
+	err := monitor.processSignal(context.Background(), throttledSignal("1", "AAPL", now), now, nil)
+	assert.Error(t, err)
+	assert.Equal(t, 0, monitor.GetShadowReport().Published)
+
+	assert.NoError(t, monitor.processSignal(context.Background(), throttledSignal("2", "AAPL", now), now, nil))
+	assert.Equal(t, 1, monitor.GetShadowReport().Published)
+
+	// Test signals never count
+	test := throttledSignal("3", "AAPL", now)
+	test.Test = true
+	assert.NoError(t, monitor.processSignal(context.Background(), test, now, nil))
+	assert.Equal(t, 1, monitor.GetShadowReport().Published)
+}
+
+func TestShadowSignalsResolve(t *testing.T) {
+	monitor, _ := newThrottledMonitor()
+	now := time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC)
+	monitor.SetClock(clock.NewFake(now.Add(time.Hour)))
+
+	monitor.shadowSignal(throttledSignal("1", "AAPL", now), "daily cap of 3 signals")
+	monitor.shadowSignal(throttledSignal("2", "MSFT", now), "daily cap of 3 signals")
+	monitor.shadowSignal(throttledSignal("3", "NVDA", now.Add(-4*time.Hour)), "daily cap of 3 signals")
+
+	monitor.resolveShadowSignals(map[string]*data.MarketData{
+		"AAPL": {Prices: []float64{101, 103}},
+		"MSFT": {Prices: []float64{98.5}},
+		"NVDA": {Prices: []float64{100}},
+	})
+
+	report := monitor.GetShadowReport()
+	assert.Equal(t, 3, report.Throttled)
+	assert.Equal(t, 1, report.Successes)
+	assert.Equal(t, 1, report.Failures)
+	assert.Equal(t, 1, report.Expired)
+	if assert.Len(t, report.Recent, 3) {
+		assert.Equal(t, "3", report.Recent[0].ID, "newest first")
+		assert.Equal(t, signal.StatusSuccess, report.Recent[2].Status)
+	}
+
+	// The shadow list is capped
+	for i := 0; i < maxShadowSignals; i++ {
+		monitor.shadowSignal(throttledSignal("x", "AMD", now), "daily cap of 3 signals")
+	}
+	assert.Len(t, monitor.GetShadowReport().Recent, maxShadowSignals)
+}
@@ -0,0 +1,28 @@
+package monitor
+
+import (
+	"context"
+
+	"github.com/hustler/trading-bot/pkg/tracing"
+)
+
+// SetTracer sets the tracer recording a trace per market check, from data
+// fetch through persisting the signals
+func (m *MarketMonitor) SetTracer(tracer *tracing.Tracer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tracer = tracer
+}
+
+// startCheckTrace starts the root span of a market check. Without a tracer
+// the span is nil and the check runs untraced.
+func (m *MarketMonitor) startCheckTrace(full bool, symbols int) (context.Context, *tracing.Span) {
+	m.mu.RLock()
+	tracer := m.tracer
+	m.mu.RUnlock()
+
+	ctx, span := tracer.Start(context.Background(), "market_check")
+	span.SetAttribute("full", full)
+	span.SetAttribute("symbols", symbols)
+	return ctx, span
+}
@@ -0,0 +1,148 @@
+package monitor
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/indicators"
+)
+
+// PositionExposure is the market value of one open position
+type PositionExposure struct {
+	Symbol   string  `json:"symbol"`
+	Quantity int     `json:"quantity"`
+	Price    float64 `json:"price"`
+	Value    float64 `json:"value"`
+	Weight   float64 `json:"weight"` // Share of gross exposure, 0-1
+}
+
+// VaRReport is the value at risk and exposure of the open portfolio on a trading day
+type VaRReport struct {
+	Day           time.Time          `json:"day"`
+	Confidence    float64            `json:"confidence"`
+	VaR           float64            `json:"var"`         // One-day loss in dollars not exceeded at Confidence
+	VaRPercent    float64            `json:"var_percent"` // VaR as a percent of gross exposure
+	GrossExposure float64            `json:"gross_exposure"`
+	Positions     []PositionExposure `json:"positions"`
+	Scenarios     int                `json:"scenarios"` // Historical days the VaR was computed from
+	Limit         float64            `json:"limit"`
+	Breached      bool               `json:"breached"`
+	ComputedAt    time.Time          `json:"computed_at"`
+}
+
+// Summary formats the report for the risk report and alerts
+func (v VaRReport) Summary() string {
+	summary := fmt.Sprintf("1-day VaR (%.0f%%): $%.2f (%.2f%% of $%.2f gross exposure, %d scenarios)\n",
+		v.Confidence*100, v.VaR, v.VaRPercent, v.GrossExposure, v.Scenarios)
+	for _, p := range v.Positions {
+		summary += fmt.Sprintf("  %s: %d @ $%.2f = $%.2f (%.1f%%)\n", p.Symbol, p.Quantity, p.Price, p.Value, p.Weight*100)
+	}
+	if v.Breached {
+		summary += fmt.Sprintf("WARNING: VaR exceeds the $%.2f limit!\n", v.Limit)
+	}
+	return summary
+}
+
+// SetVaR enables the daily value at risk computation. sender, if not nil,
+// receives an alert when VaR exceeds the configured limit.
+func (r *RiskManager) SetVaR(cfg config.VaRConfig, sender MessageSender) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.varConfig = cfg
+	r.varSender = sender
+}
+
+// LatestVaR returns the most recent value at risk report
+func (r *RiskManager) LatestVaR() (VaRReport, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.latestVaR == nil {
+		return VaRReport{}, false
+	}
+	return *r.latestVaR, true
+}
+
+// RefreshVaR computes the historical value at risk of the open positions once
+// per trading day, valuing them at the latest quotes. It returns the day's
+// report, computing it only on the first call of the day.
+func (r *RiskManager) RefreshVaR(source DailyHistorySource, stocks map[string]*data.Stock) (VaRReport, error) {
+	r.mu.RLock()
+	cfg := r.varConfig
+	sender := r.varSender
+	now := r.clock.Now()
+	today := r.session.TradingDay(now)
+	latest := r.latestVaR
+	r.mu.RUnlock()
+
+	if !cfg.Enabled {
+		return VaRReport{}, fmt.Errorf("value at risk is not enabled")
+	}
+	if latest != nil && latest.Day.Equal(today) {
+		return *latest, nil
+	}
+
+	report := VaRReport{Day: today, Confidence: cfg.Confidence, Limit: cfg.Limit, ComputedAt: now}
+
+	// Aggregate open positions by symbol at the latest price
+	bySymbol := make(map[string]*PositionExposure)
+	for _, trade := range r.tradeManager.GetActiveTrades() {
+		price := trade.Price
+		if stock, ok := stocks[trade.Symbol]; ok && stock.CurrentPrice > 0 {
+			price = stock.CurrentPrice
+		}
+		p, ok := bySymbol[trade.Symbol]
+		if !ok {
+			p = &PositionExposure{Symbol: trade.Symbol, Price: price}
+			bySymbol[trade.Symbol] = p
+		}
+		p.Quantity += trade.Quantity
+		p.Value += float64(trade.Quantity) * price
+	}
+
+	values := make(map[string]float64, len(bySymbol))
+	closes := make(map[string][]float64, len(bySymbol))
+	for symbol, p := range bySymbol {
+		report.GrossExposure += p.Value
+		values[symbol] = p.Value
+
+		history, err := source.GetDailyHistory(symbol, cfg.LookbackDays+1)
+		if err != nil {
+			log.Printf("Error fetching daily history for %s, leaving it out of VaR: %v", symbol, err)
+			continue
+		}
+		closes[symbol] = history.Prices
+	}
+
+	for _, p := range bySymbol {
+		if report.GrossExposure > 0 {
+			p.Weight = p.Value / report.GrossExposure
+		}
+		report.Positions = append(report.Positions, *p)
+	}
+	sort.Slice(report.Positions, func(i, j int) bool {
+		return report.Positions[i].Value > report.Positions[j].Value
+	})
+
+	report.VaR, report.Scenarios = indicators.HistoricalVaR(values, closes, cfg.Confidence)
+	if report.GrossExposure > 0 {
+		report.VaRPercent = report.VaR / report.GrossExposure * 100
+	}
+	report.Breached = cfg.Limit > 0 && report.VaR > cfg.Limit
+
+	r.mu.Lock()
+	r.latestVaR = &report
+	r.mu.Unlock()
+
+	if report.Breached && sender != nil {
+		if err := sender.SendMessage("Risk alert: value at risk over limit\n" + report.Summary()); err != nil {
+			return report, fmt.Errorf("failed to send VaR alert: %w", err)
+		}
+	}
+	return report, nil
+}
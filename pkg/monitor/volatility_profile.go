@@ -0,0 +1,194 @@
+package monitor
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/indicators"
+	"github.com/hustler/trading-bot/pkg/market"
+)
+
+const (
+	// defaultTargetVolatility is the annualized volatility (percent) that gets full size and base stops
+	defaultTargetVolatility = 30.0
+	// volatilityPeriod is the historical volatility window in trading days
+	volatilityPeriod = 20
+	// betaPeriod is the beta regression window in trading days
+	betaPeriod = 60
+	// historySlack is the extra trading days fetched, so a full beta window
+	// is left after dropping days missing from either series
+	historySlack = 10
+	// benchmarkSymbol is the index beta is measured against
+	benchmarkSymbol = "SPY"
+)
+
+// VolatilityProfile holds the daily risk statistics of a symbol
+type VolatilityProfile struct {
+	Symbol               string    `json:"symbol"`
+	Beta                 float64   `json:"beta"`
+	HistoricalVolatility float64   `json:"historical_volatility"` // Annualized, percent
+	UpdatedAt            time.Time `json:"updated_at"`
+}
+
+// DailyHistorySource provides daily closes (implemented by data.Provider)
+type DailyHistorySource interface {
+	GetDailyHistory(symbol string, days int) (*data.MarketData, error)
+}
+
+// SetTargetVolatility sets the annualized volatility (percent) that receives full position size
+func (r *RiskManager) SetTargetVolatility(target float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.targetVolatility = target
+}
+
+// RefreshVolatilityProfiles recomputes beta and historical volatility for symbols once per day.
+// Profiles are persisted through logger when it is not nil.
+func (r *RiskManager) RefreshVolatilityProfiles(source DailyHistorySource, symbols []string, logger indicators.IndicatorLogger) error {
+	r.mu.RLock()
+	now := r.clock.Now()
+	session := r.session
+	today := session.TradingDay(now)
+	fresh := r.profileDay.Equal(today)
+	r.mu.RUnlock()
+	if fresh {
+		return nil
+	}
+
+	benchmark, err := source.GetDailyHistory(benchmarkSymbol, betaPeriod+1+historySlack)
+	if err != nil {
+		return fmt.Errorf("failed to get %s history: %w", benchmarkSymbol, err)
+	}
+
+	profiles := make(map[string]VolatilityProfile, len(symbols))
+	for _, symbol := range symbols {
+		history, err := source.GetDailyHistory(symbol, betaPeriod+1+historySlack)
+		if err != nil {
+			log.Printf("Error fetching daily history for %s: %v", symbol, err)
+			continue
+		}
+
+		assetCloses, benchmarkCloses := alignByDate(history, benchmark, session)
+		profile := VolatilityProfile{
+			Symbol:               symbol,
+			Beta:                 indicators.Beta(assetCloses, benchmarkCloses, betaPeriod),
+			HistoricalVolatility: indicators.HistoricalVolatility(history.Prices, volatilityPeriod),
+			UpdatedAt:            now,
+		}
+		profiles[symbol] = profile
+
+		if logger != nil {
+			if err := logger.LogIndicator(symbol, "beta", profile.Beta); err != nil {
+				log.Printf("Error logging beta for %s: %v", symbol, err)
+			}
+			if err := logger.LogIndicator(symbol, "hv20", profile.HistoricalVolatility); err != nil {
+				log.Printf("Error logging historical volatility for %s: %v", symbol, err)
+			}
+		}
+	}
+
+	r.mu.Lock()
+	for symbol, profile := range profiles {
+		r.profiles[symbol] = profile
+	}
+	r.profileDay = today
+	r.mu.Unlock()
+
+	return nil
+}
+
+// alignByDate returns the closes of asset and benchmark on the trading days
+// both have, oldest first, so a day missing from either series doesn't pair
+// returns from different days. Series without a timestamp per close are
+// returned as they are.
+func alignByDate(asset, benchmark *data.MarketData, session *market.Clock) ([]float64, []float64) {
+	if len(asset.Timestamps) != len(asset.Prices) || len(benchmark.Timestamps) != len(benchmark.Prices) {
+		return asset.Prices, benchmark.Prices
+	}
+
+	benchmarkByDay := make(map[string]float64, len(benchmark.Prices))
+	for i, ts := range benchmark.Timestamps {
+		benchmarkByDay[session.DayKey(ts)] = benchmark.Prices[i]
+	}
+
+	assetCloses := make([]float64, 0, len(asset.Prices))
+	benchmarkCloses := make([]float64, 0, len(asset.Prices))
+	for i, ts := range asset.Timestamps {
+		if close, ok := benchmarkByDay[session.DayKey(ts)]; ok {
+			assetCloses = append(assetCloses, asset.Prices[i])
+			benchmarkCloses = append(benchmarkCloses, close)
+		}
+	}
+	return assetCloses, benchmarkCloses
+}
+
+// SetVolatilityProfile sets the profile for a symbol directly
+func (r *RiskManager) SetVolatilityProfile(profile VolatilityProfile) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.profiles[profile.Symbol] = profile
+}
+
+// GetVolatilityProfile returns the profile for a symbol
+func (r *RiskManager) GetVolatilityProfile(symbol string) (VolatilityProfile, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	profile, ok := r.profiles[symbol]
+	return profile, ok
+}
+
+// PositionSize scales the share count so more volatile, higher-beta symbols get
+// smaller positions. With Kelly sizing only the Kelly fraction of capital is spent.
+func (r *RiskManager) PositionSize(symbol string, price, capital float64) int {
+	if price <= 0 {
+		return 0
+	}
+	if fraction, ok := r.KellyFraction(); ok {
+		capital *= fraction
+	}
+	return int(capital * r.sizeScale(symbol) / price)
+}
+
+// StopLossLimit widens the allowed loss for volatile symbols so normal noise doesn't stop them out.
+// Combined with the smaller position size, risk per trade stays roughly constant.
+func (r *RiskManager) StopLossLimit(symbol string, baseLimit float64) float64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	profile, ok := r.profiles[symbol]
+	if !ok || profile.HistoricalVolatility <= 0 || r.targetVolatility <= 0 {
+		return baseLimit
+	}
+
+	return baseLimit * clamp(profile.HistoricalVolatility/r.targetVolatility, 0.5, 2.0)
+}
+
+// sizeScale returns the position size multiplier for a symbol, between 0.25 and 1
+func (r *RiskManager) sizeScale(symbol string) float64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	profile, ok := r.profiles[symbol]
+	if !ok || profile.HistoricalVolatility <= 0 || r.targetVolatility <= 0 {
+		return 1
+	}
+
+	scale := r.targetVolatility / profile.HistoricalVolatility
+	// High-beta names add market risk on top of their own volatility
+	if beta := math.Abs(profile.Beta); beta > 1 {
+		scale /= beta
+	}
+
+	return clamp(scale, 0.25, 1.0)
+}
+
+// clamp limits v to [min, max]
+func clamp(v, min, max float64) float64 {
+	return math.Max(min, math.Min(max, v))
+}
@@ -0,0 +1,53 @@
+package monitor
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dailyCloses serves fixed daily histories by symbol
+type dailyCloses map[string]*data.MarketData
+
+func (d dailyCloses) GetDailyHistory(symbol string, days int) (*data.MarketData, error) {
+	return d[symbol], nil
+}
+
+func TestRefreshVolatilityProfilesAlignsBetaByDate(t *testing.T) {
+	start := time.Date(2026, 7, 1, 20, 0, 0, 0, time.UTC)
+	benchmark := &data.MarketData{Symbol: benchmarkSymbol}
+	asset := &data.MarketData{Symbol: "AAPL"}
+	spy, aapl := 500.0, 200.0
+	for i := 0; i <= betaPeriod+historySlack; i++ {
+		move := 0.01
+		if i%2 == 0 {
+			move = -0.008
+		}
+		day := start.AddDate(0, 0, i)
+		spy *= math.Exp(move)
+		// AAPL moves twice as much as SPY, but has no bar on its 50th day
+		aapl *= math.Exp(2 * move)
+		benchmark.Prices = append(benchmark.Prices, spy)
+		benchmark.Timestamps = append(benchmark.Timestamps, day)
+		if i == 50 {
+			continue
+		}
+		asset.Prices = append(asset.Prices, aapl)
+		asset.Timestamps = append(asset.Timestamps, day)
+	}
+
+	risk := NewRiskManager(500, 100, nil)
+	risk.SetClock(clock.NewFake(start.AddDate(0, 0, betaPeriod+historySlack)))
+	source := dailyCloses{benchmarkSymbol: benchmark, "AAPL": asset}
+	require.NoError(t, risk.RefreshVolatilityProfiles(source, []string{"AAPL"}, nil))
+
+	profile, ok := risk.GetVolatilityProfile("AAPL")
+	require.True(t, ok)
+	assert.InDelta(t, 2.0, profile.Beta, 1e-9)
+	assert.Greater(t, profile.HistoricalVolatility, 0.0)
+}
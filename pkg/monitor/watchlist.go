@@ -0,0 +1,242 @@
+package monitor
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// SymbolExpander replaces watchlist entries such as QQQ:components with the
+// symbols they stand for (implemented by symbols.Expander)
+type SymbolExpander interface {
+	Expand(symbols []string) []string
+}
+
+// SetSymbolExpander sets how watchlist entries are expanded before each check
+func (m *MarketMonitor) SetSymbolExpander(expander SymbolExpander) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.expander = expander
+}
+
+// WatchedSymbols returns the symbols checked for signals across all watchlists
+func (m *MarketMonitor) WatchedSymbols() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.config.WatchedSymbols()
+}
+
+// AddSymbols adds symbols to the first watchlist, or to stock_symbols when no
+// watchlists are configured, and returns the ones that were not already watched
+func (m *MarketMonitor) AddSymbols(symbols []string) []string {
+	added := m.addSymbols(symbols)
+	if len(added) > 0 {
+		m.notifyWatchlistChange()
+	}
+	return added
+}
+
+// addSymbols adds symbols to the configuration and returns the ones added
+func (m *MarketMonitor) addSymbols(symbols []string) []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	watched := make(map[string]bool)
+	for _, symbol := range m.config.WatchedSymbols() {
+		watched[symbol] = true
+	}
+
+	var target []string
+	if len(m.config.Watchlists) > 0 {
+		target = m.config.Watchlists[0].Symbols
+	} else {
+		target = m.config.StockSymbols
+	}
+
+	updated := append([]string(nil), target...)
+	added := []string{}
+	for _, symbol := range symbols {
+		symbol = strings.ToUpper(strings.TrimSpace(symbol))
+		if symbol == "" || watched[symbol] {
+			continue
+		}
+		watched[symbol] = true
+		updated = append(updated, symbol)
+		added = append(added, symbol)
+	}
+
+	// Swap in a changed copy; the configuration is never changed in place
+	cfg := m.config.Clone()
+	if len(cfg.Watchlists) > 0 {
+		cfg.Watchlists[0].Symbols = updated
+	} else {
+		cfg.StockSymbols = updated
+	}
+	m.config = cfg
+	return added
+}
+
+// OnWatchlistChange registers a callback invoked with every watched symbol,
+// expanded, after symbols are added or the configuration is updated, e.g. to
+// sync a MarketWatcher without restarting it
+func (m *MarketMonitor) OnWatchlistChange(fn func(symbols []string)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.watchlistListeners = append(m.watchlistListeners, fn)
+}
+
+// notifyWatchlistChange passes the watched symbols to watchlist listeners
+func (m *MarketMonitor) notifyWatchlistChange() {
+	m.mu.RLock()
+	symbols := m.config.WatchedSymbols()
+	expander := m.expander
+	listeners := make([]func([]string), len(m.watchlistListeners))
+	copy(listeners, m.watchlistListeners)
+	m.mu.RUnlock()
+
+	if len(listeners) == 0 {
+		return
+	}
+	if expander != nil {
+		symbols = expander.Expand(symbols)
+	}
+	for _, fn := range listeners {
+		fn(append([]string(nil), symbols...))
+	}
+}
+
+// CheckSymbols immediately checks symbols outside the regular schedule, e.g.
+// on breaking news. Each symbol is checked with the strategies of the first
+// watchlist listing it; symbols on no watchlist are ignored.
+func (m *MarketMonitor) CheckSymbols(symbols []string) error {
+	m.mu.RLock()
+	now := m.clock.Now()
+	watchlists := m.config.GetWatchlists()
+	m.mu.RUnlock()
+
+	wanted := make(map[string]bool, len(symbols))
+	for _, symbol := range symbols {
+		wanted[strings.ToUpper(strings.TrimSpace(symbol))] = true
+	}
+
+	var targeted []config.WatchlistConfig
+	for _, watchlist := range m.expandWatchlists(watchlists) {
+		var subset []string
+		for _, symbol := range watchlist.Symbols {
+			if wanted[symbol] {
+				subset = append(subset, symbol)
+			}
+		}
+		if len(subset) > 0 {
+			watchlist.Symbols = subset
+			targeted = append(targeted, watchlist)
+		}
+	}
+	if len(targeted) == 0 {
+		return nil
+	}
+	return m.checkWatchlists(now, targeted, false)
+}
+
+// checkInterval returns how often the monitor wakes up: the shortest check
+// interval of any watchlist
+func (m *MarketMonitor) checkInterval() time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	interval := m.config.CheckInterval
+	for _, watchlist := range m.config.GetWatchlists() {
+		if watchlist.CheckInterval > 0 && watchlist.CheckInterval < interval {
+			interval = watchlist.CheckInterval
+		}
+	}
+	return time.Duration(interval) * time.Second
+}
+
+// dueWatchlists returns the watchlists whose check interval has elapsed at now
+// and marks them checked. Watchlists without their own interval are checked
+// on every market check.
+func (m *MarketMonitor) dueWatchlists(now time.Time) []config.WatchlistConfig {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var due []config.WatchlistConfig
+	for _, watchlist := range m.config.GetWatchlists() {
+		interval := time.Duration(watchlist.CheckInterval) * time.Second
+		if last, ok := m.lastChecked[watchlist.Name]; ok && interval > 0 && now.Sub(last) < interval {
+			continue
+		}
+		m.lastChecked[watchlist.Name] = now
+		due = append(due, watchlist)
+	}
+	return due
+}
+
+// expandWatchlists returns copies of watchlists with their entries expanded
+func (m *MarketMonitor) expandWatchlists(watchlists []config.WatchlistConfig) []config.WatchlistConfig {
+	m.mu.RLock()
+	expander := m.expander
+	m.mu.RUnlock()
+
+	if expander == nil {
+		return watchlists
+	}
+	expanded := make([]config.WatchlistConfig, len(watchlists))
+	for i, watchlist := range watchlists {
+		watchlist.Symbols = expander.Expand(watchlist.Symbols)
+		expanded[i] = watchlist
+	}
+	return expanded
+}
+
+// generateWatchlistSignals generates signals for each watchlist from its own
+// symbols and strategies. A symbol on several watchlists is signalled once, by
+// the first watchlist listing it. Once the check is over its budget, the
+// remaining watchlists are deferred to the next check.
+func (m *MarketMonitor) generateWatchlistSignals(ctx context.Context, watchlists []config.WatchlistConfig, marketData map[string]signal.MarketData, budget *checkBudget) ([]*signal.Signal, error) {
+	var signals []*signal.Signal
+	claimed := make(map[string]bool)
+	for _, watchlist := range watchlists {
+		if budget.skip(stageGenerate) {
+			m.deferWatchlist(budget, watchlist.Name, len(watchlist.Symbols))
+			continue
+		}
+
+		subset := make(map[string]signal.MarketData)
+		for _, symbol := range watchlist.Symbols {
+			if data, ok := marketData[symbol]; ok && !claimed[symbol] {
+				claimed[symbol] = true
+				subset[symbol] = data
+			}
+		}
+		if len(subset) == 0 {
+			continue
+		}
+
+		generated, err := m.signalGen.GenerateWatchlistSignalsContext(ctx, watchlist, subset)
+		if err != nil {
+			return nil, err
+		}
+		signals = append(signals, generated...)
+	}
+	return signals, nil
+}
+
+// watchlistSymbols returns the symbols of watchlists without duplicates
+func watchlistSymbols(watchlists []config.WatchlistConfig) []string {
+	seen := make(map[string]bool)
+	var symbols []string
+	for _, watchlist := range watchlists {
+		for _, symbol := range watchlist.Symbols {
+			if !seen[symbol] {
+				seen[symbol] = true
+				symbols = append(symbols, symbol)
+			}
+		}
+	}
+	return symbols
+}
@@ -0,0 +1,169 @@
+package news
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+)
+
+// MarketChecker runs an immediate check of symbols (implemented by monitor.MarketMonitor)
+type MarketChecker interface {
+	CheckSymbols(symbols []string) error
+}
+
+// Notifier delivers breaking news alerts (implemented by telegram.Bot)
+type Notifier interface {
+	SendMessage(message string) error
+}
+
+// Watchlist provides the symbols breaking news is acted on for (implemented by monitor.MarketMonitor)
+type Watchlist interface {
+	WatchedSymbols() []string
+}
+
+// BreakingNews is an article matching a breaking-news pattern for watched symbols
+type BreakingNews struct {
+	Article Article  `json:"article"`
+	Pattern string   `json:"pattern"`
+	Symbols []string `json:"symbols"`
+}
+
+// breakingPattern is a named, compiled headline pattern
+type breakingPattern struct {
+	name string
+	re   *regexp.Regexp
+}
+
+// Breaking alerts on market-moving headlines about watched symbols and
+// triggers an immediate market check of them instead of waiting for the next
+// scheduled one
+type Breaking struct {
+	patterns  []breakingPattern
+	cooldown  time.Duration
+	watchlist Watchlist
+	checker   MarketChecker
+	notifier  Notifier
+	lastAlert map[string]time.Time // Symbol/pattern -> last alert
+	now       func() time.Time
+	mu        sync.Mutex
+}
+
+// NewBreaking creates the breaking-news fast path
+func NewBreaking(cfg config.BreakingNewsConfig, watchlist Watchlist, checker MarketChecker, notifier Notifier) (*Breaking, error) {
+	names := make([]string, 0, len(cfg.Patterns))
+	for name := range cfg.Patterns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	patterns := make([]breakingPattern, 0, len(names))
+	for _, name := range names {
+		re, err := regexp.Compile("(?i)" + cfg.Patterns[name])
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile breaking news pattern %q: %w", name, err)
+		}
+		patterns = append(patterns, breakingPattern{name: name, re: re})
+	}
+
+	return &Breaking{
+		patterns:  patterns,
+		cooldown:  time.Duration(cfg.CooldownMinutes) * time.Minute,
+		watchlist: watchlist,
+		checker:   checker,
+		notifier:  notifier,
+		lastAlert: make(map[string]time.Time),
+		now:       time.Now,
+	}, nil
+}
+
+// Attach handles every batch of new articles from the news monitor
+func (b *Breaking) Attach(m *Monitor) {
+	m.RegisterCallback(func(articles []Article) {
+		b.Handle(articles)
+	})
+}
+
+// Handle alerts on and checks the watched symbols of breaking articles, and
+// returns them
+func (b *Breaking) Handle(articles []Article) []BreakingNews {
+	var breaking []BreakingNews
+	for _, article := range articles {
+		if news, ok := b.match(article); ok {
+			breaking = append(breaking, news)
+		}
+	}
+
+	for _, news := range breaking {
+		if err := b.notifier.SendMessage(FormatBreakingNews(news)); err != nil {
+			log.Printf("Error sending breaking news alert: %v", err)
+		}
+		log.Printf("Breaking news (%s) for %v, checking immediately", news.Pattern, news.Symbols)
+		if err := b.checker.CheckSymbols(news.Symbols); err != nil {
+			log.Printf("Error checking %v on breaking news: %v", news.Symbols, err)
+		}
+	}
+	return breaking
+}
+
+// match returns the article as breaking news if its headline matches a
+// pattern and it is tagged with watched symbols not alerted for that pattern
+// within the cooldown
+func (b *Breaking) match(article Article) (BreakingNews, bool) {
+	var pattern string
+	for _, p := range b.patterns {
+		if p.re.MatchString(article.Title) {
+			pattern = p.name
+			break
+		}
+	}
+	if pattern == "" {
+		return BreakingNews{}, false
+	}
+
+	watched := make(map[string]bool)
+	for _, symbol := range b.watchlist.WatchedSymbols() {
+		watched[symbol] = true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	var symbols []string
+	for _, symbol := range article.Symbols {
+		symbol = strings.ToUpper(symbol)
+		key := symbol + "/" + pattern
+		if !watched[symbol] {
+			continue
+		}
+		if last, ok := b.lastAlert[key]; ok && now.Sub(last) < b.cooldown {
+			continue
+		}
+		b.lastAlert[key] = now
+		symbols = append(symbols, symbol)
+	}
+	if len(symbols) == 0 {
+		return BreakingNews{}, false
+	}
+	return BreakingNews{Article: article, Pattern: pattern, Symbols: symbols}, true
+}
+
+// FormatBreakingNews formats a breaking news alert
+func FormatBreakingNews(news BreakingNews) string {
+	message := fmt.Sprintf("🚨 <b>BREAKING (%s): %s</b>\n\n", news.Pattern, strings.Join(news.Symbols, ", "))
+	message += news.Article.Title + "\n"
+	if news.Article.Source != "" {
+		message += fmt.Sprintf("Source: %s\n", news.Article.Source)
+	}
+	if news.Article.URL != "" {
+		message += news.Article.URL + "\n"
+	}
+	message += "\n<i>Checking affected symbols now.</i>"
+	return message
+}
@@ -0,0 +1,54 @@
+package news
+
+import (
+	"testing"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingChecker [][]string
+
+func (r *recordingChecker) CheckSymbols(symbols []string) error {
+	*r = append(*r, symbols)
+	return nil
+}
+
+type recordingNotifier []string
+
+func (r *recordingNotifier) SendMessage(message string) error {
+	*r = append(*r, message)
+	return nil
+}
+
+type staticWatchlist []string
+
+func (s staticWatchlist) WatchedSymbols() []string { return s }
+
+func TestBreakingNewsTriggersTargetedCheck(t *testing.T) {
+	checker := &recordingChecker{}
+	notifier := &recordingNotifier{}
+	b, err := NewBreaking(config.CreateDefaultConfig().News.Breaking, staticWatchlist{"MRNA", "AAPL"}, checker, notifier)
+	assert.NoError(t, err)
+
+	articles := []Article{
+		{Title: "FDA approves Moderna's next-generation vaccine", Symbols: []string{"MRNA", "PFE"}},
+		{Title: "Apple unveils new iPhone", Symbols: []string{"AAPL"}},
+		{Title: "Nasdaq halts trading in XYZ pending news", Symbols: []string{"XYZ"}},
+	}
+	breaking := b.Handle(articles)
+	assert.Len(t, breaking, 1)
+	assert.Equal(t, "FDA decision", breaking[0].Pattern)
+	assert.Equal(t, [][]string{{"MRNA"}}, [][]string(*checker))
+	assert.Len(t, *notifier, 1)
+	assert.Contains(t, (*notifier)[0], "BREAKING (FDA decision): MRNA")
+
+	// Syndicated copies within the cooldown don't trigger again
+	assert.Empty(t, b.Handle(articles[:1]))
+	assert.Len(t, *checker, 1)
+}
+
+func TestNewBreakingRejectsInvalidPattern(t *testing.T) {
+	_, err := NewBreaking(config.BreakingNewsConfig{Patterns: map[string]string{"bad": "("}}, staticWatchlist{}, &recordingChecker{}, &recordingNotifier{})
+	assert.Error(t, err)
+}
@@ -26,6 +26,7 @@ type Article struct {
 	Sentiment   float64 // -1.0 to 1.0 (negative to positive)
 	Symbols     []string
 	Keywords    []string
+	Relevance   float64 // 0 to 1, see Relevance.Score
 }
 
 // Monitor watches for financial news from various sources
@@ -33,6 +34,9 @@ type Monitor struct {
 	config      config.NewsConfig
 	authManager *auth.AuthManager
 	articles    []Article
+	relevance   map[string]*Relevance // Source -> rules; "" holds the default rules
+	retention   config.RetentionPolicy
+	archiver    Archiver // Receives articles dropped by the retention policy
 	mu          sync.RWMutex
 	ctx         context.Context
 	cancel      context.CancelFunc
@@ -42,10 +46,17 @@ type Monitor struct {
 // NewMonitor creates a new news monitor
 func NewMonitor(cfg config.NewsConfig, authManager *auth.AuthManager) *Monitor {
 	ctx, cancel := context.WithCancel(context.Background())
+
+	relevance := map[string]*Relevance{"": NewRelevance(cfg.Relevance, cfg.Keywords)}
+	for source, rules := range cfg.SourceRelevance {
+		relevance[source] = NewRelevance(rules, cfg.Keywords)
+	}
+
 	return &Monitor{
 		config:      cfg,
 		authManager: authManager,
 		articles:    make([]Article, 0),
+		relevance:   relevance,
 		ctx:         ctx,
 		cancel:      cancel,
 		callbacks:   make([]func([]Article), 0),
@@ -145,7 +156,7 @@ func (m *Monitor) fetchAllNews() {
 			continue
 		}
 
-		newArticles = append(newArticles, articles...)
+		newArticles = append(newArticles, m.filterRelevant(source, articles, newArticles)...)
 	}
 
 	if len(newArticles) > 0 {
@@ -153,36 +164,57 @@ func (m *Monitor) fetchAllNews() {
 	}
 }
 
+// filterRelevant returns the articles from source that pass its relevance
+// rules and aren't syndicated copies of stored or pending articles
+func (m *Monitor) filterRelevant(source string, articles, pending []Article) []Article {
+	relevance, ok := m.relevance[source]
+	if !ok {
+		relevance = m.relevance[""]
+	}
+
+	m.mu.RLock()
+	recent := append(append([]Article(nil), pending...), m.articles...)
+	m.mu.RUnlock()
+
+	return relevance.Relevant(articles, recent)
+}
+
 // updateArticles updates the articles list with new articles
 func (m *Monitor) updateArticles(newArticles []Article) {
 	m.mu.Lock()
-	defer m.mu.Unlock()
 
-	// Add new articles to the beginning of the list
-	combined := append(newArticles, m.articles...)
-
-	// Remove duplicates (based on URL)
+	// Remove duplicates (based on URL) so callbacks only see unseen articles
 	seen := make(map[string]bool)
-	unique := make([]Article, 0, len(combined))
-
-	for _, article := range combined {
+	for _, article := range m.articles {
+		seen[article.URL] = true
+	}
+	unseen := make([]Article, 0, len(newArticles))
+	for _, article := range newArticles {
 		if !seen[article.URL] {
 			seen[article.URL] = true
-			unique = append(unique, article)
+			unseen = append(unseen, article)
 		}
 	}
-
-	// Limit to a reasonable number to prevent memory issues
-	const maxArticles = 1000
-	if len(unique) > maxArticles {
-		unique = unique[:maxArticles]
+	newArticles = unseen
+	if len(newArticles) == 0 {
+		m.mu.Unlock()
+		return
 	}
 
+	// Add new articles to the beginning of the list
+	unique := append(append(make([]Article, 0, len(newArticles)+len(m.articles)), newArticles...), m.articles...)
+
+	// Spill articles outside the retention policy to prevent memory issues
 	m.articles = unique
+	spilled := m.pruneArticles(time.Now())
+	archiver := m.archiver
 
 	// Notify callbacks
 	callbacks := make([]func([]Article), len(m.callbacks))
 	copy(callbacks, m.callbacks)
+	m.mu.Unlock()
+
+	archiveArticles(archiver, spilled)
 
 	// Call callbacks outside of the lock
 	go func(articles []Article, callbacks []func([]Article)) {
@@ -0,0 +1,171 @@
+package news
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/hustler/trading-bot/pkg/config"
+)
+
+// Relevance score contributions, summed and capped at 1
+const (
+	titleSymbolScore  = 0.5  // A tagged symbol named in the headline
+	taggedSymbolScore = 0.25 // A tagged symbol only mentioned in the body
+	keywordScore      = 0.25 // Each configured keyword found
+)
+
+// pressReleasePublishers are newswires that carry company-paid releases
+var pressReleasePublishers = []string{
+	"prnewswire", "pr newswire", "businesswire", "business wire", "globenewswire",
+	"accesswire", "newsfile", "einpresswire", "newsfilecorp",
+}
+
+// pressReleasePattern matches headlines of routine releases and shareholder-lawsuit solicitations
+var pressReleasePattern = regexp.MustCompile(`(?i)(to (present|participate) at|to host .*conference call|announces .*(conference call|webcast|dividend date)|class action|investor alert|shareholder alert|lawsuit filed|investors who lost|reminds investors|deadline alert)`)
+
+// wordPattern splits titles into words for duplicate detection
+var wordPattern = regexp.MustCompile(`[a-z0-9]+`)
+
+// Relevance scores articles and drops noise according to configured rules
+type Relevance struct {
+	rules    config.RelevanceConfig
+	keywords []string
+}
+
+// NewRelevance creates a relevance filter. keywords are used when the rules
+// don't list their own.
+func NewRelevance(rules config.RelevanceConfig, keywords []string) *Relevance {
+	if len(rules.Keywords) > 0 {
+		keywords = rules.Keywords
+	}
+	lowered := make([]string, 0, len(keywords))
+	for _, keyword := range keywords {
+		if keyword = strings.ToLower(strings.TrimSpace(keyword)); keyword != "" {
+			lowered = append(lowered, keyword)
+		}
+	}
+	return &Relevance{rules: rules, keywords: lowered}
+}
+
+// Score rates how relevant an article is from 0 to 1: tagged symbols named in
+// the headline count most, then symbols only in the body and keyword matches
+func (r *Relevance) Score(article Article) float64 {
+	title := strings.ToLower(article.Title)
+	text := title + " " + strings.ToLower(article.Description)
+
+	var score float64
+	for _, symbol := range article.Symbols {
+		if containsWord(title, strings.ToLower(symbol)) {
+			score += titleSymbolScore
+		} else {
+			score += taggedSymbolScore
+		}
+	}
+	for _, keyword := range r.keywords {
+		if strings.Contains(text, keyword) {
+			score += keywordScore
+		}
+	}
+	if score > 1 {
+		score = 1
+	}
+	return score
+}
+
+// IsNoise reports whether an article is excluded by the rules regardless of
+// its score, and why
+func (r *Relevance) IsNoise(article Article) (string, bool) {
+	publisher := strings.ToLower(article.Source)
+	for _, excluded := range r.rules.ExcludePublishers {
+		if excluded != "" && strings.Contains(publisher, strings.ToLower(excluded)) {
+			return "excluded publisher " + article.Source, true
+		}
+	}
+
+	text := strings.ToLower(article.Title + " " + article.Description)
+	for _, excluded := range r.rules.ExcludeKeywords {
+		if excluded != "" && strings.Contains(text, strings.ToLower(excluded)) {
+			return "excluded keyword " + excluded, true
+		}
+	}
+
+	if r.rules.DropPressReleases {
+		for _, wire := range pressReleasePublishers {
+			if strings.Contains(publisher, wire) || strings.Contains(strings.ToLower(article.URL), strings.ReplaceAll(wire, " ", "")) {
+				return "press release", true
+			}
+		}
+		if pressReleasePattern.MatchString(article.Title) {
+			return "press release", true
+		}
+	}
+	return "", false
+}
+
+// IsSyndicated reports whether an article is a copy of one in recent, judged
+// by the overlap of their headline words
+func (r *Relevance) IsSyndicated(article Article, recent []Article) bool {
+	if r.rules.DuplicateSimilarity <= 0 {
+		return false
+	}
+	words := titleWords(article.Title)
+	for _, other := range recent {
+		if other.URL != article.URL && similarity(words, titleWords(other.Title)) >= r.rules.DuplicateSimilarity {
+			return true
+		}
+	}
+	return false
+}
+
+// Relevant returns the articles that are not noise, not syndicated copies of
+// each other or of recent, and score at least the minimum, with their scores set
+func (r *Relevance) Relevant(articles, recent []Article) []Article {
+	var relevant []Article
+	for _, article := range articles {
+		if _, noise := r.IsNoise(article); noise {
+			continue
+		}
+		if r.IsSyndicated(article, recent) || r.IsSyndicated(article, relevant) {
+			continue
+		}
+		article.Relevance = r.Score(article)
+		if article.Relevance < r.rules.MinScore {
+			continue
+		}
+		relevant = append(relevant, article)
+	}
+	return relevant
+}
+
+// titleWords returns the set of words in a headline
+func titleWords(title string) map[string]bool {
+	words := make(map[string]bool)
+	for _, word := range wordPattern.FindAllString(strings.ToLower(title), -1) {
+		words[word] = true
+	}
+	return words
+}
+
+// similarity returns the Jaccard similarity of two word sets
+func similarity(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for word := range a {
+		if b[word] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// containsWord reports whether text contains word delimited by non-alphanumerics
+func containsWord(text, word string) bool {
+	for _, found := range wordPattern.FindAllString(text, -1) {
+		if found == word {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+package news
+
+import (
+	"testing"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelevanceScore(t *testing.T) {
+	r := NewRelevance(config.RelevanceConfig{}, []string{"earnings"})
+
+	assert.Equal(t, 0.75, r.Score(Article{Title: "AAPL beats earnings estimates", Symbols: []string{"AAPL"}}))
+	assert.Equal(t, 0.25, r.Score(Article{Title: "Tech stocks rally", Symbols: []string{"MSFT"}}))
+	assert.Equal(t, 0.0, r.Score(Article{Title: "Oil prices slip"}))
+	assert.Equal(t, 1.0, r.Score(Article{Title: "AAPL and MSFT report earnings", Symbols: []string{"AAPL", "MSFT"}}))
+}
+
+func TestRelevanceDropsNoise(t *testing.T) {
+	r := NewRelevance(config.RelevanceConfig{
+		MinScore:            0.25,
+		ExcludeKeywords:     []string{"sponsored"},
+		DropPressReleases:   true,
+		DuplicateSimilarity: 0.8,
+	}, nil)
+
+	articles := []Article{
+		{Title: "Nvidia shares jump after record data center sales", URL: "https://a.example/1", Source: "Reuters", Symbols: []string{"NVDA"}},
+		{Title: "Nvidia shares jump after record data center sales - update", URL: "https://b.example/1", Source: "Yahoo", Symbols: []string{"NVDA"}},
+		{Title: "Acme Corp to Present at Upcoming Investor Conference", URL: "https://c.example/1", Source: "Acme", Symbols: []string{"ACME"}},
+		{Title: "ACME shareholder alert: class action filed", URL: "https://d.example/1", Source: "Law Firm", Symbols: []string{"ACME"}},
+		{Title: "Acme launches product", URL: "https://www.globenewswire.com/news/1", Source: "Acme", Symbols: []string{"ACME"}},
+		{Title: "Sponsored: why TSLA could double", URL: "https://e.example/1", Source: "Blog", Symbols: []string{"TSLA"}},
+		{Title: "Markets wrap", URL: "https://f.example/1", Source: "Reuters"},
+	}
+
+	relevant := r.Relevant(articles, nil)
+	assert.Len(t, relevant, 1)
+	assert.Equal(t, "https://a.example/1", relevant[0].URL)
+	assert.Equal(t, 0.25, relevant[0].Relevance)
+
+	// A copy of an article seen in an earlier fetch is dropped too
+	assert.Empty(t, r.Relevant(articles[1:2], relevant))
+}
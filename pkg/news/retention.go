@@ -0,0 +1,61 @@
+package news
+
+import (
+	"log"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+)
+
+// defaultMaxArticles bounds the article list when no count limit is configured
+const defaultMaxArticles = 1000
+
+// Archiver stores records spilled from memory by a retention policy (implemented by store.Logger)
+type Archiver interface {
+	ArchiveRecord(kind, key string, createdAt time.Time, record interface{}) error
+}
+
+// SetRetention bounds the articles kept in memory. Articles outside the policy
+// are stored with archiver, or discarded when it is nil.
+func (m *Monitor) SetRetention(policy config.RetentionPolicy, archiver Archiver) {
+	m.mu.Lock()
+	m.retention = policy
+	m.archiver = archiver
+	spilled := m.pruneArticles(time.Now())
+	m.mu.Unlock()
+
+	archiveArticles(archiver, spilled)
+}
+
+// pruneArticles removes articles outside the retention policy and returns them.
+// Articles are ordered newest first. Caller must hold the lock.
+func (m *Monitor) pruneArticles(now time.Time) []Article {
+	policy := m.retention
+	if policy.MaxCount == 0 {
+		policy.MaxCount = defaultMaxArticles
+	}
+
+	var spilled []Article
+	kept := make([]Article, 0, len(m.articles))
+	for rank, article := range m.articles {
+		if policy.Keep(rank, article.PublishedAt, now) {
+			kept = append(kept, article)
+		} else {
+			spilled = append(spilled, article)
+		}
+	}
+	m.articles = kept
+	return spilled
+}
+
+// archiveArticles stores spilled articles with archiver, if one is set
+func archiveArticles(archiver Archiver, spilled []Article) {
+	if archiver == nil {
+		return
+	}
+	for _, article := range spilled {
+		if err := archiver.ArchiveRecord("article", article.URL, article.PublishedAt, article); err != nil {
+			log.Printf("Error archiving article %s: %v", article.URL, err)
+		}
+	}
+}
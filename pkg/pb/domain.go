@@ -0,0 +1,528 @@
+package pb
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/execution"
+	"github.com/hustler/trading-bot/pkg/performance"
+	"github.com/hustler/trading-bot/pkg/signal"
+	"github.com/hustler/trading-bot/pkg/strategy"
+)
+
+// Values of the Side enum in proto/hustler/v1/domain.proto
+const (
+	sideUnspecified = 0
+	sideBuy         = 1
+	sideSell        = 2
+	sideHold        = 3
+)
+
+// sideValue maps a BUY/SELL/HOLD string to the Side enum
+func sideValue(side string) uint64 {
+	switch side {
+	case "BUY":
+		return sideBuy
+	case "SELL":
+		return sideSell
+	case "HOLD":
+		return sideHold
+	}
+	return sideUnspecified
+}
+
+// sideName maps the Side enum back to BUY/SELL/HOLD
+func sideName(value uint64) string {
+	switch value {
+	case sideBuy:
+		return "BUY"
+	case sideSell:
+		return "SELL"
+	case sideHold:
+		return "HOLD"
+	}
+	return ""
+}
+
+// MarshalSignal encodes a signal as a hustler.v1.Signal message
+func MarshalSignal(s *signal.Signal) []byte {
+	var e encoder
+	e.string(1, s.ID)
+	e.string(2, s.Symbol)
+	e.varint(3, sideValue(string(s.Type)))
+	e.double(4, s.Price)
+	e.double(5, s.TargetPrice)
+	e.double(6, s.StopLoss)
+	e.double(7, s.ExpectedROI)
+	e.double(8, s.Confidence)
+	e.string(9, s.Rationale)
+	e.timestamp(10, s.GeneratedAt)
+	e.string(11, s.TimeFrame)
+	for _, key := range sortedKeys(s.TechnicalData) {
+		var entry encoder
+		entry.string(1, key)
+		entry.double(2, s.TechnicalData[key])
+		e.bytes(12, entry.buf)
+	}
+	e.string(13, string(s.Status))
+	e.int(14, int64(s.MaxShares))
+	e.string(15, s.Regime)
+	e.strings(16, s.Strategies)
+	e.string(17, s.Watchlist)
+	e.bool(18, s.ShortRestricted)
+	e.string(19, s.Company)
+	e.string(20, s.Sector)
+	e.string(21, s.Industry)
+	e.string(22, s.Seasonality)
+	e.bool(23, s.Test)
+	e.string(24, s.Currency)
+	e.string(25, s.OrderType)
+	e.int(26, signal.CurrentSchemaVersion)
+	for _, change := range s.StatusChanges {
+		var c encoder
+		c.string(1, string(change.Status))
+		c.timestamp(2, change.At)
+		e.bytes(27, c.buf)
+	}
+	return e.buf
+}
+
+// UnmarshalSignal decodes a hustler.v1.Signal message. Signals written at an
+// older schema version are upgraded.
+func UnmarshalSignal(b []byte) (*signal.Signal, error) {
+	s := &signal.Signal{}
+	err := decode(b, func(f field) error {
+		var err error
+		switch f.number {
+		case 1:
+			s.ID = f.string()
+		case 2:
+			s.Symbol = f.string()
+		case 3:
+			s.Type = signal.SignalType(sideName(f.num))
+		case 4:
+			s.Price = f.double()
+		case 5:
+			s.TargetPrice = f.double()
+		case 6:
+			s.StopLoss = f.double()
+		case 7:
+			s.ExpectedROI = f.double()
+		case 8:
+			s.Confidence = f.double()
+		case 9:
+			s.Rationale = f.string()
+		case 10:
+			s.GeneratedAt, err = f.timestamp()
+		case 11:
+			s.TimeFrame = f.string()
+		case 12:
+			if s.TechnicalData == nil {
+				s.TechnicalData = make(map[string]float64)
+			}
+			err = decodeDoubleEntry(f.data, s.TechnicalData)
+		case 13:
+			s.Status = signal.Status(f.string())
+		case 14:
+			s.MaxShares = int(f.int())
+		case 15:
+			s.Regime = f.string()
+		case 16:
+			s.Strategies = append(s.Strategies, f.string())
+		case 17:
+			s.Watchlist = f.string()
+		case 18:
+			s.ShortRestricted = f.bool()
+		case 19:
+			s.Company = f.string()
+		case 20:
+			s.Sector = f.string()
+		case 21:
+			s.Industry = f.string()
+		case 22:
+			s.Seasonality = f.string()
+		case 23:
+			s.Test = f.bool()
+		case 24:
+			s.Currency = f.string()
+		case 25:
+			s.OrderType = f.string()
+		case 26:
+			s.SchemaVersion = int(f.int())
+		case 27:
+			var change signal.StatusChange
+			err = decode(f.data, func(cf field) error {
+				var err error
+				switch cf.number {
+				case 1:
+					change.Status = signal.Status(cf.string())
+				case 2:
+					change.At, err = cf.timestamp()
+				}
+				return err
+			})
+			s.StatusChanges = append(s.StatusChanges, change)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode signal: %w", err)
+	}
+	if err := signal.Upgrade(s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// MarshalTrade encodes a trade as a hustler.v1.Trade message
+func MarshalTrade(t *execution.Trade) []byte {
+	var e encoder
+	e.string(1, t.ID)
+	e.string(2, t.Symbol)
+	e.int(3, int64(t.Quantity))
+	e.double(4, t.Price)
+	e.varint(5, sideValue(string(t.Type)))
+	e.string(6, string(t.Status))
+	e.timestamp(7, t.CreatedAt)
+	e.timestamp(8, t.UpdatedAt)
+	e.string(9, t.Reason)
+	e.double(10, t.TargetPrice)
+	e.double(11, t.StopPrice)
+	e.string(12, t.PositionID)
+	e.string(13, t.Strategy)
+	e.string(14, t.BracketID)
+	e.string(15, t.SignalID)
+	e.int(16, int64(t.PlannedQuantity))
+	e.int(17, int64(t.Entries))
+	e.int(18, int64(t.ExitsTaken))
+	e.double(19, t.RealizedPnL)
+	for _, fill := range t.Fills {
+		var f encoder
+		f.string(1, fill.Symbol)
+		f.varint(2, sideValue(string(fill.Side)))
+		f.int(3, int64(fill.Quantity))
+		f.double(4, fill.Price)
+		f.timestamp(5, fill.FilledAt)
+		e.bytes(20, f.buf)
+	}
+	e.strings(21, t.CorporateActions)
+	e.double(22, t.MAE)
+	e.double(23, t.MFE)
+	return e.buf
+}
+
+// UnmarshalTrade decodes a hustler.v1.Trade message
+func UnmarshalTrade(b []byte) (*execution.Trade, error) {
+	t := &execution.Trade{}
+	err := decode(b, func(f field) error {
+		var err error
+		switch f.number {
+		case 1:
+			t.ID = f.string()
+		case 2:
+			t.Symbol = f.string()
+		case 3:
+			t.Quantity = int(f.int())
+		case 4:
+			t.Price = f.double()
+		case 5:
+			t.Type = strategy.TradeSignal(sideName(f.num))
+		case 6:
+			t.Status = execution.TradeStatus(f.string())
+		case 7:
+			t.CreatedAt, err = f.timestamp()
+		case 8:
+			t.UpdatedAt, err = f.timestamp()
+		case 9:
+			t.Reason = f.string()
+		case 10:
+			t.TargetPrice = f.double()
+		case 11:
+			t.StopPrice = f.double()
+		case 12:
+			t.PositionID = f.string()
+		case 13:
+			t.Strategy = f.string()
+		case 14:
+			t.BracketID = f.string()
+		case 15:
+			t.SignalID = f.string()
+		case 16:
+			t.PlannedQuantity = int(f.int())
+		case 17:
+			t.Entries = int(f.int())
+		case 18:
+			t.ExitsTaken = int(f.int())
+		case 19:
+			t.RealizedPnL = f.double()
+		case 20:
+			var fill execution.Fill
+			err = decode(f.data, func(ff field) error {
+				var err error
+				switch ff.number {
+				case 1:
+					fill.Symbol = ff.string()
+				case 2:
+					fill.Side = strategy.TradeSignal(sideName(ff.num))
+				case 3:
+					fill.Quantity = int(ff.int())
+				case 4:
+					fill.Price = ff.double()
+				case 5:
+					fill.FilledAt, err = ff.timestamp()
+				}
+				return err
+			})
+			t.Fills = append(t.Fills, fill)
+		case 21:
+			t.CorporateActions = append(t.CorporateActions, f.string())
+		case 22:
+			t.MAE = f.double()
+		case 23:
+			t.MFE = f.double()
+		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode trade: %w", err)
+	}
+	return t, nil
+}
+
+// MarshalQuote encodes a quote as a hustler.v1.Quote message
+func MarshalQuote(q *data.Stock) []byte {
+	var e encoder
+	e.string(1, q.Symbol)
+	e.double(2, q.CurrentPrice)
+	e.double(3, q.PreviousClose)
+	e.int(4, q.Volume)
+	e.timestamp(5, q.LastUpdated)
+	e.double(6, q.DailyHigh)
+	e.double(7, q.DailyLow)
+	e.double(8, q.Bid)
+	e.double(9, q.Ask)
+	e.double(10, q.Change)
+	e.double(11, q.ChangePercent)
+	e.bool(12, q.Halted)
+	return e.buf
+}
+
+// UnmarshalQuote decodes a hustler.v1.Quote message
+func UnmarshalQuote(b []byte) (*data.Stock, error) {
+	q := &data.Stock{}
+	err := decode(b, func(f field) error {
+		var err error
+		switch f.number {
+		case 1:
+			q.Symbol = f.string()
+		case 2:
+			q.CurrentPrice = f.double()
+		case 3:
+			q.PreviousClose = f.double()
+		case 4:
+			q.Volume = f.int()
+		case 5:
+			q.LastUpdated, err = f.timestamp()
+		case 6:
+			q.DailyHigh = f.double()
+		case 7:
+			q.DailyLow = f.double()
+		case 8:
+			q.Bid = f.double()
+		case 9:
+			q.Ask = f.double()
+		case 10:
+			q.Change = f.double()
+		case 11:
+			q.ChangePercent = f.double()
+		case 12:
+			q.Halted = f.bool()
+		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode quote: %w", err)
+	}
+	return q, nil
+}
+
+// MarshalMetrics encodes performance metrics as a hustler.v1.Metrics message
+func MarshalMetrics(m *performance.Metrics) []byte {
+	var e encoder
+	e.int(1, int64(m.SignalsCount))
+	e.int(2, int64(m.SuccessCount))
+	e.int(3, int64(m.FailureCount))
+	e.int(4, int64(m.PendingCount))
+	e.double(5, m.SuccessRate)
+	e.double(6, m.AverageROI)
+	e.double(7, m.TotalProfit)
+	for _, key := range sortedKeys(m.SymbolPerformance) {
+		sm := m.SymbolPerformance[key]
+		var v encoder
+		v.string(1, sm.Symbol)
+		v.int(2, int64(sm.SignalsCount))
+		v.int(3, int64(sm.SuccessCount))
+		v.int(4, int64(sm.FailureCount))
+		v.int(5, int64(sm.PendingCount))
+		v.double(6, sm.SuccessRate)
+		v.double(7, sm.AverageROI)
+		v.double(8, sm.TotalProfit)
+		e.bytes(8, mapEntry(key, v.buf))
+	}
+	for _, key := range sortedKeys(m.DailyPerformance) {
+		dm := m.DailyPerformance[key]
+		var v encoder
+		v.string(1, dm.Date)
+		v.int(2, int64(dm.SignalsCount))
+		v.int(3, int64(dm.SuccessCount))
+		v.int(4, int64(dm.FailureCount))
+		v.int(5, int64(dm.PendingCount))
+		v.double(6, dm.SuccessRate)
+		v.double(7, dm.TotalProfit)
+		e.bytes(9, mapEntry(key, v.buf))
+	}
+	e.timestamp(10, m.LastUpdated)
+	return e.buf
+}
+
+// UnmarshalMetrics decodes a hustler.v1.Metrics message
+func UnmarshalMetrics(b []byte) (*performance.Metrics, error) {
+	m := &performance.Metrics{
+		SymbolPerformance: make(map[string]performance.SymbolMetrics),
+		DailyPerformance:  make(map[string]performance.DailyMetrics),
+	}
+	err := decode(b, func(f field) error {
+		var err error
+		switch f.number {
+		case 1:
+			m.SignalsCount = int(f.int())
+		case 2:
+			m.SuccessCount = int(f.int())
+		case 3:
+			m.FailureCount = int(f.int())
+		case 4:
+			m.PendingCount = int(f.int())
+		case 5:
+			m.SuccessRate = f.double()
+		case 6:
+			m.AverageROI = f.double()
+		case 7:
+			m.TotalProfit = f.double()
+		case 8:
+			var sm performance.SymbolMetrics
+			key, value, err := decodeMessageEntry(f.data)
+			if err != nil {
+				return err
+			}
+			err = decode(value, func(v field) error {
+				switch v.number {
+				case 1:
+					sm.Symbol = v.string()
+				case 2:
+					sm.SignalsCount = int(v.int())
+				case 3:
+					sm.SuccessCount = int(v.int())
+				case 4:
+					sm.FailureCount = int(v.int())
+				case 5:
+					sm.PendingCount = int(v.int())
+				case 6:
+					sm.SuccessRate = v.double()
+				case 7:
+					sm.AverageROI = v.double()
+				case 8:
+					sm.TotalProfit = v.double()
+				}
+				return nil
+			})
+			m.SymbolPerformance[key] = sm
+			return err
+		case 9:
+			var dm performance.DailyMetrics
+			key, value, err := decodeMessageEntry(f.data)
+			if err != nil {
+				return err
+			}
+			err = decode(value, func(v field) error {
+				switch v.number {
+				case 1:
+					dm.Date = v.string()
+				case 2:
+					dm.SignalsCount = int(v.int())
+				case 3:
+					dm.SuccessCount = int(v.int())
+				case 4:
+					dm.FailureCount = int(v.int())
+				case 5:
+					dm.PendingCount = int(v.int())
+				case 6:
+					dm.SuccessRate = v.double()
+				case 7:
+					dm.TotalProfit = v.double()
+				}
+				return nil
+			})
+			m.DailyPerformance[key] = dm
+			return err
+		case 10:
+			m.LastUpdated, err = f.timestamp()
+		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode metrics: %w", err)
+	}
+	return m, nil
+}
+
+// mapEntry encodes an entry of a map<string, Message> field
+func mapEntry(key string, value []byte) []byte {
+	var e encoder
+	e.string(1, key)
+	e.bytes(2, value)
+	return e.buf
+}
+
+// decodeDoubleEntry decodes an entry of a map<string, double> field into m
+func decodeDoubleEntry(b []byte, m map[string]float64) error {
+	var key string
+	var value float64
+	err := decode(b, func(f field) error {
+		switch f.number {
+		case 1:
+			key = f.string()
+		case 2:
+			value = f.double()
+		}
+		return nil
+	})
+	m[key] = value
+	return err
+}
+
+// decodeMessageEntry decodes an entry of a map<string, Message> field
+func decodeMessageEntry(b []byte) (string, []byte, error) {
+	var key string
+	var value []byte
+	err := decode(b, func(f field) error {
+		switch f.number {
+		case 1:
+			key = f.string()
+		case 2:
+			value = f.data
+		}
+		return nil
+	})
+	return key, value, err
+}
+
+// sortedKeys returns the keys of a map in order, so encoding is deterministic
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,149 @@
+package pb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/execution"
+	"github.com/hustler/trading-bot/pkg/performance"
+	"github.com/hustler/trading-bot/pkg/signal"
+	"github.com/hustler/trading-bot/pkg/strategy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignalRoundTrip(t *testing.T) {
+	s := &signal.Signal{
+		SchemaVersion: signal.CurrentSchemaVersion,
+		ID:            "SIG-AAPL-BUY-1",
+		Symbol:        "AAPL",
+		Type:          signal.BUY,
+		Price:         190.5,
+		TargetPrice:   195,
+		StopLoss:      -1, // Negative values must survive the encoding
+		Confidence:    0.8,
+		GeneratedAt:   time.Date(2024, 3, 1, 14, 30, 0, 500, time.UTC),
+		TechnicalData: map[string]float64{"rsi": 31.2, "atr": 2.1},
+		Status:        "ACTIVE",
+		StatusChanges: []signal.StatusChange{{Status: signal.StatusActive, At: time.Date(2024, 3, 1, 14, 30, 0, 500, time.UTC)}},
+		MaxShares:     40,
+		Strategies:    []string{"momentum", "breakout"},
+		Currency:      "USD",
+		OrderType:     signal.OrderLimit,
+	}
+
+	encoded := MarshalSignal(s)
+	assert.Equal(t, encoded, MarshalSignal(s), "encoding is deterministic")
+
+	decoded, err := UnmarshalSignal(encoded)
+	assert.NoError(t, err)
+	assert.Equal(t, s, decoded)
+
+	// A message from before schema versioning is upgraded
+	var legacy encoder
+	legacy.string(2, "TD.TO")
+	legacy.varint(3, sideSell)
+	legacy.string(99, "field from a newer schema")
+	decoded, err = UnmarshalSignal(legacy.buf)
+	assert.NoError(t, err)
+	assert.Equal(t, signal.SELL, decoded.Type)
+	assert.Equal(t, "CAD", decoded.Currency)
+
+	_, err = UnmarshalSignal(encoded[:len(encoded)-1])
+	assert.Error(t, err)
+}
+
+func TestTradeAndMetricsRoundTrip(t *testing.T) {
+	opened := time.Date(2024, 3, 1, 14, 30, 0, 0, time.UTC)
+	trade := &execution.Trade{
+		ID:        "T-1",
+		Symbol:    "AAPL",
+		Quantity:  10,
+		Price:     190,
+		Type:      strategy.Buy,
+		Status:    execution.Executed,
+		CreatedAt: opened,
+		Fills: []execution.Fill{
+			{Symbol: "AAPL", Side: strategy.Buy, Quantity: 10, Price: 190, FilledAt: opened},
+		},
+		CorporateActions: []string{"split:2024-02-01"},
+	}
+	decodedTrade, err := UnmarshalTrade(MarshalTrade(trade))
+	assert.NoError(t, err)
+	assert.Equal(t, trade, decodedTrade)
+
+	metrics := &performance.Metrics{
+		SignalsCount:      3,
+		SuccessRate:       66.7,
+		SymbolPerformance: map[string]performance.SymbolMetrics{"AAPL": {Symbol: "AAPL", SignalsCount: 3}},
+		DailyPerformance:  map[string]performance.DailyMetrics{"2024-03-01": {Date: "2024-03-01", TotalProfit: -12.5}},
+		LastUpdated:       opened,
+	}
+	decodedMetrics, err := UnmarshalMetrics(MarshalMetrics(metrics))
+	assert.NoError(t, err)
+	assert.Equal(t, metrics, decodedMetrics)
+}
+
+// signalFixture is a hustler.v1.Signal as encoded by protoc-generated code:
+// fields in number order, proto3 zero values left out
+var signalFixture = []byte{
+	0x0a, 0x02, 'S', '1', // id
+	0x12, 0x04, 'A', 'A', 'P', 'L', // symbol
+	0x18, 0x01, // type: SIDE_BUY
+	0x21, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xf8, 0x3f, // price: 1.5
+	0x31, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xf0, 0xbf, // stop_loss: -1
+	0x52, 0x08, 0x08, 0x80, 0xe2, 0xcf, 0xaa, 0x06, 0x10, 0x05, // generated_at: 1700000000s 5ns
+	0x62, 0x0e, 0x0a, 0x03, 'r', 's', 'i', 0x11, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x3e, 0x40, // technical_data: rsi=30
+	0x70, 0xfe, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01, // max_shares: -2
+	0x82, 0x01, 0x01, 'a', // strategies
+	0x82, 0x01, 0x01, 'b',
+	0xd0, 0x01, 0x02, // schema_version: 2
+	0xda, 0x01, 0x12, 0x0a, 0x06, 'A', 'C', 'T', 'I', 'V', 'E', 0x12, 0x08, 0x08, 0x80, 0xe2, 0xcf, 0xaa, 0x06, 0x10, 0x05, // status_changes
+}
+
+func TestSignalMatchesProtocEncoding(t *testing.T) {
+	generatedAt := time.Unix(1700000000, 5).UTC()
+	s := &signal.Signal{
+		SchemaVersion: 2,
+		ID:            "S1",
+		Symbol:        "AAPL",
+		Type:          signal.BUY,
+		Price:         1.5,
+		StopLoss:      -1,
+		GeneratedAt:   generatedAt,
+		TechnicalData: map[string]float64{"rsi": 30},
+		MaxShares:     -2,
+		Strategies:    []string{"a", "b"},
+		StatusChanges: []signal.StatusChange{{Status: signal.StatusActive, At: generatedAt}},
+	}
+
+	assert.Equal(t, signalFixture, MarshalSignal(s))
+	decoded, err := UnmarshalSignal(signalFixture)
+	require.NoError(t, err)
+	assert.Equal(t, s, decoded)
+
+	// Fields added by a newer schema, of any wire type, are skipped
+	newer := append([]byte{}, signalFixture...)
+	newer = append(newer, 0x9d, 0x06, 0x01, 0x02, 0x03, 0x04) // field 99, fixed32
+	newer = append(newer, 0xa0, 0x06, 0x96, 0x01)             // field 100, varint 150
+	decoded, err = UnmarshalSignal(newer)
+	require.NoError(t, err)
+	assert.Equal(t, s, decoded)
+}
+
+func TestQuoteMatchesProtocEncoding(t *testing.T) {
+	fixture := []byte{
+		0x0a, 0x04, 'A', 'A', 'P', 'L', // symbol
+		0x11, 0x00, 0x00, 0x00, 0x00, 0x00, 0xd0, 0x67, 0x40, // current_price: 190.5
+		0x19, 0x00, 0x00, 0x00, 0x00, 0x00, 0xa8, 0x67, 0x40, // previous_close: 189.25
+		0x20, 0x87, 0xad, 0x4b, // volume: 1234567
+		0x60, 0x01, // halted
+	}
+	quote := &data.Stock{Symbol: "AAPL", CurrentPrice: 190.5, PreviousClose: 189.25, Volume: 1234567, Halted: true}
+
+	assert.Equal(t, fixture, MarshalQuote(quote))
+	decoded, err := UnmarshalQuote(fixture)
+	require.NoError(t, err)
+	assert.Equal(t, quote, decoded)
+}
@@ -0,0 +1,167 @@
+package pb
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Wire types of the protobuf encoding
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// errTruncated is returned when a message ends in the middle of a field
+var errTruncated = errors.New("truncated message")
+
+// encoder appends fields in the protobuf wire format. Like proto3, fields
+// holding their zero value are left out.
+type encoder struct {
+	buf []byte
+}
+
+func (e *encoder) tag(field, wireType int) {
+	e.buf = binary.AppendUvarint(e.buf, uint64(field)<<3|uint64(wireType))
+}
+
+func (e *encoder) varint(field int, v uint64) {
+	if v == 0 {
+		return
+	}
+	e.tag(field, wireVarint)
+	e.buf = binary.AppendUvarint(e.buf, v)
+}
+
+// int encodes int32 and int64 fields; negative values are sign extended
+func (e *encoder) int(field int, v int64) {
+	e.varint(field, uint64(v))
+}
+
+func (e *encoder) bool(field int, v bool) {
+	if v {
+		e.varint(field, 1)
+	}
+}
+
+func (e *encoder) double(field int, v float64) {
+	if v == 0 {
+		return
+	}
+	e.tag(field, wireFixed64)
+	e.buf = binary.LittleEndian.AppendUint64(e.buf, math.Float64bits(v))
+}
+
+func (e *encoder) string(field int, v string) {
+	if v == "" {
+		return
+	}
+	e.bytes(field, []byte(v))
+}
+
+// bytes encodes a length-delimited field, always, so empty nested messages
+// and repeated elements are kept
+func (e *encoder) bytes(field int, v []byte) {
+	e.tag(field, wireBytes)
+	e.buf = binary.AppendUvarint(e.buf, uint64(len(v)))
+	e.buf = append(e.buf, v...)
+}
+
+func (e *encoder) strings(field int, values []string) {
+	for _, v := range values {
+		e.bytes(field, []byte(v))
+	}
+}
+
+// timestamp encodes a google.protobuf.Timestamp; the zero time is left out
+func (e *encoder) timestamp(field int, t time.Time) {
+	if t.IsZero() {
+		return
+	}
+	var ts encoder
+	ts.int(1, t.Unix())
+	ts.int(2, int64(t.Nanosecond()))
+	e.bytes(field, ts.buf)
+}
+
+// field is one decoded field. Varint and fixed values are in num; bytes
+// fields are in data.
+type field struct {
+	number int
+	num    uint64
+	data   []byte
+}
+
+func (f field) int() int64      { return int64(f.num) }
+func (f field) bool() bool      { return f.num != 0 }
+func (f field) double() float64 { return math.Float64frombits(f.num) }
+func (f field) string() string  { return string(f.data) }
+
+func (f field) timestamp() (time.Time, error) {
+	var seconds, nanos int64
+	err := decode(f.data, func(ts field) error {
+		switch ts.number {
+		case 1:
+			seconds = ts.int()
+		case 2:
+			nanos = ts.int()
+		}
+		return nil
+	})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to decode timestamp: %w", err)
+	}
+	return time.Unix(seconds, nanos).UTC(), nil
+}
+
+// decode calls fn with each field of a message in order. Fields fn does not
+// know are skipped, so messages from newer schemas still decode.
+func decode(data []byte, fn func(field) error) error {
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 {
+			return errTruncated
+		}
+		data = data[n:]
+
+		f := field{number: int(key >> 3)}
+		switch key & 7 {
+		case wireVarint:
+			f.num, n = binary.Uvarint(data)
+			if n <= 0 {
+				return errTruncated
+			}
+			data = data[n:]
+		case wireFixed64:
+			if len(data) < 8 {
+				return errTruncated
+			}
+			f.num = binary.LittleEndian.Uint64(data)
+			data = data[8:]
+		case wireBytes:
+			size, n := binary.Uvarint(data)
+			if n <= 0 || uint64(len(data)-n) < size {
+				return errTruncated
+			}
+			f.data = data[n : n+int(size)]
+			data = data[n+int(size):]
+		case wireFixed32:
+			if len(data) < 4 {
+				return errTruncated
+			}
+			f.num = uint64(binary.LittleEndian.Uint32(data))
+			data = data[4:]
+		default:
+			return fmt.Errorf("unsupported wire type %d in field %d", key&7, f.number)
+		}
+
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
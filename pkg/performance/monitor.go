@@ -1,9 +1,13 @@
 package performance
 
 import (
+	"fmt"
 	"sync"
 	"time"
 
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/market"
 	"github.com/hustler/trading-bot/pkg/signal"
 )
 
@@ -18,6 +22,8 @@ type Metrics struct {
 	TotalProfit       float64            `json:"total_profit"`
 	SymbolPerformance map[string]SymbolMetrics `json:"symbol_performance"`
 	DailyPerformance  map[string]DailyMetrics  `json:"daily_performance"`
+	Slippage          SlippageMetrics            `json:"slippage"`
+	SlippageByHour    map[int]SlippageMetrics    `json:"slippage_by_hour"` // By hour of publication, exchange time
 	LastUpdated       time.Time          `json:"last_updated"`
 }
 
@@ -31,6 +37,7 @@ type SymbolMetrics struct {
 	SuccessRate  float64 `json:"success_rate"`
 	AverageROI   float64 `json:"average_roi"`
 	TotalProfit  float64 `json:"total_profit"`
+	Slippage     SlippageMetrics `json:"slippage"`
 }
 
 // DailyMetrics represents performance metrics for a specific day
@@ -44,18 +51,18 @@ type DailyMetrics struct {
 	TotalProfit  float64 `json:"total_profit"`
 }
 
-// SignalStatus represents the status of a signal
-type SignalStatus string
+// SignalStatus represents the status of a signal, shared with signal.Signal
+type SignalStatus = signal.Status
 
 const (
 	// StatusActive indicates the signal is active
-	StatusActive SignalStatus = "ACTIVE"
+	StatusActive = signal.StatusActive
 	// StatusSuccess indicates the signal was successful
-	StatusSuccess SignalStatus = "SUCCESS"
+	StatusSuccess = signal.StatusSuccess
 	// StatusFailure indicates the signal failed
-	StatusFailure SignalStatus = "FAILURE"
+	StatusFailure = signal.StatusFailure
 	// StatusExpired indicates the signal expired
-	StatusExpired SignalStatus = "EXPIRED"
+	StatusExpired = signal.StatusExpired
 )
 
 // SignalResult represents the result of a signal
@@ -72,6 +79,17 @@ type SignalResult struct {
 	Status      SignalStatus `json:"status"`
 	GeneratedAt time.Time   `json:"generated_at"`
 	CompletedAt time.Time   `json:"completed_at"`
+	CrowdUp     int         `json:"crowd_up"`   // 👍 reactions from subscribers
+	CrowdDown   int         `json:"crowd_down"` // 👎 reactions from subscribers
+	Strategies  []string    `json:"strategies,omitempty"` // Ensemble strategies that voted for the signal
+	Regime      string      `json:"regime,omitempty"`
+	ParamSet    string      `json:"param_set,omitempty"`
+	PublishedAt time.Time   `json:"published_at"`
+	// First price obtainable after publication and how much worse than the
+	// signal price it was, in percent; zero until a later price is observed
+	FillablePrice float64   `json:"fillable_price,omitempty"`
+	FillableAt    time.Time `json:"fillable_at,omitempty"`
+	Slippage      float64   `json:"slippage,omitempty"`
 }
 
 // Monitor tracks and analyzes trading signal performance
@@ -79,6 +97,11 @@ type Monitor struct {
 	signals      []*signal.Signal
 	results      []*SignalResult
 	metrics      *Metrics
+	clock        clock.Clock
+	retention    config.RetentionPolicy
+	archiver     Archiver // Receives results dropped by the retention policy
+	session      *market.Clock   // Exchange time zone slippage is bucketed in
+	unslipped    []*SignalResult // Results still waiting for a price to measure slippage at
 	mu           sync.RWMutex
 }
 
@@ -90,16 +113,31 @@ func NewMonitor() *Monitor {
 		metrics:      &Metrics{
 			SymbolPerformance: make(map[string]SymbolMetrics),
 			DailyPerformance:  make(map[string]DailyMetrics),
+			SlippageByHour:    make(map[int]SlippageMetrics),
 			LastUpdated:       time.Now(),
 		},
+		clock:        clock.Real{},
+		session:      market.DefaultClock(),
 		mu:           sync.RWMutex{},
 	}
 }
 
-// AddSignal adds a new signal to the monitor
-func (m *Monitor) AddSignal(s *signal.Signal) {
+// SetClock sets the clock outcomes are timestamped with
+func (m *Monitor) SetClock(c clock.Clock) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.clock = c
+}
+
+// AddSignal adds a copy of a new signal to the monitor. Test signals are
+// ignored so they never appear in the track record.
+func (m *Monitor) AddSignal(s *signal.Signal) {
+	if s.Test {
+		return
+	}
+	s = s.Clone()
+
+	m.mu.Lock()
 	
 	// Add signal to list
 	m.signals = append(m.signals, s)
@@ -115,16 +153,30 @@ func (m *Monitor) AddSignal(s *signal.Signal) {
 		ExpectedROI: s.ExpectedROI,
 		Status:      StatusActive,
 		GeneratedAt: s.GeneratedAt,
+		Strategies:  s.Strategies,
+		Regime:      s.Regime,
+		ParamSet:    s.ParamSet,
+		PublishedAt: m.clock.Now(),
 	}
 	
 	m.results = append(m.results, result)
+	m.unslipped = append(m.unslipped, result)
 	
 	// Update metrics
 	m.updateMetrics()
+	
+	// Spill results outside the retention policy
+	spilled := m.pruneResults()
+	archiver := m.archiver
+	m.mu.Unlock()
+	
+	archiveResults(archiver, spilled)
 }
 
-// UpdateSignalStatus updates the status of a signal
-func (m *Monitor) UpdateSignalStatus(signalID string, status SignalStatus, exitPrice float64) {
+// UpdateSignalStatus updates the status of a signal. Signals not tracked are
+// ignored; a change the status lifecycle does not allow returns an error
+// wrapping signal.ErrInvalidTransition.
+func (m *Monitor) UpdateSignalStatus(signalID string, status SignalStatus, exitPrice float64) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	
@@ -138,13 +190,16 @@ func (m *Monitor) UpdateSignalStatus(signalID string, status SignalStatus, exitP
 	}
 	
 	if result == nil {
-		return
+		return nil
+	}
+	if err := signal.ValidateTransition(result.Status, status); err != nil {
+		return fmt.Errorf("failed to update signal %s: %w", signalID, err)
 	}
 	
 	// Update result
 	result.Status = status
 	result.ExitPrice = exitPrice
-	result.CompletedAt = time.Now()
+	result.CompletedAt = m.clock.Now()
 	
 	// Calculate actual ROI
 	if result.Type == "BUY" {
@@ -155,6 +210,73 @@ func (m *Monitor) UpdateSignalStatus(signalID string, status SignalStatus, exitP
 	
 	// Update metrics
 	m.updateMetrics()
+	return nil
+}
+
+// CloseSignalsAtMarket closes every active signal for a symbol at exitPrice, e.g. when
+// positions are flattened at the end of the day. Signals closed in profit count as
+// successes. It returns the number of signals closed.
+func (m *Monitor) CloseSignalsAtMarket(symbol string, exitPrice float64) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	closed := 0
+	for _, r := range m.results {
+		if r.Symbol != symbol || r.Status != StatusActive {
+			continue
+		}
+
+		if r.Type == "BUY" {
+			r.ActualROI = (exitPrice - r.EntryPrice) / r.EntryPrice * 100
+		} else {
+			r.ActualROI = (r.EntryPrice - exitPrice) / r.EntryPrice * 100
+		}
+		r.Status = StatusFailure
+		if r.ActualROI > 0 {
+			r.Status = StatusSuccess
+		}
+		r.ExitPrice = exitPrice
+		r.CompletedAt = m.clock.Now()
+		closed++
+	}
+
+	if closed > 0 {
+		m.updateMetrics()
+	}
+	return closed
+}
+
+// AdjustSignal updates the tracked target and stop of an active signal.
+// A zero value leaves that level unchanged.
+func (m *Monitor) AdjustSignal(signalID string, targetPrice, stopLoss float64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, r := range m.results {
+		if r.SignalID != signalID {
+			continue
+		}
+		if r.Status != StatusActive {
+			return fmt.Errorf("signal %s is not active", signalID)
+		}
+
+		if targetPrice > 0 {
+			r.TargetPrice = targetPrice
+			if r.Type == "BUY" {
+				r.ExpectedROI = (targetPrice - r.EntryPrice) / r.EntryPrice * 100
+			} else {
+				r.ExpectedROI = (r.EntryPrice - targetPrice) / r.EntryPrice * 100
+			}
+		}
+		if stopLoss > 0 {
+			r.StopLoss = stopLoss
+		}
+
+		m.updateMetrics()
+		return nil
+	}
+
+	return fmt.Errorf("signal not found: %s", signalID)
 }
 
 // GetMetrics returns the current performance metrics
@@ -176,6 +298,11 @@ func (m *Monitor) GetMetrics() *Metrics {
 		metricsCopy.DailyPerformance[k] = v
 	}
 	
+	metricsCopy.SlippageByHour = make(map[int]SlippageMetrics, len(m.metrics.SlippageByHour))
+	for k, v := range m.metrics.SlippageByHour {
+		metricsCopy.SlippageByHour[k] = v
+	}
+	
 	return &metricsCopy
 }
 
@@ -187,8 +314,7 @@ func (m *Monitor) GetResults() []*SignalResult {
 	// Return a copy to avoid race conditions
 	resultsCopy := make([]*SignalResult, len(m.results))
 	for i, r := range m.results {
-		resultCopy := *r
-		resultsCopy[i] = &resultCopy
+		resultsCopy[i] = r.clone()
 	}
 	
 	return resultsCopy
@@ -203,8 +329,7 @@ func (m *Monitor) GetResultsBySymbol(symbol string) []*SignalResult {
 	
 	for _, r := range m.results {
 		if r.Symbol == symbol {
-			resultCopy := *r
-			results = append(results, &resultCopy)
+			results = append(results, r.clone())
 		}
 	}
 	
@@ -220,14 +345,20 @@ func (m *Monitor) GetResultsByDate(date string) []*SignalResult {
 	
 	for _, r := range m.results {
 		if r.GeneratedAt.Format("2006-01-02") == date {
-			resultCopy := *r
-			results = append(results, &resultCopy)
+			results = append(results, r.clone())
 		}
 	}
 	
 	return results
 }
 
+// clone returns a copy of the result that shares no slices with it
+func (r *SignalResult) clone() *SignalResult {
+	resultCopy := *r
+	resultCopy.Strategies = append([]string(nil), r.Strategies...)
+	return &resultCopy
+}
+
 // updateMetrics recalculates performance metrics
 func (m *Monitor) updateMetrics() {
 	// Reset counts
@@ -243,6 +374,11 @@ func (m *Monitor) updateMetrics() {
 	// Reset daily performance
 	dailyPerformance := make(map[string]DailyMetrics)
 	
+	// Reset slippage, summed per group and averaged below
+	var slippage SlippageMetrics
+	symbolSlippage := make(map[string]SlippageMetrics)
+	hourlySlippage := make(map[int]SlippageMetrics)
+	
 	// Calculate metrics
 	for _, r := range m.results {
 		// Get or create symbol metrics
@@ -280,9 +416,9 @@ func (m *Monitor) updateMetrics() {
 			m.metrics.FailureCount++
 			metrics.FailureCount++
 			daily.FailureCount++
-			m.metrics.TotalProfit -= r.ActualROI // Negative ROI
-			metrics.TotalProfit -= r.ActualROI
-			daily.TotalProfit -= r.ActualROI
+			m.metrics.TotalProfit += r.ActualROI // Already negative, for shorts as for longs
+			metrics.TotalProfit += r.ActualROI
+			daily.TotalProfit += r.ActualROI
 		case StatusExpired:
 			m.metrics.FailureCount++
 			metrics.FailureCount++
@@ -293,6 +429,14 @@ func (m *Monitor) updateMetrics() {
 			daily.PendingCount++
 		}
 		
+		// Sum slippage where a price after publication was observed
+		if r.FillablePrice > 0 {
+			hour := r.PublishedAt.In(m.session.Location()).Hour()
+			slippage = slippage.add(r.Slippage)
+			symbolSlippage[symbol] = symbolSlippage[symbol].add(r.Slippage)
+			hourlySlippage[hour] = hourlySlippage[hour].add(r.Slippage)
+		}
+		
 		// Update symbol metrics
 		symbolPerformance[symbol] = metrics
 		
@@ -314,6 +458,7 @@ func (m *Monitor) updateMetrics() {
 			metrics.SuccessRate = float64(metrics.SuccessCount) / float64(completedCount) * 100
 			metrics.AverageROI = metrics.TotalProfit / float64(completedCount)
 		}
+		metrics.Slippage = symbolSlippage[symbol].average()
 		symbolPerformance[symbol] = metrics
 	}
 	
@@ -329,5 +474,10 @@ func (m *Monitor) updateMetrics() {
 	// Update metrics
 	m.metrics.SymbolPerformance = symbolPerformance
 	m.metrics.DailyPerformance = dailyPerformance
-	m.metrics.LastUpdated = time.Now()
+	m.metrics.Slippage = slippage.average()
+	for hour, hourly := range hourlySlippage {
+		hourlySlippage[hour] = hourly.average()
+	}
+	m.metrics.SlippageByHour = hourlySlippage
+	m.metrics.LastUpdated = m.clock.Now()
 }
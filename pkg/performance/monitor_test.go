@@ -1,6 +1,7 @@
 package performance
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -70,6 +71,17 @@ func TestAddSignal(t *testing.T) {
 	assert.Equal(t, 1, dailyMetrics.PendingCount)
 }
 
+func TestAddSignalIgnoresTestSignals(t *testing.T) {
+	monitor := NewMonitor()
+
+	testSignal := createTestSignal("AAPL", signal.BUY, 150.0, 155.0, 148.0)
+	testSignal.Test = true
+	monitor.AddSignal(testSignal)
+
+	assert.Empty(t, monitor.GetResults())
+	assert.Equal(t, 0, monitor.GetMetrics().SignalsCount)
+}
+
 func TestUpdateSignalStatus(t *testing.T) {
 	monitor := NewMonitor()
 	
@@ -250,3 +262,129 @@ func createTestSignal(symbol string, signalType signal.SignalType, price, target
 		Status:        "ACTIVE",
 	}
 }
+
+func TestAdjustSignal(t *testing.T) {
+	monitor := NewMonitor()
+	s := createTestSignal("AAPL", signal.BUY, 100.0, 105.0, 97.0)
+	monitor.AddSignal(s)
+
+	err := monitor.AdjustSignal(s.ID, 110.0, 0)
+	assert.NoError(t, err)
+
+	result := monitor.GetResults()[0]
+	assert.Equal(t, 110.0, result.TargetPrice)
+	assert.Equal(t, 97.0, result.StopLoss)
+	assert.InDelta(t, 10.0, result.ExpectedROI, 0.01)
+
+	err = monitor.AdjustSignal(s.ID, 0, 98.0)
+	assert.NoError(t, err)
+	assert.Equal(t, 98.0, monitor.GetResults()[0].StopLoss)
+
+	// Completed signals can no longer be adjusted
+	monitor.UpdateSignalStatus(s.ID, StatusSuccess, 110.0)
+	assert.Error(t, monitor.AdjustSignal(s.ID, 120.0, 0))
+	assert.Error(t, monitor.AdjustSignal("missing", 120.0, 0))
+}
+
+func TestSentimentStats(t *testing.T) {
+	monitor := NewMonitor()
+	liked := createTestSignal("AAPL", signal.BUY, 100.0, 105.0, 97.0)
+	disliked := createTestSignal("MSFT", signal.BUY, 200.0, 210.0, 195.0)
+	unrated := createTestSignal("GOOGL", signal.BUY, 150.0, 155.0, 147.0)
+	monitor.AddSignal(liked)
+	monitor.AddSignal(disliked)
+	monitor.AddSignal(unrated)
+
+	assert.NoError(t, monitor.RecordSentiment(liked.ID, 12, 3))
+	assert.NoError(t, monitor.RecordSentiment(disliked.ID, 1, 9))
+	assert.Error(t, monitor.RecordSentiment("missing", 1, 0))
+
+	// Active signals are rated but not yet compared with outcomes
+	stats := monitor.GetSentimentStats()
+	assert.Equal(t, 2, stats.RatedSignals)
+	assert.Equal(t, 0, stats.BullishCount)
+
+	monitor.UpdateSignalStatus(liked.ID, StatusSuccess, 105.0)
+	monitor.UpdateSignalStatus(disliked.ID, StatusSuccess, 210.0)
+	monitor.UpdateSignalStatus(unrated.ID, StatusFailure, 147.0)
+
+	stats = monitor.GetSentimentStats()
+	assert.Equal(t, 1, stats.BullishCount)
+	assert.Equal(t, 100.0, stats.BullishSuccessRate)
+	assert.Equal(t, 1, stats.BearishCount)
+	assert.Equal(t, 100.0, stats.BearishSuccessRate)
+	assert.Equal(t, 50.0, stats.AgreementRate)
+}
+
+func TestCloseSignalsAtMarket(t *testing.T) {
+	monitor := NewMonitor()
+	monitor.AddSignal(createTestSignal("AAPL", signal.BUY, 100.0, 105.0, 97.0))
+	monitor.AddSignal(createTestSignal("MSFT", signal.SELL, 200.0, 190.0, 205.0))
+
+	assert.Equal(t, 1, monitor.CloseSignalsAtMarket("AAPL", 102.0))
+	assert.Equal(t, 0, monitor.CloseSignalsAtMarket("AAPL", 101.0))
+
+	results := monitor.GetResultsBySymbol("AAPL")
+	assert.Equal(t, StatusSuccess, results[0].Status)
+	assert.InDelta(t, 2.0, results[0].ActualROI, 0.01)
+
+	// A SELL signal closed above entry is a loss
+	assert.Equal(t, 1, monitor.CloseSignalsAtMarket("MSFT", 202.0))
+	assert.Equal(t, StatusFailure, monitor.GetResultsBySymbol("MSFT")[0].Status)
+}
+
+// Run with -race: results and metrics handed out are copies, so readers may
+// change them while signals are added and closed
+func TestMonitorConcurrentAccess(t *testing.T) {
+	monitor := NewMonitor()
+
+	const writers, signals = 4, 50
+	done := make(chan struct{})
+	readers := make(chan struct{})
+	go func() {
+		defer close(readers)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			for _, r := range monitor.GetResults() {
+				r.Strategies = append(r.Strategies, "reader")
+				r.Status = StatusFailure
+			}
+			metrics := monitor.GetMetrics()
+			metrics.SymbolPerformance["READER"] = SymbolMetrics{}
+			monitor.TrackRecord("AAPL", 5)
+		}
+	}()
+
+	finished := make(chan struct{}, writers)
+	for w := 0; w < writers; w++ {
+		go func(w int) {
+			for i := 0; i < signals; i++ {
+				s := createTestSignal("AAPL", signal.BUY, 150.0, 155.0, 148.0)
+				s.ID = fmt.Sprintf("SIG-%d-%d", w, i)
+				s.Strategies = []string{"volatility"}
+				monitor.AddSignal(s)
+				s.Strategies[0] = "changed after adding"
+				monitor.UpdateSignalStatus(s.ID, StatusSuccess, 155.0)
+			}
+			finished <- struct{}{}
+		}(w)
+	}
+	for w := 0; w < writers; w++ {
+		<-finished
+	}
+	close(done)
+	<-readers
+
+	results := monitor.GetResults()
+	assert.Len(t, results, writers*signals)
+	for _, r := range results {
+		assert.Equal(t, StatusSuccess, r.Status)
+		assert.Equal(t, []string{"volatility"}, r.Strategies)
+	}
+	_, ok := monitor.GetMetrics().SymbolPerformance["READER"]
+	assert.False(t, ok)
+}
@@ -0,0 +1,69 @@
+package performance
+
+import "sort"
+
+// PayoffStats summarizes the wins and losses of recent closed signals
+type PayoffStats struct {
+	Trades      int     `json:"trades"`
+	WinRate     float64 `json:"win_rate"`     // 0-1
+	AverageWin  float64 `json:"average_win"`  // Mean ROI of winners, percent
+	AverageLoss float64 `json:"average_loss"` // Mean ROI of losers as a positive percent
+	PayoffRatio float64 `json:"payoff_ratio"` // AverageWin over AverageLoss; 0 without both wins and losses
+}
+
+// Kelly returns the Kelly criterion fraction W - (1-W)/R. It is 0 without a
+// payoff ratio and negative when the history has no edge.
+func (s PayoffStats) Kelly() float64 {
+	if s.PayoffRatio <= 0 {
+		return 0
+	}
+	return s.WinRate - (1-s.WinRate)/s.PayoffRatio
+}
+
+// PayoffStats returns the payoff statistics of the last window closed signals,
+// or of all closed signals when window is 0. A signal counts as a win when it
+// closed with a positive ROI.
+func (m *Monitor) PayoffStats(window int) PayoffStats {
+	m.mu.RLock()
+	closed := make([]SignalResult, 0, len(m.results))
+	for _, r := range m.results {
+		if r.Status != StatusActive {
+			closed = append(closed, *r)
+		}
+	}
+	m.mu.RUnlock()
+
+	sort.SliceStable(closed, func(i, j int) bool {
+		return closed[i].CompletedAt.After(closed[j].CompletedAt)
+	})
+	if window > 0 && len(closed) > window {
+		closed = closed[:window]
+	}
+
+	stats := PayoffStats{Trades: len(closed)}
+	var wins, losses int
+	var totalWin, totalLoss float64
+	for _, r := range closed {
+		if r.ActualROI > 0 {
+			wins++
+			totalWin += r.ActualROI
+		} else {
+			losses++
+			totalLoss -= r.ActualROI
+		}
+	}
+
+	if stats.Trades > 0 {
+		stats.WinRate = float64(wins) / float64(stats.Trades)
+	}
+	if wins > 0 {
+		stats.AverageWin = totalWin / float64(wins)
+	}
+	if losses > 0 {
+		stats.AverageLoss = totalLoss / float64(losses)
+	}
+	if stats.AverageWin > 0 && stats.AverageLoss > 0 {
+		stats.PayoffRatio = stats.AverageWin / stats.AverageLoss
+	}
+	return stats
+}
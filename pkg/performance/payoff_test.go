@@ -0,0 +1,45 @@
+package performance
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/signal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayoffStatsAndKelly(t *testing.T) {
+	fake := clock.NewFake(time.Date(2025, 4, 21, 14, 0, 0, 0, time.UTC))
+	monitor := NewMonitor()
+	monitor.SetClock(fake)
+
+	// Oldest first: a large loss that falls outside the window, then three 4%
+	// winners and two 2% losers
+	exits := []float64{80, 104, 98, 104, 98, 104}
+	for i, exit := range exits {
+		s := createTestSignal("AAPL", signal.BUY, 100.0, 105.0, 95.0)
+		s.ID = fmt.Sprintf("SIG-%d", i)
+		monitor.AddSignal(s)
+		fake.Advance(time.Minute)
+		monitor.UpdateSignalStatus(s.ID, StatusSuccess, exit)
+	}
+	// Active signals are left out
+	monitor.AddSignal(createTestSignal("MSFT", signal.BUY, 100.0, 105.0, 95.0))
+
+	stats := monitor.PayoffStats(5)
+	assert.Equal(t, 5, stats.Trades)
+	assert.InDelta(t, 0.6, stats.WinRate, 0.0001)
+	assert.InDelta(t, 4.0, stats.AverageWin, 0.0001)
+	assert.InDelta(t, 2.0, stats.AverageLoss, 0.0001)
+	assert.InDelta(t, 2.0, stats.PayoffRatio, 0.0001)
+	assert.InDelta(t, 0.4, stats.Kelly(), 0.0001)
+
+	// The large loss leaves the full history without an edge
+	all := monitor.PayoffStats(0)
+	assert.Equal(t, 6, all.Trades)
+	assert.Less(t, all.Kelly(), 0.0)
+
+	assert.Equal(t, 0.0, NewMonitor().PayoffStats(10).Kelly())
+}
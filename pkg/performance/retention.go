@@ -0,0 +1,80 @@
+package performance
+
+import (
+	"log"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+)
+
+// Archiver stores records spilled from memory by a retention policy (implemented by store.Logger)
+type Archiver interface {
+	ArchiveRecord(kind, key string, createdAt time.Time, record interface{}) error
+}
+
+// SetRetention bounds the signal results kept in memory. Results outside the
+// policy are stored with archiver, or discarded when it is nil. Metrics cover
+// retained results only.
+func (m *Monitor) SetRetention(policy config.RetentionPolicy, archiver Archiver) {
+	m.mu.Lock()
+	m.retention = policy
+	m.archiver = archiver
+	spilled := m.pruneResults()
+	m.mu.Unlock()
+
+	archiveResults(archiver, spilled)
+}
+
+// pruneResults removes results outside the retention policy, along with their
+// signals, and returns them. Active results are only removed by the count limit.
+// Caller must hold the lock.
+func (m *Monitor) pruneResults() []*SignalResult {
+	policy := m.retention
+	if policy.MaxCount == 0 && policy.MaxAgeHours == 0 {
+		return nil
+	}
+
+	now := m.clock.Now()
+	var kept, spilled []*SignalResult
+	dropped := make(map[string]bool)
+	total := len(m.results)
+	for i, r := range m.results {
+		rank := total - 1 - i
+		keep := policy.Keep(rank, r.GeneratedAt, now)
+		if !keep && r.Status == StatusActive && (policy.MaxCount == 0 || rank < policy.MaxCount) {
+			keep = true
+		}
+		if keep {
+			kept = append(kept, r)
+		} else {
+			spilled = append(spilled, r)
+			dropped[r.SignalID] = true
+		}
+	}
+	if len(spilled) == 0 {
+		return nil
+	}
+
+	m.results = kept
+	signals := m.signals[:0]
+	for _, s := range m.signals {
+		if !dropped[s.ID] {
+			signals = append(signals, s)
+		}
+	}
+	m.signals = signals
+	m.updateMetrics()
+	return spilled
+}
+
+// archiveResults stores spilled results with archiver, if one is set
+func archiveResults(archiver Archiver, spilled []*SignalResult) {
+	if archiver == nil {
+		return
+	}
+	for _, r := range spilled {
+		if err := archiver.ArchiveRecord("signal_result", r.SignalID, r.GeneratedAt, r); err != nil {
+			log.Printf("Error archiving signal result %s: %v", r.SignalID, err)
+		}
+	}
+}
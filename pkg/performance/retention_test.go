@@ -0,0 +1,57 @@
+package performance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/signal"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingArchiver records archived keys by kind
+type recordingArchiver struct {
+	keys map[string][]string
+}
+
+func (a *recordingArchiver) ArchiveRecord(kind, key string, createdAt time.Time, record interface{}) error {
+	a.keys[kind] = append(a.keys[kind], key)
+	return nil
+}
+
+func TestRetentionSpillsOldResults(t *testing.T) {
+	now := time.Date(2025, 4, 21, 14, 0, 0, 0, time.UTC)
+	monitor := NewMonitor()
+	monitor.SetClock(clock.NewFake(now))
+	archiver := &recordingArchiver{keys: make(map[string][]string)}
+	monitor.SetRetention(config.RetentionPolicy{MaxCount: 3, MaxAgeHours: 24}, archiver)
+
+	add := func(id string, age time.Duration, closed bool) {
+		s := createTestSignal("AAPL", signal.BUY, 100.0, 105.0, 97.0)
+		s.ID = id
+		s.GeneratedAt = now.Add(-age)
+		monitor.AddSignal(s)
+		if closed {
+			monitor.UpdateSignalStatus(id, StatusSuccess, 105.0)
+		}
+	}
+
+	// Closed and old is spilled by age; active and old is kept until the count limit
+	add("old-closed", 48*time.Hour, true)
+	add("old-active", 48*time.Hour, false)
+	add("new-1", time.Hour, true)
+	assert.Equal(t, []string{"old-closed"}, archiver.keys["signal_result"])
+
+	add("new-2", time.Hour, true)
+	assert.Equal(t, []string{"old-closed"}, archiver.keys["signal_result"])
+
+	add("new-3", time.Hour, true)
+	assert.Equal(t, []string{"old-closed", "old-active"}, archiver.keys["signal_result"])
+
+	results := monitor.GetResults()
+	assert.Len(t, results, 3)
+	assert.Equal(t, "new-1", results[0].SignalID)
+	assert.Len(t, monitor.signals, 3)
+	assert.Equal(t, 3, monitor.GetMetrics().SignalsCount)
+}
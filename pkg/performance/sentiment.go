@@ -0,0 +1,77 @@
+package performance
+
+import "fmt"
+
+// SentimentStats compares subscriber sentiment on signals with their actual outcomes
+type SentimentStats struct {
+	RatedSignals       int     `json:"rated_signals"`        // Signals with at least one reaction
+	BullishCount       int     `json:"bullish_count"`        // Completed signals the crowd liked
+	BullishSuccessRate float64 `json:"bullish_success_rate"` // Success rate of signals the crowd liked
+	BearishCount       int     `json:"bearish_count"`        // Completed signals the crowd disliked
+	BearishSuccessRate float64 `json:"bearish_success_rate"` // Success rate of signals the crowd disliked
+	AgreementRate      float64 `json:"agreement_rate"`       // How often the crowd called the outcome
+}
+
+// RecordSentiment records the current reaction totals for a signal
+func (m *Monitor) RecordSentiment(signalID string, up, down int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, r := range m.results {
+		if r.SignalID == signalID {
+			r.CrowdUp = up
+			r.CrowdDown = down
+			return nil
+		}
+	}
+
+	return fmt.Errorf("signal not found: %s", signalID)
+}
+
+// GetSentimentStats compares crowd sentiment with actual outcomes. Only completed
+// signals with a clear majority of 👍 or 👎 count towards the rates.
+func (m *Monitor) GetSentimentStats() SentimentStats {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var stats SentimentStats
+	var bullishWins, bearishWins, agreements int
+	for _, r := range m.results {
+		if r.CrowdUp+r.CrowdDown == 0 {
+			continue
+		}
+		stats.RatedSignals++
+
+		if r.Status == StatusActive || r.CrowdUp == r.CrowdDown {
+			continue
+		}
+
+		success := r.Status == StatusSuccess
+		if r.CrowdUp > r.CrowdDown {
+			stats.BullishCount++
+			if success {
+				bullishWins++
+				agreements++
+			}
+		} else {
+			stats.BearishCount++
+			if success {
+				bearishWins++
+			} else {
+				agreements++
+			}
+		}
+	}
+
+	if stats.BullishCount > 0 {
+		stats.BullishSuccessRate = float64(bullishWins) / float64(stats.BullishCount) * 100
+	}
+	if stats.BearishCount > 0 {
+		stats.BearishSuccessRate = float64(bearishWins) / float64(stats.BearishCount) * 100
+	}
+	if decided := stats.BullishCount + stats.BearishCount; decided > 0 {
+		stats.AgreementRate = float64(agreements) / float64(decided) * 100
+	}
+
+	return stats
+}
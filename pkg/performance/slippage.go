@@ -0,0 +1,103 @@
+package performance
+
+import (
+	"sort"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/market"
+)
+
+// slippageWindow is how long after publication a signal waits for a price to
+// measure its slippage at before it is left out
+const slippageWindow = 24 * time.Hour
+
+// SlippageMetrics is the slippage of a group of signals: how much worse the
+// first price obtainable after publication was than the signal price
+type SlippageMetrics struct {
+	Signals         int     `json:"signals"`
+	AverageSlippage float64 `json:"average_slippage"` // Percent; negative when prices moved in the signal's favor
+}
+
+// add sums one more signal's slippage into the total
+func (s SlippageMetrics) add(slippage float64) SlippageMetrics {
+	return SlippageMetrics{Signals: s.Signals + 1, AverageSlippage: s.AverageSlippage + slippage}
+}
+
+// average turns the total of add into the average
+func (s SlippageMetrics) average() SlippageMetrics {
+	if s.Signals > 0 {
+		s.AverageSlippage /= float64(s.Signals)
+	}
+	return s
+}
+
+// SetSession sets the exchange whose time of day slippage is grouped by
+func (m *Monitor) SetSession(session *market.Clock) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.session = session
+}
+
+// Observe records the slippage of published signals at the first price after
+// their publication. It matches the MarketMonitor OnMarketData callback.
+func (m *Monitor) Observe(marketData map[string]*data.MarketData, now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	observed := false
+	waiting := m.unslipped[:0]
+	for _, r := range m.unslipped {
+		var price float64
+		var at time.Time
+		md, ok := marketData[r.Symbol]
+		if ok && md != nil && len(md.Prices) > 0 {
+			price, at, ok = firstPriceAfter(md, r.PublishedAt, now)
+		} else {
+			ok = false
+		}
+		if !ok {
+			if now.Sub(r.PublishedAt) < slippageWindow {
+				waiting = append(waiting, r)
+			}
+			continue
+		}
+		r.FillablePrice = price
+		r.FillableAt = at
+		r.Slippage = slippageOf(r.Type, r.EntryPrice, price)
+		observed = true
+	}
+	clear(m.unslipped[len(waiting):])
+	m.unslipped = waiting
+
+	if observed {
+		m.updateMetrics()
+	}
+}
+
+// firstPriceAfter returns the close of the first bar after publishedAt. Data
+// without timestamps counts as the latest price at now.
+func firstPriceAfter(md *data.MarketData, publishedAt, now time.Time) (float64, time.Time, bool) {
+	if len(md.Timestamps) != len(md.Prices) {
+		return md.Prices[len(md.Prices)-1], now, now.After(publishedAt)
+	}
+	i := sort.Search(len(md.Timestamps), func(i int) bool {
+		return md.Timestamps[i].After(publishedAt)
+	})
+	if i == len(md.Timestamps) {
+		return 0, time.Time{}, false
+	}
+	return md.Prices[i], md.Timestamps[i], true
+}
+
+// slippageOf returns how much worse price is than the signal price in percent:
+// higher for a BUY, lower for a SELL
+func slippageOf(signalType string, signalPrice, price float64) float64 {
+	if signalPrice == 0 {
+		return 0
+	}
+	if signalType == "SELL" {
+		return (signalPrice - price) / signalPrice * 100
+	}
+	return (price - signalPrice) / signalPrice * 100
+}
@@ -0,0 +1,49 @@
+package performance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/signal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserveMeasuresSlippageAfterPublication(t *testing.T) {
+	// 10:00 in New York
+	published := time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(published)
+	monitor := NewMonitor()
+	monitor.SetClock(fake)
+
+	buy := createTestSignal("AAPL", signal.BUY, 100.0, 105.0, 98.0)
+	sell := createTestSignal("MSFT", signal.SELL, 200.0, 190.0, 204.0)
+	monitor.AddSignal(buy)
+	monitor.AddSignal(sell)
+
+	bars := func(prices ...float64) *data.MarketData {
+		md := &data.MarketData{Prices: prices}
+		for i := range prices {
+			md.Timestamps = append(md.Timestamps, published.Add(time.Duration(i-1)*time.Minute))
+		}
+		return md
+	}
+
+	// Only bars after publication count; MSFT has none yet
+	fake.Advance(time.Minute)
+	monitor.Observe(map[string]*data.MarketData{"AAPL": bars(100, 100, 100.5), "MSFT": bars(200, 200)}, fake.Now())
+	metrics := monitor.GetMetrics()
+	assert.Equal(t, 1, metrics.Slippage.Signals)
+	assert.InDelta(t, 0.5, metrics.Slippage.AverageSlippage, 1e-9)
+
+	// A SELL slips as the price falls; the first later bar is kept
+	fake.Advance(time.Minute)
+	monitor.Observe(map[string]*data.MarketData{"AAPL": bars(100, 100, 100.5, 110), "MSFT": bars(200, 200, 199, 195)}, fake.Now())
+	metrics = monitor.GetMetrics()
+	assert.Equal(t, 2, metrics.Slippage.Signals)
+	assert.InDelta(t, 0.5, metrics.SymbolPerformance["AAPL"].Slippage.AverageSlippage, 1e-9)
+	assert.InDelta(t, 0.5, metrics.SymbolPerformance["MSFT"].Slippage.AverageSlippage, 1e-9)
+	assert.Equal(t, SlippageMetrics{Signals: 2, AverageSlippage: 0.5}, metrics.SlippageByHour[10])
+	assert.Equal(t, 199.0, monitor.GetResultsBySymbol("MSFT")[0].FillablePrice)
+}
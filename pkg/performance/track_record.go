@@ -0,0 +1,81 @@
+package performance
+
+import "sort"
+
+// CallRecord summarizes the most recent closed calls of one type on a symbol
+type CallRecord struct {
+	Symbol     string  `json:"symbol"`
+	Type       string  `json:"type"`
+	Calls      int     `json:"calls"`
+	Wins       int     `json:"wins"`
+	Losses     int     `json:"losses"`
+	AverageROI float64 `json:"average_roi"`
+	Streak     int     `json:"streak"` // Consecutive wins (positive) or losses (negative) up to the latest call
+}
+
+// TrackRecord returns the record of the last limit closed calls on symbol for
+// each signal type, BUY before SELL. A call counts as a win when it closed with
+// a positive ROI.
+func (m *Monitor) TrackRecord(symbol string, limit int) []CallRecord {
+	m.mu.RLock()
+	closed := make(map[string][]*SignalResult)
+	for _, r := range m.results {
+		if r.Symbol == symbol && r.Status != StatusActive {
+			closed[r.Type] = append(closed[r.Type], r.clone())
+		}
+	}
+	m.mu.RUnlock()
+
+	types := make([]string, 0, len(closed))
+	for signalType := range closed {
+		types = append(types, signalType)
+	}
+	sort.Strings(types)
+
+	records := make([]CallRecord, 0, len(types))
+	for _, signalType := range types {
+		results := closed[signalType]
+		sort.SliceStable(results, func(i, j int) bool {
+			return results[i].CompletedAt.After(results[j].CompletedAt)
+		})
+		if limit > 0 && len(results) > limit {
+			results = results[:limit]
+		}
+		records = append(records, summarizeCalls(symbol, signalType, results))
+	}
+	return records
+}
+
+// summarizeCalls builds a record from closed results ordered newest first
+func summarizeCalls(symbol, signalType string, results []*SignalResult) CallRecord {
+	record := CallRecord{Symbol: symbol, Type: signalType, Calls: len(results)}
+
+	var totalROI float64
+	streakOpen := true
+	for i, r := range results {
+		won := r.ActualROI > 0
+		if won {
+			record.Wins++
+		} else {
+			record.Losses++
+		}
+		totalROI += r.ActualROI
+
+		if !streakOpen {
+			continue
+		}
+		switch {
+		case i == 0 && won, record.Streak > 0 && won:
+			record.Streak++
+		case i == 0 && !won, record.Streak < 0 && !won:
+			record.Streak--
+		default:
+			streakOpen = false
+		}
+	}
+
+	if record.Calls > 0 {
+		record.AverageROI = totalROI / float64(record.Calls)
+	}
+	return record
+}
@@ -0,0 +1,58 @@
+package performance
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/signal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrackRecordSummarizesRecentCalls(t *testing.T) {
+	fake := clock.NewFake(time.Date(2025, 4, 21, 14, 0, 0, 0, time.UTC))
+	monitor := NewMonitor()
+	monitor.SetClock(fake)
+
+	// Oldest first: one win, then four losing BUY calls, plus a winning SELL call
+	exits := []float64{105, 98, 97, 99, 96}
+	for i, exit := range exits {
+		s := createTestSignal("AAPL", signal.BUY, 100.0, 105.0, 95.0)
+		s.ID = fmt.Sprintf("BUY-%d", i)
+		monitor.AddSignal(s)
+		fake.Advance(time.Minute)
+		monitor.UpdateSignalStatus(s.ID, StatusFailure, exit)
+	}
+	sell := createTestSignal("AAPL", signal.SELL, 100.0, 95.0, 105.0)
+	sell.ID = "SELL-0"
+	monitor.AddSignal(sell)
+	monitor.UpdateSignalStatus(sell.ID, StatusSuccess, 95)
+
+	// Active signals and other symbols are left out
+	monitor.AddSignal(createTestSignal("AAPL", signal.BUY, 100.0, 105.0, 95.0))
+	monitor.AddSignal(createTestSignal("MSFT", signal.BUY, 100.0, 105.0, 95.0))
+
+	records := monitor.TrackRecord("AAPL", 4)
+	assert.Len(t, records, 2)
+
+	buy := records[0]
+	assert.Equal(t, "BUY", buy.Type)
+	assert.Equal(t, 4, buy.Calls)
+	assert.Equal(t, 0, buy.Wins)
+	assert.Equal(t, 4, buy.Losses)
+	assert.Equal(t, -4, buy.Streak)
+	assert.InDelta(t, -2.5, buy.AverageROI, 0.001)
+
+	assert.Equal(t, "SELL", records[1].Type)
+	assert.Equal(t, 1, records[1].Wins)
+	assert.Equal(t, 1, records[1].Streak)
+
+	// With the older win included the losing streak stops at four
+	all := monitor.TrackRecord("AAPL", 0)
+	assert.Equal(t, 5, all[0].Calls)
+	assert.Equal(t, 1, all[0].Wins)
+	assert.Equal(t, -4, all[0].Streak)
+
+	assert.Empty(t, monitor.TrackRecord("TSLA", 5))
+}
@@ -0,0 +1,105 @@
+package privacy
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Source is a component that stores data about subscribers (implemented by
+// store.Logger, alerts.Engine and telegram.Bot)
+type Source interface {
+	ExportSubscriber(userID int64) (interface{}, error)
+	DeleteSubscriber(userID int64) error
+}
+
+// Export is everything stored about one subscriber
+type Export struct {
+	UserID     int64                  `json:"user_id"`
+	ExportedAt time.Time              `json:"exported_at"`
+	Data       map[string]interface{} `json:"data"` // Source name -> what it stores about the subscriber
+}
+
+// Service answers subscriber data export and deletion requests across every
+// registered source
+type Service struct {
+	sources map[string]Source
+	now     func() time.Time
+	mu      sync.RWMutex
+}
+
+// NewService creates a subscriber data service
+func NewService() *Service {
+	return &Service{
+		sources: make(map[string]Source),
+		now:     time.Now,
+	}
+}
+
+// AddSource registers a source under the name its data is exported as
+func (s *Service) AddSource(name string, source Source) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sources[name] = source
+}
+
+// Export collects the data every source stores about userID
+func (s *Service) Export(userID int64) (*Export, error) {
+	if userID == 0 {
+		return nil, fmt.Errorf("user ID is required")
+	}
+
+	export := &Export{
+		UserID:     userID,
+		ExportedAt: s.now().UTC(),
+		Data:       make(map[string]interface{}),
+	}
+	for _, name := range s.names() {
+		data, err := s.source(name).ExportSubscriber(userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export %s data: %w", name, err)
+		}
+		export.Data[name] = data
+	}
+	return export, nil
+}
+
+// Delete removes the data every source stores about userID. Every source is
+// attempted even if one fails.
+func (s *Service) Delete(userID int64) error {
+	if userID == 0 {
+		return fmt.Errorf("user ID is required")
+	}
+
+	var errs []error
+	for _, name := range s.names() {
+		if err := s.source(name).DeleteSubscriber(userID); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete %s data: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// names returns the registered source names in a stable order
+func (s *Service) names() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	names := make([]string, 0, len(s.sources))
+	for name := range s.sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// source returns the source registered under name
+func (s *Service) source(name string) Source {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.sources[name]
+}
@@ -0,0 +1,64 @@
+package privacy
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hustler/trading-bot/pkg/alerts"
+	"github.com/stretchr/testify/assert"
+)
+
+type failingSource struct {
+	deleted bool
+}
+
+func (f *failingSource) ExportSubscriber(userID int64) (interface{}, error) {
+	return nil, errors.New("database unavailable")
+}
+
+func (f *failingSource) DeleteSubscriber(userID int64) error {
+	f.deleted = true
+	return errors.New("database unavailable")
+}
+
+func TestExportAndDeleteSubscriberData(t *testing.T) {
+	engine := alerts.NewEngine()
+	_, err := engine.AddRule(42, "AAPL > 200")
+	assert.NoError(t, err)
+	_, err = engine.AddRule(7, "MSFT > 400")
+	assert.NoError(t, err)
+
+	service := NewService()
+	service.AddSource("alerts", engine)
+
+	export, err := service.Export(42)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), export.UserID)
+	assert.Len(t, export.Data["alerts"], 1)
+
+	assert.NoError(t, service.Delete(42))
+	assert.Empty(t, engine.ListRules(42))
+	assert.Len(t, engine.ListRules(7), 1)
+
+	_, err = service.Export(0)
+	assert.Error(t, err)
+}
+
+func TestDeleteAttemptsEverySource(t *testing.T) {
+	engine := alerts.NewEngine()
+	_, err := engine.AddRule(42, "AAPL > 200")
+	assert.NoError(t, err)
+	failing := &failingSource{}
+
+	service := NewService()
+	service.AddSource("alerts", engine)
+	service.AddSource("store", failing)
+
+	err = service.Delete(42)
+	assert.ErrorContains(t, err, "failed to delete store data")
+	assert.True(t, failing.deleted)
+	assert.Empty(t, engine.ListRules(42))
+
+	_, err = service.Export(42)
+	assert.ErrorContains(t, err, "failed to export store data")
+}
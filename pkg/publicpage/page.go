@@ -0,0 +1,256 @@
+package publicpage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/artifacts"
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/follower"
+	"github.com/hustler/trading-bot/pkg/performance"
+)
+
+// PerformanceSource provides signal metrics and outcomes (implemented by performance.Monitor)
+type PerformanceSource interface {
+	GetMetrics() *performance.Metrics
+	GetResults() []*performance.SignalResult
+}
+
+// FollowerSource reports the simulated subscriber's results (implemented by follower.Simulator)
+type FollowerSource interface {
+	Summary() follower.Summary
+}
+
+// ArtifactUploader stores generated files in object storage (implemented by artifacts.Uploader)
+type ArtifactUploader interface {
+	Upload(kind, name string, data []byte, contentType string) (artifacts.Artifact, error)
+}
+
+// Call is a closed signal listed on the page
+type Call struct {
+	SignalID    string    `json:"signal_id"`
+	Symbol      string    `json:"symbol"`
+	Type        string    `json:"type"`
+	Status      string    `json:"status"`
+	EntryPrice  float64   `json:"entry_price"`
+	ExitPrice   float64   `json:"exit_price"`
+	ROI         float64   `json:"roi"`
+	GeneratedAt time.Time `json:"generated_at"`
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+// Page is the published track record
+type Page struct {
+	Title       string            `json:"title"`
+	GeneratedAt time.Time         `json:"generated_at"`
+	Since       time.Time         `json:"since,omitempty"` // First tracked signal
+	Signals     int               `json:"signals"`
+	Wins        int               `json:"wins"`
+	Losses      int               `json:"losses"`
+	Pending     int               `json:"pending"`
+	WinRate     float64           `json:"win_rate"`
+	AverageROI  float64           `json:"average_roi"`
+	Follower    *follower.Summary `json:"follower,omitempty"`
+	Recent      []Call            `json:"recent"`
+	Disclaimer  string            `json:"disclaimer"`
+}
+
+// Generator writes the performance page as index.html and performance.json
+type Generator struct {
+	config      config.PublicPageConfig
+	performance PerformanceSource
+	follower    FollowerSource
+	uploader    ArtifactUploader
+	now         func() time.Time
+}
+
+// NewGenerator creates a page generator. follower may be nil when the follower
+// simulation is disabled.
+func NewGenerator(cfg config.PublicPageConfig, performance PerformanceSource, follower FollowerSource) *Generator {
+	return &Generator{
+		config:      cfg,
+		performance: performance,
+		follower:    follower,
+		now:         time.Now,
+	}
+}
+
+// SetUploader sets where each written page is also stored as a report
+func (g *Generator) SetUploader(uploader ArtifactUploader) {
+	g.uploader = uploader
+}
+
+// Build assembles the page from the current metrics
+func (g *Generator) Build() Page {
+	metrics := g.performance.GetMetrics()
+	page := Page{
+		Title:       g.config.Title,
+		GeneratedAt: g.now().UTC(),
+		Signals:     metrics.SignalsCount,
+		Wins:        metrics.SuccessCount,
+		Losses:      metrics.FailureCount,
+		Pending:     metrics.PendingCount,
+		WinRate:     metrics.SuccessRate,
+		AverageROI:  metrics.AverageROI,
+		Recent:      []Call{},
+		Disclaimer:  g.config.Disclaimer,
+	}
+	if g.follower != nil {
+		summary := g.follower.Summary()
+		page.Follower = &summary
+	}
+
+	results := g.performance.GetResults()
+	for _, r := range results {
+		if page.Since.IsZero() || r.GeneratedAt.Before(page.Since) {
+			page.Since = r.GeneratedAt
+		}
+		if r.Status == performance.StatusActive {
+			continue
+		}
+		page.Recent = append(page.Recent, Call{
+			SignalID:    r.SignalID,
+			Symbol:      r.Symbol,
+			Type:        r.Type,
+			Status:      string(r.Status),
+			EntryPrice:  r.EntryPrice,
+			ExitPrice:   r.ExitPrice,
+			ROI:         r.ActualROI,
+			GeneratedAt: r.GeneratedAt,
+			CompletedAt: r.CompletedAt,
+		})
+	}
+	sort.Slice(page.Recent, func(i, j int) bool {
+		return page.Recent[i].CompletedAt.After(page.Recent[j].CompletedAt)
+	})
+	if len(page.Recent) > g.config.RecentCalls {
+		page.Recent = page.Recent[:g.config.RecentCalls]
+	}
+	return page
+}
+
+// Write builds the page and writes it to the output directory. Files are
+// replaced atomically so a web server never serves a partial page.
+func (g *Generator) Write() error {
+	page := g.Build()
+
+	body, err := json.MarshalIndent(page, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode performance page: %w", err)
+	}
+	var html bytes.Buffer
+	if err := pageTemplate.Execute(&html, page); err != nil {
+		return fmt.Errorf("failed to render performance page: %w", err)
+	}
+
+	if err := os.MkdirAll(g.config.OutputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := writeFile(filepath.Join(g.config.OutputDir, "performance.json"), body); err != nil {
+		return err
+	}
+	if err := writeFile(filepath.Join(g.config.OutputDir, "index.html"), html.Bytes()); err != nil {
+		return err
+	}
+
+	if g.uploader == nil {
+		return nil
+	}
+	if _, err := g.uploader.Upload(artifacts.KindReport, "performance.json", body, "application/json"); err != nil {
+		return err
+	}
+	if _, err := g.uploader.Upload(artifacts.KindReport, "index.html", html.Bytes(), "text/html; charset=utf-8"); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Run writes the page now and then every refresh interval until stop is closed
+func (g *Generator) Run(stop <-chan struct{}) {
+	ticker := time.NewTicker(time.Duration(g.config.RefreshHours) * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		if err := g.Write(); err != nil {
+			log.Printf("Error writing public performance page: %v", err)
+		}
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// writeFile replaces path with data via a temporary file in the same directory
+func writeFile(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".page-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+	return nil
+}
+
+// pageTemplate renders the page as a self-contained HTML document
+var pageTemplate = template.Must(template.New("page").Funcs(template.FuncMap{
+	"pct":  func(v float64) string { return fmt.Sprintf("%+.2f%%", v) },
+	"rate": func(v float64) string { return fmt.Sprintf("%.1f%%", v) },
+	"date": func(t time.Time) string { return t.UTC().Format("2006-01-02 15:04 UTC") },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; max-width: 960px; margin: 2rem auto; padding: 0 1rem; color: #222; }
+.stats { display: flex; flex-wrap: wrap; gap: 1rem; }
+.stat { flex: 1 1 160px; border: 1px solid #ddd; border-radius: 8px; padding: 1rem; }
+.stat b { display: block; font-size: 1.6rem; }
+table { width: 100%; border-collapse: collapse; margin-top: 1rem; font-size: 0.9rem; }
+th, td { text-align: left; padding: 0.4rem; border-bottom: 1px solid #eee; }
+.up { color: #1a7f37; } .down { color: #cf222e; }
+footer { margin-top: 2rem; font-size: 0.8rem; color: #666; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p>Updated {{date .GeneratedAt}}{{if not .Since.IsZero}}, tracking since {{date .Since}}{{end}}. Raw data: <a href="performance.json">performance.json</a></p>
+<div class="stats">
+<div class="stat"><b>{{.Signals}}</b>signals published</div>
+<div class="stat"><b>{{rate .WinRate}}</b>win rate ({{.Wins}} won, {{.Losses}} lost)</div>
+<div class="stat"><b>{{pct .AverageROI}}</b>average ROI per signal</div>
+{{with .Follower}}<div class="stat"><b>{{pct .AvgROI}}</b>average ROI of a follower entering {{.EntryDelay}}s late ({{.Closed}} trades, {{.Missed}} missed)</div>{{end}}
+</div>
+<h2>Recent calls</h2>
+<table>
+<tr><th>Closed</th><th>Symbol</th><th>Type</th><th>Entry</th><th>Exit</th><th>ROI</th><th>Result</th></tr>
+{{range .Recent}}<tr><td>{{date .CompletedAt}}</td><td>{{.Symbol}}</td><td>{{.Type}}</td><td>{{printf "$%.2f" .EntryPrice}}</td><td>{{printf "$%.2f" .ExitPrice}}</td><td class="{{if gt .ROI 0.0}}up{{else}}down{{end}}">{{pct .ROI}}</td><td>{{.Status}}</td></tr>
+{{else}}<tr><td colspan="7">No closed signals yet.</td></tr>
+{{end}}</table>
+<footer>{{.Disclaimer}}</footer>
+</body>
+</html>
+`))
@@ -0,0 +1,62 @@
+package publicpage
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/follower"
+	"github.com/hustler/trading-bot/pkg/performance"
+	"github.com/hustler/trading-bot/pkg/signal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWritePublishesHTMLAndJSON(t *testing.T) {
+	generated := time.Date(2025, 4, 14, 14, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(generated)
+	perf := performance.NewMonitor()
+	perf.SetClock(fake)
+	perf.AddSignal(&signal.Signal{ID: "SIG-AAPL", Symbol: "AAPL", Type: signal.BUY, Price: 100, TargetPrice: 104, GeneratedAt: generated})
+	perf.AddSignal(&signal.Signal{ID: "SIG-MSFT", Symbol: "MSFT", Type: signal.BUY, Price: 200, TargetPrice: 210, GeneratedAt: generated.Add(time.Hour)})
+	perf.AddSignal(&signal.Signal{ID: "SIG-TSLA", Symbol: "TSLA", Type: signal.BUY, Price: 50, TargetPrice: 55, GeneratedAt: generated.Add(2 * time.Hour)})
+	fake.Set(generated.Add(3 * time.Hour))
+	perf.UpdateSignalStatus("SIG-AAPL", performance.StatusSuccess, 104)
+	fake.Set(generated.Add(4 * time.Hour))
+	perf.UpdateSignalStatus("SIG-MSFT", performance.StatusFailure, 196)
+
+	dir := filepath.Join(t.TempDir(), "public")
+	cfg := config.PublicPageConfig{OutputDir: dir, Title: "Track Record", RefreshHours: 24, RecentCalls: 1, Disclaimer: "Not financial advice."}
+	sim := follower.NewSimulator(config.FollowerConfig{EntryDelaySeconds: 60})
+	gen := NewGenerator(cfg, perf, sim)
+	gen.now = func() time.Time { return generated.Add(24 * time.Hour) }
+
+	assert.NoError(t, gen.Write())
+
+	body, err := os.ReadFile(filepath.Join(dir, "performance.json"))
+	assert.NoError(t, err)
+	var page Page
+	assert.NoError(t, json.Unmarshal(body, &page))
+	assert.Equal(t, 3, page.Signals)
+	assert.Equal(t, 1, page.Wins)
+	assert.Equal(t, 1, page.Losses)
+	assert.Equal(t, generated, page.Since)
+	assert.Equal(t, "Not financial advice.", page.Disclaimer)
+	assert.NotNil(t, page.Follower)
+	// Only the most recently closed call is kept
+	assert.Len(t, page.Recent, 1)
+	assert.Equal(t, "SIG-MSFT", page.Recent[0].SignalID)
+
+	html, err := os.ReadFile(filepath.Join(dir, "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(html), "<title>Track Record</title>")
+	assert.Contains(t, string(html), "Not financial advice.")
+	assert.Contains(t, string(html), "MSFT")
+
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
@@ -0,0 +1,213 @@
+package quota
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/config"
+)
+
+// Metered kinds of usage
+const (
+	Signals   = "signals"    // Signals published per day
+	LLMTokens = "llm_tokens" // LLM tokens used per day
+	APICalls  = "api_calls"  // API calls per hour
+)
+
+// Global is the tenant name the total across all tenants is reported under
+const Global = "*"
+
+// DefaultTenant meters usage that belongs to no named tenant
+const DefaultTenant = "default"
+
+// Kinds lists the metered kinds of usage
+var Kinds = []string{Signals, LLMTokens, APICalls}
+
+// Usage is one tenant's use of one kind in the current window
+type Usage struct {
+	Tenant    string    `json:"tenant"`
+	Kind      string    `json:"kind"`
+	Used      int       `json:"used"`
+	Limit     int       `json:"limit"`     // 0 is unlimited
+	Remaining int       `json:"remaining"` // Only meaningful with a limit
+	Exhausted bool      `json:"exhausted"`
+	ResetsAt  time.Time `json:"resets_at"`
+}
+
+// counter is the usage of one tenant and kind within a window
+type counter struct {
+	window time.Time // Start of the window the count belongs to
+	used   int
+}
+
+// key identifies a counter
+type key struct {
+	tenant string
+	kind   string
+}
+
+// Manager enforces per-tenant and global quotas over fixed UTC windows: a day
+// for signals and LLM tokens, an hour for API calls
+type Manager struct {
+	config   config.QuotaConfig
+	counters map[key]*counter
+	clock    clock.Clock
+	mu       sync.Mutex
+}
+
+// NewManager creates a quota manager
+func NewManager(cfg config.QuotaConfig) *Manager {
+	return &Manager{
+		config:   cfg,
+		counters: make(map[key]*counter),
+		clock:    clock.Real{},
+	}
+}
+
+// SetClock sets the clock that windows are measured with
+func (m *Manager) SetClock(c clock.Clock) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.clock = c
+}
+
+// Allow consumes amount of kind for tenant if neither the tenant's nor the
+// global quota would be exceeded, and reports whether it did
+func (m *Manager) Allow(tenant, kind string, amount int) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.clock.Now()
+	tenantCount := m.counter(tenant, kind, now)
+	globalCount := m.counter(Global, kind, now)
+	if exceeds(tenantCount, m.limit(tenant, kind), amount) || exceeds(globalCount, m.limit(Global, kind), amount) {
+		return false
+	}
+
+	tenantCount.used += amount
+	globalCount.used += amount
+	return true
+}
+
+// Record consumes amount of kind for tenant without checking the quotas, for
+// usage only known afterwards such as LLM tokens
+func (m *Manager) Record(tenant, kind string, amount int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.clock.Now()
+	m.counter(tenant, kind, now).used += amount
+	m.counter(Global, kind, now).used += amount
+}
+
+// Exhausted reports whether tenant's or the global quota of kind is used up
+func (m *Manager) Exhausted(tenant, kind string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.clock.Now()
+	return exceeds(m.counter(tenant, kind, now), m.limit(tenant, kind), 1) ||
+		exceeds(m.counter(Global, kind, now), m.limit(Global, kind), 1)
+}
+
+// Status returns the current usage of the global quota and of every tenant
+// that is configured or has used something, global first
+func (m *Manager) Status() []Usage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tenants := map[string]bool{DefaultTenant: true}
+	for tenant := range m.config.Tenants {
+		tenants[tenant] = true
+	}
+	for k := range m.counters {
+		if k.tenant != Global {
+			tenants[k.tenant] = true
+		}
+	}
+	names := make([]string, 0, len(tenants))
+	for tenant := range tenants {
+		names = append(names, tenant)
+	}
+	sort.Strings(names)
+	names = append([]string{Global}, names...)
+
+	now := m.clock.Now()
+	status := make([]Usage, 0, len(names)*len(Kinds))
+	for _, tenant := range names {
+		for _, kind := range Kinds {
+			c := m.counter(tenant, kind, now)
+			limit := m.limit(tenant, kind)
+			usage := Usage{
+				Tenant:    tenant,
+				Kind:      kind,
+				Used:      c.used,
+				Limit:     limit,
+				Exhausted: exceeds(c, limit, 1),
+				ResetsAt:  c.window.Add(windowLength(kind)),
+			}
+			if limit > 0 {
+				usage.Remaining = max(limit-c.used, 0)
+			}
+			status = append(status, usage)
+		}
+	}
+	return status
+}
+
+// counter returns the counter of tenant and kind, reset if its window has passed
+func (m *Manager) counter(tenant, kind string, now time.Time) *counter {
+	k := key{tenant: tenant, kind: kind}
+	window := now.UTC().Truncate(windowLength(kind))
+
+	c, ok := m.counters[k]
+	if !ok {
+		c = &counter{window: window}
+		m.counters[k] = c
+	}
+	if !c.window.Equal(window) {
+		c.window = window
+		c.used = 0
+	}
+	return c
+}
+
+// limit returns the limit of kind for tenant; zero is unlimited
+func (m *Manager) limit(tenant, kind string) int {
+	limits := m.config.Default
+	if tenant == Global {
+		limits = m.config.Global
+	} else if tenantLimits, ok := m.config.Tenants[tenant]; ok {
+		limits = tenantLimits
+	}
+
+	switch kind {
+	case Signals:
+		return limits.SignalsPerDay
+	case LLMTokens:
+		return limits.LLMTokensPerDay
+	case APICalls:
+		return limits.APICallsPerHour
+	}
+	return 0
+}
+
+// exceeds reports whether using amount more would go over limit
+func exceeds(c *counter, limit, amount int) bool {
+	return limit > 0 && c.used+amount > limit
+}
+
+// windowLength returns the length of the window kind is metered over
+func windowLength(kind string) time.Duration {
+	if kind == APICalls {
+		return time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// EstimateTokens approximates the LLM tokens of text at four characters per token
+func EstimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
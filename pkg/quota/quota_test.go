@@ -0,0 +1,58 @@
+package quota
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllowEnforcesTenantAndGlobalQuotas(t *testing.T) {
+	start := time.Date(2025, 4, 14, 14, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(start)
+	manager := NewManager(config.QuotaConfig{
+		Enabled: true,
+		Global:  config.QuotaLimits{SignalsPerDay: 3},
+		Default: config.QuotaLimits{SignalsPerDay: 2, APICallsPerHour: 1},
+		Tenants: map[string]config.QuotaLimits{"vip": {}},
+	})
+	manager.SetClock(fake)
+
+	assert.True(t, manager.Allow("tech", Signals, 1))
+	assert.True(t, manager.Allow("tech", Signals, 1))
+	assert.False(t, manager.Allow("tech", Signals, 1))
+	assert.True(t, manager.Exhausted("tech", Signals))
+
+	// Unlimited tenants are still held to the global quota
+	assert.True(t, manager.Allow("vip", Signals, 1))
+	assert.False(t, manager.Allow("vip", Signals, 1))
+
+	// API calls are metered hourly
+	assert.True(t, manager.Allow("alice", APICalls, 1))
+	assert.False(t, manager.Allow("alice", APICalls, 1))
+	fake.Set(start.Add(time.Hour))
+	assert.True(t, manager.Allow("alice", APICalls, 1))
+	assert.False(t, manager.Allow("tech", Signals, 1))
+
+	// Signal quotas reset at the start of the next UTC day
+	fake.Set(start.Add(10 * time.Hour))
+	assert.True(t, manager.Allow("tech", Signals, 1))
+
+	status := manager.Status()
+	assert.Equal(t, Global, status[0].Tenant)
+	assert.Equal(t, Signals, status[0].Kind)
+	assert.Equal(t, 1, status[0].Used)
+	assert.Equal(t, 2, status[0].Remaining)
+}
+
+func TestRecordMetersUsageKnownAfterwards(t *testing.T) {
+	manager := NewManager(config.QuotaConfig{Enabled: true, Default: config.QuotaLimits{LLMTokensPerDay: 1000}})
+
+	assert.False(t, manager.Exhausted("tech", LLMTokens))
+	manager.Record("tech", LLMTokens, 1200)
+	assert.True(t, manager.Exhausted("tech", LLMTokens))
+	assert.False(t, manager.Exhausted("energy", LLMTokens))
+	assert.Equal(t, 3, EstimateTokens("abcdefghij"))
+}
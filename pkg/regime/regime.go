@@ -0,0 +1,177 @@
+package regime
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/indicators"
+	"github.com/hustler/trading-bot/pkg/market"
+)
+
+// Regime is the market regime
+type Regime string
+
+const (
+	Trending       Regime = "TRENDING"
+	Ranging        Regime = "RANGING"
+	HighVolatility Regime = "HIGH_VOL"
+)
+
+// Classification is the regime of a trading day and the statistics behind it
+type Classification struct {
+	Regime     Regime    `json:"regime"`
+	Symbol     string    `json:"symbol"` // Index the regime was computed from
+	Day        time.Time `json:"day"`
+	Volatility float64   `json:"volatility"` // Annualized realized volatility, percent
+	Efficiency float64   `json:"efficiency"` // Net move over total path length, 0-1
+	Trend      float64   `json:"trend"`      // Percent change over the lookback
+}
+
+// Classify labels the regime from daily closes, oldest first. High realized
+// volatility takes precedence; otherwise an efficient, directional path is
+// trending and a choppy one ranging.
+func Classify(closes []float64, cfg config.RegimeConfig) (Classification, error) {
+	if len(closes) < 3 {
+		return Classification{}, fmt.Errorf("not enough history to classify regime: %d closes", len(closes))
+	}
+
+	c := Classification{
+		Symbol:     cfg.IndexSymbol,
+		Volatility: indicators.HistoricalVolatility(closes, cfg.VolatilityDays),
+		Efficiency: efficiencyRatio(closes, cfg.LookbackDays),
+	}
+
+	window := closes
+	if cfg.LookbackDays > 0 && len(window) > cfg.LookbackDays+1 {
+		window = window[len(window)-cfg.LookbackDays-1:]
+	}
+	if window[0] > 0 {
+		c.Trend = (window[len(window)-1] - window[0]) / window[0] * 100
+	}
+
+	switch {
+	case cfg.HighVolatility > 0 && c.Volatility >= cfg.HighVolatility:
+		c.Regime = HighVolatility
+	case c.Efficiency >= cfg.TrendEfficiency:
+		c.Regime = Trending
+	default:
+		c.Regime = Ranging
+	}
+	return c, nil
+}
+
+// efficiencyRatio returns the Kaufman efficiency ratio over the last period
+// changes: 1 for a straight line, near 0 for a market going nowhere
+func efficiencyRatio(closes []float64, period int) float64 {
+	if period <= 0 || period >= len(closes) {
+		period = len(closes) - 1
+	}
+	window := closes[len(closes)-period-1:]
+
+	var path float64
+	for i := 1; i < len(window); i++ {
+		path += math.Abs(window[i] - window[i-1])
+	}
+	if path == 0 {
+		return 0
+	}
+	return math.Abs(window[len(window)-1]-window[0]) / path
+}
+
+// DailyHistorySource provides daily closes (implemented by data.Provider)
+type DailyHistorySource interface {
+	GetDailyHistory(symbol string, days int) (*data.MarketData, error)
+}
+
+// Store persists daily classifications (implemented by store.Logger)
+type Store interface {
+	SaveRegime(c Classification) error
+}
+
+// Classifier computes the market regime once per trading day from index data
+type Classifier struct {
+	source  DailyHistorySource
+	config  config.RegimeConfig
+	store   Store
+	session *market.Clock
+	current *Classification
+	mu      sync.RWMutex
+}
+
+// NewClassifier creates a new Classifier
+func NewClassifier(source DailyHistorySource, cfg config.RegimeConfig) *Classifier {
+	return &Classifier{
+		source:  source,
+		config:  cfg,
+		session: market.DefaultClock(),
+	}
+}
+
+// SetStore sets where each day's classification is stored
+func (c *Classifier) SetStore(store Store) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.store = store
+}
+
+// Refresh classifies the regime for the trading day of now, reusing the
+// classification if the day was already classified. On error the previous
+// classification, if any, stays current.
+func (c *Classifier) Refresh(now time.Time) (Classification, error) {
+	day := c.session.TradingDay(now)
+
+	c.mu.RLock()
+	current := c.current
+	c.mu.RUnlock()
+	if current != nil && current.Day.Equal(day) {
+		return *current, nil
+	}
+
+	days := c.config.LookbackDays
+	if c.config.VolatilityDays > days {
+		days = c.config.VolatilityDays
+	}
+	history, err := c.source.GetDailyHistory(c.config.IndexSymbol, days+1)
+	if err != nil {
+		return c.fallback(fmt.Errorf("failed to get %s history: %w", c.config.IndexSymbol, err))
+	}
+
+	classification, err := Classify(history.Prices, c.config)
+	if err != nil {
+		return c.fallback(err)
+	}
+	classification.Day = day
+
+	c.mu.Lock()
+	c.current = &classification
+	store := c.store
+	c.mu.Unlock()
+
+	if store != nil {
+		if err := store.SaveRegime(classification); err != nil {
+			return classification, fmt.Errorf("failed to save regime: %w", err)
+		}
+	}
+	return classification, nil
+}
+
+// Current returns the latest classification
+func (c *Classifier) Current() (Classification, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.current == nil {
+		return Classification{}, false
+	}
+	return *c.current, true
+}
+
+// fallback returns the previous classification along with err
+func (c *Classifier) fallback(err error) (Classification, error) {
+	current, _ := c.Current()
+	return current, err
+}
@@ -0,0 +1,104 @@
+package regime
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func testConfig() config.RegimeConfig {
+	return config.CreateDefaultConfig().Regime
+}
+
+// series builds n closes from a step function of the day index
+func series(n int, step func(i int) float64) []float64 {
+	closes := make([]float64, n)
+	closes[0] = 400
+	for i := 1; i < n; i++ {
+		closes[i] = closes[i-1] * (1 + step(i))
+	}
+	return closes
+}
+
+func TestClassify(t *testing.T) {
+	cfg := testConfig()
+
+	// Steady grind higher with small noise
+	trending, err := Classify(series(51, func(i int) float64 { return 0.003 + 0.002*math.Sin(float64(i)) }), cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, Trending, trending.Regime)
+	assert.Greater(t, trending.Trend, 10.0)
+
+	// Back and forth around the same level
+	ranging, err := Classify(series(51, func(i int) float64 { return 0.006 * math.Sin(float64(i)*1.3) }), cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, Ranging, ranging.Regime)
+	assert.Less(t, ranging.Efficiency, cfg.TrendEfficiency)
+
+	// Large daily swings dominate whatever the direction
+	volatile, err := Classify(series(51, func(i int) float64 { return 0.003 + 0.04*math.Sin(float64(i)*2.1) }), cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, HighVolatility, volatile.Regime)
+	assert.GreaterOrEqual(t, volatile.Volatility, cfg.HighVolatility)
+
+	_, err = Classify([]float64{400, 401}, cfg)
+	assert.Error(t, err)
+}
+
+// fakeHistory serves fixed daily closes and counts requests
+type fakeHistory struct {
+	closes   []float64
+	requests int
+	err      error
+}
+
+func (f *fakeHistory) GetDailyHistory(symbol string, days int) (*data.MarketData, error) {
+	f.requests++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &data.MarketData{Symbol: symbol, Prices: f.closes}, nil
+}
+
+// recordingStore records saved classifications
+type recordingStore struct {
+	saved []Classification
+}
+
+func (s *recordingStore) SaveRegime(c Classification) error {
+	s.saved = append(s.saved, c)
+	return nil
+}
+
+func TestClassifierRefreshesOncePerDay(t *testing.T) {
+	source := &fakeHistory{closes: series(51, func(i int) float64 { return 0.003 })}
+	store := &recordingStore{}
+	classifier := NewClassifier(source, testConfig())
+	classifier.SetStore(store)
+
+	_, ok := classifier.Current()
+	assert.False(t, ok)
+
+	morning := time.Date(2025, 4, 21, 14, 0, 0, 0, time.UTC)
+	first, err := classifier.Refresh(morning)
+	assert.NoError(t, err)
+	assert.Equal(t, Trending, first.Regime)
+	assert.Equal(t, "2025-04-21", first.Day.Format("2006-01-02"))
+
+	_, err = classifier.Refresh(morning.Add(3 * time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, source.requests)
+	assert.Len(t, store.saved, 1)
+
+	// A failed refresh on a new day keeps the previous regime
+	source.err = fmt.Errorf("unavailable")
+	previous, err := classifier.Refresh(morning.Add(24 * time.Hour))
+	assert.Error(t, err)
+	assert.Equal(t, Trending, previous.Regime)
+	assert.Equal(t, 2, source.requests)
+}
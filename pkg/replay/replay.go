@@ -0,0 +1,105 @@
+package replay
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// Message kinds
+const (
+	KindSignal  = "signal"
+	KindOutcome = "outcome"
+)
+
+// Record is a stored signal with its exit, if it closed
+type Record struct {
+	Signal    *signal.Signal
+	ExitPrice float64
+	ClosedAt  time.Time // Zero if the signal is open or the close was not recorded
+}
+
+// Source loads the stored signal history (implemented by store.Logger)
+type Source interface {
+	SignalHistory(from, to time.Time) ([]Record, error)
+}
+
+// Sender posts messages to a Telegram channel (implemented by telegram.Bot)
+type Sender interface {
+	SendChannelMessage(channelID, message string) error
+}
+
+// Message is one message of the channel's history
+type Message struct {
+	At       time.Time `json:"at"`
+	SignalID string    `json:"signal_id"`
+	Kind     string    `json:"kind"`
+	Text     string    `json:"text"`
+}
+
+// Messages rebuilds the channel's messages from the stored history: each
+// signal as it was posted, then its outcome once it closed, oldest first
+func Messages(records []Record) []Message {
+	messages := make([]Message, 0, 2*len(records))
+	for _, r := range records {
+		s := r.Signal
+		messages = append(messages, Message{
+			At:       s.GeneratedAt,
+			SignalID: s.ID,
+			Kind:     KindSignal,
+			Text:     signal.FormatSignalMessage(s),
+		})
+		if !s.Status.Closed() {
+			continue
+		}
+
+		// Signals closed before close times were stored keep the outcome next to them
+		closedAt := r.ClosedAt
+		if closedAt.IsZero() {
+			closedAt = s.GeneratedAt
+		}
+		messages = append(messages, Message{
+			At:       closedAt,
+			SignalID: s.ID,
+			Kind:     KindOutcome,
+			Text:     fmt.Sprintf("%s: %s %s\n%s", s.ID, s.Type, s.Symbol, signal.FormatSignalOutcome(s, r.ExitPrice)),
+		})
+	}
+
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].At.Before(messages[j].At)
+	})
+	return messages
+}
+
+// Export writes the messages as JSON lines, one message per line
+func Export(w io.Writer, messages []Message) error {
+	encoder := json.NewEncoder(w)
+	for _, m := range messages {
+		if err := encoder.Encode(m); err != nil {
+			return fmt.Errorf("failed to export message for signal %s: %w", m.SignalID, err)
+		}
+	}
+	return nil
+}
+
+// Send posts the messages to a channel in order, each marked with when it was
+// originally posted, waiting interval between messages to stay under the
+// Telegram rate limit. It stops at the first failure and returns how many
+// messages were sent, so a replay can be resumed.
+func Send(sender Sender, channelID string, messages []Message, interval time.Duration) (int, error) {
+	for i, m := range messages {
+		if i > 0 && interval > 0 {
+			time.Sleep(interval)
+		}
+		text := fmt.Sprintf("🕘 <i>Originally posted %s</i>\n\n%s", m.At.UTC().Format("2006-01-02 15:04 MST"), m.Text)
+		if err := sender.SendChannelMessage(channelID, text); err != nil {
+			return i, fmt.Errorf("failed to replay message %d of %d: %w", i+1, len(messages), err)
+		}
+	}
+	return len(messages), nil
+}
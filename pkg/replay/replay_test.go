@@ -0,0 +1,73 @@
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/signal"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSender records sent messages and fails after limit messages
+type fakeSender struct {
+	channels []string
+	sent     []string
+	limit    int
+}
+
+func (f *fakeSender) SendChannelMessage(channelID, message string) error {
+	if len(f.sent) == f.limit {
+		return errors.New("rate limited")
+	}
+	f.channels = append(f.channels, channelID)
+	f.sent = append(f.sent, message)
+	return nil
+}
+
+func testRecords() []Record {
+	start := time.Date(2026, 10, 16, 13, 30, 0, 0, time.UTC)
+	closed := &signal.Signal{ID: "a", Symbol: "AAPL", Type: signal.BUY, Price: 100, TargetPrice: 110, StopLoss: 95,
+		Status: signal.StatusSuccess, GeneratedAt: start}
+	open := &signal.Signal{ID: "b", Symbol: "MSFT", Type: signal.SELL, Price: 300, TargetPrice: 290, StopLoss: 305,
+		Status: signal.StatusActive, GeneratedAt: start.Add(time.Hour)}
+	return []Record{
+		{Signal: closed, ExitPrice: 110, ClosedAt: start.Add(2 * time.Hour)},
+		{Signal: open},
+	}
+}
+
+func TestMessagesInterleavesOutcomes(t *testing.T) {
+	messages := Messages(testRecords())
+
+	assert.Len(t, messages, 3)
+	assert.Equal(t, []string{"a", "b", "a"}, []string{messages[0].SignalID, messages[1].SignalID, messages[2].SignalID})
+	assert.Equal(t, KindSignal, messages[0].Kind)
+	assert.Equal(t, KindOutcome, messages[2].Kind)
+	assert.Contains(t, messages[2].Text, "TARGET HIT")
+}
+
+func TestExportWritesJSONLines(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, Export(&buf, Messages(testRecords())))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 3)
+	var first Message
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	assert.Equal(t, "a", first.SignalID)
+	assert.Equal(t, time.Date(2026, 10, 16, 13, 30, 0, 0, time.UTC), first.At.UTC())
+}
+
+func TestSendStopsAtFirstFailure(t *testing.T) {
+	sender := &fakeSender{limit: 2}
+	sent, err := Send(sender, "@archive", Messages(testRecords()), 0)
+
+	assert.Error(t, err)
+	assert.Equal(t, 2, sent)
+	assert.Equal(t, []string{"@archive", "@archive"}, sender.channels)
+	assert.Contains(t, sender.sent[0], "Originally posted 2026-10-16 13:30 UTC")
+}
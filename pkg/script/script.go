@@ -0,0 +1,425 @@
+package script
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Program is a compiled arithmetic expression over named variables, e.g.
+// "0.5*score_bollinger + 0.5*min(volume_ratio/300, 1)". It supports numbers,
+// variables, + - * /, comparisons (< <= > >= == !=), && || and ! on truth
+// values (non-zero is true, results are 1 or 0), parentheses and the
+// functions min, max, abs, clamp(x, lo, hi) and if(cond, then, else).
+type Program struct {
+	source string
+	root   node
+}
+
+// node evaluates one part of a program
+type node func(vars map[string]float64) (float64, error)
+
+// Compile parses an expression into a program
+func Compile(source string) (*Program, error) {
+	p := &parser{tokens: tokenize(source)}
+	root, err := p.parseOr()
+	if err == nil && p.pos < len(p.tokens) {
+		err = fmt.Errorf("unexpected %q", p.tokens[p.pos].text)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile %q: %w", source, err)
+	}
+	return &Program{source: source, root: root}, nil
+}
+
+// Eval runs the program with the given variables. Referencing a variable
+// that is not set is an error.
+func (p *Program) Eval(vars map[string]float64) (float64, error) {
+	value, err := p.root(vars)
+	if err != nil {
+		return 0, fmt.Errorf("failed to evaluate %q: %w", p.source, err)
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, fmt.Errorf("failed to evaluate %q: result is %v", p.source, value)
+	}
+	return value, nil
+}
+
+// String returns the source of the program
+func (p *Program) String() string {
+	return p.source
+}
+
+// Token kinds
+const (
+	tokenNumber = iota
+	tokenIdent
+	tokenOp
+	tokenInvalid
+)
+
+type token struct {
+	kind  int
+	text  string
+	value float64
+}
+
+// operators are the operator tokens, longest first
+var operators = []string{"&&", "||", "<=", ">=", "==", "!=", "+", "-", "*", "/", "<", ">", "!", "(", ")", ","}
+
+// tokenize splits an expression into tokens. Characters that start no token
+// become invalid tokens, reported by the parser.
+func tokenize(source string) []token {
+	var tokens []token
+	rest := source
+	for {
+		rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+		if rest == "" {
+			return tokens
+		}
+
+		c := rune(rest[0])
+		switch {
+		case unicode.IsDigit(c) || c == '.':
+			end := strings.IndexFunc(rest, func(r rune) bool { return !unicode.IsDigit(r) && r != '.' })
+			if end < 0 {
+				end = len(rest)
+			}
+			value, err := strconv.ParseFloat(rest[:end], 64)
+			if err != nil {
+				tokens = append(tokens, token{kind: tokenInvalid, text: rest[:end]})
+			} else {
+				tokens = append(tokens, token{kind: tokenNumber, text: rest[:end], value: value})
+			}
+			rest = rest[end:]
+			continue
+		case unicode.IsLetter(c) || c == '_':
+			end := strings.IndexFunc(rest, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' })
+			if end < 0 {
+				end = len(rest)
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: rest[:end]})
+			rest = rest[end:]
+			continue
+		}
+
+		matched := false
+		for _, op := range operators {
+			if strings.HasPrefix(rest, op) {
+				tokens = append(tokens, token{kind: tokenOp, text: op})
+				rest = rest[len(op):]
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			tokens = append(tokens, token{kind: tokenInvalid, text: rest[:1]})
+			rest = rest[1:]
+		}
+	}
+}
+
+// parser is a recursive descent parser over the tokens of an expression
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// accept consumes the next token if it is one of the given operators
+func (p *parser) accept(ops ...string) (string, bool) {
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != tokenOp {
+		return "", false
+	}
+	for _, op := range ops {
+		if p.tokens[p.pos].text == op {
+			p.pos++
+			return op, true
+		}
+	}
+	return "", false
+}
+
+// expect consumes the given operator or fails
+func (p *parser) expect(op string) error {
+	if _, ok := p.accept(op); ok {
+		return nil
+	}
+	if p.pos >= len(p.tokens) {
+		return fmt.Errorf("expected %q at end of expression", op)
+	}
+	return fmt.Errorf("expected %q, got %q", op, p.tokens[p.pos].text)
+}
+
+// truth converts a truth value to 1 or 0
+func truth(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.accept("||"); !ok {
+			return left, nil
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(vars map[string]float64) (float64, error) {
+			a, err := l(vars)
+			if err != nil || a != 0 {
+				return truth(a != 0), err
+			}
+			b, err := right(vars)
+			return truth(b != 0), err
+		}
+	}
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseComparison()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.accept("&&"); !ok {
+			return left, nil
+		}
+		right, err := p.parseComparison()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(vars map[string]float64) (float64, error) {
+			a, err := l(vars)
+			if err != nil || a == 0 {
+				return 0, err
+			}
+			b, err := right(vars)
+			return truth(b != 0), err
+		}
+	}
+}
+
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	op, ok := p.accept("<=", ">=", "==", "!=", "<", ">")
+	if !ok {
+		return left, nil
+	}
+	right, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	compare := map[string]func(a, b float64) bool{
+		"<":  func(a, b float64) bool { return a < b },
+		"<=": func(a, b float64) bool { return a <= b },
+		">":  func(a, b float64) bool { return a > b },
+		">=": func(a, b float64) bool { return a >= b },
+		"==": func(a, b float64) bool { return a == b },
+		"!=": func(a, b float64) bool { return a != b },
+	}[op]
+	return binary(left, right, func(a, b float64) (float64, error) { return truth(compare(a, b)), nil }), nil
+}
+
+func (p *parser) parseSum() (node, error) {
+	left, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.accept("+", "-")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		if op == "+" {
+			left = binary(left, right, func(a, b float64) (float64, error) { return a + b, nil })
+		} else {
+			left = binary(left, right, func(a, b float64) (float64, error) { return a - b, nil })
+		}
+	}
+}
+
+func (p *parser) parseProduct() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.accept("*", "/")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if op == "*" {
+			left = binary(left, right, func(a, b float64) (float64, error) { return a * b, nil })
+		} else {
+			left = binary(left, right, func(a, b float64) (float64, error) {
+				if b == 0 {
+					return 0, fmt.Errorf("division by zero")
+				}
+				return a / b, nil
+			})
+		}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	op, ok := p.accept("-", "!")
+	if !ok {
+		return p.parsePrimary()
+	}
+	operand, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	if op == "-" {
+		return func(vars map[string]float64) (float64, error) {
+			value, err := operand(vars)
+			return -value, err
+		}, nil
+	}
+	return func(vars map[string]float64) (float64, error) {
+		value, err := operand(vars)
+		return truth(value == 0), err
+	}, nil
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	if _, ok := p.accept("("); ok {
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return inner, p.expect(")")
+	}
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+
+	tok := p.tokens[p.pos]
+	p.pos++
+	switch tok.kind {
+	case tokenNumber:
+		value := tok.value
+		return func(map[string]float64) (float64, error) { return value, nil }, nil
+	case tokenIdent:
+		if _, ok := p.accept("("); ok {
+			return p.parseCall(tok.text)
+		}
+		name := tok.text
+		return func(vars map[string]float64) (float64, error) {
+			value, ok := vars[name]
+			if !ok {
+				return 0, fmt.Errorf("unknown variable %q", name)
+			}
+			return value, nil
+		}, nil
+	}
+	return nil, fmt.Errorf("unexpected %q", tok.text)
+}
+
+// parseCall parses the arguments of a function call after its opening parenthesis
+func (p *parser) parseCall(name string) (node, error) {
+	var args []node
+	if _, ok := p.accept(")"); !ok {
+		for {
+			arg, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if _, ok := p.accept(","); !ok {
+				break
+			}
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+	}
+
+	arity := map[string][2]int{"min": {1, -1}, "max": {1, -1}, "abs": {1, 1}, "clamp": {3, 3}, "if": {3, 3}}
+	limits, ok := arity[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %q", name)
+	}
+	if len(args) < limits[0] || (limits[1] >= 0 && len(args) > limits[1]) {
+		return nil, fmt.Errorf("wrong number of arguments to %s: %d", name, len(args))
+	}
+
+	if name == "if" {
+		return func(vars map[string]float64) (float64, error) {
+			cond, err := args[0](vars)
+			if err != nil {
+				return 0, err
+			}
+			if cond != 0 {
+				return args[1](vars)
+			}
+			return args[2](vars)
+		}, nil
+	}
+
+	return func(vars map[string]float64) (float64, error) {
+		values := make([]float64, len(args))
+		for i, arg := range args {
+			value, err := arg(vars)
+			if err != nil {
+				return 0, err
+			}
+			values[i] = value
+		}
+		switch name {
+		case "min":
+			result := values[0]
+			for _, v := range values[1:] {
+				result = math.Min(result, v)
+			}
+			return result, nil
+		case "max":
+			result := values[0]
+			for _, v := range values[1:] {
+				result = math.Max(result, v)
+			}
+			return result, nil
+		case "abs":
+			return math.Abs(values[0]), nil
+		default: // clamp
+			return math.Max(values[1], math.Min(values[0], values[2])), nil
+		}
+	}, nil
+}
+
+// binary combines the values of two nodes
+func binary(left, right node, apply func(a, b float64) (float64, error)) node {
+	return func(vars map[string]float64) (float64, error) {
+		a, err := left(vars)
+		if err != nil {
+			return 0, err
+		}
+		b, err := right(vars)
+		if err != nil {
+			return 0, err
+		}
+		return apply(a, b)
+	}
+}
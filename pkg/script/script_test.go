@@ -0,0 +1,59 @@
+package script
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEval(t *testing.T) {
+	vars := map[string]float64{"rsi": 80, "volume_ratio": 300, "price_change": -2.5}
+	cases := map[string]float64{
+		"1 + 2 * 3":                      7,
+		"(1 + 2) * 3":                    9,
+		"-rsi / 10":                      -8,
+		"rsi > 70":                       1,
+		"rsi > 70 && volume_ratio < 200": 0,
+		"rsi > 70 || volume_ratio < 200": 1,
+		"!(rsi > 70)":                    0,
+		"abs(price_change)":              2.5,
+		"min(volume_ratio / 300, 1, 2)":  1,
+		"max(0.2, 0.1)":                  0.2,
+		"clamp(rsi / 50, 0, 1)":          1,
+		"if(rsi >= 80, 0.9, 0.1)":        0.9,
+		"0.5*(rsi>70) + 0.5*min(volume_ratio/600, 1)": 0.75,
+	}
+	for source, want := range cases {
+		program, err := Compile(source)
+		if !assert.NoError(t, err, source) {
+			continue
+		}
+		got, err := program.Eval(vars)
+		assert.NoError(t, err, source)
+		assert.InDelta(t, want, got, 1e-9, source)
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	for _, source := range []string{"", "1 +", "(1", "rsi $ 2", "foo(1)", "abs(1, 2)", "1 2", "1..2"} {
+		_, err := Compile(source)
+		assert.Error(t, err, source)
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	program, err := Compile("adx / rsi")
+	assert.NoError(t, err)
+
+	_, err = program.Eval(map[string]float64{"rsi": 50})
+	assert.ErrorContains(t, err, `unknown variable "adx"`)
+	_, err = program.Eval(map[string]float64{"adx": 30, "rsi": 0})
+	assert.ErrorContains(t, err, "division by zero")
+
+	// Short-circuiting skips what the result does not depend on
+	program, err = Compile("rsi < 30 && adx > 20")
+	assert.NoError(t, err)
+	value, err := program.Eval(map[string]float64{"rsi": 50})
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, value)
+}
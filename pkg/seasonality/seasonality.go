@@ -0,0 +1,201 @@
+package seasonality
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/market"
+)
+
+// HourStats is how a symbol typically behaves in one exchange-local hour
+type HourStats struct {
+	Hour         int     `json:"hour"`          // Exchange-local hour, e.g. 9 for 09:30-10:00
+	AvgRange     float64 `json:"avg_range"`     // Mean high-low range of closes within the hour, percent
+	AvgReturn    float64 `json:"avg_return"`    // Mean change over the hour, percent
+	ReversalRate float64 `json:"reversal_rate"` // Share of sessions whose high or low was set in the hour, 0-1
+	Sessions     int     `json:"sessions"`      // Sessions with bars in the hour
+}
+
+// Profile is the intraday seasonality of a symbol
+type Profile struct {
+	Symbol    string      `json:"symbol"`
+	Hours     []HourStats `json:"hours"` // Ordered by hour
+	Sessions  int         `json:"sessions"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// Compute builds the seasonality profile of intraday history, oldest first.
+// Only bars within the regular session are used. A session's high or low
+// counts as a reversal unless it was set on the session's first or last bar,
+// where the day simply opened or closed at its extreme.
+func Compute(history *data.MarketData, session *market.Clock) Profile {
+	profile := Profile{Symbol: history.Symbol}
+
+	type bar struct {
+		hour  int
+		price float64
+	}
+	var days []string
+	sessions := make(map[string][]bar)
+	for i, ts := range history.Timestamps {
+		if i >= len(history.Prices) || history.Prices[i] <= 0 || !session.IsOpen(ts) {
+			continue
+		}
+		key := session.DayKey(ts)
+		if _, ok := sessions[key]; !ok {
+			days = append(days, key)
+		}
+		sessions[key] = append(sessions[key], bar{hour: ts.In(session.Location()).Hour(), price: history.Prices[i]})
+	}
+
+	type totals struct {
+		ranges, returns float64
+		reversals       int
+		sessions        int
+	}
+	byHour := make(map[int]*totals)
+	hourTotals := func(hour int) *totals {
+		t, ok := byHour[hour]
+		if !ok {
+			t = &totals{}
+			byHour[hour] = t
+		}
+		return t
+	}
+
+	for _, day := range days {
+		bars := sessions[day]
+		if len(bars) < 2 {
+			continue
+		}
+		profile.Sessions++
+
+		// Range and return of each hour, measured from the close before it
+		start := 0
+		for start < len(bars) {
+			end := start
+			for end < len(bars) && bars[end].hour == bars[start].hour {
+				end++
+			}
+			reference := bars[start].price
+			if start > 0 {
+				reference = bars[start-1].price
+			}
+			high, low := reference, reference
+			for _, b := range bars[start:end] {
+				high = math.Max(high, b.price)
+				low = math.Min(low, b.price)
+			}
+			t := hourTotals(bars[start].hour)
+			t.sessions++
+			t.ranges += (high - low) / reference * 100
+			t.returns += (bars[end-1].price - reference) / reference * 100
+			start = end
+		}
+
+		highAt, lowAt := 0, 0
+		for i, b := range bars {
+			if b.price > bars[highAt].price {
+				highAt = i
+			}
+			if b.price < bars[lowAt].price {
+				lowAt = i
+			}
+		}
+		for _, at := range []int{highAt, lowAt} {
+			if at > 0 && at < len(bars)-1 {
+				hourTotals(bars[at].hour).reversals++
+			}
+		}
+	}
+
+	for hour, t := range byHour {
+		profile.Hours = append(profile.Hours, HourStats{
+			Hour:         hour,
+			AvgRange:     t.ranges / float64(t.sessions),
+			AvgReturn:    t.returns / float64(t.sessions),
+			ReversalRate: float64(t.reversals) / float64(t.sessions),
+			Sessions:     t.sessions,
+		})
+	}
+	sort.Slice(profile.Hours, func(i, j int) bool { return profile.Hours[i].Hour < profile.Hours[j].Hour })
+	return profile
+}
+
+// At returns the statistics of an exchange-local hour
+func (p Profile) At(hour int) (HourStats, bool) {
+	for _, stats := range p.Hours {
+		if stats.Hour == hour {
+			return stats, true
+		}
+	}
+	return HourStats{}, false
+}
+
+// MeanRange returns the mean hourly range across the session, percent
+func (p Profile) MeanRange() float64 {
+	if len(p.Hours) == 0 {
+		return 0
+	}
+	var total float64
+	for _, stats := range p.Hours {
+		total += stats.AvgRange
+	}
+	return total / float64(len(p.Hours))
+}
+
+// NextReversal returns the remaining hour of the session, from hour on, in
+// which the symbol most often reverses, and whether there is one
+func (p Profile) NextReversal(hour int) (HourStats, bool) {
+	var best HourStats
+	found := false
+	for _, stats := range p.Hours {
+		if stats.Hour < hour || stats.ReversalRate <= 0 {
+			continue
+		}
+		if !found || stats.ReversalRate > best.ReversalRate {
+			best, found = stats, true
+		}
+	}
+	return best, found
+}
+
+// TimeFrame returns a holding window for a signal at now: up to the typical
+// reversal hour, or the session close when no reversal is expected
+func (p Profile) TimeFrame(now time.Time, session *market.Clock) string {
+	local := now.In(session.Location())
+	remaining := session.SessionClose(now).Sub(now).Hours()
+
+	hours := remaining
+	if reversal, ok := p.NextReversal(local.Hour()); ok {
+		hours = math.Min(float64(reversal.Hour-local.Hour()+1), remaining)
+	}
+	if hours <= 1 {
+		return "up to 1 hour"
+	}
+	return fmt.Sprintf("1-%d hours", int(math.Ceil(hours)))
+}
+
+// Summary describes the statistics of the rest of the session from now for
+// signal rationales
+func (p Profile) Summary(now time.Time, session *market.Clock) string {
+	hour := now.In(session.Location()).Hour()
+
+	var lines []string
+	lines = append(lines, fmt.Sprintf("Based on %d sessions; mean hourly range %.2f%%", p.Sessions, p.MeanRange()))
+	for _, stats := range p.Hours {
+		if stats.Hour < hour {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("- %02d:00: range %.2f%%, avg move %+.2f%%, high/low set %.0f%% of sessions",
+			stats.Hour, stats.AvgRange, stats.AvgReturn, stats.ReversalRate*100))
+	}
+	if reversal, ok := p.NextReversal(hour); ok {
+		lines = append(lines, fmt.Sprintf("Most likely reversal: %02d:00 hour", reversal.Hour))
+	}
+	return strings.Join(lines, "\n")
+}
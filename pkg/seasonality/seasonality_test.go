@@ -0,0 +1,110 @@
+package seasonality
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/market"
+	"github.com/stretchr/testify/assert"
+)
+
+// sessionBars builds 15-minute closes for each session in days: the price
+// rallies into 11:00, drops until 14:00 and then recovers slightly
+func sessionBars(t *testing.T, days []string) *data.MarketData {
+	session := market.DefaultClock()
+	history := &data.MarketData{Symbol: "AAPL"}
+	for _, day := range days {
+		open, err := time.ParseInLocation("2006-01-02 15:04", day+" 09:30", session.Location())
+		assert.NoError(t, err)
+		price := 100.0
+		for ts := open; ts.Before(session.SessionClose(open)); ts = ts.Add(15 * time.Minute) {
+			switch hour := ts.Hour(); {
+			case hour < 11:
+				price += 0.5
+			case hour < 14:
+				price -= 0.25
+			default:
+				price += 0.1
+			}
+			history.Prices = append(history.Prices, price)
+			history.Volumes = append(history.Volumes, 1000)
+			history.Timestamps = append(history.Timestamps, ts)
+		}
+		// A pre-market print is ignored
+		history.Prices = append(history.Prices, 50)
+		history.Volumes = append(history.Volumes, 10)
+		history.Timestamps = append(history.Timestamps, open.Add(-time.Hour))
+	}
+	return history
+}
+
+func TestComputeFindsReversalHours(t *testing.T) {
+	session := market.DefaultClock()
+	profile := Compute(sessionBars(t, []string{"2025-04-14", "2025-04-15", "2025-04-16"}), session)
+
+	assert.Equal(t, 3, profile.Sessions)
+	assert.Len(t, profile.Hours, 7)
+	assert.Equal(t, 9, profile.Hours[0].Hour)
+
+	// The high is set in the last bar of the 10:00 hour and the low in the 13:00 hour
+	rally, _ := profile.At(10)
+	assert.Equal(t, 1.0, rally.ReversalRate)
+	assert.Greater(t, rally.AvgReturn, 0.0)
+	selloff, _ := profile.At(13)
+	assert.Equal(t, 1.0, selloff.ReversalRate)
+	assert.Less(t, selloff.AvgReturn, 0.0)
+	quiet, _ := profile.At(15)
+	assert.Equal(t, 0.0, quiet.ReversalRate)
+	assert.Less(t, quiet.AvgRange, rally.AvgRange)
+
+	reversal, ok := profile.NextReversal(11)
+	assert.True(t, ok)
+	assert.Equal(t, 13, reversal.Hour)
+	_, ok = profile.NextReversal(14)
+	assert.False(t, ok)
+
+	at := func(clock string) time.Time {
+		ts, _ := time.ParseInLocation("2006-01-02 15:04", "2025-04-17 "+clock, session.Location())
+		return ts
+	}
+	assert.Equal(t, "1-3 hours", profile.TimeFrame(at("11:15"), session))
+	assert.Equal(t, "up to 1 hour", profile.TimeFrame(at("15:30"), session))
+	assert.Contains(t, profile.Summary(at("11:15"), session), "Most likely reversal: 13:00 hour")
+}
+
+type fakeIntraday struct {
+	history *data.MarketData
+	fetches int
+}
+
+func (f *fakeIntraday) GetIntradayHistory(symbol string, days int) (*data.MarketData, error) {
+	f.fetches++
+	if f.history == nil {
+		return nil, fmt.Errorf("no history for %s", symbol)
+	}
+	return f.history, nil
+}
+
+func TestTrackerRefreshesDailyAndExposesFactors(t *testing.T) {
+	source := &fakeIntraday{history: sessionBars(t, []string{"2025-04-14", "2025-04-15"})}
+	tracker := NewTracker(source, config.SeasonalityConfig{LookbackDays: 30, MinDays: 2})
+	now, _ := time.ParseInLocation("2006-01-02 15:04", "2025-04-17 11:15", tracker.Session().Location())
+	tracker.now = func() time.Time { return now }
+
+	assert.NoError(t, tracker.Refresh(now, []string{"AAPL"}))
+	assert.NoError(t, tracker.Refresh(now.Add(time.Hour), []string{"AAPL"}))
+	assert.Equal(t, 1, source.fetches)
+
+	factors := tracker.Factors("AAPL")
+	assert.Equal(t, 2.0, factors["seasonal_hours_to_reversal"])
+	assert.Contains(t, factors, "seasonal_range_ratio")
+	assert.Nil(t, tracker.Factors("MSFT"))
+
+	// Profiles below the minimum number of sessions are not used
+	tracker.config.MinDays = 5
+	_, ok := tracker.Profile("AAPL")
+	assert.False(t, ok)
+}
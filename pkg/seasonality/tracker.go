@@ -0,0 +1,149 @@
+package seasonality
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/market"
+)
+
+// IntradayHistorySource provides intraday closes (implemented by data.Provider)
+type IntradayHistorySource interface {
+	GetIntradayHistory(symbol string, days int) (*data.MarketData, error)
+}
+
+// Store persists computed profiles (implemented by store.Logger)
+type Store interface {
+	SaveSeasonality(p Profile) error
+}
+
+// Tracker recomputes the seasonality profiles of watched symbols once per
+// trading day and exposes them as strategy factors
+type Tracker struct {
+	source   IntradayHistorySource
+	config   config.SeasonalityConfig
+	store    Store
+	session  *market.Clock
+	profiles map[string]Profile
+	day      time.Time // Trading day profiles were last computed
+	now      func() time.Time
+	mu       sync.RWMutex
+}
+
+// NewTracker creates a new Tracker
+func NewTracker(source IntradayHistorySource, cfg config.SeasonalityConfig) *Tracker {
+	return &Tracker{
+		source:   source,
+		config:   cfg,
+		session:  market.DefaultClock(),
+		profiles: make(map[string]Profile),
+		now:      time.Now,
+	}
+}
+
+// SetStore sets where computed profiles are stored
+func (t *Tracker) SetStore(store Store) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.store = store
+}
+
+// Session returns the exchange session profiles are computed in
+func (t *Tracker) Session() *market.Clock {
+	return t.session
+}
+
+// Refresh recomputes the profiles of symbols for the trading day of now,
+// unless they were already computed that day. Symbols whose history can't
+// be fetched keep their previous profile.
+func (t *Tracker) Refresh(now time.Time, symbols []string) error {
+	day := t.session.TradingDay(now)
+
+	t.mu.RLock()
+	fresh := t.day.Equal(day)
+	store := t.store
+	t.mu.RUnlock()
+	if fresh {
+		return nil
+	}
+
+	var failed int
+	profiles := make(map[string]Profile, len(symbols))
+	for _, symbol := range symbols {
+		history, err := t.source.GetIntradayHistory(symbol, t.config.LookbackDays)
+		if err != nil {
+			log.Printf("Error fetching intraday history for %s: %v", symbol, err)
+			failed++
+			continue
+		}
+
+		profile := Compute(history, t.session)
+		profile.UpdatedAt = now
+		profiles[symbol] = profile
+
+		if store != nil {
+			if err := store.SaveSeasonality(profile); err != nil {
+				log.Printf("Error saving seasonality for %s: %v", symbol, err)
+			}
+		}
+	}
+
+	t.mu.Lock()
+	for symbol, profile := range profiles {
+		t.profiles[symbol] = profile
+	}
+	t.day = day
+	t.mu.Unlock()
+
+	if failed > 0 && failed == len(symbols) {
+		return fmt.Errorf("failed to fetch intraday history for all %d symbols", failed)
+	}
+	return nil
+}
+
+// Profile returns the profile of symbol once it covers the configured minimum
+// number of sessions
+func (t *Tracker) Profile(symbol string) (Profile, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	profile, ok := t.profiles[symbol]
+	if !ok || profile.Sessions < t.config.MinDays {
+		return Profile{}, false
+	}
+	return profile, true
+}
+
+// Factors describes the current hour of symbol for strategies:
+// seasonal_range is its typical range (percent), seasonal_range_ratio that
+// range against the session's mean hourly range, seasonal_reversal_rate how
+// often the session's high or low is set in it, and
+// seasonal_hours_to_reversal the hours until the most likely reversal
+func (t *Tracker) Factors(symbol string) map[string]float64 {
+	profile, ok := t.Profile(symbol)
+	if !ok {
+		return nil
+	}
+
+	hour := t.now().In(t.session.Location()).Hour()
+	stats, ok := profile.At(hour)
+	if !ok {
+		return nil
+	}
+
+	factors := map[string]float64{
+		"seasonal_range":         stats.AvgRange,
+		"seasonal_reversal_rate": stats.ReversalRate,
+	}
+	if mean := profile.MeanRange(); mean > 0 {
+		factors["seasonal_range_ratio"] = stats.AvgRange / mean
+	}
+	if reversal, ok := profile.NextReversal(hour); ok {
+		factors["seasonal_hours_to_reversal"] = float64(reversal.Hour - hour)
+	}
+	return factors
+}
@@ -0,0 +1,43 @@
+package signal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Adjustable signal levels
+const (
+	// LevelTarget is the signal's target price
+	LevelTarget = "target"
+	// LevelStop is the signal's stop loss
+	LevelStop = "stop"
+)
+
+// AdjustLevel changes an active signal's target or stop loss. The new level must stay
+// on the correct side of the entry price; the expected ROI follows the target.
+func AdjustLevel(s *Signal, level string, value float64) error {
+	if s.Status != StatusActive {
+		return fmt.Errorf("signal %s is not active", s.ID)
+	}
+	if value <= 0 {
+		return fmt.Errorf("invalid price: %.2f", value)
+	}
+
+	switch strings.ToLower(level) {
+	case LevelTarget:
+		if (s.Type == BUY && value <= s.Price) || (s.Type == SELL && value >= s.Price) {
+			return fmt.Errorf("target $%.2f is on the wrong side of entry $%.2f for a %s signal", value, s.Price, s.Type)
+		}
+		s.TargetPrice = value
+		s.ExpectedROI = calculateExpectedROI(s.Price, value, s.Type)
+	case LevelStop:
+		if (s.Type == BUY && value >= s.Price) || (s.Type == SELL && value <= s.Price) {
+			return fmt.Errorf("stop $%.2f is on the wrong side of entry $%.2f for a %s signal", value, s.Price, s.Type)
+		}
+		s.StopLoss = value
+	default:
+		return fmt.Errorf("unknown level %q, expected %s or %s", level, LevelTarget, LevelStop)
+	}
+
+	return nil
+}
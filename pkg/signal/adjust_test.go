@@ -0,0 +1,28 @@
+package signal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdjustLevel(t *testing.T) {
+	s := &Signal{ID: "SIG-1", Type: BUY, Price: 100, TargetPrice: 105, StopLoss: 97, ExpectedROI: 5, Status: StatusActive}
+
+	assert.NoError(t, AdjustLevel(s, "target", 110))
+	assert.Equal(t, 110.0, s.TargetPrice)
+	assert.InDelta(t, 10.0, s.ExpectedROI, 0.0001)
+
+	assert.NoError(t, AdjustLevel(s, "STOP", 98))
+	assert.Equal(t, 98.0, s.StopLoss)
+
+	// Levels on the wrong side of entry are rejected and leave the signal unchanged
+	assert.Error(t, AdjustLevel(s, "target", 95))
+	assert.Error(t, AdjustLevel(s, "stop", 101))
+	assert.Error(t, AdjustLevel(s, "entry", 101))
+	assert.Equal(t, 110.0, s.TargetPrice)
+	assert.Equal(t, 98.0, s.StopLoss)
+
+	s.Status = StatusSuccess
+	assert.Error(t, AdjustLevel(s, "target", 120))
+}
@@ -0,0 +1,30 @@
+package signal
+
+// Clone returns a deep copy of the signal, sharing no maps or slices with it.
+// Signals held by the monitor keep changing as they are explained, adjusted
+// and resolved, so everything handed out to other goroutines is a clone.
+func (s *Signal) Clone() *Signal {
+	if s == nil {
+		return nil
+	}
+	clone := *s
+	if s.TechnicalData != nil {
+		clone.TechnicalData = make(map[string]float64, len(s.TechnicalData))
+		for name, value := range s.TechnicalData {
+			clone.TechnicalData[name] = value
+		}
+	}
+	clone.StatusChanges = cloneSlice(s.StatusChanges)
+	clone.Strategies = cloneSlice(s.Strategies)
+	clone.ScoreBreakdown = cloneSlice(s.ScoreBreakdown)
+	clone.Revisions = cloneSlice(s.Revisions)
+	return &clone
+}
+
+// cloneSlice copies a slice of values, keeping nil nil
+func cloneSlice[T any](items []T) []T {
+	if items == nil {
+		return nil
+	}
+	return append(make([]T, 0, len(items)), items...)
+}
@@ -0,0 +1,73 @@
+package signal
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignalCloneSharesNothing(t *testing.T) {
+	generated := time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC)
+	s := &Signal{
+		ID:             "SIG-AAPL-BUY-1",
+		TechnicalData:  map[string]float64{"rsi": 28},
+		StatusChanges:  []StatusChange{{Status: StatusActive, At: generated}},
+		Strategies:     []string{"volatility"},
+		ScoreBreakdown: []ScoreComponent{{Name: "rsi", Points: 0.2}},
+		Revisions:      []Revision{{At: generated, Price: 100}},
+	}
+
+	clone := s.Clone()
+	assert.Equal(t, s, clone)
+
+	clone.TechnicalData["rsi"] = 70
+	clone.StatusChanges[0].Status = StatusFailure
+	clone.Strategies[0] = "momentum"
+	clone.ScoreBreakdown[0].Points = 0
+	clone.Revisions[0].Price = 90
+	assert.Equal(t, 28.0, s.TechnicalData["rsi"])
+	assert.Equal(t, StatusActive, s.StatusChanges[0].Status)
+	assert.Equal(t, "volatility", s.Strategies[0])
+	assert.Equal(t, 0.2, s.ScoreBreakdown[0].Points)
+	assert.Equal(t, 100.0, s.Revisions[0].Price)
+
+	assert.Nil(t, (&Signal{}).Clone().TechnicalData)
+	assert.Nil(t, (*Signal)(nil).Clone())
+}
+
+// Run with -race: clones taken under a lock can be read and changed freely
+// while the original keeps changing
+func TestSignalCloneUnderConcurrentUpdates(t *testing.T) {
+	s := &Signal{ID: "SIG-AAPL-BUY-1", TechnicalData: map[string]float64{}}
+	var mu sync.Mutex
+	start := time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			mu.Lock()
+			s.TechnicalData["tick"] = float64(i)
+			s.StatusChanges = append(s.StatusChanges, StatusChange{Status: StatusActive, At: start})
+			mu.Unlock()
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			mu.Lock()
+			clone := s.Clone()
+			mu.Unlock()
+			clone.TechnicalData["mine"] = 1
+			clone.StatusChanges = append(clone.StatusChanges, StatusChange{Status: StatusExpired})
+		}
+	}()
+	wg.Wait()
+
+	assert.Len(t, s.StatusChanges, 200)
+	_, ok := s.TechnicalData["mine"]
+	assert.False(t, ok)
+}
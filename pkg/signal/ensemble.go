@@ -0,0 +1,275 @@
+package signal
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/hustler/trading-bot/pkg/config"
+)
+
+// Vote is one strategy's view on a symbol. HOLD abstains.
+type Vote struct {
+	Strategy   string     `json:"strategy"`
+	Type       SignalType `json:"type"`
+	Confidence float64    `json:"confidence"` // 0-1
+	Setup      string     `json:"setup,omitempty"`
+}
+
+// Strategy votes on a symbol from its technical indicators
+type Strategy interface {
+	Name() string
+	Vote(technicalData map[string]float64, params config.VolatilityConfig) Vote
+}
+
+// Decision is the combined outcome of a round of votes
+type Decision struct {
+	Type       SignalType
+	Confidence float64
+	Strategies []string // Strategies that voted for Type, strongest first
+	Votes      []Vote
+}
+
+// NewStrategy returns the built-in strategy with the given name
+func NewStrategy(name string) (Strategy, error) {
+	switch name {
+	case "volatility":
+		return volatilityStrategy{}, nil
+	case "momentum":
+		return momentumStrategy{}, nil
+	case "mean_reversion":
+		return meanReversionStrategy{}, nil
+	default:
+		return nil, fmt.Errorf("unknown strategy %q", name)
+	}
+}
+
+// Ensemble combines the votes of several strategies into a single signal and
+// learns each strategy's weight from the outcomes of the signals it voted for
+type Ensemble struct {
+	strategies []Strategy
+	config     config.EnsembleConfig
+	outcomes   map[string][]bool // Wins and losses per strategy, oldest first, at most Window long
+	mu         sync.RWMutex
+}
+
+// NewEnsemble creates an ensemble of the configured strategies
+func NewEnsemble(cfg config.EnsembleConfig) (*Ensemble, error) {
+	e := &Ensemble{
+		config:   cfg,
+		outcomes: make(map[string][]bool),
+	}
+	for _, name := range cfg.Strategies {
+		strategy, err := NewStrategy(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create ensemble: %w", err)
+		}
+		e.strategies = append(e.strategies, strategy)
+	}
+	if len(e.strategies) == 0 {
+		return nil, fmt.Errorf("failed to create ensemble: no strategies configured")
+	}
+	return e, nil
+}
+
+// Resolve records the outcome of a closed signal for every strategy that voted
+// for it. It matches the monitor.MarketMonitor OnSignalClosed callback.
+func (e *Ensemble) Resolve(s *Signal, exitPrice float64) {
+	if len(s.Strategies) == 0 || s.Price <= 0 {
+		return
+	}
+	won := exitPrice > s.Price
+	if s.Type == SELL {
+		won = exitPrice < s.Price
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, name := range s.Strategies {
+		outcomes := append(e.outcomes[name], won)
+		if len(outcomes) > e.config.Window {
+			outcomes = outcomes[len(outcomes)-e.config.Window:]
+		}
+		e.outcomes[name] = outcomes
+	}
+}
+
+// Weights returns the current weight of each strategy: twice its win rate over
+// the rolling window, smoothed with one win and one loss so an unproven
+// strategy starts at 1 and a losing one decays towards the configured minimum
+func (e *Ensemble) Weights() map[string]float64 {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	weights := make(map[string]float64, len(e.strategies))
+	for _, strategy := range e.strategies {
+		outcomes := e.outcomes[strategy.Name()]
+		wins := 0
+		for _, won := range outcomes {
+			if won {
+				wins++
+			}
+		}
+		weight := 2 * float64(wins+1) / float64(len(outcomes)+2)
+		weights[strategy.Name()] = math.Max(weight, e.config.MinWeight)
+	}
+	return weights
+}
+
+// Decide collects a vote from every strategy and combines them. The winning
+// direction has the largest weighted confidence; ensemble confidence is that
+// weighted confidence over the weight of every strategy that voted, so
+// dissenting votes pull it down. Without enough agreement the decision is HOLD.
+func (e *Ensemble) Decide(technicalData map[string]float64, params config.VolatilityConfig) Decision {
+	return e.DecideWith(technicalData, params, nil)
+}
+
+// DecideWith is Decide restricted to the named strategies, e.g. those bound to
+// a watchlist. An empty list uses every strategy.
+func (e *Ensemble) DecideWith(technicalData map[string]float64, params config.VolatilityConfig, names []string) Decision {
+	weights := e.Weights()
+	only := make(map[string]bool, len(names))
+	for _, name := range names {
+		only[name] = true
+	}
+
+	decision := Decision{Type: HOLD}
+	var totalWeight, votingWeight float64
+	backing := make(map[SignalType]float64)
+	scores := make(map[SignalType]float64)
+	for _, strategy := range e.strategies {
+		if len(only) > 0 && !only[strategy.Name()] {
+			continue
+		}
+		vote := strategy.Vote(technicalData, params)
+		vote.Strategy = strategy.Name()
+		decision.Votes = append(decision.Votes, vote)
+
+		weight := weights[vote.Strategy]
+		totalWeight += weight
+		if vote.Type == HOLD {
+			continue
+		}
+		votingWeight += weight
+		backing[vote.Type] += weight
+		scores[vote.Type] += weight * vote.Confidence
+	}
+
+	if votingWeight == 0 || scores[BUY] == scores[SELL] {
+		return decision
+	}
+	winner := BUY
+	if scores[SELL] > scores[BUY] {
+		winner = SELL
+	}
+	if backing[winner]/totalWeight < e.config.MinAgreement {
+		return decision
+	}
+
+	decision.Type = winner
+	decision.Confidence = scores[winner] / votingWeight
+
+	agreeing := make([]Vote, 0, len(decision.Votes))
+	for _, vote := range decision.Votes {
+		if vote.Type == winner {
+			agreeing = append(agreeing, vote)
+		}
+	}
+	sort.SliceStable(agreeing, func(i, j int) bool {
+		return agreeing[i].Confidence*weights[agreeing[i].Strategy] > agreeing[j].Confidence*weights[agreeing[j].Strategy]
+	})
+	for _, vote := range agreeing {
+		decision.Strategies = append(decision.Strategies, vote.Strategy)
+	}
+	return decision
+}
+
+// volatilityStrategy is the original volatility breakout strategy: it votes
+// when the volatility score clears the confidence threshold and the setup
+// passes the trend regime filter
+type volatilityStrategy struct{}
+
+func (volatilityStrategy) Name() string { return "volatility" }
+
+func (volatilityStrategy) Vote(technicalData map[string]float64, params config.VolatilityConfig) Vote {
+	score := calculateVolatilityScore(technicalData, params)
+	if score < params.ConfidenceThreshold {
+		return Vote{Type: HOLD, Confidence: score}
+	}
+
+	signalType, setup := classifySetup(technicalData)
+	if signalType == HOLD || !passesRegimeFilter(setup, technicalData, params) {
+		return Vote{Type: HOLD, Confidence: score, Setup: setup}
+	}
+	return Vote{Type: signalType, Confidence: score, Setup: setup}
+}
+
+// momentumStrategy follows established moves: price moving with RSI on the
+// same side of 50 while ADX shows a trend
+type momentumStrategy struct{}
+
+func (momentumStrategy) Name() string { return "momentum" }
+
+func (momentumStrategy) Vote(technicalData map[string]float64, params config.VolatilityConfig) Vote {
+	priceChange := technicalData["price_change"]
+	rsi := technicalData["rsi"]
+	adx, hasADX := technicalData["adx"]
+
+	if hasADX && params.ADXRangeThreshold > 0 && adx < params.ADXRangeThreshold {
+		return Vote{Type: HOLD}
+	}
+
+	var signalType SignalType
+	switch {
+	case priceChange > 0 && rsi > 50 && rsi < params.RSIOverbought:
+		signalType = BUY
+	case priceChange < 0 && rsi < 50 && rsi > params.RSIOversold:
+		signalType = SELL
+	default:
+		return Vote{Type: HOLD}
+	}
+
+	// Half from the size of the move, half from trend strength
+	confidence := 0.25
+	if params.MinVolatilityPercent > 0 {
+		confidence = 0.5 * math.Min(math.Abs(priceChange)/(2*params.MinVolatilityPercent), 1)
+	}
+	if hasADX {
+		confidence += 0.5 * math.Min(adx/50, 1)
+	} else {
+		confidence += 0.25
+	}
+	return Vote{Type: signalType, Confidence: confidence, Setup: setupTrend}
+}
+
+// meanReversionStrategy fades stretched moves: RSI beyond its extremes with
+// price at the outer Bollinger Band
+type meanReversionStrategy struct{}
+
+func (meanReversionStrategy) Name() string { return "mean_reversion" }
+
+func (meanReversionStrategy) Vote(technicalData map[string]float64, params config.VolatilityConfig) Vote {
+	price := technicalData["price"]
+	rsi := technicalData["rsi"]
+
+	var signalType SignalType
+	var stretch float64
+	switch {
+	case rsi < params.RSIOversold && price < technicalData["lower_band"]*1.02:
+		signalType = BUY
+		stretch = (params.RSIOversold - rsi) / math.Max(params.RSIOversold, 1)
+	case rsi > params.RSIOverbought && price > technicalData["upper_band"]*0.98:
+		signalType = SELL
+		stretch = (rsi - params.RSIOverbought) / math.Max(100-params.RSIOverbought, 1)
+	default:
+		return Vote{Type: HOLD}
+	}
+
+	// Fading a strong trend is how mean reversion loses
+	if adx, ok := technicalData["adx"]; ok && params.ADXTrendThreshold > 0 && adx >= params.ADXTrendThreshold {
+		return Vote{Type: HOLD, Setup: setupMeanReversion}
+	}
+	return Vote{Type: signalType, Confidence: math.Min(0.5+stretch, 1), Setup: setupMeanReversion}
+}
@@ -0,0 +1,105 @@
+package signal
+
+import (
+	"testing"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestEnsemble(t *testing.T, minAgreement float64) *Ensemble {
+	ensemble, err := NewEnsemble(config.EnsembleConfig{
+		Enabled:      true,
+		Strategies:   []string{"volatility", "momentum", "mean_reversion"},
+		MinAgreement: minAgreement,
+		Window:       4,
+		MinWeight:    0.1,
+	})
+	assert.NoError(t, err)
+	return ensemble
+}
+
+func TestEnsembleCombinesAgreeingVotes(t *testing.T) {
+	params := config.CreateDefaultConfig().VolatilityParams
+	ensemble := newTestEnsemble(t, 0.5)
+
+	// A squeeze breakout in a strong uptrend: volatility and momentum both buy
+	technicalData := map[string]float64{
+		"price": 105, "upper_band": 104, "lower_band": 95, "rsi": 65, "price_change": 2.5,
+		"volume_ratio": 2, "adx": 35, "squeeze_breakout": 1,
+	}
+
+	decision := ensemble.Decide(technicalData, params)
+	assert.Equal(t, BUY, decision.Type)
+	assert.Len(t, decision.Votes, 3)
+	assert.ElementsMatch(t, []string{"volatility", "momentum"}, decision.Strategies)
+
+	volatility := decision.Votes[0].Confidence
+	assert.InDelta(t, (volatility+0.85)/2, decision.Confidence, 0.0001)
+}
+
+func TestEnsembleRequiresAgreement(t *testing.T) {
+	params := config.CreateDefaultConfig().VolatilityParams
+
+	// Only momentum votes, carrying a third of the weight
+	technicalData := map[string]float64{
+		"price": 100, "upper_band": 110, "lower_band": 90, "rsi": 60, "price_change": 0.5,
+		"volume_ratio": 1, "adx": 30,
+	}
+
+	decision := newTestEnsemble(t, 0.5).Decide(technicalData, params)
+	assert.Equal(t, HOLD, decision.Type)
+	assert.Empty(t, decision.Strategies)
+
+	decision = newTestEnsemble(t, 0.3).Decide(technicalData, params)
+	assert.Equal(t, BUY, decision.Type)
+	assert.Equal(t, []string{"momentum"}, decision.Strategies)
+	assert.InDelta(t, 0.425, decision.Confidence, 0.0001)
+}
+
+func TestEnsembleDecideWithBoundStrategies(t *testing.T) {
+	params := config.CreateDefaultConfig().VolatilityParams
+
+	// Only momentum votes; restricted to momentum it carries all the weight
+	technicalData := map[string]float64{
+		"price": 100, "upper_band": 110, "lower_band": 90, "rsi": 60, "price_change": 0.5,
+		"volume_ratio": 1, "adx": 30,
+	}
+
+	ensemble := newTestEnsemble(t, 0.5)
+	decision := ensemble.DecideWith(technicalData, params, []string{"momentum"})
+	assert.Equal(t, BUY, decision.Type)
+	assert.Len(t, decision.Votes, 1)
+
+	decision = ensemble.DecideWith(technicalData, params, []string{"volatility", "mean_reversion"})
+	assert.Equal(t, HOLD, decision.Type)
+	assert.Len(t, decision.Votes, 2)
+}
+
+func TestEnsembleLearnsWeightsFromOutcomes(t *testing.T) {
+	ensemble := newTestEnsemble(t, 0.5)
+	assert.Equal(t, map[string]float64{"volatility": 1, "momentum": 1, "mean_reversion": 1}, ensemble.Weights())
+
+	loser := &Signal{Type: BUY, Price: 100, Strategies: []string{"momentum"}}
+	for i := 0; i < 4; i++ {
+		ensemble.Resolve(loser, 98)
+	}
+	winner := &Signal{Type: SELL, Price: 100, Strategies: []string{"volatility", "mean_reversion"}}
+	ensemble.Resolve(winner, 97)
+
+	weights := ensemble.Weights()
+	assert.InDelta(t, 2.0/6, weights["momentum"], 0.0001)
+	assert.InDelta(t, 4.0/3, weights["volatility"], 0.0001)
+	assert.InDelta(t, 4.0/3, weights["mean_reversion"], 0.0001)
+
+	// Only the last Window outcomes count, and weights never fall below the minimum
+	for i := 0; i < 4; i++ {
+		ensemble.Resolve(&Signal{Type: BUY, Price: 100, Strategies: []string{"momentum"}}, 101)
+	}
+	assert.InDelta(t, 10.0/6, ensemble.Weights()["momentum"], 0.0001)
+}
+
+func TestNewEnsembleRejectsUnknownStrategy(t *testing.T) {
+	_, err := NewEnsemble(config.EnsembleConfig{Strategies: []string{"volatility", "astrology"}})
+	assert.Error(t, err)
+}
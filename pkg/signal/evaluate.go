@@ -0,0 +1,129 @@
+package signal
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/ids"
+	"github.com/hustler/trading-bot/pkg/indicators"
+)
+
+// minEvaluationBars is how many bars a symbol needs to be evaluated, as for signals
+const minEvaluationBars = 30
+
+// Evaluation is how the generator sees a symbol now, whether or not it would
+// signal it. Trade ideas are vetted against it.
+type Evaluation struct {
+	Symbol      string             `json:"symbol"`
+	Price       float64            `json:"price"`
+	Decision    SignalType         `json:"decision"` // What the strategies would do; HOLD if none takes a side
+	Confidence  float64            `json:"confidence"`
+	Strategies  []string           `json:"strategies,omitempty"`
+	Side        SignalType         `json:"side"` // Side the levels are for: the requested side, else the decision
+	TargetPrice float64            `json:"target_price,omitempty"`
+	StopLoss    float64            `json:"stop_loss,omitempty"`
+	ExpectedROI float64            `json:"expected_roi,omitempty"`
+	Qualifies   bool               `json:"qualifies"` // The generator would signal this side now
+	Indicators  map[string]float64 `json:"indicators"`
+	Regime      string             `json:"regime,omitempty"`
+	ParamSet    string             `json:"param_set"`
+}
+
+// Evaluate runs the indicators and strategies on a symbol's market data
+// without signaling it. side is BUY or SELL to price the levels of that
+// side, or empty to follow the strategies. Unlike a market check, it leaves
+// the rolling indicator state of the symbol untouched.
+func (g *Generator) Evaluate(symbol string, data MarketData, side SignalType) (Evaluation, error) {
+	if len(data.Prices) < minEvaluationBars || len(data.Volumes) < minEvaluationBars {
+		return Evaluation{}, fmt.Errorf("not enough market data for %s: %d bars, need %d", symbol, len(data.Prices), minEvaluationBars)
+	}
+	if side != "" && side != BUY && side != SELL {
+		return Evaluation{}, fmt.Errorf("invalid side %q", side)
+	}
+
+	price := data.Prices[len(data.Prices)-1]
+	technicalData := indicators.ComputeAll(g.indicators, data.Prices, data.Volumes)
+	technicalData["price"] = price
+	g.addFactors(symbol, technicalData)
+
+	regime, paramSet, params := g.volatilityParams()
+	if tuned, ok := g.paramsFor(symbol, params); ok {
+		paramSet, params = ParamSetTuned, tuned
+	}
+	decision := g.decide(symbol, technicalData, params, nil)
+
+	evaluation := Evaluation{
+		Symbol:     symbol,
+		Price:      price,
+		Decision:   decision.Type,
+		Confidence: decision.Confidence,
+		Strategies: decision.Strategies,
+		Side:       side,
+		Indicators: technicalData,
+		Regime:     regime,
+		ParamSet:   paramSet,
+	}
+	if evaluation.Side == "" {
+		evaluation.Side = decision.Type
+	}
+	if evaluation.Side == HOLD {
+		return evaluation, nil
+	}
+
+	target, stop := calculatePriceLevels(price, evaluation.Side, technicalData, params)
+	// Band levels can fall on the wrong side of the price for a side the
+	// strategies don't take; those fall back to the percentage levels
+	if (evaluation.Side == BUY && (target <= price || stop >= price)) || (evaluation.Side == SELL && (target >= price || stop <= price)) {
+		target, stop = percentLevels(price, evaluation.Side, params)
+	}
+	evaluation.TargetPrice, evaluation.StopLoss = target, stop
+	evaluation.ExpectedROI = calculateExpectedROI(price, evaluation.TargetPrice, evaluation.Side)
+	evaluation.Qualifies = decision.Type == evaluation.Side && evaluation.ExpectedROI >= params.MinExpectedROI
+	return evaluation, nil
+}
+
+// Signal turns the evaluation into a signal on its side, with the given ID
+// prefix and rationale. The signal only carries the strategies and their
+// confidence if they agree with its side. It fails if the evaluation has no side.
+func (e Evaluation) Signal(now time.Time, prefix, rationale string) (*Signal, error) {
+	if e.Side != BUY && e.Side != SELL {
+		return nil, fmt.Errorf("no side to signal for %s", e.Symbol)
+	}
+	var confidence float64
+	var strategies []string
+	if e.Decision == e.Side {
+		confidence, strategies = e.Confidence, e.Strategies
+	}
+	return &Signal{
+		SchemaVersion: CurrentSchemaVersion,
+		ID:            ids.New(fmt.Sprintf("%s-%s-%s", prefix, e.Symbol, e.Side), now),
+		Symbol:        e.Symbol,
+		Type:          e.Side,
+		Price:         e.Price,
+		TargetPrice:   e.TargetPrice,
+		StopLoss:      e.StopLoss,
+		ExpectedROI:   e.ExpectedROI,
+		Confidence:    confidence,
+		Rationale:     rationale,
+		GeneratedAt:   now,
+		TimeFrame:     "1-3 hours",
+		TechnicalData: e.Indicators,
+		Status:        StatusActive,
+		StatusChanges: []StatusChange{{Status: StatusActive, At: now}},
+		Regime:        e.Regime,
+		Strategies:    strategies,
+		ParamSet:      e.ParamSet,
+		Currency:      CurrencyFor(e.Symbol),
+		OrderType:     OrderMarket,
+	}, nil
+}
+
+// percentLevels returns the target and stop at the minimum expected ROI and
+// stop loss percentages from the price
+func percentLevels(price float64, side SignalType, params config.VolatilityConfig) (float64, float64) {
+	if side == BUY {
+		return price * (1 + params.MinExpectedROI/100), price * (1 - params.StopLossPercent/100)
+	}
+	return price * (1 - params.MinExpectedROI/100), price * (1 + params.StopLossPercent/100)
+}
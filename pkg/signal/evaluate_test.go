@@ -0,0 +1,64 @@
+package signal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateIdea(t *testing.T) {
+	cfg := config.CreateDefaultConfig()
+	generator := NewGenerator(cfg)
+	bullish := createTestMarketData("AAPL", true)
+
+	evaluation, err := generator.Evaluate("AAPL", bullish, "")
+	assert.NoError(t, err)
+	assert.Equal(t, bullish.Prices[len(bullish.Prices)-1], evaluation.Price)
+	assert.Equal(t, evaluation.Decision, evaluation.Side)
+	assert.Contains(t, evaluation.Indicators, "rsi")
+	assert.Equal(t, ParamSetDefault, evaluation.ParamSet)
+
+	long, err := generator.Evaluate("AAPL", bullish, BUY)
+	assert.NoError(t, err)
+	assert.Greater(t, long.TargetPrice, long.Price)
+	assert.Less(t, long.StopLoss, long.Price)
+
+	// Levels stay on the right side of the price against the trend
+	short, err := generator.Evaluate("AAPL", bullish, SELL)
+	assert.NoError(t, err)
+	assert.Less(t, short.TargetPrice, short.Price)
+	assert.Greater(t, short.StopLoss, short.Price)
+	assert.NotEqual(t, SELL, short.Decision)
+	assert.False(t, short.Qualifies)
+
+	_, err = generator.Evaluate("AAPL", MarketData{Prices: []float64{100}, Volumes: []float64{1}}, "")
+	assert.Error(t, err)
+	_, err = generator.Evaluate("AAPL", bullish, HOLD)
+	assert.Error(t, err)
+}
+
+func TestEvaluationSignal(t *testing.T) {
+	now := time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC)
+	evaluation := Evaluation{Symbol: "AAPL", Price: 100, Decision: HOLD, Confidence: 0.4, Side: BUY,
+		TargetPrice: 103, StopLoss: 98, ExpectedROI: 3, Strategies: []string{"volatility"}}
+
+	s, err := evaluation.Signal(now, "IDEA", "Breakout")
+	assert.NoError(t, err)
+	assert.Equal(t, BUY, s.Type)
+	assert.Equal(t, 103.0, s.TargetPrice)
+	assert.Equal(t, StatusActive, s.Status)
+	assert.Contains(t, s.ID, "IDEA-AAPL-BUY-")
+	// The strategies didn't take the idea's side
+	assert.Zero(t, s.Confidence)
+	assert.Empty(t, s.Strategies)
+
+	evaluation.Decision = BUY
+	s, _ = evaluation.Signal(now, "IDEA", "Breakout")
+	assert.Equal(t, 0.4, s.Confidence)
+
+	evaluation.Side = HOLD
+	_, err = evaluation.Signal(now, "IDEA", "")
+	assert.Error(t, err)
+}
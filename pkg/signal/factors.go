@@ -0,0 +1,29 @@
+package signal
+
+// FactorSource provides non-price factors for a symbol, such as insider
+// activity (implemented by filings.Monitor)
+type FactorSource interface {
+	Factors(symbol string) map[string]float64
+}
+
+// AddFactorSource adds a source whose factors are merged into the technical
+// data every strategy votes on
+func (g *Generator) AddFactorSource(source FactorSource) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.factorSources = append(g.factorSources, source)
+}
+
+// addFactors merges the factors of every source into technicalData
+func (g *Generator) addFactors(symbol string, technicalData map[string]float64) {
+	g.mu.RLock()
+	sources := g.factorSources
+	g.mu.RUnlock()
+
+	for _, source := range sources {
+		for name, value := range source.Factors(symbol) {
+			technicalData[name] = value
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package signal
+
+// FeatureGate reports whether a gated behavior is on for a key such as a
+// symbol (implemented by features.Flags)
+type FeatureGate interface {
+	EnabledFor(name, key string) bool
+}
+
+// SetFeatureGate sets the flags gating new behavior such as ensemble voting
+func (g *Generator) SetFeatureGate(gate FeatureGate) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.features = gate
+}
+
+// featureEnabled reports whether a gated behavior is on for key. Without a
+// gate everything is on.
+func (g *Generator) featureEnabled(name, key string) bool {
+	g.mu.RLock()
+	gate := g.features
+	g.mu.RUnlock()
+
+	return gate == nil || gate.EnabledFor(name, key)
+}
@@ -0,0 +1,98 @@
+package signal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hustler/trading-bot/pkg/config"
+)
+
+// SymbolFilter decides whether a symbol may be signalled (implemented by symbols.Lists)
+type SymbolFilter interface {
+	Allowed(symbol string) (bool, string)
+}
+
+// SetSymbolFilter sets the blacklist/allowlist applied before signals are generated
+func (g *Generator) SetSymbolFilter(filter SymbolFilter) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.symbolFilter = filter
+}
+
+// otcExchanges are exchange codes used for OTC listings by the supported data sources
+var otcExchanges = map[string]bool{
+	"OTC":   true,
+	"OTCBB": true,
+	"OTCQB": true,
+	"OTCQX": true,
+	"PNK":   true, // Yahoo Finance pink sheets
+	"PINX":  true, // Questrade pink sheets
+	"PINK":  true,
+}
+
+// IsOTCExchange reports whether an exchange code denotes an OTC market
+func IsOTCExchange(exchange string) bool {
+	code := strings.ToUpper(strings.TrimSpace(exchange))
+	return otcExchanges[code] || strings.HasPrefix(code, "OTC")
+}
+
+// ScreenSymbol applies the price band and OTC filters to a symbol's latest price
+// and listing exchange. It returns a reason when the symbol is filtered out.
+func ScreenSymbol(filter config.PriceFilterConfig, price float64, exchange string) (bool, string) {
+	if filter.MinPrice > 0 && price < filter.MinPrice {
+		return false, fmt.Sprintf("price $%.2f below minimum $%.2f", price, filter.MinPrice)
+	}
+	if filter.MaxPrice > 0 && price > filter.MaxPrice {
+		return false, fmt.Sprintf("price $%.2f above maximum $%.2f", price, filter.MaxPrice)
+	}
+	if filter.ExcludeOTC && IsOTCExchange(exchange) {
+		return false, fmt.Sprintf("listed on OTC market %s", exchange)
+	}
+	return true, ""
+}
+
+// screen applies the price filters and the symbol blacklist/allowlist to marketData
+func (g *Generator) screen(marketData map[string]MarketData) (map[string]MarketData, map[string]string) {
+	passed, rejected := ScreenMarketData(g.currentConfig().PriceFilter, marketData)
+
+	g.mu.RLock()
+	filter := g.symbolFilter
+	g.mu.RUnlock()
+	if filter == nil {
+		return passed, rejected
+	}
+
+	for symbol := range passed {
+		if ok, reason := filter.Allowed(symbol); !ok {
+			delete(passed, symbol)
+			rejected[symbol] = reason
+		}
+	}
+	return passed, rejected
+}
+
+// ScreenMarketData returns the symbols in marketData that are not halted and pass the price filters,
+// along with the reason each other symbol was filtered out
+func ScreenMarketData(filter config.PriceFilterConfig, marketData map[string]MarketData) (map[string]MarketData, map[string]string) {
+	passed := make(map[string]MarketData, len(marketData))
+	rejected := make(map[string]string)
+
+	for symbol, data := range marketData {
+		if data.Halted {
+			rejected[symbol] = "trading halted"
+			continue
+		}
+		if len(data.Prices) == 0 {
+			passed[symbol] = data
+			continue
+		}
+		if ok, reason := ScreenSymbol(filter, data.Prices[len(data.Prices)-1], data.Exchange); !ok {
+			rejected[symbol] = reason
+			continue
+		}
+		passed[symbol] = data
+	}
+
+	return passed, rejected
+}
@@ -0,0 +1,39 @@
+package signal
+
+import (
+	"testing"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScreenMarketData(t *testing.T) {
+	filter := config.PriceFilterConfig{MinPrice: 5, MaxPrice: 1000, ExcludeOTC: true}
+	marketData := map[string]MarketData{
+		"AAPL":  {Symbol: "AAPL", Prices: []float64{180}, Exchange: "NMS"},
+		"PENNY": {Symbol: "PENNY", Prices: []float64{0.85}, Exchange: "NYQ"},
+		"BRK.A": {Symbol: "BRK.A", Prices: []float64{600000}, Exchange: "NYQ"},
+		"GRAY":  {Symbol: "GRAY", Prices: []float64{12}, Exchange: "PNK"},
+		"HALT":  {Symbol: "HALT", Prices: []float64{50}, Exchange: "NMS", Halted: true},
+	}
+
+	passed, rejected := ScreenMarketData(filter, marketData)
+	assert.Len(t, passed, 1)
+	assert.Contains(t, passed, "AAPL")
+	assert.Contains(t, rejected["PENNY"], "below minimum")
+	assert.Contains(t, rejected["BRK.A"], "above maximum")
+	assert.Contains(t, rejected["GRAY"], "OTC")
+	assert.Equal(t, "trading halted", rejected["HALT"])
+
+	// OTC listings pass when not excluded
+	filter.ExcludeOTC = false
+	passed, _ = ScreenMarketData(filter, marketData)
+	assert.Contains(t, passed, "GRAY")
+}
+
+func TestIsOTCExchange(t *testing.T) {
+	assert.True(t, IsOTCExchange("PINX"))
+	assert.True(t, IsOTCExchange("otcqx"))
+	assert.False(t, IsOTCExchange("NASDAQ"))
+	assert.False(t, IsOTCExchange(""))
+}
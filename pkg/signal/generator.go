@@ -1,11 +1,19 @@
 package signal
 
 import (
+	"context"
 	"fmt"
+	"log"
 	"math"
+	"sync"
 	"time"
 
+	"github.com/hustler/trading-bot/pkg/clock"
 	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/features"
+	"github.com/hustler/trading-bot/pkg/ids"
+	"github.com/hustler/trading-bot/pkg/indicators"
+	"github.com/hustler/trading-bot/pkg/tracing"
 )
 
 // SignalType represents the type of trading signal
@@ -22,6 +30,7 @@ const (
 
 // Signal represents a trading signal
 type Signal struct {
+	SchemaVersion int                `json:"schema_version"` // Layout version; see CurrentSchemaVersion
 	ID            string             `json:"id"`
 	Symbol        string             `json:"symbol"`
 	Type          SignalType         `json:"type"`
@@ -34,25 +43,217 @@ type Signal struct {
 	GeneratedAt   time.Time          `json:"generated_at"`
 	TimeFrame     string             `json:"time_frame"`
 	TechnicalData map[string]float64 `json:"technical_data"`
-	Status        string             `json:"status"`
+	Status        Status             `json:"status"`
+	StatusChanges []StatusChange     `json:"status_changes,omitempty"` // When the signal entered each status
+	MaxShares     int                `json:"max_shares,omitempty"` // Liquidity cap on position size; 0 means uncapped
+	Regime        string             `json:"regime,omitempty"`     // Market regime when the signal was generated
+	Strategies    []string           `json:"strategies,omitempty"` // Ensemble strategies that voted for the signal
+	ScoreBreakdown []ScoreComponent  `json:"score_breakdown,omitempty"` // What each technical score component added to the volatility strategy's confidence
+	Watchlist     string             `json:"watchlist,omitempty"`  // Watchlist the symbol was checked from
+	ParamSet      string             `json:"param_set,omitempty"`  // Parameters generated with: ParamSetDefault, ParamSetTuned or a regime
+	ShortRestricted bool             `json:"short_restricted,omitempty"` // Short-sale restriction (Rule 201) in effect; shorts only on upticks
+	Company       string             `json:"company,omitempty"`    // Company name from symbol metadata
+	Sector        string             `json:"sector,omitempty"`
+	Industry      string             `json:"industry,omitempty"`
+	Seasonality   string             `json:"seasonality,omitempty"` // Typical intraday behavior for the rest of the session
+	Test          bool               `json:"test,omitempty"`        // Fabricated to verify delivery; never tracked or traded
+	Currency      string             `json:"currency,omitempty"`    // Currency the prices are quoted in
+	OrderType     string             `json:"order_type,omitempty"`  // How the entry is placed: OrderMarket or OrderLimit
+	Revisions     []Revision         `json:"revisions,omitempty"`   // Levels replaced when the market moved before publish, oldest first
 }
 
+// squeezeBreakoutBonus is added to the volatility score when price breaks out of a squeeze
+const squeezeBreakoutBonus = 0.2
+
 // Generator is responsible for generating trading signals
 type Generator struct {
-	config *config.Config
+	config       *config.Config
+	indicators   []indicators.SeriesIndicator
+	rolling      *indicators.RollingSet // Computes indicators, reusing each symbol's state from its last check
+	liquidity    map[string]LiquidityProfile
+	liquidityDay time.Time
+	symbolFilter SymbolFilter
+	clock        clock.Clock
+	regime       string                   // Current market regime, attached to every signal
+	regimeParams *config.VolatilityConfig // Parameter set for the regime; nil uses VolatilityParams
+	ensemble     *Ensemble                // Strategies voting on each symbol; nil uses the volatility strategy alone
+	latest       map[string]map[string]float64 // Indicator values from the last analysis of each symbol
+	factorSources []FactorSource // Non-price factors merged into the technical data
+	features     FeatureGate              // Gates new behavior per symbol; nil leaves it all on
+	symbolParams SymbolParams             // Parameters tuned per symbol; nil uses the regime's
+	pairStrategy PairStrategy             // Trades the configured pairs; nil disables pair signals
+	mu           sync.RWMutex
 }
 
-// NewGenerator creates a new signal generator
+// NewGenerator creates a new signal generator. The indicator set comes from the
+// "volatility" strategy config, falling back to the defaults derived from VolatilityParams.
+// The generator keeps its own copy of cfg.
 func NewGenerator(cfg *config.Config) *Generator {
+	set := buildIndicatorSet(cfg)
 	return &Generator{
-		config: cfg,
+		config:     cfg.Clone(),
+		indicators: set,
+		rolling:    indicators.NewRollingSet(set),
+		liquidity:  make(map[string]LiquidityProfile),
+		latest:     make(map[string]map[string]float64),
+		clock:      clock.Real{},
+	}
+}
+
+// UpdateConfig updates the generator configuration to a copy of cfg. The
+// indicator set built from the previous configuration is kept.
+func (g *Generator) UpdateConfig(cfg *config.Config) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.config = cfg.Clone()
+}
+
+// currentConfig returns the generator configuration. It is replaced by updates,
+// never changed in place, so it can be read without holding the lock.
+func (g *Generator) currentConfig() *config.Config {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.config
+}
+
+// SetClock sets the clock signals are timestamped with
+func (g *Generator) SetClock(c clock.Clock) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.clock = c
+}
+
+// SetRegime sets the market regime attached to new signals. params replaces
+// VolatilityParams while the regime lasts; nil uses VolatilityParams.
+func (g *Generator) SetRegime(regime string, params *config.VolatilityConfig) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.regime = regime
+	g.regimeParams = params
+}
+
+// SetEnsemble sets the strategies that vote on each symbol. nil generates
+// signals from the volatility strategy alone.
+func (g *Generator) SetEnsemble(e *Ensemble) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.ensemble = e
+}
+
+// decide returns the signal type and confidence for a symbol, from the
+// ensemble if one is set and enabled for the symbol and from the volatility
+// strategy otherwise. A
+// non-empty strategies restricts the vote to those strategies.
+func (g *Generator) decide(symbol string, technicalData map[string]float64, params config.VolatilityConfig, strategies []string) Decision {
+	g.mu.RLock()
+	ensemble := g.ensemble
+	g.mu.RUnlock()
+
+	if ensemble != nil && g.featureEnabled(features.Ensemble, symbol) {
+		decision := ensemble.DecideWith(technicalData, params, strategies)
+		if decision.Confidence < params.ConfidenceThreshold {
+			decision.Type = HOLD
+		}
+		return decision
+	}
+
+	vote := volatilityStrategy{}.Vote(technicalData, params)
+	return Decision{Type: vote.Type, Confidence: vote.Confidence}
+}
+
+// LatestIndicators returns a copy of the indicator values from the last
+// analysis of symbol
+func (g *Generator) LatestIndicators(symbol string) (map[string]float64, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	values, ok := g.latest[symbol]
+	if !ok {
+		return nil, false
+	}
+	result := make(map[string]float64, len(values))
+	for name, value := range values {
+		result[name] = value
+	}
+	return result, true
+}
+
+// recordIndicators keeps a copy of the indicator values computed for symbol
+func (g *Generator) recordIndicators(symbol string, values map[string]float64) {
+	latest := make(map[string]float64, len(values))
+	for name, value := range values {
+		latest[name] = value
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.latest[symbol] = latest
+}
+
+// volatilityParams returns the regime label, the name of the parameter set
+// signals are generated with and its parameters
+func (g *Generator) volatilityParams() (string, string, config.VolatilityConfig) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if g.regimeParams != nil {
+		return g.regime, g.regime, *g.regimeParams
 	}
+	return g.regime, ParamSetDefault, g.config.VolatilityParams
+}
+
+// now returns the current time from the generator's clock
+func (g *Generator) now() time.Time {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.clock.Now()
+}
+
+// buildIndicatorSet builds the configured indicator set for the volatility strategy
+func buildIndicatorSet(cfg *config.Config) []indicators.SeriesIndicator {
+	defaults := indicators.DefaultVolatilityIndicators(cfg.VolatilityParams)
+
+	specs := defaults
+	if strategy, ok := cfg.Strategies["volatility"]; ok && len(strategy.Indicators) > 0 {
+		specs = strategy.Indicators
+	}
+
+	set, err := indicators.BuildAll(specs)
+	if err != nil {
+		log.Printf("Invalid indicator config for volatility strategy, using defaults: %v", err)
+		set, _ = indicators.BuildAll(defaults)
+	}
+
+	return set
 }
 
 // GenerateSignals analyzes market data and generates trading signals
 func (g *Generator) GenerateSignals(marketData map[string]MarketData) ([]*Signal, error) {
+	return g.GenerateWatchlistSignals(config.WatchlistConfig{}, marketData)
+}
+
+// GenerateWatchlistSignals generates signals for the symbols of a watchlist
+// using only the strategies bound to it, tagging each signal with its name
+func (g *Generator) GenerateWatchlistSignals(watchlist config.WatchlistConfig, marketData map[string]MarketData) ([]*Signal, error) {
+	return g.GenerateWatchlistSignalsContext(context.Background(), watchlist, marketData)
+}
+
+// GenerateWatchlistSignalsContext is GenerateWatchlistSignals recording a
+// span per analyzed symbol under the trace in ctx
+func (g *Generator) GenerateWatchlistSignalsContext(ctx context.Context, watchlist config.WatchlistConfig, marketData map[string]MarketData) ([]*Signal, error) {
 	signals := []*Signal{}
 
+	// Skip penny stocks, out-of-band prices, OTC listings and blacklisted symbols
+	// even if they are on the watchlist
+	marketData, filtered := g.screen(marketData)
+	for symbol, reason := range filtered {
+		log.Printf("Skipping %s: %s", symbol, reason)
+	}
+
 	for symbol, data := range marketData {
 		// Skip if not enough data points
 		if len(data.Prices) < 30 || len(data.Volumes) < 30 {
@@ -60,8 +261,13 @@ func (g *Generator) GenerateSignals(marketData map[string]MarketData) ([]*Signal
 		}
 
 		// Analyze volatility patterns
-		signal, generated := g.analyzeVolatilityPatterns(symbol, data)
+		symbolCtx, span := tracing.Start(ctx, "signal.analyze")
+		span.SetAttribute("symbol", symbol)
+		signal, generated := g.analyzeVolatilityPatterns(symbolCtx, symbol, data, watchlist.Strategies)
+		span.SetAttribute("generated", generated)
+		span.End()
 		if generated {
+			signal.Watchlist = watchlist.Name
 			signals = append(signals, signal)
 		}
 	}
@@ -70,54 +276,79 @@ func (g *Generator) GenerateSignals(marketData map[string]MarketData) ([]*Signal
 }
 
 // analyzeVolatilityPatterns analyzes volatility patterns for a stock
-func (g *Generator) analyzeVolatilityPatterns(symbol string, data MarketData) (*Signal, bool) {
+func (g *Generator) analyzeVolatilityPatterns(ctx context.Context, symbol string, data MarketData, strategies []string) (*Signal, bool) {
 	// Get current price
 	currentPrice := data.Prices[len(data.Prices)-1]
 	
 	// Calculate technical indicators
-	technicalData := calculateTechnicalIndicators(data, g.config.VolatilityParams, currentPrice)
-	
-	// Calculate volatility score
-	volatilityScore := calculateVolatilityScore(technicalData, g.config.VolatilityParams)
-	
-	// If volatility score is below threshold, no signal
-	if volatilityScore < g.config.VolatilityParams.ConfidenceThreshold {
-		return nil, false
+	_, span := tracing.Start(ctx, "signal.indicators")
+	technicalData := g.rolling.Compute(symbol, data.Prices, data.Volumes, data.Timestamps)
+	technicalData["price"] = currentPrice
+	g.addFactors(symbol, technicalData)
+	g.recordIndicators(symbol, technicalData)
+	span.End()
+	
+	// Thresholds and price levels follow the current market regime, unless
+	// tuned for the symbol
+	regime, paramSet, params := g.volatilityParams()
+	if tuned, ok := g.paramsFor(symbol, params); ok {
+		paramSet, params = ParamSetTuned, tuned
 	}
 	
-	// Determine signal type based on indicators
-	signalType := determineSignalType(technicalData)
+	// Score the setup and determine the signal type; the volatility strategy
+	// skips setups that don't suit the current trend regime
+	_, span = tracing.Start(ctx, "signal.strategy")
+	decision := g.decide(symbol, technicalData, params, strategies)
+	span.SetAttribute("decision", string(decision.Type))
+	span.SetAttribute("confidence", decision.Confidence)
+	span.End()
 	
 	// If HOLD, no signal
-	if signalType == HOLD {
+	if decision.Type == HOLD {
 		return nil, false
 	}
+	signalType := decision.Type
 	
 	// Calculate target price and stop loss
-	targetPrice, stopLoss := calculatePriceLevels(currentPrice, signalType, technicalData, g.config.VolatilityParams)
+	targetPrice, stopLoss := calculatePriceLevels(currentPrice, signalType, technicalData, params)
 	
 	// Calculate expected ROI
 	expectedROI := calculateExpectedROI(currentPrice, targetPrice, signalType)
 	
 	// If expected ROI is below minimum, no signal
-	if expectedROI < g.config.VolatilityParams.MinExpectedROI {
+	if expectedROI < params.MinExpectedROI {
 		return nil, false
 	}
 	
 	// Create signal
+	now := g.now()
 	signal := &Signal{
-		ID:            fmt.Sprintf("SIG-%s-%s-%d", symbol, signalType, time.Now().Unix()),
+		SchemaVersion: CurrentSchemaVersion,
+		ID:            ids.New(fmt.Sprintf("SIG-%s-%s", symbol, signalType), now),
 		Symbol:        symbol,
 		Type:          signalType,
 		Price:         currentPrice,
 		TargetPrice:   targetPrice,
 		StopLoss:      stopLoss,
 		ExpectedROI:   expectedROI,
-		Confidence:    volatilityScore,
-		GeneratedAt:   time.Now(),
+		Confidence:    decision.Confidence,
+		ScoreBreakdown: scoreBreakdown(technicalData, params),
+		GeneratedAt:   now,
 		TimeFrame:     "1-3 hours",
 		TechnicalData: technicalData,
-		Status:        "ACTIVE",
+		Status:        StatusActive,
+		StatusChanges: []StatusChange{{Status: StatusActive, At: now}},
+		Regime:        regime,
+		Strategies:    decision.Strategies,
+		ParamSet:      paramSet,
+		ShortRestricted: data.ShortRestricted,
+		Currency:      CurrencyFor(symbol),
+		OrderType:     OrderMarket,
+	}
+	
+	// Reject or downsize signals on thinly traded symbols
+	if !g.applyLiquidity(signal) {
+		return nil, false
 	}
 	
 	return signal, true
@@ -125,155 +356,67 @@ func (g *Generator) analyzeVolatilityPatterns(symbol string, data MarketData) (*
 
 // MarketData represents market data for a stock
 type MarketData struct {
-	Symbol     string
-	Prices     []float64
-	Volumes    []float64
-	Timestamps []time.Time
+	Symbol          string
+	Prices          []float64
+	Volumes         []float64
+	Timestamps      []time.Time
+	Exchange        string // Listing exchange, if known
+	Halted          bool   // Trading halted; no signals are generated
+	ShortRestricted bool   // Short-sale restriction (Rule 201) in effect
 }
 
-// calculateTechnicalIndicators calculates technical indicators from market data
-func calculateTechnicalIndicators(data MarketData, params config.VolatilityConfig, currentPrice float64) map[string]float64 {
-	indicators := make(map[string]float64)
-	
-	// Store current price in indicators map
-	indicators["price"] = currentPrice
-	
-	// Calculate Bollinger Bands
-	sma := calculateSMA(data.Prices, params.BollingerPeriod)
-	stdDev := calculateStdDev(data.Prices, params.BollingerPeriod)
-	upperBand := sma + params.BollingerDeviation*stdDev
-	lowerBand := sma - params.BollingerDeviation*stdDev
-	
-	// Calculate RSI
-	rsi := calculateRSI(data.Prices, params.RSIPeriod)
-	
-	// Calculate volume ratio
-	avgVolume := calculateSMA(data.Volumes, 10)
-	currentVolume := data.Volumes[len(data.Volumes)-1]
-	volumeRatio := currentVolume / avgVolume * 100
-	
-	// Calculate price volatility
-	priceChange := calculatePriceChange(data.Prices)
-	
-	// Store indicators
-	indicators["sma"] = sma
-	indicators["upper_band"] = upperBand
-	indicators["lower_band"] = lowerBand
-	indicators["rsi"] = rsi
-	indicators["volume_ratio"] = volumeRatio
-	indicators["price_change"] = priceChange
-	
-	return indicators
-}
-
-// calculateSMA calculates Simple Moving Average
-func calculateSMA(values []float64, period int) float64 {
-	if len(values) < period {
-		return 0
+// calculateTechnicalIndicators calculates the default technical indicators from market data
+func calculateTechnicalIndicators(data MarketData, params config.VolatilityConfig) map[string]float64 {
+	set, err := indicators.BuildAll(indicators.DefaultVolatilityIndicators(params))
+	if err != nil {
+		return map[string]float64{}
 	}
 	
-	sum := 0.0
-	for i := len(values) - period; i < len(values); i++ {
-		sum += values[i]
+	values := indicators.ComputeAll(set, data.Prices, data.Volumes)
+	if len(data.Prices) > 0 {
+		values["price"] = data.Prices[len(data.Prices)-1]
 	}
 	
-	return sum / float64(period)
+	return values
 }
 
-// calculateStdDev calculates Standard Deviation
-func calculateStdDev(values []float64, period int) float64 {
-	if len(values) < period {
-		return 0
-	}
-	
-	sma := calculateSMA(values, period)
-	sumSquaredDiff := 0.0
-	
-	for i := len(values) - period; i < len(values); i++ {
-		diff := values[i] - sma
-		sumSquaredDiff += diff * diff
-	}
-	
-	return math.Sqrt(sumSquaredDiff / float64(period))
+// calculateSMA calculates Simple Moving Average
+func calculateSMA(values []float64, period int) float64 {
+	return indicators.SimpleAverage(values, period)
 }
 
 // calculateRSI calculates Relative Strength Index
 func calculateRSI(prices []float64, period int) float64 {
-	if len(prices) < period+1 {
-		return 50 // Default to neutral
-	}
-	
-	gains := 0.0
-	losses := 0.0
-	
-	for i := len(prices) - period; i < len(prices); i++ {
-		change := prices[i] - prices[i-1]
-		if change >= 0 {
-			gains += change
-		} else {
-			losses -= change
-		}
-	}
-	
-	if losses == 0 {
-		return 100 // All gains
-	}
-	
-	rs := gains / losses
-	rsi := 100 - (100 / (1 + rs))
-	
-	return rsi
+	return indicators.RelativeStrength(prices, period)
 }
 
-// calculatePriceChange calculates recent price change percentage
-func calculatePriceChange(prices []float64) float64 {
-	if len(prices) < 2 {
-		return 0
-	}
-	
-	current := prices[len(prices)-1]
-	previous := prices[len(prices)-2]
-	
-	return (current - previous) / previous * 100
-}
-
-// calculateVolatilityScore calculates a volatility score based on technical indicators
+// calculateVolatilityScore calculates a volatility score based on technical
+// indicators, as the sum of its components' points (see scoreBreakdown)
 func calculateVolatilityScore(indicators map[string]float64, params config.VolatilityConfig) float64 {
 	score := 0.0
-	
-	// Bollinger Band score
-	currentPrice := indicators["price"]
-	upperBand := indicators["upper_band"]
-	lowerBand := indicators["lower_band"]
-	
-	// Price near or outside Bollinger Bands
-	if currentPrice > upperBand*0.98 || currentPrice < lowerBand*1.02 {
-		score += 0.3
-	}
-	
-	// RSI score
-	rsi := indicators["rsi"]
-	if rsi > params.RSIOverbought || rsi < params.RSIOversold {
-		score += 0.25
-	}
-	
-	// Volume score
-	volumeRatio := indicators["volume_ratio"]
-	if volumeRatio > params.VolumeThreshold {
-		score += 0.25
-	}
-	
-	// Price change score
-	priceChange := math.Abs(indicators["price_change"])
-	if priceChange > params.MinVolatilityPercent {
-		score += 0.2
+	for _, component := range scoreBreakdown(indicators, params) {
+		score += component.Points
 	}
 	
 	return score
 }
 
+// Setup types distinguish why a signal fired, for regime filtering
+const (
+	setupNone          = ""
+	setupBreakout      = "breakout"
+	setupMeanReversion = "mean_reversion"
+	setupTrend         = "trend"
+)
+
 // determineSignalType determines the signal type based on technical indicators
 func determineSignalType(indicators map[string]float64) SignalType {
+	signalType, _ := classifySetup(indicators)
+	return signalType
+}
+
+// classifySetup determines the signal type and the kind of setup that produced it
+func classifySetup(indicators map[string]float64) (SignalType, string) {
 	// Get indicators
 	currentPrice := indicators["price"]
 	upperBand := indicators["upper_band"]
@@ -281,20 +424,49 @@ func determineSignalType(indicators map[string]float64) SignalType {
 	rsi := indicators["rsi"]
 	priceChange := indicators["price_change"]
 	
+	// Trade in the direction of a squeeze breakout rather than fading the band
+	if breakout := indicators["squeeze_breakout"]; breakout > 0 {
+		return BUY, setupBreakout
+	} else if breakout < 0 {
+		return SELL, setupBreakout
+	}
+	
 	// Bullish conditions
-	if (currentPrice < lowerBand*1.02 && rsi < 30) || // Oversold
-	   (priceChange > 0 && rsi > 50 && rsi < 70) {    // Uptrend with momentum
-		return BUY
+	if currentPrice < lowerBand*1.02 && rsi < 30 { // Oversold
+		return BUY, setupMeanReversion
+	}
+	if priceChange > 0 && rsi > 50 && rsi < 70 { // Uptrend with momentum
+		return BUY, setupTrend
 	}
 	
 	// Bearish conditions
-	if (currentPrice > upperBand*0.98 && rsi > 70) || // Overbought
-	   (priceChange < 0 && rsi < 50 && rsi > 30) {    // Downtrend with momentum
-		return SELL
+	if currentPrice > upperBand*0.98 && rsi > 70 { // Overbought
+		return SELL, setupMeanReversion
+	}
+	if priceChange < 0 && rsi < 50 && rsi > 30 { // Downtrend with momentum
+		return SELL, setupTrend
 	}
 	
 	// No clear signal
-	return HOLD
+	return HOLD, setupNone
+}
+
+// passesRegimeFilter uses ADX trend strength to skip mean-reversion entries in strong
+// trends and trend-following entries in ranging markets. Breakouts are not filtered.
+func passesRegimeFilter(setup string, indicators map[string]float64, params config.VolatilityConfig) bool {
+	adx, ok := indicators["adx"]
+	if !ok {
+		return true
+	}
+	
+	switch setup {
+	case setupMeanReversion:
+		return params.ADXTrendThreshold <= 0 || adx < params.ADXTrendThreshold
+	case setupTrend:
+		return params.ADXRangeThreshold <= 0 || adx >= params.ADXRangeThreshold
+	default:
+		return true
+	}
 }
 
 // calculatePriceLevels calculates target price and stop loss levels
@@ -305,6 +477,20 @@ func calculatePriceLevels(currentPrice float64, signalType SignalType, indicator
 	
 	var targetPrice, stopLoss float64
 	
+	// Squeeze breakouts project the band width from the breakout and stop at the middle band
+	if indicators["squeeze_breakout"] != 0 {
+		sma := indicators["sma"]
+		projection := upperBand - lowerBand
+		if signalType == BUY {
+			targetPrice = currentPrice + projection
+			stopLoss = math.Max(sma, currentPrice*(1-params.StopLossPercent/100))
+		} else {
+			targetPrice = currentPrice - projection
+			stopLoss = math.Min(sma, currentPrice*(1+params.StopLossPercent/100))
+		}
+		return targetPrice, stopLoss
+	}
+	
 	if signalType == BUY {
 		// Target price: either upper band or a percentage gain
 		targetPrice = math.Min(upperBand, currentPrice*(1+params.MinExpectedROI/100))
@@ -333,6 +519,27 @@ func calculateExpectedROI(currentPrice, targetPrice float64, signalType SignalTy
 
 // FormatSignalMessage formats a signal for Telegram message
 func FormatSignalMessage(s *Signal) string {
+	return formatSignalMessage(s, nil, HTML)
+}
+
+// FormatRedactedSignalMessage formats a signal for Telegram message without the
+// given fields (see config.StrippableSignalFields)
+func FormatRedactedSignalMessage(s *Signal, fields []string) string {
+	return FormatChannelSignalMessage(s, fields, HTML)
+}
+
+// FormatChannelSignalMessage formats a signal for a channel with the given
+// markup, without the given fields
+func FormatChannelSignalMessage(s *Signal, fields []string, m Markup) string {
+	omit := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		omit[field] = true
+	}
+	return formatSignalMessage(s, omit, m)
+}
+
+// formatSignalMessage formats a signal, leaving out the omitted fields
+func formatSignalMessage(s *Signal, omit map[string]bool, m Markup) string {
 	// Format ROI with sign
 	roiSign := "+"
 	if s.Type == SELL {
@@ -342,20 +549,53 @@ func FormatSignalMessage(s *Signal) string {
 	// Format confidence as percentage
 	confidencePercent := math.Round(s.Confidence * 100)
 	
+	// field formats one labelled line of the message
+	field := func(emoji, label, value string) string {
+		return emoji + " " + m.Bold(label+":") + " " + m.Escape(value) + "\n"
+	}
+	
 	// Create message
-	message := fmt.Sprintf("🚨 <b>%s SIGNAL: %s</b> 🚨\n\n", s.Type, s.Symbol)
-	message += fmt.Sprintf("💰 <b>Entry Price:</b> $%.2f\n", s.Price)
-	message += fmt.Sprintf("🎯 <b>Target Price:</b> $%.2f\n", s.TargetPrice)
-	message += fmt.Sprintf("🛑 <b>Stop Loss:</b> $%.2f\n", s.StopLoss)
-	message += fmt.Sprintf("📈 <b>Expected ROI:</b> %s%.2f%%\n", roiSign, s.ExpectedROI)
-	message += fmt.Sprintf("🔍 <b>Confidence:</b> %.0f%%\n", confidencePercent)
-	message += fmt.Sprintf("⏱ <b>Time Frame:</b> %s\n\n", s.TimeFrame)
+	message := "🚨 " + m.Bold(fmt.Sprintf("%s SIGNAL: %s", s.Type, s.Symbol)) + " 🚨\n"
+	if s.Test {
+		message = "🧪 " + m.Bold("TEST SIGNAL — NOT A TRADE RECOMMENDATION") + "\n" + message
+	}
+	if s.Company != "" {
+		company := s.Company
+		if s.Sector != "" {
+			company += " · " + s.Sector
+		}
+		message += m.Escape(company) + "\n"
+	}
+	message += "\n"
+	message += field("💰", "Entry Price", fmt.Sprintf("$%.2f", s.Price))
+	if !omit["target_price"] {
+		message += field("🎯", "Target Price", fmt.Sprintf("$%.2f", s.TargetPrice))
+	}
+	if !omit["stop_loss"] {
+		message += field("🛑", "Stop Loss", fmt.Sprintf("$%.2f", s.StopLoss))
+	}
+	if !omit["expected_roi"] {
+		message += field("📈", "Expected ROI", fmt.Sprintf("%s%.2f%%", roiSign, s.ExpectedROI))
+	}
+	if !omit["confidence"] {
+		message += field("🔍", "Confidence", fmt.Sprintf("%.0f%%", confidencePercent))
+	}
+	if breakdown := formatScoreBreakdown(s.ScoreBreakdown); breakdown != "" && !omit["score_breakdown"] && !omit["confidence"] {
+		message += field("🧮", "Score", breakdown)
+	}
+	if s.MaxShares > 0 && !omit["max_size"] {
+		message += field("📦", "Max Size", fmt.Sprintf("%d shares", s.MaxShares))
+	}
+	if s.ShortRestricted {
+		message += field("⚠️", "SSR", "short-sale restriction in effect, shorts only on an uptick")
+	}
+	message += field("⏱", "Time Frame", s.TimeFrame) + "\n"
 	
-	if s.Rationale != "" {
-		message += fmt.Sprintf("📝 <b>Rationale:</b>\n%s\n\n", s.Rationale)
+	if s.Rationale != "" && !omit["rationale"] {
+		message += "📝 " + m.Bold("Rationale:") + "\n" + m.Escape(s.Rationale) + "\n\n"
 	}
 	
-	message += fmt.Sprintf("⏰ Generated at: %s", s.GeneratedAt.Format("2006-01-02 15:04:05"))
+	message += m.Escape(fmt.Sprintf("⏰ Generated at: %s", s.GeneratedAt.Format("2006-01-02 15:04:05")))
 	
 	return message
 }
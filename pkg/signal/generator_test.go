@@ -1,17 +1,20 @@
 package signal
 
 import (
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/indicators"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewGenerator(t *testing.T) {
 	cfg := config.CreateDefaultConfig()
 	generator := NewGenerator(cfg)
-	
+
 	assert.NotNil(t, generator)
 	assert.Equal(t, cfg, generator.config)
 }
@@ -22,10 +25,10 @@ func TestGenerateSignals(t *testing.T) {
 	cfg.VolatilityParams.MinVolatilityPercent = 1.0
 	cfg.VolatilityParams.MinExpectedROI = 1.5
 	cfg.VolatilityParams.ConfidenceThreshold = 0.6
-	
+
 	// Create generator
 	generator := NewGenerator(cfg)
-	
+
 	// Create test market data
 	marketData := map[string]MarketData{
 		"AAPL": createTestMarketData("AAPL", true),  // Bullish pattern
@@ -37,14 +40,14 @@ func TestGenerateSignals(t *testing.T) {
 			Timestamps: []time.Time{time.Now().Add(-1 * time.Hour), time.Now()},
 		},
 	}
-	
+
 	// Generate signals
 	signals, err := generator.GenerateSignals(marketData)
-	
+
 	// Verify results
 	assert.NoError(t, err)
 	assert.Len(t, signals, 2) // Should generate signals for AAPL and MSFT, but not GOOGL
-	
+
 	// Find signals by symbol
 	var appleSignal, msftSignal *Signal
 	for _, s := range signals {
@@ -54,17 +57,17 @@ func TestGenerateSignals(t *testing.T) {
 			msftSignal = s
 		}
 	}
-	
+
 	// Verify AAPL signal
-	assert.NotNil(t, appleSignal)
+	require.NotNil(t, appleSignal)
 	assert.Equal(t, BUY, appleSignal.Type)
 	assert.Greater(t, appleSignal.TargetPrice, appleSignal.Price)
 	assert.Less(t, appleSignal.StopLoss, appleSignal.Price)
 	assert.GreaterOrEqual(t, appleSignal.ExpectedROI, cfg.VolatilityParams.MinExpectedROI)
 	assert.GreaterOrEqual(t, appleSignal.Confidence, cfg.VolatilityParams.ConfidenceThreshold)
-	
+
 	// Verify MSFT signal
-	assert.NotNil(t, msftSignal)
+	require.NotNil(t, msftSignal)
 	assert.Equal(t, SELL, msftSignal.Type)
 	assert.Less(t, msftSignal.TargetPrice, msftSignal.Price)
 	assert.Greater(t, msftSignal.StopLoss, msftSignal.Price)
@@ -75,17 +78,17 @@ func TestGenerateSignals(t *testing.T) {
 func TestCalculateTechnicalIndicators(t *testing.T) {
 	// Create test market data
 	data := createTestMarketData("TEST", true)
-	
+
 	// Create test parameters
 	params := config.VolatilityConfig{
 		BollingerPeriod:    20,
 		BollingerDeviation: 2.0,
 		RSIPeriod:          14,
 	}
-	
+
 	// Calculate indicators
 	indicators := calculateTechnicalIndicators(data, params)
-	
+
 	// Verify indicators were calculated
 	assert.Contains(t, indicators, "sma")
 	assert.Contains(t, indicators, "upper_band")
@@ -93,25 +96,50 @@ func TestCalculateTechnicalIndicators(t *testing.T) {
 	assert.Contains(t, indicators, "rsi")
 	assert.Contains(t, indicators, "volume_ratio")
 	assert.Contains(t, indicators, "price_change")
-	
+
 	// Verify SMA is reasonable
 	assert.InDelta(t, 150.0, indicators["sma"], 10.0)
-	
+
 	// Verify Bollinger Bands
 	assert.Greater(t, indicators["upper_band"], indicators["sma"])
 	assert.Less(t, indicators["lower_band"], indicators["sma"])
-	
+
 	// Verify RSI is between 0 and 100
 	assert.GreaterOrEqual(t, indicators["rsi"], 0.0)
 	assert.LessOrEqual(t, indicators["rsi"], 100.0)
 }
 
+func TestGeneratorUsesConfiguredIndicators(t *testing.T) {
+	cfg := config.CreateDefaultConfig()
+	cfg.Strategies = map[string]config.StrategyConfig{
+		"volatility": {Indicators: []config.IndicatorConfig{
+			{Type: "bollinger", Period: 10, Deviation: 1.5},
+			{Type: "rsi", Period: 7},
+			{Type: "ema", Period: 9},
+		}},
+	}
+	generator := NewGenerator(cfg)
+	data := createTestMarketData("TEST", true)
+
+	values := indicators.ComputeAll(generator.indicators, data.Prices, data.Volumes)
+	assert.Contains(t, values, "upper_band")
+	assert.Contains(t, values, "ema_9")
+	assert.NotContains(t, values, "volume_ratio")
+	assert.Equal(t, calculateRSI(data.Prices, 7), values["rsi"])
+
+	// An invalid config falls back to the default set
+	cfg.Strategies["volatility"] = config.StrategyConfig{Indicators: []config.IndicatorConfig{{Type: "unknown"}}}
+	generator = NewGenerator(cfg)
+	values = indicators.ComputeAll(generator.indicators, data.Prices, data.Volumes)
+	assert.Contains(t, values, "volume_ratio")
+}
+
 func TestCalculateSMA(t *testing.T) {
 	// Test with valid data
 	values := []float64{1.0, 2.0, 3.0, 4.0, 5.0}
 	sma := calculateSMA(values, 3)
 	assert.Equal(t, 4.0, sma) // (3+4+5)/3 = 4
-	
+
 	// Test with period larger than data
 	sma = calculateSMA(values, 10)
 	assert.Equal(t, 0.0, sma)
@@ -122,18 +150,18 @@ func TestCalculateRSI(t *testing.T) {
 	prices := []float64{100.0, 101.0, 102.0, 103.0, 104.0}
 	rsi := calculateRSI(prices, 3)
 	assert.Equal(t, 100.0, rsi)
-	
+
 	// Test with all losses
 	prices = []float64{100.0, 99.0, 98.0, 97.0, 96.0}
 	rsi = calculateRSI(prices, 3)
 	assert.InDelta(t, 0.0, rsi, 0.1)
-	
+
 	// Test with mixed gains and losses
 	prices = []float64{100.0, 101.0, 99.0, 102.0, 98.0}
 	rsi = calculateRSI(prices, 3)
 	assert.Greater(t, rsi, 0.0)
 	assert.Less(t, rsi, 100.0)
-	
+
 	// Test with insufficient data
 	prices = []float64{100.0}
 	rsi = calculateRSI(prices, 3)
@@ -143,38 +171,84 @@ func TestCalculateRSI(t *testing.T) {
 func TestDetermineSignalType(t *testing.T) {
 	// Test BUY signal - oversold
 	indicators := map[string]float64{
-		"price":      95.0,
-		"upper_band": 110.0,
-		"lower_band": 100.0,
-		"rsi":        25.0,
+		"price":        95.0,
+		"upper_band":   110.0,
+		"lower_band":   100.0,
+		"rsi":          25.0,
 		"price_change": 0.5,
 	}
 	signalType := determineSignalType(indicators)
 	assert.Equal(t, BUY, signalType)
-	
+
 	// Test SELL signal - overbought
 	indicators = map[string]float64{
-		"price":      115.0,
-		"upper_band": 110.0,
-		"lower_band": 100.0,
-		"rsi":        75.0,
+		"price":        115.0,
+		"upper_band":   110.0,
+		"lower_band":   100.0,
+		"rsi":          75.0,
 		"price_change": -0.5,
 	}
 	signalType = determineSignalType(indicators)
 	assert.Equal(t, SELL, signalType)
-	
+
 	// Test HOLD signal - neutral
 	indicators = map[string]float64{
-		"price":      105.0,
-		"upper_band": 110.0,
-		"lower_band": 100.0,
-		"rsi":        50.0,
+		"price":        105.0,
+		"upper_band":   110.0,
+		"lower_band":   100.0,
+		"rsi":          50.0,
 		"price_change": 0.1,
 	}
 	signalType = determineSignalType(indicators)
 	assert.Equal(t, HOLD, signalType)
 }
 
+func TestSqueezeBreakoutFollowsMomentum(t *testing.T) {
+	params := config.CreateDefaultConfig().VolatilityParams
+	indicators := map[string]float64{
+		"price":            103.0,
+		"sma":              100.0,
+		"upper_band":       101.0,
+		"lower_band":       99.0,
+		"rsi":              80.0,
+		"squeeze_breakout": 1,
+	}
+
+	// Overbought above the upper band would normally fade, but a breakout buys
+	assert.Equal(t, BUY, determineSignalType(indicators))
+
+	targetPrice, stopLoss := calculatePriceLevels(103.0, BUY, indicators, params)
+	assert.Equal(t, 105.0, targetPrice)
+	assert.InDelta(t, 102.485, stopLoss, 0.001)
+
+	score := calculateVolatilityScore(indicators, params)
+	assert.InDelta(t, 0.75, score, 0.001)
+}
+
+func TestRegimeFilter(t *testing.T) {
+	params := config.CreateDefaultConfig().VolatilityParams
+
+	// Oversold at the lower band is a mean-reversion setup
+	oversold := map[string]float64{"price": 95.0, "lower_band": 96.0, "upper_band": 104.0, "rsi": 25.0, "adx": 35.0}
+	signalType, setup := classifySetup(oversold)
+	assert.Equal(t, BUY, signalType)
+	assert.False(t, passesRegimeFilter(setup, oversold, params))
+	oversold["adx"] = 15.0
+	assert.True(t, passesRegimeFilter(setup, oversold, params))
+
+	// Momentum is a trend-following setup
+	momentum := map[string]float64{"price": 100.0, "lower_band": 96.0, "upper_band": 104.0, "rsi": 60.0, "price_change": 1.0, "adx": 15.0}
+	signalType, setup = classifySetup(momentum)
+	assert.Equal(t, BUY, signalType)
+	assert.False(t, passesRegimeFilter(setup, momentum, params))
+	momentum["adx"] = 30.0
+	assert.True(t, passesRegimeFilter(setup, momentum, params))
+
+	// Without ADX the filter is inactive
+	delete(momentum, "adx")
+	assert.True(t, passesRegimeFilter(setup, momentum, params))
+}
+
 func TestCalculatePriceLevels(t *testing.T) {
 	// Test BUY signal
 	indicators := map[string]float64{
@@ -188,15 +262,15 @@ func TestCalculatePriceLevels(t *testing.T) {
 	}
 	currentPrice := 100.0
 	targetPrice, stopLoss := calculatePriceLevels(currentPrice, BUY, indicators, params)
-	
+
 	assert.InDelta(t, 102.0, targetPrice, 0.1) // Min of upper band and 2% gain
 	assert.InDelta(t, 99.0, stopLoss, 0.1)     // Max of lower band and 1% loss
-	
+
 	// Test SELL signal
 	targetPrice, stopLoss = calculatePriceLevels(currentPrice, SELL, indicators, params)
-	
-	assert.InDelta(t, 98.0, targetPrice, 0.1)  // Max of lower band and 2% drop
-	assert.InDelta(t, 101.0, stopLoss, 0.1)    // Min of upper band and 1% gain
+
+	assert.InDelta(t, 98.0, targetPrice, 0.1) // Max of lower band and 2% drop
+	assert.InDelta(t, 101.0, stopLoss, 0.1)   // Min of upper band and 1% gain
 }
 
 func TestCalculateExpectedROI(t *testing.T) {
@@ -205,7 +279,7 @@ func TestCalculateExpectedROI(t *testing.T) {
 	targetPrice := 105.0
 	roi := calculateExpectedROI(currentPrice, targetPrice, BUY)
 	assert.Equal(t, 5.0, roi) // (105-100)/100 * 100 = 5%
-	
+
 	// Test SELL signal
 	targetPrice = 95.0
 	roi = calculateExpectedROI(currentPrice, targetPrice, SELL)
@@ -226,66 +300,89 @@ func TestFormatSignalMessage(t *testing.T) {
 		GeneratedAt: time.Date(2025, 4, 20, 10, 15, 0, 0, time.UTC),
 		TimeFrame:   "1-3 hours",
 	}
-	
+
 	// Format message
 	message := FormatSignalMessage(signal)
-	
-	// Verify message contains key information
+
+	// Verify message contains key information, with the labels in bold
 	assert.Contains(t, message, "BUY SIGNAL: AAPL")
-	assert.Contains(t, message, "Entry Price: $150.25")
-	assert.Contains(t, message, "Target Price: $155.50")
-	assert.Contains(t, message, "Stop Loss: $148.00")
-	assert.Contains(t, message, "Expected ROI: +3.50%")
-	assert.Contains(t, message, "Confidence: 85%")
+	assert.Contains(t, message, "<b>Entry Price:</b> $150.25")
+	assert.Contains(t, message, "<b>Target Price:</b> $155.50")
+	assert.Contains(t, message, "<b>Stop Loss:</b> $148.00")
+	assert.Contains(t, message, "<b>Expected ROI:</b> +3.50%")
+	assert.Contains(t, message, "<b>Confidence:</b> 85%")
 	assert.Contains(t, message, "Strong momentum with increasing volume")
 	assert.Contains(t, message, "2025-04-20 10:15:00")
-	
+
 	// Test SELL signal formatting
 	signal.Type = SELL
 	message = FormatSignalMessage(signal)
 	assert.Contains(t, message, "SELL SIGNAL: AAPL")
-	assert.Contains(t, message, "Expected ROI: -3.50%")
+	assert.Contains(t, message, "<b>Expected ROI:</b> -3.50%")
 }
 
-// Helper function to create test market data
+func TestFormatRedactedSignalMessage(t *testing.T) {
+	signal := &Signal{
+		Symbol:      "AAPL",
+		Type:        BUY,
+		Price:       150.25,
+		TargetPrice: 155.50,
+		StopLoss:    148.00,
+		MaxShares:   400,
+		Rationale:   "Strong momentum",
+		GeneratedAt: time.Date(2025, 4, 20, 10, 15, 0, 0, time.UTC),
+	}
+
+	message := FormatRedactedSignalMessage(signal, []string{"max_size", "rationale"})
+	assert.Contains(t, message, "<b>Target Price:</b> $155.50")
+	assert.NotContains(t, message, "Max Size")
+	assert.NotContains(t, message, "Strong momentum")
+	assert.Contains(t, FormatSignalMessage(signal), "<b>Max Size:</b> 400 shares")
+}
+
+func TestFormatSignalMessageLabelsTestSignals(t *testing.T) {
+	signal := &Signal{Symbol: "AAPL", Type: BUY, Price: 150.25, Test: true}
+	assert.True(t, strings.HasPrefix(FormatSignalMessage(signal), "🧪 <b>TEST SIGNAL"))
+
+	signal.Test = false
+	assert.NotContains(t, FormatSignalMessage(signal), "TEST SIGNAL")
+}
+
+// createTestMarketData returns 50 hourly bars around $150 that swing 1.5%
+// either way, tighten to 0.2% swings over the last 20 bars and then break out
+// of the squeeze by 3% on three times the volume, up when bullish
 func createTestMarketData(symbol string, bullish bool) MarketData {
-	// Create base prices
-	basePrices := make([]float64, 50)
-	for i := 0; i < 50; i++ {
-		basePrices[i] = 150.0
+	direction := 1.0
+	if !bullish {
+		direction = -1.0
 	}
-	
-	// Create volumes
+
+	prices := make([]float64, 50)
 	volumes := make([]float64, 50)
-	for i := 0; i < 50; i++ {
-		volumes[i] = 1000000.0
-	}
-	
-	// Create timestamps
 	timestamps := make([]time.Time, 50)
 	now := time.Now()
+	price := 150.0
 	for i := 0; i < 50; i++ {
-		timestamps[49-i] = now.Add(time.Duration(-i) * time.Hour)
-	}
-	
-	// Modify recent prices to create pattern
-	if bullish {
-		// Create bullish pattern (price increasing with higher volume)
-		for i := 40; i < 50; i++ {
-			basePrices[i] = basePrices[i-1] * (1 + 0.005*float64(i-39))
-			volumes[i] = volumes[i-1] * 1.1
+		swing := 1.5
+		if i >= 30 {
+			swing = 0.2
+		}
+		if i%2 == 1 {
+			swing = -swing
 		}
-	} else {
-		// Create bearish pattern (price decreasing with higher volume)
-		for i := 40; i < 50; i++ {
-			basePrices[i] = basePrices[i-1] * (1 - 0.005*float64(i-39))
-			volumes[i] = volumes[i-1] * 1.1
+		if i == 49 {
+			swing = 3 * direction
 		}
+		price *= 1 + swing/100
+		prices[i] = price
+		volumes[i] = 1000000.0
+		timestamps[i] = now.Add(time.Duration(i-49) * time.Hour)
 	}
-	
+	volumes[49] = 3000000.0
+
 	return MarketData{
 		Symbol:     symbol,
-		Prices:     basePrices,
+		Prices:     prices,
 		Volumes:    volumes,
 		Timestamps: timestamps,
 	}
@@ -0,0 +1,59 @@
+package signal
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Idea is a ticker or thesis an admin submitted, with the bot's assessment
+type Idea struct {
+	ID          string     `json:"id"`
+	Symbol      string     `json:"symbol"`
+	Thesis      string     `json:"thesis,omitempty"`
+	SubmittedBy string     `json:"submitted_by"`
+	SubmittedAt time.Time  `json:"submitted_at"`
+	Evaluation  Evaluation `json:"evaluation"`
+	Review      string     `json:"review"`              // LLM advisor's verdict
+	SignalID    string     `json:"signal_id,omitempty"` // Set once tracked as a signal
+}
+
+// FormatIdea formats the assessment of a trade idea as a plain text Telegram reply
+func FormatIdea(idea *Idea) string {
+	e := idea.Evaluation
+	message := fmt.Sprintf("💡 TRADE IDEA: %s\n\n", idea.Symbol)
+	if idea.Thesis != "" {
+		message += fmt.Sprintf("\"%s\"\n\n", idea.Thesis)
+	}
+
+	message += fmt.Sprintf("Price: $%.2f\n", e.Price)
+	message += fmt.Sprintf("Strategies: %s", e.Decision)
+	if e.Decision != HOLD {
+		message += fmt.Sprintf(" (%.0f%% confidence)", e.Confidence*100)
+	}
+	message += "\n"
+	if rsi, ok := e.Indicators["rsi"]; ok {
+		message += fmt.Sprintf("RSI: %.1f\n", rsi)
+	}
+	if ratio, ok := e.Indicators["volume_ratio"]; ok {
+		message += fmt.Sprintf("Volume: %.1fx average\n", ratio)
+	}
+
+	if e.Side != HOLD {
+		message += fmt.Sprintf("\n%s levels: target $%.2f, stop $%.2f, expected ROI %.2f%%\n", e.Side, e.TargetPrice, e.StopLoss, e.ExpectedROI)
+		if e.Qualifies {
+			message += "✅ The generator would signal this now\n"
+		} else {
+			message += "⚠️ The generator would not signal this now\n"
+		}
+	}
+
+	if idea.Review != "" {
+		message += "\n" + idea.Review + "\n"
+	}
+
+	if e.Side != HOLD && idea.SignalID == "" {
+		message += fmt.Sprintf("\nTrack it as a signal with /track %s", idea.ID)
+	}
+	return strings.TrimSuffix(message, "\n")
+}
@@ -0,0 +1,153 @@
+package signal
+
+import (
+	"log"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+)
+
+// defaultLiquidityLookback is the number of trading days averaged when none is configured
+const defaultLiquidityLookback = 20
+
+// LiquidityProfile holds the average daily volume of a symbol
+type LiquidityProfile struct {
+	Symbol          string    `json:"symbol"`
+	AvgVolume       float64   `json:"avg_volume"`        // Shares per day
+	AvgDollarVolume float64   `json:"avg_dollar_volume"` // Dollars traded per day
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// DailyHistorySource provides daily closes and volumes (implemented by data.Provider)
+type DailyHistorySource interface {
+	GetDailyHistory(symbol string, days int) (*data.MarketData, error)
+}
+
+// ComputeLiquidity averages the last lookback days of volume and dollar volume.
+// It reports false when there is no usable history.
+func ComputeLiquidity(symbol string, history *data.MarketData, lookback int) (LiquidityProfile, bool) {
+	n := len(history.Prices)
+	if len(history.Volumes) < n {
+		n = len(history.Volumes)
+	}
+	if lookback > 0 && n > lookback {
+		n = lookback
+	}
+	if n == 0 {
+		return LiquidityProfile{}, false
+	}
+
+	prices := history.Prices[len(history.Prices)-n:]
+	volumes := history.Volumes[len(history.Volumes)-n:]
+
+	var shares, dollars float64
+	for i := range prices {
+		shares += volumes[i]
+		dollars += volumes[i] * prices[i]
+	}
+
+	return LiquidityProfile{
+		Symbol:          symbol,
+		AvgVolume:       shares / float64(n),
+		AvgDollarVolume: dollars / float64(n),
+		UpdatedAt:       time.Now(),
+	}, true
+}
+
+// SetLiquidityProfile sets the liquidity of a symbol used by the guardrails
+func (g *Generator) SetLiquidityProfile(profile LiquidityProfile) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.liquidity[profile.Symbol] = profile
+}
+
+// GetLiquidityProfile returns the liquidity of a symbol, if known
+func (g *Generator) GetLiquidityProfile(symbol string) (LiquidityProfile, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	profile, ok := g.liquidity[symbol]
+	return profile, ok
+}
+
+// RefreshLiquidity recomputes average volumes for symbols once per day. It does
+// nothing when no liquidity guardrail is configured.
+func (g *Generator) RefreshLiquidity(source DailyHistorySource, symbols []string) error {
+	settings := g.currentConfig().Liquidity
+	if !settings.Enabled() {
+		return nil
+	}
+
+	today := g.now().Truncate(24 * time.Hour)
+	g.mu.RLock()
+	fresh := g.liquidityDay.Equal(today)
+	g.mu.RUnlock()
+	if fresh {
+		return nil
+	}
+
+	lookback := settings.LookbackDays
+	if lookback <= 0 {
+		lookback = defaultLiquidityLookback
+	}
+
+	profiles := make(map[string]LiquidityProfile, len(symbols))
+	for _, symbol := range symbols {
+		history, err := source.GetDailyHistory(symbol, lookback)
+		if err != nil {
+			log.Printf("Error fetching daily history for %s: %v", symbol, err)
+			continue
+		}
+		if profile, ok := ComputeLiquidity(symbol, history, lookback); ok {
+			profiles[symbol] = profile
+		}
+	}
+
+	g.mu.Lock()
+	for symbol, profile := range profiles {
+		g.liquidity[symbol] = profile
+	}
+	g.liquidityDay = today
+	g.mu.Unlock()
+
+	return nil
+}
+
+// applyLiquidity rejects signals on symbols below the dollar volume floor, or
+// downsizes them, and caps their size at a percent of average volume. Symbols
+// without a liquidity profile pass unchanged. It reports false if the signal is rejected.
+func (g *Generator) applyLiquidity(s *Signal) bool {
+	settings := g.currentConfig().Liquidity
+	if !settings.Enabled() {
+		return true
+	}
+
+	profile, ok := g.GetLiquidityProfile(s.Symbol)
+	if !ok {
+		return true
+	}
+
+	scale := 1.0
+	if settings.MinAvgDollarVolume > 0 && profile.AvgDollarVolume < settings.MinAvgDollarVolume {
+		if settings.BelowFloor != config.LiquidityDownsize {
+			log.Printf("Rejected %s signal for %s: average dollar volume $%.0f below floor $%.0f",
+				s.Type, s.Symbol, profile.AvgDollarVolume, settings.MinAvgDollarVolume)
+			return false
+		}
+		scale = profile.AvgDollarVolume / settings.MinAvgDollarVolume
+	}
+
+	if settings.MaxVolumePercent <= 0 {
+		return true
+	}
+
+	s.MaxShares = int(profile.AvgVolume * settings.MaxVolumePercent / 100 * scale)
+	if s.MaxShares <= 0 {
+		log.Printf("Rejected %s signal for %s: liquidity allows no shares", s.Type, s.Symbol)
+		return false
+	}
+
+	return true
+}
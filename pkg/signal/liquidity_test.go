@@ -0,0 +1,68 @@
+package signal
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubHistory serves fixed daily history per symbol
+type stubHistory map[string]*data.MarketData
+
+func (h stubHistory) GetDailyHistory(symbol string, days int) (*data.MarketData, error) {
+	history, ok := h[symbol]
+	if !ok {
+		return nil, fmt.Errorf("no history for %s", symbol)
+	}
+	return history, nil
+}
+
+func TestComputeLiquidity(t *testing.T) {
+	history := &data.MarketData{
+		Prices:  []float64{1, 10, 20},
+		Volumes: []float64{1000000, 100000, 300000},
+	}
+
+	profile, ok := ComputeLiquidity("ABC", history, 2)
+	assert.True(t, ok)
+	assert.Equal(t, 200000.0, profile.AvgVolume)
+	assert.Equal(t, 3500000.0, profile.AvgDollarVolume)
+
+	_, ok = ComputeLiquidity("ABC", &data.MarketData{}, 20)
+	assert.False(t, ok)
+}
+
+func TestApplyLiquidity(t *testing.T) {
+	cfg := config.CreateDefaultConfig()
+	cfg.Liquidity = config.LiquidityConfig{MinAvgDollarVolume: 1000000, BelowFloor: config.LiquidityReject, MaxVolumePercent: 1}
+	g := NewGenerator(cfg)
+
+	source := stubHistory{
+		"LIQ":  {Prices: []float64{50, 50}, Volumes: []float64{200000, 200000}},
+		"THIN": {Prices: []float64{2, 2}, Volumes: []float64{250000, 250000}},
+	}
+	assert.NoError(t, g.RefreshLiquidity(source, []string{"LIQ", "THIN", "MISSING"}))
+
+	// Liquid names are capped at 1% of average volume
+	s := &Signal{Symbol: "LIQ", Type: BUY}
+	assert.True(t, g.applyLiquidity(s))
+	assert.Equal(t, 2000, s.MaxShares)
+
+	// $500k average dollar volume is below the $1M floor
+	assert.False(t, g.applyLiquidity(&Signal{Symbol: "THIN", Type: BUY}))
+
+	// Downsizing halves the cap for a name at half the floor
+	cfg.Liquidity.BelowFloor = config.LiquidityDownsize
+	g.UpdateConfig(cfg)
+	s = &Signal{Symbol: "THIN", Type: BUY}
+	assert.True(t, g.applyLiquidity(s))
+	assert.Equal(t, 1250, s.MaxShares)
+
+	// Symbols without history pass unchanged
+	s = &Signal{Symbol: "MISSING", Type: BUY}
+	assert.True(t, g.applyLiquidity(s))
+	assert.Zero(t, s.MaxShares)
+}
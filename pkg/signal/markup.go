@@ -0,0 +1,64 @@
+package signal
+
+import "strings"
+
+// Markup renders emphasis and escapes text for one Telegram parse mode, so
+// prices, rationales and company names cannot break the message entities
+type Markup interface {
+	ParseMode() string // Telegram parse_mode, empty for plain text
+	Escape(text string) string
+	Bold(text string) string   // Escapes text
+	Italic(text string) string // Escapes text
+}
+
+// Markups for the Telegram parse modes
+var (
+	HTML       Markup = htmlMarkup{}
+	MarkdownV2 Markup = markdownV2Markup{}
+	Plain      Markup = plainMarkup{}
+)
+
+// MarkupFor returns the markup for a channel's parse mode: HTML (the default),
+// MarkdownV2 or plain
+func MarkupFor(parseMode string) Markup {
+	switch strings.ToLower(parseMode) {
+	case "markdownv2":
+		return MarkdownV2
+	case "plain":
+		return Plain
+	default:
+		return HTML
+	}
+}
+
+// htmlEscaper escapes the characters Telegram's HTML parse mode reserves
+var htmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+type htmlMarkup struct{}
+
+func (htmlMarkup) ParseMode() string           { return "HTML" }
+func (htmlMarkup) Escape(text string) string   { return htmlEscaper.Replace(text) }
+func (m htmlMarkup) Bold(text string) string   { return "<b>" + m.Escape(text) + "</b>" }
+func (m htmlMarkup) Italic(text string) string { return "<i>" + m.Escape(text) + "</i>" }
+
+// markdownV2Escaper escapes every character MarkdownV2 reserves, which
+// includes the dots, dashes and plus signs of prices and percentages
+var markdownV2Escaper = strings.NewReplacer(
+	`\`, `\\`, "_", `\_`, "*", `\*`, "[", `\[`, "]", `\]`, "(", `\(`, ")", `\)`,
+	"~", `\~`, "`", "\\`", ">", `\>`, "#", `\#`, "+", `\+`, "-", `\-`, "=", `\=`,
+	"|", `\|`, "{", `\{`, "}", `\}`, ".", `\.`, "!", `\!`,
+)
+
+type markdownV2Markup struct{}
+
+func (markdownV2Markup) ParseMode() string           { return "MarkdownV2" }
+func (markdownV2Markup) Escape(text string) string   { return markdownV2Escaper.Replace(text) }
+func (m markdownV2Markup) Bold(text string) string   { return "*" + m.Escape(text) + "*" }
+func (m markdownV2Markup) Italic(text string) string { return "_" + m.Escape(text) + "_" }
+
+type plainMarkup struct{}
+
+func (plainMarkup) ParseMode() string         { return "" }
+func (plainMarkup) Escape(text string) string { return text }
+func (plainMarkup) Bold(text string) string   { return text }
+func (plainMarkup) Italic(text string) string { return text }
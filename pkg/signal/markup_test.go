@@ -0,0 +1,39 @@
+package signal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatChannelSignalMessageMarkups(t *testing.T) {
+	s := &Signal{Symbol: "BRK.B", Type: BUY, Price: 410.5, TargetPrice: 420, StopLoss: 405, ExpectedROI: 2.31,
+		Confidence: 0.8, TimeFrame: "1-3 days", Rationale: "Holds the 50-day <EMA> & volume [surge]",
+		GeneratedAt: time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)}
+
+	html := FormatChannelSignalMessage(s, nil, HTML)
+	assert.Contains(t, html, "🚨 <b>BUY SIGNAL: BRK.B</b> 🚨")
+	assert.Contains(t, html, "Holds the 50-day &lt;EMA&gt; &amp; volume [surge]")
+
+	markdown := FormatChannelSignalMessage(s, nil, MarkdownV2)
+	assert.Contains(t, markdown, `🚨 *BUY SIGNAL: BRK\.B* 🚨`)
+	assert.Contains(t, markdown, `📈 *Expected ROI:* \+2\.31%`)
+	assert.Contains(t, markdown, `Holds the 50\-day <EMA\> & volume \[surge\]`)
+	assert.Contains(t, markdown, `⏰ Generated at: 2026\-10\-16 09:30:00`)
+
+	plain := FormatChannelSignalMessage(s, []string{"rationale"}, Plain)
+	assert.Contains(t, plain, "💰 Entry Price: $410.50")
+	assert.NotContains(t, plain, "<b>")
+	assert.NotContains(t, plain, "Holds")
+
+	s.Status = StatusSuccess
+	assert.Equal(t, `✅ *TARGET HIT* at $420\.00 \(\+2\.31%\)`, FormatChannelSignalOutcome(s, 420, MarkdownV2))
+}
+
+func TestMarkupFor(t *testing.T) {
+	assert.Equal(t, HTML, MarkupFor(""))
+	assert.Equal(t, MarkdownV2, MarkupFor("MarkdownV2"))
+	assert.Equal(t, Plain, MarkupFor("plain"))
+	assert.Equal(t, "", Plain.ParseMode())
+}
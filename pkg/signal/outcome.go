@@ -0,0 +1,62 @@
+package signal
+
+import (
+	"fmt"
+	"time"
+)
+
+// ResolveOutcome returns the final status of an active signal at price: SUCCESS if
+// the target was reached, FAILURE if the stop was hit, EXPIRED once the signal is
+// older than expiry, or an empty string while it is still active
+func ResolveOutcome(s *Signal, price float64, now time.Time, expiry time.Duration) Status {
+	if s.Status != StatusActive {
+		return ""
+	}
+
+	if s.Type == BUY {
+		if price >= s.TargetPrice {
+			return StatusSuccess
+		}
+		if price <= s.StopLoss {
+			return StatusFailure
+		}
+	} else {
+		if price <= s.TargetPrice {
+			return StatusSuccess
+		}
+		if price >= s.StopLoss {
+			return StatusFailure
+		}
+	}
+
+	if expiry > 0 && now.Sub(s.GeneratedAt) > expiry {
+		return StatusExpired
+	}
+
+	return ""
+}
+
+// FormatSignalOutcome formats the final outcome of a signal for Telegram
+func FormatSignalOutcome(s *Signal, exitPrice float64) string {
+	return FormatChannelSignalOutcome(s, exitPrice, HTML)
+}
+
+// FormatChannelSignalOutcome formats the final outcome of a signal for a
+// channel with the given markup
+func FormatChannelSignalOutcome(s *Signal, exitPrice float64, m Markup) string {
+	roi := calculateExpectedROI(s.Price, exitPrice, s.Type)
+
+	var headline string
+	switch s.Status {
+	case StatusSuccess:
+		headline = "✅ " + m.Bold("TARGET HIT")
+	case StatusFailure:
+		headline = "❌ " + m.Bold("STOPPED OUT")
+	case StatusExpired:
+		headline = "⌛ " + m.Bold("EXPIRED")
+	default:
+		headline = "ℹ️ " + m.Bold(string(s.Status))
+	}
+
+	return headline + m.Escape(fmt.Sprintf(" at $%.2f (%+.2f%%)", exitPrice, roi))
+}
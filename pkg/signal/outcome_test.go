@@ -0,0 +1,26 @@
+package signal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveOutcome(t *testing.T) {
+	generatedAt := time.Date(2025, 4, 21, 10, 0, 0, 0, time.UTC)
+	buy := &Signal{Type: BUY, Price: 100, TargetPrice: 105, StopLoss: 97, GeneratedAt: generatedAt, Status: StatusActive}
+	sell := &Signal{Type: SELL, Price: 100, TargetPrice: 95, StopLoss: 103, GeneratedAt: generatedAt, Status: StatusActive}
+	now := generatedAt.Add(time.Hour)
+
+	assert.Empty(t, ResolveOutcome(buy, 101, now, 3*time.Hour))
+	assert.Equal(t, StatusSuccess, ResolveOutcome(buy, 105.5, now, 3*time.Hour))
+	assert.Equal(t, StatusFailure, ResolveOutcome(buy, 96, now, 3*time.Hour))
+	assert.Equal(t, StatusSuccess, ResolveOutcome(sell, 94, now, 3*time.Hour))
+	assert.Equal(t, StatusFailure, ResolveOutcome(sell, 104, now, 3*time.Hour))
+	assert.Equal(t, StatusExpired, ResolveOutcome(buy, 101, generatedAt.Add(4*time.Hour), 3*time.Hour))
+
+	buy.Status = StatusSuccess
+	assert.Empty(t, ResolveOutcome(buy, 96, now, 3*time.Hour))
+	assert.Equal(t, "✅ <b>TARGET HIT</b> at $105.00 (+5.00%)", FormatSignalOutcome(buy, 105))
+}
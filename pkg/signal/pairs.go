@@ -0,0 +1,305 @@
+package signal
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/ids"
+)
+
+// Leg is one side of a pair signal
+type Leg struct {
+	Symbol string     `json:"symbol"`
+	Type   SignalType `json:"type"`  // BUY for the long leg, SELL for the short leg
+	Price  float64    `json:"price"` // Price when the signal was generated
+	Ratio  float64    `json:"ratio"` // Shares traded per share of the first leg
+}
+
+// PairSignal goes long the spread between two symbols (BUY: long A, short B)
+// or short it (SELL: short A, long B), in the hedge ratio that keeps the
+// spread mean-reverting. It closes when the spread reverts or blows out.
+type PairSignal struct {
+	ID          string     `json:"id"`
+	Pair        string     `json:"pair"` // A/B
+	Type        SignalType `json:"type"`
+	Legs        []Leg      `json:"legs"`
+	HedgeRatio  float64    `json:"hedge_ratio"` // Shares of B per share of A
+	Intercept   float64    `json:"intercept"`
+	SpreadStd   float64    `json:"spread_std"`
+	ZScore      float64    `json:"z_score"` // Spread z-score when the signal was generated
+	ExitZScore  float64    `json:"exit_z_score"`
+	StopZScore  float64    `json:"stop_z_score"`
+	ADFStat     float64    `json:"adf_stat"`
+	Confidence  float64    `json:"confidence"`
+	Strategy    string     `json:"strategy"`
+	Status      Status     `json:"status"`
+	GeneratedAt time.Time  `json:"generated_at"`
+	ClosedAt    time.Time  `json:"closed_at,omitempty"`
+	ROI         float64    `json:"roi"` // Combined ROI of both legs once closed
+}
+
+// ZScoreAt returns the z-score of the spread at the given prices of A and B
+func (p *PairSignal) ZScoreAt(priceA, priceB float64) float64 {
+	if p.SpreadStd == 0 {
+		return 0
+	}
+	return (priceA - p.HedgeRatio*priceB - p.Intercept) / p.SpreadStd
+}
+
+// ROIAt returns the combined percent return of both legs at prices keyed by
+// symbol: the P&L of the long and short legs over the capital both committed
+func (p *PairSignal) ROIAt(prices map[string]float64) float64 {
+	var pnl, gross float64
+	for _, leg := range p.Legs {
+		price, ok := prices[leg.Symbol]
+		if !ok {
+			price = leg.Price
+		}
+		change := price - leg.Price
+		if leg.Type == SELL {
+			change = -change
+		}
+		pnl += change * leg.Ratio
+		gross += leg.Price * leg.Ratio
+	}
+	if gross == 0 {
+		return 0
+	}
+	return pnl / gross * 100
+}
+
+// Resolve returns the final status of an active pair signal at the prices of
+// A and B: SUCCESS once the spread reverts inside the exit z-score, FAILURE
+// once it moves past the stop, or an empty string while it is still active
+func (p *PairSignal) Resolve(priceA, priceB float64) Status {
+	if p.Status != StatusActive {
+		return ""
+	}
+	z := p.ZScoreAt(priceA, priceB)
+	if p.Type == SELL {
+		z = -z
+	}
+	switch {
+	case z >= -p.ExitZScore:
+		return StatusSuccess
+	case z <= -p.StopZScore:
+		return StatusFailure
+	}
+	return ""
+}
+
+// PairVote is a pair strategy's view on a pair. BUY goes long the spread,
+// SELL short it, and HOLD abstains.
+type PairVote struct {
+	Type         SignalType `json:"type"`
+	HedgeRatio   float64    `json:"hedge_ratio"`
+	Intercept    float64    `json:"intercept"`
+	SpreadStd    float64    `json:"spread_std"`
+	ZScore       float64    `json:"z_score"`
+	ADFStat      float64    `json:"adf_stat"`
+	Cointegrated bool       `json:"cointegrated"`
+	Confidence   float64    `json:"confidence"`
+}
+
+// PairStrategy votes on a pair from the aligned closing prices of its symbols
+type PairStrategy interface {
+	Name() string
+	Vote(a, b []float64, cfg config.PairsConfig) PairVote
+}
+
+// NewPairStrategy returns the built-in pair strategy with the given name
+func NewPairStrategy(name string) (PairStrategy, error) {
+	switch name {
+	case "cointegration":
+		return cointegrationStrategy{}, nil
+	default:
+		return nil, fmt.Errorf("unknown pair strategy %q", name)
+	}
+}
+
+// SetPairStrategy sets the strategy configured pairs are traded with. nil
+// disables pair signals.
+func (g *Generator) SetPairStrategy(strategy PairStrategy) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.pairStrategy = strategy
+}
+
+// PairSymbols returns the symbols of the configured pairs, or none while pair
+// trading is disabled
+func (g *Generator) PairSymbols() []string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if g.pairStrategy == nil || !g.config.Pairs.Enabled {
+		return nil
+	}
+	symbols := make([]string, 0, 2*len(g.config.Pairs.Pairs))
+	for _, pair := range g.config.Pairs.Pairs {
+		symbols = append(symbols, pair.A, pair.B)
+	}
+	return symbols
+}
+
+// GeneratePairSignals votes on every configured pair with data for both
+// symbols and returns a signal for each pair the strategy wants to trade
+func (g *Generator) GeneratePairSignals(marketData map[string]MarketData) []*PairSignal {
+	g.mu.RLock()
+	strategy := g.pairStrategy
+	g.mu.RUnlock()
+
+	cfg := g.currentConfig().Pairs
+	if strategy == nil || !cfg.Enabled {
+		return nil
+	}
+
+	var signals []*PairSignal
+	for _, pair := range cfg.Pairs {
+		a, okA := marketData[pair.A]
+		b, okB := marketData[pair.B]
+		if !okA || !okB || a.Halted || b.Halted {
+			continue
+		}
+		n := min(len(a.Prices), len(b.Prices), cfg.LookbackBars)
+		if n < cfg.LookbackBars {
+			continue
+		}
+
+		vote := strategy.Vote(a.Prices[len(a.Prices)-n:], b.Prices[len(b.Prices)-n:], cfg)
+		if vote.Type == HOLD {
+			continue
+		}
+		signals = append(signals, g.pairSignal(pair, vote, a.Prices[len(a.Prices)-1], b.Prices[len(b.Prices)-1], strategy.Name()))
+	}
+	return signals
+}
+
+// pairSignal builds the signal for a pair vote at the latest prices of A and B
+func (g *Generator) pairSignal(pair config.PairConfig, vote PairVote, priceA, priceB float64, strategy string) *PairSignal {
+	legA, legB := BUY, SELL
+	if vote.Type == SELL {
+		legA, legB = SELL, BUY
+	}
+
+	now := g.now()
+	cfg := g.currentConfig().Pairs
+	return &PairSignal{
+		ID:   ids.New(fmt.Sprintf("PAIR-%s-%s-%s", pair.A, pair.B, vote.Type), now),
+		Pair: pair.A + "/" + pair.B,
+		Type: vote.Type,
+		Legs: []Leg{
+			{Symbol: pair.A, Type: legA, Price: priceA, Ratio: 1},
+			{Symbol: pair.B, Type: legB, Price: priceB, Ratio: vote.HedgeRatio},
+		},
+		HedgeRatio:  vote.HedgeRatio,
+		Intercept:   vote.Intercept,
+		SpreadStd:   vote.SpreadStd,
+		ZScore:      vote.ZScore,
+		ExitZScore:  cfg.ExitZScore,
+		StopZScore:  cfg.StopZScore,
+		ADFStat:     vote.ADFStat,
+		Confidence:  vote.Confidence,
+		Strategy:    strategy,
+		Status:      StatusActive,
+		GeneratedAt: now,
+	}
+}
+
+// cointegrationStrategy is the Engle-Granger pairs strategy: it regresses A on
+// B for the hedge ratio, checks the residual spread is stationary with a
+// Dickey-Fuller test and trades the spread back toward its mean when it
+// stretches past the entry z-score
+type cointegrationStrategy struct{}
+
+func (cointegrationStrategy) Name() string { return "cointegration" }
+
+func (cointegrationStrategy) Vote(a, b []float64, cfg config.PairsConfig) PairVote {
+	vote := PairVote{Type: HOLD}
+	if len(a) != len(b) || len(a) < 3 {
+		return vote
+	}
+
+	vote.HedgeRatio, vote.Intercept = regress(a, b)
+	if vote.HedgeRatio <= 0 {
+		return vote // Not a long/short pair
+	}
+
+	spread := make([]float64, len(a))
+	var squares float64
+	for i := range a {
+		spread[i] = a[i] - vote.HedgeRatio*b[i] - vote.Intercept
+		squares += spread[i] * spread[i]
+	}
+	vote.SpreadStd = math.Sqrt(squares / float64(len(spread)))
+	if vote.SpreadStd == 0 {
+		return vote
+	}
+	vote.ZScore = spread[len(spread)-1] / vote.SpreadStd
+	vote.ADFStat = dickeyFuller(spread)
+	vote.Cointegrated = vote.ADFStat < cfg.MaxADFStat
+	if !vote.Cointegrated {
+		return vote
+	}
+
+	// A spread already past the stop has broken down rather than stretched
+	stretch := math.Abs(vote.ZScore)
+	if stretch < cfg.EntryZScore || stretch >= cfg.StopZScore {
+		return vote
+	}
+	vote.Type = BUY // A is cheap against B
+	if vote.ZScore > 0 {
+		vote.Type = SELL
+	}
+	vote.Confidence = math.Min(0.5+0.5*(stretch-cfg.EntryZScore)/(cfg.StopZScore-cfg.EntryZScore), 1)
+	return vote
+}
+
+// regress returns the slope and intercept of the least-squares fit of y on x
+func regress(y, x []float64) (slope, intercept float64) {
+	var meanX, meanY float64
+	for i := range x {
+		meanX += x[i]
+		meanY += y[i]
+	}
+	meanX /= float64(len(x))
+	meanY /= float64(len(y))
+
+	var cov, variance float64
+	for i := range x {
+		cov += (x[i] - meanX) * (y[i] - meanY)
+		variance += (x[i] - meanX) * (x[i] - meanX)
+	}
+	if variance == 0 {
+		return 0, meanY
+	}
+	slope = cov / variance
+	return slope, meanY - slope*meanX
+}
+
+// dickeyFuller returns the Dickey-Fuller t-statistic of a series with zero
+// mean: the more negative, the more strongly it reverts to the mean
+func dickeyFuller(series []float64) float64 {
+	var lagSquares, cross float64
+	for i := 1; i < len(series); i++ {
+		lagSquares += series[i-1] * series[i-1]
+		cross += series[i-1] * (series[i] - series[i-1])
+	}
+	if lagSquares == 0 {
+		return 0
+	}
+	gamma := cross / lagSquares
+
+	var residuals float64
+	for i := 1; i < len(series); i++ {
+		u := series[i] - series[i-1] - gamma*series[i-1]
+		residuals += u * u
+	}
+	variance := residuals / float64(len(series)-2)
+	if variance == 0 {
+		return math.Inf(-1)
+	}
+	return gamma / math.Sqrt(variance/lagSquares)
+}
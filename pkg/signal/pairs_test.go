@@ -0,0 +1,75 @@
+package signal
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// cointegratedPair returns n prices of B drifting in a wave and of A tracking
+// twice B plus alternating noise, with A's last price stretched by last
+func cointegratedPair(n int, last float64) (a, b []float64) {
+	for i := 0; i < n; i++ {
+		pb := 50 + 5*math.Sin(float64(i)/7) + 0.05*float64(i)
+		noise := 0.3
+		if i%2 == 1 {
+			noise = -0.3
+		}
+		if i == n-1 {
+			noise = last
+		}
+		a = append(a, 10+2*pb+noise)
+		b = append(b, pb)
+	}
+	return a, b
+}
+
+func TestCointegrationStrategyTradesStretchedSpread(t *testing.T) {
+	cfg := config.CreateDefaultConfig()
+	cfg.Pairs.Enabled = true
+	cfg.Pairs.Pairs = []config.PairConfig{{A: "KO", B: "PEP"}}
+
+	strategy, err := NewPairStrategy("cointegration")
+	assert.NoError(t, err)
+	_, err = NewPairStrategy("momentum")
+	assert.Error(t, err)
+
+	generator := NewGenerator(cfg)
+	assert.Empty(t, generator.PairSymbols())
+	generator.SetPairStrategy(strategy)
+	assert.Equal(t, []string{"KO", "PEP"}, generator.PairSymbols())
+
+	// A cheap against PEP: long KO, short PEP
+	a, b := cointegratedPair(cfg.Pairs.LookbackBars, -0.9)
+	signals := generator.GeneratePairSignals(map[string]MarketData{
+		"KO":  {Symbol: "KO", Prices: a},
+		"PEP": {Symbol: "PEP", Prices: b},
+	})
+	assert.Len(t, signals, 1)
+	p := signals[0]
+	assert.Equal(t, BUY, p.Type)
+	assert.Equal(t, "KO/PEP", p.Pair)
+	assert.InDelta(t, 2, p.HedgeRatio, 0.05)
+	assert.Less(t, p.ADFStat, cfg.Pairs.MaxADFStat)
+	assert.Equal(t, BUY, p.Legs[0].Type)
+	assert.Equal(t, SELL, p.Legs[1].Type)
+
+	// Still stretched, then reverted
+	priceB := b[len(b)-1]
+	assert.Empty(t, p.Resolve(a[len(a)-1], priceB))
+	assert.Equal(t, StatusSuccess, p.Resolve(a[len(a)-1]+0.8, priceB))
+	assert.Equal(t, StatusFailure, p.Resolve(a[len(a)-1]-1, priceB))
+
+	// KO up 1% with PEP flat gains on the long leg only
+	roi := p.ROIAt(map[string]float64{"KO": a[len(a)-1] * 1.01, "PEP": priceB})
+	assert.InDelta(t, a[len(a)-1]*0.01/(a[len(a)-1]+p.HedgeRatio*priceB)*100, roi, 1e-9)
+
+	// Within the usual range there is nothing to trade
+	a, b = cointegratedPair(cfg.Pairs.LookbackBars, 0.3)
+	assert.Empty(t, generator.GeneratePairSignals(map[string]MarketData{
+		"KO":  {Symbol: "KO", Prices: a},
+		"PEP": {Symbol: "PEP", Prices: b},
+	}))
+}
@@ -0,0 +1,37 @@
+package signal
+
+import "github.com/hustler/trading-bot/pkg/config"
+
+// Parameter sets signals are generated with besides the per-regime sets,
+// which are named after their regime
+const (
+	ParamSetDefault = "default" // The global volatility_params
+	ParamSetTuned   = "tuned"   // Parameters tuned for the symbol
+)
+
+// SymbolParams supplies parameters tuned for individual symbols (implemented by tuning.Registry)
+type SymbolParams interface {
+	ParamsFor(symbol string, defaults config.VolatilityConfig) (config.VolatilityConfig, bool)
+}
+
+// SetSymbolParams sets the per-symbol parameters that replace the regime or
+// global parameters for the symbols they cover
+func (g *Generator) SetSymbolParams(params SymbolParams) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.symbolParams = params
+}
+
+// paramsFor returns the parameters tuned for a symbol, decayed toward
+// defaults, and whether any apply
+func (g *Generator) paramsFor(symbol string, defaults config.VolatilityConfig) (config.VolatilityConfig, bool) {
+	g.mu.RLock()
+	source := g.symbolParams
+	g.mu.RUnlock()
+
+	if source == nil {
+		return defaults, false
+	}
+	return source.ParamsFor(symbol, defaults)
+}
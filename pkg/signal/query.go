@@ -0,0 +1,85 @@
+package signal
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultQueryLimit is the page size when a query sets none
+	DefaultQueryLimit = 50
+	// MaxQueryLimit is the largest page a query may request
+	MaxQueryLimit = 500
+)
+
+// Sort fields a query may order by
+const (
+	SortGeneratedAt = "generated_at"
+	SortConfidence  = "confidence"
+	SortExpectedROI = "expected_roi"
+	SortSymbol      = "symbol"
+)
+
+// Query filters, sorts and pages stored signals. Zero values do not filter.
+type Query struct {
+	Symbol        string
+	Type          SignalType
+	Status        Status
+	MinConfidence float64
+	From          time.Time // Generated at or after
+	To            time.Time // Generated before
+	Strategy      string    // Ensemble strategy that voted for the signal
+	SortBy        string    // One of the Sort fields; defaults to generated_at
+	Ascending     bool      // Defaults to descending, newest or highest first
+	Limit         int
+	Offset        int
+}
+
+// Page is one page of signals matching a query
+type Page struct {
+	Signals []*Signal `json:"signals"`
+	Total   int       `json:"total"` // Matches across all pages
+	Limit   int       `json:"limit"`
+	Offset  int       `json:"offset"`
+}
+
+// Normalize validates the query and fills in the default sort and page size
+func (q *Query) Normalize() error {
+	switch q.SortBy {
+	case "":
+		q.SortBy = SortGeneratedAt
+	case SortGeneratedAt, SortConfidence, SortExpectedROI, SortSymbol:
+	default:
+		return fmt.Errorf("invalid sort field: %s", q.SortBy)
+	}
+
+	switch q.Type {
+	case "", BUY, SELL, HOLD:
+	default:
+		return fmt.Errorf("invalid signal type: %s", q.Type)
+	}
+
+	switch q.Status {
+	case "", StatusActive, StatusSuccess, StatusFailure, StatusExpired:
+	default:
+		return fmt.Errorf("invalid signal status: %s", q.Status)
+	}
+
+	if q.MinConfidence < 0 || q.MinConfidence > 1 {
+		return fmt.Errorf("min confidence must be between 0 and 1")
+	}
+	if !q.From.IsZero() && !q.To.IsZero() && !q.To.After(q.From) {
+		return fmt.Errorf("date range end must be after its start")
+	}
+
+	if q.Limit < 0 || q.Offset < 0 {
+		return fmt.Errorf("limit and offset must not be negative")
+	}
+	if q.Limit == 0 {
+		q.Limit = DefaultQueryLimit
+	}
+	if q.Limit > MaxQueryLimit {
+		q.Limit = MaxQueryLimit
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package signal
+
+import (
+	"math"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+)
+
+// Revision actions taken when the market moved before a signal was published
+const (
+	// RevisionAdjusted means the levels were moved to the latest price
+	RevisionAdjusted = "adjusted"
+	// RevisionDropped means the signal was not published
+	RevisionDropped = "dropped"
+)
+
+// Revision records a signal's levels before the market moved them at publish time
+type Revision struct {
+	At          time.Time `json:"at"`
+	MarketPrice float64   `json:"market_price"` // Latest price the signal was re-validated against
+	Price       float64   `json:"price"`        // Entry before the revision
+	TargetPrice float64   `json:"target_price"`
+	StopLoss    float64   `json:"stop_loss"`
+	Action      string    `json:"action"` // RevisionAdjusted or RevisionDropped
+}
+
+// Revise re-validates a signal against the latest price before it is
+// published. Once the price drifted more than the configured percentage from
+// the entry, the signal is dropped if the price already passed its target or
+// stop or drifted past the drop threshold; otherwise entry, target and stop
+// move by the drift, keeping its risk and reward. Each revision is appended
+// to the signal's chain. Revise returns the action taken, or "" if the
+// signal still stands.
+func Revise(s *Signal, latest float64, cfg config.SignalRevisionConfig, at time.Time) string {
+	if cfg.MaxDriftPercent <= 0 || latest <= 0 || s.Price <= 0 {
+		return ""
+	}
+	drift := math.Abs(latest-s.Price) / s.Price * 100
+	if drift <= cfg.MaxDriftPercent {
+		return ""
+	}
+
+	revision := Revision{At: at, MarketPrice: latest, Price: s.Price, TargetPrice: s.TargetPrice, StopLoss: s.StopLoss}
+	passed := (s.Type == BUY && (latest >= s.TargetPrice || latest <= s.StopLoss)) ||
+		(s.Type == SELL && (latest <= s.TargetPrice || latest >= s.StopLoss))
+	if passed || (cfg.DropPercent > 0 && drift > cfg.DropPercent) {
+		revision.Action = RevisionDropped
+	} else {
+		revision.Action = RevisionAdjusted
+		offset := latest - s.Price
+		s.Price = latest
+		s.TargetPrice += offset
+		s.StopLoss += offset
+		s.ExpectedROI = calculateExpectedROI(s.Price, s.TargetPrice, s.Type)
+	}
+	s.Revisions = append(s.Revisions, revision)
+	return revision.Action
+}
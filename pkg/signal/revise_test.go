@@ -0,0 +1,39 @@
+package signal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRevise(t *testing.T) {
+	at := time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC)
+	cfg := config.SignalRevisionConfig{MaxDriftPercent: 0.5, DropPercent: 2}
+
+	// Within the drift allowance the signal stands
+	s := &Signal{Type: BUY, Price: 100, TargetPrice: 105, StopLoss: 97}
+	assert.Equal(t, "", Revise(s, 100.4, cfg, at))
+	assert.Empty(t, s.Revisions)
+
+	// A larger move shifts the levels, keeping risk and reward
+	assert.Equal(t, RevisionAdjusted, Revise(s, 101, cfg, at))
+	assert.Equal(t, 101.0, s.Price)
+	assert.Equal(t, 106.0, s.TargetPrice)
+	assert.Equal(t, 98.0, s.StopLoss)
+	assert.InDelta(t, 4.95, s.ExpectedROI, 0.01)
+	assert.Equal(t, []Revision{{At: at, MarketPrice: 101, Price: 100, TargetPrice: 105, StopLoss: 97, Action: RevisionAdjusted}}, s.Revisions)
+
+	// Past the drop threshold the signal is dropped with its levels unchanged
+	assert.Equal(t, RevisionDropped, Revise(s, 98.5, cfg, at))
+	assert.Equal(t, 101.0, s.Price)
+	assert.Len(t, s.Revisions, 2)
+
+	// A short whose price already fell through its target is dropped
+	short := &Signal{Type: SELL, Price: 50, TargetPrice: 49.6, StopLoss: 51}
+	assert.Equal(t, RevisionDropped, Revise(short, 49.5, config.SignalRevisionConfig{MaxDriftPercent: 0.5}, at))
+
+	// Disabled without a drift allowance
+	assert.Equal(t, "", Revise(&Signal{Type: BUY, Price: 100}, 120, config.SignalRevisionConfig{}, at))
+}
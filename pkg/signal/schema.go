@@ -0,0 +1,130 @@
+package signal
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// CurrentSchemaVersion is the version of the Signal layout written by this
+// build. Bump it and register a converter whenever a stored field changes.
+//
+//	1: the layout before versioning (payloads without schema_version)
+//	2: adds currency and order_type
+const CurrentSchemaVersion = 2
+
+// Order types a signal can be entered with
+const (
+	OrderMarket = "market"
+	OrderLimit  = "limit"
+)
+
+// converters upgrade a stored payload from the version they are keyed by to
+// the next version
+var converters = map[int]func(doc map[string]json.RawMessage) error{
+	1: upgradeV1,
+}
+
+// upgradeV1 fills in the currency and order type, which version 1 signals did
+// not record. They were all market orders quoted in the listing currency.
+func upgradeV1(doc map[string]json.RawMessage) error {
+	var symbol string
+	if raw, ok := doc["symbol"]; ok {
+		if err := json.Unmarshal(raw, &symbol); err != nil {
+			return fmt.Errorf("failed to decode symbol: %w", err)
+		}
+	}
+	setDefault(doc, "currency", CurrencyFor(symbol))
+	setDefault(doc, "order_type", OrderMarket)
+	return nil
+}
+
+// setDefault sets a field of doc unless it already has a value
+func setDefault(doc map[string]json.RawMessage, key, value string) {
+	if raw, ok := doc[key]; ok && string(raw) != "null" && string(raw) != `""` {
+		return
+	}
+	encoded, _ := json.Marshal(value)
+	doc[key] = encoded
+}
+
+// CurrencyFor returns the currency a symbol is quoted in: CAD for TSX and
+// TSX Venture listings, USD otherwise
+func CurrencyFor(symbol string) string {
+	symbol = strings.ToUpper(symbol)
+	if strings.HasSuffix(symbol, ".TO") || strings.HasSuffix(symbol, ".V") {
+		return "CAD"
+	}
+	return "USD"
+}
+
+// signalFields has the fields of Signal without its JSON methods
+type signalFields Signal
+
+// MarshalJSON encodes the signal stamped with the current schema version
+func (s Signal) MarshalJSON() ([]byte, error) {
+	s.SchemaVersion = CurrentSchemaVersion
+	return json.Marshal(signalFields(s))
+}
+
+// UnmarshalJSON decodes a signal written by any schema version, upgrading
+// older payloads to the current version. Payloads from a newer version are
+// decoded as far as this build understands them.
+func (s *Signal) UnmarshalJSON(data []byte) error {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to decode signal: %w", err)
+	}
+	if doc == nil {
+		return nil
+	}
+
+	version := 1
+	if raw, ok := doc["schema_version"]; ok {
+		if err := json.Unmarshal(raw, &version); err != nil {
+			return fmt.Errorf("failed to decode signal schema version: %w", err)
+		}
+		if version < 1 {
+			version = 1
+		}
+	}
+
+	for ; version < CurrentSchemaVersion; version++ {
+		convert, ok := converters[version]
+		if !ok {
+			return fmt.Errorf("no converter for signal schema version %d", version)
+		}
+		if err := convert(doc); err != nil {
+			return fmt.Errorf("failed to upgrade signal from schema version %d: %w", version, err)
+		}
+	}
+
+	upgraded, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("failed to encode upgraded signal: %w", err)
+	}
+	var fields signalFields
+	if err := json.Unmarshal(upgraded, &fields); err != nil {
+		return fmt.Errorf("failed to decode signal: %w", err)
+	}
+	fields.SchemaVersion = version
+	*s = Signal(fields)
+	return nil
+}
+
+// Upgrade brings a signal loaded at an older schema version, e.g. from
+// database columns, up to the current version
+func Upgrade(s *Signal) error {
+	if s.SchemaVersion >= CurrentSchemaVersion {
+		return nil
+	}
+	if s.SchemaVersion < 1 {
+		s.SchemaVersion = 1
+	}
+
+	data, err := json.Marshal(signalFields(*s))
+	if err != nil {
+		return fmt.Errorf("failed to encode signal: %w", err)
+	}
+	return s.UnmarshalJSON(data)
+}
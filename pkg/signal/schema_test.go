@@ -0,0 +1,46 @@
+package signal
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshalUpgradesLegacySignals(t *testing.T) {
+	// Written before signals carried a schema version
+	legacy := `{"id":"SIG-1","symbol":"TD.TO","type":"BUY","price":80,"status":"ACTIVE","strategies":["momentum"]}`
+
+	var s Signal
+	assert.NoError(t, json.Unmarshal([]byte(legacy), &s))
+	assert.Equal(t, CurrentSchemaVersion, s.SchemaVersion)
+	assert.Equal(t, "SIG-1", s.ID)
+	assert.Equal(t, BUY, s.Type)
+	assert.Equal(t, []string{"momentum"}, s.Strategies)
+	assert.Equal(t, "CAD", s.Currency)
+	assert.Equal(t, OrderMarket, s.OrderType)
+
+	// Current payloads keep their values and round trip unchanged
+	s.OrderType = OrderLimit
+	encoded, err := json.Marshal(s)
+	assert.NoError(t, err)
+	assert.Contains(t, string(encoded), `"schema_version":2`)
+
+	var decoded Signal
+	assert.NoError(t, json.Unmarshal(encoded, &decoded))
+	assert.Equal(t, s, decoded)
+
+	// Fields a newer build added are ignored rather than rejected
+	var newer Signal
+	assert.NoError(t, json.Unmarshal([]byte(`{"schema_version":9,"symbol":"AAPL","venue":"XNAS"}`), &newer))
+	assert.Equal(t, 9, newer.SchemaVersion)
+	assert.Equal(t, "AAPL", newer.Symbol)
+}
+
+func TestUpgradeFillsFieldsForOldRows(t *testing.T) {
+	s := &Signal{SchemaVersion: 1, Symbol: "AAPL", Status: StatusActive}
+	assert.NoError(t, Upgrade(s))
+	assert.Equal(t, CurrentSchemaVersion, s.SchemaVersion)
+	assert.Equal(t, "USD", s.Currency)
+	assert.Equal(t, OrderMarket, s.OrderType)
+}
@@ -0,0 +1,157 @@
+package signal
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/script"
+)
+
+// Technical score components, in the order they are scored
+const (
+	ScoreBollinger       = "bollinger"
+	ScoreRSI             = "rsi"
+	ScoreVolume          = "volume"
+	ScorePriceChange     = "price_change"
+	ScoreSqueezeBreakout = "squeeze_breakout"
+	ScoreScript          = "script" // What a score_expression changed the weighted score by
+)
+
+// scoreLabels name the components in messages
+var scoreLabels = map[string]string{
+	ScoreBollinger:       "Bollinger",
+	ScoreRSI:             "RSI",
+	ScoreVolume:          "Volume",
+	ScorePriceChange:     "Price change",
+	ScoreSqueezeBreakout: "Squeeze breakout",
+	ScoreScript:          "Script",
+}
+
+// ScoreComponent is what one component of the technical score added to it
+type ScoreComponent struct {
+	Name   string  `json:"name"`
+	Value  float64 `json:"value"`  // Indicator value the component was judged on; the scripted score for the script
+	Weight float64 `json:"weight"` // The most the component can add; 0 for the script
+	Points float64 `json:"points"` // What it added; the points of all components sum to the score
+}
+
+// scoreScript is a compiled score_expression
+type scoreScript struct {
+	program *script.Program
+	err     error
+	warned  sync.Once
+}
+
+// scoreScripts caches compiled score expressions by source
+var scoreScripts sync.Map
+
+// compiledScoreScript returns the compiled score expression
+func compiledScoreScript(source string) *scoreScript {
+	if cached, ok := scoreScripts.Load(source); ok {
+		return cached.(*scoreScript)
+	}
+	program, err := script.Compile(source)
+	cached, _ := scoreScripts.LoadOrStore(source, &scoreScript{program: program, err: err})
+	return cached.(*scoreScript)
+}
+
+// scoreBreakdown scores each component of the technical score with the
+// weights of params. The squeeze breakout bonus only adds what keeps the
+// score at or below 1. A score_expression then replaces the score, recorded
+// as a script component making up the difference.
+func scoreBreakdown(indicators map[string]float64, params config.VolatilityConfig) []ScoreComponent {
+	component := func(name string, value, weight float64, met bool) ScoreComponent {
+		c := ScoreComponent{Name: name, Value: value, Weight: weight}
+		if met {
+			c.Points = weight
+		}
+		return c
+	}
+
+	// Bollinger proximity is judged on where price sits between the bands:
+	// 0 at the lower band, 1 at the upper one
+	currentPrice := indicators["price"]
+	upperBand := indicators["upper_band"]
+	lowerBand := indicators["lower_band"]
+	percentB := 0.0
+	if upperBand > lowerBand {
+		percentB = (currentPrice - lowerBand) / (upperBand - lowerBand)
+	}
+
+	weights := params.ScoreWeights.OrDefault()
+	rsi := indicators["rsi"]
+	volumeRatio := indicators["volume_ratio"]
+	priceChange := math.Abs(indicators["price_change"])
+	components := []ScoreComponent{
+		component(ScoreBollinger, percentB, weights.Bollinger, currentPrice > upperBand*0.98 || currentPrice < lowerBand*1.02),
+		component(ScoreRSI, rsi, weights.RSI, rsi > params.RSIOverbought || rsi < params.RSIOversold),
+		component(ScoreVolume, volumeRatio, weights.Volume, volumeRatio > params.VolumeThreshold),
+		component(ScorePriceChange, priceChange, weights.PriceChange, priceChange > params.MinVolatilityPercent),
+	}
+
+	score := 0.0
+	for _, c := range components {
+		score += c.Points
+	}
+	breakout := component(ScoreSqueezeBreakout, indicators["squeeze_breakout"], squeezeBreakoutBonus, indicators["squeeze_breakout"] != 0)
+	breakout.Points = math.Min(breakout.Points, math.Max(1-score, 0))
+	components = append(components, breakout)
+	if params.ScoreExpression == "" {
+		return components
+	}
+
+	scripted, err := runScoreScript(params.ScoreExpression, indicators, components)
+	if err != nil {
+		return components
+	}
+	return append(components, ScoreComponent{Name: ScoreScript, Value: scripted, Points: scripted - score - breakout.Points})
+}
+
+// runScoreScript computes the score with a score expression, clamped to 0-1.
+// The script sees every indicator, each component's points as
+// score_<component> and the weighted score as score. Failures fall back to
+// the weighted score and are logged once per expression.
+func runScoreScript(source string, indicators map[string]float64, components []ScoreComponent) (float64, error) {
+	compiled := compiledScoreScript(source)
+	err := compiled.err
+	var value float64
+	if err == nil {
+		vars := make(map[string]float64, len(indicators)+len(components)+1)
+		for name, v := range indicators {
+			vars[name] = v
+		}
+		for _, c := range components {
+			vars["score_"+c.Name] = c.Points
+			vars["score"] += c.Points
+		}
+		value, err = compiled.program.Eval(vars)
+	}
+	if err != nil {
+		compiled.warned.Do(func() {
+			log.Printf("Score expression failed, using the weighted score: %v", err)
+		})
+		return 0, err
+	}
+	return math.Max(0, math.Min(value, 1)), nil
+}
+
+// formatScoreBreakdown lists the components that changed the score, e.g.
+// "Bollinger +0.30 · Volume +0.25"
+func formatScoreBreakdown(breakdown []ScoreComponent) string {
+	var parts []string
+	for _, c := range breakdown {
+		if c.Points == 0 {
+			continue
+		}
+		label := scoreLabels[c.Name]
+		if label == "" {
+			label = c.Name
+		}
+		parts = append(parts, fmt.Sprintf("%s %+.2f", label, c.Points))
+	}
+	return strings.Join(parts, " · ")
+}
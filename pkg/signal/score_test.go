@@ -0,0 +1,74 @@
+package signal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScoreBreakdown(t *testing.T) {
+	params := config.CreateDefaultConfig().VolatilityParams
+	indicators := map[string]float64{
+		"price":            103.0,
+		"upper_band":       101.0,
+		"lower_band":       99.0,
+		"rsi":              80.0,
+		"volume_ratio":     params.VolumeThreshold + 1,
+		"price_change":     params.MinVolatilityPercent + 1,
+		"squeeze_breakout": 1,
+	}
+
+	breakdown := scoreBreakdown(indicators, params)
+	points := make(map[string]float64)
+	for _, component := range breakdown {
+		points[component.Name] = component.Points
+	}
+	assert.Equal(t, 0.3, points[ScoreBollinger])
+	assert.Equal(t, 0.25, points[ScoreRSI])
+	assert.Equal(t, 0.25, points[ScoreVolume])
+	assert.Equal(t, 0.2, points[ScorePriceChange])
+	// Every other component is met, so the breakout bonus has nothing left to add
+	assert.InDelta(t, 0, points[ScoreSqueezeBreakout], 0.001)
+	assert.InDelta(t, 2.0, breakdown[0].Value, 0.001)
+	assert.InDelta(t, 1.0, calculateVolatilityScore(indicators, params), 0.001)
+
+	s := &Signal{Symbol: "AAPL", Type: BUY, Price: 103, Confidence: 1, GeneratedAt: time.Now(), ScoreBreakdown: breakdown}
+	assert.Contains(t, FormatSignalMessage(s), "Bollinger +0.30 · RSI +0.25 · Volume +0.25 · Price change +0.20")
+	assert.NotContains(t, FormatRedactedSignalMessage(s, []string{"score_breakdown"}), "Bollinger +0.30")
+	assert.NotContains(t, FormatRedactedSignalMessage(s, []string{"confidence"}), "Bollinger +0.30")
+}
+
+func TestScoreWeightsAndScript(t *testing.T) {
+	params := config.CreateDefaultConfig().VolatilityParams
+	indicators := map[string]float64{
+		"price":        100.0,
+		"upper_band":   105.0,
+		"lower_band":   95.0,
+		"rsi":          80.0,
+		"volume_ratio": params.VolumeThreshold + 1,
+	}
+
+	params.ScoreWeights = config.ScoreWeightsConfig{Bollinger: 0.1, RSI: 0.4, Volume: 0.4, PriceChange: 0.1}
+	assert.InDelta(t, 0.8, calculateVolatilityScore(indicators, params), 0.001)
+
+	// Zero weights fall back to the defaults
+	params.ScoreWeights = config.ScoreWeightsConfig{}
+	assert.InDelta(t, 0.5, calculateVolatilityScore(indicators, params), 0.001)
+
+	params.ScoreExpression = "score + 0.5 * (rsi > 75)"
+	breakdown := scoreBreakdown(indicators, params)
+	assert.InDelta(t, 1.0, calculateVolatilityScore(indicators, params), 0.001)
+	last := breakdown[len(breakdown)-1]
+	assert.Equal(t, ScoreScript, last.Name)
+	assert.InDelta(t, 0.5, last.Points, 0.001)
+
+	params.ScoreExpression = "score_volume - score_rsi"
+	assert.InDelta(t, 0.0, calculateVolatilityScore(indicators, params), 0.001)
+	assert.Contains(t, formatScoreBreakdown(scoreBreakdown(indicators, params)), "Script -0.50")
+
+	// A failing script falls back to the weighted score
+	params.ScoreExpression = "adx / 50"
+	assert.InDelta(t, 0.5, calculateVolatilityScore(indicators, params), 0.001)
+}
@@ -0,0 +1,84 @@
+package signal
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Status is the lifecycle state of a signal. A signal is generated ACTIVE and
+// closes once, as SUCCESS, FAILURE or EXPIRED.
+type Status string
+
+const (
+	// StatusActive indicates the signal is active
+	StatusActive Status = "ACTIVE"
+	// StatusSuccess indicates the signal reached its target
+	StatusSuccess Status = "SUCCESS"
+	// StatusFailure indicates the signal hit its stop
+	StatusFailure Status = "FAILURE"
+	// StatusExpired indicates the signal expired before either
+	StatusExpired Status = "EXPIRED"
+)
+
+// ErrInvalidTransition is returned for a status change the lifecycle does not allow
+var ErrInvalidTransition = errors.New("invalid signal status transition")
+
+// transitions lists the statuses each status can change to. Closed statuses
+// are final.
+var transitions = map[Status][]Status{
+	"":           {StatusActive},
+	StatusActive: {StatusSuccess, StatusFailure, StatusExpired},
+}
+
+// StatusChange records when a signal entered a status
+type StatusChange struct {
+	Status Status    `json:"status"`
+	At     time.Time `json:"at"`
+}
+
+// Closed reports whether the status is final
+func (s Status) Closed() bool {
+	return s == StatusSuccess || s == StatusFailure || s == StatusExpired
+}
+
+// CanTransition reports whether a signal can change from s to next
+func (s Status) CanTransition(next Status) bool {
+	for _, allowed := range transitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateTransition returns an error wrapping ErrInvalidTransition unless a
+// signal can change from one status to the other
+func ValidateTransition(from, to Status) error {
+	if !from.CanTransition(to) {
+		return fmt.Errorf("%w: %q to %q", ErrInvalidTransition, from, to)
+	}
+	return nil
+}
+
+// SetStatus moves the signal to status at the given time and records the change
+func (s *Signal) SetStatus(status Status, at time.Time) error {
+	if err := ValidateTransition(s.Status, status); err != nil {
+		return fmt.Errorf("failed to change status of signal %s: %w", s.ID, err)
+	}
+	s.Status = status
+	// Copies of the signal may share the history, so it is never appended in place
+	s.StatusChanges = append(s.StatusChanges[:len(s.StatusChanges):len(s.StatusChanges)], StatusChange{Status: status, At: at})
+	return nil
+}
+
+// StatusChangedAt returns when the signal entered its current status, or the
+// zero time if the change was not recorded
+func (s *Signal) StatusChangedAt() time.Time {
+	for i := len(s.StatusChanges) - 1; i >= 0; i-- {
+		if s.StatusChanges[i].Status == s.Status {
+			return s.StatusChanges[i].At
+		}
+	}
+	return time.Time{}
+}
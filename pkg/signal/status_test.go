@@ -0,0 +1,36 @@
+package signal
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignalStatusLifecycle(t *testing.T) {
+	generated := time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC)
+	s := &Signal{ID: "SIG-AAPL-BUY-1"}
+	assert.NoError(t, s.SetStatus(StatusActive, generated))
+	assert.Equal(t, generated, s.StatusChangedAt())
+
+	// A copy taken while active keeps its own history
+	active := *s
+	closed := generated.Add(time.Hour)
+	assert.NoError(t, s.SetStatus(StatusFailure, closed))
+	assert.Equal(t, StatusFailure, s.Status)
+	assert.True(t, s.Status.Closed())
+	assert.Equal(t, closed, s.StatusChangedAt())
+	assert.Len(t, s.StatusChanges, 2)
+	assert.Len(t, active.StatusChanges, 1)
+
+	// Closed statuses are final
+	err := s.SetStatus(StatusSuccess, closed.Add(time.Minute))
+	assert.True(t, errors.Is(err, ErrInvalidTransition))
+	assert.Equal(t, StatusFailure, s.Status)
+	assert.Len(t, s.StatusChanges, 2)
+
+	assert.True(t, StatusActive.CanTransition(StatusExpired))
+	assert.False(t, StatusActive.CanTransition(StatusActive))
+	assert.Error(t, ValidateTransition(StatusExpired, StatusActive))
+}
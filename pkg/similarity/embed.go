@@ -0,0 +1,144 @@
+package similarity
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// Dimensions of the hashing embedder. Indicators and news text hash into
+// separate halves so a long blurb cannot drown out the technical picture.
+const (
+	featureDimensions = 64
+	textDimensions    = 64
+	textWeight        = 0.5
+)
+
+// Embedder turns a signal context into a vector. Vectors are compared by cosine
+// similarity, so implementations only need to preserve direction.
+type Embedder interface {
+	Embed(ctx context.Context, s *signal.Signal, blurb string) ([]float64, error)
+}
+
+// HashEmbedder embeds signal contexts locally with feature hashing: each
+// indicator, the signal direction and each word of the news blurb is hashed to
+// a dimension. It needs no model or network access.
+type HashEmbedder struct{}
+
+// NewHashEmbedder creates a new HashEmbedder
+func NewHashEmbedder() *HashEmbedder {
+	return &HashEmbedder{}
+}
+
+// Embed returns the unit-length vector for a signal and its news blurb
+func (e *HashEmbedder) Embed(ctx context.Context, s *signal.Signal, blurb string) ([]float64, error) {
+	features := make([]float64, featureDimensions)
+
+	// Sort names so vectors do not depend on map iteration order
+	names := make([]string, 0, len(s.TechnicalData))
+	for name := range s.TechnicalData {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		addHashed(features, "ind:"+name, squash(s.TechnicalData[name]))
+	}
+	addHashed(features, "type:"+string(s.Type), 2)
+	addHashed(features, "confidence", squash(s.Confidence*10))
+	addHashed(features, "roi", squash(s.ExpectedROI))
+	normalize(features)
+
+	text := make([]float64, textDimensions)
+	for _, word := range tokenize(blurb) {
+		addHashed(text, "word:"+word, 1)
+	}
+	normalize(text)
+
+	vector := make([]float64, 0, featureDimensions+textDimensions)
+	vector = append(vector, features...)
+	for _, v := range text {
+		vector = append(vector, v*textWeight)
+	}
+	normalize(vector)
+	return vector, nil
+}
+
+// Cosine returns the cosine similarity of two vectors, or 0 if their lengths differ
+func Cosine(a, b []float64) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// addHashed adds value to the dimension key hashes to, with a hashed sign so
+// colliding keys tend to cancel rather than accumulate
+func addHashed(vector []float64, key string, value float64) {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	sum := h.Sum64()
+
+	index := int(sum % uint64(len(vector)))
+	if sum&(1<<63) != 0 {
+		value = -value
+	}
+	vector[index] += value
+}
+
+// squash compresses indicator values of very different scales (RSI, prices,
+// band widths) into a comparable range while keeping their sign
+func squash(v float64) float64 {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0
+	}
+	if v < 0 {
+		return -math.Log1p(-v)
+	}
+	return math.Log1p(v)
+}
+
+// normalize scales a vector to unit length in place
+func normalize(vector []float64) {
+	var norm float64
+	for _, v := range vector {
+		norm += v * v
+	}
+	if norm == 0 {
+		return
+	}
+	norm = math.Sqrt(norm)
+	for i := range vector {
+		vector[i] /= norm
+	}
+}
+
+// tokenize splits text into lowercase words, skipping short ones
+func tokenize(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	tokens := words[:0]
+	for _, word := range words {
+		if len(word) > 2 {
+			tokens = append(tokens, word)
+		}
+	}
+	return tokens
+}
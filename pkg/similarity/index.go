@@ -0,0 +1,270 @@
+package similarity
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// Setup is the embedded context of a past signal and its outcome
+type Setup struct {
+	SignalID    string    `json:"signal_id"`
+	Symbol      string    `json:"symbol"`
+	Type        string    `json:"type"`
+	Vector      []float64 `json:"vector"`
+	GeneratedAt time.Time `json:"generated_at"`
+	Outcome     string    `json:"outcome"` // SUCCESS, FAILURE or EXPIRED; empty while the signal is active
+	ROI         float64   `json:"roi"`     // Realized ROI in percent once closed
+}
+
+// Closed reports whether the setup's signal has an outcome
+func (s *Setup) Closed() bool {
+	return s.Outcome != ""
+}
+
+// Match is a historical setup similar to a new signal
+type Match struct {
+	Setup
+	Similarity float64 `json:"similarity"`
+}
+
+// Summary describes the outcomes of the setups most similar to a signal
+type Summary struct {
+	Matches    []Match `json:"matches"`
+	HitTarget  int     `json:"hit_target"`
+	StoppedOut int     `json:"stopped_out"`
+	Expired    int     `json:"expired"`
+	AverageROI float64 `json:"average_roi"`
+}
+
+// String summarizes the matches for a signal rationale, e.g. "3 of 4 similar
+// setups hit target (avg ROI +1.20%)", or returns an empty string without matches
+func (s Summary) String() string {
+	if len(s.Matches) == 0 {
+		return ""
+	}
+	noun := "setups"
+	if len(s.Matches) == 1 {
+		noun = "setup"
+	}
+	return fmt.Sprintf("%d of %d similar %s hit target (avg ROI %+.2f%%)", s.HitTarget, len(s.Matches), noun, s.AverageROI)
+}
+
+// Store persists setups (implemented by store.Logger)
+type Store interface {
+	SaveSetup(setup *Setup) error
+	LoadSetups(limit int) ([]*Setup, error)
+}
+
+// Index stores embeddings of past signal contexts and finds the closed setups
+// most similar to a new signal
+type Index struct {
+	embedder Embedder
+	config   config.SimilarityConfig
+	news     func(symbol string) string
+	store    Store
+	setups   []*Setup
+	byID     map[string]*Setup
+	mu       sync.RWMutex
+}
+
+// NewIndex creates a new Index
+func NewIndex(embedder Embedder, cfg config.SimilarityConfig) *Index {
+	return &Index{
+		embedder: embedder,
+		config:   cfg,
+		byID:     make(map[string]*Setup),
+	}
+}
+
+// SetNewsSource sets the function returning a news blurb for a symbol, e.g.
+// recent headlines. The blurb is embedded along with the indicators.
+func (x *Index) SetNewsSource(news func(symbol string) string) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.news = news
+}
+
+// SetStore persists setups to store and restores the most recent setups saved by a previous run
+func (x *Index) SetStore(store Store) error {
+	setups, err := store.LoadSetups(x.config.MaxSetups)
+	if err != nil {
+		return fmt.Errorf("failed to load setups: %w", err)
+	}
+
+	// Keep the index ordered oldest first
+	sort.Slice(setups, func(i, j int) bool {
+		return setups[i].GeneratedAt.Before(setups[j].GeneratedAt)
+	})
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	x.store = store
+	for _, setup := range setups {
+		if _, exists := x.byID[setup.SignalID]; !exists {
+			x.setups = append(x.setups, setup)
+			x.byID[setup.SignalID] = setup
+		}
+	}
+	x.trim()
+	return nil
+}
+
+// Describe embeds a new signal's context, adds it to the index and summarizes
+// the outcomes of the most similar closed setups of the same type
+func (x *Index) Describe(ctx context.Context, s *signal.Signal) (Summary, error) {
+	x.mu.RLock()
+	news := x.news
+	x.mu.RUnlock()
+
+	var blurb string
+	if news != nil {
+		blurb = news(s.Symbol)
+	}
+
+	vector, err := x.embedder.Embed(ctx, s, blurb)
+	if err != nil {
+		return Summary{}, fmt.Errorf("failed to embed signal %s: %w", s.ID, err)
+	}
+
+	summary := x.summarize(x.Similar(vector, string(s.Type), x.config.Neighbors))
+	x.add(&Setup{
+		SignalID:    s.ID,
+		Symbol:      s.Symbol,
+		Type:        string(s.Type),
+		Vector:      vector,
+		GeneratedAt: s.GeneratedAt,
+	})
+	return summary, nil
+}
+
+// Similar returns up to k closed setups of signalType whose similarity to
+// vector reaches the configured minimum, most similar first
+func (x *Index) Similar(vector []float64, signalType string, k int) []Match {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	var matches []Match
+	for _, setup := range x.setups {
+		if !setup.Closed() || setup.Type != signalType {
+			continue
+		}
+		similarity := Cosine(vector, setup.Vector)
+		if similarity < x.config.MinSimilarity {
+			continue
+		}
+		matches = append(matches, Match{Setup: *setup, Similarity: similarity})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Similarity > matches[j].Similarity
+	})
+	if k > 0 && len(matches) > k {
+		matches = matches[:k]
+	}
+	return matches
+}
+
+// Resolve records the outcome of a closed signal. It matches the
+// monitor.MarketMonitor OnSignalClosed callback.
+func (x *Index) Resolve(s *signal.Signal, exitPrice float64) {
+	x.mu.Lock()
+	setup, exists := x.byID[s.ID]
+	if !exists {
+		x.mu.Unlock()
+		return
+	}
+
+	setup.Outcome = string(s.Status)
+	if s.Price > 0 {
+		if s.Type == signal.SELL {
+			setup.ROI = (s.Price - exitPrice) / s.Price * 100
+		} else {
+			setup.ROI = (exitPrice - s.Price) / s.Price * 100
+		}
+	}
+	saved := *setup
+	x.mu.Unlock()
+
+	x.save(&saved)
+}
+
+// Len returns the number of setups in the index
+func (x *Index) Len() int {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return len(x.setups)
+}
+
+// summarize counts the outcomes of matches
+func (x *Index) summarize(matches []Match) Summary {
+	summary := Summary{Matches: matches}
+	if len(matches) == 0 {
+		return summary
+	}
+
+	var totalROI float64
+	for _, m := range matches {
+		switch m.Outcome {
+		case string(signal.StatusSuccess):
+			summary.HitTarget++
+		case string(signal.StatusFailure):
+			summary.StoppedOut++
+		default:
+			summary.Expired++
+		}
+		totalROI += m.ROI
+	}
+	summary.AverageROI = totalROI / float64(len(matches))
+	return summary
+}
+
+// add appends a setup to the index and persists it
+func (x *Index) add(setup *Setup) {
+	x.mu.Lock()
+	if _, exists := x.byID[setup.SignalID]; exists {
+		x.mu.Unlock()
+		return
+	}
+	x.setups = append(x.setups, setup)
+	x.byID[setup.SignalID] = setup
+	x.trim()
+	saved := *setup
+	x.mu.Unlock()
+
+	x.save(&saved)
+}
+
+// trim drops the oldest setups beyond the configured maximum. Caller must hold the lock.
+func (x *Index) trim() {
+	if x.config.MaxSetups <= 0 || len(x.setups) <= x.config.MaxSetups {
+		return
+	}
+
+	drop := len(x.setups) - x.config.MaxSetups
+	for _, setup := range x.setups[:drop] {
+		delete(x.byID, setup.SignalID)
+	}
+	x.setups = append([]*Setup(nil), x.setups[drop:]...)
+}
+
+// save persists a setup if a store is set
+func (x *Index) save(setup *Setup) {
+	x.mu.RLock()
+	store := x.store
+	x.mu.RUnlock()
+
+	if store == nil {
+		return
+	}
+	if err := store.SaveSetup(setup); err != nil {
+		log.Printf("Error saving setup for signal %s: %v", setup.SignalID, err)
+	}
+}
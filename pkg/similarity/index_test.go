@@ -0,0 +1,105 @@
+package similarity
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/signal"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryStore is an in-memory setup store
+type memoryStore struct {
+	saved map[string]Setup
+}
+
+func (s *memoryStore) SaveSetup(setup *Setup) error {
+	s.saved[setup.SignalID] = *setup
+	return nil
+}
+
+func (s *memoryStore) LoadSetups(limit int) ([]*Setup, error) {
+	setups := make([]*Setup, 0, len(s.saved))
+	for _, setup := range s.saved {
+		copied := setup
+		setups = append(setups, &copied)
+	}
+	return setups, nil
+}
+
+func testSignal(id string, signalType signal.SignalType, rsi, width float64) *signal.Signal {
+	return &signal.Signal{
+		ID:            id,
+		Symbol:        "AAPL",
+		Type:          signalType,
+		Price:         100,
+		TargetPrice:   103,
+		StopLoss:      98,
+		ExpectedROI:   3,
+		Confidence:    0.8,
+		GeneratedAt:   time.Date(2025, 4, 21, 14, 0, 0, 0, time.UTC),
+		TechnicalData: map[string]float64{"rsi": rsi, "bb_width": width, "atr": 1.2},
+		Status:        "ACTIVE",
+	}
+}
+
+func TestHashEmbedderSimilarity(t *testing.T) {
+	embedder := NewHashEmbedder()
+	ctx := context.Background()
+
+	base, err := embedder.Embed(ctx, testSignal("a", signal.BUY, 30, 0.02), "Apple beats earnings estimates")
+	assert.NoError(t, err)
+	near, _ := embedder.Embed(ctx, testSignal("b", signal.BUY, 31, 0.021), "Apple earnings beat estimates")
+	far, _ := embedder.Embed(ctx, testSignal("c", signal.SELL, 85, 0.3), "Regulators probe Apple app store")
+
+	assert.InDelta(t, 1.0, Cosine(base, base), 1e-9)
+	assert.Greater(t, Cosine(base, near), 0.9)
+	assert.Greater(t, Cosine(base, near), Cosine(base, far))
+}
+
+func TestIndexSummarizesSimilarClosedSetups(t *testing.T) {
+	index := NewIndex(NewHashEmbedder(), config.SimilarityConfig{Enabled: true, Neighbors: 4, MinSimilarity: 0.9, MaxSetups: 100})
+	store := &memoryStore{saved: make(map[string]Setup)}
+	assert.NoError(t, index.SetStore(store))
+	index.SetNewsSource(func(symbol string) string { return symbol + " rallies on earnings" })
+	ctx := context.Background()
+
+	// Four similar oversold BUY setups: three hit target, one stopped out
+	for i, exit := range []float64{103, 103.5, 97.5, 103} {
+		s := testSignal(fmt.Sprintf("BUY-%d", i), signal.BUY, 30+float64(i)*0.5, 0.02)
+		summary, err := index.Describe(ctx, s)
+		assert.NoError(t, err)
+		assert.Len(t, summary.Matches, i, "only earlier, closed setups are matched")
+
+		if exit > s.Price {
+			s.Status = "SUCCESS"
+		} else {
+			s.Status = "FAILURE"
+		}
+		index.Resolve(s, exit)
+	}
+
+	// A dissimilar SELL setup is never matched with BUY signals
+	sell := testSignal("SELL-0", signal.SELL, 85, 0.3)
+	_, err := index.Describe(ctx, sell)
+	assert.NoError(t, err)
+	sell.Status = "SUCCESS"
+	index.Resolve(sell, 97)
+
+	summary, err := index.Describe(ctx, testSignal("BUY-new", signal.BUY, 31, 0.02))
+	assert.NoError(t, err)
+	assert.Len(t, summary.Matches, 4)
+	assert.Equal(t, 3, summary.HitTarget)
+	assert.Equal(t, 1, summary.StoppedOut)
+	assert.Equal(t, "3 of 4 similar setups hit target (avg ROI +1.75%)", summary.String())
+	assert.Equal(t, 6, index.Len())
+
+	// Setups and their outcomes survive a restart
+	assert.Equal(t, "FAILURE", store.saved["BUY-2"].Outcome)
+	restored := NewIndex(NewHashEmbedder(), config.SimilarityConfig{Enabled: true, Neighbors: 4, MinSimilarity: 0.9, MaxSetups: 3})
+	assert.NoError(t, restored.SetStore(store))
+	assert.Equal(t, 3, restored.Len())
+}
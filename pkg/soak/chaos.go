@@ -0,0 +1,172 @@
+package soak
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+
+	"github.com/hustler/trading-bot/pkg/backtest"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// Errors injected by the chaos components
+var (
+	errProviderDown = errors.New("chaos: data provider unavailable")
+	errDatabaseDown = errors.New("chaos: database unavailable")
+)
+
+// maxOutageChecks is the longest a database outage lasts, in market checks
+const maxOutageChecks = 5
+
+// chaos decides when failures are injected and counts them
+type chaos struct {
+	rate     float64 // Probability of a failure per provider fetch, store write or LLM call
+	enabled  bool
+	outage   int // Checks left in the current database outage
+	injected Injected
+	rng      *rand.Rand
+	mu       sync.Mutex
+}
+
+// newChaos creates a chaos source failing at rate, seeded for reproducible runs
+func newChaos(rate float64, seed int64) *chaos {
+	return &chaos{rate: rate, enabled: rate > 0, rng: rand.New(rand.NewSource(seed))}
+}
+
+// roll reports whether a failure is injected, counting it with count
+func (c *chaos) roll(count *int) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.enabled || c.rng.Float64() >= c.rate {
+		return false
+	}
+	*count++
+	return true
+}
+
+// tick advances database outages by one market check, starting a new one at random
+func (c *chaos) tick() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.outage > 0 {
+		c.outage--
+		return
+	}
+	if c.enabled && c.rng.Float64() < c.rate {
+		c.outage = 1 + c.rng.Intn(maxOutageChecks)
+		c.injected.DatabaseOutages++
+	}
+}
+
+// databaseDown reports whether the database is in an outage
+func (c *chaos) databaseDown() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.outage > 0
+}
+
+// stop ends injection, and any outage, for the cool-down
+func (c *chaos) stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.enabled = false
+	c.outage = 0
+}
+
+// counts returns the failures injected so far
+func (c *chaos) counts() Injected {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.injected
+}
+
+// flakySource serves the replay of the current day, failing whole fetches
+// as a provider outage would
+type flakySource struct {
+	chaos  *chaos
+	replay *backtest.Replay
+	mu     sync.RWMutex
+}
+
+// setReplay switches to the replay of the next day
+func (s *flakySource) setReplay(replay *backtest.Replay) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.replay = replay
+}
+
+// GetMarketDataBatch returns the replayed bars, or an error for every symbol
+func (s *flakySource) GetMarketDataBatch(symbols []string) (map[string]*data.MarketData, map[string]error) {
+	if s.chaos.roll(&s.chaos.injected.ProviderFailures) {
+		errs := make(map[string]error, len(symbols))
+		for _, symbol := range symbols {
+			errs[symbol] = errProviderDown
+		}
+		return map[string]*data.MarketData{}, errs
+	}
+
+	s.mu.RLock()
+	replay := s.replay
+	s.mu.RUnlock()
+	return replay.GetMarketDataBatch(symbols)
+}
+
+// GetDailyHistory returns the replayed daily bars, or an error
+func (s *flakySource) GetDailyHistory(symbol string, days int) (*data.MarketData, error) {
+	if s.chaos.roll(&s.chaos.injected.ProviderFailures) {
+		return nil, errProviderDown
+	}
+
+	s.mu.RLock()
+	replay := s.replay
+	s.mu.RUnlock()
+	return replay.GetDailyHistory(symbol, days)
+}
+
+// flakyStore accepts signal writes except during database outages
+type flakyStore struct {
+	chaos  *chaos
+	saved  int
+	closed int
+	mu     sync.Mutex
+}
+
+// SaveSignal counts a saved signal, failing during an outage
+func (s *flakyStore) SaveSignal(sig *signal.Signal) error {
+	if s.chaos.databaseDown() {
+		return fmt.Errorf("failed to save signal %s: %w", sig.ID, errDatabaseDown)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.saved++
+	return nil
+}
+
+// CloseSignal counts a closed signal, failing during an outage
+func (s *flakyStore) CloseSignal(sig *signal.Signal, exitPrice float64) error {
+	if s.chaos.databaseDown() {
+		return fmt.Errorf("failed to close signal %s: %w", sig.ID, errDatabaseDown)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed++
+	return nil
+}
+
+// flakyExplainer explains signals with a fixed rationale, timing out at random
+type flakyExplainer struct {
+	chaos *chaos
+}
+
+// GenerateSignalExplanation returns the rationale, or a timeout
+func (e flakyExplainer) GenerateSignalExplanation(ctx context.Context, s *signal.Signal) (string, error) {
+	if e.chaos.roll(&e.chaos.injected.LLMTimeouts) {
+		return "", fmt.Errorf("failed to explain signal %s: %w", s.ID, context.DeadlineExceeded)
+	}
+	return "Soak test explanation", nil
+}
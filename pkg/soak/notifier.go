@@ -0,0 +1,77 @@
+package soak
+
+import (
+	"sync"
+
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// window tracks the signal IDs sent and closed in one session
+type window struct {
+	sent   map[string]bool
+	closed map[string]bool
+}
+
+func newWindow() window {
+	return window{sent: make(map[string]bool), closed: make(map[string]bool)}
+}
+
+// countingNotifier counts what the monitor sends and catches signals sent or
+// closed twice. Only the IDs of the current and previous sessions are kept,
+// so memory stays flat over hours; signals expire well within that.
+type countingNotifier struct {
+	current           window
+	previous          window
+	signals           int
+	outcomes          int
+	duplicateSends    []string
+	duplicateOutcomes []string
+	mu                sync.Mutex
+}
+
+func newCountingNotifier() *countingNotifier {
+	return &countingNotifier{current: newWindow(), previous: newWindow()}
+}
+
+// rotate starts the window of a new session
+func (n *countingNotifier) rotate() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.previous, n.current = n.current, newWindow()
+}
+
+// SendSignal counts a sent signal
+func (n *countingNotifier) SendSignal(s *signal.Signal) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.signals++
+	if n.current.sent[s.ID] || n.previous.sent[s.ID] {
+		n.duplicateSends = append(n.duplicateSends, s.ID)
+	}
+	n.current.sent[s.ID] = true
+	return nil
+}
+
+// UpdateSignal accepts an edited signal
+func (n *countingNotifier) UpdateSignal(s *signal.Signal) error {
+	return nil
+}
+
+// SendSignalOutcome counts a closed signal
+func (n *countingNotifier) SendSignalOutcome(s *signal.Signal, exitPrice float64) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.outcomes++
+	if n.current.closed[s.ID] || n.previous.closed[s.ID] {
+		n.duplicateOutcomes = append(n.duplicateOutcomes, s.ID)
+	}
+	n.current.closed[s.ID] = true
+	return nil
+}
+
+// SendMessage accepts a channel message
+func (n *countingNotifier) SendMessage(message string) error {
+	return nil
+}
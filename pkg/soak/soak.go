@@ -0,0 +1,260 @@
+package soak
+
+import (
+	"fmt"
+	"log"
+	"runtime"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/backtest"
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/monitor"
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// start is the first simulated session open a soak run replays
+var start = time.Date(2025, 4, 21, 13, 30, 0, 0, time.UTC)
+
+const (
+	// sessionBars is the number of 5-minute bars in a simulated session
+	sessionBars = 78
+	// warmupBars are replayed before the first check of a session, so the
+	// indicators have enough history
+	warmupBars = 30
+	// cooldownChecks run without chaos at the end, and must all succeed
+	cooldownChecks = 10
+	// goroutineSlack is how many goroutines above the starting count are
+	// tolerated once the run settles
+	goroutineSlack = 5
+	// settleTimeout bounds the wait for background goroutines to finish
+	settleTimeout = 5 * time.Second
+)
+
+// syntheticScenarios are cycled through so the watchlist mixes market conditions
+var syntheticScenarios = []string{"trend", "mean_reversion", "gap", "breakout"}
+
+// Config describes a soak run
+type Config struct {
+	Duration    time.Duration // Wall-clock time the run lasts
+	Checks      int           // Stop after this many market checks instead; 0 runs for Duration
+	Symbols     int           // Watchlist size
+	FailureRate float64       // Probability (0-1) of an injected failure per fetch, LLM call and check
+	Seed        int64
+}
+
+// Injected counts the failures a run injected
+type Injected struct {
+	ProviderFailures int `json:"provider_failures"`
+	DatabaseOutages  int `json:"database_outages"`
+	LLMTimeouts      int `json:"llm_timeouts"`
+}
+
+// Result is what a soak run observed
+type Result struct {
+	Elapsed           time.Duration `json:"elapsed"`
+	Sessions          int           `json:"sessions"`
+	Checks            int           `json:"checks"`
+	FailedChecks      int           `json:"failed_checks"`   // Checks that returned an error or panicked, chaos included
+	FailedCooldown    int           `json:"failed_cooldown"` // Checks that failed after chaos stopped
+	Injected          Injected      `json:"injected"`
+	Signals           int           `json:"signals"`
+	Outcomes          int           `json:"outcomes"`
+	SavedSignals      int           `json:"saved_signals"`
+	DuplicateSends    []string      `json:"duplicate_sends,omitempty"`    // Signal IDs sent more than once
+	DuplicateOutcomes []string      `json:"duplicate_outcomes,omitempty"` // Signal IDs closed more than once
+	GoroutinesStart   int           `json:"goroutines_start"`
+	GoroutinesMax     int           `json:"goroutines_max"`
+	GoroutinesEnd     int           `json:"goroutines_end"`
+}
+
+// Check returns a description of each way the run failed to recover, sent a
+// signal twice or leaked goroutines
+func (r *Result) Check() []string {
+	var violations []string
+	if r.FailedCooldown > 0 {
+		violations = append(violations, fmt.Sprintf("%d of %d checks failed after chaos stopped", r.FailedCooldown, cooldownChecks))
+	}
+	if len(r.DuplicateSends) > 0 {
+		violations = append(violations, fmt.Sprintf("signals sent more than once: %v", r.DuplicateSends))
+	}
+	if len(r.DuplicateOutcomes) > 0 {
+		violations = append(violations, fmt.Sprintf("signals closed more than once: %v", r.DuplicateOutcomes))
+	}
+	if r.GoroutinesEnd > r.GoroutinesStart+goroutineSlack {
+		violations = append(violations, fmt.Sprintf("goroutines grew from %d to %d", r.GoroutinesStart, r.GoroutinesEnd))
+	}
+	return violations
+}
+
+// stack is the production pipeline wired to the chaos components
+type stack struct {
+	clock    *clock.Fake
+	source   *flakySource
+	store    *flakyStore
+	notifier *countingNotifier
+	monitor  *monitor.MarketMonitor
+	symbols  []string
+}
+
+// newStack wires the market monitor and signal generator to synthetic data, a
+// flaky store and a flaky LLM
+func newStack(cfg Config, chaos *chaos) *stack {
+	symbols := make([]string, cfg.Symbols)
+	for i := range symbols {
+		symbols[i] = fmt.Sprintf("SK%03d", i)
+	}
+
+	appCfg := config.CreateDefaultConfig()
+	appCfg.StockSymbols = symbols
+	appCfg.EconomicCalendar.URL = ""
+	// Synthetic data has no daily history to measure liquidity from
+	appCfg.Liquidity = config.LiquidityConfig{}
+	// Explain in the background, so those goroutines are covered by the leak check
+	appCfg.LLM.AsyncExplanation = true
+
+	fake := clock.NewFake(start)
+	generator := signal.NewGenerator(appCfg)
+	generator.SetClock(fake)
+
+	source := &flakySource{chaos: chaos}
+	store := &flakyStore{chaos: chaos}
+	notifier := newCountingNotifier()
+
+	marketMonitor := monitor.NewMarketMonitor(appCfg, source, generator, flakyExplainer{chaos: chaos}, notifier)
+	marketMonitor.SetClock(fake)
+	marketMonitor.SetSignalStore(store)
+
+	return &stack{clock: fake, source: source, store: store, notifier: notifier, monitor: marketMonitor, symbols: symbols}
+}
+
+// session replays a fresh synthetic session starting at open
+func (s *stack) session(open time.Time, seed int64) error {
+	history := make(map[string]*data.MarketData, len(s.symbols))
+	for i, symbol := range s.symbols {
+		md, err := data.GenerateSynthetic(data.SyntheticSpec{
+			Symbol:   symbol,
+			Scenario: syntheticScenarios[(i+int(seed))%len(syntheticScenarios)],
+			Start:    open,
+			Bars:     sessionBars,
+			// Livelier than the defaults, so sessions produce signals
+			Volatility: 0.006,
+			GapPercent: 3,
+			Seed:       seed*1000 + int64(i) + 1,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to generate data for %s: %w", symbol, err)
+		}
+		history[symbol] = md
+	}
+	s.source.setReplay(backtest.NewReplay(s.clock, history))
+	s.notifier.rotate()
+	return nil
+}
+
+// check runs one market check at now, recovering a panic as a failure
+func (s *stack) check(now time.Time) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("market check panicked: %v", r)
+		}
+	}()
+	s.clock.Set(now)
+	return s.monitor.CheckMarket()
+}
+
+// Run soaks the full signal pipeline in synthetic sessions while injecting
+// provider failures, database outages and LLM timeouts, then checks that it
+// recovers once the chaos stops, never sent or closed a signal twice and did
+// not leak goroutines
+func Run(cfg Config) (*Result, error) {
+	if cfg.Symbols <= 0 || (cfg.Duration <= 0 && cfg.Checks <= 0) {
+		return nil, fmt.Errorf("symbols and duration or checks must be positive")
+	}
+	if cfg.FailureRate < 0 || cfg.FailureRate > 1 {
+		return nil, fmt.Errorf("failure rate must be between 0 and 1")
+	}
+
+	chaos := newChaos(cfg.FailureRate, cfg.Seed)
+	stack := newStack(cfg, chaos)
+
+	result := &Result{GoroutinesStart: runtime.NumGoroutine()}
+	result.GoroutinesMax = result.GoroutinesStart
+	started := time.Now()
+	done := func() bool {
+		if cfg.Checks > 0 {
+			return result.Checks >= cfg.Checks
+		}
+		return time.Since(started) >= cfg.Duration
+	}
+
+	open := start
+	var now time.Time
+	for !done() {
+		if err := stack.session(open, cfg.Seed+int64(result.Sessions)); err != nil {
+			return nil, err
+		}
+		result.Sessions++
+
+		for bar := warmupBars; bar < sessionBars && !done(); bar++ {
+			now = open.Add(time.Duration(bar) * 5 * time.Minute)
+			chaos.tick()
+			if err := stack.check(now); err != nil {
+				log.Printf("Soak check at %s failed: %v", now.Format(time.RFC3339), err)
+				result.FailedChecks++
+			}
+			result.Checks++
+		}
+
+		if n := runtime.NumGoroutine(); n > result.GoroutinesMax {
+			result.GoroutinesMax = n
+		}
+		open = nextSession(open)
+	}
+
+	// The pipeline must recover as soon as the chaos stops
+	chaos.stop()
+	for i := 0; i < cooldownChecks; i++ {
+		now = now.Add(5 * time.Minute)
+		if err := stack.check(now); err != nil {
+			log.Printf("Soak cool-down check failed: %v", err)
+			result.FailedCooldown++
+		}
+	}
+
+	result.GoroutinesEnd = settle(result.GoroutinesStart + goroutineSlack)
+	result.Elapsed = time.Since(started)
+	result.Injected = chaos.counts()
+
+	stack.notifier.mu.Lock()
+	result.Signals = stack.notifier.signals
+	result.Outcomes = stack.notifier.outcomes
+	result.DuplicateSends = stack.notifier.duplicateSends
+	result.DuplicateOutcomes = stack.notifier.duplicateOutcomes
+	stack.notifier.mu.Unlock()
+
+	stack.store.mu.Lock()
+	result.SavedSignals = stack.store.saved
+	stack.store.mu.Unlock()
+	return result, nil
+}
+
+// nextSession returns the open of the next weekday session
+func nextSession(open time.Time) time.Time {
+	next := open.AddDate(0, 0, 1)
+	for next.Weekday() == time.Saturday || next.Weekday() == time.Sunday {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+// settle waits for background goroutines to finish, up to settleTimeout, and
+// returns the goroutine count
+func settle(target int) int {
+	deadline := time.Now().Add(settleTimeout)
+	for runtime.NumGoroutine() > target && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+	}
+	return runtime.NumGoroutine()
+}
@@ -0,0 +1,44 @@
+package soak
+
+import (
+	"testing"
+
+	"github.com/hustler/trading-bot/pkg/signal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunRecoversFromChaos(t *testing.T) {
+	result, err := Run(Config{Checks: 120, Symbols: 8, FailureRate: 0.2, Seed: 1})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, 120, result.Checks)
+	assert.Equal(t, 3, result.Sessions)
+	assert.Positive(t, result.Injected.ProviderFailures)
+	assert.Positive(t, result.Injected.DatabaseOutages)
+	assert.Positive(t, result.Signals)
+	assert.Empty(t, result.Check())
+}
+
+func TestCheckReportsViolations(t *testing.T) {
+	result := &Result{FailedCooldown: 1, DuplicateSends: []string{"SIG-1"}, GoroutinesStart: 10, GoroutinesEnd: 40}
+	assert.Len(t, result.Check(), 3)
+}
+
+func TestCountingNotifierCatchesDuplicates(t *testing.T) {
+	notifier := newCountingNotifier()
+	sig := &signal.Signal{ID: "SIG-1"}
+	notifier.SendSignal(sig)
+	notifier.rotate()
+	notifier.SendSignal(sig)
+	notifier.SendSignalOutcome(sig, 100)
+	assert.Equal(t, []string{"SIG-1"}, notifier.duplicateSends)
+	assert.Empty(t, notifier.duplicateOutcomes)
+
+	// Only the previous session is remembered
+	notifier.rotate()
+	notifier.rotate()
+	notifier.SendSignal(sig)
+	assert.Len(t, notifier.duplicateSends, 1)
+}
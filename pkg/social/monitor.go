@@ -0,0 +1,266 @@
+package social
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/indicators"
+)
+
+// Spike is a poll with unusually many mentions of a symbol
+type Spike struct {
+	Symbol     string    `json:"symbol"`
+	Mentions   int       `json:"mentions"`
+	Baseline   float64   `json:"baseline"` // Mean mentions per poll before the spike
+	ZScore     float64   `json:"z_score"`
+	Sentiment  float64   `json:"sentiment"`
+	DetectedAt time.Time `json:"detected_at"`
+}
+
+// Notifier delivers spike alerts (implemented by telegram.Bot)
+type Notifier interface {
+	SendMessage(message string) error
+}
+
+// Watchlist provides the symbols mentions are tracked for (implemented by monitor.MarketMonitor)
+type Watchlist interface {
+	WatchedSymbols() []string
+}
+
+// chatter is the mention history of one symbol
+type chatter struct {
+	counts    []float64 // Mentions per poll, oldest first
+	sentiment float64   // Mean sentiment of the latest poll's mentions
+	zScore    float64   // Latest poll against the polls before it
+}
+
+// Monitor polls social sources for mentions of watched symbols, detects
+// spikes in chatter, and exposes mention volume and sentiment as strategy factors
+type Monitor struct {
+	config    config.SocialConfig
+	sources   []Source
+	notifier  Notifier
+	watchlist Watchlist
+	symbols   map[string]*chatter
+	seen      map[string]time.Time // Source/ID -> when the mention was posted
+	lastPoll  time.Time
+	lastAlert map[string]time.Time
+	now       func() time.Time
+	mu        sync.RWMutex
+}
+
+// NewMonitor creates a new social monitor. Spikes are only sent to notifier
+// when alert_on_spike is set.
+func NewMonitor(cfg config.SocialConfig, sources []Source, notifier Notifier, watchlist Watchlist) *Monitor {
+	return &Monitor{
+		config:    cfg,
+		sources:   sources,
+		notifier:  notifier,
+		watchlist: watchlist,
+		symbols:   make(map[string]*chatter),
+		seen:      make(map[string]time.Time),
+		lastAlert: make(map[string]time.Time),
+		now:       time.Now,
+	}
+}
+
+// NewSources creates the configured social sources
+func NewSources(cfg config.SocialConfig) []Source {
+	var sources []Source
+	for _, name := range cfg.Sources {
+		switch name {
+		case "stocktwits":
+			sources = append(sources, NewStockTwits())
+		case "reddit":
+			sources = append(sources, NewReddit(cfg.Subreddits))
+		}
+	}
+	return sources
+}
+
+// Run polls mentions every poll interval until ctx is done
+func (m *Monitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(m.config.PollMinutes) * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		m.Poll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll counts new mentions of every watched symbol since the previous poll
+// and returns the symbols whose chatter spiked
+func (m *Monitor) Poll(ctx context.Context) []Spike {
+	now := m.now()
+	since := m.lastPoll
+	if since.IsZero() {
+		since = now.Add(-time.Duration(m.config.PollMinutes) * time.Minute)
+	}
+	m.lastPoll = now
+
+	var spikes []Spike
+	for _, symbol := range m.watchlist.WatchedSymbols() {
+		var mentions []Mention
+		for _, source := range m.sources {
+			found, err := source.Mentions(ctx, symbol, since)
+			if err != nil {
+				log.Printf("Error fetching %s mentions of %s: %v", source.Name(), symbol, err)
+				continue
+			}
+			mentions = append(mentions, found...)
+		}
+
+		if spike, ok := m.observe(symbol, m.unseen(mentions), now); ok {
+			spikes = append(spikes, spike)
+			m.alert(spike)
+		}
+	}
+	m.pruneSeen(now)
+	return spikes
+}
+
+// unseen returns the mentions not counted by an earlier poll and marks them seen
+func (m *Monitor) unseen(mentions []Mention) []Mention {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var fresh []Mention
+	for _, mention := range mentions {
+		key := mention.Source + "/" + mention.ID
+		if _, ok := m.seen[key]; ok {
+			continue
+		}
+		m.seen[key] = mention.At
+		fresh = append(fresh, mention)
+	}
+	return fresh
+}
+
+// pruneSeen forgets mentions older than the baseline, which sources no longer return
+func (m *Monitor) pruneSeen(now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cutoff := now.Add(-time.Duration(m.config.BaselinePolls*m.config.PollMinutes) * time.Minute)
+	for key, at := range m.seen {
+		if at.Before(cutoff) {
+			delete(m.seen, key)
+		}
+	}
+}
+
+// observe records one poll's mentions of symbol and reports whether the count
+// is a spike against the polls before it
+func (m *Monitor) observe(symbol string, mentions []Mention, now time.Time) (Spike, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	state, ok := m.symbols[symbol]
+	if !ok {
+		state = &chatter{}
+		m.symbols[symbol] = state
+	}
+
+	count := float64(len(mentions))
+	var sentiment float64
+	for _, mention := range mentions {
+		sentiment += mention.Sentiment
+	}
+	if len(mentions) > 0 {
+		sentiment /= count
+	}
+
+	baseline := indicators.SimpleAverage(state.counts, len(state.counts))
+	// Quiet symbols have no variance; one mention of noise keeps the score finite
+	stdDev := math.Max(indicators.StdDev(state.counts, len(state.counts)), 1)
+	warm := len(state.counts) >= m.config.WarmupPolls && len(state.counts) > 0
+
+	state.sentiment = sentiment
+	state.zScore = 0
+	if warm {
+		state.zScore = (count - baseline) / stdDev
+	}
+	state.counts = append(state.counts, count)
+	if len(state.counts) > m.config.BaselinePolls {
+		state.counts = state.counts[len(state.counts)-m.config.BaselinePolls:]
+	}
+
+	if !warm || len(mentions) < m.config.MinMentions || state.zScore < m.config.SpikeZScore {
+		return Spike{}, false
+	}
+	return Spike{
+		Symbol:     symbol,
+		Mentions:   len(mentions),
+		Baseline:   baseline,
+		ZScore:     state.zScore,
+		Sentiment:  sentiment,
+		DetectedAt: now,
+	}, true
+}
+
+// alert sends a spike to the notifier when spike alerts are enabled and the
+// symbol is not cooling down
+func (m *Monitor) alert(spike Spike) {
+	if !m.config.AlertOnSpike || m.notifier == nil {
+		return
+	}
+
+	m.mu.Lock()
+	cooldown := time.Duration(m.config.CooldownMinutes) * time.Minute
+	if last, ok := m.lastAlert[spike.Symbol]; ok && spike.DetectedAt.Sub(last) < cooldown {
+		m.mu.Unlock()
+		return
+	}
+	m.lastAlert[spike.Symbol] = spike.DetectedAt
+	m.mu.Unlock()
+
+	if err := m.notifier.SendMessage(FormatSpikeMessage(spike)); err != nil {
+		log.Printf("Error sending social spike alert for %s: %v", spike.Symbol, err)
+	}
+}
+
+// Factors summarizes the latest poll of symbol for strategies:
+// social_mentions is the mention count, social_sentiment their mean sentiment
+// and social_zscore the count against the symbol's baseline
+func (m *Monitor) Factors(symbol string) map[string]float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	state, ok := m.symbols[symbol]
+	if !ok || len(state.counts) == 0 {
+		return nil
+	}
+	return map[string]float64{
+		"social_mentions":  state.counts[len(state.counts)-1],
+		"social_sentiment": state.sentiment,
+		"social_zscore":    state.zScore,
+	}
+}
+
+// FormatSpikeMessage formats a chatter spike as a heads-up Telegram message
+func FormatSpikeMessage(s Spike) string {
+	mood := "neutral"
+	switch {
+	case s.Sentiment >= 0.2:
+		mood = "bullish"
+	case s.Sentiment <= -0.2:
+		mood = "bearish"
+	}
+
+	message := fmt.Sprintf("📣 <b>SOCIAL SPIKE: %s</b>\n\n", s.Symbol)
+	message += fmt.Sprintf("%d mentions vs %.1f typical (%.1fσ)\n", s.Mentions, s.Baseline, s.ZScore)
+	message += fmt.Sprintf("Sentiment: %s (%+.2f)\n", mood, s.Sentiment)
+	message += "\n<i>This is an informational alert, not a trade signal.</i>\n"
+	message += fmt.Sprintf("⏰ Detected at: %s", s.DetectedAt.Format("2006-01-02 15:04:05"))
+	return message
+}
@@ -0,0 +1,95 @@
+package social
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSource returns count new mentions per poll, repeating the previous poll's
+type fakeSource struct {
+	counts []int
+	polls  int
+	nextID int
+}
+
+func (f *fakeSource) Name() string { return "fake" }
+
+func (f *fakeSource) Mentions(ctx context.Context, symbol string, since time.Time) ([]Mention, error) {
+	count := f.counts[f.polls]
+	f.polls++
+
+	var mentions []Mention
+	// A repeat of the last mention already counted
+	if f.nextID > 0 {
+		mentions = append(mentions, Mention{ID: fmt.Sprint(f.nextID - 1), Source: "fake", Symbol: symbol, At: since})
+	}
+	for i := 0; i < count; i++ {
+		mentions = append(mentions, Mention{ID: fmt.Sprint(f.nextID), Source: "fake", Symbol: symbol, Sentiment: 1, At: since})
+		f.nextID++
+	}
+	return mentions, nil
+}
+
+type recordingNotifier []string
+
+func (r *recordingNotifier) SendMessage(message string) error {
+	*r = append(*r, message)
+	return nil
+}
+
+type staticWatchlist []string
+
+func (s staticWatchlist) WatchedSymbols() []string { return s }
+
+func TestMonitorDetectsSpikes(t *testing.T) {
+	cfg := config.SocialConfig{
+		PollMinutes:     15,
+		BaselinePolls:   96,
+		WarmupPolls:     4,
+		SpikeZScore:     3,
+		MinMentions:     10,
+		AlertOnSpike:    true,
+		CooldownMinutes: 120,
+	}
+	source := &fakeSource{counts: []int{2, 3, 2, 3, 40, 45}}
+	notifier := &recordingNotifier{}
+	m := NewMonitor(cfg, []Source{source}, notifier, staticWatchlist{"GME"})
+	now := time.Date(2025, 1, 27, 15, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+
+	for i := 0; i < 4; i++ {
+		assert.Empty(t, m.Poll(context.Background()))
+		now = now.Add(15 * time.Minute)
+	}
+	assert.Equal(t, 3.0, m.Factors("GME")["social_mentions"])
+
+	spikes := m.Poll(context.Background())
+	assert.Len(t, spikes, 1)
+	assert.Equal(t, 40, spikes[0].Mentions)
+	assert.Equal(t, 2.5, spikes[0].Baseline)
+	assert.Len(t, *notifier, 1)
+	assert.Contains(t, (*notifier)[0], "SOCIAL SPIKE: GME")
+
+	factors := m.Factors("GME")
+	assert.Equal(t, 40.0, factors["social_mentions"])
+	assert.Equal(t, 1.0, factors["social_sentiment"])
+	assert.Greater(t, factors["social_zscore"], 3.0)
+
+	// The spike is now part of the baseline, so sustained chatter is not a new spike
+	now = now.Add(15 * time.Minute)
+	assert.Empty(t, m.Poll(context.Background()))
+	assert.Len(t, *notifier, 1)
+	assert.Nil(t, m.Factors("AMC"))
+}
+
+func TestSentiment(t *testing.T) {
+	assert.Equal(t, 1.0, Sentiment("Loading up on calls, this is going to the moon"))
+	assert.Equal(t, -1.0, Sentiment("Loaded puts before the crash"))
+	assert.Equal(t, 0.0, Sentiment("Holding calls and puts"))
+	assert.Equal(t, 0.0, Sentiment("Earnings are on Thursday"))
+}
@@ -0,0 +1,225 @@
+package social
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Mention is one post or message mentioning a symbol
+type Mention struct {
+	ID        string    `json:"id"`
+	Source    string    `json:"source"`
+	Symbol    string    `json:"symbol"`
+	Text      string    `json:"text"`
+	Sentiment float64   `json:"sentiment"` // -1 (bearish) to 1 (bullish)
+	At        time.Time `json:"at"`
+}
+
+// Source provides recent mentions of a symbol (implemented by StockTwits and Reddit)
+type Source interface {
+	Name() string
+	Mentions(ctx context.Context, symbol string, since time.Time) ([]Mention, error)
+}
+
+// userAgent identifies the bot; Reddit throttles generic agents heavily
+const userAgent = "hustler-trading-bot/1.0"
+
+// StockTwits reads a symbol's public message stream
+type StockTwits struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewStockTwits creates a new StockTwits source
+func NewStockTwits() *StockTwits {
+	return &StockTwits{
+		baseURL: "https://api.stocktwits.com/api/2/streams/symbol",
+		client:  &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// Name returns the source name
+func (s *StockTwits) Name() string {
+	return "stocktwits"
+}
+
+// Mentions returns the messages about symbol posted since since. Messages the
+// author tagged Bullish or Bearish use that tag as their sentiment.
+func (s *StockTwits) Mentions(ctx context.Context, symbol string, since time.Time) ([]Mention, error) {
+	var stream struct {
+		Messages []struct {
+			ID        int64  `json:"id"`
+			Body      string `json:"body"`
+			CreatedAt string `json:"created_at"`
+			Entities  struct {
+				Sentiment *struct {
+					Basic string `json:"basic"`
+				} `json:"sentiment"`
+			} `json:"entities"`
+		} `json:"messages"`
+	}
+	if err := getJSON(ctx, s.client, fmt.Sprintf("%s/%s.json", s.baseURL, url.PathEscape(symbol)), &stream); err != nil {
+		return nil, err
+	}
+
+	var mentions []Mention
+	for _, message := range stream.Messages {
+		at, err := time.Parse(time.RFC3339, message.CreatedAt)
+		if err != nil || at.Before(since) {
+			continue
+		}
+		sentiment := Sentiment(message.Body)
+		if tag := message.Entities.Sentiment; tag != nil {
+			switch tag.Basic {
+			case "Bullish":
+				sentiment = 1
+			case "Bearish":
+				sentiment = -1
+			}
+		}
+		mentions = append(mentions, Mention{
+			ID:        fmt.Sprintf("%d", message.ID),
+			Source:    s.Name(),
+			Symbol:    symbol,
+			Text:      message.Body,
+			Sentiment: sentiment,
+			At:        at,
+		})
+	}
+	return mentions, nil
+}
+
+// Reddit searches subreddits for posts mentioning a symbol
+type Reddit struct {
+	baseURL    string
+	subreddits []string
+	client     *http.Client
+}
+
+// NewReddit creates a new Reddit source searching subreddits
+func NewReddit(subreddits []string) *Reddit {
+	return &Reddit{
+		baseURL:    "https://www.reddit.com",
+		subreddits: subreddits,
+		client:     &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// Name returns the source name
+func (r *Reddit) Name() string {
+	return "reddit"
+}
+
+// Mentions returns the posts in the configured subreddits posted since since
+// whose title or text names symbol as a word or cashtag
+func (r *Reddit) Mentions(ctx context.Context, symbol string, since time.Time) ([]Mention, error) {
+	if len(r.subreddits) == 0 {
+		return nil, nil
+	}
+	query := url.Values{
+		"q":           {fmt.Sprintf("%s OR $%s", symbol, symbol)},
+		"restrict_sr": {"on"},
+		"sort":        {"new"},
+		"t":           {"day"},
+		"limit":       {"100"},
+	}
+	endpoint := fmt.Sprintf("%s/r/%s/search.json?%s", r.baseURL, strings.Join(r.subreddits, "+"), query.Encode())
+
+	var listing struct {
+		Data struct {
+			Children []struct {
+				Data struct {
+					ID         string  `json:"id"`
+					Title      string  `json:"title"`
+					SelfText   string  `json:"selftext"`
+					CreatedUTC float64 `json:"created_utc"`
+				} `json:"data"`
+			} `json:"children"`
+		} `json:"data"`
+	}
+	if err := getJSON(ctx, r.client, endpoint, &listing); err != nil {
+		return nil, err
+	}
+
+	// Search matches loosely, e.g. "ON" in any sentence; require the ticker itself
+	ticker := regexp.MustCompile(`(^|[^A-Za-z0-9])\$?` + regexp.QuoteMeta(symbol) + `($|[^A-Za-z0-9])`)
+
+	var mentions []Mention
+	for _, child := range listing.Data.Children {
+		post := child.Data
+		at := time.Unix(int64(post.CreatedUTC), 0).UTC()
+		text := strings.TrimSpace(post.Title + "\n" + post.SelfText)
+		if at.Before(since) || !ticker.MatchString(text) {
+			continue
+		}
+		mentions = append(mentions, Mention{
+			ID:        post.ID,
+			Source:    r.Name(),
+			Symbol:    symbol,
+			Text:      text,
+			Sentiment: Sentiment(text),
+			At:        at,
+		})
+	}
+	return mentions, nil
+}
+
+// bullishWords and bearishWords are the retail-trader vocabulary scored by Sentiment
+var (
+	bullishWords = map[string]bool{
+		"buy": true, "buying": true, "bought": true, "long": true, "calls": true, "bull": true, "bullish": true,
+		"moon": true, "rocket": true, "breakout": true, "undervalued": true, "beat": true, "upgrade": true, "squeeze": true, "rip": true,
+	}
+	bearishWords = map[string]bool{
+		"sell": true, "selling": true, "sold": true, "short": true, "puts": true, "bear": true, "bearish": true,
+		"dump": true, "crash": true, "overvalued": true, "miss": true, "downgrade": true, "bagholder": true, "bagholding": true, "tank": true,
+	}
+	wordPattern = regexp.MustCompile(`[a-z]+`)
+)
+
+// Sentiment scores text from -1 (bearish) to 1 (bullish) by counting bullish
+// and bearish words. Text without either scores 0.
+func Sentiment(text string) float64 {
+	var bullish, bearish int
+	for _, word := range wordPattern.FindAllString(strings.ToLower(text), -1) {
+		switch {
+		case bullishWords[word]:
+			bullish++
+		case bearishWords[word]:
+			bearish++
+		}
+	}
+	if bullish+bearish == 0 {
+		return 0
+	}
+	return float64(bullish-bearish) / float64(bullish+bearish)
+}
+
+// getJSON fetches url and decodes the JSON response into v
+func getJSON(ctx context.Context, client *http.Client, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to get %s, status: %d", url, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package store
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Payload codecs, stored by name alongside each payload
+const (
+	CodecJSON    = "json"
+	CodecMsgpack = "msgpack"
+)
+
+// gzipSuffix marks a payload name as compressed after encoding
+const gzipSuffix = "+gzip"
+
+// defaultCompressAbove is the encoded size of a journal entry above which it
+// is compressed
+const defaultCompressAbove = 4096
+
+// Codec converts the JSON documents kept in payload columns (app state, trade
+// log events and journal entries) to the bytes stored, and back
+type Codec interface {
+	Name() string
+	Encode(doc []byte) ([]byte, error)
+	Decode(data []byte) ([]byte, error)
+}
+
+// jsonCodec stores JSON documents as they are
+type jsonCodec struct{}
+
+// Name returns the name stored with JSON payloads
+func (jsonCodec) Name() string { return CodecJSON }
+
+// Encode returns the document unchanged
+func (jsonCodec) Encode(doc []byte) ([]byte, error) { return doc, nil }
+
+// Decode returns the document unchanged
+func (jsonCodec) Decode(data []byte) ([]byte, error) { return data, nil }
+
+// codecs are the codecs payloads can be read with, by name
+var codecs = map[string]Codec{
+	CodecJSON:    jsonCodec{},
+	CodecMsgpack: msgpackCodec{},
+}
+
+// CodecByName returns the codec with the given name
+func CodecByName(name string) (Codec, error) {
+	codec, ok := codecs[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown payload codec: %s", name)
+	}
+	return codec, nil
+}
+
+// SetPayloadCodec sets the codec new payloads are written with, and the
+// encoded size above which journal entries are also gzip-compressed; 0 keeps
+// the default of 4 KiB and a negative size never compresses. Payloads written
+// with any codec are still read.
+func (l *Logger) SetPayloadCodec(codec Codec, compressAbove int) {
+	l.codec = codec
+	l.compressAbove = compressAbove
+}
+
+// encodePayload encodes a JSON document with the logger's codec, compressing
+// it if compress is set and it is large. It returns the payload and the name
+// it is read back with.
+func (l *Logger) encodePayload(doc []byte, compress bool) ([]byte, string, error) {
+	// Documents that are not JSON, like some app state, are stored as they are
+	codec := l.codec
+	if codec == nil || !json.Valid(doc) {
+		codec = jsonCodec{}
+	}
+	data, err := codec.Encode(doc)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to encode %s payload: %w", codec.Name(), err)
+	}
+
+	threshold := l.compressAbove
+	if threshold == 0 {
+		threshold = defaultCompressAbove
+	}
+	if !compress || threshold < 0 || len(data) <= threshold {
+		return data, codec.Name(), nil
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, "", fmt.Errorf("failed to compress payload: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, "", fmt.Errorf("failed to compress payload: %w", err)
+	}
+	return buf.Bytes(), codec.Name() + gzipSuffix, nil
+}
+
+// decodePayload returns the JSON document of a payload stored under name
+func decodePayload(data []byte, name string) ([]byte, error) {
+	if base, compressed := strings.CutSuffix(name, gzipSuffix); compressed {
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress payload: %w", err)
+		}
+		if data, err = io.ReadAll(zr); err != nil {
+			return nil, fmt.Errorf("failed to decompress payload: %w", err)
+		}
+		name = base
+	}
+
+	codec, err := CodecByName(name)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := codec.Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s payload: %w", name, err)
+	}
+	return doc, nil
+}
@@ -0,0 +1,59 @@
+package store
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMsgpackCodecRoundTrip(t *testing.T) {
+	doc := []byte(`{"id":"T-1","qty":-300,"big":70000,"price":101.25,"notes":"` + strings.Repeat("long post-mortem ", 4) + `","tags":["breakout",null,true,false],"nested":{"a":1}}`)
+
+	codec := msgpackCodec{}
+	data, err := codec.Encode(doc)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Less(t, len(data), len(doc))
+
+	decoded, err := codec.Decode(data)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.JSONEq(t, string(doc), string(decoded))
+}
+
+func TestEncodePayload(t *testing.T) {
+	l := &Logger{}
+	l.SetPayloadCodec(msgpackCodec{}, 64)
+
+	small := []byte(`{"status":"open"}`)
+	data, name, err := l.encodePayload(small, true)
+	assert.NoError(t, err)
+	assert.Equal(t, CodecMsgpack, name)
+
+	decoded, err := decodePayload(data, name)
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(small), string(decoded))
+
+	entry, _ := json.Marshal(map[string]string{"post_mortem": strings.Repeat("stopped out on the open gap ", 20)})
+	data, name, err = l.encodePayload(entry, true)
+	assert.NoError(t, err)
+	assert.Equal(t, CodecMsgpack+gzipSuffix, name)
+	assert.Less(t, len(data), len(entry))
+
+	decoded, err = decodePayload(data, name)
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(entry), string(decoded))
+
+	// Payloads that are not JSON are kept as they are
+	data, name, err = l.encodePayload([]byte("2026-10-16T09:30:00Z"), false)
+	assert.NoError(t, err)
+	assert.Equal(t, CodecJSON, name)
+	assert.Equal(t, "2026-10-16T09:30:00Z", string(data))
+
+	_, err = decodePayload(data, "protobuf")
+	assert.Error(t, err)
+}
@@ -0,0 +1,144 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// BackupTables are the tables a backup holds, parents before the tables
+// referencing them. schema_migrations is left out: a backup records the
+// schema version instead.
+var BackupTables = []string{
+	"trades",
+	"trade_logs",
+	"indicators",
+	"app_state",
+	"signal_sentiment",
+	"entitlements",
+	"sent_notifications",
+	"archived_records",
+	"signals",
+	"metrics_snapshots",
+	"subscribers",
+	"audit_logs",
+	"trade_journal",
+	"signal_setups",
+	"market_regimes",
+	"intraday_seasonality",
+}
+
+// isBackupTable reports whether table is one of BackupTables
+func isBackupTable(table string) bool {
+	for _, t := range BackupTables {
+		if t == table {
+			return true
+		}
+	}
+	return false
+}
+
+// DumpTable writes every row of a backup table to w as JSON lines, one
+// object per row keyed by column, and returns the number of rows
+func (l *Logger) DumpTable(table string, w io.Writer) (int, error) {
+	if !isBackupTable(table) {
+		return 0, fmt.Errorf("unknown backup table %q", table)
+	}
+
+	rows, err := l.db.Query(fmt.Sprintf(`SELECT row_to_json(t)::text FROM %s t`, table))
+	if err != nil {
+		return 0, fmt.Errorf("failed to dump %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		var row string
+		if err := rows.Scan(&row); err != nil {
+			return count, fmt.Errorf("failed to scan %s row: %w", table, err)
+		}
+		if _, err := io.WriteString(w, row+"\n"); err != nil {
+			return count, fmt.Errorf("failed to write %s row: %w", table, err)
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return count, fmt.Errorf("failed to dump %s: %w", table, err)
+	}
+	return count, nil
+}
+
+// LoadTables replaces the contents of the backup tables with the JSON lines
+// dumped by DumpTable, keyed by table, in one transaction. Tables missing from
+// dumps are emptied, and tables the schema does not have yet are left out.
+// Serial columns continue after the highest restored value.
+func (l *Logger) LoadTables(dumps map[string][]byte) error {
+	for table := range dumps {
+		if !isBackupTable(table) {
+			return fmt.Errorf("unknown backup table %q", table)
+		}
+	}
+
+	ctx := context.Background()
+	tx, err := l.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin restore: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Older schema versions have fewer tables
+	tables := make([]string, 0, len(BackupTables))
+	for _, table := range BackupTables {
+		var exists bool
+		if err := tx.QueryRowContext(ctx, `SELECT to_regclass($1) IS NOT NULL`, table).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to look up %s: %w", table, err)
+		}
+		if exists {
+			tables = append(tables, table)
+		} else if len(dumps[table]) > 0 {
+			return fmt.Errorf("the schema has no %s table to restore", table)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `TRUNCATE `+strings.Join(tables, ", ")); err != nil {
+		return fmt.Errorf("failed to empty tables: %w", err)
+	}
+
+	for _, table := range tables {
+		insert, err := tx.PrepareContext(ctx, fmt.Sprintf(
+			`INSERT INTO %[1]s SELECT * FROM json_populate_record(NULL::%[1]s, $1)`, table))
+		if err != nil {
+			return fmt.Errorf("failed to prepare %s restore: %w", table, err)
+		}
+		scanner := bufio.NewScanner(bytes.NewReader(dumps[table]))
+		scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+		for scanner.Scan() {
+			if _, err := insert.ExecContext(ctx, scanner.Text()); err != nil {
+				insert.Close()
+				return fmt.Errorf("failed to restore %s row: %w", table, err)
+			}
+		}
+		insert.Close()
+		if err := scanner.Err(); err != nil {
+			return fmt.Errorf("failed to read %s dump: %w", table, err)
+		}
+
+		// Move serial sequences past the restored IDs
+		_, err = tx.ExecContext(ctx, `
+			SELECT setval(pg_get_serial_sequence($1, column_name), COALESCE((SELECT MAX(id) FROM `+table+`), 0) + 1, false)
+			FROM information_schema.columns
+			WHERE table_name = $1 AND column_name = 'id' AND column_default LIKE 'nextval%'
+		`, table)
+		if err != nil {
+			return fmt.Errorf("failed to reset %s sequence: %w", table, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit restore: %w", err)
+	}
+	return nil
+}
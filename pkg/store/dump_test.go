@@ -0,0 +1,23 @@
+package store
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackupTablesCoverMigrations(t *testing.T) {
+	migrations, err := Migrations()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	createTable := regexp.MustCompile(`CREATE TABLE (?:IF NOT EXISTS )?(\w+)`)
+	for _, m := range migrations {
+		for _, match := range createTable.FindAllStringSubmatch(m.Up, -1) {
+			assert.True(t, isBackupTable(match[1]), "table %s of migration %s is not backed up", match[1], m.Name)
+		}
+	}
+	assert.False(t, isBackupTable("schema_migrations"))
+}
@@ -0,0 +1,43 @@
+package store
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmbeddedMigrationsAreSequential(t *testing.T) {
+	migrations, err := Migrations()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.GreaterOrEqual(t, len(migrations), 2)
+	for i, m := range migrations {
+		assert.Equal(t, i+1, m.Version, "migration %s", m.Name)
+		assert.NotEmpty(t, m.Up)
+		assert.NotEmpty(t, m.Down)
+	}
+	assert.Contains(t, migrations[1].Up, "CREATE TABLE audit_logs")
+}
+
+func TestLoadMigrationsValidatesFiles(t *testing.T) {
+	migrations, err := loadMigrations(fstest.MapFS{
+		"m/0002_second.up.sql":   {Data: []byte("CREATE TABLE b ();")},
+		"m/0002_second.down.sql": {Data: []byte("DROP TABLE b;")},
+		"m/0001_first.up.sql":    {Data: []byte("CREATE TABLE a ();")},
+		"m/0001_first.down.sql":  {Data: []byte("DROP TABLE a;")},
+		"m/README.md":            {Data: []byte("ignored")},
+	}, "m")
+	assert.NoError(t, err)
+	assert.Len(t, migrations, 2)
+	assert.Equal(t, "first", migrations[0].Name)
+	assert.Equal(t, "DROP TABLE b;", migrations[1].Down)
+
+	_, err = loadMigrations(fstest.MapFS{"m/0001_first.up.sql": {Data: []byte("CREATE TABLE a ();")}}, "m")
+	assert.Error(t, err)
+
+	_, err = loadMigrations(fstest.MapFS{"m/first.up.sql": {Data: []byte("")}}, "m")
+	assert.Error(t, err)
+}
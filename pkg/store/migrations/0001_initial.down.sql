@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS archived_records;
+DROP TABLE IF EXISTS sent_notifications;
+DROP TABLE IF EXISTS entitlements;
+DROP TABLE IF EXISTS signal_sentiment;
+DROP TABLE IF EXISTS app_state;
+DROP TABLE IF EXISTS indicators;
+DROP TABLE IF EXISTS trade_logs;
+DROP TABLE IF EXISTS trades;
@@ -0,0 +1,66 @@
+-- Tables created by InitDB before versioned migrations. IF NOT EXISTS lets
+-- databases created by earlier releases adopt the migration history.
+CREATE TABLE IF NOT EXISTS trades (
+	id VARCHAR(255) PRIMARY KEY,
+	symbol VARCHAR(50) NOT NULL,
+	quantity INT NOT NULL,
+	price DECIMAL(10, 2) NOT NULL,
+	type VARCHAR(10) NOT NULL,
+	status VARCHAR(20) NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	reason TEXT
+);
+
+CREATE TABLE IF NOT EXISTS trade_logs (
+	id SERIAL PRIMARY KEY,
+	trade_id VARCHAR(255) REFERENCES trades(id),
+	event_type VARCHAR(50) NOT NULL,
+	event_data JSONB,
+	created_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS indicators (
+	id SERIAL PRIMARY KEY,
+	symbol VARCHAR(50) NOT NULL,
+	indicator_name VARCHAR(50) NOT NULL,
+	value DECIMAL(10, 4) NOT NULL,
+	timestamp TIMESTAMP NOT NULL,
+	UNIQUE(symbol, indicator_name, timestamp)
+);
+
+CREATE TABLE IF NOT EXISTS app_state (
+	key VARCHAR(255) PRIMARY KEY,
+	value JSONB NOT NULL,
+	updated_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS signal_sentiment (
+	signal_id VARCHAR(255) PRIMARY KEY,
+	thumbs_up INT NOT NULL,
+	thumbs_down INT NOT NULL,
+	updated_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS entitlements (
+	user_id BIGINT PRIMARY KEY,
+	source VARCHAR(50) NOT NULL,
+	expires_at TIMESTAMP,
+	updated_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS sent_notifications (
+	hash CHAR(64) NOT NULL,
+	chat_id VARCHAR(255) NOT NULL,
+	sent_at TIMESTAMP NOT NULL,
+	PRIMARY KEY (hash, chat_id)
+);
+
+CREATE TABLE IF NOT EXISTS archived_records (
+	kind VARCHAR(50) NOT NULL,
+	record_key VARCHAR(255) NOT NULL,
+	data JSONB NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	archived_at TIMESTAMP NOT NULL,
+	PRIMARY KEY (kind, record_key)
+);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS audit_logs;
+DROP TABLE IF EXISTS subscribers;
+DROP TABLE IF EXISTS metrics_snapshots;
+DROP TABLE IF EXISTS signals;
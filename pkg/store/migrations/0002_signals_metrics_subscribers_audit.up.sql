@@ -0,0 +1,51 @@
+CREATE TABLE signals (
+	id VARCHAR(255) PRIMARY KEY,
+	symbol VARCHAR(50) NOT NULL,
+	type VARCHAR(10) NOT NULL,
+	price DECIMAL(12, 4) NOT NULL,
+	target_price DECIMAL(12, 4) NOT NULL,
+	stop_loss DECIMAL(12, 4) NOT NULL,
+	expected_roi DECIMAL(8, 4) NOT NULL,
+	confidence DECIMAL(5, 4) NOT NULL,
+	status VARCHAR(20) NOT NULL,
+	rationale TEXT,
+	technical_data JSONB,
+	generated_at TIMESTAMP NOT NULL,
+	closed_at TIMESTAMP,
+	exit_price DECIMAL(12, 4)
+);
+
+CREATE INDEX signals_symbol_generated_at ON signals (symbol, generated_at);
+
+CREATE TABLE metrics_snapshots (
+	id SERIAL PRIMARY KEY,
+	taken_at TIMESTAMP NOT NULL,
+	signals_count INT NOT NULL,
+	success_count INT NOT NULL,
+	failure_count INT NOT NULL,
+	success_rate DECIMAL(6, 4) NOT NULL,
+	average_roi DECIMAL(8, 4) NOT NULL,
+	data JSONB NOT NULL
+);
+
+CREATE INDEX metrics_snapshots_taken_at ON metrics_snapshots (taken_at);
+
+CREATE TABLE subscribers (
+	user_id BIGINT PRIMARY KEY,
+	chat_id VARCHAR(255) NOT NULL,
+	username VARCHAR(255),
+	tier VARCHAR(20) NOT NULL DEFAULT 'free',
+	subscribed_at TIMESTAMP NOT NULL,
+	unsubscribed_at TIMESTAMP
+);
+
+CREATE TABLE audit_logs (
+	id SERIAL PRIMARY KEY,
+	actor VARCHAR(255) NOT NULL,
+	action VARCHAR(100) NOT NULL,
+	target VARCHAR(255),
+	details JSONB,
+	created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX audit_logs_created_at ON audit_logs (created_at);
@@ -0,0 +1,13 @@
+CREATE TABLE trade_journal (
+	trade_id VARCHAR(255) PRIMARY KEY,
+	symbol VARCHAR(50) NOT NULL,
+	outcome VARCHAR(20) NOT NULL,
+	pnl DECIMAL(12, 4) NOT NULL,
+	post_mortem TEXT,
+	notes TEXT,
+	data JSONB NOT NULL,
+	closed_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX trade_journal_symbol_closed_at ON trade_journal (symbol, closed_at);
@@ -0,0 +1,11 @@
+CREATE TABLE signal_setups (
+	signal_id VARCHAR(255) PRIMARY KEY,
+	symbol VARCHAR(50) NOT NULL,
+	type VARCHAR(10) NOT NULL,
+	embedding JSONB NOT NULL,
+	outcome VARCHAR(20),
+	roi DECIMAL(8, 4),
+	generated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX signal_setups_generated_at ON signal_setups (generated_at);
@@ -0,0 +1,2 @@
+ALTER TABLE signals DROP COLUMN IF EXISTS regime;
+DROP TABLE IF EXISTS market_regimes;
@@ -0,0 +1,11 @@
+CREATE TABLE market_regimes (
+	day DATE PRIMARY KEY,
+	index_symbol VARCHAR(50) NOT NULL,
+	regime VARCHAR(20) NOT NULL,
+	volatility DECIMAL(8, 4) NOT NULL,
+	efficiency DECIMAL(6, 4) NOT NULL,
+	trend DECIMAL(8, 4) NOT NULL,
+	created_at TIMESTAMP NOT NULL
+);
+
+ALTER TABLE signals ADD COLUMN regime VARCHAR(20);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS signals_strategies;
+DROP INDEX IF EXISTS signals_confidence;
+DROP INDEX IF EXISTS signals_status_generated_at;
+DROP INDEX IF EXISTS signals_type_generated_at;
+DROP INDEX IF EXISTS signals_generated_at;
+
+ALTER TABLE signals DROP COLUMN IF EXISTS strategies;
@@ -0,0 +1,7 @@
+ALTER TABLE signals ADD COLUMN strategies TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX signals_generated_at ON signals (generated_at);
+CREATE INDEX signals_type_generated_at ON signals (type, generated_at);
+CREATE INDEX signals_status_generated_at ON signals (status, generated_at);
+CREATE INDEX signals_confidence ON signals (confidence);
+CREATE INDEX signals_strategies ON signals USING GIN (strategies);
@@ -0,0 +1,10 @@
+CREATE TABLE intraday_seasonality (
+	symbol VARCHAR(50) NOT NULL,
+	hour SMALLINT NOT NULL,
+	avg_range DECIMAL(8, 4) NOT NULL,
+	avg_return DECIMAL(8, 4) NOT NULL,
+	reversal_rate DECIMAL(6, 4) NOT NULL,
+	sessions INTEGER NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	PRIMARY KEY (symbol, hour)
+);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS trades_signal_id;
+
+ALTER TABLE trades DROP COLUMN IF EXISTS signal_id;
@@ -0,0 +1,4 @@
+ALTER TABLE trades ADD COLUMN signal_id VARCHAR(255);
+
+-- Each signal produces at most one entry trade, so a replayed signal cannot be traded twice
+CREATE UNIQUE INDEX trades_signal_id ON trades (signal_id) WHERE signal_id IS NOT NULL;
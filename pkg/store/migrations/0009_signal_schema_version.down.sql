@@ -0,0 +1,3 @@
+ALTER TABLE signals DROP COLUMN IF EXISTS order_type;
+ALTER TABLE signals DROP COLUMN IF EXISTS currency;
+ALTER TABLE signals DROP COLUMN IF EXISTS schema_version;
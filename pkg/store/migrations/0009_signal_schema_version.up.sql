@@ -0,0 +1,4 @@
+-- Rows saved before versioning hold schema version 1 signals; they are upgraded when read
+ALTER TABLE signals ADD COLUMN schema_version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE signals ADD COLUMN currency VARCHAR(3);
+ALTER TABLE signals ADD COLUMN order_type VARCHAR(10);
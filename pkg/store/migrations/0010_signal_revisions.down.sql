@@ -0,0 +1 @@
+ALTER TABLE signals DROP COLUMN IF EXISTS revisions;
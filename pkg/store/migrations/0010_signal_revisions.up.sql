@@ -0,0 +1,2 @@
+-- Levels a signal replaced when the market moved before it was published
+ALTER TABLE signals ADD COLUMN revisions JSONB;
@@ -0,0 +1,7 @@
+-- Only JSON payloads convert back; rewrite others with the JSON codec first
+ALTER TABLE trade_journal DROP COLUMN IF EXISTS codec;
+ALTER TABLE trade_journal ALTER COLUMN data TYPE JSONB USING convert_from(data, 'UTF8')::jsonb;
+ALTER TABLE trade_logs DROP COLUMN IF EXISTS codec;
+ALTER TABLE trade_logs ALTER COLUMN event_data TYPE JSONB USING convert_from(event_data, 'UTF8')::jsonb;
+ALTER TABLE app_state DROP COLUMN IF EXISTS codec;
+ALTER TABLE app_state ALTER COLUMN value TYPE JSONB USING convert_from(value, 'UTF8')::jsonb;
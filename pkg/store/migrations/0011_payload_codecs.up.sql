@@ -0,0 +1,8 @@
+-- Payloads are stored as encoded by the codec named alongside them; rows
+-- written before codecs are JSON
+ALTER TABLE app_state ALTER COLUMN value TYPE BYTEA USING convert_to(value::text, 'UTF8');
+ALTER TABLE app_state ADD COLUMN codec VARCHAR(20) NOT NULL DEFAULT 'json';
+ALTER TABLE trade_logs ALTER COLUMN event_data TYPE BYTEA USING convert_to(event_data::text, 'UTF8');
+ALTER TABLE trade_logs ADD COLUMN codec VARCHAR(20) NOT NULL DEFAULT 'json';
+ALTER TABLE trade_journal ALTER COLUMN data TYPE BYTEA USING convert_to(data::text, 'UTF8');
+ALTER TABLE trade_journal ADD COLUMN codec VARCHAR(20) NOT NULL DEFAULT 'json';
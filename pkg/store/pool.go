@@ -0,0 +1,155 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"syscall"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// PoolConfig represents connection pool, timeout and retry settings
+type PoolConfig struct {
+	MaxOpenConns    int           // 0 means unlimited
+	MaxIdleConns    int           // 0 keeps no idle connections
+	ConnMaxLifetime time.Duration // 0 reuses connections forever
+	ConnMaxIdleTime time.Duration // 0 keeps idle connections forever
+	QueryTimeout    time.Duration // Per-attempt timeout; 0 disables it
+	MaxRetries      int           // Retries after a transient error
+	RetryBackoff    time.Duration // Delay before the first retry, doubled on each retry
+}
+
+// DefaultPoolConfig returns pool settings suited to a single bot instance
+func DefaultPoolConfig() PoolConfig {
+	return PoolConfig{
+		MaxOpenConns:    10,
+		MaxIdleConns:    5,
+		ConnMaxLifetime: 30 * time.Minute,
+		ConnMaxIdleTime: 5 * time.Minute,
+		QueryTimeout:    5 * time.Second,
+		MaxRetries:      3,
+		RetryBackoff:    200 * time.Millisecond,
+	}
+}
+
+// SetPoolConfig applies pool settings to the database connection
+func (l *Logger) SetPoolConfig(cfg PoolConfig) {
+	l.pool = cfg
+	l.db.SetMaxOpenConns(cfg.MaxOpenConns)
+	l.db.SetMaxIdleConns(cfg.MaxIdleConns)
+	l.db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	l.db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+}
+
+// withRetry runs op with the query timeout, retrying transient errors with
+// exponential backoff. op must be safe to run more than once.
+func (l *Logger) withRetry(op func(ctx context.Context) error) error {
+	backoff := l.pool.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		err := l.attempt(op)
+		if err == nil || attempt >= l.pool.MaxRetries || !isTransient(err) {
+			return err
+		}
+
+		log.Printf("Transient database error, retrying in %s: %v", backoff, err)
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// attempt runs op once with the query timeout
+func (l *Logger) attempt(op func(ctx context.Context) error) error {
+	ctx := context.Background()
+	if l.pool.QueryTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, l.pool.QueryTimeout)
+		defer cancel()
+	}
+	return op(ctx)
+}
+
+// exec executes a statement with the query timeout and retries
+func (l *Logger) exec(query string, args ...interface{}) (sql.Result, error) {
+	var result sql.Result
+	err := l.withRetry(func(ctx context.Context) error {
+		var err error
+		result, err = l.db.ExecContext(ctx, query, args...)
+		return err
+	})
+	return result, err
+}
+
+// queryRow runs a single-row query and scans it before the timeout is released
+func (l *Logger) queryRow(scan func(*sql.Row) error, query string, args ...interface{}) error {
+	return l.withRetry(func(ctx context.Context) error {
+		return scan(l.db.QueryRowContext(ctx, query, args...))
+	})
+}
+
+// query runs a query and passes the rows to read. read must reset any results
+// it collects, since a retry runs it again.
+func (l *Logger) query(read func(*sql.Rows) error, query string, args ...interface{}) error {
+	return l.withRetry(func(ctx context.Context) error {
+		rows, err := l.db.QueryContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		if err := read(rows); err != nil {
+			return err
+		}
+		return rows.Err()
+	})
+}
+
+// inTx runs fn in a transaction, committing if it succeeds. The whole
+// transaction is retried on transient errors.
+func (l *Logger) inTx(fn func(ctx context.Context, tx *sql.Tx) error) error {
+	return l.withRetry(func(ctx context.Context) error {
+		tx, err := l.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		if err := fn(ctx, tx); err != nil {
+			return err
+		}
+		return tx.Commit()
+	})
+}
+
+// isTransient reports whether err is worth retrying: dropped or refused
+// connections, timeouts, and Postgres errors that succeed when repeated
+func isTransient(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch {
+		case pqErr.Code.Class() == "08": // Connection exception
+			return true
+		case pqErr.Code == "40001", pqErr.Code == "40P01": // Serialization failure, deadlock
+			return true
+		case pqErr.Code == "53300", pqErr.Code == "57P01", pqErr.Code == "57P03": // Too many connections, shutdown, starting up
+			return true
+		}
+	}
+	return false
+}
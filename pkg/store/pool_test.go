@@ -0,0 +1,57 @@
+package store
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTransient(t *testing.T) {
+	assert.True(t, isTransient(driver.ErrBadConn))
+	assert.True(t, isTransient(fmt.Errorf("wrapped: %w", context.DeadlineExceeded)))
+	assert.True(t, isTransient(&pq.Error{Code: "08006"}))
+	assert.True(t, isTransient(&pq.Error{Code: "40001"}))
+	assert.False(t, isTransient(&pq.Error{Code: "23505"})) // Unique violation
+	assert.False(t, isTransient(errors.New("syntax error")))
+}
+
+func TestWithRetryRetriesTransientErrors(t *testing.T) {
+	l := &Logger{pool: PoolConfig{QueryTimeout: time.Second, MaxRetries: 2, RetryBackoff: time.Millisecond}}
+
+	attempts := 0
+	err := l.withRetry(func(ctx context.Context) error {
+		attempts++
+		_, hasDeadline := ctx.Deadline()
+		assert.True(t, hasDeadline)
+		if attempts < 3 {
+			return driver.ErrBadConn
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+
+	// Retries are bounded
+	attempts = 0
+	err = l.withRetry(func(ctx context.Context) error {
+		attempts++
+		return driver.ErrBadConn
+	})
+	assert.ErrorIs(t, err, driver.ErrBadConn)
+	assert.Equal(t, 3, attempts)
+
+	// Permanent errors are not retried
+	attempts = 0
+	err = l.withRetry(func(ctx context.Context) error {
+		attempts++
+		return &pq.Error{Code: "23505"}
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
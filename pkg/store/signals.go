@@ -0,0 +1,243 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/hustler/trading-bot/pkg/replay"
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// signalColumns are the columns a signal is read from, in scan order
+const signalColumns = `id, symbol, type, price, target_price, stop_loss, expected_roi, confidence,
+	status, rationale, technical_data, generated_at, regime, strategies, schema_version, currency, order_type, revisions`
+
+// signalSortColumns maps query sort fields to indexed columns
+var signalSortColumns = map[string]string{
+	signal.SortGeneratedAt: "generated_at",
+	signal.SortConfidence:  "confidence",
+	signal.SortExpectedROI: "expected_roi",
+	signal.SortSymbol:      "symbol",
+}
+
+// SaveSignal saves a signal, updating its levels, status and rationale if it
+// was already saved. It returns ErrDuplicateID if another signal has its ID.
+func (l *Logger) SaveSignal(s *signal.Signal) error {
+	technicalData, err := json.Marshal(s.TechnicalData)
+	if err != nil {
+		return fmt.Errorf("failed to encode technical data: %w", err)
+	}
+
+	strategies := s.Strategies
+	if strategies == nil {
+		strategies = []string{}
+	}
+
+	var revisions interface{}
+	if len(s.Revisions) > 0 {
+		encoded, err := json.Marshal(s.Revisions)
+		if err != nil {
+			return fmt.Errorf("failed to encode revisions: %w", err)
+		}
+		revisions = encoded
+	}
+
+	result, err := l.exec(`
+		INSERT INTO signals (id, symbol, type, price, target_price, stop_loss, expected_roi, confidence,
+			status, rationale, technical_data, generated_at, regime, strategies, schema_version, currency, order_type, revisions)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+		ON CONFLICT (id) DO UPDATE SET
+			target_price = EXCLUDED.target_price,
+			stop_loss = EXCLUDED.stop_loss,
+			expected_roi = EXCLUDED.expected_roi,
+			status = EXCLUDED.status,
+			rationale = EXCLUDED.rationale
+		WHERE signals.symbol = EXCLUDED.symbol AND signals.generated_at = EXCLUDED.generated_at
+	`, s.ID, s.Symbol, string(s.Type), s.Price, s.TargetPrice, s.StopLoss, s.ExpectedROI, s.Confidence,
+		s.Status, s.Rationale, technicalData, s.GeneratedAt, nullString(s.Regime), pq.Array(strategies),
+		signal.CurrentSchemaVersion, nullString(s.Currency), nullString(s.OrderType), revisions)
+	if err != nil {
+		return fmt.Errorf("failed to save signal: %w", err)
+	}
+	if err := checkUpserted(result, s.ID); err != nil {
+		return fmt.Errorf("failed to save signal: %w", err)
+	}
+
+	return nil
+}
+
+// CloseSignal records the final status and exit price of a signal
+func (l *Logger) CloseSignal(s *signal.Signal, exitPrice float64) error {
+	_, err := l.exec(`
+		UPDATE signals SET status = $2, exit_price = $3, closed_at = $4
+		WHERE id = $1
+	`, s.ID, s.Status, exitPrice, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to close signal: %w", err)
+	}
+
+	return nil
+}
+
+// SearchSignals returns the page of saved signals matching q
+func (l *Logger) SearchSignals(q signal.Query) (signal.Page, error) {
+	if err := q.Normalize(); err != nil {
+		return signal.Page{}, err
+	}
+	where, args := signalFilter(q)
+
+	page := signal.Page{Signals: []*signal.Signal{}, Limit: q.Limit, Offset: q.Offset}
+	err := l.queryRow(func(row *sql.Row) error {
+		return row.Scan(&page.Total)
+	}, "SELECT COUNT(*) FROM signals"+where, args...)
+	if err != nil {
+		return signal.Page{}, fmt.Errorf("failed to count signals: %w", err)
+	}
+
+	args = append(args, q.Limit, q.Offset)
+	query := fmt.Sprintf("SELECT %s FROM signals%s ORDER BY %s LIMIT $%d OFFSET $%d",
+		signalColumns, where, signalOrder(q), len(args)-1, len(args))
+
+	err = l.query(func(rows *sql.Rows) error {
+		for rows.Next() {
+			s, err := scanSignal(rows)
+			if err != nil {
+				return err
+			}
+			page.Signals = append(page.Signals, s)
+		}
+		return nil
+	}, query, args...)
+	if err != nil {
+		return signal.Page{}, fmt.Errorf("failed to search signals: %w", err)
+	}
+
+	return page, nil
+}
+
+// SignalHistory returns the signals generated in [from, to) with their exits,
+// oldest first. A zero bound leaves that side of the range open.
+func (l *Logger) SignalHistory(from, to time.Time) ([]replay.Record, error) {
+	where, args := signalFilter(signal.Query{From: from, To: to})
+	query := fmt.Sprintf("SELECT %s, exit_price, closed_at FROM signals%s ORDER BY generated_at ASC, id ASC",
+		signalColumns, where)
+
+	var records []replay.Record
+	err := l.query(func(rows *sql.Rows) error {
+		for rows.Next() {
+			var exitPrice sql.NullFloat64
+			var closedAt sql.NullTime
+			s, err := scanSignal(rows, &exitPrice, &closedAt)
+			if err != nil {
+				return err
+			}
+			records = append(records, replay.Record{Signal: s, ExitPrice: exitPrice.Float64, ClosedAt: closedAt.Time})
+		}
+		return nil
+	}, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load signal history: %w", err)
+	}
+
+	return records, nil
+}
+
+// signalFilter builds the WHERE clause and its arguments for a query. Every
+// condition is on an indexed column.
+func signalFilter(q signal.Query) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	add := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if q.Symbol != "" {
+		add("symbol = $%d", strings.ToUpper(q.Symbol))
+	}
+	if q.Type != "" {
+		add("type = $%d", string(q.Type))
+	}
+	if q.Status != "" {
+		add("status = $%d", string(q.Status))
+	}
+	if q.MinConfidence > 0 {
+		add("confidence >= $%d", q.MinConfidence)
+	}
+	if !q.From.IsZero() {
+		add("generated_at >= $%d", q.From)
+	}
+	if !q.To.IsZero() {
+		add("generated_at < $%d", q.To)
+	}
+	if q.Strategy != "" {
+		add("strategies @> ARRAY[$%d]::TEXT[]", q.Strategy)
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// signalOrder builds the ORDER BY clause for a query, breaking ties by ID so
+// pages are stable
+func signalOrder(q signal.Query) string {
+	direction := "DESC"
+	if q.Ascending {
+		direction = "ASC"
+	}
+	return fmt.Sprintf("%s %s, id %s", signalSortColumns[q.SortBy], direction, direction)
+}
+
+// scanSignal reads a signal from a row of signalColumns, followed by any
+// extra columns into extra
+func scanSignal(rows *sql.Rows, extra ...interface{}) (*signal.Signal, error) {
+	s := &signal.Signal{}
+	var signalType string
+	var rationale, regime, currency, orderType sql.NullString
+	var technicalData, revisions []byte
+	var strategies pq.StringArray
+	dest := append([]interface{}{&s.ID, &s.Symbol, &signalType, &s.Price, &s.TargetPrice, &s.StopLoss, &s.ExpectedROI,
+		&s.Confidence, &s.Status, &rationale, &technicalData, &s.GeneratedAt, &regime, &strategies,
+		&s.SchemaVersion, &currency, &orderType, &revisions}, extra...)
+	if err := rows.Scan(dest...); err != nil {
+		return nil, fmt.Errorf("failed to scan signal: %w", err)
+	}
+
+	s.Type = signal.SignalType(signalType)
+	s.Rationale = rationale.String
+	s.Regime = regime.String
+	s.Currency = currency.String
+	s.OrderType = orderType.String
+	if len(strategies) > 0 {
+		s.Strategies = []string(strategies)
+	}
+	if len(technicalData) > 0 {
+		if err := json.Unmarshal(technicalData, &s.TechnicalData); err != nil {
+			return nil, fmt.Errorf("failed to decode technical data: %w", err)
+		}
+	}
+	if len(revisions) > 0 {
+		if err := json.Unmarshal(revisions, &s.Revisions); err != nil {
+			return nil, fmt.Errorf("failed to decode revisions: %w", err)
+		}
+	}
+	if err := signal.Upgrade(s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// nullString stores empty strings as NULL
+func nullString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
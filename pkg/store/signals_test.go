@@ -0,0 +1,35 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/signal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignalFilter(t *testing.T) {
+	where, args := signalFilter(signal.Query{})
+	assert.Empty(t, where)
+	assert.Empty(t, args)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	where, args = signalFilter(signal.Query{
+		Symbol:        "aapl",
+		Type:          signal.BUY,
+		MinConfidence: 0.7,
+		From:          from,
+		Strategy:      "momentum",
+	})
+	assert.Equal(t, " WHERE symbol = $1 AND type = $2 AND confidence >= $3 AND generated_at >= $4 AND strategies @> ARRAY[$5]::TEXT[]", where)
+	assert.Equal(t, []interface{}{"AAPL", "BUY", 0.7, from, "momentum"}, args)
+}
+
+func TestSignalOrder(t *testing.T) {
+	q := signal.Query{}
+	assert.NoError(t, q.Normalize())
+	assert.Equal(t, "generated_at DESC, id DESC", signalOrder(q))
+
+	q = signal.Query{SortBy: signal.SortConfidence, Ascending: true}
+	assert.Equal(t, "confidence ASC, id ASC", signalOrder(q))
+}
@@ -0,0 +1,163 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// SubscriberData is everything the database stores about a Telegram user
+type SubscriberData struct {
+	Subscriber    *SubscriberRecord    `json:"subscriber,omitempty"`
+	Entitlement   *EntitlementRecord   `json:"entitlement,omitempty"`
+	Notifications []NotificationRecord `json:"notifications"` // Direct messages sent, for deduplication
+	AuditLog      []AuditRecord        `json:"audit_log"`     // Entries where the user is the actor or target
+}
+
+// SubscriberRecord is a row of the subscribers table
+type SubscriberRecord struct {
+	ChatID         string     `json:"chat_id"`
+	Username       string     `json:"username,omitempty"`
+	Tier           string     `json:"tier"`
+	SubscribedAt   time.Time  `json:"subscribed_at"`
+	UnsubscribedAt *time.Time `json:"unsubscribed_at,omitempty"`
+}
+
+// EntitlementRecord is a user's premium access
+type EntitlementRecord struct {
+	Source    string     `json:"source"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// NotificationRecord is a notification sent to the user's chat
+type NotificationRecord struct {
+	Hash   string    `json:"hash"`
+	SentAt time.Time `json:"sent_at"`
+}
+
+// AuditRecord is an audit log entry involving the user
+type AuditRecord struct {
+	Actor     string          `json:"actor"`
+	Action    string          `json:"action"`
+	Target    string          `json:"target,omitempty"`
+	Details   json.RawMessage `json:"details,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// ExportSubscriber returns everything stored about a Telegram user
+func (l *Logger) ExportSubscriber(userID int64) (interface{}, error) {
+	id := strconv.FormatInt(userID, 10)
+	data := &SubscriberData{}
+
+	err := l.query(func(rows *sql.Rows) error {
+		data.Subscriber = nil
+		for rows.Next() {
+			var r SubscriberRecord
+			var username sql.NullString
+			var unsubscribed sql.NullTime
+			if err := rows.Scan(&r.ChatID, &username, &r.Tier, &r.SubscribedAt, &unsubscribed); err != nil {
+				return fmt.Errorf("failed to scan subscriber: %w", err)
+			}
+			r.Username = username.String
+			if unsubscribed.Valid {
+				r.UnsubscribedAt = &unsubscribed.Time
+			}
+			data.Subscriber = &r
+		}
+		return nil
+	}, `SELECT chat_id, username, tier, subscribed_at, unsubscribed_at FROM subscribers WHERE user_id = $1`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query subscriber: %w", err)
+	}
+
+	err = l.query(func(rows *sql.Rows) error {
+		data.Entitlement = nil
+		for rows.Next() {
+			var r EntitlementRecord
+			var expires sql.NullTime
+			if err := rows.Scan(&r.Source, &expires, &r.UpdatedAt); err != nil {
+				return fmt.Errorf("failed to scan entitlement: %w", err)
+			}
+			if expires.Valid {
+				r.ExpiresAt = &expires.Time
+			}
+			data.Entitlement = &r
+		}
+		return nil
+	}, `SELECT source, expires_at, updated_at FROM entitlements WHERE user_id = $1`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query entitlement: %w", err)
+	}
+
+	err = l.query(func(rows *sql.Rows) error {
+		data.Notifications = make([]NotificationRecord, 0)
+		for rows.Next() {
+			var r NotificationRecord
+			if err := rows.Scan(&r.Hash, &r.SentAt); err != nil {
+				return fmt.Errorf("failed to scan notification: %w", err)
+			}
+			data.Notifications = append(data.Notifications, r)
+		}
+		return nil
+	}, `SELECT hash, sent_at FROM sent_notifications WHERE chat_id = $1 ORDER BY sent_at`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query notifications: %w", err)
+	}
+
+	err = l.query(func(rows *sql.Rows) error {
+		data.AuditLog = make([]AuditRecord, 0)
+		for rows.Next() {
+			var r AuditRecord
+			var target sql.NullString
+			var details []byte
+			if err := rows.Scan(&r.Actor, &r.Action, &target, &details, &r.CreatedAt); err != nil {
+				return fmt.Errorf("failed to scan audit log entry: %w", err)
+			}
+			r.Target = target.String
+			r.Details = details
+			data.AuditLog = append(data.AuditLog, r)
+		}
+		return nil
+	}, `
+		SELECT actor, action, target, details, created_at FROM audit_logs
+		WHERE actor = $1 OR target = $1
+		ORDER BY created_at
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query audit log: %w", err)
+	}
+
+	return data, nil
+}
+
+// DeleteSubscriber deletes everything stored about a Telegram user in one transaction
+func (l *Logger) DeleteSubscriber(userID int64) error {
+	id := strconv.FormatInt(userID, 10)
+
+	err := l.inTx(func(ctx context.Context, tx *sql.Tx) error {
+		statements := []struct {
+			query string
+			arg   interface{}
+		}{
+			{`DELETE FROM subscribers WHERE user_id = $1`, userID},
+			{`DELETE FROM entitlements WHERE user_id = $1`, userID},
+			{`DELETE FROM sent_notifications WHERE chat_id = $1`, id},
+			{`DELETE FROM audit_logs WHERE actor = $1 OR target = $1`, id},
+		}
+		for _, s := range statements {
+			if _, err := tx.ExecContext(ctx, s.query, s.arg); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete subscriber data: %w", err)
+	}
+
+	return nil
+}
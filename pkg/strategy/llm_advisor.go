@@ -2,11 +2,11 @@ package strategy
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
-	"os/exec"
 	"strings"
 	"sync"
 	"time"
@@ -32,6 +32,12 @@ type TradeDecision struct {
 	Timestamp time.Time
 	Rationale string
 	Score     float64
+	Strategy  string // Name of the strategy that produced the decision, if any
+
+	TargetPrice float64 // Optional take-profit level for the entry
+	StopPrice   float64 // Optional stop-loss level for the entry
+	MaxQuantity int     // Optional cap on the position size, e.g. from liquidity limits
+	SignalID    string  // Optional ID of the published signal acted on; each signal opens at most one trade
 }
 
 // LLMConfig represents the configuration for the LLM
@@ -39,7 +45,9 @@ type LLMConfig struct {
 	Provider   string // "openai", "anthropic", "deepseek", or "mock"
 	APIKey     string
 	ModelName  string
-	LocalPath  string // Path to local model (for deepseek)
+	LocalEndpoint string        // Base URL of the local inference server (for deepseek); empty uses http://localhost:8000
+	LocalMaxConcurrent int      // Requests in flight to the local server at once; 0 uses 1
+	LocalTimeout  time.Duration // Per request to the local server; 0 uses 2 minutes
 	MaxTokens  int
 	Temperature float64
 }
@@ -48,6 +56,9 @@ type LLMConfig struct {
 type LLMAdvisor struct {
 	config       LLMConfig
 	indicatorProc *indicators.IndicatorProcessor
+	trackRecord  TrackRecordSource // Optional; feeds the bot's own outcomes back into prompts
+	trackRecordCalls int
+	local        *LocalModelClient // Set for the deepseek provider
 	mu           sync.Mutex
 }
 
@@ -75,10 +86,14 @@ func NewLLMAdvisor(config LLMConfig, indicatorProc *indicators.IndicatorProcesso
 		config.Temperature = 0.7
 	}
 
-	return &LLMAdvisor{
+	advisor := &LLMAdvisor{
 		config:       config,
 		indicatorProc: indicatorProc,
 	}
+	if config.Provider == "deepseek" {
+		advisor.local = NewLocalModelClient(config.LocalEndpoint, config.ModelName, config.LocalMaxConcurrent, config.LocalTimeout)
+	}
+	return advisor
 }
 
 // OpenAIRequest represents a request to the OpenAI API
@@ -124,9 +139,6 @@ type AnthropicResponse struct {
 
 // GetTradeAdvice gets trading advice from the LLM
 func (l *LLMAdvisor) GetTradeAdvice(stock *data.Stock) (*TradeDecision, error) {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-
 	// Get all indicators for the stock
 	indicators := l.indicatorProc.GetAllIndicators(stock.Symbol)
 
@@ -159,6 +171,11 @@ Technical Indicators:
 		context += fmt.Sprintf("%s: %.2f\n", name, value)
 	}
 
+	// Add the bot's own record on this stock so advice learns from past outcomes
+	l.mu.Lock()
+	context += l.trackRecordContext(stock.Symbol)
+	l.mu.Unlock()
+
 	// Add prompt for LLM
 	prompt := context + `
 Based on the above market data and technical indicators, provide a trading recommendation (BUY, SELL, or HOLD) for this stock.
@@ -232,6 +249,9 @@ Include a brief rationale for your recommendation. Format your response as JSON
 	}, nil
 }
 
+// advisorSystemPrompt sets up the chat models as trading advisors
+const advisorSystemPrompt = "You are a financial advisor specialized in stock trading. Analyze the provided market data and technical indicators to make a trading recommendation."
+
 // callOpenAI calls the OpenAI API
 func (l *LLMAdvisor) callOpenAI(prompt string) (string, error) {
 	request := OpenAIRequest{
@@ -239,7 +259,7 @@ func (l *LLMAdvisor) callOpenAI(prompt string) (string, error) {
 		Messages: []Message{
 			{
 				Role:    "system",
-				Content: "You are a financial advisor specialized in stock trading. Analyze the provided market data and technical indicators to make a trading recommendation.",
+				Content: advisorSystemPrompt,
 			},
 			{
 				Role:    "user",
@@ -352,25 +372,13 @@ func (l *LLMAdvisor) callAnthropic(prompt string) (string, error) {
 	return anthropicResp.Content[0].Text, nil
 }
 
-// callDeepSeek calls the local DeepSeek model
+// callDeepSeek calls the DeepSeek model on the local inference server. The
+// reply stream is closed as soon as the JSON recommendation is complete.
 func (l *LLMAdvisor) callDeepSeek(prompt string) (string, error) {
-	if l.config.LocalPath == "" {
-		return "", fmt.Errorf("local path for DeepSeek model not specified")
-	}
-
-	// Create a command to run the local DeepSeek model
-	// This is a simplified example - actual implementation would depend on how DeepSeek is deployed
-	cmd := exec.Command(l.config.LocalPath, "--prompt", prompt)
-	
-	var out bytes.Buffer
-	cmd.Stdout = &out
-	
-	err := cmd.Run()
-	if err != nil {
-		return "", fmt.Errorf("failed to run DeepSeek model: %w", err)
+	if l.local == nil {
+		return "", fmt.Errorf("local model client not set up")
 	}
-	
-	return out.String(), nil
+	return l.local.Complete(context.Background(), advisorSystemPrompt, prompt, l.config.MaxTokens, l.config.Temperature, jsonObjectComplete)
 }
 
 // mockLLMResponse generates a mock response for testing
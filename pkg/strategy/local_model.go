@@ -0,0 +1,257 @@
+package strategy
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/errkind"
+)
+
+// Local model client settings used when the config leaves them at zero
+const (
+	defaultLocalEndpoint      = "http://localhost:8000"
+	defaultLocalMaxConcurrent = 1
+	defaultLocalTimeout       = 2 * time.Minute
+)
+
+// localHealthInterval is how long a passed health check is trusted before
+// the server is checked again
+const localHealthInterval = 30 * time.Second
+
+// LocalModelClient calls a local inference server through its OpenAI
+// compatible chat completions endpoint, as served by llama.cpp's server and
+// vLLM. Completions are streamed, and at most a set number of requests are
+// in flight so a server with one model slot isn't queued up.
+type LocalModelClient struct {
+	endpoint     string
+	model        string
+	client       *http.Client
+	slots        chan struct{}
+	healthyUntil time.Time
+	mu           sync.Mutex
+}
+
+// NewLocalModelClient creates a client for the server at endpoint, e.g.
+// http://localhost:8000, running model
+func NewLocalModelClient(endpoint, model string, maxConcurrent int, timeout time.Duration) *LocalModelClient {
+	if endpoint == "" {
+		endpoint = defaultLocalEndpoint
+	}
+	if maxConcurrent <= 0 {
+		maxConcurrent = defaultLocalMaxConcurrent
+	}
+	if timeout <= 0 {
+		timeout = defaultLocalTimeout
+	}
+	return &LocalModelClient{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		model:    model,
+		client:   &http.Client{Timeout: timeout},
+		slots:    make(chan struct{}, maxConcurrent),
+	}
+}
+
+// localStreamChunk is one server-sent event of a streamed chat completion
+type localStreamChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+		Message struct {
+			Content string `json:"content"` // Set instead when the server doesn't stream
+		} `json:"message"`
+		FinishReason string `json:"finish_reason"`
+	} `json:"choices"`
+}
+
+// Health checks that the server is up and its model is loaded. llama.cpp
+// answers 503 while the model is still loading.
+func (c *LocalModelClient) Health(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint+"/health", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create health check: %w", err)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("local model server unreachable: %w", errkind.Unreachable(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("local model server not ready: %w", errkind.Status(resp.StatusCode, strings.TrimSpace(string(body))))
+	}
+	return nil
+}
+
+// checkHealth runs the health check unless one passed recently
+func (c *LocalModelClient) checkHealth(ctx context.Context) error {
+	c.mu.Lock()
+	healthy := time.Now().Before(c.healthyUntil)
+	c.mu.Unlock()
+	if healthy {
+		return nil
+	}
+
+	if err := c.Health(ctx); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.healthyUntil = time.Now().Add(localHealthInterval)
+	c.mu.Unlock()
+	return nil
+}
+
+// markUnhealthy makes the next request check the server's health first
+func (c *LocalModelClient) markUnhealthy() {
+	c.mu.Lock()
+	c.healthyUntil = time.Time{}
+	c.mu.Unlock()
+}
+
+// Complete sends a system and user prompt and returns the streamed reply.
+// done, if not nil, is asked after each streamed piece whether the reply so
+// far is complete; the stream is then closed early, which stops generation.
+// It waits for a free slot when the concurrency limit is reached.
+func (c *LocalModelClient) Complete(ctx context.Context, system, prompt string, maxTokens int, temperature float64, done func(reply string) bool) (string, error) {
+	select {
+	case c.slots <- struct{}{}:
+		defer func() { <-c.slots }()
+	case <-ctx.Done():
+		return "", fmt.Errorf("waiting for a local model slot: %w", ctx.Err())
+	}
+
+	if err := c.checkHealth(ctx); err != nil {
+		return "", err
+	}
+
+	request := struct {
+		OpenAIRequest
+		Stream bool `json:"stream"`
+	}{
+		OpenAIRequest: OpenAIRequest{
+			Model:       c.model,
+			Messages:    []Message{{Role: "system", Content: system}, {Role: "user", Content: prompt}},
+			MaxTokens:   maxTokens,
+			Temperature: temperature,
+		},
+		Stream: true,
+	}
+	requestBody, err := json.Marshal(request)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+"/v1/chat/completions", bytes.NewReader(requestBody))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		c.markUnhealthy()
+		return "", fmt.Errorf("failed to execute request: %w", errkind.Unreachable(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		if resp.StatusCode >= 500 {
+			c.markUnhealthy()
+		}
+		return "", fmt.Errorf("failed to get response: %w", errkind.Status(resp.StatusCode, strings.TrimSpace(string(body))))
+	}
+
+	reply, err := readCompletionStream(resp.Body, done)
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(reply) == "" {
+		return "", fmt.Errorf("empty response from local model")
+	}
+	return reply, nil
+}
+
+// readCompletionStream reads a streamed chat completion: server-sent events
+// of content deltas ending with [DONE]. A server that ignored the stream
+// flag and answered with a single JSON completion is read as well.
+func readCompletionStream(r io.Reader, done func(reply string) bool) (string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var reply strings.Builder
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, ":") {
+			continue // Event separators and keep-alive comments
+		}
+
+		payload, isEvent := strings.CutPrefix(line, "data:")
+		payload = strings.TrimSpace(payload)
+		if payload == "[DONE]" {
+			break
+		}
+		if !isEvent && !strings.HasPrefix(payload, "{") {
+			continue // Other event fields, e.g. "event:" or "id:"
+		}
+
+		var chunk localStreamChunk
+		if err := json.Unmarshal([]byte(payload), &chunk); err != nil {
+			return "", fmt.Errorf("failed to parse stream chunk: %w", err)
+		}
+		finished := false
+		for _, choice := range chunk.Choices {
+			reply.WriteString(choice.Delta.Content)
+			reply.WriteString(choice.Message.Content)
+			finished = finished || choice.FinishReason != ""
+		}
+		if finished || (done != nil && done(reply.String())) {
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("failed to read stream: %w", err)
+	}
+	return reply.String(), nil
+}
+
+// jsonObjectComplete reports whether text holds a complete JSON object,
+// from its first opening brace to the matching closing one
+func jsonObjectComplete(text string) bool {
+	start := strings.IndexByte(text, '{')
+	if start < 0 {
+		return false
+	}
+
+	depth := 0
+	inString, escaped := false, false
+	for _, ch := range text[start:] {
+		switch {
+		case escaped:
+			escaped = false
+		case inString && ch == '\\':
+			escaped = true
+		case ch == '"':
+			inString = !inString
+		case inString:
+		case ch == '{':
+			depth++
+		case ch == '}':
+			depth--
+			if depth == 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package strategy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/errkind"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalModelClientStreams(t *testing.T) {
+	var loaded atomic.Bool
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" {
+			if !loaded.Load() {
+				http.Error(w, `{"error":"Loading model"}`, http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte(`{"status":"ok"}`))
+			return
+		}
+
+		var req struct {
+			Model  string `json:"model"`
+			Stream bool   `json:"stream"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		assert.Equal(t, "deepseek-coder", req.Model)
+		assert.True(t, req.Stream)
+
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, piece := range []string{`{\"signal\": \"BUY\", `, `\"rationale\": \"RSI {oversold}\"`, `}`, ` and some trailing chatter`} {
+			fmt.Fprintf(w, "data: {\"choices\":[{\"delta\":{\"content\":\"%s\"}}]}\n\n", piece)
+		}
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer server.Close()
+
+	client := NewLocalModelClient(server.URL, "deepseek-coder", 2, time.Second)
+
+	// Requests fail until the model is loaded
+	_, err := client.Complete(context.Background(), "system", "prompt", 100, 0.7, nil)
+	assert.ErrorIs(t, err, errkind.ErrProviderDown)
+
+	loaded.Store(true)
+	reply, err := client.Complete(context.Background(), "system", "prompt", 100, 0.7, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"signal": "BUY", "rationale": "RSI {oversold}"} and some trailing chatter`, reply)
+
+	// The stream is closed once the JSON object is complete
+	reply, err = client.Complete(context.Background(), "system", "prompt", 100, 0.7, jsonObjectComplete)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"signal": "BUY", "rationale": "RSI {oversold}"}`, reply)
+
+	// No more than the limit are in flight at once
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.Complete(context.Background(), "system", "prompt", 100, 0.7, nil)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(2), atomic.LoadInt32(&maxInFlight))
+}
+
+func TestReadCompletionStreamWithoutStreaming(t *testing.T) {
+	reply, err := readCompletionStream(strings.NewReader(`{"choices":[{"message":{"content":"HOLD"},"finish_reason":"stop"}]}`), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "HOLD", reply)
+
+	_, err = readCompletionStream(strings.NewReader("data: {not json\n\n"), nil)
+	assert.Error(t, err)
+}
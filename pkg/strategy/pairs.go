@@ -0,0 +1,46 @@
+package strategy
+
+import (
+	"fmt"
+
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// LegDecision is one leg of a pair trade
+type LegDecision struct {
+	Symbol string
+	Signal TradeSignal // Buy for the long leg, Sell for the short leg
+	Ratio  float64     // Shares per share of the first leg
+}
+
+// PairDecision is a decision to trade two symbols against each other. Both
+// legs are entered together and exited together.
+type PairDecision struct {
+	Pair      string
+	Legs      []LegDecision
+	Rationale string
+	Strategy  string
+	SignalID  string // Optional ID of the pair signal acted on; each signal opens at most one pair trade
+}
+
+// NewPairDecision returns the decision to enter a pair signal
+func NewPairDecision(s *signal.PairSignal) (*PairDecision, error) {
+	if len(s.Legs) != 2 {
+		return nil, fmt.Errorf("pair signal %s has %d legs, want 2", s.ID, len(s.Legs))
+	}
+
+	decision := &PairDecision{
+		Pair:      s.Pair,
+		Rationale: fmt.Sprintf("Spread z-score %+.2f, hedge ratio %.3f", s.ZScore, s.HedgeRatio),
+		Strategy:  s.Strategy,
+		SignalID:  s.ID,
+	}
+	for _, leg := range s.Legs {
+		side := Buy
+		if leg.Type == signal.SELL {
+			side = Sell
+		}
+		decision.Legs = append(decision.Legs, LegDecision{Symbol: leg.Symbol, Signal: side, Ratio: leg.Ratio})
+	}
+	return decision, nil
+}
@@ -0,0 +1,69 @@
+package strategy
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hustler/trading-bot/pkg/performance"
+)
+
+// defaultTrackRecordCalls is the number of recent calls per signal type summarized in prompts
+const defaultTrackRecordCalls = 5
+
+// TrackRecordSource provides the outcomes of the bot's recent calls (implemented by performance.Monitor)
+type TrackRecordSource interface {
+	TrackRecord(symbol string, limit int) []performance.CallRecord
+}
+
+// SetTrackRecord feeds the bot's recent win/loss record on each symbol into the
+// advice prompt. calls is the number of recent calls summarized per signal type;
+// zero uses the default.
+func (l *LLMAdvisor) SetTrackRecord(source TrackRecordSource, calls int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if calls <= 0 {
+		calls = defaultTrackRecordCalls
+	}
+	l.trackRecord = source
+	l.trackRecordCalls = calls
+}
+
+// trackRecordContext returns the prompt section describing the bot's record on
+// symbol, or an empty string when there is no record. Caller must hold the lock.
+func (l *LLMAdvisor) trackRecordContext(symbol string) string {
+	if l.trackRecord == nil {
+		return ""
+	}
+	return formatTrackRecord(symbol, l.trackRecord.TrackRecord(symbol, l.trackRecordCalls))
+}
+
+// formatTrackRecord describes call records in plain sentences for the LLM
+func formatTrackRecord(symbol string, records []performance.CallRecord) string {
+	var b strings.Builder
+	for _, r := range records {
+		if r.Calls == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&b, "- Your last %d %s %s calls: %d made money, %d lost money, average ROI %.2f%%.",
+			r.Calls, symbol, r.Type, r.Wins, r.Losses, r.AverageROI)
+		switch {
+		case r.Streak == r.Calls && r.Calls > 1:
+			fmt.Fprintf(&b, " All of them made money.")
+		case -r.Streak == r.Calls && r.Calls > 1:
+			fmt.Fprintf(&b, " All of them lost money.")
+		case r.Streak > 1:
+			fmt.Fprintf(&b, " The last %d made money.", r.Streak)
+		case r.Streak < -1:
+			fmt.Fprintf(&b, " The last %d lost money.", -r.Streak)
+		}
+		b.WriteString("\n")
+	}
+
+	if b.Len() == 0 {
+		return ""
+	}
+	return "\nYour Recent Track Record:\n" + b.String() +
+		"Weigh this record in your recommendation: be more cautious about repeating calls that have been losing money.\n"
+}
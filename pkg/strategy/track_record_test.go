@@ -0,0 +1,19 @@
+package strategy
+
+import (
+	"testing"
+
+	"github.com/hustler/trading-bot/pkg/performance"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatTrackRecord(t *testing.T) {
+	assert.Empty(t, formatTrackRecord("AAPL", nil))
+
+	text := formatTrackRecord("AAPL", []performance.CallRecord{
+		{Symbol: "AAPL", Type: "BUY", Calls: 5, Wins: 0, Losses: 5, AverageROI: -2.1, Streak: -5},
+		{Symbol: "AAPL", Type: "SELL", Calls: 3, Wins: 2, Losses: 1, AverageROI: 0.8, Streak: 2},
+	})
+	assert.Contains(t, text, "Your last 5 AAPL BUY calls: 0 made money, 5 lost money, average ROI -2.10%. All of them lost money.")
+	assert.Contains(t, text, "Your last 3 AAPL SELL calls: 2 made money, 1 lost money, average ROI 0.80%. The last 2 made money.")
+}
@@ -0,0 +1,123 @@
+package stream
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// subscriberBuffer is the number of events queued per client before
+	// events are dropped for that client
+	subscriberBuffer = 16
+	// keepAliveInterval is how often an idle stream gets a comment so proxies
+	// keep the connection open
+	keepAliveInterval = 30 * time.Second
+)
+
+// event is a named server-sent event
+type event struct {
+	name string
+	data []byte
+}
+
+// Hub fans events out to server-sent event (SSE) clients
+type Hub struct {
+	subscribers map[chan event]struct{}
+	mu          sync.RWMutex
+}
+
+// NewHub creates a new Hub
+func NewHub() *Hub {
+	return &Hub{
+		subscribers: make(map[chan event]struct{}),
+	}
+}
+
+// Subscribers returns the number of connected clients
+func (h *Hub) Subscribers() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.subscribers)
+}
+
+// Publish sends v as JSON to every connected client under the event name.
+// Clients too slow to keep up miss the event rather than blocking others.
+func (h *Hub) Publish(name string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", name, err)
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for ch := range h.subscribers {
+		select {
+		case ch <- event{name: name, data: data}:
+		default:
+		}
+	}
+	return nil
+}
+
+// ServeHTTP streams published events to the client until it disconnects
+func (h *Hub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	ch := h.subscribe()
+	defer h.unsubscribe(ch)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case e := <-ch:
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.name, e.data); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// subscribe registers a new client
+func (h *Hub) subscribe() chan event {
+	ch := make(chan event, subscriberBuffer)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.subscribers[ch] = struct{}{}
+	return ch
+}
+
+// unsubscribe removes a client
+func (h *Hub) unsubscribe(ch chan event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.subscribers, ch)
+}
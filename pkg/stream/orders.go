@@ -0,0 +1,53 @@
+package stream
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hustler/trading-bot/pkg/execution"
+)
+
+// EventOrder is the name of the event carrying an execution.OrderUpdate
+const EventOrder = "order"
+
+// OrderHooks reports order status changes as the broker pushes them
+// (implemented by execution.PaperBroker and execution.SafeBroker)
+type OrderHooks interface {
+	OnOrderUpdate(fn func(execution.OrderUpdate))
+}
+
+// MessageSender sends a message to subscribers (implemented by telegram.Bot)
+type MessageSender interface {
+	SendMessage(message string) error
+}
+
+// StreamOrders publishes every order update to the hub's clients as it
+// happens. Fills, partial fills and cancels are also sent to sender, if any.
+func (h *Hub) StreamOrders(hooks OrderHooks, sender MessageSender) {
+	hooks.OnOrderUpdate(func(update execution.OrderUpdate) {
+		if err := h.Publish(EventOrder, update); err != nil {
+			log.Printf("Error streaming %s event: %v", EventOrder, err)
+		}
+		if sender == nil || update.Status == execution.OrderRejected {
+			return
+		}
+		if err := sender.SendMessage(FormatOrderUpdate(update)); err != nil {
+			log.Printf("Error sending order update for %s: %v", update.Symbol, err)
+		}
+	})
+}
+
+// FormatOrderUpdate formats an order update for Telegram
+func FormatOrderUpdate(update execution.OrderUpdate) string {
+	switch update.Status {
+	case execution.OrderFilled:
+		return fmt.Sprintf("✅ <b>FILLED</b> %s %d %s at $%.2f", update.Side, update.Filled, update.Symbol, update.Price)
+	case execution.OrderPartiallyFilled:
+		return fmt.Sprintf("◐ <b>PARTIAL FILL</b> %s %d of %d %s at $%.2f",
+			update.Side, update.Filled, update.Quantity, update.Symbol, update.Price)
+	case execution.OrderCancelled:
+		return fmt.Sprintf("✖️ <b>CANCELLED</b> %s %s order %s (%s)", update.Side, update.Symbol, update.OrderID, update.Reason)
+	default:
+		return fmt.Sprintf("<b>%s</b> %s %d %s", update.Status, update.Side, update.Quantity, update.Symbol)
+	}
+}
@@ -0,0 +1,40 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/hustler/trading-bot/pkg/execution"
+	"github.com/hustler/trading-bot/pkg/strategy"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingHooks struct {
+	fn func(execution.OrderUpdate)
+}
+
+func (h *recordingHooks) OnOrderUpdate(fn func(execution.OrderUpdate)) { h.fn = fn }
+
+type recordingSender struct {
+	messages []string
+}
+
+func (s *recordingSender) SendMessage(message string) error {
+	s.messages = append(s.messages, message)
+	return nil
+}
+
+func TestStreamOrdersNotifiesFillsAndCancels(t *testing.T) {
+	hooks := &recordingHooks{}
+	sender := &recordingSender{}
+	NewHub().StreamOrders(hooks, sender)
+
+	hooks.fn(execution.OrderUpdate{Symbol: "AAPL", Side: strategy.Buy, Status: execution.OrderPartiallyFilled, Quantity: 100, Filled: 60, Price: 101.5})
+	hooks.fn(execution.OrderUpdate{Symbol: "AAPL", Side: strategy.Buy, Status: execution.OrderRejected, Quantity: 40})
+	hooks.fn(execution.OrderUpdate{OrderID: "BRK-1", Symbol: "MSFT", Side: strategy.Sell, Status: execution.OrderCancelled, Reason: "target and stop cancelled"})
+
+	// Rejects only go to the dashboard
+	assert.Len(t, sender.messages, 2)
+	assert.Contains(t, sender.messages[0], "BUY 60 of 100 AAPL at $101.50")
+	assert.Contains(t, sender.messages[1], "CANCELLED")
+	assert.Contains(t, sender.messages[1], "BRK-1")
+}
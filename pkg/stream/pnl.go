@@ -0,0 +1,77 @@
+package stream
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/execution"
+	"github.com/hustler/trading-bot/pkg/market"
+)
+
+// PnLEvent is the name of the event carrying execution.PnLSnapshot
+const PnLEvent = "pnl"
+
+// PnLSource values open positions (implemented by execution.TradeManager)
+type PnLSource interface {
+	PnL(prices map[string]float64, since time.Time) execution.PnLSnapshot
+}
+
+// QuoteSource provides the latest quotes (implemented by data.MarketWatcher)
+type QuoteSource interface {
+	GetAllStocks() []*data.Stock
+}
+
+// PnLPublisher publishes live P&L snapshots to a hub at a fixed frequency
+type PnLPublisher struct {
+	hub      *Hub
+	source   PnLSource
+	quotes   QuoteSource
+	interval time.Duration
+	session  *market.Clock
+}
+
+// NewPnLPublisher creates a new PnLPublisher
+func NewPnLPublisher(hub *Hub, source PnLSource, quotes QuoteSource, interval time.Duration) *PnLPublisher {
+	return &PnLPublisher{
+		hub:      hub,
+		source:   source,
+		quotes:   quotes,
+		interval: interval,
+		session:  market.DefaultClock(),
+	}
+}
+
+// Run publishes a snapshot every interval until ctx is done. Ticks without
+// connected clients are skipped.
+func (p *PnLPublisher) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if p.hub.Subscribers() == 0 {
+				continue
+			}
+			if err := p.Publish(now); err != nil {
+				log.Printf("Error publishing P&L: %v", err)
+			}
+		}
+	}
+}
+
+// Publish sends the P&L at the latest quotes, with realized P&L counted from
+// the start of the trading day of now
+func (p *PnLPublisher) Publish(now time.Time) error {
+	prices := make(map[string]float64)
+	for _, stock := range p.quotes.GetAllStocks() {
+		prices[stock.Symbol] = stock.CurrentPrice
+	}
+
+	snapshot := p.source.PnL(prices, p.session.TradingDay(now))
+	return p.hub.Publish(PnLEvent, snapshot)
+}
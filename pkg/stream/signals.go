@@ -0,0 +1,48 @@
+package stream
+
+import (
+	"log"
+
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// Signal event names
+const (
+	EventSignal         = "signal"          // A new signal was published
+	EventSignalAdjusted = "signal_adjusted" // An active signal's target or stop changed
+	EventSignalClosed   = "signal_closed"   // A signal hit its target or stop, or expired
+)
+
+// SignalEvent is the data of a signal event
+type SignalEvent struct {
+	Signal    *signal.Signal `json:"signal"`
+	ExitPrice float64        `json:"exit_price,omitempty"` // Set for closed signals
+}
+
+// SignalHooks reports signal lifecycle changes (implemented by monitor.MarketMonitor)
+type SignalHooks interface {
+	OnSignalPublished(fn func(*signal.Signal))
+	OnSignalAdjusted(fn func(*signal.Signal))
+	OnSignalClosed(fn func(s *signal.Signal, exitPrice float64))
+}
+
+// StreamSignals publishes every new, adjusted and closed signal to the hub's clients
+func (h *Hub) StreamSignals(hooks SignalHooks) {
+	hooks.OnSignalPublished(func(s *signal.Signal) {
+		h.publishSignal(EventSignal, SignalEvent{Signal: s})
+	})
+	hooks.OnSignalAdjusted(func(s *signal.Signal) {
+		h.publishSignal(EventSignalAdjusted, SignalEvent{Signal: s})
+	})
+	hooks.OnSignalClosed(func(s *signal.Signal, exitPrice float64) {
+		h.publishSignal(EventSignalClosed, SignalEvent{Signal: s, ExitPrice: exitPrice})
+	})
+}
+
+// publishSignal publishes a signal event, logging failures so signal delivery
+// is never blocked by streaming
+func (h *Hub) publishSignal(name string, e SignalEvent) {
+	if err := h.Publish(name, e); err != nil {
+		log.Printf("Error streaming %s event: %v", name, err)
+	}
+}
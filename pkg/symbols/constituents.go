@@ -0,0 +1,188 @@
+package symbols
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+)
+
+// ComponentsSuffix marks a watchlist entry that expands to an ETF's or
+// index's holdings, e.g. QQQ:components
+const ComponentsSuffix = ":components"
+
+// ConstituentsSource provides the holdings of an ETF or index
+type ConstituentsSource interface {
+	Constituents(ctx context.Context, etf string) ([]string, error)
+}
+
+// HTTPConstituents fetches holdings CSVs from a URL template
+type HTTPConstituents struct {
+	url    string // Contains {symbol}
+	client *http.Client
+}
+
+// NewHTTPConstituents creates a new HTTPConstituents
+func NewHTTPConstituents(url string) *HTTPConstituents {
+	return &HTTPConstituents{
+		url:    url,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Constituents downloads and parses the holdings of etf
+func (h *HTTPConstituents) Constituents(ctx context.Context, etf string) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", strings.ReplaceAll(h.url, "{symbol}", etf), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Add("User-Agent", "Mozilla/5.0")
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get constituents of %s, status: %d", etf, resp.StatusCode)
+	}
+	return ParseCSV(resp.Body)
+}
+
+// holdings are the cached constituents of one ETF
+type holdings struct {
+	symbols   []string
+	fetchedAt time.Time
+}
+
+// Expander replaces ETF:components watchlist entries with the ETF's current
+// holdings, refetching them once the cache is older than the refresh interval
+type Expander struct {
+	source  ConstituentsSource
+	refresh time.Duration
+	cache   map[string]holdings
+	now     func() time.Time
+	mu      sync.Mutex
+}
+
+// NewExpander creates an expander fetching holdings from the configured URL
+func NewExpander(cfg config.ConstituentsConfig) *Expander {
+	var source ConstituentsSource
+	if cfg.URL != "" {
+		source = NewHTTPConstituents(cfg.URL)
+	}
+	return NewExpanderWithSource(source, time.Duration(cfg.RefreshHours)*time.Hour)
+}
+
+// NewExpanderWithSource creates an expander fetching holdings from source
+func NewExpanderWithSource(source ConstituentsSource, refresh time.Duration) *Expander {
+	return &Expander{
+		source:  source,
+		refresh: refresh,
+		cache:   make(map[string]holdings),
+		now:     time.Now,
+	}
+}
+
+// Expand returns symbols with every ETF:components entry replaced by the
+// ETF's holdings, without duplicates. When holdings can't be fetched the last
+// known holdings are used; entries never fetched are dropped.
+func (e *Expander) Expand(symbols []string) []string {
+	seen := make(map[string]bool)
+	expanded := make([]string, 0, len(symbols))
+	add := func(symbol string) {
+		if !seen[symbol] {
+			seen[symbol] = true
+			expanded = append(expanded, symbol)
+		}
+	}
+
+	for _, symbol := range symbols {
+		etf, ok := componentsOf(symbol)
+		if !ok {
+			add(symbol)
+			continue
+		}
+		for _, holding := range e.holdings(etf) {
+			add(holding)
+		}
+	}
+	return expanded
+}
+
+// holdings returns the cached holdings of etf, refreshing them when stale
+func (e *Expander) holdings(etf string) []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	cached, ok := e.cache[etf]
+	now := e.now()
+	if ok && now.Sub(cached.fetchedAt) < e.refresh {
+		return cached.symbols
+	}
+	if e.source == nil {
+		log.Printf("Cannot expand %s%s: no constituents source configured", etf, ComponentsSuffix)
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	fetched, err := e.source.Constituents(ctx, etf)
+	if err != nil {
+		log.Printf("Error fetching constituents of %s, using last known holdings: %v", etf, err)
+		return cached.symbols
+	}
+
+	var valid []string
+	for _, symbol := range fetched {
+		if tickerPattern.MatchString(symbol) {
+			valid = append(valid, symbol)
+		}
+	}
+	if ok {
+		logHoldingChanges(etf, cached.symbols, valid)
+	}
+	e.cache[etf] = holdings{symbols: valid, fetchedAt: now}
+	return valid
+}
+
+// componentsOf returns the ETF of an ETF:components entry
+func componentsOf(symbol string) (string, bool) {
+	if !strings.HasSuffix(strings.ToLower(symbol), ComponentsSuffix) {
+		return "", false
+	}
+	etf := normalize(symbol[:len(symbol)-len(ComponentsSuffix)])
+	return etf, etf != ""
+}
+
+// logHoldingChanges logs symbols added to and removed from an ETF's holdings
+func logHoldingChanges(etf string, previous, current []string) {
+	before := make(map[string]bool, len(previous))
+	for _, symbol := range previous {
+		before[symbol] = true
+	}
+	var added []string
+	for _, symbol := range current {
+		if !before[symbol] {
+			added = append(added, symbol)
+		}
+		delete(before, symbol)
+	}
+	var removed []string
+	for symbol := range before {
+		removed = append(removed, symbol)
+	}
+	sort.Strings(removed)
+
+	if len(added) > 0 || len(removed) > 0 {
+		log.Printf("Holdings of %s changed: added %v, removed %v", etf, added, removed)
+	}
+}
@@ -0,0 +1,60 @@
+package symbols
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeConstituents serves holdings per ETF and counts fetches
+type fakeConstituents struct {
+	holdings map[string][]string
+	fetches  int
+	fail     bool
+}
+
+func (f *fakeConstituents) Constituents(ctx context.Context, etf string) ([]string, error) {
+	f.fetches++
+	if f.fail {
+		return nil, fmt.Errorf("source unavailable")
+	}
+	return f.holdings[etf], nil
+}
+
+func TestExpanderExpandsComponents(t *testing.T) {
+	source := &fakeConstituents{holdings: map[string][]string{"QQQ": {"AAPL", "MSFT", "NVDA", "-"}}}
+	now := time.Date(2025, 4, 21, 14, 0, 0, 0, time.UTC)
+	expander := NewExpanderWithSource(source, 24*time.Hour)
+	expander.now = func() time.Time { return now }
+
+	assert.Equal(t, []string{"TSLA", "AAPL", "MSFT", "NVDA"}, expander.Expand([]string{"TSLA", "qqq:components", "AAPL"}))
+	assert.Equal(t, 1, source.fetches)
+
+	// Cached until the refresh interval passes
+	expander.Expand([]string{"QQQ:components"})
+	assert.Equal(t, 1, source.fetches)
+
+	// Holdings changes are picked up on refresh
+	now = now.Add(25 * time.Hour)
+	source.holdings["QQQ"] = []string{"AAPL", "AVGO"}
+	assert.Equal(t, []string{"AAPL", "AVGO"}, expander.Expand([]string{"QQQ:components"}))
+
+	// The last known holdings are kept while the source is failing
+	now = now.Add(25 * time.Hour)
+	source.fail = true
+	assert.Equal(t, []string{"AAPL", "AVGO"}, expander.Expand([]string{"QQQ:components"}))
+
+	// Entries that were never fetched are dropped
+	assert.Equal(t, []string{"TSLA"}, expander.Expand([]string{"SPY:components", "TSLA"}))
+}
+
+func TestParseCSVSkipsPreamble(t *testing.T) {
+	holdings := "Fund Holdings as of,\"Apr 17, 2025\"\nInception Date,\"Sep 01, 2000\"\n\nTicker,Name,Weight (%)\nAAPL,APPLE INC,7.1\nMSFT,MICROSOFT CORP,6.5\n"
+	symbols, err := ParseCSV(strings.NewReader(holdings))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"AAPL", "MSFT"}, symbols)
+}
@@ -0,0 +1,256 @@
+package symbols
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+)
+
+// Import limits used when the config leaves them unset
+const (
+	defaultImportMaxSymbols   = 500
+	defaultImportMaxBytes     = 1 << 20
+	defaultImportFetchTimeout = 15 * time.Second
+)
+
+// tickerPattern matches plausible tickers such as AAPL, BRK.B or RDS-A
+var tickerPattern = regexp.MustCompile(`^[A-Z][A-Z0-9.\-]{0,9}$`)
+
+// maxPreambleRows is how many lines may precede the header of a CSV
+const maxPreambleRows = 10
+
+// symbolHeaders are CSV column headers that hold tickers, as used by Finviz,
+// TradingView and broker exports
+var symbolHeaders = map[string]bool{"ticker": true, "symbol": true, "symbols": true, "tickers": true}
+
+// QuoteValidator fetches market data to confirm symbols exist (implemented by data.Provider)
+type QuoteValidator interface {
+	GetMarketDataBatch(symbols []string) (map[string]*data.MarketData, map[string]error)
+}
+
+// Watchlist is the set of symbols the bot checks (implemented by monitor.MarketMonitor)
+type Watchlist interface {
+	WatchedSymbols() []string
+	AddSymbols(symbols []string) []string
+}
+
+// ImportResult reports what happened to each symbol of an import
+type ImportResult struct {
+	Added    []string          `json:"added"`
+	Existing []string          `json:"existing"` // Already on the watchlist
+	Rejected map[string]string `json:"rejected"` // Symbol -> reason
+}
+
+// Summary formats the result as a short message
+func (r ImportResult) Summary() string {
+	message := fmt.Sprintf("Imported %d symbols", len(r.Added))
+	if len(r.Added) > 0 {
+		message += ": " + strings.Join(r.Added, ", ")
+	}
+	message += "."
+	if len(r.Existing) > 0 {
+		message += fmt.Sprintf("\n%d already on the watchlist.", len(r.Existing))
+	}
+	if len(r.Rejected) > 0 {
+		rejected := make([]string, 0, len(r.Rejected))
+		for symbol, reason := range r.Rejected {
+			rejected = append(rejected, fmt.Sprintf("%s (%s)", symbol, reason))
+		}
+		sort.Strings(rejected)
+		message += fmt.Sprintf("\nRejected %d: %s.", len(rejected), strings.Join(rejected, ", "))
+	}
+	return message
+}
+
+// Importer adds symbols from CSV uploads or URLs to the watchlist after
+// checking the data provider has quotes for them
+type Importer struct {
+	validator  QuoteValidator
+	watchlist  Watchlist
+	maxSymbols int
+	maxBytes   int64
+	client     *http.Client
+}
+
+// NewImporter creates a new Importer
+func NewImporter(cfg config.WatchlistImportConfig, validator QuoteValidator, watchlist Watchlist) *Importer {
+	importer := &Importer{
+		validator:  validator,
+		watchlist:  watchlist,
+		maxSymbols: cfg.MaxSymbols,
+		maxBytes:   cfg.MaxBytes,
+		client:     &http.Client{Timeout: time.Duration(cfg.FetchTimeoutSeconds) * time.Second},
+	}
+	if importer.maxSymbols == 0 {
+		importer.maxSymbols = defaultImportMaxSymbols
+	}
+	if importer.maxBytes == 0 {
+		importer.maxBytes = defaultImportMaxBytes
+	}
+	if importer.client.Timeout == 0 {
+		importer.client.Timeout = defaultImportFetchTimeout
+	}
+	return importer
+}
+
+// ImportCSV imports the symbols of a CSV file
+func (i *Importer) ImportCSV(r io.Reader) (ImportResult, error) {
+	body, err := io.ReadAll(io.LimitReader(r, i.maxBytes+1))
+	if err != nil {
+		return ImportResult{}, fmt.Errorf("failed to read CSV: %w", err)
+	}
+	if int64(len(body)) > i.maxBytes {
+		return ImportResult{}, fmt.Errorf("CSV is larger than %d bytes", i.maxBytes)
+	}
+
+	symbols, err := ParseCSV(strings.NewReader(string(body)))
+	if err != nil {
+		return ImportResult{}, err
+	}
+	return i.Import(symbols)
+}
+
+// ImportURL downloads a CSV, such as a Finviz screener export, and imports its symbols
+func (i *Importer) ImportURL(ctx context.Context, url string) (ImportResult, error) {
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return ImportResult{}, fmt.Errorf("URL must be http or https")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return ImportResult{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Add("User-Agent", "Mozilla/5.0")
+
+	resp, err := i.client.Do(req)
+	if err != nil {
+		return ImportResult{}, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return ImportResult{}, fmt.Errorf("failed to download watchlist, status: %d", resp.StatusCode)
+	}
+	return i.ImportCSV(resp.Body)
+}
+
+// Import validates symbols against the data provider and adds the valid ones
+// to the watchlist
+func (i *Importer) Import(symbols []string) (ImportResult, error) {
+	if len(symbols) == 0 {
+		return ImportResult{}, fmt.Errorf("no symbols to import")
+	}
+	if len(symbols) > i.maxSymbols {
+		return ImportResult{}, fmt.Errorf("too many symbols: %d, the limit is %d", len(symbols), i.maxSymbols)
+	}
+
+	result := ImportResult{Added: []string{}, Existing: []string{}, Rejected: make(map[string]string)}
+	watched := make(map[string]bool)
+	for _, symbol := range i.watchlist.WatchedSymbols() {
+		watched[symbol] = true
+	}
+
+	seen := make(map[string]bool)
+	var candidates []string
+	for _, symbol := range symbols {
+		symbol = normalize(symbol)
+		if symbol == "" || seen[symbol] {
+			continue
+		}
+		seen[symbol] = true
+
+		switch {
+		case !tickerPattern.MatchString(symbol):
+			result.Rejected[symbol] = "invalid ticker"
+		case watched[symbol]:
+			result.Existing = append(result.Existing, symbol)
+		default:
+			candidates = append(candidates, symbol)
+		}
+	}
+
+	var valid []string
+	if len(candidates) > 0 {
+		quotes, errs := i.validator.GetMarketDataBatch(candidates)
+		for _, symbol := range candidates {
+			md := quotes[symbol]
+			switch {
+			case errs[symbol] != nil:
+				result.Rejected[symbol] = "no market data"
+			case md == nil || len(md.Prices) == 0:
+				result.Rejected[symbol] = "no quotes"
+			default:
+				valid = append(valid, symbol)
+			}
+		}
+	}
+
+	if len(valid) > 0 {
+		result.Added = append(result.Added, i.watchlist.AddSymbols(valid)...)
+	}
+	return result, nil
+}
+
+// ParseCSV returns the unique, upper-cased tickers of a CSV file. The column
+// headed Ticker or Symbol is used when present, otherwise the first column.
+// The header may follow a few preamble lines, as in fund holdings files.
+func ParseCSV(r io.Reader) ([]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("CSV is empty")
+	}
+
+	row, column := findSymbolColumn(records)
+	if row >= 0 {
+		records = records[row+1:]
+	} else {
+		column = 0
+	}
+
+	seen := make(map[string]bool)
+	var symbols []string
+	for _, record := range records {
+		if column >= len(record) {
+			continue
+		}
+		symbol := normalize(record[column])
+		if symbol == "" || seen[symbol] {
+			continue
+		}
+		seen[symbol] = true
+		symbols = append(symbols, symbol)
+	}
+	return symbols, nil
+}
+
+// findSymbolColumn returns the row and column of the Ticker or Symbol header
+// within the first maxPreambleRows rows, or -1 when there is no header
+func findSymbolColumn(records [][]string) (int, int) {
+	for i, record := range records {
+		if i > maxPreambleRows {
+			break
+		}
+		for j, header := range record {
+			if symbolHeaders[strings.ToLower(strings.TrimSpace(header))] {
+				return i, j
+			}
+		}
+	}
+	return -1, -1
+}
@@ -0,0 +1,97 @@
+package symbols
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeQuotes has market data for a fixed set of symbols
+type fakeQuotes map[string]bool
+
+func (f fakeQuotes) GetMarketDataBatch(symbols []string) (map[string]*data.MarketData, map[string]error) {
+	results := make(map[string]*data.MarketData)
+	errs := make(map[string]error)
+	for _, symbol := range symbols {
+		if f[symbol] {
+			results[symbol] = &data.MarketData{Symbol: symbol, Prices: []float64{100}}
+		} else {
+			errs[symbol] = fmt.Errorf("unknown symbol")
+		}
+	}
+	return results, errs
+}
+
+// fakeWatchlist is an in-memory Watchlist
+type fakeWatchlist struct {
+	symbols []string
+}
+
+func (f *fakeWatchlist) WatchedSymbols() []string { return f.symbols }
+
+func (f *fakeWatchlist) AddSymbols(symbols []string) []string {
+	f.symbols = append(f.symbols, symbols...)
+	return symbols
+}
+
+func TestParseCSV(t *testing.T) {
+	finviz := "\"No.\",\"Ticker\",\"Company\"\n\"1\",\"aapl\",\"Apple Inc.\"\n\"2\",\"MSFT\",\"Microsoft\"\n\"3\",\"AAPL\",\"Apple Inc.\"\n"
+	symbols, err := ParseCSV(strings.NewReader(finviz))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"AAPL", "MSFT"}, symbols)
+
+	symbols, err = ParseCSV(strings.NewReader("NVDA\nAMD\n\nTSLA\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"NVDA", "AMD", "TSLA"}, symbols)
+
+	_, err = ParseCSV(strings.NewReader(""))
+	assert.Error(t, err)
+}
+
+func TestImporterValidatesSymbols(t *testing.T) {
+	watchlist := &fakeWatchlist{symbols: []string{"AAPL"}}
+	importer := NewImporter(config.WatchlistImportConfig{}, fakeQuotes{"AAPL": true, "MSFT": true}, watchlist)
+
+	result, err := importer.ImportCSV(strings.NewReader("Symbol\nAAPL\nMSFT\nZZZZ\nNOT A TICKER\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"MSFT"}, result.Added)
+	assert.Equal(t, []string{"AAPL"}, result.Existing)
+	assert.Equal(t, map[string]string{"ZZZZ": "no market data", "NOT A TICKER": "invalid ticker"}, result.Rejected)
+	assert.Equal(t, []string{"AAPL", "MSFT"}, watchlist.symbols)
+	assert.Contains(t, result.Summary(), "Imported 1 symbols: MSFT.")
+
+	importer = NewImporter(config.WatchlistImportConfig{MaxSymbols: 1}, fakeQuotes{}, watchlist)
+	_, err = importer.Import([]string{"AMD", "NVDA"})
+	assert.Error(t, err)
+}
+
+func TestImporterImportURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/export.csv" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("Ticker,Price\nAMD,150\n"))
+	}))
+	defer server.Close()
+
+	watchlist := &fakeWatchlist{}
+	importer := NewImporter(config.WatchlistImportConfig{}, fakeQuotes{"AMD": true}, watchlist)
+
+	result, err := importer.ImportURL(context.Background(), server.URL+"/export.csv")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"AMD"}, result.Added)
+
+	_, err = importer.ImportURL(context.Background(), server.URL+"/missing.csv")
+	assert.Error(t, err)
+
+	_, err = importer.ImportURL(context.Background(), "file:///etc/passwd")
+	assert.Error(t, err)
+}
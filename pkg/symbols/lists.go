@@ -0,0 +1,233 @@
+package symbols
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// List names
+const (
+	Blacklist = "blacklist" // Never trade or signal the symbol
+	Allowlist = "allowlist" // When not empty, only these symbols are traded or signalled
+)
+
+// listsStateKey is the app state key the lists are persisted under
+const listsStateKey = "symbol_lists"
+
+// StateStore is a key/value store for application state (implemented by store.Logger)
+type StateStore interface {
+	SaveAppState(key string, value []byte) error
+	LoadAppState(key string) ([]byte, error)
+}
+
+// Entry is a symbol on the blacklist or allowlist
+type Entry struct {
+	Symbol    string    `json:"symbol"`
+	List      string    `json:"list"`
+	Reason    string    `json:"reason"`
+	AddedBy   string    `json:"added_by"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at,omitempty"` // Zero means the entry never expires
+}
+
+// Expired reports whether the entry has expired at now
+func (e Entry) Expired(now time.Time) bool {
+	return !e.ExpiresAt.IsZero() && !now.Before(e.ExpiresAt)
+}
+
+// Lists manages the symbol blacklist and allowlist enforced across screening,
+// signal generation and execution
+type Lists struct {
+	entries map[string]map[string]Entry // List name -> symbol -> entry
+	store   StateStore
+	now     func() time.Time
+	mu      sync.RWMutex
+}
+
+// NewLists creates empty symbol lists
+func NewLists() *Lists {
+	return &Lists{
+		entries: map[string]map[string]Entry{
+			Blacklist: make(map[string]Entry),
+			Allowlist: make(map[string]Entry),
+		},
+		now: time.Now,
+	}
+}
+
+// SetStore persists the lists to store and restores entries saved by a previous run
+func (l *Lists) SetStore(store StateStore) error {
+	data, err := store.LoadAppState(listsStateKey)
+	if err != nil {
+		return fmt.Errorf("failed to load symbol lists: %w", err)
+	}
+
+	var restored []Entry
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &restored); err != nil {
+			return fmt.Errorf("failed to parse symbol lists: %w", err)
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.store = store
+	for _, entry := range restored {
+		if list, ok := l.entries[entry.List]; ok {
+			list[entry.Symbol] = entry
+		}
+	}
+	l.persist()
+
+	return nil
+}
+
+// Add puts a symbol on a list, replacing any existing entry for it on that list
+func (l *Lists) Add(list, symbol, reason, addedBy string, expiresAt time.Time) (Entry, error) {
+	symbol = normalize(symbol)
+	if symbol == "" {
+		return Entry{}, fmt.Errorf("symbol is required")
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entries, ok := l.entries[list]
+	if !ok {
+		return Entry{}, fmt.Errorf("unknown list: %s", list)
+	}
+
+	now := l.now()
+	if !expiresAt.IsZero() && !expiresAt.After(now) {
+		return Entry{}, fmt.Errorf("expiry must be in the future")
+	}
+
+	entry := Entry{
+		Symbol:    symbol,
+		List:      list,
+		Reason:    reason,
+		AddedBy:   addedBy,
+		CreatedAt: now,
+		ExpiresAt: expiresAt,
+	}
+	entries[symbol] = entry
+	l.persist()
+
+	return entry, nil
+}
+
+// Remove takes a symbol off a list
+func (l *Lists) Remove(list, symbol string) error {
+	symbol = normalize(symbol)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entries, ok := l.entries[list]
+	if !ok {
+		return fmt.Errorf("unknown list: %s", list)
+	}
+	if _, ok := entries[symbol]; !ok {
+		return fmt.Errorf("%s is not on the %s", symbol, list)
+	}
+
+	delete(entries, symbol)
+	l.persist()
+	return nil
+}
+
+// Entries returns the unexpired entries of a list, sorted by symbol. An empty
+// list name returns the entries of both lists.
+func (l *Lists) Entries(list string) []Entry {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	now := l.now()
+	result := make([]Entry, 0)
+	for name, entries := range l.entries {
+		if list != "" && name != list {
+			continue
+		}
+		for _, entry := range entries {
+			if !entry.Expired(now) {
+				result = append(result, entry)
+			}
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].List != result[j].List {
+			return result[i].List < result[j].List
+		}
+		return result[i].Symbol < result[j].Symbol
+	})
+	return result
+}
+
+// Allowed reports whether a symbol may be signalled or traded. Blacklisted
+// symbols are never allowed; when the allowlist has entries only those symbols
+// are allowed. The reason explains a refusal.
+func (l *Lists) Allowed(symbol string) (bool, string) {
+	symbol = normalize(symbol)
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	now := l.now()
+	if entry, ok := l.entries[Blacklist][symbol]; ok && !entry.Expired(now) {
+		if entry.Reason != "" {
+			return false, fmt.Sprintf("%s is blacklisted: %s", symbol, entry.Reason)
+		}
+		return false, fmt.Sprintf("%s is blacklisted", symbol)
+	}
+
+	allowlistActive := false
+	for _, entry := range l.entries[Allowlist] {
+		if !entry.Expired(now) {
+			allowlistActive = true
+			break
+		}
+	}
+	if !allowlistActive {
+		return true, ""
+	}
+
+	if entry, ok := l.entries[Allowlist][symbol]; ok && !entry.Expired(now) {
+		return true, ""
+	}
+	return false, fmt.Sprintf("%s is not on the allowlist", symbol)
+}
+
+// persist saves all entries to the store. Caller must hold the lock.
+func (l *Lists) persist() {
+	if l.store == nil {
+		return
+	}
+
+	all := make([]Entry, 0)
+	for _, entries := range l.entries {
+		for _, entry := range entries {
+			all = append(all, entry)
+		}
+	}
+
+	data, err := json.Marshal(all)
+	if err != nil {
+		log.Printf("Error encoding symbol lists: %v", err)
+		return
+	}
+	if err := l.store.SaveAppState(listsStateKey, data); err != nil {
+		log.Printf("Error saving symbol lists: %v", err)
+	}
+}
+
+// normalize upper-cases and trims a ticker
+func normalize(symbol string) string {
+	return strings.ToUpper(strings.TrimSpace(symbol))
+}
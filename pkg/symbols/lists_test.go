@@ -0,0 +1,70 @@
+package symbols
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryStore is an in-memory StateStore
+type memoryStore map[string][]byte
+
+func (m memoryStore) SaveAppState(key string, value []byte) error {
+	m[key] = value
+	return nil
+}
+
+func (m memoryStore) LoadAppState(key string) ([]byte, error) {
+	return m[key], nil
+}
+
+func TestListsEnforceBlacklistAndAllowlist(t *testing.T) {
+	now := time.Date(2025, 4, 21, 14, 0, 0, 0, time.UTC)
+	lists := NewLists()
+	lists.now = func() time.Time { return now }
+
+	allowed, _ := lists.Allowed("AAPL")
+	assert.True(t, allowed)
+
+	_, err := lists.Add(Blacklist, "gme", "meme volatility", "admin", now.Add(24*time.Hour))
+	assert.NoError(t, err)
+	allowed, reason := lists.Allowed("GME")
+	assert.False(t, allowed)
+	assert.Contains(t, reason, "meme volatility")
+
+	// An allowlist restricts trading to its symbols
+	_, err = lists.Add(Allowlist, "AAPL", "", "admin", time.Time{})
+	assert.NoError(t, err)
+	allowed, _ = lists.Allowed("AAPL")
+	assert.True(t, allowed)
+	allowed, reason = lists.Allowed("MSFT")
+	assert.False(t, allowed)
+	assert.Contains(t, reason, "allowlist")
+
+	// Expired entries stop applying
+	now = now.Add(25 * time.Hour)
+	assert.Len(t, lists.Entries(Blacklist), 0)
+	assert.NoError(t, lists.Remove(Allowlist, "aapl"))
+	allowed, _ = lists.Allowed("GME")
+	assert.True(t, allowed)
+
+	_, err = lists.Add("graylist", "AAPL", "", "admin", time.Time{})
+	assert.Error(t, err)
+	assert.Error(t, lists.Remove(Blacklist, "TSLA"))
+}
+
+func TestListsPersist(t *testing.T) {
+	store := memoryStore{}
+	lists := NewLists()
+	assert.NoError(t, lists.SetStore(store))
+	_, err := lists.Add(Blacklist, "GME", "halted", "admin", time.Time{})
+	assert.NoError(t, err)
+
+	restored := NewLists()
+	assert.NoError(t, restored.SetStore(store))
+	entries := restored.Entries("")
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "GME", entries[0].Symbol)
+	assert.Equal(t, "halted", entries[0].Reason)
+}
@@ -0,0 +1,232 @@
+package symbols
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+)
+
+// UnknownSector groups symbols without a known sector
+const UnknownSector = "Unknown"
+
+// Metadata is the reference data of one symbol
+type Metadata struct {
+	Symbol    string    `json:"symbol"`
+	Name      string    `json:"name"`
+	Exchange  string    `json:"exchange"`
+	Sector    string    `json:"sector"`
+	Industry  string    `json:"industry"`
+	LotSize   int       `json:"lot_size"` // Shares per board lot; orders are sized in multiples of it
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// MetadataSource provides the reference data of a symbol
+type MetadataSource interface {
+	Metadata(ctx context.Context, symbol string) (Metadata, error)
+}
+
+// FinnhubMetadata fetches company profiles from Finnhub
+type FinnhubMetadata struct {
+	apiKey string
+	client *http.Client
+}
+
+// NewFinnhubMetadata creates a new FinnhubMetadata
+func NewFinnhubMetadata(apiKey string) *FinnhubMetadata {
+	return &FinnhubMetadata{
+		apiKey: apiKey,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Metadata fetches the company profile of symbol. Finnhub only classifies
+// companies by industry, which is reported as the sector.
+func (f *FinnhubMetadata) Metadata(ctx context.Context, symbol string) (Metadata, error) {
+	if f.apiKey == "" {
+		return Metadata{}, fmt.Errorf("no finnhub API key configured")
+	}
+
+	params := url.Values{}
+	params.Add("symbol", symbol)
+	params.Add("token", f.apiKey)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://finnhub.io/api/v1/stock/profile2?"+params.Encode(), nil)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Metadata{}, fmt.Errorf("failed to get profile of %s, status: %d", symbol, resp.StatusCode)
+	}
+
+	var profile struct {
+		Name     string `json:"name"`
+		Exchange string `json:"exchange"`
+		Industry string `json:"finnhubIndustry"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&profile); err != nil {
+		return Metadata{}, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if profile.Name == "" {
+		return Metadata{}, fmt.Errorf("no profile found for %s", symbol)
+	}
+
+	return Metadata{
+		Symbol:   symbol,
+		Name:     profile.Name,
+		Exchange: profile.Exchange,
+		Sector:   profile.Industry,
+	}, nil
+}
+
+// MetadataService caches symbol reference data, refetching it once older
+// than the refresh interval and applying configured overrides on top
+type MetadataService struct {
+	config config.MetadataConfig
+	source MetadataSource
+	cache  map[string]Metadata
+	now    func() time.Time
+	mu     sync.Mutex
+}
+
+// NewMetadataService creates a metadata service fetching from source, which
+// may be nil when only configured overrides are used
+func NewMetadataService(cfg config.MetadataConfig, source MetadataSource) *MetadataService {
+	return &MetadataService{
+		config: cfg,
+		source: source,
+		cache:  make(map[string]Metadata),
+		now:    time.Now,
+	}
+}
+
+// Lookup returns the reference data of symbol and whether any is known. When
+// it can't be refreshed the last fetched data is used.
+func (s *MetadataService) Lookup(symbol string) (Metadata, bool) {
+	symbol = normalize(symbol)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cached, ok := s.cache[symbol]
+	now := s.now()
+	if s.source != nil && (!ok || now.Sub(cached.UpdatedAt) >= time.Duration(s.config.RefreshHours)*time.Hour) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		fetched, err := s.source.Metadata(ctx, symbol)
+		cancel()
+		if err != nil {
+			log.Printf("Error fetching metadata of %s, using last known: %v", symbol, err)
+		} else {
+			fetched.Symbol = symbol
+			fetched.UpdatedAt = now
+			cached, ok = fetched, true
+			s.cache[symbol] = fetched
+		}
+	}
+
+	override, overridden := s.config.Overrides[symbol]
+	if !ok && !overridden {
+		return Metadata{Symbol: symbol, Sector: UnknownSector, LotSize: s.lotSize(0)}, false
+	}
+	return s.apply(cached, override, symbol), true
+}
+
+// apply fills in the configured override and defaults
+func (s *MetadataService) apply(metadata Metadata, override config.SymbolMetadataConfig, symbol string) Metadata {
+	metadata.Symbol = symbol
+	if override.Name != "" {
+		metadata.Name = override.Name
+	}
+	if override.Exchange != "" {
+		metadata.Exchange = override.Exchange
+	}
+	if override.Sector != "" {
+		metadata.Sector = override.Sector
+	}
+	if override.Industry != "" {
+		metadata.Industry = override.Industry
+	}
+	if metadata.Sector == "" {
+		metadata.Sector = UnknownSector
+	}
+	if override.LotSize > 0 {
+		metadata.LotSize = override.LotSize
+	}
+	metadata.LotSize = s.lotSize(metadata.LotSize)
+	return metadata
+}
+
+// lotSize returns lot, or the configured default when it is unset
+func (s *MetadataService) lotSize(lot int) int {
+	if lot > 0 {
+		return lot
+	}
+	if s.config.DefaultLotSize > 0 {
+		return s.config.DefaultLotSize
+	}
+	return 1
+}
+
+// MetadataLookup provides symbol reference data (implemented by MetadataService)
+type MetadataLookup interface {
+	Lookup(symbol string) (Metadata, bool)
+}
+
+// SectorWeight is the share of a portfolio's value in one sector
+type SectorWeight struct {
+	Sector  string   `json:"sector"`
+	Value   float64  `json:"value"`
+	Percent float64  `json:"percent"`
+	Symbols []string `json:"symbols"`
+}
+
+// SectorExposure groups position values keyed by symbol into sectors,
+// largest first. Symbols without a known sector are grouped as Unknown.
+func SectorExposure(values map[string]float64, lookup MetadataLookup) []SectorWeight {
+	bySector := make(map[string]*SectorWeight)
+	var total float64
+	for symbol, value := range values {
+		sector := UnknownSector
+		if metadata, ok := lookup.Lookup(symbol); ok && metadata.Sector != "" {
+			sector = metadata.Sector
+		}
+		weight, ok := bySector[sector]
+		if !ok {
+			weight = &SectorWeight{Sector: sector}
+			bySector[sector] = weight
+		}
+		weight.Value += value
+		weight.Symbols = append(weight.Symbols, symbol)
+		total += value
+	}
+
+	exposure := make([]SectorWeight, 0, len(bySector))
+	for _, weight := range bySector {
+		if total > 0 {
+			weight.Percent = weight.Value / total * 100
+		}
+		sort.Strings(weight.Symbols)
+		exposure = append(exposure, *weight)
+	}
+	sort.Slice(exposure, func(i, j int) bool {
+		if exposure[i].Value != exposure[j].Value {
+			return exposure[i].Value > exposure[j].Value
+		}
+		return exposure[i].Sector < exposure[j].Sector
+	})
+	return exposure
+}
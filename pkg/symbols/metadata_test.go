@@ -0,0 +1,91 @@
+package symbols
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeMetadata serves profiles per symbol and counts fetches
+type fakeMetadata struct {
+	profiles map[string]Metadata
+	fetches  int
+	fail     bool
+}
+
+func (f *fakeMetadata) Metadata(ctx context.Context, symbol string) (Metadata, error) {
+	f.fetches++
+	profile, ok := f.profiles[symbol]
+	if f.fail || !ok {
+		return Metadata{}, fmt.Errorf("no profile for %s", symbol)
+	}
+	return profile, nil
+}
+
+func TestMetadataServiceCachesAndOverrides(t *testing.T) {
+	source := &fakeMetadata{profiles: map[string]Metadata{
+		"AAPL": {Name: "Apple Inc", Exchange: "NASDAQ", Sector: "Technology"},
+		"7203": {Name: "Toyota Motor Corp", Exchange: "TSE", Sector: "Automobiles"},
+	}}
+	cfg := config.MetadataConfig{
+		RefreshHours:   24,
+		DefaultLotSize: 1,
+		Overrides: map[string]config.SymbolMetadataConfig{
+			"AAPL": {Industry: "Consumer Electronics"},
+			"7203": {LotSize: 100},
+		},
+	}
+	now := time.Date(2025, 4, 21, 14, 0, 0, 0, time.UTC)
+	service := NewMetadataService(cfg, source)
+	service.now = func() time.Time { return now }
+
+	apple, ok := service.Lookup("aapl")
+	assert.True(t, ok)
+	assert.Equal(t, "Apple Inc", apple.Name)
+	assert.Equal(t, "Technology", apple.Sector)
+	assert.Equal(t, "Consumer Electronics", apple.Industry)
+	assert.Equal(t, 1, apple.LotSize)
+
+	toyota, _ := service.Lookup("7203")
+	assert.Equal(t, 100, toyota.LotSize)
+
+	// Cached until the refresh interval passes
+	service.Lookup("AAPL")
+	assert.Equal(t, 2, source.fetches)
+
+	// The last known data is kept while the source is failing
+	now = now.Add(25 * time.Hour)
+	source.fail = true
+	apple, ok = service.Lookup("AAPL")
+	assert.True(t, ok)
+	assert.Equal(t, "Apple Inc", apple.Name)
+	assert.Equal(t, 3, source.fetches)
+
+	unknown, ok := service.Lookup("ZZZZ")
+	assert.False(t, ok)
+	assert.Equal(t, UnknownSector, unknown.Sector)
+	assert.Equal(t, 1, unknown.LotSize)
+}
+
+func TestSectorExposure(t *testing.T) {
+	source := &fakeMetadata{profiles: map[string]Metadata{
+		"AAPL": {Name: "Apple Inc", Sector: "Technology"},
+		"MSFT": {Name: "Microsoft Corp", Sector: "Technology"},
+		"XOM":  {Name: "Exxon Mobil Corp", Sector: "Energy"},
+	}}
+	service := NewMetadataService(config.MetadataConfig{RefreshHours: 24}, source)
+
+	exposure := SectorExposure(map[string]float64{"AAPL": 3000, "MSFT": 3000, "XOM": 3000, "ZZZZ": 1000}, service)
+	assert.Len(t, exposure, 3)
+	assert.Equal(t, "Technology", exposure[0].Sector)
+	assert.Equal(t, 6000.0, exposure[0].Value)
+	assert.Equal(t, 60.0, exposure[0].Percent)
+	assert.Equal(t, []string{"AAPL", "MSFT"}, exposure[0].Symbols)
+	assert.Equal(t, "Energy", exposure[1].Sector)
+	assert.Equal(t, UnknownSector, exposure[2].Sector)
+	assert.Equal(t, []string{"ZZZZ"}, exposure[2].Symbols)
+}
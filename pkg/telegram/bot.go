@@ -3,14 +3,22 @@ package telegram
 import (
 	"fmt"
 	"log"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/hustler/trading-bot/pkg/alerts"
+	"github.com/hustler/trading-bot/pkg/anomaly"
 	"github.com/hustler/trading-bot/pkg/config"
 	"github.com/hustler/trading-bot/pkg/signal"
 )
 
+// SignalAdjuster changes an active signal's target or stop (implemented by monitor.MarketMonitor)
+type SignalAdjuster interface {
+	AdjustSignal(signalID, level string, value float64) (*signal.Signal, error)
+}
+
 // Bot represents a Telegram bot for sending trading signals
 type Bot struct {
 	config      config.TelegramConfig
@@ -18,6 +26,38 @@ type Bot struct {
 	mockMessages []string
 	subscribers  map[int64]bool
 	adminUsers   map[int64]bool
+	api          *Client
+	updateOffset int
+	alertEngine  *alerts.Engine
+	adjuster     SignalAdjuster
+	signalMsgs   map[string][]sentMessage // Signal ID -> channel messages, until the signal closes
+	msgSignals   map[messageRef]string    // Channel message -> signal ID, for reactions
+	closed       map[string]bool          // Signals that reached their final status
+	explained    map[string]*signal.Signal // Signal ID -> signal with its late explanation, for messages sent after it
+	reactions    map[string]*reactionTally
+	sentiment    []SentimentRecorder
+	subscription config.SubscriptionConfig
+	entitlements EntitlementStore
+	queue        *Queue
+	queueStop    chan struct{}
+	ledger       DedupLedger
+	symbolLists  SymbolLists
+	importer     WatchlistImporter
+	watchlistChannels map[string]map[string]bool // Watchlist name -> channels its signals are sent to
+	dataRequests DataRequests
+	testSignals  TestSignalSender
+	ideas        IdeaDesk
+	focus        FocusWatcher
+	tradeDesk    TradeDesk
+	quotes       QuoteLookup
+	preferences  map[int64]Preferences      // Subscribers who finished onboarding
+	onboarding   map[int64]*onboardingState // Subscribers in the preferences wizard
+	directCounts map[int64]dailyCount       // Signals sent directly to each subscriber today
+	digests      map[int64]*pendingDigest   // Signals batched for subscribers with a digest
+	held         []heldMessage              // Messages held until quiet hours end
+	preferenceStore StateStore
+	commands     map[string]*Command // Command name -> command
+	commandOrder []string            // Command names in registration order, for /help
 	mu           sync.RWMutex
 }
 
@@ -33,18 +73,51 @@ func NewBotWithMode(config config.TelegramConfig, mockMode bool) *Bot {
 		adminUsers[id] = true
 	}
 
-	return &Bot{
+	var api *Client
+	if config.BotToken != "" && !mockMode {
+		api = NewClient(config.BotToken)
+	}
+
+	b := &Bot{
 		config:      config,
 		mockMode:    mockMode,
 		mockMessages: []string{},
 		subscribers:  make(map[int64]bool),
 		adminUsers:   adminUsers,
+		api:          api,
+		signalMsgs:   make(map[string][]sentMessage),
+		msgSignals:   make(map[messageRef]string),
+		closed:       make(map[string]bool),
+		explained:    make(map[string]*signal.Signal),
+		reactions:    make(map[string]*reactionTally),
+		preferences:  make(map[int64]Preferences),
+		onboarding:   make(map[int64]*onboardingState),
+		directCounts: make(map[int64]dailyCount),
+		digests:      make(map[int64]*pendingDigest),
+		commands:     make(map[string]*Command),
 		mu:           sync.RWMutex{},
 	}
+	b.registerBuiltinCommands()
+	return b
 }
 
 // SendMessage sends a message to the configured Telegram channel
 func (b *Bot) SendMessage(message string) error {
+	b.mu.RLock()
+	channelID := b.config.ChannelID
+	b.mu.RUnlock()
+
+	return b.SendChannelMessage(channelID, message)
+}
+
+// SendChannelMessage sends a message to a channel other than the configured
+// one, e.g. when replaying the history into a fresh channel
+func (b *Bot) SendChannelMessage(channelID, message string) error {
+	message = b.withDisclaimer(message)
+	msg := OutboundMessage{ChatID: channelID, Text: message, ParseMode: "HTML"}
+	if b.holdUntil(msg, b.channelQuietHours(channelID).QuietUntil(time.Now(), "")) {
+		return nil
+	}
 	if b.mockMode {
 		b.mu.Lock()
 		b.mockMessages = append(b.mockMessages, message)
@@ -53,45 +126,265 @@ func (b *Bot) SendMessage(message string) error {
 		return nil
 	}
 
-	// In a real implementation, this would use the Telegram Bot API
-	// to send the message to the configured channel
-	log.Printf("Would send to Telegram: %s", message)
-	
-	// TODO: Implement actual Telegram API call
-	// Example:
-	// url := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", b.config.BotToken)
-	// payload := map[string]interface{}{
-	//     "chat_id": b.config.ChannelID,
-	//     "text": message,
-	//     "parse_mode": "HTML",
-	// }
-	// ... HTTP POST request with payload
+	b.mu.RLock()
+	api := b.api
+	b.mu.RUnlock()
+
+	if api == nil {
+		log.Printf("Would send to Telegram: %s", message)
+		return nil
+	}
+
+	if b.enqueue(&msg) {
+		return nil
+	}
+
+	if _, err := api.SendMessage(channelID, message, "HTML"); err != nil {
+		return fmt.Errorf("failed to send Telegram message: %w", err)
+	}
 
 	return nil
 }
 
-// SendSignal formats and sends a trading signal via Telegram
+// SendDirectMessage sends an HTML message to a single user's chat, holding it
+// during the user's quiet hours
+func (b *Bot) SendDirectMessage(chatID int64, message string) error {
+	return b.sendDirectMessage(chatID, OutboundMessage{ChatID: formatChatID(chatID), Text: message, ParseMode: "HTML"})
+}
+
+// sendDirectMessage sends a prepared message to a single user's chat, holding
+// it during the user's quiet hours
+func (b *Bot) sendDirectMessage(chatID int64, msg OutboundMessage) error {
+	if b.holdUntil(msg, b.subscriberQuietUntil(chatID, time.Now())) {
+		return nil
+	}
+	return b.sendOutbound(&msg)
+}
+
+// sendDirect sends a message to a single chat with the given parse mode
+func (b *Bot) sendDirect(chatID int64, message, parseMode string) error {
+	if b.mockMode {
+		b.mu.Lock()
+		b.mockMessages = append(b.mockMessages, message)
+		b.mu.Unlock()
+		log.Printf("[MOCK] Telegram message sent to %d: %s", chatID, message)
+		return nil
+	}
+
+	b.mu.RLock()
+	api := b.api
+	b.mu.RUnlock()
+
+	if api == nil {
+		log.Printf("Would send to Telegram user %d: %s", chatID, message)
+		return nil
+	}
+
+	if b.enqueue(&OutboundMessage{ChatID: formatChatID(chatID), Text: message, ParseMode: parseMode}) {
+		return nil
+	}
+
+	if _, err := api.SendMessage(formatChatID(chatID), message, parseMode); err != nil {
+		return fmt.Errorf("failed to send Telegram message: %w", err)
+	}
+
+	return nil
+}
+
+// SendSignal formats and sends a trading signal to every channel whose routing
+// rules accept it. Channels with a delay receive the signal later in the background.
+// Each channel's stripped fields are withheld and the disclaimer is appended.
 func (b *Bot) SendSignal(s *signal.Signal) error {
-	message := signal.FormatSignalMessage(s)
-	return b.SendMessage(message)
+	snapshot := *s
+
+	var failed []string
+	for _, ch := range b.signalChannels() {
+		if !b.routesSignal(ch, &snapshot) {
+			continue
+		}
+		markup := signal.MarkupFor(ch.ParseMode)
+		message := b.withMarkupDisclaimer(signal.FormatChannelSignalMessage(&snapshot, b.withheldFields(ch.StripFields), markup), markup)
+
+		if ch.DelaySeconds > 0 {
+			ch := ch
+			time.AfterFunc(time.Duration(ch.DelaySeconds)*time.Second, func() {
+				if b.isClosed(snapshot.ID) {
+					log.Printf("Skipping delayed delivery of closed signal %s to %s", snapshot.ID, ch.Name)
+					return
+				}
+				if err := b.deliverSignal(ch, &snapshot, message); err != nil {
+					log.Printf("Error sending delayed signal %s to %s: %v", snapshot.ID, ch.Name, err)
+				}
+			})
+			continue
+		}
+
+		if err := b.deliverSignal(ch, &snapshot, message); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", ch.Name, err))
+		}
+	}
+
+	// Entitled premium subscribers, or onboarded ones without subscriptions,
+	// also get the signals they prefer instantly by direct message
+	b.deliverDirect(&snapshot, b.withDisclaimer(signal.FormatRedactedSignalMessage(&snapshot, b.withheldFields(nil))))
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to send signal %s: %s", s.ID, strings.Join(failed, "; "))
+	}
+	return nil
 }
 
-// HandleCommand processes a command from a user
-func (b *Bot) HandleCommand(userID int64, command string, args []string) (string, error) {
-	command = strings.ToLower(command)
-	
-	switch command {
-	case "/start":
-		return b.handleStartCommand(userID)
-	case "/settings":
-		return b.handleSettingsCommand(userID, args)
-	case "/performance":
-		return b.handlePerformanceCommand(userID)
-	case "/help":
-		return b.handleHelpCommand(userID)
-	default:
-		return "Unknown command. Type /help for available commands.", nil
+// UpdateSignal edits the channel messages for a signal after it has changed
+func (b *Bot) UpdateSignal(s *signal.Signal) error {
+	updated := func(m signal.Markup) string { return "\n✏️ " + m.Italic("Updated") }
+
+	b.mu.RLock()
+	api := b.api
+	sent := append([]sentMessage(nil), b.signalMsgs[s.ID]...)
+	b.mu.RUnlock()
+
+	if b.mockMode || api == nil {
+		return b.SendMessage(signal.FormatRedactedSignalMessage(s, b.withheldFields(nil)) + updated(signal.HTML))
+	}
+
+	var failed []string
+	for _, m := range sent {
+		markup := b.channelMarkup(m.channelID)
+		message := signal.FormatChannelSignalMessage(s, b.stripFields(m.channelID), markup) + updated(markup)
+		if err := api.EditMessageText(m.channelID, m.messageID, b.withMarkupDisclaimer(message, markup), markup.ParseMode()); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", m.channelID, err))
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to edit Telegram messages: %s", strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// SendSignalExplanation edits the LLM explanation of a signal into its channel
+// messages after the signal went out with its technical rationale. If a
+// message cannot be edited, the explanation is posted as a reply to it
+// instead. Channels that strip the rationale get neither. Messages still
+// queued or held get the explanation once they are sent.
+func (b *Bot) SendSignalExplanation(s *signal.Signal) error {
+	b.mu.Lock()
+	api := b.api
+	sent := append([]sentMessage(nil), b.signalMsgs[s.ID]...)
+	if !b.closed[s.ID] {
+		b.explained[s.ID] = s.Clone()
+	}
+	b.mu.Unlock()
+
+	if b.mockMode || api == nil {
+		return b.SendMessage(signalExplanation(s, signal.HTML))
+	}
+
+	var failed []string
+	for _, m := range sent {
+		if err := b.explainMessage(api, s, m); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", m.channelID, err))
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to send signal explanation: %s", strings.Join(failed, "; "))
 	}
+	return nil
+}
+
+// signalExplanation formats the late explanation of a signal as a message of its own
+func signalExplanation(s *signal.Signal, m signal.Markup) string {
+	return "💡 " + m.Bold(fmt.Sprintf("Analysis for %s %s:", s.Type, s.Symbol)) + "\n" + m.Escape(s.Rationale)
+}
+
+// explainMessage edits the explanation of a signal into one of its channel
+// messages, replying with it when the message cannot be edited
+func (b *Bot) explainMessage(api *Client, s *signal.Signal, m sentMessage) error {
+	stripped := b.stripFields(m.channelID)
+	if containsField(stripped, "rationale") {
+		return nil
+	}
+
+	markup := b.channelMarkup(m.channelID)
+	message := b.withMarkupDisclaimer(signal.FormatChannelSignalMessage(s, stripped, markup), markup)
+	err := api.EditMessageText(m.channelID, m.messageID, message, markup.ParseMode())
+	if err == nil {
+		return nil
+	}
+	log.Printf("Error editing Telegram message for signal %s, replying instead: %v", s.ID, err)
+
+	reply := b.withMarkupDisclaimer(signalExplanation(s, markup), markup)
+	_, err = api.ReplyToMessage(m.channelID, m.messageID, reply, markup.ParseMode())
+	return err
+}
+
+// SendSignalOutcome edits a signal's channel messages to show its final outcome.
+// If a message cannot be edited, the outcome is posted as a reply to it instead.
+func (b *Bot) SendSignalOutcome(s *signal.Signal, exitPrice float64) error {
+	b.mu.Lock()
+	api := b.api
+	sent := b.signalMsgs[s.ID]
+	// The signal is final, no further edits or delayed deliveries will follow
+	delete(b.signalMsgs, s.ID)
+	delete(b.explained, s.ID)
+	b.closed[s.ID] = true
+	b.mu.Unlock()
+
+	if b.mockMode || api == nil {
+		return b.SendMessage(fmt.Sprintf("%s: %s %s\n%s", s.ID, s.Type, s.Symbol, signal.FormatSignalOutcome(s, exitPrice)))
+	}
+
+	hash := outcomeHash(s)
+	var failed []string
+	for _, m := range sent {
+		if !b.claim(hash, m.channelID) {
+			continue
+		}
+
+		markup := b.channelMarkup(m.channelID)
+		outcome := signal.FormatChannelSignalOutcome(s, exitPrice, markup)
+		message := signal.FormatChannelSignalMessage(s, b.stripFields(m.channelID), markup) + "\n\n" + outcome
+		err := api.EditMessageText(m.channelID, m.messageID, b.withMarkupDisclaimer(message, markup), markup.ParseMode())
+		if err == nil {
+			continue
+		}
+		log.Printf("Error editing Telegram message for signal %s, replying instead: %v", s.ID, err)
+
+		if _, err := api.ReplyToMessage(m.channelID, m.messageID, b.withMarkupDisclaimer(outcome, markup), markup.ParseMode()); err != nil {
+			b.release(hash, m.channelID)
+			failed = append(failed, fmt.Sprintf("%s: %v", m.channelID, err))
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to send signal outcome: %s", strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// SendAnomaly sends a heads-up alert for an unusual print or volume spike
+func (b *Bot) SendAnomaly(a anomaly.Anomaly) error {
+	return b.SendMessage(anomaly.FormatAnomalyMessage(a))
+}
+
+// SendAlert sends a triggered alert to the user who owns the rule
+func (b *Bot) SendAlert(t alerts.Trigger) error {
+	return b.SendDirectMessage(t.Rule.UserID, alerts.FormatAlertMessage(t))
+}
+
+// SetAlertEngine enables the /alert, /alerts and /unalert commands
+func (b *Bot) SetAlertEngine(engine *alerts.Engine) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.alertEngine = engine
+}
+
+// SetSignalAdjuster enables the admin /adjust command
+func (b *Bot) SetSignalAdjuster(adjuster SignalAdjuster) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.adjuster = adjuster
 }
 
 // handleStartCommand handles the /start command
@@ -102,13 +395,18 @@ func (b *Bot) handleStartCommand(userID int64) (string, error) {
 	
 	return "Welcome to Hustler Trading Bot! You are now subscribed to trading signals.\n\n" +
 		"You will receive intraday trading signals based on volatility patterns.\n\n" +
-		"Type /help to see available commands.", nil
+		"Type /help to see available commands. First, let's tailor your signals (reply \"skip\" to keep a default).\n\n" +
+		b.startOnboarding(userID), nil
 }
 
-// handleSettingsCommand handles the /settings command
+// handleSettingsCommand handles the /settings command, showing the user's
+// preferences and running the onboarding wizard again to change them
 func (b *Bot) handleSettingsCommand(userID int64, args []string) (string, error) {
-	// In a real implementation, this would allow users to configure their preferences
-	return "Settings functionality will be available soon.", nil
+	message := "You have no preferences yet, every signal is sent.\n\n"
+	if prefs, exists := b.GetPreferences(userID); exists {
+		message = "Your preferences:\n" + prefs.describe() + "\n\n"
+	}
+	return message + "Let's update them (reply \"skip\" to keep a choice).\n\n" + b.startOnboarding(userID), nil
 }
 
 // handlePerformanceCommand handles the /performance command
@@ -122,13 +420,83 @@ func (b *Bot) handlePerformanceCommand(userID int64) (string, error) {
 		"Last Updated: %s", time.Now().Format("2006-01-02 15:04:05")), nil
 }
 
-// handleHelpCommand handles the /help command
-func (b *Bot) handleHelpCommand(userID int64) (string, error) {
-	return "Available Commands:\n\n" +
-		"/start - Subscribe to trading signals\n" +
-		"/settings - Configure your preferences\n" +
-		"/performance - View bot performance statistics\n" +
-		"/help - Show this help message", nil
+// getAlertEngine returns the configured alert engine, if any
+func (b *Bot) getAlertEngine() *alerts.Engine {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.alertEngine
+}
+
+// handleAlertCommand handles the /alert command
+func (b *Bot) handleAlertCommand(userID int64, args []string) (string, error) {
+	engine := b.getAlertEngine()
+	if engine == nil {
+		return "Alerts are not available.", nil
+	}
+
+	rule, err := engine.AddRule(userID, strings.Join(args, " "))
+	if err != nil {
+		return fmt.Sprintf("Could not create alert: %v", err), nil
+	}
+
+	return fmt.Sprintf("Alert %s created: %s", rule.ID, rule.Describe()), nil
+}
+
+// handleAlertsCommand handles the /alerts command
+func (b *Bot) handleAlertsCommand(userID int64) (string, error) {
+	engine := b.getAlertEngine()
+	if engine == nil {
+		return "Alerts are not available.", nil
+	}
+
+	rules := engine.ListRules(userID)
+	if len(rules) == 0 {
+		return "You have no alerts. Create one with /alert <rule>.", nil
+	}
+
+	message := "Your Alerts:\n\n"
+	for _, rule := range rules {
+		message += fmt.Sprintf("%s - %s\n", rule.ID, rule.Describe())
+	}
+	return message, nil
+}
+
+// handleUnalertCommand handles the /unalert command
+func (b *Bot) handleUnalertCommand(userID int64, args []string) (string, error) {
+	engine := b.getAlertEngine()
+	if engine == nil {
+		return "Alerts are not available.", nil
+	}
+
+	if err := engine.RemoveRule(strings.ToUpper(args[0]), userID); err != nil {
+		return fmt.Sprintf("Could not remove alert: %v", err), nil
+	}
+
+	return fmt.Sprintf("Alert %s removed.", strings.ToUpper(args[0])), nil
+}
+
+// handleAdjustCommand handles the admin /adjust command, e.g. /adjust SIG-ID target 182.50
+func (b *Bot) handleAdjustCommand(userID int64, args []string) (string, error) {
+
+	b.mu.RLock()
+	adjuster := b.adjuster
+	b.mu.RUnlock()
+
+	if adjuster == nil {
+		return "Signal adjustment is not available.", nil
+	}
+
+	value, err := strconv.ParseFloat(strings.TrimPrefix(args[2], "$"), 64)
+	if err != nil {
+		return fmt.Sprintf("Invalid price: %s", args[2]), nil
+	}
+
+	s, err := adjuster.AdjustSignal(args[0], args[1], value)
+	if err != nil {
+		return fmt.Sprintf("Could not adjust signal: %v", err), nil
+	}
+
+	return fmt.Sprintf("Signal %s updated: target $%.2f, stop $%.2f", s.ID, s.TargetPrice, s.StopLoss), nil
 }
 
 // IsAdmin checks if a user is an admin
@@ -168,6 +536,12 @@ func (b *Bot) UpdateConfig(config config.TelegramConfig) {
 	defer b.mu.Unlock()
 	
 	b.config = config
+	if !b.mockMode {
+		b.api = nil
+		if config.BotToken != "" {
+			b.api = NewClient(config.BotToken)
+		}
+	}
 	
 	// Update admin users
 	b.adminUsers = make(map[int64]bool)
@@ -176,11 +550,71 @@ func (b *Bot) UpdateConfig(config config.TelegramConfig) {
 	}
 }
 
-// ProcessUpdates processes incoming updates from Telegram
+// ProcessUpdates polls Telegram for new messages and replies to commands
 func (b *Bot) ProcessUpdates() error {
-	// In a real implementation, this would poll the Telegram API for updates
-	// and process incoming messages
-	
-	// For now, we'll just return nil
+	b.mu.RLock()
+	api, offset := b.api, b.updateOffset
+	b.mu.RUnlock()
+
+	if api == nil {
+		return nil
+	}
+
+	updates, err := api.GetUpdates(offset, 30)
+	if err != nil {
+		return fmt.Errorf("failed to get Telegram updates: %w", err)
+	}
+
+	for _, update := range updates {
+		b.mu.Lock()
+		if update.UpdateID >= b.updateOffset {
+			b.updateOffset = update.UpdateID + 1
+		}
+		b.mu.Unlock()
+
+		if update.MessageReactionCount != nil {
+			b.handleReactionCount(update.MessageReactionCount)
+			continue
+		}
+		if update.MessageReaction != nil {
+			b.handleReaction(update.MessageReaction)
+			continue
+		}
+
+		msg := update.Message
+		if msg == nil || msg.From == nil {
+			continue
+		}
+		if !strings.HasPrefix(msg.Text, "/") {
+			// Plain replies answer the onboarding wizard; admins' messages
+			// with a $TICKER are vetted as trade ideas
+			if reply, onboarding := b.handleOnboardingReply(msg.From.ID, msg.Text); onboarding {
+				if err := b.sendDirect(msg.Chat.ID, reply, ""); err != nil {
+					log.Printf("Error replying to Telegram onboarding: %v", err)
+				}
+			} else if reply, idea := b.handleIdeaText(msg.From.ID, msg.Text); idea {
+				if err := b.sendDirect(msg.Chat.ID, reply, ""); err != nil {
+					log.Printf("Error replying to Telegram trade idea: %v", err)
+				}
+			}
+			continue
+		}
+
+		fields := strings.Fields(msg.Text)
+		// Commands in groups may be addressed as /command@botname
+		command := strings.SplitN(fields[0], "@", 2)[0]
+
+		reply, err := b.HandleCommand(msg.From.ID, command, fields[1:])
+		if err != nil {
+			log.Printf("Error handling Telegram command %s: %v", command, err)
+			continue
+		}
+
+		// Replies are plain text since usage strings contain angle brackets
+		if err := b.sendDirect(msg.Chat.ID, reply, ""); err != nil {
+			log.Printf("Error replying to Telegram command %s: %v", command, err)
+		}
+	}
+
 	return nil
 }
@@ -1,64 +1,104 @@
 package telegram
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strconv"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/hustler/trading-bot/pkg/config"
 	"github.com/hustler/trading-bot/pkg/signal"
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
-// MockTelegramAPI is a mock implementation of the Telegram API client
-type MockTelegramAPI struct {
-	mock.Mock
+// apiCall is a request received by the fake Telegram API
+type apiCall struct {
+	Method  string
+	Payload map[string]interface{}
 }
 
-func (m *MockTelegramAPI) SendMessage(chatID int64, text string, parseMode string) error {
-	args := m.Called(chatID, text, parseMode)
-	return args.Error(0)
+// fakeAPI is a Telegram Bot API server recording the calls it receives
+type fakeAPI struct {
+	calls    []apiCall
+	updates  []Update
+	failures map[string][]int // Method -> error codes of its next calls
+	nextID   int
+	mu       sync.Mutex
 }
 
-func (m *MockTelegramAPI) GetUpdates(offset int, limit int) ([]Update, error) {
-	args := m.Called(offset, limit)
-	return args.Get(0).([]Update), args.Error(1)
+// newFakeAPI starts a fake Telegram API and points the client at it
+func newFakeAPI(t *testing.T) *fakeAPI {
+	api := &fakeAPI{failures: make(map[string][]int)}
+	server := httptest.NewServer(http.HandlerFunc(api.serve))
+
+	baseURL := apiBaseURL
+	apiBaseURL = server.URL
+	t.Cleanup(func() {
+		apiBaseURL = baseURL
+		server.Close()
+	})
+	return api
 }
 
-func TestNewBot(t *testing.T) {
-	// Create config
-	cfg := &config.TelegramConfig{
-		Token:   "test-token",
-		Channel: "@test_channel",
+func (f *fakeAPI) serve(w http.ResponseWriter, r *http.Request) {
+	var payload map[string]interface{}
+	_ = json.NewDecoder(r.Body).Decode(&payload)
+	method := path.Base(r.URL.Path)
+
+	f.mu.Lock()
+	f.calls = append(f.calls, apiCall{Method: method, Payload: payload})
+	var code int
+	if codes := f.failures[method]; len(codes) > 0 {
+		code, f.failures[method] = codes[0], codes[1:]
 	}
+	var result interface{} = true
+	switch method {
+	case "sendMessage":
+		f.nextID++
+		chatID, _ := strconv.ParseInt(fmt.Sprint(payload["chat_id"]), 10, 64)
+		result = Message{MessageID: f.nextID, Chat: Chat{ID: chatID}, Text: fmt.Sprint(payload["text"])}
+	case "getUpdates":
+		result, f.updates = f.updates, nil
+	}
+	f.mu.Unlock()
 
-	// Create bot
-	bot := NewBot(cfg)
+	if code != 0 {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error_code": code, "description": "failed"})
+		return
+	}
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "result": result})
+}
 
-	// Verify bot
-	assert.NotNil(t, bot)
-	assert.Equal(t, cfg, bot.config)
-	assert.NotNil(t, bot.api)
-	assert.NotNil(t, bot.subscribers)
-	assert.Equal(t, 0, len(bot.subscribers))
+// failNext makes the next calls of method fail with the given error codes
+func (f *fakeAPI) failNext(method string, codes ...int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failures[method] = append(f.failures[method], codes...)
 }
 
-func TestSendSignal(t *testing.T) {
-	// Create mock API
-	mockAPI := new(MockTelegramAPI)
+// sent returns the calls of method received so far
+func (f *fakeAPI) sent(method string) []apiCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
 
-	// Create config
-	cfg := &config.TelegramConfig{
-		Token:   "test-token",
-		Channel: "@test_channel",
+	var calls []apiCall
+	for _, call := range f.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
 	}
+	return calls
+}
 
-	// Create bot with mock API
-	bot := NewBot(cfg)
-	bot.api = mockAPI
-
-	// Create test signal
-	testSignal := &signal.Signal{
+// testSignal returns an active BUY signal for AAPL
+func testSignal() *signal.Signal {
+	return &signal.Signal{
 		ID:          "SIG-AAPL-BUY-1",
 		Symbol:      "AAPL",
 		Type:        signal.BUY,
@@ -68,230 +108,211 @@ func TestSendSignal(t *testing.T) {
 		ExpectedROI: 3.33,
 		Confidence:  0.85,
 		Rationale:   "This is a test rationale",
-		GeneratedAt: time.Now(),
+		GeneratedAt: time.Date(2025, 4, 20, 10, 15, 0, 0, time.UTC),
 		TimeFrame:   "1-3 hours",
+		Status:      signal.StatusActive,
 	}
-
-	// Set up mock expectations
-	// The message text is complex, so we use mock.Anything
-	mockAPI.On("SendMessage", int64(0), mock.Anything, "HTML").Return(nil)
-
-	// Send signal
-	err := bot.SendSignal(testSignal)
-
-	// Verify no error
-	assert.NoError(t, err)
-
-	// Verify mock was called
-	mockAPI.AssertExpectations(t)
 }
 
-func TestSendSignalToSubscribers(t *testing.T) {
-	// Create mock API
-	mockAPI := new(MockTelegramAPI)
-
+func TestNewBot(t *testing.T) {
 	// Create config
-	cfg := &config.TelegramConfig{
-		Token:   "test-token",
-		Channel: "@test_channel",
+	cfg := config.TelegramConfig{
+		BotToken:     "test-token",
+		ChannelID:    "@test_channel",
+		AdminUserIDs: []int64{42},
 	}
 
-	// Create bot with mock API
+	// Create bot
 	bot := NewBot(cfg)
-	bot.api = mockAPI
 
-	// Add subscribers
-	bot.subscribers = []int64{123456789, 987654321}
+	// Verify bot
+	assert.NotNil(t, bot)
+	assert.Equal(t, cfg, bot.config)
+	assert.NotNil(t, bot.api)
+	assert.NotNil(t, bot.subscribers)
+	assert.Equal(t, 0, len(bot.subscribers))
+	assert.True(t, bot.IsAdmin(42))
 
-	// Create test signal
-	testSignal := &signal.Signal{
-		ID:          "SIG-AAPL-BUY-1",
-		Symbol:      "AAPL",
-		Type:        signal.BUY,
-		Price:       150.0,
-		TargetPrice: 155.0,
-		StopLoss:    148.0,
-		ExpectedROI: 3.33,
-		Confidence:  0.85,
-		Rationale:   "This is a test rationale",
-		GeneratedAt: time.Now(),
-		TimeFrame:   "1-3 hours",
-	}
+	// Without a token or in mock mode nothing is sent to Telegram
+	assert.Nil(t, NewBot(config.TelegramConfig{}).api)
+	assert.Nil(t, NewBotWithMode(cfg, true).api)
+}
 
-	// Set up mock expectations
-	// The message text is complex, so we use mock.Anything
-	mockAPI.On("SendMessage", int64(0), mock.Anything, "HTML").Return(nil)
-	mockAPI.On("SendMessage", int64(123456789), mock.Anything, "HTML").Return(nil)
-	mockAPI.On("SendMessage", int64(987654321), mock.Anything, "HTML").Return(nil)
+func TestSendSignal(t *testing.T) {
+	api := newFakeAPI(t)
+	bot := NewBot(config.TelegramConfig{BotToken: "test-token", ChannelID: "@test_channel"})
 
 	// Send signal
-	err := bot.SendSignal(testSignal)
-
-	// Verify no error
+	err := bot.SendSignal(testSignal())
 	assert.NoError(t, err)
 
-	// Verify mock was called
-	mockAPI.AssertExpectations(t)
+	// Verify it went to the channel and was remembered for later edits
+	calls := api.sent("sendMessage")
+	require.Len(t, calls, 1)
+	assert.Equal(t, "@test_channel", calls[0].Payload["chat_id"])
+	assert.Equal(t, "HTML", calls[0].Payload["parse_mode"])
+	assert.Contains(t, calls[0].Payload["text"], "AAPL")
+	assert.Len(t, bot.signalMsgs["SIG-AAPL-BUY-1"], 1)
 }
 
-func TestProcessUpdates(t *testing.T) {
-	// Create mock API
-	mockAPI := new(MockTelegramAPI)
+func TestSendSignalError(t *testing.T) {
+	api := newFakeAPI(t)
+	api.failNext("sendMessage", http.StatusBadRequest)
+	bot := NewBot(config.TelegramConfig{BotToken: "test-token", ChannelID: "@test_channel"})
 
-	// Create config
-	cfg := &config.TelegramConfig{
-		Token:   "test-token",
-		Channel: "@test_channel",
-	}
+	err := bot.SendSignal(testSignal())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "default")
+	assert.Empty(t, bot.signalMsgs)
+}
 
-	// Create bot with mock API
-	bot := NewBot(cfg)
-	bot.api = mockAPI
+func TestSendSignalMockMode(t *testing.T) {
+	bot := NewBotWithMode(config.TelegramConfig{ChannelID: "@test_channel"}, true)
+
+	assert.NoError(t, bot.SendSignal(testSignal()))
+
+	messages := bot.GetMockMessages()
+	require.Len(t, messages, 1)
+	assert.Contains(t, messages[0], "AAPL")
+	assert.Contains(t, messages[0], "150.00")
+	assert.Contains(t, messages[0], "155.00")
+	assert.Contains(t, messages[0], "148.00")
+}
+
+func TestProcessUpdates(t *testing.T) {
+	api := newFakeAPI(t)
+	bot := NewBot(config.TelegramConfig{BotToken: "test-token", ChannelID: "@test_channel"})
 
 	// Create test updates
-	updates := []Update{
+	api.updates = []Update{
 		{
 			UpdateID: 1,
-			Message: Message{
+			Message: &Message{
 				MessageID: 1,
-				From: User{
-					ID:        123456789,
-					FirstName: "Test",
-					LastName:  "User",
-					Username:  "testuser",
-				},
-				Chat: Chat{
-					ID:   123456789,
-					Type: "private",
-				},
-				Text: "/start",
-				Date: int(time.Now().Unix()),
+				From:      &User{ID: 123456789, FirstName: "Test", Username: "testuser"},
+				Chat:      Chat{ID: 123456789, Type: "private"},
+				Text:      "/start",
 			},
 		},
 		{
 			UpdateID: 2,
-			Message: Message{
+			Message: &Message{
 				MessageID: 2,
-				From: User{
-					ID:        987654321,
-					FirstName: "Another",
-					LastName:  "User",
-					Username:  "anotheruser",
-				},
-				Chat: Chat{
-					ID:   987654321,
-					Type: "private",
-				},
-				Text: "/help",
-				Date: int(time.Now().Unix()),
+				From:      &User{ID: 987654321, FirstName: "Another", Username: "anotheruser"},
+				Chat:      Chat{ID: 987654321, Type: "private"},
+				Text:      "/help@hustler_bot",
 			},
 		},
 	}
 
-	// Set up mock expectations
-	mockAPI.On("GetUpdates", 0, 100).Return(updates, nil)
-	mockAPI.On("SendMessage", int64(123456789), mock.Anything, "HTML").Return(nil)
-	mockAPI.On("SendMessage", int64(987654321), mock.Anything, "HTML").Return(nil)
-
 	// Process updates
 	err := bot.ProcessUpdates()
-
-	// Verify no error
 	assert.NoError(t, err)
 
 	// Verify subscribers were added
-	assert.Contains(t, bot.subscribers, int64(123456789))
-	assert.NotContains(t, bot.subscribers, int64(987654321)) // /help doesn't add subscriber
-
-	// Verify mock was called
-	mockAPI.AssertExpectations(t)
+	assert.Contains(t, bot.GetSubscribers(), int64(123456789))
+	assert.NotContains(t, bot.GetSubscribers(), int64(987654321)) // /help doesn't add subscriber
+	assert.Equal(t, 3, bot.updateOffset)
+
+	// Verify both commands were answered in their chats
+	calls := api.sent("sendMessage")
+	require.Len(t, calls, 2)
+	assert.Equal(t, "123456789", calls[0].Payload["chat_id"])
+	assert.Contains(t, calls[0].Payload["text"], "Welcome")
+	assert.Equal(t, "987654321", calls[1].Payload["chat_id"])
+	assert.Contains(t, calls[1].Payload["text"], "Available Commands")
 }
 
 func TestHandleCommand(t *testing.T) {
-	// Create mock API
-	mockAPI := new(MockTelegramAPI)
-
-	// Create config
-	cfg := &config.TelegramConfig{
-		Token:   "test-token",
-		Channel: "@test_channel",
-	}
-
-	// Create bot with mock API
-	bot := NewBot(cfg)
-	bot.api = mockAPI
+	bot := NewBotWithMode(config.TelegramConfig{ChannelID: "@test_channel"}, true)
 
 	// Test cases
 	testCases := []struct {
-		command    string
-		chatID     int64
-		shouldSend bool
-		shouldAdd  bool
+		command   string
+		userID    int64
+		reply     string
+		shouldAdd bool
 	}{
-		{"/start", 123456789, true, true},
-		{"/help", 987654321, true, false},
-		{"/stop", 123456789, true, false},
-		{"/unknown", 555555555, false, false},
+		{"/start", 123456789, "Welcome", true},
+		{"/help", 987654321, "Available Commands", false},
+		{"/unknown", 555555555, "Unknown command", false},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.command, func(t *testing.T) {
-			// Reset subscribers
-			bot.subscribers = []int64{}
-
-			// Set up mock expectations if needed
-			if tc.shouldSend {
-				mockAPI.On("SendMessage", tc.chatID, mock.Anything, "HTML").Return(nil).Once()
-			}
-
-			// Handle command
-			bot.handleCommand(tc.command, tc.chatID)
+			reply, err := bot.HandleCommand(tc.userID, tc.command, nil)
+			assert.NoError(t, err)
+			assert.Contains(t, reply, tc.reply)
 
 			// Verify subscriber was added if expected
 			if tc.shouldAdd {
-				assert.Contains(t, bot.subscribers, tc.chatID)
+				assert.Contains(t, bot.GetSubscribers(), tc.userID)
 			} else {
-				assert.NotContains(t, bot.subscribers, tc.chatID)
+				assert.NotContains(t, bot.GetSubscribers(), tc.userID)
 			}
-
-			// Verify mock was called
-			mockAPI.AssertExpectations(t)
 		})
 	}
 }
 
-func TestFormatSignalMessage(t *testing.T) {
-	// Create test signal
-	testSignal := &signal.Signal{
-		ID:          "SIG-AAPL-BUY-1",
-		Symbol:      "AAPL",
-		Type:        signal.BUY,
-		Price:       150.0,
-		TargetPrice: 155.0,
-		StopLoss:    148.0,
-		ExpectedROI: 3.33,
-		Confidence:  0.85,
-		Rationale:   "This is a test rationale",
-		GeneratedAt: time.Date(2025, 4, 20, 10, 15, 0, 0, time.UTC),
-		TimeFrame:   "1-3 hours",
-	}
+func TestSendSignalExplanation(t *testing.T) {
+	api := newFakeAPI(t)
+	bot := NewBot(config.TelegramConfig{BotToken: "test-token", ChannelID: "@test_channel"})
+	require.NoError(t, bot.SendSignal(testSignal()))
+
+	// A sent message is edited at once
+	s := testSignal()
+	s.Rationale = "LLM explanation"
+	assert.NoError(t, bot.SendSignalExplanation(s))
+	edits := api.sent("editMessageText")
+	require.Len(t, edits, 1)
+	assert.Contains(t, edits[0].Payload["text"], "LLM explanation")
+
+	// Closed signals are not explained once more
+	s.Status = signal.StatusSuccess
+	require.NoError(t, bot.SendSignalOutcome(s, 155))
+	assert.NoError(t, bot.SendSignalExplanation(s))
+	assert.Empty(t, bot.explained)
+}
+
+func TestSendSignalExplanationToQueuedMessage(t *testing.T) {
+	api := newFakeAPI(t)
+	api.failNext("sendMessage", http.StatusBadGateway)
+	bot := NewBot(config.TelegramConfig{BotToken: "test-token", ChannelID: "@test_channel"})
+	require.NoError(t, bot.StartQueue(nil))
+	defer bot.StopQueue()
+
+	// The explanation comes in while the signal waits to be retried...
+	require.NoError(t, bot.SendSignal(testSignal()))
+	s := testSignal()
+	s.Rationale = "LLM explanation"
+	assert.NoError(t, bot.SendSignalExplanation(s))
+	assert.Empty(t, api.sent("editMessageText"))
+
+	// ...and is edited into the message once it is sent
+	assert.Eventually(t, func() bool { return len(api.sent("editMessageText")) == 1 }, 5*time.Second, 10*time.Millisecond)
+	edit := api.sent("editMessageText")[0]
+	assert.Contains(t, edit.Payload["text"], "LLM explanation")
+	assert.Equal(t, float64(len(api.sent("sendMessage"))), edit.Payload["message_id"])
+}
 
-	// Format message
-	message := formatSignalMessage(testSignal)
-
-	// Verify message contains key information
-	assert.Contains(t, message, "BUY SIGNAL: AAPL")
-	assert.Contains(t, message, "Entry Price: $150.00")
-	assert.Contains(t, message, "Target Price: $155.00")
-	assert.Contains(t, message, "Stop Loss: $148.00")
-	assert.Contains(t, message, "Expected ROI: +3.33%")
-	assert.Contains(t, message, "Confidence: 85%")
-	assert.Contains(t, message, "This is a test rationale")
-	assert.Contains(t, message, "2025-04-20 10:15:00")
-
-	// Test SELL signal
-	testSignal.Type = signal.SELL
-	message = formatSignalMessage(testSignal)
-	assert.Contains(t, message, "SELL SIGNAL: AAPL")
-	assert.Contains(t, message, "Expected ROI: -3.33%")
+func TestSendSignalExplanationToHeldMessage(t *testing.T) {
+	api := newFakeAPI(t)
+	now := time.Now().UTC()
+	quiet := config.QuietHoursConfig{Start: now.Add(-time.Hour).Format("15:04"), End: now.Add(time.Hour).Format("15:04")}
+	bot := NewBot(config.TelegramConfig{
+		BotToken: "test-token",
+		Channels: []config.TelegramChannelConfig{{Name: "main", ChannelID: "@test_channel", QuietHours: quiet}},
+	})
+
+	require.NoError(t, bot.SendSignal(testSignal()))
+	s := testSignal()
+	s.Rationale = "LLM explanation"
+	assert.NoError(t, bot.SendSignalExplanation(s))
+	assert.Empty(t, api.sent("sendMessage"))
+
+	bot.ReleaseHeldMessages(now.Add(2 * time.Hour))
+	require.Len(t, api.sent("sendMessage"), 1)
+	edits := api.sent("editMessageText")
+	require.Len(t, edits, 1)
+	assert.Contains(t, edits[0].Payload["text"], "LLM explanation")
 }
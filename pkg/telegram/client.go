@@ -0,0 +1,244 @@
+package telegram
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/errkind"
+)
+
+// apiBaseURL is the Telegram Bot API endpoint (overridable in tests)
+var apiBaseURL = "https://api.telegram.org"
+
+// Update represents an incoming update from the Telegram Bot API
+type Update struct {
+	UpdateID             int                          `json:"update_id"`
+	Message              *Message                     `json:"message,omitempty"`
+	MessageReaction      *MessageReactionUpdated      `json:"message_reaction,omitempty"`
+	MessageReactionCount *MessageReactionCountUpdated `json:"message_reaction_count,omitempty"`
+}
+
+// ReactionType represents a reaction (only emoji reactions are used)
+type ReactionType struct {
+	Type  string `json:"type"`
+	Emoji string `json:"emoji"`
+}
+
+// ReactionCount represents the number of times a reaction was added to a message
+type ReactionCount struct {
+	Type       ReactionType `json:"type"`
+	TotalCount int          `json:"total_count"`
+}
+
+// MessageReactionUpdated represents a change of a user's reaction in a chat
+type MessageReactionUpdated struct {
+	Chat        Chat           `json:"chat"`
+	MessageID   int            `json:"message_id"`
+	User        *User          `json:"user,omitempty"`
+	OldReaction []ReactionType `json:"old_reaction"`
+	NewReaction []ReactionType `json:"new_reaction"`
+}
+
+// MessageReactionCountUpdated represents anonymous reaction totals on a channel message
+type MessageReactionCountUpdated struct {
+	Chat      Chat            `json:"chat"`
+	MessageID int             `json:"message_id"`
+	Reactions []ReactionCount `json:"reactions"`
+}
+
+// Message represents a Telegram message
+type Message struct {
+	MessageID int    `json:"message_id"`
+	From      *User  `json:"from,omitempty"`
+	Chat      Chat   `json:"chat"`
+	Text      string `json:"text"`
+	Date      int    `json:"date"`
+}
+
+// User represents a Telegram user
+type User struct {
+	ID        int64  `json:"id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Username  string `json:"username"`
+}
+
+// Chat represents a Telegram chat
+type Chat struct {
+	ID   int64  `json:"id"`
+	Type string `json:"type"`
+}
+
+// apiResponse is the envelope of every Telegram Bot API response
+type apiResponse struct {
+	OK          bool            `json:"ok"`
+	Result      json.RawMessage `json:"result"`
+	Description string          `json:"description"`
+	ErrorCode   int             `json:"error_code"`
+	Parameters  struct {
+		RetryAfter int `json:"retry_after"`
+	} `json:"parameters"`
+}
+
+// APIError is an error returned by the Telegram Bot API
+type APIError struct {
+	Method      string
+	Code        int
+	Description string
+	RetryAfter  time.Duration // Set when the request was rate limited
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	return fmt.Sprintf("telegram %s failed (%d): %s", e.Method, e.Code, e.Description)
+}
+
+// Unwrap returns the error kind of the response code. Telegram answers 403
+// when a chat blocked the bot, which is not an authentication failure.
+func (e *APIError) Unwrap() error {
+	switch {
+	case e.Code == http.StatusUnauthorized:
+		return errkind.ErrAuth
+	case e.Code == http.StatusTooManyRequests:
+		return errkind.ErrRateLimited
+	case e.Code >= 500:
+		return errkind.ErrProviderDown
+	default:
+		return nil
+	}
+}
+
+// Retryable reports whether the request may succeed if sent again
+func (e *APIError) Retryable() bool {
+	return errkind.Retryable(e)
+}
+
+// Client is a minimal Telegram Bot API client
+type Client struct {
+	token  string
+	client *http.Client
+}
+
+// NewClient creates a new Telegram Bot API client
+func NewClient(token string) *Client {
+	return &Client{
+		token: token,
+		// Long enough for getUpdates long polling
+		client: &http.Client{Timeout: 40 * time.Second},
+	}
+}
+
+// SendMessage sends a message to a chat (numeric ID or @channel) and returns the sent message
+func (c *Client) SendMessage(chatID, text, parseMode string) (*Message, error) {
+	return c.ReplyToMessage(chatID, 0, text, parseMode)
+}
+
+// ReplyToMessage sends a message as a reply to replyTo (0 for none) and returns the sent message
+func (c *Client) ReplyToMessage(chatID string, replyTo int, text, parseMode string) (*Message, error) {
+	payload := map[string]interface{}{
+		"chat_id": chatID,
+		"text":    text,
+	}
+	if replyTo != 0 {
+		payload["reply_to_message_id"] = replyTo
+	}
+	if parseMode != "" {
+		payload["parse_mode"] = parseMode
+	}
+
+	var message Message
+	if err := c.call("sendMessage", payload, &message); err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
+
+// EditMessageText replaces the text of a previously sent message
+func (c *Client) EditMessageText(chatID string, messageID int, text, parseMode string) error {
+	payload := map[string]interface{}{
+		"chat_id":    chatID,
+		"message_id": messageID,
+		"text":       text,
+	}
+	if parseMode != "" {
+		payload["parse_mode"] = parseMode
+	}
+
+	return c.call("editMessageText", payload, nil)
+}
+
+// GetMe returns the bot's own user, verifying the token
+func (c *Client) GetMe() (*User, error) {
+	var user User
+	if err := c.call("getMe", map[string]interface{}{}, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetUpdates long-polls for updates after offset
+func (c *Client) GetUpdates(offset, timeoutSeconds int) ([]Update, error) {
+	payload := map[string]interface{}{
+		"offset":          offset,
+		"timeout":         timeoutSeconds,
+		"allowed_updates": []string{"message", "message_reaction", "message_reaction_count"},
+	}
+
+	var updates []Update
+	if err := c.call("getUpdates", payload, &updates); err != nil {
+		return nil, err
+	}
+	return updates, nil
+}
+
+// call invokes a Bot API method and decodes its result into out
+func (c *Client) call(method string, payload interface{}, out interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/bot%s/%s", apiBaseURL, url.PathEscape(c.token), method)
+	resp, err := c.client.Post(endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", errkind.Unreachable(err))
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var apiResp apiResponse
+	if err := json.Unmarshal(respBody, &apiResp); err != nil {
+		return fmt.Errorf("failed to parse response (status %d): %w", resp.StatusCode, err)
+	}
+	if !apiResp.OK {
+		return &APIError{
+			Method:      method,
+			Code:        apiResp.ErrorCode,
+			Description: apiResp.Description,
+			RetryAfter:  time.Duration(apiResp.Parameters.RetryAfter) * time.Second,
+		}
+	}
+
+	if out != nil {
+		if err := json.Unmarshal(apiResp.Result, out); err != nil {
+			return fmt.Errorf("failed to parse %s result: %w", method, err)
+		}
+	}
+
+	return nil
+}
+
+// formatChatID converts a numeric chat ID to the string form used by the client
+func formatChatID(chatID int64) string {
+	return strconv.FormatInt(chatID, 10)
+}
@@ -0,0 +1,181 @@
+package telegram
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hustler/trading-bot/pkg/symbols"
+)
+
+// CommandHandler handles a command from a user and returns the reply. The
+// arguments have already been validated against the command.
+type CommandHandler func(userID int64, args []string) (string, error)
+
+// Command is a bot command with its validation and help
+type Command struct {
+	Name        string // Without the slash, e.g. "alert"
+	Args        string // Argument synopsis for usage and help, e.g. "<symbol> [minutes]"
+	Description string // One line shown by /help
+	Help        string // Detail shown with usage and by /help <command>, e.g. examples
+	MinArgs     int
+	MaxArgs     int  // 0 for no limit
+	Admin       bool // Only admins can run and see the command
+	Handler     CommandHandler
+}
+
+// usage returns the usage line of the command
+func (c *Command) usage() string {
+	usage := "Usage: /" + c.Name
+	if c.Args != "" {
+		usage += " " + c.Args
+	}
+	if c.Help != "" {
+		usage += "\n\n" + c.Help
+	}
+	return usage
+}
+
+// summary returns the command's line in /help
+func (c *Command) summary() string {
+	line := "/" + c.Name
+	if c.Args != "" {
+		line += " " + c.Args
+	}
+	return line + " - " + c.Description + "\n"
+}
+
+// RegisterCommand adds a command to the bot, e.g. for other packages to
+// extend it. Commands are listed by /help in the order they are registered.
+func (b *Bot) RegisterCommand(cmd Command) error {
+	cmd.Name = strings.ToLower(strings.TrimPrefix(cmd.Name, "/"))
+	if cmd.Name == "" || cmd.Handler == nil {
+		return fmt.Errorf("command needs a name and a handler")
+	}
+	if cmd.MaxArgs > 0 && cmd.MaxArgs < cmd.MinArgs {
+		return fmt.Errorf("command /%s allows fewer arguments than it requires", cmd.Name)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, exists := b.commands[cmd.Name]; exists {
+		return fmt.Errorf("command /%s is already registered", cmd.Name)
+	}
+	b.commands[cmd.Name] = &cmd
+	b.commandOrder = append(b.commandOrder, cmd.Name)
+	return nil
+}
+
+// lookupCommand returns a registered command by name, with or without its slash
+func (b *Bot) lookupCommand(name string) (*Command, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	cmd, exists := b.commands[strings.ToLower(strings.TrimPrefix(name, "/"))]
+	return cmd, exists
+}
+
+// HandleCommand processes a command from a user, checking it is allowed and
+// its arguments before running it
+func (b *Bot) HandleCommand(userID int64, command string, args []string) (string, error) {
+	cmd, exists := b.lookupCommand(command)
+	if !exists {
+		return "Unknown command. Type /help for available commands.", nil
+	}
+	if cmd.Admin && !b.IsAdmin(userID) {
+		return "This command is only available to admins.", nil
+	}
+	if len(args) < cmd.MinArgs || (cmd.MaxArgs > 0 && len(args) > cmd.MaxArgs) {
+		return cmd.usage(), nil
+	}
+
+	return cmd.Handler(userID, args)
+}
+
+// handleHelpCommand handles the /help command, listing the commands available
+// to the user or explaining one
+func (b *Bot) handleHelpCommand(userID int64, args []string) (string, error) {
+	admin := b.IsAdmin(userID)
+
+	if len(args) == 1 {
+		cmd, exists := b.lookupCommand(args[0])
+		if !exists || (cmd.Admin && !admin) {
+			return "Unknown command. Type /help for available commands.", nil
+		}
+		return cmd.usage() + "\n\n" + cmd.Description, nil
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	message := "Available Commands:\n\n"
+	adminMessage := ""
+	for _, name := range b.commandOrder {
+		cmd := b.commands[name]
+		switch {
+		case !cmd.Admin:
+			message += cmd.summary()
+		case admin:
+			adminMessage += cmd.summary()
+		}
+	}
+	if adminMessage != "" {
+		message += "\nAdmin Commands:\n\n" + adminMessage
+	}
+	return strings.TrimSuffix(message, "\n"), nil
+}
+
+// withoutArgs adapts a handler for a command that takes no arguments
+func withoutArgs(handler func(userID int64) (string, error)) CommandHandler {
+	return func(userID int64, args []string) (string, error) {
+		return handler(userID)
+	}
+}
+
+// registerBuiltinCommands registers the bot's own commands
+func (b *Bot) registerBuiltinCommands() {
+	listAdd := func(list string) CommandHandler {
+		return func(userID int64, args []string) (string, error) {
+			return b.handleListAddCommand(userID, list, args)
+		}
+	}
+
+	builtins := []Command{
+		{Name: "start", Description: "Subscribe to trading signals", Handler: withoutArgs(b.handleStartCommand)},
+		{Name: "settings", Description: "Configure your preferences", Handler: b.handleSettingsCommand},
+		{Name: "performance", Description: "View bot performance statistics", Handler: withoutArgs(b.handlePerformanceCommand)},
+		{Name: "subscribe", Description: "Get premium access", Handler: withoutArgs(b.handleSubscribeCommand)},
+		{Name: "alert", Args: "<rule>", Description: "Create a price or indicator alert", MinArgs: 1,
+			Help: "Examples:\n/alert AAPL crosses above 200\n/alert RSI(TSLA) < 20", Handler: b.handleAlertCommand},
+		{Name: "alerts", Description: "List your alerts", Handler: withoutArgs(b.handleAlertsCommand)},
+		{Name: "unalert", Args: "<alert id>", Description: "Remove an alert", MinArgs: 1, MaxArgs: 1, Handler: b.handleUnalertCommand},
+		{Name: "digest", Args: "<minutes | 2h | off>", Description: "Batch your non-urgent signals into one message", MinArgs: 1, MaxArgs: 1, Handler: b.handleDigestCommand},
+		{Name: "timezone", Args: "<zone>", Description: "Set the time zone of your quiet hours, e.g. America/New_York", MinArgs: 1, MaxArgs: 1, Handler: b.handleTimeZoneCommand},
+		{Name: "mydata", Description: "Export the data stored about you", Handler: withoutArgs(b.handleMyDataCommand)},
+		{Name: "deletemydata", Description: "Delete the data stored about you", Handler: b.handleDeleteMyDataCommand},
+		{Name: "help", Args: "[command]", Description: "Show this help message", MaxArgs: 1, Handler: b.handleHelpCommand},
+
+		{Name: "adjust", Args: "<signal id> <target|stop> <price>", Description: "Move an active signal's target or stop",
+			MinArgs: 3, MaxArgs: 3, Admin: true, Handler: b.handleAdjustCommand},
+		{Name: "grant", Args: "<user id> [days]", Description: "Grant premium access", MinArgs: 1, MaxArgs: 2, Admin: true, Handler: b.handleGrantCommand},
+		{Name: "revoke", Args: "<user id>", Description: "Revoke premium access", MinArgs: 1, MaxArgs: 1, Admin: true, Handler: b.handleRevokeCommand},
+		{Name: symbols.Blacklist, Args: "<symbol> [days] [reason]", Description: "Stop signals for a symbol", MinArgs: 1, Admin: true, Handler: listAdd(symbols.Blacklist)},
+		{Name: symbols.Allowlist, Args: "<symbol> [days] [reason]", Description: "Allow signals for a symbol", MinArgs: 1, Admin: true, Handler: listAdd(symbols.Allowlist)},
+		{Name: "unlist", Args: "<blacklist|allowlist> <symbol>", Description: "Remove a symbol from a list", MinArgs: 2, MaxArgs: 2, Admin: true, Handler: b.handleUnlistCommand},
+		{Name: "lists", Description: "Show the blacklist and allowlist", Admin: true, Handler: withoutArgs(b.handleListsCommand)},
+		{Name: "import", Args: "<csv url | symbol[,symbol...]>", Description: "Import a watchlist", MinArgs: 1, Admin: true, Handler: b.handleImportCommand},
+		{Name: "testsignal", Args: "<symbol>", Description: "Send a test signal", MinArgs: 1, MaxArgs: 1, Admin: true, Handler: b.handleTestSignalCommand},
+		{Name: "idea", Args: "<symbol> [long|short] [thesis]", Description: "Have a trade idea vetted", MinArgs: 1, Admin: true, Handler: b.handleIdeaCommand},
+		{Name: "track", Args: "<idea id>", Description: "Track a vetted idea as a signal", MinArgs: 1, MaxArgs: 1, Admin: true, Handler: b.handleTrackCommand},
+		{Name: "focus", Args: "[symbol] [minutes]", Description: "Watch a symbol for micro-breakouts, or list those in focus", MaxArgs: 2, Admin: true, Handler: b.handleFocusCommand},
+		{Name: "unfocus", Args: "<symbol>", Description: "Stop watching a symbol", MinArgs: 1, MaxArgs: 1, Admin: true, Handler: b.handleUnfocusCommand},
+		{Name: "trades", Description: "List open trades", Admin: true, Handler: withoutArgs(b.handleTradesCommand)},
+		{Name: "stop", Args: "<trade id> <price>", Description: "Move a trade's stop", MinArgs: 2, MaxArgs: 2, Admin: true, Handler: b.handleStopCommand},
+		{Name: "reduce", Args: "<trade id> <shares>", Description: "Sell part of a trade", MinArgs: 2, MaxArgs: 2, Admin: true, Handler: b.handleReduceCommand},
+		{Name: "close", Args: "<trade id>", Description: "Close a trade", MinArgs: 1, MaxArgs: 1, Admin: true, Handler: b.handleCloseCommand},
+	}
+	for _, cmd := range builtins {
+		if err := b.RegisterCommand(cmd); err != nil {
+			// Unreachable: the built-in commands have unique names and handlers
+			panic(err)
+		}
+	}
+}
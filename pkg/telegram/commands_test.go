@@ -0,0 +1,108 @@
+package telegram
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCommandBot returns a mock bot with user 42 as its admin
+func newCommandBot() *Bot {
+	return NewBotWithMode(config.TelegramConfig{ChannelID: "@test_channel", AdminUserIDs: []int64{42}}, true)
+}
+
+func TestHandleCommandRejectsNonAdmins(t *testing.T) {
+	bot := newCommandBot()
+	ran := false
+	require.NoError(t, bot.RegisterCommand(Command{Name: "secret", Description: "Admins only", Admin: true,
+		Handler: func(userID int64, args []string) (string, error) {
+			ran = true
+			return "done", nil
+		}}))
+
+	reply, err := bot.HandleCommand(7, "/secret", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "This command is only available to admins.", reply)
+	assert.False(t, ran)
+
+	// Built-in admin commands are guarded the same way, before their arguments are checked
+	reply, _ = bot.HandleCommand(7, "/grant", nil)
+	assert.Equal(t, "This command is only available to admins.", reply)
+
+	reply, err = bot.HandleCommand(42, "/secret", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "done", reply)
+	assert.True(t, ran)
+}
+
+func TestHandleCommandValidatesArgs(t *testing.T) {
+	bot := newCommandBot()
+	var got []string
+	require.NoError(t, bot.RegisterCommand(Command{Name: "/Echo", Args: "<word> [word]", Description: "Echo words",
+		Help: "Example: /echo hello", MinArgs: 1, MaxArgs: 2,
+		Handler: func(userID int64, args []string) (string, error) {
+			got = args
+			return strings.Join(args, " "), nil
+		}}))
+
+	testCases := []struct {
+		name  string
+		args  []string
+		reply string
+	}{
+		{"too few", nil, "Usage: /echo <word> [word]\n\nExample: /echo hello"},
+		{"too many", []string{"a", "b", "c"}, "Usage: /echo <word> [word]\n\nExample: /echo hello"},
+		{"within bounds", []string{"a", "b"}, "a b"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got = nil
+			reply, err := bot.HandleCommand(7, "echo", tc.args)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.reply, reply)
+		})
+	}
+	assert.Equal(t, []string{"a", "b"}, got)
+
+	// Built-in commands return their usage too
+	reply, _ := bot.HandleCommand(42, "/adjust", []string{"SIG-1"})
+	assert.Equal(t, "Usage: /adjust <signal id> <target|stop> <price>", reply)
+}
+
+func TestRegisterCommand(t *testing.T) {
+	bot := newCommandBot()
+	handler := func(userID int64, args []string) (string, error) { return "", nil }
+
+	assert.Error(t, bot.RegisterCommand(Command{Name: "/", Handler: handler}))
+	assert.Error(t, bot.RegisterCommand(Command{Name: "nohandler"}))
+	assert.Error(t, bot.RegisterCommand(Command{Name: "bounds", MinArgs: 2, MaxArgs: 1, Handler: handler}))
+	assert.Error(t, bot.RegisterCommand(Command{Name: "/Help", Handler: handler}), "already registered")
+	assert.NoError(t, bot.RegisterCommand(Command{Name: "extra", Description: "An extension", Handler: handler}))
+
+	reply, err := bot.HandleCommand(7, "/EXTRA", nil)
+	assert.NoError(t, err)
+	assert.Empty(t, reply)
+}
+
+func TestHelpCommandHidesAdminCommands(t *testing.T) {
+	bot := newCommandBot()
+
+	reply, err := bot.HandleCommand(7, "/help", nil)
+	assert.NoError(t, err)
+	assert.Contains(t, reply, "/alert <rule> - Create a price or indicator alert")
+	assert.NotContains(t, reply, "Admin Commands")
+	assert.NotContains(t, reply, "/grant")
+
+	reply, _ = bot.HandleCommand(42, "/help", nil)
+	assert.Contains(t, reply, "Admin Commands:\n\n/adjust")
+	assert.Contains(t, reply, "/grant <user id> [days] - Grant premium access")
+
+	// Help on one command shows its usage, unless it is hidden from the user
+	reply, _ = bot.HandleCommand(7, "/help", []string{"alert"})
+	assert.Equal(t, "Usage: /alert <rule>\n\nExamples:\n/alert AAPL crosses above 200\n/alert RSI(TSLA) < 20\n\nCreate a price or indicator alert", reply)
+	reply, _ = bot.HandleCommand(7, "/help", []string{"grant"})
+	assert.Equal(t, "Unknown command. Type /help for available commands.", reply)
+}
@@ -0,0 +1,73 @@
+package telegram
+
+import (
+	"strings"
+
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// withDisclaimer appends the configured disclaimer to an outgoing HTML message,
+// unless it already ends with it
+func (b *Bot) withDisclaimer(message string) string {
+	return b.withMarkupDisclaimer(message, signal.HTML)
+}
+
+// withMarkupDisclaimer appends the configured disclaimer to an outgoing
+// message in the given markup, unless it already ends with it
+func (b *Bot) withMarkupDisclaimer(message string, m signal.Markup) string {
+	b.mu.RLock()
+	disclaimer := b.config.Disclaimer
+	b.mu.RUnlock()
+
+	if disclaimer == "" {
+		return message
+	}
+	footer := "\n\n" + m.Italic(disclaimer)
+	if strings.HasSuffix(message, footer) {
+		return message
+	}
+	return message + footer
+}
+
+// stripFields returns the signal fields withheld from the channel with the given chat ID
+func (b *Bot) stripFields(channelID string) []string {
+	for _, ch := range b.signalChannels() {
+		if ch.ChannelID == channelID {
+			return b.withheldFields(ch.StripFields)
+		}
+	}
+	return b.withheldFields(nil)
+}
+
+// withheldFields adds the score breakdown to stripped fields unless messages
+// are configured to show it
+func (b *Bot) withheldFields(fields []string) []string {
+	b.mu.RLock()
+	show := b.config.ScoreBreakdown
+	b.mu.RUnlock()
+
+	if show || containsField(fields, "score_breakdown") {
+		return fields
+	}
+	return append(append([]string(nil), fields...), "score_breakdown")
+}
+
+// channelMarkup returns the message markup of the channel with the given chat ID
+func (b *Bot) channelMarkup(channelID string) signal.Markup {
+	for _, ch := range b.signalChannels() {
+		if ch.ChannelID == channelID {
+			return signal.MarkupFor(ch.ParseMode)
+		}
+	}
+	return signal.HTML
+}
+
+// containsField reports whether field is one of the stripped fields
+func containsField(fields []string, field string) bool {
+	for _, f := range fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package telegram
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// DedupLedger records sent notifications so they are not sent again after a
+// restart (implemented by store.Logger and cache.Redis)
+type DedupLedger interface {
+	// ClaimNotification records hash as sent to chatID, reporting false if it already was
+	ClaimNotification(hash, chatID string) (bool, error)
+	// ReleaseNotification forgets a claim whose notification could not be sent
+	ReleaseNotification(hash, chatID string) error
+}
+
+// SetDedupLedger enables deduplication of signal notifications across restarts
+func (b *Bot) SetDedupLedger(ledger DedupLedger) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.ledger = ledger
+}
+
+// signalHash identifies a signal by its content rather than its ID, since a
+// re-run of the same check after a restart generates a new ID
+func signalHash(s *signal.Signal) string {
+	return hashNotification(fmt.Sprintf("signal|%s|%s|%s|%.2f|%.2f|%.2f",
+		s.GeneratedAt.Format("2006-01-02"), s.Symbol, s.Type, s.Price, s.TargetPrice, s.StopLoss))
+}
+
+// outcomeHash identifies the final outcome notification of a signal
+func outcomeHash(s *signal.Signal) string {
+	return hashNotification(fmt.Sprintf("outcome|%s|%s", signalHash(s), s.Status))
+}
+
+// hashNotification returns the hex SHA-256 of a notification key
+func hashNotification(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// claim reports whether a notification should be sent to chatID. Without a ledger
+// everything is sent; ledger errors also send, preferring a duplicate to a loss.
+// Claims are taken before sending, so concurrent deliveries cannot both send,
+// and released if the send fails.
+func (b *Bot) claim(hash, chatID string) bool {
+	b.mu.RLock()
+	ledger := b.ledger
+	b.mu.RUnlock()
+
+	if ledger == nil {
+		return true
+	}
+
+	claimed, err := ledger.ClaimNotification(hash, chatID)
+	if err != nil {
+		log.Printf("Error checking notification ledger: %v", err)
+		return true
+	}
+	if !claimed {
+		log.Printf("Skipping notification already sent to %s", chatID)
+	}
+	return claimed
+}
+
+// release forgets the claim of a notification that could not be sent, so a
+// later delivery sends it. Messages sent without a claim have no hash.
+func (b *Bot) release(hash, chatID string) {
+	if hash == "" {
+		return
+	}
+
+	b.mu.RLock()
+	ledger := b.ledger
+	b.mu.RUnlock()
+
+	if ledger == nil {
+		return
+	}
+	if err := ledger.ReleaseNotification(hash, chatID); err != nil {
+		log.Printf("Error releasing notification to %s: %v", chatID, err)
+	}
+}
@@ -0,0 +1,175 @@
+package telegram
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/signal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryLedger is an in-memory DedupLedger
+type memoryLedger struct {
+	claimed map[string]bool
+	err     error
+	mu      sync.Mutex
+}
+
+func newMemoryLedger() *memoryLedger {
+	return &memoryLedger{claimed: make(map[string]bool)}
+}
+
+func (l *memoryLedger) ClaimNotification(hash, chatID string) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.err != nil {
+		return false, l.err
+	}
+	if l.claimed[chatID+":"+hash] {
+		return false, nil
+	}
+	l.claimed[chatID+":"+hash] = true
+	return true, nil
+}
+
+func (l *memoryLedger) ReleaseNotification(hash, chatID string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.claimed, chatID+":"+hash)
+	return nil
+}
+
+func (l *memoryLedger) len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.claimed)
+}
+
+func TestSignalHashIgnoresID(t *testing.T) {
+	s := testSignal()
+	rerun := testSignal()
+	rerun.ID = "SIG-AAPL-BUY-2"
+	assert.Equal(t, signalHash(s), signalHash(rerun))
+
+	rerun.Price = 151
+	assert.NotEqual(t, signalHash(s), signalHash(rerun))
+
+	// Each final status is its own notification
+	s.Status = signal.StatusSuccess
+	failed := testSignal()
+	failed.Status = signal.StatusFailure
+	assert.NotEqual(t, outcomeHash(s), outcomeHash(failed))
+	assert.NotEqual(t, signalHash(s), outcomeHash(s))
+}
+
+func TestSendSignalSkipsClaimed(t *testing.T) {
+	api := newFakeAPI(t)
+	ledger := newMemoryLedger()
+	bot := NewBot(config.TelegramConfig{BotToken: "test-token", ChannelID: "@test_channel"})
+	bot.SetDedupLedger(ledger)
+
+	// A restart re-runs the check and generates the signal under a new ID
+	assert.NoError(t, bot.SendSignal(testSignal()))
+	rerun := testSignal()
+	rerun.ID = "SIG-AAPL-BUY-2"
+	assert.NoError(t, bot.SendSignal(rerun))
+
+	assert.Len(t, api.sent("sendMessage"), 1)
+	assert.Equal(t, 1, ledger.len())
+}
+
+func TestSendSignalReleasesFailedClaim(t *testing.T) {
+	api := newFakeAPI(t)
+	api.failNext("sendMessage", http.StatusBadGateway)
+	ledger := newMemoryLedger()
+	bot := NewBot(config.TelegramConfig{BotToken: "test-token", ChannelID: "@test_channel"})
+	bot.SetDedupLedger(ledger)
+
+	// A failed send leaves nothing claimed, so the next attempt goes out
+	assert.Error(t, bot.SendSignal(testSignal()))
+	assert.Equal(t, 0, ledger.len())
+
+	assert.NoError(t, bot.SendSignal(testSignal()))
+	assert.Len(t, api.sent("sendMessage"), 2)
+	assert.Equal(t, 1, ledger.len())
+}
+
+func TestQueuedSignalReleasesDroppedClaim(t *testing.T) {
+	api := newFakeAPI(t)
+	api.failNext("sendMessage", http.StatusForbidden)
+	ledger := newMemoryLedger()
+	bot := NewBot(config.TelegramConfig{BotToken: "test-token", ChannelID: "@test_channel"})
+	bot.SetDedupLedger(ledger)
+	require.NoError(t, bot.StartQueue(nil))
+	defer bot.StopQueue()
+
+	// The claim is held while the message waits in the queue...
+	assert.NoError(t, bot.SendSignal(testSignal()))
+	assert.Equal(t, 1, ledger.len())
+
+	// ...and released once the queue gives up on it
+	assert.Eventually(t, func() bool { return ledger.len() == 0 }, 5*time.Second, 10*time.Millisecond)
+	assert.Len(t, api.sent("sendMessage"), 1)
+}
+
+func TestHeldSignalReleasesClaimWhenClosed(t *testing.T) {
+	ledger := newMemoryLedger()
+	now := time.Now().UTC()
+	quiet := config.QuietHoursConfig{Start: now.Add(-time.Hour).Format("15:04"), End: now.Add(time.Hour).Format("15:04")}
+	bot := NewBotWithMode(config.TelegramConfig{
+		Channels: []config.TelegramChannelConfig{{Name: "main", ChannelID: "@test_channel", QuietHours: quiet}},
+	}, true)
+	bot.SetDedupLedger(ledger)
+
+	s := testSignal()
+	assert.NoError(t, bot.SendSignal(s))
+	assert.Equal(t, 1, ledger.len())
+	assert.Empty(t, bot.GetMockMessages())
+
+	// The signal closed during quiet hours, so it was never sent
+	s.Status = signal.StatusSuccess
+	assert.NoError(t, bot.SendSignalOutcome(s, 155))
+	bot.ReleaseHeldMessages(now.Add(2 * time.Hour))
+	assert.Equal(t, 0, ledger.len())
+}
+
+func TestSendSignalOutcomeReleasesFailedClaim(t *testing.T) {
+	api := newFakeAPI(t)
+	ledger := newMemoryLedger()
+	bot := NewBot(config.TelegramConfig{BotToken: "test-token", ChannelID: "@test_channel"})
+	bot.SetDedupLedger(ledger)
+	require.NoError(t, bot.SendSignal(testSignal()))
+
+	// Neither the edit nor the reply got through
+	api.failNext("editMessageText", http.StatusBadRequest)
+	api.failNext("sendMessage", http.StatusBadGateway)
+	s := testSignal()
+	s.Status = signal.StatusSuccess
+	assert.Error(t, bot.SendSignalOutcome(s, 155))
+	assert.Equal(t, 1, ledger.len(), "only the signal itself stays claimed")
+
+	// A successful edit keeps its claim
+	bot.mu.Lock()
+	bot.signalMsgs[s.ID] = []sentMessage{{channelID: "@test_channel", messageID: 1}}
+	bot.mu.Unlock()
+	assert.NoError(t, bot.SendSignalOutcome(s, 155))
+	assert.Equal(t, 2, ledger.len())
+}
+
+func TestClaimSendsOnLedgerError(t *testing.T) {
+	api := newFakeAPI(t)
+	ledger := newMemoryLedger()
+	ledger.err = errors.New("database down")
+	bot := NewBot(config.TelegramConfig{BotToken: "test-token", ChannelID: "@test_channel"})
+	bot.SetDedupLedger(ledger)
+
+	// A duplicate is preferred to a lost signal
+	assert.NoError(t, bot.SendSignal(testSignal()))
+	assert.NoError(t, bot.SendSignal(testSignal()))
+	assert.Len(t, api.sent("sendMessage"), 2)
+}
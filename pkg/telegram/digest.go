@@ -0,0 +1,156 @@
+package telegram
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// defaultDigestUrgentConfidence is the confidence at or above which signals
+// skip a subscriber's digest, unless configured
+const defaultDigestUrgentConfidence = 0.85
+
+// Digest intervals a subscriber can choose
+const (
+	minDigestInterval = 5 * time.Minute
+	maxDigestInterval = 24 * time.Hour
+)
+
+// pendingDigest holds the signals batched for a subscriber until it is due
+type pendingDigest struct {
+	signals []signal.Signal
+	due     time.Time
+}
+
+// urgentConfidence returns the confidence at or above which signals are sent
+// instantly to subscribers with a digest
+func (b *Bot) urgentConfidence() float64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.config.DigestUrgentConfidence > 0 {
+		return b.config.DigestUrgentConfidence
+	}
+	return defaultDigestUrgentConfidence
+}
+
+// digestSignal adds a signal to a subscriber's digest instead of sending it,
+// reporting false if the subscriber has no digest or the signal is urgent
+func (b *Bot) digestSignal(userID int64, prefs Preferences, s *signal.Signal, now time.Time) bool {
+	if prefs.DigestMinutes <= 0 || s.Confidence >= b.urgentConfidence() {
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	digest, exists := b.digests[userID]
+	if !exists {
+		digest = &pendingDigest{due: now.Add(time.Duration(prefs.DigestMinutes) * time.Minute)}
+		b.digests[userID] = digest
+	}
+	digest.signals = append(digest.signals, *s)
+	return true
+}
+
+// FlushDigests sends every digest that is due as one message. Signals that
+// closed while they waited are left out.
+func (b *Bot) FlushDigests(now time.Time) {
+	b.mu.Lock()
+	due := make(map[int64][]signal.Signal)
+	for userID, digest := range b.digests {
+		if !now.Before(digest.due) {
+			due[userID] = digest.signals
+			delete(b.digests, userID)
+		}
+	}
+	b.mu.Unlock()
+
+	for userID, signals := range due {
+		var open []signal.Signal
+		for _, s := range signals {
+			if !b.isClosed(s.ID) {
+				open = append(open, s)
+			}
+		}
+		if len(open) == 0 {
+			continue
+		}
+		if err := b.SendDirectMessage(userID, b.withDisclaimer(formatDigest(open))); err != nil {
+			log.Printf("Error sending signal digest to user %d: %v", userID, err)
+		}
+	}
+}
+
+// formatDigest formats batched signals as one HTML message
+func formatDigest(signals []signal.Signal) string {
+	message := fmt.Sprintf("📬 <b>Signal Digest</b> (%d signals)\n\n", len(signals))
+	for _, s := range signals {
+		message += fmt.Sprintf("<b>%s %s</b> at $%.2f, target $%.2f, stop $%.2f, %.0f%% confidence (%s)\n",
+			s.Type, html.EscapeString(s.Symbol), s.Price, s.TargetPrice, s.StopLoss, s.Confidence*100, s.GeneratedAt.Format("15:04"))
+	}
+	return strings.TrimSuffix(message, "\n")
+}
+
+// parseDigestInterval parses a digest interval in minutes or as a duration
+// like 2h, 0 for off
+func parseDigestInterval(arg string) (time.Duration, error) {
+	if strings.EqualFold(arg, "off") {
+		return 0, nil
+	}
+
+	interval, err := time.ParseDuration(arg)
+	if minutes, convErr := strconv.Atoi(arg); convErr == nil {
+		interval, err = time.Duration(minutes)*time.Minute, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("invalid interval: %s", arg)
+	}
+	if interval < minDigestInterval || interval > maxDigestInterval {
+		return 0, fmt.Errorf("the interval must be between %s and %s", minDigestInterval, maxDigestInterval)
+	}
+	return interval.Round(time.Minute), nil
+}
+
+// handleDigestCommand handles the /digest command, batching the user's
+// non-urgent signals into one message per interval
+func (b *Bot) handleDigestCommand(userID int64, args []string) (string, error) {
+	interval, err := parseDigestInterval(args[0])
+	if err != nil {
+		return fmt.Sprintf("Could not set your digest: %v", err), nil
+	}
+
+	b.mu.Lock()
+	prefs, exists := b.preferences[userID]
+	if !exists {
+		// Without onboarding every signal keeps being sent, just batched
+		prefs = unfilteredPreferences()
+	}
+	prefs.DigestMinutes = int(interval / time.Minute)
+	b.preferences[userID] = prefs
+	b.subscribers[userID] = true
+	if digest, exists := b.digests[userID]; exists && interval == 0 {
+		// Send what was batched so far right away
+		digest.due = time.Time{}
+	}
+	b.mu.Unlock()
+	b.savePreferences()
+
+	if interval == 0 {
+		b.FlushDigests(time.Now())
+		return "Digest off. Signals will be sent as they come.", nil
+	}
+	return fmt.Sprintf("Signals will be batched into a digest every %s. Signals with at least %.0f%% confidence are still sent instantly.",
+		formatInterval(interval), b.urgentConfidence()*100), nil
+}
+
+// formatInterval formats a digest interval, e.g. 30m or 2h
+func formatInterval(interval time.Duration) string {
+	if interval%time.Hour == 0 {
+		return fmt.Sprintf("%dh", interval/time.Hour)
+	}
+	return fmt.Sprintf("%dm", interval/time.Minute)
+}
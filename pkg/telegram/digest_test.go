@@ -0,0 +1,119 @@
+package telegram
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/signal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// digestTestSignal returns an active BUY signal with the given confidence
+func digestTestSignal(id, symbol string, confidence float64) *signal.Signal {
+	s := testSignal()
+	s.ID = id
+	s.Symbol = symbol
+	s.Confidence = confidence
+	return s
+}
+
+func TestParseDigestInterval(t *testing.T) {
+	testCases := []struct {
+		arg      string
+		interval time.Duration
+		err      string
+	}{
+		{"30", 30 * time.Minute, ""},
+		{"2h", 2 * time.Hour, ""},
+		{"90m30s", 91 * time.Minute, ""},
+		{"OFF", 0, ""},
+		{"1", 0, "the interval must be between 5m0s and 24h0m0s"},
+		{"25h", 0, "the interval must be between 5m0s and 24h0m0s"},
+		{"soon", 0, "invalid interval: soon"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.arg, func(t *testing.T) {
+			interval, err := parseDigestInterval(tc.arg)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.interval, interval)
+		})
+	}
+}
+
+func TestDigestSignal(t *testing.T) {
+	bot := NewBotWithMode(config.TelegramConfig{}, true)
+	now := time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC)
+	prefs := Preferences{Risk: "high", Frequency: "all", DigestMinutes: 30}
+
+	// Urgent signals and subscribers without a digest are sent as they come
+	assert.False(t, bot.digestSignal(1, prefs, digestTestSignal("SIG-1", "AAPL", 0.9), now))
+	assert.False(t, bot.digestSignal(1, unfilteredPreferences(), digestTestSignal("SIG-2", "AAPL", 0.7), now))
+
+	assert.True(t, bot.digestSignal(1, prefs, digestTestSignal("SIG-3", "AAPL", 0.7), now))
+	assert.True(t, bot.digestSignal(1, prefs, digestTestSignal("SIG-4", "MSFT", 0.6), now.Add(20*time.Minute)))
+	require.Contains(t, bot.digests, int64(1))
+	assert.Len(t, bot.digests[1].signals, 2)
+	assert.Equal(t, now.Add(30*time.Minute), bot.digests[1].due, "due from the first signal")
+
+	// The urgent threshold can be configured
+	strict := NewBotWithMode(config.TelegramConfig{DigestUrgentConfidence: 0.95}, true)
+	assert.True(t, strict.digestSignal(1, prefs, digestTestSignal("SIG-1", "AAPL", 0.9), now))
+}
+
+func TestFlushDigests(t *testing.T) {
+	bot := NewBotWithMode(config.TelegramConfig{}, true)
+	now := time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC)
+	prefs := Preferences{Risk: "high", Frequency: "all", DigestMinutes: 30}
+	bot.digestSignal(1, prefs, digestTestSignal("SIG-1", "AAPL", 0.7), now)
+	bot.digestSignal(1, prefs, digestTestSignal("SIG-2", "MSFT", 0.7), now)
+	bot.digestSignal(2, prefs, digestTestSignal("SIG-2", "MSFT", 0.7), now)
+	bot.mu.Lock()
+	bot.closed["SIG-2"] = true
+	bot.mu.Unlock()
+
+	bot.FlushDigests(now.Add(29 * time.Minute))
+	assert.Empty(t, bot.GetMockMessages())
+
+	// Closed signals are left out, and a digest with only those is not sent
+	bot.FlushDigests(now.Add(30 * time.Minute))
+	messages := bot.GetMockMessages()
+	require.Len(t, messages, 1)
+	assert.Contains(t, messages[0], "<b>Signal Digest</b> (1 signals)")
+	assert.Contains(t, messages[0], "<b>BUY AAPL</b> at $150.00, target $155.00, stop $148.00, 70% confidence (10:15)")
+	assert.NotContains(t, messages[0], "MSFT")
+	assert.Empty(t, bot.digests)
+}
+
+func TestDigestCommand(t *testing.T) {
+	bot := NewBotWithMode(config.TelegramConfig{}, true)
+
+	reply, err := bot.HandleCommand(7, "/digest", []string{"soon"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Could not set your digest: invalid interval: soon", reply)
+
+	reply, err = bot.HandleCommand(7, "/digest", []string{"2h"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Signals will be batched into a digest every 2h. Signals with at least 85% confidence are still sent instantly.", reply)
+	prefs, exists := bot.GetPreferences(7)
+	require.True(t, exists)
+	assert.Equal(t, 120, prefs.DigestMinutes)
+	assert.Equal(t, "high", prefs.Risk, "every signal is still delivered")
+	assert.Contains(t, bot.GetSubscribers(), int64(7))
+
+	// Turning the digest off sends what was batched at once
+	bot.digestSignal(7, prefs, digestTestSignal("SIG-1", "AAPL", 0.7), time.Now())
+	reply, err = bot.HandleCommand(7, "/digest", []string{"off"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Digest off. Signals will be sent as they come.", reply)
+	require.Len(t, bot.GetMockMessages(), 1)
+	assert.Contains(t, bot.GetMockMessages()[0], "AAPL")
+	prefs, _ = bot.GetPreferences(7)
+	assert.Equal(t, 0, prefs.DigestMinutes)
+}
@@ -0,0 +1,83 @@
+package telegram
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/focus"
+)
+
+// FocusWatcher polls single symbols at high frequency (implemented by focus.Watcher)
+type FocusWatcher interface {
+	Start(symbol string, duration time.Duration, chatID int64) (focus.Session, error)
+	Stop(symbol string) bool
+	Sessions() []focus.Session
+}
+
+// SetFocusWatcher enables the admin /focus and /unfocus commands
+func (b *Bot) SetFocusWatcher(watcher FocusWatcher) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.focus = watcher
+}
+
+// getFocusWatcher returns the configured focus watcher, if any
+func (b *Bot) getFocusWatcher() FocusWatcher {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.focus
+}
+
+// handleFocusCommand handles the admin /focus command. With a symbol it polls
+// the symbol every few seconds and sends its micro-breakouts to the admin;
+// without one it lists the symbols in focus.
+func (b *Bot) handleFocusCommand(userID int64, args []string) (string, error) {
+	watcher := b.getFocusWatcher()
+	if watcher == nil {
+		return "Focus mode is not available.", nil
+	}
+
+	if len(args) == 0 {
+		sessions := watcher.Sessions()
+		if len(sessions) == 0 {
+			return "No symbols in focus. Usage: /focus <symbol> [minutes]", nil
+		}
+		message := "In Focus:\n\n"
+		for _, s := range sessions {
+			message += fmt.Sprintf("%s - $%.2f, %d alerts, until %s\n", s.Symbol, s.LastPrice, s.Alerts, s.ExpiresAt.Format("15:04"))
+		}
+		return message, nil
+	}
+
+	var duration time.Duration
+	if len(args) == 2 {
+		minutes, err := strconv.Atoi(args[1])
+		if err != nil || minutes <= 0 {
+			return "Usage: /focus <symbol> [minutes]", nil
+		}
+		duration = time.Duration(minutes) * time.Minute
+	}
+
+	session, err := watcher.Start(strings.ToUpper(args[0]), duration, userID)
+	if err != nil {
+		return fmt.Sprintf("Could not focus: %v", err), nil
+	}
+	return fmt.Sprintf("Focusing on %s until %s. Micro-breakouts will be sent here.",
+		session.Symbol, session.ExpiresAt.Format("15:04")), nil
+}
+
+// handleUnfocusCommand handles the admin /unfocus command
+func (b *Bot) handleUnfocusCommand(userID int64, args []string) (string, error) {
+	watcher := b.getFocusWatcher()
+	if watcher == nil {
+		return "Focus mode is not available.", nil
+	}
+
+	symbol := strings.ToUpper(args[0])
+	if !watcher.Stop(symbol) {
+		return fmt.Sprintf("%s is not in focus.", symbol), nil
+	}
+	return fmt.Sprintf("Stopped focusing on %s.", symbol), nil
+}
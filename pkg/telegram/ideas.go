@@ -0,0 +1,114 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// cashtag matches a ticker written as $AAPL or $BRK.B
+var cashtag = regexp.MustCompile(`\$([A-Za-z]{1,5}(?:\.[A-Za-z])?)\b`)
+
+// Words that give a trade idea its side
+var (
+	longWords  = map[string]bool{"long": true, "buy": true, "bullish": true, "calls": true}
+	shortWords = map[string]bool{"short": true, "sell": true, "bearish": true, "puts": true}
+)
+
+// IdeaDesk vets trade ideas and tracks them as signals (implemented by monitor.MarketMonitor)
+type IdeaDesk interface {
+	AssessIdea(ctx context.Context, symbol string, side signal.SignalType, thesis, submittedBy string) (*signal.Idea, error)
+	TrackIdea(id string) (*signal.Signal, error)
+}
+
+// SetIdeaDesk enables the admin /idea and /track commands, and vetting of
+// messages with a $TICKER admins forward to the bot
+func (b *Bot) SetIdeaDesk(desk IdeaDesk) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.ideas = desk
+}
+
+// handleIdeaCommand handles /idea <symbol> [long|short] [thesis]
+func (b *Bot) handleIdeaCommand(userID int64, args []string) (string, error) {
+	symbol := strings.ToUpper(strings.TrimPrefix(args[0], "$"))
+	var side signal.SignalType
+	thesis := args[1:]
+	if len(thesis) > 0 {
+		if side = ideaSide(thesis[:1]); side != "" {
+			thesis = thesis[1:]
+		}
+	}
+	return b.assessIdea(userID, symbol, side, strings.Join(thesis, " ")), nil
+}
+
+// handleIdeaText vets a plain message from an admin mentioning a $TICKER,
+// such as a forwarded thesis. It reports false for other messages.
+func (b *Bot) handleIdeaText(userID int64, text string) (string, bool) {
+	b.mu.RLock()
+	desk := b.ideas
+	b.mu.RUnlock()
+	if desk == nil || !b.IsAdmin(userID) {
+		return "", false
+	}
+
+	match := cashtag.FindStringSubmatch(text)
+	if match == nil {
+		return "", false
+	}
+	return b.assessIdea(userID, strings.ToUpper(match[1]), ideaSide(strings.Fields(text)), strings.TrimSpace(text)), true
+}
+
+// assessIdea has the desk vet an idea and formats its assessment
+func (b *Bot) assessIdea(userID int64, symbol string, side signal.SignalType, thesis string) string {
+	b.mu.RLock()
+	desk := b.ideas
+	b.mu.RUnlock()
+	if desk == nil {
+		return "Trade ideas are not available."
+	}
+
+	idea, err := desk.AssessIdea(context.Background(), symbol, side, thesis, "telegram:"+strconv.FormatInt(userID, 10))
+	if err != nil {
+		return fmt.Sprintf("Could not assess %s: %v", symbol, err)
+	}
+	return signal.FormatIdea(idea)
+}
+
+// handleTrackCommand handles /track <idea id>, turning an assessed idea into a tracked signal
+func (b *Bot) handleTrackCommand(userID int64, args []string) (string, error) {
+	b.mu.RLock()
+	desk := b.ideas
+	b.mu.RUnlock()
+	if desk == nil {
+		return "Trade ideas are not available.", nil
+	}
+
+	s, err := desk.TrackIdea(args[0])
+	if err != nil {
+		return fmt.Sprintf("Could not track the idea: %v", err), nil
+	}
+	return fmt.Sprintf("Tracking %s %s at $%.2f as signal %s (target $%.2f, stop $%.2f)",
+		s.Type, s.Symbol, s.Price, s.ID, s.TargetPrice, s.StopLoss), nil
+}
+
+// ideaSide returns the side the words of an idea take, empty if none or both
+func ideaSide(words []string) signal.SignalType {
+	var long, short bool
+	for _, word := range words {
+		word = strings.ToLower(strings.Trim(word, ".,!?:;()"))
+		long = long || longWords[word]
+		short = short || shortWords[word]
+	}
+	switch {
+	case long && !short:
+		return signal.BUY
+	case short && !long:
+		return signal.SELL
+	}
+	return ""
+}
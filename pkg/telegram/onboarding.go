@@ -0,0 +1,290 @@
+package telegram
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// preferencesStateKey is the app state key subscriber preferences are persisted under
+const preferencesStateKey = "telegram_preferences"
+
+// Onboarding steps, in order
+const (
+	stepSymbols = iota + 1
+	stepRisk
+	stepFrequency
+)
+
+// riskConfidence is the lowest signal confidence delivered at each risk tolerance
+var riskConfidence = map[string]float64{"low": 0.8, "medium": 0.65, "high": 0}
+
+// frequencyLimit is the most signals delivered a day at each notification
+// frequency, 0 for no limit
+var frequencyLimit = map[string]int{"all": 0, "few": 5, "one": 1}
+
+// tickerPattern matches the symbols a subscriber can pick
+var tickerPattern = regexp.MustCompile(`^[A-Z][A-Z0-9.\-]{0,9}$`)
+
+// Preferences are a subscriber's choices for direct signal delivery
+type Preferences struct {
+	Symbols       []string `json:"symbols,omitempty"`        // Empty for every symbol
+	Risk          string   `json:"risk"`                     // low, medium or high
+	Frequency     string   `json:"frequency"`                // all, few or one
+	DigestMinutes int      `json:"digest_minutes,omitempty"` // Batch non-urgent signals this often, 0 to send each as it comes
+	TimeZone      string   `json:"time_zone,omitempty"`      // Quiet hours follow this IANA time zone
+}
+
+// defaultPreferences are kept for the steps a subscriber skips
+func defaultPreferences() Preferences {
+	return Preferences{Risk: "medium", Frequency: "all"}
+}
+
+// unfilteredPreferences accept every signal, for subscribers who set a
+// delivery option without onboarding
+func unfilteredPreferences() Preferences {
+	return Preferences{Risk: "high", Frequency: "all"}
+}
+
+// Accepts reports whether a signal matches the preferred symbols and risk tolerance
+func (p Preferences) Accepts(s *signal.Signal) bool {
+	if s.Confidence < riskConfidence[p.Risk] {
+		return false
+	}
+	if len(p.Symbols) == 0 {
+		return true
+	}
+	for _, symbol := range p.Symbols {
+		if strings.EqualFold(symbol, s.Symbol) {
+			return true
+		}
+	}
+	return false
+}
+
+// describe summarizes the preferences for a reply
+func (p Preferences) describe() string {
+	symbols := "all symbols"
+	if len(p.Symbols) > 0 {
+		symbols = strings.Join(p.Symbols, ", ")
+	}
+	frequency := "every signal"
+	if limit := frequencyLimit[p.Frequency]; limit > 0 {
+		frequency = fmt.Sprintf("up to %d a day", limit)
+	}
+	delivery := "instant"
+	if p.DigestMinutes > 0 {
+		delivery = "digest every " + formatInterval(time.Duration(p.DigestMinutes)*time.Minute)
+	}
+	message := fmt.Sprintf("Symbols: %s\nRisk tolerance: %s\nFrequency: %s\nDelivery: %s", symbols, p.Risk, frequency, delivery)
+	if p.TimeZone != "" {
+		message += "\nTime zone: " + p.TimeZone
+	}
+	return message
+}
+
+// dailyCount counts the signals sent to a subscriber on one day
+type dailyCount struct {
+	day   string
+	count int
+}
+
+// SetPreferenceStore persists subscriber preferences to store and restores
+// those saved by a previous run. Subscribers with preferences are subscribed.
+func (b *Bot) SetPreferenceStore(store StateStore) error {
+	data, err := store.LoadAppState(preferencesStateKey)
+	if err != nil {
+		return fmt.Errorf("failed to load subscriber preferences: %w", err)
+	}
+
+	restored := make(map[int64]Preferences)
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &restored); err != nil {
+			return fmt.Errorf("failed to parse subscriber preferences: %w", err)
+		}
+	}
+
+	b.mu.Lock()
+	b.preferenceStore = store
+	for userID, prefs := range restored {
+		b.preferences[userID] = prefs
+		b.subscribers[userID] = true
+	}
+	b.mu.Unlock()
+
+	if len(restored) > 0 {
+		log.Printf("Restored preferences of %d Telegram subscribers", len(restored))
+	}
+	return nil
+}
+
+// savePreferences persists the subscriber preferences, if a store is set
+func (b *Bot) savePreferences() {
+	b.mu.RLock()
+	store := b.preferenceStore
+	data, err := json.Marshal(b.preferences)
+	b.mu.RUnlock()
+
+	if store == nil {
+		return
+	}
+	if err != nil {
+		log.Printf("Error encoding subscriber preferences: %v", err)
+		return
+	}
+	if err := store.SaveAppState(preferencesStateKey, data); err != nil {
+		log.Printf("Error saving subscriber preferences: %v", err)
+	}
+}
+
+// GetPreferences returns a subscriber's preferences, if they finished onboarding
+func (b *Bot) GetPreferences(userID int64) (Preferences, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	prefs, exists := b.preferences[userID]
+	return prefs, exists
+}
+
+// startOnboarding starts the preferences wizard for a user and returns its
+// first question. Skipped steps keep the user's current choices.
+func (b *Bot) startOnboarding(userID int64) string {
+	b.mu.Lock()
+	prefs, exists := b.preferences[userID]
+	if !exists {
+		prefs = defaultPreferences()
+	}
+	b.onboarding[userID] = &onboardingState{step: stepSymbols, prefs: prefs}
+	b.mu.Unlock()
+	return onboardingQuestion(stepSymbols)
+}
+
+// onboardingState is a user's progress through the preferences wizard
+type onboardingState struct {
+	step  int
+	prefs Preferences
+}
+
+// onboardingQuestion returns the question asked at an onboarding step
+func onboardingQuestion(step int) string {
+	switch step {
+	case stepSymbols:
+		return "Step 1/3: Which symbols interest you? Reply with tickers separated by commas, e.g. AAPL, MSFT, or \"all\"."
+	case stepRisk:
+		return "Step 2/3: What is your risk tolerance? Reply \"low\" for only the highest-confidence signals, \"medium\" or \"high\" for every signal."
+	default:
+		return "Step 3/3: How often do you want signals? Reply \"all\" for every signal, \"few\" for up to 5 a day or \"one\" for 1 a day."
+	}
+}
+
+// handleOnboardingReply handles a plain message from a user in the preferences
+// wizard, reporting false if the user is not onboarding. "skip" keeps the
+// default for a step.
+func (b *Bot) handleOnboardingReply(userID int64, text string) (string, bool) {
+	b.mu.Lock()
+	state, onboarding := b.onboarding[userID]
+	if !onboarding {
+		b.mu.Unlock()
+		return "", false
+	}
+	reply, finished := b.advanceOnboarding(userID, state, text)
+	b.mu.Unlock()
+
+	if finished {
+		b.savePreferences()
+	}
+	return reply, true
+}
+
+// advanceOnboarding applies a reply to a user's onboarding step and returns
+// the next question, or a summary and true once the wizard is finished. The
+// caller must hold the bot's lock.
+func (b *Bot) advanceOnboarding(userID int64, state *onboardingState, text string) (string, bool) {
+
+	answer := strings.ToLower(strings.TrimSpace(text))
+	if answer != "skip" {
+		switch state.step {
+		case stepSymbols:
+			symbols, err := parseSymbolChoice(text)
+			if err != nil {
+				return fmt.Sprintf("Could not read your symbols: %v\n\n%s", err, onboardingQuestion(stepSymbols)), false
+			}
+			state.prefs.Symbols = symbols
+		case stepRisk:
+			if _, valid := riskConfidence[answer]; !valid {
+				return "Please reply low, medium or high.\n\n" + onboardingQuestion(stepRisk), false
+			}
+			state.prefs.Risk = answer
+		case stepFrequency:
+			if _, valid := frequencyLimit[answer]; !valid {
+				return "Please reply all, few or one.\n\n" + onboardingQuestion(stepFrequency), false
+			}
+			state.prefs.Frequency = answer
+		}
+	}
+
+	if state.step < stepFrequency {
+		state.step++
+		return onboardingQuestion(state.step), false
+	}
+
+	delete(b.onboarding, userID)
+	b.preferences[userID] = state.prefs
+	b.subscribers[userID] = true
+	return "You're all set. Signals matching your preferences will be sent to this chat.\n\n" +
+		state.prefs.describe() + "\n\nChange them anytime with /settings.", true
+}
+
+// parseSymbolChoice parses the symbols a subscriber picked, nil for all
+func parseSymbolChoice(text string) ([]string, error) {
+	fields := strings.FieldsFunc(strings.ToUpper(text), func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\n'
+	})
+	if len(fields) == 1 && fields[0] == "ALL" {
+		return nil, nil
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no symbols given")
+	}
+
+	seen := make(map[string]bool)
+	var symbols []string
+	for _, field := range fields {
+		symbol := strings.TrimPrefix(field, "$")
+		if !tickerPattern.MatchString(symbol) {
+			return nil, fmt.Errorf("%s is not a valid symbol", field)
+		}
+		if !seen[symbol] {
+			seen[symbol] = true
+			symbols = append(symbols, symbol)
+		}
+	}
+	return symbols, nil
+}
+
+// withinDailyLimit reports whether another signal can be sent to a subscriber
+// today under their notification frequency, and counts it if so
+func (b *Bot) withinDailyLimit(userID int64, prefs Preferences, now time.Time) bool {
+	limit := frequencyLimit[prefs.Frequency]
+	if limit == 0 {
+		return true
+	}
+
+	day := now.Format("2006-01-02")
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	sent := b.directCounts[userID]
+	if sent.day != day {
+		sent = dailyCount{day: day}
+	}
+	if sent.count >= limit {
+		return false
+	}
+	sent.count++
+	b.directCounts[userID] = sent
+	return true
+}
@@ -0,0 +1,126 @@
+package telegram
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/signal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreferencesAccepts(t *testing.T) {
+	testCases := []struct {
+		name       string
+		prefs      Preferences
+		symbol     string
+		confidence float64
+		accepts    bool
+	}{
+		{"every symbol", Preferences{Risk: "medium"}, "AAPL", 0.7, true},
+		{"below the risk tolerance", Preferences{Risk: "low"}, "AAPL", 0.7, false},
+		{"high risk takes anything", Preferences{Risk: "high"}, "AAPL", 0.1, true},
+		{"picked symbol in any case", Preferences{Symbols: []string{"aapl", "MSFT"}, Risk: "medium"}, "AAPL", 0.7, true},
+		{"other symbol", Preferences{Symbols: []string{"MSFT"}, Risk: "medium"}, "AAPL", 0.9, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &signal.Signal{Symbol: tc.symbol, Confidence: tc.confidence}
+			assert.Equal(t, tc.accepts, tc.prefs.Accepts(s))
+		})
+	}
+}
+
+func TestWithinDailyLimit(t *testing.T) {
+	bot := NewBotWithMode(config.TelegramConfig{}, true)
+	now := time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC)
+	one := Preferences{Frequency: "one"}
+
+	assert.True(t, bot.withinDailyLimit(1, one, now))
+	assert.False(t, bot.withinDailyLimit(1, one, now.Add(time.Hour)))
+	assert.True(t, bot.withinDailyLimit(2, one, now), "counted per subscriber")
+	assert.True(t, bot.withinDailyLimit(1, one, now.Add(24*time.Hour)), "reset the next day")
+
+	for i := 0; i < 10; i++ {
+		assert.True(t, bot.withinDailyLimit(3, Preferences{Frequency: "all"}, now))
+	}
+}
+
+func TestOnboardingWizard(t *testing.T) {
+	state := newMemoryState()
+	bot := NewBotWithMode(config.TelegramConfig{}, true)
+	require.NoError(t, bot.SetPreferenceStore(state))
+
+	_, onboarding := bot.handleOnboardingReply(7, "AAPL")
+	assert.False(t, onboarding, "not in the wizard before /start")
+
+	reply, err := bot.HandleCommand(7, "/start", nil)
+	require.NoError(t, err)
+	assert.Contains(t, reply, "Step 1/3")
+
+	steps := []struct {
+		answer string
+		reply  string
+	}{
+		{"AAPL, 1BAD", "Could not read your symbols: 1BAD is not a valid symbol"},
+		{"aapl, $MSFT aapl", "Step 2/3"},
+		{"extreme", "Please reply low, medium or high."},
+		{"Low", "Step 3/3"},
+		{"skip", "You're all set."},
+	}
+	for _, step := range steps {
+		reply, onboarding := bot.handleOnboardingReply(7, step.answer)
+		assert.True(t, onboarding)
+		assert.Contains(t, reply, step.reply, step.answer)
+	}
+
+	// The wizard is over and its choices are kept
+	_, onboarding = bot.handleOnboardingReply(7, "hello")
+	assert.False(t, onboarding)
+	prefs, exists := bot.GetPreferences(7)
+	require.True(t, exists)
+	assert.Equal(t, Preferences{Symbols: []string{"AAPL", "MSFT"}, Risk: "low", Frequency: "all"}, prefs)
+
+	var saved map[int64]Preferences
+	require.NoError(t, json.Unmarshal(state.values[preferencesStateKey], &saved))
+	assert.Equal(t, prefs, saved[7])
+
+	// The next run restores the preferences and the subscription
+	restarted := NewBotWithMode(config.TelegramConfig{}, true)
+	require.NoError(t, restarted.SetPreferenceStore(state))
+	restored, exists := restarted.GetPreferences(7)
+	assert.True(t, exists)
+	assert.Equal(t, prefs, restored)
+	assert.Contains(t, restarted.GetSubscribers(), int64(7))
+}
+
+func TestDeliverDirect(t *testing.T) {
+	bot := NewBotWithMode(config.TelegramConfig{ChannelID: "@test_channel"}, true)
+	bot.SetDedupLedger(newMemoryLedger())
+	bot.mu.Lock()
+	bot.subscribers[1] = true // Onboarded for AAPL
+	bot.preferences[1] = Preferences{Symbols: []string{"AAPL"}, Risk: "low", Frequency: "all"}
+	bot.subscribers[2] = true // Onboarded for one signal a day of any symbol
+	bot.preferences[2] = Preferences{Risk: "medium", Frequency: "one"}
+	bot.subscribers[3] = true // Onboarded for MSFT
+	bot.preferences[3] = Preferences{Symbols: []string{"MSFT"}, Risk: "medium", Frequency: "all"}
+	bot.subscribers[4] = true // Never onboarded
+	bot.mu.Unlock()
+
+	bot.deliverDirect(testSignal(), "first")
+	assert.Equal(t, []string{"first", "first"}, bot.GetMockMessages())
+
+	// Subscriber 2 had their signal for the day
+	next := testSignal()
+	next.ID = "SIG-AAPL-BUY-2"
+	next.Price = 151
+	bot.deliverDirect(next, "second")
+	assert.Equal(t, []string{"first", "first", "second"}, bot.GetMockMessages())
+
+	// The same signal is not sent twice
+	bot.deliverDirect(next, "second")
+	assert.Len(t, bot.GetMockMessages(), 3)
+}
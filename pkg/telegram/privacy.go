@@ -0,0 +1,108 @@
+package telegram
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hustler/trading-bot/pkg/privacy"
+)
+
+// maxExportLength keeps a /mydata reply within Telegram's message size limit
+const maxExportLength = 3800
+
+// DataRequests exports and deletes everything stored about a subscriber
+// (implemented by privacy.Service)
+type DataRequests interface {
+	Export(userID int64) (*privacy.Export, error)
+	Delete(userID int64) error
+}
+
+// subscriberRecord is what the bot itself keeps about a subscriber
+type subscriberRecord struct {
+	Subscribed  bool         `json:"subscribed"`
+	Preferences *Preferences `json:"preferences,omitempty"`
+}
+
+// SetDataRequests enables the /mydata and /deletemydata commands
+func (b *Bot) SetDataRequests(requests DataRequests) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.dataRequests = requests
+}
+
+// getDataRequests returns the configured data request handler, if any
+func (b *Bot) getDataRequests() DataRequests {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.dataRequests
+}
+
+// ExportSubscriber returns what the bot keeps in memory about userID
+func (b *Bot) ExportSubscriber(userID int64) (interface{}, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	record := subscriberRecord{Subscribed: b.subscribers[userID]}
+	if prefs, exists := b.preferences[userID]; exists {
+		record.Preferences = &prefs
+	}
+	return record, nil
+}
+
+// DeleteSubscriber unsubscribes userID and forgets their preferences
+func (b *Bot) DeleteSubscriber(userID int64) error {
+	b.mu.Lock()
+	delete(b.subscribers, userID)
+	delete(b.preferences, userID)
+	delete(b.onboarding, userID)
+	delete(b.directCounts, userID)
+	delete(b.digests, userID)
+	b.mu.Unlock()
+
+	b.savePreferences()
+	return nil
+}
+
+// handleMyDataCommand handles the /mydata command, replying with everything
+// stored about the user as JSON
+func (b *Bot) handleMyDataCommand(userID int64) (string, error) {
+	requests := b.getDataRequests()
+	if requests == nil {
+		return "Data export is not available.", nil
+	}
+
+	export, err := requests.Export(userID)
+	if err != nil {
+		return "", err
+	}
+	body, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode data export: %w", err)
+	}
+
+	reply := string(body)
+	if len(reply) > maxExportLength {
+		reply = reply[:maxExportLength] + "\n…\n\nYour export is too long for one message; please ask an admin for the full file."
+	}
+	return reply, nil
+}
+
+// handleDeleteMyDataCommand handles the /deletemydata command, which deletes
+// everything stored about the user once confirmed
+func (b *Bot) handleDeleteMyDataCommand(userID int64, args []string) (string, error) {
+	requests := b.getDataRequests()
+	if requests == nil {
+		return "Data deletion is not available.", nil
+	}
+	if len(args) != 1 || strings.ToLower(args[0]) != "confirm" {
+		return "This permanently deletes your subscription, alerts, premium access and message history.\n\n" +
+			"Send /deletemydata confirm to proceed.", nil
+	}
+
+	if err := requests.Delete(userID); err != nil {
+		return "", err
+	}
+	log.Printf("Deleted stored data for Telegram user %d on request", userID)
+	return "All data stored about you has been deleted and you have been unsubscribed.", nil
+}
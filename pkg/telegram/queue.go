@@ -0,0 +1,354 @@
+package telegram
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/errkind"
+)
+
+// Telegram Bot API rate limits
+const (
+	globalMessagesPerSecond = 30
+	privateChatInterval     = time.Second
+	groupChatInterval       = 3 * time.Second // 20 messages per minute in groups and channels
+)
+
+const (
+	// maxSendAttempts is the number of attempts before a message is dropped
+	maxSendAttempts = 8
+	// maxRetryBackoff caps the delay between attempts
+	maxRetryBackoff = 5 * time.Minute
+	// outboxStateKey is the app state key the pending queue is persisted under
+	outboxStateKey = "telegram_outbox"
+)
+
+// StateStore is a key/value store for application state (implemented by store.Logger)
+type StateStore interface {
+	SaveAppState(key string, value []byte) error
+	LoadAppState(key string) ([]byte, error)
+}
+
+// OutboundMessage is a message waiting in the outbound queue
+type OutboundMessage struct {
+	ID        int64     `json:"id"`
+	ChatID    string    `json:"chat_id"`
+	Text      string    `json:"text"`
+	ParseMode string    `json:"parse_mode,omitempty"`
+	SignalID  string    `json:"signal_id,omitempty"`  // Set for signal messages so they can be edited later
+	DedupHash string    `json:"dedup_hash,omitempty"` // Claim in the dedup ledger, released if the message is dropped
+	Attempts  int       `json:"attempts"`
+	NotBefore time.Time `json:"not_before"`
+}
+
+// Queue sends outbound messages within Telegram's rate limits, retrying failures
+// and persisting pending messages so bursts and restarts never drop notifications
+type Queue struct {
+	send      func(*OutboundMessage) (*Message, error)
+	onSent    func(*OutboundMessage, *Message)
+	onDropped func(*OutboundMessage)
+	store     StateStore
+	pending   []*OutboundMessage
+	lastSent  map[string]time.Time
+	window    []time.Time // Send times within the last second
+	nextID    int64
+	now       func() time.Time
+	wake      chan struct{}
+	mu        sync.Mutex
+}
+
+// NewQueue creates a new outbound queue. onSent is called after each successful
+// send and onDropped after a message is given up on; either may be nil.
+func NewQueue(send func(*OutboundMessage) (*Message, error), onSent func(*OutboundMessage, *Message), onDropped func(*OutboundMessage)) *Queue {
+	return &Queue{
+		send:      send,
+		onSent:    onSent,
+		onDropped: onDropped,
+		lastSent:  make(map[string]time.Time),
+		now:       time.Now,
+		wake:      make(chan struct{}, 1),
+	}
+}
+
+// SetStore persists the queue to store and restores messages left from a previous run
+func (q *Queue) SetStore(store StateStore) error {
+	data, err := store.LoadAppState(outboxStateKey)
+	if err != nil {
+		return fmt.Errorf("failed to load outbound queue: %w", err)
+	}
+
+	var restored []*OutboundMessage
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &restored); err != nil {
+			return fmt.Errorf("failed to parse outbound queue: %w", err)
+		}
+	}
+
+	q.mu.Lock()
+	q.store = store
+	q.pending = append(restored, q.pending...)
+	for _, msg := range q.pending {
+		if msg.ID > q.nextID {
+			q.nextID = msg.ID
+		}
+	}
+	q.persist()
+	q.mu.Unlock()
+
+	if len(restored) > 0 {
+		log.Printf("Restored %d pending Telegram messages", len(restored))
+		q.signal()
+	}
+	return nil
+}
+
+// Enqueue adds a message to the queue
+func (q *Queue) Enqueue(msg *OutboundMessage) {
+	q.mu.Lock()
+	q.nextID++
+	msg.ID = q.nextID
+	q.pending = append(q.pending, msg)
+	q.persist()
+	q.mu.Unlock()
+
+	q.signal()
+}
+
+// Len returns the number of pending messages
+func (q *Queue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.pending)
+}
+
+// Run sends queued messages until stop is closed
+func (q *Queue) Run(stop <-chan struct{}) {
+	for {
+		msg, wait := q.next()
+		if msg != nil {
+			sent, err := q.send(msg)
+			q.complete(msg, sent, err)
+			continue
+		}
+
+		var timer <-chan time.Time
+		if wait >= 0 {
+			timer = time.After(wait)
+		}
+		select {
+		case <-stop:
+			return
+		case <-q.wake:
+		case <-timer:
+		}
+	}
+}
+
+// next returns the first message that may be sent now, or how long to wait
+// for one (-1 when the queue is empty). Picking a message reserves its rate slot.
+func (q *Queue) next() (*OutboundMessage, time.Duration) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.pending) == 0 {
+		return nil, -1
+	}
+
+	now := q.now()
+	for len(q.window) > 0 && now.Sub(q.window[0]) >= time.Second {
+		q.window = q.window[1:]
+	}
+	if len(q.window) >= globalMessagesPerSecond {
+		return nil, q.window[0].Add(time.Second).Sub(now)
+	}
+
+	wait := time.Duration(-1)
+	for _, msg := range q.pending {
+		readyAt := msg.NotBefore
+		if last, ok := q.lastSent[msg.ChatID]; ok && last.Add(chatInterval(msg.ChatID)).After(readyAt) {
+			readyAt = last.Add(chatInterval(msg.ChatID))
+		}
+
+		if !readyAt.After(now) {
+			q.lastSent[msg.ChatID] = now
+			q.window = append(q.window, now)
+			return msg, 0
+		}
+		if until := readyAt.Sub(now); wait < 0 || until < wait {
+			wait = until
+		}
+	}
+
+	return nil, wait
+}
+
+// complete removes a sent message or schedules a retry for a failed one
+func (q *Queue) complete(msg *OutboundMessage, sent *Message, err error) {
+	q.mu.Lock()
+	if err != nil {
+		msg.Attempts++
+		var apiErr *APIError
+		permanent := errors.As(err, &apiErr) && !apiErr.Retryable()
+
+		if permanent || msg.Attempts >= maxSendAttempts {
+			if errors.Is(err, errkind.ErrAuth) {
+				log.Printf("Dropping Telegram message %d to %s: bot token rejected, check telegram.bot_token: %v", msg.ID, msg.ChatID, err)
+			} else {
+				log.Printf("Dropping Telegram message %d to %s after %d attempts: %v", msg.ID, msg.ChatID, msg.Attempts, err)
+			}
+			q.remove(msg)
+			q.persist()
+			q.mu.Unlock()
+
+			if q.onDropped != nil {
+				q.onDropped(msg)
+			}
+			return
+		}
+
+		backoff := retryBackoff(msg.Attempts)
+		if apiErr != nil && apiErr.RetryAfter > 0 {
+			backoff = apiErr.RetryAfter
+		}
+		msg.NotBefore = q.now().Add(backoff)
+		log.Printf("Error sending Telegram message %d to %s, retrying in %s: %v", msg.ID, msg.ChatID, backoff, err)
+		q.persist()
+		q.mu.Unlock()
+		return
+	}
+
+	q.remove(msg)
+	q.persist()
+	q.mu.Unlock()
+
+	if q.onSent != nil {
+		q.onSent(msg, sent)
+	}
+}
+
+// remove deletes a message from the pending list. Caller must hold the lock.
+func (q *Queue) remove(msg *OutboundMessage) {
+	for i, pending := range q.pending {
+		if pending == msg {
+			q.pending = append(q.pending[:i], q.pending[i+1:]...)
+			return
+		}
+	}
+}
+
+// persist saves the pending list to the store. Caller must hold the lock.
+func (q *Queue) persist() {
+	if q.store == nil {
+		return
+	}
+
+	data, err := json.Marshal(q.pending)
+	if err != nil {
+		log.Printf("Error encoding outbound queue: %v", err)
+		return
+	}
+	if err := q.store.SaveAppState(outboxStateKey, data); err != nil {
+		log.Printf("Error saving outbound queue: %v", err)
+	}
+}
+
+// signal wakes the run loop without blocking
+func (q *Queue) signal() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// chatInterval returns the minimum interval between messages to a chat. Channels
+// (@name) and groups (negative IDs) are limited more strictly than private chats.
+func chatInterval(chatID string) time.Duration {
+	if strings.HasPrefix(chatID, "@") || strings.HasPrefix(chatID, "-") {
+		return groupChatInterval
+	}
+	return privateChatInterval
+}
+
+// retryBackoff returns the exponential delay before the given attempt is retried
+func retryBackoff(attempts int) time.Duration {
+	backoff := time.Second << uint(attempts-1)
+	if backoff > maxRetryBackoff || backoff <= 0 {
+		return maxRetryBackoff
+	}
+	return backoff
+}
+
+// StartQueue routes outbound messages through a rate-limited, persistent queue.
+// Messages still pending from a previous run are restored from store and sent.
+func (b *Bot) StartQueue(store StateStore) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.mockMode || b.api == nil {
+		return fmt.Errorf("Telegram API not configured")
+	}
+	if b.queue != nil {
+		return fmt.Errorf("queue already started")
+	}
+
+	queue := NewQueue(b.sendQueued, func(msg *OutboundMessage, sent *Message) {
+		if msg.SignalID != "" {
+			b.recordSignalMessage(msg.SignalID, msg.ChatID, sent)
+		}
+	}, func(msg *OutboundMessage) {
+		b.release(msg.DedupHash, msg.ChatID)
+	})
+	if store != nil {
+		if err := queue.SetStore(store); err != nil {
+			return err
+		}
+	}
+
+	b.queue = queue
+	b.queueStop = make(chan struct{})
+	go queue.Run(b.queueStop)
+
+	return nil
+}
+
+// StopQueue stops sending queued messages. Pending messages stay persisted.
+func (b *Bot) StopQueue() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.queue == nil {
+		return
+	}
+	close(b.queueStop)
+	b.queue = nil
+}
+
+// enqueue adds a message to the outbound queue, reporting false if the queue is not running
+func (b *Bot) enqueue(msg *OutboundMessage) bool {
+	b.mu.RLock()
+	queue := b.queue
+	b.mu.RUnlock()
+
+	if queue == nil {
+		return false
+	}
+	queue.Enqueue(msg)
+	return true
+}
+
+// sendQueued sends a message taken from the queue
+func (b *Bot) sendQueued(msg *OutboundMessage) (*Message, error) {
+	b.mu.RLock()
+	api := b.api
+	b.mu.RUnlock()
+
+	if api == nil {
+		return nil, fmt.Errorf("Telegram API not configured")
+	}
+	return api.SendMessage(msg.ChatID, msg.Text, msg.ParseMode)
+}
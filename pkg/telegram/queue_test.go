@@ -0,0 +1,216 @@
+package telegram
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryState is an in-memory StateStore
+type memoryState struct {
+	values map[string][]byte
+	mu     sync.Mutex
+}
+
+func newMemoryState() *memoryState {
+	return &memoryState{values: make(map[string][]byte)}
+}
+
+func (m *memoryState) SaveAppState(key string, value []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.values[key] = append([]byte(nil), value...)
+	return nil
+}
+
+func (m *memoryState) LoadAppState(key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.values[key], nil
+}
+
+// newTestQueue returns a queue whose clock only moves when the test moves it
+func newTestQueue() (*Queue, *time.Time) {
+	now := time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC)
+	q := NewQueue(func(*OutboundMessage) (*Message, error) { return &Message{}, nil }, nil, nil)
+	q.now = func() time.Time { return now }
+	return q, &now
+}
+
+func TestQueueRateLimitsChats(t *testing.T) {
+	q, now := newTestQueue()
+	q.Enqueue(&OutboundMessage{ChatID: "123", Text: "first"})
+	q.Enqueue(&OutboundMessage{ChatID: "123", Text: "second"})
+	q.Enqueue(&OutboundMessage{ChatID: "@channel", Text: "third"})
+	q.Enqueue(&OutboundMessage{ChatID: "@channel", Text: "fourth"})
+
+	// The first message of each chat goes out at once
+	msg, _ := q.next()
+	assert.Equal(t, "first", msg.Text)
+	q.complete(msg, &Message{}, nil)
+	msg, _ = q.next()
+	assert.Equal(t, "third", msg.Text)
+	q.complete(msg, &Message{}, nil)
+
+	// Private chats wait a second, channels three
+	msg, wait := q.next()
+	assert.Nil(t, msg)
+	assert.Equal(t, privateChatInterval, wait)
+
+	*now = now.Add(privateChatInterval)
+	msg, _ = q.next()
+	assert.Equal(t, "second", msg.Text)
+	q.complete(msg, &Message{}, nil)
+
+	msg, wait = q.next()
+	assert.Nil(t, msg)
+	assert.Equal(t, groupChatInterval-privateChatInterval, wait)
+
+	*now = now.Add(wait)
+	msg, _ = q.next()
+	assert.Equal(t, "fourth", msg.Text)
+	q.complete(msg, &Message{}, nil)
+
+	msg, wait = q.next()
+	assert.Nil(t, msg)
+	assert.Equal(t, time.Duration(-1), wait)
+}
+
+func TestQueueRateLimitsGlobally(t *testing.T) {
+	q, now := newTestQueue()
+	for i := 0; i <= globalMessagesPerSecond; i++ {
+		q.Enqueue(&OutboundMessage{ChatID: formatChatID(int64(i)), Text: "hello"})
+	}
+
+	for i := 0; i < globalMessagesPerSecond; i++ {
+		msg, _ := q.next()
+		require.NotNil(t, msg)
+		q.complete(msg, &Message{}, nil)
+	}
+
+	// The last message waits for the one-second window to pass
+	msg, wait := q.next()
+	assert.Nil(t, msg)
+	assert.Equal(t, time.Second, wait)
+
+	*now = now.Add(time.Second)
+	msg, _ = q.next()
+	assert.NotNil(t, msg)
+}
+
+func TestQueueRetriesFailures(t *testing.T) {
+	q, now := newTestQueue()
+	msg := &OutboundMessage{ChatID: "123", Text: "hello"}
+	q.Enqueue(msg)
+
+	// Server errors back off exponentially
+	picked, _ := q.next()
+	q.complete(picked, nil, &APIError{Method: "sendMessage", Code: http.StatusBadGateway})
+	assert.Equal(t, 1, q.Len())
+	assert.Equal(t, 1, msg.Attempts)
+	assert.Equal(t, now.Add(time.Second), msg.NotBefore)
+
+	picked, _ = q.next()
+	assert.Nil(t, picked)
+
+	// Rate limits wait as long as Telegram asks
+	*now = msg.NotBefore
+	picked, _ = q.next()
+	require.NotNil(t, picked)
+	q.complete(picked, nil, &APIError{Method: "sendMessage", Code: http.StatusTooManyRequests, RetryAfter: 30 * time.Second})
+	assert.Equal(t, now.Add(30*time.Second), msg.NotBefore)
+
+	// Network errors are retried until the attempts run out
+	for msg.Attempts < maxSendAttempts {
+		q.complete(msg, nil, errors.New("connection reset"))
+	}
+	assert.Equal(t, 0, q.Len())
+
+	// Permanent errors are dropped at once
+	q.Enqueue(&OutboundMessage{ChatID: "123", Text: "blocked"})
+	*now = now.Add(maxRetryBackoff)
+	picked, _ = q.next()
+	q.complete(picked, nil, &APIError{Method: "sendMessage", Code: http.StatusForbidden})
+	assert.Equal(t, 0, q.Len())
+}
+
+func TestRetryBackoff(t *testing.T) {
+	assert.Equal(t, time.Second, retryBackoff(1))
+	assert.Equal(t, 4*time.Second, retryBackoff(3))
+	assert.Equal(t, maxRetryBackoff, retryBackoff(20))
+	assert.Equal(t, maxRetryBackoff, retryBackoff(100))
+}
+
+func TestQueueReplaysPersistedMessages(t *testing.T) {
+	state := newMemoryState()
+
+	// A queue stopped with messages pending leaves them in the store
+	first, _ := newTestQueue()
+	require.NoError(t, first.SetStore(state))
+	first.Enqueue(&OutboundMessage{ChatID: "123", Text: "first"})
+	first.Enqueue(&OutboundMessage{ChatID: "456", Text: "second", SignalID: "SIG-1"})
+	assert.NotEmpty(t, state.values[outboxStateKey])
+
+	// The next run restores and sends them, keeping the IDs unique
+	var sent []string
+	var mu sync.Mutex
+	done := make(chan struct{})
+	second := NewQueue(func(msg *OutboundMessage) (*Message, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		sent = append(sent, msg.Text)
+		if len(sent) == 3 {
+			close(done)
+		}
+		return &Message{}, nil
+	}, nil, nil)
+	require.NoError(t, second.SetStore(state))
+	assert.Equal(t, 2, second.Len())
+
+	late := &OutboundMessage{ChatID: "789", Text: "third"}
+	second.Enqueue(late)
+	assert.Equal(t, int64(3), late.ID)
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go second.Run(stop)
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("restored messages were not sent")
+	}
+	mu.Lock()
+	assert.Equal(t, []string{"first", "second", "third"}, sent)
+	mu.Unlock()
+
+	assert.Eventually(t, func() bool { return second.Len() == 0 }, time.Second, 10*time.Millisecond)
+	assert.JSONEq(t, "[]", string(state.values[outboxStateKey]))
+}
+
+func TestStartQueue(t *testing.T) {
+	api := newFakeAPI(t)
+	api.failNext("sendMessage", http.StatusInternalServerError)
+	bot := NewBot(config.TelegramConfig{BotToken: "test-token", ChannelID: "@test_channel"})
+	require.NoError(t, bot.StartQueue(newMemoryState()))
+	defer bot.StopQueue()
+	assert.Error(t, bot.StartQueue(nil))
+
+	// The signal is queued, retried after the failure and then remembered
+	assert.NoError(t, bot.SendSignal(testSignal()))
+	assert.Eventually(t, func() bool {
+		bot.mu.RLock()
+		defer bot.mu.RUnlock()
+		return len(bot.signalMsgs["SIG-AAPL-BUY-1"]) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Len(t, api.sent("sendMessage"), 2)
+
+	// Bots without the API cannot queue
+	assert.Error(t, NewBotWithMode(config.TelegramConfig{}, true).StartQueue(nil))
+}
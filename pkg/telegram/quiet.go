@@ -0,0 +1,149 @@
+package telegram
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+)
+
+// riskAlertPrefix starts the critical risk alerts that are delivered even in
+// quiet hours
+const riskAlertPrefix = "risk alert"
+
+// heldMessage is a message held back until a quiet window ends
+type heldMessage struct {
+	msg   OutboundMessage
+	until time.Time
+}
+
+// isRiskAlert reports whether a message is a critical risk alert
+func isRiskAlert(message string) bool {
+	return strings.HasPrefix(strings.ToLower(message), riskAlertPrefix)
+}
+
+// channelQuietHours returns the quiet window of the channel with the given chat ID
+func (b *Bot) channelQuietHours(channelID string) config.QuietHoursConfig {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, ch := range b.config.Channels {
+		if ch.ChannelID == channelID {
+			return ch.QuietHours
+		}
+	}
+	if channelID == b.config.ChannelID {
+		return b.config.QuietHours
+	}
+	return config.QuietHoursConfig{}
+}
+
+// subscriberQuietUntil returns when a subscriber's quiet window ends, in their
+// own time zone, zero if it is not quiet for them
+func (b *Bot) subscriberQuietUntil(userID int64, now time.Time) time.Time {
+	b.mu.RLock()
+	quiet := b.config.SubscriberQuietHours
+	zone := b.preferences[userID].TimeZone
+	b.mu.RUnlock()
+	return quiet.QuietUntil(now, zone)
+}
+
+// holdUntil holds a message back until the given time, reporting false if the
+// time is zero or the message is a risk alert and must go out now
+func (b *Bot) holdUntil(msg OutboundMessage, until time.Time) bool {
+	if until.IsZero() || isRiskAlert(msg.Text) {
+		return false
+	}
+
+	b.mu.Lock()
+	b.held = append(b.held, heldMessage{msg: msg, until: until})
+	b.mu.Unlock()
+	log.Printf("Holding Telegram message to %s until quiet hours end at %s", msg.ChatID, until.Format(time.RFC3339))
+	return true
+}
+
+// ReleaseHeldMessages sends the messages whose quiet window has ended, in the
+// order they were held. Signals that closed while they were held are dropped.
+func (b *Bot) ReleaseHeldMessages(now time.Time) {
+	b.mu.Lock()
+	var due []OutboundMessage
+	kept := b.held[:0]
+	for _, held := range b.held {
+		if now.Before(held.until) {
+			kept = append(kept, held)
+		} else {
+			due = append(due, held.msg)
+		}
+	}
+	b.held = kept
+	b.mu.Unlock()
+
+	for i := range due {
+		msg := &due[i]
+		if msg.SignalID != "" && b.isClosed(msg.SignalID) {
+			b.release(msg.DedupHash, msg.ChatID)
+			continue
+		}
+		if err := b.sendOutbound(msg); err != nil {
+			log.Printf("Error sending Telegram message held for quiet hours to %s: %v", msg.ChatID, err)
+		}
+	}
+}
+
+// sendOutbound sends a prepared message through the queue or the API,
+// remembering signal messages so they can be edited later. A message that
+// fails to send gives up its claim in the dedup ledger.
+func (b *Bot) sendOutbound(msg *OutboundMessage) error {
+	if b.mockMode {
+		b.mu.Lock()
+		b.mockMessages = append(b.mockMessages, msg.Text)
+		b.mu.Unlock()
+		log.Printf("[MOCK] Telegram message sent to %s: %s", msg.ChatID, msg.Text)
+		return nil
+	}
+
+	b.mu.RLock()
+	api := b.api
+	b.mu.RUnlock()
+
+	if api == nil {
+		log.Printf("Would send to Telegram %s: %s", msg.ChatID, msg.Text)
+		return nil
+	}
+	if b.enqueue(msg) {
+		return nil
+	}
+
+	sent, err := api.SendMessage(msg.ChatID, msg.Text, msg.ParseMode)
+	if err != nil {
+		b.release(msg.DedupHash, msg.ChatID)
+		return fmt.Errorf("failed to send Telegram message: %w", err)
+	}
+	if msg.SignalID != "" {
+		b.recordSignalMessage(msg.SignalID, msg.ChatID, sent)
+	}
+	return nil
+}
+
+// handleTimeZoneCommand handles the /timezone command, setting the time zone
+// the user's quiet hours are kept in
+func (b *Bot) handleTimeZoneCommand(userID int64, args []string) (string, error) {
+	zone := args[0]
+	if _, err := time.LoadLocation(zone); err != nil || zone == "" || strings.EqualFold(zone, "local") {
+		return fmt.Sprintf("Unknown time zone: %s. Use a name like America/New_York or Europe/London.", zone), nil
+	}
+
+	b.mu.Lock()
+	prefs, exists := b.preferences[userID]
+	if !exists {
+		prefs = unfilteredPreferences()
+	}
+	prefs.TimeZone = zone
+	b.preferences[userID] = prefs
+	b.subscribers[userID] = true
+	b.mu.Unlock()
+	b.savePreferences()
+
+	return fmt.Sprintf("Time zone set to %s. Quiet hours follow your local time.", zone), nil
+}
@@ -0,0 +1,105 @@
+package telegram
+
+import (
+	"log"
+)
+
+// Reactions counted as subscriber sentiment on signal messages
+const (
+	thumbsUp   = "👍"
+	thumbsDown = "👎"
+)
+
+// SentimentRecorder stores subscriber reaction totals per signal
+// (implemented by performance.Monitor and store.Logger)
+type SentimentRecorder interface {
+	RecordSentiment(signalID string, up, down int) error
+}
+
+// reactionTally holds the reaction totals for a signal message
+type reactionTally struct {
+	up   int
+	down int
+}
+
+// AddSentimentRecorder adds a recorder for reactions on signal messages
+func (b *Bot) AddSentimentRecorder(recorder SentimentRecorder) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.sentiment = append(b.sentiment, recorder)
+}
+
+// handleReactionCount applies anonymous reaction totals from a channel message
+func (b *Bot) handleReactionCount(update *MessageReactionCountUpdated) {
+	var tally reactionTally
+	for _, r := range update.Reactions {
+		switch r.Type.Emoji {
+		case thumbsUp:
+			tally.up = r.TotalCount
+		case thumbsDown:
+			tally.down = r.TotalCount
+		}
+	}
+
+	b.mu.Lock()
+	signalID, ok := b.msgSignals[messageRef{chatID: update.Chat.ID, messageID: update.MessageID}]
+	if ok {
+		b.reactions[signalID] = &tally
+	}
+	b.mu.Unlock()
+
+	if ok {
+		b.recordSentiment(signalID, tally)
+	}
+}
+
+// handleReaction applies a single user's reaction change, as sent for group chats
+func (b *Bot) handleReaction(update *MessageReactionUpdated) {
+	b.mu.Lock()
+	signalID, ok := b.msgSignals[messageRef{chatID: update.Chat.ID, messageID: update.MessageID}]
+	if !ok {
+		b.mu.Unlock()
+		return
+	}
+
+	tally, exists := b.reactions[signalID]
+	if !exists {
+		tally = &reactionTally{}
+		b.reactions[signalID] = tally
+	}
+	for _, r := range update.OldReaction {
+		tally.add(r.Emoji, -1)
+	}
+	for _, r := range update.NewReaction {
+		tally.add(r.Emoji, 1)
+	}
+	current := *tally
+	b.mu.Unlock()
+
+	b.recordSentiment(signalID, current)
+}
+
+// add adjusts the tally for an emoji by delta, never dropping below zero
+func (t *reactionTally) add(emoji string, delta int) {
+	switch emoji {
+	case thumbsUp:
+		t.up = max(t.up+delta, 0)
+	case thumbsDown:
+		t.down = max(t.down+delta, 0)
+	}
+}
+
+// recordSentiment passes the reaction totals for a signal to every recorder
+func (b *Bot) recordSentiment(signalID string, tally reactionTally) {
+	b.mu.RLock()
+	recorders := make([]SentimentRecorder, len(b.sentiment))
+	copy(recorders, b.sentiment)
+	b.mu.RUnlock()
+
+	for _, recorder := range recorders {
+		if err := recorder.RecordSentiment(signalID, tally.up, tally.down); err != nil {
+			log.Printf("Error recording sentiment for signal %s: %v", signalID, err)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package telegram
+
+import (
+	"log"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// Subscriber tiers
+const (
+	TierFree    = "free"
+	TierPremium = "premium"
+)
+
+// sentMessage is a signal message delivered to a channel
+type sentMessage struct {
+	channelID string
+	messageID int
+}
+
+// messageRef identifies a message by its numeric chat ID, as reported in updates
+type messageRef struct {
+	chatID    int64
+	messageID int
+}
+
+// signalChannels returns the channels signals are routed to. Without explicit
+// routing, the configured channel_id receives every signal immediately.
+func (b *Bot) signalChannels() []config.TelegramChannelConfig {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if len(b.config.Channels) > 0 {
+		channels := make([]config.TelegramChannelConfig, len(b.config.Channels))
+		copy(channels, b.config.Channels)
+		return channels
+	}
+
+	return []config.TelegramChannelConfig{{
+		Name:      "default",
+		ChannelID: b.config.ChannelID,
+		Tier:      TierPremium,
+	}}
+}
+
+// acceptsSignal reports whether a channel's confidence, ROI and signal type
+// thresholds accept a signal
+func acceptsSignal(ch config.TelegramChannelConfig, s *signal.Signal) bool {
+	return ch.Filter().Allows(s.Confidence, s.ExpectedROI, string(s.Type))
+}
+
+// SetWatchlists restricts signals from each watchlist to the channels bound to
+// it. Watchlists without channels are sent to every channel.
+func (b *Bot) SetWatchlists(watchlists []config.WatchlistConfig) {
+	channels := make(map[string]map[string]bool)
+	for _, watchlist := range watchlists {
+		if len(watchlist.Channels) == 0 {
+			continue
+		}
+		channels[watchlist.Name] = make(map[string]bool)
+		for _, name := range watchlist.Channels {
+			channels[watchlist.Name][name] = true
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.watchlistChannels = channels
+}
+
+// routesSignal reports whether a signal is sent to a channel, by the channel's
+// rules and the channels bound to the signal's watchlist
+func (b *Bot) routesSignal(ch config.TelegramChannelConfig, s *signal.Signal) bool {
+	if !acceptsSignal(ch, s) {
+		return false
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	bound, ok := b.watchlistChannels[s.Watchlist]
+	return !ok || bound[ch.Name]
+}
+
+// isClosed reports whether a signal already reached its final status
+func (b *Bot) isClosed(signalID string) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.closed[signalID]
+}
+
+// deliverSignal sends a formatted signal to one channel, unless the ledger shows it
+// was already sent, and remembers the message so later updates, outcomes and
+// reactions can be tied back to the signal
+func (b *Bot) deliverSignal(ch config.TelegramChannelConfig, s *signal.Signal, message string) error {
+	hash := signalHash(s)
+	if !b.claim(hash, ch.ChannelID) {
+		return nil
+	}
+
+	parseMode := signal.MarkupFor(ch.ParseMode).ParseMode()
+	msg := OutboundMessage{ChatID: ch.ChannelID, Text: message, ParseMode: parseMode, SignalID: s.ID, DedupHash: hash}
+	if b.holdUntil(msg, ch.QuietHours.QuietUntil(time.Now(), "")) {
+		return nil
+	}
+
+	return b.sendOutbound(&msg)
+}
+
+// recordSignalMessage remembers a delivered signal message. A message that
+// was queued or held while the signal's explanation came in gets it now.
+func (b *Bot) recordSignalMessage(signalID, channelID string, sent *Message) {
+	m := sentMessage{channelID: channelID, messageID: sent.MessageID}
+
+	b.mu.Lock()
+	b.signalMsgs[signalID] = append(b.signalMsgs[signalID], m)
+	b.msgSignals[messageRef{chatID: sent.Chat.ID, messageID: sent.MessageID}] = signalID
+	api, explained := b.api, b.explained[signalID]
+	b.mu.Unlock()
+
+	if explained == nil || api == nil {
+		return
+	}
+	if err := b.explainMessage(api, explained, m); err != nil {
+		log.Printf("Error sending explanation of signal %s to %s: %v", signalID, channelID, err)
+	}
+}
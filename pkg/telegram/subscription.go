@@ -0,0 +1,164 @@
+package telegram
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// EntitlementStore records which users have paid premium access (implemented by store.Logger)
+type EntitlementStore interface {
+	IsEntitled(userID int64) (bool, error)
+	GrantEntitlement(userID int64, source string, expiresAt time.Time) error
+	RevokeEntitlement(userID int64) error
+}
+
+// SetSubscription enables entitlement-gated premium delivery and the /subscribe flow
+func (b *Bot) SetSubscription(cfg config.SubscriptionConfig, entitlements EntitlementStore) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.subscription = cfg
+	b.entitlements = entitlements
+}
+
+// subscriptionEnabled returns the entitlement store if premium gating is enabled
+func (b *Bot) subscriptionEnabled() (config.SubscriptionConfig, EntitlementStore, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.subscription, b.entitlements, b.subscription.Enabled && b.entitlements != nil
+}
+
+// isEntitled reports whether a user has premium access. Errors deny access.
+func isEntitled(entitlements EntitlementStore, userID int64) bool {
+	entitled, err := entitlements.IsEntitled(userID)
+	if err != nil {
+		log.Printf("Error checking entitlement for user %d: %v", userID, err)
+		return false
+	}
+	return entitled
+}
+
+// deliverDirect sends a signal directly to subscribers whose preferences
+// accept it and whose daily limit allows it, or batches it into their digest.
+// With premium subscriptions only entitled subscribers get direct signals,
+// otherwise those who finished onboarding.
+func (b *Bot) deliverDirect(s *signal.Signal, message string) {
+	_, entitlements, enabled := b.subscriptionEnabled()
+	hash := signalHash(s)
+
+	for _, userID := range b.GetSubscribers() {
+		prefs, onboarded := b.GetPreferences(userID)
+		eligible := onboarded
+		if enabled {
+			eligible = isEntitled(entitlements, userID)
+		}
+		if !eligible {
+			continue
+		}
+		if onboarded && (!prefs.Accepts(s) || !b.withinDailyLimit(userID, prefs, time.Now())) {
+			continue
+		}
+		chatID := formatChatID(userID)
+		if !b.claim(hash, chatID) || b.digestSignal(userID, prefs, s, time.Now()) {
+			continue
+		}
+		msg := OutboundMessage{ChatID: chatID, Text: message, ParseMode: "HTML", DedupHash: hash}
+		if err := b.sendDirectMessage(userID, msg); err != nil {
+			log.Printf("Error sending signal to user %d: %v", userID, err)
+		}
+	}
+}
+
+// handleSubscribeCommand handles the /subscribe onboarding command
+func (b *Bot) handleSubscribeCommand(userID int64) (string, error) {
+	cfg, entitlements, enabled := b.subscriptionEnabled()
+	if !enabled {
+		return "Premium subscriptions are not available.", nil
+	}
+
+	// Subscribing to premium also subscribes to direct signal delivery
+	b.mu.Lock()
+	b.subscribers[userID] = true
+	b.mu.Unlock()
+
+	if isEntitled(entitlements, userID) {
+		message := "You have premium access. Premium signals are delivered instantly to this chat."
+		if cfg.PremiumInviteURL != "" {
+			message += "\n\nJoin the premium channel: " + cfg.PremiumInviteURL
+		}
+		return message, nil
+	}
+
+	if cfg.CheckoutURL == "" {
+		return "Premium access is granted manually. Please contact an admin.", nil
+	}
+
+	checkout, err := url.Parse(cfg.CheckoutURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid checkout URL: %w", err)
+	}
+	query := checkout.Query()
+	query.Set("client_reference_id", strconv.FormatInt(userID, 10))
+	checkout.RawQuery = query.Encode()
+
+	return "Premium members get every signal instantly, including those the free channel only sees later or not at all.\n\n" +
+		"Subscribe here: " + checkout.String() + "\n\n" +
+		"Once your payment is confirmed, send /subscribe again to get access.", nil
+}
+
+// handleGrantCommand handles the admin /grant command for the manual allowlist
+func (b *Bot) handleGrantCommand(userID int64, args []string) (string, error) {
+	_, entitlements, enabled := b.subscriptionEnabled()
+	if !enabled {
+		return "Premium subscriptions are not available.", nil
+	}
+
+	target, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Sprintf("Invalid user ID: %s", args[0]), nil
+	}
+
+	var expiresAt time.Time
+	if len(args) == 2 {
+		days, err := strconv.Atoi(args[1])
+		if err != nil || days <= 0 {
+			return fmt.Sprintf("Invalid number of days: %s", args[1]), nil
+		}
+		expiresAt = time.Now().AddDate(0, 0, days)
+	}
+
+	if err := entitlements.GrantEntitlement(target, "manual", expiresAt); err != nil {
+		return "", err
+	}
+
+	if expiresAt.IsZero() {
+		return fmt.Sprintf("Premium access granted to %d.", target), nil
+	}
+	return fmt.Sprintf("Premium access granted to %d until %s.", target, expiresAt.Format("2006-01-02")), nil
+}
+
+// handleRevokeCommand handles the admin /revoke command for the manual allowlist
+func (b *Bot) handleRevokeCommand(userID int64, args []string) (string, error) {
+	_, entitlements, enabled := b.subscriptionEnabled()
+	if !enabled {
+		return "Premium subscriptions are not available.", nil
+	}
+
+	target, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Sprintf("Invalid user ID: %s", args[0]), nil
+	}
+
+	if err := entitlements.RevokeEntitlement(target); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Premium access revoked for %d.", target), nil
+}
@@ -0,0 +1,103 @@
+package telegram
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/symbols"
+)
+
+// SymbolLists manages the symbol blacklist and allowlist (implemented by symbols.Lists)
+type SymbolLists interface {
+	Add(list, symbol, reason, addedBy string, expiresAt time.Time) (symbols.Entry, error)
+	Remove(list, symbol string) error
+	Entries(list string) []symbols.Entry
+}
+
+// SetSymbolLists enables the admin /blacklist, /allowlist, /unlist and /lists commands
+func (b *Bot) SetSymbolLists(lists SymbolLists) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.symbolLists = lists
+}
+
+// getSymbolLists returns the symbol lists, if configured
+func (b *Bot) getSymbolLists() SymbolLists {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.symbolLists
+}
+
+// handleListAddCommand handles the admin /blacklist and /allowlist commands:
+// <symbol> [days] [reason...]
+func (b *Bot) handleListAddCommand(userID int64, list string, args []string) (string, error) {
+	lists := b.getSymbolLists()
+	if lists == nil {
+		return "Symbol lists are not available.", nil
+	}
+
+	symbol, rest := args[0], args[1:]
+	var expiresAt time.Time
+	if len(rest) > 0 {
+		if days, err := strconv.Atoi(rest[0]); err == nil {
+			if days <= 0 {
+				return fmt.Sprintf("Invalid number of days: %s", rest[0]), nil
+			}
+			expiresAt = time.Now().AddDate(0, 0, days)
+			rest = rest[1:]
+		}
+	}
+
+	entry, err := lists.Add(list, symbol, strings.Join(rest, " "), strconv.FormatInt(userID, 10), expiresAt)
+	if err != nil {
+		return fmt.Sprintf("Could not update the %s: %v", list, err), nil
+	}
+
+	return fmt.Sprintf("Added %s.", formatListEntry(entry)), nil
+}
+
+// handleUnlistCommand handles the admin /unlist command: <blacklist|allowlist> <symbol>
+func (b *Bot) handleUnlistCommand(userID int64, args []string) (string, error) {
+	lists := b.getSymbolLists()
+	if lists == nil {
+		return "Symbol lists are not available.", nil
+	}
+
+	if err := lists.Remove(strings.ToLower(args[0]), args[1]); err != nil {
+		return fmt.Sprintf("Could not remove %s: %v", args[1], err), nil
+	}
+	return fmt.Sprintf("Removed %s from the %s.", strings.ToUpper(args[1]), strings.ToLower(args[0])), nil
+}
+
+// handleListsCommand handles the admin /lists command
+func (b *Bot) handleListsCommand(userID int64) (string, error) {
+	lists := b.getSymbolLists()
+	if lists == nil {
+		return "Symbol lists are not available.", nil
+	}
+
+	entries := lists.Entries("")
+	if len(entries) == 0 {
+		return "The blacklist and allowlist are empty.", nil
+	}
+
+	message := "Symbol lists:\n"
+	for _, entry := range entries {
+		message += "- " + formatListEntry(entry) + "\n"
+	}
+	return message, nil
+}
+
+// formatListEntry formats a list entry as a single line
+func formatListEntry(entry symbols.Entry) string {
+	line := fmt.Sprintf("%s to the %s", entry.Symbol, entry.List)
+	if entry.Reason != "" {
+		line += fmt.Sprintf(" (%s)", entry.Reason)
+	}
+	if !entry.ExpiresAt.IsZero() {
+		line += " until " + entry.ExpiresAt.Format("2006-01-02")
+	}
+	return line
+}
@@ -0,0 +1,40 @@
+package telegram
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// TestSignalSender pushes a labeled test signal through the real signal
+// pipeline (implemented by monitor.MarketMonitor)
+type TestSignalSender interface {
+	SendTestSignal(symbol string) (*signal.Signal, error)
+}
+
+// SetTestSignalSender enables the admin /testsignal command
+func (b *Bot) SetTestSignalSender(sender TestSignalSender) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.testSignals = sender
+}
+
+// handleTestSignalCommand handles the admin /testsignal command, which sends a
+// TEST signal to verify the pipeline end to end
+func (b *Bot) handleTestSignalCommand(userID int64, args []string) (string, error) {
+
+	b.mu.RLock()
+	sender := b.testSignals
+	b.mu.RUnlock()
+
+	if sender == nil {
+		return "Test signals are not available.", nil
+	}
+
+	s, err := sender.SendTestSignal(strings.ToUpper(args[0]))
+	if err != nil {
+		return fmt.Sprintf("Test signal failed: %v", err), nil
+	}
+	return fmt.Sprintf("Test signal %s sent for %s at $%.2f", s.ID, s.Symbol, s.Price), nil
+}
@@ -0,0 +1,138 @@
+package telegram
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/execution"
+)
+
+// TradeDesk modifies and closes open trades on behalf of a user, recording
+// every change in the audit log (implemented by execution.TradeManager)
+type TradeDesk interface {
+	GetActiveTrades() []*execution.Trade
+	ChangeStop(tradeID string, stopPrice float64, actor string) (*execution.Trade, error)
+	ReduceTrade(tradeID string, quantity int, stock *data.Stock, actor string) (*execution.Trade, error)
+	CloseTrade(tradeID string, stock *data.Stock, actor string) (*execution.Trade, error)
+}
+
+// QuoteLookup provides the latest quote of a symbol (implemented by data.MarketWatcher)
+type QuoteLookup interface {
+	GetStock(symbol string) (*data.Stock, bool)
+}
+
+// SetTradeDesk enables the admin /trades, /stop, /reduce and /close commands.
+// Reductions and closes are priced at quotes.
+func (b *Bot) SetTradeDesk(desk TradeDesk, quotes QuoteLookup) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tradeDesk = desk
+	b.quotes = quotes
+}
+
+// getTradeDesk returns the configured trade desk and quotes, if any
+func (b *Bot) getTradeDesk() (TradeDesk, QuoteLookup) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.tradeDesk, b.quotes
+}
+
+// tradeActor is the audit actor of trade changes made by a Telegram user
+func tradeActor(userID int64) string {
+	return fmt.Sprintf("telegram:%d", userID)
+}
+
+// handleTradesCommand handles the admin /trades command, listing the open positions
+func (b *Bot) handleTradesCommand(userID int64) (string, error) {
+	desk, _ := b.getTradeDesk()
+	if desk == nil {
+		return "Trading is not available.", nil
+	}
+
+	trades := desk.GetActiveTrades()
+	if len(trades) == 0 {
+		return "No open trades.", nil
+	}
+	sort.Slice(trades, func(i, j int) bool { return trades[i].CreatedAt.Before(trades[j].CreatedAt) })
+
+	message := "Open Trades:\n\n"
+	for _, trade := range trades {
+		side := "Long"
+		if trade.IsShort() {
+			side = "Short"
+		}
+		message += fmt.Sprintf("%s - %s %d %s at $%.2f, stop $%.2f\n",
+			trade.ID, side, trade.Quantity, trade.Symbol, trade.Price, trade.StopPrice)
+	}
+	return message, nil
+}
+
+// handleStopCommand handles the admin /stop command, e.g. /stop TRADE-ID 98.50
+func (b *Bot) handleStopCommand(userID int64, args []string) (string, error) {
+	desk, _ := b.getTradeDesk()
+	if desk == nil {
+		return "Trading is not available.", nil
+	}
+
+	price, err := strconv.ParseFloat(strings.TrimPrefix(args[1], "$"), 64)
+	if err != nil {
+		return fmt.Sprintf("Invalid price: %s", args[1]), nil
+	}
+
+	trade, err := desk.ChangeStop(args[0], price, tradeActor(userID))
+	if err != nil {
+		return fmt.Sprintf("Could not change stop: %v", err), nil
+	}
+	return fmt.Sprintf("Stop of %s in %s moved to $%.2f", trade.ID, trade.Symbol, trade.StopPrice), nil
+}
+
+// handleReduceCommand handles the admin /reduce command, e.g. /reduce TRADE-ID 5
+func (b *Bot) handleReduceCommand(userID int64, args []string) (string, error) {
+	quantity, err := strconv.Atoi(args[1])
+	if err != nil || quantity <= 0 {
+		return fmt.Sprintf("Invalid number of shares: %s", args[1]), nil
+	}
+
+	return b.exitTrade(userID, args[0], func(desk TradeDesk, stock *data.Stock) (*execution.Trade, error) {
+		return desk.ReduceTrade(args[0], quantity, stock, tradeActor(userID))
+	})
+}
+
+// handleCloseCommand handles the admin /close command, e.g. /close TRADE-ID
+func (b *Bot) handleCloseCommand(userID int64, args []string) (string, error) {
+
+	return b.exitTrade(userID, args[0], func(desk TradeDesk, stock *data.Stock) (*execution.Trade, error) {
+		return desk.CloseTrade(args[0], stock, tradeActor(userID))
+	})
+}
+
+// exitTrade exits some or all of an open trade at its latest quote
+func (b *Bot) exitTrade(userID int64, tradeID string, exit func(TradeDesk, *data.Stock) (*execution.Trade, error)) (string, error) {
+	desk, quotes := b.getTradeDesk()
+	if desk == nil || quotes == nil {
+		return "Trading is not available.", nil
+	}
+
+	var symbol string
+	for _, trade := range desk.GetActiveTrades() {
+		if trade.ID == tradeID {
+			symbol = trade.Symbol
+		}
+	}
+	if symbol == "" {
+		return fmt.Sprintf("No open trade %s.", tradeID), nil
+	}
+	stock, ok := quotes.GetStock(symbol)
+	if !ok || stock.CurrentPrice <= 0 {
+		return fmt.Sprintf("No quote for %s.", symbol), nil
+	}
+
+	exitTrade, err := exit(desk, stock)
+	if err != nil {
+		return fmt.Sprintf("Could not exit %s: %v", tradeID, err), nil
+	}
+	return fmt.Sprintf("%s %d %s at $%.2f (%s)", exitTrade.Type, exitTrade.Quantity, exitTrade.Symbol, exitTrade.Price, exitTrade.Reason), nil
+}
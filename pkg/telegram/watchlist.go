@@ -0,0 +1,48 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/symbols"
+)
+
+// WatchlistImporter adds symbols to the watchlist after validating them (implemented by symbols.Importer)
+type WatchlistImporter interface {
+	Import(symbols []string) (symbols.ImportResult, error)
+	ImportURL(ctx context.Context, url string) (symbols.ImportResult, error)
+}
+
+// SetWatchlistImporter enables the admin /import command
+func (b *Bot) SetWatchlistImporter(importer WatchlistImporter) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.importer = importer
+}
+
+// handleImportCommand handles the admin /import command: <csv url> or <symbol>[,<symbol>...]
+func (b *Bot) handleImportCommand(userID int64, args []string) (string, error) {
+	b.mu.RLock()
+	importer := b.importer
+	b.mu.RUnlock()
+	if importer == nil {
+		return "Watchlist import is not available.", nil
+	}
+
+	var result symbols.ImportResult
+	var err error
+	if strings.HasPrefix(args[0], "http://") || strings.HasPrefix(args[0], "https://") {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		result, err = importer.ImportURL(ctx, args[0])
+	} else {
+		list := strings.FieldsFunc(strings.Join(args, ","), func(r rune) bool { return r == ',' || r == ' ' })
+		result, err = importer.Import(list)
+	}
+	if err != nil {
+		return fmt.Sprintf("Could not import the watchlist: %v", err), nil
+	}
+	return result.Summary(), nil
+}
@@ -0,0 +1,132 @@
+package timeseries
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Influx writes points to InfluxDB 2.x using the line protocol
+type Influx struct {
+	url    string
+	org    string
+	bucket string
+	token  string
+	client *http.Client
+}
+
+// NewInflux creates an InfluxDB backend
+func NewInflux(baseURL, org, bucket, token string) *Influx {
+	return &Influx{
+		url:    strings.TrimRight(baseURL, "/"),
+		org:    org,
+		bucket: bucket,
+		token:  token,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// WritePoints writes a batch of points
+func (i *Influx) WritePoints(points []Point) error {
+	var body bytes.Buffer
+	for _, p := range points {
+		body.WriteString(lineProtocol(p))
+		body.WriteByte('\n')
+	}
+
+	params := url.Values{}
+	params.Add("org", i.org)
+	params.Add("bucket", i.bucket)
+	params.Add("precision", "ms")
+
+	req, err := http.NewRequest("POST", i.url+"/api/v2/write?"+params.Encode(), &body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	if i.token != "" {
+		req.Header.Set("Authorization", "Token "+i.token)
+	}
+
+	resp, err := i.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to write points, status: %d, body: %s", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
+
+// Ping checks that InfluxDB is reachable and healthy
+func (i *Influx) Ping() error {
+	resp, err := i.client.Get(i.url + "/health")
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("influxdb unhealthy, status: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// lineProtocol encodes a point as an InfluxDB line with sorted tags and fields
+func lineProtocol(p Point) string {
+	var b strings.Builder
+	b.WriteString(escapeLine(p.Measurement, ", "))
+
+	tagKeys := make([]string, 0, len(p.Tags))
+	for k := range p.Tags {
+		tagKeys = append(tagKeys, k)
+	}
+	sort.Strings(tagKeys)
+	for _, k := range tagKeys {
+		if p.Tags[k] == "" {
+			continue
+		}
+		b.WriteString("," + escapeLine(k, ",= ") + "=" + escapeLine(p.Tags[k], ",= "))
+	}
+
+	fieldKeys := make([]string, 0, len(p.Fields))
+	for k := range p.Fields {
+		fieldKeys = append(fieldKeys, k)
+	}
+	sort.Strings(fieldKeys)
+	for n, k := range fieldKeys {
+		if n == 0 {
+			b.WriteByte(' ')
+		} else {
+			b.WriteByte(',')
+		}
+		b.WriteString(escapeLine(k, ",= ") + "=" + strconv.FormatFloat(p.Fields[k], 'f', -1, 64))
+	}
+
+	b.WriteString(" " + strconv.FormatInt(p.Time.UnixMilli(), 10))
+	return b.String()
+}
+
+// escapeLine backslash-escapes the given special characters
+func escapeLine(s, special string) string {
+	if !strings.ContainsAny(s, special) {
+		return s
+	}
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(special, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
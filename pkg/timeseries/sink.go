@@ -0,0 +1,223 @@
+package timeseries
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+)
+
+// Measurements written by the sink
+const (
+	MeasurementQuote     = "quote"
+	MeasurementIndicator = "indicator"
+)
+
+// Point is a single time-series sample
+type Point struct {
+	Measurement string
+	Tags        map[string]string
+	Fields      map[string]float64
+	Time        time.Time
+}
+
+// Backend writes batches of points to a time-series database
+type Backend interface {
+	WritePoints(points []Point) error
+}
+
+// Sink buffers quotes and indicator values and writes them to a backend in
+// batches, so slow writes never block market data handling
+type Sink struct {
+	backend   Backend
+	batchSize int
+	interval  time.Duration
+	maxBuffer int
+	buffer    []Point
+	dropped   int
+	now       func() time.Time
+	flushing  sync.Mutex // Serializes writes so batches stay in order
+	stop      chan struct{}
+	done      chan struct{}
+	mu        sync.Mutex
+}
+
+// NewSink creates a Sink writing to backend
+func NewSink(backend Backend, cfg config.TimeseriesConfig) *Sink {
+	s := &Sink{
+		backend:   backend,
+		batchSize: cfg.BatchSize,
+		interval:  time.Duration(cfg.FlushIntervalSeconds) * time.Second,
+		maxBuffer: cfg.MaxBufferedPoints,
+		now:       time.Now,
+	}
+	if s.batchSize <= 0 {
+		s.batchSize = 500
+	}
+	if s.interval <= 0 {
+		s.interval = 10 * time.Second
+	}
+	return s
+}
+
+// NewBackend creates the backend selected by cfg
+func NewBackend(cfg config.TimeseriesConfig) (Backend, error) {
+	switch cfg.Backend {
+	case config.TimeseriesInflux:
+		return NewInflux(cfg.URL, cfg.Org, cfg.Bucket, cfg.Token), nil
+	case config.TimeseriesTimescale:
+		return NewTimescale(cfg.DSN)
+	default:
+		return nil, fmt.Errorf("unsupported timeseries backend: %s", cfg.Backend)
+	}
+}
+
+// PingBackend checks that the backend selected by cfg is reachable without
+// creating its schema
+func PingBackend(cfg config.TimeseriesConfig) error {
+	switch cfg.Backend {
+	case config.TimeseriesInflux:
+		return NewInflux(cfg.URL, cfg.Org, cfg.Bucket, cfg.Token).Ping()
+	case config.TimeseriesTimescale:
+		return PingTimescale(cfg.DSN)
+	default:
+		return fmt.Errorf("unsupported timeseries backend: %s", cfg.Backend)
+	}
+}
+
+// Start flushes buffered points every flush interval until Stop is called
+func (s *Sink) Start() {
+	s.mu.Lock()
+	if s.stop != nil {
+		s.mu.Unlock()
+		return
+	}
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	stop, done := s.stop, s.done
+	s.mu.Unlock()
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := s.Flush(); err != nil {
+					log.Printf("Error writing time-series points: %v", err)
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the flush loop and writes any buffered points
+func (s *Sink) Stop() error {
+	s.mu.Lock()
+	stop, done := s.stop, s.done
+	s.stop = nil
+	s.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+	return s.Flush()
+}
+
+// RecordQuote records a quote update. It matches the MarketWatcher OnUpdate callback.
+func (s *Sink) RecordQuote(stock *data.Stock) {
+	at := stock.LastUpdated
+	if at.IsZero() {
+		at = s.now()
+	}
+	s.Write(Point{
+		Measurement: MeasurementQuote,
+		Tags:        map[string]string{"symbol": stock.Symbol},
+		Fields: map[string]float64{
+			"price":  stock.CurrentPrice,
+			"bid":    stock.Bid,
+			"ask":    stock.Ask,
+			"volume": float64(stock.Volume),
+		},
+		Time: at,
+	})
+}
+
+// LogIndicator records an indicator value (implements indicators.IndicatorLogger)
+func (s *Sink) LogIndicator(symbol, indicatorName string, value float64) error {
+	s.Write(Point{
+		Measurement: MeasurementIndicator,
+		Tags:        map[string]string{"symbol": symbol, "name": indicatorName},
+		Fields:      map[string]float64{"value": value},
+		Time:        s.now(),
+	})
+	return nil
+}
+
+// Write buffers a point, flushing in the background once a batch is full
+func (s *Sink) Write(p Point) {
+	s.mu.Lock()
+	s.buffer = append(s.buffer, p)
+	if s.maxBuffer > 0 && len(s.buffer) > s.maxBuffer {
+		overflow := len(s.buffer) - s.maxBuffer
+		s.buffer = s.buffer[overflow:]
+		s.dropped += overflow
+	}
+	full := len(s.buffer) >= s.batchSize
+	s.mu.Unlock()
+
+	if full {
+		go func() {
+			if err := s.Flush(); err != nil {
+				log.Printf("Error writing time-series points: %v", err)
+			}
+		}()
+	}
+}
+
+// Flush writes all buffered points. Points from a failed batch are put back
+// so they are retried on the next flush.
+func (s *Sink) Flush() error {
+	s.flushing.Lock()
+	defer s.flushing.Unlock()
+
+	for {
+		s.mu.Lock()
+		if s.dropped > 0 {
+			log.Printf("Time-series buffer full, dropped %d points", s.dropped)
+			s.dropped = 0
+		}
+		n := len(s.buffer)
+		if n > s.batchSize {
+			n = s.batchSize
+		}
+		batch := make([]Point, n)
+		copy(batch, s.buffer[:n])
+		s.buffer = s.buffer[n:]
+		s.mu.Unlock()
+
+		if n == 0 {
+			return nil
+		}
+		if err := s.backend.WritePoints(batch); err != nil {
+			s.mu.Lock()
+			s.buffer = append(batch, s.buffer...)
+			s.mu.Unlock()
+			return fmt.Errorf("failed to write %d points: %w", n, err)
+		}
+	}
+}
+
+// Buffered returns the number of points waiting to be written
+func (s *Sink) Buffered() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.buffer)
+}
@@ -0,0 +1,81 @@
+package timeseries
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingBackend records written batches and fails while failing is set
+type recordingBackend struct {
+	batches [][]Point
+	failing bool
+}
+
+func (b *recordingBackend) WritePoints(points []Point) error {
+	if b.failing {
+		return errors.New("backend down")
+	}
+	b.batches = append(b.batches, points)
+	return nil
+}
+
+func TestLineProtocol(t *testing.T) {
+	at := time.Date(2025, 4, 21, 14, 0, 0, 0, time.UTC)
+	line := lineProtocol(Point{
+		Measurement: MeasurementIndicator,
+		Tags:        map[string]string{"symbol": "BRK B", "name": "rsi,14"},
+		Fields:      map[string]float64{"value": 61.5},
+		Time:        at,
+	})
+	assert.Equal(t, `indicator,name=rsi\,14,symbol=BRK\ B value=61.5 1745244000000`, line)
+}
+
+func TestSinkBatchesAndRetriesFailedWrites(t *testing.T) {
+	backend := &recordingBackend{failing: true}
+	sink := NewSink(backend, config.TimeseriesConfig{BatchSize: 1000, MaxBufferedPoints: 3})
+
+	for i := 0; i < 4; i++ {
+		sink.RecordQuote(&data.Stock{Symbol: "AAPL", CurrentPrice: 100 + float64(i)})
+	}
+	// The oldest point was dropped to stay within the buffer limit
+	assert.Equal(t, 3, sink.Buffered())
+
+	assert.Error(t, sink.Flush())
+	assert.Equal(t, 3, sink.Buffered())
+
+	// Failed points are kept; the buffer limit still applies to new ones
+	backend.failing = false
+	assert.NoError(t, sink.LogIndicator("AAPL", "rsi", 55))
+	assert.NoError(t, sink.Flush())
+	assert.Equal(t, 0, sink.Buffered())
+	assert.Len(t, backend.batches, 1)
+	assert.Len(t, backend.batches[0], 3)
+	assert.Equal(t, 102.0, backend.batches[0][0].Fields["price"])
+	assert.Equal(t, MeasurementIndicator, backend.batches[0][2].Measurement)
+}
+
+func TestInfluxWritesLineProtocol(t *testing.T) {
+	var body, auth, query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		body, auth, query = string(raw), r.Header.Get("Authorization"), r.URL.RawQuery
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	influx := NewInflux(server.URL, "org", "hustler", "secret")
+	at := time.Date(2025, 4, 21, 14, 0, 0, 0, time.UTC)
+	err := influx.WritePoints([]Point{{Measurement: MeasurementQuote, Tags: map[string]string{"symbol": "AAPL"}, Fields: map[string]float64{"price": 101}, Time: at}})
+	assert.NoError(t, err)
+	assert.Equal(t, "quote,symbol=AAPL price=101 1745244000000\n", body)
+	assert.Equal(t, "Token secret", auth)
+	assert.Contains(t, query, "bucket=hustler")
+}
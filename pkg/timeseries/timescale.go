@@ -0,0 +1,111 @@
+package timeseries
+
+import (
+	"database/sql"
+	"fmt"
+
+	_ "github.com/lib/pq"
+)
+
+// Timescale writes points to TimescaleDB hypertables kept apart from the main
+// database, one table per measurement
+type Timescale struct {
+	db *sql.DB
+}
+
+// NewTimescale connects to TimescaleDB and creates the hypertables if needed
+func NewTimescale(dsn string) (*Timescale, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to timescale: %w", err)
+	}
+
+	t := &Timescale{db: db}
+	if err := t.init(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return t, nil
+}
+
+// PingTimescale checks that TimescaleDB accepts connections at dsn
+func PingTimescale(dsn string) error {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return fmt.Errorf("failed to connect to timescale: %w", err)
+	}
+	defer db.Close()
+
+	if err := db.Ping(); err != nil {
+		return fmt.Errorf("failed to ping timescale: %w", err)
+	}
+	return nil
+}
+
+// init creates the quote and indicator hypertables
+func (t *Timescale) init() error {
+	statements := []string{
+		`CREATE EXTENSION IF NOT EXISTS timescaledb`,
+		`CREATE TABLE IF NOT EXISTS quotes (
+			time TIMESTAMPTZ NOT NULL,
+			symbol VARCHAR(50) NOT NULL,
+			price DOUBLE PRECISION,
+			bid DOUBLE PRECISION,
+			ask DOUBLE PRECISION,
+			volume DOUBLE PRECISION
+		)`,
+		`SELECT create_hypertable('quotes', 'time', if_not_exists => TRUE)`,
+		`CREATE INDEX IF NOT EXISTS quotes_symbol_time ON quotes (symbol, time DESC)`,
+		`CREATE TABLE IF NOT EXISTS indicator_values (
+			time TIMESTAMPTZ NOT NULL,
+			symbol VARCHAR(50) NOT NULL,
+			name VARCHAR(50) NOT NULL,
+			value DOUBLE PRECISION
+		)`,
+		`SELECT create_hypertable('indicator_values', 'time', if_not_exists => TRUE)`,
+		`CREATE INDEX IF NOT EXISTS indicator_values_symbol_name_time ON indicator_values (symbol, name, time DESC)`,
+	}
+	for _, statement := range statements {
+		if _, err := t.db.Exec(statement); err != nil {
+			return fmt.Errorf("failed to initialize timescale schema: %w", err)
+		}
+	}
+	return nil
+}
+
+// WritePoints writes a batch of points in one transaction
+func (t *Timescale) WritePoints(points []Point) error {
+	tx, err := t.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, p := range points {
+		switch p.Measurement {
+		case MeasurementQuote:
+			_, err = tx.Exec(`
+				INSERT INTO quotes (time, symbol, price, bid, ask, volume) VALUES ($1, $2, $3, $4, $5, $6)
+			`, p.Time, p.Tags["symbol"], p.Fields["price"], p.Fields["bid"], p.Fields["ask"], p.Fields["volume"])
+		case MeasurementIndicator:
+			_, err = tx.Exec(`
+				INSERT INTO indicator_values (time, symbol, name, value) VALUES ($1, $2, $3, $4)
+			`, p.Time, p.Tags["symbol"], p.Tags["name"], p.Fields["value"])
+		default:
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to insert %s point: %w", p.Measurement, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit points: %w", err)
+	}
+	return nil
+}
+
+// Close closes the database connection
+func (t *Timescale) Close() error {
+	return t.db.Close()
+}
@@ -0,0 +1,286 @@
+package tracing
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+)
+
+// OTLP span kind and status codes
+const (
+	spanKindInternal = 1
+	statusCodeError  = 2
+)
+
+// instrumentationScope names the instrumentation in exported spans
+const instrumentationScope = "github.com/hustler/trading-bot"
+
+// OTLPExporter queues finished spans and posts them to an OpenTelemetry
+// collector as OTLP/HTTP JSON, so exporting never blocks a market check
+type OTLPExporter struct {
+	endpoint  string
+	service   string
+	headers   map[string]string
+	interval  time.Duration
+	maxQueued int
+	client    *http.Client
+	queue     []SpanData
+	dropped   int
+	flushing  sync.Mutex // Serializes posts so a failed batch is retried in order
+	stop      chan struct{}
+	done      chan struct{}
+	mu        sync.Mutex
+}
+
+// NewOTLPExporter creates an exporter posting to the configured collector
+func NewOTLPExporter(cfg config.TracingConfig) *OTLPExporter {
+	e := &OTLPExporter{
+		endpoint:  strings.TrimRight(cfg.Endpoint, "/") + "/v1/traces",
+		service:   cfg.ServiceName,
+		headers:   cfg.Headers,
+		interval:  time.Duration(cfg.FlushIntervalSeconds) * time.Second,
+		maxQueued: cfg.MaxQueuedSpans,
+		client:    &http.Client{Timeout: 10 * time.Second},
+	}
+	if e.service == "" {
+		e.service = "hustler"
+	}
+	if e.interval <= 0 {
+		e.interval = 5 * time.Second
+	}
+	return e
+}
+
+// Export queues a finished span
+func (e *OTLPExporter) Export(span SpanData) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.queue = append(e.queue, span)
+	if e.maxQueued > 0 && len(e.queue) > e.maxQueued {
+		overflow := len(e.queue) - e.maxQueued
+		e.queue = e.queue[overflow:]
+		e.dropped += overflow
+	}
+}
+
+// Start posts queued spans every flush interval until Stop is called
+func (e *OTLPExporter) Start() {
+	e.mu.Lock()
+	if e.stop != nil {
+		e.mu.Unlock()
+		return
+	}
+	e.stop = make(chan struct{})
+	e.done = make(chan struct{})
+	stop, done := e.stop, e.done
+	e.mu.Unlock()
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(e.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := e.Flush(); err != nil {
+					log.Printf("Error exporting traces: %v", err)
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the flush loop and posts any queued spans
+func (e *OTLPExporter) Stop() error {
+	e.mu.Lock()
+	stop, done := e.stop, e.done
+	e.stop = nil
+	e.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+	return e.Flush()
+}
+
+// Flush posts all queued spans. Spans of a failed post are queued again so
+// they are retried on the next flush.
+func (e *OTLPExporter) Flush() error {
+	e.flushing.Lock()
+	defer e.flushing.Unlock()
+
+	e.mu.Lock()
+	if e.dropped > 0 {
+		log.Printf("Trace queue full, dropped %d spans", e.dropped)
+		e.dropped = 0
+	}
+	batch := e.queue
+	e.queue = nil
+	e.mu.Unlock()
+
+	if len(batch) == 0 {
+		return nil
+	}
+	if err := e.post(batch); err != nil {
+		e.mu.Lock()
+		e.queue = append(batch, e.queue...)
+		e.mu.Unlock()
+		return fmt.Errorf("failed to export %d spans: %w", len(batch), err)
+	}
+	return nil
+}
+
+// post sends spans to the collector
+func (e *OTLPExporter) post(spans []SpanData) error {
+	body, err := json.Marshal(e.encode(spans))
+	if err != nil {
+		return fmt.Errorf("failed to marshal spans: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", e.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range e.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to export spans, status: %d, body: %s", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
+
+// OTLP JSON payload, see opentelemetry-proto trace/v1
+type (
+	otlpRequest struct {
+		ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+	}
+	otlpResourceSpans struct {
+		Resource   otlpResource     `json:"resource"`
+		ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+	}
+	otlpResource struct {
+		Attributes []otlpKeyValue `json:"attributes"`
+	}
+	otlpScopeSpans struct {
+		Scope otlpScope  `json:"scope"`
+		Spans []otlpSpan `json:"spans"`
+	}
+	otlpScope struct {
+		Name string `json:"name"`
+	}
+	otlpSpan struct {
+		TraceID           string         `json:"traceId"`
+		SpanID            string         `json:"spanId"`
+		ParentSpanID      string         `json:"parentSpanId,omitempty"`
+		Name              string         `json:"name"`
+		Kind              int            `json:"kind"`
+		StartTimeUnixNano string         `json:"startTimeUnixNano"`
+		EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+		Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+		Status            *otlpStatus    `json:"status,omitempty"`
+	}
+	otlpStatus struct {
+		Code    int    `json:"code"`
+		Message string `json:"message,omitempty"`
+	}
+	otlpKeyValue struct {
+		Key   string    `json:"key"`
+		Value otlpValue `json:"value"`
+	}
+	otlpValue struct {
+		StringValue *string  `json:"stringValue,omitempty"`
+		BoolValue   *bool    `json:"boolValue,omitempty"`
+		IntValue    *string  `json:"intValue,omitempty"` // int64 is encoded as a string in OTLP JSON
+		DoubleValue *float64 `json:"doubleValue,omitempty"`
+	}
+)
+
+// encode converts spans to an OTLP export request
+func (e *OTLPExporter) encode(spans []SpanData) otlpRequest {
+	encoded := make([]otlpSpan, 0, len(spans))
+	for _, span := range spans {
+		s := otlpSpan{
+			TraceID:           hex.EncodeToString(span.TraceID[:]),
+			SpanID:            hex.EncodeToString(span.SpanID[:]),
+			Name:              span.Name,
+			Kind:              spanKindInternal,
+			StartTimeUnixNano: strconv.FormatInt(span.Start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(span.End.UnixNano(), 10),
+			Attributes:        encodeAttributes(span.Attributes),
+		}
+		if span.ParentID != ([8]byte{}) {
+			s.ParentSpanID = hex.EncodeToString(span.ParentID[:])
+		}
+		if span.Error != "" {
+			s.Status = &otlpStatus{Code: statusCodeError, Message: span.Error}
+		}
+		encoded = append(encoded, s)
+	}
+
+	return otlpRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource: otlpResource{Attributes: encodeAttributes(map[string]interface{}{"service.name": e.service})},
+		ScopeSpans: []otlpScopeSpans{{
+			Scope: otlpScope{Name: instrumentationScope},
+			Spans: encoded,
+		}},
+	}}}
+}
+
+// encodeAttributes converts attributes to OTLP key-values sorted by key.
+// Values of other types are sent as strings.
+func encodeAttributes(attributes map[string]interface{}) []otlpKeyValue {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	encoded := make([]otlpKeyValue, 0, len(keys))
+	for _, key := range keys {
+		var value otlpValue
+		switch v := attributes[key].(type) {
+		case string:
+			value.StringValue = &v
+		case bool:
+			value.BoolValue = &v
+		case int:
+			i := strconv.Itoa(v)
+			value.IntValue = &i
+		case int64:
+			i := strconv.FormatInt(v, 10)
+			value.IntValue = &i
+		case float64:
+			value.DoubleValue = &v
+		default:
+			str := fmt.Sprint(v)
+			value.StringValue = &str
+		}
+		encoded = append(encoded, otlpKeyValue{Key: key, Value: value})
+	}
+	return encoded
+}
@@ -0,0 +1,126 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// SpanData is a finished span
+type SpanData struct {
+	TraceID    [16]byte
+	SpanID     [8]byte
+	ParentID   [8]byte // Zero for a root span
+	Name       string
+	Start      time.Time
+	End        time.Time
+	Attributes map[string]interface{}
+	Error      string // Set when the operation failed
+}
+
+// Exporter receives finished spans (implemented by OTLPExporter)
+type Exporter interface {
+	Export(span SpanData)
+}
+
+// Tracer starts root spans and hands finished spans to its exporter
+type Tracer struct {
+	exporter Exporter
+	now      func() time.Time
+}
+
+// NewTracer creates a tracer exporting to exporter
+func NewTracer(exporter Exporter) *Tracer {
+	return &Tracer{exporter: exporter, now: time.Now}
+}
+
+// Span is an operation being timed. A nil span ignores all calls, so code
+// can be instrumented whether or not a trace is being recorded.
+type Span struct {
+	tracer *Tracer
+	data   SpanData
+	ended  bool
+	mu     sync.Mutex
+}
+
+// spanKey is the context key of the current span
+type spanKey struct{}
+
+// Start starts a new trace with a root span named name
+func (t *Tracer) Start(ctx context.Context, name string) (context.Context, *Span) {
+	if t == nil {
+		return ctx, nil
+	}
+	span := &Span{tracer: t, data: SpanData{Name: name, Start: t.now()}}
+	rand.Read(span.data.TraceID[:])
+	rand.Read(span.data.SpanID[:])
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// Start starts a child of the span in ctx. Without a span in ctx nothing is
+// traced and the returned span is nil.
+func Start(ctx context.Context, name string) (context.Context, *Span) {
+	parent, _ := ctx.Value(spanKey{}).(*Span)
+	if parent == nil {
+		return ctx, nil
+	}
+	span := &Span{tracer: parent.tracer, data: SpanData{
+		TraceID:  parent.data.TraceID,
+		ParentID: parent.data.SpanID,
+		Name:     name,
+		Start:    parent.tracer.now(),
+	}}
+	rand.Read(span.data.SpanID[:])
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// SetAttribute records a string, bool, int or float64 attribute
+func (s *Span) SetAttribute(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.data.Attributes == nil {
+		s.data.Attributes = make(map[string]interface{})
+	}
+	s.data.Attributes[key] = value
+}
+
+// RecordError marks the span as failed with err. A nil err is ignored.
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data.Error = err.Error()
+}
+
+// End finishes the span and exports it. Only the first call has an effect.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.data.End = s.tracer.now()
+	data := s.data
+	s.mu.Unlock()
+
+	s.tracer.exporter.Export(data)
+}
+
+// TraceID returns the hex trace ID, or "" for a nil span
+func (s *Span) TraceID() string {
+	if s == nil {
+		return ""
+	}
+	return hex.EncodeToString(s.data.TraceID[:])
+}
@@ -0,0 +1,105 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpansAreExportedAsOTLP(t *testing.T) {
+	var received otlpRequest
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/traces", r.URL.Path)
+		auth = r.Header.Get("Authorization")
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	exporter := NewOTLPExporter(config.TracingConfig{
+		Endpoint:    server.URL + "/",
+		ServiceName: "hustler-test",
+		Headers:     map[string]string{"Authorization": "Bearer token"},
+	})
+	tracer := NewTracer(exporter)
+	start := time.Unix(1700000000, 0)
+	tracer.now = func() time.Time { return start }
+
+	ctx, root := tracer.Start(context.Background(), "market_check")
+	root.SetAttribute("symbols", 3)
+	_, child := Start(ctx, "llm.explain")
+	child.RecordError(errors.New("rate limited"))
+	child.End()
+	root.End()
+	root.End()
+	assert.NoError(t, exporter.Flush())
+
+	assert.Equal(t, "Bearer token", auth)
+	assert.Len(t, received.ResourceSpans, 1)
+	resource := received.ResourceSpans[0]
+	assert.Equal(t, "service.name", resource.Resource.Attributes[0].Key)
+	assert.Equal(t, "hustler-test", *resource.Resource.Attributes[0].Value.StringValue)
+
+	spans := resource.ScopeSpans[0].Spans
+	assert.Len(t, spans, 2)
+	llm, check := spans[0], spans[1]
+	assert.Equal(t, "market_check", check.Name)
+	assert.Equal(t, root.TraceID(), check.TraceID)
+	assert.Empty(t, check.ParentSpanID)
+	assert.Equal(t, "symbols", check.Attributes[0].Key)
+	assert.Equal(t, "3", *check.Attributes[0].Value.IntValue)
+	assert.Equal(t, "1700000000000000000", check.StartTimeUnixNano)
+
+	assert.Equal(t, check.TraceID, llm.TraceID)
+	assert.Equal(t, check.SpanID, llm.ParentSpanID)
+	assert.Equal(t, statusCodeError, llm.Status.Code)
+	assert.Equal(t, "rate limited", llm.Status.Message)
+}
+
+func TestFailedExportIsRetried(t *testing.T) {
+	fail := true
+	exported := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var req otlpRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		exported += len(req.ResourceSpans[0].ScopeSpans[0].Spans)
+	}))
+	defer server.Close()
+
+	exporter := NewOTLPExporter(config.TracingConfig{Endpoint: server.URL, MaxQueuedSpans: 2})
+	tracer := NewTracer(exporter)
+	for i := 0; i < 3; i++ {
+		_, span := tracer.Start(context.Background(), "market_check")
+		span.End()
+	}
+
+	assert.Error(t, exporter.Flush())
+	fail = false
+	assert.NoError(t, exporter.Flush())
+	// The oldest span was dropped when the queue overflowed
+	assert.Equal(t, 2, exported)
+}
+
+func TestUntracedSpansAreNoOps(t *testing.T) {
+	var tracer *Tracer
+	ctx, root := tracer.Start(context.Background(), "market_check")
+	assert.Nil(t, root)
+
+	_, child := Start(ctx, "data.fetch")
+	assert.Nil(t, child)
+	child.SetAttribute("failed", 1)
+	child.RecordError(errors.New("boom"))
+	child.End()
+	assert.Equal(t, "", child.TraceID())
+}
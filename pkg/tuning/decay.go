@@ -0,0 +1,52 @@
+package tuning
+
+import (
+	"math"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+)
+
+// Weight returns how much of a symbol's tuned parameters still applies age
+// after they were last validated: 1 through the grace period, then halving
+// every half-life, and 0 once it falls below the minimum weight
+func Weight(decay config.ParamDecayConfig, age time.Duration) float64 {
+	stale := age - time.Duration(decay.GraceDays)*24*time.Hour
+	if stale <= 0 {
+		return 1
+	}
+	if decay.HalfLifeDays <= 0 {
+		return 0
+	}
+
+	weight := math.Pow(0.5, stale.Hours()/24/decay.HalfLifeDays)
+	if weight < decay.MinWeight {
+		return 0
+	}
+	return weight
+}
+
+// Blend moves tuned parameters toward the defaults, keeping weight of the
+// distance between them. Zero tuned fields are not tuned and keep the
+// default. Indicator periods are fixed at startup, so they keep the defaults.
+func Blend(defaults, tuned config.VolatilityConfig, weight float64) config.VolatilityConfig {
+	blend := func(def, value float64) float64 {
+		if value == 0 {
+			return def
+		}
+		return def + (value-def)*weight
+	}
+
+	blended := defaults
+	blended.MinVolatilityPercent = blend(defaults.MinVolatilityPercent, tuned.MinVolatilityPercent)
+	blended.MinExpectedROI = blend(defaults.MinExpectedROI, tuned.MinExpectedROI)
+	blended.StopLossPercent = blend(defaults.StopLossPercent, tuned.StopLossPercent)
+	blended.BollingerDeviation = blend(defaults.BollingerDeviation, tuned.BollingerDeviation)
+	blended.RSIOverbought = blend(defaults.RSIOverbought, tuned.RSIOverbought)
+	blended.RSIOversold = blend(defaults.RSIOversold, tuned.RSIOversold)
+	blended.VolumeThreshold = blend(defaults.VolumeThreshold, tuned.VolumeThreshold)
+	blended.ConfidenceThreshold = blend(defaults.ConfidenceThreshold, tuned.ConfidenceThreshold)
+	blended.ADXTrendThreshold = blend(defaults.ADXTrendThreshold, tuned.ADXTrendThreshold)
+	blended.ADXRangeThreshold = blend(defaults.ADXRangeThreshold, tuned.ADXRangeThreshold)
+	return blended
+}
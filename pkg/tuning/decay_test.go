@@ -0,0 +1,112 @@
+package tuning
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/stretchr/testify/assert"
+)
+
+var day = 24 * time.Hour
+
+func TestWeightDecaysAfterGracePeriod(t *testing.T) {
+	decay := config.ParamDecayConfig{GraceDays: 7, HalfLifeDays: 14, MinWeight: 0.1}
+
+	assert.Equal(t, 1.0, Weight(decay, 7*day))
+	assert.InDelta(t, 0.5, Weight(decay, 21*day), 1e-9)
+	assert.InDelta(t, 0.25, Weight(decay, 35*day), 1e-9)
+	assert.Equal(t, 0.0, Weight(decay, 100*day))
+}
+
+func TestBlendMovesTowardDefaults(t *testing.T) {
+	defaults := config.CreateDefaultConfig().VolatilityParams
+	tuned := config.VolatilityConfig{ConfidenceThreshold: defaults.ConfidenceThreshold + 0.2, RSIPeriod: 5}
+
+	half := Blend(defaults, tuned, 0.5)
+	assert.InDelta(t, defaults.ConfidenceThreshold+0.1, half.ConfidenceThreshold, 1e-9)
+	assert.Equal(t, defaults.StopLossPercent, half.StopLossPercent)
+	assert.Equal(t, defaults.RSIPeriod, half.RSIPeriod)
+
+	assert.Equal(t, defaults, Blend(defaults, tuned, 0))
+}
+
+func TestRegistryRevertsStaleParams(t *testing.T) {
+	now := time.Date(2025, 6, 2, 14, 0, 0, 0, time.UTC)
+	defaults := config.CreateDefaultConfig().VolatilityParams
+	tuned := defaults
+	tuned.StopLossPercent = defaults.StopLossPercent * 2
+
+	registry := NewRegistry(config.ParamDecayConfig{GraceDays: 7, HalfLifeDays: 14, MinWeight: 0.1},
+		map[string]config.SymbolParamsConfig{
+			"NVDA": {Params: tuned, ValidatedAt: now.Add(-2 * day)},
+			"SHOP": {Params: tuned, ValidatedAt: now.Add(-90 * day)},
+		})
+	registry.SetClock(clock.NewFake(now))
+
+	params, ok := registry.ParamsFor("NVDA", defaults)
+	assert.True(t, ok)
+	assert.Equal(t, tuned.StopLossPercent, params.StopLossPercent)
+
+	params, ok = registry.ParamsFor("SHOP", defaults)
+	assert.False(t, ok)
+	assert.Equal(t, defaults, params)
+
+	_, ok = registry.ParamsFor("AAPL", defaults)
+	assert.False(t, ok)
+
+	statuses := registry.Status()
+	assert.Len(t, statuses, 2)
+	assert.Equal(t, "SHOP", statuses[1].Symbol)
+	assert.True(t, statuses[1].Reverted)
+
+	assert.NoError(t, registry.Revalidate("SHOP", now))
+	_, ok = registry.ParamsFor("SHOP", defaults)
+	assert.True(t, ok)
+	assert.Error(t, registry.Revalidate("AAPL", now))
+}
+
+// flatHistory serves a day of constant one-minute bars for every symbol
+type flatHistory struct {
+	start time.Time
+}
+
+func (h flatHistory) GetIntradayHistory(symbol string, days int) (*data.MarketData, error) {
+	md := &data.MarketData{Symbol: symbol}
+	for i := 0; i < 390; i++ {
+		md.Prices = append(md.Prices, 100)
+		md.Volumes = append(md.Volumes, 100000)
+		md.Timestamps = append(md.Timestamps, h.start.Add(time.Duration(i)*time.Minute))
+	}
+	return md, nil
+}
+
+func (h flatHistory) GetDailyHistory(symbol string, days int) (*data.MarketData, error) {
+	return nil, fmt.Errorf("no daily history")
+}
+
+func TestValidatorNeedsEnoughSignals(t *testing.T) {
+	now := time.Date(2025, 6, 2, 14, 0, 0, 0, time.UTC)
+	cfg := config.CreateDefaultConfig()
+	cfg.CheckInterval = 900
+	cfg.SymbolParams = map[string]config.SymbolParamsConfig{
+		"NVDA": {Params: cfg.VolatilityParams, ValidatedAt: now.Add(-60 * day)},
+	}
+
+	registry := NewRegistry(cfg.ParamDecay, cfg.SymbolParams)
+	registry.SetClock(clock.NewFake(now))
+	validator := NewValidator(cfg, flatHistory{start: time.Date(2025, 5, 30, 13, 30, 0, 0, time.UTC)}, registry)
+
+	// A flat market produces no signals, so the parameters keep decaying
+	validation, err := validator.Validate("NVDA")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, validation.Tuned.SignalsCount)
+	assert.False(t, validation.Revalidated)
+	assert.True(t, registry.Status()[0].Reverted)
+
+	_, err = validator.Validate("AAPL")
+	assert.Error(t, err)
+}
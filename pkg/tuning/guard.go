@@ -0,0 +1,207 @@
+package tuning
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/market"
+	"github.com/hustler/trading-bot/pkg/performance"
+)
+
+// warmupDays of history before the held-out window settle the indicators
+const warmupDays = 2
+
+// Audit actions recorded for optimizer proposals
+const (
+	ActionAccepted = "params.proposal_accepted"
+	ActionRejected = "params.proposal_rejected"
+	ActionApplied  = "params.proposal_applied"
+)
+
+// guardActor is the audit actor of decisions the guard makes on its own
+const guardActor = "optimizer-guard"
+
+// AuditLog records who did what to which target (implemented by store.Logger)
+type AuditLog interface {
+	RecordAudit(actor, action, target string, details interface{}) error
+}
+
+// Proposal is a parameter set the walk-forward optimizer found for a symbol
+type Proposal struct {
+	Symbol string                  `json:"symbol"`
+	Params config.VolatilityConfig `json:"params"`
+	Source string                  `json:"source"` // Optimizer run that produced the parameters
+}
+
+// Decision is the out-of-sample verdict on a proposal
+type Decision struct {
+	Proposal
+	Candidate *performance.Metrics `json:"candidate"` // The proposal on the held-out window
+	Incumbent *performance.Metrics `json:"incumbent"` // The symbol's current parameters on the same window
+	Accepted  bool                 `json:"accepted"`
+	Applied   bool                 `json:"applied"`
+	Reason    string               `json:"reason"`
+	DecidedAt time.Time            `json:"decided_at"`
+}
+
+// Guard keeps optimizer results that only fit the data they were optimized
+// on from going live: a proposal must beat the symbol's incumbent parameters
+// on the most recent trading days, which the optimizer must hold out.
+// Accepted proposals are applied at once with auto_apply and otherwise wait
+// for approval.
+type Guard struct {
+	config   *config.Config
+	history  HistorySource
+	registry *Registry
+	audit    AuditLog
+	pending  map[string]*Decision // Accepted proposals awaiting approval, by symbol
+	session  *market.Clock
+	clock    clock.Clock
+	mu       sync.Mutex
+}
+
+// NewGuard creates a guard applying accepted proposals to registry
+func NewGuard(cfg *config.Config, history HistorySource, registry *Registry) *Guard {
+	return &Guard{
+		config:   cfg,
+		history:  history,
+		registry: registry,
+		pending:  make(map[string]*Decision),
+		session:  market.DefaultClock(),
+		clock:    clock.Real{},
+	}
+}
+
+// SetAuditLog sets the audit log decisions are recorded in. Without one they are only logged.
+func (g *Guard) SetAuditLog(audit AuditLog) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.audit = audit
+}
+
+// SetClock sets the clock decisions are timestamped with
+func (g *Guard) SetClock(c clock.Clock) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.clock = c
+}
+
+// Evaluate backtests a proposal and the symbol's incumbent parameters on the
+// held-out window and decides whether the proposal may replace them
+func (g *Guard) Evaluate(p Proposal) (*Decision, error) {
+	guard := g.config.OptimizerGuard
+	if guard.HoldoutDays <= 0 {
+		return nil, fmt.Errorf("the out-of-sample guard needs holdout_days")
+	}
+
+	intraday, err := g.history.GetIntradayHistory(p.Symbol, guard.HoldoutDays+warmupDays)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get history for %s: %w", p.Symbol, err)
+	}
+	days := tradingDays(g.session, intraday.Timestamps)
+	if len(days) == 0 {
+		return nil, fmt.Errorf("no history for %s", p.Symbol)
+	}
+	if len(days) > guard.HoldoutDays {
+		days = days[len(days)-guard.HoldoutDays:]
+	}
+	daily, err := g.history.GetDailyHistory(p.Symbol, dailyHistoryDays)
+	if err != nil {
+		daily = nil // Liquidity checks fall back to their defaults
+	}
+
+	defaults := g.config.VolatilityParams
+	incumbent, _ := g.registry.ParamsFor(p.Symbol, defaults)
+	decision := &Decision{Proposal: p}
+	if decision.Candidate, err = replay(g.config, g.session, p.Symbol, Blend(defaults, p.Params, 1), intraday, daily, days); err != nil {
+		return nil, err
+	}
+	if decision.Incumbent, err = replay(g.config, g.session, p.Symbol, incumbent, intraday, daily, days); err != nil {
+		return nil, err
+	}
+	decision.Accepted, decision.Reason = judge(decision.Candidate, decision.Incumbent, guard)
+
+	g.mu.Lock()
+	decision.DecidedAt = g.clock.Now()
+	if decision.Accepted {
+		g.pending[p.Symbol] = decision
+	}
+	g.mu.Unlock()
+
+	action := ActionRejected
+	if decision.Accepted {
+		action = ActionAccepted
+	}
+	g.record(guardActor, action, decision)
+
+	if decision.Accepted && guard.AutoApply {
+		return g.Apply(p.Symbol, guardActor)
+	}
+	return decision, nil
+}
+
+// Apply applies the accepted proposal awaiting approval for symbol
+func (g *Guard) Apply(symbol, actor string) (*Decision, error) {
+	g.mu.Lock()
+	decision, ok := g.pending[symbol]
+	if ok {
+		delete(g.pending, symbol)
+		decision.Applied = true
+	}
+	now := g.clock.Now()
+	g.mu.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf("no accepted proposal awaiting approval for %s", symbol)
+	}
+	g.registry.Set(symbol, decision.Params, now)
+	g.record(actor, ActionApplied, decision)
+	return decision, nil
+}
+
+// Pending returns the accepted proposals awaiting approval, sorted by symbol
+func (g *Guard) Pending() []Decision {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	pending := make([]Decision, 0, len(g.pending))
+	for _, decision := range g.pending {
+		pending = append(pending, *decision)
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].Symbol < pending[j].Symbol })
+	return pending
+}
+
+// record logs a decision and writes it to the audit log
+func (g *Guard) record(actor, action string, decision *Decision) {
+	log.Printf("Optimizer proposal for %s from %s: %s (%s) by %s",
+		decision.Symbol, decision.Source, action, decision.Reason, actor)
+
+	g.mu.Lock()
+	audit := g.audit
+	g.mu.Unlock()
+
+	if audit == nil {
+		return
+	}
+	if err := audit.RecordAudit(actor, action, decision.Symbol, decision); err != nil {
+		log.Printf("Error recording optimizer decision for %s: %v", decision.Symbol, err)
+	}
+}
+
+// judge decides whether a candidate beat the incumbent on the held-out window
+func judge(candidate, incumbent *performance.Metrics, guard config.OptimizerGuardConfig) (bool, string) {
+	if candidate.SignalsCount < guard.MinSignals {
+		return false, fmt.Sprintf("%d held-out signals, %d needed", candidate.SignalsCount, guard.MinSignals)
+	}
+	if candidate.AverageROI <= incumbent.AverageROI+guard.MinImprovement {
+		return false, fmt.Sprintf("average ROI %+.2f%% does not beat the incumbent's %+.2f%% by %.2f points",
+			candidate.AverageROI, incumbent.AverageROI, guard.MinImprovement)
+	}
+	return true, fmt.Sprintf("average ROI %+.2f%% beats the incumbent's %+.2f%%", candidate.AverageROI, incumbent.AverageROI)
+}
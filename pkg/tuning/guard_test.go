@@ -0,0 +1,64 @@
+package tuning
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/performance"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingAudit keeps the actions written to the audit log
+type recordingAudit struct {
+	actions []string
+}
+
+func (a *recordingAudit) RecordAudit(actor, action, target string, details interface{}) error {
+	a.actions = append(a.actions, action)
+	return nil
+}
+
+func TestGuardRejectsProposalWithoutHeldOutSignals(t *testing.T) {
+	now := time.Date(2025, 6, 2, 14, 0, 0, 0, time.UTC)
+	cfg := config.CreateDefaultConfig()
+	cfg.CheckInterval = 900
+	cfg.OptimizerGuard.AutoApply = true
+
+	registry := NewRegistry(cfg.ParamDecay, nil)
+	registry.SetClock(clock.NewFake(now))
+	guard := NewGuard(cfg, flatHistory{start: time.Date(2025, 5, 30, 13, 30, 0, 0, time.UTC)}, registry)
+	guard.SetClock(clock.NewFake(now))
+	audit := &recordingAudit{}
+	guard.SetAuditLog(audit)
+
+	proposed := cfg.VolatilityParams
+	proposed.StopLossPercent *= 2
+
+	// A flat market produces no held-out signals, so nothing is applied
+	decision, err := guard.Evaluate(Proposal{Symbol: "NVDA", Params: proposed, Source: "walk-forward"})
+	assert.NoError(t, err)
+	assert.False(t, decision.Accepted)
+	assert.False(t, decision.Applied)
+	assert.Equal(t, now, decision.DecidedAt)
+	assert.Equal(t, []string{ActionRejected}, audit.actions)
+	assert.Empty(t, guard.Pending())
+	assert.Empty(t, registry.Symbols())
+
+	_, err = guard.Apply("NVDA", "admin")
+	assert.Error(t, err)
+}
+
+func TestJudgeRequiresImprovement(t *testing.T) {
+	guard := config.OptimizerGuardConfig{MinSignals: 3, MinImprovement: 0.5}
+
+	accepted, _ := judge(&performance.Metrics{SignalsCount: 2, AverageROI: 5}, &performance.Metrics{AverageROI: 1}, guard)
+	assert.False(t, accepted)
+
+	accepted, _ = judge(&performance.Metrics{SignalsCount: 4, AverageROI: 1.2}, &performance.Metrics{AverageROI: 1}, guard)
+	assert.False(t, accepted)
+
+	accepted, _ = judge(&performance.Metrics{SignalsCount: 4, AverageROI: 2}, &performance.Metrics{AverageROI: 1}, guard)
+	assert.True(t, accepted)
+}
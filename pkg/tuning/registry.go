@@ -0,0 +1,139 @@
+package tuning
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/config"
+)
+
+// SymbolStatus describes how much of a symbol's tuned parameters still applies
+type SymbolStatus struct {
+	Symbol      string    `json:"symbol"`
+	ValidatedAt time.Time `json:"validated_at"`
+	Weight      float64   `json:"weight"`   // 1 is fully tuned, 0 is back on the global parameters
+	Reverted    bool      `json:"reverted"` // Decayed fully to the global parameters
+}
+
+// Registry holds the parameters tuned for individual symbols and decays them
+// toward the global parameters as their last validation ages
+type Registry struct {
+	decay    config.ParamDecayConfig
+	tuned    map[string]config.SymbolParamsConfig
+	reverted map[string]bool // Symbols already reported as reverted to the defaults
+	clock    clock.Clock
+	mu       sync.RWMutex
+}
+
+// NewRegistry creates a registry of tuned parameters keyed by symbol
+func NewRegistry(decay config.ParamDecayConfig, tuned map[string]config.SymbolParamsConfig) *Registry {
+	copied := make(map[string]config.SymbolParamsConfig, len(tuned))
+	for symbol, params := range tuned {
+		copied[symbol] = params
+	}
+	return &Registry{
+		decay:    decay,
+		tuned:    copied,
+		reverted: make(map[string]bool),
+		clock:    clock.Real{},
+	}
+}
+
+// SetClock sets the clock parameter ages are measured with
+func (r *Registry) SetClock(c clock.Clock) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.clock = c
+}
+
+// Symbols returns the symbols with tuned parameters, sorted
+func (r *Registry) Symbols() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	symbols := make([]string, 0, len(r.tuned))
+	for symbol := range r.tuned {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+// Tuned returns the tuned parameters of a symbol before decay
+func (r *Registry) Tuned(symbol string) (config.VolatilityConfig, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	tuned, ok := r.tuned[symbol]
+	return tuned.Params, ok
+}
+
+// ParamsFor returns the parameters signals for symbol are generated with: its
+// tuned parameters decayed toward defaults. It reports false for symbols
+// without tuned parameters or whose parameters have fully decayed.
+func (r *Registry) ParamsFor(symbol string, defaults config.VolatilityConfig) (config.VolatilityConfig, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tuned, ok := r.tuned[symbol]
+	if !ok {
+		return defaults, false
+	}
+	weight := Weight(r.decay, r.clock.Now().Sub(tuned.ValidatedAt))
+	if weight == 0 {
+		if !r.reverted[symbol] {
+			r.reverted[symbol] = true
+			log.Printf("Tuned parameters for %s were last validated %s and have reverted to the defaults",
+				symbol, tuned.ValidatedAt.Format("2006-01-02"))
+		}
+		return defaults, false
+	}
+	return Blend(defaults, tuned.Params, weight), true
+}
+
+// Set replaces a symbol's tuned parameters with params validated at
+func (r *Registry) Set(symbol string, params config.VolatilityConfig, validatedAt time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tuned[symbol] = config.SymbolParamsConfig{Params: params, ValidatedAt: validatedAt}
+	delete(r.reverted, symbol)
+}
+
+// Revalidate marks a symbol's tuned parameters as confirmed at, restoring their full weight
+func (r *Registry) Revalidate(symbol string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tuned, ok := r.tuned[symbol]
+	if !ok {
+		return fmt.Errorf("no tuned parameters for %s", symbol)
+	}
+	tuned.ValidatedAt = at
+	r.tuned[symbol] = tuned
+	delete(r.reverted, symbol)
+	return nil
+}
+
+// Status returns the decay of every symbol's tuned parameters, sorted by symbol
+func (r *Registry) Status() []SymbolStatus {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := r.clock.Now()
+	statuses := make([]SymbolStatus, 0, len(r.tuned))
+	for symbol, tuned := range r.tuned {
+		weight := Weight(r.decay, now.Sub(tuned.ValidatedAt))
+		statuses = append(statuses, SymbolStatus{
+			Symbol:      symbol,
+			ValidatedAt: tuned.ValidatedAt,
+			Weight:      weight,
+			Reverted:    weight == 0,
+		})
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Symbol < statuses[j].Symbol })
+	return statuses
+}
@@ -0,0 +1,54 @@
+package tuning
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/backtest"
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/market"
+	"github.com/hustler/trading-bot/pkg/performance"
+)
+
+// dailyHistoryDays of daily bars are served to the backtest's liquidity checks
+const dailyHistoryDays = 365
+
+// replay backtests symbol alone with params over the regular sessions of days.
+// Earlier bars in intraday warm up the indicators.
+func replay(cfg *config.Config, session *market.Clock, symbol string, params config.VolatilityConfig, intraday, daily *data.MarketData, days []time.Time) (*performance.Metrics, error) {
+	backtestCfg := cfg.Clone()
+	backtestCfg.VolatilityParams = params
+	backtestCfg.Watchlists = nil
+	backtestCfg.StockSymbols = []string{symbol}
+
+	runner := backtest.NewRunner(backtestCfg, map[string]*data.MarketData{symbol: intraday})
+	if daily != nil {
+		runner.Source.SetDailyHistory(symbol, daily)
+	}
+
+	step := time.Duration(backtestCfg.CheckInterval) * time.Second
+	if step <= 0 {
+		step = time.Minute
+	}
+	for _, day := range days {
+		for at := session.SessionOpen(day); at.Before(session.SessionClose(day)); at = at.Add(step) {
+			if err := runner.Step(at); err != nil {
+				return nil, fmt.Errorf("failed to backtest %s: %w", symbol, err)
+			}
+		}
+	}
+	return runner.Performance.GetMetrics(), nil
+}
+
+// tradingDays returns the distinct trading days of timestamps in order
+func tradingDays(session *market.Clock, timestamps []time.Time) []time.Time {
+	var days []time.Time
+	for _, t := range timestamps {
+		day := session.TradingDay(t)
+		if session.IsTradingDay(day) && (len(days) == 0 || !days[len(days)-1].Equal(day)) {
+			days = append(days, day)
+		}
+	}
+	return days
+}
@@ -0,0 +1,222 @@
+package tuning
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/indicators"
+	"github.com/hustler/trading-bot/pkg/market"
+)
+
+// surfacePeriod is the realized volatility window in trading days
+const surfacePeriod = 20
+
+// surfaceRefreshInterval is how often the surface checks whether a new day's
+// volatility is due
+const surfaceRefreshInterval = time.Hour
+
+// Default bounds of the threshold multiplier
+const (
+	defaultMinScale = 0.5
+	defaultMaxScale = 2.5
+)
+
+// DailyHistorySource provides daily closes (implemented by data.Provider)
+type DailyHistorySource interface {
+	GetDailyHistory(symbol string, days int) (*data.MarketData, error)
+}
+
+// SymbolVolatility is a symbol's realized volatility and the multiplier of
+// its thresholds
+type SymbolVolatility struct {
+	Symbol     string  `json:"symbol"`
+	Volatility float64 `json:"volatility"` // Annualized, percent
+	Scale      float64 `json:"scale"`
+}
+
+// VolatilitySurface holds the realized volatility of the watched symbols,
+// recomputed daily, and scales the global volatility, stop loss and expected
+// ROI thresholds to it. Symbols with tuned parameters keep them.
+type VolatilitySurface struct {
+	config     config.VolatilityScalingConfig
+	history    DailyHistorySource
+	tuned      *Registry // Optional
+	session    *market.Clock
+	clock      clock.Clock
+	volatility map[string]float64
+	reference  float64 // Volatility the global thresholds apply to unscaled
+	day        time.Time
+	mu         sync.RWMutex
+}
+
+// NewVolatilitySurface creates a surface of daily realized volatility.
+// tuned may be nil.
+func NewVolatilitySurface(history DailyHistorySource, tuned *Registry, cfg config.VolatilityScalingConfig) *VolatilitySurface {
+	return &VolatilitySurface{
+		config:     cfg,
+		history:    history,
+		tuned:      tuned,
+		session:    market.DefaultClock(),
+		clock:      clock.Real{},
+		volatility: make(map[string]float64),
+	}
+}
+
+// SetClock sets the clock deciding when the surface is refreshed
+func (s *VolatilitySurface) SetClock(c clock.Clock) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clock = c
+}
+
+// Run refreshes the surface for symbols now and then daily, until stop is closed
+func (s *VolatilitySurface) Run(symbols []string, stop <-chan struct{}) {
+	refresh := func() {
+		if err := s.Refresh(symbols); err != nil {
+			log.Printf("Error refreshing volatility surface: %v", err)
+		}
+	}
+	refresh()
+
+	ticker := time.NewTicker(surfaceRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			refresh()
+		}
+	}
+}
+
+// Refresh recomputes the realized volatility of symbols once per trading day.
+// Symbols whose history can't be fetched keep their previous volatility.
+func (s *VolatilitySurface) Refresh(symbols []string) error {
+	s.mu.RLock()
+	today := s.session.TradingDay(s.clock.Now())
+	fresh := s.day.Equal(today)
+	s.mu.RUnlock()
+	if fresh {
+		return nil
+	}
+
+	computed := make(map[string]float64, len(symbols))
+	for _, symbol := range symbols {
+		history, err := s.history.GetDailyHistory(symbol, surfacePeriod+1)
+		if err != nil {
+			log.Printf("Error fetching daily history for %s: %v", symbol, err)
+			continue
+		}
+		if volatility := indicators.HistoricalVolatility(history.Prices, surfacePeriod); volatility > 0 {
+			computed[symbol] = volatility
+		}
+	}
+	if len(computed) == 0 && len(symbols) > 0 {
+		return fmt.Errorf("failed to compute the volatility of any of %d symbols", len(symbols))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for symbol, volatility := range computed {
+		s.volatility[symbol] = volatility
+	}
+	s.reference = s.config.ReferenceVolatility
+	if s.reference <= 0 {
+		s.reference = median(s.volatility)
+	}
+	s.day = today
+	return nil
+}
+
+// Scale returns the multiplier of a symbol's thresholds: its volatility
+// relative to the reference, within the configured bounds
+func (s *VolatilitySurface) Scale(symbol string) (float64, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.scale(symbol)
+}
+
+// scale returns the multiplier of a symbol's thresholds; s.mu must be held
+func (s *VolatilitySurface) scale(symbol string) (float64, bool) {
+	volatility, ok := s.volatility[symbol]
+	if !ok || s.reference <= 0 {
+		return 1, false
+	}
+
+	minScale, maxScale := s.config.MinScale, s.config.MaxScale
+	if minScale <= 0 {
+		minScale = defaultMinScale
+	}
+	if maxScale <= 0 {
+		maxScale = defaultMaxScale
+	}
+	return math.Max(minScale, math.Min(maxScale, volatility/s.reference)), true
+}
+
+// ParamsFor returns the parameters signals for symbol are generated with: its
+// tuned parameters if it has any, otherwise the defaults scaled to its
+// volatility. It reports false for symbols without either.
+func (s *VolatilitySurface) ParamsFor(symbol string, defaults config.VolatilityConfig) (config.VolatilityConfig, bool) {
+	if s.tuned != nil {
+		if params, ok := s.tuned.ParamsFor(symbol, defaults); ok {
+			return params, true
+		}
+	}
+
+	scale, ok := s.Scale(symbol)
+	if !ok {
+		return defaults, false
+	}
+	return ScaleThresholds(defaults, scale), true
+}
+
+// Surface returns the volatility and threshold multiplier of every symbol, sorted by symbol
+func (s *VolatilitySurface) Surface() []SymbolVolatility {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	surface := make([]SymbolVolatility, 0, len(s.volatility))
+	for symbol, volatility := range s.volatility {
+		scale, _ := s.scale(symbol)
+		surface = append(surface, SymbolVolatility{Symbol: symbol, Volatility: volatility, Scale: scale})
+	}
+	sort.Slice(surface, func(i, j int) bool { return surface[i].Symbol < surface[j].Symbol })
+	return surface
+}
+
+// ScaleThresholds multiplies the volatility, stop loss and expected ROI
+// thresholds of params by scale
+func ScaleThresholds(params config.VolatilityConfig, scale float64) config.VolatilityConfig {
+	scaled := params
+	scaled.MinVolatilityPercent *= scale
+	scaled.StopLossPercent *= scale
+	scaled.MinExpectedROI *= scale
+	return scaled
+}
+
+// median returns the median of the values, 0 if there are none
+func median(values map[string]float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := make([]float64, 0, len(values))
+	for _, v := range values {
+		sorted = append(sorted, v)
+	}
+	sort.Float64s(sorted)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
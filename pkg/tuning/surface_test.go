@@ -0,0 +1,86 @@
+package tuning
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/stretchr/testify/assert"
+)
+
+// swingHistory serves daily closes alternating up and down by a symbol's swing
+type swingHistory map[string]float64
+
+func (h swingHistory) GetDailyHistory(symbol string, days int) (*data.MarketData, error) {
+	swing, ok := h[symbol]
+	if !ok {
+		return nil, fmt.Errorf("no history for %s", symbol)
+	}
+	prices := make([]float64, days)
+	for i := range prices {
+		prices[i] = 100
+		if i%2 == 1 {
+			prices[i] = 100 * (1 + swing)
+		}
+	}
+	return &data.MarketData{Symbol: symbol, Prices: prices}, nil
+}
+
+func TestVolatilitySurfaceScalesThresholds(t *testing.T) {
+	now := time.Date(2025, 6, 2, 14, 0, 0, 0, time.UTC)
+	defaults := config.CreateDefaultConfig().VolatilityParams
+
+	tuned := NewRegistry(config.ParamDecayConfig{GraceDays: 7}, map[string]config.SymbolParamsConfig{
+		"NVDA": {Params: config.VolatilityConfig{StopLossPercent: 3}, ValidatedAt: now},
+	})
+	tuned.SetClock(clock.NewFake(now))
+	history := swingHistory{"KO": 0.005, "AAPL": 0.01, "MSFT": 0.011, "TSLA": 0.04, "NVDA": 0.03}
+
+	surface := NewVolatilitySurface(history, tuned, config.VolatilityScalingConfig{Enabled: true})
+	surface.SetClock(clock.NewFake(now))
+	assert.NoError(t, surface.Refresh([]string{"KO", "AAPL", "MSFT", "TSLA", "NVDA", "GME"}))
+
+	// MSFT is the median, so it is traded on the global thresholds
+	scale, ok := surface.Scale("MSFT")
+	assert.True(t, ok)
+	assert.InDelta(t, 1.0, scale, 1e-9)
+	scale, _ = surface.Scale("AAPL")
+	assert.InDelta(t, 0.91, scale, 0.01)
+
+	quiet, ok := surface.ParamsFor("KO", defaults)
+	assert.True(t, ok)
+	assert.InDelta(t, defaults.MinVolatilityPercent*0.5, quiet.MinVolatilityPercent, 1e-9)
+	assert.InDelta(t, defaults.StopLossPercent*0.5, quiet.StopLossPercent, 1e-9)
+
+	volatile, _ := surface.ParamsFor("TSLA", defaults)
+	assert.InDelta(t, defaults.MinExpectedROI*2.5, volatile.MinExpectedROI, 1e-9)
+	assert.Equal(t, defaults.RSIOverbought, volatile.RSIOverbought)
+
+	// Tuned parameters win over scaling
+	params, ok := surface.ParamsFor("NVDA", defaults)
+	assert.True(t, ok)
+	assert.Equal(t, 3.0, params.StopLossPercent)
+	assert.Equal(t, defaults.MinExpectedROI, params.MinExpectedROI)
+
+	// Symbols without history keep the global thresholds
+	params, ok = surface.ParamsFor("GME", defaults)
+	assert.False(t, ok)
+	assert.Equal(t, defaults, params)
+
+	assert.Len(t, surface.Surface(), 5)
+}
+
+func TestVolatilitySurfaceReferenceVolatility(t *testing.T) {
+	surface := NewVolatilitySurface(swingHistory{"AAPL": 0.01}, nil, config.VolatilityScalingConfig{ReferenceVolatility: 1000, MinScale: 0.2})
+	surface.SetClock(clock.NewFake(time.Date(2025, 6, 2, 14, 0, 0, 0, time.UTC)))
+	assert.NoError(t, surface.Refresh([]string{"AAPL"}))
+
+	scale, ok := surface.Scale("AAPL")
+	assert.True(t, ok)
+	assert.Equal(t, 0.2, scale)
+
+	assert.Error(t, NewVolatilitySurface(swingHistory{}, nil, config.VolatilityScalingConfig{}).Refresh([]string{"AAPL"}))
+}
@@ -0,0 +1,132 @@
+package tuning
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/clock"
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/market"
+	"github.com/hustler/trading-bot/pkg/performance"
+)
+
+// HistorySource provides the candles a symbol is backtested on (implemented by data.Provider)
+type HistorySource interface {
+	GetIntradayHistory(symbol string, days int) (*data.MarketData, error)
+	GetDailyHistory(symbol string, days int) (*data.MarketData, error)
+}
+
+// Validation is the outcome of backtesting a symbol's tuned parameters
+// against the global parameters over recent history
+type Validation struct {
+	Symbol      string               `json:"symbol"`
+	Tuned       *performance.Metrics `json:"tuned"`
+	Defaults    *performance.Metrics `json:"defaults"`
+	Revalidated bool                 `json:"revalidated"`
+}
+
+// Validator re-validates tuned parameters by backtesting them on recent
+// history. Parameters that still do at least as well as the global parameters
+// regain their full weight; the rest keep decaying.
+type Validator struct {
+	config   *config.Config
+	history  HistorySource
+	registry *Registry
+	session  *market.Clock
+	clock    clock.Clock
+}
+
+// NewValidator creates a validator for the symbols in registry
+func NewValidator(cfg *config.Config, history HistorySource, registry *Registry) *Validator {
+	return &Validator{
+		config:   cfg,
+		history:  history,
+		registry: registry,
+		session:  market.DefaultClock(),
+		clock:    clock.Real{},
+	}
+}
+
+// SetClock sets the clock re-validations are timestamped with
+func (v *Validator) SetClock(c clock.Clock) {
+	v.clock = c
+}
+
+// Validate backtests a symbol's tuned parameters and the global parameters
+// over the recent history, re-validating the tuned parameters if they
+// produced enough signals and succeeded at least as often
+func (v *Validator) Validate(symbol string) (*Validation, error) {
+	tuned, ok := v.registry.Tuned(symbol)
+	if !ok {
+		return nil, fmt.Errorf("no tuned parameters for %s", symbol)
+	}
+
+	intraday, err := v.history.GetIntradayHistory(symbol, v.config.ParamDecay.BacktestDays)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get history for %s: %w", symbol, err)
+	}
+	if len(intraday.Timestamps) == 0 {
+		return nil, fmt.Errorf("no history for %s", symbol)
+	}
+	daily, err := v.history.GetDailyHistory(symbol, dailyHistoryDays)
+	if err != nil {
+		daily = nil // Liquidity checks fall back to their defaults
+	}
+
+	defaults := v.config.VolatilityParams
+	days := tradingDays(v.session, intraday.Timestamps)
+	validation := &Validation{Symbol: symbol}
+	if validation.Tuned, err = replay(v.config, v.session, symbol, Blend(defaults, tuned, 1), intraday, daily, days); err != nil {
+		return nil, err
+	}
+	if validation.Defaults, err = replay(v.config, v.session, symbol, defaults, intraday, daily, days); err != nil {
+		return nil, err
+	}
+
+	validation.Revalidated = validation.Tuned.SignalsCount >= v.config.ParamDecay.MinSignals &&
+		validation.Tuned.SuccessRate >= validation.Defaults.SuccessRate
+	if validation.Revalidated {
+		if err := v.registry.Revalidate(symbol, v.clock.Now()); err != nil {
+			return nil, err
+		}
+	}
+	return validation, nil
+}
+
+// ValidateAll validates every symbol with tuned parameters, logging the results
+func (v *Validator) ValidateAll() {
+	for _, symbol := range v.registry.Symbols() {
+		validation, err := v.Validate(symbol)
+		if err != nil {
+			log.Printf("Error validating tuned parameters for %s: %v", symbol, err)
+			continue
+		}
+		if validation.Revalidated {
+			log.Printf("Tuned parameters for %s re-validated: %.0f%% success over %d signals vs %.0f%% with the defaults",
+				symbol, validation.Tuned.SuccessRate, validation.Tuned.SignalsCount, validation.Defaults.SuccessRate)
+		} else {
+			log.Printf("Tuned parameters for %s not re-validated and keep decaying: %.0f%% success over %d signals vs %.0f%% with the defaults",
+				symbol, validation.Tuned.SuccessRate, validation.Tuned.SignalsCount, validation.Defaults.SuccessRate)
+		}
+	}
+}
+
+// Run validates every tuned symbol now and then every configured interval
+// until stop is closed
+func (v *Validator) Run(stop <-chan struct{}) {
+	v.ValidateAll()
+
+	ticker := time.NewTicker(time.Duration(v.config.ParamDecay.ValidateHours) * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			v.ValidateAll()
+		}
+	}
+}
@@ -1,18 +1,27 @@
 package ui
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/hustler/trading-bot/pkg/api"
 	"github.com/hustler/trading-bot/pkg/config"
 	"github.com/hustler/trading-bot/pkg/data"
+	"github.com/hustler/trading-bot/pkg/execution"
+	"github.com/hustler/trading-bot/pkg/journal"
 	"github.com/hustler/trading-bot/pkg/llm"
+	"github.com/hustler/trading-bot/pkg/monitor"
 	"github.com/hustler/trading-bot/pkg/news"
+	"github.com/hustler/trading-bot/pkg/quota"
 	"github.com/hustler/trading-bot/pkg/signal"
+	"github.com/hustler/trading-bot/pkg/stream"
 	"github.com/hustler/trading-bot/pkg/telegram"
 )
 
@@ -24,6 +33,19 @@ type Controller struct {
 	llmManager    *llm.Manager
 	signalGen     *signal.Generator
 	telegramBot   *telegram.Bot
+	journal       *journal.Journal
+	risk          *monitor.RiskManager
+	pnlHub        *stream.Hub
+	pnlPublisher  *stream.PnLPublisher
+	quotas        *quota.Manager
+	testSignals   *monitor.MarketMonitor
+	signalDetails SignalDetails
+	configMu      sync.Mutex // Guards config, which is replaced rather than changed
+}
+
+// SignalDetails aggregates everything known about a signal (implemented by api.Server)
+type SignalDetails interface {
+	SignalDetail(id string) (api.SignalDetail, bool)
 }
 
 // NewController creates a new UI controller
@@ -45,6 +67,37 @@ func NewController(
 	}
 }
 
+// SetJournal sets the trade journal browsed from the UI
+func (c *Controller) SetJournal(j *journal.Journal) {
+	c.journal = j
+}
+
+// SetRiskManager sets the risk manager whose value at risk is shown in the UI
+func (c *Controller) SetRiskManager(risk *monitor.RiskManager) {
+	c.risk = risk
+}
+
+// SetQuotaManager sets the quota manager whose usage is shown in the UI
+func (c *Controller) SetQuotaManager(quotas *quota.Manager) {
+	c.quotas = quotas
+}
+
+// SetTestSignalSender sets the monitor that sends test signals from the UI
+func (c *Controller) SetTestSignalSender(m *monitor.MarketMonitor) {
+	c.testSignals = m
+}
+
+// SetSignalDetails sets where the signal detail page gets its data
+func (c *Controller) SetSignalDetails(details SignalDetails) {
+	c.signalDetails = details
+}
+
+// SetPnLStream pushes live P&L of the positions in trades to the dashboard every interval
+func (c *Controller) SetPnLStream(trades *execution.TradeManager, interval time.Duration) {
+	c.pnlHub = stream.NewHub()
+	c.pnlPublisher = stream.NewPnLPublisher(c.pnlHub, trades, c.marketWatcher, interval)
+}
+
 // Start starts the web server
 func (c *Controller) Start(port int) error {
 	// Set up API routes
@@ -57,6 +110,16 @@ func (c *Controller) Start(port int) error {
 	http.HandleFunc("/api/telegram/test", c.handleTelegramTest)
 	http.HandleFunc("/api/llm/switch", c.handleLLMSwitch)
 	http.HandleFunc("/api/generate-signals", c.handleGenerateSignals)
+	http.HandleFunc("/api/journal", c.handleJournal)
+	http.HandleFunc("/api/risk/var", c.handleVaR)
+	http.HandleFunc("/api/risk/account", c.handleAccount)
+	http.HandleFunc("/api/quotas", c.handleQuotas)
+	http.HandleFunc("/api/signals/test", c.handleTestSignal)
+	http.HandleFunc("/api/signals/detail", c.handleSignalDetail)
+	if c.pnlHub != nil {
+		http.Handle("/api/stream/pnl", c.pnlHub)
+		go c.pnlPublisher.Run(context.Background())
+	}
 
 	// Serve static files
 	http.Handle("/", http.FileServer(http.Dir("./web/admin")))
@@ -167,7 +230,10 @@ func (c *Controller) handleNews(w http.ResponseWriter, r *http.Request) {
 func (c *Controller) handleConfig(w http.ResponseWriter, r *http.Request) {
 	if r.Method == http.MethodGet {
 		// Return current configuration
-		writeJSON(w, c.config)
+		c.configMu.Lock()
+		current := c.config
+		c.configMu.Unlock()
+		writeJSON(w, current)
 		return
 	}
 
@@ -185,8 +251,10 @@ func (c *Controller) handleConfig(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		// Update the current configuration
-		*c.config = newConfig
+		// Update the current configuration, leaving the old one to its readers
+		c.configMu.Lock()
+		c.config = &newConfig
+		c.configMu.Unlock()
 
 		// Apply configuration changes
 		// This would need to be implemented based on the specific components
@@ -243,9 +311,14 @@ func (c *Controller) handleLLMSwitch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Update the configuration
-	c.config.LLM.Provider = provider
-	if err := config.SaveConfig(c.config, "config.json"); err != nil {
+	// Update a copy of the configuration and swap it in
+	c.configMu.Lock()
+	updated := *c.config
+	updated.LLM.Provider = provider
+	c.config = &updated
+	err := config.SaveConfig(&updated, "config.json")
+	c.configMu.Unlock()
+	if err != nil {
 		log.Printf("Warning: Failed to save configuration after LLM switch: %v", err)
 	}
 
@@ -295,6 +368,39 @@ func (c *Controller) handleGenerateSignals(w http.ResponseWriter, r *http.Reques
 	writeJSON(w, signals)
 }
 
+// handleJournal lists journal entries (GET) and saves manual notes on an entry (PUT)
+func (c *Controller) handleJournal(w http.ResponseWriter, r *http.Request) {
+	if c.journal == nil {
+		http.Error(w, "Journal not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, c.journal.Entries(r.URL.Query().Get("symbol")))
+
+	case http.MethodPut:
+		var req struct {
+			TradeID string `json:"trade_id"`
+			Notes   string `json:"notes"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		entry, err := c.journal.SetNotes(req.TradeID, req.Notes)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		writeJSON(w, entry)
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
 // Helper function to write JSON response
 func writeJSON(w http.ResponseWriter, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
@@ -302,3 +408,106 @@ func writeJSON(w http.ResponseWriter, data interface{}) {
 		http.Error(w, fmt.Sprintf("Failed to encode response: %v", err), http.StatusInternalServerError)
 	}
 }
+
+// handleVaR returns the latest value at risk and exposure report
+func (c *Controller) handleVaR(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if c.risk == nil {
+		http.Error(w, "Risk manager not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	report, ok := c.risk.LatestVaR()
+	if !ok {
+		http.Error(w, "Value at risk has not been computed yet", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, report)
+}
+
+// handleAccount returns the cash, margin and buying power of the account at the latest quotes
+func (c *Controller) handleAccount(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if c.risk == nil {
+		http.Error(w, "Risk manager not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	balance, ok := c.risk.AccountBalance(c.marketWatcher.Snapshot())
+	if !ok {
+		http.Error(w, "No account cash configured", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, balance)
+}
+
+// handleQuotas returns the current usage of every quota
+func (c *Controller) handleQuotas(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if c.quotas == nil {
+		http.Error(w, "Quotas not enabled", http.StatusServiceUnavailable)
+		return
+	}
+	writeJSON(w, c.quotas.Status())
+}
+
+// handleTestSignal sends a labeled TEST signal through the live pipeline
+func (c *Controller) handleTestSignal(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if c.testSignals == nil {
+		http.Error(w, "Test signals not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	var req struct {
+		Symbol string `json:"symbol"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || strings.TrimSpace(req.Symbol) == "" {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	sent, err := c.testSignals.SendTestSignal(strings.ToUpper(strings.TrimSpace(req.Symbol)))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	writeJSON(w, sent)
+}
+
+// handleSignalDetail returns the chart data, trades, journal and outcome of a signal
+func (c *Controller) handleSignalDetail(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if c.signalDetails == nil {
+		http.Error(w, "Signal details not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		http.Error(w, "ID parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	detail, exists := c.signalDetails.SignalDetail(id)
+	if !exists {
+		http.Error(w, "Signal not found", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, detail)
+}
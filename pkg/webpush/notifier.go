@@ -0,0 +1,125 @@
+package webpush
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hustler/trading-bot/pkg/alerts"
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/monitor"
+	"github.com/hustler/trading-bot/pkg/signal"
+)
+
+// htmlTag matches the HTML formatting of Telegram messages
+var htmlTag = regexp.MustCompile(`</?[a-zA-Z][^>]*>`)
+
+// Pusher sends a message to every subscribed browser (implemented by Sender)
+type Pusher interface {
+	Push(msg Message) error
+}
+
+// Notifier forwards notifications to another notifier and pushes signals and
+// alerts to subscribed browsers. Push failures are logged, so notifications
+// are never blocked by push services.
+type Notifier struct {
+	next   monitor.Notifier
+	pusher Pusher
+	filter config.SignalFilter
+}
+
+// NewNotifier creates a Notifier in front of next
+func NewNotifier(next monitor.Notifier, pusher Pusher) *Notifier {
+	return &Notifier{next: next, pusher: pusher}
+}
+
+// SetFilter limits the signals pushed. Changes and outcomes of filtered
+// signals are not pushed either.
+func (n *Notifier) SetFilter(filter config.SignalFilter) {
+	n.filter = filter
+}
+
+// SendSignal sends a new signal and pushes it
+func (n *Notifier) SendSignal(s *signal.Signal) error {
+	if !n.allows(s) {
+		return n.next.SendSignal(s)
+	}
+	title := fmt.Sprintf("%s %s", s.Type, s.Symbol)
+	if s.Test {
+		title = "TEST " + title
+	}
+	n.push(Message{
+		Title: title,
+		Body: fmt.Sprintf("Entry $%.2f · Target $%.2f · Stop $%.2f · %.0f%% confidence",
+			s.Price, s.TargetPrice, s.StopLoss, s.Confidence*100),
+		Tag:    s.ID,
+		URL:    "/",
+		Urgent: !s.Test,
+	})
+	return n.next.SendSignal(s)
+}
+
+// UpdateSignal sends a signal change and pushes it, replacing the signal's notification
+func (n *Notifier) UpdateSignal(s *signal.Signal) error {
+	if !n.allows(s) {
+		return n.next.UpdateSignal(s)
+	}
+	n.push(Message{
+		Title: fmt.Sprintf("%s %s updated", s.Type, s.Symbol),
+		Body:  fmt.Sprintf("Target $%.2f · Stop $%.2f", s.TargetPrice, s.StopLoss),
+		Tag:   s.ID,
+		URL:   "/",
+	})
+	return n.next.UpdateSignal(s)
+}
+
+// SendSignalOutcome sends a signal's outcome and pushes it
+func (n *Notifier) SendSignalOutcome(s *signal.Signal, exitPrice float64) error {
+	if !n.allows(s) {
+		return n.next.SendSignalOutcome(s, exitPrice)
+	}
+	n.push(Message{
+		Title: fmt.Sprintf("%s %s %s", s.Type, s.Symbol, strings.ToLower(string(s.Status))),
+		Body:  fmt.Sprintf("Exit $%.2f (entry $%.2f)", exitPrice, s.Price),
+		Tag:   s.ID,
+		URL:   "/",
+	})
+	return n.next.SendSignalOutcome(s, exitPrice)
+}
+
+// SendMessage sends a message, such as a risk alert, and pushes it as plain text
+func (n *Notifier) SendMessage(message string) error {
+	n.push(Message{
+		Title:  "Hustler",
+		Body:   html.UnescapeString(htmlTag.ReplaceAllString(message, "")),
+		URL:    "/",
+		Urgent: true,
+	})
+	return n.next.SendMessage(message)
+}
+
+// SendAlert pushes a triggered alert rule. It implements alerts.Notifier.
+func (n *Notifier) SendAlert(t alerts.Trigger) error {
+	n.push(Message{
+		Title:  fmt.Sprintf("Alert: %s", t.Rule.Symbol),
+		Body:   fmt.Sprintf("%s (observed %.2f, price $%.2f)", t.Rule.Expression, t.Observed, t.Price),
+		Tag:    "alert-" + t.Rule.ID,
+		URL:    "/",
+		Urgent: true,
+	})
+	return nil
+}
+
+// allows reports whether the filter lets a signal through
+func (n *Notifier) allows(s *signal.Signal) bool {
+	return n.filter.Allows(s.Confidence, s.ExpectedROI, string(s.Type))
+}
+
+// push pushes a message, logging failures
+func (n *Notifier) push(msg Message) {
+	if err := n.pusher.Push(msg); err != nil {
+		log.Printf("Error sending web push: %v", err)
+	}
+}
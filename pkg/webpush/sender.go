@@ -0,0 +1,122 @@
+package webpush
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hustler/trading-bot/pkg/config"
+	"github.com/hustler/trading-bot/pkg/errkind"
+)
+
+// Message is the payload the service worker shows as a notification
+type Message struct {
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+	Tag    string `json:"tag,omitempty"` // Replaces an earlier notification with the same tag
+	URL    string `json:"url,omitempty"` // Opened when the notification is clicked
+	Urgent bool   `json:"-"`             // Delivered right away even to devices saving battery
+}
+
+// Sender pushes messages to every subscribed browser
+type Sender struct {
+	vapid  *vapid
+	subs   *Subscriptions
+	ttl    int
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewSender creates a Sender pushing to subs
+func NewSender(cfg config.WebPushConfig, subs *Subscriptions) (*Sender, error) {
+	v, err := newVAPID(cfg.VAPIDPrivateKey, cfg.Subject)
+	if err != nil {
+		return nil, err
+	}
+	return &Sender{
+		vapid:  v,
+		subs:   subs,
+		ttl:    cfg.TTLSeconds,
+		client: &http.Client{Timeout: 10 * time.Second},
+		now:    time.Now,
+	}, nil
+}
+
+// PublicKey returns the application server key browsers subscribe with
+func (s *Sender) PublicKey() string {
+	return s.vapid.publicKey
+}
+
+// Push sends a message to every subscription. Subscriptions the push service
+// reports as expired or unsubscribed are removed.
+func (s *Sender) Push(msg Message) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode push message: %w", err)
+	}
+
+	var errs []error
+	for _, sub := range s.subs.List() {
+		err := s.send(sub, payload, msg.Urgent)
+		if isGone(err) {
+			log.Printf("Removing expired push subscription %s", sub.Endpoint)
+			if err := s.subs.Remove(sub.Endpoint); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// send encrypts and posts a payload to one subscription
+func (s *Sender) send(sub Subscription, payload []byte, urgent bool) error {
+	body, err := encrypt(payload, sub.Keys.P256dh, sub.Keys.Auth)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt push message: %w", err)
+	}
+	authorization, err := s.vapid.authorization(sub.Endpoint, s.now())
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, sub.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(s.ttl))
+	if urgent {
+		req.Header.Set("Urgency", "high")
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to push message: %w", errkind.Unreachable(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("failed to push message: %w", errkind.Status(resp.StatusCode, strings.TrimSpace(string(respBody))))
+	}
+	return nil
+}
+
+// isGone reports whether a push failed because the subscription no longer exists
+func isGone(err error) bool {
+	var status *errkind.StatusError
+	return errors.As(err, &status) && (status.Code == http.StatusNotFound || status.Code == http.StatusGone)
+}
@@ -0,0 +1,142 @@
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hustler/trading-bot/pkg/config"
+)
+
+// browser is a subscribed user agent that can decrypt what it receives
+type browser struct {
+	key  *ecdh.PrivateKey
+	auth []byte
+}
+
+func newBrowser(t *testing.T) *browser {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	auth := make([]byte, 16)
+	_, err = rand.Read(auth)
+	assert.NoError(t, err)
+	return &browser{key: key, auth: auth}
+}
+
+func (b *browser) subscription(endpoint string) Subscription {
+	sub := Subscription{Endpoint: endpoint}
+	sub.Keys.P256dh = base64.RawURLEncoding.EncodeToString(b.key.PublicKey().Bytes())
+	sub.Keys.Auth = base64.RawURLEncoding.EncodeToString(b.auth)
+	return sub
+}
+
+// decrypt reverses encrypt as a browser does
+func (b *browser) decrypt(t *testing.T, body []byte) []byte {
+	salt := body[:16]
+	assert.Equal(t, uint32(recordSize), binary.BigEndian.Uint32(body[16:20]))
+	keyLen := int(body[20])
+	asPublicBytes := body[21 : 21+keyLen]
+
+	asPublic, err := ecdh.P256().NewPublicKey(asPublicBytes)
+	assert.NoError(t, err)
+	sharedSecret, err := b.key.ECDH(asPublic)
+	assert.NoError(t, err)
+
+	keyInfo := append([]byte("WebPush: info\x00"), b.key.PublicKey().Bytes()...)
+	keyInfo = append(keyInfo, asPublicBytes...)
+	ikm, _ := derive(sharedSecret, b.auth, keyInfo, 32)
+	cek, _ := derive(ikm, salt, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	nonce, _ := derive(ikm, salt, []byte("Content-Encoding: nonce\x00"), 12)
+
+	block, _ := aes.NewCipher(cek)
+	gcm, _ := cipher.NewGCM(block)
+	plaintext, err := gcm.Open(nil, nonce, body[21+keyLen:], nil)
+	assert.NoError(t, err)
+	assert.Equal(t, byte(0x02), plaintext[len(plaintext)-1])
+	return plaintext[:len(plaintext)-1]
+}
+
+func newTestSender(t *testing.T) (*Sender, *Subscriptions) {
+	_, private, err := GenerateVAPIDKeys()
+	assert.NoError(t, err)
+	subs, _ := NewSubscriptions("")
+	sender, err := NewSender(config.WebPushConfig{
+		VAPIDPrivateKey: private,
+		Subject:         "mailto:ops@example.com",
+		TTLSeconds:      60,
+	}, subs)
+	assert.NoError(t, err)
+	return sender, subs
+}
+
+func TestPushEncryptsAndSigns(t *testing.T) {
+	sender, subs := newTestSender(t)
+	b := newBrowser(t)
+
+	var received []byte
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.ReadAll(r.Body)
+		header = r.Header
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+	assert.NoError(t, subs.Add(b.subscription(server.URL+"/push/abc")))
+
+	err := sender.Push(Message{Title: "BUY NVDA", Body: "Entry $100.00", Tag: "sig-1", Urgent: true})
+	assert.NoError(t, err)
+
+	var msg Message
+	assert.NoError(t, json.Unmarshal(b.decrypt(t, received), &msg))
+	assert.Equal(t, "BUY NVDA", msg.Title)
+	assert.Equal(t, "sig-1", msg.Tag)
+
+	assert.Equal(t, "aes128gcm", header.Get("Content-Encoding"))
+	assert.Equal(t, "60", header.Get("TTL"))
+	assert.Equal(t, "high", header.Get("Urgency"))
+
+	// The VAPID token is signed by the key browsers subscribed with
+	auth := header.Get("Authorization")
+	assert.True(t, strings.HasPrefix(auth, "vapid t="))
+	assert.True(t, strings.HasSuffix(auth, ", k="+sender.PublicKey()))
+	signed := strings.TrimSuffix(strings.TrimPrefix(auth, "vapid t="), ", k="+sender.PublicKey())
+	token, err := jwt.Parse(signed, func(*jwt.Token) (interface{}, error) {
+		return &sender.vapid.key.PublicKey, nil
+	}, jwt.WithValidMethods([]string{"ES256"}))
+	assert.NoError(t, err)
+	assert.Equal(t, server.URL, token.Claims.(jwt.MapClaims)["aud"])
+}
+
+func TestPushRemovesExpiredSubscriptions(t *testing.T) {
+	sender, subs := newTestSender(t)
+
+	gone := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer gone.Close()
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	assert.NoError(t, subs.Add(newBrowser(t).subscription(gone.URL)))
+	assert.NoError(t, subs.Add(newBrowser(t).subscription(failing.URL)))
+
+	err := sender.Push(Message{Title: "hi"})
+	assert.Error(t, err)
+	remaining := subs.List()
+	assert.Len(t, remaining, 1)
+	assert.Equal(t, failing.URL, remaining[0].Endpoint)
+}
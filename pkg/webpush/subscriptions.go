@@ -0,0 +1,127 @@
+package webpush
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Subscription is a browser's push subscription, as PushSubscription.toJSON()
+// encodes it
+type Subscription struct {
+	Endpoint string `json:"endpoint"`
+	Keys     struct {
+		P256dh string `json:"p256dh"`
+		Auth   string `json:"auth"`
+	} `json:"keys"`
+	Username  string    `json:"username,omitempty"` // API user who subscribed
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Subscriptions holds push subscriptions, saved to a JSON file so they
+// survive restarts
+type Subscriptions struct {
+	path string
+	subs map[string]Subscription // By endpoint
+	mu   sync.RWMutex
+}
+
+// NewSubscriptions loads the subscriptions saved at path. An empty path keeps
+// them in memory only.
+func NewSubscriptions(path string) (*Subscriptions, error) {
+	s := &Subscriptions{path: path, subs: make(map[string]Subscription)}
+	if path == "" {
+		return s, nil
+	}
+
+	body, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read push subscriptions: %w", err)
+	}
+	var saved []Subscription
+	if err := json.Unmarshal(body, &saved); err != nil {
+		return nil, fmt.Errorf("failed to parse push subscriptions: %w", err)
+	}
+	for _, sub := range saved {
+		s.subs[sub.Endpoint] = sub
+	}
+	return s, nil
+}
+
+// Add adds or replaces the subscription of a browser
+func (s *Subscriptions) Add(sub Subscription) error {
+	if sub.Endpoint == "" || sub.Keys.P256dh == "" || sub.Keys.Auth == "" {
+		return fmt.Errorf("subscription endpoint and keys are required")
+	}
+	if _, err := decodeKey(sub.Keys.P256dh); err != nil {
+		return fmt.Errorf("invalid p256dh key: %w", err)
+	}
+	if sub.CreatedAt.IsZero() {
+		sub.CreatedAt = time.Now()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subs[sub.Endpoint] = sub
+	return s.save()
+}
+
+// Remove removes the subscription with endpoint, if any
+func (s *Subscriptions) Remove(endpoint string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.subs[endpoint]; !ok {
+		return nil
+	}
+	delete(s.subs, endpoint)
+	return s.save()
+}
+
+// List returns every subscription, oldest first
+func (s *Subscriptions) List() []Subscription {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	subs := make([]Subscription, 0, len(s.subs))
+	for _, sub := range s.subs {
+		subs = append(subs, sub)
+	}
+	sort.Slice(subs, func(i, j int) bool { return subs[i].CreatedAt.Before(subs[j].CreatedAt) })
+	return subs
+}
+
+// save writes the subscriptions to the file, replacing it atomically. The
+// caller holds the lock.
+func (s *Subscriptions) save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	subs := make([]Subscription, 0, len(s.subs))
+	for _, sub := range s.subs {
+		subs = append(subs, sub)
+	}
+	body, err := json.MarshalIndent(subs, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode push subscriptions: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("failed to create push subscriptions directory: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, body, 0600); err != nil {
+		return fmt.Errorf("failed to write push subscriptions: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to replace push subscriptions: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+// Shows pushed signals and alerts, and opens the dashboard when one is clicked.
+// Payloads are the JSON messages sent by pkg/webpush.
+
+self.addEventListener('push', (event) => {
+  let message = { title: 'Hustler', body: '' };
+  if (event.data) {
+    try {
+      message = event.data.json();
+    } catch (e) {
+      message.body = event.data.text();
+    }
+  }
+
+  event.waitUntil(
+    self.registration.showNotification(message.title, {
+      body: message.body,
+      tag: message.tag,
+      renotify: Boolean(message.tag),
+      data: { url: message.url || '/' }
+    })
+  );
+});
+
+self.addEventListener('notificationclick', (event) => {
+  event.notification.close();
+  const url = event.notification.data.url;
+
+  event.waitUntil(
+    self.clients.matchAll({ type: 'window', includeUncontrolled: true }).then((windows) => {
+      for (const client of windows) {
+        if ('focus' in client) {
+          client.navigate(url);
+          return client.focus();
+        }
+      }
+      return self.clients.openWindow(url);
+    })
+  );
+});
@@ -1,5 +1,6 @@
-import React, { useState } from 'react';
+import React, { useEffect, useState } from 'react';
 import { useAuth } from '../hooks/useAuth';
+import { push, pushSupported } from '../services/push';
 
 interface SettingsProps {}
 
@@ -27,12 +28,32 @@ const Settings: React.FC<SettingsProps> = () => {
     telegram: false,
     telegramChatId: ''
   });
+  const [pushEnabled, setPushEnabled] = useState(false);
+  const [pushError, setPushError] = useState('');
   const [tradingHours, setTradingHours] = useState({
     start: '09:30',
     end: '16:00',
     timezone: 'America/New_York'
   });
   
+  useEffect(() => {
+    push.isSubscribed().then(setPushEnabled).catch(() => setPushEnabled(false));
+  }, []);
+
+  const handleTogglePush = async (enabled: boolean) => {
+    setPushError('');
+    try {
+      if (enabled) {
+        await push.subscribe();
+      } else {
+        await push.unsubscribe();
+      }
+      setPushEnabled(enabled);
+    } catch (err) {
+      setPushError(err instanceof Error ? err.message : 'Failed to update push notifications');
+    }
+  };
+
   const handleConnectQuestrade = () => {
     // In a real app, this would initiate OAuth flow
     alert('Connecting to Questrade...');
@@ -370,6 +391,28 @@ const Settings: React.FC<SettingsProps> = () => {
               </div>
             )}
           </div>
+
+          <div>
+            <div className="flex items-center mb-2">
+              <input
+                id="push-notifications"
+                type="checkbox"
+                className="h-4 w-4 text-primary-600 focus:ring-primary-500 border-gray-300 rounded"
+                checked={pushEnabled}
+                disabled={!pushSupported()}
+                onChange={(e) => handleTogglePush(e.target.checked)}
+              />
+              <label htmlFor="push-notifications" className="ml-2 block text-sm text-gray-900 dark:text-gray-100">
+                Push Notifications on this device
+              </label>
+            </div>
+            <p className="ml-6 text-sm text-gray-500 dark:text-gray-400">
+              {pushSupported()
+                ? 'Signals and risk alerts appear even when the dashboard is closed.'
+                : 'Not supported here. On iPhone and iPad, add the dashboard to your home screen first.'}
+            </p>
+            {pushError && <p className="ml-6 text-sm text-red-600">{pushError}</p>}
+          </div>
         </div>
       </div>
     </div>
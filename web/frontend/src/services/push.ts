@@ -0,0 +1,58 @@
+import axios from 'axios';
+
+const API_URL = 'http://localhost:8080/api';
+
+// Web push needs a service worker. iOS only allows it once the dashboard is
+// added to the home screen.
+export const pushSupported = (): boolean =>
+  'serviceWorker' in navigator && 'PushManager' in window && 'Notification' in window;
+
+// Converts the base64url VAPID public key to the bytes PushManager expects
+const urlBase64ToUint8Array = (base64: string): Uint8Array => {
+  const padded = (base64 + '='.repeat((4 - (base64.length % 4)) % 4)).replace(/-/g, '+').replace(/_/g, '/');
+  const raw = window.atob(padded);
+  return Uint8Array.from(raw, (c) => c.charCodeAt(0));
+};
+
+const registration = (): Promise<ServiceWorkerRegistration> =>
+  navigator.serviceWorker.register('/service-worker.js');
+
+export const push = {
+  // Reports whether this browser is subscribed
+  isSubscribed: async (): Promise<boolean> => {
+    if (!pushSupported()) {
+      return false;
+    }
+    const reg = await registration();
+    return (await reg.pushManager.getSubscription()) !== null;
+  },
+
+  // Asks for notification permission, subscribes and registers the subscription with the bot
+  subscribe: async (): Promise<void> => {
+    if (!pushSupported()) {
+      throw new Error('Push notifications are not supported by this browser');
+    }
+    if ((await Notification.requestPermission()) !== 'granted') {
+      throw new Error('Notification permission was denied');
+    }
+
+    const { data } = await axios.get(`${API_URL}/v1/push/key`);
+    const reg = await registration();
+    const subscription = await reg.pushManager.subscribe({
+      userVisibleOnly: true,
+      applicationServerKey: urlBase64ToUint8Array(data.public_key)
+    });
+    await axios.post(`${API_URL}/v1/push/subscriptions`, subscription.toJSON());
+  },
+
+  // Unsubscribes this browser and removes its subscription from the bot
+  unsubscribe: async (): Promise<void> => {
+    const reg = await registration();
+    const subscription = await reg.pushManager.getSubscription();
+    if (!subscription) {
+      return;
+    }
+    await axios.delete(`${API_URL}/v1/push/subscriptions`, { data: { endpoint: subscription.endpoint } });
+    await subscription.unsubscribe();
+  }
+};